@@ -0,0 +1,268 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/lorrc/service-desk-backend/internal/adapters/secondary/postgres"
+	"github.com/lorrc/service-desk-backend/internal/adapters/secondary/sanitizer"
+	"github.com/lorrc/service-desk-backend/internal/config"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+)
+
+// runSeed creates the default roles and permissions, and the admin user
+// configured through ADMIN_*, without starting the API. With --tickets it
+// also fills an organization with demo data.
+func runSeed(args []string) error {
+	fset := flag.NewFlagSet("seed", flag.ContinueOnError)
+	org := fset.String("org", "", "organization ID or portal slug to fill with demo data; a new slug creates the organization (default DEFAULT_ORG_ID)")
+	tickets := fset.Int("tickets", 0, "number of demo tickets to generate; 0 seeds only roles and the admin")
+	agents := fset.Int("agents", 5, "number of demo agents")
+	customers := fset.Int("customers", 20, "number of demo customers")
+	days := fset.Int("days", 90, "how many days back demo tickets are spread")
+	password := fset.String("password", "Password123!", "password of the demo accounts")
+	seed := fset.Int64("seed", 1, "random seed; the same seed generates the same data")
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+	if *tickets < 0 || *days < 1 {
+		return errors.New("seed: --tickets must not be negative and --days must be positive")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	logger := newLogger(cfg, nil)
+
+	// Demo data is written in batches, which takes a while for large runs
+	timeout := time.Minute
+	if *tickets > 0 {
+		timeout += time.Duration(*tickets) * 10 * time.Millisecond
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	pool, err := openPool(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	authService, err := newAuthService(ctx, pool, cfg)
+	if err != nil {
+		return err
+	}
+	logger.Info("rbac defaults ensured")
+
+	defaultOrgID, err := uuid.Parse(cfg.App.DefaultOrgID)
+	if err != nil {
+		return fmt.Errorf("invalid default org ID: %w", err)
+	}
+	if err := seedAdminUser(ctx, cfg.Admin, authService, defaultOrgID, logger); err != nil {
+		return fmt.Errorf("failed to seed admin user: %w", err)
+	}
+	if *tickets == 0 {
+		return nil
+	}
+
+	settingsRepo := postgres.NewOrgSettingsRepository(pool)
+	orgID, slug, err := resolveSeedOrganization(ctx, settingsRepo, *org, cfg.App.DefaultOrgID)
+	if err != nil {
+		return fmt.Errorf("seed: %w", err)
+	}
+
+	generator := services.NewDemoDataGenerator(
+		authService,
+		postgres.NewUserRepository(pool),
+		postgres.NewTicketRepository(pool),
+		postgres.NewCommentRepository(pool),
+		settingsRepo,
+		sanitizer.NewSanitizer(),
+	)
+	result, err := generator.Generate(ctx, orgID, services.DemoDataOptions{
+		Tickets:     *tickets,
+		Agents:      *agents,
+		Customers:   *customers,
+		Period:      time.Duration(*days) * 24 * time.Hour,
+		EmailDomain: slug + ".example.com",
+		Password:    *password,
+		Seed:        *seed,
+	})
+	if err != nil {
+		return fmt.Errorf("seed demo data: %w", err)
+	}
+
+	logger.Info("demo data generated",
+		"organization_id", orgID,
+		"users", len(result.Users),
+		"tickets", result.Tickets,
+		"comments", result.Comments,
+	)
+	fmt.Printf("generated %d tickets and %d comments in organization %s\n", result.Tickets, result.Comments, orgID)
+	fmt.Printf("demo accounts agentN@%[1]s and customerN@%[1]s use the password %[2]q\n", slug+".example.com", *password)
+	return nil
+}
+
+// resolveSeedOrganization finds the organization named by --org, an ID or
+// a portal slug, creating one for a slug that is not in use yet. It also
+// returns a short name for the organization, used in demo email addresses.
+func resolveSeedOrganization(ctx context.Context, settingsRepo ports.OrgSettingsRepository, org, defaultOrgID string) (uuid.UUID, string, error) {
+	if org == "" {
+		org = defaultOrgID
+	}
+	if orgID, err := uuid.Parse(org); err == nil {
+		return orgID, "org-" + orgID.String()[:8], nil
+	}
+
+	slug, err := domain.NormalizePortalSlug(org)
+	if err != nil {
+		return uuid.Nil, "", fmt.Errorf("invalid --org %q: %w", org, err)
+	}
+	orgID, err := settingsRepo.GetOrganizationIDByPortalSlug(ctx, slug)
+	if errors.Is(err, apperrors.ErrPortalNotFound) {
+		orgID, err = settingsRepo.CreateOrganization(ctx, strings.ToUpper(slug[:1])+slug[1:], slug)
+	}
+	if err != nil {
+		return uuid.Nil, "", fmt.Errorf("organization %q: %w", org, err)
+	}
+	return orgID, slug, nil
+}
+
+// runCreateAdmin registers an admin user in an organization, or with
+// --super a super-admin who can also work across every organization. The
+// password is read from standard input unless given with --password.
+func runCreateAdmin(args []string) error {
+	fset := flag.NewFlagSet("create-admin", flag.ContinueOnError)
+	email := fset.String("email", "", "email address of the new admin (required)")
+	org := fset.String("org", "", "organization ID (default DEFAULT_ORG_ID)")
+	name := fset.String("name", "Administrator", "full name of the new admin")
+	password := fset.String("password", "", "password; read from standard input if not given")
+	super := fset.Bool("super", false, "create a super-admin, who can see and impersonate the admins of every organization")
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+	if *email == "" {
+		fset.Usage()
+		return errors.New("create-admin: --email is required")
+	}
+
+	orgID := uuid.Nil
+	if *org != "" {
+		parsed, err := uuid.Parse(*org)
+		if err != nil {
+			return fmt.Errorf("create-admin: invalid --org: %w", err)
+		}
+		orgID = parsed
+	}
+
+	if *password == "" {
+		fmt.Fprint(os.Stderr, "Password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("create-admin: read password: %w", err)
+		}
+		*password = strings.TrimRight(line, "\r\n")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	pool, err := openPool(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	authService, err := newAuthService(ctx, pool, cfg)
+	if err != nil {
+		return err
+	}
+
+	role := "admin"
+	if *super {
+		role = "superadmin"
+	}
+
+	user, err := authService.Register(ctx, *name, *email, *password, role, orgID)
+	if err != nil {
+		return fmt.Errorf("create-admin: %w", err)
+	}
+
+	fmt.Printf("created %s %s (%s) in organization %s\n", role, user.Email, user.ID, user.OrganizationID)
+	return nil
+}
+
+// newAuthService builds the auth service for the admin commands, making
+// sure the default roles it assigns exist.
+func newAuthService(ctx context.Context, pool *pgxpool.Pool, cfg *config.Config) (ports.AuthService, error) {
+	defaultOrgID, err := uuid.Parse(cfg.App.DefaultOrgID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid default org ID: %w", err)
+	}
+
+	userRepo := postgres.NewUserRepository(pool)
+	authzRepo := postgres.NewAuthorizationRepository(pool)
+	if err := authzRepo.EnsureRBACDefaults(ctx); err != nil {
+		return nil, fmt.Errorf("ensure rbac defaults: %w", err)
+	}
+
+	quotaService := services.NewQuotaService(postgres.NewQuotaRepository(pool), services.NewAuthorizationService(authzRepo), domain.OrgQuota{
+		MaxUsers:       quotaLimit(cfg.Quotas.MaxUsers),
+		MaxOpenTickets: quotaLimit(cfg.Quotas.MaxOpenTickets),
+	})
+	return services.NewAuthService(userRepo, authzRepo, postgres.NewOrgSettingsRepository(pool), quotaService, defaultOrgID), nil
+}
+
+// seedAdminUser creates an admin user from configuration in the default
+// organization if it doesn't already exist there.
+func seedAdminUser(ctx context.Context, cfg config.AdminConfig, authService ports.AuthService, defaultOrgID uuid.UUID, logger *slog.Logger) error {
+	// If no admin email is configured, do nothing.
+	if cfg.Email == "" {
+		logger.Info("admin user seeding not configured")
+		return nil
+	}
+
+	logger.Info("attempting to seed admin user", "email", cfg.Email)
+
+	// A simple way to check for existence is to try to log in.
+	// This avoids needing a GetUserByEmail method on the auth service.
+	_, err := authService.Login(ctx, defaultOrgID, cfg.Email, cfg.Password)
+	if err == nil {
+		logger.Info("admin user already exists", "email", cfg.Email)
+		return nil // User already exists
+	}
+
+	// If the error is anything other than invalid credentials, it's a real problem.
+	if !errors.Is(err, apperrors.ErrInvalidCredentials) && !errors.Is(err, apperrors.ErrUserNotFound) {
+		return fmt.Errorf("failed during admin existence check: %w", err)
+	}
+
+	// User does not exist, so create them.
+	fullName := fmt.Sprintf("%s %s", cfg.FirstName, cfg.LastName)
+	_, err = authService.Register(ctx, fullName, cfg.Email, cfg.Password, "admin", defaultOrgID)
+	if err != nil {
+		return fmt.Errorf("failed to register admin user: %w", err)
+	}
+
+	logger.Info("successfully seeded admin user", "email", cfg.Email)
+	return nil
+}
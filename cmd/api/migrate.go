@@ -0,0 +1,166 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+
+	"github.com/lorrc/service-desk-backend/internal/config"
+	"github.com/lorrc/service-desk-backend/migrations"
+)
+
+// runMigrate applies or rolls back the embedded schema migrations, or shows
+// which have been applied.
+func runMigrate(args []string) error {
+	fset := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	fset.Usage = func() {
+		fmt.Fprintln(fset.Output(), "Usage: service-desk-app migrate up [N] | down [N] | status | force VERSION")
+	}
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+	if fset.NArg() == 0 {
+		fset.Usage()
+		return errors.New("migrate: missing action")
+	}
+	action := fset.Arg(0)
+
+	// N for up and down, VERSION for force
+	number := 0
+	if fset.NArg() > 1 {
+		n, err := strconv.Atoi(fset.Arg(1))
+		if err != nil || n < 1 {
+			return fmt.Errorf("migrate: invalid number %q", fset.Arg(1))
+		}
+		number = n
+	}
+	if action == "force" && number == 0 {
+		return errors.New("migrate force: missing version")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	m, err := newMigrator(cfg.Database.URL)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	switch action {
+	case "up":
+		if number > 0 {
+			err = m.Steps(number)
+		} else {
+			err = m.Up()
+		}
+	case "down":
+		if number == 0 {
+			number = 1
+		}
+		err = m.Steps(-number)
+	case "force":
+		err = m.Force(number)
+	case "status":
+		return printMigrationStatus(m)
+	default:
+		fset.Usage()
+		return fmt.Errorf("migrate: unknown action %q", action)
+	}
+
+	if errors.Is(err, migrate.ErrNoChange) {
+		fmt.Println("no change")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("migrate %s: %w", action, err)
+	}
+	return printMigrationStatus(m)
+}
+
+// newMigrator creates a migrator for the embedded migrations. It holds a
+// Postgres advisory lock while migrating, so concurrent runs wait for each
+// other instead of racing.
+func newMigrator(databaseURL string) (*migrate.Migrate, error) {
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("open migrations: %w", err)
+	}
+	m, err := migrate.NewWithSourceInstance("iofs", src, databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("connect for migrations: %w", err)
+	}
+	return m, nil
+}
+
+// migrateOnStart applies any pending migrations before the server starts.
+func migrateOnStart(databaseURL string, logger *slog.Logger) error {
+	m, err := newMigrator(databaseURL)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	logger.Info("applying pending migrations")
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("migrate up: %w", err)
+	}
+
+	version, _, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return fmt.Errorf("read migration version: %w", err)
+	}
+	logger.Info("database schema is up to date", "version", version)
+	return nil
+}
+
+// printMigrationStatus lists every embedded migration and whether it has
+// been applied.
+func printMigrationStatus(m *migrate.Migrate) error {
+	current, dirty, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return fmt.Errorf("read migration version: %w", err)
+	}
+
+	entries, err := fs.ReadDir(migrations.FS, ".")
+	if err != nil {
+		return fmt.Errorf("list migrations: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS")
+	for _, entry := range entries {
+		mig, err := source.DefaultParse(entry.Name())
+		if err != nil || mig.Direction != source.Up {
+			continue
+		}
+		status := "pending"
+		switch {
+		case mig.Version == current && dirty:
+			status = "dirty"
+		case mig.Version <= current:
+			status = "applied"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", mig.Version, mig.Identifier, status)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if dirty {
+		return fmt.Errorf("migration %d is dirty; fix the schema by hand, then force the version", current)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/lorrc/service-desk-backend/internal/adapters/secondary/memory"
+	"github.com/lorrc/service-desk-backend/internal/adapters/secondary/postgres"
+	"github.com/lorrc/service-desk-backend/internal/config"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// repositories are the storage adapters the API runs on, before the
+// services' decorators are applied.
+type repositories struct {
+	users              ports.UserRepository
+	tickets            ports.TicketRepository
+	ticketArchive      ports.TicketArchiveRepository
+	authz              ports.AuthorizationRepository
+	comments           ports.CommentRepository
+	events             ports.TicketEventRepository
+	analytics          ports.AnalyticsRepository
+	webhooks           ports.WebhookRepository
+	inboundHooks       ports.InboundHookRepository
+	teams              ports.TeamRepository
+	escalationRules    ports.EscalationRuleRepository
+	macros             ports.MacroRepository
+	searchQueue        ports.SearchQueueRepository
+	searchIndex        ports.SearchIndex
+	outbox             ports.NotificationOutboxRepository
+	orgSettings        ports.OrgSettingsRepository
+	retention          ports.RetentionRepository
+	quotas             ports.QuotaRepository
+	platform           ports.PlatformRepository
+	auditLog           ports.AuditLogRepository
+	dataExports        ports.DataExportRepository
+	rateLimitOverrides ports.RateLimitOverrideRepository
+	snoozes            ports.TicketSnoozeRepository
+	commentDrafts      ports.CommentDraftRepository
+	emailPreferences   ports.EmailPreferenceRepository
+	txManager          ports.TransactionManager
+}
+
+// newPostgresRepositories creates the Postgres repositories. Each
+// repository's queries are timed and classified under its name.
+func newPostgresRepositories(cfg *config.Config, pool *pgxpool.Pool, instrumentation *postgres.Instrumentation, readOpts []postgres.RepositoryOption) repositories {
+	instrument := func(repository string) postgres.DBTX {
+		return instrumentation.Instrument(pool, repository)
+	}
+
+	return repositories{
+		users:              postgres.NewUserRepository(instrument("users"), readOpts...),
+		tickets:            postgres.NewTicketRepository(instrument("tickets"), readOpts...),
+		ticketArchive:      postgres.NewTicketArchiveRepository(instrument("ticket_archive")),
+		authz:              postgres.NewAuthorizationRepository(instrument("authorization")),
+		comments:           postgres.NewCommentRepository(instrument("comments")),
+		events:             postgres.NewTicketEventRepository(instrument("ticket_events")),
+		analytics:          postgres.NewAnalyticsRepository(instrument("analytics"), readOpts...),
+		webhooks:           postgres.NewWebhookRepository(instrument("webhooks")),
+		inboundHooks:       postgres.NewInboundHookRepository(instrument("inbound_hooks")),
+		teams:              postgres.NewTeamRepository(instrument("teams")),
+		escalationRules:    postgres.NewEscalationRuleRepository(instrument("escalation_rules")),
+		macros:             postgres.NewMacroRepository(instrument("macros")),
+		searchQueue:        postgres.NewSearchQueueRepository(instrument("search_queue")),
+		searchIndex:        postgres.NewSearchIndex(instrument("search_index"), readOpts...),
+		outbox:             postgres.NewNotificationOutboxRepository(instrument("notification_outbox")),
+		orgSettings:        postgres.NewOrgSettingsRepository(instrument("org_settings")),
+		retention:          postgres.NewRetentionRepository(instrument("retention"), readOpts...),
+		quotas:             postgres.NewQuotaRepository(instrument("quotas")),
+		platform:           postgres.NewPlatformRepository(instrument("platform")),
+		auditLog:           postgres.NewAuditLogRepository(instrument("audit_log"), readOpts...),
+		dataExports:        postgres.NewDataExportRepository(instrument("data_exports")),
+		rateLimitOverrides: postgres.NewRateLimitOverrideRepository(instrument("rate_limit_overrides")),
+		snoozes:            postgres.NewTicketSnoozeRepository(instrument("ticket_snoozes")),
+		commentDrafts:      postgres.NewCommentDraftRepository(instrument("comment_drafts")),
+		emailPreferences:   postgres.NewEmailPreferenceRepository(instrument("email_preferences")),
+		txManager: postgres.NewTransactionManager(pool, postgres.WithRetryPolicy(postgres.RetryPolicy{
+			MaxRetries: cfg.Database.TxMaxRetries,
+			Backoff:    cfg.Database.TxRetryBackoff,
+			MaxBackoff: cfg.Database.TxRetryMaxBackoff,
+		})),
+	}
+}
+
+// newMemoryRepositories creates repositories that keep their data in store.
+func newMemoryRepositories(store *memory.Store) repositories {
+	return repositories{
+		users:              memory.NewUserRepository(store),
+		tickets:            memory.NewTicketRepository(store),
+		ticketArchive:      memory.NewTicketArchiveRepository(store),
+		authz:              memory.NewAuthorizationRepository(store),
+		comments:           memory.NewCommentRepository(store),
+		events:             memory.NewTicketEventRepository(store),
+		analytics:          memory.NewAnalyticsRepository(store),
+		webhooks:           memory.NewWebhookRepository(store),
+		inboundHooks:       memory.NewInboundHookRepository(store),
+		teams:              memory.NewTeamRepository(store),
+		escalationRules:    memory.NewEscalationRuleRepository(store),
+		macros:             memory.NewMacroRepository(store),
+		searchQueue:        memory.NewSearchQueueRepository(store),
+		searchIndex:        memory.NewSearchIndex(store),
+		outbox:             memory.NewNotificationOutboxRepository(store),
+		orgSettings:        memory.NewOrgSettingsRepository(store),
+		retention:          memory.NewRetentionRepository(store),
+		quotas:             memory.NewQuotaRepository(store),
+		platform:           memory.NewPlatformRepository(store),
+		auditLog:           memory.NewAuditLogRepository(store),
+		dataExports:        memory.NewDataExportRepository(store),
+		rateLimitOverrides: memory.NewRateLimitOverrideRepository(store),
+		snoozes:            memory.NewTicketSnoozeRepository(store),
+		commentDrafts:      memory.NewCommentDraftRepository(store),
+		emailPreferences:   memory.NewEmailPreferenceRepository(store),
+		txManager:          memory.NewTransactionManager(),
+	}
+}
@@ -0,0 +1,772 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware" // Import standard middleware
+	"github.com/go-chi/cors"              // FIX: Import CORS
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	httpAdapter "github.com/lorrc/service-desk-backend/internal/adapters/primary/http"
+	mw "github.com/lorrc/service-desk-backend/internal/adapters/primary/http/middleware"
+	"github.com/lorrc/service-desk-backend/internal/adapters/secondary/captcha"
+	"github.com/lorrc/service-desk-backend/internal/adapters/secondary/email"
+	"github.com/lorrc/service-desk-backend/internal/adapters/secondary/memory"
+	"github.com/lorrc/service-desk-backend/internal/adapters/secondary/opensearch"
+	"github.com/lorrc/service-desk-backend/internal/adapters/secondary/pdf"
+	"github.com/lorrc/service-desk-backend/internal/adapters/secondary/postgres"
+	"github.com/lorrc/service-desk-backend/internal/adapters/secondary/redis"
+	"github.com/lorrc/service-desk-backend/internal/adapters/secondary/sanitizer"
+	"github.com/lorrc/service-desk-backend/internal/adapters/secondary/slack"
+	"github.com/lorrc/service-desk-backend/internal/adapters/secondary/sms"
+	"github.com/lorrc/service-desk-backend/internal/adapters/secondary/teams"
+	"github.com/lorrc/service-desk-backend/internal/adapters/secondary/webhook"
+	"github.com/lorrc/service-desk-backend/internal/auth"
+	"github.com/lorrc/service-desk-backend/internal/config"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports" // Assuming interface exists here
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/lorrc/service-desk-backend/internal/infrastructure/circuitbreaker"
+	"github.com/lorrc/service-desk-backend/internal/infrastructure/logging"
+	"github.com/lorrc/service-desk-backend/migrations"
+)
+
+// runServe starts the HTTP API and background jobs and blocks until the
+// process is asked to shut down.
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	devInMemory := fs.Bool("dev-inmemory", false, "Keep all data in memory, seeded with demo accounts, instead of Postgres (development only)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// The database URL is required configuration but unused in memory
+	if *devInMemory && os.Getenv("DATABASE_URL") == "" {
+		if err := os.Setenv("DATABASE_URL", "memory://"); err != nil {
+			return err
+		}
+	}
+
+	// 1. Load Configuration
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if *devInMemory && cfg.IsProduction() {
+		return errors.New("--dev-inmemory cannot be used in production")
+	}
+
+	// 2. Initialize Structured Logger
+	logLevel := new(slog.LevelVar)
+	logger := newLogger(cfg, logLevel)
+
+	logger.Info("starting service", "version", cfg.App.Version)
+
+	// 3. Initialize Database Pool
+	// FIX: Use timeout to prevent hanging if DB is down
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Breakers make requests fail fast while a dependency is down
+	var breakers []*circuitbreaker.Breaker
+	newBreaker := func(name string) *circuitbreaker.Breaker {
+		breaker := circuitbreaker.New(circuitbreaker.Config{
+			Name:             name,
+			FailureThreshold: cfg.CircuitBreaker.FailureThreshold,
+			OpenTimeout:      cfg.CircuitBreaker.OpenTimeout,
+			Logger:           logger,
+		})
+		breakers = append(breakers, breaker)
+		return breaker
+	}
+	withBreaker := func(name string, n ports.Notifier) ports.Notifier {
+		if !cfg.CircuitBreaker.Enabled {
+			return n
+		}
+		return circuitbreaker.NewNotifier(newBreaker(name), n)
+	}
+
+	defaultOrgID, err := uuid.Parse(cfg.App.DefaultOrgID)
+	if err != nil {
+		return fmt.Errorf("invalid default org ID: %w", err)
+	}
+
+	// Each repository's queries are timed and classified under its name
+	instrumentation := postgres.NewInstrumentation(logger, cfg.Database.SlowQueryThreshold)
+
+	var (
+		repos       repositories
+		database    httpAdapter.HealthChecker
+		schema      httpAdapter.SchemaVersioner
+		readReplica *postgres.ReadReplica
+	)
+	if *devInMemory {
+		store := memory.NewStore(defaultOrgID)
+		accounts, err := store.Seed(ctx, defaultOrgID)
+		if err != nil {
+			return fmt.Errorf("seed in-memory data: %w", err)
+		}
+		for _, account := range accounts {
+			logger.Info("seeded account", "email", account.Email, "role", account.Role, "password", memory.SeedPassword)
+		}
+		logger.Warn("running with in-memory storage, data is lost when the server stops")
+		repos = newMemoryRepositories(store)
+		database = store
+	} else {
+		if cfg.Database.MigrateOnStart {
+			if err := migrateOnStart(cfg.Database.URL, logger); err != nil {
+				return err
+			}
+		}
+
+		var poolOptions []func(*pgxpool.Config)
+		if cfg.CircuitBreaker.Enabled {
+			dbBreaker := newBreaker("database")
+			poolOptions = append(poolOptions, func(c *pgxpool.Config) {
+				postgres.UseCircuitBreaker(c, dbBreaker)
+			})
+		}
+
+		pool, err := openPool(ctx, cfg, poolOptions...)
+		if err != nil {
+			return err
+		}
+		// FIX: This defer will now actually run because we return error instead of os.Exit
+		defer pool.Close()
+		logger.Info("database connection established")
+
+		// List and analytics queries read from the replica when one is set, and
+		// listing totals fall back to estimates when counting takes too long
+		readOpts := []postgres.RepositoryOption{postgres.WithCountBudget(cfg.Database.CountTimeBudget)}
+		if cfg.Database.ReadURL != "" {
+			replicaPool, err := newPool(ctx, cfg, cfg.Database.ReadURL)
+			if err != nil {
+				return fmt.Errorf("read replica: %w", err)
+			}
+			defer replicaPool.Close()
+			readReplica = postgres.NewReadReplica(ctx, replicaPool, logger)
+			readOpts = append(readOpts, postgres.WithReadReplica(readReplica))
+			logger.Info("read replica configured", "healthy", readReplica.Healthy())
+		}
+
+		repos = newPostgresRepositories(cfg, pool, instrumentation, readOpts)
+		database = pool
+		schema = postgres.NewSchemaVersionReader(pool)
+	}
+
+	// 4. Initialize Components
+	tokenManager := auth.NewTokenManager(cfg.JWT.Secret, cfg.JWT.AccessTokenTTL)
+	txManager := repos.txManager
+
+	// Cache and rate limit state are shared through Redis when configured
+	var cache ports.Cache = memory.NewCache(time.Minute)
+	var rateLimitStore ports.RateLimitStore
+	if cfg.Cache.RedisURL != "" {
+		redisClient, err := redis.NewClient(redis.Config{URL: cfg.Cache.RedisURL, PoolSize: 10})
+		if err != nil {
+			return err
+		}
+		defer redisClient.Close()
+		if err := redisClient.Ping(ctx); err != nil {
+			return fmt.Errorf("redis ping failed: %w", err)
+		}
+		cache = redis.NewCache(redisClient, "service-desk:")
+		rateLimitStore = redis.NewRateLimitStore(redisClient, "service-desk:")
+		logger.Info("redis connection established")
+	}
+
+	// 5. Rate Limiters
+	errorHandler := httpAdapter.NewErrorHandler(logger)
+	var generalRateLimiter, authRateLimiter, portalRateLimiter *mw.RateLimiter
+	if cfg.RateLimit.Enabled {
+		// ... (keep your existing rate limiter config) ...
+		generalRateLimiter = mw.NewRateLimiter(mw.RateLimiterConfig{
+			Name:              "general",
+			RequestsPerSecond: cfg.RateLimit.RequestsPerSecond,
+			BurstSize:         cfg.RateLimit.BurstSize,
+			CleanupInterval:   time.Minute,
+			TTL:               3 * time.Minute,
+			Tokens:            tokenManager,
+			Store:             rateLimitStore,
+			Logger:            logger,
+			ErrorHandler:      errorHandler.Handle,
+		})
+		authRateLimiter = mw.NewRateLimiter(mw.RateLimiterConfig{
+			Name:              "auth",
+			RequestsPerSecond: cfg.RateLimit.AuthRPS,
+			BurstSize:         cfg.RateLimit.AuthBurst,
+			CleanupInterval:   time.Minute,
+			TTL:               5 * time.Minute,
+			Store:             rateLimitStore,
+			Logger:            logger,
+			ErrorHandler:      errorHandler.Handle,
+		})
+		if cfg.Portal.Enabled {
+			portalRateLimiter = mw.NewRateLimiter(mw.RateLimiterConfig{
+				Name:              "portal",
+				RequestsPerSecond: cfg.RateLimit.PortalRPS,
+				BurstSize:         cfg.RateLimit.PortalBurst,
+				CleanupInterval:   time.Minute,
+				TTL:               time.Hour,
+				Store:             rateLimitStore,
+				Logger:            logger,
+				ErrorHandler:      errorHandler.Handle,
+			})
+		}
+	}
+
+	// Settings that can be reloaded on SIGHUP or via /admin/config/reload
+	runtimeConfig := config.NewRuntime(cfg)
+	runtimeConfig.OnReload(func(rc *domain.RuntimeConfig) {
+		logLevel.Set(logging.ParseLevel(rc.LogLevel))
+		if generalRateLimiter != nil {
+			generalRateLimiter.SetLimit(rc.RateLimit.RequestsPerSecond, rc.RateLimit.Burst)
+		}
+		if authRateLimiter != nil {
+			authRateLimiter.SetLimit(rc.RateLimit.AuthRequestsPerSecond, rc.RateLimit.AuthBurst)
+		}
+	})
+
+	// 6. Dependency Injection
+	userRepo := repos.users
+	// Tickets closed for a while move to the archive and are read through
+	// to it. The search indexer uses the hot tables so archived tickets
+	// leave the search index.
+	ticketArchiveRepo := repos.ticketArchive
+	hotTicketRepo := repos.tickets
+	ticketRepo := services.NewArchiveReadThroughTicketRepository(hotTicketRepo, ticketArchiveRepo)
+	authzRepo := repos.authz
+	hotCommentRepo := repos.comments
+	commentRepo := services.NewArchiveReadThroughCommentRepository(hotCommentRepo, ticketArchiveRepo)
+	analyticsRepo := repos.analytics
+	webhookRepo := repos.webhooks
+	inboundHookRepo := repos.inboundHooks
+	teamRepo := repos.teams
+	escalationRuleRepo := repos.escalationRules
+	macroRepo := repos.macros
+	searchQueueRepo := repos.searchQueue
+	eventRepo := services.NewArchiveReadThroughEventRepository(
+		services.NewSearchIndexingEventRepository(
+			services.NewWebhookPublishingEventRepository(repos.events, webhookRepo),
+			searchQueueRepo,
+		),
+		ticketArchiveRepo,
+	)
+	outboxRepo := repos.outbox
+	orgSettingsRepo := repos.orgSettings
+	retentionRepo := repos.retention
+	quotaRepo := repos.quotas
+	platformRepo := repos.platform
+	auditRepo := repos.auditLog
+	dataExportRepo := repos.dataExports
+	rateLimitOverrideRepo := repos.rateLimitOverrides
+	snoozeRepo := repos.snoozes
+	commentDraftRepo := repos.commentDrafts
+	emailPreferenceRepo := repos.emailPreferences
+	if err := authzRepo.EnsureRBACDefaults(ctx); err != nil {
+		return fmt.Errorf("ensure rbac defaults: %w", err)
+	}
+	if cfg.Cache.PermissionTTL > 0 {
+		authzRepo = services.NewCachingAuthorizationRepository(authzRepo, cache, cfg.Cache.PermissionTTL, logger)
+	}
+
+	searchIndex := repos.searchIndex
+	if cfg.Search.Backend == "opensearch" {
+		openSearchIndex := opensearch.NewIndex(opensearch.Config{
+			URL:      cfg.Search.OpenSearchURL,
+			Index:    cfg.Search.OpenSearchIndex,
+			Username: cfg.Search.OpenSearchUsername,
+			Password: cfg.Search.OpenSearchPassword,
+			Timeout:  cfg.Search.Timeout,
+		})
+		if err := openSearchIndex.EnsureIndex(ctx); err != nil {
+			return fmt.Errorf("ensure search index: %w", err)
+		}
+		searchIndex = openSearchIndex
+		logger.Info("searching with opensearch", "index", cfg.Search.OpenSearchIndex)
+	}
+
+	// FIX: Don't use Mock in production
+	var emailNotifier ports.Notifier // Use your interface type
+	// Outside production, emails are kept for previewing under /dev/emails
+	var mailbox *email.Mailbox
+	if cfg.App.Environment == "production" {
+		// emailNotifier = email.NewSMTPNotifier(cfg.SMTP) // TODO: Implement real SMTP
+		logger.Warn("using mock notifier in production")
+		emailNotifier = email.NewMockSMTPNotifier(userRepo)
+	} else {
+		mailbox = email.NewMailbox(200)
+		emailNotifier = email.NewCapturingNotifier(userRepo, mailbox, logger)
+	}
+
+	// Ticket emails honour the recipient's opt-outs and carry the links to opt out
+	emailNotifier = services.NewOptOutNotifier(emailNotifier, emailPreferenceRepo, services.OptOutConfig{
+		LinkURL: cfg.Notifications.PreferencesURL,
+		Secret:  []byte(cfg.JWT.Secret),
+	})
+	notifiers := []ports.Notifier{withBreaker("email", emailNotifier)}
+	if cfg.Slack.Enabled() {
+		notifiers = append(notifiers, withBreaker("slack", slack.NewNotifier(slack.Config{
+			WebhookURL:     cfg.Slack.WebhookURL,
+			BotToken:       cfg.Slack.BotToken,
+			DefaultChannel: cfg.Slack.DefaultChannel,
+			OrgChannels:    cfg.Slack.OrgChannels,
+		}, userRepo, logger)))
+		logger.Info("slack notifications enabled")
+	}
+	if cfg.Teams.Enabled() {
+		notifiers = append(notifiers, withBreaker("teams", teams.NewNotifier(teams.Config{
+			WebhookURL:  cfg.Teams.WebhookURL,
+			OrgWebhooks: cfg.Teams.OrgWebhooks,
+		}, userRepo, logger)))
+		logger.Info("teams notifications enabled")
+	}
+	if cfg.SMS.Enabled() {
+		notifiers = append(notifiers, withBreaker("sms", sms.NewTwilioNotifier(sms.Config{
+			AccountSID:        cfg.SMS.TwilioAccountSID,
+			AuthToken:         cfg.SMS.TwilioAuthToken,
+			FromNumber:        cfg.SMS.FromNumber,
+			MaxPerUserPerHour: cfg.SMS.MaxPerUserPerHour,
+			MaxPerMinute:      cfg.SMS.MaxPerMinute,
+		}, userRepo, logger)))
+		logger.Info("sms notifications enabled")
+	}
+	notifier := services.NewMultiNotifier(notifiers...)
+
+	authzService := services.NewAuthorizationService(authzRepo)
+	quotaService := services.NewQuotaService(quotaRepo, authzService, domain.OrgQuota{
+		MaxUsers:       quotaLimit(cfg.Quotas.MaxUsers),
+		MaxOpenTickets: quotaLimit(cfg.Quotas.MaxOpenTickets),
+	})
+	authService := services.NewAuthService(userRepo, authzRepo, orgSettingsRepo, quotaService, defaultOrgID)
+	platformService := services.NewPlatformService(platformRepo, orgSettingsRepo, userRepo, authzService, quotaService, auditRepo)
+	assigneeService := services.NewAssigneeService(userRepo, ticketRepo, analyticsRepo, orgSettingsRepo, authzService)
+	userLookupService := services.NewUserLookupService(userRepo)
+	profileService := services.NewProfileService(userRepo, authzRepo, orgSettingsRepo, emailPreferenceRepo, auditRepo, outboxRepo, txManager, services.EmailChangeConfig{
+		ConfirmURL: cfg.EmailChange.ConfirmURL,
+		Secret:     []byte(cfg.JWT.Secret),
+		LinkTTL:    cfg.EmailChange.LinkTTL,
+	})
+	htmlSanitizer := sanitizer.NewSanitizer()
+	ticketService := services.NewTicketService(ticketRepo, authzService, outboxRepo, eventRepo, userRepo, teamRepo, orgSettingsRepo, quotaService, htmlSanitizer, txManager)
+	commentService := services.NewCommentService(commentRepo, ticketService, authzService, outboxRepo, eventRepo, snoozeRepo, commentDraftRepo, htmlSanitizer, txManager, cfg.Notifications.CommentBatchWindow)
+	eventService := services.NewEventService(eventRepo, ticketService)
+	adminService := services.NewAdminService(userRepo, authzRepo, authzService, ticketRepo, eventRepo, outboxRepo, analyticsRepo, auditRepo, quotaService, txManager)
+	webhookSender := webhook.NewSender(cfg.Webhooks.Timeout)
+	webhookService := services.NewWebhookService(webhookRepo, webhookSender, authzService, auditRepo, txManager)
+	eventReplayService := services.NewEventReplayService(eventRepo, webhookRepo, authzService, auditRepo, txManager)
+	inboundHookService := services.NewInboundHookService(inboundHookRepo, ticketService, userRepo, authzService, auditRepo, txManager)
+	teamService := services.NewTeamService(teamRepo, userRepo, authzService, auditRepo, txManager)
+	escalationService := services.NewEscalationService(escalationRuleRepo, teamRepo, userRepo, orgSettingsRepo, authzService, auditRepo, txManager)
+	macroService := services.NewMacroService(macroRepo, userRepo, ticketService, commentService, authzService, auditRepo, txManager)
+	snoozeService := services.NewSnoozeService(snoozeRepo, ticketService, authzService)
+	commentDraftService := services.NewCommentDraftService(commentDraftRepo, ticketService, authzService)
+	emailPreferenceService := services.NewEmailPreferenceService(emailPreferenceRepo, ticketService, []byte(cfg.JWT.Secret))
+	pdfRenderer, err := pdf.NewRenderer()
+	if err != nil {
+		return fmt.Errorf("create ticket PDF renderer: %w", err)
+	}
+	ticketExportService := services.NewTicketExportService(ticketService, commentService, userRepo, teamRepo, pdfRenderer)
+	orgSettingsService := services.NewOrgSettingsService(orgSettingsRepo, retentionRepo, auditRepo, authzService, txManager)
+	portalService := services.NewPortalService(orgSettingsRepo, userRepo, authzRepo, ticketService, commentService, outboxRepo,
+		captcha.NewVerifier(captcha.Config{
+			Secret:    cfg.Portal.CaptchaSecret,
+			VerifyURL: cfg.Portal.CaptchaVerifyURL,
+		}), txManager, services.PortalConfig{
+			LinkURL:    cfg.Portal.LinkURL,
+			LinkSecret: []byte(cfg.JWT.Secret),
+			LinkTTL:    cfg.Portal.LinkTTL,
+		})
+	dataExportService := services.NewDataExportService(dataExportRepo, userRepo, authzService, auditRepo, txManager)
+	searchService := services.NewSearchService(searchIndex, authzService, userRepo)
+	configService := services.NewConfigService(runtimeConfig, authzService, auditRepo)
+	rateLimitService := services.NewRateLimitService(rateLimitOverrideRepo, mw.NewRateLimiterGroup(generalRateLimiter, authRateLimiter, portalRateLimiter), userRepo, authzService, auditRepo, txManager)
+	dispatcherConfig := services.DispatcherConfig{
+		PollInterval: cfg.Notifications.PollInterval,
+		BatchSize:    cfg.Notifications.BatchSize,
+		ClaimLease:   cfg.Notifications.ClaimLease,
+		MaxAttempts:  cfg.Notifications.MaxAttempts,
+		BaseBackoff:  cfg.Notifications.RetryBase,
+		MaxBackoff:   cfg.Notifications.RetryMax,
+	}
+	notificationDispatcher := services.NewNotificationDispatcher(outboxRepo, notifier, dispatcherConfig, logger)
+	webhookDispatcherConfig := dispatcherConfig
+	webhookDispatcherConfig.MaxAttempts = cfg.Webhooks.MaxAttempts
+	webhookDispatcher := services.NewWebhookDispatcher(webhookRepo, webhookSender, webhookDispatcherConfig, logger)
+	analyticsRollupJob := services.NewAnalyticsRollupJob(analyticsRepo, txManager, services.AnalyticsRollupConfig{
+		Interval:     cfg.Analytics.RollupInterval,
+		LookbackDays: cfg.Analytics.RollupLookbackDays,
+	}, logger)
+	dataExportJob := services.NewDataExportJob(dataExportRepo, outboxRepo, txManager, services.DataExportConfig{
+		PollInterval: cfg.DataExports.PollInterval,
+		Retention:    cfg.DataExports.Retention,
+	}, logger)
+	searchIndexer := services.NewSearchIndexer(searchQueueRepo, searchIndex, hotTicketRepo, hotCommentRepo, dispatcherConfig, logger)
+	retentionJob := services.NewRetentionJob(retentionRepo, txManager, services.RetentionConfig{
+		Interval: cfg.Retention.Interval,
+		DryRun:   cfg.Retention.DryRun,
+	}, logger)
+	archiveJob := services.NewArchiveJob(ticketArchiveRepo, services.ArchiveConfig{
+		Interval:  cfg.Archive.Interval,
+		AfterDays: cfg.Archive.AfterDays,
+		BatchSize: cfg.Archive.BatchSize,
+	}, logger)
+	snoozeJob := services.NewSnoozeJob(snoozeRepo, hotTicketRepo, outboxRepo, txManager, services.SnoozeConfig{
+		Interval:  cfg.Snooze.CheckInterval,
+		BatchSize: cfg.Snooze.BatchSize,
+	}, logger)
+	activityTracker := services.NewActivityTracker(userRepo, services.ActivityConfig{
+		Throttle:      cfg.Activity.Throttle,
+		FlushInterval: cfg.Activity.FlushInterval,
+	}, logger)
+
+	if err := rateLimitService.LoadOverrides(ctx); err != nil {
+		return fmt.Errorf("load rate limit overrides: %w", err)
+	}
+
+	// Seed admin user if configured
+	if err := seedAdminUser(ctx, cfg.Admin, authService, defaultOrgID, logger); err != nil {
+		return fmt.Errorf("failed to seed admin user: %w", err)
+	}
+
+	authHandler := httpAdapter.NewAuthHandler(authService, profileService, tokenManager, errorHandler, logger)
+	meHandler := httpAdapter.NewMeHandler(authzService, profileService, dataExportService, teamService, errorHandler, logger)
+	emailPreferenceHandler := httpAdapter.NewEmailPreferenceHandler(emailPreferenceService, errorHandler, logger)
+	assigneeHandler := httpAdapter.NewAssigneeHandler(assigneeService, errorHandler, logger)
+	searchHandler := httpAdapter.NewSearchHandler(searchService, errorHandler, logger)
+	adminHandler := httpAdapter.NewAdminHandler(adminService, dataExportService, errorHandler, logger)
+	webhookHandler := httpAdapter.NewWebhookHandler(webhookService, errorHandler, logger)
+	eventReplayHandler := httpAdapter.NewEventReplayHandler(eventReplayService, errorHandler, logger)
+	inboundHookHandler := httpAdapter.NewInboundHookHandler(inboundHookService, errorHandler, logger)
+	teamHandler := httpAdapter.NewTeamHandler(teamService, errorHandler, logger)
+	escalationHandler := httpAdapter.NewEscalationHandler(escalationService, errorHandler, logger)
+	macroHandler := httpAdapter.NewMacroHandler(macroService, errorHandler, logger)
+	rateLimitHandler := httpAdapter.NewRateLimitHandler(rateLimitService, errorHandler, logger)
+	portalHandler := httpAdapter.NewPortalHandler(portalService, errorHandler, logger)
+	orgSettingsHandler := httpAdapter.NewOrgSettingsHandler(orgSettingsService, errorHandler, logger)
+	quotaHandler := httpAdapter.NewQuotaHandler(quotaService, errorHandler, logger)
+	platformHandler := httpAdapter.NewPlatformHandler(platformService, tokenManager, errorHandler, logger)
+	configHandler := httpAdapter.NewConfigHandler(configService, errorHandler, logger)
+	commentHandler := httpAdapter.NewCommentHandler(commentService, commentDraftService, userLookupService, errorHandler, logger)
+	ticketHandler := httpAdapter.NewTicketHandler(ticketService, eventService, commentService, macroService, snoozeService, ticketExportService, assigneeService, userLookupService, commentHandler, errorHandler, logger)
+	versionHandler := httpAdapter.NewVersionHandler(cfg.App.Version)
+	openAPIHandler := httpAdapter.NewOpenAPIHandler(cfg.App.Version)
+	metaHandler := httpAdapter.NewMetaHandler()
+	healthHandler := httpAdapter.NewHealthHandler(database, cfg.App.Version, breakers...)
+	if schema != nil {
+		schemaVersion, err := migrations.LatestVersion()
+		if err != nil {
+			return fmt.Errorf("read embedded migrations: %w", err)
+		}
+		healthHandler.RequireSchemaVersion(schema, schemaVersion)
+	}
+
+	// 7. Setup Router
+	r := chi.NewRouter()
+
+	r.Use(middleware.RealIP) // 1. Important for Rate Limiting behind proxy
+	r.Use(mw.RequestID)
+	r.Use(mw.Locale)
+	r.Use(mw.RequestLogger(logger))
+	r.Use(mw.RecoveryLogger(logger))
+
+	if cfg.Compression.Enabled {
+		compressionCfg := mw.DefaultCompressionConfig()
+		compressionCfg.MinSize = cfg.Compression.MinSize
+		r.Use(mw.Compression(compressionCfg))
+	}
+
+	r.Use(cors.Handler(cors.Options{
+		AllowOriginFunc:  mw.AllowOrigins(func() []string { return runtimeConfig.Current().CORSOrigins }),
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Accept-Language", "Authorization", "Content-Type", mw.IdempotencyKeyHeader, mw.OrgSlugHeader},
+		AllowCredentials: true,
+	}))
+
+	// Injected faults come after CORS so browsers still see the responses
+	if cfg.FaultInjection.Enabled {
+		rules := faultRules(cfg.FaultInjection)
+		r.Use(mw.FaultInjection(rules, logger))
+		logger.Warn("fault injection enabled", "rules", len(rules))
+	}
+
+	if generalRateLimiter != nil {
+		r.Use(generalRateLimiter.Middleware)
+	}
+
+	r.Get("/health", healthHandler.HandleHealth)
+	r.Get("/health/live", healthHandler.HandleLiveness)
+	r.Get("/health/ready", healthHandler.HandleReadiness)
+	r.Get("/version", versionHandler.HandleVersion)
+
+	// Profiles take as long as their seconds parameter, so they are exempt
+	// from the write timeout
+	if cfg.Server.PprofEnabled {
+		r.Group(func(r chi.Router) {
+			r.Use(mw.JWTMiddleware(tokenManager))
+			r.Use(mw.SessionMiddleware(authService))
+			r.Use(mw.RequirePermission(authzService, "admin:access"))
+			r.Use(withoutWriteDeadline)
+			r.Mount("/debug", middleware.Profiler())
+		})
+		logger.Info("pprof endpoints enabled", "path", "/debug/pprof/")
+	}
+
+	if mailbox != nil {
+		r.Route("/dev/emails", httpAdapter.NewDevEmailHandler(mailbox, errorHandler).RegisterRoutes)
+		logger.Info("email previews enabled", "path", "/dev/emails")
+	}
+
+	if cfg.Server.MetricsEnabled {
+		r.Group(func(r chi.Router) {
+			r.Use(mw.JWTMiddleware(tokenManager))
+			r.Use(mw.SessionMiddleware(authService))
+			r.Use(mw.RequirePermission(authzService, "admin:access"))
+			r.Handle("/metrics", instrumentation)
+		})
+		logger.Info("metrics endpoint enabled", "path", "/metrics")
+	}
+
+	r.Route("/api/v1", func(r chi.Router) {
+		r.Get("/openapi.json", openAPIHandler.HandleSpec)
+		r.Get("/docs", openAPIHandler.HandleDocs)
+		r.Route("/meta", metaHandler.RegisterRoutes)
+		r.Route("/hooks", inboundHookHandler.RegisterReceiverRoutes)
+
+		// Requests without a token name their organization with a header or
+		// subdomain
+		resolveOrganization := mw.Organization(orgSettingsRepo, cfg.App.TenantDomain)
+
+		if cfg.Portal.Enabled {
+			r.Route("/public", func(r chi.Router) {
+				r.Group(func(r chi.Router) {
+					if portalRateLimiter != nil {
+						r.Use(portalRateLimiter.Middleware)
+					}
+					r.Use(resolveOrganization)
+					portalHandler.RegisterSubmissionRoutes(r)
+				})
+				portalHandler.RegisterLinkRoutes(r)
+			})
+		}
+
+		r.Group(func(r chi.Router) {
+			if authRateLimiter != nil {
+				r.Use(authRateLimiter.Middleware)
+			}
+			r.Route("/auth", func(r chi.Router) {
+				r.Use(resolveOrganization)
+				authHandler.RegisterRoutes(r)
+			})
+			r.Route("/email-preferences", emailPreferenceHandler.RegisterLinkRoutes)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(mw.JWTMiddleware(tokenManager))
+			r.Use(mw.SessionMiddleware(authService))
+			r.Use(mw.Activity(activityTracker))
+			r.Use(mw.Idempotency(cache, cfg.Cache.IdempotencyTTL, logger))
+			r.Route("/me", func(r chi.Router) {
+				meHandler.RegisterRoutes(r)
+				r.Route("/email-preferences", emailPreferenceHandler.RegisterRoutes)
+			})
+			r.Route("/assignees", assigneeHandler.RegisterRoutes)
+			r.Route("/macros", macroHandler.RegisterRoutes)
+			r.Route("/search", searchHandler.RegisterRoutes)
+			r.Route("/admin", func(r chi.Router) {
+				adminHandler.RegisterRoutes(r)
+				r.Route("/webhooks", webhookHandler.RegisterRoutes)
+				r.Route("/events", eventReplayHandler.RegisterRoutes)
+				r.Route("/inbound-hooks", inboundHookHandler.RegisterRoutes)
+				r.Route("/teams", teamHandler.RegisterRoutes)
+				r.Route("/escalation-rules", escalationHandler.RegisterRoutes)
+				r.Route("/macros", macroHandler.RegisterAdminRoutes)
+				r.Route("/rate-limits", rateLimitHandler.RegisterRoutes)
+				r.Route("/org/settings", orgSettingsHandler.RegisterRoutes)
+				r.Route("/usage", quotaHandler.RegisterRoutes)
+				r.Route("/config", configHandler.RegisterRoutes)
+				r.Route("/organizations", platformHandler.RegisterRoutes)
+			})
+			r.Route("/tickets", ticketHandler.RegisterRoutes)
+		})
+	})
+
+	srv := &http.Server{
+		Addr:              cfg.Server.Port,
+		Handler:           r,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: 5 * time.Second,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
+
+	// 8. Start Server and background dispatchers
+	dispatcherCtx, stopDispatcher := context.WithCancel(context.Background())
+	dispatcherDone := make(chan struct{})
+	webhookDispatcherDone := make(chan struct{})
+	analyticsRollupDone := make(chan struct{})
+	dataExportDone := make(chan struct{})
+	retentionDone := make(chan struct{})
+	searchIndexerDone := make(chan struct{})
+	archiveDone := make(chan struct{})
+	snoozeDone := make(chan struct{})
+	activityDone := make(chan struct{})
+	go func() {
+		defer close(dispatcherDone)
+		notificationDispatcher.Run(dispatcherCtx)
+	}()
+	go func() {
+		defer close(webhookDispatcherDone)
+		webhookDispatcher.Run(dispatcherCtx)
+	}()
+	go func() {
+		defer close(analyticsRollupDone)
+		analyticsRollupJob.Run(dispatcherCtx)
+	}()
+	go func() {
+		defer close(dataExportDone)
+		dataExportJob.Run(dispatcherCtx)
+	}()
+	go func() {
+		defer close(retentionDone)
+		retentionJob.Run(dispatcherCtx)
+	}()
+	go func() {
+		defer close(searchIndexerDone)
+		searchIndexer.Run(dispatcherCtx)
+	}()
+	go func() {
+		defer close(archiveDone)
+		if cfg.Archive.AfterDays > 0 {
+			archiveJob.Run(dispatcherCtx)
+		}
+	}()
+	go func() {
+		defer close(snoozeDone)
+		snoozeJob.Run(dispatcherCtx)
+	}()
+	go func() {
+		defer close(activityDone)
+		activityTracker.Run(dispatcherCtx)
+	}()
+	if readReplica != nil {
+		go readReplica.Monitor(dispatcherCtx, cfg.Database.ReadCheckInterval)
+	}
+
+	go func() {
+		logger.Info("server starting", "port", cfg.Server.Port)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("server error", "error", err)
+			os.Exit(1)
+		}
+	}()
+
+	// Reload the runtime configuration on SIGHUP
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	go func() {
+		for range hup {
+			rc, err := runtimeConfig.Reload()
+			if err != nil {
+				logger.Error("configuration reload failed, keeping current configuration", "error", err)
+				continue
+			}
+			logger.Info("configuration reloaded", "log_level", rc.LogLevel)
+		}
+	}()
+
+	// 9. Graceful Shutdown
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-quit
+
+	logger.Info("shutdown signal received", "signal", sig.String())
+
+	// Fail readiness and keep serving while load balancers notice, unless
+	// a second signal asks to stop right away
+	healthHandler.StartDraining()
+	if cfg.Server.DrainPeriod > 0 {
+		logger.Info("draining before shutdown", "period", cfg.Server.DrainPeriod.String())
+		select {
+		case <-time.After(cfg.Server.DrainPeriod):
+		case sig := <-quit:
+			logger.Warn("second shutdown signal received, skipping drain", "signal", sig.String())
+		}
+	}
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer shutdownCancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logger.Error("server shutdown error", "error", err)
+		// We don't exit here, we try to close other resources
+	}
+
+	logger.Info("waiting for background tasks to finish...")
+	stopDispatcher()
+	<-dispatcherDone
+	<-webhookDispatcherDone
+	<-analyticsRollupDone
+	<-dataExportDone
+	<-retentionDone
+	<-searchIndexerDone
+	<-archiveDone
+	<-snoozeDone
+	<-activityDone
+
+	logger.Info("server shutdown complete")
+	return nil
+}
+
+// quotaLimit converts a configured quota, where 0 means unlimited, into a
+// limit.
+func quotaLimit(n int) *int {
+	if n == 0 {
+		return nil
+	}
+	return &n
+}
+
+// withoutWriteDeadline clears the server's write deadline for the request.
+func withoutWriteDeadline(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+		next.ServeHTTP(w, r)
+	})
+}
+
+// faultRules combines the configured latencies and error rates into one
+// rule per path prefix.
+func faultRules(cfg config.FaultInjectionConfig) []mw.FaultRule {
+	byPrefix := make(map[string]*mw.FaultRule)
+	rule := func(prefix string) *mw.FaultRule {
+		if byPrefix[prefix] == nil {
+			byPrefix[prefix] = &mw.FaultRule{PathPrefix: prefix}
+		}
+		return byPrefix[prefix]
+	}
+	for prefix, latency := range cfg.Latency {
+		rule(prefix).Latency = latency
+	}
+	for prefix, rate := range cfg.ErrorRate {
+		rule(prefix).ErrorRate = rate
+	}
+
+	rules := make([]mw.FaultRule, 0, len(byPrefix))
+	for _, r := range byPrefix {
+		rules = append(rules, *r)
+	}
+	return rules
+}
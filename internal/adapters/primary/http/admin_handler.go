@@ -1,8 +1,11 @@
 package http
 
 import (
+	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -15,30 +18,48 @@ import (
 )
 
 type AdminHandler struct {
-	adminService ports.AdminService
-	errorHandler *ErrorHandler
-	logger       *slog.Logger
+	adminService      ports.AdminService
+	dataExportService ports.DataExportService
+	errorHandler      *ErrorHandler
+	logger            *slog.Logger
 }
 
-func NewAdminHandler(adminService ports.AdminService, errorHandler *ErrorHandler, logger *slog.Logger) *AdminHandler {
+func NewAdminHandler(adminService ports.AdminService, dataExportService ports.DataExportService, errorHandler *ErrorHandler, logger *slog.Logger) *AdminHandler {
 	return &AdminHandler{
-		adminService: adminService,
-		errorHandler: errorHandler,
-		logger:       logger.With("handler", "admin"),
+		adminService:      adminService,
+		dataExportService: dataExportService,
+		errorHandler:      errorHandler,
+		logger:            logger.With("handler", "admin"),
 	}
 }
 
 func (h *AdminHandler) RegisterRoutes(r chi.Router) {
 	r.Route("/users", func(r chi.Router) {
 		r.Get("/", h.HandleListUsers)
+		r.Post("/import", h.HandleImportUsers)
 		r.Patch("/{userID}/role", h.HandleUpdateUserRole)
 		r.Patch("/{userID}/status", h.HandleUpdateUserStatus)
+		r.Post("/{userID}/offboard", h.HandleOffboardUser)
 		r.Post("/{userID}/reset-password", h.HandleResetPassword)
+		r.Post("/{userID}/force-logout", h.HandleForceLogout)
+		r.Delete("/{userID}", h.HandleDeleteUser)
+		r.Post("/{userID}/export", h.HandleRequestUserExport)
+		r.Get("/{userID}/export", h.HandleGetUserExport)
+		r.Get("/{userID}/export/download", h.HandleDownloadUserExport)
 	})
 
 	r.Get("/analytics/overview", h.HandleAnalyticsOverview)
+	r.Get("/analytics/export", h.HandleAnalyticsExport)
+	r.Get("/audit-log", h.HandleListAuditLog)
 }
 
+const (
+	// maxUsersPerPage caps the user list page size
+	maxUsersPerPage = 200
+	// maxAuditEntriesPerPage caps the audit log page size
+	maxAuditEntriesPerPage = 200
+)
+
 type UpdateUserRoleRequest struct {
 	Role string `json:"role"`
 }
@@ -47,7 +68,7 @@ func (r *UpdateUserRoleRequest) Validate() error {
 	v := validation.NewValidator()
 
 	v.Required("role", r.Role).
-		OneOf("role", r.Role, []string{"admin", "agent", "customer"})
+		OneOf("role", r.Role, domain.UserRoles)
 
 	if v.HasErrors() {
 		return v.Errors()
@@ -70,6 +91,26 @@ func (r *UpdateUserStatusRequest) Validate() error {
 	return nil
 }
 
+// OffboardUserRequest says who takes over a deactivated user's open
+// tickets. Without reassignTo the tickets are left unassigned.
+type OffboardUserRequest struct {
+	ReassignTo *string `json:"reassignTo"`
+}
+
+func (r *OffboardUserRequest) Validate() error {
+	v := validation.NewValidator()
+
+	if r.ReassignTo != nil {
+		v.Required("reassignTo", *r.ReassignTo).
+			UUID("reassignTo", *r.ReassignTo)
+	}
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
 // HandleListUsers handles GET /admin/users
 func (h *AdminHandler) HandleListUsers(w http.ResponseWriter, r *http.Request) {
 	claims, ok := h.getClaims(w, r)
@@ -77,7 +118,35 @@ func (h *AdminHandler) HandleListUsers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	users, err := h.adminService.ListUsers(r.Context(), claims.UserID, claims.OrgID)
+	pagination := validation.ParsePagination(r, maxUsersPerPage)
+	filter := domain.UserFilter{
+		Query:  r.URL.Query().Get("q"),
+		Role:   validation.ParseStringQueryParam(r, "role"),
+		Limit:  pagination.Limit,
+		Offset: pagination.Offset,
+	}
+
+	v := validation.NewValidator()
+
+	if filter.Role != nil {
+		v.OneOf("role", *filter.Role, domain.UserRoles)
+	}
+
+	if activeStr := r.URL.Query().Get("active"); activeStr != "" {
+		active, err := strconv.ParseBool(activeStr)
+		if err != nil {
+			v.Custom("active", false, "Must be true or false")
+		} else {
+			filter.IsActive = &active
+		}
+	}
+
+	if v.HasErrors() {
+		h.errorHandler.Handle(w, r, v.Errors())
+		return
+	}
+
+	users, total, err := h.adminService.ListUsers(r.Context(), claims.UserID, claims.OrgID, filter)
 	if err != nil {
 		h.errorHandler.Handle(w, r, err)
 		return
@@ -88,7 +157,7 @@ func (h *AdminHandler) HandleListUsers(w http.ResponseWriter, r *http.Request) {
 		response = append(response, toUserSummaryDTO(user))
 	}
 
-	WriteList(w, response)
+	WritePaginated(w, response, pagination.Limit, pagination.Offset, total)
 }
 
 // HandleUpdateUserRole handles PATCH /admin/users/{userID}/role
@@ -123,6 +192,29 @@ func (h *AdminHandler) HandleUpdateUserRole(w http.ResponseWriter, r *http.Reque
 	WriteNoContent(w)
 }
 
+// HandleImportUsers handles POST /admin/users/import. The body is a CSV with
+// name, email and optional role columns, sent raw or as a multipart "file".
+func (h *AdminHandler) HandleImportUsers(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	rows, err := readUserImport(w, r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	report, err := h.adminService.ImportUsers(r.Context(), claims.UserID, claims.OrgID, rows)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toUserImportReportDTO(report))
+}
+
 // HandleUpdateUserStatus handles PATCH /admin/users/{userID}/status
 func (h *AdminHandler) HandleUpdateUserStatus(w http.ResponseWriter, r *http.Request) {
 	claims, ok := h.getClaims(w, r)
@@ -155,6 +247,49 @@ func (h *AdminHandler) HandleUpdateUserStatus(w http.ResponseWriter, r *http.Req
 	WriteNoContent(w)
 }
 
+// HandleOffboardUser handles POST /admin/users/{userID}/offboard
+func (h *AdminHandler) HandleOffboardUser(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	userID, err := h.parseUserID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[OffboardUserRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	var reassignTo *uuid.UUID
+	if req.ReassignTo != nil {
+		assigneeID, err := uuid.Parse(*req.ReassignTo)
+		if err != nil {
+			h.errorHandler.Handle(w, r, err)
+			return
+		}
+		reassignTo = &assigneeID
+	}
+
+	result, err := h.adminService.OffboardUser(r.Context(), claims.UserID, claims.OrgID, userID, reassignTo)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toOffboardResultDTO(result))
+}
+
 // HandleResetPassword handles POST /admin/users/{userID}/reset-password
 func (h *AdminHandler) HandleResetPassword(w http.ResponseWriter, r *http.Request) {
 	claims, ok := h.getClaims(w, r)
@@ -179,6 +314,116 @@ func (h *AdminHandler) HandleResetPassword(w http.ResponseWriter, r *http.Reques
 	})
 }
 
+// HandleForceLogout handles POST /admin/users/{userID}/force-logout
+func (h *AdminHandler) HandleForceLogout(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	userID, err := h.parseUserID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := h.adminService.ForceLogout(r.Context(), claims.UserID, claims.OrgID, userID); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteNoContent(w)
+}
+
+// HandleDeleteUser handles DELETE /admin/users/{userID}
+func (h *AdminHandler) HandleDeleteUser(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	userID, err := h.parseUserID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := h.adminService.DeleteUser(r.Context(), claims.UserID, claims.OrgID, userID); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteNoContent(w)
+}
+
+// HandleRequestUserExport handles POST /admin/users/{userID}/export
+func (h *AdminHandler) HandleRequestUserExport(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	userID, err := h.parseUserID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	export, err := h.dataExportService.RequestUserExport(r.Context(), claims.UserID, claims.OrgID, userID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusAccepted, toDataExportDTO(export))
+}
+
+// HandleGetUserExport handles GET /admin/users/{userID}/export
+func (h *AdminHandler) HandleGetUserExport(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	userID, err := h.parseUserID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	export, err := h.dataExportService.GetUserExport(r.Context(), claims.UserID, claims.OrgID, userID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toDataExportDTO(export))
+}
+
+// HandleDownloadUserExport handles GET /admin/users/{userID}/export/download
+func (h *AdminHandler) HandleDownloadUserExport(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	userID, err := h.parseUserID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	export, err := h.dataExportService.GetUserExport(r.Context(), claims.UserID, claims.OrgID, userID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := writeDataExportDownload(w, export); err != nil {
+		h.errorHandler.Handle(w, r, err)
+	}
+}
+
 // HandleAnalyticsOverview handles GET /admin/analytics/overview
 func (h *AdminHandler) HandleAnalyticsOverview(w http.ResponseWriter, r *http.Request) {
 	claims, ok := h.getClaims(w, r)
@@ -186,9 +431,13 @@ func (h *AdminHandler) HandleAnalyticsOverview(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	days := validation.ParseIntQueryParam(r, "days", 30)
+	rng, err := parseAnalyticsRange(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
 
-	overview, err := h.adminService.GetAnalyticsOverview(r.Context(), claims.UserID, claims.OrgID, days)
+	overview, err := h.adminService.GetAnalyticsOverview(r.Context(), claims.UserID, claims.OrgID, rng)
 	if err != nil {
 		h.errorHandler.Handle(w, r, err)
 		return
@@ -197,6 +446,134 @@ func (h *AdminHandler) HandleAnalyticsOverview(w http.ResponseWriter, r *http.Re
 	WriteJSON(w, http.StatusOK, toAnalyticsOverviewResponse(overview))
 }
 
+// HandleAnalyticsExport handles GET /admin/analytics/export
+// It returns the same data as the overview as a CSV download.
+func (h *AdminHandler) HandleAnalyticsExport(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	rng, err := parseAnalyticsRange(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	overview, err := h.adminService.GetAnalyticsOverview(r.Context(), claims.UserID, claims.OrgID, rng)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	filename := fmt.Sprintf("analytics-%s.csv", time.Now().UTC().Format("2006-01-02"))
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeAnalyticsCSV(w, overview); err != nil {
+		// The status has already been sent, so the download is just cut short.
+		h.logger.Error("failed to write analytics export", "error", err)
+	}
+}
+
+// HandleListAuditLog handles GET /admin/audit-log
+func (h *AdminHandler) HandleListAuditLog(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	pagination := validation.ParsePagination(r, maxAuditEntriesPerPage)
+	filter := domain.AuditLogFilter{
+		TargetID: validation.ParseStringQueryParam(r, "targetId"),
+		Limit:    pagination.Limit,
+		Offset:   pagination.Offset,
+	}
+
+	v := validation.NewValidator()
+
+	if action := validation.ParseStringQueryParam(r, "action"); action != nil {
+		value := domain.AuditAction(*action)
+		filter.Action = &value
+	}
+
+	if actorIDStr := r.URL.Query().Get("actorId"); actorIDStr != "" {
+		actorID, err := uuid.Parse(actorIDStr)
+		if err != nil {
+			v.Custom("actorId", false, "Must be a valid UUID")
+		} else {
+			filter.ActorID = &actorID
+		}
+	}
+
+	from, err := validation.ParseTimeQueryParam(r, "from")
+	if err != nil {
+		v.Custom("from", false, "Must be a valid date or timestamp")
+	} else if from != nil {
+		filter.From = &from.Time
+	}
+
+	to, err := validation.ParseTimeQueryParam(r, "to")
+	if err != nil {
+		v.Custom("to", false, "Must be a valid date or timestamp")
+	} else if to != nil {
+		// A date-only end includes the whole day.
+		end := to.Time
+		if to.DateOnly {
+			end = end.Add(24*time.Hour - time.Nanosecond)
+		}
+		filter.To = &end
+	}
+
+	if v.HasErrors() {
+		h.errorHandler.Handle(w, r, v.Errors())
+		return
+	}
+
+	entries, total, err := h.adminService.ListAuditLog(r.Context(), claims.UserID, claims.OrgID, filter)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	response := make([]AuditEntryDTO, 0, len(entries))
+	for _, entry := range entries {
+		response = append(response, toAuditEntryDTO(entry))
+	}
+
+	WritePaginated(w, response, pagination.Limit, pagination.Offset, total)
+}
+
+// parseAnalyticsRange reads the analytics date range from the query string.
+// Explicit from/to dates take precedence; a missing end defaults to today
+// and a missing start to the given number of days before the end.
+func parseAnalyticsRange(r *http.Request) (domain.AnalyticsRange, error) {
+	days := validation.ParseIntQueryParam(r, "days", domain.DefaultAnalyticsDays)
+
+	v := validation.NewValidator()
+	from, fromErr := validation.ParseTimeQueryParam(r, "from")
+	v.Custom("from", fromErr == nil, "Invalid start date")
+	to, toErr := validation.ParseTimeQueryParam(r, "to")
+	v.Custom("to", toErr == nil, "Invalid end date")
+	if v.HasErrors() {
+		return domain.AnalyticsRange{}, v.Errors()
+	}
+
+	if from == nil && to == nil {
+		return domain.LastDaysRange(time.Now(), days)
+	}
+
+	end := time.Now()
+	if to != nil {
+		end = to.Time
+	}
+	if from == nil {
+		return domain.LastDaysRange(end, days)
+	}
+	return domain.NewAnalyticsRange(from.Time, end)
+}
+
 // UserSummaryDTO defines the admin list representation for a user.
 type UserSummaryDTO struct {
 	ID           string   `json:"id"`
@@ -220,17 +597,89 @@ type WorkloadItemDTO struct {
 	Count      int64   `json:"count"`
 }
 
+type TeamWorkloadDTO struct {
+	TeamID      string `json:"teamId"`
+	Name        string `json:"name"`
+	MemberCount int64  `json:"memberCount"`
+	OpenCount   int64  `json:"openCount"`
+	QueuedCount int64  `json:"queuedCount"`
+}
+
 type VolumePointDTO struct {
 	Day           string `json:"day"`
 	CreatedCount  int64  `json:"createdCount"`
 	ResolvedCount int64  `json:"resolvedCount"`
 }
 
+type PriorityVolumeDTO struct {
+	Priority      string `json:"priority"`
+	CreatedCount  int64  `json:"createdCount"`
+	ResolvedCount int64  `json:"resolvedCount"`
+}
+
+type ResponseTimeStatsDTO struct {
+	Count        int64   `json:"count"`
+	AverageHours float64 `json:"averageHours"`
+	MedianHours  float64 `json:"medianHours"`
+	P90Hours     float64 `json:"p90Hours"`
+}
+
+type AgentResponseTimeDTO struct {
+	AgentID  string `json:"agentId"`
+	FullName string `json:"fullName"`
+	Email    string `json:"email"`
+	ResponseTimeStatsDTO
+}
+
 type AnalyticsOverviewResponse struct {
-	StatusCounts []StatusCountDTO  `json:"statusCounts"`
-	Workload     []WorkloadItemDTO `json:"workload"`
-	Volume       []VolumePointDTO  `json:"volume"`
-	MTTRHours    float64           `json:"mttrHours"`
+	From               string                 `json:"from"`
+	To                 string                 `json:"to"`
+	StatusCounts       []StatusCountDTO       `json:"statusCounts"`
+	Workload           []WorkloadItemDTO      `json:"workload"`
+	TeamWorkload       []TeamWorkloadDTO      `json:"teamWorkload"`
+	Volume             []VolumePointDTO       `json:"volume"`
+	VolumeByPriority   []PriorityVolumeDTO    `json:"volumeByPriority"`
+	MTTRHours          float64                `json:"mttrHours"`
+	FirstResponse      ResponseTimeStatsDTO   `json:"firstResponse"`
+	AgentFirstResponse []AgentResponseTimeDTO `json:"agentFirstResponse"`
+}
+
+// AuditEntryDTO defines the JSON representation of an audit log entry.
+// ImpersonatorID names the super-admin who acted as ActorID, if any.
+type AuditEntryDTO struct {
+	ID             int64           `json:"id"`
+	ActorID        string          `json:"actorId"`
+	ImpersonatorID *string         `json:"impersonatorId"`
+	Action         string          `json:"action"`
+	TargetType     string          `json:"targetType"`
+	TargetID       string          `json:"targetId"`
+	Before         json.RawMessage `json:"before"`
+	After          json.RawMessage `json:"after"`
+	RequestID      string          `json:"requestId"`
+	CreatedAt      string          `json:"createdAt"`
+}
+
+// UserImportResultDTO reports the outcome of one imported row.
+type UserImportResultDTO struct {
+	Line              int    `json:"line"`
+	Email             string `json:"email"`
+	Status            string `json:"status"`
+	UserID            string `json:"userId,omitempty"`
+	Role              string `json:"role,omitempty"`
+	TemporaryPassword string `json:"temporaryPassword,omitempty"`
+	Error             string `json:"error,omitempty"`
+}
+
+type UserImportReportDTO struct {
+	Created int                   `json:"created"`
+	Failed  int                   `json:"failed"`
+	Results []UserImportResultDTO `json:"results"`
+}
+
+type OffboardResultDTO struct {
+	UserID       string  `json:"userId"`
+	ReassignedTo *string `json:"reassignedTo"`
+	TicketIDs    []int64 `json:"ticketIds"`
 }
 
 type ResetPasswordResponse struct {
@@ -255,6 +704,74 @@ func toUserSummaryDTO(user *domain.UserSummary) UserSummaryDTO {
 	}
 }
 
+func toOffboardResultDTO(result *domain.OffboardResult) OffboardResultDTO {
+	dto := OffboardResultDTO{
+		UserID:    result.UserID.String(),
+		TicketIDs: result.TicketIDs,
+	}
+	if result.ReassignedTo != nil {
+		value := result.ReassignedTo.String()
+		dto.ReassignedTo = &value
+	}
+	return dto
+}
+
+func toUserImportReportDTO(report *domain.UserImportReport) UserImportReportDTO {
+	results := make([]UserImportResultDTO, len(report.Results))
+	for i, result := range report.Results {
+		dto := UserImportResultDTO{
+			Line:  result.Line,
+			Email: result.Email,
+			Error: result.Error,
+		}
+		if result.Error == "" {
+			dto.Status = "created"
+			dto.UserID = result.UserID.String()
+			dto.Role = result.Role
+			dto.TemporaryPassword = result.TemporaryPassword
+		} else {
+			dto.Status = "failed"
+		}
+		results[i] = dto
+	}
+
+	return UserImportReportDTO{
+		Created: report.Created,
+		Failed:  report.Failed,
+		Results: results,
+	}
+}
+
+func toAuditEntryDTO(entry *domain.AuditEntry) AuditEntryDTO {
+	var impersonatorID *string
+	if entry.ImpersonatorID != nil {
+		value := entry.ImpersonatorID.String()
+		impersonatorID = &value
+	}
+
+	return AuditEntryDTO{
+		ID:             entry.ID,
+		ActorID:        entry.ActorID.String(),
+		ImpersonatorID: impersonatorID,
+		Action:         string(entry.Action),
+		TargetType:     entry.TargetType,
+		TargetID:       entry.TargetID,
+		Before:         nullJSON(entry.Before),
+		After:          nullJSON(entry.After),
+		RequestID:      entry.RequestID,
+		CreatedAt:      entry.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// nullJSON renders missing JSON as null rather than an empty value, which
+// would not encode.
+func nullJSON(value json.RawMessage) json.RawMessage {
+	if len(value) == 0 {
+		return json.RawMessage("null")
+	}
+	return value
+}
+
 func toAnalyticsOverviewResponse(overview *domain.AnalyticsOverview) AnalyticsOverviewResponse {
 	statusCounts := make([]StatusCountDTO, 0, len(overview.StatusCounts))
 	for _, count := range overview.StatusCounts {
@@ -279,6 +796,17 @@ func toAnalyticsOverviewResponse(overview *domain.AnalyticsOverview) AnalyticsOv
 		})
 	}
 
+	teamWorkload := make([]TeamWorkloadDTO, 0, len(overview.TeamWorkload))
+	for _, team := range overview.TeamWorkload {
+		teamWorkload = append(teamWorkload, TeamWorkloadDTO{
+			TeamID:      team.TeamID.String(),
+			Name:        team.Name,
+			MemberCount: team.MemberCount,
+			OpenCount:   team.OpenCount,
+			QueuedCount: team.QueuedCount,
+		})
+	}
+
 	volume := make([]VolumePointDTO, 0, len(overview.Volume))
 	for _, point := range overview.Volume {
 		volume = append(volume, VolumePointDTO{
@@ -288,11 +816,45 @@ func toAnalyticsOverviewResponse(overview *domain.AnalyticsOverview) AnalyticsOv
 		})
 	}
 
+	volumeByPriority := make([]PriorityVolumeDTO, 0, len(overview.VolumeByPriority))
+	for _, volume := range overview.VolumeByPriority {
+		volumeByPriority = append(volumeByPriority, PriorityVolumeDTO{
+			Priority:      volume.Priority.String(),
+			CreatedCount:  volume.CreatedCount,
+			ResolvedCount: volume.ResolvedCount,
+		})
+	}
+
+	agentFirstResponse := make([]AgentResponseTimeDTO, 0, len(overview.AgentFirstResponse))
+	for _, agent := range overview.AgentFirstResponse {
+		agentFirstResponse = append(agentFirstResponse, AgentResponseTimeDTO{
+			AgentID:              agent.AgentID.String(),
+			FullName:             agent.FullName,
+			Email:                agent.Email,
+			ResponseTimeStatsDTO: toResponseTimeStatsDTO(agent.ResponseTimeStats),
+		})
+	}
+
 	return AnalyticsOverviewResponse{
-		StatusCounts: statusCounts,
-		Workload:     workload,
-		Volume:       volume,
-		MTTRHours:    overview.MTTRHours,
+		From:               overview.Range.From.Format("2006-01-02"),
+		To:                 overview.Range.To.Format("2006-01-02"),
+		StatusCounts:       statusCounts,
+		Workload:           workload,
+		TeamWorkload:       teamWorkload,
+		Volume:             volume,
+		VolumeByPriority:   volumeByPriority,
+		MTTRHours:          overview.MTTRHours,
+		FirstResponse:      toResponseTimeStatsDTO(overview.FirstResponse),
+		AgentFirstResponse: agentFirstResponse,
+	}
+}
+
+func toResponseTimeStatsDTO(stats domain.ResponseTimeStats) ResponseTimeStatsDTO {
+	return ResponseTimeStatsDTO{
+		Count:        stats.Count,
+		AverageHours: stats.AverageHours,
+		MedianHours:  stats.MedianHours,
+		P90Hours:     stats.P90Hours,
 	}
 }
 
@@ -3,11 +3,13 @@ package http
 import (
 	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"io"
 	"log/slog"
 	stdhttp "net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -33,7 +35,7 @@ func TestAdminUsersList(t *testing.T) {
 
 	userRepo := pgadapter.NewUserRepository(testPool)
 	authRepo := pgadapter.NewAuthorizationRepository(testPool)
-	authService := services.NewAuthService(userRepo, authRepo, orgID)
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), orgID)
 
 	agent := registerUser(t, ctx, authService, "Agent User", "agent-"+uuid.NewString()+"@example.com", "agent", orgID)
 	customer := registerUser(t, ctx, authService, "Customer User", "customer-"+uuid.NewString()+"@example.com", "customer", orgID)
@@ -47,17 +49,82 @@ func TestAdminUsersList(t *testing.T) {
 
 	require.Equal(t, stdhttp.StatusOK, recorder.Code)
 
-	var response struct {
-		Data  []UserSummaryDTO `json:"data"`
-		Count int              `json:"count"`
-	}
+	var response PaginatedResponse[UserSummaryDTO]
 	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&response))
 
+	assert.Equal(t, int64(3), response.Pagination.TotalCount)
 	assertUserInList(t, response.Data, admin.ID, "admin")
 	assertUserInList(t, response.Data, agent.ID, "agent")
 	assertUserInList(t, response.Data, customer.ID, "customer")
 }
 
+func TestAdminUsersList_Filters(t *testing.T) {
+	ctx := context.Background()
+	orgID := createTestOrganization(t, ctx)
+
+	_, token := createAdminAndToken(t, ctx, orgID)
+
+	userRepo := pgadapter.NewUserRepository(testPool)
+	authRepo := pgadapter.NewAuthorizationRepository(testPool)
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), orgID)
+
+	alice := registerUser(t, ctx, authService, "Alice Agent", "alice-"+uuid.NewString()+"@example.com", "agent", orgID)
+	_ = registerUser(t, ctx, authService, "Bob Agent", "bob-"+uuid.NewString()+"@example.com", "agent", orgID)
+	carol := registerUser(t, ctx, authService, "Carol Customer", "carol-"+uuid.NewString()+"@example.com", "customer", orgID)
+	require.NoError(t, userRepo.Anonymize(ctx, carol.ID, time.Now()))
+
+	router, _ := newAdminRouter()
+	list := func(query string) (int, PaginatedResponse[UserSummaryDTO]) {
+		req := httptest.NewRequest(stdhttp.MethodGet, "/admin/users"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+
+		var response PaginatedResponse[UserSummaryDTO]
+		if recorder.Code == stdhttp.StatusOK {
+			require.NoError(t, json.NewDecoder(recorder.Body).Decode(&response))
+		}
+		return recorder.Code, response
+	}
+
+	t.Run("search by name", func(t *testing.T) {
+		code, response := list("?q=alice")
+		require.Equal(t, stdhttp.StatusOK, code)
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, alice.ID.String(), response.Data[0].ID)
+		assert.Equal(t, int64(1), response.Pagination.TotalCount)
+	})
+
+	t.Run("role filter", func(t *testing.T) {
+		code, response := list("?role=agent")
+		require.Equal(t, stdhttp.StatusOK, code)
+		assert.Equal(t, int64(2), response.Pagination.TotalCount)
+	})
+
+	t.Run("active filter", func(t *testing.T) {
+		code, response := list("?active=false")
+		require.Equal(t, stdhttp.StatusOK, code)
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, carol.ID.String(), response.Data[0].ID)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		code, response := list("?limit=2&offset=0")
+		require.Equal(t, stdhttp.StatusOK, code)
+		assert.Len(t, response.Data, 2)
+		assert.Equal(t, int64(4), response.Pagination.TotalCount)
+		assert.True(t, response.Pagination.HasMore)
+	})
+
+	t.Run("invalid filters", func(t *testing.T) {
+		code, _ := list("?role=owner")
+		assert.Equal(t, stdhttp.StatusUnprocessableEntity, code)
+
+		code, _ = list("?active=maybe")
+		assert.Equal(t, stdhttp.StatusUnprocessableEntity, code)
+	})
+}
+
 func TestAdminUsersList_Forbidden(t *testing.T) {
 	ctx := context.Background()
 	orgID := createTestOrganization(t, ctx)
@@ -66,7 +133,7 @@ func TestAdminUsersList_Forbidden(t *testing.T) {
 
 	userRepo := pgadapter.NewUserRepository(testPool)
 	authRepo := pgadapter.NewAuthorizationRepository(testPool)
-	authService := services.NewAuthService(userRepo, authRepo, orgID)
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), orgID)
 
 	customer := registerUser(t, ctx, authService, "Customer User", "customer-"+uuid.NewString()+"@example.com", "customer", orgID)
 
@@ -91,7 +158,7 @@ func TestAdminUpdateUserRole(t *testing.T) {
 
 	userRepo := pgadapter.NewUserRepository(testPool)
 	authRepo := pgadapter.NewAuthorizationRepository(testPool)
-	authService := services.NewAuthService(userRepo, authRepo, orgID)
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), orgID)
 
 	target := registerUser(t, ctx, authService, "Target User", "target-"+uuid.NewString()+"@example.com", "customer", orgID)
 
@@ -121,6 +188,47 @@ func TestAdminUpdateUserRole(t *testing.T) {
 	assertUserInList(t, response.Data, target.ID, "agent")
 }
 
+func TestAdminAuditLog(t *testing.T) {
+	ctx := context.Background()
+	orgID := createTestOrganization(t, ctx)
+
+	admin, token := createAdminAndToken(t, ctx, orgID)
+
+	userRepo := pgadapter.NewUserRepository(testPool)
+	authRepo := pgadapter.NewAuthorizationRepository(testPool)
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), orgID)
+
+	target := registerUser(t, ctx, authService, "Target User", "target-"+uuid.NewString()+"@example.com", "customer", orgID)
+
+	router, _ := newAdminRouter()
+	req := httptest.NewRequest(stdhttp.MethodPatch, "/admin/users/"+target.ID.String()+"/role", bytes.NewReader([]byte(`{"role":"agent"}`)))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(mw.RequestIDHeader, "req-audit-1")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, stdhttp.StatusNoContent, recorder.Code)
+
+	listReq := httptest.NewRequest(stdhttp.MethodGet, "/admin/audit-log?action=user.role_changed&targetId="+target.ID.String(), nil)
+	listReq.Header.Set("Authorization", "Bearer "+token)
+	listRecorder := httptest.NewRecorder()
+
+	router.ServeHTTP(listRecorder, listReq)
+	require.Equal(t, stdhttp.StatusOK, listRecorder.Code)
+
+	var response PaginatedResponse[AuditEntryDTO]
+	require.NoError(t, json.NewDecoder(listRecorder.Body).Decode(&response))
+
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, int64(1), response.Pagination.TotalCount)
+	entry := response.Data[0]
+	assert.Equal(t, admin.ID.String(), entry.ActorID)
+	assert.Equal(t, "req-audit-1", entry.RequestID)
+	assert.JSONEq(t, `{"roles":["customer"]}`, string(entry.Before))
+	assert.JSONEq(t, `{"roles":["agent"]}`, string(entry.After))
+}
+
 func TestAdminUpdateUserStatus(t *testing.T) {
 	ctx := context.Background()
 	orgID := createTestOrganization(t, ctx)
@@ -129,7 +237,7 @@ func TestAdminUpdateUserStatus(t *testing.T) {
 
 	userRepo := pgadapter.NewUserRepository(testPool)
 	authRepo := pgadapter.NewAuthorizationRepository(testPool)
-	authService := services.NewAuthService(userRepo, authRepo, orgID)
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), orgID)
 
 	target := registerUser(t, ctx, authService, "Inactive User", "inactive-"+uuid.NewString()+"@example.com", "customer", orgID)
 
@@ -168,7 +276,7 @@ func TestAdminResetPassword(t *testing.T) {
 
 	userRepo := pgadapter.NewUserRepository(testPool)
 	authRepo := pgadapter.NewAuthorizationRepository(testPool)
-	authService := services.NewAuthService(userRepo, authRepo, orgID)
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), orgID)
 
 	target := registerUser(t, ctx, authService, "Reset User", "reset-"+uuid.NewString()+"@example.com", "customer", orgID)
 
@@ -184,10 +292,10 @@ func TestAdminResetPassword(t *testing.T) {
 	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&response))
 	require.NotEmpty(t, response.TemporaryPassword)
 
-	_, err := authService.Login(ctx, target.Email, "Password1")
+	_, err := authService.Login(ctx, orgID, target.Email, "Password1")
 	assert.ErrorIs(t, err, apperrors.ErrInvalidCredentials)
 
-	user, err := authService.Login(ctx, target.Email, response.TemporaryPassword)
+	user, err := authService.Login(ctx, orgID, target.Email, response.TemporaryPassword)
 	require.NoError(t, err)
 	assert.Equal(t, target.ID, user.ID)
 }
@@ -200,7 +308,7 @@ func TestAdminResetPassword_Forbidden(t *testing.T) {
 
 	userRepo := pgadapter.NewUserRepository(testPool)
 	authRepo := pgadapter.NewAuthorizationRepository(testPool)
-	authService := services.NewAuthService(userRepo, authRepo, orgID)
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), orgID)
 
 	customer := registerUser(t, ctx, authService, "Customer User", "customer-"+uuid.NewString()+"@example.com", "customer", orgID)
 	target := registerUser(t, ctx, authService, "Target User", "target-"+uuid.NewString()+"@example.com", "customer", orgID)
@@ -217,6 +325,250 @@ func TestAdminResetPassword_Forbidden(t *testing.T) {
 	require.Equal(t, stdhttp.StatusForbidden, recorder.Code)
 }
 
+func TestAdminOffboardUser(t *testing.T) {
+	ctx := context.Background()
+	orgID := createTestOrganization(t, ctx)
+
+	admin, token := createAdminAndToken(t, ctx, orgID)
+
+	userRepo := pgadapter.NewUserRepository(testPool)
+	authRepo := pgadapter.NewAuthorizationRepository(testPool)
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), orgID)
+	ticketRepo := pgadapter.NewTicketRepository(testPool)
+
+	leaving := registerUser(t, ctx, authService, "Leaving Agent", "leaving-"+uuid.NewString()+"@example.com", "agent", orgID)
+	successor := registerUser(t, ctx, authService, "Successor Agent", "successor-"+uuid.NewString()+"@example.com", "agent", orgID)
+	customer := registerUser(t, ctx, authService, "Customer User", "customer-"+uuid.NewString()+"@example.com", "customer", orgID)
+
+	assignTo := func(title string, assigneeID uuid.UUID) *domain.Ticket {
+		ticket := createTicket(t, ctx, ticketRepo, customer, title)
+		require.NoError(t, ticket.Assign(assigneeID))
+		updated, err := ticketRepo.Update(ctx, ticket)
+		require.NoError(t, err)
+		return updated
+	}
+	open := assignTo("Open ticket", leaving.ID)
+	closed := assignTo("Closed ticket", leaving.ID)
+	require.NoError(t, closed.UpdateStatus(domain.StatusClosed))
+	_, err := ticketRepo.Update(ctx, closed)
+	require.NoError(t, err)
+
+	router, _ := newAdminRouter()
+	offboard := func(userID uuid.UUID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(stdhttp.MethodPost, "/admin/users/"+userID.String()+"/offboard", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("rejects a customer as the new assignee", func(t *testing.T) {
+		recorder := offboard(leaving.ID, `{"reassignTo":"`+customer.ID.String()+`"}`)
+		require.Equal(t, stdhttp.StatusUnprocessableEntity, recorder.Code)
+
+		stillActive, err := userRepo.GetByID(ctx, leaving.ID)
+		require.NoError(t, err)
+		assert.True(t, stillActive.IsActive)
+	})
+
+	t.Run("reassigns open tickets", func(t *testing.T) {
+		recorder := offboard(leaving.ID, `{"reassignTo":"`+successor.ID.String()+`"}`)
+		require.Equal(t, stdhttp.StatusOK, recorder.Code)
+
+		var response OffboardResultDTO
+		require.NoError(t, json.NewDecoder(recorder.Body).Decode(&response))
+		assert.Equal(t, []int64{open.ID}, response.TicketIDs)
+		require.NotNil(t, response.ReassignedTo)
+		assert.Equal(t, successor.ID.String(), *response.ReassignedTo)
+
+		deactivated, err := userRepo.GetByID(ctx, leaving.ID)
+		require.NoError(t, err)
+		assert.False(t, deactivated.IsActive)
+
+		reassigned, err := ticketRepo.GetByID(ctx, orgID, open.ID)
+		require.NoError(t, err)
+		assert.True(t, reassigned.IsAssignedTo(successor.ID))
+
+		untouched, err := ticketRepo.GetByID(ctx, orgID, closed.ID)
+		require.NoError(t, err)
+		assert.True(t, untouched.IsAssignedTo(leaving.ID))
+
+		var queued int
+		require.NoError(t, testPool.QueryRow(ctx,
+			"SELECT COUNT(*) FROM notification_outbox WHERE recipient_user_id = $1 AND ticket_id = $2",
+			successor.ID, open.ID).Scan(&queued))
+		assert.Equal(t, 1, queued)
+
+		action := domain.AuditUserOffboarded
+		entries, _, err := pgadapter.NewAuditLogRepository(testPool).List(ctx, orgID, domain.AuditLogFilter{
+			Action: &action,
+			Limit:  10,
+		})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, admin.ID, entries[0].ActorID)
+	})
+
+	t.Run("unassigns open tickets", func(t *testing.T) {
+		ticket := assignTo("Another open ticket", successor.ID)
+
+		recorder := offboard(successor.ID, `{}`)
+		require.Equal(t, stdhttp.StatusOK, recorder.Code)
+
+		unassigned, err := ticketRepo.GetByID(ctx, orgID, ticket.ID)
+		require.NoError(t, err)
+		assert.Nil(t, unassigned.AssigneeID)
+	})
+}
+
+func TestAdminForceLogout(t *testing.T) {
+	ctx := context.Background()
+	orgID := createTestOrganization(t, ctx)
+
+	admin, token := createAdminAndToken(t, ctx, orgID)
+
+	userRepo := pgadapter.NewUserRepository(testPool)
+	authRepo := pgadapter.NewAuthorizationRepository(testPool)
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), orgID)
+
+	target := registerUser(t, ctx, authService, "Target User", "target-"+uuid.NewString()+"@example.com", "agent", orgID)
+	issuedAt := time.Now().Add(-time.Minute)
+	_, err := authService.ValidateSession(ctx, target.ID, issuedAt)
+	require.NoError(t, err)
+
+	router, _ := newAdminRouter()
+	req := httptest.NewRequest(stdhttp.MethodPost, "/admin/users/"+target.ID.String()+"/force-logout", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, stdhttp.StatusNoContent, recorder.Code)
+
+	_, err = authService.ValidateSession(ctx, target.ID, issuedAt)
+	assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+
+	stored, err := userRepo.GetByID(ctx, target.ID)
+	require.NoError(t, err)
+	assert.True(t, stored.IsActive)
+
+	action := domain.AuditUserForcedLogout
+	entries, _, err := pgadapter.NewAuditLogRepository(testPool).List(ctx, orgID, domain.AuditLogFilter{
+		Action: &action,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, admin.ID, entries[0].ActorID)
+	assert.Equal(t, target.ID.String(), entries[0].TargetID)
+}
+
+func TestAdminDeleteUser(t *testing.T) {
+	ctx := context.Background()
+	orgID := createTestOrganization(t, ctx)
+
+	admin, token := createAdminAndToken(t, ctx, orgID)
+
+	userRepo := pgadapter.NewUserRepository(testPool)
+	authRepo := pgadapter.NewAuthorizationRepository(testPool)
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), orgID)
+	ticketRepo := pgadapter.NewTicketRepository(testPool)
+
+	target := registerUser(t, ctx, authService, "Target User", "target-"+uuid.NewString()+"@example.com", "customer", orgID)
+	ticket := createTicket(t, ctx, ticketRepo, target, "Ticket from a departing user")
+
+	router, _ := newAdminRouter()
+	req := httptest.NewRequest(stdhttp.MethodDelete, "/admin/users/"+target.ID.String(), nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, stdhttp.StatusNoContent, recorder.Code)
+
+	anonymized, err := userRepo.GetByID(ctx, target.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.AnonymizedUserName, anonymized.FullName)
+	assert.Equal(t, domain.AnonymizedEmail(target.ID), anonymized.Email)
+	assert.False(t, anonymized.IsActive)
+	assert.NotNil(t, anonymized.TokensRevokedAt)
+
+	stored, err := ticketRepo.GetByID(ctx, orgID, ticket.ID)
+	require.NoError(t, err)
+	assert.Equal(t, target.ID, stored.RequesterID)
+
+	action := domain.AuditUserAnonymized
+	entries, _, err := pgadapter.NewAuditLogRepository(testPool).List(ctx, orgID, domain.AuditLogFilter{
+		Action: &action,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, admin.ID, entries[0].ActorID)
+	assert.Equal(t, target.ID.String(), entries[0].TargetID)
+
+	selfReq := httptest.NewRequest(stdhttp.MethodDelete, "/admin/users/"+admin.ID.String(), nil)
+	selfReq.Header.Set("Authorization", "Bearer "+token)
+	selfRecorder := httptest.NewRecorder()
+
+	router.ServeHTTP(selfRecorder, selfReq)
+	require.Equal(t, stdhttp.StatusForbidden, selfRecorder.Code)
+}
+
+func TestAdminImportUsers(t *testing.T) {
+	ctx := context.Background()
+	orgID := createTestOrganization(t, ctx)
+
+	_, token := createAdminAndToken(t, ctx, orgID)
+
+	userRepo := pgadapter.NewUserRepository(testPool)
+	authRepo := pgadapter.NewAuthorizationRepository(testPool)
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), orgID)
+
+	existing := registerUser(t, ctx, authService, "Existing User", "existing-"+uuid.NewString()+"@example.com", "customer", orgID)
+	agentEmail := "agent-" + uuid.NewString() + "@example.com"
+	customerEmail := "customer-" + uuid.NewString() + "@example.com"
+
+	csvBody := "email,name,role\n" +
+		agentEmail + ",Agent User,agent\n" +
+		customerEmail + ",Customer User,\n" +
+		existing.Email + ",Existing Again,agent\n" +
+		"not-an-email,Broken User,agent\n" +
+		agentEmail + ",Agent Twice,agent\n"
+
+	router, _ := newAdminRouter()
+	req := httptest.NewRequest(stdhttp.MethodPost, "/admin/users/import", strings.NewReader(csvBody))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "text/csv")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, stdhttp.StatusOK, recorder.Code)
+
+	var report UserImportReportDTO
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&report))
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, 3, report.Failed)
+	require.Len(t, report.Results, 5)
+
+	assert.Equal(t, "created", report.Results[0].Status)
+	assert.Equal(t, 2, report.Results[0].Line)
+	assert.NotEmpty(t, report.Results[0].TemporaryPassword)
+	assert.Equal(t, "customer", report.Results[1].Role)
+	assert.Equal(t, "failed", report.Results[2].Status)
+	assert.Equal(t, "failed", report.Results[3].Status)
+	assert.Contains(t, report.Results[4].Error, "line 2")
+
+	_, err := authService.Login(ctx, orgID, agentEmail, report.Results[0].TemporaryPassword)
+	require.NoError(t, err)
+
+	badReq := httptest.NewRequest(stdhttp.MethodPost, "/admin/users/import", strings.NewReader("foo,bar\n1,2\n"))
+	badReq.Header.Set("Authorization", "Bearer "+token)
+	badRecorder := httptest.NewRecorder()
+
+	router.ServeHTTP(badRecorder, badReq)
+	require.Equal(t, stdhttp.StatusUnprocessableEntity, badRecorder.Code)
+}
+
 func TestAdminAnalyticsOverview(t *testing.T) {
 	ctx := context.Background()
 	orgID := createTestOrganization(t, ctx)
@@ -225,22 +577,27 @@ func TestAdminAnalyticsOverview(t *testing.T) {
 
 	userRepo := pgadapter.NewUserRepository(testPool)
 	authRepo := pgadapter.NewAuthorizationRepository(testPool)
-	authService := services.NewAuthService(userRepo, authRepo, orgID)
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), orgID)
 
 	agent := registerUser(t, ctx, authService, "Agent User", "agent-"+uuid.NewString()+"@example.com", "agent", orgID)
 	customer := registerUser(t, ctx, authService, "Customer User", "customer-"+uuid.NewString()+"@example.com", "customer", orgID)
 
 	ticketRepo := pgadapter.NewTicketRepository(testPool)
 
-	openTicket := createTicket(t, ctx, ticketRepo, customer.ID, "Open Ticket")
+	openTicket := createTicket(t, ctx, ticketRepo, customer, "Open Ticket")
 	assert.Equal(t, domain.StatusOpen, openTicket.Status)
 
-	closedTicket := createTicket(t, ctx, ticketRepo, customer.ID, "Closed Ticket")
+	closedTicket := createTicket(t, ctx, ticketRepo, customer, "Closed Ticket")
 	require.NoError(t, closedTicket.Assign(agent.ID))
 	require.NoError(t, closedTicket.UpdateStatus(domain.StatusClosed))
 	_, err := ticketRepo.Update(ctx, closedTicket)
 	require.NoError(t, err)
 
+	commentRepo := pgadapter.NewCommentRepository(testPool)
+	reply, err := commentRepo.Create(ctx, &domain.Comment{TicketID: openTicket.ID, AuthorID: agent.ID, Body: "On it"})
+	require.NoError(t, err)
+	require.NoError(t, commentRepo.MarkFirstResponse(ctx, reply))
+
 	router, _ := newAdminRouter()
 	req := httptest.NewRequest(stdhttp.MethodGet, "/admin/analytics/overview?days=7", nil)
 	req.Header.Set("Authorization", "Bearer "+token)
@@ -259,9 +616,86 @@ func TestAdminAnalyticsOverview(t *testing.T) {
 	assertWorkloadUnassigned(t, response.Workload, 1)
 	assert.GreaterOrEqual(t, response.MTTRHours, 0.0)
 
+	assert.Equal(t, int64(1), response.FirstResponse.Count)
+	assert.GreaterOrEqual(t, response.FirstResponse.P90Hours, 0.0)
+	require.Len(t, response.AgentFirstResponse, 1)
+	assert.Equal(t, agent.ID.String(), response.AgentFirstResponse[0].AgentID)
+
 	createdTotal, resolvedTotal := sumVolume(response.Volume)
 	assert.Equal(t, int64(2), createdTotal)
 	assert.Equal(t, int64(1), resolvedTotal)
+
+	require.Len(t, response.VolumeByPriority, 3)
+	var priorityCreated, priorityResolved int64
+	for _, volume := range response.VolumeByPriority {
+		priorityCreated += volume.CreatedCount
+		priorityResolved += volume.ResolvedCount
+	}
+	assert.Equal(t, createdTotal, priorityCreated)
+	assert.Equal(t, resolvedTotal, priorityResolved)
+}
+
+func TestAdminAnalyticsOverview_DateRange(t *testing.T) {
+	ctx := context.Background()
+	orgID := createTestOrganization(t, ctx)
+
+	_, token := createAdminAndToken(t, ctx, orgID)
+	router, _ := newAdminRouter()
+
+	req := httptest.NewRequest(stdhttp.MethodGet, "/admin/analytics/overview?from=2026-01-01&to=2026-01-31", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, stdhttp.StatusOK, recorder.Code)
+
+	var response AnalyticsOverviewResponse
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&response))
+	assert.Equal(t, "2026-01-01", response.From)
+	assert.Equal(t, "2026-01-31", response.To)
+	assert.Len(t, response.Volume, 31)
+
+	for _, query := range []string{"from=2026-02-01&to=2026-01-01", "from=2024-01-01&to=2026-01-01", "from=yesterday"} {
+		req := httptest.NewRequest(stdhttp.MethodGet, "/admin/analytics/overview?"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+		assert.Equal(t, stdhttp.StatusBadRequest, recorder.Code, query)
+	}
+}
+
+func TestAdminAnalyticsExport(t *testing.T) {
+	ctx := context.Background()
+	orgID := createTestOrganization(t, ctx)
+
+	_, token := createAdminAndToken(t, ctx, orgID)
+
+	userRepo := pgadapter.NewUserRepository(testPool)
+	authRepo := pgadapter.NewAuthorizationRepository(testPool)
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), orgID)
+	customer := registerUser(t, ctx, authService, "Customer User", "customer-"+uuid.NewString()+"@example.com", "customer", orgID)
+
+	createTicket(t, ctx, pgadapter.NewTicketRepository(testPool), customer, "Open Ticket")
+
+	router, _ := newAdminRouter()
+	req := httptest.NewRequest(stdhttp.MethodGet, "/admin/analytics/export?days=7", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, stdhttp.StatusOK, recorder.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Header().Get("Content-Disposition"), "attachment")
+
+	reader := csv.NewReader(recorder.Body)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	require.NoError(t, err)
+
+	assert.Contains(t, records, []string{"status_OPEN", "1"})
+	assert.Contains(t, records, []string{"day", "created", "resolved"})
+	assert.Contains(t, records, []string{"assignee_id", "full_name", "email", "open_tickets"})
 }
 
 func newAdminRouter() (*chi.Mux, *auth.TokenManager) {
@@ -269,19 +703,32 @@ func newAdminRouter() (*chi.Mux, *auth.TokenManager) {
 	userRepo := pgadapter.NewUserRepository(testPool)
 	analyticsRepo := pgadapter.NewAnalyticsRepository(testPool)
 	authzService := services.NewAuthorizationService(authRepo)
-	adminService := services.NewAdminService(userRepo, authRepo, authzService, analyticsRepo)
+	auditRepo := pgadapter.NewAuditLogRepository(testPool)
+	txManager := pgadapter.NewTransactionManager(testPool)
+	ticketRepo := pgadapter.NewTicketRepository(testPool)
+	eventRepo := pgadapter.NewTicketEventRepository(testPool)
+	outboxRepo := pgadapter.NewNotificationOutboxRepository(testPool)
+	adminService := services.NewAdminService(userRepo, authRepo, authzService, ticketRepo, eventRepo, outboxRepo, analyticsRepo, auditRepo, newTestQuotaService(), txManager)
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	errorHandler := NewErrorHandler(logger)
-	adminHandler := NewAdminHandler(adminService, errorHandler, logger)
+	dataExportService := services.NewDataExportService(pgadapter.NewDataExportRepository(testPool), userRepo, authzService, auditRepo, txManager)
+	adminHandler := NewAdminHandler(adminService, dataExportService, errorHandler, logger)
 	tokenManager := auth.NewTokenManager("test-secret", time.Hour)
 
 	router := chi.NewRouter()
+	router.Use(mw.RequestID)
 	router.Use(mw.JWTMiddleware(tokenManager))
 	router.Route("/admin", adminHandler.RegisterRoutes)
 
 	return router, tokenManager
 }
 
+// newTestQuotaService returns a quota service without default limits.
+func newTestQuotaService() ports.QuotaService {
+	authzService := services.NewAuthorizationService(pgadapter.NewAuthorizationRepository(testPool))
+	return services.NewQuotaService(pgadapter.NewQuotaRepository(testPool), authzService, domain.OrgQuota{})
+}
+
 func createTestOrganization(t *testing.T, ctx context.Context) uuid.UUID {
 	orgID := uuid.New()
 	_, err := testPool.Exec(ctx, "INSERT INTO organizations (id, name) VALUES ($1, $2)", orgID, "Test Org "+orgID.String())
@@ -292,7 +739,7 @@ func createTestOrganization(t *testing.T, ctx context.Context) uuid.UUID {
 func createAdminAndToken(t *testing.T, ctx context.Context, orgID uuid.UUID) (*domain.User, string) {
 	userRepo := pgadapter.NewUserRepository(testPool)
 	authRepo := pgadapter.NewAuthorizationRepository(testPool)
-	authService := services.NewAuthService(userRepo, authRepo, orgID)
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), orgID)
 
 	admin := registerUser(t, ctx, authService, "Admin User", "admin-"+uuid.NewString()+"@example.com", "admin", orgID)
 
@@ -309,12 +756,13 @@ func registerUser(t *testing.T, ctx context.Context, authService ports.AuthServi
 	return user
 }
 
-func createTicket(t *testing.T, ctx context.Context, repo ports.TicketRepository, requesterID uuid.UUID, title string) *domain.Ticket {
+func createTicket(t *testing.T, ctx context.Context, repo ports.TicketRepository, requester *domain.User, title string) *domain.Ticket {
 	params := domain.TicketParams{
-		Title:       title,
-		Description: "Analytics test",
-		Priority:    domain.PriorityMedium,
-		RequesterID: requesterID,
+		Title:          title,
+		Description:    "Analytics test",
+		Priority:       domain.PriorityMedium,
+		RequesterID:    requester.ID,
+		OrganizationID: requester.OrganizationID,
 	}
 
 	ticket, err := domain.NewTicket(params)
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+)
+
+// writeAnalyticsCSV writes the analytics overview as CSV. Each table starts
+// with a title row and a header row, and tables are separated by a blank row
+// so the file opens cleanly in a spreadsheet.
+func writeAnalyticsCSV(w io.Writer, overview *domain.AnalyticsOverview) error {
+	cw := csv.NewWriter(w)
+
+	rows := [][]string{
+		{"Overview"},
+		{"metric", "value"},
+		{"from", overview.Range.From.Format("2006-01-02")},
+		{"to", overview.Range.To.Format("2006-01-02")},
+		{"mttr_hours", formatHours(overview.MTTRHours)},
+		{"first_response_count", strconv.FormatInt(overview.FirstResponse.Count, 10)},
+		{"first_response_avg_hours", formatHours(overview.FirstResponse.AverageHours)},
+		{"first_response_median_hours", formatHours(overview.FirstResponse.MedianHours)},
+		{"first_response_p90_hours", formatHours(overview.FirstResponse.P90Hours)},
+	}
+	for _, count := range overview.StatusCounts {
+		rows = append(rows, []string{"status_" + count.Status.String(), strconv.FormatInt(count.Count, 10)})
+	}
+
+	rows = append(rows, nil, []string{"Volume"}, []string{"day", "created", "resolved"})
+	for _, point := range overview.Volume {
+		rows = append(rows, []string{
+			point.Day.Format("2006-01-02"),
+			strconv.FormatInt(point.CreatedCount, 10),
+			strconv.FormatInt(point.ResolvedCount, 10),
+		})
+	}
+
+	rows = append(rows, nil, []string{"Volume by priority"}, []string{"priority", "created", "resolved"})
+	for _, volume := range overview.VolumeByPriority {
+		rows = append(rows, []string{
+			volume.Priority.String(),
+			strconv.FormatInt(volume.CreatedCount, 10),
+			strconv.FormatInt(volume.ResolvedCount, 10),
+		})
+	}
+
+	rows = append(rows, nil, []string{"Workload"}, []string{"assignee_id", "full_name", "email", "open_tickets"})
+	for _, item := range overview.Workload {
+		assigneeID := ""
+		if item.AssigneeID != nil {
+			assigneeID = item.AssigneeID.String()
+		}
+		rows = append(rows, []string{assigneeID, item.FullName, item.Email, strconv.FormatInt(item.Count, 10)})
+	}
+
+	rows = append(rows, nil, []string{"Team workload"}, []string{"team_id", "name", "members", "open_tickets", "queued_tickets"})
+	for _, team := range overview.TeamWorkload {
+		rows = append(rows, []string{
+			team.TeamID.String(),
+			team.Name,
+			strconv.FormatInt(team.MemberCount, 10),
+			strconv.FormatInt(team.OpenCount, 10),
+			strconv.FormatInt(team.QueuedCount, 10),
+		})
+	}
+
+	rows = append(rows, nil, []string{"First response by agent"},
+		[]string{"agent_id", "full_name", "email", "tickets", "avg_hours", "median_hours", "p90_hours"})
+	for _, agent := range overview.AgentFirstResponse {
+		rows = append(rows, []string{
+			agent.AgentID.String(),
+			agent.FullName,
+			agent.Email,
+			strconv.FormatInt(agent.Count, 10),
+			formatHours(agent.AverageHours),
+			formatHours(agent.MedianHours),
+			formatHours(agent.P90Hours),
+		})
+	}
+
+	for _, row := range rows {
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatHours(hours float64) string {
+	return strconv.FormatFloat(hours, 'f', 2, 64)
+}
@@ -3,21 +3,32 @@ package http
 import (
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 	mw "github.com/lorrc/service-desk-backend/internal/adapters/primary/http/middleware"
+	"github.com/lorrc/service-desk-backend/internal/adapters/primary/validation"
 	"github.com/lorrc/service-desk-backend/internal/auth"
 	"github.com/lorrc/service-desk-backend/internal/core/domain"
 	"github.com/lorrc/service-desk-backend/internal/core/ports"
 )
 
-// AssigneeDTO represents a user that can be assigned to tickets.
+// AssigneeDTO represents a user that can be assigned to tickets, with how
+// many open tickets they have. maxOpenTickets is the organization's limit,
+// null when it has none.
 type AssigneeDTO struct {
-	ID       string `json:"id"`
-	FullName string `json:"fullName"`
-	Email    string `json:"email"`
+	ID             string `json:"id"`
+	FullName       string `json:"fullName"`
+	Email          string `json:"email"`
+	OpenTickets    int64  `json:"openTickets"`
+	MaxOpenTickets *int   `json:"maxOpenTickets"`
+	AtCapacity     bool   `json:"atCapacity"`
 }
 
+// maxAssigneesPerPage caps the assignee list page size
+const maxAssigneesPerPage = 200
+
 // AssigneeHandler handles HTTP requests for assignable users.
 type AssigneeHandler struct {
 	assigneeService ports.AssigneeService
@@ -50,25 +61,64 @@ func (h *AssigneeHandler) HandleListAssignees(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	users, err := h.assigneeService.ListAssignableUsers(r.Context(), claims.UserID, claims.OrgID)
+	pagination := validation.ParsePagination(r, maxAssigneesPerPage)
+	filter := domain.AssigneeFilter{
+		Query:  r.URL.Query().Get("q"),
+		Limit:  pagination.Limit,
+		Offset: pagination.Offset,
+	}
+
+	v := validation.NewValidator()
+
+	if teamIDStr := r.URL.Query().Get("teamId"); teamIDStr != "" {
+		teamID, err := uuid.Parse(teamIDStr)
+		if err != nil {
+			v.Custom("teamId", false, "Must be a valid UUID")
+		} else {
+			filter.TeamID = &teamID
+		}
+	}
+
+	if availableStr := r.URL.Query().Get("available"); availableStr != "" {
+		available, err := strconv.ParseBool(availableStr)
+		if err != nil {
+			v.Custom("available", false, "Must be true or false")
+		} else {
+			filter.Available = &available
+		}
+	}
+
+	if v.HasErrors() {
+		h.errorHandler.Handle(w, r, v.Errors())
+		return
+	}
+
+	assignees, total, err := h.assigneeService.ListAssignableUsers(r.Context(), claims.UserID, claims.OrgID, filter)
 	if err != nil {
 		h.errorHandler.Handle(w, r, err)
 		return
 	}
 
-	WriteList(w, mapAssignees(users))
+	WritePaginated(w, mapAssignees(assignees), pagination.Limit, pagination.Offset, total)
 }
 
-func mapAssignees(users []*domain.User) []AssigneeDTO {
-	assignees := make([]AssigneeDTO, 0, len(users))
-	for _, user := range users {
-		assignees = append(assignees, AssigneeDTO{
-			ID:       user.ID.String(),
-			FullName: user.FullName,
-			Email:    user.Email,
-		})
+func mapAssignees(assignees []domain.Assignee) []AssigneeDTO {
+	dtos := make([]AssigneeDTO, 0, len(assignees))
+	for _, assignee := range assignees {
+		dtos = append(dtos, toAssigneeDTO(assignee))
+	}
+	return dtos
+}
+
+func toAssigneeDTO(assignee domain.Assignee) AssigneeDTO {
+	return AssigneeDTO{
+		ID:             assignee.User.ID.String(),
+		FullName:       assignee.User.FullName,
+		Email:          assignee.User.Email,
+		OpenTickets:    assignee.OpenTickets,
+		MaxOpenTickets: assignee.MaxOpenTickets,
+		AtCapacity:     assignee.AtCapacity(),
 	}
-	return assignees
 }
 
 // getClaims extracts and validates user claims from the request context.
@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	stdhttp "net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -21,17 +22,12 @@ import (
 	"github.com/lorrc/service-desk-backend/internal/core/services"
 )
 
-type assigneeListResponse struct {
-	Data  []AssigneeDTO `json:"data"`
-	Count int           `json:"count"`
-}
-
 func TestAssigneeList(t *testing.T) {
 	ctx := context.Background()
 	authRepo := pgadapter.NewAuthorizationRepository(testPool)
 	userRepo := pgadapter.NewUserRepository(testPool)
 	defaultOrgID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
-	authService := services.NewAuthService(userRepo, authRepo, defaultOrgID)
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), defaultOrgID)
 
 	adminEmail := uuid.NewString() + "@example.com"
 	adminUser, err := authService.Register(ctx, "Admin User", adminEmail, "Password1", "admin", uuid.Nil)
@@ -49,7 +45,18 @@ func TestAssigneeList(t *testing.T) {
 	token, err := tokenManager.GenerateToken(adminUser.ID, adminUser.OrganizationID)
 	require.NoError(t, err)
 
-	req := httptest.NewRequest(stdhttp.MethodGet, "/assignees", nil)
+	// Other tests add users to the default organization, so each user is
+	// looked up by their email
+	assertAssigneePresent(t, listAssignees(t, router, token, adminEmail), adminUser.ID)
+	assertAssigneePresent(t, listAssignees(t, router, token, agentEmail), agentUser.ID)
+	assertAssigneeMissing(t, listAssignees(t, router, token, customerEmail), customerUser.ID)
+	assertAssigneeMissing(t, listAssignees(t, router, token, agentEmail), adminUser.ID)
+}
+
+func listAssignees(t *testing.T, router stdhttp.Handler, token, query string) []AssigneeDTO {
+	t.Helper()
+
+	req := httptest.NewRequest(stdhttp.MethodGet, "/assignees?q="+url.QueryEscape(query), nil)
 	req.Header.Set("Authorization", "Bearer "+token)
 	recorder := httptest.NewRecorder()
 
@@ -57,14 +64,10 @@ func TestAssigneeList(t *testing.T) {
 
 	require.Equal(t, stdhttp.StatusOK, recorder.Code)
 
-	var response assigneeListResponse
-	err = json.NewDecoder(recorder.Body).Decode(&response)
-	require.NoError(t, err)
-	assert.Equal(t, len(response.Data), response.Count)
-
-	assertAssigneePresent(t, response.Data, adminUser.ID)
-	assertAssigneePresent(t, response.Data, agentUser.ID)
-	assertAssigneeMissing(t, response.Data, customerUser.ID)
+	var response PaginatedResponse[AssigneeDTO]
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&response))
+	assert.Equal(t, int64(len(response.Data)), response.Pagination.TotalCount)
+	return response.Data
 }
 
 func TestAssigneeList_Forbidden(t *testing.T) {
@@ -72,7 +75,7 @@ func TestAssigneeList_Forbidden(t *testing.T) {
 	authRepo := pgadapter.NewAuthorizationRepository(testPool)
 	userRepo := pgadapter.NewUserRepository(testPool)
 	defaultOrgID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
-	authService := services.NewAuthService(userRepo, authRepo, defaultOrgID)
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), defaultOrgID)
 
 	customerEmail := uuid.NewString() + "@example.com"
 	customerUser, err := authService.Register(ctx, "Customer User", customerEmail, "Password1", "customer", uuid.Nil)
@@ -94,7 +97,13 @@ func TestAssigneeList_Forbidden(t *testing.T) {
 func newAssigneeRouter() (*chi.Mux, *auth.TokenManager) {
 	authRepo := pgadapter.NewAuthorizationRepository(testPool)
 	authzService := services.NewAuthorizationService(authRepo)
-	assigneeService := services.NewAssigneeService(pgadapter.NewUserRepository(testPool), authzService)
+	assigneeService := services.NewAssigneeService(
+		pgadapter.NewUserRepository(testPool),
+		pgadapter.NewTicketRepository(testPool),
+		pgadapter.NewAnalyticsRepository(testPool),
+		pgadapter.NewOrgSettingsRepository(testPool),
+		authzService,
+	)
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	errorHandler := NewErrorHandler(logger)
 	handler := NewAssigneeHandler(assigneeService, errorHandler, logger)
@@ -1,21 +1,28 @@
 package http
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	mw "github.com/lorrc/service-desk-backend/internal/adapters/primary/http/middleware"
 	"github.com/lorrc/service-desk-backend/internal/adapters/primary/validation"
 	"github.com/lorrc/service-desk-backend/internal/auth"
 	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
 	"github.com/lorrc/service-desk-backend/internal/core/ports"
 )
 
 // LoginRequest defines the expected JSON body for a login request.
+// OrganizationID picks the account when the email is registered in several
+// organizations, and takes precedence over the organization the request's
+// X-Org-Slug header or subdomain names.
 type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email          string `json:"email"`
+	Password       string `json:"password"`
+	OrganizationID string `json:"organizationId,omitempty"`
 }
 
 // Validate validates the login request
@@ -27,6 +34,8 @@ func (r *LoginRequest) Validate() error {
 
 	v.Required("password", r.Password)
 
+	v.UUID("organizationId", r.OrganizationID)
+
 	if v.HasErrors() {
 		return v.Errors()
 	}
@@ -55,6 +64,23 @@ func (r *RegisterRequest) Validate() error {
 	return nil
 }
 
+// ConfirmEmailChangeRequest defines the JSON body for confirming a change
+// of email with the token from the confirmation link.
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token"`
+}
+
+func (r *ConfirmEmailChangeRequest) Validate() error {
+	v := validation.NewValidator()
+
+	v.Required("token", r.Token)
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
 // AuthResponse defines the JSON response containing the authentication token.
 type AuthResponse struct {
 	Token string   `json:"token"`
@@ -72,24 +98,27 @@ type UserDTO struct {
 
 // AuthHandler handles authentication HTTP requests
 type AuthHandler struct {
-	authService  ports.AuthService
-	tokenManager *auth.TokenManager
-	errorHandler *ErrorHandler
-	logger       *slog.Logger
+	authService    ports.AuthService
+	profileService ports.ProfileService
+	tokenManager   *auth.TokenManager
+	errorHandler   *ErrorHandler
+	logger         *slog.Logger
 }
 
 // NewAuthHandler creates a new AuthHandler with the necessary dependencies.
 func NewAuthHandler(
 	authService ports.AuthService,
+	profileService ports.ProfileService,
 	tokenManager *auth.TokenManager,
 	errorHandler *ErrorHandler,
 	logger *slog.Logger,
 ) *AuthHandler {
 	return &AuthHandler{
-		authService:  authService,
-		tokenManager: tokenManager,
-		errorHandler: errorHandler,
-		logger:       logger.With("handler", "auth"),
+		authService:    authService,
+		profileService: profileService,
+		tokenManager:   tokenManager,
+		errorHandler:   errorHandler,
+		logger:         logger.With("handler", "auth"),
 	}
 }
 
@@ -97,6 +126,7 @@ func NewAuthHandler(
 func (h *AuthHandler) RegisterRoutes(r chi.Router) {
 	r.Post("/login", h.HandleLogin)
 	r.Post("/register", h.HandleRegister)
+	r.Post("/confirm-email", h.HandleConfirmEmailChange)
 }
 
 // HandleLogin processes login requests
@@ -112,9 +142,14 @@ func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := h.authService.Login(r.Context(), req.Email, req.Password)
+	orgID := resolvedOrganizationID(r)
+	if req.OrganizationID != "" {
+		orgID = uuid.MustParse(req.OrganizationID)
+	}
+
+	user, err := h.authService.Login(r.Context(), orgID, req.Email, req.Password)
 	if err != nil {
-		h.errorHandler.Handle(w, r, err)
+		h.errorHandler.Handle(w, r, organizationChoiceError(err))
 		return
 	}
 
@@ -139,6 +174,29 @@ func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// organizationChoiceError lists the organizations to choose from in the
+// details of a login that matched accounts in several, so the client can
+// ask the user and log in again with organizationId. Other errors are
+// returned as they are.
+func organizationChoiceError(err error) error {
+	var choice *domain.OrganizationChoiceError
+	if !errors.As(err, &choice) {
+		return err
+	}
+
+	organizations := make([]OrganizationDTO, 0, len(choice.Organizations))
+	for _, org := range choice.Organizations {
+		organizations = append(organizations, toOrganizationDTO(org))
+	}
+	return &apperrors.AppError{
+		Err:        apperrors.ErrOrganizationRequired,
+		Message:    apperrors.CodeOrganizationRequired.Description,
+		Code:       apperrors.CodeOrganizationRequired.Code,
+		StatusCode: apperrors.CodeOrganizationRequired.Status,
+		Details:    map[string]interface{}{"organizations": organizations},
+	}
+}
+
 // HandleRegister processes new user registration requests.
 func (h *AuthHandler) HandleRegister(w http.ResponseWriter, r *http.Request) {
 	req, err := validation.DecodeAndValidate[RegisterRequest](r)
@@ -153,7 +211,7 @@ func (h *AuthHandler) HandleRegister(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Register user (domain validation happens in the service)
-	user, err := h.authService.Register(r.Context(), req.FullName, req.Email, req.Password, "customer", uuid.Nil)
+	user, err := h.authService.Register(r.Context(), req.FullName, req.Email, req.Password, "customer", resolvedOrganizationID(r))
 	if err != nil {
 		h.errorHandler.Handle(w, r, err)
 		return
@@ -180,6 +238,42 @@ func (h *AuthHandler) HandleRegister(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// HandleConfirmEmailChange handles POST /auth/confirm-email. The token from
+// the link sent to the new address authenticates the request.
+func (h *AuthHandler) HandleConfirmEmailChange(w http.ResponseWriter, r *http.Request) {
+	req, err := validation.DecodeAndValidate[ConfirmEmailChangeRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	user, err := h.profileService.ConfirmEmailChange(r.Context(), req.Token)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	h.logger.Info("user email changed",
+		"user_id", user.ID,
+	)
+
+	WriteJSON(w, http.StatusOK, toUserDTO(user))
+}
+
+// resolvedOrganizationID returns the organization the request's X-Org-Slug
+// header or subdomain names, or uuid.Nil when it names none.
+func resolvedOrganizationID(r *http.Request) uuid.UUID {
+	if org, ok := mw.GetOrganization(r.Context()); ok {
+		return org.ID
+	}
+	return uuid.Nil
+}
+
 // toUserDTO converts a domain user to a safe DTO
 func toUserDTO(user *domain.User) *UserDTO {
 	return &UserDTO{
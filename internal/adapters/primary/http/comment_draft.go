@@ -0,0 +1,123 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/adapters/primary/validation"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// SaveCommentDraftRequest defines the JSON body for autosaving a reply
+// that hasn't been posted yet. The body may be empty.
+type SaveCommentDraftRequest struct {
+	Body string `json:"body"`
+}
+
+// Validate validates the save draft request
+func (r *SaveCommentDraftRequest) Validate() error {
+	v := validation.NewValidator()
+
+	v.MaxLength("body", r.Body, domain.MaxCommentBodyLength)
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
+// CommentDraftDTO describes the current user's draft reply to a ticket.
+type CommentDraftDTO struct {
+	TicketID  int64  `json:"ticketId"`
+	Body      string `json:"body"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+func toCommentDraftDTO(draft *domain.CommentDraft) CommentDraftDTO {
+	return CommentDraftDTO{
+		TicketID:  draft.TicketID,
+		Body:      draft.Body,
+		UpdatedAt: draft.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// HandleGetDraft handles GET /tickets/{ticketID}/comments/draft
+func (h *CommentHandler) HandleGetDraft(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	ticketID, err := h.parseTicketID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	draft, err := h.draftService.GetDraft(r.Context(), ticketID, claims.UserID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toCommentDraftDTO(draft))
+}
+
+// HandleSaveDraft handles PUT /tickets/{ticketID}/comments/draft
+func (h *CommentHandler) HandleSaveDraft(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	ticketID, err := h.parseTicketID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[SaveCommentDraftRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	draft, err := h.draftService.SaveDraft(r.Context(), ports.SaveCommentDraftParams{
+		TicketID: ticketID,
+		ActorID:  claims.UserID,
+		Body:     req.Body,
+	})
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toCommentDraftDTO(draft))
+}
+
+// HandleDiscardDraft handles DELETE /tickets/{ticketID}/comments/draft
+func (h *CommentHandler) HandleDiscardDraft(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	ticketID, err := h.parseTicketID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := h.draftService.DiscardDraft(r.Context(), ticketID, claims.UserID); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteNoContent(w)
+}
@@ -18,6 +18,7 @@ import (
 // CommentHandler handles HTTP requests for comments.
 type CommentHandler struct {
 	commentService ports.CommentService
+	draftService   ports.CommentDraftService
 	userLookup     ports.UserLookupService
 	errorHandler   *ErrorHandler
 	logger         *slog.Logger
@@ -26,12 +27,14 @@ type CommentHandler struct {
 // NewCommentHandler creates a new CommentHandler.
 func NewCommentHandler(
 	commentService ports.CommentService,
+	draftService ports.CommentDraftService,
 	userLookup ports.UserLookupService,
 	errorHandler *ErrorHandler,
 	logger *slog.Logger,
 ) *CommentHandler {
 	return &CommentHandler{
 		commentService: commentService,
+		draftService:   draftService,
 		userLookup:     userLookup,
 		errorHandler:   errorHandler,
 		logger:         logger.With("handler", "comment"),
@@ -50,6 +53,9 @@ func (h *CommentHandler) Router() http.Handler {
 func (h *CommentHandler) RegisterRoutes(r chi.Router) {
 	r.Post("/", h.HandleCreateComment)
 	r.Get("/", h.HandleListComments)
+	r.Get("/draft", h.HandleGetDraft)
+	r.Put("/draft", h.HandleSaveDraft)
+	r.Delete("/draft", h.HandleDiscardDraft)
 }
 
 // --- Request DTOs ---
@@ -79,6 +85,7 @@ type CommentDTO struct {
 	AuthorID  string `json:"authorId"`
 	Author    *UserInfoDTO `json:"author,omitempty"`
 	Body      string `json:"body"`
+	BodyHTML  string `json:"bodyHtml"`
 	CreatedAt string `json:"createdAt"`
 }
 
@@ -95,6 +102,7 @@ func toCommentDTO(comment *domain.Comment, userInfoByID map[uuid.UUID]UserInfoDT
 		AuthorID:  comment.AuthorID.String(),
 		Author:    author,
 		Body:      comment.Body,
+		BodyHTML:  comment.BodyHTML,
 		CreatedAt: comment.CreatedAt.Format(time.RFC3339),
 	}
 }
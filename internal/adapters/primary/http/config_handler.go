@@ -0,0 +1,114 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	mw "github.com/lorrc/service-desk-backend/internal/adapters/primary/http/middleware"
+	"github.com/lorrc/service-desk-backend/internal/auth"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// ConfigHandler handles HTTP requests for the runtime configuration.
+type ConfigHandler struct {
+	configService ports.ConfigService
+	errorHandler  *ErrorHandler
+	logger        *slog.Logger
+}
+
+// NewConfigHandler creates a new ConfigHandler.
+func NewConfigHandler(configService ports.ConfigService, errorHandler *ErrorHandler, logger *slog.Logger) *ConfigHandler {
+	return &ConfigHandler{
+		configService: configService,
+		errorHandler:  errorHandler,
+		logger:        logger.With("handler", "config"),
+	}
+}
+
+// RegisterRoutes registers the /admin/config routes.
+func (h *ConfigHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.HandleGetConfig)
+	r.Post("/reload", h.HandleReloadConfig)
+}
+
+// RuntimeRateLimitDTO defines the JSON representation of the reloadable rate
+// limits.
+type RuntimeRateLimitDTO struct {
+	RequestsPerSecond     float64 `json:"requestsPerSecond"`
+	Burst                 int     `json:"burst"`
+	AuthRequestsPerSecond float64 `json:"authRequestsPerSecond"`
+	AuthBurst             int     `json:"authBurst"`
+}
+
+// RuntimeConfigDTO defines the JSON representation of the runtime
+// configuration.
+type RuntimeConfigDTO struct {
+	LogLevel    string              `json:"logLevel"`
+	RateLimit   RuntimeRateLimitDTO `json:"rateLimit"`
+	CORSOrigins []string            `json:"corsOrigins"`
+}
+
+// HandleGetConfig handles GET /admin/config
+func (h *ConfigHandler) HandleGetConfig(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	cfg, err := h.configService.GetRuntimeConfig(r.Context(), claims.UserID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toRuntimeConfigDTO(cfg))
+}
+
+// HandleReloadConfig handles POST /admin/config/reload
+func (h *ConfigHandler) HandleReloadConfig(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	cfg, err := h.configService.ReloadConfig(r.Context(), claims.UserID, claims.OrgID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	h.logger.Info("configuration reloaded", "actor_id", claims.UserID, "log_level", cfg.LogLevel)
+	WriteJSON(w, http.StatusOK, toRuntimeConfigDTO(cfg))
+}
+
+func toRuntimeConfigDTO(cfg *domain.RuntimeConfig) RuntimeConfigDTO {
+	origins := cfg.CORSOrigins
+	if origins == nil {
+		origins = []string{}
+	}
+	return RuntimeConfigDTO{
+		LogLevel: cfg.LogLevel,
+		RateLimit: RuntimeRateLimitDTO{
+			RequestsPerSecond:     cfg.RateLimit.RequestsPerSecond,
+			Burst:                 cfg.RateLimit.Burst,
+			AuthRequestsPerSecond: cfg.RateLimit.AuthRequestsPerSecond,
+			AuthBurst:             cfg.RateLimit.AuthBurst,
+		},
+		CORSOrigins: origins,
+	}
+}
+
+// getClaims extracts and validates user claims from the request context.
+func (h *ConfigHandler) getClaims(w http.ResponseWriter, r *http.Request) (*auth.Claims, bool) {
+	claims, ok := mw.GetClaims(r.Context())
+	if !ok {
+		WriteJSON(w, http.StatusUnauthorized, ErrorResponse{
+			Error: "Not authorized",
+			Code:  "UNAUTHORIZED",
+		})
+		return nil, false
+	}
+	return claims, true
+}
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+// DataExportDTO describes a personal data export without its content.
+type DataExportDTO struct {
+	ID          string  `json:"id"`
+	UserID      string  `json:"userId"`
+	Status      string  `json:"status"`
+	Error       string  `json:"error,omitempty"`
+	CreatedAt   string  `json:"createdAt"`
+	CompletedAt *string `json:"completedAt"`
+	ExpiresAt   *string `json:"expiresAt"`
+}
+
+func toDataExportDTO(export *domain.DataExport) DataExportDTO {
+	dto := DataExportDTO{
+		ID:        export.ID.String(),
+		UserID:    export.UserID.String(),
+		Status:    string(export.Status),
+		Error:     export.Error,
+		CreatedAt: export.CreatedAt.Format(time.RFC3339),
+	}
+	if export.CompletedAt != nil {
+		value := export.CompletedAt.Format(time.RFC3339)
+		dto.CompletedAt = &value
+	}
+	if export.ExpiresAt != nil {
+		value := export.ExpiresAt.Format(time.RFC3339)
+		dto.ExpiresAt = &value
+	}
+	return dto
+}
+
+// writeDataExportDownload sends a finished export as a JSON attachment.
+func writeDataExportDownload(w http.ResponseWriter, export *domain.DataExport) error {
+	if !export.IsDownloadable(time.Now()) {
+		return apperrors.ErrDataExportNotReady
+	}
+
+	filename := fmt.Sprintf("data-export-%s.json", export.UserID)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(export.Payload)
+	return nil
+}
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lorrc/service-desk-backend/internal/adapters/primary/validation"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// DevEmailHandler lets developers preview the emails the API would have
+// sent, instead of reading them from the logs. It is only mounted outside
+// production.
+type DevEmailHandler struct {
+	emails       ports.EmailCapture
+	errorHandler *ErrorHandler
+}
+
+// NewDevEmailHandler creates a new DevEmailHandler.
+func NewDevEmailHandler(emails ports.EmailCapture, errorHandler *ErrorHandler) *DevEmailHandler {
+	return &DevEmailHandler{
+		emails:       emails,
+		errorHandler: errorHandler,
+	}
+}
+
+// RegisterRoutes registers the /dev/emails routes.
+func (h *DevEmailHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.HandleListEmails)
+	r.Get("/{emailID}", h.HandleGetEmail)
+	r.Get("/{emailID}/html", h.HandlePreviewHTML)
+	r.Get("/{emailID}/text", h.HandlePreviewText)
+}
+
+// CapturedEmailDTO defines the JSON representation of a captured email.
+// The bodies are left out of listings.
+type CapturedEmailDTO struct {
+	ID         int64  `json:"id"`
+	Type       string `json:"type"`
+	TicketID   int64  `json:"ticketId,omitempty"`
+	ToName     string `json:"toName"`
+	ToEmail    string `json:"toEmail"`
+	Subject    string `json:"subject"`
+	HTMLBody   string `json:"htmlBody,omitempty"`
+	TextBody   string `json:"textBody,omitempty"`
+	CapturedAt string `json:"capturedAt"`
+}
+
+// HandleListEmails handles GET /dev/emails
+func (h *DevEmailHandler) HandleListEmails(w http.ResponseWriter, r *http.Request) {
+	limit := validation.ParseIntQueryParam(r, "limit", 50)
+
+	emails, err := h.emails.List(r.Context(), limit)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	response := make([]CapturedEmailDTO, 0, len(emails))
+	for _, email := range emails {
+		dto := toCapturedEmailDTO(&email)
+		dto.HTMLBody, dto.TextBody = "", ""
+		response = append(response, dto)
+	}
+
+	WriteList(w, response)
+}
+
+// HandleGetEmail handles GET /dev/emails/{emailID}
+func (h *DevEmailHandler) HandleGetEmail(w http.ResponseWriter, r *http.Request) {
+	email, ok := h.getEmail(w, r)
+	if !ok {
+		return
+	}
+	WriteSuccess(w, toCapturedEmailDTO(email))
+}
+
+// HandlePreviewHTML handles GET /dev/emails/{emailID}/html, rendering the
+// HTML body as a browser would show it in a mail client.
+func (h *DevEmailHandler) HandlePreviewHTML(w http.ResponseWriter, r *http.Request) {
+	email, ok := h.getEmail(w, r)
+	if !ok {
+		return
+	}
+	writeEmailBody(w, "text/html; charset=utf-8", email.HTMLBody)
+}
+
+// HandlePreviewText handles GET /dev/emails/{emailID}/text
+func (h *DevEmailHandler) HandlePreviewText(w http.ResponseWriter, r *http.Request) {
+	email, ok := h.getEmail(w, r)
+	if !ok {
+		return
+	}
+	writeEmailBody(w, "text/plain; charset=utf-8", email.TextBody)
+}
+
+// getEmail looks up the email named in the URL, writing the error response
+// if there is none.
+func (h *DevEmailHandler) getEmail(w http.ResponseWriter, r *http.Request) (*ports.CapturedEmail, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "emailID"), 10, 64)
+	if err != nil || id <= 0 {
+		v := validation.NewValidator()
+		v.Custom("emailID", false, "Invalid email ID")
+		h.errorHandler.Handle(w, r, v.Errors())
+		return nil, false
+	}
+
+	email, err := h.emails.Get(r.Context(), id)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return nil, false
+	}
+	return email, true
+}
+
+// writeEmailBody writes a rendered body as is. Previews must not load
+// anything but the email itself.
+func writeEmailBody(w http.ResponseWriter, contentType, body string) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; img-src data:")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(body))
+}
+
+func toCapturedEmailDTO(email *ports.CapturedEmail) CapturedEmailDTO {
+	return CapturedEmailDTO{
+		ID:         email.ID,
+		Type:       string(email.Type),
+		TicketID:   email.TicketID,
+		ToName:     email.ToName,
+		ToEmail:    email.ToEmail,
+		Subject:    email.Subject,
+		HTMLBody:   email.HTMLBody,
+		TextBody:   email.TextBody,
+		CapturedAt: email.CapturedAt.Format(time.RFC3339),
+	}
+}
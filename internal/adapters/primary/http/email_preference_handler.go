@@ -0,0 +1,273 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	mw "github.com/lorrc/service-desk-backend/internal/adapters/primary/http/middleware"
+	"github.com/lorrc/service-desk-backend/internal/adapters/primary/validation"
+	"github.com/lorrc/service-desk-backend/internal/auth"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// EmailPreferencesResponse defines the JSON response for the ticket emails
+// the user opted out of.
+type EmailPreferencesResponse struct {
+	Unsubscribed   bool    `json:"unsubscribed"`
+	MutedTicketIDs []int64 `json:"mutedTicketIds"`
+}
+
+// UpdateEmailPreferencesRequest defines the JSON body for turning all
+// ticket emails off or back on.
+type UpdateEmailPreferencesRequest struct {
+	Unsubscribed *bool `json:"unsubscribed"`
+}
+
+func (r *UpdateEmailPreferencesRequest) Validate() error {
+	v := validation.NewValidator()
+
+	v.NotNil("unsubscribed", r.Unsubscribed)
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
+// EmailOptOutRequest defines the JSON body for applying a "mute this
+// ticket" or "unsubscribe" link with the token from a ticket email.
+type EmailOptOutRequest struct {
+	Token string `json:"token"`
+}
+
+func (r *EmailOptOutRequest) Validate() error {
+	v := validation.NewValidator()
+
+	v.Required("token", r.Token)
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
+// EmailOptOutResponse defines the JSON response for an applied opt-out
+// link. ticketId is left out when the link unsubscribed from all tickets.
+type EmailOptOutResponse struct {
+	TicketID int64 `json:"ticketId,omitempty"`
+}
+
+// EmailPreferenceHandler handles HTTP requests for the ticket emails users
+// opt out of.
+type EmailPreferenceHandler struct {
+	service      ports.EmailPreferenceService
+	errorHandler *ErrorHandler
+	logger       *slog.Logger
+}
+
+// NewEmailPreferenceHandler creates a new EmailPreferenceHandler.
+func NewEmailPreferenceHandler(service ports.EmailPreferenceService, errorHandler *ErrorHandler, logger *slog.Logger) *EmailPreferenceHandler {
+	return &EmailPreferenceHandler{
+		service:      service,
+		errorHandler: errorHandler,
+		logger:       logger.With("handler", "email_preferences"),
+	}
+}
+
+// RegisterRoutes registers the /me/email-preferences routes.
+func (h *EmailPreferenceHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.HandleGetPreferences)
+	r.Put("/", h.HandleUpdatePreferences)
+	r.Put("/muted-tickets/{ticketID}", h.HandleMuteTicket)
+	r.Delete("/muted-tickets/{ticketID}", h.HandleUnmuteTicket)
+}
+
+// RegisterLinkRoutes registers the /email-preferences routes recipients
+// reach without logging in, with the token from the links in ticket emails.
+func (h *EmailPreferenceHandler) RegisterLinkRoutes(r chi.Router) {
+	r.Post("/mute", h.HandleMuteWithToken)
+	r.Post("/unsubscribe", h.HandleUnsubscribeWithToken)
+}
+
+// HandleGetPreferences handles GET /me/email-preferences
+func (h *EmailPreferenceHandler) HandleGetPreferences(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	prefs, err := h.service.GetPreferences(r.Context(), claims.UserID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toEmailPreferencesResponse(prefs))
+}
+
+// HandleUpdatePreferences handles PUT /me/email-preferences
+func (h *EmailPreferenceHandler) HandleUpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[UpdateEmailPreferencesRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	prefs, err := h.service.SetUnsubscribed(r.Context(), claims.UserID, *req.Unsubscribed)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	h.logger.Info("email preferences updated",
+		"user_id", claims.UserID,
+		"unsubscribed", prefs.Unsubscribed,
+	)
+
+	WriteJSON(w, http.StatusOK, toEmailPreferencesResponse(prefs))
+}
+
+// HandleMuteTicket handles PUT /me/email-preferences/muted-tickets/{ticketID}
+func (h *EmailPreferenceHandler) HandleMuteTicket(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	ticketID, err := h.parseTicketID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := h.service.MuteTicket(r.Context(), claims.UserID, ticketID); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteNoContent(w)
+}
+
+// HandleUnmuteTicket handles DELETE /me/email-preferences/muted-tickets/{ticketID}
+func (h *EmailPreferenceHandler) HandleUnmuteTicket(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	ticketID, err := h.parseTicketID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := h.service.UnmuteTicket(r.Context(), claims.UserID, ticketID); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteNoContent(w)
+}
+
+// HandleMuteWithToken handles POST /email-preferences/mute
+func (h *EmailPreferenceHandler) HandleMuteWithToken(w http.ResponseWriter, r *http.Request) {
+	req, err := validation.DecodeAndValidate[EmailOptOutRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	optOut, err := h.service.MuteTicketWithToken(r.Context(), req.Token)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	h.logger.Info("ticket muted from email link",
+		"user_id", optOut.UserID,
+		"ticket_id", optOut.TicketID,
+	)
+
+	WriteJSON(w, http.StatusOK, EmailOptOutResponse{TicketID: optOut.TicketID})
+}
+
+// HandleUnsubscribeWithToken handles POST /email-preferences/unsubscribe
+func (h *EmailPreferenceHandler) HandleUnsubscribeWithToken(w http.ResponseWriter, r *http.Request) {
+	req, err := validation.DecodeAndValidate[EmailOptOutRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	optOut, err := h.service.UnsubscribeWithToken(r.Context(), req.Token)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	h.logger.Info("unsubscribed from ticket emails from email link",
+		"user_id", optOut.UserID,
+	)
+
+	WriteJSON(w, http.StatusOK, EmailOptOutResponse{})
+}
+
+// toEmailPreferencesResponse converts domain email preferences to a DTO.
+func toEmailPreferencesResponse(prefs *domain.EmailPreferences) EmailPreferencesResponse {
+	muted := prefs.MutedTicketIDs
+	if muted == nil {
+		muted = []int64{}
+	}
+	return EmailPreferencesResponse{
+		Unsubscribed:   prefs.Unsubscribed,
+		MutedTicketIDs: muted,
+	}
+}
+
+// getClaims extracts and validates user claims from the request context.
+func (h *EmailPreferenceHandler) getClaims(w http.ResponseWriter, r *http.Request) (*auth.Claims, bool) {
+	claims, ok := mw.GetClaims(r.Context())
+	if !ok {
+		WriteJSON(w, http.StatusUnauthorized, ErrorResponse{
+			Error: "Not authorized",
+			Code:  "UNAUTHORIZED",
+		})
+		return nil, false
+	}
+	return claims, true
+}
+
+// parseTicketID extracts and validates the ticket ID from the URL
+func (h *EmailPreferenceHandler) parseTicketID(r *http.Request) (int64, error) {
+	ticketIDStr := chi.URLParam(r, "ticketID")
+	ticketID, err := strconv.ParseInt(ticketIDStr, 10, 64)
+	if err != nil || ticketID <= 0 {
+		v := validation.NewValidator()
+		v.Custom("ticketID", false, "Invalid ticket ID")
+		return 0, v.Errors()
+	}
+	return ticketID, nil
+}
@@ -6,9 +6,11 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"strings"
 
 	mw "github.com/lorrc/service-desk-backend/internal/adapters/primary/http/middleware"
 	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/i18n"
 )
 
 // GetRequestID retrieves the request ID from context
@@ -47,12 +49,18 @@ func (h *ErrorHandler) Handle(w http.ResponseWriter, r *http.Request, err error)
 	// Check for AppError first (our custom error type)
 	var appErr *apperrors.AppError
 	if errors.As(err, &appErr) {
-		h.logError(r, appErr.StatusCode, appErr.Err, requestID)
-		h.writeErrorResponse(w, appErr.StatusCode, ErrorResponse{
+		response := ErrorResponse{
 			Error:   appErr.Message,
 			Code:    appErr.Code,
 			Details: appErr.Details,
-		})
+		}
+		// Messages left at the code's default are translated like domain
+		// errors; custom messages are kept as they are
+		if appErr.Message == apperrors.CodeFor(appErr.Err).Description {
+			response.Error = localizedMessage(r, appErr.Err, response)
+		}
+		h.logError(r, appErr.StatusCode, appErr.Err, requestID)
+		h.writeErrorResponse(w, appErr.StatusCode, response)
 		return
 	}
 
@@ -60,104 +68,65 @@ func (h *ErrorHandler) Handle(w http.ResponseWriter, r *http.Request, err error)
 	var validationErrs *apperrors.ValidationErrors
 	if errors.As(err, &validationErrs) {
 		h.logError(r, http.StatusUnprocessableEntity, err, requestID)
-		h.writeValidationErrorResponse(w, validationErrs)
+		h.writeValidationErrorResponse(w, r, validationErrs)
 		return
 	}
 
 	// Map known domain errors to HTTP responses
 	statusCode, response := h.mapDomainError(err)
+	response.Error = localizedMessage(r, err, response)
 	h.logError(r, statusCode, err, requestID)
 	h.writeErrorResponse(w, statusCode, response)
 }
 
 // mapDomainError converts domain errors to HTTP status codes and responses
 func (h *ErrorHandler) mapDomainError(err error) (int, ErrorResponse) {
-	switch {
-	// Authentication & Authorization
-	case errors.Is(err, apperrors.ErrInvalidCredentials):
-		return http.StatusUnauthorized, ErrorResponse{
-			Error: "Invalid credentials",
-			Code:  "INVALID_CREDENTIALS",
-		}
-	case errors.Is(err, apperrors.ErrUnauthorized):
-		return http.StatusUnauthorized, ErrorResponse{
-			Error: "Authentication required",
-			Code:  "UNAUTHORIZED",
-		}
-	case errors.Is(err, apperrors.ErrForbidden):
-		return http.StatusForbidden, ErrorResponse{
-			Error: "You do not have permission to perform this action",
-			Code:  "FORBIDDEN",
-		}
-	case errors.Is(err, apperrors.ErrUserInactive):
-		return http.StatusForbidden, ErrorResponse{
-			Error: "User account is inactive",
-			Code:  "USER_INACTIVE",
-		}
-
-	// Not Found errors
-	case errors.Is(err, apperrors.ErrUserNotFound):
-		return http.StatusNotFound, ErrorResponse{
-			Error: "User not found",
-			Code:  "USER_NOT_FOUND",
-		}
-	case errors.Is(err, apperrors.ErrTicketNotFound):
-		return http.StatusNotFound, ErrorResponse{
-			Error: "Ticket not found",
-			Code:  "TICKET_NOT_FOUND",
-		}
-
-	// Conflict errors
-	case errors.Is(err, apperrors.ErrUserExists):
-		return http.StatusConflict, ErrorResponse{
-			Error: "A user with this email already exists",
-			Code:  "USER_EXISTS",
-		}
-
-	// Validation errors
-	case errors.Is(err, apperrors.ErrTitleRequired),
-		errors.Is(err, apperrors.ErrTitleTooLong),
-		errors.Is(err, apperrors.ErrDescriptionTooLong),
-		errors.Is(err, apperrors.ErrInvalidPriority),
-		errors.Is(err, apperrors.ErrInvalidStatus),
-		errors.Is(err, apperrors.ErrCommentBodyRequired),
-		errors.Is(err, apperrors.ErrCommentBodyTooLong),
-		errors.Is(err, apperrors.ErrEmailRequired),
-		errors.Is(err, apperrors.ErrEmailInvalid),
-		errors.Is(err, apperrors.ErrPasswordTooWeak),
-		errors.Is(err, apperrors.ErrPasswordRequired),
-		errors.Is(err, apperrors.ErrFullNameRequired):
-		return http.StatusBadRequest, ErrorResponse{
-			Error: err.Error(),
-			Code:  "VALIDATION_ERROR",
-		}
-
-	// Business rule violations
-	case errors.Is(err, apperrors.ErrInvalidStatusTransition):
-		return http.StatusBadRequest, ErrorResponse{
-			Error: "Invalid status transition",
-			Code:  "INVALID_STATUS_TRANSITION",
-		}
-	case errors.Is(err, apperrors.ErrCannotAssignClosed):
-		return http.StatusBadRequest, ErrorResponse{
-			Error: "Cannot assign a closed ticket",
-			Code:  "CANNOT_ASSIGN_CLOSED",
-		}
+	code := apperrors.CodeFor(err)
+	message := code.Description
+	if code == apperrors.CodeInvalidValue {
+		message = err.Error()
+	}
+	return code.Status, ErrorResponse{
+		Error: message,
+		Code:  code.Code,
+	}
+}
 
-	// Rate limiting
-	case errors.Is(err, apperrors.ErrRateLimited):
-		return http.StatusTooManyRequests, ErrorResponse{
-			Error: "Too many requests. Please try again later.",
-			Code:  "RATE_LIMITED",
-		}
+// validationMessageKeys maps domain validation errors, which share the
+// VALIDATION_ERROR code, to their message catalog keys.
+var validationMessageKeys = []struct {
+	err error
+	key string
+}{
+	{apperrors.ErrTitleRequired, "error.title_required"},
+	{apperrors.ErrTitleTooLong, "error.title_too_long"},
+	{apperrors.ErrDescriptionTooLong, "error.description_too_long"},
+	{apperrors.ErrInvalidPriority, "error.invalid_priority"},
+	{apperrors.ErrInvalidStatus, "error.invalid_status"},
+	{apperrors.ErrCommentBodyRequired, "error.comment_body_required"},
+	{apperrors.ErrCommentBodyTooLong, "error.comment_body_too_long"},
+	{apperrors.ErrEmailRequired, "error.email_required"},
+	{apperrors.ErrEmailInvalid, "error.email_invalid"},
+	{apperrors.ErrPasswordTooWeak, "error.password_too_weak"},
+	{apperrors.ErrPasswordRequired, "error.password_required"},
+	{apperrors.ErrFullNameRequired, "error.full_name_required"},
+}
 
-	// Default to internal server error
-	default:
-		return http.StatusInternalServerError, ErrorResponse{
-			Error: "An unexpected error occurred",
-			Code:  "INTERNAL_ERROR",
+// localizedMessage translates the message of a mapped domain error into the
+// request's locale. Errors are looked up by code, or by the specific error for
+// validation failures; the English message is kept if there is no entry.
+func localizedMessage(r *http.Request, err error, response ErrorResponse) string {
+	key := "error." + strings.ToLower(response.Code)
+	if response.Code == apperrors.CodeInvalidValue.Code {
+		key = ""
+		for _, v := range validationMessageKeys {
+			if errors.Is(err, v.err) {
+				key = v.key
+				break
+			}
 		}
 	}
+	return i18n.TOr(mw.GetLocale(r.Context()), key, response.Error)
 }
 
 // logError logs the error with appropriate context
@@ -189,12 +158,12 @@ func (h *ErrorHandler) writeErrorResponse(w http.ResponseWriter, statusCode int,
 }
 
 // writeValidationErrorResponse writes a validation error response
-func (h *ErrorHandler) writeValidationErrorResponse(w http.ResponseWriter, errs *apperrors.ValidationErrors) {
+func (h *ErrorHandler) writeValidationErrorResponse(w http.ResponseWriter, r *http.Request, errs *apperrors.ValidationErrors) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusUnprocessableEntity)
 	_ = json.NewEncoder(w).Encode(ValidationErrorResponse{
-		Error:  "Validation failed",
-		Code:   "VALIDATION_ERROR",
+		Error:  i18n.T(mw.GetLocale(r.Context()), "error.validation_failed"),
+		Code:   apperrors.CodeValidationFailed.Code,
 		Fields: errs.Errors,
 	})
 }
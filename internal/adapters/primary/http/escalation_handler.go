@@ -0,0 +1,382 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	mw "github.com/lorrc/service-desk-backend/internal/adapters/primary/http/middleware"
+	"github.com/lorrc/service-desk-backend/internal/adapters/primary/validation"
+	"github.com/lorrc/service-desk-backend/internal/auth"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// EscalationHandler handles HTTP requests for managing escalation rules
+// under /admin/escalation-rules.
+type EscalationHandler struct {
+	escalationService ports.EscalationService
+	errorHandler      *ErrorHandler
+	logger            *slog.Logger
+}
+
+// NewEscalationHandler creates a new EscalationHandler.
+func NewEscalationHandler(escalationService ports.EscalationService, errorHandler *ErrorHandler, logger *slog.Logger) *EscalationHandler {
+	return &EscalationHandler{
+		escalationService: escalationService,
+		errorHandler:      errorHandler,
+		logger:            logger.With("handler", "escalation"),
+	}
+}
+
+// RegisterRoutes registers the /admin/escalation-rules routes.
+func (h *EscalationHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.HandleListRules)
+	r.Post("/", h.HandleCreateRule)
+	r.Post("/dry-run", h.HandleDryRun)
+	r.Put("/{ruleID}", h.HandleUpdateRule)
+	r.Delete("/{ruleID}", h.HandleDeleteRule)
+}
+
+// EscalationRuleRequest defines the JSON body for creating or replacing an
+// escalation rule. An empty priorities list matches tickets of any priority;
+// isActive defaults to true.
+type EscalationRuleRequest struct {
+	Name             string                    `json:"name"`
+	Trigger          string                    `json:"trigger"`
+	ThresholdMinutes int                       `json:"thresholdMinutes"`
+	Priorities       []string                  `json:"priorities"`
+	Actions          []domain.EscalationAction `json:"actions"`
+	IsActive         *bool                     `json:"isActive"`
+}
+
+func (r *EscalationRuleRequest) Validate() error {
+	v := validation.NewValidator()
+
+	v.Required("name", r.Name).
+		MaxLength("name", r.Name, domain.MaxEscalationRuleNameLength)
+	v.Required("trigger", r.Trigger).
+		OneOf("trigger", r.Trigger, []string{"UNASSIGNED", "OVERDUE", "IDLE"})
+	v.Range("thresholdMinutes", r.ThresholdMinutes, 1, domain.MaxEscalationThresholdMinutes)
+	for _, priority := range r.Priorities {
+		v.OneOf("priorities", priority, []string{"LOW", "MEDIUM", "HIGH"})
+	}
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
+func (r *EscalationRuleRequest) params() domain.EscalationRuleParams {
+	priorities := make([]domain.TicketPriority, 0, len(r.Priorities))
+	for _, priority := range r.Priorities {
+		priorities = append(priorities, domain.TicketPriority(priority))
+	}
+
+	return domain.EscalationRuleParams{
+		Name:             r.Name,
+		Trigger:          domain.EscalationTrigger(r.Trigger),
+		ThresholdMinutes: r.ThresholdMinutes,
+		Priorities:       priorities,
+		Actions:          r.Actions,
+		IsActive:         r.IsActive == nil || *r.IsActive,
+	}
+}
+
+// SampleTicketRequest describes the ticket a dry run evaluates rules
+// against. Status defaults to OPEN; without a dueAt the due date follows
+// from the priority and the organization's business hours.
+type SampleTicketRequest struct {
+	Status     string     `json:"status"`
+	Priority   string     `json:"priority"`
+	AssigneeID string     `json:"assigneeId"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  *time.Time `json:"updatedAt"`
+	DueAt      *time.Time `json:"dueAt"`
+}
+
+// EscalationDryRunRequest defines the JSON body for a dry run. Without a
+// rule, the organization's active rules are evaluated; without at, they are
+// evaluated as of now.
+type EscalationDryRunRequest struct {
+	Rule   *EscalationRuleRequest `json:"rule"`
+	Ticket SampleTicketRequest    `json:"ticket"`
+	At     *time.Time             `json:"at"`
+}
+
+func (r *EscalationDryRunRequest) Validate() error {
+	if r.Rule != nil {
+		if err := r.Rule.Validate(); err != nil {
+			return err
+		}
+	}
+
+	v := validation.NewValidator()
+
+	if r.Ticket.Status != "" {
+		v.OneOf("ticket.status", r.Ticket.Status, []string{"OPEN", "IN_PROGRESS", "CLOSED"})
+	}
+	v.Required("ticket.priority", r.Ticket.Priority).
+		OneOf("ticket.priority", r.Ticket.Priority, []string{"LOW", "MEDIUM", "HIGH"})
+	if r.Ticket.AssigneeID != "" {
+		v.UUID("ticket.assigneeId", r.Ticket.AssigneeID)
+	}
+	v.Custom("ticket.createdAt", !r.Ticket.CreatedAt.IsZero(), "Created at is required")
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
+func (r *EscalationDryRunRequest) params() ports.EscalationDryRunParams {
+	status := domain.StatusOpen
+	if r.Ticket.Status != "" {
+		status = domain.TicketStatus(r.Ticket.Status)
+	}
+
+	params := ports.EscalationDryRunParams{
+		Ticket: domain.Ticket{
+			Status:    status,
+			Priority:  domain.TicketPriority(r.Ticket.Priority),
+			CreatedAt: r.Ticket.CreatedAt,
+			UpdatedAt: r.Ticket.UpdatedAt,
+			DueAt:     r.Ticket.DueAt,
+		},
+	}
+	if r.Ticket.AssigneeID != "" {
+		assigneeID := uuid.MustParse(r.Ticket.AssigneeID)
+		params.Ticket.AssigneeID = &assigneeID
+	}
+	if r.Rule != nil {
+		rule := r.Rule.params()
+		params.Rule = &rule
+	}
+	if r.At != nil {
+		params.At = *r.At
+	}
+	return params
+}
+
+// EscalationRuleDTO defines the JSON representation of an escalation rule.
+type EscalationRuleDTO struct {
+	ID               string                    `json:"id"`
+	Name             string                    `json:"name"`
+	Trigger          string                    `json:"trigger"`
+	ThresholdMinutes int                       `json:"thresholdMinutes"`
+	Priorities       []string                  `json:"priorities"`
+	Actions          []domain.EscalationAction `json:"actions"`
+	IsActive         bool                      `json:"isActive"`
+	CreatedBy        string                    `json:"createdBy"`
+	CreatedAt        string                    `json:"createdAt"`
+	UpdatedAt        string                    `json:"updatedAt"`
+}
+
+// EscalationEvaluationDTO defines the JSON representation of a rule's dry
+// run. RuleID is empty for a draft rule; FiresAt is null if the rule would
+// never fire for the ticket as it stands.
+type EscalationEvaluationDTO struct {
+	RuleID  string                    `json:"ruleId,omitempty"`
+	Name    string                    `json:"name"`
+	Fires   bool                      `json:"fires"`
+	FiresAt *string                   `json:"firesAt"`
+	Actions []domain.EscalationAction `json:"actions"`
+}
+
+// HandleListRules handles GET /admin/escalation-rules
+func (h *EscalationHandler) HandleListRules(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	rules, err := h.escalationService.ListRules(r.Context(), claims.UserID, claims.OrgID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	response := make([]EscalationRuleDTO, 0, len(rules))
+	for _, rule := range rules {
+		response = append(response, toEscalationRuleDTO(rule))
+	}
+
+	WriteList(w, response)
+}
+
+// HandleCreateRule handles POST /admin/escalation-rules
+func (h *EscalationHandler) HandleCreateRule(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[EscalationRuleRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	rule, err := h.escalationService.CreateRule(r.Context(), claims.UserID, claims.OrgID, req.params())
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteCreated(w, toEscalationRuleDTO(rule))
+}
+
+// HandleUpdateRule handles PUT /admin/escalation-rules/{ruleID}
+func (h *EscalationHandler) HandleUpdateRule(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	ruleID, err := h.parseRuleID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[EscalationRuleRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	rule, err := h.escalationService.UpdateRule(r.Context(), claims.UserID, claims.OrgID, ruleID, req.params())
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toEscalationRuleDTO(rule))
+}
+
+// HandleDeleteRule handles DELETE /admin/escalation-rules/{ruleID}
+func (h *EscalationHandler) HandleDeleteRule(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	ruleID, err := h.parseRuleID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := h.escalationService.DeleteRule(r.Context(), claims.UserID, claims.OrgID, ruleID); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteNoContent(w)
+}
+
+// HandleDryRun handles POST /admin/escalation-rules/dry-run
+func (h *EscalationHandler) HandleDryRun(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[EscalationDryRunRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	evaluations, err := h.escalationService.DryRun(r.Context(), claims.UserID, claims.OrgID, req.params())
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	response := make([]EscalationEvaluationDTO, 0, len(evaluations))
+	for _, evaluation := range evaluations {
+		response = append(response, toEscalationEvaluationDTO(evaluation))
+	}
+
+	WriteList(w, response)
+}
+
+func toEscalationRuleDTO(rule *domain.EscalationRule) EscalationRuleDTO {
+	priorities := make([]string, 0, len(rule.Priorities))
+	for _, priority := range rule.Priorities {
+		priorities = append(priorities, string(priority))
+	}
+
+	return EscalationRuleDTO{
+		ID:               rule.ID.String(),
+		Name:             rule.Name,
+		Trigger:          string(rule.Trigger),
+		ThresholdMinutes: rule.ThresholdMinutes,
+		Priorities:       priorities,
+		Actions:          rule.Actions,
+		IsActive:         rule.IsActive,
+		CreatedBy:        rule.CreatedBy.String(),
+		CreatedAt:        rule.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        rule.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func toEscalationEvaluationDTO(evaluation domain.EscalationEvaluation) EscalationEvaluationDTO {
+	dto := EscalationEvaluationDTO{
+		Name:    evaluation.Rule.Name,
+		Fires:   evaluation.Fires,
+		Actions: evaluation.Rule.Actions,
+	}
+	if evaluation.Rule.ID != uuid.Nil {
+		dto.RuleID = evaluation.Rule.ID.String()
+	}
+	if evaluation.FiresAt != nil {
+		value := evaluation.FiresAt.UTC().Format(time.RFC3339)
+		dto.FiresAt = &value
+	}
+	return dto
+}
+
+func (h *EscalationHandler) parseRuleID(r *http.Request) (uuid.UUID, error) {
+	idParam := chi.URLParam(r, "ruleID")
+	ruleID, err := uuid.Parse(idParam)
+	if err != nil {
+		v := validation.NewValidator()
+		v.Custom("ruleID", false, "Invalid escalation rule ID")
+		return uuid.Nil, v.Errors()
+	}
+
+	return ruleID, nil
+}
+
+// getClaims extracts and validates user claims from the request context.
+func (h *EscalationHandler) getClaims(w http.ResponseWriter, r *http.Request) (*auth.Claims, bool) {
+	claims, ok := mw.GetClaims(r.Context())
+	if !ok {
+		WriteJSON(w, http.StatusUnauthorized, ErrorResponse{
+			Error: "Not authorized",
+			Code:  "UNAUTHORIZED",
+		})
+		return nil, false
+	}
+	return claims, true
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	mw "github.com/lorrc/service-desk-backend/internal/adapters/primary/http/middleware"
+	"github.com/lorrc/service-desk-backend/internal/adapters/primary/validation"
+	"github.com/lorrc/service-desk-backend/internal/auth"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// ReplayEventsRequest defines the JSON body for sending ticket events to
+// webhooks again. Events created from from up to to are replayed, for one
+// ticket if ticketId is given; after is the nextCursor of the previous
+// batch.
+type ReplayEventsRequest struct {
+	TicketID *int64    `json:"ticketId"`
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	After    int64     `json:"after"`
+}
+
+func (r *ReplayEventsRequest) Validate() error {
+	v := validation.NewValidator()
+
+	v.Custom("from", !r.From.IsZero(), "This field is required")
+	v.Custom("to", !r.To.IsZero(), "This field is required")
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
+// EventReplayDTO defines the JSON response for a replay. nextCursor is set
+// when more events match; pass it as after to replay the next batch.
+type EventReplayDTO struct {
+	Replayed   int    `json:"replayed"`
+	NextCursor *int64 `json:"nextCursor,omitempty"`
+}
+
+// EventReplayHandler handles HTTP requests for replaying ticket events.
+type EventReplayHandler struct {
+	replayService ports.EventReplayService
+	errorHandler  *ErrorHandler
+	logger        *slog.Logger
+}
+
+// NewEventReplayHandler creates a new EventReplayHandler.
+func NewEventReplayHandler(replayService ports.EventReplayService, errorHandler *ErrorHandler, logger *slog.Logger) *EventReplayHandler {
+	return &EventReplayHandler{
+		replayService: replayService,
+		errorHandler:  errorHandler,
+		logger:        logger.With("handler", "event_replay"),
+	}
+}
+
+// RegisterRoutes registers the /admin/events routes.
+func (h *EventReplayHandler) RegisterRoutes(r chi.Router) {
+	r.Post("/replay", h.HandleReplayEvents)
+}
+
+// HandleReplayEvents handles POST /admin/events/replay
+func (h *EventReplayHandler) HandleReplayEvents(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[ReplayEventsRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	filter, err := domain.NewEventReplayFilter(req.TicketID, req.From, req.To, req.After)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	result, err := h.replayService.ReplayEvents(r.Context(), claims.UserID, claims.OrgID, filter)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	h.logger.Info("ticket events replayed",
+		"org_id", claims.OrgID,
+		"user_id", claims.UserID,
+		"replayed", result.Replayed,
+		"more", result.NextCursor != nil,
+	)
+
+	WriteJSON(w, http.StatusAccepted, EventReplayDTO{
+		Replayed:   result.Replayed,
+		NextCursor: result.NextCursor,
+	})
+}
+
+// getClaims extracts and validates user claims from the request context.
+func (h *EventReplayHandler) getClaims(w http.ResponseWriter, r *http.Request) (*auth.Claims, bool) {
+	claims, ok := mw.GetClaims(r.Context())
+	if !ok {
+		WriteJSON(w, http.StatusUnauthorized, ErrorResponse{
+			Error: "Not authorized",
+			Code:  "UNAUTHORIZED",
+		})
+		return nil, false
+	}
+	return claims, true
+}
@@ -3,9 +3,14 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"runtime"
+	"sync/atomic"
 	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lorrc/service-desk-backend/internal/infrastructure/circuitbreaker"
 )
 
 // HealthChecker defines the interface for health check dependencies
@@ -13,22 +18,51 @@ type HealthChecker interface {
 	Ping(ctx context.Context) error
 }
 
+// PoolStatter is implemented by database pools that report connection
+// statistics, such as *pgxpool.Pool
+type PoolStatter interface {
+	Stat() *pgxpool.Stat
+}
+
+// SchemaVersioner reports the schema version the database has been
+// migrated to, and whether the last migration left it dirty
+type SchemaVersioner interface {
+	SchemaVersion(ctx context.Context) (version uint, dirty bool, err error)
+}
+
 // HealthHandler handles health check requests
 type HealthHandler struct {
 	db        HealthChecker
 	startTime time.Time
 	version   string
+	draining  atomic.Bool
+	breakers  []*circuitbreaker.Breaker
+
+	schema        SchemaVersioner
+	schemaVersion uint
 }
 
-// NewHealthHandler creates a new health handler
-func NewHealthHandler(db HealthChecker, version string) *HealthHandler {
+// NewHealthHandler creates a new health handler. The state of any breakers
+// given is reported by the detailed health check.
+func NewHealthHandler(db HealthChecker, version string, breakers ...*circuitbreaker.Breaker) *HealthHandler {
 	return &HealthHandler{
 		db:        db,
 		startTime: time.Now(),
 		version:   version,
+		breakers:  breakers,
 	}
 }
 
+// RequireSchemaVersion makes the readiness probe fail while the database
+// schema is older than version, the newest migration the binary was built
+// with, or left dirty by a failed migration. A newer schema passes, so
+// instances of the previous release stay ready while a rolling deploy
+// that migrated ahead of them completes.
+func (h *HealthHandler) RequireSchemaVersion(schema SchemaVersioner, version uint) {
+	h.schema = schema
+	h.schemaVersion = version
+}
+
 // HealthResponse represents the health check response
 type HealthResponse struct {
 	Status    string           `json:"status"`
@@ -45,6 +79,24 @@ type Check struct {
 	Latency string `json:"latency,omitempty"`
 }
 
+// PoolStats represents the database connection pool statistics. When
+// acquired_conns stays at max_conns and acquire waits grow, requests are
+// queueing for connections and DB_MAX_OPEN_CONNS is the bottleneck.
+type PoolStats struct {
+	MaxConns             int32   `json:"max_conns"`
+	TotalConns           int32   `json:"total_conns"`
+	AcquiredConns        int32   `json:"acquired_conns"`
+	IdleConns            int32   `json:"idle_conns"`
+	ConstructingConns    int32   `json:"constructing_conns"`
+	Utilization          float64 `json:"utilization"`
+	Saturated            bool    `json:"saturated"`
+	AcquireCount         int64   `json:"acquire_count"`
+	WaitedAcquireCount   int64   `json:"waited_acquire_count"`
+	CanceledAcquireCount int64   `json:"canceled_acquire_count"`
+	AcquireWaitTotal     string  `json:"acquire_wait_total"`
+	AcquireWaitAverage   string  `json:"acquire_wait_average"`
+}
+
 // HandleLiveness handles liveness probe requests (is the service running?)
 // Used by Kubernetes to know when to restart a container
 func (h *HealthHandler) HandleLiveness(w http.ResponseWriter, r *http.Request) {
@@ -58,6 +110,12 @@ func (h *HealthHandler) HandleLiveness(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(response)
 }
 
+// StartDraining makes the readiness probe fail from now on, so load
+// balancers stop sending new traffic before the server shuts down.
+func (h *HealthHandler) StartDraining() {
+	h.draining.Store(true)
+}
+
 // HandleReadiness handles readiness probe requests (can the service accept traffic?)
 // Used by Kubernetes to know when to add the pod to the service
 func (h *HealthHandler) HandleReadiness(w http.ResponseWriter, r *http.Request) {
@@ -67,11 +125,27 @@ func (h *HealthHandler) HandleReadiness(w http.ResponseWriter, r *http.Request)
 	checks := make(map[string]Check)
 	overallStatus := "healthy"
 
-	// Check database connectivity
-	dbCheck := h.checkDatabase(ctx)
-	checks["database"] = dbCheck
-	if dbCheck.Status != "healthy" {
+	if h.draining.Load() {
+		checks["shutdown"] = Check{
+			Status:  "unhealthy",
+			Message: "server is shutting down",
+		}
 		overallStatus = "unhealthy"
+	} else {
+		// Check database connectivity
+		dbCheck := h.checkDatabase(ctx)
+		checks["database"] = dbCheck
+		if dbCheck.Status != "healthy" {
+			overallStatus = "unhealthy"
+		}
+
+		if h.schema != nil {
+			schemaCheck := h.checkSchema(ctx)
+			checks["migrations"] = schemaCheck
+			if schemaCheck.Status != "healthy" {
+				overallStatus = "unhealthy"
+			}
+		}
 	}
 
 	response := HealthResponse{
@@ -107,6 +181,17 @@ func (h *HealthHandler) HandleHealth(w http.ResponseWriter, r *http.Request) {
 		overallStatus = "degraded"
 	}
 
+	// A dependency that keeps failing degrades the service even while the
+	// database answers
+	breakers := make([]circuitbreaker.Snapshot, 0, len(h.breakers))
+	for _, breaker := range h.breakers {
+		snapshot := breaker.Snapshot()
+		breakers = append(breakers, snapshot)
+		if snapshot.State != circuitbreaker.Closed.String() {
+			overallStatus = "degraded"
+		}
+	}
+
 	// Add memory stats
 	var memStats runtime.MemStats
 	runtime.ReadMemStats(&memStats)
@@ -119,7 +204,9 @@ func (h *HealthHandler) HandleHealth(w http.ResponseWriter, r *http.Request) {
 			Sys        uint64 `json:"sys_bytes"`
 			NumGC      uint32 `json:"num_gc"`
 		} `json:"memory"`
-		Goroutines int `json:"goroutines"`
+		Goroutines      int                       `json:"goroutines"`
+		DatabasePool    *PoolStats                `json:"database_pool,omitempty"`
+		CircuitBreakers []circuitbreaker.Snapshot `json:"circuit_breakers"`
 	}{
 		HealthResponse: HealthResponse{
 			Status:    overallStatus,
@@ -128,7 +215,9 @@ func (h *HealthHandler) HandleHealth(w http.ResponseWriter, r *http.Request) {
 			Uptime:    time.Since(h.startTime).Round(time.Second).String(),
 			Checks:    checks,
 		},
-		Goroutines: runtime.NumGoroutine(),
+		Goroutines:      runtime.NumGoroutine(),
+		DatabasePool:    h.poolStats(),
+		CircuitBreakers: breakers,
 	}
 	response.Memory.Alloc = memStats.Alloc
 	response.Memory.TotalAlloc = memStats.TotalAlloc
@@ -173,6 +262,72 @@ func (h *HealthHandler) checkDatabase(ctx context.Context) Check {
 	}
 }
 
+// checkSchema checks that the database schema has the migrations the
+// binary expects
+func (h *HealthHandler) checkSchema(ctx context.Context) Check {
+	start := time.Now()
+	version, dirty, err := h.schema.SchemaVersion(ctx)
+	latency := time.Since(start)
+
+	switch {
+	case err != nil:
+		return Check{
+			Status:  "unhealthy",
+			Message: err.Error(),
+			Latency: latency.String(),
+		}
+	case dirty:
+		return Check{
+			Status:  "unhealthy",
+			Message: fmt.Sprintf("migration %d is dirty", version),
+			Latency: latency.String(),
+		}
+	case version < h.schemaVersion:
+		return Check{
+			Status:  "unhealthy",
+			Message: fmt.Sprintf("migrations pending: schema is at version %d, expected %d", version, h.schemaVersion),
+			Latency: latency.String(),
+		}
+	}
+
+	return Check{
+		Status:  "healthy",
+		Message: fmt.Sprintf("schema is at version %d", version),
+		Latency: latency.String(),
+	}
+}
+
+// poolStats reports the database pool statistics, or nil if the database
+// does not expose them
+func (h *HealthHandler) poolStats() *PoolStats {
+	statter, ok := h.db.(PoolStatter)
+	if !ok {
+		return nil
+	}
+
+	stat := statter.Stat()
+	stats := &PoolStats{
+		MaxConns:             stat.MaxConns(),
+		TotalConns:           stat.TotalConns(),
+		AcquiredConns:        stat.AcquiredConns(),
+		IdleConns:            stat.IdleConns(),
+		ConstructingConns:    stat.ConstructingConns(),
+		Saturated:            stat.AcquiredConns() >= stat.MaxConns(),
+		AcquireCount:         stat.AcquireCount(),
+		WaitedAcquireCount:   stat.EmptyAcquireCount(),
+		CanceledAcquireCount: stat.CanceledAcquireCount(),
+		AcquireWaitTotal:     stat.EmptyAcquireWaitTime().String(),
+		AcquireWaitAverage:   time.Duration(0).String(),
+	}
+	if stats.MaxConns > 0 {
+		stats.Utilization = float64(stats.AcquiredConns) / float64(stats.MaxConns)
+	}
+	if stats.WaitedAcquireCount > 0 {
+		stats.AcquireWaitAverage = (stat.EmptyAcquireWaitTime() / time.Duration(stats.WaitedAcquireCount)).String()
+	}
+	return stats
+}
+
 // RegisterRoutes registers health check routes
 func (h *HealthHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/health", h.HandleHealth)
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePinger struct{}
+
+func (fakePinger) Ping(context.Context) error { return nil }
+
+type fakeSchemaVersioner struct {
+	version uint
+	dirty   bool
+	err     error
+}
+
+func (f fakeSchemaVersioner) SchemaVersion(context.Context) (uint, bool, error) {
+	return f.version, f.dirty, f.err
+}
+
+func TestHealthHandler_ReadinessChecksSchemaVersion(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema fakeSchemaVersioner
+		want   int
+	}{
+		{"current", fakeSchemaVersioner{version: 40}, stdhttp.StatusOK},
+		{"ahead", fakeSchemaVersioner{version: 41}, stdhttp.StatusOK},
+		{"pending", fakeSchemaVersioner{version: 39}, stdhttp.StatusServiceUnavailable},
+		{"dirty", fakeSchemaVersioner{version: 40, dirty: true}, stdhttp.StatusServiceUnavailable},
+		{"unreadable", fakeSchemaVersioner{err: errors.New("relation does not exist")}, stdhttp.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHealthHandler(fakePinger{}, "test")
+			h.RequireSchemaVersion(tt.schema, 40)
+
+			rec := httptest.NewRecorder()
+			h.HandleReadiness(rec, httptest.NewRequest(stdhttp.MethodGet, "/health/ready", nil))
+			assert.Equal(t, tt.want, rec.Code)
+
+			var resp HealthResponse
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+			assert.Contains(t, resp.Checks, "migrations")
+		})
+	}
+}
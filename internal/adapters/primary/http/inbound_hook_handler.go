@@ -0,0 +1,245 @@
+package http
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	mw "github.com/lorrc/service-desk-backend/internal/adapters/primary/http/middleware"
+	"github.com/lorrc/service-desk-backend/internal/adapters/primary/validation"
+	"github.com/lorrc/service-desk-backend/internal/auth"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// maxInboundHookPayload caps the size of a payload posted to an inbound hook
+const maxInboundHookPayload = 1 << 20
+
+// inboundHookSignatureHeaders are the headers a payload's signature is read
+// from, in order: our own, then those Sentry and Grafana sign with.
+var inboundHookSignatureHeaders = []string{
+	"X-Hook-Signature",
+	"Sentry-Hook-Signature",
+	"X-Grafana-Alerting-Signature",
+}
+
+// InboundHookHandler handles HTTP requests for inbound hooks: managing them
+// under /admin/inbound-hooks and receiving payloads under /hooks.
+type InboundHookHandler struct {
+	hookService  ports.InboundHookService
+	errorHandler *ErrorHandler
+	logger       *slog.Logger
+}
+
+// NewInboundHookHandler creates a new InboundHookHandler.
+func NewInboundHookHandler(hookService ports.InboundHookService, errorHandler *ErrorHandler, logger *slog.Logger) *InboundHookHandler {
+	return &InboundHookHandler{
+		hookService:  hookService,
+		errorHandler: errorHandler,
+		logger:       logger.With("handler", "inbound_hook"),
+	}
+}
+
+// RegisterRoutes registers the /admin/inbound-hooks routes.
+func (h *InboundHookHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.HandleListInboundHooks)
+	r.Post("/", h.HandleCreateInboundHook)
+	r.Delete("/{hookID}", h.HandleDeleteInboundHook)
+}
+
+// RegisterReceiverRoutes registers the public /hooks routes external systems
+// post to. Requests are authenticated by their signature.
+func (h *InboundHookHandler) RegisterReceiverRoutes(r chi.Router) {
+	r.Post("/{source}", h.HandleReceive)
+}
+
+// CreateInboundHookRequest defines the JSON body for registering an inbound hook.
+type CreateInboundHookRequest struct {
+	Source      string                    `json:"source"`
+	Format      string                    `json:"format"`
+	RequesterID string                    `json:"requesterId"`
+	Mapping     domain.InboundHookMapping `json:"mapping"`
+}
+
+func (r *CreateInboundHookRequest) Validate() error {
+	v := validation.NewValidator()
+
+	v.Required("source", r.Source)
+	v.Required("format", r.Format).
+		OneOf("format", r.Format, []string{
+			string(domain.InboundHookAlertmanager),
+			string(domain.InboundHookGrafana),
+			string(domain.InboundHookSentry),
+			string(domain.InboundHookGeneric),
+		})
+	v.Required("requesterId", r.RequesterID).
+		UUID("requesterId", r.RequesterID)
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
+// InboundHookDTO defines the JSON representation of an inbound hook.
+// The signing secret is only included in the response to its creation.
+type InboundHookDTO struct {
+	ID          string                    `json:"id"`
+	Source      string                    `json:"source"`
+	Format      string                    `json:"format"`
+	RequesterID string                    `json:"requesterId"`
+	Mapping     domain.InboundHookMapping `json:"mapping"`
+	IsActive    bool                      `json:"isActive"`
+	CreatedBy   string                    `json:"createdBy"`
+	CreatedAt   string                    `json:"createdAt"`
+	Secret      string                    `json:"secret,omitempty"`
+}
+
+// InboundHookReceiptDTO is the response to a payload posted to a hook.
+type InboundHookReceiptDTO struct {
+	TicketID *int64 `json:"ticketId"`
+	Ignored  bool   `json:"ignored"`
+}
+
+// HandleListInboundHooks handles GET /admin/inbound-hooks
+func (h *InboundHookHandler) HandleListInboundHooks(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	hooks, err := h.hookService.ListInboundHooks(r.Context(), claims.UserID, claims.OrgID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	response := make([]InboundHookDTO, 0, len(hooks))
+	for _, hook := range hooks {
+		response = append(response, toInboundHookDTO(hook))
+	}
+
+	WriteList(w, response)
+}
+
+// HandleCreateInboundHook handles POST /admin/inbound-hooks
+func (h *InboundHookHandler) HandleCreateInboundHook(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[CreateInboundHookRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	hook, err := h.hookService.CreateInboundHook(r.Context(), claims.UserID, claims.OrgID, domain.InboundHookParams{
+		Source:      req.Source,
+		Format:      domain.InboundHookFormat(req.Format),
+		Mapping:     req.Mapping,
+		RequesterID: uuid.MustParse(req.RequesterID),
+	})
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	response := toInboundHookDTO(hook)
+	response.Secret = hook.Secret
+
+	WriteCreated(w, response)
+}
+
+// HandleDeleteInboundHook handles DELETE /admin/inbound-hooks/{hookID}
+func (h *InboundHookHandler) HandleDeleteInboundHook(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	hookID, err := uuid.Parse(chi.URLParam(r, "hookID"))
+	if err != nil {
+		v := validation.NewValidator()
+		v.Custom("hookID", false, "Invalid inbound hook ID")
+		h.errorHandler.Handle(w, r, v.Errors())
+		return
+	}
+
+	if err := h.hookService.DeleteInboundHook(r.Context(), claims.UserID, claims.OrgID, hookID); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteNoContent(w)
+}
+
+// HandleReceive handles POST /hooks/{source}
+func (h *InboundHookHandler) HandleReceive(w http.ResponseWriter, r *http.Request) {
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxInboundHookPayload))
+	if err != nil {
+		h.errorHandler.Handle(w, r, apperrors.NewBadRequestError(err, "Payload is too large or could not be read"))
+		return
+	}
+
+	var signature string
+	for _, header := range inboundHookSignatureHeaders {
+		if signature = r.Header.Get(header); signature != "" {
+			break
+		}
+	}
+
+	source := chi.URLParam(r, "source")
+	ticket, err := h.hookService.Receive(r.Context(), source, signature, payload)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if ticket == nil {
+		WriteJSON(w, http.StatusAccepted, InboundHookReceiptDTO{Ignored: true})
+		return
+	}
+
+	h.logger.Info("ticket created from inbound hook",
+		"ticket_id", ticket.ID,
+		"source", source,
+	)
+	WriteCreated(w, InboundHookReceiptDTO{TicketID: &ticket.ID})
+}
+
+func toInboundHookDTO(hook *domain.InboundHook) InboundHookDTO {
+	return InboundHookDTO{
+		ID:          hook.ID.String(),
+		Source:      hook.Source,
+		Format:      string(hook.Format),
+		RequesterID: hook.RequesterID.String(),
+		Mapping:     hook.Mapping,
+		IsActive:    hook.IsActive,
+		CreatedBy:   hook.CreatedBy.String(),
+		CreatedAt:   hook.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// getClaims extracts and validates user claims from the request context.
+func (h *InboundHookHandler) getClaims(w http.ResponseWriter, r *http.Request) (*auth.Claims, bool) {
+	claims, ok := mw.GetClaims(r.Context())
+	if !ok {
+		WriteJSON(w, http.StatusUnauthorized, ErrorResponse{
+			Error: "Not authorized",
+			Code:  "UNAUTHORIZED",
+		})
+		return nil, false
+	}
+	return claims, true
+}
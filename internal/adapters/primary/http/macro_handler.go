@@ -0,0 +1,221 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	mw "github.com/lorrc/service-desk-backend/internal/adapters/primary/http/middleware"
+	"github.com/lorrc/service-desk-backend/internal/adapters/primary/validation"
+	"github.com/lorrc/service-desk-backend/internal/auth"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// MacroHandler handles HTTP requests for listing macros under /macros and
+// managing them under /admin/macros. Macros are applied through
+// POST /tickets/{ticketID}/macros/{macroID}/apply.
+type MacroHandler struct {
+	macroService ports.MacroService
+	errorHandler *ErrorHandler
+	logger       *slog.Logger
+}
+
+// NewMacroHandler creates a new MacroHandler.
+func NewMacroHandler(macroService ports.MacroService, errorHandler *ErrorHandler, logger *slog.Logger) *MacroHandler {
+	return &MacroHandler{
+		macroService: macroService,
+		errorHandler: errorHandler,
+		logger:       logger.With("handler", "macro"),
+	}
+}
+
+// RegisterRoutes registers the /macros routes.
+func (h *MacroHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.HandleListMacros)
+}
+
+// RegisterAdminRoutes registers the /admin/macros routes.
+func (h *MacroHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Post("/", h.HandleCreateMacro)
+	r.Put("/{macroID}", h.HandleUpdateMacro)
+	r.Delete("/{macroID}", h.HandleDeleteMacro)
+}
+
+// MacroRequest defines the JSON body for creating or replacing a macro.
+type MacroRequest struct {
+	Name    string               `json:"name"`
+	Actions []domain.MacroAction `json:"actions"`
+}
+
+func (r *MacroRequest) Validate() error {
+	v := validation.NewValidator()
+
+	v.Required("name", r.Name).
+		MaxLength("name", r.Name, domain.MaxMacroNameLength)
+	v.Custom("actions", len(r.Actions) > 0, "At least one action is required")
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
+func (r *MacroRequest) params() domain.MacroParams {
+	return domain.MacroParams{
+		Name:    r.Name,
+		Actions: r.Actions,
+	}
+}
+
+// MacroDTO defines the JSON representation of a macro.
+type MacroDTO struct {
+	ID        string               `json:"id"`
+	Name      string               `json:"name"`
+	Actions   []domain.MacroAction `json:"actions"`
+	CreatedBy string               `json:"createdBy"`
+	CreatedAt string               `json:"createdAt"`
+	UpdatedAt string               `json:"updatedAt"`
+}
+
+// HandleListMacros handles GET /macros
+func (h *MacroHandler) HandleListMacros(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	macros, err := h.macroService.ListMacros(r.Context(), claims.UserID, claims.OrgID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	response := make([]MacroDTO, 0, len(macros))
+	for _, macro := range macros {
+		response = append(response, toMacroDTO(macro))
+	}
+
+	WriteList(w, response)
+}
+
+// HandleCreateMacro handles POST /admin/macros
+func (h *MacroHandler) HandleCreateMacro(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[MacroRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	macro, err := h.macroService.CreateMacro(r.Context(), claims.UserID, claims.OrgID, req.params())
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteCreated(w, toMacroDTO(macro))
+}
+
+// HandleUpdateMacro handles PUT /admin/macros/{macroID}
+func (h *MacroHandler) HandleUpdateMacro(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	macroID, err := parseMacroID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[MacroRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	macro, err := h.macroService.UpdateMacro(r.Context(), claims.UserID, claims.OrgID, macroID, req.params())
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toMacroDTO(macro))
+}
+
+// HandleDeleteMacro handles DELETE /admin/macros/{macroID}
+func (h *MacroHandler) HandleDeleteMacro(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	macroID, err := parseMacroID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := h.macroService.DeleteMacro(r.Context(), claims.UserID, claims.OrgID, macroID); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteNoContent(w)
+}
+
+func toMacroDTO(macro *domain.Macro) MacroDTO {
+	return MacroDTO{
+		ID:        macro.ID.String(),
+		Name:      macro.Name,
+		Actions:   macro.Actions,
+		CreatedBy: macro.CreatedBy.String(),
+		CreatedAt: macro.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: macro.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// parseMacroID parses the macroID path parameter. The ticket handler uses
+// it too, to apply macros.
+func parseMacroID(r *http.Request) (uuid.UUID, error) {
+	idParam := chi.URLParam(r, "macroID")
+	macroID, err := uuid.Parse(idParam)
+	if err != nil {
+		v := validation.NewValidator()
+		v.Custom("macroID", false, "Invalid macro ID")
+		return uuid.Nil, v.Errors()
+	}
+
+	return macroID, nil
+}
+
+// getClaims extracts and validates user claims from the request context.
+func (h *MacroHandler) getClaims(w http.ResponseWriter, r *http.Request) (*auth.Claims, bool) {
+	claims, ok := mw.GetClaims(r.Context())
+	if !ok {
+		WriteJSON(w, http.StatusUnauthorized, ErrorResponse{
+			Error: "Not authorized",
+			Code:  "UNAUTHORIZED",
+		})
+		return nil, false
+	}
+	return claims, true
+}
@@ -1,14 +1,20 @@
 package http
 
 import (
+	"fmt"
 	"log/slog"
 	"net/http"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	mw "github.com/lorrc/service-desk-backend/internal/adapters/primary/http/middleware"
+	"github.com/lorrc/service-desk-backend/internal/adapters/primary/validation"
 	"github.com/lorrc/service-desk-backend/internal/auth"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
 	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/i18n"
 )
 
 // PermissionsResponse defines the JSON response for user permissions.
@@ -16,29 +22,286 @@ type PermissionsResponse struct {
 	Permissions []string `json:"permissions"`
 }
 
+// SMSPreferencesResponse defines the JSON response for SMS notification settings.
+type SMSPreferencesResponse struct {
+	PhoneNumber string `json:"phoneNumber"`
+	OptIn       bool   `json:"optIn"`
+}
+
+// UpdateSMSPreferencesRequest defines the JSON body for updating SMS notification settings.
+type UpdateSMSPreferencesRequest struct {
+	PhoneNumber string `json:"phoneNumber"`
+	OptIn       *bool  `json:"optIn"`
+}
+
+func (r *UpdateSMSPreferencesRequest) Validate() error {
+	v := validation.NewValidator()
+
+	v.NotNil("optIn", r.OptIn)
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
+// LocaleResponse defines the JSON response for the user's preferred locale.
+type LocaleResponse struct {
+	Locale    string   `json:"locale"`
+	Available []string `json:"available"`
+}
+
+// UpdateLocaleRequest defines the JSON body for changing the preferred locale.
+type UpdateLocaleRequest struct {
+	Locale string `json:"locale"`
+}
+
+func (r *UpdateLocaleRequest) Validate() error {
+	v := validation.NewValidator()
+
+	v.Required("locale", r.Locale)
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
+// UpdateProfileRequest defines the JSON body for a partial update of the
+// user's own profile. Omitted fields are left unchanged.
+type UpdateProfileRequest struct {
+	FullName    *string `json:"fullName"`
+	PhoneNumber *string `json:"phoneNumber"`
+	Timezone    *string `json:"timezone"`
+	Locale      *string `json:"locale"`
+}
+
+func (r *UpdateProfileRequest) Validate() error {
+	v := validation.NewValidator()
+
+	v.Custom("body", r.FullName != nil || r.PhoneNumber != nil || r.Timezone != nil || r.Locale != nil,
+		"At least one field must be changed")
+
+	if r.FullName != nil {
+		v.Required("fullName", *r.FullName).
+			MaxLength("fullName", *r.FullName, domain.MaxFullNameLength)
+	}
+	if r.Timezone != nil {
+		v.Required("timezone", *r.Timezone)
+	}
+	if r.Locale != nil {
+		v.Required("locale", *r.Locale)
+	}
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
+// ProfileResponse defines the JSON response for the user's own profile.
+type ProfileResponse struct {
+	ID          string `json:"id"`
+	FullName    string `json:"fullName"`
+	Email       string `json:"email"`
+	PhoneNumber string `json:"phoneNumber"`
+	SMSOptIn    bool   `json:"smsOptIn"`
+	Timezone    string `json:"timezone"`
+	Locale      string `json:"locale"`
+}
+
+// OrganizationDTO represents the organization a user belongs to.
+type OrganizationDTO struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	PortalSlug string `json:"portalSlug"`
+}
+
+// MeResponse defines the JSON response for everything clients load about
+// the signed-in user when they start.
+type MeResponse struct {
+	User             ProfileResponse          `json:"user"`
+	Roles            []string                 `json:"roles"`
+	Permissions      []string                 `json:"permissions"`
+	Organization     OrganizationDTO          `json:"organization"`
+	EmailPreferences EmailPreferencesResponse `json:"emailPreferences"`
+}
+
+// OutOfOfficeWindowDTO is a period the user is away.
+type OutOfOfficeWindowDTO struct {
+	StartsAt string `json:"startsAt"`
+	EndsAt   string `json:"endsAt"`
+	Note     string `json:"note"`
+}
+
+// AvailabilityResponse defines the JSON response for the user's
+// out-of-office windows.
+type AvailabilityResponse struct {
+	Windows          []OutOfOfficeWindowDTO `json:"windows"`
+	ReassignWhenAway bool                   `json:"reassignWhenAway"`
+	AwayNow          bool                   `json:"awayNow"`
+}
+
+// OutOfOfficeWindowRequest defines one window in UpdateAvailabilityRequest.
+type OutOfOfficeWindowRequest struct {
+	StartsAt time.Time `json:"startsAt"`
+	EndsAt   time.Time `json:"endsAt"`
+	Note     string    `json:"note"`
+}
+
+// UpdateAvailabilityRequest defines the JSON body for replacing the user's
+// out-of-office windows. With reassignWhenAway set, the user's open tickets
+// are flagged for reassignment while they are away.
+type UpdateAvailabilityRequest struct {
+	Windows          []OutOfOfficeWindowRequest `json:"windows"`
+	ReassignWhenAway bool                       `json:"reassignWhenAway"`
+}
+
+func (r *UpdateAvailabilityRequest) Validate() error {
+	v := validation.NewValidator()
+
+	for i, w := range r.Windows {
+		v.Custom("windows", !w.StartsAt.IsZero() && !w.EndsAt.IsZero(),
+			fmt.Sprintf("Window %d needs a startsAt and an endsAt", i+1))
+	}
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
+// ChangeEmailRequest defines the JSON body for changing the login email.
+// The current password confirms the request.
+type ChangeEmailRequest struct {
+	NewEmail string `json:"newEmail"`
+	Password string `json:"password"`
+}
+
+func (r *ChangeEmailRequest) Validate() error {
+	v := validation.NewValidator()
+
+	v.Required("newEmail", r.NewEmail).
+		Email("newEmail", r.NewEmail)
+	v.Required("password", r.Password)
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
+// ChangeEmailResponse defines the JSON response for a pending email change.
+type ChangeEmailResponse struct {
+	NewEmail string `json:"newEmail"`
+}
+
+// DeleteAccountRequest defines the JSON body for deleting the user's own
+// account. The current password confirms the request.
+type DeleteAccountRequest struct {
+	Password string `json:"password"`
+}
+
+func (r *DeleteAccountRequest) Validate() error {
+	v := validation.NewValidator()
+
+	v.Required("password", r.Password)
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
 // MeHandler handles HTTP requests for the authenticated user.
 type MeHandler struct {
-	authzService ports.AuthorizationService
-	errorHandler *ErrorHandler
-	logger       *slog.Logger
+	authzService      ports.AuthorizationService
+	profileService    ports.ProfileService
+	dataExportService ports.DataExportService
+	teamService       ports.TeamService
+	errorHandler      *ErrorHandler
+	logger            *slog.Logger
 }
 
 // NewMeHandler creates a new MeHandler.
 func NewMeHandler(
 	authzService ports.AuthorizationService,
+	profileService ports.ProfileService,
+	dataExportService ports.DataExportService,
+	teamService ports.TeamService,
 	errorHandler *ErrorHandler,
 	logger *slog.Logger,
 ) *MeHandler {
 	return &MeHandler{
-		authzService: authzService,
-		errorHandler: errorHandler,
-		logger:       logger.With("handler", "me"),
+		authzService:      authzService,
+		profileService:    profileService,
+		dataExportService: dataExportService,
+		teamService:       teamService,
+		errorHandler:      errorHandler,
+		logger:            logger.With("handler", "me"),
 	}
 }
 
 // RegisterRoutes registers the /me routes.
 func (h *MeHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.HandleGetMe)
 	r.Get("/permissions", h.HandlePermissions)
+	r.Get("/sms", h.HandleGetSMSPreferences)
+	r.Put("/sms", h.HandleUpdateSMSPreferences)
+	r.Get("/locale", h.HandleGetLocale)
+	r.Put("/locale", h.HandleUpdateLocale)
+	r.Get("/availability", h.HandleGetAvailability)
+	r.Put("/availability", h.HandleUpdateAvailability)
+	r.Get("/teams", h.HandleListMyTeams)
+	r.Post("/email", h.HandleChangeEmail)
+	r.Patch("/", h.HandleUpdateProfile)
+	r.Delete("/", h.HandleDeleteAccount)
+	r.Post("/export", h.HandleRequestExport)
+	r.Get("/export", h.HandleGetExport)
+	r.Get("/export/download", h.HandleDownloadExport)
+}
+
+// HandleGetMe handles GET /me, returning the profile, roles, permissions,
+// organization and email preferences of the user in one response.
+func (h *MeHandler) HandleGetMe(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	me, err := h.profileService.GetCurrentUser(r.Context(), claims.UserID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	roles, permissions := me.Roles, me.Permissions
+	if roles == nil {
+		roles = []string{}
+	}
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	sort.Strings(roles)
+	sort.Strings(permissions)
+
+	WriteJSON(w, http.StatusOK, MeResponse{
+		User:             toProfileResponse(me.User),
+		Roles:            roles,
+		Permissions:      permissions,
+		Organization:     toOrganizationDTO(me.Organization),
+		EmailPreferences: toEmailPreferencesResponse(me.EmailPreferences),
+	})
+}
+
+// toOrganizationDTO converts a domain organization to a DTO.
+func toOrganizationDTO(org *domain.Organization) OrganizationDTO {
+	return OrganizationDTO{
+		ID:         org.ID.String(),
+		Name:       org.Name,
+		PortalSlug: org.PortalSlug,
+	}
 }
 
 // HandlePermissions handles GET /me/permissions.
@@ -65,6 +328,349 @@ func (h *MeHandler) HandlePermissions(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// HandleGetSMSPreferences handles GET /me/sms.
+func (h *MeHandler) HandleGetSMSPreferences(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	prefs, err := h.profileService.GetSMSPreferences(r.Context(), claims.UserID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, SMSPreferencesResponse{
+		PhoneNumber: prefs.PhoneNumber,
+		OptIn:       prefs.OptIn,
+	})
+}
+
+// HandleUpdateSMSPreferences handles PUT /me/sms.
+func (h *MeHandler) HandleUpdateSMSPreferences(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[UpdateSMSPreferencesRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	prefs, err := h.profileService.UpdateSMSPreferences(r.Context(), claims.UserID, domain.SMSPreferences{
+		PhoneNumber: req.PhoneNumber,
+		OptIn:       *req.OptIn,
+	})
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, SMSPreferencesResponse{
+		PhoneNumber: prefs.PhoneNumber,
+		OptIn:       prefs.OptIn,
+	})
+}
+
+// HandleGetLocale handles GET /me/locale.
+func (h *MeHandler) HandleGetLocale(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	locale, err := h.profileService.GetLocale(r.Context(), claims.UserID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, LocaleResponse{
+		Locale:    locale,
+		Available: i18n.Locales(),
+	})
+}
+
+// HandleUpdateLocale handles PUT /me/locale.
+func (h *MeHandler) HandleUpdateLocale(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[UpdateLocaleRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	locale, err := h.profileService.UpdateLocale(r.Context(), claims.UserID, req.Locale)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, LocaleResponse{
+		Locale:    locale,
+		Available: i18n.Locales(),
+	})
+}
+
+// HandleUpdateProfile handles PATCH /me.
+func (h *MeHandler) HandleUpdateProfile(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[UpdateProfileRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	user, err := h.profileService.UpdateProfile(r.Context(), claims.UserID, domain.ProfileChanges{
+		FullName:    req.FullName,
+		PhoneNumber: req.PhoneNumber,
+		Timezone:    req.Timezone,
+		Locale:      req.Locale,
+	})
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toProfileResponse(user))
+}
+
+func toProfileResponse(user *domain.User) ProfileResponse {
+	return ProfileResponse{
+		ID:          user.ID.String(),
+		FullName:    user.FullName,
+		Email:       user.Email,
+		PhoneNumber: user.PhoneNumber,
+		SMSOptIn:    user.SMSOptIn,
+		Timezone:    user.Timezone,
+		Locale:      user.Locale,
+	}
+}
+
+// HandleGetAvailability handles GET /me/availability.
+func (h *MeHandler) HandleGetAvailability(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	availability, err := h.profileService.GetAvailability(r.Context(), claims.UserID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toAvailabilityResponse(availability))
+}
+
+// HandleUpdateAvailability handles PUT /me/availability.
+func (h *MeHandler) HandleUpdateAvailability(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[UpdateAvailabilityRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	windows := make([]domain.OutOfOfficeWindow, 0, len(req.Windows))
+	for _, window := range req.Windows {
+		windows = append(windows, domain.OutOfOfficeWindow{
+			StartsAt: window.StartsAt,
+			EndsAt:   window.EndsAt,
+			Note:     window.Note,
+		})
+	}
+
+	availability, err := h.profileService.UpdateAvailability(r.Context(), claims.UserID, domain.Availability{
+		Windows:          windows,
+		ReassignWhenAway: req.ReassignWhenAway,
+	})
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toAvailabilityResponse(availability))
+}
+
+func toAvailabilityResponse(availability *domain.Availability) AvailabilityResponse {
+	windows := make([]OutOfOfficeWindowDTO, 0, len(availability.Windows))
+	for _, window := range availability.Windows {
+		windows = append(windows, OutOfOfficeWindowDTO{
+			StartsAt: window.StartsAt.UTC().Format(time.RFC3339),
+			EndsAt:   window.EndsAt.UTC().Format(time.RFC3339),
+			Note:     window.Note,
+		})
+	}
+
+	return AvailabilityResponse{
+		Windows:          windows,
+		ReassignWhenAway: availability.ReassignWhenAway,
+		AwayNow:          availability.AwayAt(time.Now()),
+	}
+}
+
+// HandleListMyTeams handles GET /me/teams, listing the teams whose queues
+// the user can claim tickets from.
+func (h *MeHandler) HandleListMyTeams(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	teams, err := h.teamService.ListMyTeams(r.Context(), claims.UserID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	response := make([]TeamDTO, 0, len(teams))
+	for _, team := range teams {
+		response = append(response, toTeamDTO(team))
+	}
+
+	WriteList(w, response)
+}
+
+// HandleChangeEmail handles POST /me/email. The email only changes once the
+// link sent to the new address is confirmed.
+func (h *MeHandler) HandleChangeEmail(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[ChangeEmailRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := h.profileService.RequestEmailChange(r.Context(), claims.UserID, req.NewEmail, req.Password); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusAccepted, ChangeEmailResponse{NewEmail: strings.TrimSpace(req.NewEmail)})
+}
+
+// HandleDeleteAccount handles DELETE /me.
+func (h *MeHandler) HandleDeleteAccount(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[DeleteAccountRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := h.profileService.DeleteAccount(r.Context(), claims.UserID, req.Password); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteNoContent(w)
+}
+
+// HandleRequestExport handles POST /me/export. The export is built in the
+// background and the user is notified when it is ready.
+func (h *MeHandler) HandleRequestExport(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	export, err := h.dataExportService.RequestOwnExport(r.Context(), claims.UserID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusAccepted, toDataExportDTO(export))
+}
+
+// HandleGetExport handles GET /me/export.
+func (h *MeHandler) HandleGetExport(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	export, err := h.dataExportService.GetOwnExport(r.Context(), claims.UserID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toDataExportDTO(export))
+}
+
+// HandleDownloadExport handles GET /me/export/download.
+func (h *MeHandler) HandleDownloadExport(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	export, err := h.dataExportService.GetOwnExport(r.Context(), claims.UserID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := writeDataExportDownload(w, export); err != nil {
+		h.errorHandler.Handle(w, r, err)
+	}
+}
+
 // getClaims extracts and validates user claims from the request context.
 func (h *MeHandler) getClaims(w http.ResponseWriter, r *http.Request) (*auth.Claims, bool) {
 	claims, ok := mw.GetClaims(r.Context())
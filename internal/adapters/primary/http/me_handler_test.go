@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	mw "github.com/lorrc/service-desk-backend/internal/adapters/primary/http/middleware"
 	pgadapter "github.com/lorrc/service-desk-backend/internal/adapters/secondary/postgres"
 	"github.com/lorrc/service-desk-backend/internal/auth"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
 	"github.com/lorrc/service-desk-backend/internal/core/services"
 )
 
@@ -86,12 +88,48 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
+func TestMe(t *testing.T) {
+	ctx := context.Background()
+	authRepo := pgadapter.NewAuthorizationRepository(testPool)
+	userRepo := pgadapter.NewUserRepository(testPool)
+	defaultOrgID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), defaultOrgID)
+
+	userEmail := uuid.NewString() + "@example.com"
+	user, err := authService.Register(ctx, "Test User", userEmail, "Password1", "agent", uuid.Nil)
+	require.NoError(t, err)
+
+	router, tokenManager := newMeRouter()
+	token, err := tokenManager.GenerateToken(user.ID, user.OrganizationID)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(stdhttp.MethodGet, "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	require.Equal(t, stdhttp.StatusOK, recorder.Code)
+
+	var response MeResponse
+	err = json.NewDecoder(recorder.Body).Decode(&response)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID.String(), response.User.ID)
+	assert.Equal(t, userEmail, response.User.Email)
+	assert.Equal(t, []string{"agent"}, response.Roles)
+	assert.Contains(t, response.Permissions, "tickets:assign")
+	assert.Equal(t, defaultOrgID.String(), response.Organization.ID)
+	assert.Equal(t, "Default Organization", response.Organization.Name)
+	assert.False(t, response.EmailPreferences.Unsubscribed)
+	assert.Empty(t, response.EmailPreferences.MutedTicketIDs)
+}
+
 func TestMePermissions(t *testing.T) {
 	ctx := context.Background()
 	authRepo := pgadapter.NewAuthorizationRepository(testPool)
 	userRepo := pgadapter.NewUserRepository(testPool)
 	defaultOrgID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
-	authService := services.NewAuthService(userRepo, authRepo, defaultOrgID)
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), defaultOrgID)
 
 	userEmail := uuid.NewString() + "@example.com"
 	user, err := authService.Register(ctx, "Test User", userEmail, "Password1", "admin", uuid.Nil)
@@ -132,12 +170,216 @@ func TestMePermissions_Unauthorized(t *testing.T) {
 	require.Equal(t, stdhttp.StatusUnauthorized, recorder.Code)
 }
 
+func TestMeSMSPreferences(t *testing.T) {
+	ctx := context.Background()
+	authRepo := pgadapter.NewAuthorizationRepository(testPool)
+	userRepo := pgadapter.NewUserRepository(testPool)
+	defaultOrgID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), defaultOrgID)
+
+	user, err := authService.Register(ctx, "Test User", uuid.NewString()+"@example.com", "Password1", "agent", uuid.Nil)
+	require.NoError(t, err)
+
+	router, tokenManager := newMeRouter()
+	token, err := tokenManager.GenerateToken(user.ID, user.OrganizationID)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(stdhttp.MethodPut, "/me/sms", strings.NewReader(`{"phoneNumber":"+14155550123","optIn":true}`))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, stdhttp.StatusOK, recorder.Code)
+
+	req = httptest.NewRequest(stdhttp.MethodGet, "/me/sms", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, stdhttp.StatusOK, recorder.Code)
+
+	var response SMSPreferencesResponse
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&response))
+	assert.Equal(t, "+14155550123", response.PhoneNumber)
+	assert.True(t, response.OptIn)
+
+	req = httptest.NewRequest(stdhttp.MethodPut, "/me/sms", strings.NewReader(`{"phoneNumber":"","optIn":true}`))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, stdhttp.StatusUnprocessableEntity, recorder.Code)
+}
+
+func TestMeLocale(t *testing.T) {
+	ctx := context.Background()
+	authRepo := pgadapter.NewAuthorizationRepository(testPool)
+	userRepo := pgadapter.NewUserRepository(testPool)
+	defaultOrgID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), defaultOrgID)
+
+	user, err := authService.Register(ctx, "Test User", uuid.NewString()+"@example.com", "Password1", "agent", uuid.Nil)
+	require.NoError(t, err)
+
+	router, tokenManager := newMeRouter()
+	token, err := tokenManager.GenerateToken(user.ID, user.OrganizationID)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(stdhttp.MethodPut, "/me/locale", strings.NewReader(`{"locale":"es-MX"}`))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, stdhttp.StatusOK, recorder.Code)
+
+	req = httptest.NewRequest(stdhttp.MethodGet, "/me/locale", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, stdhttp.StatusOK, recorder.Code)
+
+	var response LocaleResponse
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&response))
+	assert.Equal(t, "es", response.Locale)
+	assert.Contains(t, response.Available, "en")
+
+	req = httptest.NewRequest(stdhttp.MethodPut, "/me/locale", strings.NewReader(`{"locale":"xx"}`))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, stdhttp.StatusUnprocessableEntity, recorder.Code)
+}
+
+func TestMeDeleteAccount(t *testing.T) {
+	ctx := context.Background()
+	authRepo := pgadapter.NewAuthorizationRepository(testPool)
+	userRepo := pgadapter.NewUserRepository(testPool)
+	defaultOrgID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), defaultOrgID)
+
+	email := uuid.NewString() + "@example.com"
+	user, err := authService.Register(ctx, "Test User", email, "Password1", "customer", uuid.Nil)
+	require.NoError(t, err)
+
+	router, tokenManager := newMeRouter()
+	token, err := tokenManager.GenerateToken(user.ID, user.OrganizationID)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(stdhttp.MethodDelete, "/me", strings.NewReader(`{"password":"WrongPassword1"}`))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, stdhttp.StatusUnauthorized, recorder.Code)
+
+	req = httptest.NewRequest(stdhttp.MethodDelete, "/me", strings.NewReader(`{"password":"Password1"}`))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, stdhttp.StatusNoContent, recorder.Code)
+
+	_, err = authService.Login(ctx, user.OrganizationID, email, "Password1")
+	require.Error(t, err)
+
+	anonymized, err := userRepo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.AnonymizedUserName, anonymized.FullName)
+	assert.False(t, anonymized.IsActive)
+}
+
+func TestMeDataExport(t *testing.T) {
+	ctx := context.Background()
+	authRepo := pgadapter.NewAuthorizationRepository(testPool)
+	userRepo := pgadapter.NewUserRepository(testPool)
+	defaultOrgID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	authService := services.NewAuthService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool), newTestQuotaService(), defaultOrgID)
+
+	user, err := authService.Register(ctx, "Export User", uuid.NewString()+"@example.com", "Password1", "customer", uuid.Nil)
+	require.NoError(t, err)
+
+	router, tokenManager := newMeRouter()
+	token, err := tokenManager.GenerateToken(user.ID, user.OrganizationID)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(stdhttp.MethodGet, "/me/export", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, stdhttp.StatusNotFound, recorder.Code)
+
+	req = httptest.NewRequest(stdhttp.MethodPost, "/me/export", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, stdhttp.StatusAccepted, recorder.Code)
+
+	var requested DataExportDTO
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&requested))
+	assert.Equal(t, string(domain.DataExportPending), requested.Status)
+
+	req = httptest.NewRequest(stdhttp.MethodGet, "/me/export/download", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, stdhttp.StatusConflict, recorder.Code)
+
+	// Build the export as the background job would.
+	job := services.NewDataExportJob(
+		pgadapter.NewDataExportRepository(testPool),
+		pgadapter.NewNotificationOutboxRepository(testPool),
+		pgadapter.NewTransactionManager(testPool),
+		services.DataExportConfig{PollInterval: time.Second, Retention: time.Hour},
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+	for {
+		n, err := job.ProcessNext(ctx)
+		require.NoError(t, err)
+		if n == 0 {
+			break
+		}
+	}
+
+	req = httptest.NewRequest(stdhttp.MethodGet, "/me/export", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, stdhttp.StatusOK, recorder.Code)
+
+	var ready DataExportDTO
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&ready))
+	assert.Equal(t, requested.ID, ready.ID)
+	assert.Equal(t, string(domain.DataExportReady), ready.Status)
+	assert.NotNil(t, ready.ExpiresAt)
+
+	req = httptest.NewRequest(stdhttp.MethodGet, "/me/export/download", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, stdhttp.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Header().Get("Content-Disposition"), "attachment")
+
+	var data domain.UserData
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&data))
+	assert.Equal(t, user.ID, data.Profile.ID)
+	assert.Equal(t, "Export User", data.Profile.FullName)
+	assert.Contains(t, data.Profile.Roles, "customer")
+}
+
 func newMeRouter() (*chi.Mux, *auth.TokenManager) {
 	authRepo := pgadapter.NewAuthorizationRepository(testPool)
 	authzService := services.NewAuthorizationService(authRepo)
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	errorHandler := NewErrorHandler(logger)
-	meHandler := NewMeHandler(authzService, errorHandler, logger)
+	userRepo := pgadapter.NewUserRepository(testPool)
+	auditRepo := pgadapter.NewAuditLogRepository(testPool)
+	txManager := pgadapter.NewTransactionManager(testPool)
+	profileService := services.NewProfileService(userRepo, authRepo, pgadapter.NewOrgSettingsRepository(testPool),
+		pgadapter.NewEmailPreferenceRepository(testPool), auditRepo, pgadapter.NewNotificationOutboxRepository(testPool),
+		txManager, services.EmailChangeConfig{})
+	dataExportService := services.NewDataExportService(pgadapter.NewDataExportRepository(testPool), userRepo, authzService, auditRepo, txManager)
+	teamService := services.NewTeamService(pgadapter.NewTeamRepository(testPool), userRepo, authzService, auditRepo, txManager)
+	meHandler := NewMeHandler(authzService, profileService, dataExportService, teamService, errorHandler, logger)
 	tokenManager := auth.NewTokenManager("test-secret", time.Hour)
 
 	router := chi.NewRouter()
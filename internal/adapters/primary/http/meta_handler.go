@@ -0,0 +1,54 @@
+package http
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+// MetaHandler serves information about the API itself.
+type MetaHandler struct{}
+
+// NewMetaHandler creates a new MetaHandler.
+func NewMetaHandler() *MetaHandler {
+	return &MetaHandler{}
+}
+
+// RegisterRoutes registers the /meta routes.
+func (h *MetaHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/error-codes", h.HandleListErrorCodes)
+	r.Get("/event-types", h.HandleListEventTypes)
+}
+
+// HandleListErrorCodes handles GET /meta/error-codes
+func (h *MetaHandler) HandleListErrorCodes(w http.ResponseWriter, r *http.Request) {
+	WriteList(w, apperrors.Codes())
+}
+
+// EventTypeDTO describes a type of ticket event. Payload names the schema of
+// its payload in the OpenAPI document.
+type EventTypeDTO struct {
+	Type         string `json:"type"`
+	Version      int    `json:"version"`
+	WebhookEvent string `json:"webhookEvent"`
+	Description  string `json:"description"`
+	Payload      string `json:"payload"`
+}
+
+// HandleListEventTypes handles GET /meta/event-types
+func (h *MetaHandler) HandleListEventTypes(w http.ResponseWriter, r *http.Request) {
+	types := make([]EventTypeDTO, 0, len(domain.EventCatalog))
+	for _, def := range domain.EventCatalog {
+		types = append(types, EventTypeDTO{
+			Type:         string(def.Type),
+			Version:      def.Version,
+			WebhookEvent: string(def.Webhook),
+			Description:  def.Description,
+			Payload:      reflect.TypeOf(def.Payload).Name(),
+		})
+	}
+	WriteList(w, types)
+}
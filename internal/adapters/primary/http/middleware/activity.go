@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// ActivityRecorder notes that a user is active. Touch is called on every
+// authenticated request, so it must be cheap and must not block.
+type ActivityRecorder interface {
+	Touch(userID uuid.UUID)
+}
+
+// Activity records that the authenticated user is active, so their last
+// active time stays current without them logging in again. A super-admin
+// impersonating the user counts as the super-admin's activity instead. It
+// must run after SessionMiddleware, so that only valid sessions count.
+func Activity(recorder ActivityRecorder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if claims, ok := GetClaims(r.Context()); ok {
+				if claims.ImpersonatorID != nil {
+					recorder.Touch(*claims.ImpersonatorID)
+				} else {
+					recorder.Touch(claims.UserID)
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
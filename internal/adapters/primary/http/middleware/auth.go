@@ -2,10 +2,14 @@ package middleware
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"strings"
 
 	"github.com/lorrc/service-desk-backend/internal/auth"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/i18n"
 )
 
 // UserClaimsKey is the key used to store user claims in the request context.
@@ -19,20 +23,20 @@ func JWTMiddleware(tm *auth.TokenManager) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
 			if authHeader == "" {
-				writeJSONError(w, http.StatusUnauthorized, "Authorization header is required", "UNAUTHORIZED")
+				writeJSONError(w, r, "error.missing_auth_header", apperrors.CodeUnauthorized)
 				return
 			}
 
 			parts := strings.Split(authHeader, " ")
 			if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
-				writeJSONError(w, http.StatusUnauthorized, "Authorization header format must be Bearer {token}", "INVALID_AUTH_FORMAT")
+				writeJSONError(w, r, "error.invalid_auth_format", apperrors.CodeInvalidAuthFormat)
 				return
 			}
 
 			tokenString := parts[1]
 			claims, err := tm.ValidateToken(tokenString)
 			if err != nil {
-				writeJSONError(w, http.StatusUnauthorized, "Invalid or expired token", "INVALID_TOKEN")
+				writeJSONError(w, r, "error.invalid_token", apperrors.CodeInvalidToken)
 				return
 			}
 
@@ -43,6 +47,12 @@ func JWTMiddleware(tm *auth.TokenManager) func(http.Handler) http.Handler {
 			ctx = context.WithValue(ctx, contextKey("user_id"), claims.UserID.String())
 			ctx = context.WithValue(ctx, contextKey("org_id"), claims.OrgID.String())
 
+			// Changes made on an impersonation token are attributed to the
+			// super-admin in the audit log as well.
+			if claims.ImpersonatorID != nil {
+				ctx = domain.WithImpersonator(ctx, *claims.ImpersonatorID)
+			}
+
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -91,9 +101,13 @@ func OptionalJWTMiddleware(tm *auth.TokenManager) func(http.Handler) http.Handle
 	}
 }
 
-// writeJSONError writes a JSON error response
-func writeJSONError(w http.ResponseWriter, status int, message, code string) {
+// writeJSONError writes a JSON error response, with the message for
+// messageKey translated into the request's locale
+func writeJSONError(w http.ResponseWriter, r *http.Request, messageKey string, code apperrors.ErrorCode) {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	_, _ = w.Write([]byte(`{"error":"` + message + `","code":"` + code + `"}`))
+	w.WriteHeader(code.Status)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"error": i18n.T(GetLocale(r.Context()), messageKey),
+		"code":  code.Code,
+	})
 }
@@ -0,0 +1,267 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CompressionConfig holds response compression configuration
+type CompressionConfig struct {
+	MinSize      int      // Responses smaller than this are sent as is
+	Level        int      // gzip compression level
+	ContentTypes []string // Media types that may be compressed
+	ExemptPaths  []string // Path prefixes that are never compressed
+}
+
+// DefaultCompressionConfig returns a sensible default configuration
+func DefaultCompressionConfig() CompressionConfig {
+	return CompressionConfig{
+		MinSize: 1024,
+		Level:   gzip.DefaultCompression,
+		ContentTypes: []string{
+			"application/json",
+			"application/problem+json",
+			"text/csv",
+			"text/plain",
+		},
+	}
+}
+
+// Compression returns a middleware that gzips responses of a whitelisted
+// content type once they reach the configured size. Smaller responses are
+// buffered and sent uncompressed, as the gzip overhead outweighs the saving.
+// Connection upgrades (websockets) and exempt paths pass straight through.
+func Compression(cfg CompressionConfig) func(http.Handler) http.Handler {
+	if cfg.MinSize < 0 {
+		cfg.MinSize = 0
+	}
+	if cfg.Level < gzip.HuffmanOnly || cfg.Level > gzip.BestCompression {
+		cfg.Level = gzip.DefaultCompression
+	}
+
+	types := make(map[string]bool, len(cfg.ContentTypes))
+	for _, t := range cfg.ContentTypes {
+		types[strings.ToLower(t)] = true
+	}
+
+	pool := &sync.Pool{
+		New: func() any {
+			gz, _ := gzip.NewWriterLevel(nil, cfg.Level)
+			return gz
+		},
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !shouldCompress(r, cfg.ExemptPaths) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{
+				ResponseWriter: w,
+				minSize:        cfg.MinSize,
+				types:          types,
+				pool:           pool,
+				statusCode:     http.StatusOK,
+			}
+			defer cw.Close()
+
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// shouldCompress reports whether the request accepts gzip and is not an
+// upgrade, HEAD request or exempt path.
+func shouldCompress(r *http.Request, exempt []string) bool {
+	if r.Method == http.MethodHead {
+		return false
+	}
+	if r.Header.Get("Upgrade") != "" ||
+		strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade") {
+		return false
+	}
+	for _, prefix := range exempt {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			return false
+		}
+	}
+	return acceptsGzip(r.Header.Get("Accept-Encoding"))
+}
+
+// acceptsGzip reports whether an Accept-Encoding header allows gzip, honouring
+// an explicit q=0.
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding != "gzip" && coding != "*" {
+			continue
+		}
+		q := 1.0
+		if name, value, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(name) == "q" {
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				q = parsed
+			}
+		}
+		return q > 0
+	}
+	return false
+}
+
+// compressWriter buffers the start of a response until it knows whether the
+// response is worth compressing, then either gzips or passes it through.
+type compressWriter struct {
+	http.ResponseWriter
+	minSize    int
+	types      map[string]bool
+	pool       *sync.Pool
+	statusCode int
+
+	buf         bytes.Buffer
+	wroteHeader bool
+	decided     bool
+	gz          *gzip.Writer
+}
+
+func (cw *compressWriter) WriteHeader(code int) {
+	if cw.wroteHeader {
+		return
+	}
+	cw.wroteHeader = true
+	cw.statusCode = code
+
+	// Informational and bodyless responses are never compressed
+	if code < http.StatusOK || code == http.StatusNoContent || code == http.StatusNotModified {
+		cw.decided = true
+		cw.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (cw *compressWriter) Write(b []byte) (int, error) {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if cw.decided {
+		if cw.gz != nil {
+			return cw.gz.Write(b)
+		}
+		return cw.ResponseWriter.Write(b)
+	}
+
+	cw.buf.Write(b)
+	if cw.buf.Len() >= cw.minSize {
+		if err := cw.decide(); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+// decide picks gzip or passthrough based on what has been buffered so far,
+// sends the headers and flushes the buffer.
+func (cw *compressWriter) decide() error {
+	cw.decided = true
+	h := cw.Header()
+
+	if cw.compressible() {
+		h.Add("Vary", "Accept-Encoding")
+		if cw.buf.Len() >= cw.minSize {
+			h.Set("Content-Encoding", "gzip")
+			h.Del("Content-Length")
+
+			gz := cw.pool.Get().(*gzip.Writer)
+			gz.Reset(cw.ResponseWriter)
+			cw.gz = gz
+		}
+	}
+
+	cw.ResponseWriter.WriteHeader(cw.statusCode)
+
+	if cw.buf.Len() == 0 {
+		return nil
+	}
+	var err error
+	if cw.gz != nil {
+		_, err = cw.gz.Write(cw.buf.Bytes())
+	} else {
+		_, err = cw.ResponseWriter.Write(cw.buf.Bytes())
+	}
+	cw.buf.Reset()
+	return err
+}
+
+// compressible reports whether the response content type is whitelisted and
+// the handler has not already encoded it.
+func (cw *compressWriter) compressible() bool {
+	h := cw.Header()
+	if h.Get("Content-Encoding") != "" {
+		return false
+	}
+	contentType := h.Get("Content-Type")
+	if contentType == "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return cw.types[mediaType]
+}
+
+// Close sends any buffered response and finishes the gzip stream.
+func (cw *compressWriter) Close() {
+	if !cw.decided {
+		if !cw.wroteHeader {
+			// Nothing was written; let net/http send its implicit 200.
+			return
+		}
+		_ = cw.decide()
+	}
+	if cw.gz != nil {
+		_ = cw.gz.Close()
+		cw.gz.Reset(nil)
+		cw.pool.Put(cw.gz)
+		cw.gz = nil
+	}
+}
+
+// Flush implements http.Flusher. Flushing commits to a decision, so streamed
+// responses below the threshold are sent uncompressed.
+func (cw *compressWriter) Flush() {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if !cw.decided {
+		_ = cw.decide()
+	}
+	if cw.gz != nil {
+		_ = cw.gz.Flush()
+	}
+	if f, ok := cw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker when supported by the underlying writer.
+func (cw *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := cw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response does not implement http.Hijacker")
+	}
+	return hijacker.Hijack()
+}
+
+// Unwrap returns the underlying ResponseWriter for http.ResponseController.
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
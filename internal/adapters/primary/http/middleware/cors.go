@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// AllowOrigins returns a CORS origin check that consults origins on every
+// request, so the allowed origins can be changed without a restart. "*"
+// allows any origin.
+func AllowOrigins(origins func() []string) func(r *http.Request, origin string) bool {
+	return func(r *http.Request, origin string) bool {
+		for _, allowed := range origins() {
+			if allowed == "*" || strings.EqualFold(allowed, origin) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+// FaultInjectedHeader names the fault injected into a response, "latency"
+// or "error", so clients can tell injected faults from real ones.
+const FaultInjectedHeader = "X-Fault-Injected"
+
+// FaultRule is the fault injected into requests under a path prefix.
+type FaultRule struct {
+	PathPrefix string
+	Latency    time.Duration // Added before the request is handled
+	ErrorRate  float64       // Share of requests failed, 0 to 1
+}
+
+// FaultInjection returns a middleware that delays and fails requests by
+// the rule with the longest path prefix matching them, so frontend retries
+// and the circuit breakers can be exercised before a real incident does.
+// Failed requests get a 503 SERVICE_UNAVAILABLE, as from an overloaded
+// server. Requests no rule matches pass through untouched. It must never
+// be used in production.
+func FaultInjection(rules []FaultRule, logger *slog.Logger) func(http.Handler) http.Handler {
+	rules = append([]FaultRule(nil), rules...)
+	sort.SliceStable(rules, func(i, j int) bool {
+		return len(rules[i].PathPrefix) > len(rules[j].PathPrefix)
+	})
+	logger = logger.With("component", "fault_injection")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rule, ok := matchFaultRule(rules, r.URL.Path)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if rule.Latency > 0 {
+				w.Header().Set(FaultInjectedHeader, "latency")
+				timer := time.NewTimer(rule.Latency)
+				select {
+				case <-timer.C:
+				case <-r.Context().Done():
+					timer.Stop()
+					return
+				}
+			}
+
+			if rule.ErrorRate > 0 && rand.Float64() < rule.ErrorRate {
+				logger.Debug("injecting error", "method", r.Method, "path", r.URL.Path)
+				w.Header().Set(FaultInjectedHeader, "error")
+				w.Header().Set("Retry-After", "1")
+				writeJSONError(w, r, "error.service_unavailable", apperrors.CodeServiceUnavailable)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// matchFaultRule returns the rule for path; rules are sorted longest
+// prefix first.
+func matchFaultRule(rules []FaultRule, path string) (FaultRule, bool) {
+	for _, rule := range rules {
+		if strings.HasPrefix(path, rule.PathPrefix) {
+			return rule, true
+		}
+	}
+	return FaultRule{}, false
+}
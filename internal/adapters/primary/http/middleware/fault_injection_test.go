@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaultInjection(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := FaultInjection([]FaultRule{
+		{PathPrefix: "/api/v1", Latency: 20 * time.Millisecond},
+		{PathPrefix: "/api/v1/search", ErrorRate: 1},
+	}, logger)(ok)
+
+	serve := func(r *http.Request) (*httptest.ResponseRecorder, time.Duration) {
+		rec := httptest.NewRecorder()
+		start := time.Now()
+		handler.ServeHTTP(rec, r)
+		return rec, time.Since(start)
+	}
+
+	t.Run("delays requests under a prefix", func(t *testing.T) {
+		rec, took := serve(httptest.NewRequest(http.MethodGet, "/api/v1/tickets", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "latency", rec.Header().Get(FaultInjectedHeader))
+		assert.GreaterOrEqual(t, took, 20*time.Millisecond)
+	})
+
+	t.Run("the longest prefix wins", func(t *testing.T) {
+		rec, took := serve(httptest.NewRequest(http.MethodGet, "/api/v1/search?q=printer", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, "error", rec.Header().Get(FaultInjectedHeader))
+		assert.Contains(t, rec.Body.String(), "SERVICE_UNAVAILABLE")
+		assert.Less(t, took, 20*time.Millisecond)
+	})
+
+	t.Run("leaves other requests alone", func(t *testing.T) {
+		rec, _ := serve(httptest.NewRequest(http.MethodGet, "/health", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get(FaultInjectedHeader))
+	})
+
+	t.Run("stops waiting when the client goes away", func(t *testing.T) {
+		slow := FaultInjection([]FaultRule{{PathPrefix: "/", Latency: time.Minute}}, logger)(ok)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		rec := httptest.NewRecorder()
+		done := make(chan struct{})
+		go func() {
+			slow.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("request was not abandoned")
+		}
+	})
+}
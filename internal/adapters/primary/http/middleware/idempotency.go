@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// IdempotencyKeyHeader names the header clients set to make a POST safe to
+// retry.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyPendingTTL bounds how long a key stays claimed by a request
+// that never finishes, such as one on a replica that crashed.
+const idempotencyPendingTTL = time.Minute
+
+// idempotentResponse is what the cache holds for an idempotency key. While
+// the first request is running only RequestHash is set.
+type idempotentResponse struct {
+	RequestHash string `json:"request_hash"`
+	Done        bool   `json:"done"`
+	Status      int    `json:"status,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// Idempotency replays the stored response when a POST is retried with the
+// same Idempotency-Key, instead of running it again. Keys are scoped to the
+// user and route and kept for ttl. A retry while the first request is still
+// running gets 409, and reusing a key for a different body gets 422. Server
+// errors aren't stored, so the request can be retried. It must run after
+// JWTMiddleware; if the cache fails, requests run as if they had no key.
+func Idempotency(cache ports.Cache, ttl time.Duration, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			idempotencyKey := r.Header.Get(IdempotencyKeyHeader)
+			claims, ok := GetClaims(r.Context())
+			if r.Method != http.MethodPost || idempotencyKey == "" || !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				writeJSONError(w, r, "error.validation_failed", apperrors.CodeInvalidValue)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			requestHash := sha256.Sum256(body)
+			record := idempotentResponse{RequestHash: hex.EncodeToString(requestHash[:])}
+			scope := sha256.Sum256([]byte(claims.UserID.String() + " " + r.Method + " " + r.URL.Path + " " + idempotencyKey))
+			key := "idempotency:" + hex.EncodeToString(scope[:])
+
+			pending, _ := json.Marshal(record)
+			claimed, err := cache.SetNX(r.Context(), key, pending, idempotencyPendingTTL)
+			if err != nil {
+				logger.Warn("idempotency cache unavailable", "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !claimed {
+				replayIdempotentResponse(w, r, cache, key, record.RequestHash, logger)
+				return
+			}
+
+			rec := &recordingWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			if rec.status >= http.StatusInternalServerError {
+				if err := cache.Delete(r.Context(), key); err != nil {
+					logger.Warn("failed to release idempotency key", "error", err)
+				}
+				return
+			}
+
+			record.Done = true
+			record.Status = rec.status
+			record.ContentType = rec.Header().Get("Content-Type")
+			record.Body = rec.body.Bytes()
+			data, err := json.Marshal(record)
+			if err == nil {
+				err = cache.Set(r.Context(), key, data, ttl)
+			}
+			if err != nil {
+				logger.Warn("failed to store idempotent response", "error", err)
+			}
+		})
+	}
+}
+
+// replayIdempotentResponse answers a retry of a request whose key is
+// already claimed.
+func replayIdempotentResponse(w http.ResponseWriter, r *http.Request, cache ports.Cache, key, requestHash string, logger *slog.Logger) {
+	data, found, err := cache.Get(r.Context(), key)
+	if err != nil {
+		logger.Warn("idempotency cache unavailable", "error", err)
+		writeJSONError(w, r, "error.idempotency_in_progress", apperrors.CodeIdempotencyInProgress)
+		return
+	}
+
+	var stored idempotentResponse
+	if found && json.Unmarshal(data, &stored) != nil {
+		found = false
+	}
+	switch {
+	case found && stored.RequestHash != requestHash:
+		writeJSONError(w, r, "error.idempotency_key_reused", apperrors.CodeIdempotencyKeyReused)
+		return
+	case !found || !stored.Done:
+		// The key expired between the claim and now, or the first request
+		// is still running
+		writeJSONError(w, r, "error.idempotency_in_progress", apperrors.CodeIdempotencyInProgress)
+		return
+	}
+
+	if stored.ContentType != "" {
+		w.Header().Set("Content-Type", stored.ContentType)
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(stored.Status)
+	_, _ = w.Write(stored.Body)
+}
+
+// recordingWriter passes a response through while keeping a copy of it.
+type recordingWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rw *recordingWriter) WriteHeader(code int) {
+	if !rw.wroteHeader {
+		rw.status = code
+		rw.wroteHeader = true
+	}
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *recordingWriter) Write(b []byte) (int, error) {
+	rw.wroteHeader = true
+	rw.body.Write(b)
+	return rw.ResponseWriter.Write(b)
+}
+
+func (rw *recordingWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
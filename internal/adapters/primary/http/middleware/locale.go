@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/lorrc/service-desk-backend/internal/i18n"
+)
+
+// LocaleKey is the context key for the locale API messages are written in
+const LocaleKey contextKey = "locale"
+
+// Locale is a middleware that picks the request's locale from its
+// Accept-Language header. SessionMiddleware later replaces it with the
+// authenticated user's stored preference.
+func Locale(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := i18n.Match(r.Header.Get("Accept-Language"))
+		w.Header().Set("Content-Language", locale)
+		next.ServeHTTP(w, r.WithContext(WithLocale(r.Context(), locale)))
+	})
+}
+
+// WithLocale returns a copy of ctx carrying locale
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, LocaleKey, locale)
+}
+
+// GetLocale retrieves the request's locale from the context, defaulting to
+// i18n.DefaultLocale
+func GetLocale(ctx context.Context) string {
+	if locale, ok := ctx.Value(LocaleKey).(string); ok && locale != "" {
+		return locale
+	}
+	return i18n.DefaultLocale
+}
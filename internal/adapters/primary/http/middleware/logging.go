@@ -38,6 +38,11 @@ func (rw *responseWriter) Flush() {
 	}
 }
 
+// Unwrap returns the underlying ResponseWriter, for http.ResponseController
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // Hijack implements http.Hijacker when supported by the underlying writer.
 func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	hijacker, ok := rw.ResponseWriter.(http.Hijacker)
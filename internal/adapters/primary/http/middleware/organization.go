@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+// OrgSlugHeader names the organization a request without a token is for
+const OrgSlugHeader = "X-Org-Slug"
+
+// OrganizationKey is the context key for the organization a request was
+// resolved to
+const OrganizationKey contextKey = "organization"
+
+// OrganizationResolver looks up organizations by their slug.
+type OrganizationResolver interface {
+	GetOrganizationIDByPortalSlug(ctx context.Context, slug string) (uuid.UUID, error)
+}
+
+// ResolvedOrganization is the organization a request was resolved to.
+type ResolvedOrganization struct {
+	ID   uuid.UUID
+	Slug string
+}
+
+// Organization resolves the organization a request made without a token is
+// for: the one named by its X-Org-Slug header or, when tenantDomain is set,
+// by the subdomain of tenantDomain it was sent to. The header takes
+// precedence. Requests naming neither continue without an organization, and
+// those naming one that doesn't exist are refused.
+func Organization(resolver OrganizationResolver, tenantDomain string) func(http.Handler) http.Handler {
+	tenantDomain = strings.ToLower(strings.Trim(tenantDomain, "."))
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			slug := strings.ToLower(strings.TrimSpace(r.Header.Get(OrgSlugHeader)))
+			if slug == "" {
+				slug = subdomain(r.Host, tenantDomain)
+			}
+			if slug == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			orgID, err := resolver.GetOrganizationIDByPortalSlug(r.Context(), slug)
+			if err != nil {
+				if errors.Is(err, apperrors.ErrPortalNotFound) {
+					writeJSONError(w, r, "error.organization_not_found", apperrors.CodeOrganizationNotFound)
+				} else {
+					writeJSONError(w, r, "error.internal_error", apperrors.CodeInternal)
+				}
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), OrganizationKey, ResolvedOrganization{ID: orgID, Slug: slug})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetOrganization retrieves the organization the request was resolved to
+func GetOrganization(ctx context.Context) (ResolvedOrganization, bool) {
+	org, ok := ctx.Value(OrganizationKey).(ResolvedOrganization)
+	return org, ok
+}
+
+// subdomain returns the label host has in front of tenantDomain, or "" when
+// host is not a direct subdomain of it.
+func subdomain(host, tenantDomain string) string {
+	if tenantDomain == "" {
+		return ""
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+	label, ok := strings.CutSuffix(host, "."+tenantDomain)
+	if !ok || label == "" || strings.Contains(label, ".") {
+		return ""
+	}
+	return label
+}
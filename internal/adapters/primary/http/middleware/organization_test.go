@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type slugResolver map[string]uuid.UUID
+
+func (s slugResolver) GetOrganizationIDByPortalSlug(ctx context.Context, slug string) (uuid.UUID, error) {
+	if id, ok := s[slug]; ok {
+		return id, nil
+	}
+	return uuid.Nil, apperrors.ErrPortalNotFound
+}
+
+func TestOrganization(t *testing.T) {
+	acme, globex := uuid.New(), uuid.New()
+	handler := Organization(slugResolver{"acme": acme, "globex": globex}, "help.example.com")(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if org, ok := GetOrganization(r.Context()); ok {
+				w.Header().Set("X-Resolved", org.Slug+" "+org.ID.String())
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	serve := func(host, header string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", nil)
+		req.Host = host
+		if header != "" {
+			req.Header.Set(OrgSlugHeader, header)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("resolves the header", func(t *testing.T) {
+		rec := serve("api.example.com", "Acme")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "acme "+acme.String(), rec.Header().Get("X-Resolved"))
+	})
+
+	t.Run("resolves the subdomain", func(t *testing.T) {
+		rec := serve("acme.help.example.com:8443", "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "acme "+acme.String(), rec.Header().Get("X-Resolved"))
+	})
+
+	t.Run("the header takes precedence over the subdomain", func(t *testing.T) {
+		rec := serve("acme.help.example.com", "globex")
+		assert.Equal(t, "globex "+globex.String(), rec.Header().Get("X-Resolved"))
+	})
+
+	t.Run("continues without an organization when none is named", func(t *testing.T) {
+		for _, host := range []string{"help.example.com", "api.example.com", "a.acme.help.example.com"} {
+			rec := serve(host, "")
+			assert.Equal(t, http.StatusOK, rec.Code, host)
+			assert.Empty(t, rec.Header().Get("X-Resolved"), host)
+		}
+	})
+
+	t.Run("refuses an unknown organization", func(t *testing.T) {
+		rec := serve("initech.help.example.com", "")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), apperrors.CodeOrganizationNotFound.Code)
+	})
+}
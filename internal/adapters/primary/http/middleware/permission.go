@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+// PermissionChecker checks whether a user holds a permission.
+type PermissionChecker interface {
+	Can(ctx context.Context, userID uuid.UUID, permission string) (bool, error)
+}
+
+// RequirePermission rejects requests from users without permission. It
+// must run after JWTMiddleware, and is meant for routes with no service of
+// their own to check permissions, such as /debug/pprof.
+func RequirePermission(checker PermissionChecker, permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := GetClaims(r.Context())
+			if !ok {
+				writeJSONError(w, r, "error.unauthorized", apperrors.CodeUnauthorized)
+				return
+			}
+
+			allowed, err := checker.Can(r.Context(), claims.UserID, permission)
+			if err != nil {
+				writeJSONError(w, r, "error.internal_error", apperrors.CodeInternal)
+				return
+			}
+			if !allowed {
+				writeJSONError(w, r, "error.forbidden", apperrors.CodeForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -1,34 +1,70 @@
 package middleware
 
 import (
+	"context"
+	"log/slog"
 	"net"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/auth"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
 	"golang.org/x/time/rate"
 )
 
-// RateLimiter provides IP-based rate limiting
+// RateLimiter provides IP-based rate limiting. Requests whose bearer token
+// belongs to a user with an override are limited per user instead, at the
+// override's rate. With a store, buckets are shared between replicas and
+// the limiter only tracks rejections locally; if the store fails, the local
+// buckets are used.
 type RateLimiter struct {
-	visitors map[string]*visitor
-	mu       sync.RWMutex
-	rate     rate.Limit
-	burst    int
-	cleanup  time.Duration
+	name      string
+	visitors  map[string]*visitor
+	mu        sync.RWMutex
+	rate      rate.Limit
+	burst     int
+	cleanup   time.Duration
+	tokens    *auth.TokenManager
+	store     ports.RateLimitStore
+	logger    *slog.Logger
+	errors    func(http.ResponseWriter, *http.Request, error)
+	overrides overrideSet
 }
 
 type visitor struct {
-	limiter  *rate.Limiter
-	lastSeen time.Time
+	limiter      *rate.Limiter
+	lastSeen     time.Time
+	rejected     int
+	lastRejected time.Time
+}
+
+// overrideSet indexes rate limit overrides by the user or organization they
+// apply to.
+type overrideSet struct {
+	byUser map[uuid.UUID]*domain.RateLimitOverride
+	byOrg  map[uuid.UUID]*domain.RateLimitOverride
 }
 
 // RateLimiterConfig holds rate limiter configuration
 type RateLimiterConfig struct {
-	RequestsPerSecond float64       // Requests allowed per second
-	BurstSize         int           // Maximum burst size
-	CleanupInterval   time.Duration // How often to clean up old visitors
-	TTL               time.Duration // How long to keep inactive visitors
+	Name              string               // Identifies the limiter in the admin API
+	RequestsPerSecond float64              // Requests allowed per second
+	BurstSize         int                  // Maximum burst size
+	CleanupInterval   time.Duration        // How often to clean up old visitors
+	TTL               time.Duration        // How long to keep inactive visitors
+	Tokens            *auth.TokenManager   // Identifies users so overrides can apply; optional
+	Store             ports.RateLimitStore // Shares buckets between replicas; optional
+	Logger            *slog.Logger         // Reports store failures; defaults to slog.Default()
+	// Writes the 429 response; optional, used to route it through the
+	// API's error handler
+	ErrorHandler func(http.ResponseWriter, *http.Request, error)
 }
 
 // DefaultRateLimiterConfig returns a sensible default configuration
@@ -54,10 +90,18 @@ func AuthRateLimiterConfig() RateLimiterConfig {
 // NewRateLimiter creates a new rate limiter with the given configuration
 func NewRateLimiter(cfg RateLimiterConfig) *RateLimiter {
 	rl := &RateLimiter{
+		name:     cfg.Name,
 		visitors: make(map[string]*visitor),
 		rate:     rate.Limit(cfg.RequestsPerSecond),
 		burst:    cfg.BurstSize,
 		cleanup:  cfg.TTL,
+		tokens:   cfg.Tokens,
+		store:    cfg.Store,
+		logger:   cfg.Logger,
+		errors:   cfg.ErrorHandler,
+	}
+	if rl.logger == nil {
+		rl.logger = slog.Default()
 	}
 
 	// Start background cleanup goroutine
@@ -66,20 +110,74 @@ func NewRateLimiter(cfg RateLimiterConfig) *RateLimiter {
 	return rl
 }
 
-// getVisitor returns the rate limiter for the given IP, creating one if necessary
-func (rl *RateLimiter) getVisitor(ip string) *rate.Limiter {
+// allow takes a token from the limiter for key, creating one if necessary
+// and bringing its rate up to date, and records the request if it is rejected.
+func (rl *RateLimiter) allow(ctx context.Context, key string, limit rate.Limit, burst int) ports.RateLimitDecision {
+	// shared is nil when the limit is kept locally
+	var shared *ports.RateLimitDecision
+	if rl.store != nil {
+		decision, err := rl.store.Allow(ctx, rl.storeKey(key), float64(limit), burst)
+		if err != nil {
+			rl.logger.Warn("shared rate limit unavailable, limiting locally", "limiter", rl.name, "error", err)
+		} else {
+			shared = &decision
+		}
+	}
+
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	v, exists := rl.visitors[ip]
+	now := time.Now()
+	v, exists := rl.visitors[key]
 	if !exists {
-		limiter := rate.NewLimiter(rl.rate, rl.burst)
-		rl.visitors[ip] = &visitor{limiter: limiter, lastSeen: time.Now()}
-		return limiter
+		v = &visitor{limiter: rate.NewLimiter(limit, burst)}
+		rl.visitors[key] = v
+	} else if v.limiter.Limit() != limit || v.limiter.Burst() != burst {
+		v.limiter.SetLimitAt(now, limit)
+		v.limiter.SetBurstAt(now, burst)
 	}
+	v.lastSeen = now
 
-	v.lastSeen = time.Now()
-	return v.limiter
+	var decision ports.RateLimitDecision
+	if shared != nil {
+		decision = *shared
+	} else {
+		decision = reserve(v.limiter, now)
+	}
+	if !decision.Allowed {
+		v.rejected++
+		v.lastRejected = now
+	}
+	return decision
+}
+
+// reserve takes a token from a local limiter if one is available now. The
+// reservation tells how long the client would have to wait otherwise.
+func reserve(limiter *rate.Limiter, now time.Time) ports.RateLimitDecision {
+	r := limiter.ReserveN(now, 1)
+	if !r.OK() {
+		// A burst of zero never allows a request
+		return ports.RateLimitDecision{RetryAfter: time.Second}
+	}
+
+	decision := ports.RateLimitDecision{Allowed: true}
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		decision = ports.RateLimitDecision{RetryAfter: delay}
+	}
+
+	tokens := limiter.TokensAt(now)
+	if tokens > 0 {
+		decision.Remaining = int(tokens)
+	}
+	if missing := float64(limiter.Burst()) - tokens; missing > 0 && limiter.Limit() > 0 && limiter.Limit() != rate.Inf {
+		decision.Reset = time.Duration(missing / float64(limiter.Limit()) * float64(time.Second))
+	}
+	return decision
+}
+
+func (rl *RateLimiter) storeKey(key string) string {
+	return "ratelimit:" + rl.name + ":" + key
 }
 
 // cleanupVisitors removes old visitors that haven't been seen recently
@@ -98,21 +196,56 @@ func (rl *RateLimiter) cleanupVisitors(interval, ttl time.Duration) {
 	}
 }
 
+// limits returns the limiter's default rate and burst.
+func (rl *RateLimiter) limits() (rate.Limit, int) {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+	return rl.rate, rl.burst
+}
+
+// SetLimit changes the default rate and burst. Clients already being
+// tracked pick up the new values on their next request.
+func (rl *RateLimiter) SetLimit(requestsPerSecond float64, burst int) {
+	rl.mu.Lock()
+	rl.rate = rate.Limit(requestsPerSecond)
+	rl.burst = burst
+	rl.mu.Unlock()
+}
+
 // Allow checks if a request from the given IP is allowed
 func (rl *RateLimiter) Allow(ip string) bool {
-	return rl.getVisitor(ip).Allow()
+	limit, burst := rl.limits()
+	return rl.allow(context.Background(), ip, limit, burst).Allowed
 }
 
 // Middleware returns an HTTP middleware that rate limits requests
 func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ip := getClientIP(r)
+		limit, burst := rl.limits()
+		key := getClientIP(r)
+		if userID, override := rl.overrideFor(r); override != nil {
+			if override.Unlimited {
+				next.ServeHTTP(w, r)
+				return
+			}
+			key = "user:" + userID.String()
+			limit, burst = rate.Limit(override.RequestsPerSecond), override.Burst
+		}
+
+		decision := rl.allow(r.Context(), key, limit, burst)
+		header := w.Header()
+		header.Set("X-RateLimit-Limit", strconv.Itoa(burst))
+		header.Set("X-RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		header.Set("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(decision.Reset)))
 
-		if !rl.Allow(ip) {
-			w.Header().Set("Content-Type", "application/json")
-			w.Header().Set("Retry-After", "1")
-			w.WriteHeader(http.StatusTooManyRequests)
-			_, _ = w.Write([]byte(`{"error":"Too many requests. Please try again later.","code":"RATE_LIMITED"}`))
+		if !decision.Allowed {
+			retryAfter := max(ceilSeconds(decision.RetryAfter), 1)
+			header.Set("Retry-After", strconv.Itoa(retryAfter))
+			if rl.errors != nil {
+				rl.errors(w, r, apperrors.NewRateLimitError(retryAfter))
+				return
+			}
+			writeJSONError(w, r, "error.rate_limited", apperrors.CodeRateLimited)
 			return
 		}
 
@@ -120,6 +253,169 @@ func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 	})
 }
 
+// ceilSeconds rounds d up to whole seconds, as rate limit headers use.
+func ceilSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
+
+// overrideFor returns the override that applies to the user whose bearer
+// token the request carries, preferring one for the user over one for their
+// organization. Tokens are only checked for validity here; the session is
+// still validated further down the chain.
+func (rl *RateLimiter) overrideFor(r *http.Request) (uuid.UUID, *domain.RateLimitOverride) {
+	if rl.tokens == nil {
+		return uuid.Nil, nil
+	}
+
+	rl.mu.RLock()
+	empty := len(rl.overrides.byUser) == 0 && len(rl.overrides.byOrg) == 0
+	rl.mu.RUnlock()
+	if empty {
+		return uuid.Nil, nil
+	}
+
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return uuid.Nil, nil
+	}
+	claims, err := rl.tokens.ValidateToken(token)
+	if err != nil {
+		return uuid.Nil, nil
+	}
+
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+	if override, ok := rl.overrides.byUser[claims.UserID]; ok {
+		return claims.UserID, override
+	}
+	if override, ok := rl.overrides.byOrg[claims.OrgID]; ok {
+		return claims.UserID, override
+	}
+	return uuid.Nil, nil
+}
+
+// SetOverrides replaces the overrides the limiter applies.
+func (rl *RateLimiter) SetOverrides(overrides []*domain.RateLimitOverride) {
+	set := overrideSet{
+		byUser: make(map[uuid.UUID]*domain.RateLimitOverride),
+		byOrg:  make(map[uuid.UUID]*domain.RateLimitOverride),
+	}
+	for _, override := range overrides {
+		if override.UserID != nil {
+			set.byUser[*override.UserID] = override
+		} else {
+			set.byOrg[override.OrganizationID] = override
+		}
+	}
+
+	rl.mu.Lock()
+	rl.overrides = set
+	rl.mu.Unlock()
+}
+
+// Throttled returns the clients the limiter has rejected since it last saw
+// them idle long enough to be forgotten.
+func (rl *RateLimiter) Throttled() []domain.ThrottledClient {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+
+	now := time.Now()
+	clients := make([]domain.ThrottledClient, 0)
+	for key, v := range rl.visitors {
+		if v.rejected == 0 {
+			continue
+		}
+		clients = append(clients, domain.ThrottledClient{
+			Limiter:      rl.name,
+			Key:          key,
+			Rejected:     v.rejected,
+			LastRejected: v.lastRejected,
+			Blocked:      rl.blocked(v, now),
+		})
+	}
+	return clients
+}
+
+// blocked reports whether the client has no tokens left. Shared buckets
+// aren't read back from the store, so a client limited through the store
+// counts as blocked for a second after its last rejection.
+func (rl *RateLimiter) blocked(v *visitor, now time.Time) bool {
+	if rl.store != nil {
+		return now.Sub(v.lastRejected) < time.Second
+	}
+	return v.limiter.TokensAt(now) < 1
+}
+
+// Clear forgets a client, so its next request starts with a full burst.
+// It reports whether the client was known.
+func (rl *RateLimiter) Clear(key string) bool {
+	if rl.store != nil {
+		if err := rl.store.Reset(context.Background(), rl.storeKey(key)); err != nil {
+			rl.logger.Warn("failed to reset shared rate limit", "limiter", rl.name, "key", key, "error", err)
+		}
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if _, exists := rl.visitors[key]; !exists {
+		return false
+	}
+	delete(rl.visitors, key)
+	return true
+}
+
+// RateLimiterGroup exposes several rate limiters to the admin API as one.
+type RateLimiterGroup struct {
+	limiters []*RateLimiter
+}
+
+var _ ports.RateLimitControl = (*RateLimiterGroup)(nil)
+
+// NewRateLimiterGroup groups the given limiters, skipping nil ones so it can
+// be built when rate limiting is disabled.
+func NewRateLimiterGroup(limiters ...*RateLimiter) *RateLimiterGroup {
+	group := &RateLimiterGroup{}
+	for _, rl := range limiters {
+		if rl != nil {
+			group.limiters = append(group.limiters, rl)
+		}
+	}
+	return group
+}
+
+// Throttled returns the throttled clients of every limiter, most recently
+// rejected first.
+func (g *RateLimiterGroup) Throttled() []domain.ThrottledClient {
+	clients := make([]domain.ThrottledClient, 0)
+	for _, rl := range g.limiters {
+		clients = append(clients, rl.Throttled()...)
+	}
+	sort.Slice(clients, func(i, j int) bool {
+		return clients[i].LastRejected.After(clients[j].LastRejected)
+	})
+	return clients
+}
+
+// Clear forgets the client in every limiter and reports whether any of them
+// knew it.
+func (g *RateLimiterGroup) Clear(key string) bool {
+	cleared := false
+	for _, rl := range g.limiters {
+		if rl.Clear(key) {
+			cleared = true
+		}
+	}
+	return cleared
+}
+
+// SetOverrides hands the overrides to every limiter.
+func (g *RateLimiterGroup) SetOverrides(overrides []*domain.RateLimitOverride) {
+	for _, rl := range g.limiters {
+		rl.SetOverrides(overrides)
+	}
+}
+
 // getClientIP extracts the client IP from the request
 // It checks X-Forwarded-For and X-Real-IP headers first (for reverse proxies)
 func getClientIP(r *http.Request) string {
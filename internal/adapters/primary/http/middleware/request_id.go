@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
 )
 
 // contextKey is a custom type for context keys to avoid collisions
@@ -28,8 +29,9 @@ func RequestID(next http.Handler) http.Handler {
 		// Set the request ID in the response header
 		w.Header().Set(RequestIDHeader, requestID)
 
-		// Add to context
+		// Add to context, including for the core services' audit entries
 		ctx := context.WithValue(r.Context(), RequestIDKey, requestID)
+		ctx = domain.WithRequestID(ctx, requestID)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
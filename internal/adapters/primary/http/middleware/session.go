@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/i18n"
+)
+
+// SessionValidator checks whether a token's user still holds a valid session.
+type SessionValidator interface {
+	ValidateSession(ctx context.Context, userID uuid.UUID, issuedAt time.Time) (*domain.User, error)
+}
+
+// SessionMiddleware rejects requests whose token belongs to a deactivated user
+// or was issued before the user's tokens were revoked, and switches the
+// request to the user's preferred locale. An impersonation token also needs
+// the super-admin who was issued it to still hold a valid session. It must
+// run after JWTMiddleware.
+func SessionMiddleware(validator SessionValidator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := GetClaims(r.Context())
+			if !ok {
+				writeJSONError(w, r, "error.unauthorized", apperrors.CodeUnauthorized)
+				return
+			}
+
+			var issuedAt time.Time
+			if claims.IssuedAt != nil {
+				issuedAt = claims.IssuedAt.Time
+			}
+
+			user, err := validator.ValidateSession(r.Context(), claims.UserID, issuedAt)
+			if err == nil && claims.ImpersonatorID != nil {
+				_, err = validator.ValidateSession(r.Context(), *claims.ImpersonatorID, issuedAt)
+			}
+			if err != nil {
+				switch {
+				case errors.Is(err, apperrors.ErrUserInactive):
+					writeJSONError(w, r, "error.user_inactive", apperrors.CodeUserInactive)
+				case errors.Is(err, apperrors.ErrUnauthorized):
+					writeJSONError(w, r, "error.invalid_token", apperrors.CodeInvalidToken)
+				default:
+					writeJSONError(w, r, "error.internal_error", apperrors.CodeInternal)
+				}
+				return
+			}
+
+			ctx := r.Context()
+			if i18n.IsSupported(user.Locale) {
+				ctx = WithLocale(ctx, user.Locale)
+				w.Header().Set("Content-Language", user.Locale)
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,706 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	mw "github.com/lorrc/service-desk-backend/internal/adapters/primary/http/middleware"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+// apiParam describes a query parameter of an API operation.
+type apiParam struct {
+	name        string
+	kind        string // OpenAPI type: string, integer or boolean
+	format      string
+	description string
+}
+
+// apiOperation describes one /api/v1 endpoint for the OpenAPI document.
+// Request and response bodies are given as zero values of their DTOs, whose
+// schemas are derived from their JSON tags.
+type apiOperation struct {
+	method      string
+	path        string
+	tag         string
+	summary     string
+	public      bool // Served without a bearer token
+	tenant      bool // Takes the organization from the X-Org-Slug header or subdomain
+	query       []apiParam
+	request     any // JSON request body, nil if none
+	requestType string
+	status      int
+	response    any    // JSON response body, nil if none
+	contentType string // Response content type when it isn't JSON
+}
+
+var (
+	limitParam  = apiParam{name: "limit", kind: "integer", description: "Maximum number of items to return"}
+	offsetParam = apiParam{name: "offset", kind: "integer", description: "Number of items to skip"}
+	daysParam   = apiParam{name: "days", kind: "integer", description: "Number of days up to the end date, used when from is not given"}
+	fromParam   = apiParam{name: "from", kind: "string", description: "Start date (YYYY-MM-DD) or RFC 3339 timestamp"}
+	toParam     = apiParam{name: "to", kind: "string", description: "End date (YYYY-MM-DD, inclusive) or RFC 3339 timestamp"}
+
+	ticketFieldsParam = apiParam{name: "fields", kind: "string", description: "Comma-separated ticket fields to return, e.g. id,title,status"}
+	ticketTokenParam  = apiParam{name: "token", kind: "string", description: "Access token from the link emailed to the ticket's requester"}
+	ticketEmbedParam  = apiParam{name: "embed", kind: "string", description: "Comma-separated resources to embed: requester, assignee, lastComment. Defaults to requester,assignee"}
+)
+
+// apiOperations lists every endpoint under /api/v1. A test checks it
+// against the registered routes, so add new endpoints here as well.
+var apiOperations = []apiOperation{
+	// Auth
+	{method: http.MethodPost, path: "/auth/login", tag: "auth", summary: "Log in with email and password", public: true, tenant: true,
+		request: LoginRequest{}, status: http.StatusOK, response: AuthResponse{}},
+	{method: http.MethodPost, path: "/auth/register", tag: "auth", summary: "Register a new account", public: true, tenant: true,
+		request: RegisterRequest{}, status: http.StatusCreated, response: AuthResponse{}},
+	{method: http.MethodPost, path: "/auth/confirm-email", tag: "auth", summary: "Confirm a change of email with the token emailed to the new address", public: true,
+		request: ConfirmEmailChangeRequest{}, status: http.StatusOK, response: UserDTO{}},
+
+	// Meta
+	{method: http.MethodGet, path: "/meta/error-codes", tag: "meta", summary: "List the error codes the API can return", public: true,
+		status: http.StatusOK, response: ListResponse[apperrors.ErrorCode]{}},
+	{method: http.MethodGet, path: "/meta/event-types", tag: "meta", summary: "List the ticket event types with the version and schema of their payload", public: true,
+		status: http.StatusOK, response: ListResponse[EventTypeDTO]{}},
+
+	// Me
+	{method: http.MethodGet, path: "/me", tag: "me", summary: "Get the current user's profile, roles, permissions, organization and email preferences",
+		status: http.StatusOK, response: MeResponse{}},
+	{method: http.MethodGet, path: "/me/permissions", tag: "me", summary: "List the current user's permissions",
+		status: http.StatusOK, response: PermissionsResponse{}},
+	{method: http.MethodGet, path: "/me/sms", tag: "me", summary: "Get SMS notification preferences",
+		status: http.StatusOK, response: SMSPreferencesResponse{}},
+	{method: http.MethodPut, path: "/me/sms", tag: "me", summary: "Update SMS notification preferences",
+		request: UpdateSMSPreferencesRequest{}, status: http.StatusOK, response: SMSPreferencesResponse{}},
+	{method: http.MethodGet, path: "/me/locale", tag: "me", summary: "Get the preferred locale",
+		status: http.StatusOK, response: LocaleResponse{}},
+	{method: http.MethodPut, path: "/me/locale", tag: "me", summary: "Change the preferred locale",
+		request: UpdateLocaleRequest{}, status: http.StatusOK, response: LocaleResponse{}},
+	{method: http.MethodGet, path: "/me/availability", tag: "me", summary: "Get upcoming out-of-office windows",
+		status: http.StatusOK, response: AvailabilityResponse{}},
+	{method: http.MethodPut, path: "/me/availability", tag: "me", summary: "Replace out-of-office windows",
+		request: UpdateAvailabilityRequest{}, status: http.StatusOK, response: AvailabilityResponse{}},
+	{method: http.MethodGet, path: "/me/teams", tag: "me", summary: "List the teams whose queues the current user can claim tickets from",
+		status: http.StatusOK, response: ListResponse[TeamDTO]{}},
+	{method: http.MethodPost, path: "/me/email", tag: "me", summary: "Change the login email; a confirmation link is emailed to the new address",
+		request: ChangeEmailRequest{}, status: http.StatusAccepted, response: ChangeEmailResponse{}},
+	{method: http.MethodPatch, path: "/me", tag: "me", summary: "Update the current user's name, phone number, timezone or locale",
+		request: UpdateProfileRequest{}, status: http.StatusOK, response: ProfileResponse{}},
+	{method: http.MethodDelete, path: "/me", tag: "me", summary: "Delete the current user's account",
+		request: DeleteAccountRequest{}, status: http.StatusNoContent},
+	{method: http.MethodPost, path: "/me/export", tag: "me", summary: "Request an export of the current user's data",
+		status: http.StatusAccepted, response: DataExportDTO{}},
+	{method: http.MethodGet, path: "/me/export", tag: "me", summary: "Get the status of the latest data export",
+		status: http.StatusOK, response: DataExportDTO{}},
+	{method: http.MethodGet, path: "/me/export/download", tag: "me", summary: "Download the latest data export",
+		status: http.StatusOK, response: map[string]any{}},
+	{method: http.MethodGet, path: "/me/email-preferences", tag: "me", summary: "Get the ticket emails the current user opted out of",
+		status: http.StatusOK, response: EmailPreferencesResponse{}},
+	{method: http.MethodPut, path: "/me/email-preferences", tag: "me", summary: "Turn all ticket emails off or back on",
+		request: UpdateEmailPreferencesRequest{}, status: http.StatusOK, response: EmailPreferencesResponse{}},
+	{method: http.MethodPut, path: "/me/email-preferences/muted-tickets/{ticketID}", tag: "me", summary: "Stop emails about a ticket",
+		status: http.StatusNoContent},
+	{method: http.MethodDelete, path: "/me/email-preferences/muted-tickets/{ticketID}", tag: "me", summary: "Receive emails about a muted ticket again",
+		status: http.StatusNoContent},
+
+	// Email links
+	{method: http.MethodPost, path: "/email-preferences/mute", tag: "email links", public: true,
+		summary: "Mute a ticket with the token from the link in one of its emails",
+		request: EmailOptOutRequest{}, status: http.StatusOK, response: EmailOptOutResponse{}},
+	{method: http.MethodPost, path: "/email-preferences/unsubscribe", tag: "email links", public: true,
+		summary: "Unsubscribe from all ticket emails with the token from the link in a ticket email",
+		request: EmailOptOutRequest{}, status: http.StatusOK, response: EmailOptOutResponse{}},
+
+	// Assignees
+	{method: http.MethodGet, path: "/assignees", tag: "tickets", summary: "List users tickets can be assigned to, with their open tickets",
+		query: []apiParam{
+			limitParam, offsetParam,
+			{name: "q", kind: "string", description: "Search by name or email"},
+			{name: "teamId", kind: "string", format: "uuid", description: "Only members of this team"},
+			{name: "available", kind: "boolean", description: "true for agents below the open ticket limit, false for those at it"},
+		},
+		status: http.StatusOK, response: PaginatedResponse[AssigneeDTO]{}},
+
+	// Search
+	{method: http.MethodGet, path: "/search", tag: "tickets", summary: "Search the titles, descriptions and comments of the tickets you can see",
+		query: []apiParam{
+			{name: "q", kind: "string", description: "Search text; quote phrases and prefix words with - to exclude them"},
+			{name: "limit", kind: "integer", description: "Maximum number of hits to return, at most 100"},
+		},
+		status: http.StatusOK, response: ListResponse[SearchHitDTO]{}},
+
+	// Tickets
+	{method: http.MethodGet, path: "/tickets", tag: "tickets", summary: "List tickets",
+		query: []apiParam{
+			limitParam, offsetParam,
+			{name: "status", kind: "string", description: "OPEN, IN_PROGRESS or CLOSED"},
+			{name: "priority", kind: "string", description: "LOW, MEDIUM or HIGH"},
+			{name: "assigneeId", kind: "string", format: "uuid"},
+			{name: "unassigned", kind: "boolean", description: "Only tickets without an assignee"},
+			{name: "needsReassignment", kind: "boolean", description: "Only open tickets whose assignee is out of office and asked for reassignment"},
+			{name: "teamId", kind: "string", format: "uuid", description: "Only tickets assigned to this team; with unassigned, the team's queue"},
+			{name: "createdFrom", kind: "string", description: "Date (YYYY-MM-DD) or RFC 3339 timestamp"},
+			{name: "createdTo", kind: "string", description: "Date (YYYY-MM-DD, inclusive) or RFC 3339 timestamp"},
+			{name: "includeSnoozed", kind: "boolean", description: "Also list tickets snoozed until later, which agents do not see by default"},
+			ticketFieldsParam, ticketEmbedParam,
+		},
+		status: http.StatusOK, response: PaginatedResponse[TicketDTO]{}},
+	{method: http.MethodPost, path: "/tickets", tag: "tickets", summary: "Create a ticket",
+		request: CreateTicketRequest{}, status: http.StatusCreated, response: TicketDTO{}},
+	{method: http.MethodGet, path: "/tickets/{ticketID}", tag: "tickets", summary: "Get a ticket",
+		query:  []apiParam{ticketFieldsParam, ticketEmbedParam},
+		status: http.StatusOK, response: TicketDTO{}},
+	{method: http.MethodPatch, path: "/tickets/{ticketID}", tag: "tickets", summary: "Update a ticket's status, assignee, title, description or priority at once",
+		request: UpdateTicketRequest{}, status: http.StatusOK, response: TicketDTO{}},
+	{method: http.MethodPatch, path: "/tickets/{ticketID}/status", tag: "tickets", summary: "Change a ticket's status",
+		request: UpdateStatusRequest{}, status: http.StatusOK, response: TicketDTO{}},
+	{method: http.MethodPatch, path: "/tickets/{ticketID}/assignee", tag: "tickets",
+		summary: "Assign a ticket; X-Capacity-Warning is set when the assignee was already at the open ticket limit",
+		request: AssignTicketRequest{}, status: http.StatusOK, response: TicketDTO{}},
+	{method: http.MethodPatch, path: "/tickets/{ticketID}/team", tag: "tickets", summary: "Move a ticket to a team's queue, removing its assignee",
+		request: AssignTeamRequest{}, status: http.StatusOK, response: TicketDTO{}},
+	{method: http.MethodPost, path: "/tickets/{ticketID}/claim", tag: "tickets", summary: "Claim a ticket from one of your team queues",
+		status: http.StatusOK, response: TicketDTO{}},
+	{method: http.MethodPost, path: "/tickets/{ticketID}/macros/{macroID}/apply", tag: "macros",
+		summary: "Apply a macro's reply and changes to a ticket; either all of them take effect or none do",
+		status:  http.StatusOK, response: TicketDTO{}},
+	{method: http.MethodGet, path: "/tickets/{ticketID}/snooze", tag: "tickets", summary: "Get a ticket's snooze",
+		status: http.StatusOK, response: TicketSnoozeDTO{}},
+	{method: http.MethodPost, path: "/tickets/{ticketID}/snooze", tag: "tickets",
+		summary: "Hide a ticket from agents' queues until a given time, then resurface it with a reminder",
+		request: SnoozeTicketRequest{}, status: http.StatusOK, response: TicketSnoozeDTO{}},
+	{method: http.MethodDelete, path: "/tickets/{ticketID}/snooze", tag: "tickets", summary: "Bring a snoozed ticket back straight away",
+		status: http.StatusNoContent},
+	{method: http.MethodGet, path: "/tickets/{ticketID}/export", tag: "tickets", summary: "Download a ticket with its metadata and comments as a document",
+		query: []apiParam{
+			{name: "format", kind: "string", description: "pdf, the default and only format"},
+		},
+		status: http.StatusOK, contentType: "application/pdf"},
+	{method: http.MethodGet, path: "/tickets/{ticketID}/events", tag: "tickets", summary: "List a ticket's events",
+		query: []apiParam{
+			{name: "after", kind: "integer", description: "Only events after this event ID (the previous nextCursor)"},
+			{name: "limit", kind: "integer", description: "Maximum number of events to return, at most 200"},
+		},
+		status: http.StatusOK, response: TicketEventsResponse{}},
+	{method: http.MethodGet, path: "/tickets/{ticketID}/assignment-suggestions", tag: "tickets",
+		summary: "Suggest agents for a ticket, ranked by their past tickets with its tags or team and their open tickets",
+		status:  http.StatusOK, response: ListResponse[AssignmentSuggestionDTO]{}},
+	{method: http.MethodPost, path: "/tickets/{ticketID}/comments", tag: "tickets", summary: "Comment on a ticket",
+		request: CreateCommentRequest{}, status: http.StatusCreated, response: CommentDTO{}},
+	{method: http.MethodGet, path: "/tickets/{ticketID}/comments", tag: "tickets", summary: "List a ticket's comments",
+		status: http.StatusOK, response: ListResponse[CommentDTO]{}},
+	{method: http.MethodGet, path: "/tickets/{ticketID}/comments/draft", tag: "tickets",
+		summary: "Get the reply the current user is drafting on a ticket",
+		status:  http.StatusOK, response: CommentDraftDTO{}},
+	{method: http.MethodPut, path: "/tickets/{ticketID}/comments/draft", tag: "tickets",
+		summary: "Autosave the reply the current user is drafting; posting a comment discards it",
+		request: SaveCommentDraftRequest{}, status: http.StatusOK, response: CommentDraftDTO{}},
+	{method: http.MethodDelete, path: "/tickets/{ticketID}/comments/draft", tag: "tickets", summary: "Discard the current user's draft reply",
+		status: http.StatusNoContent},
+
+	// Admin: users
+	{method: http.MethodGet, path: "/admin/users", tag: "admin", summary: "List users",
+		query: []apiParam{
+			limitParam, offsetParam,
+			{name: "q", kind: "string", description: "Search by name or email"},
+			{name: "role", kind: "string"},
+			{name: "active", kind: "boolean"},
+		},
+		status: http.StatusOK, response: PaginatedResponse[UserSummaryDTO]{}},
+	{method: http.MethodPost, path: "/admin/users/import", tag: "admin", summary: "Import users from a CSV with name, email and optional role columns",
+		requestType: "text/csv", status: http.StatusOK, response: UserImportReportDTO{}},
+	{method: http.MethodPatch, path: "/admin/users/{userID}/role", tag: "admin", summary: "Change a user's role",
+		request: UpdateUserRoleRequest{}, status: http.StatusNoContent},
+	{method: http.MethodPatch, path: "/admin/users/{userID}/status", tag: "admin", summary: "Activate or deactivate a user",
+		request: UpdateUserStatusRequest{}, status: http.StatusNoContent},
+	{method: http.MethodPost, path: "/admin/users/{userID}/offboard", tag: "admin", summary: "Deactivate a user and hand over their tickets",
+		request: OffboardUserRequest{}, status: http.StatusOK, response: OffboardResultDTO{}},
+	{method: http.MethodPost, path: "/admin/users/{userID}/reset-password", tag: "admin", summary: "Reset a user's password",
+		status: http.StatusOK, response: ResetPasswordResponse{}},
+	{method: http.MethodPost, path: "/admin/users/{userID}/force-logout", tag: "admin", summary: "Revoke a user's sessions",
+		status: http.StatusNoContent},
+	{method: http.MethodDelete, path: "/admin/users/{userID}", tag: "admin", summary: "Delete a user",
+		status: http.StatusNoContent},
+	{method: http.MethodPost, path: "/admin/users/{userID}/export", tag: "admin", summary: "Request an export of a user's data",
+		status: http.StatusAccepted, response: DataExportDTO{}},
+	{method: http.MethodGet, path: "/admin/users/{userID}/export", tag: "admin", summary: "Get the status of a user's latest data export",
+		status: http.StatusOK, response: DataExportDTO{}},
+	{method: http.MethodGet, path: "/admin/users/{userID}/export/download", tag: "admin", summary: "Download a user's latest data export",
+		status: http.StatusOK, response: map[string]any{}},
+
+	// Admin: analytics and audit log
+	{method: http.MethodGet, path: "/admin/analytics/overview", tag: "admin", summary: "Get ticket analytics",
+		query:  []apiParam{daysParam, fromParam, toParam},
+		status: http.StatusOK, response: AnalyticsOverviewResponse{}},
+	{method: http.MethodGet, path: "/admin/analytics/export", tag: "admin", summary: "Download ticket analytics as CSV",
+		query:  []apiParam{daysParam, fromParam, toParam},
+		status: http.StatusOK, contentType: "text/csv"},
+	{method: http.MethodGet, path: "/admin/audit-log", tag: "admin", summary: "List audit log entries",
+		query: []apiParam{
+			limitParam, offsetParam,
+			{name: "action", kind: "string"},
+			{name: "actorId", kind: "string", format: "uuid"},
+			{name: "targetId", kind: "string"},
+			fromParam, toParam,
+		},
+		status: http.StatusOK, response: PaginatedResponse[AuditEntryDTO]{}},
+
+	// Admin: webhooks
+	{method: http.MethodPost, path: "/admin/events/replay", tag: "webhooks", summary: "Send a time range of ticket events to the webhooks again",
+		request: ReplayEventsRequest{}, status: http.StatusAccepted, response: EventReplayDTO{}},
+	{method: http.MethodGet, path: "/admin/webhooks", tag: "webhooks", summary: "List webhooks",
+		status: http.StatusOK, response: ListResponse[WebhookDTO]{}},
+	{method: http.MethodPost, path: "/admin/webhooks", tag: "webhooks", summary: "Register a webhook",
+		request: CreateWebhookRequest{}, status: http.StatusCreated, response: WebhookDTO{}},
+	{method: http.MethodDelete, path: "/admin/webhooks/{webhookID}", tag: "webhooks", summary: "Delete a webhook",
+		status: http.StatusNoContent},
+	{method: http.MethodGet, path: "/admin/webhooks/{webhookID}/deliveries", tag: "webhooks", summary: "List a webhook's recent deliveries",
+		query:  []apiParam{limitParam},
+		status: http.StatusOK, response: ListResponse[WebhookDeliveryDTO]{}},
+	{method: http.MethodPost, path: "/admin/webhooks/{webhookID}/test", tag: "webhooks", summary: "Send a test delivery",
+		status: http.StatusOK, response: WebhookDeliveryDTO{}},
+
+	// Admin: inbound hooks
+	{method: http.MethodGet, path: "/admin/inbound-hooks", tag: "inbound hooks", summary: "List inbound hooks",
+		status: http.StatusOK, response: ListResponse[InboundHookDTO]{}},
+	{method: http.MethodPost, path: "/admin/inbound-hooks", tag: "inbound hooks", summary: "Register an inbound hook",
+		request: CreateInboundHookRequest{}, status: http.StatusCreated, response: InboundHookDTO{}},
+	{method: http.MethodDelete, path: "/admin/inbound-hooks/{hookID}", tag: "inbound hooks", summary: "Delete an inbound hook",
+		status: http.StatusNoContent},
+	{method: http.MethodPost, path: "/hooks/{source}", tag: "inbound hooks", public: true,
+		summary: "Raise a ticket from an alert payload, signed with the hook's secret in X-Hook-Signature, Sentry-Hook-Signature or X-Grafana-Alerting-Signature",
+		request: json.RawMessage{}, status: http.StatusCreated, response: InboundHookReceiptDTO{}},
+
+	// Admin: teams
+	{method: http.MethodGet, path: "/admin/teams", tag: "teams", summary: "List teams",
+		status: http.StatusOK, response: ListResponse[TeamDTO]{}},
+	{method: http.MethodPost, path: "/admin/teams", tag: "teams", summary: "Create a team",
+		request: TeamRequest{}, status: http.StatusCreated, response: TeamDTO{}},
+	{method: http.MethodPatch, path: "/admin/teams/{teamID}", tag: "teams", summary: "Rename a team",
+		request: TeamRequest{}, status: http.StatusOK, response: TeamDTO{}},
+	{method: http.MethodDelete, path: "/admin/teams/{teamID}", tag: "teams", summary: "Delete a team; tickets in its queue become unassigned",
+		status: http.StatusNoContent},
+	{method: http.MethodPut, path: "/admin/teams/{teamID}/members/{userID}", tag: "teams", summary: "Add an agent to a team",
+		status: http.StatusOK, response: TeamDTO{}},
+	{method: http.MethodDelete, path: "/admin/teams/{teamID}/members/{userID}", tag: "teams", summary: "Remove an agent from a team",
+		status: http.StatusOK, response: TeamDTO{}},
+
+	// Macros
+	{method: http.MethodGet, path: "/macros", tag: "macros", summary: "List the organization's macros",
+		status: http.StatusOK, response: ListResponse[MacroDTO]{}},
+	{method: http.MethodPost, path: "/admin/macros", tag: "macros", summary: "Create a macro",
+		request: MacroRequest{}, status: http.StatusCreated, response: MacroDTO{}},
+	{method: http.MethodPut, path: "/admin/macros/{macroID}", tag: "macros", summary: "Replace a macro",
+		request: MacroRequest{}, status: http.StatusOK, response: MacroDTO{}},
+	{method: http.MethodDelete, path: "/admin/macros/{macroID}", tag: "macros", summary: "Delete a macro",
+		status: http.StatusNoContent},
+
+	// Admin: escalation rules
+	{method: http.MethodGet, path: "/admin/escalation-rules", tag: "escalation rules", summary: "List escalation rules",
+		status: http.StatusOK, response: ListResponse[EscalationRuleDTO]{}},
+	{method: http.MethodPost, path: "/admin/escalation-rules", tag: "escalation rules", summary: "Create an escalation rule",
+		request: EscalationRuleRequest{}, status: http.StatusCreated, response: EscalationRuleDTO{}},
+	{method: http.MethodPost, path: "/admin/escalation-rules/dry-run", tag: "escalation rules",
+		summary: "Evaluate a draft rule, or the active rules, against a sample ticket without saving or acting on anything",
+		request: EscalationDryRunRequest{}, status: http.StatusOK, response: ListResponse[EscalationEvaluationDTO]{}},
+	{method: http.MethodPut, path: "/admin/escalation-rules/{ruleID}", tag: "escalation rules", summary: "Replace an escalation rule",
+		request: EscalationRuleRequest{}, status: http.StatusOK, response: EscalationRuleDTO{}},
+	{method: http.MethodDelete, path: "/admin/escalation-rules/{ruleID}", tag: "escalation rules", summary: "Delete an escalation rule",
+		status: http.StatusNoContent},
+
+	// Public portal
+	{method: http.MethodPost, path: "/public/{orgSlug}/tickets", tag: "public portal", public: true,
+		summary: "Submit a ticket without an account; the submitter is emailed a link to follow it",
+		request: SubmitPortalTicketRequest{}, status: http.StatusCreated, response: PortalSubmissionDTO{}},
+	{method: http.MethodPost, path: "/public/tickets", tag: "public portal", public: true, tenant: true,
+		summary: "Submit a ticket to the organization named by the X-Org-Slug header or subdomain",
+		request: SubmitPortalTicketRequest{}, status: http.StatusCreated, response: PortalSubmissionDTO{}},
+	{method: http.MethodGet, path: "/public/tickets/{ticketID}", tag: "public portal", public: true,
+		summary: "Get a ticket with the access token emailed to its requester",
+		query:   []apiParam{ticketTokenParam},
+		status:  http.StatusOK, response: PortalTicketDTO{}},
+	{method: http.MethodPost, path: "/public/tickets/{ticketID}/comments", tag: "public portal", public: true,
+		summary: "Reply to a ticket with the access token emailed to its requester",
+		query:   []apiParam{ticketTokenParam},
+		request: CreateCommentRequest{}, status: http.StatusCreated, response: PortalCommentDTO{}},
+
+	// Admin: rate limits
+	{method: http.MethodGet, path: "/admin/rate-limits", tag: "rate limits", summary: "List throttled clients",
+		status: http.StatusOK, response: ListResponse[ThrottledClientDTO]{}},
+	{method: http.MethodDelete, path: "/admin/rate-limits/{key}", tag: "rate limits", summary: "Clear a throttled client",
+		status: http.StatusNoContent},
+	{method: http.MethodGet, path: "/admin/rate-limits/overrides", tag: "rate limits", summary: "List rate limit overrides",
+		status: http.StatusOK, response: ListResponse[RateLimitOverrideDTO]{}},
+	{method: http.MethodPut, path: "/admin/rate-limits/overrides", tag: "rate limits", summary: "Set a rate limit override",
+		request: SetRateLimitOverrideRequest{}, status: http.StatusOK, response: RateLimitOverrideDTO{}},
+	{method: http.MethodDelete, path: "/admin/rate-limits/overrides/{overrideID}", tag: "rate limits", summary: "Delete a rate limit override",
+		status: http.StatusNoContent},
+
+	// Admin: organization settings
+	{method: http.MethodGet, path: "/admin/org/settings/hours", tag: "organization", summary: "Get business hours",
+		status: http.StatusOK, response: BusinessHoursDTO{}},
+	{method: http.MethodPut, path: "/admin/org/settings/hours", tag: "organization", summary: "Update business hours",
+		request: UpdateBusinessHoursRequest{}, status: http.StatusOK, response: BusinessHoursDTO{}},
+	{method: http.MethodGet, path: "/admin/org/settings/retention", tag: "organization", summary: "Get the data retention policy",
+		status: http.StatusOK, response: RetentionPolicyDTO{}},
+	{method: http.MethodPut, path: "/admin/org/settings/retention", tag: "organization", summary: "Update the data retention policy",
+		request: UpdateRetentionPolicyRequest{}, status: http.StatusOK, response: RetentionPolicyDTO{}},
+	{method: http.MethodGet, path: "/admin/org/settings/retention/upcoming", tag: "organization", summary: "List tickets due to be purged",
+		query: []apiParam{
+			limitParam, offsetParam,
+			{name: "days", kind: "integer", description: "Look this many days ahead, at most 365"},
+		},
+		status: http.StatusOK, response: PaginatedResponse[PurgeCandidateDTO]{}},
+	{method: http.MethodGet, path: "/admin/org/settings/registration-domains", tag: "organization", summary: "Get the email domains allowed to self-register",
+		status: http.StatusOK, response: RegistrationDomainsDTO{}},
+	{method: http.MethodPut, path: "/admin/org/settings/registration-domains", tag: "organization", summary: "Update the email domains allowed to self-register",
+		request: RegistrationDomainsDTO{}, status: http.StatusOK, response: RegistrationDomainsDTO{}},
+	{method: http.MethodGet, path: "/admin/org/settings/portal", tag: "organization", summary: "Get the public portal settings",
+		status: http.StatusOK, response: PortalSettingsDTO{}},
+	{method: http.MethodPut, path: "/admin/org/settings/portal", tag: "organization", summary: "Update the public portal settings",
+		request: PortalSettingsDTO{}, status: http.StatusOK, response: PortalSettingsDTO{}},
+	{method: http.MethodGet, path: "/admin/org/settings/agent-capacity", tag: "organization", summary: "Get the open ticket limit of agents",
+		status: http.StatusOK, response: AgentCapacityDTO{}},
+	{method: http.MethodPut, path: "/admin/org/settings/agent-capacity", tag: "organization",
+		summary: "Set the open ticket limit of agents and whether assigning past it warns or is refused",
+		request: UpdateAgentCapacityRequest{}, status: http.StatusOK, response: AgentCapacityDTO{}},
+	{method: http.MethodGet, path: "/admin/usage", tag: "organization", summary: "Get quota usage",
+		status: http.StatusOK, response: UsageReportDTO{}},
+
+	// Admin: runtime configuration
+	{method: http.MethodGet, path: "/admin/config", tag: "config", summary: "Get the runtime configuration",
+		status: http.StatusOK, response: RuntimeConfigDTO{}},
+	{method: http.MethodPost, path: "/admin/config/reload", tag: "config", summary: "Reload the runtime configuration",
+		status: http.StatusOK, response: RuntimeConfigDTO{}},
+
+	// Platform: super-admins
+	{method: http.MethodGet, path: "/admin/organizations", tag: "platform", summary: "List every organization with its usage",
+		query:  []apiParam{limitParam, offsetParam, {name: "q", kind: "string", description: "Search organization names and portal slugs"}},
+		status: http.StatusOK, response: PaginatedResponse[OrganizationSummaryDTO]{}},
+	{method: http.MethodGet, path: "/admin/organizations/{orgID}", tag: "platform", summary: "Get an organization's usage, quota and health",
+		status: http.StatusOK, response: OrganizationOverviewDTO{}},
+	{method: http.MethodPost, path: "/admin/organizations/{orgID}/impersonate", tag: "platform", summary: "Get a short-lived token acting as one of the organization's admins",
+		request: ImpersonateRequest{}, status: http.StatusOK, response: ImpersonationResponse{}},
+}
+
+// OpenAPIHandler serves the OpenAPI document describing /api/v1 and a
+// Swagger UI page for browsing it.
+type OpenAPIHandler struct {
+	spec []byte
+}
+
+// NewOpenAPIHandler builds the OpenAPI document for the given API version.
+func NewOpenAPIHandler(version string) *OpenAPIHandler {
+	spec, err := json.Marshal(buildOpenAPISpec(version))
+	if err != nil {
+		// The document is built from static types, so this is a programming error
+		panic(fmt.Sprintf("openapi: encoding document: %v", err))
+	}
+	return &OpenAPIHandler{spec: spec}
+}
+
+// HandleSpec handles GET /api/v1/openapi.json
+func (h *OpenAPIHandler) HandleSpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(h.spec)
+}
+
+// HandleDocs handles GET /api/v1/docs
+func (h *OpenAPIHandler) HandleDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(swaggerUIPage))
+}
+
+// swaggerUIPage loads Swagger UI from a CDN and points it at the document
+// served next to it.
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Service Desk API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
+
+// buildOpenAPISpec assembles the OpenAPI 3.0 document from apiOperations.
+func buildOpenAPISpec(version string) map[string]any {
+	schemas := newSchemaRegistry()
+	errorSchema := schemas.schemaFor(reflect.TypeOf(ErrorResponse{}))
+	validationSchema := schemas.schemaFor(reflect.TypeOf(ValidationErrorResponse{}))
+	// Event payloads are listed as components for /meta/event-types to name
+	for _, def := range domain.EventCatalog {
+		schemas.schemaFor(reflect.TypeOf(def.Payload))
+	}
+
+	paths := make(map[string]map[string]any)
+	for _, op := range apiOperations {
+		operation := map[string]any{
+			"tags":        []string{op.tag},
+			"summary":     op.summary,
+			"operationId": operationID(op),
+		}
+
+		var parameters []map[string]any
+		for _, name := range pathParams(op.path) {
+			parameters = append(parameters, map[string]any{
+				"name":     name,
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]any{"type": "string"},
+			})
+		}
+		for _, param := range op.query {
+			schema := map[string]any{"type": param.kind}
+			if param.format != "" {
+				schema["format"] = param.format
+			}
+			parameter := map[string]any{"name": param.name, "in": "query", "schema": schema}
+			if param.description != "" {
+				parameter["description"] = param.description
+			}
+			parameters = append(parameters, parameter)
+		}
+		if op.tenant {
+			parameters = append(parameters, map[string]any{
+				"name":        mw.OrgSlugHeader,
+				"in":          "header",
+				"description": "Slug of the organization the request is for, when it is not sent to the organization's subdomain",
+				"schema":      map[string]any{"type": "string"},
+			})
+		}
+		if op.method == http.MethodPost && !op.public {
+			parameters = append(parameters, map[string]any{
+				"name":        mw.IdempotencyKeyHeader,
+				"in":          "header",
+				"description": "Repeating a request with the same key replays the first response",
+				"schema":      map[string]any{"type": "string"},
+			})
+		}
+		if len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+
+		switch {
+		case op.request != nil:
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": schemas.schemaFor(reflect.TypeOf(op.request))},
+				},
+			}
+		case op.requestType != "":
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{
+					op.requestType: map[string]any{"schema": map[string]any{"type": "string"}},
+				},
+			}
+		}
+
+		success := map[string]any{"description": http.StatusText(op.status)}
+		switch {
+		case op.response != nil:
+			success["content"] = map[string]any{
+				"application/json": map[string]any{"schema": schemas.schemaFor(reflect.TypeOf(op.response))},
+			}
+		case op.contentType != "":
+			success["content"] = map[string]any{
+				op.contentType: map[string]any{"schema": map[string]any{"type": "string"}},
+			}
+		}
+		responses := map[string]any{
+			fmt.Sprint(op.status): success,
+			"default": map[string]any{
+				"description": "Error",
+				"content":     map[string]any{"application/json": map[string]any{"schema": errorSchema}},
+			},
+		}
+		if op.request != nil || len(op.query) > 0 {
+			responses[fmt.Sprint(http.StatusUnprocessableEntity)] = map[string]any{
+				"description": "Validation failed",
+				"content":     map[string]any{"application/json": map[string]any{"schema": validationSchema}},
+			}
+		}
+		operation["responses"] = responses
+
+		if op.public {
+			operation["security"] = []any{}
+		}
+
+		if paths[op.path] == nil {
+			paths[op.path] = make(map[string]any)
+		}
+		paths[op.path][strings.ToLower(op.method)] = operation
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "Service Desk API",
+			"version": version,
+		},
+		"servers":  []map[string]any{{"url": "/api/v1"}},
+		"security": []map[string]any{{"bearerAuth": []string{}}},
+		"paths":    paths,
+		"components": map[string]any{
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+			"schemas": schemas.schemas,
+		},
+	}
+}
+
+// operationID derives an operation ID such as "get_tickets_ticketID_events".
+func operationID(op apiOperation) string {
+	id := strings.ToLower(op.method)
+	for _, segment := range strings.Split(op.path, "/") {
+		segment = strings.Trim(segment, "{}")
+		segment = strings.ReplaceAll(segment, "-", "_")
+		if segment != "" {
+			id += "_" + segment
+		}
+	}
+	return id
+}
+
+// pathParams returns the names of the {placeholders} in path.
+func pathParams(path string) []string {
+	var names []string
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			names = append(names, strings.Trim(segment, "{}"))
+		}
+	}
+	return names
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	uuidType       = reflect.TypeOf(uuid.UUID{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaRegistry derives JSON schemas from Go types. Named structs become
+// components referenced by $ref; generic wrappers such as
+// PaginatedResponse[T] are inlined.
+type schemaRegistry struct {
+	schemas map[string]any
+	types   map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: make(map[string]any),
+		types:   make(map[reflect.Type]string),
+	}
+}
+
+func (s *schemaRegistry) schemaFor(t reflect.Type) map[string]any {
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case uuidType:
+		return map[string]any{"type": "string", "format": "uuid"}
+	case rawMessageType:
+		return map[string]any{"type": "object"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := s.schemaFor(t.Elem())
+		if _, isRef := schema["$ref"]; isRef {
+			// Siblings of $ref are ignored in OpenAPI 3.0
+			return map[string]any{"allOf": []any{schema}, "nullable": true}
+		}
+		schema["nullable"] = true
+		return schema
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": s.schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": s.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" || strings.Contains(t.Name(), "[") {
+			return s.structSchema(t)
+		}
+		return s.ref(t)
+	default:
+		// interface{} and anything else accepts any value
+		return map[string]any{}
+	}
+}
+
+// ref registers a named struct as a component and returns a reference to it.
+func (s *schemaRegistry) ref(t reflect.Type) map[string]any {
+	name, ok := s.types[t]
+	if !ok {
+		name = t.Name()
+		if _, taken := s.schemas[name]; taken {
+			// Qualify names shared by types from different packages
+			name = pkgName(t) + "." + name
+		}
+		s.types[t] = name
+		s.schemas[name] = map[string]any{} // Placeholder for recursive types
+		s.schemas[name] = s.structSchema(t)
+	}
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+func (s *schemaRegistry) structSchema(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	s.addFields(t, properties)
+	return map[string]any{"type": "object", "properties": properties}
+}
+
+// addFields adds the JSON fields of struct t to properties, flattening
+// embedded structs the way encoding/json does.
+func (s *schemaRegistry) addFields(t reflect.Type, properties map[string]any) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			s.addFields(field.Type, properties)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = s.schemaFor(field.Type)
+	}
+}
+
+func pkgName(t reflect.Type) string {
+	path := t.PkgPath()
+	return path[strings.LastIndex(path, "/")+1:]
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"encoding/json"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI_CoversRegisteredRoutes(t *testing.T) {
+	// Mirrors the /api/v1 routes set up in cmd/api
+	r := chi.NewRouter()
+	r.Route("/auth", (&AuthHandler{}).RegisterRoutes)
+	r.Route("/meta", (&MetaHandler{}).RegisterRoutes)
+	r.Route("/hooks", (&InboundHookHandler{}).RegisterReceiverRoutes)
+	r.Route("/public", func(r chi.Router) {
+		(&PortalHandler{}).RegisterSubmissionRoutes(r)
+		(&PortalHandler{}).RegisterLinkRoutes(r)
+	})
+	r.Route("/email-preferences", (&EmailPreferenceHandler{}).RegisterLinkRoutes)
+	r.Route("/me", func(r chi.Router) {
+		(&MeHandler{}).RegisterRoutes(r)
+		r.Route("/email-preferences", (&EmailPreferenceHandler{}).RegisterRoutes)
+	})
+	r.Route("/assignees", (&AssigneeHandler{}).RegisterRoutes)
+	r.Route("/macros", (&MacroHandler{}).RegisterRoutes)
+	r.Route("/search", (&SearchHandler{}).RegisterRoutes)
+	r.Route("/admin", func(r chi.Router) {
+		(&AdminHandler{}).RegisterRoutes(r)
+		r.Route("/webhooks", (&WebhookHandler{}).RegisterRoutes)
+		r.Route("/events", (&EventReplayHandler{}).RegisterRoutes)
+		r.Route("/inbound-hooks", (&InboundHookHandler{}).RegisterRoutes)
+		r.Route("/teams", (&TeamHandler{}).RegisterRoutes)
+		r.Route("/escalation-rules", (&EscalationHandler{}).RegisterRoutes)
+		r.Route("/macros", (&MacroHandler{}).RegisterAdminRoutes)
+		r.Route("/rate-limits", (&RateLimitHandler{}).RegisterRoutes)
+		r.Route("/org/settings", (&OrgSettingsHandler{}).RegisterRoutes)
+		r.Route("/usage", (&QuotaHandler{}).RegisterRoutes)
+		r.Route("/config", (&ConfigHandler{}).RegisterRoutes)
+		r.Route("/organizations", (&PlatformHandler{}).RegisterRoutes)
+	})
+	r.Route("/tickets", (&TicketHandler{commentHandler: &CommentHandler{}}).RegisterRoutes)
+
+	var registered []string
+	err := chi.Walk(r, func(method, route string, _ stdhttp.Handler, _ ...func(stdhttp.Handler) stdhttp.Handler) error {
+		route = strings.ReplaceAll(route, "/*/", "/")
+		if len(route) > 1 {
+			route = strings.TrimSuffix(route, "/")
+		}
+		registered = append(registered, method+" "+route)
+		return nil
+	})
+	require.NoError(t, err)
+
+	var documented []string
+	for _, op := range apiOperations {
+		documented = append(documented, op.method+" "+op.path)
+	}
+
+	sort.Strings(registered)
+	sort.Strings(documented)
+	assert.Equal(t, registered, documented)
+}
+
+func TestOpenAPIHandler(t *testing.T) {
+	h := NewOpenAPIHandler("1.2.3")
+
+	t.Run("serves the document", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.HandleSpec(rec, httptest.NewRequest(stdhttp.MethodGet, "/api/v1/openapi.json", nil))
+
+		require.Equal(t, stdhttp.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+		var spec struct {
+			OpenAPI string `json:"openapi"`
+			Info    struct {
+				Version string `json:"version"`
+			} `json:"info"`
+			Paths      map[string]map[string]json.RawMessage `json:"paths"`
+			Components struct {
+				Schemas map[string]json.RawMessage `json:"schemas"`
+			} `json:"components"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &spec))
+		assert.Equal(t, "3.0.3", spec.OpenAPI)
+		assert.Equal(t, "1.2.3", spec.Info.Version)
+		assert.Contains(t, spec.Paths["/tickets/{ticketID}"], "get")
+		assert.Contains(t, spec.Components.Schemas, "TicketDTO")
+
+		// Every reference resolves to a component
+		for _, ref := range strings.Split(rec.Body.String(), `"$ref":"#/components/schemas/`)[1:] {
+			name, _, _ := strings.Cut(ref, `"`)
+			assert.Contains(t, spec.Components.Schemas, name)
+		}
+	})
+
+	t.Run("serves Swagger UI", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.HandleDocs(rec, httptest.NewRequest(stdhttp.MethodGet, "/api/v1/docs", nil))
+
+		require.Equal(t, stdhttp.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+		assert.Contains(t, rec.Body.String(), `url: "openapi.json"`)
+	})
+}
@@ -0,0 +1,518 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	mw "github.com/lorrc/service-desk-backend/internal/adapters/primary/http/middleware"
+	"github.com/lorrc/service-desk-backend/internal/adapters/primary/validation"
+	"github.com/lorrc/service-desk-backend/internal/auth"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+const (
+	// defaultPurgeWindowDays is how far ahead upcoming purges are listed by default
+	defaultPurgeWindowDays = 30
+	// maxPurgeWindowDays caps how far ahead upcoming purges can be listed
+	maxPurgeWindowDays = 365
+	// maxPurgesPerPage caps the page size of the upcoming purges
+	maxPurgesPerPage = 200
+)
+
+// weekdayNames maps the day names used in the API to time.Weekday.
+var weekdayNames = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// OrgSettingsHandler handles HTTP requests for organization settings.
+type OrgSettingsHandler struct {
+	settingsService ports.OrgSettingsService
+	errorHandler    *ErrorHandler
+	logger          *slog.Logger
+}
+
+// NewOrgSettingsHandler creates a new OrgSettingsHandler.
+func NewOrgSettingsHandler(settingsService ports.OrgSettingsService, errorHandler *ErrorHandler, logger *slog.Logger) *OrgSettingsHandler {
+	return &OrgSettingsHandler{
+		settingsService: settingsService,
+		errorHandler:    errorHandler,
+		logger:          logger.With("handler", "org_settings"),
+	}
+}
+
+// RegisterRoutes registers the /admin/org/settings routes.
+func (h *OrgSettingsHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/hours", h.HandleGetBusinessHours)
+	r.Put("/hours", h.HandleUpdateBusinessHours)
+	r.Get("/retention", h.HandleGetRetentionPolicy)
+	r.Put("/retention", h.HandleUpdateRetentionPolicy)
+	r.Get("/retention/upcoming", h.HandleListUpcomingPurges)
+	r.Get("/registration-domains", h.HandleGetRegistrationDomains)
+	r.Put("/registration-domains", h.HandleUpdateRegistrationDomains)
+	r.Get("/portal", h.HandleGetPortalSettings)
+	r.Put("/portal", h.HandleUpdatePortalSettings)
+	r.Get("/agent-capacity", h.HandleGetAgentCapacity)
+	r.Put("/agent-capacity", h.HandleUpdateAgentCapacity)
+}
+
+// WorkingDayDTO defines the JSON representation of one day's working hours.
+type WorkingDayDTO struct {
+	Day   string `json:"day"`
+	Open  string `json:"open"`
+	Close string `json:"close"`
+}
+
+// HolidayDTO defines the JSON representation of a holiday.
+type HolidayDTO struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+}
+
+// BusinessHoursDTO defines the JSON representation of an organization's
+// business hours and holiday calendar.
+type BusinessHoursDTO struct {
+	Timezone  string          `json:"timezone"`
+	Days      []WorkingDayDTO `json:"days"`
+	Holidays  []HolidayDTO    `json:"holidays"`
+	UpdatedAt *string         `json:"updatedAt"`
+}
+
+// UpdateBusinessHoursRequest defines the JSON body for replacing an
+// organization's business hours and holiday calendar.
+type UpdateBusinessHoursRequest struct {
+	Timezone string          `json:"timezone"`
+	Days     []WorkingDayDTO `json:"days"`
+	Holidays []HolidayDTO    `json:"holidays"`
+}
+
+func (r *UpdateBusinessHoursRequest) Validate() error {
+	v := validation.NewValidator()
+
+	v.Required("timezone", r.Timezone).
+		Custom("days", len(r.Days) > 0, "At least one working day is required")
+
+	for _, day := range r.Days {
+		_, known := weekdayNames[strings.ToLower(day.Day)]
+		v.Custom("days", known, "Unknown day of the week: "+day.Day)
+		_, openErr := domain.ParseClockTime(day.Open)
+		_, closeErr := domain.ParseClockTime(day.Close)
+		v.Custom("days", openErr == nil && closeErr == nil, "Times must be formatted HH:MM")
+	}
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
+// toDomain converts a validated request into business hours.
+func (r *UpdateBusinessHoursRequest) toDomain() domain.BusinessHours {
+	hours := domain.BusinessHours{
+		Timezone: r.Timezone,
+		Days:     make([]domain.WorkingDay, 0, len(r.Days)),
+		Holidays: make([]domain.Holiday, 0, len(r.Holidays)),
+	}
+	for _, day := range r.Days {
+		openAt, _ := domain.ParseClockTime(day.Open)
+		closeAt, _ := domain.ParseClockTime(day.Close)
+		hours.Days = append(hours.Days, domain.WorkingDay{
+			Weekday: weekdayNames[strings.ToLower(day.Day)],
+			Open:    openAt,
+			Close:   closeAt,
+		})
+	}
+	for _, holiday := range r.Holidays {
+		hours.Holidays = append(hours.Holidays, domain.Holiday{
+			Date: holiday.Date,
+			Name: strings.TrimSpace(holiday.Name),
+		})
+	}
+	return hours
+}
+
+// HandleGetBusinessHours handles GET /admin/org/settings/hours
+func (h *OrgSettingsHandler) HandleGetBusinessHours(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	hours, err := h.settingsService.GetBusinessHours(r.Context(), claims.UserID, claims.OrgID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toBusinessHoursDTO(hours))
+}
+
+// HandleUpdateBusinessHours handles PUT /admin/org/settings/hours
+func (h *OrgSettingsHandler) HandleUpdateBusinessHours(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[UpdateBusinessHoursRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	hours, err := h.settingsService.UpdateBusinessHours(r.Context(), claims.UserID, claims.OrgID, req.toDomain())
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toBusinessHoursDTO(hours))
+}
+
+// RetentionPolicyDTO defines the JSON representation of an organization's
+// data retention policy. A null closedTicketDays keeps closed tickets forever.
+type RetentionPolicyDTO struct {
+	ClosedTicketDays *int    `json:"closedTicketDays"`
+	GraceDays        int     `json:"graceDays"`
+	UpdatedAt        *string `json:"updatedAt"`
+}
+
+// UpdateRetentionPolicyRequest defines the JSON body for replacing an
+// organization's data retention policy. graceDays defaults to 30.
+type UpdateRetentionPolicyRequest struct {
+	ClosedTicketDays *int `json:"closedTicketDays"`
+	GraceDays        *int `json:"graceDays"`
+}
+
+// PurgeCandidateDTO defines the JSON representation of a ticket due to be
+// purged by the retention policy.
+type PurgeCandidateDTO struct {
+	TicketID     int64   `json:"ticketId"`
+	Title        string  `json:"title"`
+	ClosedAt     string  `json:"closedAt"`
+	DeletedAt    *string `json:"deletedAt"`
+	SoftDeleteAt string  `json:"softDeleteAt"`
+	HardDeleteAt string  `json:"hardDeleteAt"`
+}
+
+// HandleGetRetentionPolicy handles GET /admin/org/settings/retention
+func (h *OrgSettingsHandler) HandleGetRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	policy, err := h.settingsService.GetRetentionPolicy(r.Context(), claims.UserID, claims.OrgID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toRetentionPolicyDTO(policy))
+}
+
+// HandleUpdateRetentionPolicy handles PUT /admin/org/settings/retention
+func (h *OrgSettingsHandler) HandleUpdateRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[UpdateRetentionPolicyRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	policy := domain.RetentionPolicy{
+		ClosedTicketDays: req.ClosedTicketDays,
+		GraceDays:        domain.DefaultRetentionGraceDays,
+	}
+	if req.GraceDays != nil {
+		policy.GraceDays = *req.GraceDays
+	}
+
+	saved, err := h.settingsService.UpdateRetentionPolicy(r.Context(), claims.UserID, claims.OrgID, policy)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toRetentionPolicyDTO(saved))
+}
+
+// HandleListUpcomingPurges handles GET /admin/org/settings/retention/upcoming.
+// The days query parameter sets how far ahead to look.
+func (h *OrgSettingsHandler) HandleListUpcomingPurges(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	pagination := validation.ParsePagination(r, maxPurgesPerPage)
+	withinDays := defaultPurgeWindowDays
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		days, err := strconv.Atoi(daysStr)
+		if err != nil || days < 0 || days > maxPurgeWindowDays {
+			v := validation.NewValidator()
+			v.Custom("days", false, "Must be a whole number of days between 0 and 365")
+			h.errorHandler.Handle(w, r, v.Errors())
+			return
+		}
+		withinDays = days
+	}
+
+	candidates, total, err := h.settingsService.ListUpcomingPurges(r.Context(), claims.UserID, claims.OrgID,
+		withinDays, pagination.Limit, pagination.Offset)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	response := make([]PurgeCandidateDTO, 0, len(candidates))
+	for _, candidate := range candidates {
+		dto := PurgeCandidateDTO{
+			TicketID:     candidate.TicketID,
+			Title:        candidate.Title,
+			ClosedAt:     candidate.ClosedAt.Format(time.RFC3339),
+			SoftDeleteAt: candidate.SoftDeleteAt.Format(time.RFC3339),
+			HardDeleteAt: candidate.HardDeleteAt.Format(time.RFC3339),
+		}
+		if candidate.DeletedAt != nil {
+			value := candidate.DeletedAt.Format(time.RFC3339)
+			dto.DeletedAt = &value
+		}
+		response = append(response, dto)
+	}
+
+	WritePaginated(w, response, pagination.Limit, pagination.Offset, total)
+}
+
+// RegistrationDomainsDTO defines the JSON representation of the email
+// domains allowed to self-register. An empty list lets anyone register.
+type RegistrationDomainsDTO struct {
+	Domains []string `json:"domains"`
+}
+
+// HandleGetRegistrationDomains handles GET /admin/org/settings/registration-domains
+func (h *OrgSettingsHandler) HandleGetRegistrationDomains(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	domains, err := h.settingsService.GetRegistrationDomains(r.Context(), claims.UserID, claims.OrgID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, RegistrationDomainsDTO{Domains: domains})
+}
+
+// HandleUpdateRegistrationDomains handles PUT /admin/org/settings/registration-domains
+func (h *OrgSettingsHandler) HandleUpdateRegistrationDomains(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[RegistrationDomainsDTO](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	domains, err := h.settingsService.UpdateRegistrationDomains(r.Context(), claims.UserID, claims.OrgID, req.Domains)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, RegistrationDomainsDTO{Domains: domains})
+}
+
+// PortalSettingsDTO defines the JSON representation of the organization's
+// public portal settings. An empty slug turns the portal off.
+type PortalSettingsDTO struct {
+	Slug string `json:"slug"`
+}
+
+// HandleGetPortalSettings handles GET /admin/org/settings/portal
+func (h *OrgSettingsHandler) HandleGetPortalSettings(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	slug, err := h.settingsService.GetPortalSlug(r.Context(), claims.UserID, claims.OrgID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, PortalSettingsDTO{Slug: slug})
+}
+
+// HandleUpdatePortalSettings handles PUT /admin/org/settings/portal
+func (h *OrgSettingsHandler) HandleUpdatePortalSettings(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[PortalSettingsDTO](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	slug, err := h.settingsService.UpdatePortalSlug(r.Context(), claims.UserID, claims.OrgID, req.Slug)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, PortalSettingsDTO{Slug: slug})
+}
+
+// AgentCapacityDTO defines the JSON representation of how many open tickets
+// the organization's agents should have at most. A null maxOpenTickets sets
+// no limit; enforcement is WARN or BLOCK.
+type AgentCapacityDTO struct {
+	MaxOpenTickets *int   `json:"maxOpenTickets"`
+	Enforcement    string `json:"enforcement"`
+}
+
+// UpdateAgentCapacityRequest defines the JSON body for replacing the open
+// ticket limit of the organization's agents. enforcement defaults to WARN.
+type UpdateAgentCapacityRequest struct {
+	MaxOpenTickets *int   `json:"maxOpenTickets"`
+	Enforcement    string `json:"enforcement"`
+}
+
+// HandleGetAgentCapacity handles GET /admin/org/settings/agent-capacity
+func (h *OrgSettingsHandler) HandleGetAgentCapacity(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	capacity, err := h.settingsService.GetAgentCapacity(r.Context(), claims.UserID, claims.OrgID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toAgentCapacityDTO(capacity))
+}
+
+// HandleUpdateAgentCapacity handles PUT /admin/org/settings/agent-capacity
+func (h *OrgSettingsHandler) HandleUpdateAgentCapacity(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[UpdateAgentCapacityRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	capacity := domain.AgentCapacity{
+		MaxOpenTickets: req.MaxOpenTickets,
+		Enforcement:    domain.CapacityWarn,
+	}
+	if req.Enforcement != "" {
+		capacity.Enforcement = domain.CapacityEnforcement(req.Enforcement)
+	}
+
+	saved, err := h.settingsService.UpdateAgentCapacity(r.Context(), claims.UserID, claims.OrgID, capacity)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toAgentCapacityDTO(saved))
+}
+
+func toAgentCapacityDTO(capacity *domain.AgentCapacity) AgentCapacityDTO {
+	return AgentCapacityDTO{
+		MaxOpenTickets: capacity.MaxOpenTickets,
+		Enforcement:    string(capacity.Enforcement),
+	}
+}
+
+func toRetentionPolicyDTO(policy *domain.RetentionPolicy) RetentionPolicyDTO {
+	// The default policy has never been saved.
+	var updatedAt *string
+	if !policy.UpdatedAt.IsZero() {
+		value := policy.UpdatedAt.Format(time.RFC3339)
+		updatedAt = &value
+	}
+
+	return RetentionPolicyDTO{
+		ClosedTicketDays: policy.ClosedTicketDays,
+		GraceDays:        policy.GraceDays,
+		UpdatedAt:        updatedAt,
+	}
+}
+
+func toBusinessHoursDTO(hours *domain.BusinessHours) BusinessHoursDTO {
+	days := make([]WorkingDayDTO, 0, len(hours.Days))
+	for _, day := range hours.Days {
+		days = append(days, WorkingDayDTO{
+			Day:   strings.ToLower(day.Weekday.String()),
+			Open:  day.Open.String(),
+			Close: day.Close.String(),
+		})
+	}
+
+	holidays := make([]HolidayDTO, 0, len(hours.Holidays))
+	for _, holiday := range hours.Holidays {
+		holidays = append(holidays, HolidayDTO{Date: holiday.Date, Name: holiday.Name})
+	}
+
+	// The default calendar has never been saved.
+	var updatedAt *string
+	if !hours.UpdatedAt.IsZero() {
+		value := hours.UpdatedAt.Format(time.RFC3339)
+		updatedAt = &value
+	}
+
+	return BusinessHoursDTO{
+		Timezone:  hours.Timezone,
+		Days:      days,
+		Holidays:  holidays,
+		UpdatedAt: updatedAt,
+	}
+}
+
+// getClaims extracts and validates user claims from the request context.
+func (h *OrgSettingsHandler) getClaims(w http.ResponseWriter, r *http.Request) (*auth.Claims, bool) {
+	claims, ok := mw.GetClaims(r.Context())
+	if !ok {
+		WriteJSON(w, http.StatusUnauthorized, ErrorResponse{
+			Error: "Not authorized",
+			Code:  "UNAUTHORIZED",
+		})
+		return nil, false
+	}
+	return claims, true
+}
@@ -0,0 +1,267 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	mw "github.com/lorrc/service-desk-backend/internal/adapters/primary/http/middleware"
+	"github.com/lorrc/service-desk-backend/internal/adapters/primary/validation"
+	"github.com/lorrc/service-desk-backend/internal/auth"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+const (
+	// maxOrganizationsPerPage caps the organization list page size
+	maxOrganizationsPerPage = 200
+	// impersonationTTL is how long a super-admin's impersonation token lasts
+	impersonationTTL = 30 * time.Minute
+)
+
+// PlatformHandler serves the super-admin endpoints for working across
+// organizations.
+type PlatformHandler struct {
+	platformService ports.PlatformService
+	tokenManager    *auth.TokenManager
+	errorHandler    *ErrorHandler
+	logger          *slog.Logger
+}
+
+func NewPlatformHandler(platformService ports.PlatformService, tokenManager *auth.TokenManager, errorHandler *ErrorHandler, logger *slog.Logger) *PlatformHandler {
+	return &PlatformHandler{
+		platformService: platformService,
+		tokenManager:    tokenManager,
+		errorHandler:    errorHandler,
+		logger:          logger.With("handler", "platform"),
+	}
+}
+
+func (h *PlatformHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.HandleListOrganizations)
+	r.Get("/{orgID}", h.HandleGetOrganization)
+	r.Post("/{orgID}/impersonate", h.HandleImpersonate)
+}
+
+// ImpersonateRequest names the admin a super-admin signs in as.
+type ImpersonateRequest struct {
+	UserID string `json:"userId"`
+}
+
+func (r *ImpersonateRequest) Validate() error {
+	v := validation.NewValidator()
+
+	v.Required("userId", r.UserID).
+		UUID("userId", r.UserID)
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
+// OrganizationSummaryDTO defines the JSON representation of an organization
+// in the super-admin listing.
+type OrganizationSummaryDTO struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	PortalSlug  string `json:"portalSlug"`
+	CreatedAt   string `json:"createdAt"`
+	ActiveUsers int64  `json:"activeUsers"`
+	OpenTickets int64  `json:"openTickets"`
+}
+
+// OrganizationHealthDTO defines the JSON representation of an
+// organization's health. The counts of recent activity start at since.
+type OrganizationHealthDTO struct {
+	Since                    string  `json:"since"`
+	LastActiveAt             *string `json:"lastActiveAt"`
+	TicketsCreated           int64   `json:"ticketsCreated"`
+	OverdueTickets           int64   `json:"overdueTickets"`
+	PendingWebhookDeliveries int64   `json:"pendingWebhookDeliveries"`
+	DeadWebhookDeliveries    int64   `json:"deadWebhookDeliveries"`
+}
+
+// OrganizationOverviewDTO defines the JSON representation of everything a
+// super-admin sees of one organization.
+type OrganizationOverviewDTO struct {
+	ID         string                `json:"id"`
+	Name       string                `json:"name"`
+	PortalSlug string                `json:"portalSlug"`
+	CreatedAt  string                `json:"createdAt"`
+	Usage      UsageReportDTO        `json:"usage"`
+	Health     OrganizationHealthDTO `json:"health"`
+}
+
+// ImpersonationResponse carries a token that acts as the impersonated
+// admin until expiresAt.
+type ImpersonationResponse struct {
+	Token     string   `json:"token"`
+	ExpiresAt string   `json:"expiresAt"`
+	User      *UserDTO `json:"user"`
+}
+
+// HandleListOrganizations handles GET /admin/organizations
+func (h *PlatformHandler) HandleListOrganizations(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	pagination := validation.ParsePagination(r, maxOrganizationsPerPage)
+	filter := domain.OrganizationFilter{
+		Query:  r.URL.Query().Get("q"),
+		Limit:  pagination.Limit,
+		Offset: pagination.Offset,
+	}
+
+	organizations, total, err := h.platformService.ListOrganizations(r.Context(), claims.UserID, claims.OrgID, filter)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	response := make([]OrganizationSummaryDTO, 0, len(organizations))
+	for _, summary := range organizations {
+		response = append(response, toOrganizationSummaryDTO(summary))
+	}
+
+	WritePaginated(w, response, pagination.Limit, pagination.Offset, total)
+}
+
+// HandleGetOrganization handles GET /admin/organizations/{orgID}
+func (h *PlatformHandler) HandleGetOrganization(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	orgID, err := h.parseOrgID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	overview, err := h.platformService.GetOrganization(r.Context(), claims.UserID, orgID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toOrganizationOverviewDTO(overview))
+}
+
+// HandleImpersonate handles POST /admin/organizations/{orgID}/impersonate
+func (h *PlatformHandler) HandleImpersonate(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	orgID, err := h.parseOrgID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[ImpersonateRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	user, err := h.platformService.Impersonate(r.Context(), claims.UserID, orgID, uuid.MustParse(req.UserID))
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	expiresAt := time.Now().Add(impersonationTTL)
+	token, err := h.tokenManager.GenerateImpersonationToken(user.ID, user.OrganizationID, claims.UserID, impersonationTTL)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	h.logger.Info("super-admin impersonating user",
+		"impersonator_id", claims.UserID,
+		"user_id", user.ID,
+		"organization_id", user.OrganizationID,
+	)
+
+	WriteJSON(w, http.StatusOK, ImpersonationResponse{
+		Token:     token,
+		ExpiresAt: expiresAt.UTC().Format(time.RFC3339),
+		User:      toUserDTO(user),
+	})
+}
+
+func toOrganizationSummaryDTO(summary *domain.OrganizationSummary) OrganizationSummaryDTO {
+	org := summary.Organization
+	return OrganizationSummaryDTO{
+		ID:          org.ID.String(),
+		Name:        org.Name,
+		PortalSlug:  org.PortalSlug,
+		CreatedAt:   org.CreatedAt.Format(time.RFC3339),
+		ActiveUsers: summary.Usage.ActiveUsers,
+		OpenTickets: summary.Usage.OpenTickets,
+	}
+}
+
+func toOrganizationOverviewDTO(overview *domain.OrganizationOverview) OrganizationOverviewDTO {
+	org, report, health := overview.Organization, overview.Quota, overview.Health
+
+	var lastActive *string
+	if health.LastActiveAt != nil {
+		value := health.LastActiveAt.Format(time.RFC3339)
+		lastActive = &value
+	}
+
+	return OrganizationOverviewDTO{
+		ID:         org.ID.String(),
+		Name:       org.Name,
+		PortalSlug: org.PortalSlug,
+		CreatedAt:  org.CreatedAt.Format(time.RFC3339),
+		Usage: UsageReportDTO{
+			Users:       QuotaUsageDTO{Used: report.Usage.ActiveUsers, Limit: report.Quota.MaxUsers},
+			OpenTickets: QuotaUsageDTO{Used: report.Usage.OpenTickets, Limit: report.Quota.MaxOpenTickets},
+		},
+		Health: OrganizationHealthDTO{
+			Since:                    health.Since.Format(time.RFC3339),
+			LastActiveAt:             lastActive,
+			TicketsCreated:           health.TicketsCreated,
+			OverdueTickets:           health.OverdueTickets,
+			PendingWebhookDeliveries: health.PendingWebhookDeliveries,
+			DeadWebhookDeliveries:    health.DeadWebhookDeliveries,
+		},
+	}
+}
+
+func (h *PlatformHandler) parseOrgID(r *http.Request) (uuid.UUID, error) {
+	orgID, err := uuid.Parse(chi.URLParam(r, "orgID"))
+	if err != nil {
+		v := validation.NewValidator()
+		v.Custom("orgID", false, "Invalid organization ID")
+		return uuid.Nil, v.Errors()
+	}
+	return orgID, nil
+}
+
+// getClaims extracts and validates user claims from the request context.
+func (h *PlatformHandler) getClaims(w http.ResponseWriter, r *http.Request) (*auth.Claims, bool) {
+	claims, ok := mw.GetClaims(r.Context())
+	if !ok {
+		WriteJSON(w, http.StatusUnauthorized, ErrorResponse{
+			Error: "Not authorized",
+			Code:  "UNAUTHORIZED",
+		})
+		return nil, false
+	}
+	return claims, true
+}
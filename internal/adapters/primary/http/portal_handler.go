@@ -0,0 +1,252 @@
+package http
+
+import (
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	mw "github.com/lorrc/service-desk-backend/internal/adapters/primary/http/middleware"
+	"github.com/lorrc/service-desk-backend/internal/adapters/primary/validation"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// PortalHandler handles the public portal: anonymous ticket submissions
+// under /public/{orgSlug}, or /public/tickets for the organization named by
+// the X-Org-Slug header or subdomain, and guest access under
+// /public/tickets. Nothing
+// here is authenticated; submissions are guarded by a captcha and guest
+// access by the per-ticket token emailed to the requester.
+type PortalHandler struct {
+	portalService ports.PortalService
+	errorHandler  *ErrorHandler
+	logger        *slog.Logger
+}
+
+// NewPortalHandler creates a new PortalHandler.
+func NewPortalHandler(portalService ports.PortalService, errorHandler *ErrorHandler, logger *slog.Logger) *PortalHandler {
+	return &PortalHandler{
+		portalService: portalService,
+		errorHandler:  errorHandler,
+		logger:        logger.With("handler", "portal"),
+	}
+}
+
+// RegisterSubmissionRoutes registers the /public route tickets are
+// submitted to, which should be strictly rate limited.
+func (h *PortalHandler) RegisterSubmissionRoutes(r chi.Router) {
+	r.Post("/{orgSlug}/tickets", h.HandleSubmitTicket)
+	r.Post("/tickets", h.HandleSubmitTicket)
+}
+
+// RegisterLinkRoutes registers the /public routes guests reach with the
+// ?token= from their link.
+func (h *PortalHandler) RegisterLinkRoutes(r chi.Router) {
+	r.Get("/tickets/{ticketID}", h.HandleGetTicket)
+	r.Post("/tickets/{ticketID}/comments", h.HandleAddComment)
+}
+
+// SubmitPortalTicketRequest defines the JSON body of a portal submission.
+type SubmitPortalTicketRequest struct {
+	FullName     string `json:"fullName"`
+	Email        string `json:"email"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	CaptchaToken string `json:"captchaToken"`
+}
+
+func (r *SubmitPortalTicketRequest) Validate() error {
+	v := validation.NewValidator()
+
+	v.Required("fullName", r.FullName).
+		MaxLength("fullName", r.FullName, domain.MaxFullNameLength)
+	v.Required("email", r.Email).
+		Email("email", r.Email)
+	v.Required("title", r.Title).
+		MaxLength("title", r.Title, domain.MaxTitleLength)
+	v.MaxLength("description", r.Description, domain.MaxDescriptionLength)
+	v.Required("captchaToken", r.CaptchaToken)
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
+// PortalSubmissionDTO is the response to a portal submission. The link to
+// the ticket is only sent by email, to prove the submitter owns the address.
+type PortalSubmissionDTO struct {
+	TicketID int64 `json:"ticketId"`
+}
+
+// PortalTicketDTO is a ticket as its guest requester sees it.
+type PortalTicketDTO struct {
+	ID              int64              `json:"id"`
+	Title           string             `json:"title"`
+	Description     string             `json:"description"`
+	DescriptionHTML string             `json:"descriptionHtml"`
+	Status          string             `json:"status"`
+	CreatedAt       string             `json:"createdAt"`
+	UpdatedAt       *string            `json:"updatedAt,omitempty"`
+	Comments        []PortalCommentDTO `json:"comments"`
+}
+
+// PortalCommentDTO is a comment as a guest sees it. Staff are not named.
+type PortalCommentDTO struct {
+	ID            string `json:"id"`
+	Body          string `json:"body"`
+	BodyHTML      string `json:"bodyHtml"`
+	FromRequester bool   `json:"fromRequester"`
+	CreatedAt     string `json:"createdAt"`
+}
+
+// HandleSubmitTicket handles POST /public/{orgSlug}/tickets and
+// POST /public/tickets
+func (h *PortalHandler) HandleSubmitTicket(w http.ResponseWriter, r *http.Request) {
+	req, err := validation.DecodeAndValidate[SubmitPortalTicketRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	slug := chi.URLParam(r, "orgSlug")
+	if slug == "" {
+		org, ok := mw.GetOrganization(r.Context())
+		if !ok {
+			h.errorHandler.Handle(w, r, apperrors.ErrPortalNotFound)
+			return
+		}
+		slug = org.Slug
+	}
+
+	ticket, err := h.portalService.SubmitTicket(r.Context(), ports.SubmitPortalTicketParams{
+		Slug: slug,
+		Submission: domain.PortalSubmission{
+			FullName:    req.FullName,
+			Email:       req.Email,
+			Title:       req.Title,
+			Description: req.Description,
+		},
+		CaptchaToken: req.CaptchaToken,
+		RemoteIP:     remoteIP(r),
+	})
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	h.logger.Info("ticket submitted through public portal",
+		"ticket_id", ticket.ID,
+		"org_slug", slug,
+	)
+	WriteCreated(w, PortalSubmissionDTO{TicketID: ticket.ID})
+}
+
+// HandleGetTicket handles GET /public/tickets/{ticketID}?token=
+func (h *PortalHandler) HandleGetTicket(w http.ResponseWriter, r *http.Request) {
+	ticketID, err := h.parseTicketID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	ticket, comments, err := h.portalService.GetTicket(r.Context(), ticketID, r.URL.Query().Get("token"))
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	response := PortalTicketDTO{
+		ID:              ticket.ID,
+		Title:           ticket.Title,
+		Description:     ticket.Description,
+		DescriptionHTML: ticket.DescriptionHTML,
+		Status:          string(ticket.Status),
+		CreatedAt:       ticket.CreatedAt.Format(time.RFC3339),
+		Comments:        make([]PortalCommentDTO, 0, len(comments)),
+	}
+	if ticket.UpdatedAt != nil {
+		updatedAt := ticket.UpdatedAt.Format(time.RFC3339)
+		response.UpdatedAt = &updatedAt
+	}
+	for _, comment := range comments {
+		response.Comments = append(response.Comments, toPortalCommentDTO(comment, ticket))
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// HandleAddComment handles POST /public/tickets/{ticketID}/comments?token=
+func (h *PortalHandler) HandleAddComment(w http.ResponseWriter, r *http.Request) {
+	ticketID, err := h.parseTicketID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[CreateCommentRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	comment, err := h.portalService.AddComment(r.Context(), ticketID, r.URL.Query().Get("token"), req.Body)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteCreated(w, PortalCommentDTO{
+		ID:            strconv.FormatInt(comment.ID, 10),
+		Body:          comment.Body,
+		BodyHTML:      comment.BodyHTML,
+		FromRequester: true,
+		CreatedAt:     comment.CreatedAt.Format(time.RFC3339),
+	})
+}
+
+func toPortalCommentDTO(comment *domain.Comment, ticket *domain.Ticket) PortalCommentDTO {
+	return PortalCommentDTO{
+		ID:            strconv.FormatInt(comment.ID, 10),
+		Body:          comment.Body,
+		BodyHTML:      comment.BodyHTML,
+		FromRequester: comment.AuthorID == ticket.RequesterID,
+		CreatedAt:     comment.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// parseTicketID extracts and validates the ticket ID from the URL
+func (h *PortalHandler) parseTicketID(r *http.Request) (int64, error) {
+	ticketIDStr := chi.URLParam(r, "ticketID")
+	ticketID, err := strconv.ParseInt(ticketIDStr, 10, 64)
+	if err != nil || ticketID <= 0 {
+		v := validation.NewValidator()
+		v.Custom("ticketID", false, "Invalid ticket ID")
+		return 0, v.Errors()
+	}
+	return ticketID, nil
+}
+
+// remoteIP returns the client's IP address, which the RealIP middleware has
+// already taken from the proxy headers.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	mw "github.com/lorrc/service-desk-backend/internal/adapters/primary/http/middleware"
+	"github.com/lorrc/service-desk-backend/internal/auth"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// QuotaHandler handles HTTP requests for an organization's usage quotas.
+type QuotaHandler struct {
+	quotaService ports.QuotaService
+	errorHandler *ErrorHandler
+	logger       *slog.Logger
+}
+
+// NewQuotaHandler creates a new QuotaHandler.
+func NewQuotaHandler(quotaService ports.QuotaService, errorHandler *ErrorHandler, logger *slog.Logger) *QuotaHandler {
+	return &QuotaHandler{
+		quotaService: quotaService,
+		errorHandler: errorHandler,
+		logger:       logger.With("handler", "quota"),
+	}
+}
+
+// RegisterRoutes registers the /admin/usage routes.
+func (h *QuotaHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.HandleGetUsage)
+}
+
+// QuotaUsageDTO defines the JSON representation of one quota. A null limit
+// is unlimited.
+type QuotaUsageDTO struct {
+	Used  int64 `json:"used"`
+	Limit *int  `json:"limit"`
+}
+
+// UsageReportDTO defines the JSON representation of an organization's usage
+// against its quotas.
+type UsageReportDTO struct {
+	Users       QuotaUsageDTO `json:"users"`
+	OpenTickets QuotaUsageDTO `json:"openTickets"`
+}
+
+// HandleGetUsage handles GET /admin/usage
+func (h *QuotaHandler) HandleGetUsage(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.quotaService.GetReport(r.Context(), claims.UserID, claims.OrgID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, UsageReportDTO{
+		Users:       QuotaUsageDTO{Used: report.Usage.ActiveUsers, Limit: report.Quota.MaxUsers},
+		OpenTickets: QuotaUsageDTO{Used: report.Usage.OpenTickets, Limit: report.Quota.MaxOpenTickets},
+	})
+}
+
+// getClaims extracts and validates user claims from the request context.
+func (h *QuotaHandler) getClaims(w http.ResponseWriter, r *http.Request) (*auth.Claims, bool) {
+	claims, ok := mw.GetClaims(r.Context())
+	if !ok {
+		WriteJSON(w, http.StatusUnauthorized, ErrorResponse{
+			Error: "Not authorized",
+			Code:  "UNAUTHORIZED",
+		})
+		return nil, false
+	}
+	return claims, true
+}
@@ -0,0 +1,255 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	mw "github.com/lorrc/service-desk-backend/internal/adapters/primary/http/middleware"
+	"github.com/lorrc/service-desk-backend/internal/adapters/primary/validation"
+	"github.com/lorrc/service-desk-backend/internal/auth"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// RateLimitHandler handles HTTP requests for inspecting the API rate limits
+// and managing overrides.
+type RateLimitHandler struct {
+	rateLimitService ports.RateLimitService
+	errorHandler     *ErrorHandler
+	logger           *slog.Logger
+}
+
+// NewRateLimitHandler creates a new RateLimitHandler.
+func NewRateLimitHandler(rateLimitService ports.RateLimitService, errorHandler *ErrorHandler, logger *slog.Logger) *RateLimitHandler {
+	return &RateLimitHandler{
+		rateLimitService: rateLimitService,
+		errorHandler:     errorHandler,
+		logger:           logger.With("handler", "rate_limit"),
+	}
+}
+
+// RegisterRoutes registers the /admin/rate-limits routes.
+func (h *RateLimitHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.HandleListThrottled)
+	r.Get("/overrides", h.HandleListOverrides)
+	r.Put("/overrides", h.HandleSetOverride)
+	r.Delete("/overrides/{overrideID}", h.HandleDeleteOverride)
+	r.Delete("/{key}", h.HandleClearThrottled)
+}
+
+// SetRateLimitOverrideRequest defines the JSON body for setting an override.
+// Without userId the override applies to the whole organization.
+type SetRateLimitOverrideRequest struct {
+	UserID            *string  `json:"userId"`
+	Unlimited         bool     `json:"unlimited"`
+	RequestsPerSecond *float64 `json:"requestsPerSecond"`
+	Burst             *int     `json:"burst"`
+	Note              string   `json:"note"`
+}
+
+func (r *SetRateLimitOverrideRequest) Validate() error {
+	v := validation.NewValidator()
+
+	if r.UserID != nil {
+		v.Required("userId", *r.UserID).
+			UUID("userId", *r.UserID)
+	}
+	if !r.Unlimited {
+		v.NotNil("requestsPerSecond", r.RequestsPerSecond).
+			NotNil("burst", r.Burst)
+	}
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
+// ThrottledClientDTO defines the JSON representation of a throttled client.
+type ThrottledClientDTO struct {
+	Limiter      string `json:"limiter"`
+	Key          string `json:"key"`
+	Rejected     int    `json:"rejected"`
+	LastRejected string `json:"lastRejectedAt"`
+	Blocked      bool   `json:"blocked"`
+}
+
+// RateLimitOverrideDTO defines the JSON representation of an override.
+type RateLimitOverrideDTO struct {
+	ID                string   `json:"id"`
+	UserID            *string  `json:"userId"`
+	Unlimited         bool     `json:"unlimited"`
+	RequestsPerSecond *float64 `json:"requestsPerSecond"`
+	Burst             *int     `json:"burst"`
+	Note              string   `json:"note"`
+	CreatedBy         string   `json:"createdBy"`
+	CreatedAt         string   `json:"createdAt"`
+}
+
+// HandleListThrottled handles GET /admin/rate-limits
+func (h *RateLimitHandler) HandleListThrottled(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	clients, err := h.rateLimitService.ListThrottled(r.Context(), claims.UserID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	response := make([]ThrottledClientDTO, 0, len(clients))
+	for _, client := range clients {
+		response = append(response, ThrottledClientDTO{
+			Limiter:      client.Limiter,
+			Key:          client.Key,
+			Rejected:     client.Rejected,
+			LastRejected: client.LastRejected.UTC().Format(time.RFC3339),
+			Blocked:      client.Blocked,
+		})
+	}
+
+	WriteList(w, response)
+}
+
+// HandleClearThrottled handles DELETE /admin/rate-limits/{key}
+func (h *RateLimitHandler) HandleClearThrottled(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.rateLimitService.ClearThrottled(r.Context(), claims.UserID, claims.OrgID, chi.URLParam(r, "key")); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteNoContent(w)
+}
+
+// HandleListOverrides handles GET /admin/rate-limits/overrides
+func (h *RateLimitHandler) HandleListOverrides(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	overrides, err := h.rateLimitService.ListOverrides(r.Context(), claims.UserID, claims.OrgID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	response := make([]RateLimitOverrideDTO, 0, len(overrides))
+	for _, override := range overrides {
+		response = append(response, toRateLimitOverrideDTO(override))
+	}
+
+	WriteList(w, response)
+}
+
+// HandleSetOverride handles PUT /admin/rate-limits/overrides
+func (h *RateLimitHandler) HandleSetOverride(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[SetRateLimitOverrideRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	params := domain.RateLimitOverrideParams{
+		Unlimited: req.Unlimited,
+		Note:      req.Note,
+	}
+	if req.UserID != nil {
+		userID, err := uuid.Parse(*req.UserID)
+		if err != nil {
+			h.errorHandler.Handle(w, r, err)
+			return
+		}
+		params.UserID = &userID
+	}
+	if req.RequestsPerSecond != nil {
+		params.RequestsPerSecond = *req.RequestsPerSecond
+	}
+	if req.Burst != nil {
+		params.Burst = *req.Burst
+	}
+
+	override, err := h.rateLimitService.SetOverride(r.Context(), claims.UserID, claims.OrgID, params)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toRateLimitOverrideDTO(override))
+}
+
+// HandleDeleteOverride handles DELETE /admin/rate-limits/overrides/{overrideID}
+func (h *RateLimitHandler) HandleDeleteOverride(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	overrideID, err := uuid.Parse(chi.URLParam(r, "overrideID"))
+	if err != nil {
+		v := validation.NewValidator()
+		v.Custom("overrideID", false, "Invalid override ID")
+		h.errorHandler.Handle(w, r, v.Errors())
+		return
+	}
+
+	if err := h.rateLimitService.DeleteOverride(r.Context(), claims.UserID, claims.OrgID, overrideID); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteNoContent(w)
+}
+
+func toRateLimitOverrideDTO(override *domain.RateLimitOverride) RateLimitOverrideDTO {
+	dto := RateLimitOverrideDTO{
+		ID:        override.ID.String(),
+		Unlimited: override.Unlimited,
+		Note:      override.Note,
+		CreatedBy: override.CreatedBy.String(),
+		CreatedAt: override.CreatedAt.Format(time.RFC3339),
+	}
+	if override.UserID != nil {
+		value := override.UserID.String()
+		dto.UserID = &value
+	}
+	if !override.Unlimited {
+		rps, burst := override.RequestsPerSecond, override.Burst
+		dto.RequestsPerSecond = &rps
+		dto.Burst = &burst
+	}
+	return dto
+}
+
+// getClaims extracts and validates user claims from the request context.
+func (h *RateLimitHandler) getClaims(w http.ResponseWriter, r *http.Request) (*auth.Claims, bool) {
+	claims, ok := mw.GetClaims(r.Context())
+	if !ok {
+		WriteJSON(w, http.StatusUnauthorized, ErrorResponse{
+			Error: "Not authorized",
+			Code:  "UNAUTHORIZED",
+		})
+		return nil, false
+	}
+	return claims, true
+}
@@ -3,6 +3,8 @@ package http
 import (
 	"encoding/json"
 	"net/http"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
 )
 
 // PaginatedResponse wraps paginated data with metadata
@@ -11,12 +13,14 @@ type PaginatedResponse[T any] struct {
 	Pagination PaginationMetadata `json:"pagination"`
 }
 
-// PaginationMetadata contains pagination information
+// PaginationMetadata contains pagination information. Approximate is set
+// when TotalCount is an estimate because counting exactly took too long.
 type PaginationMetadata struct {
-	Limit      int   `json:"limit"`
-	Offset     int   `json:"offset"`
-	TotalCount int64 `json:"totalCount,omitempty"`
-	HasMore    bool  `json:"hasMore"`
+	Limit       int   `json:"limit"`
+	Offset      int   `json:"offset"`
+	TotalCount  int64 `json:"totalCount,omitempty"`
+	Approximate bool  `json:"approximate,omitempty"`
+	HasMore     bool  `json:"hasMore"`
 }
 
 // SuccessResponse wraps a successful response
@@ -65,16 +69,21 @@ func WriteNoContent(w http.ResponseWriter) {
 }
 
 // WritePaginated writes a paginated response
-func WritePaginated[T any](w http.ResponseWriter, data []T, limit, offset int, totalCount int64) {
-	hasMore := int64(offset+len(data)) < totalCount
+func WritePaginated[T any](w http.ResponseWriter, data []T, limit, offset int, total domain.Total) {
+	hasMore := int64(offset+len(data)) < total.Count
+	if total.Approximate {
+		// An estimate can be off either way; a full page likely has more
+		hasMore = len(data) >= limit
+	}
 
 	response := PaginatedResponse[T]{
 		Data: data,
 		Pagination: PaginationMetadata{
-			Limit:      limit,
-			Offset:     offset,
-			TotalCount: totalCount,
-			HasMore:    hasMore,
+			Limit:       limit,
+			Offset:      offset,
+			TotalCount:  total.Count,
+			Approximate: total.Approximate,
+			HasMore:     hasMore,
 		},
 	}
 
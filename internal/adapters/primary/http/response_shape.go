@@ -0,0 +1,177 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/lorrc/service-desk-backend/internal/adapters/primary/validation"
+)
+
+// ResponseShaper lets clients trim and expand one kind of resource with the
+// ?fields= and ?embed= query parameters.
+//
+//	?fields=id,title,status              only these fields are returned
+//	?embed=requester,assignee,lastComment only these related resources are embedded
+//
+// Embedded resources are returned even if they are not listed in fields.
+type ResponseShaper struct {
+	names         []string // JSON field names, in response order
+	known         map[string]bool
+	embeddable    map[string]bool
+	defaultEmbeds []string
+}
+
+// NewResponseShaper creates a shaper for responses encoded from dto's type.
+// embeds are the fields holding related resources, which are only returned
+// when embedded; defaultEmbeds are embedded when the request has no embed
+// parameter.
+func NewResponseShaper(dto any, embeds, defaultEmbeds []string) *ResponseShaper {
+	s := &ResponseShaper{
+		known:         make(map[string]bool),
+		embeddable:    make(map[string]bool, len(embeds)),
+		defaultEmbeds: defaultEmbeds,
+	}
+
+	t := reflect.TypeOf(dto)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		s.names = append(s.names, name)
+		s.known[name] = true
+	}
+
+	for _, embed := range embeds {
+		if !s.known[embed] {
+			panic("response shaper: " + embed + " is not a field of " + t.Name())
+		}
+		s.embeddable[embed] = true
+	}
+
+	return s
+}
+
+// ResponseShape is the shape a request asked for.
+type ResponseShape struct {
+	shaper *ResponseShaper
+	fields map[string]bool // nil keeps every field
+	embeds map[string]bool
+}
+
+// Parse reads the fields and embed query parameters, rejecting names the
+// resource doesn't have.
+func (s *ResponseShaper) Parse(r *http.Request) (ResponseShape, error) {
+	query := r.URL.Query()
+	v := validation.NewValidator()
+	shape := ResponseShape{shaper: s, embeds: make(map[string]bool)}
+
+	if fields := splitQueryList(query.Get("fields")); len(fields) > 0 {
+		shape.fields = make(map[string]bool, len(fields))
+		for _, field := range fields {
+			if !s.known[field] {
+				v.Custom("fields", false, "Unknown field: "+field)
+				continue
+			}
+			shape.fields[field] = true
+		}
+	}
+
+	embeds := s.defaultEmbeds
+	if query.Has("embed") {
+		embeds = splitQueryList(query.Get("embed"))
+	}
+	for _, embed := range embeds {
+		if !s.embeddable[embed] {
+			v.Custom("embed", false, "Unknown embed: "+embed)
+			continue
+		}
+		shape.embeds[embed] = true
+	}
+
+	if v.HasErrors() {
+		return ResponseShape{}, v.Errors()
+	}
+	return shape, nil
+}
+
+// Embeds reports whether the related resource should be loaded.
+func (s ResponseShape) Embeds(name string) bool {
+	return s.embeds[name]
+}
+
+// Apply trims v, a value of the shaper's DTO type, to the requested shape.
+func (s ResponseShape) Apply(v any) (json.RawMessage, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &values); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	first := true
+	for _, name := range s.shaper.names {
+		value, ok := values[name]
+		if !ok || !s.keeps(name) {
+			continue
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		key, _ := json.Marshal(name)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// keeps reports whether a field belongs in the response.
+func (s ResponseShape) keeps(name string) bool {
+	if s.shaper.embeddable[name] {
+		return s.embeds[name]
+	}
+	return s.fields == nil || s.fields[name]
+}
+
+// ApplyShape trims each item to the requested shape.
+func ApplyShape[T any](shape ResponseShape, items []T) ([]json.RawMessage, error) {
+	shaped := make([]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		value, err := shape.Apply(item)
+		if err != nil {
+			return nil, err
+		}
+		shaped = append(shaped, value)
+	}
+	return shaped, nil
+}
+
+// splitQueryList splits a comma-separated query parameter, dropping blanks.
+func splitQueryList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
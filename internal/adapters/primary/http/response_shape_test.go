@@ -0,0 +1,82 @@
+package http
+
+import (
+	stdhttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseShaper(t *testing.T) {
+	type itemDTO struct {
+		ID     int64        `json:"id"`
+		Title  string       `json:"title"`
+		Status string       `json:"status"`
+		Owner  *UserInfoDTO `json:"owner,omitempty"`
+		Latest *CommentDTO  `json:"latest,omitempty"`
+	}
+	shaper := NewResponseShaper(itemDTO{}, []string{"owner", "latest"}, []string{"owner"})
+	item := itemDTO{
+		ID:     1,
+		Title:  "Printer",
+		Status: "OPEN",
+		Owner:  &UserInfoDTO{ID: "u1", FullName: "Ann", Email: "ann@example.com"},
+		Latest: &CommentDTO{ID: "7", TicketID: 1, AuthorID: "u1", Body: "hi", CreatedAt: "2026-01-02T03:04:05Z"},
+	}
+
+	shape := func(t *testing.T, query string) ResponseShape {
+		t.Helper()
+		s, err := shaper.Parse(httptest.NewRequest(stdhttp.MethodGet, "/items"+query, nil))
+		require.NoError(t, err)
+		return s
+	}
+
+	t.Run("defaults keep every field and the default embeds", func(t *testing.T) {
+		s := shape(t, "")
+		assert.True(t, s.Embeds("owner"))
+		assert.False(t, s.Embeds("latest"))
+
+		body, err := s.Apply(item)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id":1,"title":"Printer","status":"OPEN","owner":{"id":"u1","fullName":"Ann","email":"ann@example.com"}}`, string(body))
+	})
+
+	t.Run("fields trims the response in field order", func(t *testing.T) {
+		body, err := shape(t, "?fields=status,%20id&embed=").Apply(item)
+		require.NoError(t, err)
+		assert.Equal(t, `{"id":1,"status":"OPEN"}`, string(body))
+	})
+
+	t.Run("embeds are returned without being listed in fields", func(t *testing.T) {
+		s := shape(t, "?fields=id&embed=latest")
+		assert.False(t, s.Embeds("owner"))
+		assert.True(t, s.Embeds("latest"))
+
+		body, err := s.Apply(item)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id":1,"latest":{"id":"7","ticketId":1,"authorId":"u1","body":"hi","bodyHtml":"","createdAt":"2026-01-02T03:04:05Z"}}`, string(body))
+	})
+
+	t.Run("applies to every item", func(t *testing.T) {
+		items, err := ApplyShape(shape(t, "?fields=id&embed="), []itemDTO{item, {ID: 2}})
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		assert.Equal(t, `{"id":2}`, string(items[1]))
+	})
+
+	t.Run("rejects unknown names", func(t *testing.T) {
+		_, err := shaper.Parse(httptest.NewRequest(stdhttp.MethodGet, "/items?fields=id,secret&embed=owner,nope", nil))
+
+		var validationErrs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &validationErrs)
+		assert.Contains(t, validationErrs.Errors, "fields")
+		assert.Contains(t, validationErrs.Errors, "embed")
+	})
+
+	t.Run("embeds must be fields", func(t *testing.T) {
+		assert.Panics(t, func() { NewResponseShaper(itemDTO{}, []string{"missing"}, nil) })
+	})
+}
@@ -0,0 +1,86 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	mw "github.com/lorrc/service-desk-backend/internal/adapters/primary/http/middleware"
+	"github.com/lorrc/service-desk-backend/internal/adapters/primary/validation"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// SearchHitDTO is a ticket or comment matching a search.
+type SearchHitDTO struct {
+	Kind     string  `json:"kind"`
+	ID       int64   `json:"id"`
+	TicketID int64   `json:"ticketId"`
+	Title    string  `json:"title"`
+	Snippet  string  `json:"snippet"`
+	Score    float64 `json:"score"`
+}
+
+// SearchHandler handles HTTP requests for ticket and comment search.
+type SearchHandler struct {
+	searchService ports.SearchService
+	errorHandler  *ErrorHandler
+	logger        *slog.Logger
+}
+
+// NewSearchHandler creates a new SearchHandler.
+func NewSearchHandler(
+	searchService ports.SearchService,
+	errorHandler *ErrorHandler,
+	logger *slog.Logger,
+) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+		errorHandler:  errorHandler,
+		logger:        logger.With("handler", "search"),
+	}
+}
+
+// RegisterRoutes registers the /search routes.
+func (h *SearchHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.HandleSearch)
+}
+
+// HandleSearch handles GET /search?q=...&limit=...
+func (h *SearchHandler) HandleSearch(w http.ResponseWriter, r *http.Request) {
+	claims, ok := mw.GetClaims(r.Context())
+	if !ok {
+		WriteJSON(w, http.StatusUnauthorized, ErrorResponse{
+			Error: "Not authorized",
+			Code:  "UNAUTHORIZED",
+		})
+		return
+	}
+
+	hits, err := h.searchService.Search(r.Context(), ports.SearchParams{
+		ViewerID: claims.UserID,
+		Text:     r.URL.Query().Get("q"),
+		Limit:    validation.ParseIntQueryParam(r, "limit", domain.DefaultSearchLimit),
+	})
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteList(w, mapSearchHits(hits))
+}
+
+func mapSearchHits(hits []*domain.SearchHit) []SearchHitDTO {
+	dtos := make([]SearchHitDTO, 0, len(hits))
+	for _, hit := range hits {
+		dtos = append(dtos, SearchHitDTO{
+			Kind:     string(hit.Kind),
+			ID:       hit.ID,
+			TicketID: hit.TicketID,
+			Title:    hit.Title,
+			Snippet:  hit.Snippet,
+			Score:    hit.Score,
+		})
+	}
+	return dtos
+}
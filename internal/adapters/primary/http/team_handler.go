@@ -0,0 +1,267 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	mw "github.com/lorrc/service-desk-backend/internal/adapters/primary/http/middleware"
+	"github.com/lorrc/service-desk-backend/internal/adapters/primary/validation"
+	"github.com/lorrc/service-desk-backend/internal/auth"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// TeamHandler handles HTTP requests for managing teams under /admin/teams.
+type TeamHandler struct {
+	teamService  ports.TeamService
+	errorHandler *ErrorHandler
+	logger       *slog.Logger
+}
+
+// NewTeamHandler creates a new TeamHandler.
+func NewTeamHandler(teamService ports.TeamService, errorHandler *ErrorHandler, logger *slog.Logger) *TeamHandler {
+	return &TeamHandler{
+		teamService:  teamService,
+		errorHandler: errorHandler,
+		logger:       logger.With("handler", "team"),
+	}
+}
+
+// RegisterRoutes registers the /admin/teams routes.
+func (h *TeamHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.HandleListTeams)
+	r.Post("/", h.HandleCreateTeam)
+	r.Patch("/{teamID}", h.HandleRenameTeam)
+	r.Delete("/{teamID}", h.HandleDeleteTeam)
+	r.Put("/{teamID}/members/{userID}", h.HandleAddMember)
+	r.Delete("/{teamID}/members/{userID}", h.HandleRemoveMember)
+}
+
+// TeamRequest defines the JSON body for creating or renaming a team.
+type TeamRequest struct {
+	Name string `json:"name"`
+}
+
+func (r *TeamRequest) Validate() error {
+	v := validation.NewValidator()
+
+	v.Required("name", r.Name).
+		MaxLength("name", r.Name, domain.MaxTeamNameLength)
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
+// TeamDTO defines the JSON representation of a team.
+type TeamDTO struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	MemberIDs []string `json:"memberIds"`
+	CreatedAt string   `json:"createdAt"`
+}
+
+// HandleListTeams handles GET /admin/teams
+func (h *TeamHandler) HandleListTeams(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	teams, err := h.teamService.ListTeams(r.Context(), claims.UserID, claims.OrgID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	response := make([]TeamDTO, 0, len(teams))
+	for _, team := range teams {
+		response = append(response, toTeamDTO(team))
+	}
+
+	WriteList(w, response)
+}
+
+// HandleCreateTeam handles POST /admin/teams
+func (h *TeamHandler) HandleCreateTeam(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[TeamRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	team, err := h.teamService.CreateTeam(r.Context(), claims.UserID, claims.OrgID, req.Name)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteCreated(w, toTeamDTO(team))
+}
+
+// HandleRenameTeam handles PATCH /admin/teams/{teamID}
+func (h *TeamHandler) HandleRenameTeam(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	teamID, err := h.parseTeamID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[TeamRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	team, err := h.teamService.RenameTeam(r.Context(), claims.UserID, claims.OrgID, teamID, req.Name)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toTeamDTO(team))
+}
+
+// HandleDeleteTeam handles DELETE /admin/teams/{teamID}
+func (h *TeamHandler) HandleDeleteTeam(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	teamID, err := h.parseTeamID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := h.teamService.DeleteTeam(r.Context(), claims.UserID, claims.OrgID, teamID); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteNoContent(w)
+}
+
+// HandleAddMember handles PUT /admin/teams/{teamID}/members/{userID}
+func (h *TeamHandler) HandleAddMember(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	teamID, userID, err := h.parseMemberPath(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	team, err := h.teamService.AddMember(r.Context(), claims.UserID, claims.OrgID, teamID, userID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toTeamDTO(team))
+}
+
+// HandleRemoveMember handles DELETE /admin/teams/{teamID}/members/{userID}
+func (h *TeamHandler) HandleRemoveMember(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	teamID, userID, err := h.parseMemberPath(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	team, err := h.teamService.RemoveMember(r.Context(), claims.UserID, claims.OrgID, teamID, userID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toTeamDTO(team))
+}
+
+func toTeamDTO(team *domain.Team) TeamDTO {
+	memberIDs := make([]string, 0, len(team.MemberIDs))
+	for _, id := range team.MemberIDs {
+		memberIDs = append(memberIDs, id.String())
+	}
+
+	return TeamDTO{
+		ID:        team.ID.String(),
+		Name:      team.Name,
+		MemberIDs: memberIDs,
+		CreatedAt: team.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func (h *TeamHandler) parseTeamID(r *http.Request) (uuid.UUID, error) {
+	idParam := chi.URLParam(r, "teamID")
+	teamID, err := uuid.Parse(idParam)
+	if err != nil {
+		v := validation.NewValidator()
+		v.Custom("teamID", false, "Invalid team ID")
+		return uuid.Nil, v.Errors()
+	}
+
+	return teamID, nil
+}
+
+func (h *TeamHandler) parseMemberPath(r *http.Request) (uuid.UUID, uuid.UUID, error) {
+	teamID, err := h.parseTeamID(r)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		v := validation.NewValidator()
+		v.Custom("userID", false, "Invalid user ID")
+		return uuid.Nil, uuid.Nil, v.Errors()
+	}
+
+	return teamID, userID, nil
+}
+
+// getClaims extracts and validates user claims from the request context.
+func (h *TeamHandler) getClaims(w http.ResponseWriter, r *http.Request) (*auth.Claims, bool) {
+	claims, ok := mw.GetClaims(r.Context())
+	if !ok {
+		WriteJSON(w, http.StatusUnauthorized, ErrorResponse{
+			Error: "Not authorized",
+			Code:  "UNAUTHORIZED",
+		})
+		return nil, false
+	}
+	return claims, true
+}
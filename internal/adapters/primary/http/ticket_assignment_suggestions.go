@@ -0,0 +1,55 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+)
+
+// AssignmentSuggestionDTO is an agent suggested for a ticket, with the
+// figures the ranking is based on. The assignee's open tickets are the
+// other one.
+type AssignmentSuggestionDTO struct {
+	Assignee AssigneeDTO `json:"assignee"`
+	// SharedTagTickets and SameTeamTickets count the tickets of the last
+	// 180 days the agent was assigned that share a tag or the team
+	SharedTagTickets int64   `json:"sharedTagTickets"`
+	SameTeamTickets  int64   `json:"sameTeamTickets"`
+	Score            float64 `json:"score"`
+}
+
+func toAssignmentSuggestionDTOs(suggestions []domain.AssignmentSuggestion) []AssignmentSuggestionDTO {
+	dtos := make([]AssignmentSuggestionDTO, 0, len(suggestions))
+	for _, s := range suggestions {
+		dtos = append(dtos, AssignmentSuggestionDTO{
+			Assignee:         toAssigneeDTO(s.Assignee),
+			SharedTagTickets: s.SharedTagTickets,
+			SameTeamTickets:  s.SameTeamTickets,
+			Score:            s.Score,
+		})
+	}
+	return dtos
+}
+
+// HandleSuggestAssignees handles GET /tickets/{ticketID}/assignment-suggestions
+// It lists the agents best placed to take the ticket, best first.
+func (h *TicketHandler) HandleSuggestAssignees(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	ticketID, err := h.parseTicketID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	suggestions, err := h.assigneeService.SuggestAssignees(r.Context(), claims.UserID, claims.OrgID, ticketID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteList(w, toAssignmentSuggestionDTOs(suggestions))
+}
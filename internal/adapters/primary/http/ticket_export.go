@@ -0,0 +1,57 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	mw "github.com/lorrc/service-desk-backend/internal/adapters/primary/http/middleware"
+	"github.com/lorrc/service-desk-backend/internal/adapters/primary/validation"
+)
+
+// ticketExportFormats are the formats tickets can be exported in.
+var ticketExportFormats = []string{"pdf"}
+
+// HandleExportTicket handles GET /tickets/{ticketID}/export?format=pdf
+// It returns the ticket, its metadata and its comment thread as a document
+// download, in the request's locale.
+func (h *TicketHandler) HandleExportTicket(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	ticketID, err := h.parseTicketID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "pdf"
+	}
+	v := validation.NewValidator()
+	v.OneOf("format", format, ticketExportFormats)
+	if v.HasErrors() {
+		h.errorHandler.Handle(w, r, v.Errors())
+		return
+	}
+
+	document, err := h.exportService.ExportTicketPDF(r.Context(), ticketID, claims.UserID, mw.GetLocale(r.Context()))
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	h.logger.Info("ticket exported", "ticket_id", ticketID, "format", format, "user_id", claims.UserID)
+
+	filename := fmt.Sprintf("ticket-%d.pdf", ticketID)
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Header().Set("Content-Length", strconv.Itoa(len(document)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(document); err != nil {
+		h.logger.Error("failed to write ticket export", "ticket_id", ticketID, "error", err)
+	}
+}
@@ -1,6 +1,8 @@
 package http
 
 import (
+	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -23,30 +25,45 @@ const (
 
 // TicketHandler handles HTTP requests for tickets
 type TicketHandler struct {
-	ticketService  ports.TicketService
-	eventService   ports.EventService
-	userLookup     ports.UserLookupService
-	commentHandler *CommentHandler
-	errorHandler   *ErrorHandler
-	logger         *slog.Logger
+	ticketService   ports.TicketService
+	eventService    ports.EventService
+	commentService  ports.CommentService
+	macroService    ports.MacroService
+	snoozeService   ports.SnoozeService
+	exportService   ports.TicketExportService
+	assigneeService ports.AssigneeService
+	userLookup      ports.UserLookupService
+	commentHandler  *CommentHandler
+	errorHandler    *ErrorHandler
+	logger          *slog.Logger
 }
 
 // NewTicketHandler creates a new ticket handler
 func NewTicketHandler(
 	ticketService ports.TicketService,
 	eventService ports.EventService,
+	commentService ports.CommentService,
+	macroService ports.MacroService,
+	snoozeService ports.SnoozeService,
+	exportService ports.TicketExportService,
+	assigneeService ports.AssigneeService,
 	userLookup ports.UserLookupService,
 	commentHandler *CommentHandler,
 	errorHandler *ErrorHandler,
 	logger *slog.Logger,
 ) *TicketHandler {
 	return &TicketHandler{
-		ticketService:  ticketService,
-		eventService:   eventService,
-		userLookup:     userLookup,
-		commentHandler: commentHandler,
-		errorHandler:   errorHandler,
-		logger:         logger.With("handler", "ticket"),
+		ticketService:   ticketService,
+		eventService:    eventService,
+		commentService:  commentService,
+		macroService:    macroService,
+		snoozeService:   snoozeService,
+		exportService:   exportService,
+		assigneeService: assigneeService,
+		userLookup:      userLookup,
+		commentHandler:  commentHandler,
+		errorHandler:    errorHandler,
+		logger:          logger.With("handler", "ticket"),
 	}
 }
 
@@ -65,9 +82,18 @@ func (h *TicketHandler) RegisterRoutes(r chi.Router) {
 	// Routes for a specific ticket
 	r.Route("/{ticketID}", func(r chi.Router) {
 		r.Get("/", h.HandleGetTicket)
+		r.Patch("/", h.HandleUpdateTicket)
 		r.Patch("/status", h.HandleUpdateTicketStatus)
 		r.Patch("/assignee", h.HandleAssignTicket)
+		r.Patch("/team", h.HandleAssignTeam)
+		r.Post("/claim", h.HandleClaimTicket)
+		r.Post("/macros/{macroID}/apply", h.HandleApplyMacro)
+		r.Get("/snooze", h.HandleGetSnooze)
+		r.Post("/snooze", h.HandleSnoozeTicket)
+		r.Delete("/snooze", h.HandleUnsnoozeTicket)
+		r.Get("/export", h.HandleExportTicket)
 		r.Get("/events", h.HandleListTicketEvents)
+		r.Get("/assignment-suggestions", h.HandleSuggestAssignees)
 
 		// Mount the comment routes nested under /tickets/{ticketID}
 		if h.commentHandler != nil {
@@ -83,6 +109,8 @@ type CreateTicketRequest struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	Priority    string `json:"priority"`
+	// Visibility is PUBLIC when omitted
+	Visibility  string `json:"visibility"`
 }
 
 // Validate validates the create ticket request
@@ -97,6 +125,8 @@ func (r *CreateTicketRequest) Validate() error {
 	v.Required("priority", r.Priority).
 		OneOf("priority", r.Priority, []string{"LOW", "MEDIUM", "HIGH"})
 
+	v.OneOf("visibility", r.Visibility, []string{"PUBLIC", "INTERNAL"})
+
 	if v.HasErrors() {
 		return v.Errors()
 	}
@@ -139,22 +169,119 @@ func (r *AssignTicketRequest) Validate() error {
 	return nil
 }
 
+// AssignTeamRequest defines the expected JSON body for moving a ticket to a
+// team's queue
+type AssignTeamRequest struct {
+	TeamID string `json:"teamId"`
+}
+
+// Validate validates the assign team request
+func (r *AssignTeamRequest) Validate() error {
+	v := validation.NewValidator()
+
+	v.Required("teamId", r.TeamID).
+		UUID("teamId", r.TeamID)
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
+// UpdateTicketRequest defines the expected JSON body for a partial ticket
+// update. Omitted fields are left unchanged.
+type UpdateTicketRequest struct {
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+	Priority    *string `json:"priority"`
+	Status      *string `json:"status"`
+	AssigneeID  *string `json:"assigneeId"`
+}
+
+// Validate validates the update ticket request
+func (r *UpdateTicketRequest) Validate() error {
+	v := validation.NewValidator()
+
+	v.Custom("body", r.Title != nil || r.Description != nil || r.Priority != nil || r.Status != nil || r.AssigneeID != nil,
+		"At least one field must be changed")
+
+	if r.Title != nil {
+		v.Required("title", *r.Title).
+			MaxLength("title", *r.Title, domain.MaxTitleLength)
+	}
+	if r.Description != nil {
+		v.MaxLength("description", *r.Description, domain.MaxDescriptionLength)
+	}
+	if r.Priority != nil {
+		v.OneOf("priority", *r.Priority, []string{"LOW", "MEDIUM", "HIGH"})
+	}
+	if r.Status != nil {
+		v.OneOf("status", *r.Status, []string{"OPEN", "IN_PROGRESS", "CLOSED"})
+	}
+	if r.AssigneeID != nil {
+		v.Required("assigneeId", *r.AssigneeID).
+			UUID("assigneeId", *r.AssigneeID)
+	}
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
+// changes converts a validated request to domain ticket changes
+func (r *UpdateTicketRequest) changes() domain.TicketChanges {
+	changes := domain.TicketChanges{
+		Title:       r.Title,
+		Description: r.Description,
+	}
+	if r.Priority != nil {
+		priority := domain.TicketPriority(*r.Priority)
+		changes.Priority = &priority
+	}
+	if r.Status != nil {
+		status := domain.TicketStatus(*r.Status)
+		changes.Status = &status
+	}
+	if r.AssigneeID != nil {
+		if assigneeID, err := uuid.Parse(*r.AssigneeID); err == nil {
+			changes.AssigneeID = &assigneeID
+		}
+	}
+	return changes
+}
+
 // TicketDTO defines the JSON response for tickets.
 type TicketDTO struct {
 	ID          int64   `json:"id"`
 	Title       string  `json:"title"`
 	Description string  `json:"description"`
+	DescriptionHTML string `json:"descriptionHtml"`
 	Status      string  `json:"status"`
 	Priority    string  `json:"priority"`
 	RequesterID string  `json:"requesterId"`
 	Requester   *UserInfoDTO `json:"requester,omitempty"`
 	AssigneeID  *string `json:"assigneeId"`
 	Assignee    *UserInfoDTO `json:"assignee,omitempty"`
+	TeamID      *string `json:"teamId"`
 	CreatedAt   string  `json:"createdAt"`
 	UpdatedAt   *string `json:"updatedAt"`
 	ClosedAt    *string `json:"closedAt"`
+	DueAt       *string `json:"dueAt"`
+	ArchivedAt  *string `json:"archivedAt,omitempty"`
+	Tags        []string `json:"tags"`
+	Visibility  string  `json:"visibility"`
+	LastComment *CommentDTO `json:"lastComment,omitempty"`
 }
 
+// ticketShaper shapes ticket responses. The requester and assignee are
+// embedded unless the request says otherwise.
+var ticketShaper = NewResponseShaper(
+	TicketDTO{},
+	[]string{"requester", "assignee", "lastComment"},
+	[]string{"requester", "assignee"},
+)
+
 func toTicketDTO(ticket *domain.Ticket, userInfoByID map[uuid.UUID]UserInfoDTO) TicketDTO {
 	var assigneeID *string
 	if ticket.AssigneeID != nil {
@@ -176,6 +303,12 @@ func toTicketDTO(ticket *domain.Ticket, userInfoByID map[uuid.UUID]UserInfoDTO)
 		}
 	}
 
+	var teamID *string
+	if ticket.TeamID != nil {
+		value := ticket.TeamID.String()
+		teamID = &value
+	}
+
 	var updatedAt *string
 	if ticket.UpdatedAt != nil {
 		value := ticket.UpdatedAt.Format(time.RFC3339)
@@ -188,19 +321,42 @@ func toTicketDTO(ticket *domain.Ticket, userInfoByID map[uuid.UUID]UserInfoDTO)
 		closedAt = &value
 	}
 
+	var dueAt *string
+	if ticket.DueAt != nil {
+		value := ticket.DueAt.Format(time.RFC3339)
+		dueAt = &value
+	}
+
+	var archivedAt *string
+	if ticket.ArchivedAt != nil {
+		value := ticket.ArchivedAt.Format(time.RFC3339)
+		archivedAt = &value
+	}
+
+	tags := ticket.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
 	return TicketDTO{
 		ID:          ticket.ID,
 		Title:       ticket.Title,
 		Description: ticket.Description,
+		DescriptionHTML: ticket.DescriptionHTML,
 		Status:      string(ticket.Status),
 		Priority:    string(ticket.Priority),
 		RequesterID: ticket.RequesterID.String(),
 		Requester:   requester,
 		AssigneeID:  assigneeID,
 		Assignee:    assignee,
+		TeamID:      teamID,
 		CreatedAt:   ticket.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:   updatedAt,
 		ClosedAt:    closedAt,
+		DueAt:       dueAt,
+		ArchivedAt:  archivedAt,
+		Tags:        tags,
+		Visibility:  string(ticket.Visibility),
 	}
 }
 
@@ -221,6 +377,12 @@ func (h *TicketHandler) HandleListTickets(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	shape, err := ticketShaper.Parse(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
 	// Parse pagination
 	pagination := validation.ParsePagination(r, maxTicketsPerPage)
 
@@ -228,6 +390,8 @@ func (h *TicketHandler) HandleListTickets(w http.ResponseWriter, r *http.Request
 	status := validation.ParseStringQueryParam(r, "status")
 	priority := validation.ParseStringQueryParam(r, "priority")
 	unassigned := validation.ParseBoolQueryParam(r, "unassigned", false)
+	needsReassignment := validation.ParseBoolQueryParam(r, "needsReassignment", false)
+	includeSnoozed := validation.ParseBoolQueryParam(r, "includeSnoozed", false)
 
 	v := validation.NewValidator()
 
@@ -241,6 +405,16 @@ func (h *TicketHandler) HandleListTickets(w http.ResponseWriter, r *http.Request
 		}
 	}
 
+	var teamID *uuid.UUID
+	if teamIDStr := r.URL.Query().Get("teamId"); teamIDStr != "" {
+		parsedTeamID, err := uuid.Parse(teamIDStr)
+		if err != nil {
+			v.Custom("teamId", false, "Must be a valid UUID")
+		} else {
+			teamID = &parsedTeamID
+		}
+	}
+
 	createdFrom, err := validation.ParseTimeQueryParam(r, "createdFrom")
 	if err != nil {
 		v.Custom("createdFrom", false, "Must be a valid date or timestamp")
@@ -279,15 +453,18 @@ func (h *TicketHandler) HandleListTickets(w http.ResponseWriter, r *http.Request
 	}
 
 	params := ports.ListTicketsParams{
-		ViewerID:    claims.UserID,
-		Limit:       pagination.Limit + 1,
-		Offset:      pagination.Offset,
-		Status:      status,
-		Priority:    priority,
-		AssigneeID:  assigneeID,
-		Unassigned:  unassigned,
-		CreatedFrom: createdFromTime,
-		CreatedTo:   createdToTime,
+		ViewerID:          claims.UserID,
+		Limit:             pagination.Limit + 1,
+		Offset:            pagination.Offset,
+		Status:            status,
+		Priority:          priority,
+		AssigneeID:        assigneeID,
+		Unassigned:        unassigned,
+		CreatedFrom:       createdFromTime,
+		CreatedTo:         createdToTime,
+		NeedsReassignment: needsReassignment,
+		TeamID:            teamID,
+		IncludeSnoozed:    includeSnoozed,
 	}
 
 	tickets, err := h.ticketService.ListTickets(r.Context(), params)
@@ -296,19 +473,14 @@ func (h *TicketHandler) HandleListTickets(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	userInfoByID, err := buildUserInfoDTOMap(
-		r.Context(),
-		h.userLookup,
-		claims.OrgID,
-		collectTicketUserIDs(tickets),
-	)
+	response, err := h.shapeTickets(r, claims, shape, tickets)
 	if err != nil {
 		h.errorHandler.Handle(w, r, err)
 		return
 	}
 
 	// Use simple pagination (without total count for performance)
-	WritePaginatedSimple(w, toTicketDTOs(tickets, userInfoByID), pagination.Limit, pagination.Offset)
+	WritePaginatedSimple(w, response, pagination.Limit, pagination.Offset)
 }
 
 // HandleCreateTicket handles POST /tickets
@@ -334,6 +506,7 @@ func (h *TicketHandler) HandleCreateTicket(w http.ResponseWriter, r *http.Reques
 		Description: req.Description,
 		Priority:    domain.TicketPriority(req.Priority),
 		RequesterID: claims.UserID,
+		Visibility:  domain.TicketVisibility(req.Visibility),
 	}
 
 	ticket, err := h.ticketService.CreateTicket(r.Context(), params)
@@ -374,12 +547,68 @@ func (h *TicketHandler) HandleGetTicket(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	shape, err := ticketShaper.Parse(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
 	ticket, err := h.ticketService.GetTicket(r.Context(), ticketID, claims.UserID)
 	if err != nil {
 		h.errorHandler.Handle(w, r, err)
 		return
 	}
 
+	response, err := h.shapeTickets(r, claims, shape, []*domain.Ticket{ticket})
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, response[0])
+}
+
+// HandleUpdateTicket handles PATCH /tickets/{ticketID}
+func (h *TicketHandler) HandleUpdateTicket(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	ticketID, err := h.parseTicketID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[UpdateTicketRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	params := ports.UpdateTicketParams{
+		TicketID: ticketID,
+		Changes:  req.changes(),
+		ActorID:  claims.UserID,
+	}
+
+	ticket, err := h.ticketService.UpdateTicket(r.Context(), params)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	h.logger.Info("ticket updated",
+		"ticket_id", ticketID,
+		"user_id", claims.UserID,
+	)
+
 	userInfoByID, err := buildUserInfoDTOMap(
 		r.Context(),
 		h.userLookup,
@@ -487,7 +716,7 @@ func (h *TicketHandler) HandleAssignTicket(w http.ResponseWriter, r *http.Reques
 		ActorID:    claims.UserID,
 	}
 
-	ticket, err := h.ticketService.AssignTicket(r.Context(), params)
+	ticket, warning, err := h.ticketService.AssignTicket(r.Context(), params)
 	if err != nil {
 		h.errorHandler.Handle(w, r, err)
 		return
@@ -497,6 +726,7 @@ func (h *TicketHandler) HandleAssignTicket(w http.ResponseWriter, r *http.Reques
 		"ticket_id", ticketID,
 		"assignee_id", assigneeID,
 		"user_id", claims.UserID,
+		"over_capacity", warning != nil,
 	)
 
 	userInfoByID, err := buildUserInfoDTOMap(
@@ -510,6 +740,155 @@ func (h *TicketHandler) HandleAssignTicket(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	WriteJSONWithHeaders(w, http.StatusOK, toTicketDTO(ticket, userInfoByID), capacityWarningHeaders(warning))
+}
+
+// capacityWarningHeaders sets X-Capacity-Warning when an assignee was
+// already at the organization's open ticket limit, so clients can tell
+// whoever assigned the ticket.
+func capacityWarningHeaders(warning *domain.CapacityWarning) map[string]string {
+	if warning == nil {
+		return nil
+	}
+	return map[string]string{
+		"X-Capacity-Warning": fmt.Sprintf("assignee has %d open tickets; the limit is %d",
+			warning.OpenTickets, warning.MaxOpenTickets),
+	}
+}
+
+// HandleAssignTeam handles PATCH /tickets/{ticketID}/team
+func (h *TicketHandler) HandleAssignTeam(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	ticketID, err := h.parseTicketID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[AssignTeamRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	teamID, err := uuid.Parse(req.TeamID)
+	if err != nil {
+		// This shouldn't happen since we validated the UUID format
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	ticket, err := h.ticketService.AssignTeam(r.Context(), ports.AssignTeamParams{
+		TicketID: ticketID,
+		TeamID:   teamID,
+		ActorID:  claims.UserID,
+	})
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	h.logger.Info("ticket assigned to team",
+		"ticket_id", ticketID,
+		"team_id", teamID,
+		"user_id", claims.UserID,
+	)
+
+	h.writeTicket(w, r, claims.OrgID, ticket)
+}
+
+// HandleClaimTicket handles POST /tickets/{ticketID}/claim, assigning a
+// ticket in one of the caller's team queues to the caller.
+func (h *TicketHandler) HandleClaimTicket(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	ticketID, err := h.parseTicketID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	ticket, err := h.ticketService.ClaimTicket(r.Context(), ports.ClaimTicketParams{
+		TicketID: ticketID,
+		ActorID:  claims.UserID,
+	})
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	h.logger.Info("ticket claimed",
+		"ticket_id", ticketID,
+		"user_id", claims.UserID,
+	)
+
+	h.writeTicket(w, r, claims.OrgID, ticket)
+}
+
+// HandleApplyMacro handles POST /tickets/{ticketID}/macros/{macroID}/apply
+func (h *TicketHandler) HandleApplyMacro(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	ticketID, err := h.parseTicketID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	macroID, err := parseMacroID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	ticket, err := h.macroService.ApplyMacro(r.Context(), ports.ApplyMacroParams{
+		TicketID: ticketID,
+		MacroID:  macroID,
+		ActorID:  claims.UserID,
+		OrgID:    claims.OrgID,
+	})
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	h.logger.Info("macro applied",
+		"ticket_id", ticketID,
+		"macro_id", macroID,
+		"user_id", claims.UserID,
+	)
+
+	h.writeTicket(w, r, claims.OrgID, ticket)
+}
+
+// writeTicket writes a ticket with its requester and assignee embedded.
+func (h *TicketHandler) writeTicket(w http.ResponseWriter, r *http.Request, orgID uuid.UUID, ticket *domain.Ticket) {
+	userInfoByID, err := buildUserInfoDTOMap(
+		r.Context(),
+		h.userLookup,
+		orgID,
+		collectTicketUserIDs([]*domain.Ticket{ticket}),
+	)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
 	WriteJSON(w, http.StatusOK, toTicketDTO(ticket, userInfoByID))
 }
 
@@ -624,6 +1003,50 @@ func (h *TicketHandler) parseEventQuery(r *http.Request) (int64, int, error) {
 	return afterID, limit, nil
 }
 
+// shapeTickets loads the resources the shape embeds and converts tickets to
+// shaped responses.
+func (h *TicketHandler) shapeTickets(
+	r *http.Request,
+	claims *auth.Claims,
+	shape ResponseShape,
+	tickets []*domain.Ticket,
+) ([]json.RawMessage, error) {
+	var userIDs []uuid.UUID
+	if shape.Embeds("requester") || shape.Embeds("assignee") {
+		userIDs = collectTicketUserIDs(tickets)
+	}
+
+	var lastComments map[int64]*domain.Comment
+	if shape.Embeds("lastComment") {
+		var err error
+		lastComments, err = h.commentService.GetLatestComments(r.Context(), ports.GetLatestCommentsParams{
+			Tickets: tickets,
+			ActorID: claims.UserID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, comment := range lastComments {
+			userIDs = append(userIDs, comment.AuthorID)
+		}
+	}
+
+	userInfoByID, err := buildUserInfoDTOMap(r.Context(), h.userLookup, claims.OrgID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := toTicketDTOs(tickets, userInfoByID)
+	for i, ticket := range tickets {
+		if comment, ok := lastComments[ticket.ID]; ok {
+			lastComment := toCommentDTO(comment, userInfoByID)
+			dtos[i].LastComment = &lastComment
+		}
+	}
+
+	return ApplyShape(shape, dtos)
+}
+
 func collectTicketUserIDs(tickets []*domain.Ticket) []uuid.UUID {
 	userIDs := make([]uuid.UUID, 0, len(tickets)*2)
 	for _, ticket := range tickets {
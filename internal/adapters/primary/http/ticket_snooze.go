@@ -0,0 +1,138 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/adapters/primary/validation"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// SnoozeTicketRequest defines the JSON body for snoozing a ticket. The
+// ticket is left out of agents' queues until the given time.
+type SnoozeTicketRequest struct {
+	Until time.Time `json:"until"`
+	Note  string    `json:"note"`
+}
+
+// Validate validates the snooze request
+func (r *SnoozeTicketRequest) Validate() error {
+	v := validation.NewValidator()
+
+	v.Custom("until", !r.Until.IsZero(), "This field is required")
+	v.MaxLength("note", r.Note, domain.MaxSnoozeNoteLength)
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
+// TicketSnoozeDTO describes a ticket's snooze.
+type TicketSnoozeDTO struct {
+	TicketID  int64  `json:"ticketId"`
+	SnoozedBy string `json:"snoozedBy"`
+	Until     string `json:"until"`
+	Note      string `json:"note"`
+	CreatedAt string `json:"createdAt"`
+}
+
+func toTicketSnoozeDTO(snooze *domain.TicketSnooze) TicketSnoozeDTO {
+	return TicketSnoozeDTO{
+		TicketID:  snooze.TicketID,
+		SnoozedBy: snooze.SnoozedBy.String(),
+		Until:     snooze.Until.UTC().Format(time.RFC3339),
+		Note:      snooze.Note,
+		CreatedAt: snooze.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// HandleGetSnooze handles GET /tickets/{ticketID}/snooze
+func (h *TicketHandler) HandleGetSnooze(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	ticketID, err := h.parseTicketID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	snooze, err := h.snoozeService.GetSnooze(r.Context(), ticketID, claims.UserID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toTicketSnoozeDTO(snooze))
+}
+
+// HandleSnoozeTicket handles POST /tickets/{ticketID}/snooze
+func (h *TicketHandler) HandleSnoozeTicket(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	ticketID, err := h.parseTicketID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[SnoozeTicketRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	snooze, err := h.snoozeService.SnoozeTicket(r.Context(), ports.SnoozeTicketParams{
+		TicketID: ticketID,
+		ActorID:  claims.UserID,
+		Until:    req.Until,
+		Note:     req.Note,
+	})
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	h.logger.Info("ticket snoozed",
+		"ticket_id", ticketID,
+		"until", snooze.Until,
+		"user_id", claims.UserID,
+	)
+
+	WriteJSON(w, http.StatusOK, toTicketSnoozeDTO(snooze))
+}
+
+// HandleUnsnoozeTicket handles DELETE /tickets/{ticketID}/snooze
+func (h *TicketHandler) HandleUnsnoozeTicket(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	ticketID, err := h.parseTicketID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := h.snoozeService.UnsnoozeTicket(r.Context(), ticketID, claims.UserID); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	h.logger.Info("ticket unsnoozed", "ticket_id", ticketID, "user_id", claims.UserID)
+
+	WriteNoContent(w)
+}
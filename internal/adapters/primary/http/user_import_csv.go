@@ -0,0 +1,100 @@
+package http
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/lorrc/service-desk-backend/internal/adapters/primary/validation"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+)
+
+// maxUserImportBytes caps the size of an uploaded user import file
+const maxUserImportBytes = 1 << 20
+
+// readUserImport reads the CSV of a user import, sent either as the raw
+// request body or as the "file" field of a multipart form.
+func readUserImport(w http.ResponseWriter, r *http.Request) ([]domain.UserImportRow, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxUserImportBytes)
+
+	var body io.Reader = r.Body
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			return nil, userImportFileError("A CSV file is required")
+		}
+		defer file.Close()
+		body = file
+	}
+
+	return parseUserImportCSV(body)
+}
+
+// parseUserImportCSV parses a CSV with a header row naming its name, email
+// and optional role columns, in any order.
+func parseUserImportCSV(body io.Reader) ([]domain.UserImportRow, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, userImportFileError("File is empty")
+	}
+	if err != nil {
+		return nil, userImportFileError("File is not valid CSV: " + err.Error())
+	}
+
+	columns := map[string]int{"name": -1, "email": -1, "role": -1}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := columns[name]; ok {
+			columns[name] = i
+		}
+	}
+	if columns["name"] < 0 || columns["email"] < 0 {
+		return nil, userImportFileError("Header row must include name and email columns")
+	}
+
+	field := func(record []string, column string) string {
+		i := columns[column]
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return record[i]
+	}
+
+	var rows []domain.UserImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, userImportFileError("File is not valid CSV: " + err.Error())
+		}
+		if len(rows) == domain.MaxUserImportRows {
+			return nil, userImportFileError(fmt.Sprintf("File must contain at most %d users", domain.MaxUserImportRows))
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, domain.UserImportRow{
+			Line:     line,
+			FullName: field(record, "name"),
+			Email:    field(record, "email"),
+			Role:     field(record, "role"),
+		})
+	}
+
+	return rows, nil
+}
+
+func userImportFileError(message string) error {
+	v := validation.NewValidator()
+	v.Custom("file", false, message)
+	return v.Errors()
+}
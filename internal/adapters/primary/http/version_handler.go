@@ -0,0 +1,27 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/lorrc/service-desk-backend/internal/buildinfo"
+)
+
+// VersionHandler reports which build is running.
+type VersionHandler struct {
+	info buildinfo.Info
+}
+
+// NewVersionHandler creates a new version handler. version overrides the
+// version set at build time when it is not empty.
+func NewVersionHandler(version string) *VersionHandler {
+	info := buildinfo.Get()
+	if version != "" {
+		info.Version = version
+	}
+	return &VersionHandler{info: info}
+}
+
+// HandleVersion handles GET /version
+func (h *VersionHandler) HandleVersion(w http.ResponseWriter, r *http.Request) {
+	WriteJSON(w, http.StatusOK, h.info)
+}
@@ -0,0 +1,287 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	mw "github.com/lorrc/service-desk-backend/internal/adapters/primary/http/middleware"
+	"github.com/lorrc/service-desk-backend/internal/adapters/primary/validation"
+	"github.com/lorrc/service-desk-backend/internal/auth"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// WebhookHandler handles HTTP requests for managing outgoing webhooks.
+type WebhookHandler struct {
+	webhookService ports.WebhookService
+	errorHandler   *ErrorHandler
+	logger         *slog.Logger
+}
+
+// NewWebhookHandler creates a new WebhookHandler.
+func NewWebhookHandler(webhookService ports.WebhookService, errorHandler *ErrorHandler, logger *slog.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		errorHandler:   errorHandler,
+		logger:         logger.With("handler", "webhook"),
+	}
+}
+
+// RegisterRoutes registers the /admin/webhooks routes.
+func (h *WebhookHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.HandleListWebhooks)
+	r.Post("/", h.HandleCreateWebhook)
+	r.Delete("/{webhookID}", h.HandleDeleteWebhook)
+	r.Get("/{webhookID}/deliveries", h.HandleListDeliveries)
+	r.Post("/{webhookID}/test", h.HandleTestWebhook)
+}
+
+// CreateWebhookRequest defines the JSON body for registering a webhook.
+type CreateWebhookRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"eventTypes"`
+}
+
+func (r *CreateWebhookRequest) Validate() error {
+	v := validation.NewValidator()
+
+	v.Required("url", r.URL).
+		Custom("eventTypes", len(r.EventTypes) > 0, "At least one event type is required")
+
+	if v.HasErrors() {
+		return v.Errors()
+	}
+	return nil
+}
+
+// WebhookDTO defines the JSON representation of a webhook.
+// The signing secret is only included in the response to its creation.
+type WebhookDTO struct {
+	ID         string   `json:"id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"eventTypes"`
+	IsActive   bool     `json:"isActive"`
+	CreatedBy  string   `json:"createdBy"`
+	CreatedAt  string   `json:"createdAt"`
+	Secret     string   `json:"secret,omitempty"`
+}
+
+// WebhookDeliveryDTO defines the JSON representation of a webhook delivery.
+type WebhookDeliveryDTO struct {
+	ID             int64           `json:"id"`
+	WebhookID      string          `json:"webhookId"`
+	EventType      string          `json:"eventType"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *string         `json:"nextAttemptAt"`
+	ResponseStatus *int            `json:"responseStatus"`
+	LastError      *string         `json:"lastError"`
+	CreatedAt      string          `json:"createdAt"`
+	DeliveredAt    *string         `json:"deliveredAt"`
+}
+
+// HandleListWebhooks handles GET /admin/webhooks
+func (h *WebhookHandler) HandleListWebhooks(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	webhooks, err := h.webhookService.ListWebhooks(r.Context(), claims.UserID, claims.OrgID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	response := make([]WebhookDTO, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		response = append(response, toWebhookDTO(webhook))
+	}
+
+	WriteList(w, response)
+}
+
+// HandleCreateWebhook handles POST /admin/webhooks
+func (h *WebhookHandler) HandleCreateWebhook(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[CreateWebhookRequest](r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	eventTypes := make([]domain.WebhookEventType, 0, len(req.EventTypes))
+	for _, t := range req.EventTypes {
+		eventTypes = append(eventTypes, domain.WebhookEventType(t))
+	}
+
+	webhook, err := h.webhookService.CreateWebhook(r.Context(), claims.UserID, claims.OrgID, domain.WebhookParams{
+		URL:        req.URL,
+		EventTypes: eventTypes,
+	})
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	response := toWebhookDTO(webhook)
+	response.Secret = webhook.Secret
+
+	WriteCreated(w, response)
+}
+
+// HandleDeleteWebhook handles DELETE /admin/webhooks/{webhookID}
+func (h *WebhookHandler) HandleDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	webhookID, err := h.parseWebhookID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(r.Context(), claims.UserID, claims.OrgID, webhookID); err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteNoContent(w)
+}
+
+// HandleListDeliveries handles GET /admin/webhooks/{webhookID}/deliveries
+func (h *WebhookHandler) HandleListDeliveries(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	webhookID, err := h.parseWebhookID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	limit := validation.ParseIntQueryParam(r, "limit", 50)
+
+	deliveries, err := h.webhookService.ListDeliveries(r.Context(), claims.UserID, claims.OrgID, webhookID, limit)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	response := make([]WebhookDeliveryDTO, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		response = append(response, toWebhookDeliveryDTO(delivery))
+	}
+
+	WriteList(w, response)
+}
+
+// HandleTestWebhook handles POST /admin/webhooks/{webhookID}/test
+func (h *WebhookHandler) HandleTestWebhook(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.getClaims(w, r)
+	if !ok {
+		return
+	}
+
+	webhookID, err := h.parseWebhookID(r)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	delivery, err := h.webhookService.TestWebhook(r.Context(), claims.UserID, claims.OrgID, webhookID)
+	if err != nil {
+		h.errorHandler.Handle(w, r, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toWebhookDeliveryDTO(delivery))
+}
+
+func toWebhookDTO(webhook *domain.Webhook) WebhookDTO {
+	eventTypes := make([]string, 0, len(webhook.EventTypes))
+	for _, t := range webhook.EventTypes {
+		eventTypes = append(eventTypes, string(t))
+	}
+
+	return WebhookDTO{
+		ID:         webhook.ID.String(),
+		URL:        webhook.URL,
+		EventTypes: eventTypes,
+		IsActive:   webhook.IsActive,
+		CreatedBy:  webhook.CreatedBy.String(),
+		CreatedAt:  webhook.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func toWebhookDeliveryDTO(delivery *domain.WebhookDelivery) WebhookDeliveryDTO {
+	// Only pending deliveries have a meaningful next attempt.
+	var nextAttemptAt *string
+	if delivery.Status == domain.WebhookDeliveryPending {
+		value := delivery.NextAttemptAt.Format(time.RFC3339)
+		nextAttemptAt = &value
+	}
+
+	var deliveredAt *string
+	if delivery.DeliveredAt != nil {
+		value := delivery.DeliveredAt.Format(time.RFC3339)
+		deliveredAt = &value
+	}
+
+	return WebhookDeliveryDTO{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID.String(),
+		EventType:      string(delivery.EventType),
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  nextAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+		DeliveredAt:    deliveredAt,
+	}
+}
+
+func (h *WebhookHandler) parseWebhookID(r *http.Request) (uuid.UUID, error) {
+	idParam := chi.URLParam(r, "webhookID")
+	webhookID, err := uuid.Parse(idParam)
+	if err != nil {
+		v := validation.NewValidator()
+		v.Custom("webhookID", false, "Invalid webhook ID")
+		return uuid.Nil, v.Errors()
+	}
+
+	return webhookID, nil
+}
+
+// getClaims extracts and validates user claims from the request context.
+func (h *WebhookHandler) getClaims(w http.ResponseWriter, r *http.Request) (*auth.Claims, bool) {
+	claims, ok := mw.GetClaims(r.Context())
+	if !ok {
+		WriteJSON(w, http.StatusUnauthorized, ErrorResponse{
+			Error: "Not authorized",
+			Code:  "UNAUTHORIZED",
+		})
+		return nil, false
+	}
+	return claims, true
+}
@@ -0,0 +1,100 @@
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// DefaultVerifyURL is hCaptcha's siteverify endpoint. reCAPTCHA and
+// Cloudflare Turnstile accept the same request at their own endpoints.
+const DefaultVerifyURL = "https://api.hcaptcha.com/siteverify"
+
+// Config holds the captcha provider settings.
+type Config struct {
+	Secret    string
+	VerifyURL string
+	Timeout   time.Duration
+}
+
+// Verifier is a secondary adapter that checks captcha responses with a
+// siteverify-style provider. It implements the ports.CaptchaVerifier
+// interface.
+type Verifier struct {
+	cfg    Config
+	client *http.Client
+}
+
+var _ ports.CaptchaVerifier = (*Verifier)(nil)
+
+// NewVerifier creates a new captcha verifier.
+func NewVerifier(cfg Config) ports.CaptchaVerifier {
+	if cfg.VerifyURL == "" {
+		cfg.VerifyURL = DefaultVerifyURL
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &Verifier{
+		cfg:    cfg,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// verifyResponse is the part of a siteverify response the verifier reads
+type verifyResponse struct {
+	Success    bool     `json:"success"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+// Verify asks the provider whether token is a solved captcha. An empty
+// token is rejected without asking.
+func (v *Verifier) Verify(ctx context.Context, token, remoteIP string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+
+	form := url.Values{
+		"secret":   {v.cfg.Secret},
+		"response": {token},
+	}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.cfg.VerifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("verify captcha: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("verify captcha: unexpected status %d", resp.StatusCode)
+	}
+
+	var result verifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, fmt.Errorf("decode captcha response: %w", err)
+	}
+	// A rejected secret is our misconfiguration, not the submitter's
+	for _, code := range result.ErrorCodes {
+		if code == "missing-input-secret" || code == "invalid-input-secret" {
+			return false, fmt.Errorf("verify captcha: provider rejected the secret: %s", code)
+		}
+	}
+	return result.Success, nil
+}
@@ -0,0 +1,65 @@
+package captcha
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifier_Verify(t *testing.T) {
+	ctx := context.Background()
+
+	newServer := func(t *testing.T, response string) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "s3cret", r.PostForm.Get("secret"))
+			assert.Equal(t, "token", r.PostForm.Get("response"))
+			assert.Equal(t, "203.0.113.7", r.PostForm.Get("remoteip"))
+			_, _ = w.Write([]byte(response))
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	t.Run("accepts a solved captcha", func(t *testing.T) {
+		server := newServer(t, `{"success": true}`)
+		v := NewVerifier(Config{Secret: "s3cret", VerifyURL: server.URL})
+
+		ok, err := v.Verify(ctx, "token", "203.0.113.7")
+
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("rejects a failed captcha", func(t *testing.T) {
+		server := newServer(t, `{"success": false, "error-codes": ["invalid-input-response"]}`)
+		v := NewVerifier(Config{Secret: "s3cret", VerifyURL: server.URL})
+
+		ok, err := v.Verify(ctx, "token", "203.0.113.7")
+
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("reports a rejected secret as an error", func(t *testing.T) {
+		server := newServer(t, `{"success": false, "error-codes": ["invalid-input-secret"]}`)
+		v := NewVerifier(Config{Secret: "s3cret", VerifyURL: server.URL})
+
+		_, err := v.Verify(ctx, "token", "203.0.113.7")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects an empty token without asking", func(t *testing.T) {
+		v := NewVerifier(Config{Secret: "s3cret", VerifyURL: "http://127.0.0.1:0"})
+
+		ok, err := v.Verify(ctx, "", "203.0.113.7")
+
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
@@ -2,6 +2,7 @@ package email
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 
 	"github.com/lorrc/service-desk-backend/internal/core/ports"
@@ -11,7 +12,9 @@ import (
 // It implements the ports.Notifier interface.
 type MockSMTPNotifier struct {
 	userRepo ports.UserRepository
+	renderer *Renderer
 	logger   *slog.Logger
+	mailbox  *Mailbox // Optional; keeps the rendered emails for previewing
 }
 
 // NewMockSMTPNotifier creates a new mock notifier.
@@ -19,6 +22,7 @@ type MockSMTPNotifier struct {
 func NewMockSMTPNotifier(userRepo ports.UserRepository) ports.Notifier {
 	return &MockSMTPNotifier{
 		userRepo: userRepo,
+		renderer: mustNewRenderer(),
 		logger:   slog.Default().With("component", "email_notifier"),
 	}
 }
@@ -27,31 +31,63 @@ func NewMockSMTPNotifier(userRepo ports.UserRepository) ports.Notifier {
 func NewMockSMTPNotifierWithLogger(userRepo ports.UserRepository, logger *slog.Logger) ports.Notifier {
 	return &MockSMTPNotifier{
 		userRepo: userRepo,
+		renderer: mustNewRenderer(),
 		logger:   logger.With("component", "email_notifier"),
 	}
 }
 
-// Notify logs the notification to the console instead of sending an email.
-// It runs in a separate goroutine and should handle its own errors.
-func (n *MockSMTPNotifier) Notify(ctx context.Context, params ports.NotificationParams) {
-	// Use a new background context in case the original request context is cancelled.
-	notifyCtx := context.Background()
+// NewCapturingNotifier creates a mock notifier that also keeps every
+// rendered email in mailbox, for previewing in development.
+func NewCapturingNotifier(userRepo ports.UserRepository, mailbox *Mailbox, logger *slog.Logger) ports.Notifier {
+	return &MockSMTPNotifier{
+		userRepo: userRepo,
+		renderer: mustNewRenderer(),
+		logger:   logger.With("component", "email_notifier"),
+		mailbox:  mailbox,
+	}
+}
 
+// Notify logs the notification to the console instead of sending an email.
+func (n *MockSMTPNotifier) Notify(ctx context.Context, params ports.NotificationParams) error {
 	// 1. Get the recipient's details
-	user, err := n.userRepo.GetByID(notifyCtx, params.RecipientUserID)
+	user, err := n.userRepo.GetByID(ctx, params.RecipientUserID)
 	if err != nil {
-		n.logger.Error("failed to get user for notification",
-			"user_id", params.RecipientUserID,
-			"error", err,
-		)
-		return
+		return fmt.Errorf("get recipient %s: %w", params.RecipientUserID, err)
 	}
 
-	// 2. Log the mock email
+	// 2. Render the email in the recipient's language so template errors
+	// surface even without SMTP
+	msg, err := n.renderer.Render(params, user.FullName, user.Locale)
+	if err != nil {
+		return err
+	}
+
+	// 3. Log the mock email, to the address the notification names if it
+	// names one
+	to := user.Email
+	if addr := params.Data[ports.NotificationDataRecipientEmail]; addr != "" {
+		to = addr
+	}
 	n.logger.Info("mock email sent",
 		"to_name", user.FullName,
-		"to_email", user.Email,
-		"subject", params.Subject,
+		"to_email", to,
+		"subject", msg.Subject,
+		"type", params.Type,
 		"ticket_id", params.TicketID,
 	)
+	n.logger.Debug("mock email body", "text", msg.TextBody)
+
+	if n.mailbox != nil {
+		n.mailbox.add(ports.CapturedEmail{
+			Type:     params.Type,
+			TicketID: params.TicketID,
+			ToName:   user.FullName,
+			ToEmail:  to,
+			Subject:  msg.Subject,
+			HTMLBody: msg.HTMLBody,
+			TextBody: msg.TextBody,
+		})
+	}
+
+	return nil
 }
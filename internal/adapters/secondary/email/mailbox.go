@@ -0,0 +1,77 @@
+package email
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// Mailbox keeps the most recent emails rendered by a notifier in memory,
+// so templates can be previewed in development without an SMTP server.
+// Once it holds capacity emails, the oldest is dropped for each new one.
+type Mailbox struct {
+	mu       sync.RWMutex
+	capacity int
+	nextID   int64
+	emails   []ports.CapturedEmail // oldest first
+}
+
+var _ ports.EmailCapture = (*Mailbox)(nil)
+
+// NewMailbox creates a mailbox keeping up to capacity emails.
+func NewMailbox(capacity int) *Mailbox {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &Mailbox{capacity: capacity}
+}
+
+// add stores a rendered email.
+func (m *Mailbox) add(email ports.CapturedEmail) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextID++
+	email.ID = m.nextID
+	if email.CapturedAt.IsZero() {
+		email.CapturedAt = time.Now().UTC()
+	}
+	if len(m.emails) == m.capacity {
+		m.emails = append(m.emails[:0], m.emails[1:]...)
+	}
+	m.emails = append(m.emails, email)
+}
+
+// List returns up to limit emails, newest first. A limit of zero or less
+// returns all of them.
+func (m *Mailbox) List(ctx context.Context, limit int) ([]ports.CapturedEmail, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if limit <= 0 || limit > len(m.emails) {
+		limit = len(m.emails)
+	}
+	emails := make([]ports.CapturedEmail, 0, limit)
+	for i := len(m.emails) - 1; i >= 0 && len(emails) < limit; i-- {
+		emails = append(emails, m.emails[i])
+	}
+	return emails, nil
+}
+
+// Get returns the email with the ID, or apperrors.ErrCapturedEmailNotFound
+// if it was never captured or has been dropped.
+func (m *Mailbox) Get(ctx context.Context, id int64) (*ports.CapturedEmail, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for i := range m.emails {
+		if m.emails[i].ID == id {
+			email := m.emails[i]
+			return &email, nil
+		}
+	}
+	return nil, apperrors.ErrCapturedEmailNotFound
+}
@@ -0,0 +1,60 @@
+package email
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapturingNotifier_KeepsRenderedEmails(t *testing.T) {
+	ctx := context.Background()
+	user := &domain.User{ID: uuid.New(), FullName: "Jane Doe", Email: "jane@example.com", Locale: "en"}
+	userRepo := mocks.NewMockUserRepository()
+	userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+
+	mailbox := NewMailbox(2)
+	notifier := NewCapturingNotifier(userRepo, mailbox, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	for ticketID := int64(1); ticketID <= 3; ticketID++ {
+		require.NoError(t, notifier.Notify(ctx, ports.NotificationParams{
+			RecipientUserID: user.ID,
+			Type:            ports.NotificationTicketCreated,
+			Subject:         "Ticket created",
+			Message:         "Your ticket was created.",
+			TicketID:        ticketID,
+			Data:            map[string]string{"title": "Printer"},
+		}))
+	}
+
+	emails, err := mailbox.List(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, emails, 2, "the oldest email is dropped once the mailbox is full")
+	assert.Equal(t, int64(3), emails[0].TicketID)
+	assert.Equal(t, int64(2), emails[1].TicketID)
+	assert.Equal(t, "jane@example.com", emails[0].ToEmail)
+	assert.Equal(t, "We received your ticket: #3", emails[0].Subject)
+	assert.Contains(t, emails[0].HTMLBody, "Hi Jane Doe,")
+	assert.NotEmpty(t, emails[0].TextBody)
+	assert.False(t, emails[0].CapturedAt.IsZero())
+
+	email, err := mailbox.Get(ctx, emails[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, emails[1], *email)
+
+	_, err = mailbox.Get(ctx, 1)
+	assert.ErrorIs(t, err, apperrors.ErrCapturedEmailNotFound)
+
+	latest, err := mailbox.List(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, latest, 1)
+	assert.Equal(t, int64(3), latest[0].TicketID)
+}
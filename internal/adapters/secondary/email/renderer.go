@@ -0,0 +1,232 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/i18n"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// defaultTemplate renders notifications whose type has no dedicated template.
+const defaultTemplate = "default"
+
+// templateNames lists the notification types with dedicated templates.
+var templateNames = []ports.NotificationType{
+	ports.NotificationTicketCreated,
+	ports.NotificationStatusChanged,
+	ports.NotificationCommentAdded,
+	ports.NotificationTicketAssigned,
+	ports.NotificationDataExportReady,
+	ports.NotificationPortalLink,
+	ports.NotificationEmailChange,
+	ports.NotificationEmailChanged,
+	ports.NotificationSnoozeEnded,
+	ports.NotificationRequesterReplied,
+}
+
+// Message is a rendered email with HTML and plain-text bodies.
+type Message struct {
+	Subject  string
+	HTMLBody string
+	TextBody string
+}
+
+// templateData is the value passed to every email template.
+type templateData struct {
+	Locale        string
+	RecipientName string
+	Subject       string
+	Message       string
+	TicketID      int64
+	Data          map[string]string
+}
+
+// templateFuncs are available to every template. Templates translate text
+// with {{t $.Locale "key" args...}}.
+var templateFuncs = map[string]any{
+	"t": i18n.T,
+}
+
+// Renderer renders notifications into branded emails using the embedded
+// templates. Each notification type is rendered inside a shared layout, with
+// its text resolved from the recipient's locale catalog.
+type Renderer struct {
+	html map[string]*htmltemplate.Template
+	text map[string]*texttemplate.Template
+}
+
+// NewRenderer parses the embedded templates.
+func NewRenderer() (*Renderer, error) {
+	htmlLayout, err := htmltemplate.New("layout.html").Funcs(templateFuncs).ParseFS(templateFS, "templates/html/layout.html")
+	if err != nil {
+		return nil, fmt.Errorf("parse html layout: %w", err)
+	}
+	textLayout, err := texttemplate.New("layout.txt").Funcs(templateFuncs).ParseFS(templateFS, "templates/text/layout.txt")
+	if err != nil {
+		return nil, fmt.Errorf("parse text layout: %w", err)
+	}
+
+	r := &Renderer{
+		html: make(map[string]*htmltemplate.Template),
+		text: make(map[string]*texttemplate.Template),
+	}
+
+	names := []string{defaultTemplate}
+	for _, t := range templateNames {
+		names = append(names, string(t))
+	}
+
+	for _, name := range names {
+		htmlLayoutClone, err := htmlLayout.Clone()
+		if err != nil {
+			return nil, err
+		}
+		htmlTmpl, err := htmlLayoutClone.ParseFS(templateFS, "templates/html/"+name+".html")
+		if err != nil {
+			return nil, fmt.Errorf("parse html template %q: %w", name, err)
+		}
+
+		textLayoutClone, err := textLayout.Clone()
+		if err != nil {
+			return nil, err
+		}
+		textTmpl, err := textLayoutClone.ParseFS(templateFS, "templates/text/"+name+".txt")
+		if err != nil {
+			return nil, fmt.Errorf("parse text template %q: %w", name, err)
+		}
+
+		r.html[name] = htmlTmpl
+		r.text[name] = textTmpl
+	}
+
+	return r, nil
+}
+
+// mustNewRenderer is like NewRenderer but panics if the embedded templates
+// fail to parse, which can only happen if they are broken at build time.
+func mustNewRenderer() *Renderer {
+	r, err := NewRenderer()
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Render builds the email for a notification addressed to recipientName in
+// the given locale. Unsupported locales render in i18n.DefaultLocale.
+func (r *Renderer) Render(params ports.NotificationParams, recipientName, locale string) (*Message, error) {
+	name := string(params.Type)
+	if _, ok := r.html[name]; !ok {
+		name = defaultTemplate
+	}
+
+	if !i18n.IsSupported(locale) {
+		locale = i18n.DefaultLocale
+	}
+	subject := localizedSubject(params, locale)
+
+	data := templateData{
+		Locale:        locale,
+		RecipientName: recipientName,
+		Subject:       subject,
+		Message:       params.Message,
+		TicketID:      params.TicketID,
+		Data:          params.Data,
+	}
+
+	var htmlBody bytes.Buffer
+	if err := r.html[name].ExecuteTemplate(&htmlBody, "layout.html", data); err != nil {
+		return nil, fmt.Errorf("render html template %q: %w", name, err)
+	}
+
+	var textBody bytes.Buffer
+	if err := r.text[name].ExecuteTemplate(&textBody, "layout.txt", data); err != nil {
+		return nil, fmt.Errorf("render text template %q: %w", name, err)
+	}
+
+	return &Message{
+		Subject:  subject,
+		HTMLBody: htmlBody.String(),
+		TextBody: textBody.String(),
+	}, nil
+}
+
+// localizedSubject translates the subject of a notification type with a
+// catalog entry, using the batch variant when several events were combined.
+// Other notifications keep the subject they were queued with.
+func localizedSubject(params ports.NotificationParams, locale string) string {
+	key := "email." + string(params.Type) + ".subject"
+	if params.TicketID == 0 {
+		return i18n.TOr(locale, key, params.Subject)
+	}
+	count := params.Data["count"]
+	if count != "" && i18n.Has(locale, key+"_batch") {
+		return i18n.T(locale, key+"_batch", params.TicketID, count)
+	}
+	return i18n.TOr(locale, key, params.Subject, params.TicketID)
+}
+
+// Bytes encodes the message as a multipart/alternative MIME email, with the
+// plain-text part first so clients prefer the HTML part when they can show it.
+func (m *Message) Bytes(from, to string) ([]byte, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", m.TextBody},
+		{"text/html; charset=utf-8", m.HTMLBody},
+	}
+
+	for _, part := range parts {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", part.contentType)
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+
+		pw, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(pw)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	headers := []string{
+		"From: " + from,
+		"To: " + to,
+		"Subject: " + mime.QEncoding.Encode("utf-8", m.Subject),
+		"Date: " + time.Now().UTC().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/alternative; boundary=" + writer.Boundary(),
+	}
+	msg.WriteString(strings.Join(headers, "\r\n"))
+	msg.WriteString("\r\n\r\n")
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
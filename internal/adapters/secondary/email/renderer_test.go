@@ -0,0 +1,171 @@
+package email
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderer_Render(t *testing.T) {
+	r, err := NewRenderer()
+	require.NoError(t, err)
+
+	t.Run("uses the template for the notification type", func(t *testing.T) {
+		msg, err := r.Render(ports.NotificationParams{
+			Type:     ports.NotificationCommentAdded,
+			Subject:  "A new comment was added to your ticket: #7",
+			Message:  "A new comment has been added to your ticket 'Printer'.",
+			TicketID: 7,
+			Data: map[string]string{
+				"title":   "Printer",
+				"comment": "<b>Have you tried turning it off?</b>",
+			},
+		}, "Jane Doe", "en")
+		require.NoError(t, err)
+
+		assert.Equal(t, "A new comment was added to your ticket: #7", msg.Subject)
+		assert.Contains(t, msg.HTMLBody, "Hi Jane Doe,")
+		assert.Contains(t, msg.HTMLBody, "&lt;b&gt;Have you tried turning it off?&lt;/b&gt;")
+		assert.Contains(t, msg.TextBody, "<b>Have you tried turning it off?</b>")
+		assert.Contains(t, msg.TextBody, "#7 Printer")
+	})
+
+	t.Run("mentions how many comments a batch covers", func(t *testing.T) {
+		msg, err := r.Render(ports.NotificationParams{
+			Type:     ports.NotificationCommentAdded,
+			Subject:  "3 new comments on your ticket: #7",
+			TicketID: 7,
+			Data: map[string]string{
+				"title":   "Printer",
+				"comment": "Latest reply",
+				"count":   "3",
+			},
+		}, "Jane Doe", "en")
+		require.NoError(t, err)
+
+		assert.Contains(t, msg.TextBody, "3 new comments were added to your ticket #7 Printer. The latest:")
+		assert.Contains(t, msg.HTMLBody, "3 new comments were added")
+	})
+
+	t.Run("renders in the recipient's locale", func(t *testing.T) {
+		msg, err := r.Render(ports.NotificationParams{
+			Type:     ports.NotificationTicketAssigned,
+			Subject:  "Ticket assigned to you: #7",
+			TicketID: 7,
+			Data: map[string]string{
+				"title":    "Printer",
+				"priority": "HIGH",
+			},
+		}, "Juan", "es")
+		require.NoError(t, err)
+
+		assert.Equal(t, "Se te asignó un ticket: #7", msg.Subject)
+		assert.Contains(t, msg.TextBody, "Hola Juan:")
+		assert.Contains(t, msg.TextBody, "Se te ha asignado el ticket #7 Printer.")
+		assert.Contains(t, msg.HTMLBody, `<html lang="es">`)
+	})
+
+	t.Run("unsupported locales fall back to English", func(t *testing.T) {
+		msg, err := r.Render(ports.NotificationParams{
+			Type:     ports.NotificationTicketAssigned,
+			TicketID: 7,
+			Data:     map[string]string{"title": "Printer"},
+		}, "Jane Doe", "xx")
+		require.NoError(t, err)
+
+		assert.Equal(t, "Ticket assigned to you: #7", msg.Subject)
+		assert.Contains(t, msg.TextBody, "Hi Jane Doe,")
+	})
+
+	t.Run("falls back to the message for unknown types", func(t *testing.T) {
+		msg, err := r.Render(ports.NotificationParams{
+			Subject:  "Hello",
+			Message:  "Something happened.",
+			TicketID: 1,
+		}, "Jane Doe", "en")
+		require.NoError(t, err)
+
+		assert.Contains(t, msg.HTMLBody, "Something happened.")
+		assert.Contains(t, msg.TextBody, "Something happened.")
+	})
+
+	t.Run("renders account notifications without a ticket", func(t *testing.T) {
+		msg, err := r.Render(ports.NotificationParams{
+			Type:    ports.NotificationDataExportReady,
+			Subject: "Your data export is ready",
+			Data:    map[string]string{"expires_at": "2026-01-08T00:00:00Z"},
+		}, "Jane Doe", "en")
+		require.NoError(t, err)
+
+		assert.Equal(t, "Your data export is ready", msg.Subject)
+		assert.Contains(t, msg.TextBody, "It will be deleted after 2026-01-08T00:00:00Z.")
+		assert.Contains(t, msg.TextBody, "activity on your account")
+		assert.NotContains(t, msg.TextBody, "ticket #")
+	})
+
+	t.Run("adds the opt-out links to the footer", func(t *testing.T) {
+		msg, err := r.Render(ports.NotificationParams{
+			Type:     ports.NotificationTicketAssigned,
+			TicketID: 7,
+			Data: map[string]string{
+				"title":            "Printer",
+				"mute_link":        "https://help.example.com/email?action=mute&token=abc",
+				"unsubscribe_link": "https://help.example.com/email?action=unsubscribe&token=def",
+			},
+		}, "Jane Doe", "en")
+		require.NoError(t, err)
+
+		assert.Contains(t, msg.TextBody, "Mute this ticket: https://help.example.com/email?action=mute&token=abc")
+		assert.Contains(t, msg.TextBody, "Unsubscribe from all ticket emails: https://help.example.com/email?action=unsubscribe&token=def")
+		assert.Contains(t, msg.HTMLBody, `href="https://help.example.com/email?action=mute&amp;token=abc"`)
+
+		msg, err = r.Render(ports.NotificationParams{
+			Type:     ports.NotificationTicketAssigned,
+			TicketID: 7,
+			Data:     map[string]string{"title": "Printer"},
+		}, "Jane Doe", "en")
+		require.NoError(t, err)
+
+		assert.NotContains(t, msg.TextBody, "Mute this ticket")
+	})
+
+	t.Run("renders the email change confirmation in the recipient's locale", func(t *testing.T) {
+		msg, err := r.Render(ports.NotificationParams{
+			Type:    ports.NotificationEmailChange,
+			Subject: "Confirm your new email address",
+			Data: map[string]string{
+				"old_email":  "jane@example.com",
+				"new_email":  "jane@example.org",
+				"link":       "https://help.example.com/confirm-email?token=abc",
+				"expires_at": "2026-01-02T00:00:00Z",
+			},
+		}, "Jane Doe", "es")
+		require.NoError(t, err)
+
+		assert.Equal(t, "Confirma tu nueva dirección de correo", msg.Subject)
+		assert.Contains(t, msg.TextBody, "Pediste iniciar sesión con jane@example.org")
+		assert.Contains(t, msg.TextBody, "https://help.example.com/confirm-email?token=abc")
+		assert.Contains(t, msg.HTMLBody, `href="https://help.example.com/confirm-email?token=abc"`)
+	})
+}
+
+func TestMessage_Bytes(t *testing.T) {
+	msg := &Message{
+		Subject:  "Ticket update",
+		HTMLBody: "<p>Hello</p>",
+		TextBody: "Hello",
+	}
+
+	raw, err := msg.Bytes("desk@example.com", "jane@example.com")
+	require.NoError(t, err)
+
+	content := string(raw)
+	assert.True(t, strings.HasPrefix(content, "From: desk@example.com\r\n"))
+	assert.Contains(t, content, "Content-Type: multipart/alternative; boundary=")
+	assert.Contains(t, content, "Content-Type: text/plain; charset=utf-8")
+	assert.Contains(t, content, "Content-Type: text/html; charset=utf-8")
+	assert.Less(t, strings.Index(content, "text/plain"), strings.Index(content, "text/html"))
+}
@@ -0,0 +1,356 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// AnalyticsRepository computes analytics from the tickets in a Store. It
+// keeps no daily rollups: every figure is counted from the tickets as they
+// are, soft-deleted and archived ones included where the rollups would
+// include them.
+type AnalyticsRepository struct {
+	store *Store
+}
+
+var _ ports.AnalyticsRepository = (*AnalyticsRepository)(nil)
+
+// NewAnalyticsRepository creates a new in-memory analytics repository.
+func NewAnalyticsRepository(store *Store) ports.AnalyticsRepository {
+	return &AnalyticsRepository{store: store}
+}
+
+// GetOverview summarizes the organization's tickets.
+func (r *AnalyticsRepository) GetOverview(ctx context.Context, orgID uuid.UUID, rng domain.AnalyticsRange) (*domain.AnalyticsOverview, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tickets := make([]*ticketRow, 0)
+	for _, id := range sortedKeys(s.tickets) {
+		if row := s.tickets[id]; row.ticket.OrganizationID == orgID {
+			tickets = append(tickets, row)
+		}
+	}
+
+	volume, byPriority, mttrHours := volumeStats(tickets, rng)
+	firstResponse, agentFirstResponse := s.firstResponseStats(tickets, rng)
+	return &domain.AnalyticsOverview{
+		Range:              rng,
+		StatusCounts:       statusCounts(tickets),
+		Workload:           s.workload(tickets),
+		TeamWorkload:       s.teamWorkload(orgID, tickets),
+		Volume:             volume,
+		VolumeByPriority:   byPriority,
+		MTTRHours:          mttrHours,
+		FirstResponse:      firstResponse,
+		AgentFirstResponse: agentFirstResponse,
+	}, nil
+}
+
+// RollupThrough returns the last day RefreshDailyRollups was called
+// through, or nil if it never was.
+func (r *AnalyticsRepository) RollupThrough(ctx context.Context) (*time.Time, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return clonePtr(s.rollupThrough), nil
+}
+
+// RefreshDailyRollups only records through: the overview is always counted
+// from the tickets themselves.
+func (r *AnalyticsRepository) RefreshDailyRollups(ctx context.Context, from, through time.Time) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.rollupThrough == nil || through.After(*s.rollupThrough) {
+		s.rollupThrough = &through
+	}
+	return nil
+}
+
+// GetWorkload counts the open tickets of each assignee.
+func (r *AnalyticsRepository) GetWorkload(ctx context.Context, orgID uuid.UUID) ([]domain.WorkloadItem, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tickets := make([]*ticketRow, 0)
+	for _, id := range sortedKeys(s.tickets) {
+		if row := s.tickets[id]; row.ticket.OrganizationID == orgID {
+			tickets = append(tickets, row)
+		}
+	}
+	return s.workload(tickets), nil
+}
+
+// ListAgentTicketHistory counts the assigned tickets matching query per
+// assignee. Like the archive in Postgres, archived tickets are left out.
+func (r *AnalyticsRepository) ListAgentTicketHistory(ctx context.Context, orgID uuid.UUID, query domain.SimilarTicketsQuery) ([]domain.AgentTicketHistory, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	byAgent := make(map[uuid.UUID]*domain.AgentTicketHistory)
+	for _, row := range s.tickets {
+		t := row.ticket
+		if t.OrganizationID != orgID || !row.isCurrent() || t.ID == query.TicketID ||
+			t.AssigneeID == nil || t.CreatedAt.Before(query.Since) {
+			continue
+		}
+		sharedTag := slices.ContainsFunc(t.Tags, func(tag string) bool { return slices.Contains(query.Tags, tag) })
+		sameTeam := query.TeamID != nil && t.TeamID != nil && *t.TeamID == *query.TeamID
+		if !sharedTag && !sameTeam {
+			continue
+		}
+		h, ok := byAgent[*t.AssigneeID]
+		if !ok {
+			h = &domain.AgentTicketHistory{AgentID: *t.AssigneeID}
+			byAgent[*t.AssigneeID] = h
+		}
+		if sharedTag {
+			h.SharedTagTickets++
+		}
+		if sameTeam {
+			h.SameTeamTickets++
+		}
+	}
+
+	history := make([]domain.AgentTicketHistory, 0, len(byAgent))
+	for _, h := range byAgent {
+		history = append(history, *h)
+	}
+	sort.Slice(history, func(i, j int) bool { return history[i].AgentID.String() < history[j].AgentID.String() })
+	return history, nil
+}
+
+// isCurrent reports whether the ticket counts toward the current state of
+// the organization: it is neither soft-deleted nor archived.
+func (row *ticketRow) isCurrent() bool {
+	return row.deletedAt == nil && row.archivedAt == nil
+}
+
+func statusCounts(tickets []*ticketRow) []domain.StatusCount {
+	counts := []domain.StatusCount{
+		{Status: domain.StatusOpen},
+		{Status: domain.StatusInProgress},
+		{Status: domain.StatusClosed},
+	}
+	for _, row := range tickets {
+		if !row.isCurrent() {
+			continue
+		}
+		for i := range counts {
+			if counts[i].Status == row.ticket.Status {
+				counts[i].Count++
+			}
+		}
+	}
+	return counts
+}
+
+// workload counts the open tickets of each assignee, unassigned tickets
+// included. Callers must hold the lock.
+func (s *Store) workload(tickets []*ticketRow) []domain.WorkloadItem {
+	items := make([]domain.WorkloadItem, 0)
+	for _, row := range tickets {
+		if !row.isCurrent() || row.ticket.Status == domain.StatusClosed {
+			continue
+		}
+		assigneeID := row.ticket.AssigneeID
+		found := false
+		for i := range items {
+			if sameUser(items[i].AssigneeID, assigneeID) {
+				items[i].Count++
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		item := domain.WorkloadItem{AssigneeID: clonePtr(assigneeID), Count: 1}
+		if assigneeID != nil {
+			if user, ok := s.users[*assigneeID]; ok {
+				item.FullName, item.Email = user.user.FullName, user.user.Email
+			}
+		}
+		items = append(items, item)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.FullName != b.FullName {
+			return a.FullName < b.FullName
+		}
+		return a.Email < b.Email
+	})
+	return items
+}
+
+// teamWorkload counts the open and queued tickets of each of the
+// organization's teams. Callers must hold the lock.
+func (s *Store) teamWorkload(orgID uuid.UUID, tickets []*ticketRow) []domain.TeamWorkload {
+	items := make([]domain.TeamWorkload, 0)
+	for _, team := range s.teams {
+		if team.OrganizationID != orgID {
+			continue
+		}
+		item := domain.TeamWorkload{TeamID: team.ID, Name: team.Name, MemberCount: int64(len(team.MemberIDs))}
+		for _, row := range tickets {
+			t := row.ticket
+			if !row.isCurrent() || t.Status == domain.StatusClosed || t.TeamID == nil || *t.TeamID != team.ID {
+				continue
+			}
+			item.OpenCount++
+			if t.AssigneeID == nil {
+				item.QueuedCount++
+			}
+		}
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+	return items
+}
+
+// volumeStats counts the tickets created and resolved on each day of the
+// range, and per priority, and averages the working time the resolved
+// tickets took.
+func volumeStats(tickets []*ticketRow, rng domain.AnalyticsRange) ([]domain.VolumePoint, []domain.PriorityVolume, float64) {
+	points := make([]domain.VolumePoint, 0, rng.Days())
+	for day := rng.From; !day.After(rng.To); day = day.AddDate(0, 0, 1) {
+		points = append(points, domain.VolumePoint{Day: day})
+	}
+	byPriority := []domain.PriorityVolume{
+		{Priority: domain.PriorityHigh},
+		{Priority: domain.PriorityMedium},
+		{Priority: domain.PriorityLow},
+	}
+	dayIndex := func(t time.Time) (int, bool) {
+		day := t.UTC().Truncate(24 * time.Hour)
+		if day.Before(rng.From) || day.After(rng.To) {
+			return 0, false
+		}
+		return int(day.Sub(rng.From).Hours() / 24), true
+	}
+	priorityIndex := func(p domain.TicketPriority) int {
+		for i := range byPriority {
+			if byPriority[i].Priority == p {
+				return i
+			}
+		}
+		return -1
+	}
+
+	var resolutionSeconds float64
+	var resolved int64
+	for _, row := range tickets {
+		t := row.ticket
+		p := priorityIndex(t.Priority)
+		if i, ok := dayIndex(t.CreatedAt); ok {
+			points[i].CreatedCount++
+			if p >= 0 {
+				byPriority[p].CreatedCount++
+			}
+		}
+		if t.ClosedAt == nil {
+			continue
+		}
+		if i, ok := dayIndex(*t.ClosedAt); ok {
+			points[i].ResolvedCount++
+			if p >= 0 {
+				byPriority[p].ResolvedCount++
+			}
+			if t.ResolutionTime != nil {
+				resolutionSeconds += t.ResolutionTime.Seconds()
+			} else {
+				resolutionSeconds += t.ClosedAt.Sub(t.CreatedAt).Seconds()
+			}
+			resolved++
+		}
+	}
+
+	var mttrHours float64
+	if resolved > 0 {
+		mttrHours = resolutionSeconds / float64(resolved) / 3600
+	}
+	return points, byPriority, mttrHours
+}
+
+// firstResponseStats summarizes the first responses given in the range,
+// overall and per agent. Callers must hold the lock.
+func (s *Store) firstResponseStats(tickets []*ticketRow, rng domain.AnalyticsRange) (domain.ResponseTimeStats, []domain.AgentResponseTime) {
+	end := rng.To.AddDate(0, 0, 1)
+	all := make([]float64, 0)
+	byAgent := make(map[uuid.UUID][]float64)
+	for _, row := range tickets {
+		at := row.firstResponseAt
+		if at == nil || at.Before(rng.From) || !at.Before(end) {
+			continue
+		}
+		seconds := at.Sub(row.ticket.CreatedAt).Seconds()
+		all = append(all, seconds)
+		if row.firstResponseBy != nil {
+			if _, ok := s.users[*row.firstResponseBy]; ok {
+				byAgent[*row.firstResponseBy] = append(byAgent[*row.firstResponseBy], seconds)
+			}
+		}
+	}
+
+	agents := make([]domain.AgentResponseTime, 0, len(byAgent))
+	for agentID, seconds := range byAgent {
+		user := s.users[agentID].user
+		agents = append(agents, domain.AgentResponseTime{
+			AgentID:           agentID,
+			FullName:          user.FullName,
+			Email:             user.Email,
+			ResponseTimeStats: responseTimeStats(seconds),
+		})
+	}
+	sort.Slice(agents, func(i, j int) bool {
+		if agents[i].FullName != agents[j].FullName {
+			return agents[i].FullName < agents[j].FullName
+		}
+		return agents[i].Email < agents[j].Email
+	})
+	return responseTimeStats(all), agents
+}
+
+// responseTimeStats summarizes response times given in seconds, computing
+// percentiles the way Postgres' PERCENTILE_CONT does.
+func responseTimeStats(seconds []float64) domain.ResponseTimeStats {
+	stats := domain.ResponseTimeStats{Count: int64(len(seconds))}
+	if len(seconds) == 0 {
+		return stats
+	}
+	sort.Float64s(seconds)
+
+	var sum float64
+	for _, v := range seconds {
+		sum += v
+	}
+	stats.AverageHours = sum / float64(len(seconds)) / 3600
+	stats.MedianHours = percentile(seconds, 0.5) / 3600
+	stats.P90Hours = percentile(seconds, 0.9) / 3600
+	return stats
+}
+
+// percentile interpolates the fraction p of the sorted values.
+func percentile(sorted []float64, p float64) float64 {
+	pos := p * float64(len(sorted)-1)
+	lower := int(pos)
+	if lower+1 >= len(sorted) {
+		return sorted[lower]
+	}
+	return sorted[lower] + (pos-float64(lower))*(sorted[lower+1]-sorted[lower])
+}
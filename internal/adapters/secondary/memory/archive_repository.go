@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// TicketArchiveRepository archives tickets in a Store. Archived tickets stay
+// where they are, marked as archived, so the other repositories stop seeing
+// them.
+type TicketArchiveRepository struct {
+	store *Store
+}
+
+var _ ports.TicketArchiveRepository = (*TicketArchiveRepository)(nil)
+
+// NewTicketArchiveRepository creates a new in-memory ticket archive
+// repository.
+func NewTicketArchiveRepository(store *Store) ports.TicketArchiveRepository {
+	return &TicketArchiveRepository{store: store}
+}
+
+// ArchiveClosedBefore archives up to limit tickets closed before
+// closedBefore, oldest first, and queues them for search indexing. It
+// returns how many were archived.
+func (r *TicketArchiveRepository) ArchiveClosedBefore(ctx context.Context, closedBefore time.Time, limit int) (int64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	closed := make([]*ticketRow, 0)
+	for _, row := range s.tickets {
+		t := row.ticket
+		if row.archivedAt == nil && row.deletedAt == nil && t.Status == domain.StatusClosed && t.ClosedAt != nil && t.ClosedAt.Before(closedBefore) {
+			closed = append(closed, row)
+		}
+	}
+	sort.Slice(closed, func(i, j int) bool {
+		a, b := closed[i].ticket, closed[j].ticket
+		if !a.ClosedAt.Equal(*b.ClosedAt) {
+			return a.ClosedAt.Before(*b.ClosedAt)
+		}
+		return a.ID < b.ID
+	})
+
+	now := time.Now().UTC()
+	closed = paginate(closed, limit, 0)
+	for _, row := range closed {
+		row.archivedAt = &now
+		s.enqueueForSearch(row.ticket.ID)
+	}
+	return int64(len(closed)), nil
+}
+
+// GetTicket retrieves an archived ticket of the organization.
+func (r *TicketArchiveRepository) GetTicket(ctx context.Context, orgID uuid.UUID, ticketID int64) (*domain.Ticket, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	row, ok := s.archivedTicket(ticketID)
+	if !ok || row.ticket.OrganizationID != orgID {
+		return nil, apperrors.ErrTicketNotFound
+	}
+	ticket := cloneTicket(&row.ticket)
+	ticket.ArchivedAt = clonePtr(row.archivedAt)
+	return ticket, nil
+}
+
+// ListComments returns the archived ticket's comments, oldest first.
+func (r *TicketArchiveRepository) ListComments(ctx context.Context, ticketID int64) ([]*domain.Comment, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.archivedTicket(ticketID); !ok {
+		return []*domain.Comment{}, nil
+	}
+	return s.allTicketComments(ticketID), nil
+}
+
+// ListEvents returns the archived ticket's events in order.
+func (r *TicketArchiveRepository) ListEvents(ctx context.Context, ticketID int64) ([]*domain.Event, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.archivedTicket(ticketID); !ok {
+		return []*domain.Event{}, nil
+	}
+	return s.ticketEvents(ticketID), nil
+}
+
+// archivedTicket returns the ticket if it is archived and not soft-deleted.
+// Callers must hold the lock.
+func (s *Store) archivedTicket(id int64) (*ticketRow, bool) {
+	row, ok := s.tickets[id]
+	if !ok || row.archivedAt == nil || row.deletedAt != nil {
+		return nil, false
+	}
+	return row, true
+}
@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// AuditLogRepository keeps the audit log in a Store.
+type AuditLogRepository struct {
+	store *Store
+}
+
+var _ ports.AuditLogRepository = (*AuditLogRepository)(nil)
+
+// NewAuditLogRepository creates a new in-memory audit log repository.
+func NewAuditLogRepository(store *Store) ports.AuditLogRepository {
+	return &AuditLogRepository{store: store}
+}
+
+// Create stores an audit entry, setting its ID and CreatedAt.
+func (r *AuditLogRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry.ID = s.nextID("audit_log")
+	entry.CreatedAt = time.Now().UTC()
+	s.auditLog = append(s.auditLog, cloneAuditEntry(entry))
+	return nil
+}
+
+// List returns a page of the organization's audit entries, newest first,
+// along with how many entries match the filter in total.
+func (r *AuditLogRepository) List(ctx context.Context, orgID uuid.UUID, filter domain.AuditLogFilter) ([]*domain.AuditEntry, domain.Total, error) {
+	entries := r.list(orgID, filter)
+	total := domain.Total{Count: int64(len(entries))}
+	return paginate(entries, filter.Limit, filter.Offset), total, nil
+}
+
+// ListPage returns a keyset page of the organization's audit entries
+// matching filter, newest first. The filter's Limit and Offset are ignored.
+func (r *AuditLogRepository) ListPage(ctx context.Context, orgID uuid.UUID, filter domain.AuditLogFilter, page domain.PageRequest) (domain.Page[*domain.AuditEntry], error) {
+	return keysetPage(r.list(orgID, filter), page, func(e *domain.AuditEntry) domain.PageCursor {
+		return domain.PageCursor{CreatedAt: e.CreatedAt, ID: strconv.FormatInt(e.ID, 10)}
+	}, func(e *domain.AuditEntry, cursor domain.PageCursor) bool {
+		return follows(e.CreatedAt, compareInt64(e.ID, cursor.ID), cursor, false)
+	}), nil
+}
+
+// list returns the organization's entries matching filter, newest first.
+func (r *AuditLogRepository) list(orgID uuid.UUID, filter domain.AuditLogFilter) []*domain.AuditEntry {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := make([]*domain.AuditEntry, 0)
+	for _, entry := range s.auditLog {
+		if entry.OrganizationID == orgID && auditEntryMatches(entry, filter) {
+			entries = append(entries, cloneAuditEntry(entry))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].CreatedAt.Equal(entries[j].CreatedAt) {
+			return entries[i].CreatedAt.After(entries[j].CreatedAt)
+		}
+		return entries[i].ID > entries[j].ID
+	})
+	return entries
+}
+
+// auditEntryMatches reports whether entry matches the filters of filter.
+func auditEntryMatches(entry *domain.AuditEntry, filter domain.AuditLogFilter) bool {
+	switch {
+	case filter.Action != nil && entry.Action != *filter.Action:
+		return false
+	case filter.ActorID != nil && entry.ActorID != *filter.ActorID:
+		return false
+	case filter.TargetID != nil && entry.TargetID != *filter.TargetID:
+		return false
+	case filter.From != nil && entry.CreatedAt.Before(*filter.From):
+		return false
+	case filter.To != nil && entry.CreatedAt.After(*filter.To):
+		return false
+	}
+	return true
+}
+
+func cloneAuditEntry(e *domain.AuditEntry) *domain.AuditEntry {
+	c := *e
+	c.Before = slices.Clone(e.Before)
+	c.After = slices.Clone(e.After)
+	c.ImpersonatorID = clonePtr(e.ImpersonatorID)
+	return &c
+}
@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/google/uuid"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// rolePermissions are the default roles and their permissions, as seeded
+// into Postgres by the authorization repository there.
+var rolePermissions = map[string][]string{
+	"superadmin": {
+		"tickets:create", "tickets:read", "tickets:read:all",
+		"tickets:update:status", "tickets:update", "tickets:assign", "tickets:claim", "tickets:list:all",
+		"comments:create", "comments:read", "macros:apply", "admin:access", "platform:access",
+	},
+	"admin": {
+		"tickets:create", "tickets:read", "tickets:read:all",
+		"tickets:update:status", "tickets:update", "tickets:assign", "tickets:claim", "tickets:list:all",
+		"comments:create", "comments:read", "macros:apply", "admin:access",
+	},
+	"agent": {
+		"tickets:create", "tickets:read", "tickets:read:all",
+		"tickets:update:status", "tickets:update", "tickets:assign", "tickets:claim", "tickets:list:all",
+		"comments:create", "comments:read", "macros:apply",
+	},
+	"customer": {"tickets:create", "tickets:read", "comments:create", "comments:read"},
+	"guest":    {"tickets:create", "tickets:read", "comments:create", "comments:read"},
+}
+
+// AuthorizationRepository keeps user roles in a Store. The roles and their
+// permissions are fixed to the defaults.
+type AuthorizationRepository struct {
+	store *Store
+}
+
+var _ ports.AuthorizationRepository = (*AuthorizationRepository)(nil)
+
+// NewAuthorizationRepository creates a new in-memory authorization
+// repository.
+func NewAuthorizationRepository(store *Store) ports.AuthorizationRepository {
+	return &AuthorizationRepository{store: store}
+}
+
+// GetUserPermissions returns the permissions granted by the user's roles.
+func (r *AuthorizationRepository) GetUserPermissions(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	permissions := make([]string, 0)
+	row, ok := s.users[userID]
+	if !ok {
+		return permissions, nil
+	}
+	for _, role := range row.roles {
+		for _, permission := range rolePermissions[role] {
+			if !slices.Contains(permissions, permission) {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+	return permissions, nil
+}
+
+// AssignRole gives the user a role on top of those they have.
+func (r *AuthorizationRepository) AssignRole(ctx context.Context, userID uuid.UUID, roleName string) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := rolePermissions[roleName]; !ok {
+		return apperrors.ErrRoleNotFound
+	}
+	row, ok := s.users[userID]
+	if !ok {
+		return apperrors.ErrUserNotFound
+	}
+	if slices.Contains(row.roles, roleName) {
+		return apperrors.ErrRoleAlreadyAssigned
+	}
+	row.roles = append(row.roles, roleName)
+	return nil
+}
+
+// SetUserRole replaces the user's roles with roleName.
+func (r *AuthorizationRepository) SetUserRole(ctx context.Context, userID uuid.UUID, roleName string) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := rolePermissions[roleName]; !ok {
+		return apperrors.ErrRoleNotFound
+	}
+	row, ok := s.users[userID]
+	if !ok {
+		return apperrors.ErrUserNotFound
+	}
+	row.roles = []string{roleName}
+	return nil
+}
+
+// GetUserRoles returns the names of the user's roles, sorted.
+func (r *AuthorizationRepository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	roles := make([]string, 0)
+	if row, ok := s.users[userID]; ok {
+		roles = append(roles, row.roles...)
+	}
+	sort.Strings(roles)
+	return roles, nil
+}
+
+// EnsureRBACDefaults does nothing: the default roles are built in.
+func (r *AuthorizationRepository) EnsureRBACDefaults(ctx context.Context) error {
+	return nil
+}
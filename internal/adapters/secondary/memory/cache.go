@@ -0,0 +1,103 @@
+// Package memory provides in-process implementations of ports that can
+// also be backed by a shared store, for single-instance deployments. Its
+// repositories keep their data in a Store instead of Postgres, so the API
+// can run without a database for local development.
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// Cache is an in-memory implementation of ports.Cache. Expired entries are
+// dropped when read and swept periodically.
+type Cache struct {
+	mu    sync.Mutex
+	items map[string]cacheItem
+}
+
+type cacheItem struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+var _ ports.Cache = (*Cache)(nil)
+
+// NewCache creates an in-memory cache that sweeps expired entries every
+// sweepInterval.
+func NewCache(sweepInterval time.Duration) *Cache {
+	c := &Cache{
+		items: make(map[string]cacheItem),
+	}
+	go c.sweep(sweepInterval)
+	return c
+}
+
+// Get returns the value stored under key, if it has not expired.
+func (c *Cache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, ok := c.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !time.Now().Before(item.expiresAt) {
+		delete(c.items, key)
+		return nil, false, nil
+	}
+	return item.value, true, nil
+}
+
+// Set stores value under key for ttl.
+func (c *Cache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items[key] = cacheItem{value: value, expiresAt: time.Now().Add(ttl)}
+	return nil
+}
+
+// SetNX stores value under key for ttl unless an unexpired value is there.
+func (c *Cache) SetNX(_ context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if item, ok := c.items[key]; ok && now.Before(item.expiresAt) {
+		return false, nil
+	}
+	c.items[key] = cacheItem{value: value, expiresAt: now.Add(ttl)}
+	return true, nil
+}
+
+// Delete removes keys.
+func (c *Cache) Delete(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.items, key)
+	}
+	return nil
+}
+
+// sweep removes expired entries every interval.
+func (c *Cache) sweep(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		c.mu.Lock()
+		now := time.Now()
+		for key, item := range c.items {
+			if !now.Before(item.expiresAt) {
+				delete(c.items, key)
+			}
+		}
+		c.mu.Unlock()
+	}
+}
@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// commentDraftKey identifies a user's draft on a ticket.
+type commentDraftKey struct {
+	ticketID int64
+	userID   uuid.UUID
+}
+
+// CommentDraftRepository keeps comment drafts in a Store.
+type CommentDraftRepository struct {
+	store *Store
+}
+
+var _ ports.CommentDraftRepository = (*CommentDraftRepository)(nil)
+
+// NewCommentDraftRepository creates a new in-memory comment draft repository.
+func NewCommentDraftRepository(store *Store) ports.CommentDraftRepository {
+	return &CommentDraftRepository{store: store}
+}
+
+// Upsert stores a draft, replacing the user's existing one on the ticket.
+func (r *CommentDraftRepository) Upsert(ctx context.Context, draft *domain.CommentDraft) (*domain.CommentDraft, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := *draft
+	s.commentDrafts[commentDraftKey{ticketID: draft.TicketID, userID: draft.UserID}] = &stored
+	c := stored
+	return &c, nil
+}
+
+// Get retrieves a user's draft on a ticket.
+func (r *CommentDraftRepository) Get(ctx context.Context, ticketID int64, userID uuid.UUID) (*domain.CommentDraft, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	draft, ok := s.commentDrafts[commentDraftKey{ticketID: ticketID, userID: userID}]
+	if !ok {
+		return nil, apperrors.ErrCommentDraftNotFound
+	}
+	c := *draft
+	return &c, nil
+}
+
+// Delete removes a user's draft on a ticket, if there is one.
+func (r *CommentDraftRepository) Delete(ctx context.Context, ticketID int64, userID uuid.UUID) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.commentDrafts, commentDraftKey{ticketID: ticketID, userID: userID})
+	return nil
+}
@@ -0,0 +1,166 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// CommentRepository keeps comments in a Store. The comments of archived
+// tickets are left to the TicketArchiveRepository.
+type CommentRepository struct {
+	store *Store
+}
+
+var _ ports.CommentRepository = (*CommentRepository)(nil)
+
+// NewCommentRepository creates a new in-memory comment repository.
+func NewCommentRepository(store *Store) ports.CommentRepository {
+	return &CommentRepository{store: store}
+}
+
+// Create stores a new comment on an existing ticket.
+func (r *CommentRepository) Create(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tickets[comment.TicketID]; !ok {
+		return nil, apperrors.ErrTicketNotFound
+	}
+
+	created := &domain.Comment{
+		ID:        s.nextID("comments"),
+		TicketID:  comment.TicketID,
+		AuthorID:  comment.AuthorID,
+		Body:      comment.Body,
+		BodyHTML:  comment.BodyHTML,
+		CreatedAt: time.Now().UTC(),
+	}
+	s.comments[created.ID] = created
+	c := *created
+	return &c, nil
+}
+
+// CreateBatch stores comments, setting the ID of each, and queues their
+// tickets for search indexing. A zero CreatedAt is set to now.
+func (r *CommentRepository) CreateBatch(ctx context.Context, comments []*domain.Comment) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, comment := range comments {
+		if _, ok := s.tickets[comment.TicketID]; !ok {
+			return apperrors.ErrTicketNotFound
+		}
+	}
+
+	now := time.Now().UTC()
+	ticketIDs := make([]int64, 0)
+	for _, comment := range comments {
+		comment.ID = s.nextID("comments")
+		if comment.CreatedAt.IsZero() {
+			comment.CreatedAt = now
+		}
+		c := *comment
+		s.comments[c.ID] = &c
+		if !slices.Contains(ticketIDs, c.TicketID) {
+			ticketIDs = append(ticketIDs, c.TicketID)
+		}
+	}
+	s.enqueueForSearch(ticketIDs...)
+	return nil
+}
+
+// ListByTicketID returns the ticket's comments, oldest first.
+func (r *CommentRepository) ListByTicketID(ctx context.Context, ticketID int64) ([]*domain.Comment, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.ticketComments(ticketID), nil
+}
+
+// ListLatestByTicketIDs returns the most recent comment on each of the
+// tickets. Tickets without comments are left out.
+func (r *CommentRepository) ListLatestByTicketIDs(ctx context.Context, ticketIDs []int64) ([]*domain.Comment, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := slices.Clone(ticketIDs)
+	slices.Sort(ids)
+	latest := make([]*domain.Comment, 0)
+	for _, ticketID := range slices.Compact(ids) {
+		comments := s.ticketComments(ticketID)
+		if len(comments) > 0 {
+			latest = append(latest, comments[len(comments)-1])
+		}
+	}
+	return latest, nil
+}
+
+// ListPageByTicketID returns a keyset page of the ticket's comments, oldest
+// first.
+func (r *CommentRepository) ListPageByTicketID(ctx context.Context, ticketID int64, page domain.PageRequest) (domain.Page[*domain.Comment], error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return keysetPage(s.ticketComments(ticketID), page, func(c *domain.Comment) domain.PageCursor {
+		return domain.PageCursor{CreatedAt: c.CreatedAt, ID: strconv.FormatInt(c.ID, 10)}
+	}, func(c *domain.Comment, cursor domain.PageCursor) bool {
+		return follows(c.CreatedAt, compareInt64(c.ID, cursor.ID), cursor, true)
+	}), nil
+}
+
+// MarkFirstResponse records the comment as the ticket's first response,
+// leaving tickets that already have one untouched.
+func (r *CommentRepository) MarkFirstResponse(ctx context.Context, comment *domain.Comment) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	row, ok := s.tickets[comment.TicketID]
+	if !ok || row.deletedAt != nil || row.firstResponseAt != nil {
+		return nil
+	}
+	at, by := comment.CreatedAt, comment.AuthorID
+	row.firstResponseAt, row.firstResponseBy = &at, &by
+	return nil
+}
+
+// ticketComments returns copies of the comments of a ticket that is not
+// archived, oldest first. Callers must hold the lock.
+func (s *Store) ticketComments(ticketID int64) []*domain.Comment {
+	comments := make([]*domain.Comment, 0)
+	if s.isArchived(ticketID) {
+		return comments
+	}
+	return s.allTicketComments(ticketID)
+}
+
+// allTicketComments returns copies of the ticket's comments, archived or
+// not, oldest first. Callers must hold the lock.
+func (s *Store) allTicketComments(ticketID int64) []*domain.Comment {
+	comments := make([]*domain.Comment, 0)
+	for _, comment := range s.comments {
+		if comment.TicketID == ticketID {
+			c := *comment
+			comments = append(comments, &c)
+		}
+	}
+	sort.Slice(comments, func(i, j int) bool {
+		if !comments[i].CreatedAt.Equal(comments[j].CreatedAt) {
+			return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+		}
+		return comments[i].ID < comments[j].ID
+	})
+	return comments
+}
@@ -0,0 +1,243 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// DataExportRepository keeps data exports in a Store and collects the data
+// they contain from it.
+type DataExportRepository struct {
+	store *Store
+}
+
+var _ ports.DataExportRepository = (*DataExportRepository)(nil)
+
+// NewDataExportRepository creates a new in-memory data export repository.
+func NewDataExportRepository(store *Store) ports.DataExportRepository {
+	return &DataExportRepository{store: store}
+}
+
+// Create stores a new export request.
+func (r *DataExportRepository) Create(ctx context.Context, export *domain.DataExport) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.dataExports[export.ID] = cloneDataExport(export)
+	return nil
+}
+
+// GetLatest retrieves the user's most recent export.
+func (r *DataExportRepository) GetLatest(ctx context.Context, userID uuid.UUID) (*domain.DataExport, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var latest *domain.DataExport
+	for _, export := range s.dataExports {
+		if export.UserID == userID && (latest == nil || export.CreatedAt.After(latest.CreatedAt)) {
+			latest = export
+		}
+	}
+	if latest == nil {
+		return nil, apperrors.ErrDataExportNotFound
+	}
+	return cloneDataExport(latest), nil
+}
+
+// ClaimPending returns the oldest pending export, or nil if there is none.
+func (r *DataExportRepository) ClaimPending(ctx context.Context) (*domain.DataExport, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var oldest *domain.DataExport
+	for _, export := range s.dataExports {
+		if export.Status == domain.DataExportPending && (oldest == nil || export.CreatedAt.Before(oldest.CreatedAt)) {
+			oldest = export
+		}
+	}
+	if oldest == nil {
+		return nil, nil
+	}
+	return cloneDataExport(oldest), nil
+}
+
+// MarkReady stores the finished export's payload.
+func (r *DataExportRepository) MarkReady(ctx context.Context, id uuid.UUID, payload []byte, completedAt, expiresAt time.Time) error {
+	return r.update(id, func(export *domain.DataExport) {
+		export.Status = domain.DataExportReady
+		export.Payload = slices.Clone(payload)
+		export.Error = ""
+		export.CompletedAt = &completedAt
+		export.ExpiresAt = &expiresAt
+	})
+}
+
+// MarkFailed records why an export could not be generated.
+func (r *DataExportRepository) MarkFailed(ctx context.Context, id uuid.UUID, completedAt time.Time, lastErr string) error {
+	return r.update(id, func(export *domain.DataExport) {
+		export.Status = domain.DataExportFailed
+		export.Error = lastErr
+		export.CompletedAt = &completedAt
+	})
+}
+
+// DeleteExpired removes exports that expired at or before now, returning
+// how many were removed.
+func (r *DataExportRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+	for id, export := range s.dataExports {
+		if export.ExpiresAt != nil && !export.ExpiresAt.After(now) {
+			delete(s.dataExports, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// CollectUserData gathers everything stored about the user.
+func (r *DataExportRepository) CollectUserData(ctx context.Context, userID uuid.UUID) (*domain.UserData, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	row, ok := s.users[userID]
+	if !ok {
+		return nil, apperrors.ErrUserNotFound
+	}
+	user := row.user
+	roles := slices.Clone(row.roles)
+	slices.Sort(roles)
+	if roles == nil {
+		roles = []string{}
+	}
+
+	data := &domain.UserData{
+		Profile: domain.UserDataProfile{
+			ID:              user.ID,
+			OrganizationID:  user.OrganizationID,
+			FullName:        user.FullName,
+			Email:           user.Email,
+			Roles:           roles,
+			PhoneNumber:     user.PhoneNumber,
+			SMSOptIn:        user.SMSOptIn,
+			Locale:          user.Locale,
+			Timezone:        user.Timezone,
+			IsActive:        user.IsActive,
+			CreatedAt:       user.CreatedAt,
+			LastActiveAt:    clonePtr(user.LastActiveAt),
+			TokensRevokedAt: clonePtr(user.TokensRevokedAt),
+		},
+		Tickets:      make([]domain.UserDataTicket, 0),
+		Comments:     make([]domain.UserDataComment, 0),
+		TicketEvents: make([]domain.UserDataTicketEvent, 0),
+		AuditEvents:  make([]domain.UserDataAuditEvent, 0),
+	}
+
+	// Soft-deleted tickets are included: they are personal data held until
+	// retention removes them.
+	for _, id := range sortedKeys(s.tickets) {
+		t := s.tickets[id].ticket
+		role := ""
+		switch {
+		case t.RequesterID == userID:
+			role = "requester"
+		case t.AssigneeID != nil && *t.AssigneeID == userID:
+			role = "assignee"
+		default:
+			continue
+		}
+		data.Tickets = append(data.Tickets, domain.UserDataTicket{
+			ID:          t.ID,
+			Title:       t.Title,
+			Description: t.Description,
+			Status:      string(t.Status),
+			Priority:    string(t.Priority),
+			Role:        role,
+			CreatedAt:   t.CreatedAt,
+			UpdatedAt:   clonePtr(t.UpdatedAt),
+			ClosedAt:    clonePtr(t.ClosedAt),
+		})
+	}
+
+	for _, id := range sortedKeys(s.comments) {
+		if comment := s.comments[id]; comment.AuthorID == userID {
+			data.Comments = append(data.Comments, domain.UserDataComment{
+				ID:        comment.ID,
+				TicketID:  comment.TicketID,
+				Body:      comment.Body,
+				CreatedAt: comment.CreatedAt,
+			})
+		}
+	}
+
+	for _, id := range sortedKeys(s.events) {
+		if event := s.events[id]; event.ActorID == userID {
+			data.TicketEvents = append(data.TicketEvents, domain.UserDataTicketEvent{
+				ID:        event.ID,
+				TicketID:  event.TicketID,
+				Type:      string(event.Type),
+				Payload:   slices.Clone(event.Payload),
+				CreatedAt: event.CreatedAt,
+			})
+		}
+	}
+
+	entries := make([]*domain.AuditEntry, 0)
+	for _, entry := range s.auditLog {
+		if entry.ActorID == userID || (entry.TargetType == domain.AuditTargetUser && entry.TargetID == userID.String()) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].CreatedAt.Equal(entries[j].CreatedAt) {
+			return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	for _, entry := range entries {
+		data.AuditEvents = append(data.AuditEvents, domain.UserDataAuditEvent{
+			Action:    entry.Action,
+			ActorID:   entry.ActorID,
+			TargetID:  entry.TargetID,
+			CreatedAt: entry.CreatedAt,
+		})
+	}
+
+	return data, nil
+}
+
+// update applies fn to an export.
+func (r *DataExportRepository) update(id uuid.UUID, fn func(*domain.DataExport)) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	export, ok := s.dataExports[id]
+	if !ok {
+		return apperrors.ErrDataExportNotFound
+	}
+	fn(export)
+	return nil
+}
+
+func cloneDataExport(e *domain.DataExport) *domain.DataExport {
+	c := *e
+	c.Payload = slices.Clone(e.Payload)
+	c.CompletedAt = clonePtr(e.CompletedAt)
+	c.ExpiresAt = clonePtr(e.ExpiresAt)
+	return &c
+}
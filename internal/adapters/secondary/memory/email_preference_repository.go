@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// emailPreferencesRow is what a user has opted out of.
+type emailPreferencesRow struct {
+	unsubscribed bool
+	mutedTickets map[int64]bool
+}
+
+// EmailPreferenceRepository keeps users' email opt-outs in a Store.
+type EmailPreferenceRepository struct {
+	store *Store
+}
+
+var _ ports.EmailPreferenceRepository = (*EmailPreferenceRepository)(nil)
+
+// NewEmailPreferenceRepository creates a new in-memory email preference repository.
+func NewEmailPreferenceRepository(store *Store) ports.EmailPreferenceRepository {
+	return &EmailPreferenceRepository{store: store}
+}
+
+// Get returns the user's email preferences, empty if they have none.
+func (r *EmailPreferenceRepository) Get(ctx context.Context, userID uuid.UUID) (*domain.EmailPreferences, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	prefs := &domain.EmailPreferences{UserID: userID, MutedTicketIDs: make([]int64, 0)}
+	if row, ok := s.emailPreferences[userID]; ok {
+		prefs.Unsubscribed = row.unsubscribed
+		for ticketID := range row.mutedTickets {
+			prefs.MutedTicketIDs = append(prefs.MutedTicketIDs, ticketID)
+		}
+		sort.Slice(prefs.MutedTicketIDs, func(i, j int) bool { return prefs.MutedTicketIDs[i] < prefs.MutedTicketIDs[j] })
+	}
+	return prefs, nil
+}
+
+// SetUnsubscribed turns all of the user's ticket emails off or back on.
+func (r *EmailPreferenceRepository) SetUnsubscribed(ctx context.Context, userID uuid.UUID, unsubscribed bool) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.emailPreferencesRow(userID).unsubscribed = unsubscribed
+	return nil
+}
+
+// MuteTicket stops the user's emails about a ticket.
+func (r *EmailPreferenceRepository) MuteTicket(ctx context.Context, userID uuid.UUID, ticketID int64) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.emailPreferencesRow(userID).mutedTickets[ticketID] = true
+	return nil
+}
+
+// UnmuteTicket lets the user's emails about a ticket through again.
+func (r *EmailPreferenceRepository) UnmuteTicket(ctx context.Context, userID uuid.UUID, ticketID int64) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if row, ok := s.emailPreferences[userID]; ok {
+		delete(row.mutedTickets, ticketID)
+	}
+	return nil
+}
+
+// emailPreferencesRow returns the user's row, adding it if they have none.
+// Callers must hold the write lock.
+func (s *Store) emailPreferencesRow(userID uuid.UUID) *emailPreferencesRow {
+	row, ok := s.emailPreferences[userID]
+	if !ok {
+		row = &emailPreferencesRow{mutedTickets: make(map[int64]bool)}
+		s.emailPreferences[userID] = row
+	}
+	return row
+}
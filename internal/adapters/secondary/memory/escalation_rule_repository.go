@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// EscalationRuleRepository keeps escalation rules in a Store.
+type EscalationRuleRepository struct {
+	store *Store
+}
+
+var _ ports.EscalationRuleRepository = (*EscalationRuleRepository)(nil)
+
+// NewEscalationRuleRepository creates a new in-memory escalation rule
+// repository.
+func NewEscalationRuleRepository(store *Store) ports.EscalationRuleRepository {
+	return &EscalationRuleRepository{store: store}
+}
+
+// Create stores a new escalation rule.
+func (r *EscalationRuleRepository) Create(ctx context.Context, rule *domain.EscalationRule) (*domain.EscalationRule, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	created := cloneEscalationRule(rule)
+	created.ID = uuid.New()
+	created.CreatedAt, created.UpdatedAt = now, now
+	s.escalationRules[created.ID] = created
+	return cloneEscalationRule(created), nil
+}
+
+// GetByID retrieves an escalation rule by its ID.
+func (r *EscalationRuleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.EscalationRule, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rule, ok := s.escalationRules[id]
+	if !ok {
+		return nil, apperrors.ErrEscalationRuleNotFound
+	}
+	return cloneEscalationRule(rule), nil
+}
+
+// ListByOrganization retrieves the organization's escalation rules, oldest
+// first.
+func (r *EscalationRuleRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.EscalationRule, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rules := make([]*domain.EscalationRule, 0)
+	for _, rule := range s.escalationRules {
+		if rule.OrganizationID == orgID {
+			rules = append(rules, cloneEscalationRule(rule))
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return createdBefore(rules[i].CreatedAt, rules[i].ID, rules[j].CreatedAt, rules[j].ID)
+	})
+	return rules, nil
+}
+
+// Update saves the configurable fields of an escalation rule.
+func (r *EscalationRuleRepository) Update(ctx context.Context, rule *domain.EscalationRule) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.escalationRules[rule.ID]
+	if !ok {
+		return apperrors.ErrEscalationRuleNotFound
+	}
+	updated := cloneEscalationRule(rule)
+	existing.Name = updated.Name
+	existing.Trigger = updated.Trigger
+	existing.ThresholdMinutes = updated.ThresholdMinutes
+	existing.Priorities = updated.Priorities
+	existing.Actions = updated.Actions
+	existing.IsActive = updated.IsActive
+	existing.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// Delete removes an escalation rule.
+func (r *EscalationRuleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.escalationRules[id]; !ok {
+		return apperrors.ErrEscalationRuleNotFound
+	}
+	delete(s.escalationRules, id)
+	return nil
+}
+
+func cloneEscalationRule(rule *domain.EscalationRule) *domain.EscalationRule {
+	c := *rule
+	c.Priorities = slices.Clone(rule.Priorities)
+	if c.Priorities == nil {
+		c.Priorities = []domain.TicketPriority{}
+	}
+	c.Actions = make([]domain.EscalationAction, len(rule.Actions))
+	for i, action := range rule.Actions {
+		action.UserID = clonePtr(action.UserID)
+		action.TeamID = clonePtr(action.TeamID)
+		c.Actions[i] = action
+	}
+	return &c
+}
@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// TicketEventRepository keeps ticket events in a Store. The events of
+// archived tickets are left to the TicketArchiveRepository.
+type TicketEventRepository struct {
+	store *Store
+}
+
+var _ ports.TicketEventRepository = (*TicketEventRepository)(nil)
+
+// NewTicketEventRepository creates a new in-memory ticket event repository.
+func NewTicketEventRepository(store *Store) ports.TicketEventRepository {
+	return &TicketEventRepository{store: store}
+}
+
+// Create stores a new event of an existing ticket.
+func (r *TicketEventRepository) Create(ctx context.Context, event *domain.Event) (*domain.Event, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tickets[event.TicketID]; !ok {
+		return nil, apperrors.ErrTicketNotFound
+	}
+
+	created := &domain.Event{
+		ID:        s.nextID("ticket_events"),
+		TicketID:  event.TicketID,
+		Type:      event.Type,
+		Version:   event.Version,
+		Payload:   slices.Clone(event.Payload),
+		ActorID:   event.ActorID,
+		CreatedAt: time.Now().UTC(),
+	}
+	s.events[created.ID] = created
+	return cloneEvent(created), nil
+}
+
+// ListByTicketID returns up to limit of the ticket's events after afterID,
+// in order.
+func (r *TicketEventRepository) ListByTicketID(ctx context.Context, ticketID int64, afterID int64, limit int) ([]*domain.Event, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	events := make([]*domain.Event, 0)
+	if s.isArchived(ticketID) {
+		return events, nil
+	}
+	for _, event := range s.ticketEvents(ticketID) {
+		if len(events) == limit {
+			break
+		}
+		if event.ID > afterID {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// ListForReplay returns up to filter.Limit of the organization's events
+// matching the filter, in order. Deleted and archived tickets have none.
+func (r *TicketEventRepository) ListForReplay(ctx context.Context, filter domain.EventReplayFilter) ([]*domain.Event, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	events := make([]*domain.Event, 0)
+	for _, id := range sortedKeys(s.events) {
+		if len(events) == filter.Limit {
+			break
+		}
+		event := s.events[id]
+		row, ok := s.tickets[event.TicketID]
+		if !ok || row.ticket.OrganizationID != filter.OrganizationID || row.deletedAt != nil || s.isArchived(event.TicketID) {
+			continue
+		}
+		if filter.TicketID != nil && event.TicketID != *filter.TicketID {
+			continue
+		}
+		if event.ID <= filter.AfterID || event.CreatedAt.Before(filter.From) || !event.CreatedAt.Before(filter.To) {
+			continue
+		}
+		events = append(events, cloneEvent(event))
+	}
+	return events, nil
+}
+
+// ticketEvents returns copies of the ticket's events in order. Callers must
+// hold the lock.
+func (s *Store) ticketEvents(ticketID int64) []*domain.Event {
+	events := make([]*domain.Event, 0)
+	for _, id := range sortedKeys(s.events) {
+		if event := s.events[id]; event.TicketID == ticketID {
+			events = append(events, cloneEvent(event))
+		}
+	}
+	return events
+}
+
+func cloneEvent(e *domain.Event) *domain.Event {
+	c := *e
+	c.Payload = slices.Clone(e.Payload)
+	return &c
+}
@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// InboundHookRepository keeps inbound webhook endpoints in a Store.
+type InboundHookRepository struct {
+	store *Store
+}
+
+var _ ports.InboundHookRepository = (*InboundHookRepository)(nil)
+
+// NewInboundHookRepository creates a new in-memory inbound hook repository.
+func NewInboundHookRepository(store *Store) ports.InboundHookRepository {
+	return &InboundHookRepository{store: store}
+}
+
+// Create stores a new inbound hook. Sources are unique across
+// organizations.
+func (r *InboundHookRepository) Create(ctx context.Context, hook *domain.InboundHook) (*domain.InboundHook, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.inboundHooks {
+		if existing.Source == hook.Source {
+			return nil, apperrors.ErrInboundHookExists
+		}
+	}
+
+	created := cloneInboundHook(hook)
+	created.ID = uuid.New()
+	created.CreatedAt = time.Now().UTC()
+	s.inboundHooks[created.ID] = created
+	return cloneInboundHook(created), nil
+}
+
+// GetByID retrieves an inbound hook by its ID.
+func (r *InboundHookRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.InboundHook, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	hook, ok := s.inboundHooks[id]
+	if !ok {
+		return nil, apperrors.ErrInboundHookNotFound
+	}
+	return cloneInboundHook(hook), nil
+}
+
+// GetBySource retrieves the inbound hook receiving payloads for source.
+func (r *InboundHookRepository) GetBySource(ctx context.Context, source string) (*domain.InboundHook, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, hook := range s.inboundHooks {
+		if hook.Source == source {
+			return cloneInboundHook(hook), nil
+		}
+	}
+	return nil, apperrors.ErrInboundHookNotFound
+}
+
+// ListByOrganization retrieves the organization's inbound hooks, oldest
+// first.
+func (r *InboundHookRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.InboundHook, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	hooks := make([]*domain.InboundHook, 0)
+	for _, hook := range s.inboundHooks {
+		if hook.OrganizationID == orgID {
+			hooks = append(hooks, cloneInboundHook(hook))
+		}
+	}
+	sort.Slice(hooks, func(i, j int) bool {
+		return createdBefore(hooks[i].CreatedAt, hooks[i].ID, hooks[j].CreatedAt, hooks[j].ID)
+	})
+	return hooks, nil
+}
+
+// Delete removes an inbound hook.
+func (r *InboundHookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.inboundHooks[id]; !ok {
+		return apperrors.ErrInboundHookNotFound
+	}
+	delete(s.inboundHooks, id)
+	return nil
+}
+
+func cloneInboundHook(h *domain.InboundHook) *domain.InboundHook {
+	c := *h
+	c.Mapping.PriorityMap = maps.Clone(h.Mapping.PriorityMap)
+	c.Mapping.SkipValues = slices.Clone(h.Mapping.SkipValues)
+	return &c
+}
@@ -0,0 +1,126 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// MacroRepository keeps macros in a Store.
+type MacroRepository struct {
+	store *Store
+}
+
+var _ ports.MacroRepository = (*MacroRepository)(nil)
+
+// NewMacroRepository creates a new in-memory macro repository.
+func NewMacroRepository(store *Store) ports.MacroRepository {
+	return &MacroRepository{store: store}
+}
+
+// Create stores a new macro.
+func (r *MacroRepository) Create(ctx context.Context, macro *domain.Macro) (*domain.Macro, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.macroNameTaken(macro.OrganizationID, macro.Name, uuid.Nil) {
+		return nil, apperrors.ErrMacroExists
+	}
+
+	now := time.Now().UTC()
+	created := cloneMacro(macro)
+	created.ID = uuid.New()
+	created.CreatedAt, created.UpdatedAt = now, now
+	s.macros[created.ID] = created
+	return cloneMacro(created), nil
+}
+
+// GetByID retrieves a macro by its ID.
+func (r *MacroRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Macro, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	macro, ok := s.macros[id]
+	if !ok {
+		return nil, apperrors.ErrMacroNotFound
+	}
+	return cloneMacro(macro), nil
+}
+
+// ListByOrganization retrieves the organization's macros, ordered by name.
+func (r *MacroRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.Macro, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	macros := make([]*domain.Macro, 0)
+	for _, macro := range s.macros {
+		if macro.OrganizationID == orgID {
+			macros = append(macros, cloneMacro(macro))
+		}
+	}
+	sort.Slice(macros, func(i, j int) bool { return macros[i].Name < macros[j].Name })
+	return macros, nil
+}
+
+// Update saves a macro's name and actions.
+func (r *MacroRepository) Update(ctx context.Context, macro *domain.Macro) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.macros[macro.ID]
+	if !ok {
+		return apperrors.ErrMacroNotFound
+	}
+	if s.macroNameTaken(existing.OrganizationID, macro.Name, macro.ID) {
+		return apperrors.ErrMacroExists
+	}
+	existing.Name = macro.Name
+	existing.Actions = cloneMacro(macro).Actions
+	existing.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// Delete removes a macro.
+func (r *MacroRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.macros[id]; !ok {
+		return apperrors.ErrMacroNotFound
+	}
+	delete(s.macros, id)
+	return nil
+}
+
+// macroNameTaken reports whether a macro of the organization other than
+// except is named name. Callers must hold the lock.
+func (s *Store) macroNameTaken(orgID uuid.UUID, name string, except uuid.UUID) bool {
+	for _, macro := range s.macros {
+		if macro.ID != except && macro.OrganizationID == orgID && macro.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func cloneMacro(m *domain.Macro) *domain.Macro {
+	c := *m
+	c.Actions = make([]domain.MacroAction, len(m.Actions))
+	for i, action := range m.Actions {
+		action.AssigneeID = clonePtr(action.AssigneeID)
+		action.Tags = slices.Clone(action.Tags)
+		c.Actions[i] = action
+	}
+	return &c
+}
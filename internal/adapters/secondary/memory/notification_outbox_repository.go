@@ -0,0 +1,169 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"sort"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// outboxStatus is where a queued notification is in its delivery.
+type outboxStatus int
+
+const (
+	outboxPending outboxStatus = iota
+	outboxSent
+	outboxDead
+)
+
+// outboxRow is a queued notification.
+type outboxRow struct {
+	id            int64
+	params        ports.NotificationParams
+	status        outboxStatus
+	attempts      int
+	count         int
+	batchKey      string
+	nextAttemptAt time.Time
+	lastError     string
+}
+
+// NotificationOutboxRepository keeps the notification outbox in a Store.
+type NotificationOutboxRepository struct {
+	store *Store
+}
+
+var _ ports.NotificationOutboxRepository = (*NotificationOutboxRepository)(nil)
+
+// NewNotificationOutboxRepository creates a new in-memory notification
+// outbox repository.
+func NewNotificationOutboxRepository(store *Store) ports.NotificationOutboxRepository {
+	return &NotificationOutboxRepository{store: store}
+}
+
+// Enqueue stores a notification for delivery.
+func (r *NotificationOutboxRepository) Enqueue(ctx context.Context, params ports.NotificationParams) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.enqueueNotification(params, "", time.Now().UTC())
+	return nil
+}
+
+// EnqueueBatched stores a notification due after window, or folds it into the
+// waiting notification with the same batch key, keeping its due time.
+func (r *NotificationOutboxRepository) EnqueueBatched(ctx context.Context, batchKey string, params ports.NotificationParams, window time.Duration) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, row := range s.outbox {
+		if row.batchKey != "" && row.batchKey == batchKey {
+			row.params.Subject = params.Subject
+			row.params.Message = params.Message
+			row.params.Data = maps.Clone(params.Data)
+			row.count++
+			return nil
+		}
+	}
+	s.enqueueNotification(params, batchKey, time.Now().UTC().Add(window))
+	return nil
+}
+
+// ClaimDue returns up to limit pending notifications that are due, pushing
+// their next attempt out by lease and closing their batches.
+func (r *NotificationOutboxRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*ports.OutboxNotification, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	due := make([]*outboxRow, 0)
+	for _, row := range s.outbox {
+		if row.status == outboxPending && !row.nextAttemptAt.After(now) {
+			due = append(due, row)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].nextAttemptAt.Equal(due[j].nextAttemptAt) {
+			return due[i].nextAttemptAt.Before(due[j].nextAttemptAt)
+		}
+		return due[i].id < due[j].id
+	})
+
+	notifications := make([]*ports.OutboxNotification, 0)
+	for _, row := range paginate(due, limit, 0) {
+		row.nextAttemptAt = now.Add(lease)
+		row.batchKey = ""
+		params := row.params
+		params.Data = maps.Clone(row.params.Data)
+		notifications = append(notifications, &ports.OutboxNotification{
+			ID:       row.id,
+			Params:   params,
+			Attempts: row.attempts,
+			Count:    row.count,
+		})
+	}
+	return notifications, nil
+}
+
+// MarkSent records a successful delivery.
+func (r *NotificationOutboxRepository) MarkSent(ctx context.Context, id int64) error {
+	r.update(id, func(row *outboxRow) {
+		row.status = outboxSent
+		row.lastError = ""
+	})
+	return nil
+}
+
+// MarkRetry records a failed delivery and schedules the next attempt.
+func (r *NotificationOutboxRepository) MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, lastErr string) error {
+	r.update(id, func(row *outboxRow) {
+		row.nextAttemptAt = nextAttemptAt.UTC()
+		row.lastError = lastErr
+	})
+	return nil
+}
+
+// MarkDead moves a notification to the dead-letter state after its final
+// failed attempt.
+func (r *NotificationOutboxRepository) MarkDead(ctx context.Context, id int64, lastErr string) error {
+	r.update(id, func(row *outboxRow) {
+		row.status = outboxDead
+		row.lastError = lastErr
+	})
+	return nil
+}
+
+// update counts an attempt at delivering the notification and applies fn
+// to it. Unknown notifications are ignored.
+func (r *NotificationOutboxRepository) update(id int64, fn func(*outboxRow)) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if row, ok := s.outbox[id]; ok {
+		row.attempts++
+		fn(row)
+	}
+}
+
+// enqueueNotification stores a pending notification. Callers must hold the
+// write lock.
+func (s *Store) enqueueNotification(params ports.NotificationParams, batchKey string, due time.Time) {
+	params.Data = maps.Clone(params.Data)
+	if params.Data == nil {
+		params.Data = map[string]string{}
+	}
+	id := s.nextID("notification_outbox")
+	s.outbox[id] = &outboxRow{
+		id:            id,
+		params:        params,
+		count:         1,
+		batchKey:      batchKey,
+		nextAttemptAt: due,
+	}
+}
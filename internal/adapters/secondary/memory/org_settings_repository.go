@@ -0,0 +1,223 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// OrgSettingsRepository keeps organization settings in a Store.
+type OrgSettingsRepository struct {
+	store *Store
+}
+
+var _ ports.OrgSettingsRepository = (*OrgSettingsRepository)(nil)
+
+// NewOrgSettingsRepository creates a new in-memory organization settings
+// repository.
+func NewOrgSettingsRepository(store *Store) ports.OrgSettingsRepository {
+	return &OrgSettingsRepository{store: store}
+}
+
+// GetBusinessHours retrieves an organization's business hours and holidays,
+// returning apperrors.ErrNotFound if none have been configured.
+func (r *OrgSettingsRepository) GetBusinessHours(ctx context.Context, orgID uuid.UUID) (*domain.BusinessHours, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	org, ok := s.organizations[orgID]
+	if !ok || org.businessHours == nil {
+		return nil, apperrors.ErrNotFound
+	}
+	return cloneBusinessHours(org.businessHours), nil
+}
+
+// SaveBusinessHours replaces an organization's business hours and holidays.
+func (r *OrgSettingsRepository) SaveBusinessHours(ctx context.Context, hours *domain.BusinessHours) (*domain.BusinessHours, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org, ok := s.organizations[hours.OrganizationID]
+	if !ok {
+		return nil, apperrors.ErrNotFound
+	}
+	org.businessHours = cloneBusinessHours(hours)
+	org.businessHours.UpdatedAt = time.Now().UTC()
+	sort.Slice(org.businessHours.Holidays, func(i, j int) bool {
+		return org.businessHours.Holidays[i].Date < org.businessHours.Holidays[j].Date
+	})
+
+	saved := *hours
+	saved.UpdatedAt = org.businessHours.UpdatedAt
+	return &saved, nil
+}
+
+// GetRegistrationDomains returns the email domains allowed to self-register
+// into an organization, sorted. It is empty if registration is open.
+func (r *OrgSettingsRepository) GetRegistrationDomains(ctx context.Context, orgID uuid.UUID) ([]string, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	domains := make([]string, 0)
+	if org, ok := s.organizations[orgID]; ok {
+		domains = append(domains, org.registrationDomains...)
+	}
+	slices.Sort(domains)
+	return domains, nil
+}
+
+// SaveRegistrationDomains replaces an organization's registration allowlist.
+func (r *OrgSettingsRepository) SaveRegistrationDomains(ctx context.Context, orgID uuid.UUID, domains []string) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org, ok := s.organizations[orgID]
+	if !ok {
+		return apperrors.ErrNotFound
+	}
+	org.registrationDomains = slices.Clone(domains)
+	return nil
+}
+
+// GetPortalSlug returns the slug of an organization's public portal, or ""
+// if it has none.
+func (r *OrgSettingsRepository) GetPortalSlug(ctx context.Context, orgID uuid.UUID) (string, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	org, ok := s.organizations[orgID]
+	if !ok {
+		return "", apperrors.ErrNotFound
+	}
+	return org.portalSlug, nil
+}
+
+// SavePortalSlug sets the slug of an organization's public portal. An empty
+// slug turns the portal off.
+func (r *OrgSettingsRepository) SavePortalSlug(ctx context.Context, orgID uuid.UUID, slug string) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org, ok := s.organizations[orgID]
+	if !ok {
+		return nil
+	}
+	if slug != "" {
+		for id, other := range s.organizations {
+			if id != orgID && other.portalSlug == slug {
+				return apperrors.ErrPortalSlugTaken
+			}
+		}
+	}
+	org.portalSlug = slug
+	return nil
+}
+
+// GetAgentCapacity returns the open ticket limit of an organization's
+// agents.
+func (r *OrgSettingsRepository) GetAgentCapacity(ctx context.Context, orgID uuid.UUID) (*domain.AgentCapacity, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	org, ok := s.organizations[orgID]
+	if !ok {
+		return nil, apperrors.ErrNotFound
+	}
+	if org.agentCapacity == nil {
+		return domain.DefaultAgentCapacity(orgID), nil
+	}
+	capacity := *org.agentCapacity
+	capacity.MaxOpenTickets = clonePtr(capacity.MaxOpenTickets)
+	return &capacity, nil
+}
+
+// SaveAgentCapacity sets the open ticket limit of an organization's agents.
+func (r *OrgSettingsRepository) SaveAgentCapacity(ctx context.Context, capacity *domain.AgentCapacity) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org, ok := s.organizations[capacity.OrganizationID]
+	if !ok {
+		return nil
+	}
+	saved := *capacity
+	saved.MaxOpenTickets = clonePtr(capacity.MaxOpenTickets)
+	org.agentCapacity = &saved
+	return nil
+}
+
+// GetOrganizationIDByPortalSlug returns the organization whose public portal
+// is served under slug.
+func (r *OrgSettingsRepository) GetOrganizationIDByPortalSlug(ctx context.Context, slug string) (uuid.UUID, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if slug != "" {
+		for id, org := range s.organizations {
+			if org.portalSlug == slug {
+				return id, nil
+			}
+		}
+	}
+	return uuid.Nil, apperrors.ErrPortalNotFound
+}
+
+// CreateOrganization adds an organization with its public portal served
+// under slug.
+func (r *OrgSettingsRepository) CreateOrganization(ctx context.Context, name, slug string) (uuid.UUID, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, org := range s.organizations {
+		if slug != "" && org.portalSlug == slug {
+			return uuid.Nil, apperrors.ErrPortalSlugTaken
+		}
+	}
+	orgID := uuid.New()
+	s.organizations[orgID] = &organizationRow{name: name, createdAt: time.Now().UTC(), portalSlug: slug}
+	return orgID, nil
+}
+
+// GetOrganization retrieves an organization.
+func (r *OrgSettingsRepository) GetOrganization(ctx context.Context, orgID uuid.UUID) (*domain.Organization, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	org, ok := s.organizations[orgID]
+	if !ok {
+		return nil, apperrors.ErrNotFound
+	}
+	return &domain.Organization{ID: orgID, Name: org.name, PortalSlug: org.portalSlug, CreatedAt: org.createdAt}, nil
+}
+
+// cloneBusinessHours copies the stored fields of a calendar.
+func cloneBusinessHours(b *domain.BusinessHours) *domain.BusinessHours {
+	holidays := slices.Clone(b.Holidays)
+	if holidays == nil {
+		holidays = []domain.Holiday{}
+	}
+	return &domain.BusinessHours{
+		OrganizationID: b.OrganizationID,
+		Timezone:       b.Timezone,
+		Days:           slices.Clone(b.Days),
+		Holidays:       holidays,
+		UpdatedAt:      b.UpdatedAt,
+	}
+}
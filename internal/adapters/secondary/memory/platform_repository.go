@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// PlatformRepository reads every organization in a Store.
+type PlatformRepository struct {
+	store *Store
+}
+
+var _ ports.PlatformRepository = (*PlatformRepository)(nil)
+
+// NewPlatformRepository creates a new in-memory platform repository.
+func NewPlatformRepository(store *Store) ports.PlatformRepository {
+	return &PlatformRepository{store: store}
+}
+
+// ListOrganizations returns a page of organizations, oldest first, with
+// their active users and open tickets, and how many match the filter.
+func (r *PlatformRepository) ListOrganizations(ctx context.Context, filter domain.OrganizationFilter) ([]*domain.OrganizationSummary, domain.Total, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	query := strings.ToLower(filter.Query)
+	matches := make([]*domain.OrganizationSummary, 0)
+	for id, org := range s.organizations {
+		if query != "" && !strings.Contains(strings.ToLower(org.name), query) && !strings.Contains(org.portalSlug, query) {
+			continue
+		}
+		matches = append(matches, &domain.OrganizationSummary{
+			Organization: domain.Organization{ID: id, Name: org.name, PortalSlug: org.portalSlug, CreatedAt: org.createdAt},
+		})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i].Organization, matches[j].Organization
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID.String() < b.ID.String()
+	})
+
+	total := domain.Total{Count: int64(len(matches))}
+	page := paginate(matches, filter.Limit, filter.Offset)
+	for _, summary := range page {
+		summary.Usage = s.usage(summary.Organization.ID)
+	}
+	return page, total, nil
+}
+
+// GetHealth counts the organization's tickets created since since, its
+// open tickets past their due date and the state of its webhook deliveries.
+func (r *PlatformRepository) GetHealth(ctx context.Context, orgID uuid.UUID, since time.Time) (*domain.OrganizationHealth, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	health := &domain.OrganizationHealth{Since: since}
+	for _, row := range s.users {
+		lastActive := row.user.LastActiveAt
+		if row.user.OrganizationID != orgID || lastActive == nil {
+			continue
+		}
+		if health.LastActiveAt == nil || lastActive.After(*health.LastActiveAt) {
+			health.LastActiveAt = clonePtr(lastActive)
+		}
+	}
+	for _, row := range s.tickets {
+		t := row.ticket
+		if t.OrganizationID != orgID || row.deletedAt != nil || row.archivedAt != nil {
+			continue
+		}
+		if !t.CreatedAt.Before(since) {
+			health.TicketsCreated++
+		}
+		if t.Status != domain.StatusClosed && t.DueAt != nil && t.DueAt.Before(now) {
+			health.OverdueTickets++
+		}
+	}
+	for _, delivery := range s.deliveries {
+		webhook, ok := s.webhooks[delivery.WebhookID]
+		if !ok || webhook.OrganizationID != orgID {
+			continue
+		}
+		switch {
+		case delivery.Status == domain.WebhookDeliveryPending:
+			health.PendingWebhookDeliveries++
+		case delivery.Status == domain.WebhookDeliveryDead && !delivery.CreatedAt.Before(since):
+			health.DeadWebhookDeliveries++
+		}
+	}
+	return health, nil
+}
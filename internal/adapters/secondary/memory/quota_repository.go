@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// QuotaRepository reads organization quotas and usage from a Store.
+type QuotaRepository struct {
+	store *Store
+}
+
+var _ ports.QuotaRepository = (*QuotaRepository)(nil)
+
+// NewQuotaRepository creates a new in-memory quota repository.
+func NewQuotaRepository(store *Store) ports.QuotaRepository {
+	return &QuotaRepository{store: store}
+}
+
+// GetQuota retrieves an organization's own quota, returning
+// apperrors.ErrNotFound if it has none.
+func (r *QuotaRepository) GetQuota(ctx context.Context, orgID uuid.UUID) (*domain.OrgQuota, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	org, ok := s.organizations[orgID]
+	if !ok || org.quota == nil {
+		return nil, apperrors.ErrNotFound
+	}
+	return &domain.OrgQuota{
+		MaxUsers:       clonePtr(org.quota.MaxUsers),
+		MaxOpenTickets: clonePtr(org.quota.MaxOpenTickets),
+	}, nil
+}
+
+// GetUsage counts an organization's active users and open tickets.
+func (r *QuotaRepository) GetUsage(ctx context.Context, orgID uuid.UUID) (*domain.OrgUsage, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	usage := s.usage(orgID)
+	return &usage, nil
+}
+
+// usage counts an organization's active users and open tickets. Callers
+// must hold the lock.
+func (s *Store) usage(orgID uuid.UUID) domain.OrgUsage {
+	var usage domain.OrgUsage
+	for _, row := range s.users {
+		if row.user.OrganizationID == orgID && row.user.IsActive {
+			usage.ActiveUsers++
+		}
+	}
+	for _, row := range s.tickets {
+		if row.ticket.OrganizationID == orgID && row.ticket.Status != domain.StatusClosed && row.deletedAt == nil && row.archivedAt == nil {
+			usage.OpenTickets++
+		}
+	}
+	return usage
+}
@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// RateLimitOverrideRepository keeps rate limit overrides in a Store.
+type RateLimitOverrideRepository struct {
+	store *Store
+}
+
+var _ ports.RateLimitOverrideRepository = (*RateLimitOverrideRepository)(nil)
+
+// NewRateLimitOverrideRepository creates a new in-memory rate limit override
+// repository.
+func NewRateLimitOverrideRepository(store *Store) ports.RateLimitOverrideRepository {
+	return &RateLimitOverrideRepository{store: store}
+}
+
+// Upsert stores an override, replacing the existing one for the same
+// organization and user. A replaced override keeps its ID.
+func (r *RateLimitOverrideRepository) Upsert(ctx context.Context, override *domain.RateLimitOverride) (*domain.RateLimitOverride, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := cloneRateLimitOverride(override)
+	for _, existing := range s.rateLimitOverrides {
+		if existing.OrganizationID == override.OrganizationID && sameUser(existing.UserID, override.UserID) {
+			stored.ID = existing.ID
+			break
+		}
+	}
+	s.rateLimitOverrides[stored.ID] = stored
+	return cloneRateLimitOverride(stored), nil
+}
+
+// GetByID retrieves an override by its ID.
+func (r *RateLimitOverrideRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.RateLimitOverride, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	override, ok := s.rateLimitOverrides[id]
+	if !ok {
+		return nil, apperrors.ErrRateLimitOverrideNotFound
+	}
+	return cloneRateLimitOverride(override), nil
+}
+
+// ListByOrganization retrieves the organization's overrides, the
+// organization-wide one first.
+func (r *RateLimitOverrideRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.RateLimitOverride, error) {
+	return r.list(func(o *domain.RateLimitOverride) bool { return o.OrganizationID == orgID }), nil
+}
+
+// ListAll retrieves every override, grouped by organization.
+func (r *RateLimitOverrideRepository) ListAll(ctx context.Context) ([]*domain.RateLimitOverride, error) {
+	return r.list(func(*domain.RateLimitOverride) bool { return true }), nil
+}
+
+// Delete removes an override.
+func (r *RateLimitOverrideRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.rateLimitOverrides[id]; !ok {
+		return apperrors.ErrRateLimitOverrideNotFound
+	}
+	delete(s.rateLimitOverrides, id)
+	return nil
+}
+
+// list returns the overrides matching keep, ordered by organization, then
+// by user with organization-wide overrides first.
+func (r *RateLimitOverrideRepository) list(keep func(*domain.RateLimitOverride) bool) []*domain.RateLimitOverride {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	overrides := make([]*domain.RateLimitOverride, 0)
+	for _, override := range s.rateLimitOverrides {
+		if keep(override) {
+			overrides = append(overrides, cloneRateLimitOverride(override))
+		}
+	}
+	sort.Slice(overrides, func(i, j int) bool {
+		a, b := overrides[i], overrides[j]
+		if a.OrganizationID != b.OrganizationID {
+			return compareUUID(a.OrganizationID, b.OrganizationID.String()) < 0
+		}
+		if (a.UserID == nil) != (b.UserID == nil) {
+			return a.UserID == nil
+		}
+		if a.UserID != nil && *a.UserID != *b.UserID {
+			return compareUUID(*a.UserID, b.UserID.String()) < 0
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+	return overrides
+}
+
+// sameUser reports whether two optional user IDs are equal.
+func sameUser(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func cloneRateLimitOverride(o *domain.RateLimitOverride) *domain.RateLimitOverride {
+	c := *o
+	c.UserID = clonePtr(o.UserID)
+	return &c
+}
@@ -0,0 +1,204 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// RetentionRepository keeps retention policies in a Store and applies them
+// to its tickets, live and archived.
+type RetentionRepository struct {
+	store *Store
+}
+
+var _ ports.RetentionRepository = (*RetentionRepository)(nil)
+
+// NewRetentionRepository creates a new in-memory retention repository.
+func NewRetentionRepository(store *Store) ports.RetentionRepository {
+	return &RetentionRepository{store: store}
+}
+
+// GetPolicy retrieves an organization's retention policy, returning
+// apperrors.ErrNotFound if it has not configured one.
+func (r *RetentionRepository) GetPolicy(ctx context.Context, orgID uuid.UUID) (*domain.RetentionPolicy, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	org, ok := s.organizations[orgID]
+	if !ok || org.retention == nil {
+		return nil, apperrors.ErrNotFound
+	}
+	return cloneRetentionPolicy(org.retention), nil
+}
+
+// SavePolicy stores an organization's retention policy.
+func (r *RetentionRepository) SavePolicy(ctx context.Context, policy *domain.RetentionPolicy) (*domain.RetentionPolicy, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org, ok := s.organizations[policy.OrganizationID]
+	if !ok {
+		return nil, apperrors.ErrNotFound
+	}
+	org.retention = cloneRetentionPolicy(policy)
+	org.retention.UpdatedAt = time.Now().UTC()
+	return cloneRetentionPolicy(org.retention), nil
+}
+
+// ListEnabledPolicies returns the policies that purge closed tickets,
+// ordered by organization.
+func (r *RetentionRepository) ListEnabledPolicies(ctx context.Context) ([]*domain.RetentionPolicy, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	policies := make([]*domain.RetentionPolicy, 0)
+	for _, org := range s.organizations {
+		if org.retention != nil && org.retention.ClosedTicketDays != nil {
+			policies = append(policies, cloneRetentionPolicy(org.retention))
+		}
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		return compareUUID(policies[i].OrganizationID, policies[j].OrganizationID.String()) < 0
+	})
+	return policies, nil
+}
+
+// ListPurgeCandidates returns a page of the tickets a retention policy will
+// purge, those already soft-deleted first, and the total number of them.
+func (r *RetentionRepository) ListPurgeCandidates(ctx context.Context, orgID uuid.UUID, closedBefore time.Time, limit, offset int) ([]*domain.PurgeCandidate, domain.Total, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	candidates := make([]*domain.PurgeCandidate, 0)
+	for _, row := range s.retentionTickets(orgID) {
+		if row.deletedAt != nil || row.ticket.ClosedAt.Before(closedBefore) {
+			candidates = append(candidates, &domain.PurgeCandidate{
+				TicketID:  row.ticket.ID,
+				Title:     row.ticket.Title,
+				ClosedAt:  *row.ticket.ClosedAt,
+				DeletedAt: clonePtr(row.deletedAt),
+			})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if (a.DeletedAt == nil) != (b.DeletedAt == nil) {
+			return b.DeletedAt == nil
+		}
+		if a.DeletedAt != nil && !a.DeletedAt.Equal(*b.DeletedAt) {
+			return a.DeletedAt.Before(*b.DeletedAt)
+		}
+		if !a.ClosedAt.Equal(b.ClosedAt) {
+			return a.ClosedAt.Before(b.ClosedAt)
+		}
+		return a.TicketID < b.TicketID
+	})
+
+	total := domain.Total{Count: int64(len(candidates))}
+	return paginate(candidates, limit, offset), total, nil
+}
+
+// CountExpired counts the organization's closed tickets that are due to be
+// soft-deleted and its soft-deleted tickets that are due to be removed.
+func (r *RetentionRepository) CountExpired(ctx context.Context, orgID uuid.UUID, closedBefore, deletedBefore time.Time) (int64, int64, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var softDelete, hardDelete int64
+	for _, row := range s.retentionTickets(orgID) {
+		if row.deletedAt == nil && row.ticket.ClosedAt.Before(closedBefore) {
+			softDelete++
+		}
+		if row.deletedAt != nil && row.deletedAt.Before(deletedBefore) {
+			hardDelete++
+		}
+	}
+	return softDelete, hardDelete, nil
+}
+
+// SoftDeleteExpired hides the organization's tickets closed before
+// closedBefore, marking them deleted at now. Live tickets are queued for
+// search indexing so they are removed from the search index too.
+func (r *RetentionRepository) SoftDeleteExpired(ctx context.Context, orgID uuid.UUID, closedBefore, now time.Time) (int64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+	for _, row := range s.retentionTickets(orgID) {
+		if row.deletedAt != nil || !row.ticket.ClosedAt.Before(closedBefore) {
+			continue
+		}
+		at := now
+		row.deletedAt = &at
+		if row.archivedAt == nil {
+			s.enqueueForSearch(row.ticket.ID)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// HardDeleteExpired removes the organization's tickets soft-deleted before
+// deletedBefore, live and archived. Their comments and events are removed
+// with them.
+func (r *RetentionRepository) HardDeleteExpired(ctx context.Context, orgID uuid.UUID, deletedBefore time.Time) (int64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := make(map[int64]bool)
+	for _, row := range s.retentionTickets(orgID) {
+		if row.deletedAt != nil && row.deletedAt.Before(deletedBefore) {
+			removed[row.ticket.ID] = true
+			delete(s.tickets, row.ticket.ID)
+			delete(s.searchQueue, row.ticket.ID)
+		}
+	}
+	for id, comment := range s.comments {
+		if removed[comment.TicketID] {
+			delete(s.comments, id)
+		}
+	}
+	for id, event := range s.events {
+		if removed[event.TicketID] {
+			delete(s.events, id)
+		}
+	}
+	for key := range s.commentDrafts {
+		if removed[key.ticketID] {
+			delete(s.commentDrafts, key)
+		}
+	}
+	return int64(len(removed)), nil
+}
+
+// retentionTickets returns the organization's closed tickets, live and
+// archived, as seen by retention. Callers must hold the lock.
+func (s *Store) retentionTickets(orgID uuid.UUID) []*ticketRow {
+	rows := make([]*ticketRow, 0)
+	for _, row := range s.tickets {
+		t := row.ticket
+		if t.OrganizationID == orgID && t.Status == domain.StatusClosed && t.ClosedAt != nil {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func cloneRetentionPolicy(p *domain.RetentionPolicy) *domain.RetentionPolicy {
+	c := *p
+	c.ClosedTicketDays = clonePtr(p.ClosedTicketDays)
+	return &c
+}
@@ -0,0 +1,144 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// snippetWords is how many words of a document's body a snippet shows.
+const snippetWords = 30
+
+// searchDocumentKey identifies a document, like the search_documents
+// primary key.
+type searchDocumentKey struct {
+	kind domain.SearchDocumentKind
+	id   int64
+}
+
+// SearchIndex searches the documents in a Store by plain substring matching:
+// a document matches if each word of the text appears in its title or body,
+// ignoring case. Matches in the title rank above those in the body.
+type SearchIndex struct {
+	store *Store
+}
+
+var _ ports.SearchIndex = (*SearchIndex)(nil)
+
+// NewSearchIndex creates a new in-memory search index.
+func NewSearchIndex(store *Store) ports.SearchIndex {
+	return &SearchIndex{store: store}
+}
+
+// Index adds the documents, replacing any stored under the same key.
+func (i *SearchIndex) Index(ctx context.Context, docs []domain.SearchDocument) error {
+	s := i.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, doc := range docs {
+		s.searchDocuments[searchDocumentKey{kind: doc.Kind, id: doc.ID}] = doc
+	}
+	return nil
+}
+
+// DeleteTicket removes the ticket's documents.
+func (i *SearchIndex) DeleteTicket(ctx context.Context, orgID uuid.UUID, ticketID int64) error {
+	s := i.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, doc := range s.searchDocuments {
+		if doc.OrganizationID == orgID && doc.TicketID == ticketID {
+			delete(s.searchDocuments, key)
+		}
+	}
+	return nil
+}
+
+// Search returns the best matching documents.
+func (i *SearchIndex) Search(ctx context.Context, query domain.SearchQuery) ([]*domain.SearchHit, error) {
+	s := i.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	terms := strings.Fields(strings.ToLower(query.Text))
+	hits := make([]*domain.SearchHit, 0)
+	for _, doc := range s.searchDocuments {
+		if doc.OrganizationID != query.OrganizationID {
+			continue
+		}
+		if query.RequesterID != nil && doc.RequesterID != *query.RequesterID {
+			continue
+		}
+		if query.HideInternal && doc.Visibility == domain.VisibilityInternal {
+			continue
+		}
+		if score, ok := searchScore(doc, terms); ok {
+			hits = append(hits, &domain.SearchHit{
+				Kind:     doc.Kind,
+				ID:       doc.ID,
+				TicketID: doc.TicketID,
+				Title:    doc.Title,
+				Snippet:  searchSnippet(doc.Body, terms),
+				Score:    score,
+			})
+		}
+	}
+	sort.Slice(hits, func(a, b int) bool {
+		if hits[a].Score != hits[b].Score {
+			return hits[a].Score > hits[b].Score
+		}
+		if hits[a].TicketID != hits[b].TicketID {
+			return hits[a].TicketID > hits[b].TicketID
+		}
+		return hits[a].ID > hits[b].ID
+	})
+	return paginate(hits, query.Limit, 0), nil
+}
+
+// searchScore reports whether every term appears in the document and, if
+// so, scores it by where the terms appear.
+func searchScore(doc domain.SearchDocument, terms []string) (float64, bool) {
+	if len(terms) == 0 {
+		return 0, false
+	}
+	title, body := strings.ToLower(doc.Title), strings.ToLower(doc.Body)
+	score := 0.0
+	for _, term := range terms {
+		inTitle, inBody := strings.Contains(title, term), strings.Contains(body, term)
+		if !inTitle && !inBody {
+			return 0, false
+		}
+		if inTitle {
+			score += 1
+		}
+		if inBody {
+			score += 0.4
+		}
+	}
+	return score / float64(len(terms)), true
+}
+
+// searchSnippet returns the start of body with the words containing a term
+// wrapped in <em> tags.
+func searchSnippet(body string, terms []string) string {
+	words := strings.Fields(body)
+	if len(words) > snippetWords {
+		words = words[:snippetWords]
+	}
+	for i, word := range words {
+		lower := strings.ToLower(word)
+		for _, term := range terms {
+			if strings.Contains(lower, term) {
+				words[i] = "<em>" + word + "</em>"
+				break
+			}
+		}
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// searchQueueRow is a ticket waiting to be (re)indexed.
+type searchQueueRow struct {
+	job         ports.SearchIndexJob
+	availableAt time.Time
+}
+
+// SearchQueueRepository keeps the search indexing queue in a Store.
+type SearchQueueRepository struct {
+	store *Store
+}
+
+var _ ports.SearchQueueRepository = (*SearchQueueRepository)(nil)
+
+// NewSearchQueueRepository creates a new in-memory search queue repository.
+func NewSearchQueueRepository(store *Store) ports.SearchQueueRepository {
+	return &SearchQueueRepository{store: store}
+}
+
+// Enqueue queues a ticket for indexing, replacing any earlier entry.
+func (r *SearchQueueRepository) Enqueue(ctx context.Context, ticketID int64) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.enqueueForSearch(ticketID)
+	return nil
+}
+
+// Claim returns up to limit available tickets, oldest entry first, hiding
+// them from other claims for lease.
+func (r *SearchQueueRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]*ports.SearchIndexJob, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	available := make([]*searchQueueRow, 0)
+	for _, row := range s.searchQueue {
+		if !row.availableAt.After(now) {
+			available = append(available, row)
+		}
+	}
+	sort.Slice(available, func(i, j int) bool {
+		a, b := available[i].job, available[j].job
+		if !a.EnqueuedAt.Equal(b.EnqueuedAt) {
+			return a.EnqueuedAt.Before(b.EnqueuedAt)
+		}
+		return a.TicketID < b.TicketID
+	})
+
+	jobs := make([]*ports.SearchIndexJob, 0)
+	for _, row := range paginate(available, limit, 0) {
+		row.availableAt = now.Add(lease)
+		job := row.job
+		jobs = append(jobs, &job)
+	}
+	return jobs, nil
+}
+
+// Complete removes the job from the queue, unless the ticket was queued
+// again after it was claimed.
+func (r *SearchQueueRepository) Complete(ctx context.Context, job *ports.SearchIndexJob) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if row, ok := s.searchQueue[job.TicketID]; ok && row.job.EnqueuedAt.Equal(job.EnqueuedAt) {
+		delete(s.searchQueue, job.TicketID)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+)
+
+// SeedPassword is the password of every account created by Seed.
+const SeedPassword = "Password123!"
+
+// SeedAccount is an account created by Seed.
+type SeedAccount struct {
+	Email string
+	Role  string
+}
+
+// seedAccounts are the accounts Seed creates, one per role a frontend
+// developer is likely to need.
+var seedAccounts = []struct {
+	name  string
+	email string
+	role  string
+}{
+	{"Sam Superadmin", "superadmin@example.com", "superadmin"},
+	{"Ada Admin", "admin@example.com", "admin"},
+	{"Alex Agent", "agent@example.com", "agent"},
+	{"Casey Customer", "customer@example.com", "customer"},
+}
+
+// Seed fills the store with demo data for the organization: a super-admin,
+// an admin, an agent and a customer, all with SeedPassword, a support team
+// and a handful of tickets with comments. It returns the accounts it
+// created.
+func (s *Store) Seed(ctx context.Context, orgID uuid.UUID) ([]SeedAccount, error) {
+	hashed, err := domain.HashPassword(SeedPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if _, ok := s.organizations[orgID]; !ok {
+		s.organizations[orgID] = &organizationRow{name: DefaultOrganizationName, createdAt: time.Now().UTC()}
+	}
+	s.mu.Unlock()
+
+	userRepo := NewUserRepository(s)
+	authzRepo := NewAuthorizationRepository(s)
+	accounts := make([]SeedAccount, 0, len(seedAccounts))
+	userIDs := make(map[string]uuid.UUID, len(seedAccounts))
+	for _, account := range seedAccounts {
+		user, err := userRepo.Create(ctx, &domain.User{
+			OrganizationID: orgID,
+			FullName:       account.name,
+			Email:          account.email,
+			HashedPassword: hashed,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("seed user %s: %w", account.email, err)
+		}
+		if err := authzRepo.AssignRole(ctx, user.ID, account.role); err != nil {
+			return nil, fmt.Errorf("seed user %s: %w", account.email, err)
+		}
+		userIDs[account.role] = user.ID
+		accounts = append(accounts, SeedAccount{Email: account.email, Role: account.role})
+	}
+	agentID, customerID := userIDs["agent"], userIDs["customer"]
+
+	teamRepo := NewTeamRepository(s)
+	team, err := teamRepo.Create(ctx, &domain.Team{OrganizationID: orgID, Name: "Support"})
+	if err != nil {
+		return nil, fmt.Errorf("seed team: %w", err)
+	}
+	if err := teamRepo.AddMember(ctx, team.ID, agentID); err != nil {
+		return nil, fmt.Errorf("seed team: %w", err)
+	}
+
+	now := time.Now().UTC()
+	hoursAgo := func(h int) time.Time { return now.Add(-time.Duration(h) * time.Hour) }
+	closedAt := hoursAgo(20)
+	resolution := 4 * time.Hour
+	tickets := []*domain.Ticket{
+		{
+			Title:       "Cannot log in to the billing portal",
+			Description: "Since this morning the billing portal rejects my password.",
+			Status:      domain.StatusOpen,
+			Priority:    domain.PriorityHigh,
+			CreatedAt:   hoursAgo(2),
+		},
+		{
+			Title:       "Invoice shows the wrong address",
+			Description: "The March invoice still has our old office address.",
+			Status:      domain.StatusInProgress,
+			Priority:    domain.PriorityMedium,
+			AssigneeID:  &agentID,
+			CreatedAt:   hoursAgo(26),
+		},
+		{
+			Title:       "Request a second user licence",
+			Description: "We have a new team member who needs access.",
+			Status:      domain.StatusOpen,
+			Priority:    domain.PriorityLow,
+			TeamID:      &team.ID,
+			CreatedAt:   hoursAgo(50),
+		},
+		{
+			Title:          "Export of last year's tickets",
+			Description:    "Please send a CSV export of all tickets from last year.",
+			Status:         domain.StatusClosed,
+			Priority:       domain.PriorityMedium,
+			AssigneeID:     &agentID,
+			CreatedAt:      hoursAgo(72),
+			ClosedAt:       &closedAt,
+			ResolutionTime: &resolution,
+		},
+	}
+	// Seed text is plain, so its sanitized HTML is the text escaped
+	for _, ticket := range tickets {
+		ticket.OrganizationID = orgID
+		ticket.RequesterID = customerID
+		ticket.DescriptionHTML = html.EscapeString(ticket.Description)
+	}
+	if err := NewTicketRepository(s).CreateBatch(ctx, tickets); err != nil {
+		return nil, fmt.Errorf("seed tickets: %w", err)
+	}
+
+	comments := []*domain.Comment{
+		{TicketID: tickets[1].ID, AuthorID: agentID, Body: "Thanks for reporting this, I'm updating the address now.", CreatedAt: hoursAgo(25)},
+		{TicketID: tickets[1].ID, AuthorID: customerID, Body: "Great, could you resend the invoice once it's fixed?", CreatedAt: hoursAgo(24)},
+		{TicketID: tickets[3].ID, AuthorID: agentID, Body: "The export is attached. Let us know if anything is missing.", CreatedAt: hoursAgo(21)},
+	}
+	for _, comment := range comments {
+		comment.BodyHTML = html.EscapeString(comment.Body)
+	}
+	commentRepo := NewCommentRepository(s)
+	if err := commentRepo.CreateBatch(ctx, comments); err != nil {
+		return nil, fmt.Errorf("seed comments: %w", err)
+	}
+	for _, comment := range comments {
+		if comment.AuthorID == agentID {
+			if err := commentRepo.MarkFirstResponse(ctx, comment); err != nil {
+				return nil, fmt.Errorf("seed comments: %w", err)
+			}
+		}
+	}
+
+	return accounts, nil
+}
@@ -0,0 +1,269 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// Store holds the data of the in-memory repositories. Repositories created
+// on the same store see each other's changes, as they would through a
+// database, so the API can run without Postgres for local development.
+// Nothing is persisted: the data is gone when the process exits.
+type Store struct {
+	mu sync.RWMutex
+
+	sequences map[string]int64
+
+	organizations      map[uuid.UUID]*organizationRow
+	users              map[uuid.UUID]*userRow
+	tickets            map[int64]*ticketRow
+	comments           map[int64]*domain.Comment
+	events             map[int64]*domain.Event
+	outbox             map[int64]*outboxRow
+	webhooks           map[uuid.UUID]*domain.Webhook
+	deliveries         map[int64]*domain.WebhookDelivery
+	searchQueue        map[int64]*searchQueueRow
+	searchDocuments    map[searchDocumentKey]domain.SearchDocument
+	inboundHooks       map[uuid.UUID]*domain.InboundHook
+	teams              map[uuid.UUID]*domain.Team
+	escalationRules    map[uuid.UUID]*domain.EscalationRule
+	macros             map[uuid.UUID]*domain.Macro
+	rateLimitOverrides map[uuid.UUID]*domain.RateLimitOverride
+	auditLog           []*domain.AuditEntry
+	dataExports        map[uuid.UUID]*domain.DataExport
+	snoozes            map[int64]*domain.TicketSnooze
+	commentDrafts      map[commentDraftKey]*domain.CommentDraft
+	emailPreferences   map[uuid.UUID]*emailPreferencesRow
+	rollupThrough      *time.Time
+}
+
+// organizationRow is an organization and the settings stored with it.
+type organizationRow struct {
+	name                string
+	createdAt           time.Time
+	portalSlug          string
+	registrationDomains []string
+	businessHours       *domain.BusinessHours
+	retention           *domain.RetentionPolicy
+	quota               *domain.OrgQuota
+	agentCapacity       *domain.AgentCapacity
+}
+
+// userRow is a user with their roles and availability.
+type userRow struct {
+	user             domain.User
+	roles            []string
+	reassignWhenAway bool
+	outOfOffice      []domain.OutOfOfficeWindow
+}
+
+// ticketRow is a ticket with the state kept alongside it in the tickets
+// table: soft deletion, its first response and whether it was archived.
+type ticketRow struct {
+	ticket          domain.Ticket
+	deletedAt       *time.Time
+	firstResponseAt *time.Time
+	firstResponseBy *uuid.UUID
+	archivedAt      *time.Time
+}
+
+// DefaultOrganizationName names the organizations a store is created with,
+// as the initial migration names the default organization.
+const DefaultOrganizationName = "Default Organization"
+
+// NewStore creates an empty store holding the given organizations.
+func NewStore(orgIDs ...uuid.UUID) *Store {
+	s := &Store{
+		sequences:          make(map[string]int64),
+		organizations:      make(map[uuid.UUID]*organizationRow),
+		users:              make(map[uuid.UUID]*userRow),
+		tickets:            make(map[int64]*ticketRow),
+		comments:           make(map[int64]*domain.Comment),
+		events:             make(map[int64]*domain.Event),
+		outbox:             make(map[int64]*outboxRow),
+		webhooks:           make(map[uuid.UUID]*domain.Webhook),
+		deliveries:         make(map[int64]*domain.WebhookDelivery),
+		searchQueue:        make(map[int64]*searchQueueRow),
+		searchDocuments:    make(map[searchDocumentKey]domain.SearchDocument),
+		inboundHooks:       make(map[uuid.UUID]*domain.InboundHook),
+		teams:              make(map[uuid.UUID]*domain.Team),
+		escalationRules:    make(map[uuid.UUID]*domain.EscalationRule),
+		macros:             make(map[uuid.UUID]*domain.Macro),
+		rateLimitOverrides: make(map[uuid.UUID]*domain.RateLimitOverride),
+		dataExports:        make(map[uuid.UUID]*domain.DataExport),
+		snoozes:            make(map[int64]*domain.TicketSnooze),
+		commentDrafts:      make(map[commentDraftKey]*domain.CommentDraft),
+		emailPreferences:   make(map[uuid.UUID]*emailPreferencesRow),
+	}
+	for _, id := range orgIDs {
+		s.organizations[id] = &organizationRow{name: DefaultOrganizationName, createdAt: time.Now().UTC()}
+	}
+	return s
+}
+
+// Ping reports the store as reachable, for health checks.
+func (s *Store) Ping(context.Context) error {
+	return nil
+}
+
+// nextID returns the next value of the named sequence, starting at 1.
+// Callers must hold the write lock.
+func (s *Store) nextID(sequence string) int64 {
+	s.sequences[sequence]++
+	return s.sequences[sequence]
+}
+
+// liveTicket returns the ticket if it exists, is not archived and is not
+// soft-deleted. Callers must hold the lock.
+func (s *Store) liveTicket(id int64) (*ticketRow, bool) {
+	row, ok := s.tickets[id]
+	if !ok || row.archivedAt != nil || row.deletedAt != nil {
+		return nil, false
+	}
+	return row, true
+}
+
+// isArchived reports whether the ticket has been moved to the archive, with
+// its comments and events. Callers must hold the lock.
+func (s *Store) isArchived(ticketID int64) bool {
+	row, ok := s.tickets[ticketID]
+	return ok && row.archivedAt != nil
+}
+
+// enqueueForSearch queues tickets for indexing, like
+// SearchQueueRepository.Enqueue. Callers must hold the write lock.
+func (s *Store) enqueueForSearch(ticketIDs ...int64) {
+	now := time.Now().UTC()
+	for _, id := range ticketIDs {
+		row, ok := s.tickets[id]
+		if !ok {
+			continue
+		}
+		s.searchQueue[id] = &searchQueueRow{
+			job:         ports.SearchIndexJob{TicketID: id, OrganizationID: row.ticket.OrganizationID, EnqueuedAt: now},
+			availableAt: now,
+		}
+	}
+}
+
+// TransactionManager runs functions against a Store. The store has no
+// rollback: changes made before fn fails are kept. That is good enough for
+// local development, where failed transactions are rare and short-lived.
+type TransactionManager struct{}
+
+var _ ports.TransactionManager = TransactionManager{}
+
+// NewTransactionManager creates a transaction manager for the in-memory
+// repositories.
+func NewTransactionManager() ports.TransactionManager {
+	return TransactionManager{}
+}
+
+// WithTransaction runs fn.
+func (TransactionManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys[K int64 | string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// paginate returns the rows between offset and offset+limit.
+func paginate[T any](rows []T, limit, offset int) []T {
+	if offset >= len(rows) {
+		return rows[:0]
+	}
+	rows = rows[offset:]
+	if limit >= 0 && limit < len(rows) {
+		rows = rows[:limit]
+	}
+	return rows
+}
+
+// keysetPage returns the page of rows, already sorted in listing order,
+// that follows page.After. after reports whether a row comes after the
+// cursor in that order.
+func keysetPage[T any](rows []T, page domain.PageRequest, cursorOf func(T) domain.PageCursor, after func(T, domain.PageCursor) bool) domain.Page[T] {
+	if page.After != nil {
+		start := len(rows)
+		for i, row := range rows {
+			if after(row, *page.After) {
+				start = i
+				break
+			}
+		}
+		rows = rows[start:]
+	}
+
+	limit := page.PageLimit()
+	if len(rows) <= limit {
+		return domain.Page[T]{Items: rows}
+	}
+	rows = rows[:limit]
+	next := cursorOf(rows[limit-1])
+	return domain.Page[T]{Items: rows, NextCursor: &next}
+}
+
+// follows reports whether a row created at createdAt, whose ID compares
+// to the cursor's as cmp does, comes after the cursor: in a newest-first
+// listing unless ascending is set.
+func follows(createdAt time.Time, cmp int, cursor domain.PageCursor, ascending bool) bool {
+	if ascending {
+		if !createdAt.Equal(cursor.CreatedAt) {
+			return createdAt.After(cursor.CreatedAt)
+		}
+		return cmp > 0
+	}
+	if !createdAt.Equal(cursor.CreatedAt) {
+		return createdAt.Before(cursor.CreatedAt)
+	}
+	return cmp < 0
+}
+
+// compareInt64 compares id with a cursor ID holding an int64.
+func compareInt64(id int64, cursorID string) int {
+	other, err := strconv.ParseInt(cursorID, 10, 64)
+	if err != nil {
+		return 0
+	}
+	switch {
+	case id < other:
+		return -1
+	case id > other:
+		return 1
+	}
+	return 0
+}
+
+// compareUUID compares id with a cursor ID holding a UUID, in the byte
+// order Postgres sorts UUIDs in.
+func compareUUID(id uuid.UUID, cursorID string) int {
+	other, err := uuid.Parse(cursorID)
+	if err != nil {
+		return 0
+	}
+	return bytes.Compare(id[:], other[:])
+}
+
+// clonePtr returns a copy of the value p points at, or nil.
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
@@ -0,0 +1,173 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// TeamRepository keeps teams and their members in a Store.
+type TeamRepository struct {
+	store *Store
+}
+
+var _ ports.TeamRepository = (*TeamRepository)(nil)
+
+// NewTeamRepository creates a new in-memory team repository.
+func NewTeamRepository(store *Store) ports.TeamRepository {
+	return &TeamRepository{store: store}
+}
+
+// Create stores a new team without members.
+func (r *TeamRepository) Create(ctx context.Context, team *domain.Team) (*domain.Team, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.teamNameTaken(team.OrganizationID, team.Name, uuid.Nil) {
+		return nil, apperrors.ErrTeamExists
+	}
+
+	created := &domain.Team{
+		ID:             uuid.New(),
+		OrganizationID: team.OrganizationID,
+		Name:           team.Name,
+		MemberIDs:      []uuid.UUID{},
+		CreatedAt:      time.Now().UTC(),
+	}
+	s.teams[created.ID] = created
+	return cloneTeam(created), nil
+}
+
+// GetByID retrieves a team with its members.
+func (r *TeamRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Team, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	team, ok := s.teams[id]
+	if !ok {
+		return nil, apperrors.ErrTeamNotFound
+	}
+	return cloneTeam(team), nil
+}
+
+// ListByOrganization retrieves the organization's teams, ordered by name.
+func (r *TeamRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.Team, error) {
+	return r.list(func(team *domain.Team) bool { return team.OrganizationID == orgID }), nil
+}
+
+// ListByMember retrieves the teams the user belongs to, ordered by name.
+func (r *TeamRepository) ListByMember(ctx context.Context, userID uuid.UUID) ([]*domain.Team, error) {
+	return r.list(func(team *domain.Team) bool { return slices.Contains(team.MemberIDs, userID) }), nil
+}
+
+// Update renames a team.
+func (r *TeamRepository) Update(ctx context.Context, team *domain.Team) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.teams[team.ID]
+	if !ok {
+		return apperrors.ErrTeamNotFound
+	}
+	if s.teamNameTaken(existing.OrganizationID, team.Name, team.ID) {
+		return apperrors.ErrTeamExists
+	}
+	existing.Name = team.Name
+	return nil
+}
+
+// Delete removes a team, taking its tickets out of the team.
+func (r *TeamRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.teams[id]; !ok {
+		return apperrors.ErrTeamNotFound
+	}
+	delete(s.teams, id)
+	for _, row := range s.tickets {
+		if row.ticket.TeamID != nil && *row.ticket.TeamID == id {
+			row.ticket.TeamID = nil
+		}
+	}
+	return nil
+}
+
+// AddMember adds a user to a team. Adding an existing member does nothing.
+func (r *TeamRepository) AddMember(ctx context.Context, teamID, userID uuid.UUID) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	team, ok := s.teams[teamID]
+	if !ok {
+		return apperrors.ErrTeamNotFound
+	}
+	if _, ok := s.users[userID]; !ok {
+		return apperrors.ErrUserNotFound
+	}
+	if !slices.Contains(team.MemberIDs, userID) {
+		team.MemberIDs = append(team.MemberIDs, userID)
+	}
+	return nil
+}
+
+// RemoveMember removes a user from a team. It returns
+// apperrors.ErrUserNotFound if the user is not a member.
+func (r *TeamRepository) RemoveMember(ctx context.Context, teamID, userID uuid.UUID) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	team, ok := s.teams[teamID]
+	if !ok || !slices.Contains(team.MemberIDs, userID) {
+		return apperrors.ErrUserNotFound
+	}
+	team.MemberIDs = slices.DeleteFunc(team.MemberIDs, func(id uuid.UUID) bool { return id == userID })
+	return nil
+}
+
+// list returns the teams matching keep, ordered by name.
+func (r *TeamRepository) list(keep func(*domain.Team) bool) []*domain.Team {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	teams := make([]*domain.Team, 0)
+	for _, team := range s.teams {
+		if keep(team) {
+			teams = append(teams, cloneTeam(team))
+		}
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].Name < teams[j].Name })
+	return teams
+}
+
+// teamNameTaken reports whether a team of the organization other than
+// except is named name. Callers must hold the lock.
+func (s *Store) teamNameTaken(orgID uuid.UUID, name string, except uuid.UUID) bool {
+	for _, team := range s.teams {
+		if team.ID != except && team.OrganizationID == orgID && team.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// cloneTeam copies a team, sorting its member IDs as Postgres returns them.
+func cloneTeam(t *domain.Team) *domain.Team {
+	c := *t
+	c.MemberIDs = slices.Clone(t.MemberIDs)
+	slices.SortFunc(c.MemberIDs, func(a, b uuid.UUID) int { return compareUUID(a, b.String()) })
+	return &c
+}
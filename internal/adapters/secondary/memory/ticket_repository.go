@@ -0,0 +1,284 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// TicketRepository keeps tickets in a Store. Like its Postgres counterpart
+// it does not see tickets that are soft-deleted, unless listing with
+// IncludeDeleted, or archived.
+type TicketRepository struct {
+	store *Store
+}
+
+var _ ports.TicketRepository = (*TicketRepository)(nil)
+
+// NewTicketRepository creates a new in-memory ticket repository.
+func NewTicketRepository(store *Store) ports.TicketRepository {
+	return &TicketRepository{store: store}
+}
+
+// Create stores a new ticket.
+func (r *TicketRepository) Create(ctx context.Context, ticket *domain.Ticket) (*domain.Ticket, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	row := &ticketRow{ticket: domain.Ticket{
+		ID:              s.nextID("tickets"),
+		Title:           ticket.Title,
+		Description:     ticket.Description,
+		DescriptionHTML: ticket.DescriptionHTML,
+		Status:          ticket.Status,
+		Priority:        ticket.Priority,
+		RequesterID:     ticket.RequesterID,
+		OrganizationID:  ticket.OrganizationID,
+		CreatedAt:       time.Now().UTC(),
+		DueAt:           clonePtr(ticket.DueAt),
+		Tags:            []string{},
+		Visibility:      ticket.Visibility,
+	}}
+	s.tickets[row.ticket.ID] = row
+	return cloneTicket(&row.ticket), nil
+}
+
+// CreateBatch stores tickets, setting the ID of each, and queues them for
+// search indexing. Their timestamps are kept; a zero CreatedAt is set to
+// now.
+func (r *TicketRepository) CreateBatch(ctx context.Context, tickets []*domain.Ticket) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	ids := make([]int64, 0, len(tickets))
+	for _, ticket := range tickets {
+		ticket.ID = s.nextID("tickets")
+		if ticket.CreatedAt.IsZero() {
+			ticket.CreatedAt = now
+		}
+		if ticket.Tags == nil {
+			ticket.Tags = []string{}
+		}
+		if ticket.Visibility == "" {
+			ticket.Visibility = domain.VisibilityPublic
+		}
+		s.tickets[ticket.ID] = &ticketRow{ticket: *cloneTicket(ticket)}
+		ids = append(ids, ticket.ID)
+	}
+	s.enqueueForSearch(ids...)
+	return nil
+}
+
+// GetByID retrieves a ticket of the organization.
+func (r *TicketRepository) GetByID(ctx context.Context, orgID uuid.UUID, id int64) (*domain.Ticket, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	row, ok := s.liveTicket(id)
+	if !ok || row.ticket.OrganizationID != orgID {
+		return nil, apperrors.ErrTicketNotFound
+	}
+	return cloneTicket(&row.ticket), nil
+}
+
+// Update saves changes to a ticket of ticket.OrganizationID. UpdatedAt is
+// set to now if the ticket has none.
+func (r *TicketRepository) Update(ctx context.Context, ticket *domain.Ticket) (*domain.Ticket, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	row, ok := s.liveTicket(ticket.ID)
+	if !ok || row.ticket.OrganizationID != ticket.OrganizationID {
+		return nil, apperrors.ErrTicketNotFound
+	}
+
+	updated := cloneTicket(ticket)
+	updated.RequesterID = row.ticket.RequesterID
+	updated.Visibility = row.ticket.Visibility
+	updated.CreatedAt = row.ticket.CreatedAt
+	updated.ArchivedAt = nil
+	if updated.UpdatedAt == nil {
+		now := time.Now().UTC()
+		updated.UpdatedAt = &now
+	}
+	if updated.Tags == nil {
+		updated.Tags = []string{}
+	}
+	row.ticket = *updated
+	return cloneTicket(&row.ticket), nil
+}
+
+// ListPaginated lists the organization's tickets matching params, newest
+// first. Unlike ListPage it does not filter by requester.
+func (r *TicketRepository) ListPaginated(ctx context.Context, params ports.ListTicketsRepoParams) ([]*domain.Ticket, error) {
+	params.RequesterID.Valid = false
+	return r.list(params), nil
+}
+
+// ListByRequesterPaginated lists the tickets params.RequesterID requested.
+func (r *TicketRepository) ListByRequesterPaginated(ctx context.Context, params ports.ListTicketsRepoParams) ([]*domain.Ticket, error) {
+	if !params.RequesterID.Valid {
+		return []*domain.Ticket{}, nil
+	}
+	params.HideSnoozed = false
+	return r.list(params), nil
+}
+
+// ListByAssigneePaginated lists the tickets assigned to params.AssigneeID.
+func (r *TicketRepository) ListByAssigneePaginated(ctx context.Context, params ports.ListTicketsRepoParams) ([]*domain.Ticket, error) {
+	if !params.AssigneeID.Valid {
+		return []*domain.Ticket{}, nil
+	}
+	params.Unassigned.Valid = false
+	params.NeedsReassignment.Valid = false
+	return r.list(params), nil
+}
+
+// ListPage returns a keyset page of tickets matching params, newest first.
+func (r *TicketRepository) ListPage(ctx context.Context, params ports.ListTicketsRepoParams, page domain.PageRequest) (domain.Page[*domain.Ticket], error) {
+	params.Limit, params.Offset = -1, 0
+	tickets := r.list(params)
+
+	return keysetPage(tickets, page, func(t *domain.Ticket) domain.PageCursor {
+		return domain.PageCursor{CreatedAt: t.CreatedAt, ID: strconv.FormatInt(t.ID, 10)}
+	}, func(t *domain.Ticket, cursor domain.PageCursor) bool {
+		return follows(t.CreatedAt, compareInt64(t.ID, cursor.ID), cursor, false)
+	}), nil
+}
+
+// ListOpenByAssignee returns the organization's tickets assigned to the user
+// that are not closed, ordered by ID.
+func (r *TicketRepository) ListOpenByAssignee(ctx context.Context, orgID, assigneeID uuid.UUID) ([]*domain.Ticket, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tickets := make([]*domain.Ticket, 0)
+	for _, id := range sortedKeys(s.tickets) {
+		row, ok := s.liveTicket(id)
+		if !ok || row.ticket.OrganizationID != orgID || row.ticket.Status == domain.StatusClosed {
+			continue
+		}
+		if row.ticket.AssigneeID != nil && *row.ticket.AssigneeID == assigneeID {
+			tickets = append(tickets, cloneTicket(&row.ticket))
+		}
+	}
+	return tickets, nil
+}
+
+// Claim assigns a ticket to assigneeID if it is still unassigned and open.
+func (r *TicketRepository) Claim(ctx context.Context, orgID uuid.UUID, ticketID int64, assigneeID uuid.UUID, at time.Time) (*domain.Ticket, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	row, ok := s.liveTicket(ticketID)
+	if !ok || row.ticket.OrganizationID != orgID || row.ticket.AssigneeID != nil || row.ticket.Status == domain.StatusClosed {
+		return nil, apperrors.ErrTicketAlreadyClaimed
+	}
+	row.ticket.AssigneeID = &assigneeID
+	row.ticket.UpdatedAt = &at
+	return cloneTicket(&row.ticket), nil
+}
+
+// list returns the tickets matching params, newest first, paged by the
+// Limit and Offset of params; a negative Limit returns them all.
+func (r *TicketRepository) list(params ports.ListTicketsRepoParams) []*domain.Ticket {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	tickets := make([]*domain.Ticket, 0)
+	for _, row := range s.tickets {
+		if row.archivedAt != nil || (row.deletedAt != nil && !params.IncludeDeleted) {
+			continue
+		}
+		if s.ticketMatches(&row.ticket, params, now) {
+			tickets = append(tickets, cloneTicket(&row.ticket))
+		}
+	}
+	sort.Slice(tickets, func(i, j int) bool {
+		if !tickets[i].CreatedAt.Equal(tickets[j].CreatedAt) {
+			return tickets[i].CreatedAt.After(tickets[j].CreatedAt)
+		}
+		return tickets[i].ID > tickets[j].ID
+	})
+	return paginate(tickets, int(params.Limit), int(params.Offset))
+}
+
+// ticketMatches reports whether t matches the filters of params. Callers
+// must hold the lock.
+func (s *Store) ticketMatches(t *domain.Ticket, params ports.ListTicketsRepoParams, now time.Time) bool {
+	if t.OrganizationID != uuid.UUID(params.OrganizationID.Bytes) {
+		return false
+	}
+	if params.Status.Valid && string(t.Status) != params.Status.String {
+		return false
+	}
+	if params.Priority.Valid && string(t.Priority) != params.Priority.String {
+		return false
+	}
+	if params.RequesterID.Valid && t.RequesterID != uuid.UUID(params.RequesterID.Bytes) {
+		return false
+	}
+	if params.Unassigned.Valid && params.Unassigned.Bool {
+		if t.AssigneeID != nil {
+			return false
+		}
+	} else if params.AssigneeID.Valid && (t.AssigneeID == nil || *t.AssigneeID != uuid.UUID(params.AssigneeID.Bytes)) {
+		return false
+	}
+	if params.CreatedFrom.Valid && t.CreatedAt.Before(params.CreatedFrom.Time) {
+		return false
+	}
+	if params.CreatedTo.Valid && !t.CreatedAt.Before(params.CreatedTo.Time) {
+		return false
+	}
+	if params.NeedsReassignment.Valid && params.NeedsReassignment.Bool {
+		if t.Status == domain.StatusClosed || t.AssigneeID == nil {
+			return false
+		}
+		assignee, ok := s.users[*t.AssigneeID]
+		if !ok || !assignee.reassignWhenAway || !assignee.isAway(now) {
+			return false
+		}
+	}
+	if params.TeamID.Valid && (t.TeamID == nil || *t.TeamID != uuid.UUID(params.TeamID.Bytes)) {
+		return false
+	}
+	if params.HideInternal && t.IsInternal() {
+		return false
+	}
+	if params.HideSnoozed {
+		if snooze, ok := s.snoozes[t.ID]; ok && snooze.IsActive(now) {
+			return false
+		}
+	}
+	return true
+}
+
+func cloneTicket(t *domain.Ticket) *domain.Ticket {
+	c := *t
+	c.AssigneeID = clonePtr(t.AssigneeID)
+	c.TeamID = clonePtr(t.TeamID)
+	c.UpdatedAt = clonePtr(t.UpdatedAt)
+	c.ClosedAt = clonePtr(t.ClosedAt)
+	c.DueAt = clonePtr(t.DueAt)
+	c.ResolutionTime = clonePtr(t.ResolutionTime)
+	c.ArchivedAt = clonePtr(t.ArchivedAt)
+	c.Tags = slices.Clone(t.Tags)
+	return &c
+}
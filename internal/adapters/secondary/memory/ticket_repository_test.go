@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testOrgID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+func TestStore_Seed(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+
+	accounts, err := store.Seed(ctx, testOrgID)
+	require.NoError(t, err)
+	require.Len(t, accounts, 4)
+
+	admin, err := NewUserRepository(store).GetByEmail(ctx, testOrgID, "admin@example.com")
+	require.NoError(t, err)
+	assert.True(t, admin.CheckPassword(SeedPassword))
+
+	permissions, err := NewAuthorizationRepository(store).GetUserPermissions(ctx, admin.ID)
+	require.NoError(t, err)
+	assert.Contains(t, permissions, "admin:access")
+	assert.NotContains(t, permissions, "platform:access")
+
+	tickets, err := NewTicketRepository(store).ListPaginated(ctx, ports.ListTicketsRepoParams{
+		OrganizationID: pgtype.UUID{Bytes: testOrgID, Valid: true},
+		Limit:          10,
+	})
+	require.NoError(t, err)
+	assert.Len(t, tickets, 4)
+
+	jobs, err := NewSearchQueueRepository(store).Claim(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Len(t, jobs, 4, "seeded tickets are queued for search indexing")
+}
+
+func TestTicketRepository_ListPage(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore(testOrgID)
+	repo := NewTicketRepository(store)
+	requesterID := uuid.New()
+
+	base := time.Now().UTC().Add(-time.Hour)
+	tickets := make([]*domain.Ticket, 5)
+	for i := range tickets {
+		tickets[i] = &domain.Ticket{
+			Title:          "Ticket",
+			Status:         domain.StatusOpen,
+			Priority:       domain.PriorityLow,
+			RequesterID:    requesterID,
+			OrganizationID: testOrgID,
+			CreatedAt:      base.Add(time.Duration(i) * time.Minute),
+		}
+	}
+	tickets[4].Priority = domain.PriorityHigh
+	require.NoError(t, repo.CreateBatch(ctx, tickets))
+
+	params := ports.ListTicketsRepoParams{OrganizationID: pgtype.UUID{Bytes: testOrgID, Valid: true}}
+	first, err := repo.ListPage(ctx, params, domain.PageRequest{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, first.Items, 2)
+	require.NotNil(t, first.NextCursor)
+	assert.Equal(t, []int64{tickets[4].ID, tickets[3].ID}, ticketIDs(first.Items), "newest first")
+
+	second, err := repo.ListPage(ctx, params, domain.PageRequest{Limit: 2, After: first.NextCursor})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{tickets[2].ID, tickets[1].ID}, ticketIDs(second.Items))
+
+	params.Priority = pgtype.Text{String: string(domain.PriorityHigh), Valid: true}
+	high, err := repo.ListPage(ctx, params, domain.PageRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{tickets[4].ID}, ticketIDs(high.Items))
+	assert.Nil(t, high.NextCursor)
+}
+
+func TestTicketRepository_Archived(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore(testOrgID)
+	repo := NewTicketRepository(store)
+	archive := NewTicketArchiveRepository(store)
+
+	closedAt := time.Now().UTC().Add(-48 * time.Hour)
+	ticket := &domain.Ticket{
+		Title:          "Old ticket",
+		Status:         domain.StatusClosed,
+		Priority:       domain.PriorityLow,
+		RequesterID:    uuid.New(),
+		OrganizationID: testOrgID,
+		ClosedAt:       &closedAt,
+	}
+	require.NoError(t, repo.CreateBatch(ctx, []*domain.Ticket{ticket}))
+	_, err := NewCommentRepository(store).Create(ctx, &domain.Comment{TicketID: ticket.ID, AuthorID: ticket.RequesterID, Body: "Thanks"})
+	require.NoError(t, err)
+
+	archived, err := archive.ArchiveClosedBefore(ctx, time.Now().UTC().Add(-24*time.Hour), 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), archived)
+
+	_, err = repo.GetByID(ctx, testOrgID, ticket.ID)
+	assert.ErrorIs(t, err, apperrors.ErrTicketNotFound, "archived tickets leave the hot repository")
+
+	comments, err := NewCommentRepository(store).ListByTicketID(ctx, ticket.ID)
+	require.NoError(t, err)
+	assert.Empty(t, comments)
+
+	got, err := archive.GetTicket(ctx, testOrgID, ticket.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, got.ArchivedAt)
+
+	comments, err = archive.ListComments(ctx, ticket.ID)
+	require.NoError(t, err)
+	assert.Len(t, comments, 1)
+}
+
+func ticketIDs(tickets []*domain.Ticket) []int64 {
+	ids := make([]int64, 0, len(tickets))
+	for _, t := range tickets {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
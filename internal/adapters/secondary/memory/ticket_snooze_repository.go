@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// TicketSnoozeRepository keeps ticket snoozes in a Store.
+type TicketSnoozeRepository struct {
+	store *Store
+}
+
+var _ ports.TicketSnoozeRepository = (*TicketSnoozeRepository)(nil)
+
+// NewTicketSnoozeRepository creates a new in-memory ticket snooze repository.
+func NewTicketSnoozeRepository(store *Store) ports.TicketSnoozeRepository {
+	return &TicketSnoozeRepository{store: store}
+}
+
+// Upsert stores a snooze, replacing the ticket's existing one.
+func (r *TicketSnoozeRepository) Upsert(ctx context.Context, snooze *domain.TicketSnooze) (*domain.TicketSnooze, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := *snooze
+	s.snoozes[snooze.TicketID] = &stored
+	c := stored
+	return &c, nil
+}
+
+// GetByTicketID retrieves a ticket's snooze.
+func (r *TicketSnoozeRepository) GetByTicketID(ctx context.Context, ticketID int64) (*domain.TicketSnooze, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snooze, ok := s.snoozes[ticketID]
+	if !ok {
+		return nil, apperrors.ErrTicketNotSnoozed
+	}
+	c := *snooze
+	return &c, nil
+}
+
+// Delete removes a ticket's snooze.
+func (r *TicketSnoozeRepository) Delete(ctx context.Context, ticketID int64) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.snoozes[ticketID]; !ok {
+		return apperrors.ErrTicketNotSnoozed
+	}
+	delete(s.snoozes, ticketID)
+	return nil
+}
+
+// TakeDue removes up to limit snoozes that ended by now, earliest first,
+// and returns them.
+func (r *TicketSnoozeRepository) TakeDue(ctx context.Context, now time.Time, limit int) ([]*domain.TicketSnooze, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	due := make([]*domain.TicketSnooze, 0)
+	for _, snooze := range s.snoozes {
+		if !snooze.IsActive(now) {
+			due = append(due, snooze)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].Until.Equal(due[j].Until) {
+			return due[i].Until.Before(due[j].Until)
+		}
+		return due[i].TicketID < due[j].TicketID
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	for _, snooze := range due {
+		delete(s.snoozes, snooze.TicketID)
+	}
+	return due, nil
+}
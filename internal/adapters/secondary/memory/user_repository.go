@@ -0,0 +1,408 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// UserRepository keeps users in a Store.
+type UserRepository struct {
+	store *Store
+}
+
+var _ ports.UserRepository = (*UserRepository)(nil)
+
+// NewUserRepository creates a new in-memory user repository.
+func NewUserRepository(store *Store) ports.UserRepository {
+	return &UserRepository{store: store}
+}
+
+// Create stores a new user, returning apperrors.ErrUserExists if another
+// user has the email.
+func (r *UserRepository) Create(ctx context.Context, user *domain.User) (*domain.User, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.userByEmail(user.OrganizationID, user.Email) != nil {
+		return nil, apperrors.ErrUserExists
+	}
+
+	row := &userRow{user: domain.User{
+		ID:             uuid.New(),
+		OrganizationID: user.OrganizationID,
+		FullName:       user.FullName,
+		Email:          user.Email,
+		HashedPassword: user.HashedPassword,
+		CreatedAt:      time.Now().UTC(),
+		IsActive:       true,
+		Locale:         "en",
+		Timezone:       "UTC",
+	}}
+	s.users[row.user.ID] = row
+	return cloneUser(&row.user), nil
+}
+
+// GetByEmail retrieves the user of an organization by email address.
+func (r *UserRepository) GetByEmail(ctx context.Context, orgID uuid.UUID, email string) (*domain.User, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	row := s.userByEmail(orgID, email)
+	if row == nil {
+		return nil, apperrors.ErrUserNotFound
+	}
+	return cloneUser(&row.user), nil
+}
+
+// ListByEmail retrieves the users with an email address in every
+// organization, oldest first.
+func (r *UserRepository) ListByEmail(ctx context.Context, email string) ([]*domain.User, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	users := make([]*domain.User, 0)
+	for _, row := range s.users {
+		if row.user.Email == email {
+			users = append(users, cloneUser(&row.user))
+		}
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if !users[i].CreatedAt.Equal(users[j].CreatedAt) {
+			return users[i].CreatedAt.Before(users[j].CreatedAt)
+		}
+		return users[i].ID.String() < users[j].ID.String()
+	})
+	return users, nil
+}
+
+// GetByID retrieves a user by ID.
+func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	row, ok := s.users[id]
+	if !ok {
+		return nil, apperrors.ErrUserNotFound
+	}
+	return cloneUser(&row.user), nil
+}
+
+// CountUsers counts the users of every organization.
+func (r *UserRepository) CountUsers(ctx context.Context) (int64, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return int64(len(s.users)), nil
+}
+
+// ListAssignableUsers returns the organization's active admins and agents
+// that match the filter's query and team, leaving out those who are
+// currently out of office.
+func (r *UserRepository) ListAssignableUsers(ctx context.Context, orgID uuid.UUID, filter domain.AssigneeFilter) ([]*domain.User, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var members []uuid.UUID
+	if filter.TeamID != nil {
+		if team, ok := s.teams[*filter.TeamID]; ok {
+			members = team.MemberIDs
+		}
+	}
+
+	now := time.Now()
+	query := strings.ToLower(filter.Query)
+	users := make([]*domain.User, 0)
+	for _, row := range s.users {
+		u := row.user
+		if u.OrganizationID != orgID || !u.IsActive || row.isAway(now) {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(u.FullName), query) && !strings.Contains(strings.ToLower(u.Email), query) {
+			continue
+		}
+		if filter.TeamID != nil && !slices.Contains(members, u.ID) {
+			continue
+		}
+		if slices.Contains(row.roles, "admin") || slices.Contains(row.roles, "agent") {
+			users = append(users, cloneUser(&row.user))
+		}
+	}
+	sortUsersByName(users)
+	return users, nil
+}
+
+// SearchByOrganization returns a page of the organization's users ordered by
+// name, along with how many users match the filter in total.
+func (r *UserRepository) SearchByOrganization(ctx context.Context, orgID uuid.UUID, filter domain.UserFilter) ([]*domain.UserSummary, domain.Total, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	query := strings.ToLower(filter.Query)
+	matches := make([]*userRow, 0)
+	for _, row := range s.users {
+		u := row.user
+		if u.OrganizationID != orgID {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(u.FullName), query) && !strings.Contains(strings.ToLower(u.Email), query) {
+			continue
+		}
+		if filter.Role != nil && !slices.Contains(row.roles, *filter.Role) {
+			continue
+		}
+		if filter.IsActive != nil && u.IsActive != *filter.IsActive {
+			continue
+		}
+		matches = append(matches, row)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return userLess(&matches[i].user, &matches[j].user)
+	})
+
+	total := domain.Total{Count: int64(len(matches))}
+	summaries := make([]*domain.UserSummary, 0)
+	for _, row := range paginate(matches, filter.Limit, filter.Offset) {
+		roles := slices.Clone(row.roles)
+		if roles == nil {
+			roles = []string{}
+		}
+		sort.Strings(roles)
+		summaries = append(summaries, &domain.UserSummary{
+			ID:             row.user.ID,
+			OrganizationID: row.user.OrganizationID,
+			FullName:       row.user.FullName,
+			Email:          row.user.Email,
+			Roles:          roles,
+			IsActive:       row.user.IsActive,
+			CreatedAt:      row.user.CreatedAt,
+			LastActiveAt:   clonePtr(row.user.LastActiveAt),
+		})
+	}
+	return summaries, total, nil
+}
+
+// ListPageByOrganization retrieves a keyset page of the organization's
+// users, newest first.
+func (r *UserRepository) ListPageByOrganization(ctx context.Context, orgID uuid.UUID, page domain.PageRequest) (domain.Page[*domain.User], error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	users := make([]*domain.User, 0)
+	for _, row := range s.users {
+		if row.user.OrganizationID == orgID {
+			users = append(users, cloneUser(&row.user))
+		}
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if !users[i].CreatedAt.Equal(users[j].CreatedAt) {
+			return users[i].CreatedAt.After(users[j].CreatedAt)
+		}
+		return bytes.Compare(users[i].ID[:], users[j].ID[:]) > 0
+	})
+
+	return keysetPage(users, page, func(u *domain.User) domain.PageCursor {
+		return domain.PageCursor{CreatedAt: u.CreatedAt, ID: u.ID.String()}
+	}, func(u *domain.User, cursor domain.PageCursor) bool {
+		return follows(u.CreatedAt, compareUUID(u.ID, cursor.ID), cursor, false)
+	}), nil
+}
+
+func (r *UserRepository) SetActive(ctx context.Context, userID uuid.UUID, isActive bool) error {
+	return r.update(userID, func(row *userRow) { row.user.IsActive = isActive })
+}
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, userID uuid.UUID, hashedPassword string) error {
+	return r.update(userID, func(row *userRow) { row.user.HashedPassword = hashedPassword })
+}
+
+func (r *UserRepository) UpdateLastActive(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	at = at.UTC()
+	return r.update(userID, func(row *userRow) { row.user.LastActiveAt = &at })
+}
+
+// UpdateLastActiveBatch records when several users were last active.
+func (r *UserRepository) UpdateLastActiveBatch(ctx context.Context, lastActive map[uuid.UUID]time.Time) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for userID, at := range lastActive {
+		row, ok := s.users[userID]
+		if !ok {
+			continue
+		}
+		at = at.UTC()
+		if row.user.LastActiveAt == nil || at.After(*row.user.LastActiveAt) {
+			row.user.LastActiveAt = &at
+		}
+	}
+	return nil
+}
+
+func (r *UserRepository) RevokeTokens(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	at = at.UTC()
+	return r.update(userID, func(row *userRow) { row.user.TokensRevokedAt = &at })
+}
+
+func (r *UserRepository) UpdateSMSPreferences(ctx context.Context, userID uuid.UUID, prefs domain.SMSPreferences) error {
+	return r.update(userID, func(row *userRow) {
+		row.user.PhoneNumber = prefs.PhoneNumber
+		row.user.SMSOptIn = prefs.OptIn
+	})
+}
+
+func (r *UserRepository) UpdateLocale(ctx context.Context, userID uuid.UUID, locale string) error {
+	return r.update(userID, func(row *userRow) { row.user.Locale = locale })
+}
+
+// UpdateProfile saves the user's name, phone number, SMS opt-in, locale and
+// timezone.
+func (r *UserRepository) UpdateProfile(ctx context.Context, user *domain.User) error {
+	return r.update(user.ID, func(row *userRow) {
+		row.user.FullName = user.FullName
+		row.user.PhoneNumber = user.PhoneNumber
+		row.user.SMSOptIn = user.SMSOptIn
+		row.user.Locale = user.Locale
+		row.user.Timezone = user.Timezone
+	})
+}
+
+// UpdateEmail changes the user's login email. It returns
+// apperrors.ErrUserExists if another user of the organization has the
+// address.
+func (r *UserRepository) UpdateEmail(ctx context.Context, userID uuid.UUID, email string) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	row, ok := s.users[userID]
+	if !ok {
+		return apperrors.ErrUserNotFound
+	}
+	if other := s.userByEmail(row.user.OrganizationID, email); other != nil && other.user.ID != userID {
+		return apperrors.ErrUserExists
+	}
+	row.user.Email = email
+	return nil
+}
+
+// GetAvailability returns the user's out-of-office windows that have not
+// ended yet.
+func (r *UserRepository) GetAvailability(ctx context.Context, userID uuid.UUID) (*domain.Availability, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	row, ok := s.users[userID]
+	if !ok {
+		return nil, apperrors.ErrUserNotFound
+	}
+
+	now := time.Now()
+	availability := &domain.Availability{UserID: userID, Windows: []domain.OutOfOfficeWindow{}, ReassignWhenAway: row.reassignWhenAway}
+	for _, w := range row.outOfOffice {
+		if w.EndsAt.After(now) {
+			availability.Windows = append(availability.Windows, w)
+		}
+	}
+	sort.Slice(availability.Windows, func(i, j int) bool {
+		return availability.Windows[i].StartsAt.Before(availability.Windows[j].StartsAt)
+	})
+	return availability, nil
+}
+
+// SaveAvailability replaces the user's out-of-office windows.
+func (r *UserRepository) SaveAvailability(ctx context.Context, availability *domain.Availability) error {
+	return r.update(availability.UserID, func(row *userRow) {
+		row.reassignWhenAway = availability.ReassignWhenAway
+		row.outOfOffice = slices.Clone(availability.Windows)
+	})
+}
+
+// Anonymize strips a user's personal data while keeping the user, so the
+// tickets and comments that reference it stay intact. The account is
+// deactivated and every outstanding token is revoked.
+func (r *UserRepository) Anonymize(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	at = at.UTC()
+	return r.update(userID, func(row *userRow) {
+		row.user.FullName = domain.AnonymizedUserName
+		row.user.Email = domain.AnonymizedEmail(userID)
+		row.user.HashedPassword = ""
+		row.user.PhoneNumber = ""
+		row.user.SMSOptIn = false
+		row.user.IsActive = false
+		row.user.TokensRevokedAt = &at
+	})
+}
+
+// update applies fn to the user, returning apperrors.ErrUserNotFound if
+// there is no such user.
+func (r *UserRepository) update(userID uuid.UUID, fn func(*userRow)) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	row, ok := s.users[userID]
+	if !ok {
+		return apperrors.ErrUserNotFound
+	}
+	fn(row)
+	return nil
+}
+
+// userByEmail returns the organization's user with the email, or nil.
+// Callers must hold the lock.
+func (s *Store) userByEmail(orgID uuid.UUID, email string) *userRow {
+	for _, row := range s.users {
+		if row.user.OrganizationID == orgID && row.user.Email == email {
+			return row
+		}
+	}
+	return nil
+}
+
+// isAway reports whether the user is out of office at t.
+func (row *userRow) isAway(t time.Time) bool {
+	for _, w := range row.outOfOffice {
+		if !w.StartsAt.After(t) && w.EndsAt.After(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// userLess orders users by name and then email.
+func userLess(a, b *domain.User) bool {
+	if a.FullName != b.FullName {
+		return a.FullName < b.FullName
+	}
+	return a.Email < b.Email
+}
+
+func sortUsersByName(users []*domain.User) {
+	sort.Slice(users, func(i, j int) bool { return userLess(users[i], users[j]) })
+}
+
+func cloneUser(u *domain.User) *domain.User {
+	c := *u
+	c.LastActiveAt = clonePtr(u.LastActiveAt)
+	c.TokensRevokedAt = clonePtr(u.TokensRevokedAt)
+	return &c
+}
@@ -0,0 +1,271 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// WebhookRepository keeps webhooks and their delivery log in a Store.
+type WebhookRepository struct {
+	store *Store
+}
+
+var _ ports.WebhookRepository = (*WebhookRepository)(nil)
+
+// NewWebhookRepository creates a new in-memory webhook repository.
+func NewWebhookRepository(store *Store) ports.WebhookRepository {
+	return &WebhookRepository{store: store}
+}
+
+// Create stores a new webhook.
+func (r *WebhookRepository) Create(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	created := cloneWebhook(webhook)
+	created.ID = uuid.New()
+	created.CreatedAt = time.Now().UTC()
+	if created.EventTypes == nil {
+		created.EventTypes = []domain.WebhookEventType{}
+	}
+	s.webhooks[created.ID] = created
+	return cloneWebhook(created), nil
+}
+
+// GetByID retrieves a webhook by its ID.
+func (r *WebhookRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Webhook, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	webhook, ok := s.webhooks[id]
+	if !ok {
+		return nil, apperrors.ErrWebhookNotFound
+	}
+	return cloneWebhook(webhook), nil
+}
+
+// ListByOrganization retrieves all webhooks registered by an organization,
+// oldest first.
+func (r *WebhookRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.Webhook, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	webhooks := make([]*domain.Webhook, 0)
+	for _, webhook := range s.webhooks {
+		if webhook.OrganizationID == orgID {
+			webhooks = append(webhooks, cloneWebhook(webhook))
+		}
+	}
+	sort.Slice(webhooks, func(i, j int) bool {
+		return createdBefore(webhooks[i].CreatedAt, webhooks[i].ID, webhooks[j].CreatedAt, webhooks[j].ID)
+	})
+	return webhooks, nil
+}
+
+// Delete removes a webhook along with its delivery log.
+func (r *WebhookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.webhooks[id]; !ok {
+		return apperrors.ErrWebhookNotFound
+	}
+	delete(s.webhooks, id)
+	for deliveryID, delivery := range s.deliveries {
+		if delivery.WebhookID == id {
+			delete(s.deliveries, deliveryID)
+		}
+	}
+	return nil
+}
+
+// EnqueueForTicket queues a delivery to every active webhook in the ticket's
+// organization that subscribes to eventType.
+func (r *WebhookRepository) EnqueueForTicket(ctx context.Context, ticketID int64, eventType domain.WebhookEventType, payload []byte) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	row, ok := s.tickets[ticketID]
+	if !ok || row.deletedAt != nil {
+		return nil
+	}
+	for _, webhook := range s.webhooks {
+		if webhook.OrganizationID == row.ticket.OrganizationID && webhook.IsActive && slices.Contains(webhook.EventTypes, eventType) {
+			s.createDelivery(&domain.WebhookDelivery{
+				WebhookID:     webhook.ID,
+				EventType:     eventType,
+				Payload:       payload,
+				Status:        domain.WebhookDeliveryPending,
+				NextAttemptAt: time.Now().UTC(),
+			})
+		}
+	}
+	return nil
+}
+
+// CreateDelivery stores a single delivery, such as a test event.
+func (r *WebhookRepository) CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) (*domain.WebhookDelivery, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.webhooks[delivery.WebhookID]; !ok {
+		return nil, apperrors.ErrWebhookNotFound
+	}
+	return cloneWebhookDelivery(s.createDelivery(delivery)), nil
+}
+
+// ListDeliveries retrieves the most recent deliveries for a webhook, newest
+// first.
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]*domain.WebhookDelivery, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	deliveries := make([]*domain.WebhookDelivery, 0)
+	for _, delivery := range s.deliveries {
+		if delivery.WebhookID == webhookID {
+			deliveries = append(deliveries, cloneWebhookDelivery(delivery))
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ID > deliveries[j].ID })
+	return paginate(deliveries, limit, 0), nil
+}
+
+// ClaimDueDeliveries returns up to limit pending deliveries that are due,
+// pushing their next attempt out by lease.
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*ports.WebhookDeliveryJob, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	due := make([]*domain.WebhookDelivery, 0)
+	for _, delivery := range s.deliveries {
+		if delivery.Status == domain.WebhookDeliveryPending && !delivery.NextAttemptAt.After(now) {
+			due = append(due, delivery)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].NextAttemptAt.Equal(due[j].NextAttemptAt) {
+			return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+		}
+		return due[i].ID < due[j].ID
+	})
+
+	jobs := make([]*ports.WebhookDeliveryJob, 0)
+	for _, delivery := range paginate(due, limit, 0) {
+		webhook := s.webhooks[delivery.WebhookID]
+		delivery.NextAttemptAt = now.Add(lease)
+		jobs = append(jobs, &ports.WebhookDeliveryJob{
+			DeliveryID: delivery.ID,
+			WebhookID:  webhook.ID,
+			URL:        webhook.URL,
+			Secret:     webhook.Secret,
+			EventType:  delivery.EventType,
+			Payload:    slices.Clone(delivery.Payload),
+			Attempts:   delivery.Attempts,
+		})
+	}
+	return jobs, nil
+}
+
+// MarkDelivered records a successful delivery.
+func (r *WebhookRepository) MarkDelivered(ctx context.Context, id int64, responseStatus int) error {
+	r.update(id, responseStatus, func(d *domain.WebhookDelivery) {
+		now := time.Now().UTC()
+		d.Status = domain.WebhookDeliverySucceeded
+		d.LastError = nil
+		d.DeliveredAt = &now
+	})
+	return nil
+}
+
+// MarkRetry records a failed delivery and schedules the next attempt.
+func (r *WebhookRepository) MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, responseStatus int, lastErr string) error {
+	r.update(id, responseStatus, func(d *domain.WebhookDelivery) {
+		d.NextAttemptAt = nextAttemptAt.UTC()
+		d.LastError = &lastErr
+	})
+	return nil
+}
+
+// MarkDead moves a delivery to the dead-letter state after its final failed
+// attempt.
+func (r *WebhookRepository) MarkDead(ctx context.Context, id int64, responseStatus int, lastErr string) error {
+	r.update(id, responseStatus, func(d *domain.WebhookDelivery) {
+		d.Status = domain.WebhookDeliveryDead
+		d.LastError = &lastErr
+	})
+	return nil
+}
+
+// update counts an attempt at the delivery, records its response status,
+// if there was a response, and applies fn to it. Unknown deliveries are
+// ignored.
+func (r *WebhookRepository) update(id int64, responseStatus int, fn func(*domain.WebhookDelivery)) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delivery, ok := s.deliveries[id]
+	if !ok {
+		return
+	}
+	delivery.Attempts++
+	delivery.ResponseStatus = nil
+	if responseStatus != 0 {
+		delivery.ResponseStatus = &responseStatus
+	}
+	fn(delivery)
+}
+
+// createDelivery stores a new delivery. Callers must hold the write lock.
+func (s *Store) createDelivery(delivery *domain.WebhookDelivery) *domain.WebhookDelivery {
+	created := &domain.WebhookDelivery{
+		ID:            s.nextID("webhook_deliveries"),
+		WebhookID:     delivery.WebhookID,
+		EventType:     delivery.EventType,
+		Payload:       slices.Clone(delivery.Payload),
+		Status:        delivery.Status,
+		NextAttemptAt: delivery.NextAttemptAt.UTC(),
+		CreatedAt:     time.Now().UTC(),
+	}
+	s.deliveries[created.ID] = created
+	return created
+}
+
+// createdBefore orders rows by creation time and then ID.
+func createdBefore(aCreatedAt time.Time, aID uuid.UUID, bCreatedAt time.Time, bID uuid.UUID) bool {
+	if !aCreatedAt.Equal(bCreatedAt) {
+		return aCreatedAt.Before(bCreatedAt)
+	}
+	return compareUUID(aID, bID.String()) < 0
+}
+
+func cloneWebhook(w *domain.Webhook) *domain.Webhook {
+	c := *w
+	c.EventTypes = slices.Clone(w.EventTypes)
+	return &c
+}
+
+func cloneWebhookDelivery(d *domain.WebhookDelivery) *domain.WebhookDelivery {
+	c := *d
+	c.Payload = slices.Clone(d.Payload)
+	c.ResponseStatus = clonePtr(d.ResponseStatus)
+	c.LastError = clonePtr(d.LastError)
+	c.DeliveredAt = clonePtr(d.DeliveredAt)
+	return &c
+}
@@ -0,0 +1,316 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// maxResponseSize caps how much of a response is read
+const maxResponseSize = 10 << 20
+
+// Config holds the OpenSearch connection settings.
+type Config struct {
+	URL      string // Cluster address, such as https://search.internal:9200
+	Index    string // Name of the index holding ticket and comment documents
+	Username string // Optional basic auth credentials
+	Password string
+	Timeout  time.Duration
+}
+
+// Index is a secondary adapter that stores tickets and comments in an
+// OpenSearch (or Elasticsearch) index, for installations too large to search
+// in Postgres. It implements the ports.SearchIndex interface.
+type Index struct {
+	cfg    Config
+	client *http.Client
+}
+
+var _ ports.SearchIndex = (*Index)(nil)
+
+// NewIndex creates a new OpenSearch index adapter.
+func NewIndex(cfg Config) *Index {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	cfg.URL = strings.TrimRight(cfg.URL, "/")
+
+	return &Index{
+		cfg:    cfg,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// document is a ticket or comment as stored in OpenSearch
+type document struct {
+	Kind           string    `json:"kind"`
+	ID             int64     `json:"id"`
+	TicketID       int64     `json:"ticket_id"`
+	OrganizationID string    `json:"organization_id"`
+	RequesterID    string    `json:"requester_id"`
+	Visibility     string    `json:"visibility"`
+	Title          string    `json:"title"`
+	Body           string    `json:"body"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// mapping keeps IDs as exact keywords so searches can filter on them
+var mapping = map[string]any{
+	"mappings": map[string]any{
+		"properties": map[string]any{
+			"kind":            map[string]string{"type": "keyword"},
+			"id":              map[string]string{"type": "long"},
+			"ticket_id":       map[string]string{"type": "long"},
+			"organization_id": map[string]string{"type": "keyword"},
+			"requester_id":    map[string]string{"type": "keyword"},
+			"visibility":      map[string]string{"type": "keyword"},
+			"title":           map[string]string{"type": "text"},
+			"body":            map[string]string{"type": "text"},
+			"updated_at":      map[string]string{"type": "date"},
+		},
+	},
+}
+
+// EnsureIndex creates the index with its mapping. If the index already
+// exists, fields added to the mapping since it was created are added to it.
+func (x *Index) EnsureIndex(ctx context.Context) error {
+	body, err := json.Marshal(mapping)
+	if err != nil {
+		return err
+	}
+
+	status, respBody, err := x.do(ctx, http.MethodPut, "/"+x.cfg.Index, "application/json", body)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusBadRequest && bytes.Contains(respBody, []byte("resource_already_exists_exception")) {
+		return x.updateMapping(ctx)
+	}
+	return checkStatus(status, respBody)
+}
+
+// updateMapping puts the mapping's fields on an existing index
+func (x *Index) updateMapping(ctx context.Context) error {
+	body, err := json.Marshal(mapping["mappings"])
+	if err != nil {
+		return err
+	}
+
+	status, respBody, err := x.do(ctx, http.MethodPut, "/"+x.cfg.Index+"/_mapping", "application/json", body)
+	if err != nil {
+		return err
+	}
+	return checkStatus(status, respBody)
+}
+
+// Index adds the documents, replacing any stored under the same key.
+func (x *Index) Index(ctx context.Context, docs []domain.SearchDocument) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, doc := range docs {
+		action := map[string]any{"index": map[string]string{"_id": doc.Key()}}
+		if err := enc.Encode(action); err != nil {
+			return err
+		}
+		if err := enc.Encode(document{
+			Kind:           string(doc.Kind),
+			ID:             doc.ID,
+			TicketID:       doc.TicketID,
+			OrganizationID: doc.OrganizationID.String(),
+			RequesterID:    doc.RequesterID.String(),
+			Visibility:     string(doc.Visibility),
+			Title:          doc.Title,
+			Body:           doc.Body,
+			UpdatedAt:      doc.UpdatedAt.UTC(),
+		}); err != nil {
+			return err
+		}
+	}
+
+	status, respBody, err := x.do(ctx, http.MethodPost, "/"+x.cfg.Index+"/_bulk", "application/x-ndjson", body.Bytes())
+	if err != nil {
+		return err
+	}
+	if err := checkStatus(status, respBody); err != nil {
+		return err
+	}
+
+	// A bulk request succeeds as a whole even if some documents fail
+	var result struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			ID    string          `json:"_id"`
+			Error json.RawMessage `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return fmt.Errorf("decode bulk response: %w", err)
+	}
+	if !result.Errors {
+		return nil
+	}
+	for _, item := range result.Items {
+		for _, op := range item {
+			if len(op.Error) > 0 {
+				return fmt.Errorf("index document %s: %s", op.ID, op.Error)
+			}
+		}
+	}
+	return errors.New("bulk index reported errors")
+}
+
+// DeleteTicket removes the ticket's documents.
+func (x *Index) DeleteTicket(ctx context.Context, orgID uuid.UUID, ticketID int64) error {
+	body, err := json.Marshal(map[string]any{
+		"query": map[string]any{
+			"bool": map[string]any{
+				"filter": []any{
+					term("organization_id", orgID.String()),
+					term("ticket_id", ticketID),
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	status, respBody, err := x.do(ctx, http.MethodPost, "/"+x.cfg.Index+"/_delete_by_query?conflicts=proceed", "application/json", body)
+	if err != nil {
+		return err
+	}
+	return checkStatus(status, respBody)
+}
+
+// Search returns the best matching documents. The text may use quotes, |
+// for OR and a leading - to exclude words.
+func (x *Index) Search(ctx context.Context, query domain.SearchQuery) ([]*domain.SearchHit, error) {
+	filters := []any{term("organization_id", query.OrganizationID.String())}
+	if query.RequesterID != nil {
+		filters = append(filters, term("requester_id", query.RequesterID.String()))
+	}
+	// Documents indexed before visibility was stored have none and are
+	// public, so internal ones are excluded rather than public ones required
+	exclude := []any{}
+	if query.HideInternal {
+		exclude = append(exclude, term("visibility", string(domain.VisibilityInternal)))
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"size": query.Limit,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"must": map[string]any{
+					"simple_query_string": map[string]any{
+						"query":            query.Text,
+						"fields":           []string{"title^2", "body"},
+						"default_operator": "and",
+					},
+				},
+				"filter":   filters,
+				"must_not": exclude,
+			},
+		},
+		"highlight": map[string]any{
+			"pre_tags":  []string{"<em>"},
+			"post_tags": []string{"</em>"},
+			"fields": map[string]any{
+				"body": map[string]any{"number_of_fragments": 1, "fragment_size": 200, "no_match_size": 200},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	status, respBody, err := x.do(ctx, http.MethodPost, "/"+x.cfg.Index+"/_search", "application/json", body)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStatus(status, respBody); err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Hits struct {
+			Hits []struct {
+				Score     float64             `json:"_score"`
+				Source    document            `json:"_source"`
+				Highlight map[string][]string `json:"highlight"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("decode search response: %w", err)
+	}
+
+	hits := make([]*domain.SearchHit, 0, len(result.Hits.Hits))
+	for _, h := range result.Hits.Hits {
+		hit := &domain.SearchHit{
+			Kind:     domain.SearchDocumentKind(h.Source.Kind),
+			ID:       h.Source.ID,
+			TicketID: h.Source.TicketID,
+			Title:    h.Source.Title,
+			Score:    h.Score,
+		}
+		if fragments := h.Highlight["body"]; len(fragments) > 0 {
+			hit.Snippet = fragments[0]
+		}
+		hits = append(hits, hit)
+	}
+	return hits, nil
+}
+
+func term(field string, value any) map[string]any {
+	return map[string]any{"term": map[string]any{field: value}}
+}
+
+// do sends a request to the cluster and returns the response status and body
+func (x *Index) do(ctx context.Context, method, path, contentType string, body []byte) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, x.cfg.URL+path, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	if x.cfg.Username != "" {
+		req.SetBasicAuth(x.cfg.Username, x.cfg.Password)
+	}
+
+	resp, err := x.client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return resp.StatusCode, nil, err
+	}
+	return resp.StatusCode, respBody, nil
+}
+
+// checkStatus reports a non-2xx response as an error
+func checkStatus(status int, body []byte) error {
+	if status < 200 || status >= 300 {
+		if len(body) > 1024 {
+			body = body[:1024]
+		}
+		return fmt.Errorf("opensearch returned %d: %s", status, body)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package opensearch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_Index(t *testing.T) {
+	ctx := context.Background()
+	ticket := &domain.Ticket{ID: 7, Title: "Printer on fire", OrganizationID: uuid.New(), RequesterID: uuid.New(), CreatedAt: time.Now()}
+	docs := domain.TicketSearchDocuments(ticket, []*domain.Comment{{ID: 3, TicketID: 7, Body: "Water?"}})
+
+	t.Run("bulk indexes documents by key", func(t *testing.T) {
+		var lines []map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/tickets/_bulk", r.URL.Path)
+			assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+			user, pass, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "admin", user)
+			assert.Equal(t, "secret", pass)
+
+			scanner := bufio.NewScanner(r.Body)
+			for scanner.Scan() {
+				var line map[string]any
+				require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+				lines = append(lines, line)
+			}
+			_, _ = io.WriteString(w, `{"errors":false,"items":[]}`)
+		}))
+		defer server.Close()
+
+		index := NewIndex(Config{URL: server.URL + "/", Index: "tickets", Username: "admin", Password: "secret"})
+
+		require.NoError(t, index.Index(ctx, docs))
+		require.Len(t, lines, 4)
+		assert.Equal(t, map[string]any{"index": map[string]any{"_id": "ticket-7"}}, lines[0])
+		assert.Equal(t, "Printer on fire", lines[1]["title"])
+		assert.Equal(t, ticket.OrganizationID.String(), lines[1]["organization_id"])
+		assert.Equal(t, "PUBLIC", lines[1]["visibility"])
+		assert.Equal(t, map[string]any{"index": map[string]any{"_id": "comment-3"}}, lines[2])
+		assert.Equal(t, "Water?", lines[3]["body"])
+	})
+
+	t.Run("reports documents that failed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, `{"errors":true,"items":[{"index":{"_id":"comment-3","status":400,"error":{"type":"mapper_parsing_exception"}}}]}`)
+		}))
+		defer server.Close()
+
+		err := NewIndex(Config{URL: server.URL, Index: "tickets"}).Index(ctx, docs)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "comment-3")
+		assert.Contains(t, err.Error(), "mapper_parsing_exception")
+	})
+}
+
+func TestIndex_Search(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	requesterID := uuid.New()
+
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/tickets/_search", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_, _ = io.WriteString(w, `{"hits":{"hits":[{"_score":2.5,"_source":{"kind":"comment","id":3,"ticket_id":7,"title":"Printer on fire"},"highlight":{"body":["Is the <em>printer</em> off?"]}}]}}`)
+	}))
+	defer server.Close()
+
+	hits, err := NewIndex(Config{URL: server.URL, Index: "tickets"}).Search(ctx, domain.SearchQuery{
+		OrganizationID: orgID,
+		RequesterID:    &requesterID,
+		HideInternal:   true,
+		Text:           "printer",
+		Limit:          5,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, domain.SearchHit{
+		Kind:     domain.SearchDocumentComment,
+		ID:       3,
+		TicketID: 7,
+		Title:    "Printer on fire",
+		Snippet:  "Is the <em>printer</em> off?",
+		Score:    2.5,
+	}, *hits[0])
+
+	assert.EqualValues(t, 5, got["size"])
+	filters := got["query"].(map[string]any)["bool"].(map[string]any)["filter"].([]any)
+	assert.Equal(t, []any{
+		map[string]any{"term": map[string]any{"organization_id": orgID.String()}},
+		map[string]any{"term": map[string]any{"requester_id": requesterID.String()}},
+	}, filters)
+	excluded := got["query"].(map[string]any)["bool"].(map[string]any)["must_not"].([]any)
+	assert.Equal(t, []any{
+		map[string]any{"term": map[string]any{"visibility": "INTERNAL"}},
+	}, excluded)
+}
+
+func TestIndex_DeleteTicket(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/tickets/_delete_by_query", r.URL.Path)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := NewIndex(Config{URL: server.URL, Index: "tickets"}).DeleteTicket(context.Background(), uuid.New(), 7)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "opensearch returned 503")
+}
+
+func TestIndex_EnsureIndex(t *testing.T) {
+	var paths []string
+	var properties map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == "/tickets/_mapping" {
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			properties = body["properties"].(map[string]any)
+			_, _ = io.WriteString(w, `{"acknowledged":true}`)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `{"error":{"type":"resource_already_exists_exception"},"status":400}`)
+	}))
+	defer server.Close()
+
+	require.NoError(t, NewIndex(Config{URL: server.URL, Index: "tickets"}).EnsureIndex(context.Background()))
+	assert.Equal(t, []string{"/tickets", "/tickets/_mapping"}, paths)
+	assert.Equal(t, map[string]any{"type": "keyword"}, properties["visibility"])
+}
@@ -0,0 +1,259 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Page geometry, in points: A4 with 2 cm margins.
+const (
+	pageWidth    = 595.28
+	pageHeight   = 841.89
+	margin       = 56.69
+	contentWidth = pageWidth - 2*margin
+	// footerHeight is kept free at the bottom of each page for its number
+	footerHeight = 24.0
+	lineSpacing  = 1.35
+)
+
+// font selects one of the two standard fonts every PDF reader has, so
+// nothing needs to be embedded.
+type font int
+
+const (
+	regular font = iota
+	bold
+)
+
+// resource is the name a page's content refers to the font by.
+func (f font) resource() string {
+	if f == bold {
+		return "F2"
+	}
+	return "F1"
+}
+
+// document lays out text top to bottom across as many pages as it needs.
+// Text is set in Helvetica with WinAnsiEncoding; characters outside it are
+// replaced by a question mark.
+type document struct {
+	title string
+	pages []*bytes.Buffer
+	// y is the baseline of the last line on the current page
+	y float64
+}
+
+func newDocument(title string) *document {
+	d := &document{title: title}
+	d.newPage()
+	return d
+}
+
+func (d *document) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pageHeight - margin
+}
+
+func (d *document) page() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+// advance moves down by height, starting a new page if it doesn't fit.
+func (d *document) advance(height float64) {
+	if d.y-height < margin+footerHeight {
+		d.newPage()
+	}
+	d.y -= height
+}
+
+// space leaves a vertical gap, which is dropped at the top of a page.
+func (d *document) space(height float64) {
+	if d.y == pageHeight-margin {
+		return
+	}
+	if d.y-height < margin+footerHeight {
+		d.newPage()
+		return
+	}
+	d.y -= height
+}
+
+// text sets s in the given font, size and gray level, starting indent
+// points from the left margin and wrapping at the right one. Line breaks
+// in s are kept.
+func (d *document) text(f font, size float64, gray float64, indent float64, s string) {
+	for _, line := range wrap(encode(s), f, size, contentWidth-indent) {
+		d.advance(size * lineSpacing)
+		d.show(f, size, gray, margin+indent, d.y, line)
+	}
+}
+
+// field sets a label in bold with its value beside it, the value wrapping
+// within its column.
+func (d *document) field(label, value string, labelWidth float64) {
+	const size = 10
+	lines := wrap(encode(value), regular, size, contentWidth-labelWidth)
+	for i, line := range lines {
+		d.advance(size * lineSpacing)
+		if i == 0 {
+			d.show(bold, size, 0.3, margin, d.y, encode(label))
+		}
+		d.show(regular, size, 0, margin+labelWidth, d.y, line)
+	}
+}
+
+// rule draws a thin horizontal line across the content width.
+func (d *document) rule() {
+	d.advance(8)
+	fmt.Fprintf(d.page(), "0.75 G 0.5 w %.2f %.2f m %.2f %.2f l S\n", margin, d.y+4, pageWidth-margin, d.y+4)
+}
+
+func (d *document) show(f font, size, gray, x, y float64, line []byte) {
+	if len(line) == 0 {
+		return
+	}
+	fmt.Fprintf(d.page(), "BT %.2f g /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", gray, f.resource(), size, x, y, escape(line))
+}
+
+// bytes returns the finished PDF, numbering the pages in their footers.
+func (d *document) bytes() []byte {
+	var out bytes.Buffer
+	offsets := []int{}
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-5 are fixed; each page then takes two, its page object and
+	// its content stream
+	const firstPage = 6
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title (%s) /Producer (Service Desk) >>", escape(encode(d.title))))
+
+	for i, content := range d.pages {
+		footer := encode(fmt.Sprintf("%d / %d", i+1, len(d.pages)))
+		fmt.Fprintf(content, "BT 0.5 g /F1 8.0 Tf %.2f %.2f Td (%s) Tj ET\n",
+			pageWidth-margin-width(footer, regular, 8), margin, escape(footer))
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// escape makes encoded text safe inside a PDF string literal.
+func escape(text []byte) string {
+	var b strings.Builder
+	for _, c := range text {
+		switch c {
+		case '(', ')', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// wrap breaks encoded text into lines no wider than maxWidth, at spaces
+// where it can and within words that are too long on their own. An empty
+// text is a single empty line.
+func wrap(text []byte, f font, size, maxWidth float64) [][]byte {
+	var lines [][]byte
+	for _, paragraph := range bytes.Split(text, []byte{'\n'}) {
+		var line []byte
+		for _, word := range bytes.Split(paragraph, []byte{' '}) {
+			candidate := word
+			if len(line) > 0 {
+				candidate = append(append(append([]byte{}, line...), ' '), word...)
+			}
+			if width(candidate, f, size) <= maxWidth {
+				line = candidate
+				continue
+			}
+			if len(line) > 0 {
+				lines = append(lines, line)
+			}
+			// Break a word wider than the line wherever it has to
+			for width(word, f, size) > maxWidth {
+				n := 1
+				for n < len(word) && width(word[:n+1], f, size) <= maxWidth {
+					n++
+				}
+				lines = append(lines, word[:n])
+				word = word[n:]
+			}
+			line = word
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// width returns how wide encoded text is set in the font, in points.
+func width(text []byte, f font, size float64) float64 {
+	widths := &helveticaWidths
+	if f == bold {
+		widths = &helveticaBoldWidths
+	}
+
+	units := 0
+	for _, c := range text {
+		if c >= 32 && c <= 126 {
+			units += widths[c-32]
+		} else {
+			units += defaultWidth
+		}
+	}
+	return float64(units) * size / 1000
+}
+
+// winAnsiExtras maps the characters WinAnsiEncoding places between 0x80
+// and 0x9f, where it differs from Latin-1.
+var winAnsiExtras = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// encode converts s to WinAnsiEncoding. Tabs become spaces, carriage
+// returns and other control characters are dropped, and characters the
+// encoding lacks become '?'.
+func encode(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == '\n':
+			out = append(out, '\n')
+		case r == '\t':
+			out = append(out, ' ', ' ', ' ', ' ')
+		case r < 32 || r == 127 || (r >= 0x80 && r < 0xa0):
+			// control characters
+		case r < 0x100:
+			out = append(out, byte(r))
+		default:
+			if c, ok := winAnsiExtras[r]; ok {
+				out = append(out, c)
+			} else {
+				out = append(out, '?')
+			}
+		}
+	}
+	return out
+}
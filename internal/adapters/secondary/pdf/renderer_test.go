@@ -0,0 +1,99 @@
+package pdf
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDocument(description string, comments int) *domain.TicketDocument {
+	created := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	doc := &domain.TicketDocument{
+		Ticket: &domain.Ticket{
+			ID:          42,
+			Title:       "Printer (floor 2) offline",
+			Description: description,
+			Status:      domain.StatusOpen,
+			Priority:    domain.PriorityHigh,
+			Visibility:  domain.VisibilityPublic,
+			CreatedAt:   created,
+		},
+		RequesterName: "Carl Customer",
+		GeneratedBy:   "Alice Agent",
+		GeneratedAt:   created.Add(time.Hour),
+		Location:      time.UTC,
+	}
+	for i := 0; i < comments; i++ {
+		doc.Comments = append(doc.Comments, domain.TicketDocumentComment{
+			AuthorName: "Alice Agent",
+			Body:       "Checked the spooler again.",
+			CreatedAt:  created.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	return doc
+}
+
+func TestRenderer_RenderPDF(t *testing.T) {
+	r, err := NewRenderer()
+	require.NoError(t, err)
+
+	t.Run("renders the ticket and its thread", func(t *testing.T) {
+		out, err := r.RenderPDF(testDocument("Shows error E-42.", 1), "en")
+		require.NoError(t, err)
+
+		assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4")))
+		assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+		// Parentheses in text are escaped inside PDF strings
+		assert.Contains(t, string(out), `Printer \(floor 2\) offline`)
+		assert.Contains(t, string(out), "(Carl Customer)")
+		assert.Contains(t, string(out), "(Shows error E-42.)")
+		assert.Contains(t, string(out), "(Checked the spooler again.)")
+		assert.Contains(t, string(out), "(2026-03-01 09:30 UTC)")
+		assert.Equal(t, 1, strings.Count(string(out), "/Type /Page "))
+	})
+
+	t.Run("long threads continue on more pages", func(t *testing.T) {
+		out, err := r.RenderPDF(testDocument(strings.Repeat("The printer is offline again. ", 200), 60), "en")
+		require.NoError(t, err)
+
+		pages := strings.Count(string(out), "/Type /Page ")
+		assert.Greater(t, pages, 2)
+		assert.Contains(t, string(out), "(1 / ")
+	})
+
+	t.Run("uses the requested locale", func(t *testing.T) {
+		out, err := r.RenderPDF(testDocument("", 0), "es")
+		require.NoError(t, err)
+
+		assert.Contains(t, string(out), "(Solicitante)")
+	})
+}
+
+func TestWrap(t *testing.T) {
+	t.Run("breaks at spaces within the width", func(t *testing.T) {
+		lines := wrap([]byte("one two three four"), regular, 10, width([]byte("one two three"), regular, 10))
+		assert.Equal(t, [][]byte{[]byte("one two three"), []byte("four")}, lines)
+	})
+
+	t.Run("keeps line breaks", func(t *testing.T) {
+		lines := wrap([]byte("one\n\ntwo"), regular, 10, 500)
+		assert.Equal(t, [][]byte{[]byte("one"), {}, []byte("two")}, lines)
+	})
+
+	t.Run("breaks words longer than a line", func(t *testing.T) {
+		word := []byte(strings.Repeat("x", 100))
+		for _, line := range wrap(word, regular, 10, 100) {
+			assert.LessOrEqual(t, width(line, regular, 10), 100.0)
+		}
+	})
+}
+
+func TestEncode(t *testing.T) {
+	assert.Equal(t, []byte("caf\xe9 \x93ok\x94 ?"), encode("café “ok” 😀"))
+	assert.Equal(t, []byte("a    b"), encode("a\tb\r"))
+}
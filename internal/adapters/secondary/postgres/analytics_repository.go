@@ -6,26 +6,23 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/lorrc/service-desk-backend/internal/core/domain"
 	"github.com/lorrc/service-desk-backend/internal/core/ports"
 )
 
 type AnalyticsRepository struct {
-	pool *pgxpool.Pool
+	conn    DBTX
+	replica *ReadReplica
 }
 
 var _ ports.AnalyticsRepository = (*AnalyticsRepository)(nil)
 
-func NewAnalyticsRepository(pool *pgxpool.Pool) ports.AnalyticsRepository {
-	return &AnalyticsRepository{pool: pool}
+func NewAnalyticsRepository(conn DBTX, opts ...RepositoryOption) ports.AnalyticsRepository {
+	o := applyRepositoryOptions(opts)
+	return &AnalyticsRepository{conn: conn, replica: o.replica}
 }
 
-func (r *AnalyticsRepository) GetOverview(ctx context.Context, orgID uuid.UUID, days int) (*domain.AnalyticsOverview, error) {
-	if days <= 0 {
-		days = 30
-	}
-
+func (r *AnalyticsRepository) GetOverview(ctx context.Context, orgID uuid.UUID, rng domain.AnalyticsRange) (*domain.AnalyticsOverview, error) {
 	statusCounts, err := r.fetchStatusCounts(ctx, orgID)
 	if err != nil {
 		return nil, err
@@ -36,34 +33,114 @@ func (r *AnalyticsRepository) GetOverview(ctx context.Context, orgID uuid.UUID,
 		return nil, err
 	}
 
-	volume, err := r.fetchVolume(ctx, orgID, days)
+	teamWorkload, err := r.fetchTeamWorkload(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	volume, err := r.fetchVolume(ctx, orgID, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	volumeByPriority, err := r.fetchVolumeByPriority(ctx, orgID, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	mttrHours, err := r.fetchMTTRHours(ctx, orgID, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	firstResponse, err := r.fetchFirstResponse(ctx, orgID, rng)
 	if err != nil {
 		return nil, err
 	}
 
-	mttrHours, err := r.fetchMTTRHours(ctx, orgID)
+	agentFirstResponse, err := r.fetchAgentFirstResponse(ctx, orgID, rng)
 	if err != nil {
 		return nil, err
 	}
 
 	return &domain.AnalyticsOverview{
-		StatusCounts: statusCounts,
-		Workload:     workload,
-		Volume:       volume,
-		MTTRHours:    mttrHours,
+		Range:              rng,
+		StatusCounts:       statusCounts,
+		Workload:           workload,
+		TeamWorkload:       teamWorkload,
+		Volume:             volume,
+		VolumeByPriority:   volumeByPriority,
+		MTTRHours:          mttrHours,
+		FirstResponse:      firstResponse,
+		AgentFirstResponse: agentFirstResponse,
 	}, nil
 }
 
+// GetWorkload counts the open tickets of each assignee.
+func (r *AnalyticsRepository) GetWorkload(ctx context.Context, orgID uuid.UUID) ([]domain.WorkloadItem, error) {
+	return r.fetchWorkload(ctx, orgID)
+}
+
+// ListAgentTicketHistory counts the assigned tickets matching query per
+// assignee. Archived tickets are not counted.
+func (r *AnalyticsRepository) ListAgentTicketHistory(ctx context.Context, orgID uuid.UUID, query domain.SimilarTicketsQuery) ([]domain.AgentTicketHistory, error) {
+	const sql = `
+SELECT t.assignee_id,
+       COUNT(*) FILTER (WHERE t.tags && $3::text[]),
+       COUNT(*) FILTER (WHERE t.team_id = $4)
+FROM tickets t
+WHERE t.organization_id = $1
+  AND t.id != $2
+  AND t.assignee_id IS NOT NULL
+  AND t.deleted_at IS NULL
+  AND t.created_at >= $5
+  AND (t.tags && $3::text[] OR t.team_id = $4)
+GROUP BY t.assignee_id
+ORDER BY t.assignee_id
+`
+
+	tags := query.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	teamID := pgtype.UUID{}
+	if query.TeamID != nil {
+		teamID = pgtype.UUID{Bytes: *query.TeamID, Valid: true}
+	}
+
+	rows, err := GetReadDBTX(ctx, r.conn, r.replica).Query(ctx, sql,
+		pgtype.UUID{Bytes: orgID, Valid: true}, query.TicketID, tags, teamID,
+		pgtype.Timestamptz{Time: query.Since, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := make([]domain.AgentTicketHistory, 0)
+	for rows.Next() {
+		var h domain.AgentTicketHistory
+		if err := rows.Scan(&h.AgentID, &h.SharedTagTickets, &h.SameTeamTickets); err != nil {
+			return nil, err
+		}
+		history = append(history, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
 func (r *AnalyticsRepository) fetchStatusCounts(ctx context.Context, orgID uuid.UUID) ([]domain.StatusCount, error) {
 	const query = `
 SELECT t.status, COUNT(*)
 FROM tickets t
-JOIN users ru ON t.requester_id = ru.id
-WHERE ru.organization_id = $1
+WHERE t.organization_id = $1
+  AND t.deleted_at IS NULL
 GROUP BY t.status
 `
 
-	rows, err := r.pool.Query(ctx, query, pgtype.UUID{Bytes: orgID, Valid: true})
+	rows, err := GetReadDBTX(ctx, r.conn, r.replica).Query(ctx, query, pgtype.UUID{Bytes: orgID, Valid: true})
 	if err != nil {
 		return nil, err
 	}
@@ -100,15 +177,15 @@ func (r *AnalyticsRepository) fetchWorkload(ctx context.Context, orgID uuid.UUID
 	const query = `
 SELECT t.assignee_id, u.full_name, u.email, COUNT(*)
 FROM tickets t
-JOIN users ru ON t.requester_id = ru.id
 LEFT JOIN users u ON t.assignee_id = u.id
-WHERE ru.organization_id = $1
+WHERE t.organization_id = $1
   AND t.status != 'CLOSED'
+  AND t.deleted_at IS NULL
 GROUP BY t.assignee_id, u.full_name, u.email
 ORDER BY COUNT(*) DESC, u.full_name, u.email
 `
 
-	rows, err := r.pool.Query(ctx, query, pgtype.UUID{Bytes: orgID, Valid: true})
+	rows, err := GetReadDBTX(ctx, r.conn, r.replica).Query(ctx, query, pgtype.UUID{Bytes: orgID, Valid: true})
 	if err != nil {
 		return nil, err
 	}
@@ -147,42 +224,97 @@ ORDER BY COUNT(*) DESC, u.full_name, u.email
 	return items, nil
 }
 
-func (r *AnalyticsRepository) fetchVolume(ctx context.Context, orgID uuid.UUID, days int) ([]domain.VolumePoint, error) {
+func (r *AnalyticsRepository) fetchTeamWorkload(ctx context.Context, orgID uuid.UUID) ([]domain.TeamWorkload, error) {
 	const query = `
-WITH days AS (
-  SELECT generate_series(
-    date_trunc('day', NOW()) - ($2::int - 1) * interval '1 day',
-    date_trunc('day', NOW()),
-    interval '1 day'
-  ) AS day
+SELECT tm.id, tm.name,
+       (SELECT COUNT(*) FROM team_members m WHERE m.team_id = tm.id),
+       COUNT(t.id),
+       COUNT(t.id) FILTER (WHERE t.assignee_id IS NULL)
+FROM teams tm
+LEFT JOIN tickets t ON t.team_id = tm.id AND t.status != 'CLOSED' AND t.deleted_at IS NULL
+WHERE tm.organization_id = $1
+GROUP BY tm.id, tm.name
+ORDER BY tm.name
+`
+
+	rows, err := GetReadDBTX(ctx, r.conn, r.replica).Query(ctx, query, pgtype.UUID{Bytes: orgID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]domain.TeamWorkload, 0)
+	for rows.Next() {
+		var item domain.TeamWorkload
+		if err := rows.Scan(&item.TeamID, &item.Name, &item.MemberCount, &item.OpenCount, &item.QueuedCount); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// dailyVolumeCTE yields the ticket volume per day and priority of
+// organization $1 from date $2 to date $3. Days the rollup job has completed
+// come from analytics_daily_volume; later days, normally just today, are
+// counted from the tickets table. Soft-deleted tickets are counted, like
+// the rollups count them, so past volume does not change as retention
+// deletes tickets.
+const dailyVolumeCTE = `
+WITH rollup_state AS (
+  SELECT COALESCE(MAX(rolled_up_through), '-infinity'::date) AS through
+  FROM analytics_rollup_state
 ),
-created AS (
-  SELECT date_trunc('day', t.created_at) AS day, COUNT(*) AS created_count
-  FROM tickets t
-  JOIN users ru ON t.requester_id = ru.id
-  WHERE ru.organization_id = $1
-    AND t.created_at >= date_trunc('day', NOW()) - ($2::int - 1) * interval '1 day'
-  GROUP BY 1
+live_from AS (
+  SELECT GREATEST($2::date, through + 1) AS day
+  FROM rollup_state
 ),
-resolved AS (
-  SELECT date_trunc('day', t.closed_at) AS day, COUNT(*) AS resolved_count
+daily AS (
+  SELECT v.day, v.priority, v.created_count, v.resolved_count, v.resolution_seconds
+  FROM analytics_daily_volume v
+  CROSS JOIN rollup_state s
+  WHERE v.organization_id = $1
+    AND v.day >= $2::date
+    AND v.day <= LEAST($3::date, s.through)
+  UNION ALL
+  SELECT date_trunc('day', t.created_at)::date, t.priority, COUNT(*), 0, 0::float8
+  FROM tickets t
+  CROSS JOIN live_from f
+  WHERE t.organization_id = $1
+    AND t.created_at >= f.day
+    AND t.created_at < $3::date + 1
+  GROUP BY 1, 2
+  UNION ALL
+  SELECT date_trunc('day', t.closed_at)::date, t.priority, 0, COUNT(*),
+         SUM(COALESCE(t.resolution_working_seconds, EXTRACT(EPOCH FROM (t.closed_at - t.created_at))))::float8
   FROM tickets t
-  JOIN users ru ON t.requester_id = ru.id
-  WHERE ru.organization_id = $1
-    AND t.closed_at IS NOT NULL
-    AND t.closed_at >= date_trunc('day', NOW()) - ($2::int - 1) * interval '1 day'
-  GROUP BY 1
+  CROSS JOIN live_from f
+  WHERE t.organization_id = $1
+    AND t.closed_at >= f.day
+    AND t.closed_at < $3::date + 1
+  GROUP BY 1, 2
+)`
+
+func (r *AnalyticsRepository) fetchVolume(ctx context.Context, orgID uuid.UUID, rng domain.AnalyticsRange) ([]domain.VolumePoint, error) {
+	const query = dailyVolumeCTE + `,
+days AS (
+  SELECT generate_series($2::date, $3::date, interval '1 day')::date AS day
 )
 SELECT d.day,
-       COALESCE(c.created_count, 0) AS created_count,
-       COALESCE(r.resolved_count, 0) AS resolved_count
+       COALESCE(SUM(v.created_count), 0)::bigint AS created_count,
+       COALESCE(SUM(v.resolved_count), 0)::bigint AS resolved_count
 FROM days d
-LEFT JOIN created c ON c.day = d.day
-LEFT JOIN resolved r ON r.day = d.day
+LEFT JOIN daily v ON v.day = d.day
+GROUP BY d.day
 ORDER BY d.day
 `
 
-	rows, err := r.pool.Query(ctx, query, pgtype.UUID{Bytes: orgID, Valid: true}, days)
+	rows, err := GetReadDBTX(ctx, r.conn, r.replica).Query(ctx, query, pgtype.UUID{Bytes: orgID, Valid: true}, rng.From, rng.To)
 	if err != nil {
 		return nil, err
 	}
@@ -212,24 +344,215 @@ ORDER BY d.day
 	return points, nil
 }
 
-func (r *AnalyticsRepository) fetchMTTRHours(ctx context.Context, orgID uuid.UUID) (float64, error) {
-	const query = `
-SELECT AVG(EXTRACT(EPOCH FROM (t.closed_at - t.created_at)))
-FROM tickets t
-JOIN users ru ON t.requester_id = ru.id
-WHERE ru.organization_id = $1
-  AND t.closed_at IS NOT NULL
+// fetchVolumeByPriority counts tickets created and resolved in the range,
+// per priority. Every priority is listed, even without tickets.
+func (r *AnalyticsRepository) fetchVolumeByPriority(ctx context.Context, orgID uuid.UUID, rng domain.AnalyticsRange) ([]domain.PriorityVolume, error) {
+	const query = dailyVolumeCTE + `
+SELECT priority, SUM(created_count)::bigint, SUM(resolved_count)::bigint
+FROM daily
+GROUP BY priority
 `
 
-	row := r.pool.QueryRow(ctx, query, pgtype.UUID{Bytes: orgID, Valid: true})
+	rows, err := GetReadDBTX(ctx, r.conn, r.replica).Query(ctx, query, pgtype.UUID{Bytes: orgID, Valid: true}, rng.From, rng.To)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[domain.TicketPriority]domain.PriorityVolume)
+	for rows.Next() {
+		var (
+			priority      string
+			createdCount  int64
+			resolvedCount int64
+		)
+		if err := rows.Scan(&priority, &createdCount, &resolvedCount); err != nil {
+			return nil, err
+		}
+		counts[domain.TicketPriority(priority)] = domain.PriorityVolume{
+			CreatedCount:  createdCount,
+			ResolvedCount: resolvedCount,
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	priorities := []domain.TicketPriority{domain.PriorityHigh, domain.PriorityMedium, domain.PriorityLow}
+	volumes := make([]domain.PriorityVolume, 0, len(priorities))
+	for _, priority := range priorities {
+		volume := counts[priority]
+		volume.Priority = priority
+		volumes = append(volumes, volume)
+	}
+
+	return volumes, nil
+}
+
+// fetchMTTRHours averages the working time tickets closed in the range took
+// to resolve.
+func (r *AnalyticsRepository) fetchMTTRHours(ctx context.Context, orgID uuid.UUID, rng domain.AnalyticsRange) (float64, error) {
+	const query = dailyVolumeCTE + `
+SELECT SUM(resolution_seconds) / NULLIF(SUM(resolved_count), 0)
+FROM daily
+`
+
+	row := GetReadDBTX(ctx, r.conn, r.replica).QueryRow(ctx, query, pgtype.UUID{Bytes: orgID, Valid: true}, rng.From, rng.To)
 	var avgSeconds pgtype.Float8
 	if err := row.Scan(&avgSeconds); err != nil {
 		return 0, err
 	}
-	if !avgSeconds.Valid {
-		return 0, nil
+	return secondsToHours(avgSeconds), nil
+}
+
+// RollupThrough returns the last day the daily rollups are complete for,
+// or nil if they have never been built.
+func (r *AnalyticsRepository) RollupThrough(ctx context.Context) (*time.Time, error) {
+	var through pgtype.Date
+	err := GetDBTX(ctx, r.conn).QueryRow(ctx, "SELECT MAX(rolled_up_through) FROM analytics_rollup_state").Scan(&through)
+	if err != nil {
+		return nil, err
+	}
+	if !through.Valid {
+		return nil, nil
+	}
+	return &through.Time, nil
+}
+
+// RefreshDailyRollups rebuilds the daily rollups of every organization from
+// one date to another, inclusive, and records them as complete through the
+// end date. A zero from date rebuilds all history. Callers should run it in
+// a transaction so readers never see a partial rebuild. Soft-deleted
+// tickets are counted: they were created and resolved all the same.
+func (r *AnalyticsRepository) RefreshDailyRollups(ctx context.Context, from, through time.Time) error {
+	q := GetDBTX(ctx, r.conn)
+
+	var fromDate pgtype.Date
+	if !from.IsZero() {
+		fromDate = pgtype.Date{Time: from, Valid: true}
+	}
+	throughDate := pgtype.Date{Time: through, Valid: true}
+
+	if _, err := q.Exec(ctx, `
+DELETE FROM analytics_daily_volume
+WHERE ($1::date IS NULL OR day >= $1::date)
+  AND day <= $2::date
+`, fromDate, throughDate); err != nil {
+		return err
+	}
+
+	if _, err := q.Exec(ctx, `
+INSERT INTO analytics_daily_volume (organization_id, day, priority, created_count, resolved_count, resolution_seconds)
+SELECT organization_id, day, priority, SUM(created_count), SUM(resolved_count), SUM(resolution_seconds)
+FROM (
+  SELECT t.organization_id, date_trunc('day', t.created_at)::date AS day, t.priority,
+         COUNT(*) AS created_count, 0 AS resolved_count, 0::float8 AS resolution_seconds
+  FROM tickets t
+  WHERE ($1::date IS NULL OR t.created_at >= $1::date)
+    AND t.created_at < $2::date + 1
+  GROUP BY 1, 2, 3
+  UNION ALL
+  SELECT t.organization_id, date_trunc('day', t.closed_at)::date, t.priority,
+         0, COUNT(*), SUM(COALESCE(t.resolution_working_seconds, EXTRACT(EPOCH FROM (t.closed_at - t.created_at))))::float8
+  FROM tickets t
+  WHERE t.closed_at IS NOT NULL
+    AND ($1::date IS NULL OR t.closed_at >= $1::date)
+    AND t.closed_at < $2::date + 1
+  GROUP BY 1, 2, 3
+) counts
+GROUP BY organization_id, day, priority
+`, fromDate, throughDate); err != nil {
+		return err
+	}
+
+	_, err := q.Exec(ctx, `
+INSERT INTO analytics_rollup_state (id, rolled_up_through, refreshed_at)
+VALUES (TRUE, $1, NOW())
+ON CONFLICT (id) DO UPDATE
+SET rolled_up_through = GREATEST(analytics_rollup_state.rolled_up_through, EXCLUDED.rolled_up_through),
+    refreshed_at = EXCLUDED.refreshed_at
+`, throughDate)
+	return err
+}
+
+// firstResponseStatsColumns aggregates first-response times in seconds.
+const firstResponseStatsColumns = `
+COUNT(*),
+AVG(EXTRACT(EPOCH FROM (t.first_response_at - t.created_at))),
+PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM (t.first_response_at - t.created_at))),
+PERCENTILE_CONT(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM (t.first_response_at - t.created_at)))`
+
+// fetchFirstResponse summarizes first responses given in the range,
+// soft-deleted tickets included like the daily volume.
+func (r *AnalyticsRepository) fetchFirstResponse(ctx context.Context, orgID uuid.UUID, rng domain.AnalyticsRange) (domain.ResponseTimeStats, error) {
+	query := `
+SELECT ` + firstResponseStatsColumns + `
+FROM tickets t
+WHERE t.organization_id = $1
+  AND t.first_response_at >= $2::date
+  AND t.first_response_at < $3::date + 1
+`
+
+	var (
+		stats                domain.ResponseTimeStats
+		avgSeconds, p50, p90 pgtype.Float8
+	)
+	row := GetReadDBTX(ctx, r.conn, r.replica).QueryRow(ctx, query, pgtype.UUID{Bytes: orgID, Valid: true}, rng.From, rng.To)
+	if err := row.Scan(&stats.Count, &avgSeconds, &p50, &p90); err != nil {
+		return stats, err
+	}
+
+	stats.AverageHours = secondsToHours(avgSeconds)
+	stats.MedianHours = secondsToHours(p50)
+	stats.P90Hours = secondsToHours(p90)
+	return stats, nil
+}
+
+func (r *AnalyticsRepository) fetchAgentFirstResponse(ctx context.Context, orgID uuid.UUID, rng domain.AnalyticsRange) ([]domain.AgentResponseTime, error) {
+	query := `
+SELECT u.id, u.full_name, u.email, ` + firstResponseStatsColumns + `
+FROM tickets t
+JOIN users u ON t.first_response_by = u.id
+WHERE t.organization_id = $1
+  AND t.first_response_at >= $2::date
+  AND t.first_response_at < $3::date + 1
+GROUP BY u.id, u.full_name, u.email
+ORDER BY u.full_name, u.email
+`
+
+	rows, err := GetReadDBTX(ctx, r.conn, r.replica).Query(ctx, query, pgtype.UUID{Bytes: orgID, Valid: true}, rng.From, rng.To)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	agents := make([]domain.AgentResponseTime, 0)
+	for rows.Next() {
+		var (
+			agent                domain.AgentResponseTime
+			avgSeconds, p50, p90 pgtype.Float8
+		)
+		if err := rows.Scan(&agent.AgentID, &agent.FullName, &agent.Email, &agent.Count, &avgSeconds, &p50, &p90); err != nil {
+			return nil, err
+		}
+		agent.AverageHours = secondsToHours(avgSeconds)
+		agent.MedianHours = secondsToHours(p50)
+		agent.P90Hours = secondsToHours(p90)
+		agents = append(agents, agent)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return agents, nil
+}
+
+func secondsToHours(seconds pgtype.Float8) float64 {
+	if !seconds.Valid {
+		return 0
 	}
-	return avgSeconds.Float64 / 3600, nil
+	return seconds.Float64 / 3600
 }
 
 func textOrEmpty(text pgtype.Text) string {
@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/lorrc/service-desk-backend/internal/adapters/secondary/postgres/db"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// TicketArchiveRepository moves closed tickets into the ticket_archive
+// table and reads them back. Archived rows are JSON snapshots of the
+// ticket, comment and event rows, decoded with the same models as the hot
+// tables.
+type TicketArchiveRepository struct {
+	conn DBTX
+}
+
+var _ ports.TicketArchiveRepository = (*TicketArchiveRepository)(nil)
+
+// NewTicketArchiveRepository creates a new ticket archive repository.
+func NewTicketArchiveRepository(conn DBTX) ports.TicketArchiveRepository {
+	return &TicketArchiveRepository{conn: conn}
+}
+
+// ArchiveClosedBefore moves up to limit tickets closed before closedBefore,
+// oldest first, into the archive with their comments and events. Tickets
+// soft-deleted by retention are left for retention to remove. The moved
+// tickets are queued for search indexing so they leave the search index.
+func (r *TicketArchiveRepository) ArchiveClosedBefore(ctx context.Context, closedBefore time.Time, limit int) (int64, error) {
+	const archive = `
+WITH candidates AS (
+    SELECT t.id FROM tickets t
+    WHERE t.status = 'CLOSED'
+      AND t.closed_at < $1
+      AND t.deleted_at IS NULL
+    ORDER BY t.closed_at, t.id
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+),
+archived AS (
+    INSERT INTO ticket_archive (ticket_id, organization_id, title, closed_at, ticket, comments, events)
+    SELECT t.id, t.organization_id, t.title, t.closed_at, to_jsonb(t),
+           COALESCE((SELECT jsonb_agg(to_jsonb(c) ORDER BY c.created_at, c.id) FROM comments c WHERE c.ticket_id = t.id), '[]'),
+           COALESCE((SELECT jsonb_agg(to_jsonb(e) ORDER BY e.id) FROM ticket_events e WHERE e.ticket_id = t.id), '[]')
+    FROM tickets t
+    JOIN candidates ON candidates.id = t.id
+    RETURNING ticket_id, organization_id
+),
+queued AS (
+    INSERT INTO search_index_queue (ticket_id, organization_id)
+    SELECT ticket_id, organization_id FROM archived
+    ON CONFLICT (ticket_id) DO UPDATE
+    SET enqueued_at = NOW(), available_at = NOW()
+)
+DELETE FROM tickets t
+USING archived a
+WHERE t.id = a.ticket_id
+`
+
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx, archive, pgtype.Timestamptz{Time: closedBefore.UTC(), Valid: true}, limit)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// GetTicket returns an archived ticket of the organization. Tickets
+// soft-deleted by retention are not found.
+func (r *TicketArchiveRepository) GetTicket(ctx context.Context, orgID uuid.UUID, ticketID int64) (*domain.Ticket, error) {
+	var (
+		snapshot   []byte
+		archivedAt pgtype.Timestamptz
+	)
+	err := GetDBTX(ctx, r.conn).QueryRow(ctx, `
+SELECT ticket, archived_at FROM ticket_archive
+WHERE ticket_id = $1 AND organization_id = $2 AND deleted_at IS NULL`,
+		ticketID, orgID,
+	).Scan(&snapshot, &archivedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrTicketNotFound
+		}
+		return nil, err
+	}
+
+	var dbTicket db.Ticket
+	if err := json.Unmarshal(snapshot, &dbTicket); err != nil {
+		return nil, fmt.Errorf("decode archived ticket %d: %w", ticketID, err)
+	}
+
+	ticket := mapDBTicketToDomain(dbTicket)
+	if ticket.DescriptionHTML == "" {
+		// Archived before descriptions were stored as HTML, when they were
+		// plain text
+		ticket.DescriptionHTML = html.EscapeString(ticket.Description)
+	}
+	ticket.ArchivedAt = &archivedAt.Time
+	return ticket, nil
+}
+
+// ListComments returns an archived ticket's comments, oldest first. Tickets
+// that are not archived have none.
+func (r *TicketArchiveRepository) ListComments(ctx context.Context, ticketID int64) ([]*domain.Comment, error) {
+	var dbComments []db.Comment
+	if err := r.scanSnapshot(ctx, "comments", ticketID, &dbComments); err != nil {
+		return nil, err
+	}
+
+	comments := make([]*domain.Comment, 0, len(dbComments))
+	for _, dbComment := range dbComments {
+		comment := mapDBCommentToDomain(dbComment)
+		if comment.BodyHTML == "" {
+			// Archived before comments were stored as HTML
+			comment.BodyHTML = html.EscapeString(comment.Body)
+		}
+		comments = append(comments, comment)
+	}
+	return comments, nil
+}
+
+// archivedEvent is an event row as stored in the archive, where the
+// payload is nested JSON rather than bytes
+type archivedEvent struct {
+	ID        int64              `json:"id"`
+	TicketID  int64              `json:"ticket_id"`
+	Type      string             `json:"type"`
+	Version   int16              `json:"version"`
+	Payload   json.RawMessage    `json:"payload"`
+	ActorID   pgtype.UUID        `json:"actor_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+// ListEvents returns an archived ticket's events in order. Tickets that are
+// not archived have none.
+func (r *TicketArchiveRepository) ListEvents(ctx context.Context, ticketID int64) ([]*domain.Event, error) {
+	var archived []archivedEvent
+	if err := r.scanSnapshot(ctx, "events", ticketID, &archived); err != nil {
+		return nil, err
+	}
+
+	events := make([]*domain.Event, 0, len(archived))
+	for _, e := range archived {
+		if e.Version == 0 {
+			// Archived before events were versioned
+			e.Version = 1
+		}
+		events = append(events, mapDBTicketEventToDomain(db.TicketEvent{
+			ID:        e.ID,
+			TicketID:  e.TicketID,
+			Type:      e.Type,
+			Version:   e.Version,
+			Payload:   e.Payload,
+			ActorID:   e.ActorID,
+			CreatedAt: e.CreatedAt,
+		}))
+	}
+	return events, nil
+}
+
+// scanSnapshot decodes the comments or events column of an archived
+// ticket into dest, leaving dest empty if the ticket is not archived
+func (r *TicketArchiveRepository) scanSnapshot(ctx context.Context, column string, ticketID int64, dest any) error {
+	var snapshot []byte
+	err := GetDBTX(ctx, r.conn).QueryRow(ctx,
+		"SELECT "+column+" FROM ticket_archive WHERE ticket_id = $1 AND deleted_at IS NULL",
+		ticketID,
+	).Scan(&snapshot)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(snapshot, dest); err != nil {
+		return fmt.Errorf("decode archived %s of ticket %d: %w", column, ticketID, err)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// AuditLogRepository handles database operations for the admin audit log.
+type AuditLogRepository struct {
+	conn        DBTX
+	replica     *ReadReplica
+	countBudget time.Duration
+}
+
+var _ ports.AuditLogRepository = (*AuditLogRepository)(nil)
+
+// NewAuditLogRepository creates a new audit log repository.
+func NewAuditLogRepository(conn DBTX, opts ...RepositoryOption) ports.AuditLogRepository {
+	o := applyRepositoryOptions(opts)
+	return &AuditLogRepository{conn: conn, replica: o.replica, countBudget: o.countBudget}
+}
+
+// Create stores an audit entry.
+func (r *AuditLogRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	const query = `
+INSERT INTO audit_log (organization_id, actor_id, impersonator_id, action, target_type, target_id, before_value, after_value, request_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, created_at
+`
+
+	var createdAt pgtype.Timestamptz
+	err := GetDBTX(ctx, r.conn).QueryRow(ctx, query,
+		entry.OrganizationID,
+		entry.ActorID,
+		nullableUUID(entry.ImpersonatorID),
+		string(entry.Action),
+		entry.TargetType,
+		entry.TargetID,
+		nullableJSON(entry.Before),
+		nullableJSON(entry.After),
+		entry.RequestID,
+	).Scan(&entry.ID, &createdAt)
+	if err != nil {
+		return err
+	}
+	entry.CreatedAt = createdAt.Time
+	return nil
+}
+
+// List returns a page of the organization's audit entries, newest first,
+// along with how many entries match the filter in total.
+func (r *AuditLogRepository) List(ctx context.Context, orgID uuid.UUID, filter domain.AuditLogFilter) ([]*domain.AuditEntry, domain.Total, error) {
+	conditions, args := auditLogConditions(orgID, filter)
+	where := strings.Join(conditions, " AND ")
+
+	q := GetReadDBTX(ctx, r.conn, r.replica)
+
+	total, err := countRows(ctx, q, r.countBudget, "FROM audit_log WHERE "+where, args...)
+	if err != nil {
+		return nil, domain.Total{}, err
+	}
+
+	query := fmt.Sprintf(`
+SELECT id, organization_id, actor_id, impersonator_id, action, target_type, target_id, before_value, after_value, request_id, created_at
+FROM audit_log
+WHERE %s
+ORDER BY created_at DESC, id DESC
+LIMIT $%d OFFSET $%d
+`, where, len(args)+1, len(args)+2)
+
+	rows, err := q.Query(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, domain.Total{}, err
+	}
+
+	entries, err := scanAuditEntries(rows)
+	if err != nil {
+		return nil, domain.Total{}, err
+	}
+	return entries, total, nil
+}
+
+// ListPage returns a keyset page of the organization's audit entries,
+// newest first. Limit and Offset in filter are ignored in favor of page.
+func (r *AuditLogRepository) ListPage(ctx context.Context, orgID uuid.UUID, filter domain.AuditLogFilter, page domain.PageRequest) (domain.Page[*domain.AuditEntry], error) {
+	conditions, args := auditLogConditions(orgID, filter)
+	after, args := auditLogKeyset.after(page.After, args)
+	if after != "" {
+		conditions = append(conditions, after)
+	}
+	args = append(args, auditLogKeyset.limit(page))
+
+	query := fmt.Sprintf(`
+SELECT id, organization_id, actor_id, impersonator_id, action, target_type, target_id, before_value, after_value, request_id, created_at
+FROM audit_log
+WHERE %s
+ORDER BY %s
+LIMIT $%d
+`, strings.Join(conditions, " AND "), auditLogKeyset.orderBy(), len(args))
+
+	rows, err := GetReadDBTX(ctx, r.conn, r.replica).Query(ctx, query, args...)
+	if err != nil {
+		return domain.Page[*domain.AuditEntry]{}, err
+	}
+
+	entries, err := scanAuditEntries(rows)
+	if err != nil {
+		return domain.Page[*domain.AuditEntry]{}, err
+	}
+	return keysetPage(entries, page, func(e *domain.AuditEntry) domain.PageCursor {
+		return domain.PageCursor{CreatedAt: e.CreatedAt, ID: strconv.FormatInt(e.ID, 10)}
+	}), nil
+}
+
+// auditLogConditions returns the WHERE conditions and their arguments that
+// select the organization's entries matching filter.
+func auditLogConditions(orgID uuid.UUID, filter domain.AuditLogFilter) ([]string, []any) {
+	conditions := []string{"organization_id = $1"}
+	args := []any{orgID}
+	addCondition := func(format string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if filter.Action != nil {
+		addCondition("action = $%d", string(*filter.Action))
+	}
+	if filter.ActorID != nil {
+		addCondition("actor_id = $%d", *filter.ActorID)
+	}
+	if filter.TargetID != nil {
+		addCondition("target_id = $%d", *filter.TargetID)
+	}
+	if filter.From != nil {
+		addCondition("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		addCondition("created_at <= $%d", *filter.To)
+	}
+	return conditions, args
+}
+
+// scanAuditEntries reads audit entries from rows and closes them.
+func scanAuditEntries(rows pgx.Rows) ([]*domain.AuditEntry, error) {
+	defer rows.Close()
+
+	entries := make([]*domain.AuditEntry, 0)
+	for rows.Next() {
+		var (
+			entry          domain.AuditEntry
+			impersonatorID pgtype.UUID
+			action         string
+			createdAt      pgtype.Timestamptz
+		)
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.OrganizationID,
+			&entry.ActorID,
+			&impersonatorID,
+			&action,
+			&entry.TargetType,
+			&entry.TargetID,
+			&entry.Before,
+			&entry.After,
+			&entry.RequestID,
+			&createdAt,
+		); err != nil {
+			return nil, err
+		}
+		if impersonatorID.Valid {
+			id := uuid.UUID(impersonatorID.Bytes)
+			entry.ImpersonatorID = &id
+		}
+		entry.Action = domain.AuditAction(action)
+		entry.CreatedAt = createdAt.Time
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// nullableJSON stores empty JSON as NULL.
+func nullableJSON(value []byte) any {
+	if len(value) == 0 {
+		return nil
+	}
+	return value
+}
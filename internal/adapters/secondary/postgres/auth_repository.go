@@ -6,7 +6,6 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/lorrc/service-desk-backend/internal/adapters/secondary/postgres/db"
 	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
 	"github.com/lorrc/service-desk-backend/internal/core/ports"
@@ -14,25 +13,21 @@ import (
 
 // AuthorizationRepository handles database operations for RBAC.
 type AuthorizationRepository struct {
-	q    db.Querier
-	dbtx db.DBTX
+	conn DBTX
 }
 
 // Ensure implementation matches the interface.
 var _ ports.AuthorizationRepository = (*AuthorizationRepository)(nil)
 
 // NewAuthorizationRepository creates a new repository for authorization queries.
-func NewAuthorizationRepository(pool *pgxpool.Pool) ports.AuthorizationRepository {
-	return &AuthorizationRepository{
-		q:    db.New(pool),
-		dbtx: pool,
-	}
+func NewAuthorizationRepository(conn DBTX) ports.AuthorizationRepository {
+	return &AuthorizationRepository{conn: conn}
 }
 
 // GetUserPermissions fetches all distinct permissions for a given user ID.
 func (r *AuthorizationRepository) GetUserPermissions(ctx context.Context, userID uuid.UUID) ([]string, error) {
 	pgUUID := pgtype.UUID{Bytes: userID, Valid: true}
-	permissions, err := r.q.GetUserPermissions(ctx, pgUUID)
+	permissions, err := r.querier(ctx).GetUserPermissions(ctx, pgUUID)
 	if err != nil {
 		return nil, err
 	}
@@ -47,7 +42,7 @@ func (r *AuthorizationRepository) AssignRole(ctx context.Context, userID uuid.UU
 	}
 
 	for attempt := 0; attempt < 2; attempt++ {
-		status, err := r.q.AssignRole(ctx, params)
+		status, err := r.querier(ctx).AssignRole(ctx, params)
 		if err != nil {
 			return err
 		}
@@ -85,7 +80,7 @@ func (r *AuthorizationRepository) SetUserRole(ctx context.Context, userID uuid.U
 	}
 
 	for attempt := 0; attempt < 2; attempt++ {
-		status, err := r.q.SetUserRole(ctx, params)
+		status, err := r.querier(ctx).SetUserRole(ctx, params)
 		if err != nil {
 			return err
 		}
@@ -108,6 +103,39 @@ func (r *AuthorizationRepository) SetUserRole(ctx context.Context, userID uuid.U
 	return apperrors.ErrRoleNotFound
 }
 
+// GetUserRoles returns the names of the user's roles.
+func (r *AuthorizationRepository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	const query = `
+SELECT r.name
+FROM user_roles ur
+JOIN roles r ON r.id = ur.role_id
+WHERE ur.user_id = $1
+ORDER BY r.name
+`
+
+	rows, err := GetDBTX(ctx, r.conn).Query(ctx, query, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := make([]string, 0, 1)
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+
+	return roles, rows.Err()
+}
+
+// querier returns queries bound to the transaction in ctx, if there is one.
+func (r *AuthorizationRepository) querier(ctx context.Context) db.Querier {
+	return db.New(GetDBTX(ctx, r.conn))
+}
+
 func (r *AuthorizationRepository) EnsureRBACDefaults(ctx context.Context) error {
 	return r.ensureRBACDefaults(ctx)
 }
@@ -119,35 +147,43 @@ func (r *AuthorizationRepository) ensureRBACDefaults(ctx context.Context) error
 			('tickets:read'),
 			('tickets:read:all'),
 			('tickets:update:status'),
+			('tickets:update'),
 			('tickets:assign'),
+			('tickets:claim'),
 			('tickets:list:all'),
 			('comments:create'),
 			('comments:read'),
-			('admin:access')
+			('macros:apply'),
+			('admin:access'),
+			('platform:access')
 		ON CONFLICT DO NOTHING;`,
-		`INSERT INTO roles (name) VALUES ('admin'), ('agent'), ('customer')
+		`INSERT INTO roles (name) VALUES ('superadmin'), ('admin'), ('agent'), ('customer'), ('guest')
 		ON CONFLICT DO NOTHING;`,
 		`INSERT INTO role_permissions (role_id, permission_id)
-		SELECT r.id, p.id FROM roles r, permissions p WHERE r.name = 'admin'
+		SELECT r.id, p.id FROM roles r, permissions p WHERE r.name = 'superadmin'
+		ON CONFLICT DO NOTHING;`,
+		`INSERT INTO role_permissions (role_id, permission_id)
+		SELECT r.id, p.id FROM roles r, permissions p
+		WHERE r.name = 'admin' AND p.code <> 'platform:access'
 		ON CONFLICT DO NOTHING;`,
 		`INSERT INTO role_permissions (role_id, permission_id)
 		SELECT r.id, p.id FROM roles r, permissions p
 		WHERE r.name = 'agent' AND p.code IN (
 			'tickets:create', 'tickets:read', 'tickets:read:all',
-			'tickets:update:status', 'tickets:assign', 'tickets:list:all',
-			'comments:create', 'comments:read'
+			'tickets:update:status', 'tickets:update', 'tickets:assign', 'tickets:claim', 'tickets:list:all',
+			'comments:create', 'comments:read', 'macros:apply'
 		)
 		ON CONFLICT DO NOTHING;`,
 		`INSERT INTO role_permissions (role_id, permission_id)
 		SELECT r.id, p.id FROM roles r, permissions p
-		WHERE r.name = 'customer' AND p.code IN (
+		WHERE r.name IN ('customer', 'guest') AND p.code IN (
 			'tickets:create', 'tickets:read', 'comments:create', 'comments:read'
 		)
 		ON CONFLICT DO NOTHING;`,
 	}
 
 	for _, stmt := range statements {
-		if _, err := r.dbtx.Exec(ctx, stmt); err != nil {
+		if _, err := GetDBTX(ctx, r.conn).Exec(ctx, stmt); err != nil {
 			return fmt.Errorf("seed rbac defaults: %w", err)
 		}
 	}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// reserveIDs takes n values from the sequence behind table's id column, so
+// rows copied in bulk can be given their IDs before they are written.
+func reserveIDs(ctx context.Context, q DBTX, table string, n int) ([]int64, error) {
+	rows, err := q.Query(ctx,
+		"SELECT nextval(pg_get_serial_sequence($1, 'id')) FROM generate_series(1, $2)",
+		table, n,
+	)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) != n {
+		return nil, fmt.Errorf("reserved %d %s ids, want %d", len(ids), table, n)
+	}
+	return ids, nil
+}
+
+// enqueueTicketsForSearch queues tickets for indexing, like
+// SearchQueueRepository.Enqueue, for rows written in bulk without events.
+func enqueueTicketsForSearch(ctx context.Context, q DBTX, ticketIDs []int64) error {
+	const enqueue = `
+INSERT INTO search_index_queue (ticket_id, organization_id)
+SELECT id, organization_id FROM tickets WHERE id = ANY($1::bigint[])
+ON CONFLICT (ticket_id) DO UPDATE
+SET enqueued_at = NOW(), available_at = NOW()
+`
+
+	_, err := q.Exec(ctx, enqueue, ticketIDs)
+	return err
+}
+
+// nullableUUID stores a missing UUID as NULL
+func nullableUUID(id *uuid.UUID) pgtype.UUID {
+	if id == nil {
+		return pgtype.UUID{}
+	}
+	return pgtype.UUID{Bytes: *id, Valid: true}
+}
+
+// nullableTimestamptz stores a missing time as NULL
+func nullableTimestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lorrc/service-desk-backend/internal/infrastructure/circuitbreaker"
+)
+
+// UseCircuitBreaker makes a pool fail fast with circuitbreaker.ErrOpen
+// while breaker is open, instead of waiting on connections to a database
+// that is down. Connection failures and timeouts count against the
+// breaker; errors the server reports about a statement don't.
+func UseCircuitBreaker(config *pgxpool.Config, breaker *circuitbreaker.Breaker) {
+	beforeConnect := config.BeforeConnect
+	config.BeforeConnect = func(ctx context.Context, connConfig *pgx.ConnConfig) error {
+		if err := breaker.Allow(); err != nil {
+			return err
+		}
+		if beforeConnect != nil {
+			return beforeConnect(ctx, connConfig)
+		}
+		return nil
+	}
+
+	prepareConn := config.PrepareConn
+	config.PrepareConn = func(ctx context.Context, conn *pgx.Conn) (bool, error) {
+		if err := breaker.Allow(); err != nil {
+			// Keep the connection; it may well be fine once the breaker closes
+			return true, err
+		}
+		if prepareConn != nil {
+			return prepareConn(ctx, conn)
+		}
+		return true, nil
+	}
+
+	config.ConnConfig.Tracer = &breakerTracer{breaker: breaker}
+}
+
+// breakerTracer reports the outcome of connects and queries to a breaker.
+type breakerTracer struct {
+	breaker *circuitbreaker.Breaker
+}
+
+func (t *breakerTracer) TraceConnectStart(ctx context.Context, _ pgx.TraceConnectStartData) context.Context {
+	return ctx
+}
+
+func (t *breakerTracer) TraceConnectEnd(_ context.Context, data pgx.TraceConnectEndData) {
+	t.record(data.Err)
+}
+
+func (t *breakerTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	return ctx
+}
+
+func (t *breakerTracer) TraceQueryEnd(_ context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	t.record(data.Err)
+}
+
+func (t *breakerTracer) record(err error) {
+	switch {
+	case err == nil:
+		t.breaker.Success()
+	case isUnavailable(err):
+		t.breaker.Failure()
+	case isServerError(err):
+		// The server answered, so it's up
+		t.breaker.Success()
+	}
+}
+
+// isUnavailable reports whether err means the database could not be
+// reached or did not answer in time.
+func isUnavailable(err error) bool {
+	if errors.Is(err, circuitbreaker.ErrOpen) || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// Connection exceptions, shutdowns and too many connections
+		switch {
+		case len(pgErr.Code) == 5 && pgErr.Code[:2] == "08",
+			pgErr.Code == "57P01", pgErr.Code == "57P02", pgErr.Code == "57P03",
+			pgErr.Code == "53300":
+			return true
+		}
+		return false
+	}
+
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	return errors.As(err, &connectErr) ||
+		errors.As(err, &netErr) ||
+		pgconn.Timeout(err) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+func isServerError(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr)
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// CommentDraftRepository persists users' unposted replies to tickets.
+type CommentDraftRepository struct {
+	conn DBTX
+}
+
+var _ ports.CommentDraftRepository = (*CommentDraftRepository)(nil)
+
+// NewCommentDraftRepository creates a new comment draft repository.
+func NewCommentDraftRepository(conn DBTX) ports.CommentDraftRepository {
+	return &CommentDraftRepository{conn: conn}
+}
+
+const commentDraftColumns = "ticket_id, user_id, organization_id, body, updated_at"
+
+func scanCommentDraft(row pgx.Row) (*domain.CommentDraft, error) {
+	var (
+		d         domain.CommentDraft
+		updatedAt pgtype.Timestamptz
+	)
+	if err := row.Scan(
+		&d.TicketID,
+		&d.UserID,
+		&d.OrganizationID,
+		&d.Body,
+		&updatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	d.UpdatedAt = updatedAt.Time
+	return &d, nil
+}
+
+// Upsert stores a draft, replacing the user's existing one on the ticket.
+func (r *CommentDraftRepository) Upsert(ctx context.Context, draft *domain.CommentDraft) (*domain.CommentDraft, error) {
+	row := GetDBTX(ctx, r.conn).QueryRow(ctx, `
+INSERT INTO comment_drafts (ticket_id, user_id, organization_id, body, updated_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (ticket_id, user_id)
+DO UPDATE SET body = EXCLUDED.body,
+              updated_at = EXCLUDED.updated_at
+RETURNING `+commentDraftColumns,
+		draft.TicketID,
+		draft.UserID,
+		draft.OrganizationID,
+		draft.Body,
+		draft.UpdatedAt,
+	)
+	return scanCommentDraft(row)
+}
+
+// Get retrieves a user's draft on a ticket.
+func (r *CommentDraftRepository) Get(ctx context.Context, ticketID int64, userID uuid.UUID) (*domain.CommentDraft, error) {
+	row := GetDBTX(ctx, r.conn).QueryRow(ctx,
+		"SELECT "+commentDraftColumns+" FROM comment_drafts WHERE ticket_id = $1 AND user_id = $2", ticketID, userID)
+	draft, err := scanCommentDraft(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.ErrCommentDraftNotFound
+	}
+	return draft, err
+}
+
+// Delete removes a user's draft on a ticket, if there is one.
+func (r *CommentDraftRepository) Delete(ctx context.Context, ticketID int64, userID uuid.UUID) error {
+	_, err := GetDBTX(ctx, r.conn).Exec(ctx, "DELETE FROM comment_drafts WHERE ticket_id = $1 AND user_id = $2", ticketID, userID)
+	return err
+}
@@ -2,9 +2,13 @@ package postgres
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/lorrc/service-desk-backend/internal/adapters/secondary/postgres/db"
 	"github.com/lorrc/service-desk-backend/internal/core/domain"
 	"github.com/lorrc/service-desk-backend/internal/core/ports"
@@ -12,16 +16,16 @@ import (
 
 // CommentRepository handles database operations for comments.
 type CommentRepository struct {
-	pool *pgxpool.Pool
+	conn DBTX
 }
 
 // Ensure implementation matches the interface.
 var _ ports.CommentRepository = (*CommentRepository)(nil)
 
 // NewCommentRepository creates a new comment repository.
-func NewCommentRepository(pool *pgxpool.Pool) ports.CommentRepository {
+func NewCommentRepository(conn DBTX) ports.CommentRepository {
 	return &CommentRepository{
-		pool: pool,
+		conn: conn,
 	}
 }
 
@@ -32,17 +36,19 @@ func mapDBCommentToDomain(dbComment db.Comment) *domain.Comment {
 		TicketID:  dbComment.TicketID,
 		AuthorID:  dbComment.AuthorID.Bytes,
 		Body:      dbComment.Body,
+		BodyHTML:  dbComment.BodyHtml,
 		CreatedAt: dbComment.CreatedAt.Time,
 	}
 }
 
 // Create persists a new comment to the database.
 func (r *CommentRepository) Create(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
-	q := db.New(GetDBTX(ctx, r.pool))
+	q := db.New(GetDBTX(ctx, r.conn))
 	params := db.CreateCommentParams{
 		TicketID: comment.TicketID,
 		AuthorID: pgtype.UUID{Bytes: comment.AuthorID, Valid: true},
 		Body:     comment.Body,
+		BodyHtml: comment.BodyHTML,
 	}
 
 	dbComment, err := q.CreateComment(ctx, params)
@@ -52,9 +58,74 @@ func (r *CommentRepository) Create(ctx context.Context, comment *domain.Comment)
 	return mapDBCommentToDomain(dbComment), nil
 }
 
+// CreateBatch inserts comments with a single COPY, setting the ID of each
+// from the comments sequence, and queues their tickets for search
+// indexing. Unlike Create it keeps their CreatedAt, so imported comments
+// keep their history; a zero CreatedAt is set to now. First responses are
+// not marked.
+func (r *CommentRepository) CreateBatch(ctx context.Context, comments []*domain.Comment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+	q := GetDBTX(ctx, r.conn)
+
+	ids, err := reserveIDs(ctx, q, "comments", len(comments))
+	if err != nil {
+		return fmt.Errorf("reserving comment ids: %w", err)
+	}
+
+	now := time.Now().UTC()
+	rows := make([][]any, len(comments))
+	ticketIDs := make([]int64, 0, len(comments))
+	seen := make(map[int64]bool, len(comments))
+	for i, comment := range comments {
+		comment.ID = ids[i]
+		if comment.CreatedAt.IsZero() {
+			comment.CreatedAt = now
+		}
+		rows[i] = []any{
+			comment.ID,
+			comment.TicketID,
+			pgtype.UUID{Bytes: comment.AuthorID, Valid: true},
+			comment.Body,
+			comment.BodyHTML,
+			pgtype.Timestamptz{Time: comment.CreatedAt, Valid: true},
+		}
+		if !seen[comment.TicketID] {
+			seen[comment.TicketID] = true
+			ticketIDs = append(ticketIDs, comment.TicketID)
+		}
+	}
+
+	columns := []string{"id", "ticket_id", "author_id", "body", "body_html", "created_at"}
+	if _, err := q.CopyFrom(ctx, pgx.Identifier{"comments"}, columns, pgx.CopyFromRows(rows)); err != nil {
+		return fmt.Errorf("copying comments: %w", err)
+	}
+	return enqueueTicketsForSearch(ctx, q, ticketIDs)
+}
+
+// MarkFirstResponse stamps the ticket with the comment as its first response,
+// leaving tickets that already have one untouched.
+func (r *CommentRepository) MarkFirstResponse(ctx context.Context, comment *domain.Comment) error {
+	const query = `
+UPDATE tickets
+SET first_response_at = $2, first_response_by = $3
+WHERE id = $1
+  AND first_response_at IS NULL
+  AND deleted_at IS NULL
+`
+
+	_, err := GetDBTX(ctx, r.conn).Exec(ctx, query,
+		comment.TicketID,
+		pgtype.Timestamptz{Time: comment.CreatedAt, Valid: true},
+		pgtype.UUID{Bytes: comment.AuthorID, Valid: true},
+	)
+	return err
+}
+
 // ListByTicketID retrieves all comments for a specific ticket, ordered by creation.
 func (r *CommentRepository) ListByTicketID(ctx context.Context, ticketID int64) ([]*domain.Comment, error) {
-	q := db.New(GetDBTX(ctx, r.pool))
+	q := db.New(GetDBTX(ctx, r.conn))
 	dbComments, err := q.ListCommentsByTicketID(ctx, ticketID)
 	if err != nil {
 		return nil, err
@@ -66,3 +137,62 @@ func (r *CommentRepository) ListByTicketID(ctx context.Context, ticketID int64)
 	}
 	return comments, nil
 }
+
+// ListLatestByTicketIDs retrieves the most recent comment on each of the
+// given tickets. Tickets without comments are left out.
+func (r *CommentRepository) ListLatestByTicketIDs(ctx context.Context, ticketIDs []int64) ([]*domain.Comment, error) {
+	q := db.New(GetDBTX(ctx, r.conn))
+	dbComments, err := q.ListLatestCommentsByTicketIDs(ctx, ticketIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]*domain.Comment, len(dbComments))
+	for i, dbComment := range dbComments {
+		comments[i] = mapDBCommentToDomain(dbComment)
+	}
+	return comments, nil
+}
+
+// ListPageByTicketID retrieves a keyset page of a ticket's comments, oldest
+// first.
+func (r *CommentRepository) ListPageByTicketID(ctx context.Context, ticketID int64, page domain.PageRequest) (domain.Page[*domain.Comment], error) {
+	conditions := []string{"ticket_id = $1"}
+	args := []any{ticketID}
+
+	after, args := commentKeyset.after(page.After, args)
+	if after != "" {
+		conditions = append(conditions, after)
+	}
+	args = append(args, commentKeyset.limit(page))
+
+	query := fmt.Sprintf(`
+SELECT id, ticket_id, author_id, body, body_html, created_at
+FROM comments
+WHERE %s
+ORDER BY %s
+LIMIT $%d
+`, strings.Join(conditions, " AND "), commentKeyset.orderBy(), len(args))
+
+	rows, err := GetDBTX(ctx, r.conn).Query(ctx, query, args...)
+	if err != nil {
+		return domain.Page[*domain.Comment]{}, err
+	}
+	defer rows.Close()
+
+	comments := make([]*domain.Comment, 0)
+	for rows.Next() {
+		var c db.Comment
+		if err := rows.Scan(&c.ID, &c.TicketID, &c.AuthorID, &c.Body, &c.BodyHtml, &c.CreatedAt); err != nil {
+			return domain.Page[*domain.Comment]{}, err
+		}
+		comments = append(comments, mapDBCommentToDomain(c))
+	}
+	if err := rows.Err(); err != nil {
+		return domain.Page[*domain.Comment]{}, err
+	}
+
+	return keysetPage(comments, page, func(c *domain.Comment) domain.PageCursor {
+		return domain.PageCursor{CreatedAt: c.CreatedAt, ID: strconv.FormatInt(c.ID, 10)}
+	}), nil
+}
@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+)
+
+// WithCountBudget limits how long counting a listing's total may take.
+// Counts running longer are cancelled and replaced by the planner's row
+// estimate, which Postgres derives from pg_class.reltuples and the
+// filter's selectivity. Without it, totals are always exact.
+func WithCountBudget(budget time.Duration) RepositoryOption {
+	return func(o *repositoryOptions) {
+		o.countBudget = budget
+	}
+}
+
+// countRows counts the rows selected by from, a FROM clause with its joins
+// and conditions, within budget. Counts in a transaction are always exact,
+// as cancelling one would abort the transaction.
+func countRows(ctx context.Context, q DBTX, budget time.Duration, from string, args ...any) (domain.Total, error) {
+	if inTransaction(q) || budget <= 0 {
+		return exactCount(ctx, q, from, args...)
+	}
+
+	countCtx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+
+	total, err := exactCount(countCtx, q, from, args...)
+	if err == nil || ctx.Err() != nil || !errors.Is(countCtx.Err(), context.DeadlineExceeded) {
+		return total, err
+	}
+	return estimatedCount(ctx, q, from, args...)
+}
+
+func exactCount(ctx context.Context, q DBTX, from string, args ...any) (domain.Total, error) {
+	var count int64
+	if err := q.QueryRow(ctx, "SELECT COUNT(*) "+from, args...).Scan(&count); err != nil {
+		return domain.Total{}, err
+	}
+	return domain.Total{Count: count}, nil
+}
+
+// estimatedCount returns the planner's estimate of how many rows from
+// selects, without running the query.
+func estimatedCount(ctx context.Context, q DBTX, from string, args ...any) (domain.Total, error) {
+	var plan []byte
+	if err := q.QueryRow(ctx, "EXPLAIN (FORMAT JSON) SELECT 1 "+from, args...).Scan(&plan); err != nil {
+		return domain.Total{}, err
+	}
+
+	var explained []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(plan, &explained); err != nil || len(explained) == 0 {
+		return domain.Total{}, errors.New("postgres: unreadable query plan")
+	}
+	return domain.Total{Count: int64(explained[0].Plan.Rows), Approximate: true}, nil
+}
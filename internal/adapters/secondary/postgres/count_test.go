@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountRows(t *testing.T) {
+	ctx := context.Background()
+	require.NotNil(t, testPool, "testPool is nil. TestMain may not have run.")
+
+	t.Run("counts exactly within the budget", func(t *testing.T) {
+		total, err := countRows(ctx, testPool, time.Second, "FROM generate_series(1, $1::int) g", 42)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.Total{Count: 42}, total)
+	})
+
+	t.Run("counts exactly without a budget", func(t *testing.T) {
+		total, err := countRows(ctx, testPool, 0, "FROM (SELECT pg_sleep(0.1)) s")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.Total{Count: 1}, total)
+	})
+
+	t.Run("estimates when counting runs over the budget", func(t *testing.T) {
+		start := time.Now()
+		total, err := countRows(ctx, testPool, 50*time.Millisecond, "FROM (SELECT pg_sleep(5)) s")
+
+		require.NoError(t, err)
+		assert.True(t, total.Approximate)
+		assert.Equal(t, int64(1), total.Count)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+}
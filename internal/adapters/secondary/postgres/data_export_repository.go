@@ -0,0 +1,342 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// DataExportRepository handles database operations for personal data exports.
+type DataExportRepository struct {
+	conn DBTX
+}
+
+var _ ports.DataExportRepository = (*DataExportRepository)(nil)
+
+// NewDataExportRepository creates a new data export repository.
+func NewDataExportRepository(conn DBTX) ports.DataExportRepository {
+	return &DataExportRepository{conn: conn}
+}
+
+const dataExportColumns = `id, organization_id, user_id, requested_by, status, payload, COALESCE(error, ''), created_at, completed_at, expires_at`
+
+// Create stores a new export request.
+func (r *DataExportRepository) Create(ctx context.Context, export *domain.DataExport) error {
+	const query = `
+INSERT INTO data_exports (id, organization_id, user_id, requested_by, status, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+	_, err := GetDBTX(ctx, r.conn).Exec(ctx, query,
+		export.ID,
+		export.OrganizationID,
+		export.UserID,
+		export.RequestedBy,
+		string(export.Status),
+		export.CreatedAt,
+	)
+	return err
+}
+
+// GetLatest returns the user's most recently requested export.
+func (r *DataExportRepository) GetLatest(ctx context.Context, userID uuid.UUID) (*domain.DataExport, error) {
+	query := `
+SELECT ` + dataExportColumns + `
+FROM data_exports
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT 1
+`
+
+	export, err := scanDataExport(GetDBTX(ctx, r.conn).QueryRow(ctx, query, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.ErrDataExportNotFound
+	}
+	return export, err
+}
+
+// ClaimPending locks the oldest pending export until the transaction in ctx
+// ends. Exports locked by another worker are skipped.
+func (r *DataExportRepository) ClaimPending(ctx context.Context) (*domain.DataExport, error) {
+	query := `
+SELECT ` + dataExportColumns + `
+FROM data_exports
+WHERE status = 'PENDING'
+ORDER BY created_at
+LIMIT 1
+FOR UPDATE SKIP LOCKED
+`
+
+	export, err := scanDataExport(GetDBTX(ctx, r.conn).QueryRow(ctx, query))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return export, err
+}
+
+// MarkReady stores the generated export.
+func (r *DataExportRepository) MarkReady(ctx context.Context, id uuid.UUID, payload []byte, completedAt, expiresAt time.Time) error {
+	const query = `
+UPDATE data_exports
+SET status = 'READY', payload = $2, error = NULL, completed_at = $3, expires_at = $4
+WHERE id = $1
+`
+
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx, query, id, payload, completedAt.UTC(), expiresAt.UTC())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrDataExportNotFound
+	}
+	return nil
+}
+
+// MarkFailed records that an export could not be generated.
+func (r *DataExportRepository) MarkFailed(ctx context.Context, id uuid.UUID, completedAt time.Time, lastErr string) error {
+	const query = `
+UPDATE data_exports
+SET status = 'FAILED', error = $2, completed_at = $3
+WHERE id = $1
+`
+
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx, query, id, lastErr, completedAt.UTC())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrDataExportNotFound
+	}
+	return nil
+}
+
+// DeleteExpired removes exports whose download window has passed, so the
+// personal data they hold is not kept longer than needed.
+func (r *DataExportRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx, "DELETE FROM data_exports WHERE expires_at <= $1", now.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// CollectUserData gathers the user's profile, the tickets they requested or
+// are assigned, their comments and ticket changes, and the audit entries
+// they made or that target them.
+func (r *DataExportRepository) CollectUserData(ctx context.Context, userID uuid.UUID) (*domain.UserData, error) {
+	q := GetDBTX(ctx, r.conn)
+	data := &domain.UserData{
+		Tickets:      make([]domain.UserDataTicket, 0),
+		Comments:     make([]domain.UserDataComment, 0),
+		TicketEvents: make([]domain.UserDataTicketEvent, 0),
+		AuditEvents:  make([]domain.UserDataAuditEvent, 0),
+	}
+
+	if err := r.collectProfile(ctx, q, userID, &data.Profile); err != nil {
+		return nil, err
+	}
+
+	steps := []func(context.Context, DBTX, uuid.UUID, *domain.UserData) error{
+		collectTickets,
+		collectComments,
+		collectTicketEvents,
+		collectAuditEvents,
+	}
+	for _, step := range steps {
+		if err := step(ctx, q, userID, data); err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
+
+func (r *DataExportRepository) collectProfile(ctx context.Context, q DBTX, userID uuid.UUID, profile *domain.UserDataProfile) error {
+	const query = `
+SELECT id, organization_id, full_name, email, COALESCE(phone_number, ''), sms_opt_in, locale, timezone,
+       is_active, created_at, last_active_at, tokens_revoked_at,
+       COALESCE((SELECT ARRAY_AGG(r.name ORDER BY r.name)
+                 FROM user_roles ur JOIN roles r ON r.id = ur.role_id
+                 WHERE ur.user_id = users.id), '{}')
+FROM users
+WHERE id = $1
+`
+
+	var createdAt, lastActiveAt, tokensRevokedAt pgtype.Timestamptz
+	err := q.QueryRow(ctx, query, userID).Scan(
+		&profile.ID,
+		&profile.OrganizationID,
+		&profile.FullName,
+		&profile.Email,
+		&profile.PhoneNumber,
+		&profile.SMSOptIn,
+		&profile.Locale,
+		&profile.Timezone,
+		&profile.IsActive,
+		&createdAt,
+		&lastActiveAt,
+		&tokensRevokedAt,
+		&profile.Roles,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apperrors.ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	profile.CreatedAt = createdAt.Time
+	profile.LastActiveAt = toTimePtr(lastActiveAt)
+	profile.TokensRevokedAt = toTimePtr(tokensRevokedAt)
+	return nil
+}
+
+// collectTickets exports the user's tickets, soft-deleted ones included:
+// they are personal data held until retention removes them.
+func collectTickets(ctx context.Context, q DBTX, userID uuid.UUID, data *domain.UserData) error {
+	const query = `
+SELECT id, title, COALESCE(description, ''), status, priority,
+       CASE WHEN requester_id = $1 THEN 'requester' ELSE 'assignee' END,
+       created_at, updated_at, closed_at
+FROM tickets
+WHERE requester_id = $1 OR assignee_id = $1
+ORDER BY id
+`
+
+	rows, err := q.Query(ctx, query, userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			ticket                         domain.UserDataTicket
+			createdAt, updatedAt, closedAt pgtype.Timestamptz
+		)
+		if err := rows.Scan(
+			&ticket.ID,
+			&ticket.Title,
+			&ticket.Description,
+			&ticket.Status,
+			&ticket.Priority,
+			&ticket.Role,
+			&createdAt,
+			&updatedAt,
+			&closedAt,
+		); err != nil {
+			return err
+		}
+		ticket.CreatedAt = createdAt.Time
+		ticket.UpdatedAt = toTimePtr(updatedAt)
+		ticket.ClosedAt = toTimePtr(closedAt)
+		data.Tickets = append(data.Tickets, ticket)
+	}
+	return rows.Err()
+}
+
+func collectComments(ctx context.Context, q DBTX, userID uuid.UUID, data *domain.UserData) error {
+	rows, err := q.Query(ctx, "SELECT id, ticket_id, body, created_at FROM comments WHERE author_id = $1 ORDER BY id", userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			comment   domain.UserDataComment
+			createdAt pgtype.Timestamptz
+		)
+		if err := rows.Scan(&comment.ID, &comment.TicketID, &comment.Body, &createdAt); err != nil {
+			return err
+		}
+		comment.CreatedAt = createdAt.Time
+		data.Comments = append(data.Comments, comment)
+	}
+	return rows.Err()
+}
+
+func collectTicketEvents(ctx context.Context, q DBTX, userID uuid.UUID, data *domain.UserData) error {
+	rows, err := q.Query(ctx, "SELECT id, ticket_id, type, payload, created_at FROM ticket_events WHERE actor_id = $1 ORDER BY id", userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			event     domain.UserDataTicketEvent
+			createdAt pgtype.Timestamptz
+		)
+		if err := rows.Scan(&event.ID, &event.TicketID, &event.Type, &event.Payload, &createdAt); err != nil {
+			return err
+		}
+		event.CreatedAt = createdAt.Time
+		data.TicketEvents = append(data.TicketEvents, event)
+	}
+	return rows.Err()
+}
+
+func collectAuditEvents(ctx context.Context, q DBTX, userID uuid.UUID, data *domain.UserData) error {
+	const query = `
+SELECT action, actor_id, target_id, created_at
+FROM audit_log
+WHERE actor_id = $1 OR (target_type = $2 AND target_id = $3)
+ORDER BY created_at, id
+`
+
+	rows, err := q.Query(ctx, query, userID, domain.AuditTargetUser, userID.String())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			event     domain.UserDataAuditEvent
+			action    string
+			createdAt pgtype.Timestamptz
+		)
+		if err := rows.Scan(&action, &event.ActorID, &event.TargetID, &createdAt); err != nil {
+			return err
+		}
+		event.Action = domain.AuditAction(action)
+		event.CreatedAt = createdAt.Time
+		data.AuditEvents = append(data.AuditEvents, event)
+	}
+	return rows.Err()
+}
+
+func scanDataExport(row pgx.Row) (*domain.DataExport, error) {
+	var (
+		export                           domain.DataExport
+		status                           string
+		createdAt, completedAt, expireAt pgtype.Timestamptz
+	)
+	if err := row.Scan(
+		&export.ID,
+		&export.OrganizationID,
+		&export.UserID,
+		&export.RequestedBy,
+		&status,
+		&export.Payload,
+		&export.Error,
+		&createdAt,
+		&completedAt,
+		&expireAt,
+	); err != nil {
+		return nil, err
+	}
+	export.Status = domain.DataExportStatus(status)
+	export.CreatedAt = createdAt.Time
+	export.CompletedAt = toTimePtr(completedAt)
+	export.ExpiresAt = toTimePtr(expireAt)
+	return &export, nil
+}
@@ -12,19 +12,25 @@ import (
 )
 
 const createComment = `-- name: CreateComment :one
-INSERT INTO comments (ticket_id, author_id, body)
-VALUES ($1, $2, $3)
-RETURNING id, ticket_id, author_id, body, created_at
+INSERT INTO comments (ticket_id, author_id, body, body_html)
+VALUES ($1, $2, $3, $4)
+RETURNING id, ticket_id, author_id, body, created_at, body_html
 `
 
 type CreateCommentParams struct {
 	TicketID int64       `json:"ticket_id"`
 	AuthorID pgtype.UUID `json:"author_id"`
 	Body     string      `json:"body"`
+	BodyHtml string      `json:"body_html"`
 }
 
 func (q *Queries) CreateComment(ctx context.Context, arg CreateCommentParams) (Comment, error) {
-	row := q.db.QueryRow(ctx, createComment, arg.TicketID, arg.AuthorID, arg.Body)
+	row := q.db.QueryRow(ctx, createComment,
+		arg.TicketID,
+		arg.AuthorID,
+		arg.Body,
+		arg.BodyHtml,
+	)
 	var i Comment
 	err := row.Scan(
 		&i.ID,
@@ -32,12 +38,13 @@ func (q *Queries) CreateComment(ctx context.Context, arg CreateCommentParams) (C
 		&i.AuthorID,
 		&i.Body,
 		&i.CreatedAt,
+		&i.BodyHtml,
 	)
 	return i, err
 }
 
 const listCommentsByTicketID = `-- name: ListCommentsByTicketID :many
-SELECT id, ticket_id, author_id, body, created_at FROM comments
+SELECT id, ticket_id, author_id, body, created_at, body_html FROM comments
 WHERE ticket_id = $1
 ORDER BY created_at ASC
 `
@@ -57,6 +64,40 @@ func (q *Queries) ListCommentsByTicketID(ctx context.Context, ticketID int64) ([
 			&i.AuthorID,
 			&i.Body,
 			&i.CreatedAt,
+			&i.BodyHtml,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLatestCommentsByTicketIDs = `-- name: ListLatestCommentsByTicketIDs :many
+SELECT DISTINCT ON (ticket_id) id, ticket_id, author_id, body, created_at, body_html FROM comments
+WHERE ticket_id = ANY($1::bigint[])
+ORDER BY ticket_id, created_at DESC, id DESC
+`
+
+func (q *Queries) ListLatestCommentsByTicketIDs(ctx context.Context, ticketIds []int64) ([]Comment, error) {
+	rows, err := q.db.Query(ctx, listLatestCommentsByTicketIDs, ticketIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Comment
+	for rows.Next() {
+		var i Comment
+		if err := rows.Scan(
+			&i.ID,
+			&i.TicketID,
+			&i.AuthorID,
+			&i.Body,
+			&i.CreatedAt,
+			&i.BodyHtml,
 		); err != nil {
 			return nil, err
 		}
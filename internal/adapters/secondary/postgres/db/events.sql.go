@@ -12,14 +12,15 @@ import (
 )
 
 const createTicketEvent = `-- name: CreateTicketEvent :one
-INSERT INTO ticket_events (ticket_id, type, payload, actor_id)
-VALUES ($1, $2, $3, $4)
-RETURNING id, ticket_id, type, payload, actor_id, created_at
+INSERT INTO ticket_events (ticket_id, type, version, payload, actor_id)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, ticket_id, type, payload, actor_id, created_at, version
 `
 
 type CreateTicketEventParams struct {
 	TicketID int64       `json:"ticket_id"`
 	Type     string      `json:"type"`
+	Version  int16       `json:"version"`
 	Payload  []byte      `json:"payload"`
 	ActorID  pgtype.UUID `json:"actor_id"`
 }
@@ -28,6 +29,7 @@ func (q *Queries) CreateTicketEvent(ctx context.Context, arg CreateTicketEventPa
 	row := q.db.QueryRow(ctx, createTicketEvent,
 		arg.TicketID,
 		arg.Type,
+		arg.Version,
 		arg.Payload,
 		arg.ActorID,
 	)
@@ -39,12 +41,13 @@ func (q *Queries) CreateTicketEvent(ctx context.Context, arg CreateTicketEventPa
 		&i.Payload,
 		&i.ActorID,
 		&i.CreatedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const listTicketEvents = `-- name: ListTicketEvents :many
-SELECT id, ticket_id, type, payload, actor_id, created_at FROM ticket_events
+SELECT id, ticket_id, type, payload, actor_id, created_at, version FROM ticket_events
 WHERE ticket_id = $1
   AND id > $2
 ORDER BY id ASC
@@ -73,6 +76,7 @@ func (q *Queries) ListTicketEvents(ctx context.Context, arg ListTicketEventsPara
 			&i.Payload,
 			&i.ActorID,
 			&i.CreatedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
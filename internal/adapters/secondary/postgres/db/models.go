@@ -14,6 +14,7 @@ type Comment struct {
 	AuthorID  pgtype.UUID        `json:"author_id"`
 	Body      string             `json:"body"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	BodyHtml  string             `json:"body_html"`
 }
 
 type Organization struct {
@@ -38,16 +39,23 @@ type RolePermission struct {
 }
 
 type Ticket struct {
-	ID          int64              `json:"id"`
-	Title       string             `json:"title"`
-	Description pgtype.Text        `json:"description"`
-	Status      string             `json:"status"`
-	Priority    string             `json:"priority"`
-	RequesterID pgtype.UUID        `json:"requester_id"`
-	AssigneeID  pgtype.UUID        `json:"assignee_id"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
-	ClosedAt    pgtype.Timestamptz `json:"closed_at"`
+	ID                       int64              `json:"id"`
+	Title                    string             `json:"title"`
+	Description              pgtype.Text        `json:"description"`
+	Status                   string             `json:"status"`
+	Priority                 string             `json:"priority"`
+	RequesterID              pgtype.UUID        `json:"requester_id"`
+	AssigneeID               pgtype.UUID        `json:"assignee_id"`
+	CreatedAt                pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt                pgtype.Timestamptz `json:"updated_at"`
+	ClosedAt                 pgtype.Timestamptz `json:"closed_at"`
+	TeamID                   pgtype.UUID        `json:"team_id"`
+	DueAt                    pgtype.Timestamptz `json:"due_at"`
+	ResolutionWorkingSeconds pgtype.Float8      `json:"resolution_working_seconds"`
+	Tags                     []string           `json:"tags"`
+	OrganizationID           pgtype.UUID        `json:"organization_id"`
+	DescriptionHtml          pgtype.Text        `json:"description_html"`
+	Visibility               string             `json:"visibility"`
 }
 
 type TicketEvent struct {
@@ -57,17 +65,24 @@ type TicketEvent struct {
 	Payload   []byte             `json:"payload"`
 	ActorID   pgtype.UUID        `json:"actor_id"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	Version   int16              `json:"version"`
 }
 
 type User struct {
-	ID             pgtype.UUID        `json:"id"`
-	OrganizationID pgtype.UUID        `json:"organization_id"`
-	FullName       string             `json:"full_name"`
-	Email          string             `json:"email"`
-	HashedPassword string             `json:"hashed_password"`
-	CreatedAt      pgtype.Timestamptz `json:"created_at"`
-	IsActive       bool               `json:"is_active"`
-	LastActiveAt   pgtype.Timestamptz `json:"last_active_at"`
+	ID               pgtype.UUID        `json:"id"`
+	OrganizationID   pgtype.UUID        `json:"organization_id"`
+	FullName         string             `json:"full_name"`
+	Email            string             `json:"email"`
+	HashedPassword   string             `json:"hashed_password"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	IsActive         bool               `json:"is_active"`
+	LastActiveAt     pgtype.Timestamptz `json:"last_active_at"`
+	TokensRevokedAt  pgtype.Timestamptz `json:"tokens_revoked_at"`
+	PhoneNumber      pgtype.Text        `json:"phone_number"`
+	SmsOptIn         bool               `json:"sms_opt_in"`
+	Locale           string             `json:"locale"`
+	Timezone         string             `json:"timezone"`
+	ReassignWhenAway bool               `json:"reassign_when_away"`
 }
 
 type UserRole struct {
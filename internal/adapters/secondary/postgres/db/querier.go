@@ -17,14 +17,18 @@ type Querier interface {
 	CreateTicket(ctx context.Context, arg CreateTicketParams) (Ticket, error)
 	CreateTicketEvent(ctx context.Context, arg CreateTicketEventParams) (TicketEvent, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
-	GetTicketByID(ctx context.Context, id int64) (Ticket, error)
-	GetUserByEmail(ctx context.Context, email string) (User, error)
+	GetTicketByID(ctx context.Context, arg GetTicketByIDParams) (Ticket, error)
+	GetUserByEmail(ctx context.Context, arg GetUserByEmailParams) (User, error)
 	GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
 	GetUserPermissions(ctx context.Context, userID pgtype.UUID) ([]string, error)
 	ListCommentsByTicketID(ctx context.Context, ticketID int64) ([]Comment, error)
+	ListLatestCommentsByTicketIDs(ctx context.Context, ticketIds []int64) ([]Comment, error)
 	ListTicketEvents(ctx context.Context, arg ListTicketEventsParams) ([]TicketEvent, error)
+	ListOpenTicketsByAssignee(ctx context.Context, arg ListOpenTicketsByAssigneeParams) ([]Ticket, error)
+	ListTicketsByAssigneePaginated(ctx context.Context, arg ListTicketsByAssigneePaginatedParams) ([]Ticket, error)
 	ListTicketsByRequesterPaginated(ctx context.Context, arg ListTicketsByRequesterPaginatedParams) ([]Ticket, error)
 	ListTicketsPaginated(ctx context.Context, arg ListTicketsPaginatedParams) ([]Ticket, error)
+	ListUsersByEmail(ctx context.Context, email string) ([]User, error)
 	SetUserRole(ctx context.Context, arg SetUserRoleParams) (string, error)
 	UpdateTicket(ctx context.Context, arg UpdateTicketParams) (Ticket, error)
 }
@@ -11,18 +11,72 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const claimTicket = `-- name: ClaimTicket :one
+UPDATE tickets
+SET
+    assignee_id = $2,
+    updated_at = $3
+WHERE id = $1
+  AND organization_id = $4
+  AND assignee_id IS NULL
+  AND status <> 'CLOSED'
+  AND deleted_at IS NULL
+RETURNING id, title, description, status, priority, requester_id, assignee_id, created_at, updated_at, closed_at, team_id, due_at, resolution_working_seconds, tags, organization_id, description_html, visibility
+`
+
+type ClaimTicketParams struct {
+	ID             int64              `json:"id"`
+	AssigneeID     pgtype.UUID        `json:"assignee_id"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	OrganizationID pgtype.UUID        `json:"organization_id"`
+}
+
+func (q *Queries) ClaimTicket(ctx context.Context, arg ClaimTicketParams) (Ticket, error) {
+	row := q.db.QueryRow(ctx, claimTicket,
+		arg.ID,
+		arg.AssigneeID,
+		arg.UpdatedAt,
+		arg.OrganizationID,
+	)
+	var i Ticket
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.Status,
+		&i.Priority,
+		&i.RequesterID,
+		&i.AssigneeID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ClosedAt,
+		&i.TeamID,
+		&i.DueAt,
+		&i.ResolutionWorkingSeconds,
+		&i.Tags,
+		&i.OrganizationID,
+		&i.DescriptionHtml,
+		&i.Visibility,
+	)
+	return i, err
+}
+
 const createTicket = `-- name: CreateTicket :one
-INSERT INTO tickets (title, description, status, priority, requester_id)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, title, description, status, priority, requester_id, assignee_id, created_at, updated_at, closed_at
+INSERT INTO tickets (title, description, status, priority, requester_id, due_at, organization_id, description_html, visibility)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, title, description, status, priority, requester_id, assignee_id, created_at, updated_at, closed_at, team_id, due_at, resolution_working_seconds, tags, organization_id, description_html, visibility
 `
 
 type CreateTicketParams struct {
-	Title       string      `json:"title"`
-	Description pgtype.Text `json:"description"`
-	Status      string      `json:"status"`
-	Priority    string      `json:"priority"`
-	RequesterID pgtype.UUID `json:"requester_id"`
+	Title           string             `json:"title"`
+	Description     pgtype.Text        `json:"description"`
+	Status          string             `json:"status"`
+	Priority        string             `json:"priority"`
+	RequesterID     pgtype.UUID        `json:"requester_id"`
+	DueAt           pgtype.Timestamptz `json:"due_at"`
+	OrganizationID  pgtype.UUID        `json:"organization_id"`
+	DescriptionHtml pgtype.Text        `json:"description_html"`
+	Visibility      string             `json:"visibility"`
 }
 
 func (q *Queries) CreateTicket(ctx context.Context, arg CreateTicketParams) (Ticket, error) {
@@ -32,6 +86,10 @@ func (q *Queries) CreateTicket(ctx context.Context, arg CreateTicketParams) (Tic
 		arg.Status,
 		arg.Priority,
 		arg.RequesterID,
+		arg.DueAt,
+		arg.OrganizationID,
+		arg.DescriptionHtml,
+		arg.Visibility,
 	)
 	var i Ticket
 	err := row.Scan(
@@ -45,17 +103,32 @@ func (q *Queries) CreateTicket(ctx context.Context, arg CreateTicketParams) (Tic
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ClosedAt,
+		&i.TeamID,
+		&i.DueAt,
+		&i.ResolutionWorkingSeconds,
+		&i.Tags,
+		&i.OrganizationID,
+		&i.DescriptionHtml,
+		&i.Visibility,
 	)
 	return i, err
 }
 
 const getTicketByID = `-- name: GetTicketByID :one
-SELECT id, title, description, status, priority, requester_id, assignee_id, created_at, updated_at, closed_at FROM tickets
-WHERE id = $1 LIMIT 1
+SELECT id, title, description, status, priority, requester_id, assignee_id, created_at, updated_at, closed_at, team_id, due_at, resolution_working_seconds, tags, organization_id, description_html, visibility FROM tickets
+WHERE id = $1
+  AND organization_id = $2
+  AND deleted_at IS NULL
+LIMIT 1
 `
 
-func (q *Queries) GetTicketByID(ctx context.Context, id int64) (Ticket, error) {
-	row := q.db.QueryRow(ctx, getTicketByID, id)
+type GetTicketByIDParams struct {
+	ID             int64       `json:"id"`
+	OrganizationID pgtype.UUID `json:"organization_id"`
+}
+
+func (q *Queries) GetTicketByID(ctx context.Context, arg GetTicketByIDParams) (Ticket, error) {
+	row := q.db.QueryRow(ctx, getTicketByID, arg.ID, arg.OrganizationID)
 	var i Ticket
 	err := row.Scan(
 		&i.ID,
@@ -68,46 +141,224 @@ func (q *Queries) GetTicketByID(ctx context.Context, id int64) (Ticket, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ClosedAt,
+		&i.TeamID,
+		&i.DueAt,
+		&i.ResolutionWorkingSeconds,
+		&i.Tags,
+		&i.OrganizationID,
+		&i.DescriptionHtml,
+		&i.Visibility,
 	)
 	return i, err
 }
 
+const listOpenTicketsByAssignee = `-- name: ListOpenTicketsByAssignee :many
+SELECT id, title, description, status, priority, requester_id, assignee_id, created_at, updated_at, closed_at, team_id, due_at, resolution_working_seconds, tags, organization_id, description_html, visibility FROM tickets
+WHERE assignee_id = $1
+  AND organization_id = $2
+  AND status <> 'CLOSED'
+  AND deleted_at IS NULL
+ORDER BY id
+FOR UPDATE
+`
+
+type ListOpenTicketsByAssigneeParams struct {
+	AssigneeID     pgtype.UUID `json:"assignee_id"`
+	OrganizationID pgtype.UUID `json:"organization_id"`
+}
+
+func (q *Queries) ListOpenTicketsByAssignee(ctx context.Context, arg ListOpenTicketsByAssigneeParams) ([]Ticket, error) {
+	rows, err := q.db.Query(ctx, listOpenTicketsByAssignee, arg.AssigneeID, arg.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Ticket
+	for rows.Next() {
+		var i Ticket
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.RequesterID,
+			&i.AssigneeID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ClosedAt,
+			&i.TeamID,
+			&i.DueAt,
+			&i.ResolutionWorkingSeconds,
+			&i.Tags,
+			&i.OrganizationID,
+			&i.DescriptionHtml,
+			&i.Visibility,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTicketsByAssigneePaginated = `-- name: ListTicketsByAssigneePaginated :many
+SELECT id, title, description, status, priority, requester_id, assignee_id, created_at, updated_at, closed_at, team_id, due_at, resolution_working_seconds, tags, organization_id, description_html, visibility FROM tickets
+WHERE
+    organization_id = $1
+  AND
+    assignee_id = $2
+  AND
+    (status = $3 OR $3 IS NULL)
+  AND
+    (priority = $4 OR $4 IS NULL)
+  AND
+    (requester_id = $5 OR $5 IS NULL)
+  AND
+    (created_at >= $6 OR $6 IS NULL)
+  AND
+    (created_at < $7 OR $7 IS NULL)
+  AND
+    (team_id = $8 OR $8 IS NULL)
+  AND
+    (deleted_at IS NULL OR $9::boolean)
+  AND
+    (NOT $10::boolean OR NOT EXISTS (
+      SELECT 1 FROM ticket_snoozes s WHERE s.ticket_id = tickets.id AND s.snoozed_until > NOW()
+    ))
+ORDER BY created_at DESC
+LIMIT $12
+    OFFSET $11
+`
+
+type ListTicketsByAssigneePaginatedParams struct {
+	OrganizationID pgtype.UUID        `json:"organization_id"`
+	AssigneeID     pgtype.UUID        `json:"assignee_id"`
+	Status         pgtype.Text        `json:"status"`
+	Priority       pgtype.Text        `json:"priority"`
+	RequesterID    pgtype.UUID        `json:"requester_id"`
+	CreatedFrom    pgtype.Timestamptz `json:"created_from"`
+	CreatedTo      pgtype.Timestamptz `json:"created_to"`
+	TeamID         pgtype.UUID        `json:"team_id"`
+	IncludeDeleted bool               `json:"include_deleted"`
+	HideSnoozed    bool               `json:"hide_snoozed"`
+	Offset         int32              `json:"offset"`
+	Limit          int32              `json:"limit"`
+}
+
+func (q *Queries) ListTicketsByAssigneePaginated(ctx context.Context, arg ListTicketsByAssigneePaginatedParams) ([]Ticket, error) {
+	rows, err := q.db.Query(ctx, listTicketsByAssigneePaginated,
+		arg.OrganizationID,
+		arg.AssigneeID,
+		arg.Status,
+		arg.Priority,
+		arg.RequesterID,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.TeamID,
+		arg.IncludeDeleted,
+		arg.HideSnoozed,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Ticket
+	for rows.Next() {
+		var i Ticket
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.RequesterID,
+			&i.AssigneeID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ClosedAt,
+			&i.TeamID,
+			&i.DueAt,
+			&i.ResolutionWorkingSeconds,
+			&i.Tags,
+			&i.OrganizationID,
+			&i.DescriptionHtml,
+			&i.Visibility,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listTicketsByRequesterPaginated = `-- name: ListTicketsByRequesterPaginated :many
-SELECT id, title, description, status, priority, requester_id, assignee_id, created_at, updated_at, closed_at FROM tickets
+SELECT id, title, description, status, priority, requester_id, assignee_id, created_at, updated_at, closed_at, team_id, due_at, resolution_working_seconds, tags, organization_id, description_html, visibility FROM tickets
 WHERE
-    requester_id = $1
+    organization_id = $1
   AND
-    (status = $2 OR $2 IS NULL)
+    requester_id = $2
   AND
-    (priority = $3 OR $3 IS NULL)
+    (status = $3 OR $3 IS NULL)
+  AND
+    (priority = $4 OR $4 IS NULL)
   AND
     (
-      ($4 = TRUE AND assignee_id IS NULL)
-      OR ($4 IS NULL AND (assignee_id = $5 OR $5 IS NULL))
+      ($5 = TRUE AND assignee_id IS NULL)
+      OR ($5 IS NULL AND (assignee_id = $6 OR $6 IS NULL))
     )
   AND
-    (created_at >= $6 OR $6 IS NULL)
+    (created_at >= $7 OR $7 IS NULL)
   AND
-    (created_at < $7 OR $7 IS NULL)
+    (created_at < $8 OR $8 IS NULL)
+  AND
+    (
+      ($9 = TRUE AND status <> 'CLOSED' AND assignee_id IN (
+        SELECT u.id FROM users u
+        JOIN out_of_office_windows w ON w.user_id = u.id
+        WHERE u.reassign_when_away AND w.starts_at <= NOW() AND w.ends_at > NOW()
+      ))
+      OR $9 IS NULL
+    )
+  AND
+    (team_id = $10 OR $10 IS NULL)
+  AND
+    (deleted_at IS NULL OR $11::boolean)
+  AND
+    (NOT $12::boolean OR visibility = 'PUBLIC')
 ORDER BY created_at DESC
-LIMIT $9
-    OFFSET $8
+LIMIT $14
+    OFFSET $13
 `
 
 type ListTicketsByRequesterPaginatedParams struct {
-	RequesterID pgtype.UUID        `json:"requester_id"`
-	Status      pgtype.Text        `json:"status"`
-	Priority    pgtype.Text        `json:"priority"`
-	Unassigned  interface{}        `json:"unassigned"`
-	AssigneeID  pgtype.UUID        `json:"assignee_id"`
-	CreatedFrom pgtype.Timestamptz `json:"created_from"`
-	CreatedTo   pgtype.Timestamptz `json:"created_to"`
-	Offset      int32              `json:"offset"`
-	Limit       int32              `json:"limit"`
+	OrganizationID    pgtype.UUID        `json:"organization_id"`
+	RequesterID       pgtype.UUID        `json:"requester_id"`
+	Status            pgtype.Text        `json:"status"`
+	Priority          pgtype.Text        `json:"priority"`
+	Unassigned        interface{}        `json:"unassigned"`
+	AssigneeID        pgtype.UUID        `json:"assignee_id"`
+	CreatedFrom       pgtype.Timestamptz `json:"created_from"`
+	CreatedTo         pgtype.Timestamptz `json:"created_to"`
+	NeedsReassignment interface{}        `json:"needs_reassignment"`
+	TeamID            pgtype.UUID        `json:"team_id"`
+	IncludeDeleted    bool               `json:"include_deleted"`
+	HideInternal      bool               `json:"hide_internal"`
+	Offset            int32              `json:"offset"`
+	Limit             int32              `json:"limit"`
 }
 
 func (q *Queries) ListTicketsByRequesterPaginated(ctx context.Context, arg ListTicketsByRequesterPaginatedParams) ([]Ticket, error) {
 	rows, err := q.db.Query(ctx, listTicketsByRequesterPaginated,
+		arg.OrganizationID,
 		arg.RequesterID,
 		arg.Status,
 		arg.Priority,
@@ -115,6 +366,10 @@ func (q *Queries) ListTicketsByRequesterPaginated(ctx context.Context, arg ListT
 		arg.AssigneeID,
 		arg.CreatedFrom,
 		arg.CreatedTo,
+		arg.NeedsReassignment,
+		arg.TeamID,
+		arg.IncludeDeleted,
+		arg.HideInternal,
 		arg.Offset,
 		arg.Limit,
 	)
@@ -136,6 +391,13 @@ func (q *Queries) ListTicketsByRequesterPaginated(ctx context.Context, arg ListT
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ClosedAt,
+			&i.TeamID,
+			&i.DueAt,
+			&i.ResolutionWorkingSeconds,
+			&i.Tags,
+			&i.OrganizationID,
+			&i.DescriptionHtml,
+			&i.Visibility,
 		); err != nil {
 			return nil, err
 		}
@@ -148,44 +410,73 @@ func (q *Queries) ListTicketsByRequesterPaginated(ctx context.Context, arg ListT
 }
 
 const listTicketsPaginated = `-- name: ListTicketsPaginated :many
-SELECT id, title, description, status, priority, requester_id, assignee_id, created_at, updated_at, closed_at FROM tickets
+SELECT id, title, description, status, priority, requester_id, assignee_id, created_at, updated_at, closed_at, team_id, due_at, resolution_working_seconds, tags, organization_id, description_html, visibility FROM tickets
 WHERE
-    (status = $1 OR $1 IS NULL)
+    organization_id = $1
+  AND
+    (status = $2 OR $2 IS NULL)
+  AND
+    (priority = $3 OR $3 IS NULL)
+  AND
+    (
+      ($4 = TRUE AND assignee_id IS NULL)
+      OR ($4 IS NULL AND (assignee_id = $5 OR $5 IS NULL))
+    )
   AND
-    (priority = $2 OR $2 IS NULL)
+    (created_at >= $6 OR $6 IS NULL)
+  AND
+    (created_at < $7 OR $7 IS NULL)
   AND
     (
-      ($3 = TRUE AND assignee_id IS NULL)
-      OR ($3 IS NULL AND (assignee_id = $4 OR $4 IS NULL))
+      ($8 = TRUE AND status <> 'CLOSED' AND assignee_id IN (
+        SELECT u.id FROM users u
+        JOIN out_of_office_windows w ON w.user_id = u.id
+        WHERE u.reassign_when_away AND w.starts_at <= NOW() AND w.ends_at > NOW()
+      ))
+      OR $8 IS NULL
     )
   AND
-    (created_at >= $5 OR $5 IS NULL)
+    (team_id = $9 OR $9 IS NULL)
   AND
-    (created_at < $6 OR $6 IS NULL)
+    (deleted_at IS NULL OR $10::boolean)
+  AND
+    (NOT $11::boolean OR NOT EXISTS (
+      SELECT 1 FROM ticket_snoozes s WHERE s.ticket_id = tickets.id AND s.snoozed_until > NOW()
+    ))
 ORDER BY created_at DESC
-LIMIT $8
-    OFFSET $7
+LIMIT $13
+    OFFSET $12
 `
 
 type ListTicketsPaginatedParams struct {
-	Status      pgtype.Text        `json:"status"`
-	Priority    pgtype.Text        `json:"priority"`
-	Unassigned  interface{}        `json:"unassigned"`
-	AssigneeID  pgtype.UUID        `json:"assignee_id"`
-	CreatedFrom pgtype.Timestamptz `json:"created_from"`
-	CreatedTo   pgtype.Timestamptz `json:"created_to"`
-	Offset      int32              `json:"offset"`
-	Limit       int32              `json:"limit"`
+	OrganizationID    pgtype.UUID        `json:"organization_id"`
+	Status            pgtype.Text        `json:"status"`
+	Priority          pgtype.Text        `json:"priority"`
+	Unassigned        interface{}        `json:"unassigned"`
+	AssigneeID        pgtype.UUID        `json:"assignee_id"`
+	CreatedFrom       pgtype.Timestamptz `json:"created_from"`
+	CreatedTo         pgtype.Timestamptz `json:"created_to"`
+	NeedsReassignment interface{}        `json:"needs_reassignment"`
+	TeamID            pgtype.UUID        `json:"team_id"`
+	IncludeDeleted    bool               `json:"include_deleted"`
+	HideSnoozed       bool               `json:"hide_snoozed"`
+	Offset            int32              `json:"offset"`
+	Limit             int32              `json:"limit"`
 }
 
 func (q *Queries) ListTicketsPaginated(ctx context.Context, arg ListTicketsPaginatedParams) ([]Ticket, error) {
 	rows, err := q.db.Query(ctx, listTicketsPaginated,
+		arg.OrganizationID,
 		arg.Status,
 		arg.Priority,
 		arg.Unassigned,
 		arg.AssigneeID,
 		arg.CreatedFrom,
 		arg.CreatedTo,
+		arg.NeedsReassignment,
+		arg.TeamID,
+		arg.IncludeDeleted,
+		arg.HideSnoozed,
 		arg.Offset,
 		arg.Limit,
 	)
@@ -207,6 +498,13 @@ func (q *Queries) ListTicketsPaginated(ctx context.Context, arg ListTicketsPagin
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ClosedAt,
+			&i.TeamID,
+			&i.DueAt,
+			&i.ResolutionWorkingSeconds,
+			&i.Tags,
+			&i.OrganizationID,
+			&i.DescriptionHtml,
+			&i.Visibility,
 		); err != nil {
 			return nil, err
 		}
@@ -224,17 +522,36 @@ SET
     status = $2,
     assignee_id = $3,
     updated_at = $4,
-    closed_at = $5
+    closed_at = $5,
+    title = $6,
+    description = $7,
+    priority = $8,
+    team_id = $9,
+    due_at = $10,
+    resolution_working_seconds = $11,
+    tags = $12,
+    description_html = $14
 WHERE id = $1
-RETURNING id, title, description, status, priority, requester_id, assignee_id, created_at, updated_at, closed_at
+  AND organization_id = $13
+  AND deleted_at IS NULL
+RETURNING id, title, description, status, priority, requester_id, assignee_id, created_at, updated_at, closed_at, team_id, due_at, resolution_working_seconds, tags, organization_id, description_html, visibility
 `
 
 type UpdateTicketParams struct {
-	ID         int64              `json:"id"`
-	Status     string             `json:"status"`
-	AssigneeID pgtype.UUID        `json:"assignee_id"`
-	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
-	ClosedAt   pgtype.Timestamptz `json:"closed_at"`
+	ID                       int64              `json:"id"`
+	Status                   string             `json:"status"`
+	AssigneeID               pgtype.UUID        `json:"assignee_id"`
+	UpdatedAt                pgtype.Timestamptz `json:"updated_at"`
+	ClosedAt                 pgtype.Timestamptz `json:"closed_at"`
+	Title                    string             `json:"title"`
+	Description              pgtype.Text        `json:"description"`
+	Priority                 string             `json:"priority"`
+	TeamID                   pgtype.UUID        `json:"team_id"`
+	DueAt                    pgtype.Timestamptz `json:"due_at"`
+	ResolutionWorkingSeconds pgtype.Float8      `json:"resolution_working_seconds"`
+	Tags                     []string           `json:"tags"`
+	OrganizationID           pgtype.UUID        `json:"organization_id"`
+	DescriptionHtml          pgtype.Text        `json:"description_html"`
 }
 
 func (q *Queries) UpdateTicket(ctx context.Context, arg UpdateTicketParams) (Ticket, error) {
@@ -244,6 +561,15 @@ func (q *Queries) UpdateTicket(ctx context.Context, arg UpdateTicketParams) (Tic
 		arg.AssigneeID,
 		arg.UpdatedAt,
 		arg.ClosedAt,
+		arg.Title,
+		arg.Description,
+		arg.Priority,
+		arg.TeamID,
+		arg.DueAt,
+		arg.ResolutionWorkingSeconds,
+		arg.Tags,
+		arg.OrganizationID,
+		arg.DescriptionHtml,
 	)
 	var i Ticket
 	err := row.Scan(
@@ -257,6 +583,13 @@ func (q *Queries) UpdateTicket(ctx context.Context, arg UpdateTicketParams) (Tic
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ClosedAt,
+		&i.TeamID,
+		&i.DueAt,
+		&i.ResolutionWorkingSeconds,
+		&i.Tags,
+		&i.OrganizationID,
+		&i.DescriptionHtml,
+		&i.Visibility,
 	)
 	return i, err
 }
@@ -25,7 +25,7 @@ func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (organization_id, full_name, email, hashed_password)
 VALUES ($1, $2, $3, $4)
-    RETURNING id, organization_id, full_name, email, hashed_password, created_at, is_active, last_active_at
+    RETURNING id, organization_id, full_name, email, hashed_password, created_at, is_active, last_active_at, tokens_revoked_at, phone_number, sms_opt_in, locale, timezone
 `
 
 type CreateUserParams struct {
@@ -52,17 +52,27 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.CreatedAt,
 		&i.IsActive,
 		&i.LastActiveAt,
+		&i.TokensRevokedAt,
+		&i.PhoneNumber,
+		&i.SmsOptIn,
+		&i.Locale,
+		&i.Timezone,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, organization_id, full_name, email, hashed_password, created_at, is_active, last_active_at FROM users
-WHERE email = $1 LIMIT 1
+SELECT id, organization_id, full_name, email, hashed_password, created_at, is_active, last_active_at, tokens_revoked_at, phone_number, sms_opt_in, locale, timezone FROM users
+WHERE organization_id = $1 AND email = $2 LIMIT 1
 `
 
-func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
-	row := q.db.QueryRow(ctx, getUserByEmail, email)
+type GetUserByEmailParams struct {
+	OrganizationID pgtype.UUID `json:"organization_id"`
+	Email          string      `json:"email"`
+}
+
+func (q *Queries) GetUserByEmail(ctx context.Context, arg GetUserByEmailParams) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByEmail, arg.OrganizationID, arg.Email)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -73,12 +83,17 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.CreatedAt,
 		&i.IsActive,
 		&i.LastActiveAt,
+		&i.TokensRevokedAt,
+		&i.PhoneNumber,
+		&i.SmsOptIn,
+		&i.Locale,
+		&i.Timezone,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, organization_id, full_name, email, hashed_password, created_at, is_active, last_active_at FROM users
+SELECT id, organization_id, full_name, email, hashed_password, created_at, is_active, last_active_at, tokens_revoked_at, phone_number, sms_opt_in, locale, timezone FROM users
 WHERE id = $1 LIMIT 1
 `
 
@@ -94,6 +109,51 @@ func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
 		&i.CreatedAt,
 		&i.IsActive,
 		&i.LastActiveAt,
+		&i.TokensRevokedAt,
+		&i.PhoneNumber,
+		&i.SmsOptIn,
+		&i.Locale,
+		&i.Timezone,
 	)
 	return i, err
 }
+
+const listUsersByEmail = `-- name: ListUsersByEmail :many
+SELECT id, organization_id, full_name, email, hashed_password, created_at, is_active, last_active_at, tokens_revoked_at, phone_number, sms_opt_in, locale, timezone FROM users
+WHERE email = $1
+ORDER BY created_at, id
+`
+
+func (q *Queries) ListUsersByEmail(ctx context.Context, email string) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsersByEmail, email)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganizationID,
+			&i.FullName,
+			&i.Email,
+			&i.HashedPassword,
+			&i.CreatedAt,
+			&i.IsActive,
+			&i.LastActiveAt,
+			&i.TokensRevokedAt,
+			&i.PhoneNumber,
+			&i.SmsOptIn,
+			&i.Locale,
+			&i.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
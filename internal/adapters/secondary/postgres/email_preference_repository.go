@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// EmailPreferenceRepository persists the ticket emails users opted out of.
+type EmailPreferenceRepository struct {
+	conn DBTX
+}
+
+var _ ports.EmailPreferenceRepository = (*EmailPreferenceRepository)(nil)
+
+// NewEmailPreferenceRepository creates a new email preference repository.
+func NewEmailPreferenceRepository(conn DBTX) ports.EmailPreferenceRepository {
+	return &EmailPreferenceRepository{conn: conn}
+}
+
+// Get returns the user's email preferences, empty if they have none.
+func (r *EmailPreferenceRepository) Get(ctx context.Context, userID uuid.UUID) (*domain.EmailPreferences, error) {
+	db := GetDBTX(ctx, r.conn)
+	prefs := &domain.EmailPreferences{UserID: userID, MutedTicketIDs: make([]int64, 0)}
+
+	err := db.QueryRow(ctx, "SELECT unsubscribed FROM email_preferences WHERE user_id = $1", userID).Scan(&prefs.Unsubscribed)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	rows, err := db.Query(ctx, "SELECT ticket_id FROM muted_tickets WHERE user_id = $1 ORDER BY ticket_id", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var ticketID int64
+		if err := rows.Scan(&ticketID); err != nil {
+			return nil, err
+		}
+		prefs.MutedTicketIDs = append(prefs.MutedTicketIDs, ticketID)
+	}
+	return prefs, rows.Err()
+}
+
+// SetUnsubscribed turns all of the user's ticket emails off or back on.
+// Opt-out links outlive their users, so nothing is stored for a user who
+// no longer exists.
+func (r *EmailPreferenceRepository) SetUnsubscribed(ctx context.Context, userID uuid.UUID, unsubscribed bool) error {
+	_, err := GetDBTX(ctx, r.conn).Exec(ctx, `
+INSERT INTO email_preferences (user_id, unsubscribed, updated_at)
+SELECT id, $2, NOW() FROM users WHERE id = $1
+ON CONFLICT (user_id)
+DO UPDATE SET unsubscribed = EXCLUDED.unsubscribed,
+              updated_at = EXCLUDED.updated_at`, userID, unsubscribed)
+	return err
+}
+
+// MuteTicket stops the user's emails about a ticket. Like SetUnsubscribed,
+// it stores nothing for a user or ticket that no longer exists.
+func (r *EmailPreferenceRepository) MuteTicket(ctx context.Context, userID uuid.UUID, ticketID int64) error {
+	_, err := GetDBTX(ctx, r.conn).Exec(ctx, `
+INSERT INTO muted_tickets (user_id, ticket_id)
+SELECT u.id, t.id FROM users u, tickets t WHERE u.id = $1 AND t.id = $2
+ON CONFLICT (user_id, ticket_id) DO NOTHING`, userID, ticketID)
+	return err
+}
+
+// UnmuteTicket lets the user's emails about a ticket through again.
+func (r *EmailPreferenceRepository) UnmuteTicket(ctx context.Context, userID uuid.UUID, ticketID int64) error {
+	_, err := GetDBTX(ctx, r.conn).Exec(ctx, "DELETE FROM muted_tickets WHERE user_id = $1 AND ticket_id = $2", userID, ticketID)
+	return err
+}
@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// EscalationRuleRepository persists escalation rules.
+type EscalationRuleRepository struct {
+	conn DBTX
+}
+
+var _ ports.EscalationRuleRepository = (*EscalationRuleRepository)(nil)
+
+// NewEscalationRuleRepository creates a new escalation rule repository.
+func NewEscalationRuleRepository(conn DBTX) ports.EscalationRuleRepository {
+	return &EscalationRuleRepository{conn: conn}
+}
+
+const escalationRuleColumns = "id, organization_id, name, trigger, threshold_minutes, priorities, actions, is_active, created_by, created_at, updated_at"
+
+func scanEscalationRule(row pgx.Row) (*domain.EscalationRule, error) {
+	var (
+		rule       domain.EscalationRule
+		trigger    string
+		priorities []string
+		actions    []byte
+		createdAt  pgtype.Timestamptz
+		updatedAt  pgtype.Timestamptz
+	)
+	if err := row.Scan(
+		&rule.ID,
+		&rule.OrganizationID,
+		&rule.Name,
+		&trigger,
+		&rule.ThresholdMinutes,
+		&priorities,
+		&actions,
+		&rule.IsActive,
+		&rule.CreatedBy,
+		&createdAt,
+		&updatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(actions, &rule.Actions); err != nil {
+		return nil, err
+	}
+	rule.Trigger = domain.EscalationTrigger(trigger)
+	rule.Priorities = make([]domain.TicketPriority, 0, len(priorities))
+	for _, priority := range priorities {
+		rule.Priorities = append(rule.Priorities, domain.TicketPriority(priority))
+	}
+	rule.CreatedAt = createdAt.Time
+	rule.UpdatedAt = updatedAt.Time
+	return &rule, nil
+}
+
+// escalationRuleValues converts the rule's priorities and actions to their
+// column types.
+func escalationRuleValues(rule *domain.EscalationRule) ([]string, []byte, error) {
+	priorities := make([]string, 0, len(rule.Priorities))
+	for _, priority := range rule.Priorities {
+		priorities = append(priorities, string(priority))
+	}
+
+	actions, err := json.Marshal(rule.Actions)
+	if err != nil {
+		return nil, nil, err
+	}
+	return priorities, actions, nil
+}
+
+// Create persists a new escalation rule.
+func (r *EscalationRuleRepository) Create(ctx context.Context, rule *domain.EscalationRule) (*domain.EscalationRule, error) {
+	priorities, actions, err := escalationRuleValues(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	row := GetDBTX(ctx, r.conn).QueryRow(ctx, `
+INSERT INTO escalation_rules (organization_id, name, trigger, threshold_minutes, priorities, actions, is_active, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING `+escalationRuleColumns,
+		rule.OrganizationID,
+		rule.Name,
+		string(rule.Trigger),
+		rule.ThresholdMinutes,
+		priorities,
+		actions,
+		rule.IsActive,
+		rule.CreatedBy,
+	)
+	return scanEscalationRule(row)
+}
+
+// GetByID retrieves an escalation rule by its ID.
+func (r *EscalationRuleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.EscalationRule, error) {
+	row := GetDBTX(ctx, r.conn).QueryRow(ctx, "SELECT "+escalationRuleColumns+" FROM escalation_rules WHERE id = $1", id)
+
+	rule, err := scanEscalationRule(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrEscalationRuleNotFound
+		}
+		return nil, err
+	}
+	return rule, nil
+}
+
+// ListByOrganization retrieves all escalation rules of an organization.
+func (r *EscalationRuleRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.EscalationRule, error) {
+	rows, err := GetDBTX(ctx, r.conn).Query(ctx,
+		"SELECT "+escalationRuleColumns+" FROM escalation_rules WHERE organization_id = $1 ORDER BY created_at, id",
+		orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := make([]*domain.EscalationRule, 0)
+	for rows.Next() {
+		rule, err := scanEscalationRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// Update saves the rule's configuration.
+func (r *EscalationRuleRepository) Update(ctx context.Context, rule *domain.EscalationRule) error {
+	priorities, actions, err := escalationRuleValues(rule)
+	if err != nil {
+		return err
+	}
+
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx, `
+UPDATE escalation_rules
+SET name = $2, trigger = $3, threshold_minutes = $4, priorities = $5, actions = $6, is_active = $7, updated_at = NOW()
+WHERE id = $1`,
+		rule.ID,
+		rule.Name,
+		string(rule.Trigger),
+		rule.ThresholdMinutes,
+		priorities,
+		actions,
+		rule.IsActive,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrEscalationRuleNotFound
+	}
+	return nil
+}
+
+// Delete removes an escalation rule.
+func (r *EscalationRuleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx, "DELETE FROM escalation_rules WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrEscalationRuleNotFound
+	}
+	return nil
+}
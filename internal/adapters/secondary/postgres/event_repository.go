@@ -6,7 +6,6 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/lorrc/service-desk-backend/internal/adapters/secondary/postgres/db"
 	"github.com/lorrc/service-desk-backend/internal/core/domain"
 	"github.com/lorrc/service-desk-backend/internal/core/ports"
@@ -14,14 +13,14 @@ import (
 
 // TicketEventRepository handles persistence for ticket events.
 type TicketEventRepository struct {
-	pool *pgxpool.Pool
+	conn DBTX
 }
 
 var _ ports.TicketEventRepository = (*TicketEventRepository)(nil)
 
 // NewTicketEventRepository creates a new ticket event repository.
-func NewTicketEventRepository(pool *pgxpool.Pool) ports.TicketEventRepository {
-	return &TicketEventRepository{pool: pool}
+func NewTicketEventRepository(conn DBTX) ports.TicketEventRepository {
+	return &TicketEventRepository{conn: conn}
 }
 
 func mapDBTicketEventToDomain(dbEvent db.TicketEvent) *domain.Event {
@@ -34,6 +33,7 @@ func mapDBTicketEventToDomain(dbEvent db.TicketEvent) *domain.Event {
 		ID:        dbEvent.ID,
 		TicketID:  dbEvent.TicketID,
 		Type:      domain.EventType(dbEvent.Type),
+		Version:   int(dbEvent.Version),
 		Payload:   json.RawMessage(dbEvent.Payload),
 		ActorID:   actorID,
 		CreatedAt: dbEvent.CreatedAt.Time,
@@ -42,10 +42,11 @@ func mapDBTicketEventToDomain(dbEvent db.TicketEvent) *domain.Event {
 
 // Create persists a new ticket event.
 func (r *TicketEventRepository) Create(ctx context.Context, event *domain.Event) (*domain.Event, error) {
-	q := db.New(GetDBTX(ctx, r.pool))
+	q := db.New(GetDBTX(ctx, r.conn))
 	params := db.CreateTicketEventParams{
 		TicketID: event.TicketID,
 		Type:     string(event.Type),
+		Version:  int16(event.Version),
 		Payload:  []byte(event.Payload),
 		ActorID:  pgtype.UUID{Bytes: event.ActorID, Valid: true},
 	}
@@ -60,7 +61,7 @@ func (r *TicketEventRepository) Create(ctx context.Context, event *domain.Event)
 
 // ListByTicketID retrieves events for a ticket after a cursor.
 func (r *TicketEventRepository) ListByTicketID(ctx context.Context, ticketID int64, afterID int64, limit int) ([]*domain.Event, error) {
-	q := db.New(GetDBTX(ctx, r.pool))
+	q := db.New(GetDBTX(ctx, r.conn))
 	params := db.ListTicketEventsParams{
 		TicketID: ticketID,
 		ID:       afterID,
@@ -79,3 +80,48 @@ func (r *TicketEventRepository) ListByTicketID(ctx context.Context, ticketID int
 
 	return events, nil
 }
+
+// ListForReplay returns up to filter.Limit of the organization's events
+// matching the filter, in order.
+func (r *TicketEventRepository) ListForReplay(ctx context.Context, filter domain.EventReplayFilter) ([]*domain.Event, error) {
+	const query = `
+SELECT e.id, e.ticket_id, e.type, e.payload, e.actor_id, e.created_at, e.version
+FROM ticket_events e
+JOIN tickets t ON t.id = e.ticket_id
+WHERE t.organization_id = $1
+  AND t.deleted_at IS NULL
+  AND ($2::bigint IS NULL OR e.ticket_id = $2)
+  AND e.created_at >= $3 AND e.created_at < $4
+  AND e.id > $5
+ORDER BY e.id
+LIMIT $6
+`
+
+	var ticketID pgtype.Int8
+	if filter.TicketID != nil {
+		ticketID = pgtype.Int8{Int64: *filter.TicketID, Valid: true}
+	}
+
+	rows, err := GetDBTX(ctx, r.conn).Query(ctx, query,
+		filter.OrganizationID,
+		ticketID,
+		pgtype.Timestamptz{Time: filter.From, Valid: true},
+		pgtype.Timestamptz{Time: filter.To, Valid: true},
+		filter.AfterID,
+		filter.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]*domain.Event, 0)
+	for rows.Next() {
+		var e db.TicketEvent
+		if err := rows.Scan(&e.ID, &e.TicketID, &e.Type, &e.Payload, &e.ActorID, &e.CreatedAt, &e.Version); err != nil {
+			return nil, err
+		}
+		events = append(events, mapDBTicketEventToDomain(e))
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// InboundHookRepository persists inbound hook registrations.
+type InboundHookRepository struct {
+	conn DBTX
+}
+
+var _ ports.InboundHookRepository = (*InboundHookRepository)(nil)
+
+// NewInboundHookRepository creates a new inbound hook repository.
+func NewInboundHookRepository(conn DBTX) ports.InboundHookRepository {
+	return &InboundHookRepository{conn: conn}
+}
+
+const inboundHookColumns = "id, organization_id, source, format, secret, mapping, requester_id, is_active, created_by, created_at"
+
+func scanInboundHook(row pgx.Row) (*domain.InboundHook, error) {
+	var (
+		h         domain.InboundHook
+		format    string
+		mapping   []byte
+		createdAt pgtype.Timestamptz
+	)
+	if err := row.Scan(
+		&h.ID,
+		&h.OrganizationID,
+		&h.Source,
+		&format,
+		&h.Secret,
+		&mapping,
+		&h.RequesterID,
+		&h.IsActive,
+		&h.CreatedBy,
+		&createdAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(mapping, &h.Mapping); err != nil {
+		return nil, err
+	}
+	h.Format = domain.InboundHookFormat(format)
+	h.CreatedAt = createdAt.Time
+	return &h, nil
+}
+
+// Create persists a new inbound hook.
+func (r *InboundHookRepository) Create(ctx context.Context, hook *domain.InboundHook) (*domain.InboundHook, error) {
+	mapping, err := json.Marshal(hook.Mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	row := GetDBTX(ctx, r.conn).QueryRow(ctx, `
+INSERT INTO inbound_hooks (organization_id, source, format, secret, mapping, requester_id, is_active, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING `+inboundHookColumns,
+		hook.OrganizationID,
+		hook.Source,
+		string(hook.Format),
+		hook.Secret,
+		mapping,
+		hook.RequesterID,
+		hook.IsActive,
+		hook.CreatedBy,
+	)
+
+	created, err := scanInboundHook(row)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, apperrors.ErrInboundHookExists
+		}
+		return nil, err
+	}
+	return created, nil
+}
+
+// GetByID retrieves an inbound hook by its ID.
+func (r *InboundHookRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.InboundHook, error) {
+	row := GetDBTX(ctx, r.conn).QueryRow(ctx, "SELECT "+inboundHookColumns+" FROM inbound_hooks WHERE id = $1", id)
+	return r.get(row)
+}
+
+// GetBySource retrieves the inbound hook posted to at /hooks/{source}.
+func (r *InboundHookRepository) GetBySource(ctx context.Context, source string) (*domain.InboundHook, error) {
+	row := GetDBTX(ctx, r.conn).QueryRow(ctx, "SELECT "+inboundHookColumns+" FROM inbound_hooks WHERE source = $1", source)
+	return r.get(row)
+}
+
+func (r *InboundHookRepository) get(row pgx.Row) (*domain.InboundHook, error) {
+	hook, err := scanInboundHook(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrInboundHookNotFound
+		}
+		return nil, err
+	}
+	return hook, nil
+}
+
+// ListByOrganization retrieves all inbound hooks of an organization.
+func (r *InboundHookRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.InboundHook, error) {
+	rows, err := GetDBTX(ctx, r.conn).Query(ctx,
+		"SELECT "+inboundHookColumns+" FROM inbound_hooks WHERE organization_id = $1 ORDER BY created_at, id",
+		orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hooks := make([]*domain.InboundHook, 0)
+	for rows.Next() {
+		hook, err := scanInboundHook(rows)
+		if err != nil {
+			return nil, err
+		}
+		hooks = append(hooks, hook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return hooks, nil
+}
+
+// Delete removes an inbound hook.
+func (r *InboundHookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx, "DELETE FROM inbound_hooks WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrInboundHookNotFound
+	}
+	return nil
+}
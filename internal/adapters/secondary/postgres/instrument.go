@@ -0,0 +1,275 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+)
+
+// queryDurationBuckets are the upper bounds, in seconds, of the query
+// duration histogram
+var queryDurationBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Instrumentation records how long each repository's queries take and how
+// they end, and logs queries slower than a threshold. A repository is
+// instrumented by creating it on the connection Instrument returns; its
+// queries are observed whether they run on that connection, on the
+// transaction in their context or on the read replica.
+//
+// Instrumentation serves the recorded durations at its ServeHTTP in the
+// Prometheus text format.
+type Instrumentation struct {
+	logger        *slog.Logger
+	slowThreshold time.Duration
+	now           func() time.Time
+
+	mu     sync.Mutex
+	series map[querySeries]*queryHistogram
+}
+
+// querySeries identifies a histogram: a repository's queries ending with
+// one outcome
+type querySeries struct {
+	repository string
+	outcome    string
+}
+
+type queryHistogram struct {
+	buckets []uint64 // Per bucket of queryDurationBuckets, not cumulative
+	count   uint64
+	sum     float64
+}
+
+// NewInstrumentation creates an instrumentation that logs queries taking
+// slowThreshold or longer. A slowThreshold of 0 logs none.
+func NewInstrumentation(logger *slog.Logger, slowThreshold time.Duration) *Instrumentation {
+	return &Instrumentation{
+		logger:        logger.With("component", "postgres"),
+		slowThreshold: slowThreshold,
+		now:           time.Now,
+		series:        make(map[querySeries]*queryHistogram),
+	}
+}
+
+// Instrument returns conn with its queries observed under repository's
+// name. A nil Instrumentation returns conn unchanged.
+func (i *Instrumentation) Instrument(conn DBTX, repository string) DBTX {
+	if i == nil {
+		return conn
+	}
+	return &instrumentedConn{DBTX: conn, repository: repository, inst: i}
+}
+
+// observe records a query that started at start and ended with err
+func (i *Instrumentation) observe(ctx context.Context, repository, sql string, start time.Time, err error) {
+	elapsed := i.now().Sub(start)
+	outcome := ClassifyQueryError(err)
+
+	i.mu.Lock()
+	key := querySeries{repository: repository, outcome: outcome}
+	h, ok := i.series[key]
+	if !ok {
+		h = &queryHistogram{buckets: make([]uint64, len(queryDurationBuckets))}
+		i.series[key] = h
+	}
+	seconds := elapsed.Seconds()
+	if b := sort.SearchFloat64s(queryDurationBuckets, seconds); b < len(h.buckets) {
+		h.buckets[b]++
+	}
+	h.count++
+	h.sum += seconds
+	i.mu.Unlock()
+
+	if i.slowThreshold > 0 && elapsed >= i.slowThreshold {
+		i.logger.WarnContext(ctx, "slow query",
+			"repository", repository,
+			"query", queryName(sql),
+			"duration_ms", elapsed.Milliseconds(),
+			"outcome", outcome,
+			"request_id", domain.RequestIDFromContext(ctx),
+		)
+	}
+}
+
+// ServeHTTP writes the query duration histograms in the Prometheus text
+// format.
+func (i *Instrumentation) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = i.WriteMetrics(w)
+}
+
+// WriteMetrics writes the query duration histograms to w in the Prometheus
+// text format, ordered by repository and outcome.
+func (i *Instrumentation) WriteMetrics(w io.Writer) error {
+	i.mu.Lock()
+	keys := make([]querySeries, 0, len(i.series))
+	snapshot := make(map[querySeries]queryHistogram, len(i.series))
+	for key, h := range i.series {
+		keys = append(keys, key)
+		snapshot[key] = queryHistogram{buckets: append([]uint64(nil), h.buckets...), count: h.count, sum: h.sum}
+	}
+	i.mu.Unlock()
+
+	sort.Slice(keys, func(a, b int) bool {
+		if keys[a].repository != keys[b].repository {
+			return keys[a].repository < keys[b].repository
+		}
+		return keys[a].outcome < keys[b].outcome
+	})
+
+	var sb strings.Builder
+	sb.WriteString("# HELP db_query_duration_seconds Time taken by repository queries.\n")
+	sb.WriteString("# TYPE db_query_duration_seconds histogram\n")
+	for _, key := range keys {
+		h := snapshot[key]
+		labels := fmt.Sprintf("repository=%q,outcome=%q", key.repository, key.outcome)
+
+		var cumulative uint64
+		for b, bound := range queryDurationBuckets {
+			cumulative += h.buckets[b]
+			fmt.Fprintf(&sb, "db_query_duration_seconds_bucket{%s,le=\"%g\"} %d\n", labels, bound, cumulative)
+		}
+		fmt.Fprintf(&sb, "db_query_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, h.count)
+		fmt.Fprintf(&sb, "db_query_duration_seconds_sum{%s} %g\n", labels, h.sum)
+		fmt.Fprintf(&sb, "db_query_duration_seconds_count{%s} %d\n", labels, h.count)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// ClassifyQueryError names how a query ended, for metrics and logs:
+// "ok", "not_found", "constraint" for integrity violations, "retryable"
+// for errors IsRetryable accepts, "timeout", "canceled" or "error".
+func ClassifyQueryError(err error) string {
+	var pgErr *pgconn.PgError
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, pgx.ErrNoRows):
+		return "not_found"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.As(err, &pgErr) && strings.HasPrefix(pgErr.Code, "23"):
+		return "constraint"
+	case errors.As(err, &pgErr) && pgErr.Code == "57014":
+		// query_canceled, raised when the statement timeout expires
+		return "timeout"
+	case IsRetryable(err):
+		return "retryable"
+	}
+	return "error"
+}
+
+// queryName returns the sqlc name of a query, or its first line if it has
+// none, for logging
+func queryName(sql string) string {
+	sql = strings.TrimSpace(sql)
+	if rest, ok := strings.CutPrefix(sql, "-- name: "); ok {
+		name, _, _ := strings.Cut(rest, " ")
+		return name
+	}
+
+	line, _, _ := strings.Cut(sql, "\n")
+	line = strings.Join(strings.Fields(line), " ")
+	if len(line) > 120 {
+		line = line[:120] + "..."
+	}
+	return line
+}
+
+// instrumentedConn observes the queries run on a repository's connection
+type instrumentedConn struct {
+	DBTX
+	repository string
+	inst       *Instrumentation
+}
+
+// on returns q with its queries observed like c's, for the transactions
+// and replicas reached through c
+func (c *instrumentedConn) on(q DBTX) DBTX {
+	return &instrumentedConn{DBTX: q, repository: c.repository, inst: c.inst}
+}
+
+func (c *instrumentedConn) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	start := c.inst.now()
+	tag, err := c.DBTX.Exec(ctx, sql, args...)
+	c.inst.observe(ctx, c.repository, sql, start, err)
+	return tag, err
+}
+
+func (c *instrumentedConn) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	start := c.inst.now()
+	rows, err := c.DBTX.Query(ctx, sql, args...)
+	if err != nil {
+		c.inst.observe(ctx, c.repository, sql, start, err)
+		return rows, err
+	}
+	return &instrumentedRows{Rows: rows, done: func(err error) {
+		c.inst.observe(ctx, c.repository, sql, start, err)
+	}}, nil
+}
+
+func (c *instrumentedConn) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	start := c.inst.now()
+	row := c.DBTX.QueryRow(ctx, sql, args...)
+	return &instrumentedRow{row: row, done: func(err error) {
+		c.inst.observe(ctx, c.repository, sql, start, err)
+	}}
+}
+
+func (c *instrumentedConn) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	start := c.inst.now()
+	n, err := c.DBTX.CopyFrom(ctx, tableName, columnNames, rowSrc)
+	c.inst.observe(ctx, c.repository, "COPY "+tableName.Sanitize(), start, err)
+	return n, err
+}
+
+// instrumentedRows observes a query once its rows are closed, when the
+// last of them has been read
+type instrumentedRows struct {
+	pgx.Rows
+	done   func(err error)
+	closed bool
+}
+
+func (r *instrumentedRows) Close() {
+	r.Rows.Close()
+	if !r.closed {
+		r.closed = true
+		r.done(r.Rows.Err())
+	}
+}
+
+// instrumentedRow observes a single-row query once it is scanned
+type instrumentedRow struct {
+	row  pgx.Row
+	done func(err error)
+}
+
+func (r *instrumentedRow) Scan(dest ...any) error {
+	err := r.row.Scan(dest...)
+	r.done(err)
+	return err
+}
+
+// instrumentWith returns q observed like conn if conn is instrumented
+func instrumentWith(conn, q DBTX) DBTX {
+	if c, ok := conn.(*instrumentedConn); ok {
+		return c.on(q)
+	}
+	return q
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrumentation(t *testing.T) {
+	ctx := domain.WithRequestID(context.Background(), "req-1")
+	require.NotNil(t, testPool, "testPool is nil. TestMain may not have run.")
+
+	var logs bytes.Buffer
+	inst := NewInstrumentation(slog.New(slog.NewTextHandler(&logs, nil)), 50*time.Millisecond)
+	conn := inst.Instrument(testPool, "widgets")
+
+	var n int
+	require.NoError(t, conn.QueryRow(ctx, "SELECT 1").Scan(&n))
+	err := conn.QueryRow(ctx, "SELECT 1 WHERE false").Scan(&n)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	rows, err := conn.Query(ctx, "SELECT g FROM generate_series(1, 3) g")
+	require.NoError(t, err)
+	_, err = pgx.CollectRows(rows, pgx.RowTo[int32])
+	require.NoError(t, err)
+
+	// Queries on the transaction in context are observed too
+	tm := NewTransactionManager(testPool)
+	require.NoError(t, tm.WithTransaction(ctx, func(txCtx context.Context) error {
+		_, err := GetDBTX(txCtx, conn).Exec(txCtx, "-- name: Sleep :exec\nSELECT pg_sleep(0.1)")
+		return err
+	}))
+
+	var metrics bytes.Buffer
+	require.NoError(t, inst.WriteMetrics(&metrics))
+	assert.Contains(t, metrics.String(), `db_query_duration_seconds_count{repository="widgets",outcome="ok"} 3`)
+	assert.Contains(t, metrics.String(), `db_query_duration_seconds_count{repository="widgets",outcome="not_found"} 1`)
+	assert.Contains(t, metrics.String(), `db_query_duration_seconds_bucket{repository="widgets",outcome="ok",le="+Inf"} 3`)
+
+	assert.Equal(t, 1, strings.Count(logs.String(), "slow query"))
+	assert.Contains(t, logs.String(), "query=Sleep")
+	assert.Contains(t, logs.String(), "request_id=req-1")
+}
+
+func TestClassifyQueryError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, "ok"},
+		{pgx.ErrNoRows, "not_found"},
+		{&pgconn.PgError{Code: "23505"}, "constraint"},
+		{&pgconn.PgError{Code: "40001"}, "retryable"},
+		{&pgconn.PgError{Code: "57014"}, "timeout"},
+		{context.DeadlineExceeded, "timeout"},
+		{context.Canceled, "canceled"},
+		{errors.New("boom"), "error"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ClassifyQueryError(tt.err), "%v", tt.err)
+	}
+}
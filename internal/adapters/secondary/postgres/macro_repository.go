@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// MacroRepository persists macros.
+type MacroRepository struct {
+	conn DBTX
+}
+
+var _ ports.MacroRepository = (*MacroRepository)(nil)
+
+// NewMacroRepository creates a new macro repository.
+func NewMacroRepository(conn DBTX) ports.MacroRepository {
+	return &MacroRepository{conn: conn}
+}
+
+const macroColumns = "id, organization_id, name, actions, created_by, created_at, updated_at"
+
+func scanMacro(row pgx.Row) (*domain.Macro, error) {
+	var (
+		m         domain.Macro
+		actions   []byte
+		createdAt pgtype.Timestamptz
+		updatedAt pgtype.Timestamptz
+	)
+	if err := row.Scan(&m.ID, &m.OrganizationID, &m.Name, &actions, &m.CreatedBy, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(actions, &m.Actions); err != nil {
+		return nil, err
+	}
+	m.CreatedAt = createdAt.Time
+	m.UpdatedAt = updatedAt.Time
+	return &m, nil
+}
+
+// Create persists a new macro.
+func (r *MacroRepository) Create(ctx context.Context, macro *domain.Macro) (*domain.Macro, error) {
+	actions, err := json.Marshal(macro.Actions)
+	if err != nil {
+		return nil, err
+	}
+
+	row := GetDBTX(ctx, r.conn).QueryRow(ctx, `
+INSERT INTO macros (organization_id, name, actions, created_by)
+VALUES ($1, $2, $3, $4)
+RETURNING `+macroColumns,
+		macro.OrganizationID,
+		macro.Name,
+		actions,
+		macro.CreatedBy,
+	)
+
+	created, err := scanMacro(row)
+	if err != nil {
+		return nil, macroError(err)
+	}
+	return created, nil
+}
+
+// GetByID retrieves a macro by its ID.
+func (r *MacroRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Macro, error) {
+	row := GetDBTX(ctx, r.conn).QueryRow(ctx, "SELECT "+macroColumns+" FROM macros WHERE id = $1", id)
+
+	macro, err := scanMacro(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrMacroNotFound
+		}
+		return nil, err
+	}
+	return macro, nil
+}
+
+// ListByOrganization retrieves all macros of an organization ordered by name.
+func (r *MacroRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.Macro, error) {
+	rows, err := GetDBTX(ctx, r.conn).Query(ctx,
+		"SELECT "+macroColumns+" FROM macros WHERE organization_id = $1 ORDER BY name",
+		orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	macros := make([]*domain.Macro, 0)
+	for rows.Next() {
+		macro, err := scanMacro(rows)
+		if err != nil {
+			return nil, err
+		}
+		macros = append(macros, macro)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return macros, nil
+}
+
+// Update saves the macro's name and actions.
+func (r *MacroRepository) Update(ctx context.Context, macro *domain.Macro) error {
+	actions, err := json.Marshal(macro.Actions)
+	if err != nil {
+		return err
+	}
+
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx,
+		"UPDATE macros SET name = $2, actions = $3, updated_at = NOW() WHERE id = $1",
+		macro.ID, macro.Name, actions,
+	)
+	if err != nil {
+		return macroError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrMacroNotFound
+	}
+	return nil
+}
+
+// Delete removes a macro.
+func (r *MacroRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx, "DELETE FROM macros WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrMacroNotFound
+	}
+	return nil
+}
+
+// macroError maps a unique violation on the macro's name to ErrMacroExists.
+func macroError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return apperrors.ErrMacroExists
+	}
+	return err
+}
@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// NotificationOutboxRepository persists queued notifications.
+type NotificationOutboxRepository struct {
+	conn DBTX
+}
+
+var _ ports.NotificationOutboxRepository = (*NotificationOutboxRepository)(nil)
+
+// NewNotificationOutboxRepository creates a new notification outbox repository.
+func NewNotificationOutboxRepository(conn DBTX) ports.NotificationOutboxRepository {
+	return &NotificationOutboxRepository{conn: conn}
+}
+
+// Enqueue stores a notification for delivery, inside the transaction in ctx if there is one.
+func (r *NotificationOutboxRepository) Enqueue(ctx context.Context, params ports.NotificationParams) error {
+	const enqueue = `
+INSERT INTO notification_outbox (recipient_user_id, type, subject, message, ticket_id, data)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+	payload, err := marshalNotificationData(params.Data)
+	if err != nil {
+		return err
+	}
+
+	_, err = GetDBTX(ctx, r.conn).Exec(ctx, enqueue,
+		pgtype.UUID{Bytes: params.RecipientUserID, Valid: true},
+		string(params.Type),
+		params.Subject,
+		params.Message,
+		params.TicketID,
+		payload,
+	)
+	return err
+}
+
+// EnqueueBatched stores a notification due after window, or folds it into the
+// waiting notification with the same batch key. Folding keeps the original due
+// time so a steady stream of events cannot postpone delivery indefinitely.
+func (r *NotificationOutboxRepository) EnqueueBatched(ctx context.Context, batchKey string, params ports.NotificationParams, window time.Duration) error {
+	const enqueueBatched = `
+INSERT INTO notification_outbox (recipient_user_id, type, subject, message, ticket_id, data, batch_key, next_attempt_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, NOW() + ($8 * INTERVAL '1 second'))
+ON CONFLICT (batch_key) WHERE batch_key IS NOT NULL DO UPDATE
+SET subject = EXCLUDED.subject,
+    message = EXCLUDED.message,
+    data = EXCLUDED.data,
+    batch_count = notification_outbox.batch_count + 1
+`
+
+	payload, err := marshalNotificationData(params.Data)
+	if err != nil {
+		return err
+	}
+
+	_, err = GetDBTX(ctx, r.conn).Exec(ctx, enqueueBatched,
+		pgtype.UUID{Bytes: params.RecipientUserID, Valid: true},
+		string(params.Type),
+		params.Subject,
+		params.Message,
+		params.TicketID,
+		payload,
+		batchKey,
+		window.Seconds(),
+	)
+	return err
+}
+
+// marshalNotificationData encodes a notification's template data, storing a
+// missing map as an empty object.
+func marshalNotificationData(data map[string]string) ([]byte, error) {
+	if data == nil {
+		data = map[string]string{}
+	}
+	return json.Marshal(data)
+}
+
+// ClaimDue locks up to limit pending notifications that are due and pushes
+// their next attempt out by lease, so concurrent workers skip them while
+// they are being delivered. Claiming closes a notification's batch, so later
+// events start a new one instead of folding into it.
+func (r *NotificationOutboxRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*ports.OutboxNotification, error) {
+	const claimDue = `
+UPDATE notification_outbox
+SET next_attempt_at = NOW() + ($2 * INTERVAL '1 second'),
+    batch_key = NULL
+WHERE id IN (
+    SELECT id FROM notification_outbox
+    WHERE status = 'PENDING' AND next_attempt_at <= NOW()
+    ORDER BY next_attempt_at, id
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, recipient_user_id, type, subject, message, ticket_id, data, attempts, batch_count
+`
+
+	rows, err := GetDBTX(ctx, r.conn).Query(ctx, claimDue, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := make([]*ports.OutboxNotification, 0)
+	for rows.Next() {
+		var (
+			n                ports.OutboxNotification
+			recipientID      uuid.UUID
+			notificationType string
+			data             []byte
+		)
+		if err := rows.Scan(
+			&n.ID,
+			&recipientID,
+			&notificationType,
+			&n.Params.Subject,
+			&n.Params.Message,
+			&n.Params.TicketID,
+			&data,
+			&n.Attempts,
+			&n.Count,
+		); err != nil {
+			return nil, err
+		}
+
+		n.Params.RecipientUserID = recipientID
+		n.Params.Type = ports.NotificationType(notificationType)
+		if err := json.Unmarshal(data, &n.Params.Data); err != nil {
+			return nil, err
+		}
+
+		notifications = append(notifications, &n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// MarkSent records a successful delivery.
+func (r *NotificationOutboxRepository) MarkSent(ctx context.Context, id int64) error {
+	_, err := GetDBTX(ctx, r.conn).Exec(ctx,
+		"UPDATE notification_outbox SET status = 'SENT', attempts = attempts + 1, sent_at = NOW(), last_error = NULL WHERE id = $1",
+		id,
+	)
+	return err
+}
+
+// MarkRetry records a failed delivery and schedules the next attempt.
+func (r *NotificationOutboxRepository) MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, lastErr string) error {
+	_, err := GetDBTX(ctx, r.conn).Exec(ctx,
+		"UPDATE notification_outbox SET attempts = attempts + 1, next_attempt_at = $2, last_error = $3 WHERE id = $1",
+		id,
+		pgtype.Timestamptz{Time: nextAttemptAt.UTC(), Valid: true},
+		lastErr,
+	)
+	return err
+}
+
+// MarkDead moves a notification to the dead-letter state after its final failed attempt.
+func (r *NotificationOutboxRepository) MarkDead(ctx context.Context, id int64, lastErr string) error {
+	_, err := GetDBTX(ctx, r.conn).Exec(ctx,
+		"UPDATE notification_outbox SET status = 'DEAD', attempts = attempts + 1, last_error = $2 WHERE id = $1",
+		id,
+		lastErr,
+	)
+	return err
+}
@@ -0,0 +1,324 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// OrgSettingsRepository persists per-organization settings.
+type OrgSettingsRepository struct {
+	conn DBTX
+}
+
+var _ ports.OrgSettingsRepository = (*OrgSettingsRepository)(nil)
+
+// NewOrgSettingsRepository creates a new organization settings repository.
+func NewOrgSettingsRepository(conn DBTX) ports.OrgSettingsRepository {
+	return &OrgSettingsRepository{conn: conn}
+}
+
+// workingDayRecord is the stored form of a domain.WorkingDay.
+type workingDayRecord struct {
+	Weekday int `json:"weekday"`
+	Open    int `json:"open"`
+	Close   int `json:"close"`
+}
+
+// GetBusinessHours retrieves an organization's business hours and holidays,
+// returning apperrors.ErrNotFound if none have been configured.
+func (r *OrgSettingsRepository) GetBusinessHours(ctx context.Context, orgID uuid.UUID) (*domain.BusinessHours, error) {
+	q := GetDBTX(ctx, r.conn)
+
+	var (
+		hours       domain.BusinessHours
+		workingDays []byte
+		updatedAt   pgtype.Timestamptz
+	)
+	err := q.QueryRow(ctx,
+		"SELECT organization_id, timezone, working_days, updated_at FROM organization_business_hours WHERE organization_id = $1",
+		orgID,
+	).Scan(&hours.OrganizationID, &hours.Timezone, &workingDays, &updatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, err
+	}
+	hours.UpdatedAt = updatedAt.Time
+
+	var records []workingDayRecord
+	if err := json.Unmarshal(workingDays, &records); err != nil {
+		return nil, err
+	}
+	hours.Days = make([]domain.WorkingDay, 0, len(records))
+	for _, rec := range records {
+		hours.Days = append(hours.Days, domain.WorkingDay{
+			Weekday: time.Weekday(rec.Weekday),
+			Open:    domain.ClockTime(rec.Open),
+			Close:   domain.ClockTime(rec.Close),
+		})
+	}
+
+	rows, err := q.Query(ctx,
+		"SELECT holiday_date, name FROM organization_holidays WHERE organization_id = $1 ORDER BY holiday_date",
+		orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hours.Holidays = make([]domain.Holiday, 0)
+	for rows.Next() {
+		var (
+			date pgtype.Date
+			name string
+		)
+		if err := rows.Scan(&date, &name); err != nil {
+			return nil, err
+		}
+		hours.Holidays = append(hours.Holidays, domain.Holiday{
+			Date: date.Time.Format(domain.HolidayDateLayout),
+			Name: name,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &hours, nil
+}
+
+// SaveBusinessHours replaces an organization's business hours and holidays.
+// Callers should run it in a transaction so the two are replaced together.
+func (r *OrgSettingsRepository) SaveBusinessHours(ctx context.Context, hours *domain.BusinessHours) (*domain.BusinessHours, error) {
+	q := GetDBTX(ctx, r.conn)
+
+	records := make([]workingDayRecord, 0, len(hours.Days))
+	for _, day := range hours.Days {
+		records = append(records, workingDayRecord{
+			Weekday: int(day.Weekday),
+			Open:    int(day.Open),
+			Close:   int(day.Close),
+		})
+	}
+	workingDays, err := json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedAt pgtype.Timestamptz
+	err = q.QueryRow(ctx, `
+INSERT INTO organization_business_hours (organization_id, timezone, working_days, updated_at)
+VALUES ($1, $2, $3, NOW())
+ON CONFLICT (organization_id) DO UPDATE
+SET timezone = EXCLUDED.timezone,
+    working_days = EXCLUDED.working_days,
+    updated_at = EXCLUDED.updated_at
+RETURNING updated_at`,
+		hours.OrganizationID,
+		hours.Timezone,
+		workingDays,
+	).Scan(&updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := q.Exec(ctx, "DELETE FROM organization_holidays WHERE organization_id = $1", hours.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	for _, holiday := range hours.Holidays {
+		if _, err := q.Exec(ctx,
+			"INSERT INTO organization_holidays (organization_id, holiday_date, name) VALUES ($1, $2, $3)",
+			hours.OrganizationID,
+			holiday.Date,
+			holiday.Name,
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	saved := *hours
+	saved.UpdatedAt = updatedAt.Time
+	return &saved, nil
+}
+
+// GetRegistrationDomains returns the email domains allowed to self-register
+// into an organization, sorted. It is empty if registration is open.
+func (r *OrgSettingsRepository) GetRegistrationDomains(ctx context.Context, orgID uuid.UUID) ([]string, error) {
+	rows, err := GetDBTX(ctx, r.conn).Query(ctx,
+		"SELECT domain FROM organization_registration_domains WHERE organization_id = $1 ORDER BY domain",
+		orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	domains := make([]string, 0)
+	for rows.Next() {
+		var d string
+		if err := rows.Scan(&d); err != nil {
+			return nil, err
+		}
+		domains = append(domains, d)
+	}
+	return domains, rows.Err()
+}
+
+// GetPortalSlug returns the slug of an organization's public portal, or ""
+// if it has none.
+func (r *OrgSettingsRepository) GetPortalSlug(ctx context.Context, orgID uuid.UUID) (string, error) {
+	var slug pgtype.Text
+	err := GetDBTX(ctx, r.conn).QueryRow(ctx, "SELECT portal_slug FROM organizations WHERE id = $1", orgID).Scan(&slug)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", apperrors.ErrNotFound
+		}
+		return "", err
+	}
+	return slug.String, nil
+}
+
+// SavePortalSlug sets the slug of an organization's public portal. An empty
+// slug turns the portal off.
+func (r *OrgSettingsRepository) SavePortalSlug(ctx context.Context, orgID uuid.UUID, slug string) error {
+	_, err := GetDBTX(ctx, r.conn).Exec(ctx,
+		"UPDATE organizations SET portal_slug = $2 WHERE id = $1",
+		orgID,
+		pgtype.Text{String: slug, Valid: slug != ""},
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return apperrors.ErrPortalSlugTaken
+		}
+		return err
+	}
+	return nil
+}
+
+// GetAgentCapacity returns the open ticket limit of an organization's
+// agents.
+func (r *OrgSettingsRepository) GetAgentCapacity(ctx context.Context, orgID uuid.UUID) (*domain.AgentCapacity, error) {
+	var (
+		maxOpen     pgtype.Int4
+		enforcement string
+	)
+	err := GetDBTX(ctx, r.conn).QueryRow(ctx,
+		"SELECT agent_max_open_tickets, agent_capacity_enforcement FROM organizations WHERE id = $1",
+		orgID,
+	).Scan(&maxOpen, &enforcement)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, err
+	}
+
+	capacity := &domain.AgentCapacity{
+		OrganizationID: orgID,
+		Enforcement:    domain.CapacityEnforcement(enforcement),
+	}
+	if maxOpen.Valid {
+		limit := int(maxOpen.Int32)
+		capacity.MaxOpenTickets = &limit
+	}
+	return capacity, nil
+}
+
+// SaveAgentCapacity sets the open ticket limit of an organization's agents.
+func (r *OrgSettingsRepository) SaveAgentCapacity(ctx context.Context, capacity *domain.AgentCapacity) error {
+	maxOpen := pgtype.Int4{}
+	if capacity.MaxOpenTickets != nil {
+		maxOpen = pgtype.Int4{Int32: int32(*capacity.MaxOpenTickets), Valid: true}
+	}
+	_, err := GetDBTX(ctx, r.conn).Exec(ctx,
+		"UPDATE organizations SET agent_max_open_tickets = $2, agent_capacity_enforcement = $3 WHERE id = $1",
+		capacity.OrganizationID, maxOpen, string(capacity.Enforcement),
+	)
+	return err
+}
+
+// GetOrganizationIDByPortalSlug returns the organization whose public portal
+// is served under slug.
+func (r *OrgSettingsRepository) GetOrganizationIDByPortalSlug(ctx context.Context, slug string) (uuid.UUID, error) {
+	var orgID uuid.UUID
+	err := GetDBTX(ctx, r.conn).QueryRow(ctx, "SELECT id FROM organizations WHERE portal_slug = $1", slug).Scan(&orgID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, apperrors.ErrPortalNotFound
+		}
+		return uuid.Nil, err
+	}
+	return orgID, nil
+}
+
+// CreateOrganization adds an organization named name, with its public
+// portal served under slug.
+func (r *OrgSettingsRepository) CreateOrganization(ctx context.Context, name, slug string) (uuid.UUID, error) {
+	var orgID uuid.UUID
+	err := GetDBTX(ctx, r.conn).QueryRow(ctx,
+		"INSERT INTO organizations (name, portal_slug) VALUES ($1, $2) RETURNING id",
+		name,
+		slug,
+	).Scan(&orgID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return uuid.Nil, apperrors.ErrPortalSlugTaken
+		}
+		return uuid.Nil, err
+	}
+	return orgID, nil
+}
+
+// GetOrganization retrieves an organization.
+func (r *OrgSettingsRepository) GetOrganization(ctx context.Context, orgID uuid.UUID) (*domain.Organization, error) {
+	org := &domain.Organization{}
+	var slug pgtype.Text
+	err := GetDBTX(ctx, r.conn).QueryRow(ctx,
+		"SELECT id, name, portal_slug, created_at FROM organizations WHERE id = $1",
+		orgID,
+	).Scan(&org.ID, &org.Name, &slug, &org.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, err
+	}
+	org.PortalSlug = slug.String
+	return org, nil
+}
+
+// SaveRegistrationDomains replaces an organization's registration allowlist.
+func (r *OrgSettingsRepository) SaveRegistrationDomains(ctx context.Context, orgID uuid.UUID, domains []string) error {
+	q := GetDBTX(ctx, r.conn)
+
+	if _, err := q.Exec(ctx, "DELETE FROM organization_registration_domains WHERE organization_id = $1", orgID); err != nil {
+		return err
+	}
+
+	for _, d := range domains {
+		if _, err := q.Exec(ctx,
+			"INSERT INTO organization_registration_domains (organization_id, domain) VALUES ($1, $2)",
+			orgID,
+			d,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
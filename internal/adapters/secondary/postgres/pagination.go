@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+)
+
+// keyset describes how a listing is ordered for keyset pagination: by
+// creation time and then ID, which breaks ties between rows created in the
+// same instant so every row has a stable place in the listing.
+type keyset struct {
+	createdAt string // column holding the creation time, e.g. "t.created_at"
+	id        string // column holding the row ID
+	idType    string // SQL type of the ID column, e.g. "bigint" or "uuid"
+	ascending bool   // oldest first instead of newest first
+}
+
+var (
+	ticketKeyset   = keyset{createdAt: "created_at", id: "id", idType: "bigint"}
+	commentKeyset  = keyset{createdAt: "created_at", id: "id", idType: "bigint", ascending: true}
+	userKeyset     = keyset{createdAt: "u.created_at", id: "u.id", idType: "uuid"}
+	auditLogKeyset = keyset{createdAt: "created_at", id: "id", idType: "bigint"}
+)
+
+// after appends the cursor to args and returns the condition that keeps the
+// rows following it. It returns an empty condition for the first page.
+func (k keyset) after(cursor *domain.PageCursor, args []any) (string, []any) {
+	if cursor == nil {
+		return "", args
+	}
+
+	op := "<"
+	if k.ascending {
+		op = ">"
+	}
+	args = append(args, cursor.CreatedAt, cursor.ID)
+	return fmt.Sprintf("(%s, %s) %s ($%d, $%d::%s)", k.createdAt, k.id, op, len(args)-1, len(args), k.idType), args
+}
+
+// orderBy returns the ORDER BY expression of the listing.
+func (k keyset) orderBy() string {
+	dir := "DESC"
+	if k.ascending {
+		dir = "ASC"
+	}
+	return fmt.Sprintf("%s %s, %s %s", k.createdAt, dir, k.id, dir)
+}
+
+// limit returns how many rows to fetch for page: one more than the page
+// holds, which tells keysetPage whether another page follows.
+func (k keyset) limit(page domain.PageRequest) int {
+	return page.PageLimit() + 1
+}
+
+// keysetPage turns the rows fetched with keyset.limit into a page, dropping
+// the extra row and pointing the cursor at the page's last row if another
+// page follows.
+func keysetPage[T any](rows []T, page domain.PageRequest, cursorOf func(T) domain.PageCursor) domain.Page[T] {
+	limit := page.PageLimit()
+	if len(rows) <= limit {
+		return domain.Page[T]{Items: rows}
+	}
+
+	rows = rows[:limit]
+	next := cursorOf(rows[limit-1])
+	return domain.Page[T]{Items: rows, NextCursor: &next}
+}
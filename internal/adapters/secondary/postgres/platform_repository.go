@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// PlatformRepository reads organizations and their activity across the
+// whole platform, for super-admins.
+type PlatformRepository struct {
+	conn DBTX
+}
+
+var _ ports.PlatformRepository = (*PlatformRepository)(nil)
+
+// NewPlatformRepository creates a new platform repository.
+func NewPlatformRepository(conn DBTX) ports.PlatformRepository {
+	return &PlatformRepository{conn: conn}
+}
+
+// ListOrganizations returns a page of organizations, oldest first, with
+// their active users and open tickets, and how many match the filter.
+func (r *PlatformRepository) ListOrganizations(ctx context.Context, filter domain.OrganizationFilter) ([]*domain.OrganizationSummary, domain.Total, error) {
+	q := GetDBTX(ctx, r.conn)
+
+	where := "TRUE"
+	args := make([]any, 0, 3)
+	if filter.Query != "" {
+		args = append(args, "%"+escapeLike(filter.Query)+"%")
+		where = "(o.name ILIKE $1 OR o.portal_slug ILIKE $1)"
+	}
+
+	total, err := exactCount(ctx, q, "FROM organizations o WHERE "+where, args...)
+	if err != nil {
+		return nil, domain.Total{}, err
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	rows, err := q.Query(ctx, `
+SELECT o.id, o.name, o.portal_slug, o.created_at,
+       (SELECT COUNT(*) FROM users u WHERE u.organization_id = o.id AND u.is_active),
+       (SELECT COUNT(*)
+          FROM tickets t
+         WHERE t.organization_id = o.id
+           AND t.status <> 'CLOSED'
+           AND t.deleted_at IS NULL)
+FROM organizations o
+WHERE `+where+`
+ORDER BY o.created_at, o.id
+LIMIT $`+strconv.Itoa(len(args)-1)+` OFFSET $`+strconv.Itoa(len(args)),
+		args...,
+	)
+	if err != nil {
+		return nil, domain.Total{}, err
+	}
+	defer rows.Close()
+
+	summaries := make([]*domain.OrganizationSummary, 0)
+	for rows.Next() {
+		var (
+			summary domain.OrganizationSummary
+			slug    pgtype.Text
+		)
+		if err := rows.Scan(
+			&summary.Organization.ID,
+			&summary.Organization.Name,
+			&slug,
+			&summary.Organization.CreatedAt,
+			&summary.Usage.ActiveUsers,
+			&summary.Usage.OpenTickets,
+		); err != nil {
+			return nil, domain.Total{}, err
+		}
+		summary.Organization.PortalSlug = slug.String
+		summaries = append(summaries, &summary)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.Total{}, err
+	}
+	return summaries, total, nil
+}
+
+// GetHealth counts the organization's tickets created since since, its
+// open tickets past their due date and the state of its webhook deliveries.
+func (r *PlatformRepository) GetHealth(ctx context.Context, orgID uuid.UUID, since time.Time) (*domain.OrganizationHealth, error) {
+	health := &domain.OrganizationHealth{Since: since}
+	var lastActiveAt pgtype.Timestamptz
+	err := GetDBTX(ctx, r.conn).QueryRow(ctx, `
+SELECT
+    (SELECT MAX(last_active_at) FROM users WHERE organization_id = $1),
+    (SELECT COUNT(*)
+       FROM tickets
+      WHERE organization_id = $1
+        AND created_at >= $2
+        AND deleted_at IS NULL),
+    (SELECT COUNT(*)
+       FROM tickets
+      WHERE organization_id = $1
+        AND status <> 'CLOSED'
+        AND deleted_at IS NULL
+        AND due_at < NOW()),
+    (SELECT COUNT(*)
+       FROM webhook_deliveries d
+       JOIN webhooks w ON w.id = d.webhook_id
+      WHERE w.organization_id = $1
+        AND d.status = 'PENDING'),
+    (SELECT COUNT(*)
+       FROM webhook_deliveries d
+       JOIN webhooks w ON w.id = d.webhook_id
+      WHERE w.organization_id = $1
+        AND d.status = 'DEAD'
+        AND d.created_at >= $2)`,
+		orgID, since,
+	).Scan(
+		&lastActiveAt,
+		&health.TicketsCreated,
+		&health.OverdueTickets,
+		&health.PendingWebhookDeliveries,
+		&health.DeadWebhookDeliveries,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if lastActiveAt.Valid {
+		health.LastActiveAt = &lastActiveAt.Time
+	}
+	return health, nil
+}
@@ -1,9 +1,14 @@
 -- name: CreateComment :one
-INSERT INTO comments (ticket_id, author_id, body)
-VALUES ($1, $2, $3)
+INSERT INTO comments (ticket_id, author_id, body, body_html)
+VALUES ($1, $2, $3, $4)
 RETURNING *;
 
 -- name: ListCommentsByTicketID :many
 SELECT * FROM comments
 WHERE ticket_id = $1
 ORDER BY created_at ASC;
+
+-- name: ListLatestCommentsByTicketIDs :many
+SELECT DISTINCT ON (ticket_id) * FROM comments
+WHERE ticket_id = ANY(@ticket_ids::bigint[])
+ORDER BY ticket_id, created_at DESC, id DESC;
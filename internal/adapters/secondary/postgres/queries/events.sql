@@ -1,6 +1,6 @@
 -- name: CreateTicketEvent :one
-INSERT INTO ticket_events (ticket_id, type, payload, actor_id)
-VALUES ($1, $2, $3, $4)
+INSERT INTO ticket_events (ticket_id, type, version, payload, actor_id)
+VALUES ($1, $2, $3, $4, $5)
 RETURNING *;
 
 -- name: ListTicketEvents :many
@@ -1,11 +1,14 @@
 -- name: CreateTicket :one
-INSERT INTO tickets (title, description, status, priority, requester_id)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO tickets (title, description, status, priority, requester_id, due_at, organization_id, description_html, visibility)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING *;
 
 -- name: GetTicketByID :one
 SELECT * FROM tickets
-WHERE id = $1 LIMIT 1;
+WHERE id = $1
+  AND organization_id = $2
+  AND deleted_at IS NULL
+LIMIT 1;
 
 -- name: UpdateTicket :one
 UPDATE tickets
@@ -13,13 +16,46 @@ SET
     status = $2,
     assignee_id = $3,
     updated_at = $4,
-    closed_at = $5
+    closed_at = $5,
+    title = $6,
+    description = $7,
+    priority = $8,
+    team_id = $9,
+    due_at = $10,
+    resolution_working_seconds = $11,
+    tags = $12,
+    description_html = $14
+WHERE id = $1
+  AND organization_id = $13
+  AND deleted_at IS NULL
+RETURNING *;
+
+-- name: ClaimTicket :one
+UPDATE tickets
+SET
+    assignee_id = $2,
+    updated_at = $3
 WHERE id = $1
+  AND organization_id = $4
+  AND assignee_id IS NULL
+  AND status <> 'CLOSED'
+  AND deleted_at IS NULL
 RETURNING *;
 
+-- name: ListOpenTicketsByAssignee :many
+SELECT * FROM tickets
+WHERE assignee_id = $1
+  AND organization_id = $2
+  AND status <> 'CLOSED'
+  AND deleted_at IS NULL
+ORDER BY id
+FOR UPDATE;
+
 -- name: ListTicketsPaginated :many
 SELECT * FROM tickets
 WHERE
+    organization_id = sqlc.arg('organization_id')
+  AND
     (status = sqlc.narg('status') OR sqlc.narg('status') IS NULL)
   AND
     (priority = sqlc.narg('priority') OR sqlc.narg('priority') IS NULL)
@@ -32,6 +68,51 @@ WHERE
     (created_at >= sqlc.narg('created_from') OR sqlc.narg('created_from') IS NULL)
   AND
     (created_at < sqlc.narg('created_to') OR sqlc.narg('created_to') IS NULL)
+  AND
+    (
+      (sqlc.narg('needs_reassignment') = TRUE AND status <> 'CLOSED' AND assignee_id IN (
+        SELECT u.id FROM users u
+        JOIN out_of_office_windows w ON w.user_id = u.id
+        WHERE u.reassign_when_away AND w.starts_at <= NOW() AND w.ends_at > NOW()
+      ))
+      OR sqlc.narg('needs_reassignment') IS NULL
+    )
+  AND
+    (team_id = sqlc.narg('team_id') OR sqlc.narg('team_id') IS NULL)
+  AND
+    (deleted_at IS NULL OR sqlc.arg('include_deleted')::boolean)
+  AND
+    (NOT sqlc.arg('hide_snoozed')::boolean OR NOT EXISTS (
+      SELECT 1 FROM ticket_snoozes s WHERE s.ticket_id = tickets.id AND s.snoozed_until > NOW()
+    ))
+ORDER BY created_at DESC
+LIMIT sqlc.arg('limit')
+    OFFSET sqlc.arg('offset');
+
+-- name: ListTicketsByAssigneePaginated :many
+SELECT * FROM tickets
+WHERE
+    organization_id = sqlc.arg('organization_id')
+  AND
+    assignee_id = sqlc.arg('assignee_id')
+  AND
+    (status = sqlc.narg('status') OR sqlc.narg('status') IS NULL)
+  AND
+    (priority = sqlc.narg('priority') OR sqlc.narg('priority') IS NULL)
+  AND
+    (requester_id = sqlc.narg('requester_id') OR sqlc.narg('requester_id') IS NULL)
+  AND
+    (created_at >= sqlc.narg('created_from') OR sqlc.narg('created_from') IS NULL)
+  AND
+    (created_at < sqlc.narg('created_to') OR sqlc.narg('created_to') IS NULL)
+  AND
+    (team_id = sqlc.narg('team_id') OR sqlc.narg('team_id') IS NULL)
+  AND
+    (deleted_at IS NULL OR sqlc.arg('include_deleted')::boolean)
+  AND
+    (NOT sqlc.arg('hide_snoozed')::boolean OR NOT EXISTS (
+      SELECT 1 FROM ticket_snoozes s WHERE s.ticket_id = tickets.id AND s.snoozed_until > NOW()
+    ))
 ORDER BY created_at DESC
 LIMIT sqlc.arg('limit')
     OFFSET sqlc.arg('offset');
@@ -39,6 +120,8 @@ LIMIT sqlc.arg('limit')
 -- name: ListTicketsByRequesterPaginated :many
 SELECT * FROM tickets
 WHERE
+    organization_id = sqlc.arg('organization_id')
+  AND
     requester_id = sqlc.arg('requester_id')
   AND
     (status = sqlc.narg('status') OR sqlc.narg('status') IS NULL)
@@ -53,6 +136,21 @@ WHERE
     (created_at >= sqlc.narg('created_from') OR sqlc.narg('created_from') IS NULL)
   AND
     (created_at < sqlc.narg('created_to') OR sqlc.narg('created_to') IS NULL)
+  AND
+    (
+      (sqlc.narg('needs_reassignment') = TRUE AND status <> 'CLOSED' AND assignee_id IN (
+        SELECT u.id FROM users u
+        JOIN out_of_office_windows w ON w.user_id = u.id
+        WHERE u.reassign_when_away AND w.starts_at <= NOW() AND w.ends_at > NOW()
+      ))
+      OR sqlc.narg('needs_reassignment') IS NULL
+    )
+  AND
+    (team_id = sqlc.narg('team_id') OR sqlc.narg('team_id') IS NULL)
+  AND
+    (deleted_at IS NULL OR sqlc.arg('include_deleted')::boolean)
+  AND
+    (NOT sqlc.arg('hide_internal')::boolean OR visibility = 'PUBLIC')
 ORDER BY created_at DESC
 LIMIT sqlc.arg('limit')
     OFFSET sqlc.arg('offset');
@@ -1,14 +1,19 @@
 -- name: CreateUser :one
 INSERT INTO users (organization_id, full_name, email, hashed_password)
 VALUES ($1, $2, $3, $4)
-    RETURNING id, organization_id, full_name, email, hashed_password, created_at, is_active, last_active_at;
+    RETURNING id, organization_id, full_name, email, hashed_password, created_at, is_active, last_active_at, tokens_revoked_at, phone_number, sms_opt_in, locale, timezone;
 
 -- name: GetUserByEmail :one
-SELECT id, organization_id, full_name, email, hashed_password, created_at, is_active, last_active_at FROM users
-WHERE email = $1 LIMIT 1;
+SELECT id, organization_id, full_name, email, hashed_password, created_at, is_active, last_active_at, tokens_revoked_at, phone_number, sms_opt_in, locale, timezone FROM users
+WHERE organization_id = $1 AND email = $2 LIMIT 1;
+
+-- name: ListUsersByEmail :many
+SELECT id, organization_id, full_name, email, hashed_password, created_at, is_active, last_active_at, tokens_revoked_at, phone_number, sms_opt_in, locale, timezone FROM users
+WHERE email = $1
+ORDER BY created_at, id;
 
 -- name: GetUserByID :one
-SELECT id, organization_id, full_name, email, hashed_password, created_at, is_active, last_active_at FROM users
+SELECT id, organization_id, full_name, email, hashed_password, created_at, is_active, last_active_at, tokens_revoked_at, phone_number, sms_opt_in, locale, timezone FROM users
 WHERE id = $1 LIMIT 1;
 
 -- name: CountUsers :one
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// QuotaRepository reads per-organization quotas and usage.
+type QuotaRepository struct {
+	conn DBTX
+}
+
+var _ ports.QuotaRepository = (*QuotaRepository)(nil)
+
+// NewQuotaRepository creates a new quota repository.
+func NewQuotaRepository(conn DBTX) ports.QuotaRepository {
+	return &QuotaRepository{conn: conn}
+}
+
+// GetQuota retrieves an organization's own quota, returning
+// apperrors.ErrNotFound if it has none.
+func (r *QuotaRepository) GetQuota(ctx context.Context, orgID uuid.UUID) (*domain.OrgQuota, error) {
+	var maxUsers, maxOpenTickets pgtype.Int4
+	err := GetDBTX(ctx, r.conn).QueryRow(ctx,
+		"SELECT max_users, max_open_tickets FROM organization_quotas WHERE organization_id = $1",
+		orgID,
+	).Scan(&maxUsers, &maxOpenTickets)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, err
+	}
+
+	var quota domain.OrgQuota
+	if maxUsers.Valid {
+		limit := int(maxUsers.Int32)
+		quota.MaxUsers = &limit
+	}
+	if maxOpenTickets.Valid {
+		limit := int(maxOpenTickets.Int32)
+		quota.MaxOpenTickets = &limit
+	}
+	return &quota, nil
+}
+
+// GetUsage counts an organization's active users and open tickets.
+func (r *QuotaRepository) GetUsage(ctx context.Context, orgID uuid.UUID) (*domain.OrgUsage, error) {
+	var usage domain.OrgUsage
+	err := GetDBTX(ctx, r.conn).QueryRow(ctx, `
+SELECT
+    (SELECT COUNT(*) FROM users WHERE organization_id = $1 AND is_active),
+    (SELECT COUNT(*)
+       FROM tickets t
+      WHERE t.organization_id = $1
+        AND t.status <> 'CLOSED'
+        AND t.deleted_at IS NULL)`,
+		orgID,
+	).Scan(&usage.ActiveUsers, &usage.OpenTickets)
+	if err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
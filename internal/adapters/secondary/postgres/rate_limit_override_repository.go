@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// RateLimitOverrideRepository persists rate limit overrides.
+type RateLimitOverrideRepository struct {
+	conn DBTX
+}
+
+var _ ports.RateLimitOverrideRepository = (*RateLimitOverrideRepository)(nil)
+
+// NewRateLimitOverrideRepository creates a new rate limit override repository.
+func NewRateLimitOverrideRepository(conn DBTX) ports.RateLimitOverrideRepository {
+	return &RateLimitOverrideRepository{conn: conn}
+}
+
+const rateLimitOverrideColumns = "id, organization_id, user_id, unlimited, requests_per_second, burst, note, created_by, created_at"
+
+func scanRateLimitOverride(row pgx.Row) (*domain.RateLimitOverride, error) {
+	var (
+		o         domain.RateLimitOverride
+		userID    pgtype.UUID
+		createdAt pgtype.Timestamptz
+	)
+	if err := row.Scan(
+		&o.ID,
+		&o.OrganizationID,
+		&userID,
+		&o.Unlimited,
+		&o.RequestsPerSecond,
+		&o.Burst,
+		&o.Note,
+		&o.CreatedBy,
+		&createdAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if userID.Valid {
+		id := uuid.UUID(userID.Bytes)
+		o.UserID = &id
+	}
+	o.CreatedAt = createdAt.Time
+	return &o, nil
+}
+
+// Upsert stores an override, replacing the existing one for the same
+// organization and user.
+func (r *RateLimitOverrideRepository) Upsert(ctx context.Context, override *domain.RateLimitOverride) (*domain.RateLimitOverride, error) {
+	var userID pgtype.UUID
+	if override.UserID != nil {
+		userID = pgtype.UUID{Bytes: *override.UserID, Valid: true}
+	}
+
+	row := GetDBTX(ctx, r.conn).QueryRow(ctx, `
+INSERT INTO rate_limit_overrides (id, organization_id, user_id, unlimited, requests_per_second, burst, note, created_by, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (organization_id, (COALESCE(user_id, '00000000-0000-0000-0000-000000000000')))
+DO UPDATE SET unlimited = EXCLUDED.unlimited,
+              requests_per_second = EXCLUDED.requests_per_second,
+              burst = EXCLUDED.burst,
+              note = EXCLUDED.note,
+              created_by = EXCLUDED.created_by,
+              created_at = EXCLUDED.created_at
+RETURNING `+rateLimitOverrideColumns,
+		override.ID,
+		override.OrganizationID,
+		userID,
+		override.Unlimited,
+		override.RequestsPerSecond,
+		override.Burst,
+		override.Note,
+		override.CreatedBy,
+		override.CreatedAt,
+	)
+	return scanRateLimitOverride(row)
+}
+
+// GetByID retrieves a single override.
+func (r *RateLimitOverrideRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.RateLimitOverride, error) {
+	row := GetDBTX(ctx, r.conn).QueryRow(ctx, "SELECT "+rateLimitOverrideColumns+" FROM rate_limit_overrides WHERE id = $1", id)
+	override, err := scanRateLimitOverride(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.ErrRateLimitOverrideNotFound
+	}
+	return override, err
+}
+
+// ListByOrganization returns an organization's overrides, the
+// organization-wide one first.
+func (r *RateLimitOverrideRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.RateLimitOverride, error) {
+	return r.list(ctx, "SELECT "+rateLimitOverrideColumns+` FROM rate_limit_overrides
+WHERE organization_id = $1
+ORDER BY user_id NULLS FIRST, created_at`, orgID)
+}
+
+// ListAll returns the overrides of every organization.
+func (r *RateLimitOverrideRepository) ListAll(ctx context.Context) ([]*domain.RateLimitOverride, error) {
+	return r.list(ctx, "SELECT "+rateLimitOverrideColumns+" FROM rate_limit_overrides ORDER BY organization_id, user_id NULLS FIRST")
+}
+
+// Delete removes an override.
+func (r *RateLimitOverrideRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx, "DELETE FROM rate_limit_overrides WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrRateLimitOverrideNotFound
+	}
+	return nil
+}
+
+func (r *RateLimitOverrideRepository) list(ctx context.Context, query string, args ...any) ([]*domain.RateLimitOverride, error) {
+	rows, err := GetDBTX(ctx, r.conn).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	overrides := make([]*domain.RateLimitOverride, 0)
+	for rows.Next() {
+		override, err := scanRateLimitOverride(rows)
+		if err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, override)
+	}
+	return overrides, rows.Err()
+}
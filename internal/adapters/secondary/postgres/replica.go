@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ReadReplica routes list and analytics queries to a read-only replica
+// while it is healthy. When a health check fails, those queries go to the
+// primary until the replica answers again.
+type ReadReplica struct {
+	pool    *pgxpool.Pool
+	healthy atomic.Bool
+	logger  *slog.Logger
+}
+
+// NewReadReplica creates a replica router for pool and checks that the
+// replica is reachable, reading from the primary if it isn't.
+func NewReadReplica(ctx context.Context, pool *pgxpool.Pool, logger *slog.Logger) *ReadReplica {
+	r := &ReadReplica{
+		pool:   pool,
+		logger: logger.With("component", "read_replica"),
+	}
+	r.healthy.Store(true)
+	r.check(ctx, 5*time.Second)
+	return r
+}
+
+// Healthy reports whether reads are being sent to the replica.
+func (r *ReadReplica) Healthy() bool {
+	return r.healthy.Load()
+}
+
+// Monitor pings the replica every interval until ctx is cancelled.
+func (r *ReadReplica) Monitor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.check(ctx, interval)
+		}
+	}
+}
+
+func (r *ReadReplica) check(ctx context.Context, timeout time.Duration) {
+	pingCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := r.pool.Ping(pingCtx)
+	if ctx.Err() != nil {
+		return
+	}
+	healthy := err == nil
+	if r.healthy.Swap(healthy) == healthy {
+		return
+	}
+	if healthy {
+		r.logger.Info("read replica recovered; reading from replica")
+	} else {
+		r.logger.Warn("read replica unhealthy; reading from primary", "error", err)
+	}
+}
+
+// RepositoryOption configures a repository.
+type RepositoryOption func(*repositoryOptions)
+
+type repositoryOptions struct {
+	replica     *ReadReplica
+	countBudget time.Duration
+}
+
+// WithReadReplica sends the repository's list and analytics queries to
+// replica while it is healthy.
+func WithReadReplica(replica *ReadReplica) RepositoryOption {
+	return func(o *repositoryOptions) {
+		o.replica = replica
+	}
+}
+
+func applyRepositoryOptions(opts []RepositoryOption) repositoryOptions {
+	var o repositoryOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// GetReadDBTX returns the connection for a query that tolerates replication
+// lag: the transaction from context if available, otherwise conn if it is a
+// transaction, otherwise the replica when it is healthy, otherwise conn.
+func GetReadDBTX(ctx context.Context, conn DBTX, replica *ReadReplica) DBTX {
+	if tx, ok := TxFromContext(ctx); ok {
+		return instrumentWith(conn, tx)
+	}
+	if replica != nil && replica.Healthy() && !inTransaction(conn) {
+		return instrumentWith(conn, replica.pool)
+	}
+	return conn
+}
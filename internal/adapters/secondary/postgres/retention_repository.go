@@ -0,0 +1,240 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// RetentionRepository persists data retention policies and purges the
+// tickets they expire. Tickets belong to their requester's organization.
+type RetentionRepository struct {
+	conn        DBTX
+	countBudget time.Duration
+}
+
+var _ ports.RetentionRepository = (*RetentionRepository)(nil)
+
+// NewRetentionRepository creates a new retention repository.
+func NewRetentionRepository(conn DBTX, opts ...RepositoryOption) ports.RetentionRepository {
+	o := applyRepositoryOptions(opts)
+	return &RetentionRepository{conn: conn, countBudget: o.countBudget}
+}
+
+const retentionPolicyColumns = "organization_id, closed_ticket_days, grace_days, updated_at"
+
+func scanRetentionPolicy(row pgx.Row) (*domain.RetentionPolicy, error) {
+	var (
+		p          domain.RetentionPolicy
+		closedDays pgtype.Int4
+		updatedAt  pgtype.Timestamptz
+	)
+	if err := row.Scan(&p.OrganizationID, &closedDays, &p.GraceDays, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	if closedDays.Valid {
+		days := int(closedDays.Int32)
+		p.ClosedTicketDays = &days
+	}
+	p.UpdatedAt = updatedAt.Time
+	return &p, nil
+}
+
+// GetPolicy retrieves an organization's retention policy, returning
+// apperrors.ErrNotFound if none has been configured.
+func (r *RetentionRepository) GetPolicy(ctx context.Context, orgID uuid.UUID) (*domain.RetentionPolicy, error) {
+	row := GetDBTX(ctx, r.conn).QueryRow(ctx,
+		"SELECT "+retentionPolicyColumns+" FROM organization_retention_policies WHERE organization_id = $1", orgID)
+	policy, err := scanRetentionPolicy(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.ErrNotFound
+	}
+	return policy, err
+}
+
+// SavePolicy creates or replaces an organization's retention policy.
+func (r *RetentionRepository) SavePolicy(ctx context.Context, policy *domain.RetentionPolicy) (*domain.RetentionPolicy, error) {
+	var closedDays pgtype.Int4
+	if policy.ClosedTicketDays != nil {
+		closedDays = pgtype.Int4{Int32: int32(*policy.ClosedTicketDays), Valid: true}
+	}
+
+	row := GetDBTX(ctx, r.conn).QueryRow(ctx, `
+INSERT INTO organization_retention_policies (organization_id, closed_ticket_days, grace_days, updated_at)
+VALUES ($1, $2, $3, NOW())
+ON CONFLICT (organization_id)
+DO UPDATE SET closed_ticket_days = EXCLUDED.closed_ticket_days,
+              grace_days = EXCLUDED.grace_days,
+              updated_at = EXCLUDED.updated_at
+RETURNING `+retentionPolicyColumns,
+		policy.OrganizationID,
+		closedDays,
+		policy.GraceDays,
+	)
+	return scanRetentionPolicy(row)
+}
+
+// ListEnabledPolicies returns the policies that purge closed tickets.
+func (r *RetentionRepository) ListEnabledPolicies(ctx context.Context) ([]*domain.RetentionPolicy, error) {
+	rows, err := GetDBTX(ctx, r.conn).Query(ctx, "SELECT "+retentionPolicyColumns+` FROM organization_retention_policies
+WHERE closed_ticket_days IS NOT NULL
+ORDER BY organization_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	policies := make([]*domain.RetentionPolicy, 0)
+	for rows.Next() {
+		policy, err := scanRetentionPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	return policies, rows.Err()
+}
+
+// retentionTickets is an organization's closed tickets, live and
+// archived, as seen by retention.
+const retentionTickets = `(
+    SELECT id, organization_id, title, closed_at, deleted_at FROM tickets
+    WHERE organization_id = $1 AND status = 'CLOSED' AND closed_at IS NOT NULL
+    UNION ALL
+    SELECT ticket_id, organization_id, title, closed_at, deleted_at FROM ticket_archive
+    WHERE organization_id = $1
+) t`
+
+// purgeCandidatesWhere matches an organization's soft-deleted tickets and
+// its tickets closed before $2.
+const purgeCandidatesWhere = `
+FROM ` + retentionTickets + `
+WHERE t.deleted_at IS NOT NULL OR t.closed_at < $2`
+
+// ListPurgeCandidates returns a page of the tickets a retention policy will
+// purge, those already soft-deleted first, and the total number of them.
+func (r *RetentionRepository) ListPurgeCandidates(ctx context.Context, orgID uuid.UUID, closedBefore time.Time, limit, offset int) ([]*domain.PurgeCandidate, domain.Total, error) {
+	q := GetDBTX(ctx, r.conn)
+
+	total, err := countRows(ctx, q, r.countBudget, purgeCandidatesWhere, orgID, closedBefore)
+	if err != nil {
+		return nil, domain.Total{}, err
+	}
+
+	rows, err := q.Query(ctx, "SELECT t.id, t.title, t.closed_at, t.deleted_at"+purgeCandidatesWhere+`
+ORDER BY t.deleted_at NULLS LAST, t.closed_at, t.id
+LIMIT $3 OFFSET $4`, orgID, closedBefore, limit, offset)
+	if err != nil {
+		return nil, domain.Total{}, err
+	}
+	defer rows.Close()
+
+	candidates := make([]*domain.PurgeCandidate, 0)
+	for rows.Next() {
+		var (
+			c         domain.PurgeCandidate
+			closedAt  pgtype.Timestamptz
+			deletedAt pgtype.Timestamptz
+		)
+		if err := rows.Scan(&c.TicketID, &c.Title, &closedAt, &deletedAt); err != nil {
+			return nil, domain.Total{}, err
+		}
+		c.ClosedAt = closedAt.Time
+		if deletedAt.Valid {
+			c.DeletedAt = &deletedAt.Time
+		}
+		candidates = append(candidates, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.Total{}, err
+	}
+	return candidates, total, nil
+}
+
+// CountExpired counts the organization's closed tickets that are due to be
+// soft-deleted and its soft-deleted tickets that are due to be removed.
+func (r *RetentionRepository) CountExpired(ctx context.Context, orgID uuid.UUID, closedBefore, deletedBefore time.Time) (int64, int64, error) {
+	var softDelete, hardDelete int64
+	err := GetDBTX(ctx, r.conn).QueryRow(ctx, `
+SELECT COUNT(*) FILTER (WHERE t.deleted_at IS NULL AND t.closed_at < $2),
+       COUNT(*) FILTER (WHERE t.deleted_at < $3)
+FROM `+retentionTickets,
+		orgID, closedBefore, deletedBefore,
+	).Scan(&softDelete, &hardDelete)
+	return softDelete, hardDelete, err
+}
+
+// SoftDeleteExpired hides the organization's tickets closed before
+// closedBefore, marking them deleted at now. Live tickets are queued for
+// search indexing so they are removed from the search index too; archived
+// ones are no longer indexed.
+func (r *RetentionRepository) SoftDeleteExpired(ctx context.Context, orgID uuid.UUID, closedBefore, now time.Time) (int64, error) {
+	q := GetDBTX(ctx, r.conn)
+	tag, err := q.Exec(ctx, `
+WITH deleted AS (
+    UPDATE tickets t
+    SET deleted_at = $3
+    WHERE t.organization_id = $1
+      AND t.status = 'CLOSED'
+      AND t.deleted_at IS NULL
+      AND t.closed_at < $2
+    RETURNING t.id, t.organization_id
+)
+INSERT INTO search_index_queue (ticket_id, organization_id)
+SELECT id, organization_id FROM deleted
+ON CONFLICT (ticket_id) DO UPDATE
+SET enqueued_at = NOW(), available_at = NOW()`,
+		orgID, closedBefore, now,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	archived, err := q.Exec(ctx, `
+UPDATE ticket_archive
+SET deleted_at = $3
+WHERE organization_id = $1
+  AND deleted_at IS NULL
+  AND closed_at < $2`,
+		orgID, closedBefore, now,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected() + archived.RowsAffected(), nil
+}
+
+// HardDeleteExpired removes the organization's tickets soft-deleted before
+// deletedBefore, live and archived. Their comments and events are removed
+// with them.
+func (r *RetentionRepository) HardDeleteExpired(ctx context.Context, orgID uuid.UUID, deletedBefore time.Time) (int64, error) {
+	q := GetDBTX(ctx, r.conn)
+	tag, err := q.Exec(ctx, `
+DELETE FROM tickets t
+WHERE t.organization_id = $1
+  AND t.deleted_at < $2`,
+		orgID, deletedBefore,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	archived, err := q.Exec(ctx, `
+DELETE FROM ticket_archive
+WHERE organization_id = $1
+  AND deleted_at < $2`,
+		orgID, deletedBefore,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected() + archived.RowsAffected(), nil
+}
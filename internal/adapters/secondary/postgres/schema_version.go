@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SchemaVersionReader reads the schema version the migrations have brought
+// the database to.
+type SchemaVersionReader struct {
+	conn DBTX
+}
+
+// NewSchemaVersionReader creates a reader of the schema version.
+func NewSchemaVersionReader(conn DBTX) *SchemaVersionReader {
+	return &SchemaVersionReader{conn: conn}
+}
+
+// SchemaVersion returns the version recorded in schema_migrations, and
+// whether its migration failed halfway and left the schema dirty. It
+// returns version 0 if no migration has been applied.
+func (r *SchemaVersionReader) SchemaVersion(ctx context.Context) (uint, bool, error) {
+	var (
+		version int64
+		dirty   bool
+	)
+	err := r.conn.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return uint(version), dirty, nil
+}
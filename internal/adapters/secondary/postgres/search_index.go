@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// SearchIndex searches tickets and comments with Postgres full-text search.
+// Documents live in the search_documents table, where their weighted
+// tsvector is generated: matches in the title rank above those in the body.
+// Searches go to the read replica if one is configured.
+type SearchIndex struct {
+	conn    DBTX
+	replica *ReadReplica
+}
+
+var _ ports.SearchIndex = (*SearchIndex)(nil)
+
+// NewSearchIndex creates a new Postgres search index.
+func NewSearchIndex(conn DBTX, opts ...RepositoryOption) ports.SearchIndex {
+	o := applyRepositoryOptions(opts)
+	return &SearchIndex{conn: conn, replica: o.replica}
+}
+
+// Index adds the documents, replacing any stored under the same key.
+func (s *SearchIndex) Index(ctx context.Context, docs []domain.SearchDocument) error {
+	const upsert = `
+INSERT INTO search_documents (kind, id, ticket_id, organization_id, requester_id, visibility, title, body, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (kind, id) DO UPDATE
+SET ticket_id = EXCLUDED.ticket_id,
+    organization_id = EXCLUDED.organization_id,
+    requester_id = EXCLUDED.requester_id,
+    visibility = EXCLUDED.visibility,
+    title = EXCLUDED.title,
+    body = EXCLUDED.body,
+    updated_at = EXCLUDED.updated_at
+`
+
+	q := GetDBTX(ctx, s.conn)
+	for _, doc := range docs {
+		if _, err := q.Exec(ctx, upsert,
+			string(doc.Kind),
+			doc.ID,
+			doc.TicketID,
+			doc.OrganizationID,
+			doc.RequesterID,
+			string(doc.Visibility),
+			doc.Title,
+			doc.Body,
+			pgtype.Timestamptz{Time: doc.UpdatedAt.UTC(), Valid: true},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteTicket removes the ticket's documents.
+func (s *SearchIndex) DeleteTicket(ctx context.Context, orgID uuid.UUID, ticketID int64) error {
+	_, err := GetDBTX(ctx, s.conn).Exec(ctx,
+		"DELETE FROM search_documents WHERE organization_id = $1 AND ticket_id = $2",
+		orgID,
+		ticketID,
+	)
+	return err
+}
+
+// Search returns the best matching documents. The text is parsed like a web
+// search, so it may use quotes, OR and a leading - to exclude words.
+func (s *SearchIndex) Search(ctx context.Context, query domain.SearchQuery) ([]*domain.SearchHit, error) {
+	const search = `
+SELECT d.kind, d.id, d.ticket_id, d.title,
+       ts_headline('simple', d.body, q, 'StartSel=<em>, StopSel=</em>, MaxFragments=1, MaxWords=30, MinWords=10'),
+       ts_rank(d.document, q)
+FROM search_documents d
+CROSS JOIN websearch_to_tsquery('simple', $2) q
+WHERE d.organization_id = $1
+  AND d.document @@ q
+  AND ($3::uuid IS NULL OR d.requester_id = $3)
+  AND (NOT $4 OR d.visibility <> 'INTERNAL')
+ORDER BY 6 DESC, d.ticket_id DESC, d.id DESC
+LIMIT $5
+`
+
+	requesterID := pgtype.UUID{}
+	if query.RequesterID != nil {
+		requesterID = pgtype.UUID{Bytes: *query.RequesterID, Valid: true}
+	}
+
+	rows, err := GetReadDBTX(ctx, s.conn, s.replica).Query(ctx, search,
+		query.OrganizationID,
+		query.Text,
+		requesterID,
+		query.HideInternal,
+		query.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hits := make([]*domain.SearchHit, 0)
+	for rows.Next() {
+		var (
+			hit  domain.SearchHit
+			kind string
+			rank float32
+		)
+		if err := rows.Scan(&kind, &hit.ID, &hit.TicketID, &hit.Title, &hit.Snippet, &rank); err != nil {
+			return nil, err
+		}
+		hit.Kind = domain.SearchDocumentKind(kind)
+		hit.Snippet = strings.TrimSpace(hit.Snippet)
+		hit.Score = float64(rank)
+		hits = append(hits, &hit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return hits, nil
+}
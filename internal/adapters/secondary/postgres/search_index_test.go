@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchIndex_IndexSearchDelete(t *testing.T) {
+	ctx := context.Background()
+	index := NewSearchIndex(testPool)
+	orgID := uuid.New()
+	requesterID := uuid.New()
+	ticket := &domain.Ticket{
+		ID:             time.Now().UnixNano(),
+		Title:          "Printer on fire",
+		Description:    "There is smoke coming out of the third floor printer",
+		RequesterID:    requesterID,
+		OrganizationID: orgID,
+		CreatedAt:      time.Now(),
+	}
+	comment := &domain.Comment{ID: ticket.ID, TicketID: ticket.ID, Body: "The extinguisher is by the lifts", CreatedAt: time.Now()}
+
+	require.NoError(t, index.Index(ctx, domain.TicketSearchDocuments(ticket, []*domain.Comment{comment})))
+
+	// Reindexing replaces the documents rather than duplicating them
+	ticket.Title = "Printer still on fire"
+	require.NoError(t, index.Index(ctx, domain.TicketSearchDocuments(ticket, []*domain.Comment{comment})))
+
+	hits, err := index.Search(ctx, domain.SearchQuery{OrganizationID: orgID, Text: "printer", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, hits, 2, "the comment matches on its ticket's title")
+	assert.Equal(t, domain.SearchDocumentTicket, hits[0].Kind, "title and body matches rank first")
+	assert.Equal(t, "Printer still on fire", hits[0].Title)
+	assert.Contains(t, hits[0].Snippet, "<em>printer</em>")
+
+	hits, err = index.Search(ctx, domain.SearchQuery{OrganizationID: orgID, Text: "extinguisher", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, domain.SearchDocumentComment, hits[0].Kind)
+	assert.Equal(t, ticket.ID, hits[0].TicketID)
+
+	otherRequester := uuid.New()
+	hits, err = index.Search(ctx, domain.SearchQuery{OrganizationID: orgID, RequesterID: &otherRequester, Text: "printer", Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, hits, "requesters only find their own tickets")
+
+	hits, err = index.Search(ctx, domain.SearchQuery{OrganizationID: uuid.New(), Text: "printer", Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, hits, "other organizations find nothing")
+
+	ticket.Visibility = domain.VisibilityInternal
+	require.NoError(t, index.Index(ctx, domain.TicketSearchDocuments(ticket, []*domain.Comment{comment})))
+	hits, err = index.Search(ctx, domain.SearchQuery{OrganizationID: orgID, RequesterID: &requesterID, HideInternal: true, Text: "printer", Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, hits, "internal tickets can be hidden, even from their requester")
+
+	require.NoError(t, index.DeleteTicket(ctx, orgID, ticket.ID))
+	hits, err = index.Search(ctx, domain.SearchQuery{OrganizationID: orgID, Text: "printer", Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+}
+
+func TestSearchQueueRepository_EnqueueClaimComplete(t *testing.T) {
+	ctx := context.Background()
+	ticketRepo, userRepo := newTestRepos(t)
+	queue := NewSearchQueueRepository(testPool)
+
+	requester := createTestUser(t, ctx, userRepo)
+	ticket, err := domain.NewTicket(domain.TicketParams{
+		Title:          "Queued ticket",
+		Priority:       domain.PriorityLow,
+		RequesterID:    requester.ID,
+		OrganizationID: requester.OrganizationID,
+	})
+	require.NoError(t, err)
+	ticket, err = ticketRepo.Create(ctx, ticket)
+	require.NoError(t, err)
+
+	// Drain whatever other tests queued
+	for {
+		jobs, err := queue.Claim(ctx, 100, time.Minute)
+		require.NoError(t, err)
+		if len(jobs) == 0 {
+			break
+		}
+		for _, job := range jobs {
+			require.NoError(t, queue.Complete(ctx, job))
+		}
+	}
+
+	require.NoError(t, queue.Enqueue(ctx, ticket.ID))
+	jobs, err := queue.Claim(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, ticket.ID, jobs[0].TicketID)
+	assert.Equal(t, requester.OrganizationID, jobs[0].OrganizationID)
+
+	// Claimed tickets are skipped until their lease runs out
+	again, err := queue.Claim(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, again)
+
+	// A ticket queued again while claimed is not completed by the old claim
+	require.NoError(t, queue.Enqueue(ctx, ticket.ID))
+	require.NoError(t, queue.Complete(ctx, jobs[0]))
+	requeued, err := queue.Claim(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, requeued, 1)
+
+	require.NoError(t, queue.Complete(ctx, requeued[0]))
+	empty, err := queue.Claim(ctx, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// SearchQueueRepository persists the queue of tickets waiting to be indexed
+// for search.
+type SearchQueueRepository struct {
+	conn DBTX
+}
+
+var _ ports.SearchQueueRepository = (*SearchQueueRepository)(nil)
+
+// NewSearchQueueRepository creates a new search queue repository.
+func NewSearchQueueRepository(conn DBTX) ports.SearchQueueRepository {
+	return &SearchQueueRepository{conn: conn}
+}
+
+// Enqueue queues the ticket for indexing, or marks it as queued again if it
+// already is so an indexer working on it does not drop the newer change.
+// Soft-deleted tickets are queued too, so they are removed from the index.
+func (r *SearchQueueRepository) Enqueue(ctx context.Context, ticketID int64) error {
+	const enqueue = `
+INSERT INTO search_index_queue (ticket_id, organization_id)
+SELECT id, organization_id FROM tickets WHERE id = $1
+ON CONFLICT (ticket_id) DO UPDATE
+SET enqueued_at = NOW(), available_at = NOW()
+`
+
+	_, err := GetDBTX(ctx, r.conn).Exec(ctx, enqueue, ticketID)
+	return err
+}
+
+// Claim locks up to limit queued tickets, oldest first, and pushes them out
+// by lease so concurrent indexers skip them while they are being indexed.
+func (r *SearchQueueRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]*ports.SearchIndexJob, error) {
+	const claim = `
+UPDATE search_index_queue
+SET available_at = NOW() + ($2 * INTERVAL '1 second')
+WHERE ticket_id IN (
+    SELECT ticket_id FROM search_index_queue
+    WHERE available_at <= NOW()
+    ORDER BY enqueued_at, ticket_id
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING ticket_id, organization_id, enqueued_at
+`
+
+	rows, err := GetDBTX(ctx, r.conn).Query(ctx, claim, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := make([]*ports.SearchIndexJob, 0)
+	for rows.Next() {
+		var (
+			job        ports.SearchIndexJob
+			enqueuedAt pgtype.Timestamptz
+		)
+		if err := rows.Scan(&job.TicketID, &job.OrganizationID, &enqueuedAt); err != nil {
+			return nil, err
+		}
+		job.EnqueuedAt = enqueuedAt.Time
+		jobs = append(jobs, &job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// Complete removes an indexed ticket from the queue, unless it was queued
+// again after it was claimed.
+func (r *SearchQueueRepository) Complete(ctx context.Context, job *ports.SearchIndexJob) error {
+	_, err := GetDBTX(ctx, r.conn).Exec(ctx,
+		"DELETE FROM search_index_queue WHERE ticket_id = $1 AND enqueued_at = $2",
+		job.TicketID,
+		pgtype.Timestamptz{Time: job.EnqueuedAt, Valid: true},
+	)
+	return err
+}
@@ -0,0 +1,186 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// TeamRepository persists teams and their members.
+type TeamRepository struct {
+	conn DBTX
+}
+
+var _ ports.TeamRepository = (*TeamRepository)(nil)
+
+// NewTeamRepository creates a new team repository.
+func NewTeamRepository(conn DBTX) ports.TeamRepository {
+	return &TeamRepository{conn: conn}
+}
+
+// teamColumns selects a team along with its members, ordered for stable
+// output. Queries using it must group by t.id.
+const teamColumns = `t.id, t.organization_id, t.name, t.created_at,
+	COALESCE(ARRAY_AGG(m.user_id ORDER BY m.user_id) FILTER (WHERE m.user_id IS NOT NULL), '{}')`
+
+func scanTeam(row pgx.Row) (*domain.Team, error) {
+	var (
+		t         domain.Team
+		createdAt pgtype.Timestamptz
+	)
+	if err := row.Scan(&t.ID, &t.OrganizationID, &t.Name, &createdAt, &t.MemberIDs); err != nil {
+		return nil, err
+	}
+	t.CreatedAt = createdAt.Time
+	return &t, nil
+}
+
+func (r *TeamRepository) list(ctx context.Context, query string, arg any) ([]*domain.Team, error) {
+	rows, err := GetDBTX(ctx, r.conn).Query(ctx, query, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	teams := make([]*domain.Team, 0)
+	for rows.Next() {
+		team, err := scanTeam(rows)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, team)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+// Create persists a new team without members.
+func (r *TeamRepository) Create(ctx context.Context, team *domain.Team) (*domain.Team, error) {
+	created := &domain.Team{
+		OrganizationID: team.OrganizationID,
+		Name:           team.Name,
+		MemberIDs:      []uuid.UUID{},
+	}
+
+	var createdAt pgtype.Timestamptz
+	err := GetDBTX(ctx, r.conn).QueryRow(ctx,
+		"INSERT INTO teams (organization_id, name) VALUES ($1, $2) RETURNING id, created_at",
+		team.OrganizationID, team.Name,
+	).Scan(&created.ID, &createdAt)
+	if err != nil {
+		return nil, teamError(err)
+	}
+
+	created.CreatedAt = createdAt.Time
+	return created, nil
+}
+
+// GetByID retrieves a team and its members.
+func (r *TeamRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Team, error) {
+	row := GetDBTX(ctx, r.conn).QueryRow(ctx, `
+SELECT `+teamColumns+`
+FROM teams t
+LEFT JOIN team_members m ON m.team_id = t.id
+WHERE t.id = $1
+GROUP BY t.id`, id)
+
+	team, err := scanTeam(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrTeamNotFound
+		}
+		return nil, err
+	}
+	return team, nil
+}
+
+// ListByOrganization retrieves all teams of an organization ordered by name.
+func (r *TeamRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.Team, error) {
+	return r.list(ctx, `
+SELECT `+teamColumns+`
+FROM teams t
+LEFT JOIN team_members m ON m.team_id = t.id
+WHERE t.organization_id = $1
+GROUP BY t.id
+ORDER BY t.name`, orgID)
+}
+
+// ListByMember retrieves the teams a user belongs to ordered by name.
+func (r *TeamRepository) ListByMember(ctx context.Context, userID uuid.UUID) ([]*domain.Team, error) {
+	return r.list(ctx, `
+SELECT `+teamColumns+`
+FROM teams t
+LEFT JOIN team_members m ON m.team_id = t.id
+WHERE t.id IN (SELECT team_id FROM team_members WHERE user_id = $1)
+GROUP BY t.id
+ORDER BY t.name`, userID)
+}
+
+// Update saves the team's name.
+func (r *TeamRepository) Update(ctx context.Context, team *domain.Team) error {
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx, "UPDATE teams SET name = $2 WHERE id = $1", team.ID, team.Name)
+	if err != nil {
+		return teamError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrTeamNotFound
+	}
+	return nil
+}
+
+// Delete removes a team. Its tickets leave the queue but keep any assignee.
+func (r *TeamRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx, "DELETE FROM teams WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrTeamNotFound
+	}
+	return nil
+}
+
+// AddMember adds a user to a team. Adding an existing member does nothing.
+func (r *TeamRepository) AddMember(ctx context.Context, teamID, userID uuid.UUID) error {
+	_, err := GetDBTX(ctx, r.conn).Exec(ctx,
+		"INSERT INTO team_members (team_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+		teamID, userID,
+	)
+	return err
+}
+
+// RemoveMember removes a user from a team. It returns
+// apperrors.ErrUserNotFound if the user is not a member.
+func (r *TeamRepository) RemoveMember(ctx context.Context, teamID, userID uuid.UUID) error {
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx,
+		"DELETE FROM team_members WHERE team_id = $1 AND user_id = $2",
+		teamID, userID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrUserNotFound
+	}
+	return nil
+}
+
+// teamError maps a unique violation on the team's name to ErrTeamExists.
+func teamError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return apperrors.ErrTeamExists
+	}
+	return err
+}
@@ -3,12 +3,14 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/lorrc/service-desk-backend/internal/adapters/secondary/postgres/db"
 	"github.com/lorrc/service-desk-backend/internal/core/domain"
 	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
@@ -17,29 +19,45 @@ import (
 )
 
 // TicketRepository is the secondary adapter for ticket persistence.
+// Tickets soft-deleted by retention are invisible to it: they cannot be
+// read, updated or claimed, and are listed only with IncludeDeleted.
 type TicketRepository struct {
-	pool *pgxpool.Pool
+	conn    DBTX
+	replica *ReadReplica
 }
 
 // Ensure TicketRepository implements the ports.TicketRepository interface.
 var _ ports.TicketRepository = (*TicketRepository)(nil)
 
 // NewTicketRepository creates a new ticket repository.
-func NewTicketRepository(pool *pgxpool.Pool) ports.TicketRepository {
+func NewTicketRepository(conn DBTX, opts ...RepositoryOption) ports.TicketRepository {
+	o := applyRepositoryOptions(opts)
 	return &TicketRepository{
-		pool: pool,
+		conn:    conn,
+		replica: o.replica,
 	}
 }
 
 // mapDBTicketToDomain converts a database ticket model to a core domain model.
 func mapDBTicketToDomain(dbTicket db.Ticket) *domain.Ticket {
 	domainTicket := &domain.Ticket{
-		ID:          dbTicket.ID,
-		Title:       dbTicket.Title,
-		Description: utils.FromString(dbTicket.Description),
-		Status:      domain.TicketStatus(dbTicket.Status),
-		Priority:    domain.TicketPriority(dbTicket.Priority),
-		CreatedAt:   dbTicket.CreatedAt.Time,
+		ID:              dbTicket.ID,
+		Title:           dbTicket.Title,
+		Description:     utils.FromString(dbTicket.Description),
+		DescriptionHTML: utils.FromString(dbTicket.DescriptionHtml),
+		Status:          domain.TicketStatus(dbTicket.Status),
+		Priority:        domain.TicketPriority(dbTicket.Priority),
+		CreatedAt:       dbTicket.CreatedAt.Time,
+		Tags:            dbTicket.Tags,
+		OrganizationID:  dbTicket.OrganizationID.Bytes,
+		Visibility:      domain.TicketVisibility(dbTicket.Visibility),
+	}
+	if domainTicket.Tags == nil {
+		domainTicket.Tags = []string{}
+	}
+	// Tickets archived before visibility existed were all public
+	if domainTicket.Visibility == "" {
+		domainTicket.Visibility = domain.VisibilityPublic
 	}
 
 	if dbTicket.RequesterID.Valid {
@@ -49,12 +67,23 @@ func mapDBTicketToDomain(dbTicket db.Ticket) *domain.Ticket {
 		assigneeUUID := uuid.UUID(dbTicket.AssigneeID.Bytes)
 		domainTicket.AssigneeID = &assigneeUUID
 	}
+	if dbTicket.TeamID.Valid {
+		teamUUID := uuid.UUID(dbTicket.TeamID.Bytes)
+		domainTicket.TeamID = &teamUUID
+	}
 	if dbTicket.UpdatedAt.Valid {
 		domainTicket.UpdatedAt = &dbTicket.UpdatedAt.Time
 	}
 	if dbTicket.ClosedAt.Valid {
 		domainTicket.ClosedAt = &dbTicket.ClosedAt.Time
 	}
+	if dbTicket.DueAt.Valid {
+		domainTicket.DueAt = &dbTicket.DueAt.Time
+	}
+	if dbTicket.ResolutionWorkingSeconds.Valid {
+		resolutionTime := time.Duration(dbTicket.ResolutionWorkingSeconds.Float64 * float64(time.Second))
+		domainTicket.ResolutionTime = &resolutionTime
+	}
 
 	return domainTicket
 }
@@ -70,13 +99,19 @@ func mapDBTicketListToDomain(dbTickets []db.Ticket) []*domain.Ticket {
 
 // Create persists a new ticket entity.
 func (r *TicketRepository) Create(ctx context.Context, ticket *domain.Ticket) (*domain.Ticket, error) {
-	q := db.New(GetDBTX(ctx, r.pool))
+	q := db.New(GetDBTX(ctx, r.conn))
 	params := db.CreateTicketParams{
-		Title:       ticket.Title,
-		Description: utils.ToString(ticket.Description),
-		Status:      string(ticket.Status),
-		Priority:    string(ticket.Priority),
-		RequesterID: pgtype.UUID{Bytes: ticket.RequesterID, Valid: true},
+		Title:           ticket.Title,
+		Description:     utils.ToString(ticket.Description),
+		DescriptionHtml: utils.ToString(ticket.DescriptionHTML),
+		Status:          string(ticket.Status),
+		Priority:        string(ticket.Priority),
+		RequesterID:     pgtype.UUID{Bytes: ticket.RequesterID, Valid: true},
+		OrganizationID:  pgtype.UUID{Bytes: ticket.OrganizationID, Valid: true},
+		Visibility:      string(ticket.Visibility),
+	}
+	if ticket.DueAt != nil {
+		params.DueAt = pgtype.Timestamptz{Time: *ticket.DueAt, Valid: true}
 	}
 
 	createdTicket, err := q.CreateTicket(ctx, params)
@@ -86,10 +121,81 @@ func (r *TicketRepository) Create(ctx context.Context, ticket *domain.Ticket) (*
 	return mapDBTicketToDomain(createdTicket), nil
 }
 
-// GetByID retrieves a single ticket by its ID.
-func (r *TicketRepository) GetByID(ctx context.Context, id int64) (*domain.Ticket, error) {
-	q := db.New(GetDBTX(ctx, r.pool))
-	dbTicket, err := q.GetTicketByID(ctx, id)
+// ticketCopyColumns are the columns CreateBatch copies tickets into.
+var ticketCopyColumns = []string{
+	"id", "title", "description", "status", "priority",
+	"requester_id", "assignee_id", "team_id", "organization_id",
+	"created_at", "updated_at", "closed_at", "due_at",
+	"resolution_working_seconds", "tags", "description_html", "visibility",
+}
+
+// CreateBatch inserts tickets with a single COPY, setting the ID of each
+// from the tickets sequence, and queues them for search indexing. Unlike
+// Create it keeps their timestamps, so imported tickets keep their
+// history; a zero CreatedAt is set to now.
+func (r *TicketRepository) CreateBatch(ctx context.Context, tickets []*domain.Ticket) error {
+	if len(tickets) == 0 {
+		return nil
+	}
+	q := GetDBTX(ctx, r.conn)
+
+	ids, err := reserveIDs(ctx, q, "tickets", len(tickets))
+	if err != nil {
+		return fmt.Errorf("reserving ticket ids: %w", err)
+	}
+
+	now := time.Now().UTC()
+	rows := make([][]any, len(tickets))
+	for i, ticket := range tickets {
+		ticket.ID = ids[i]
+		if ticket.CreatedAt.IsZero() {
+			ticket.CreatedAt = now
+		}
+		if ticket.Tags == nil {
+			ticket.Tags = []string{}
+		}
+		if ticket.Visibility == "" {
+			ticket.Visibility = domain.VisibilityPublic
+		}
+
+		resolution := pgtype.Float8{}
+		if ticket.ResolutionTime != nil {
+			resolution = pgtype.Float8{Float64: ticket.ResolutionTime.Seconds(), Valid: true}
+		}
+		rows[i] = []any{
+			ticket.ID,
+			ticket.Title,
+			utils.ToString(ticket.Description),
+			string(ticket.Status),
+			string(ticket.Priority),
+			pgtype.UUID{Bytes: ticket.RequesterID, Valid: true},
+			nullableUUID(ticket.AssigneeID),
+			nullableUUID(ticket.TeamID),
+			pgtype.UUID{Bytes: ticket.OrganizationID, Valid: true},
+			pgtype.Timestamptz{Time: ticket.CreatedAt, Valid: true},
+			nullableTimestamptz(ticket.UpdatedAt),
+			nullableTimestamptz(ticket.ClosedAt),
+			nullableTimestamptz(ticket.DueAt),
+			resolution,
+			ticket.Tags,
+			utils.ToString(ticket.DescriptionHTML),
+			string(ticket.Visibility),
+		}
+	}
+
+	if _, err := q.CopyFrom(ctx, pgx.Identifier{"tickets"}, ticketCopyColumns, pgx.CopyFromRows(rows)); err != nil {
+		return fmt.Errorf("copying tickets: %w", err)
+	}
+	return enqueueTicketsForSearch(ctx, q, ids)
+}
+
+// GetByID retrieves a single ticket of an organization by its ID.
+func (r *TicketRepository) GetByID(ctx context.Context, orgID uuid.UUID, id int64) (*domain.Ticket, error) {
+	q := db.New(GetDBTX(ctx, r.conn))
+	dbTicket, err := q.GetTicketByID(ctx, db.GetTicketByIDParams{
+		ID:             id,
+		OrganizationID: pgtype.UUID{Bytes: orgID, Valid: true},
+	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperrors.ErrTicketNotFound
@@ -101,14 +207,27 @@ func (r *TicketRepository) GetByID(ctx context.Context, id int64) (*domain.Ticke
 
 // Update persists changes to an existing ticket entity.
 func (r *TicketRepository) Update(ctx context.Context, ticket *domain.Ticket) (*domain.Ticket, error) {
-	q := db.New(GetDBTX(ctx, r.pool))
+	q := db.New(GetDBTX(ctx, r.conn))
 	params := db.UpdateTicketParams{
-		ID:     ticket.ID,
-		Status: string(ticket.Status),
+		ID:              ticket.ID,
+		Status:          string(ticket.Status),
+		Title:           ticket.Title,
+		Description:     utils.ToString(ticket.Description),
+		DescriptionHtml: utils.ToString(ticket.DescriptionHTML),
+		Priority:        string(ticket.Priority),
+		Tags:            ticket.Tags,
+		OrganizationID: pgtype.UUID{
+			Bytes: ticket.OrganizationID,
+			Valid: true,
+		},
 		AssigneeID: pgtype.UUID{
 			Bytes: [16]byte{},
 			Valid: ticket.AssigneeID != nil,
 		},
+		TeamID: pgtype.UUID{
+			Bytes: [16]byte{},
+			Valid: ticket.TeamID != nil,
+		},
 		UpdatedAt: pgtype.Timestamptz{
 			Time:  time.Time{},
 			Valid: ticket.UpdatedAt != nil,
@@ -122,6 +241,9 @@ func (r *TicketRepository) Update(ctx context.Context, ticket *domain.Ticket) (*
 	if ticket.AssigneeID != nil {
 		params.AssigneeID.Bytes = *ticket.AssigneeID
 	}
+	if ticket.TeamID != nil {
+		params.TeamID.Bytes = *ticket.TeamID
+	}
 	if ticket.UpdatedAt != nil {
 		params.UpdatedAt.Time = *ticket.UpdatedAt
 	} else {
@@ -131,9 +253,21 @@ func (r *TicketRepository) Update(ctx context.Context, ticket *domain.Ticket) (*
 	if ticket.ClosedAt != nil {
 		params.ClosedAt.Time = *ticket.ClosedAt
 	}
+	if ticket.DueAt != nil {
+		params.DueAt = pgtype.Timestamptz{Time: *ticket.DueAt, Valid: true}
+	}
+	if ticket.ResolutionTime != nil {
+		params.ResolutionWorkingSeconds = pgtype.Float8{Float64: ticket.ResolutionTime.Seconds(), Valid: true}
+	}
+	if params.Tags == nil {
+		params.Tags = []string{}
+	}
 
 	updatedTicket, err := q.UpdateTicket(ctx, params)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrTicketNotFound
+		}
 		return nil, err
 	}
 	return mapDBTicketToDomain(updatedTicket), nil
@@ -141,16 +275,21 @@ func (r *TicketRepository) Update(ctx context.Context, ticket *domain.Ticket) (*
 
 // ListPaginated retrieves all tickets with pagination and optional filters.
 func (r *TicketRepository) ListPaginated(ctx context.Context, params ports.ListTicketsRepoParams) ([]*domain.Ticket, error) {
-	q := db.New(GetDBTX(ctx, r.pool))
+	q := db.New(GetReadDBTX(ctx, r.conn, r.replica))
 	dbParams := db.ListTicketsPaginatedParams{
-		Limit:       params.Limit,
-		Offset:      params.Offset,
-		Status:      params.Status,
-		Priority:    params.Priority,
-		AssigneeID:  params.AssigneeID,
-		Unassigned:  params.Unassigned,
-		CreatedFrom: params.CreatedFrom,
-		CreatedTo:   params.CreatedTo,
+		OrganizationID:    params.OrganizationID,
+		Limit:             params.Limit,
+		Offset:            params.Offset,
+		Status:            params.Status,
+		Priority:          params.Priority,
+		AssigneeID:        params.AssigneeID,
+		Unassigned:        params.Unassigned,
+		CreatedFrom:       params.CreatedFrom,
+		CreatedTo:         params.CreatedTo,
+		NeedsReassignment: params.NeedsReassignment,
+		TeamID:            params.TeamID,
+		IncludeDeleted:    params.IncludeDeleted,
+		HideSnoozed:       params.HideSnoozed,
 	}
 
 	dbTickets, err := q.ListTicketsPaginated(ctx, dbParams)
@@ -161,19 +300,60 @@ func (r *TicketRepository) ListPaginated(ctx context.Context, params ports.ListT
 	return mapDBTicketListToDomain(dbTickets), nil
 }
 
+// Claim assigns a ticket to assigneeID if it is still unassigned and open.
+// The check and the update are one statement, so of two agents claiming the
+// same ticket only one succeeds.
+func (r *TicketRepository) Claim(ctx context.Context, orgID uuid.UUID, ticketID int64, assigneeID uuid.UUID, at time.Time) (*domain.Ticket, error) {
+	q := db.New(GetDBTX(ctx, r.conn))
+	claimed, err := q.ClaimTicket(ctx, db.ClaimTicketParams{
+		ID:             ticketID,
+		AssigneeID:     pgtype.UUID{Bytes: assigneeID, Valid: true},
+		UpdatedAt:      pgtype.Timestamptz{Time: at, Valid: true},
+		OrganizationID: pgtype.UUID{Bytes: orgID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrTicketAlreadyClaimed
+		}
+		return nil, err
+	}
+	return mapDBTicketToDomain(claimed), nil
+}
+
+// ListOpenByAssignee retrieves the tickets of an organization assigned to a
+// user that are not closed, locking them until the surrounding transaction
+// ends.
+func (r *TicketRepository) ListOpenByAssignee(ctx context.Context, orgID, assigneeID uuid.UUID) ([]*domain.Ticket, error) {
+	q := db.New(GetDBTX(ctx, r.conn))
+	dbTickets, err := q.ListOpenTicketsByAssignee(ctx, db.ListOpenTicketsByAssigneeParams{
+		AssigneeID:     pgtype.UUID{Bytes: assigneeID, Valid: true},
+		OrganizationID: pgtype.UUID{Bytes: orgID, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapDBTicketListToDomain(dbTickets), nil
+}
+
 // ListByRequesterPaginated retrieves tickets for a specific user with pagination and optional filters.
 func (r *TicketRepository) ListByRequesterPaginated(ctx context.Context, params ports.ListTicketsRepoParams) ([]*domain.Ticket, error) {
-	q := db.New(GetDBTX(ctx, r.pool))
+	q := db.New(GetReadDBTX(ctx, r.conn, r.replica))
 	dbParams := db.ListTicketsByRequesterPaginatedParams{
-		RequesterID: params.RequesterID,
-		Limit:       params.Limit,
-		Offset:      params.Offset,
-		Status:      params.Status,
-		Priority:    params.Priority,
-		AssigneeID:  params.AssigneeID,
-		Unassigned:  params.Unassigned,
-		CreatedFrom: params.CreatedFrom,
-		CreatedTo:   params.CreatedTo,
+		OrganizationID:    params.OrganizationID,
+		RequesterID:       params.RequesterID,
+		Limit:             params.Limit,
+		Offset:            params.Offset,
+		Status:            params.Status,
+		Priority:          params.Priority,
+		AssigneeID:        params.AssigneeID,
+		Unassigned:        params.Unassigned,
+		CreatedFrom:       params.CreatedFrom,
+		CreatedTo:         params.CreatedTo,
+		NeedsReassignment: params.NeedsReassignment,
+		TeamID:            params.TeamID,
+		IncludeDeleted:    params.IncludeDeleted,
+		HideInternal:      params.HideInternal,
 	}
 
 	dbTickets, err := q.ListTicketsByRequesterPaginated(ctx, dbParams)
@@ -183,3 +363,139 @@ func (r *TicketRepository) ListByRequesterPaginated(ctx context.Context, params
 
 	return mapDBTicketListToDomain(dbTickets), nil
 }
+
+// ListByAssigneePaginated retrieves the tickets assigned to a user with
+// pagination and optional filters.
+func (r *TicketRepository) ListByAssigneePaginated(ctx context.Context, params ports.ListTicketsRepoParams) ([]*domain.Ticket, error) {
+	q := db.New(GetReadDBTX(ctx, r.conn, r.replica))
+	dbParams := db.ListTicketsByAssigneePaginatedParams{
+		OrganizationID: params.OrganizationID,
+		AssigneeID:     params.AssigneeID,
+		Limit:          params.Limit,
+		Offset:         params.Offset,
+		Status:         params.Status,
+		Priority:       params.Priority,
+		RequesterID:    params.RequesterID,
+		CreatedFrom:    params.CreatedFrom,
+		CreatedTo:      params.CreatedTo,
+		TeamID:         params.TeamID,
+		IncludeDeleted: params.IncludeDeleted,
+		HideSnoozed:    params.HideSnoozed,
+	}
+
+	dbTickets, err := q.ListTicketsByAssigneePaginated(ctx, dbParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapDBTicketListToDomain(dbTickets), nil
+}
+
+// ListPage retrieves a keyset page of tickets, newest first, with the same
+// filters as ListPaginated plus the requester. Limit and Offset in params
+// are ignored in favor of page.
+func (r *TicketRepository) ListPage(ctx context.Context, params ports.ListTicketsRepoParams, page domain.PageRequest) (domain.Page[*domain.Ticket], error) {
+	var conditions []string
+	if !params.IncludeDeleted {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+	var args []any
+	addCondition := func(format string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	addCondition("organization_id = $%d", params.OrganizationID)
+	if params.Status.Valid {
+		addCondition("status = $%d", params.Status)
+	}
+	if params.Priority.Valid {
+		addCondition("priority = $%d", params.Priority)
+	}
+	if params.RequesterID.Valid {
+		addCondition("requester_id = $%d", params.RequesterID)
+	}
+	if params.Unassigned.Valid && params.Unassigned.Bool {
+		conditions = append(conditions, "assignee_id IS NULL")
+	} else if params.AssigneeID.Valid {
+		addCondition("assignee_id = $%d", params.AssigneeID)
+	}
+	if params.CreatedFrom.Valid {
+		addCondition("created_at >= $%d", params.CreatedFrom)
+	}
+	if params.CreatedTo.Valid {
+		addCondition("created_at < $%d", params.CreatedTo)
+	}
+	if params.NeedsReassignment.Valid && params.NeedsReassignment.Bool {
+		conditions = append(conditions, `status <> 'CLOSED' AND assignee_id IN (
+    SELECT u.id FROM users u
+    JOIN out_of_office_windows w ON w.user_id = u.id
+    WHERE u.reassign_when_away AND w.starts_at <= NOW() AND w.ends_at > NOW()
+)`)
+	}
+	if params.TeamID.Valid {
+		addCondition("team_id = $%d", params.TeamID)
+	}
+	if params.HideInternal {
+		conditions = append(conditions, "visibility = 'PUBLIC'")
+	}
+	if params.HideSnoozed {
+		conditions = append(conditions, `NOT EXISTS (
+    SELECT 1 FROM ticket_snoozes s WHERE s.ticket_id = tickets.id AND s.snoozed_until > NOW()
+)`)
+	}
+
+	after, args := ticketKeyset.after(page.After, args)
+	if after != "" {
+		conditions = append(conditions, after)
+	}
+	args = append(args, ticketKeyset.limit(page))
+
+	query := fmt.Sprintf(`
+SELECT id, title, description, status, priority, requester_id, assignee_id, created_at, updated_at, closed_at, team_id, due_at, resolution_working_seconds, tags, organization_id, description_html, visibility
+FROM tickets
+WHERE %s
+ORDER BY %s
+LIMIT $%d
+`, strings.Join(conditions, " AND "), ticketKeyset.orderBy(), len(args))
+
+	rows, err := GetReadDBTX(ctx, r.conn, r.replica).Query(ctx, query, args...)
+	if err != nil {
+		return domain.Page[*domain.Ticket]{}, err
+	}
+	defer rows.Close()
+
+	tickets := make([]*domain.Ticket, 0)
+	for rows.Next() {
+		var t db.Ticket
+		if err := rows.Scan(
+			&t.ID,
+			&t.Title,
+			&t.Description,
+			&t.Status,
+			&t.Priority,
+			&t.RequesterID,
+			&t.AssigneeID,
+			&t.CreatedAt,
+			&t.UpdatedAt,
+			&t.ClosedAt,
+			&t.TeamID,
+			&t.DueAt,
+			&t.ResolutionWorkingSeconds,
+			&t.Tags,
+			&t.OrganizationID,
+			&t.DescriptionHtml,
+			&t.Visibility,
+		); err != nil {
+			return domain.Page[*domain.Ticket]{}, err
+		}
+		tickets = append(tickets, mapDBTicketToDomain(t))
+	}
+	if err := rows.Err(); err != nil {
+		return domain.Page[*domain.Ticket]{}, err
+	}
+
+	return keysetPage(tickets, page, func(t *domain.Ticket) domain.PageCursor {
+		return domain.PageCursor{CreatedAt: t.CreatedAt, ID: strconv.FormatInt(t.ID, 10)}
+	}), nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
 	"github.com/lorrc/service-desk-backend/internal/core/ports"
 	"github.com/lorrc/service-desk-backend/internal/core/utils"
 	"github.com/stretchr/testify/assert"
@@ -39,10 +40,11 @@ func TestTicketRepository_CreateGet(t *testing.T) {
 
 	// 2. Create a new ticket
 	newTicket, err := domain.NewTicket(domain.TicketParams{
-		Title:       "Test Ticket",
-		Description: "This is a description",
-		Priority:    domain.PriorityMedium,
-		RequesterID: testUser.ID,
+		Title:          "Test Ticket",
+		Description:    "This is a description",
+		Priority:       domain.PriorityMedium,
+		RequesterID:    testUser.ID,
+		OrganizationID: testUser.OrganizationID,
 	})
 	require.NoError(t, err)
 
@@ -51,7 +53,7 @@ func TestTicketRepository_CreateGet(t *testing.T) {
 	assert.NotZero(t, createdTicket.ID)
 
 	// 3. Get the ticket by ID
-	foundTicket, err := ticketRepo.GetByID(ctx, createdTicket.ID)
+	foundTicket, err := ticketRepo.GetByID(ctx, defaultOrgID, createdTicket.ID)
 	require.NoError(t, err, "Failed to get ticket by ID")
 
 	// 4. Assert values are correct
@@ -60,6 +62,16 @@ func TestTicketRepository_CreateGet(t *testing.T) {
 	assert.Equal(t, domain.PriorityMedium, foundTicket.Priority)
 	assert.Equal(t, testUser.ID, foundTicket.RequesterID)
 	assert.Equal(t, domain.StatusOpen, foundTicket.Status)
+	assert.Equal(t, defaultOrgID, foundTicket.OrganizationID)
+
+	// 5. Other organizations can neither read nor update the ticket
+	_, err = ticketRepo.GetByID(ctx, uuid.New(), createdTicket.ID)
+	assert.ErrorIs(t, err, apperrors.ErrTicketNotFound)
+
+	foreign := *foundTicket
+	foreign.OrganizationID = uuid.New()
+	_, err = ticketRepo.Update(ctx, &foreign)
+	assert.ErrorIs(t, err, apperrors.ErrTicketNotFound)
 }
 
 func TestTicketRepository_PaginatedList(t *testing.T) {
@@ -70,16 +82,17 @@ func TestTicketRepository_PaginatedList(t *testing.T) {
 	user2 := createTestUser(t, ctx, userRepo)
 
 	// Create tickets
-	_, _ = ticketRepo.Create(ctx, &domain.Ticket{Title: "T1", Priority: domain.PriorityHigh, RequesterID: user1.ID, Status: domain.StatusOpen})
-	_, _ = ticketRepo.Create(ctx, &domain.Ticket{Title: "T2", Priority: domain.PriorityLow, RequesterID: user1.ID, Status: domain.StatusOpen})
-	_, _ = ticketRepo.Create(ctx, &domain.Ticket{Title: "T3", Priority: domain.PriorityMedium, RequesterID: user1.ID, Status: domain.StatusClosed})
-	_, _ = ticketRepo.Create(ctx, &domain.Ticket{Title: "T4", Priority: domain.PriorityHigh, RequesterID: user2.ID, Status: domain.StatusOpen})
+	_, _ = ticketRepo.Create(ctx, &domain.Ticket{Title: "T1", Priority: domain.PriorityHigh, RequesterID: user1.ID, OrganizationID: defaultOrgID, Status: domain.StatusOpen})
+	_, _ = ticketRepo.Create(ctx, &domain.Ticket{Title: "T2", Priority: domain.PriorityLow, RequesterID: user1.ID, OrganizationID: defaultOrgID, Status: domain.StatusOpen})
+	_, _ = ticketRepo.Create(ctx, &domain.Ticket{Title: "T3", Priority: domain.PriorityMedium, RequesterID: user1.ID, OrganizationID: defaultOrgID, Status: domain.StatusClosed})
+	_, _ = ticketRepo.Create(ctx, &domain.Ticket{Title: "T4", Priority: domain.PriorityHigh, RequesterID: user2.ID, OrganizationID: defaultOrgID, Status: domain.StatusOpen})
 
 	// Test case 1: List all for user 1
 	params1 := ports.ListTicketsRepoParams{
-		RequesterID: pgtype.UUID{Bytes: user1.ID, Valid: true},
-		Limit:       10,
-		Offset:      0,
+		OrganizationID: pgtype.UUID{Bytes: defaultOrgID, Valid: true},
+		RequesterID:    pgtype.UUID{Bytes: user1.ID, Valid: true},
+		Limit:          10,
+		Offset:         0,
 	}
 	tickets1, err := ticketRepo.ListByRequesterPaginated(ctx, params1)
 	require.NoError(t, err)
@@ -87,9 +100,10 @@ func TestTicketRepository_PaginatedList(t *testing.T) {
 
 	// Test case 2: List all for user 2
 	params2 := ports.ListTicketsRepoParams{
-		RequesterID: pgtype.UUID{Bytes: user2.ID, Valid: true},
-		Limit:       10,
-		Offset:      0,
+		OrganizationID: pgtype.UUID{Bytes: defaultOrgID, Valid: true},
+		RequesterID:    pgtype.UUID{Bytes: user2.ID, Valid: true},
+		Limit:          10,
+		Offset:         0,
 	}
 	tickets2, err := ticketRepo.ListByRequesterPaginated(ctx, params2)
 	require.NoError(t, err)
@@ -98,9 +112,10 @@ func TestTicketRepository_PaginatedList(t *testing.T) {
 
 	// Test case 3: List with pagination (Limit 1, Offset 1) for user 1
 	params3 := ports.ListTicketsRepoParams{
-		RequesterID: pgtype.UUID{Bytes: user1.ID, Valid: true},
-		Limit:       1,
-		Offset:      1,
+		OrganizationID: pgtype.UUID{Bytes: defaultOrgID, Valid: true},
+		RequesterID:    pgtype.UUID{Bytes: user1.ID, Valid: true},
+		Limit:          1,
+		Offset:         1,
 	}
 	tickets3, err := ticketRepo.ListByRequesterPaginated(ctx, params3)
 	require.NoError(t, err)
@@ -109,10 +124,11 @@ func TestTicketRepository_PaginatedList(t *testing.T) {
 
 	// Test case 4: List with filter (Priority: high) for user 1
 	params4 := ports.ListTicketsRepoParams{
-		RequesterID: pgtype.UUID{Bytes: user1.ID, Valid: true},
-		Limit:       10,
-		Offset:      0,
-		Priority:    utils.ToString(string(domain.PriorityHigh)),
+		OrganizationID: pgtype.UUID{Bytes: defaultOrgID, Valid: true},
+		RequesterID:    pgtype.UUID{Bytes: user1.ID, Valid: true},
+		Limit:          10,
+		Offset:         0,
+		Priority:       utils.ToString(string(domain.PriorityHigh)),
 	}
 	tickets4, err := ticketRepo.ListByRequesterPaginated(ctx, params4)
 	require.NoError(t, err)
@@ -121,10 +137,11 @@ func TestTicketRepository_PaginatedList(t *testing.T) {
 
 	// Test case 5: List with filter (Status: closed) for user 1
 	params5 := ports.ListTicketsRepoParams{
-		RequesterID: pgtype.UUID{Bytes: user1.ID, Valid: true},
-		Limit:       10,
-		Offset:      0,
-		Status:      utils.ToString(string(domain.StatusClosed)),
+		OrganizationID: pgtype.UUID{Bytes: defaultOrgID, Valid: true},
+		RequesterID:    pgtype.UUID{Bytes: user1.ID, Valid: true},
+		Limit:          10,
+		Offset:         0,
+		Status:         utils.ToString(string(domain.StatusClosed)),
 	}
 	tickets5, err := ticketRepo.ListByRequesterPaginated(ctx, params5)
 	require.NoError(t, err)
@@ -132,6 +149,38 @@ func TestTicketRepository_PaginatedList(t *testing.T) {
 	assert.Equal(t, "T3", tickets5[0].Title)
 }
 
+func TestTicketRepository_ListPage(t *testing.T) {
+	ctx := context.Background()
+	ticketRepo, userRepo := newTestRepos(t)
+
+	user := createTestUser(t, ctx, userRepo)
+	for _, title := range []string{"K1", "K2", "K3"} {
+		_, err := ticketRepo.Create(ctx, &domain.Ticket{Title: title, Priority: domain.PriorityLow, RequesterID: user.ID, OrganizationID: defaultOrgID, Status: domain.StatusOpen})
+		require.NoError(t, err)
+	}
+	params := ports.ListTicketsRepoParams{
+		OrganizationID: pgtype.UUID{Bytes: defaultOrgID, Valid: true},
+		RequesterID:    pgtype.UUID{Bytes: user.ID, Valid: true},
+	}
+
+	first, err := ticketRepo.ListPage(ctx, params, domain.PageRequest{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, first.Items, 2)
+	assert.Equal(t, "K3", first.Items[0].Title) // Newest first
+	assert.Equal(t, "K2", first.Items[1].Title)
+	require.NotNil(t, first.NextCursor)
+
+	// A ticket created between pages doesn't shift the next page
+	_, err = ticketRepo.Create(ctx, &domain.Ticket{Title: "K4", Priority: domain.PriorityLow, RequesterID: user.ID, OrganizationID: defaultOrgID, Status: domain.StatusOpen})
+	require.NoError(t, err)
+
+	second, err := ticketRepo.ListPage(ctx, params, domain.PageRequest{After: first.NextCursor, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, second.Items, 1)
+	assert.Equal(t, "K1", second.Items[0].Title)
+	assert.Nil(t, second.NextCursor)
+}
+
 func TestTicketRepository_ListFilters(t *testing.T) {
 	ctx := context.Background()
 	ticketRepo, userRepo := newTestRepos(t)
@@ -139,11 +188,11 @@ func TestTicketRepository_ListFilters(t *testing.T) {
 	requester := createTestUser(t, ctx, userRepo)
 	assignee := createTestUser(t, ctx, userRepo)
 
-	ticket1, err := ticketRepo.Create(ctx, &domain.Ticket{Title: "T1", Priority: domain.PriorityHigh, RequesterID: requester.ID, Status: domain.StatusOpen})
+	ticket1, err := ticketRepo.Create(ctx, &domain.Ticket{Title: "T1", Priority: domain.PriorityHigh, RequesterID: requester.ID, OrganizationID: defaultOrgID, Status: domain.StatusOpen})
 	require.NoError(t, err)
-	ticket2, err := ticketRepo.Create(ctx, &domain.Ticket{Title: "T2", Priority: domain.PriorityLow, RequesterID: requester.ID, Status: domain.StatusOpen})
+	ticket2, err := ticketRepo.Create(ctx, &domain.Ticket{Title: "T2", Priority: domain.PriorityLow, RequesterID: requester.ID, OrganizationID: defaultOrgID, Status: domain.StatusOpen})
 	require.NoError(t, err)
-	ticket3, err := ticketRepo.Create(ctx, &domain.Ticket{Title: "T3", Priority: domain.PriorityMedium, RequesterID: requester.ID, Status: domain.StatusOpen})
+	ticket3, err := ticketRepo.Create(ctx, &domain.Ticket{Title: "T3", Priority: domain.PriorityMedium, RequesterID: requester.ID, OrganizationID: defaultOrgID, Status: domain.StatusOpen})
 	require.NoError(t, err)
 
 	require.NoError(t, ticket1.Assign(assignee.ID))
@@ -162,10 +211,11 @@ func TestTicketRepository_ListFilters(t *testing.T) {
 	require.NoError(t, err)
 
 	assigneeParams := ports.ListTicketsRepoParams{
-		RequesterID: pgtype.UUID{Bytes: requester.ID, Valid: true},
-		Limit:       10,
-		Offset:      0,
-		AssigneeID:  pgtype.UUID{Bytes: assignee.ID, Valid: true},
+		OrganizationID: pgtype.UUID{Bytes: defaultOrgID, Valid: true},
+		RequesterID:    pgtype.UUID{Bytes: requester.ID, Valid: true},
+		Limit:          10,
+		Offset:         0,
+		AssigneeID:     pgtype.UUID{Bytes: assignee.ID, Valid: true},
 	}
 	assigneeTickets, err := ticketRepo.ListByRequesterPaginated(ctx, assigneeParams)
 	require.NoError(t, err)
@@ -173,10 +223,11 @@ func TestTicketRepository_ListFilters(t *testing.T) {
 	assert.Equal(t, "T1", assigneeTickets[0].Title)
 
 	unassignedParams := ports.ListTicketsRepoParams{
-		RequesterID: pgtype.UUID{Bytes: requester.ID, Valid: true},
-		Limit:       10,
-		Offset:      0,
-		Unassigned:  pgtype.Bool{Bool: true, Valid: true},
+		OrganizationID: pgtype.UUID{Bytes: defaultOrgID, Valid: true},
+		RequesterID:    pgtype.UUID{Bytes: requester.ID, Valid: true},
+		Limit:          10,
+		Offset:         0,
+		Unassigned:     pgtype.Bool{Bool: true, Valid: true},
 	}
 	unassignedTickets, err := ticketRepo.ListByRequesterPaginated(ctx, unassignedParams)
 	require.NoError(t, err)
@@ -184,14 +235,158 @@ func TestTicketRepository_ListFilters(t *testing.T) {
 	assert.ElementsMatch(t, []string{"T2", "T3"}, []string{unassignedTickets[0].Title, unassignedTickets[1].Title})
 
 	dateParams := ports.ListTicketsRepoParams{
-		RequesterID: pgtype.UUID{Bytes: requester.ID, Valid: true},
-		Limit:       10,
-		Offset:      0,
-		CreatedFrom: pgtype.Timestamptz{Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Valid: true},
-		CreatedTo:   pgtype.Timestamptz{Time: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), Valid: true},
+		OrganizationID: pgtype.UUID{Bytes: defaultOrgID, Valid: true},
+		RequesterID:    pgtype.UUID{Bytes: requester.ID, Valid: true},
+		Limit:          10,
+		Offset:         0,
+		CreatedFrom:    pgtype.Timestamptz{Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Valid: true},
+		CreatedTo:      pgtype.Timestamptz{Time: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), Valid: true},
 	}
 	dateTickets, err := ticketRepo.ListByRequesterPaginated(ctx, dateParams)
 	require.NoError(t, err)
 	require.Len(t, dateTickets, 1)
 	assert.Equal(t, "T2", dateTickets[0].Title)
 }
+
+func TestTicketRepository_ListByAssigneePaginated(t *testing.T) {
+	ctx := context.Background()
+	ticketRepo, userRepo := newTestRepos(t)
+
+	requester := createTestUser(t, ctx, userRepo)
+	agent := createTestUser(t, ctx, userRepo)
+	otherAgent := createTestUser(t, ctx, userRepo)
+
+	assign := func(title string, status domain.TicketStatus, assigneeID uuid.UUID) {
+		ticket, err := ticketRepo.Create(ctx, &domain.Ticket{Title: title, Priority: domain.PriorityMedium, RequesterID: requester.ID, OrganizationID: defaultOrgID, Status: status})
+		require.NoError(t, err)
+		ticket.AssigneeID = &assigneeID
+		_, err = ticketRepo.Update(ctx, ticket)
+		require.NoError(t, err)
+	}
+	assign("A1", domain.StatusOpen, agent.ID)
+	assign("A2", domain.StatusClosed, agent.ID)
+	assign("A3", domain.StatusOpen, agent.ID)
+	assign("B1", domain.StatusOpen, otherAgent.ID)
+
+	params := ports.ListTicketsRepoParams{
+		OrganizationID: pgtype.UUID{Bytes: defaultOrgID, Valid: true},
+		AssigneeID:     pgtype.UUID{Bytes: agent.ID, Valid: true},
+		Limit:          10,
+	}
+	tickets, err := ticketRepo.ListByAssigneePaginated(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, tickets, 3)
+	assert.Equal(t, "A3", tickets[0].Title) // Newest first
+
+	params.Status = pgtype.Text{String: string(domain.StatusOpen), Valid: true}
+	tickets, err = ticketRepo.ListByAssigneePaginated(ctx, params)
+	require.NoError(t, err)
+	assert.Len(t, tickets, 2)
+
+	params.Limit, params.Offset = 1, 1
+	tickets, err = ticketRepo.ListByAssigneePaginated(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, tickets, 1)
+	assert.Equal(t, "A1", tickets[0].Title)
+
+	// Other organizations see none of the agent's tickets
+	params.OrganizationID = pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	tickets, err = ticketRepo.ListByAssigneePaginated(ctx, params)
+	require.NoError(t, err)
+	assert.Empty(t, tickets)
+}
+
+func TestTicketRepository_SoftDeleted(t *testing.T) {
+	ctx := context.Background()
+	ticketRepo, userRepo := newTestRepos(t)
+
+	requester := createTestUser(t, ctx, userRepo)
+	agent := createTestUser(t, ctx, userRepo)
+
+	ticket, err := ticketRepo.Create(ctx, &domain.Ticket{Title: "Deleted", Priority: domain.PriorityLow, RequesterID: requester.ID, OrganizationID: defaultOrgID, Status: domain.StatusClosed})
+	require.NoError(t, err)
+	ticket.AssigneeID = &agent.ID
+	_, err = ticketRepo.Update(ctx, ticket)
+	require.NoError(t, err)
+
+	_, err = testPool.Exec(ctx, "UPDATE tickets SET deleted_at = NOW() WHERE id = $1", ticket.ID)
+	require.NoError(t, err)
+
+	_, err = ticketRepo.GetByID(ctx, defaultOrgID, ticket.ID)
+	assert.ErrorIs(t, err, apperrors.ErrTicketNotFound)
+	_, err = ticketRepo.Update(ctx, ticket)
+	assert.ErrorIs(t, err, apperrors.ErrTicketNotFound)
+
+	params := ports.ListTicketsRepoParams{
+		OrganizationID: pgtype.UUID{Bytes: defaultOrgID, Valid: true},
+		RequesterID:    pgtype.UUID{Bytes: requester.ID, Valid: true},
+		AssigneeID:     pgtype.UUID{Bytes: agent.ID, Valid: true},
+		Limit:          10,
+	}
+	listings := map[string]func() ([]*domain.Ticket, error){
+		"requester": func() ([]*domain.Ticket, error) { return ticketRepo.ListByRequesterPaginated(ctx, params) },
+		"assignee":  func() ([]*domain.Ticket, error) { return ticketRepo.ListByAssigneePaginated(ctx, params) },
+		"all":       func() ([]*domain.Ticket, error) { return ticketRepo.ListPaginated(ctx, params) },
+		"page": func() ([]*domain.Ticket, error) {
+			page, err := ticketRepo.ListPage(ctx, params, domain.PageRequest{Limit: 10})
+			return page.Items, err
+		},
+	}
+	for name, list := range listings {
+		params.IncludeDeleted = false
+		tickets, err := list()
+		require.NoError(t, err, name)
+		assert.Empty(t, tickets, name)
+
+		params.IncludeDeleted = true
+		tickets, err = list()
+		require.NoError(t, err, name)
+		require.Len(t, tickets, 1, name)
+		assert.Equal(t, ticket.ID, tickets[0].ID, name)
+	}
+}
+
+func TestTicketRepository_CreateBatch(t *testing.T) {
+	ctx := context.Background()
+	ticketRepo, userRepo := newTestRepos(t)
+	commentRepo := NewCommentRepository(testPool)
+
+	requester := createTestUser(t, ctx, userRepo)
+	createdAt := time.Date(2019, 3, 1, 9, 0, 0, 0, time.UTC)
+	closedAt := createdAt.Add(48 * time.Hour)
+
+	tickets := []*domain.Ticket{
+		{Title: "Legacy 1", Priority: domain.PriorityLow, Status: domain.StatusClosed, RequesterID: requester.ID, OrganizationID: defaultOrgID, CreatedAt: createdAt, ClosedAt: &closedAt, Tags: []string{"legacy"}},
+		{Title: "Legacy 2", Priority: domain.PriorityHigh, Status: domain.StatusOpen, RequesterID: requester.ID, OrganizationID: defaultOrgID},
+	}
+	require.NoError(t, ticketRepo.CreateBatch(ctx, tickets))
+	require.NotZero(t, tickets[0].ID)
+	assert.NotEqual(t, tickets[0].ID, tickets[1].ID)
+
+	got, err := ticketRepo.GetByID(ctx, defaultOrgID, tickets[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Legacy 1", got.Title)
+	assert.True(t, createdAt.Equal(got.CreatedAt))
+	require.NotNil(t, got.ClosedAt)
+	assert.True(t, closedAt.Equal(*got.ClosedAt))
+	assert.Equal(t, []string{"legacy"}, got.Tags)
+
+	comments := []*domain.Comment{
+		{TicketID: tickets[0].ID, AuthorID: requester.ID, Body: "first", CreatedAt: createdAt.Add(time.Hour)},
+		{TicketID: tickets[0].ID, AuthorID: requester.ID, Body: "second", CreatedAt: createdAt.Add(2 * time.Hour)},
+	}
+	require.NoError(t, commentRepo.CreateBatch(ctx, comments))
+
+	listed, err := commentRepo.ListByTicketID(ctx, tickets[0].ID)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Equal(t, comments[0].ID, listed[0].ID)
+	assert.Equal(t, "second", listed[1].Body)
+
+	var queued int
+	require.NoError(t, testPool.QueryRow(ctx,
+		"SELECT COUNT(*) FROM search_index_queue WHERE ticket_id = ANY($1)",
+		[]int64{tickets[0].ID, tickets[1].ID},
+	).Scan(&queued))
+	assert.Equal(t, 2, queued)
+}
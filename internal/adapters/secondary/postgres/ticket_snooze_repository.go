@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// TicketSnoozeRepository persists ticket snoozes.
+type TicketSnoozeRepository struct {
+	conn DBTX
+}
+
+var _ ports.TicketSnoozeRepository = (*TicketSnoozeRepository)(nil)
+
+// NewTicketSnoozeRepository creates a new ticket snooze repository.
+func NewTicketSnoozeRepository(conn DBTX) ports.TicketSnoozeRepository {
+	return &TicketSnoozeRepository{conn: conn}
+}
+
+const ticketSnoozeColumns = "ticket_id, organization_id, snoozed_by, snoozed_until, note, created_at"
+
+func scanTicketSnooze(row pgx.Row) (*domain.TicketSnooze, error) {
+	var (
+		s            domain.TicketSnooze
+		snoozedUntil pgtype.Timestamptz
+		createdAt    pgtype.Timestamptz
+	)
+	if err := row.Scan(
+		&s.TicketID,
+		&s.OrganizationID,
+		&s.SnoozedBy,
+		&snoozedUntil,
+		&s.Note,
+		&createdAt,
+	); err != nil {
+		return nil, err
+	}
+
+	s.Until = snoozedUntil.Time
+	s.CreatedAt = createdAt.Time
+	return &s, nil
+}
+
+// Upsert stores a snooze, replacing the ticket's existing one.
+func (r *TicketSnoozeRepository) Upsert(ctx context.Context, snooze *domain.TicketSnooze) (*domain.TicketSnooze, error) {
+	row := GetDBTX(ctx, r.conn).QueryRow(ctx, `
+INSERT INTO ticket_snoozes (ticket_id, organization_id, snoozed_by, snoozed_until, note, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (ticket_id)
+DO UPDATE SET snoozed_by = EXCLUDED.snoozed_by,
+              snoozed_until = EXCLUDED.snoozed_until,
+              note = EXCLUDED.note,
+              created_at = EXCLUDED.created_at
+RETURNING `+ticketSnoozeColumns,
+		snooze.TicketID,
+		snooze.OrganizationID,
+		snooze.SnoozedBy,
+		snooze.Until,
+		snooze.Note,
+		snooze.CreatedAt,
+	)
+	return scanTicketSnooze(row)
+}
+
+// GetByTicketID retrieves a ticket's snooze, which may have ended but not
+// yet been taken.
+func (r *TicketSnoozeRepository) GetByTicketID(ctx context.Context, ticketID int64) (*domain.TicketSnooze, error) {
+	row := GetDBTX(ctx, r.conn).QueryRow(ctx, "SELECT "+ticketSnoozeColumns+" FROM ticket_snoozes WHERE ticket_id = $1", ticketID)
+	snooze, err := scanTicketSnooze(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.ErrTicketNotSnoozed
+	}
+	return snooze, err
+}
+
+// Delete removes a ticket's snooze.
+func (r *TicketSnoozeRepository) Delete(ctx context.Context, ticketID int64) error {
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx, "DELETE FROM ticket_snoozes WHERE ticket_id = $1", ticketID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrTicketNotSnoozed
+	}
+	return nil
+}
+
+// TakeDue removes up to limit snoozes that ended by now, earliest first,
+// and returns them. Snoozes locked by another transaction are skipped.
+func (r *TicketSnoozeRepository) TakeDue(ctx context.Context, now time.Time, limit int) ([]*domain.TicketSnooze, error) {
+	rows, err := GetDBTX(ctx, r.conn).Query(ctx, `
+DELETE FROM ticket_snoozes
+WHERE ticket_id IN (
+    SELECT ticket_id FROM ticket_snoozes
+    WHERE snoozed_until <= $1
+    ORDER BY snoozed_until
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING `+ticketSnoozeColumns, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	snoozes := make([]*domain.TicketSnooze, 0)
+	for rows.Next() {
+		snooze, err := scanTicketSnooze(rows)
+		if err != nil {
+			return nil, err
+		}
+		snoozes = append(snoozes, snooze)
+	}
+	return snoozes, rows.Err()
+}
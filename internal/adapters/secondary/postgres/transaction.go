@@ -2,7 +2,10 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -11,19 +14,77 @@ import (
 
 // TransactionManager handles database transactions
 type TransactionManager struct {
-	pool *pgxpool.Pool
+	pool  *pgxpool.Pool
+	retry RetryPolicy
+}
+
+// RetryPolicy controls how often a transaction that failed with a
+// serialization failure, a deadlock or a connection error is run again.
+// Retries wait Backoff, doubling each time up to MaxBackoff, with jitter.
+type RetryPolicy struct {
+	MaxRetries int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// TransactionOption configures a TransactionManager.
+type TransactionOption func(*TransactionManager)
+
+// WithRetryPolicy retries failed transactions according to policy. Without
+// it, transactions are not retried.
+func WithRetryPolicy(policy RetryPolicy) TransactionOption {
+	return func(tm *TransactionManager) {
+		tm.retry = policy
+	}
 }
 
 // NewTransactionManager creates a new transaction manager
-func NewTransactionManager(pool *pgxpool.Pool) *TransactionManager {
-	return &TransactionManager{pool: pool}
+func NewTransactionManager(pool *pgxpool.Pool, opts ...TransactionOption) *TransactionManager {
+	tm := &TransactionManager{pool: pool}
+	for _, opt := range opts {
+		opt(tm)
+	}
+	return tm
 }
 
 // WithTransaction executes a function within a database transaction.
 // If the function returns an error, the transaction is rolled back.
-// If the function succeeds, the transaction is committed.
+// If the function succeeds, the transaction is committed. Transactions that
+// fail with a retryable error are run again from the start, so fn must not
+// have effects outside the database.
 func (tm *TransactionManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
-	tx, err := tm.pool.Begin(ctx)
+	// A transaction started inside another runs in it, as a savepoint,
+	// and is retried by the outer one
+	if _, nested := TxFromContext(ctx); nested {
+		return tm.runTransaction(ctx, fn)
+	}
+
+	backoff := tm.retry.Backoff
+	for attempt := 0; ; attempt++ {
+		err := tm.runTransaction(ctx, fn)
+		if err == nil || attempt >= tm.retry.MaxRetries || !IsRetryable(err) {
+			return err
+		}
+
+		// Full jitter keeps retries of conflicting transactions apart
+		wait := time.Duration(rand.Int64N(int64(backoff) + 1))
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+		backoff = min(backoff*2, tm.retry.MaxBackoff)
+	}
+}
+
+// runTransaction runs fn in a single transaction, or in a savepoint of the
+// transaction already in ctx.
+func (tm *TransactionManager) runTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	begin := tm.pool.Begin
+	if outer, nested := TxFromContext(ctx); nested {
+		begin = outer.Begin
+	}
+	tx, err := begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -82,6 +143,17 @@ func (tm *TransactionManager) WithReadOnlyTransaction(ctx context.Context, fn fu
 	return nil
 }
 
+// IsRetryable reports whether err is a serialization failure, a deadlock,
+// or a connection error that happened before the statement reached the
+// server, all of which may succeed if the transaction is run again.
+func IsRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "40001" || pgErr.Code == "40P01"
+	}
+	return pgconn.SafeToRetry(err)
+}
+
 // TxContext is a context key for storing transaction
 type txContextKey struct{}
 
@@ -97,17 +169,32 @@ func TxFromContext(ctx context.Context) (pgx.Tx, bool) {
 }
 
 // DBTX is an interface that matches both *pgxpool.Pool and pgx.Tx
-// This allows repositories to work with either a pool or a transaction
+// This allows repositories to work with either a pool or a transaction:
+// a repository created on a transaction runs every query in it, so several
+// repositories created on the same transaction share it.
 type DBTX interface {
 	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
 	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
 }
 
-// GetDBTX returns the transaction from context if available, otherwise returns the pool
-func GetDBTX(ctx context.Context, pool *pgxpool.Pool) DBTX {
+// GetDBTX returns the transaction from context if available, otherwise
+// returns conn, the pool or transaction the repository was created with.
+// A transaction from an instrumented repository's context is instrumented
+// like its connection.
+func GetDBTX(ctx context.Context, conn DBTX) DBTX {
 	if tx, ok := TxFromContext(ctx); ok {
-		return tx
+		return instrumentWith(conn, tx)
+	}
+	return conn
+}
+
+// inTransaction reports whether q runs its queries in a transaction.
+func inTransaction(q DBTX) bool {
+	if c, ok := q.(*instrumentedConn); ok {
+		q = c.DBTX
 	}
-	return pool
+	_, ok := q.(pgx.Tx)
+	return ok
 }
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, IsRetryable(&pgconn.PgError{Code: "40001"}))
+	assert.True(t, IsRetryable(fmt.Errorf("update ticket: %w", &pgconn.PgError{Code: "40P01"})))
+	assert.False(t, IsRetryable(&pgconn.PgError{Code: "23505"}))
+	assert.False(t, IsRetryable(errors.New("not found")))
+}
+
+func TestTransactionManager_RetriesSerializationFailures(t *testing.T) {
+	ctx := context.Background()
+	require.NotNil(t, testPool, "testPool is nil. TestMain may not have run.")
+	tm := NewTransactionManager(testPool, WithRetryPolicy(RetryPolicy{
+		MaxRetries: 2,
+		Backoff:    time.Millisecond,
+		MaxBackoff: 5 * time.Millisecond,
+	}))
+
+	t.Run("succeeds after a retry", func(t *testing.T) {
+		attempts := 0
+		err := tm.WithTransaction(ctx, func(ctx context.Context) error {
+			attempts++
+			if attempts == 1 {
+				return &pgconn.PgError{Code: "40001"}
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("gives up after the retry limit", func(t *testing.T) {
+		attempts := 0
+		err := tm.WithTransaction(ctx, func(ctx context.Context) error {
+			attempts++
+			return &pgconn.PgError{Code: "40P01"}
+		})
+		require.Error(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		attempts := 0
+		err := tm.WithTransaction(ctx, func(ctx context.Context) error {
+			attempts++
+			return errors.New("validation failed")
+		})
+		require.Error(t, err)
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+func TestRepositories_ShareTransaction(t *testing.T) {
+	ctx := context.Background()
+	require.NotNil(t, testPool, "testPool is nil. TestMain may not have run.")
+
+	tx, err := testPool.Begin(ctx)
+	require.NoError(t, err)
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Repositories created on the same transaction see each other's writes
+	userRepo := NewUserRepository(tx)
+	ticketRepo := NewTicketRepository(tx)
+	user := createTestUser(t, ctx, userRepo)
+	ticket, err := ticketRepo.Create(ctx, &domain.Ticket{
+		Title:          "Shared transaction",
+		Priority:       domain.PriorityLow,
+		RequesterID:    user.ID,
+		OrganizationID: defaultOrgID,
+		Status:         domain.StatusOpen,
+	})
+	require.NoError(t, err)
+
+	// Nothing is visible outside it until it commits
+	_, err = NewUserRepository(testPool).GetByID(ctx, user.ID)
+	assert.ErrorIs(t, err, apperrors.ErrUserNotFound)
+
+	require.NoError(t, tx.Rollback(ctx))
+	_, err = NewTicketRepository(testPool).GetByID(ctx, defaultOrgID, ticket.ID)
+	assert.ErrorIs(t, err, apperrors.ErrTicketNotFound)
+}
+
+func TestTransactionManager_NestedTransactions(t *testing.T) {
+	ctx := context.Background()
+	require.NotNil(t, testPool, "testPool is nil. TestMain may not have run.")
+	tm := NewTransactionManager(testPool)
+	userRepo := NewUserRepository(testPool)
+
+	t.Run("commit with the outer transaction", func(t *testing.T) {
+		var user *domain.User
+		err := tm.WithTransaction(ctx, func(txCtx context.Context) error {
+			if err := tm.WithTransaction(txCtx, func(innerCtx context.Context) error {
+				user = createTestUser(t, innerCtx, userRepo)
+				return nil
+			}); err != nil {
+				return err
+			}
+			return errors.New("outer failed")
+		})
+		require.Error(t, err)
+
+		_, err = userRepo.GetByID(ctx, user.ID)
+		assert.ErrorIs(t, err, apperrors.ErrUserNotFound)
+	})
+
+	t.Run("roll back without the outer transaction", func(t *testing.T) {
+		var user *domain.User
+		err := tm.WithTransaction(ctx, func(txCtx context.Context) error {
+			user = createTestUser(t, txCtx, userRepo)
+			innerErr := tm.WithTransaction(txCtx, func(innerCtx context.Context) error {
+				createTestUser(t, innerCtx, userRepo)
+				return errors.New("inner failed")
+			})
+			assert.Error(t, innerErr)
+			return nil
+		})
+		require.NoError(t, err)
+
+		_, err = userRepo.GetByID(ctx, user.ID)
+		assert.NoError(t, err)
+	})
+}
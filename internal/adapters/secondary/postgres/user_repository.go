@@ -3,13 +3,14 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/lorrc/service-desk-backend/internal/adapters/secondary/postgres/db"
 	"github.com/lorrc/service-desk-backend/internal/core/domain"
 	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
@@ -18,34 +19,47 @@ import (
 
 // UserRepository is the postgres adapter for user persistence.
 type UserRepository struct {
-	q    db.Querier
-	pool *pgxpool.Pool
+	conn        DBTX
+	replica     *ReadReplica
+	countBudget time.Duration
 }
 
 var _ ports.UserRepository = (*UserRepository)(nil)
 
 // NewUserRepository creates a new user repository.
-func NewUserRepository(pool *pgxpool.Pool) ports.UserRepository {
+func NewUserRepository(conn DBTX, opts ...RepositoryOption) ports.UserRepository {
+	o := applyRepositoryOptions(opts)
 	return &UserRepository{
-		q:    db.New(pool),
-		pool: pool,
+		conn:        conn,
+		replica:     o.replica,
+		countBudget: o.countBudget,
 	}
 }
 
 // mapDBUserToDomain converts a database user model to a domain model.
 func mapDBUserToDomain(dbUser db.User) *domain.User {
 	return &domain.User{
-		ID:             dbUser.ID.Bytes,
-		OrganizationID: dbUser.OrganizationID.Bytes,
-		FullName:       dbUser.FullName,
-		Email:          dbUser.Email,
-		HashedPassword: dbUser.HashedPassword,
-		CreatedAt:      dbUser.CreatedAt.Time,
-		IsActive:       dbUser.IsActive,
-		LastActiveAt:   toTimePtr(dbUser.LastActiveAt),
+		ID:              dbUser.ID.Bytes,
+		OrganizationID:  dbUser.OrganizationID.Bytes,
+		FullName:        dbUser.FullName,
+		Email:           dbUser.Email,
+		HashedPassword:  dbUser.HashedPassword,
+		CreatedAt:       dbUser.CreatedAt.Time,
+		IsActive:        dbUser.IsActive,
+		LastActiveAt:    toTimePtr(dbUser.LastActiveAt),
+		TokensRevokedAt: toTimePtr(dbUser.TokensRevokedAt),
+		PhoneNumber:     dbUser.PhoneNumber.String,
+		SMSOptIn:        dbUser.SmsOptIn,
+		Locale:          dbUser.Locale,
+		Timezone:        dbUser.Timezone,
 	}
 }
 
+// querier returns queries bound to the transaction in ctx, if there is one.
+func (r *UserRepository) querier(ctx context.Context) db.Querier {
+	return db.New(GetDBTX(ctx, r.conn))
+}
+
 func toTimePtr(ts pgtype.Timestamptz) *time.Time {
 	if !ts.Valid {
 		return nil
@@ -63,7 +77,7 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) (*domain
 		HashedPassword: user.HashedPassword,
 	}
 
-	createdUser, err := r.q.CreateUser(ctx, params)
+	createdUser, err := r.querier(ctx).CreateUser(ctx, params)
 	if err != nil {
 		// FIX: Check for Postgres Unique Violation (Code "23505")
 		var pgErr *pgconn.PgError
@@ -76,9 +90,12 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) (*domain
 	return mapDBUserToDomain(createdUser), nil
 }
 
-// GetByEmail retrieves a user by email address.
-func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
-	dbUser, err := r.q.GetUserByEmail(ctx, email)
+// GetByEmail retrieves the user of an organization by email address.
+func (r *UserRepository) GetByEmail(ctx context.Context, orgID uuid.UUID, email string) (*domain.User, error) {
+	dbUser, err := r.querier(ctx).GetUserByEmail(ctx, db.GetUserByEmailParams{
+		OrganizationID: pgtype.UUID{Bytes: orgID, Valid: true},
+		Email:          email,
+	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperrors.ErrUserNotFound
@@ -88,9 +105,23 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 	return mapDBUserToDomain(dbUser), nil
 }
 
+// ListByEmail retrieves the users with an email address in every
+// organization, oldest first.
+func (r *UserRepository) ListByEmail(ctx context.Context, email string) ([]*domain.User, error) {
+	dbUsers, err := r.querier(ctx).ListUsersByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]*domain.User, 0, len(dbUsers))
+	for _, dbUser := range dbUsers {
+		users = append(users, mapDBUserToDomain(dbUser))
+	}
+	return users, nil
+}
+
 // GetByID retrieves a user by their ID.
 func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
-	dbUser, err := r.q.GetUserByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	dbUser, err := r.querier(ctx).GetUserByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperrors.ErrUserNotFound
@@ -102,11 +133,13 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Use
 
 // CountUsers returns the total number of users.
 func (r *UserRepository) CountUsers(ctx context.Context) (int64, error) {
-	return r.q.CountUsers(ctx)
+	return r.querier(ctx).CountUsers(ctx)
 }
 
-// ListAssignableUsers returns users eligible for ticket assignment in the same org.
-func (r *UserRepository) ListAssignableUsers(ctx context.Context, orgID uuid.UUID) ([]*domain.User, error) {
+// ListAssignableUsers returns users eligible for ticket assignment in the same
+// org that match the filter's query and team, leaving out those who are
+// currently out of office.
+func (r *UserRepository) ListAssignableUsers(ctx context.Context, orgID uuid.UUID, filter domain.AssigneeFilter) ([]*domain.User, error) {
 	const listAssignableUsers = `
 SELECT DISTINCT u.id, u.organization_id, u.full_name, u.email, u.hashed_password, u.created_at, u.is_active, u.last_active_at
 FROM users u
@@ -115,10 +148,27 @@ JOIN roles r ON ur.role_id = r.id
 WHERE u.organization_id = $1
   AND u.is_active = TRUE
   AND r.name IN ('admin', 'agent')
+  AND NOT EXISTS (
+      SELECT 1 FROM out_of_office_windows w
+      WHERE w.user_id = u.id AND w.starts_at <= NOW() AND w.ends_at > NOW()
+  )
+  AND ($2::text IS NULL OR u.full_name ILIKE $2 OR u.email ILIKE $2)
+  AND ($3::uuid IS NULL OR EXISTS (
+      SELECT 1 FROM team_members tm WHERE tm.team_id = $3 AND tm.user_id = u.id
+  ))
 ORDER BY u.full_name, u.email
 `
 
-	rows, err := r.pool.Query(ctx, listAssignableUsers, pgtype.UUID{Bytes: orgID, Valid: true})
+	var query pgtype.Text
+	if filter.Query != "" {
+		query = pgtype.Text{String: "%" + escapeLike(filter.Query) + "%", Valid: true}
+	}
+	var teamID pgtype.UUID
+	if filter.TeamID != nil {
+		teamID = pgtype.UUID{Bytes: *filter.TeamID, Valid: true}
+	}
+
+	rows, err := GetReadDBTX(ctx, r.conn, r.replica).Query(ctx, listAssignableUsers, pgtype.UUID{Bytes: orgID, Valid: true}, query, teamID)
 	if err != nil {
 		return nil, err
 	}
@@ -151,8 +201,37 @@ ORDER BY u.full_name, u.email
 	return users, nil
 }
 
-func (r *UserRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.UserSummary, error) {
-	const listUsers = `
+// SearchByOrganization returns a page of the organization's users ordered by
+// name, along with how many users match the filter in total.
+func (r *UserRepository) SearchByOrganization(ctx context.Context, orgID uuid.UUID, filter domain.UserFilter) ([]*domain.UserSummary, domain.Total, error) {
+	conditions := []string{"u.organization_id = $1"}
+	args := []any{pgtype.UUID{Bytes: orgID, Valid: true}}
+	addCondition := func(format string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, strings.ReplaceAll(format, "$?", fmt.Sprintf("$%d", len(args))))
+	}
+
+	if filter.Query != "" {
+		addCondition("(u.full_name ILIKE $? OR u.email ILIKE $?)", "%"+escapeLike(filter.Query)+"%")
+	}
+	if filter.Role != nil {
+		addCondition(`EXISTS (
+    SELECT 1 FROM user_roles fur JOIN roles fr ON fr.id = fur.role_id
+    WHERE fur.user_id = u.id AND fr.name = $?)`, *filter.Role)
+	}
+	if filter.IsActive != nil {
+		addCondition("u.is_active = $?", *filter.IsActive)
+	}
+	where := strings.Join(conditions, " AND ")
+
+	q := GetReadDBTX(ctx, r.conn, r.replica)
+
+	total, err := countRows(ctx, q, r.countBudget, "FROM users u WHERE "+where, args...)
+	if err != nil {
+		return nil, domain.Total{}, err
+	}
+
+	listUsers := fmt.Sprintf(`
 SELECT u.id,
        u.organization_id,
        u.full_name,
@@ -164,14 +243,15 @@ SELECT u.id,
 FROM users u
 LEFT JOIN user_roles ur ON u.id = ur.user_id
 LEFT JOIN roles r ON ur.role_id = r.id
-WHERE u.organization_id = $1
+WHERE %s
 GROUP BY u.id
 ORDER BY u.full_name, u.email
-`
+LIMIT $%d OFFSET $%d
+`, where, len(args)+1, len(args)+2)
 
-	rows, err := r.pool.Query(ctx, listUsers, pgtype.UUID{Bytes: orgID, Valid: true})
+	rows, err := q.Query(ctx, listUsers, append(args, filter.Limit, filter.Offset)...)
 	if err != nil {
-		return nil, err
+		return nil, domain.Total{}, err
 	}
 	defer rows.Close()
 
@@ -198,7 +278,7 @@ ORDER BY u.full_name, u.email
 			&lastActive,
 			&roles,
 		); err != nil {
-			return nil, err
+			return nil, domain.Total{}, err
 		}
 
 		if roles == nil {
@@ -218,14 +298,74 @@ ORDER BY u.full_name, u.email
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return nil, domain.Total{}, err
 	}
 
-	return users, nil
+	return users, total, nil
+}
+
+// ListPageByOrganization retrieves a keyset page of the organization's
+// users, newest first.
+func (r *UserRepository) ListPageByOrganization(ctx context.Context, orgID uuid.UUID, page domain.PageRequest) (domain.Page[*domain.User], error) {
+	conditions := []string{"u.organization_id = $1"}
+	args := []any{pgtype.UUID{Bytes: orgID, Valid: true}}
+
+	after, args := userKeyset.after(page.After, args)
+	if after != "" {
+		conditions = append(conditions, after)
+	}
+	args = append(args, userKeyset.limit(page))
+
+	query := fmt.Sprintf(`
+SELECT u.id, u.organization_id, u.full_name, u.email, u.hashed_password, u.created_at, u.is_active, u.last_active_at
+FROM users u
+WHERE %s
+ORDER BY %s
+LIMIT $%d
+`, strings.Join(conditions, " AND "), userKeyset.orderBy(), len(args))
+
+	rows, err := GetReadDBTX(ctx, r.conn, r.replica).Query(ctx, query, args...)
+	if err != nil {
+		return domain.Page[*domain.User]{}, err
+	}
+	defer rows.Close()
+
+	users := make([]*domain.User, 0)
+	for rows.Next() {
+		user := &domain.User{}
+		var lastActive pgtype.Timestamptz
+		if err := rows.Scan(
+			&user.ID,
+			&user.OrganizationID,
+			&user.FullName,
+			&user.Email,
+			&user.HashedPassword,
+			&user.CreatedAt,
+			&user.IsActive,
+			&lastActive,
+		); err != nil {
+			return domain.Page[*domain.User]{}, err
+		}
+		user.LastActiveAt = toTimePtr(lastActive)
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return domain.Page[*domain.User]{}, err
+	}
+
+	return keysetPage(users, page, func(u *domain.User) domain.PageCursor {
+		return domain.PageCursor{CreatedAt: u.CreatedAt, ID: u.ID.String()}
+	}), nil
+}
+
+// escapeLike escapes the LIKE wildcards in a search term so it is matched
+// literally.
+func escapeLike(term string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(term)
 }
 
 func (r *UserRepository) SetActive(ctx context.Context, userID uuid.UUID, isActive bool) error {
-	tag, err := r.pool.Exec(ctx, "UPDATE users SET is_active = $2 WHERE id = $1", pgtype.UUID{Bytes: userID, Valid: true}, isActive)
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx, "UPDATE users SET is_active = $2 WHERE id = $1", pgtype.UUID{Bytes: userID, Valid: true}, isActive)
 	if err != nil {
 		return err
 	}
@@ -236,7 +376,7 @@ func (r *UserRepository) SetActive(ctx context.Context, userID uuid.UUID, isActi
 }
 
 func (r *UserRepository) UpdatePassword(ctx context.Context, userID uuid.UUID, hashedPassword string) error {
-	tag, err := r.pool.Exec(ctx, "UPDATE users SET hashed_password = $2 WHERE id = $1", pgtype.UUID{Bytes: userID, Valid: true}, hashedPassword)
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx, "UPDATE users SET hashed_password = $2 WHERE id = $1", pgtype.UUID{Bytes: userID, Valid: true}, hashedPassword)
 	if err != nil {
 		return err
 	}
@@ -247,7 +387,206 @@ func (r *UserRepository) UpdatePassword(ctx context.Context, userID uuid.UUID, h
 }
 
 func (r *UserRepository) UpdateLastActive(ctx context.Context, userID uuid.UUID, at time.Time) error {
-	tag, err := r.pool.Exec(ctx, "UPDATE users SET last_active_at = $2 WHERE id = $1", pgtype.UUID{Bytes: userID, Valid: true}, pgtype.Timestamptz{Time: at.UTC(), Valid: true})
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx, "UPDATE users SET last_active_at = $2 WHERE id = $1", pgtype.UUID{Bytes: userID, Valid: true}, pgtype.Timestamptz{Time: at.UTC(), Valid: true})
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrUserNotFound
+	}
+	return nil
+}
+
+// UpdateLastActiveBatch records when several users were last active with a
+// single UPDATE.
+func (r *UserRepository) UpdateLastActiveBatch(ctx context.Context, lastActive map[uuid.UUID]time.Time) error {
+	if len(lastActive) == 0 {
+		return nil
+	}
+
+	const updateLastActiveBatch = `
+UPDATE users u
+SET last_active_at = GREATEST(u.last_active_at, a.at)
+FROM unnest($1::uuid[], $2::timestamptz[]) AS a(user_id, at)
+WHERE u.id = a.user_id
+`
+
+	ids := make([]pgtype.UUID, 0, len(lastActive))
+	times := make([]pgtype.Timestamptz, 0, len(lastActive))
+	for userID, at := range lastActive {
+		ids = append(ids, pgtype.UUID{Bytes: userID, Valid: true})
+		times = append(times, pgtype.Timestamptz{Time: at.UTC(), Valid: true})
+	}
+
+	_, err := GetDBTX(ctx, r.conn).Exec(ctx, updateLastActiveBatch, ids, times)
+	return err
+}
+
+func (r *UserRepository) RevokeTokens(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx, "UPDATE users SET tokens_revoked_at = $2 WHERE id = $1", pgtype.UUID{Bytes: userID, Valid: true}, pgtype.Timestamptz{Time: at.UTC(), Valid: true})
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *UserRepository) UpdateSMSPreferences(ctx context.Context, userID uuid.UUID, prefs domain.SMSPreferences) error {
+	phone := pgtype.Text{String: prefs.PhoneNumber, Valid: prefs.PhoneNumber != ""}
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx, "UPDATE users SET phone_number = $2, sms_opt_in = $3 WHERE id = $1", pgtype.UUID{Bytes: userID, Valid: true}, phone, prefs.OptIn)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *UserRepository) UpdateLocale(ctx context.Context, userID uuid.UUID, locale string) error {
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx, "UPDATE users SET locale = $2 WHERE id = $1", pgtype.UUID{Bytes: userID, Valid: true}, locale)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrUserNotFound
+	}
+	return nil
+}
+
+// UpdateProfile saves the user's name, phone number, SMS opt-in, locale and
+// timezone.
+func (r *UserRepository) UpdateProfile(ctx context.Context, user *domain.User) error {
+	phone := pgtype.Text{String: user.PhoneNumber, Valid: user.PhoneNumber != ""}
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx, `
+		UPDATE users
+		SET full_name = $2,
+			phone_number = $3,
+			sms_opt_in = $4,
+			locale = $5,
+			timezone = $6
+		WHERE id = $1`,
+		pgtype.UUID{Bytes: user.ID, Valid: true},
+		user.FullName,
+		phone,
+		user.SMSOptIn,
+		user.Locale,
+		user.Timezone,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrUserNotFound
+	}
+	return nil
+}
+
+// UpdateEmail changes the user's login email. It returns
+// apperrors.ErrUserExists if another user has the address.
+func (r *UserRepository) UpdateEmail(ctx context.Context, userID uuid.UUID, email string) error {
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx, "UPDATE users SET email = $2 WHERE id = $1", pgtype.UUID{Bytes: userID, Valid: true}, email)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return apperrors.ErrUserExists
+		}
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrUserNotFound
+	}
+	return nil
+}
+
+// GetAvailability returns the user's out-of-office windows that have not
+// ended yet.
+func (r *UserRepository) GetAvailability(ctx context.Context, userID uuid.UUID) (*domain.Availability, error) {
+	availability := &domain.Availability{UserID: userID, Windows: []domain.OutOfOfficeWindow{}}
+	dbtx := GetDBTX(ctx, r.conn)
+	id := pgtype.UUID{Bytes: userID, Valid: true}
+
+	err := dbtx.QueryRow(ctx, "SELECT reassign_when_away FROM users WHERE id = $1", id).Scan(&availability.ReassignWhenAway)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := dbtx.Query(ctx, `
+		SELECT starts_at, ends_at, note
+		FROM out_of_office_windows
+		WHERE user_id = $1 AND ends_at > NOW()
+		ORDER BY starts_at`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var w domain.OutOfOfficeWindow
+		if err := rows.Scan(&w.StartsAt, &w.EndsAt, &w.Note); err != nil {
+			return nil, err
+		}
+		availability.Windows = append(availability.Windows, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return availability, nil
+}
+
+// SaveAvailability replaces the user's out-of-office windows. Call it within
+// a transaction so the windows are never seen half replaced.
+func (r *UserRepository) SaveAvailability(ctx context.Context, availability *domain.Availability) error {
+	dbtx := GetDBTX(ctx, r.conn)
+	id := pgtype.UUID{Bytes: availability.UserID, Valid: true}
+
+	tag, err := dbtx.Exec(ctx, "UPDATE users SET reassign_when_away = $2 WHERE id = $1", id, availability.ReassignWhenAway)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrUserNotFound
+	}
+
+	if _, err := dbtx.Exec(ctx, "DELETE FROM out_of_office_windows WHERE user_id = $1", id); err != nil {
+		return err
+	}
+	for _, w := range availability.Windows {
+		if _, err := dbtx.Exec(ctx,
+			"INSERT INTO out_of_office_windows (user_id, starts_at, ends_at, note) VALUES ($1, $2, $3, $4)",
+			id, w.StartsAt, w.EndsAt, w.Note,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Anonymize strips a user's personal data while keeping the row, so the
+// tickets and comments that reference it stay intact. The account is
+// deactivated and every outstanding token is revoked.
+func (r *UserRepository) Anonymize(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx, `
+		UPDATE users
+		SET full_name = $2,
+			email = $3,
+			hashed_password = '',
+			phone_number = NULL,
+			sms_opt_in = FALSE,
+			is_active = FALSE,
+			tokens_revoked_at = $4
+		WHERE id = $1`,
+		pgtype.UUID{Bytes: userID, Valid: true},
+		domain.AnonymizedUserName,
+		domain.AnonymizedEmail(userID),
+		pgtype.Timestamptz{Time: at.UTC(), Valid: true},
+	)
 	if err != nil {
 		return err
 	}
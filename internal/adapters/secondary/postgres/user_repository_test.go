@@ -40,7 +40,7 @@ func TestUserRepository_CreateGet(t *testing.T) {
 	require.NoError(t, err, "Failed to create user")
 
 	// 2. Get the user by email
-	foundUser, err := userRepo.GetByEmail(ctx, "test.user@example.com")
+	foundUser, err := userRepo.GetByEmail(ctx, orgID, "test.user@example.com")
 	require.NoError(t, err, "Failed to get user by email")
 
 	// 3. Assert values are correct
@@ -58,7 +58,8 @@ func TestUserRepository_GetByEmail_NotFound(t *testing.T) {
 	ctx := context.Background()
 	_, userRepo := newTestRepos(t)
 
-	_, err := userRepo.GetByEmail(ctx, "nonexistent@example.com")
+	orgID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	_, err := userRepo.GetByEmail(ctx, orgID, "nonexistent@example.com")
 	require.Error(t, err)
 	assert.ErrorIs(t, err, errors.ErrUserNotFound)
 }
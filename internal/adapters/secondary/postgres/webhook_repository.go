@@ -0,0 +1,324 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// WebhookRepository persists webhook registrations and their deliveries.
+type WebhookRepository struct {
+	conn DBTX
+}
+
+var _ ports.WebhookRepository = (*WebhookRepository)(nil)
+
+// NewWebhookRepository creates a new webhook repository.
+func NewWebhookRepository(conn DBTX) ports.WebhookRepository {
+	return &WebhookRepository{conn: conn}
+}
+
+const webhookColumns = "id, organization_id, url, secret, event_types, is_active, created_by, created_at"
+
+func scanWebhook(row pgx.Row) (*domain.Webhook, error) {
+	var (
+		w          domain.Webhook
+		eventTypes []string
+		createdAt  pgtype.Timestamptz
+	)
+	if err := row.Scan(
+		&w.ID,
+		&w.OrganizationID,
+		&w.URL,
+		&w.Secret,
+		&eventTypes,
+		&w.IsActive,
+		&w.CreatedBy,
+		&createdAt,
+	); err != nil {
+		return nil, err
+	}
+
+	w.EventTypes = make([]domain.WebhookEventType, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		w.EventTypes = append(w.EventTypes, domain.WebhookEventType(t))
+	}
+	w.CreatedAt = createdAt.Time
+	return &w, nil
+}
+
+// Create persists a new webhook.
+func (r *WebhookRepository) Create(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	eventTypes := make([]string, 0, len(webhook.EventTypes))
+	for _, t := range webhook.EventTypes {
+		eventTypes = append(eventTypes, string(t))
+	}
+
+	row := GetDBTX(ctx, r.conn).QueryRow(ctx, `
+INSERT INTO webhooks (organization_id, url, secret, event_types, is_active, created_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING `+webhookColumns,
+		webhook.OrganizationID,
+		webhook.URL,
+		webhook.Secret,
+		eventTypes,
+		webhook.IsActive,
+		webhook.CreatedBy,
+	)
+	return scanWebhook(row)
+}
+
+// GetByID retrieves a webhook by its ID.
+func (r *WebhookRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Webhook, error) {
+	row := GetDBTX(ctx, r.conn).QueryRow(ctx, "SELECT "+webhookColumns+" FROM webhooks WHERE id = $1", id)
+	webhook, err := scanWebhook(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// ListByOrganization retrieves all webhooks registered by an organization.
+func (r *WebhookRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.Webhook, error) {
+	rows, err := GetDBTX(ctx, r.conn).Query(ctx,
+		"SELECT "+webhookColumns+" FROM webhooks WHERE organization_id = $1 ORDER BY created_at, id",
+		orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := make([]*domain.Webhook, 0)
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// Delete removes a webhook along with its delivery log.
+func (r *WebhookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := GetDBTX(ctx, r.conn).Exec(ctx, "DELETE FROM webhooks WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// EnqueueForTicket queues a delivery to every active webhook in the ticket's
+// organization that subscribes to eventType.
+func (r *WebhookRepository) EnqueueForTicket(ctx context.Context, ticketID int64, eventType domain.WebhookEventType, payload []byte) error {
+	const enqueue = `
+INSERT INTO webhook_deliveries (webhook_id, event_type, payload)
+SELECT w.id, $2, $3
+FROM tickets t
+JOIN webhooks w ON w.organization_id = t.organization_id
+WHERE t.id = $1 AND t.deleted_at IS NULL AND w.is_active AND $2 = ANY(w.event_types)
+`
+
+	_, err := GetDBTX(ctx, r.conn).Exec(ctx, enqueue, ticketID, string(eventType), payload)
+	return err
+}
+
+const webhookDeliveryColumns = "id, webhook_id, event_type, payload, status, attempts, next_attempt_at, response_status, last_error, created_at, delivered_at"
+
+func scanWebhookDelivery(row pgx.Row) (*domain.WebhookDelivery, error) {
+	var (
+		d              domain.WebhookDelivery
+		eventType      string
+		status         string
+		payload        []byte
+		nextAttemptAt  pgtype.Timestamptz
+		responseStatus pgtype.Int4
+		lastError      pgtype.Text
+		createdAt      pgtype.Timestamptz
+		deliveredAt    pgtype.Timestamptz
+	)
+	if err := row.Scan(
+		&d.ID,
+		&d.WebhookID,
+		&eventType,
+		&payload,
+		&status,
+		&d.Attempts,
+		&nextAttemptAt,
+		&responseStatus,
+		&lastError,
+		&createdAt,
+		&deliveredAt,
+	); err != nil {
+		return nil, err
+	}
+
+	d.EventType = domain.WebhookEventType(eventType)
+	d.Status = domain.WebhookDeliveryStatus(status)
+	d.Payload = payload
+	d.NextAttemptAt = nextAttemptAt.Time
+	d.CreatedAt = createdAt.Time
+	if responseStatus.Valid {
+		value := int(responseStatus.Int32)
+		d.ResponseStatus = &value
+	}
+	if lastError.Valid {
+		d.LastError = &lastError.String
+	}
+	if deliveredAt.Valid {
+		d.DeliveredAt = &deliveredAt.Time
+	}
+	return &d, nil
+}
+
+// CreateDelivery stores a single delivery, such as a test event.
+func (r *WebhookRepository) CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) (*domain.WebhookDelivery, error) {
+	row := GetDBTX(ctx, r.conn).QueryRow(ctx, `
+INSERT INTO webhook_deliveries (webhook_id, event_type, payload, status, next_attempt_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING `+webhookDeliveryColumns,
+		delivery.WebhookID,
+		string(delivery.EventType),
+		[]byte(delivery.Payload),
+		string(delivery.Status),
+		pgtype.Timestamptz{Time: delivery.NextAttemptAt.UTC(), Valid: true},
+	)
+	return scanWebhookDelivery(row)
+}
+
+// ListDeliveries retrieves the most recent deliveries for a webhook, newest first.
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]*domain.WebhookDelivery, error) {
+	rows, err := GetDBTX(ctx, r.conn).Query(ctx,
+		"SELECT "+webhookDeliveryColumns+" FROM webhook_deliveries WHERE webhook_id = $1 ORDER BY id DESC LIMIT $2",
+		webhookID,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]*domain.WebhookDelivery, 0)
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// ClaimDueDeliveries locks up to limit pending deliveries that are due and
+// pushes their next attempt out by lease, so concurrent workers skip them
+// while they are being sent.
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*ports.WebhookDeliveryJob, error) {
+	const claimDue = `
+UPDATE webhook_deliveries d
+SET next_attempt_at = NOW() + ($2 * INTERVAL '1 second')
+FROM webhooks w
+WHERE w.id = d.webhook_id AND d.id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE status = 'PENDING' AND next_attempt_at <= NOW()
+    ORDER BY next_attempt_at, id
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING d.id, d.webhook_id, w.url, w.secret, d.event_type, d.payload, d.attempts
+`
+
+	rows, err := GetDBTX(ctx, r.conn).Query(ctx, claimDue, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := make([]*ports.WebhookDeliveryJob, 0)
+	for rows.Next() {
+		var (
+			job       ports.WebhookDeliveryJob
+			eventType string
+		)
+		if err := rows.Scan(
+			&job.DeliveryID,
+			&job.WebhookID,
+			&job.URL,
+			&job.Secret,
+			&eventType,
+			&job.Payload,
+			&job.Attempts,
+		); err != nil {
+			return nil, err
+		}
+		job.EventType = domain.WebhookEventType(eventType)
+		jobs = append(jobs, &job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// MarkDelivered records a successful delivery.
+func (r *WebhookRepository) MarkDelivered(ctx context.Context, id int64, responseStatus int) error {
+	_, err := GetDBTX(ctx, r.conn).Exec(ctx,
+		"UPDATE webhook_deliveries SET status = 'SUCCEEDED', attempts = attempts + 1, response_status = $2, last_error = NULL, delivered_at = NOW() WHERE id = $1",
+		id,
+		nullableStatus(responseStatus),
+	)
+	return err
+}
+
+// MarkRetry records a failed delivery and schedules the next attempt.
+func (r *WebhookRepository) MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, responseStatus int, lastErr string) error {
+	_, err := GetDBTX(ctx, r.conn).Exec(ctx,
+		"UPDATE webhook_deliveries SET attempts = attempts + 1, next_attempt_at = $2, response_status = $3, last_error = $4 WHERE id = $1",
+		id,
+		pgtype.Timestamptz{Time: nextAttemptAt.UTC(), Valid: true},
+		nullableStatus(responseStatus),
+		lastErr,
+	)
+	return err
+}
+
+// MarkDead moves a delivery to the dead-letter state after its final failed attempt.
+func (r *WebhookRepository) MarkDead(ctx context.Context, id int64, responseStatus int, lastErr string) error {
+	_, err := GetDBTX(ctx, r.conn).Exec(ctx,
+		"UPDATE webhook_deliveries SET status = 'DEAD', attempts = attempts + 1, response_status = $2, last_error = $3 WHERE id = $1",
+		id,
+		nullableStatus(responseStatus),
+		lastErr,
+	)
+	return err
+}
+
+// nullableStatus stores a missing HTTP response as NULL
+func nullableStatus(status int) pgtype.Int4 {
+	return pgtype.Int4{Int32: int32(status), Valid: status != 0}
+}
@@ -0,0 +1,68 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// Cache is a Redis implementation of ports.Cache. Keys are namespaced with
+// prefix so several deployments can share a server.
+type Cache struct {
+	client *Client
+	prefix string
+}
+
+var _ ports.Cache = (*Cache)(nil)
+
+// NewCache creates a cache storing its keys under prefix.
+func NewCache(client *Client, prefix string) *Cache {
+	return &Cache{client: client, prefix: prefix}
+}
+
+// Get returns the value stored under key.
+func (c *Cache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := c.client.Do(ctx, "GET", c.prefix+key)
+	if errors.Is(err, ErrNil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	value, _ := reply.([]byte)
+	return value, true, nil
+}
+
+// Set stores value under key for ttl.
+func (c *Cache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := c.client.Do(ctx, "SET", c.prefix+key, value, "PX", ttl.Milliseconds())
+	return err
+}
+
+// SetNX stores value under key for ttl unless the key exists.
+func (c *Cache) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	_, err := c.client.Do(ctx, "SET", c.prefix+key, value, "PX", ttl.Milliseconds(), "NX")
+	if errors.Is(err, ErrNil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Delete removes keys.
+func (c *Cache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	args := make([]any, 0, len(keys)+1)
+	args = append(args, "DEL")
+	for _, key := range keys {
+		args = append(args, c.prefix+key)
+	}
+	_, err := c.client.Do(ctx, args...)
+	return err
+}
@@ -0,0 +1,163 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServer understands just enough RESP to serve GET, SET [PX n] [NX],
+// DEL and AUTH, ignoring expiry.
+type fakeServer struct {
+	ln       net.Listener
+	mu       sync.Mutex
+	data     map[string]string
+	commands [][]string
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeServer{ln: ln, data: make(map[string]string)}
+	t.Cleanup(func() { _ = ln.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *fakeServer) url() string {
+	return "redis://:secret@" + s.ln.Addr().String() + "/2"
+}
+
+func (s *fakeServer) serve() {
+	for {
+		nc, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(nc)
+	}
+}
+
+func (s *fakeServer) handle(nc net.Conn) {
+	defer nc.Close()
+	r := bufio.NewReader(nc)
+	for {
+		reply, err := readReply(r)
+		if err != nil {
+			return
+		}
+		var args []string
+		for _, arg := range reply.([]any) {
+			args = append(args, string(arg.([]byte)))
+		}
+		_, _ = nc.Write([]byte(s.exec(args)))
+	}
+}
+
+func (s *fakeServer) exec(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.commands = append(s.commands, args)
+
+	switch strings.ToUpper(args[0]) {
+	case "AUTH", "SELECT":
+		return "+OK\r\n"
+	case "GET":
+		value, ok := s.data[args[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case "SET":
+		if _, exists := s.data[args[1]]; exists && strings.EqualFold(args[len(args)-1], "NX") {
+			return "$-1\r\n"
+		}
+		s.data[args[1]] = args[2]
+		return "+OK\r\n"
+	case "DEL":
+		deleted := 0
+		for _, key := range args[1:] {
+			if _, ok := s.data[key]; ok {
+				delete(s.data, key)
+				deleted++
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", deleted)
+	default:
+		return "-ERR unknown command\r\n"
+	}
+}
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+	client, err := NewClient(Config{URL: server.url()})
+	require.NoError(t, err)
+	defer client.Close()
+	cache := NewCache(client, "sd:")
+
+	_, found, err := cache.Get(ctx, "missing")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, cache.Set(ctx, "perms", []byte(`["tickets:read"]`), time.Minute))
+	value, found, err := cache.Get(ctx, "perms")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, `["tickets:read"]`, string(value))
+
+	stored, err := cache.SetNX(ctx, "perms", []byte("other"), time.Minute)
+	require.NoError(t, err)
+	assert.False(t, stored)
+
+	require.NoError(t, cache.Delete(ctx, "perms"))
+	stored, err = cache.SetNX(ctx, "perms", []byte("other"), time.Minute)
+	require.NoError(t, err)
+	assert.True(t, stored)
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	assert.Equal(t, []string{"AUTH", "secret"}, server.commands[0])
+	assert.Equal(t, []string{"SELECT", "2"}, server.commands[1])
+	assert.Equal(t, []string{"SET", "sd:perms", `["tickets:read"]`, "PX", "60000"}, server.commands[3])
+}
+
+func TestClient_ErrorReplyKeepsConnection(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+	client, err := NewClient(Config{URL: server.url()})
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.Do(ctx, "BOGUS")
+	var replyErr Error
+	require.ErrorAs(t, err, &replyErr)
+	assert.Equal(t, "ERR unknown command", string(replyErr))
+
+	// The connection is returned to the pool and reused
+	_, err = client.Do(ctx, "DEL", "key")
+	require.NoError(t, err)
+	assert.Len(t, client.pool, 1)
+}
+
+func TestReadReply_ErrorInArray(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("*3\r\n:1\r\n-ERR oops\r\n$-1\r\n+NEXT\r\n"))
+
+	reply, err := readReply(r)
+	require.NoError(t, err)
+	assert.Equal(t, []any{int64(1), Error("ERR oops"), nil}, reply)
+
+	// The whole array was read, so the next reply starts cleanly
+	next, err := readReply(r)
+	require.NoError(t, err)
+	assert.Equal(t, "NEXT", next)
+}
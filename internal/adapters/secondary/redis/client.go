@@ -0,0 +1,266 @@
+// Package redis is a secondary adapter that shares cache and rate limiter
+// state between replicas through Redis. It speaks the RESP protocol
+// directly and only supports the handful of commands the adapters need.
+package redis
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNil is returned for a nil reply, such as GET on a missing key.
+var ErrNil = errors.New("redis: nil reply")
+
+// Error is an error reply from the server.
+type Error string
+
+func (e Error) Error() string { return "redis: " + string(e) }
+
+// Config holds the Redis connection settings.
+type Config struct {
+	URL         string // redis://[:password@]host:port[/db]
+	PoolSize    int
+	DialTimeout time.Duration
+}
+
+// Client is a small pooled Redis client.
+type Client struct {
+	addr        string
+	username    string
+	password    string
+	db          int
+	dialTimeout time.Duration
+	pool        chan *conn
+}
+
+type conn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+// NewClient creates a client for the server in cfg.URL. Connections are
+// opened lazily.
+func NewClient(cfg Config) (*Client, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("parse redis url: %w", err)
+	}
+	if u.Scheme != "redis" {
+		return nil, fmt.Errorf("unsupported redis url scheme %q", u.Scheme)
+	}
+
+	c := &Client{
+		addr:        u.Host,
+		dialTimeout: cfg.DialTimeout,
+		pool:        make(chan *conn, max(cfg.PoolSize, 1)),
+	}
+	if u.Port() == "" {
+		c.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.User != nil {
+		c.username = u.User.Username()
+		c.password, _ = u.User.Password()
+	}
+	if path := strings.Trim(u.Path, "/"); path != "" {
+		if c.db, err = strconv.Atoi(path); err != nil {
+			return nil, fmt.Errorf("invalid redis database %q", path)
+		}
+	}
+	if c.dialTimeout <= 0 {
+		c.dialTimeout = 5 * time.Second
+	}
+	return c, nil
+}
+
+// Do sends a command and returns its reply: a string for status replies,
+// int64, []byte for bulk strings, or []any for arrays. Nil replies return
+// ErrNil. Within an array, nil elements are nil and error elements are an
+// Error.
+func (c *Client) Do(ctx context.Context, args ...any) (any, error) {
+	cn, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := cn.do(ctx, args)
+	var replyErr Error
+	if err != nil && !errors.Is(err, ErrNil) && !errors.As(err, &replyErr) {
+		// The connection may be mid-reply; don't reuse it
+		_ = cn.Close()
+		return nil, err
+	}
+	c.put(cn)
+	return reply, err
+}
+
+// Ping checks that the server is reachable.
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.Do(ctx, "PING")
+	return err
+}
+
+// Close closes the idle connections.
+func (c *Client) Close() error {
+	for {
+		select {
+		case cn := <-c.pool:
+			_ = cn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+func (c *Client) get(ctx context.Context) (*conn, error) {
+	select {
+	case cn := <-c.pool:
+		return cn, nil
+	default:
+	}
+
+	dialer := net.Dialer{Timeout: c.dialTimeout}
+	nc, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, fmt.Errorf("redis dial: %w", err)
+	}
+	cn := &conn{Conn: nc, r: bufio.NewReader(nc)}
+
+	if c.password != "" {
+		args := []any{"AUTH", c.password}
+		if c.username != "" {
+			args = []any{"AUTH", c.username, c.password}
+		}
+		if _, err := cn.do(ctx, args); err != nil {
+			_ = cn.Close()
+			return nil, err
+		}
+	}
+	if c.db != 0 {
+		if _, err := cn.do(ctx, []any{"SELECT", c.db}); err != nil {
+			_ = cn.Close()
+			return nil, err
+		}
+	}
+	return cn, nil
+}
+
+func (c *Client) put(cn *conn) {
+	select {
+	case c.pool <- cn:
+	default:
+		_ = cn.Close()
+	}
+}
+
+func (cn *conn) do(ctx context.Context, args []any) (any, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(5 * time.Second)
+	}
+	if err := cn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	if _, err := cn.Write(encodeCommand(args)); err != nil {
+		return nil, err
+	}
+	return readReply(cn.r)
+}
+
+// encodeCommand encodes a command as a RESP array of bulk strings.
+func encodeCommand(args []any) []byte {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, '*')
+	buf = strconv.AppendInt(buf, int64(len(args)), 10)
+	buf = append(buf, '\r', '\n')
+	for _, arg := range args {
+		var s string
+		switch v := arg.(type) {
+		case string:
+			s = v
+		case []byte:
+			s = string(v)
+		case int:
+			s = strconv.Itoa(v)
+		case int64:
+			s = strconv.FormatInt(v, 10)
+		case float64:
+			s = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			s = fmt.Sprint(v)
+		}
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(s)), 10)
+		buf = append(buf, '\r', '\n')
+		buf = append(buf, s...)
+		buf = append(buf, '\r', '\n')
+	}
+	return buf
+}
+
+// readReply reads one RESP reply.
+func readReply(r *bufio.Reader) (any, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || !strings.HasSuffix(line, "\r\n") {
+		return nil, fmt.Errorf("redis: malformed reply %q", line)
+	}
+	kind, body := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return body, nil
+	case '-':
+		return nil, Error(body)
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed bulk length %q", body)
+		}
+		if n < 0 {
+			return nil, ErrNil
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		return data[:n], nil
+	case '*':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed array length %q", body)
+		}
+		if n < 0 {
+			return nil, ErrNil
+		}
+		items := make([]any, n)
+		for i := range items {
+			item, err := readReply(r)
+			var replyErr Error
+			switch {
+			case errors.As(err, &replyErr):
+				// Keep reading so the rest of the array is not left on the
+				// connection
+				item = replyErr
+			case err != nil && !errors.Is(err, ErrNil):
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("redis: unknown reply type %q", kind)
+	}
+}
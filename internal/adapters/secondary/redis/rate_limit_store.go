@@ -0,0 +1,79 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// gcraScript implements a token bucket as a generic cell rate algorithm:
+// the key holds the theoretical arrival time, in microseconds, of the next
+// request. Time comes from the server so replicas' clocks don't matter.
+//
+// KEYS[1] = bucket key, ARGV[1] = emission interval (us), ARGV[2] = burst
+// Returns {allowed, remaining, retry after (us), reset (us)}.
+const gcraScript = `
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+local interval = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local tat = tonumber(redis.call('GET', KEYS[1]) or now)
+if tat < now then tat = now end
+local next = tat + interval
+if next - now > interval * burst then
+  return {0, 0, next - now - interval * burst, tat - now}
+end
+redis.call('SET', KEYS[1], string.format('%.0f', next), 'PX', math.ceil((next - now) / 1000))
+return {1, math.floor((now + interval * burst - next) / interval), 0, next - now}
+`
+
+// RateLimitStore is a Redis implementation of ports.RateLimitStore, so
+// every replica draws from the same buckets.
+type RateLimitStore struct {
+	client *Client
+	prefix string
+}
+
+var _ ports.RateLimitStore = (*RateLimitStore)(nil)
+
+// NewRateLimitStore creates a store keeping its buckets under prefix.
+func NewRateLimitStore(client *Client, prefix string) *RateLimitStore {
+	return &RateLimitStore{client: client, prefix: prefix}
+}
+
+// Allow takes a token from key's bucket.
+func (s *RateLimitStore) Allow(ctx context.Context, key string, requestsPerSecond float64, burst int) (ports.RateLimitDecision, error) {
+	if requestsPerSecond <= 0 || burst < 1 {
+		return ports.RateLimitDecision{}, nil
+	}
+	interval := int64(math.Ceil(1e6 / requestsPerSecond))
+
+	reply, err := s.client.Do(ctx, "EVAL", gcraScript, 1, s.prefix+key, interval, burst)
+	if err != nil {
+		return ports.RateLimitDecision{}, err
+	}
+	values, ok := reply.([]any)
+	if !ok || len(values) != 4 {
+		return ports.RateLimitDecision{}, fmt.Errorf("redis: unexpected rate limit reply %v", reply)
+	}
+
+	var n [4]int64
+	for i, value := range values {
+		n[i], _ = value.(int64)
+	}
+	return ports.RateLimitDecision{
+		Allowed:    n[0] == 1,
+		Remaining:  int(n[1]),
+		RetryAfter: time.Duration(n[2]) * time.Microsecond,
+		Reset:      time.Duration(n[3]) * time.Microsecond,
+	}, nil
+}
+
+// Reset refills key's bucket.
+func (s *RateLimitStore) Reset(ctx context.Context, key string) error {
+	_, err := s.client.Do(ctx, "DEL", s.prefix+key)
+	return err
+}
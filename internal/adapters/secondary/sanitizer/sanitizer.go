@@ -0,0 +1,32 @@
+package sanitizer
+
+import (
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// Sanitizer is a secondary adapter that cleans rich text with an allowlist
+// of the elements and attributes user content needs. It implements the
+// ports.HTMLSanitizer interface.
+type Sanitizer struct {
+	policy *bluemonday.Policy
+}
+
+var _ ports.HTMLSanitizer = (*Sanitizer)(nil)
+
+// NewSanitizer creates a new sanitizer. Links keep their href only for
+// http, https and mailto URLs, and open in a new tab without telling the
+// target where they came from.
+func NewSanitizer() ports.HTMLSanitizer {
+	policy := bluemonday.UGCPolicy()
+	policy.AllowURLSchemes("http", "https", "mailto")
+	policy.RequireNoReferrerOnLinks(true)
+	policy.AddTargetBlankToFullyQualifiedLinks(true)
+	return &Sanitizer{policy: policy}
+}
+
+// Sanitize returns html with everything outside the allowlist removed.
+// It is safe for concurrent use.
+func (s *Sanitizer) Sanitize(html string) string {
+	return s.policy.Sanitize(html)
+}
@@ -0,0 +1,54 @@
+package sanitizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizer_Sanitize(t *testing.T) {
+	s := NewSanitizer()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "keeps formatting",
+			input: "<p>The <strong>printer</strong> on floor 2 shows:</p><ul><li><code>E-42</code></li></ul>",
+			want:  "<p>The <strong>printer</strong> on floor 2 shows:</p><ul><li><code>E-42</code></li></ul>",
+		},
+		{
+			name:  "escapes plain text",
+			input: `Error: value must be < 5 & "positive"`,
+			want:  "Error: value must be &lt; 5 &amp; &#34;positive&#34;",
+		},
+		{
+			name:  "removes scripts",
+			input: `<p>Hi</p><script>alert(document.cookie)</script>`,
+			want:  "<p>Hi</p>",
+		},
+		{
+			name:  "removes event handlers",
+			input: `<img src="https://example.com/a.png" onerror="alert(1)">`,
+			want:  `<img src="https://example.com/a.png">`,
+		},
+		{
+			name:  "removes javascript links",
+			input: `<a href="javascript:alert(1)">Click</a>`,
+			want:  "Click",
+		},
+		{
+			name:  "hardens external links",
+			input: `<a href="https://example.com/kb/42">KB</a>`,
+			want:  `<a href="https://example.com/kb/42" rel="nofollow noreferrer noopener" target="_blank">KB</a>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, s.Sanitize(tt.input))
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// postMessageURL is the Slack Web API endpoint used when a bot token is configured.
+const postMessageURL = "https://slack.com/api/chat.postMessage"
+
+// Config holds the Slack connection settings.
+// Either WebhookURL or BotToken must be set. With a bot token, messages are
+// posted to the organization's channel from OrgChannels, falling back to
+// DefaultChannel. An incoming webhook is bound to a single channel.
+type Config struct {
+	WebhookURL     string
+	BotToken       string
+	DefaultChannel string
+	OrgChannels    map[string]string // organization ID -> channel
+	Timeout        time.Duration
+}
+
+// Notifier is a secondary adapter that posts ticket notifications to Slack.
+// It implements the ports.Notifier interface.
+type Notifier struct {
+	cfg      Config
+	userRepo ports.UserRepository
+	client   *http.Client
+	logger   *slog.Logger
+	apiURL   string
+}
+
+var _ ports.Notifier = (*Notifier)(nil)
+
+// NewNotifier creates a new Slack notifier.
+// It requires a UserRepository to resolve the organization of a notification.
+func NewNotifier(cfg Config, userRepo ports.UserRepository, logger *slog.Logger) ports.Notifier {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &Notifier{
+		cfg:      cfg,
+		userRepo: userRepo,
+		client:   &http.Client{Timeout: timeout},
+		logger:   logger.With("component", "slack_notifier"),
+		apiURL:   postMessageURL,
+	}
+}
+
+// Notify posts the notification to the organization's Slack channel.
+// Notification types that are not relevant to a shared channel are ignored.
+func (n *Notifier) Notify(ctx context.Context, params ports.NotificationParams) error {
+	if !isChannelNotification(params.Type) {
+		return nil
+	}
+
+	user, err := n.userRepo.GetByID(ctx, params.RecipientUserID)
+	if err != nil {
+		return fmt.Errorf("get recipient %s: %w", params.RecipientUserID, err)
+	}
+
+	channel := n.cfg.DefaultChannel
+	if orgChannel, ok := n.cfg.OrgChannels[user.OrganizationID.String()]; ok {
+		channel = orgChannel
+	}
+
+	msg := buildMessage(params, user.FullName)
+	msg.Channel = channel
+
+	if n.cfg.BotToken != "" {
+		if channel == "" {
+			n.logger.Warn("no slack channel configured for organization",
+				"org_id", user.OrganizationID,
+				"ticket_id", params.TicketID,
+			)
+			return nil
+		}
+		return n.postMessage(ctx, msg)
+	}
+
+	return n.postWebhook(ctx, msg)
+}
+
+// isChannelNotification reports whether a notification type is posted to Slack
+func isChannelNotification(t ports.NotificationType) bool {
+	switch t {
+	case ports.NotificationTicketCreated:
+		return true
+	default:
+		return false
+	}
+}
+
+// message is the JSON payload accepted by both webhooks and chat.postMessage
+type message struct {
+	Channel string  `json:"channel,omitempty"`
+	Text    string  `json:"text"`
+	Blocks  []block `json:"blocks"`
+}
+
+type block struct {
+	Type   string `json:"type"`
+	Text   *text  `json:"text,omitempty"`
+	Fields []text `json:"fields,omitempty"`
+}
+
+type text struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// buildMessage formats a notification as Slack blocks, with Text as the
+// fallback shown in push notifications.
+func buildMessage(params ports.NotificationParams, requesterName string) message {
+	title := params.Data["title"]
+	headline := fmt.Sprintf("New ticket #%d: %s", params.TicketID, title)
+
+	return message{
+		Text: headline,
+		Blocks: []block{
+			{
+				Type: "header",
+				Text: &text{Type: "plain_text", Text: fmt.Sprintf("New ticket #%d", params.TicketID)},
+			},
+			{
+				Type: "section",
+				Text: &text{Type: "mrkdwn", Text: fmt.Sprintf("*%s*", escape(title))},
+				Fields: []text{
+					{Type: "mrkdwn", Text: fmt.Sprintf("*Priority*\n%s", escape(params.Data["priority"]))},
+					{Type: "mrkdwn", Text: fmt.Sprintf("*Requester*\n%s", escape(requesterName))},
+				},
+			},
+		},
+	}
+}
+
+// escape escapes the characters Slack treats as control sequences in mrkdwn
+func escape(s string) string {
+	var b bytes.Buffer
+	for _, r := range s {
+		switch r {
+		case '&':
+			b.WriteString("&amp;")
+		case '<':
+			b.WriteString("&lt;")
+		case '>':
+			b.WriteString("&gt;")
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// postWebhook sends the message to the configured incoming webhook
+func (n *Notifier) postWebhook(ctx context.Context, msg message) error {
+	// Incoming webhooks always post to the channel they were created for.
+	msg.Channel = ""
+
+	resp, err := n.post(ctx, n.cfg.WebhookURL, "", msg)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("slack webhook returned %d: %s", resp.StatusCode, body)
+	}
+	return nil
+}
+
+// postMessage sends the message through the chat.postMessage Web API
+func (n *Notifier) postMessage(ctx context.Context, msg message) error {
+	resp, err := n.post(ctx, n.apiURL, n.cfg.BotToken, msg)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("slack api returned %d", resp.StatusCode)
+	}
+
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("decode slack response: %w", err)
+	}
+	if !result.OK {
+		return fmt.Errorf("slack api error: %s", result.Error)
+	}
+	return nil
+}
+
+func (n *Notifier) post(ctx context.Context, url, token string, msg message) (*http.Response, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return n.client.Do(req)
+}
@@ -0,0 +1,109 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifier_Notify(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	orgID := uuid.New()
+	requester := &domain.User{ID: uuid.New(), OrganizationID: orgID, FullName: "Jane <Doe>"}
+
+	created := ports.NotificationParams{
+		RecipientUserID: requester.ID,
+		Type:            ports.NotificationTicketCreated,
+		TicketID:        42,
+		Data:            map[string]string{"title": "VPN is down", "priority": "HIGH"},
+	}
+
+	t.Run("posts new tickets to the webhook", func(t *testing.T) {
+		var got message
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		userRepo := mocks.NewMockUserRepository()
+		userRepo.On("GetByID", ctx, requester.ID).Return(requester, nil)
+
+		n := NewNotifier(Config{WebhookURL: server.URL}, userRepo, logger)
+		require.NoError(t, n.Notify(ctx, created))
+
+		assert.Equal(t, "New ticket #42: VPN is down", got.Text)
+		assert.Empty(t, got.Channel)
+		require.Len(t, got.Blocks, 2)
+		assert.Equal(t, "*Requester*\nJane &lt;Doe&gt;", got.Blocks[1].Fields[1].Text)
+	})
+
+	t.Run("posts to the organization's channel with a bot token", func(t *testing.T) {
+		var (
+			got       message
+			authorize string
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorize = r.Header.Get("Authorization")
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			_, _ = w.Write([]byte(`{"ok":true}`))
+		}))
+		defer server.Close()
+
+		userRepo := mocks.NewMockUserRepository()
+		userRepo.On("GetByID", ctx, requester.ID).Return(requester, nil)
+
+		n := NewNotifier(Config{
+			BotToken:       "xoxb-test",
+			DefaultChannel: "#support",
+			OrgChannels:    map[string]string{orgID.String(): "#acme-support"},
+		}, userRepo, logger).(*Notifier)
+		n.apiURL = server.URL
+
+		require.NoError(t, n.Notify(ctx, created))
+		assert.Equal(t, "Bearer xoxb-test", authorize)
+		assert.Equal(t, "#acme-support", got.Channel)
+	})
+
+	t.Run("returns slack api errors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"ok":false,"error":"channel_not_found"}`))
+		}))
+		defer server.Close()
+
+		userRepo := mocks.NewMockUserRepository()
+		userRepo.On("GetByID", ctx, requester.ID).Return(requester, nil)
+
+		n := NewNotifier(Config{BotToken: "xoxb-test", DefaultChannel: "#support"}, userRepo, logger).(*Notifier)
+		n.apiURL = server.URL
+
+		err := n.Notify(ctx, created)
+		assert.ErrorContains(t, err, "channel_not_found")
+	})
+
+	t.Run("ignores personal notifications", func(t *testing.T) {
+		userRepo := mocks.NewMockUserRepository()
+		n := NewNotifier(Config{WebhookURL: "http://127.0.0.1:0"}, userRepo, logger)
+
+		err := n.Notify(ctx, ports.NotificationParams{
+			RecipientUserID: requester.ID,
+			Type:            ports.NotificationCommentAdded,
+			TicketID:        42,
+		})
+
+		require.NoError(t, err)
+		userRepo.AssertNotCalled(t, "GetByID")
+	})
+}
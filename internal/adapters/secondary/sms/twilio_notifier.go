@@ -0,0 +1,213 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/time/rate"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// twilioBaseURL is the Twilio REST API root.
+const twilioBaseURL = "https://api.twilio.com/2010-04-01"
+
+// maxBodyLength keeps messages within a single SMS segment.
+const maxBodyLength = 160
+
+// userLimiterIdle is how long a user's rate limiter is kept unused. By then
+// it has refilled and is no different from a new one.
+const userLimiterIdle = time.Hour
+
+// Config holds the Twilio connection and rate limiting settings.
+type Config struct {
+	AccountSID string
+	AuthToken  string
+	FromNumber string
+
+	// MaxPerUserPerHour caps how many messages a single user receives per hour.
+	MaxPerUserPerHour int
+	// MaxPerMinute caps how many messages are sent across all users per minute.
+	MaxPerMinute int
+
+	Timeout time.Duration
+}
+
+// TwilioNotifier is a secondary adapter that sends urgent ticket
+// notifications as SMS through Twilio. It implements the ports.Notifier interface.
+//
+// Only ticket assignments at the highest priority are sent, and only to
+// users who have opted in with a phone number. Messages over the rate
+// limits are dropped rather than retried, so an incident cannot turn into
+// an SMS storm.
+type TwilioNotifier struct {
+	cfg      Config
+	userRepo ports.UserRepository
+	client   *http.Client
+	logger   *slog.Logger
+	baseURL  string
+
+	global *rate.Limiter
+	now    func() time.Time
+
+	mu        sync.Mutex
+	users     map[uuid.UUID]*userLimiter
+	lastSweep time.Time
+}
+
+// userLimiter is a user's rate limiter and when it was last used.
+type userLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+var _ ports.Notifier = (*TwilioNotifier)(nil)
+
+// NewTwilioNotifier creates a new Twilio SMS notifier.
+func NewTwilioNotifier(cfg Config, userRepo ports.UserRepository, logger *slog.Logger) ports.Notifier {
+	if cfg.MaxPerUserPerHour <= 0 {
+		cfg.MaxPerUserPerHour = 5
+	}
+	if cfg.MaxPerMinute <= 0 {
+		cfg.MaxPerMinute = 30
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &TwilioNotifier{
+		cfg:      cfg,
+		userRepo: userRepo,
+		client:   &http.Client{Timeout: timeout},
+		logger:   logger.With("component", "sms_notifier"),
+		baseURL:  twilioBaseURL,
+		global:   rate.NewLimiter(rate.Every(time.Minute/time.Duration(cfg.MaxPerMinute)), cfg.MaxPerMinute),
+		now:      time.Now,
+		users:    make(map[uuid.UUID]*userLimiter),
+	}
+}
+
+// Notify sends an SMS for urgent notifications to opted-in users.
+func (n *TwilioNotifier) Notify(ctx context.Context, params ports.NotificationParams) error {
+	if !isUrgent(params) {
+		return nil
+	}
+
+	user, err := n.userRepo.GetByID(ctx, params.RecipientUserID)
+	if err != nil {
+		return fmt.Errorf("get recipient %s: %w", params.RecipientUserID, err)
+	}
+	if !user.SMSOptIn || user.PhoneNumber == "" {
+		return nil
+	}
+
+	if !n.allow(user.ID) {
+		n.logger.Warn("sms rate limit reached, dropping message",
+			"user_id", user.ID,
+			"ticket_id", params.TicketID,
+		)
+		return nil
+	}
+
+	return n.send(ctx, user.PhoneNumber, buildBody(params))
+}
+
+// isUrgent reports whether a notification warrants an SMS
+func isUrgent(params ports.NotificationParams) bool {
+	return params.Type == ports.NotificationTicketAssigned &&
+		params.Data["priority"] == string(domain.PriorityHigh)
+}
+
+// allow checks both the per-user and the global rate limits. A message the
+// global limit refuses gives the user's token back, so a global throttle
+// does not use up users' budgets for messages that were never sent.
+func (n *TwilioNotifier) allow(userID uuid.UUID) bool {
+	now := n.now()
+	limiter := n.userLimiter(userID, now)
+
+	reservation := limiter.ReserveN(now, 1)
+	if !reservation.OK() || reservation.DelayFrom(now) > 0 {
+		reservation.CancelAt(now)
+		return false
+	}
+	if !n.global.AllowN(now, 1) {
+		reservation.CancelAt(now)
+		return false
+	}
+	return true
+}
+
+// userLimiter returns the user's rate limiter, first dropping the limiters
+// of users idle for longer than userLimiterIdle
+func (n *TwilioNotifier) userLimiter(userID uuid.UUID, now time.Time) *rate.Limiter {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if now.Sub(n.lastSweep) >= userLimiterIdle {
+		for id, user := range n.users {
+			if now.Sub(user.lastSeen) >= userLimiterIdle {
+				delete(n.users, id)
+			}
+		}
+		n.lastSweep = now
+	}
+
+	user, ok := n.users[userID]
+	if !ok {
+		user = &userLimiter{
+			limiter: rate.NewLimiter(rate.Every(time.Hour/time.Duration(n.cfg.MaxPerUserPerHour)), n.cfg.MaxPerUserPerHour),
+		}
+		n.users[userID] = user
+	}
+	user.lastSeen = now
+	return user.limiter
+}
+
+// buildBody formats the SMS text, truncated to a single segment
+func buildBody(params ports.NotificationParams) string {
+	body := fmt.Sprintf("[Service Desk] %s priority ticket #%d assigned to you: %s",
+		params.Data["priority"], params.TicketID, params.Data["title"])
+	if runes := []rune(body); len(runes) > maxBodyLength {
+		body = string(runes[:maxBodyLength-3]) + "..."
+	}
+	return body
+}
+
+// send posts the message to the Twilio Messages API
+func (n *TwilioNotifier) send(ctx context.Context, to, body string) error {
+	endpoint := fmt.Sprintf("%s/Accounts/%s/Messages.json", n.baseURL, url.PathEscape(n.cfg.AccountSID))
+	form := url.Values{
+		"To":   {to},
+		"From": {n.cfg.FromNumber},
+		"Body": {body},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(n.cfg.AccountSID, n.cfg.AuthToken)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("twilio returned %d: %s", resp.StatusCode, respBody)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package sms
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwilioNotifier_Notify(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	agent := &domain.User{ID: uuid.New(), FullName: "Agent", PhoneNumber: "+14155550123", SMSOptIn: true}
+
+	assigned := func(priority domain.TicketPriority) ports.NotificationParams {
+		return ports.NotificationParams{
+			RecipientUserID: agent.ID,
+			Type:            ports.NotificationTicketAssigned,
+			TicketID:        42,
+			Data:            map[string]string{"title": "Database down", "priority": string(priority)},
+		}
+	}
+
+	newNotifier := func(t *testing.T, cfg Config, userRepo ports.UserRepository) (*TwilioNotifier, *[]string) {
+		var sent []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseForm())
+			user, pass, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "AC123", user)
+			assert.Equal(t, "secret", pass)
+			assert.True(t, strings.HasSuffix(r.URL.Path, "/Accounts/AC123/Messages.json"))
+			sent = append(sent, r.PostForm.Get("To")+": "+r.PostForm.Get("Body"))
+			w.WriteHeader(http.StatusCreated)
+		}))
+		t.Cleanup(server.Close)
+
+		cfg.AccountSID = "AC123"
+		cfg.AuthToken = "secret"
+		cfg.FromNumber = "+15005550006"
+		n := NewTwilioNotifier(cfg, userRepo, logger).(*TwilioNotifier)
+		n.baseURL = server.URL
+		return n, &sent
+	}
+
+	t.Run("texts opted-in users about high-priority assignments", func(t *testing.T) {
+		userRepo := mocks.NewMockUserRepository()
+		userRepo.On("GetByID", ctx, agent.ID).Return(agent, nil)
+		n, sent := newNotifier(t, Config{}, userRepo)
+
+		require.NoError(t, n.Notify(ctx, assigned(domain.PriorityHigh)))
+		require.Len(t, *sent, 1)
+		assert.Equal(t, "+14155550123: [Service Desk] HIGH priority ticket #42 assigned to you: Database down", (*sent)[0])
+	})
+
+	t.Run("skips users who have not opted in", func(t *testing.T) {
+		optedOut := *agent
+		optedOut.SMSOptIn = false
+		userRepo := mocks.NewMockUserRepository()
+		userRepo.On("GetByID", ctx, agent.ID).Return(&optedOut, nil)
+		n, sent := newNotifier(t, Config{}, userRepo)
+
+		require.NoError(t, n.Notify(ctx, assigned(domain.PriorityHigh)))
+		assert.Empty(t, *sent)
+	})
+
+	t.Run("ignores lower priorities", func(t *testing.T) {
+		userRepo := mocks.NewMockUserRepository()
+		n, sent := newNotifier(t, Config{}, userRepo)
+
+		require.NoError(t, n.Notify(ctx, assigned(domain.PriorityMedium)))
+		assert.Empty(t, *sent)
+		userRepo.AssertNotCalled(t, "GetByID")
+	})
+
+	t.Run("drops messages over the per-user limit", func(t *testing.T) {
+		userRepo := mocks.NewMockUserRepository()
+		userRepo.On("GetByID", ctx, agent.ID).Return(agent, nil)
+		n, sent := newNotifier(t, Config{MaxPerUserPerHour: 2}, userRepo)
+
+		for i := 0; i < 5; i++ {
+			require.NoError(t, n.Notify(ctx, assigned(domain.PriorityHigh)))
+		}
+		assert.Len(t, *sent, 2)
+	})
+
+	t.Run("keeps the user's budget when the global limit drops a message", func(t *testing.T) {
+		userRepo := mocks.NewMockUserRepository()
+		userRepo.On("GetByID", ctx, agent.ID).Return(agent, nil)
+		n, sent := newNotifier(t, Config{MaxPerUserPerHour: 2}, userRepo)
+		now := time.Now()
+		n.now = func() time.Time { return now }
+
+		// Exhaust the global limit, then let it refill
+		require.True(t, n.global.AllowN(now, n.global.Burst()))
+		for i := 0; i < 3; i++ {
+			require.NoError(t, n.Notify(ctx, assigned(domain.PriorityHigh)))
+		}
+		assert.Empty(t, *sent)
+
+		now = now.Add(time.Minute)
+		for i := 0; i < 3; i++ {
+			require.NoError(t, n.Notify(ctx, assigned(domain.PriorityHigh)))
+		}
+		assert.Len(t, *sent, 2)
+	})
+}
+
+func TestTwilioNotifier_EvictsIdleUserLimiters(t *testing.T) {
+	n := NewTwilioNotifier(Config{}, mocks.NewMockUserRepository(), slog.New(slog.NewTextHandler(io.Discard, nil))).(*TwilioNotifier)
+	now := time.Now()
+	n.now = func() time.Time { return now }
+
+	idle, active := uuid.New(), uuid.New()
+	require.True(t, n.allow(idle))
+	now = now.Add(30 * time.Minute)
+	require.True(t, n.allow(active))
+
+	now = now.Add(45 * time.Minute)
+	require.True(t, n.allow(active))
+
+	assert.NotContains(t, n.users, idle)
+	assert.Contains(t, n.users, active)
+}
+
+func TestBuildBody_Truncates(t *testing.T) {
+	body := buildBody(ports.NotificationParams{
+		TicketID: 1,
+		Data:     map[string]string{"title": strings.Repeat("é", 200), "priority": "HIGH"},
+	})
+
+	assert.Equal(t, maxBodyLength, len([]rune(body)))
+	assert.True(t, strings.HasSuffix(body, "..."))
+}
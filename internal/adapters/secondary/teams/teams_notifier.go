@@ -0,0 +1,171 @@
+package teams
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// Config holds the Microsoft Teams connection settings.
+// Each organization posts to its webhook from OrgWebhooks, falling back to
+// WebhookURL. Organizations without a webhook are skipped.
+type Config struct {
+	WebhookURL  string
+	OrgWebhooks map[string]string // organization ID -> incoming webhook URL
+	Timeout     time.Duration
+}
+
+// Notifier is a secondary adapter that posts high-priority tickets to
+// Microsoft Teams as adaptive cards. It implements the ports.Notifier interface.
+type Notifier struct {
+	cfg      Config
+	userRepo ports.UserRepository
+	client   *http.Client
+	logger   *slog.Logger
+}
+
+var _ ports.Notifier = (*Notifier)(nil)
+
+// NewNotifier creates a new Teams notifier.
+// It requires a UserRepository to resolve the organization of a notification.
+func NewNotifier(cfg Config, userRepo ports.UserRepository, logger *slog.Logger) ports.Notifier {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &Notifier{
+		cfg:      cfg,
+		userRepo: userRepo,
+		client:   &http.Client{Timeout: timeout},
+		logger:   logger.With("component", "teams_notifier"),
+	}
+}
+
+// Notify posts newly created high-priority tickets to the organization's
+// Teams channel. All other notifications are ignored.
+func (n *Notifier) Notify(ctx context.Context, params ports.NotificationParams) error {
+	if params.Type != ports.NotificationTicketCreated ||
+		params.Data["priority"] != string(domain.PriorityHigh) {
+		return nil
+	}
+
+	user, err := n.userRepo.GetByID(ctx, params.RecipientUserID)
+	if err != nil {
+		return fmt.Errorf("get recipient %s: %w", params.RecipientUserID, err)
+	}
+
+	webhookURL := n.cfg.WebhookURL
+	if orgWebhook, ok := n.cfg.OrgWebhooks[user.OrganizationID.String()]; ok {
+		webhookURL = orgWebhook
+	}
+	if webhookURL == "" {
+		n.logger.Debug("no teams webhook configured for organization",
+			"org_id", user.OrganizationID,
+			"ticket_id", params.TicketID,
+		)
+		return nil
+	}
+
+	body, err := json.Marshal(buildMessage(params, user.FullName))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("teams webhook returned %d: %s", resp.StatusCode, respBody)
+	}
+	return nil
+}
+
+// message is the incoming webhook payload wrapping an adaptive card
+type message struct {
+	Type        string       `json:"type"`
+	Attachments []attachment `json:"attachments"`
+}
+
+type attachment struct {
+	ContentType string `json:"contentType"`
+	Content     card   `json:"content"`
+}
+
+type card struct {
+	Schema  string        `json:"$schema"`
+	Type    string        `json:"type"`
+	Version string        `json:"version"`
+	Body    []cardElement `json:"body"`
+}
+
+type cardElement struct {
+	Type   string `json:"type"`
+	Text   string `json:"text,omitempty"`
+	Weight string `json:"weight,omitempty"`
+	Size   string `json:"size,omitempty"`
+	Color  string `json:"color,omitempty"`
+	Wrap   bool   `json:"wrap,omitempty"`
+	Facts  []fact `json:"facts,omitempty"`
+}
+
+type fact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// buildMessage formats a new ticket as an adaptive card
+func buildMessage(params ports.NotificationParams, requesterName string) message {
+	return message{
+		Type: "message",
+		Attachments: []attachment{
+			{
+				ContentType: "application/vnd.microsoft.card.adaptive",
+				Content: card{
+					Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+					Type:    "AdaptiveCard",
+					Version: "1.4",
+					Body: []cardElement{
+						{
+							Type:   "TextBlock",
+							Text:   fmt.Sprintf("High-priority ticket #%d", params.TicketID),
+							Weight: "Bolder",
+							Size:   "Medium",
+							Color:  "Attention",
+						},
+						{
+							Type: "TextBlock",
+							Text: params.Data["title"],
+							Wrap: true,
+						},
+						{
+							Type: "FactSet",
+							Facts: []fact{
+								{Title: "Priority", Value: params.Data["priority"]},
+								{Title: "Requester", Value: requesterName},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,87 @@
+package teams
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifier_Notify(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	orgID := uuid.New()
+	requester := &domain.User{ID: uuid.New(), OrganizationID: orgID, FullName: "Jane Doe"}
+
+	newTicket := func(priority domain.TicketPriority) ports.NotificationParams {
+		return ports.NotificationParams{
+			RecipientUserID: requester.ID,
+			Type:            ports.NotificationTicketCreated,
+			TicketID:        42,
+			Data:            map[string]string{"title": "VPN is down", "priority": string(priority)},
+		}
+	}
+
+	t.Run("posts high-priority tickets to the organization's webhook", func(t *testing.T) {
+		var got message
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		userRepo := mocks.NewMockUserRepository()
+		userRepo.On("GetByID", ctx, requester.ID).Return(requester, nil)
+
+		n := NewNotifier(Config{
+			WebhookURL:  "http://127.0.0.1:0",
+			OrgWebhooks: map[string]string{orgID.String(): server.URL},
+		}, userRepo, logger)
+
+		require.NoError(t, n.Notify(ctx, newTicket(domain.PriorityHigh)))
+		require.Len(t, got.Attachments, 1)
+		assert.Equal(t, "application/vnd.microsoft.card.adaptive", got.Attachments[0].ContentType)
+		assert.Equal(t, "High-priority ticket #42", got.Attachments[0].Content.Body[0].Text)
+	})
+
+	t.Run("returns webhook errors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		userRepo := mocks.NewMockUserRepository()
+		userRepo.On("GetByID", ctx, requester.ID).Return(requester, nil)
+
+		n := NewNotifier(Config{WebhookURL: server.URL}, userRepo, logger)
+
+		assert.Error(t, n.Notify(ctx, newTicket(domain.PriorityHigh)))
+	})
+
+	t.Run("ignores lower priorities", func(t *testing.T) {
+		userRepo := mocks.NewMockUserRepository()
+		n := NewNotifier(Config{WebhookURL: "http://127.0.0.1:0"}, userRepo, logger)
+
+		require.NoError(t, n.Notify(ctx, newTicket(domain.PriorityMedium)))
+		userRepo.AssertNotCalled(t, "GetByID")
+	})
+
+	t.Run("skips organizations without a webhook", func(t *testing.T) {
+		userRepo := mocks.NewMockUserRepository()
+		userRepo.On("GetByID", ctx, requester.ID).Return(requester, nil)
+
+		n := NewNotifier(Config{}, userRepo, logger)
+
+		require.NoError(t, n.Notify(ctx, newTicket(domain.PriorityHigh)))
+	})
+}
@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// Headers set on every webhook request.
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Sender is a secondary adapter that POSTs signed webhook payloads.
+// It implements the ports.WebhookSender interface.
+//
+// Each request carries an HMAC-SHA256 signature of "<timestamp>.<body>"
+// keyed with the webhook's secret, so receivers can verify both the origin
+// and the freshness of a delivery.
+type Sender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+var _ ports.WebhookSender = (*Sender)(nil)
+
+// NewSender creates a new webhook sender.
+func NewSender(timeout time.Duration) ports.WebhookSender {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &Sender{
+		client: &http.Client{
+			Timeout: timeout,
+			// Redirects are reported as failures rather than followed, so a
+			// delivery never ends up somewhere other than the registered URL.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+// Send delivers the job's payload to its webhook URL
+func (s *Sender) Send(ctx context.Context, job *ports.WebhookDeliveryJob) (int, error) {
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, job.URL, bytes.NewReader(job.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "service-desk-webhooks/1.0")
+	req.Header.Set(HeaderEvent, string(job.EventType))
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(job.DeliveryID, 10))
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, "sha256="+Sign(job.Secret, timestamp, job.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return resp.StatusCode, fmt.Errorf("webhook returned %d: %s", resp.StatusCode, respBody)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 of "<timestamp>.<body>"
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
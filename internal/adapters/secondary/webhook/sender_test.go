@@ -0,0 +1,89 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSender_Send(t *testing.T) {
+	ctx := context.Background()
+	payload := []byte(`{"event":"ticket.created","ticketId":42}`)
+
+	newJob := func(url string) *ports.WebhookDeliveryJob {
+		return &ports.WebhookDeliveryJob{
+			DeliveryID: 7,
+			WebhookID:  uuid.New(),
+			URL:        url,
+			Secret:     "s3cret",
+			EventType:  domain.WebhookTicketCreated,
+			Payload:    payload,
+		}
+	}
+
+	t.Run("posts a signed payload", func(t *testing.T) {
+		var (
+			gotBody   []byte
+			gotHeader http.Header
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotBody, _ = io.ReadAll(r.Body)
+			gotHeader = r.Header
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		status, err := NewSender(0).Send(ctx, newJob(server.URL))
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, status)
+		assert.Equal(t, payload, gotBody)
+		assert.Equal(t, "ticket.created", gotHeader.Get(HeaderEvent))
+		assert.Equal(t, "7", gotHeader.Get(HeaderDelivery))
+
+		timestamp := gotHeader.Get(HeaderTimestamp)
+		require.NotEmpty(t, timestamp)
+		assert.Equal(t, "sha256="+Sign("s3cret", timestamp, payload), gotHeader.Get(HeaderSignature))
+	})
+
+	t.Run("reports non-2xx responses as errors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		status, err := NewSender(0).Send(ctx, newJob(server.URL))
+
+		require.Error(t, err)
+		assert.Equal(t, http.StatusInternalServerError, status)
+	})
+
+	t.Run("does not follow redirects", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "http://127.0.0.1:0/elsewhere", http.StatusFound)
+		}))
+		defer server.Close()
+
+		status, err := NewSender(0).Send(ctx, newJob(server.URL))
+
+		require.Error(t, err)
+		assert.Equal(t, http.StatusFound, status)
+	})
+}
+
+func TestSign(t *testing.T) {
+	sig := Sign("key", "1700000000", []byte(`{}`))
+
+	assert.Len(t, sig, 64)
+	assert.Equal(t, sig, Sign("key", "1700000000", []byte(`{}`)))
+	assert.NotEqual(t, sig, Sign("other", "1700000000", []byte(`{}`)))
+	assert.NotEqual(t, sig, Sign("key", "1700000001", []byte(`{}`)))
+}
@@ -8,10 +8,12 @@ import (
 	"github.com/google/uuid"
 )
 
-// Claims defines the structured data we store in the JWT
+// Claims defines the structured data we store in the JWT. ImpersonatorID is
+// set on tokens a super-admin was issued to act as UserID.
 type Claims struct {
-	UserID uuid.UUID `json:"user_id"`
-	OrgID  uuid.UUID `json:"org_id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	OrgID          uuid.UUID  `json:"org_id"`
+	ImpersonatorID *uuid.UUID `json:"impersonator_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -33,15 +35,26 @@ func (tm *TokenManager) GenerateToken(userID, orgID uuid.UUID) (string, error) {
 	if ttl <= 0 {
 		ttl = time.Hour
 	}
+	return tm.sign(&Claims{UserID: userID, OrgID: orgID}, ttl)
+}
+
+// GenerateImpersonationToken creates an access token for userID on behalf of
+// the super-admin impersonatorID, valid for ttl or the access token lifetime,
+// whichever is shorter.
+func (tm *TokenManager) GenerateImpersonationToken(userID, orgID, impersonatorID uuid.UUID, ttl time.Duration) (string, error) {
+	if tm.accessTTL > 0 && tm.accessTTL < ttl {
+		ttl = tm.accessTTL
+	}
+	return tm.sign(&Claims{UserID: userID, OrgID: orgID, ImpersonatorID: &impersonatorID}, ttl)
+}
 
-	expirationTime := time.Now().Add(ttl)
-	claims := &Claims{
-		UserID: userID,
-		OrgID:  orgID,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			Subject:   userID.String(),
-		},
+// sign fills in the registered claims and signs the token.
+func (tm *TokenManager) sign(claims *Claims, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		Subject:   claims.UserID.String(),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(tm.secretKey)
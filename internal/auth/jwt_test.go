@@ -29,3 +29,27 @@ func TestTokenManager_UsesConfiguredTTL(t *testing.T) {
 	expectedExpiry := start.Add(ttl)
 	assert.WithinDuration(t, expectedExpiry, claims.ExpiresAt.Time, 2*time.Second)
 }
+
+func TestTokenManager_GenerateImpersonationToken(t *testing.T) {
+	tm := NewTokenManager("test-secret", 2*time.Hour)
+	userID, orgID, impersonatorID := uuid.New(), uuid.New(), uuid.New()
+
+	start := time.Now()
+
+	token, err := tm.GenerateImpersonationToken(userID, orgID, impersonatorID, 30*time.Minute)
+	require.NoError(t, err)
+
+	claims, err := tm.ValidateToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, userID, claims.UserID)
+	assert.Equal(t, orgID, claims.OrgID)
+	require.NotNil(t, claims.ImpersonatorID)
+	assert.Equal(t, impersonatorID, *claims.ImpersonatorID)
+	assert.WithinDuration(t, start.Add(30*time.Minute), claims.ExpiresAt.Time, 2*time.Second)
+
+	plain, err := tm.GenerateToken(userID, orgID)
+	require.NoError(t, err)
+	claims, err = tm.ValidateToken(plain)
+	require.NoError(t, err)
+	assert.Nil(t, claims.ImpersonatorID)
+}
@@ -4,12 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"os"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
+
+	"github.com/lorrc/service-desk-backend/internal/buildinfo"
 )
 
 // Config holds all application configuration
@@ -26,6 +28,18 @@ type Config struct {
 	// Rate limiting configuration
 	RateLimit RateLimitConfig
 
+	// Response compression configuration
+	Compression CompressionConfig
+
+	// Cache configuration
+	Cache CacheConfig
+
+	// Circuit breaker configuration
+	CircuitBreaker CircuitBreakerConfig
+
+	// CORS configuration
+	CORS CORSConfig
+
 	// Logging configuration
 	Logging LoggingConfig
 
@@ -34,6 +48,54 @@ type Config struct {
 
 	// Admin user configuration
 	Admin AdminConfig
+
+	// Notification outbox configuration
+	Notifications NotificationConfig
+
+	// Slack integration configuration
+	Slack SlackConfig
+
+	// Microsoft Teams integration configuration
+	Teams TeamsConfig
+
+	// SMS (Twilio) configuration
+	SMS SMSConfig
+
+	// Outgoing webhook delivery configuration
+	Webhooks WebhookConfig
+
+	// Analytics rollup configuration
+	Analytics AnalyticsConfig
+
+	// Personal data export configuration
+	DataExports DataExportConfig
+
+	// Data retention configuration
+	Retention RetentionConfig
+
+	// Default per-organization quotas
+	Quotas QuotaConfig
+
+	// Public ticket portal configuration
+	Portal PortalConfig
+
+	// Login email change configuration
+	EmailChange EmailChangeConfig
+
+	// Ticket search configuration
+	Search SearchConfig
+
+	// Closed ticket archival configuration
+	Archive ArchiveConfig
+
+	// Ticket snooze configuration
+	Snooze SnoozeConfig
+
+	// User activity tracking configuration
+	Activity ActivityConfig
+
+	// Fault injection configuration, for staging
+	FaultInjection FaultInjectionConfig
 }
 
 // ServerConfig holds HTTP server configuration
@@ -43,15 +105,37 @@ type ServerConfig struct {
 	WriteTimeout    time.Duration
 	IdleTimeout     time.Duration
 	ShutdownTimeout time.Duration
+	DrainPeriod     time.Duration // How long readiness fails before shutdown starts
+	PprofEnabled    bool          // Serve profiles under /debug/pprof to admins
+	MetricsEnabled  bool          // Serve query metrics under /metrics to admins
 }
 
 // DatabaseConfig holds database configuration
 type DatabaseConfig struct {
-	URL             string
-	MaxOpenConns    int
-	MaxIdleConns    int
-	ConnMaxLifetime time.Duration
-	ConnMaxIdleTime time.Duration
+	URL              string
+	MaxOpenConns     int
+	MaxIdleConns     int
+	ConnMaxLifetime  time.Duration
+	ConnMaxIdleTime  time.Duration
+	MigrateOnStart   bool          // Apply pending migrations before serving
+	StatementTimeout time.Duration // Server-side limit on each statement; 0 disables
+
+	// Transactions failing with a serialization failure, deadlock or
+	// connection error are retried with exponential backoff
+	TxMaxRetries      int
+	TxRetryBackoff    time.Duration
+	TxRetryMaxBackoff time.Duration
+
+	// Optional read-only replica for list and analytics queries
+	ReadURL           string
+	ReadCheckInterval time.Duration // How often the replica's health is checked
+
+	// How long a listing's total may take to count exactly before the
+	// planner's estimate is returned instead; 0 always counts exactly
+	CountTimeBudget time.Duration
+
+	// Queries taking this long or longer are logged; 0 disables the log
+	SlowQueryThreshold time.Duration
 }
 
 // JWTConfig holds JWT configuration
@@ -68,6 +152,35 @@ type RateLimitConfig struct {
 	BurstSize         int
 	AuthRPS           float64 // Stricter limit for auth endpoints
 	AuthBurst         int
+	PortalRPS         float64 // Strictest limit, for the public portal
+	PortalBurst       int
+}
+
+// CompressionConfig holds response compression configuration
+type CompressionConfig struct {
+	Enabled bool
+	MinSize int // Responses smaller than this (bytes) are not compressed
+}
+
+// CacheConfig holds cache configuration. Without a Redis URL the cache and
+// rate limits are kept in process memory.
+type CacheConfig struct {
+	RedisURL       string        // Shares the cache and rate limits between replicas
+	PermissionTTL  time.Duration // How long user permissions are cached; 0 disables
+	IdempotencyTTL time.Duration // How long responses to Idempotency-Key requests are kept
+}
+
+// CircuitBreakerConfig holds the settings of the breakers around the
+// database and notifiers
+type CircuitBreakerConfig struct {
+	Enabled          bool
+	FailureThreshold int           // Consecutive failures that open a breaker
+	OpenTimeout      time.Duration // How long a breaker fails fast before trying again
+}
+
+// CORSConfig holds cross-origin request configuration
+type CORSConfig struct {
+	AllowedOrigins []string // "*" allows any origin
 }
 
 // LoggingConfig holds logging configuration
@@ -82,6 +195,7 @@ type AppConfig struct {
 	Version      string
 	Environment  string
 	DefaultOrgID string
+	TenantDomain string // Requests to <slug>.TenantDomain are for the organization with that slug
 }
 
 // AdminConfig holds the initial admin user configuration
@@ -92,30 +206,201 @@ type AdminConfig struct {
 	LastName  string
 }
 
-// Load loads configuration from environment variables
+// NotificationConfig holds notification outbox delivery configuration
+type NotificationConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	ClaimLease   time.Duration
+	MaxAttempts  int
+	RetryBase    time.Duration
+	RetryMax     time.Duration
+	// CommentBatchWindow is how long comment notifications wait to be
+	// combined per recipient and ticket; zero sends each one on its own.
+	CommentBatchWindow time.Duration
+	// PreferencesURL is the page the "mute this ticket" and "unsubscribe"
+	// links in ticket emails open; empty leaves the links out.
+	PreferencesURL string
+}
+
+// SlackConfig holds Slack integration configuration
+type SlackConfig struct {
+	WebhookURL     string
+	BotToken       string
+	DefaultChannel string
+	OrgChannels    map[string]string // organization ID -> channel
+}
+
+// Enabled returns true if a Slack webhook or bot token is configured
+func (c SlackConfig) Enabled() bool {
+	return c.WebhookURL != "" || c.BotToken != ""
+}
+
+// TeamsConfig holds Microsoft Teams integration configuration
+type TeamsConfig struct {
+	WebhookURL  string
+	OrgWebhooks map[string]string // organization ID -> incoming webhook URL
+}
+
+// Enabled returns true if any Teams webhook is configured
+func (c TeamsConfig) Enabled() bool {
+	return c.WebhookURL != "" || len(c.OrgWebhooks) > 0
+}
+
+// SMSConfig holds Twilio SMS configuration
+type SMSConfig struct {
+	TwilioAccountSID  string
+	TwilioAuthToken   string
+	FromNumber        string
+	MaxPerUserPerHour int
+	MaxPerMinute      int
+}
+
+// Enabled returns true if Twilio credentials are configured
+func (c SMSConfig) Enabled() bool {
+	return c.TwilioAccountSID != "" && c.TwilioAuthToken != ""
+}
+
+// WebhookConfig holds outgoing webhook delivery configuration.
+// Polling and backoff are shared with the notification outbox.
+type WebhookConfig struct {
+	Timeout     time.Duration
+	MaxAttempts int
+}
+
+// AnalyticsConfig holds the daily analytics rollup configuration
+type AnalyticsConfig struct {
+	RollupInterval     time.Duration
+	RollupLookbackDays int
+}
+
+// DataExportConfig holds the personal data export configuration
+type DataExportConfig struct {
+	PollInterval time.Duration
+	Retention    time.Duration
+}
+
+// RetentionConfig holds the data retention job configuration
+type RetentionConfig struct {
+	Interval time.Duration
+	DryRun   bool
+}
+
+// QuotaConfig holds the default per-organization quotas. 0 is unlimited.
+type QuotaConfig struct {
+	MaxUsers       int
+	MaxOpenTickets int
+}
+
+// PortalConfig holds the public ticket portal configuration. Submissions
+// are checked with a siteverify-style captcha provider such as hCaptcha,
+// reCAPTCHA or Cloudflare Turnstile.
+type PortalConfig struct {
+	Enabled          bool
+	LinkURL          string        // Page guests open their emailed link on
+	LinkTTL          time.Duration // How long an emailed link works
+	CaptchaSecret    string
+	CaptchaVerifyURL string // Empty uses hCaptcha
+}
+
+// EmailChangeConfig holds the configuration for changing a login email,
+// which is confirmed through a link sent to the new address. Without a
+// confirm URL users cannot change their email.
+type EmailChangeConfig struct {
+	ConfirmURL string        // Page the confirmation link opens
+	LinkTTL    time.Duration // How long a confirmation link works
+}
+
+// SearchConfig holds the ticket search configuration. Tickets are searched
+// with Postgres full-text search unless Backend is "opensearch".
+// Indexing shares the NOTIFY_* polling settings.
+type SearchConfig struct {
+	Backend            string // postgres or opensearch
+	OpenSearchURL      string
+	OpenSearchIndex    string
+	OpenSearchUsername string
+	OpenSearchPassword string
+	Timeout            time.Duration
+}
+
+// ArchiveConfig holds the closed ticket archival configuration. Tickets
+// closed for AfterDays are moved into the archive; 0 disables archival.
+type ArchiveConfig struct {
+	AfterDays int
+	Interval  time.Duration
+	BatchSize int // Tickets moved per transaction
+}
+
+// SnoozeConfig holds the ticket snooze configuration. Snoozes are checked
+// every CheckInterval, so tickets resurface up to that long after their
+// snooze ends.
+type SnoozeConfig struct {
+	CheckInterval time.Duration
+	BatchSize     int // Snoozes ended per transaction
+}
+
+// ActivityConfig holds the user activity tracking configuration. A user's
+// last active time is updated at most once per Throttle, and the updates
+// are written together every FlushInterval.
+type ActivityConfig struct {
+	Throttle      time.Duration
+	FlushInterval time.Duration
+}
+
+// FaultInjectionConfig holds the fault injection settings, used in staging
+// to check how clients cope with slow and failing requests. Latency and
+// ErrorRate map a path prefix to the delay added to the requests under it
+// and the share of them that fail; the longest matching prefix applies.
+// Fault injection cannot be enabled in production.
+type FaultInjectionConfig struct {
+	Enabled   bool
+	Latency   map[string]time.Duration
+	ErrorRate map[string]float64 // 0 to 1
+}
+
+// Load loads configuration from environment variables, and from the YAML
+// file named by CONFIG_FILE if set. Environment variables take precedence
+// over the file.
 func Load() (*Config, error) {
 	// Load .env file if it exists (for local development)
 	if err := godotenv.Load(); err != nil {
 		log.Println("No .env file found, using system environment variables")
 	}
 
-	cfg := &Config{
+	return build()
+}
+
+// fromEnv builds the configuration from environment variables and the
+// config file
+func fromEnv() *Config {
+	return &Config{
 		Server: ServerConfig{
 			Port:            getEnvOrDefault("SERVER_PORT", ":8080"),
 			ReadTimeout:     getDurationOrDefault("SERVER_READ_TIMEOUT", 15*time.Second),
 			WriteTimeout:    getDurationOrDefault("SERVER_WRITE_TIMEOUT", 15*time.Second),
 			IdleTimeout:     getDurationOrDefault("SERVER_IDLE_TIMEOUT", 60*time.Second),
 			ShutdownTimeout: getDurationOrDefault("SERVER_SHUTDOWN_TIMEOUT", 30*time.Second),
+			DrainPeriod:     getDurationOrDefault("SERVER_DRAIN_PERIOD", 5*time.Second),
+			PprofEnabled:    getBoolOrDefault("SERVER_PPROF_ENABLED", false),
+			MetricsEnabled:  getBoolOrDefault("SERVER_METRICS_ENABLED", false),
 		},
 		Database: DatabaseConfig{
-			URL:             os.Getenv("DATABASE_URL"),
-			MaxOpenConns:    getIntOrDefault("DB_MAX_OPEN_CONNS", 25),
-			MaxIdleConns:    getIntOrDefault("DB_MAX_IDLE_CONNS", 5),
-			ConnMaxLifetime: getDurationOrDefault("DB_CONN_MAX_LIFETIME", 5*time.Minute),
-			ConnMaxIdleTime: getDurationOrDefault("DB_CONN_MAX_IDLE_TIME", 5*time.Minute),
+			URL:                lookup("DATABASE_URL"),
+			MaxOpenConns:       getIntOrDefault("DB_MAX_OPEN_CONNS", 25),
+			MaxIdleConns:       getIntOrDefault("DB_MAX_IDLE_CONNS", 5),
+			ConnMaxLifetime:    getDurationOrDefault("DB_CONN_MAX_LIFETIME", 5*time.Minute),
+			ConnMaxIdleTime:    getDurationOrDefault("DB_CONN_MAX_IDLE_TIME", 5*time.Minute),
+			StatementTimeout:   getDurationOrDefault("DB_STATEMENT_TIMEOUT", 30*time.Second),
+			TxMaxRetries:       getIntOrDefault("DB_TX_MAX_RETRIES", 3),
+			TxRetryBackoff:     getDurationOrDefault("DB_TX_RETRY_BACKOFF", 20*time.Millisecond),
+			TxRetryMaxBackoff:  getDurationOrDefault("DB_TX_RETRY_MAX_BACKOFF", time.Second),
+			ReadURL:            lookup("DATABASE_READ_URL"),
+			ReadCheckInterval:  getDurationOrDefault("DATABASE_READ_CHECK_INTERVAL", 10*time.Second),
+			CountTimeBudget:    getDurationOrDefault("DB_COUNT_TIME_BUDGET", 500*time.Millisecond),
+			SlowQueryThreshold: getDurationOrDefault("DB_SLOW_QUERY_THRESHOLD", 500*time.Millisecond),
+			MigrateOnStart:     getBoolOrDefault("MIGRATE_ON_START", false),
 		},
 		JWT: JWTConfig{
-			Secret:          os.Getenv("JWT_SECRET"),
+			Secret:          lookup("JWT_SECRET"),
 			AccessTokenTTL:  getDurationOrDefault("JWT_ACCESS_TOKEN_TTL", 1*time.Hour),
 			RefreshTokenTTL: getDurationOrDefault("JWT_REFRESH_TOKEN_TTL", 7*24*time.Hour),
 		},
@@ -125,6 +410,25 @@ func Load() (*Config, error) {
 			BurstSize:         getIntOrDefault("RATE_LIMIT_BURST", 20),
 			AuthRPS:           getFloatOrDefault("RATE_LIMIT_AUTH_RPS", 1),
 			AuthBurst:         getIntOrDefault("RATE_LIMIT_AUTH_BURST", 5),
+			PortalRPS:         getFloatOrDefault("RATE_LIMIT_PORTAL_RPS", 0.05),
+			PortalBurst:       getIntOrDefault("RATE_LIMIT_PORTAL_BURST", 3),
+		},
+		Compression: CompressionConfig{
+			Enabled: getBoolOrDefault("COMPRESSION_ENABLED", true),
+			MinSize: getIntOrDefault("COMPRESSION_MIN_SIZE", 1024),
+		},
+		Cache: CacheConfig{
+			RedisURL:       lookup("REDIS_URL"),
+			PermissionTTL:  getDurationOrDefault("PERMISSION_CACHE_TTL", 30*time.Second),
+			IdempotencyTTL: getDurationOrDefault("IDEMPOTENCY_KEY_TTL", 24*time.Hour),
+		},
+		CircuitBreaker: CircuitBreakerConfig{
+			Enabled:          getBoolOrDefault("CIRCUIT_BREAKER_ENABLED", true),
+			FailureThreshold: getIntOrDefault("CIRCUIT_BREAKER_FAILURE_THRESHOLD", 5),
+			OpenTimeout:      getDurationOrDefault("CIRCUIT_BREAKER_OPEN_TIMEOUT", 30*time.Second),
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: getListOrDefault("CORS_ALLOWED_ORIGINS", []string{"*"}),
 		},
 		Logging: LoggingConfig{
 			Level:  getEnvOrDefault("LOG_LEVEL", "info"),
@@ -132,9 +436,10 @@ func Load() (*Config, error) {
 		},
 		App: AppConfig{
 			Name:         getEnvOrDefault("APP_NAME", "service-desk"),
-			Version:      getEnvOrDefault("APP_VERSION", "dev"),
+			Version:      getEnvOrDefault("APP_VERSION", buildinfo.Version),
 			Environment:  getEnvOrDefault("APP_ENV", "development"),
 			DefaultOrgID: getEnvOrDefault("DEFAULT_ORG_ID", "00000000-0000-0000-0000-000000000001"),
+			TenantDomain: getEnvOrDefault("TENANT_DOMAIN", ""),
 		},
 		Admin: AdminConfig{
 			Email:     getEnvOrDefault("ADMIN_EMAIL", ""),
@@ -142,13 +447,92 @@ func Load() (*Config, error) {
 			FirstName: getEnvOrDefault("ADMIN_FIRST_NAME", ""),
 			LastName:  getEnvOrDefault("ADMIN_LAST_NAME", ""),
 		},
+		Notifications: NotificationConfig{
+			PollInterval: getDurationOrDefault("NOTIFY_POLL_INTERVAL", 5*time.Second),
+			BatchSize:    getIntOrDefault("NOTIFY_BATCH_SIZE", 50),
+			ClaimLease:   getDurationOrDefault("NOTIFY_CLAIM_LEASE", 5*time.Minute),
+			MaxAttempts:  getIntOrDefault("NOTIFY_MAX_ATTEMPTS", 8),
+			RetryBase:    getDurationOrDefault("NOTIFY_RETRY_BASE", 30*time.Second),
+			RetryMax:     getDurationOrDefault("NOTIFY_RETRY_MAX", time.Hour),
+
+			CommentBatchWindow: getDurationOrDefault("NOTIFY_COMMENT_BATCH_WINDOW", 2*time.Minute),
+			PreferencesURL:     lookup("NOTIFY_PREFERENCES_URL"),
+		},
+		Slack: SlackConfig{
+			WebhookURL:     lookup("SLACK_WEBHOOK_URL"),
+			BotToken:       lookup("SLACK_BOT_TOKEN"),
+			DefaultChannel: getEnvOrDefault("SLACK_DEFAULT_CHANNEL", ""),
+			OrgChannels:    getMapOrDefault("SLACK_ORG_CHANNELS", nil),
+		},
+		Teams: TeamsConfig{
+			WebhookURL:  lookup("TEAMS_WEBHOOK_URL"),
+			OrgWebhooks: getMapOrDefault("TEAMS_ORG_WEBHOOKS", nil),
+		},
+		SMS: SMSConfig{
+			TwilioAccountSID:  lookup("TWILIO_ACCOUNT_SID"),
+			TwilioAuthToken:   lookup("TWILIO_AUTH_TOKEN"),
+			FromNumber:        lookup("SMS_FROM_NUMBER"),
+			MaxPerUserPerHour: getIntOrDefault("SMS_MAX_PER_USER_PER_HOUR", 5),
+			MaxPerMinute:      getIntOrDefault("SMS_MAX_PER_MINUTE", 30),
+		},
+		Webhooks: WebhookConfig{
+			Timeout:     getDurationOrDefault("WEBHOOK_TIMEOUT", 10*time.Second),
+			MaxAttempts: getIntOrDefault("WEBHOOK_MAX_ATTEMPTS", 10),
+		},
+		Analytics: AnalyticsConfig{
+			RollupInterval:     getDurationOrDefault("ANALYTICS_ROLLUP_INTERVAL", time.Hour),
+			RollupLookbackDays: getIntOrDefault("ANALYTICS_ROLLUP_LOOKBACK_DAYS", 7),
+		},
+		DataExports: DataExportConfig{
+			PollInterval: getDurationOrDefault("DATA_EXPORT_POLL_INTERVAL", 30*time.Second),
+			Retention:    getDurationOrDefault("DATA_EXPORT_RETENTION", 7*24*time.Hour),
+		},
+		Retention: RetentionConfig{
+			Interval: getDurationOrDefault("RETENTION_INTERVAL", 24*time.Hour),
+			DryRun:   getBoolOrDefault("RETENTION_DRY_RUN", false),
+		},
+		Quotas: QuotaConfig{
+			MaxUsers:       getIntOrDefault("QUOTA_MAX_USERS", 0),
+			MaxOpenTickets: getIntOrDefault("QUOTA_MAX_OPEN_TICKETS", 0),
+		},
+		Portal: PortalConfig{
+			Enabled:          getBoolOrDefault("PORTAL_ENABLED", false),
+			LinkURL:          lookup("PORTAL_LINK_URL"),
+			LinkTTL:          getDurationOrDefault("PORTAL_LINK_TTL", 30*24*time.Hour),
+			CaptchaSecret:    lookup("CAPTCHA_SECRET"),
+			CaptchaVerifyURL: lookup("CAPTCHA_VERIFY_URL"),
+		},
+		EmailChange: EmailChangeConfig{
+			ConfirmURL: lookup("EMAIL_CHANGE_CONFIRM_URL"),
+			LinkTTL:    getDurationOrDefault("EMAIL_CHANGE_LINK_TTL", 24*time.Hour),
+		},
+		Search: SearchConfig{
+			Backend:            getEnvOrDefault("SEARCH_BACKEND", "postgres"),
+			OpenSearchURL:      lookup("OPENSEARCH_URL"),
+			OpenSearchIndex:    getEnvOrDefault("OPENSEARCH_INDEX", "service-desk-tickets"),
+			OpenSearchUsername: lookup("OPENSEARCH_USERNAME"),
+			OpenSearchPassword: lookup("OPENSEARCH_PASSWORD"),
+			Timeout:            getDurationOrDefault("SEARCH_TIMEOUT", 10*time.Second),
+		},
+		Archive: ArchiveConfig{
+			AfterDays: getIntOrDefault("ARCHIVE_AFTER_DAYS", 0),
+			Interval:  getDurationOrDefault("ARCHIVE_INTERVAL", 24*time.Hour),
+			BatchSize: getIntOrDefault("ARCHIVE_BATCH_SIZE", 500),
+		},
+		Snooze: SnoozeConfig{
+			CheckInterval: getDurationOrDefault("SNOOZE_CHECK_INTERVAL", time.Minute),
+			BatchSize:     getIntOrDefault("SNOOZE_BATCH_SIZE", 100),
+		},
+		Activity: ActivityConfig{
+			Throttle:      getDurationOrDefault("ACTIVITY_THROTTLE", 5*time.Minute),
+			FlushInterval: getDurationOrDefault("ACTIVITY_FLUSH_INTERVAL", 30*time.Second),
+		},
+		FaultInjection: FaultInjectionConfig{
+			Enabled:   getBoolOrDefault("FAULT_INJECTION_ENABLED", false),
+			Latency:   getDurationMapOrDefault("FAULT_INJECTION_LATENCY", nil),
+			ErrorRate: getFloatMapOrDefault("FAULT_INJECTION_ERROR_RATE", nil),
+		},
 	}
-
-	if err := cfg.Validate(); err != nil {
-		return nil, err
-	}
-
-	return cfg, nil
 }
 
 // Validate validates the configuration
@@ -180,6 +564,209 @@ func (c *Config) Validate() error {
 		errs = append(errs, "DB_MAX_IDLE_CONNS cannot be greater than DB_MAX_OPEN_CONNS")
 	}
 
+	if c.Database.StatementTimeout < 0 {
+		errs = append(errs, "DB_STATEMENT_TIMEOUT cannot be negative")
+	}
+
+	if c.Database.CountTimeBudget < 0 {
+		errs = append(errs, "DB_COUNT_TIME_BUDGET cannot be negative")
+	}
+
+	if c.Database.SlowQueryThreshold < 0 {
+		errs = append(errs, "DB_SLOW_QUERY_THRESHOLD cannot be negative")
+	}
+
+	if c.Database.TxMaxRetries < 0 {
+		errs = append(errs, "DB_TX_MAX_RETRIES cannot be negative")
+	}
+
+	if c.Database.TxMaxRetries > 0 && (c.Database.TxRetryBackoff <= 0 || c.Database.TxRetryMaxBackoff < c.Database.TxRetryBackoff) {
+		errs = append(errs, "DB_TX_RETRY_BACKOFF must be positive and no greater than DB_TX_RETRY_MAX_BACKOFF")
+	}
+
+	if c.Database.ReadURL != "" && c.Database.ReadCheckInterval <= 0 {
+		errs = append(errs, "DATABASE_READ_CHECK_INTERVAL must be positive")
+	}
+
+	switch c.Logging.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, "LOG_LEVEL must be one of debug, info, warn, error")
+	}
+
+	if c.RateLimit.Enabled {
+		if c.RateLimit.RequestsPerSecond <= 0 || c.RateLimit.AuthRPS <= 0 {
+			errs = append(errs, "RATE_LIMIT_RPS and RATE_LIMIT_AUTH_RPS must be positive")
+		}
+		if c.RateLimit.BurstSize < 1 || c.RateLimit.AuthBurst < 1 {
+			errs = append(errs, "RATE_LIMIT_BURST and RATE_LIMIT_AUTH_BURST must be at least 1")
+		}
+		if c.Portal.Enabled && (c.RateLimit.PortalRPS <= 0 || c.RateLimit.PortalBurst < 1) {
+			errs = append(errs, "RATE_LIMIT_PORTAL_RPS must be positive and RATE_LIMIT_PORTAL_BURST at least 1")
+		}
+	}
+
+	if c.Notifications.PollInterval <= 0 {
+		errs = append(errs, "NOTIFY_POLL_INTERVAL must be positive")
+	}
+
+	if c.Notifications.BatchSize < 1 {
+		errs = append(errs, "NOTIFY_BATCH_SIZE must be at least 1")
+	}
+
+	if c.Notifications.MaxAttempts < 1 {
+		errs = append(errs, "NOTIFY_MAX_ATTEMPTS must be at least 1")
+	}
+
+	if c.Notifications.RetryBase > c.Notifications.RetryMax {
+		errs = append(errs, "NOTIFY_RETRY_BASE cannot be greater than NOTIFY_RETRY_MAX")
+	}
+
+	if c.Notifications.CommentBatchWindow < 0 {
+		errs = append(errs, "NOTIFY_COMMENT_BATCH_WINDOW cannot be negative")
+	}
+
+	if c.Notifications.PreferencesURL != "" {
+		if u, err := url.Parse(c.Notifications.PreferencesURL); err != nil || !u.IsAbs() {
+			errs = append(errs, "NOTIFY_PREFERENCES_URL must be an absolute URL")
+		}
+	}
+
+	if c.Webhooks.MaxAttempts < 1 {
+		errs = append(errs, "WEBHOOK_MAX_ATTEMPTS must be at least 1")
+	}
+
+	if c.Analytics.RollupInterval <= 0 {
+		errs = append(errs, "ANALYTICS_ROLLUP_INTERVAL must be positive")
+	}
+
+	if c.Analytics.RollupLookbackDays < 1 {
+		errs = append(errs, "ANALYTICS_ROLLUP_LOOKBACK_DAYS must be at least 1")
+	}
+
+	if c.DataExports.PollInterval <= 0 {
+		errs = append(errs, "DATA_EXPORT_POLL_INTERVAL must be positive")
+	}
+
+	if c.DataExports.Retention <= 0 {
+		errs = append(errs, "DATA_EXPORT_RETENTION must be positive")
+	}
+
+	if c.Server.DrainPeriod < 0 {
+		errs = append(errs, "SERVER_DRAIN_PERIOD cannot be negative")
+	}
+
+	if c.Compression.MinSize < 0 {
+		errs = append(errs, "COMPRESSION_MIN_SIZE cannot be negative")
+	}
+
+	if c.CircuitBreaker.Enabled && (c.CircuitBreaker.FailureThreshold <= 0 || c.CircuitBreaker.OpenTimeout <= 0) {
+		errs = append(errs, "CIRCUIT_BREAKER_FAILURE_THRESHOLD and CIRCUIT_BREAKER_OPEN_TIMEOUT must be positive")
+	}
+
+	if c.Cache.PermissionTTL < 0 {
+		errs = append(errs, "PERMISSION_CACHE_TTL cannot be negative")
+	}
+
+	if c.Cache.IdempotencyTTL <= 0 {
+		errs = append(errs, "IDEMPOTENCY_KEY_TTL must be positive")
+	}
+
+	if c.Retention.Interval <= 0 {
+		errs = append(errs, "RETENTION_INTERVAL must be positive")
+	}
+
+	if c.Quotas.MaxUsers < 0 {
+		errs = append(errs, "QUOTA_MAX_USERS cannot be negative")
+	}
+
+	if c.Quotas.MaxOpenTickets < 0 {
+		errs = append(errs, "QUOTA_MAX_OPEN_TICKETS cannot be negative")
+	}
+
+	if len(c.Slack.OrgChannels) > 0 && c.Slack.BotToken == "" {
+		errs = append(errs, "SLACK_BOT_TOKEN is required if SLACK_ORG_CHANNELS is set")
+	}
+
+	if c.SMS.Enabled() && c.SMS.FromNumber == "" {
+		errs = append(errs, "SMS_FROM_NUMBER is required if Twilio credentials are set")
+	}
+
+	if c.Portal.Enabled {
+		if u, err := url.Parse(c.Portal.LinkURL); err != nil || !u.IsAbs() {
+			errs = append(errs, "PORTAL_LINK_URL must be an absolute URL if PORTAL_ENABLED is set")
+		}
+		if c.Portal.CaptchaSecret == "" {
+			errs = append(errs, "CAPTCHA_SECRET is required if PORTAL_ENABLED is set")
+		}
+		if c.Portal.LinkTTL <= 0 {
+			errs = append(errs, "PORTAL_LINK_TTL must be positive")
+		}
+	}
+
+	if c.EmailChange.ConfirmURL != "" {
+		if u, err := url.Parse(c.EmailChange.ConfirmURL); err != nil || !u.IsAbs() {
+			errs = append(errs, "EMAIL_CHANGE_CONFIRM_URL must be an absolute URL")
+		}
+		if c.EmailChange.LinkTTL <= 0 {
+			errs = append(errs, "EMAIL_CHANGE_LINK_TTL must be positive")
+		}
+	}
+
+	switch c.Search.Backend {
+	case "postgres":
+	case "opensearch":
+		if u, err := url.Parse(c.Search.OpenSearchURL); err != nil || !u.IsAbs() {
+			errs = append(errs, "OPENSEARCH_URL must be an absolute URL if SEARCH_BACKEND is opensearch")
+		}
+		if c.Search.OpenSearchIndex == "" {
+			errs = append(errs, "OPENSEARCH_INDEX is required if SEARCH_BACKEND is opensearch")
+		}
+	default:
+		errs = append(errs, "SEARCH_BACKEND must be postgres or opensearch")
+	}
+
+	if c.Archive.AfterDays < 0 {
+		errs = append(errs, "ARCHIVE_AFTER_DAYS cannot be negative")
+	}
+
+	if c.Archive.AfterDays > 0 {
+		if c.Archive.Interval <= 0 {
+			errs = append(errs, "ARCHIVE_INTERVAL must be positive")
+		}
+		if c.Archive.BatchSize < 1 {
+			errs = append(errs, "ARCHIVE_BATCH_SIZE must be at least 1")
+		}
+	}
+
+	if c.Snooze.CheckInterval <= 0 {
+		errs = append(errs, "SNOOZE_CHECK_INTERVAL must be positive")
+	}
+	if c.Snooze.BatchSize < 1 {
+		errs = append(errs, "SNOOZE_BATCH_SIZE must be at least 1")
+	}
+
+	if c.Activity.Throttle < 0 {
+		errs = append(errs, "ACTIVITY_THROTTLE cannot be negative")
+	}
+	if c.Activity.FlushInterval <= 0 {
+		errs = append(errs, "ACTIVITY_FLUSH_INTERVAL must be positive")
+	}
+
+	if c.FaultInjection.Enabled && c.IsProduction() {
+		errs = append(errs, "FAULT_INJECTION_ENABLED cannot be set in production")
+	}
+	for prefix, latency := range c.FaultInjection.Latency {
+		if latency < 0 {
+			errs = append(errs, fmt.Sprintf("FAULT_INJECTION_LATENCY for %s cannot be negative", prefix))
+		}
+	}
+	for prefix, rate := range c.FaultInjection.ErrorRate {
+		if rate < 0 || rate > 1 {
+			errs = append(errs, fmt.Sprintf("FAULT_INJECTION_ERROR_RATE for %s must be between 0 and 1", prefix))
+		}
+	}
+
 	if len(errs) > 0 {
 		return errors.New("configuration errors:\n  - " + strings.Join(errs, "\n  - "))
 	}
@@ -200,14 +787,14 @@ func (c *Config) IsProduction() bool {
 // Helper functions
 
 func getEnvOrDefault(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
+	if value := lookup(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
 
 func getIntOrDefault(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
+	if value := lookup(key); value != "" {
 		if intValue, err := strconv.Atoi(value); err == nil {
 			return intValue
 		}
@@ -216,7 +803,7 @@ func getIntOrDefault(key string, defaultValue int) int {
 }
 
 func getFloatOrDefault(key string, defaultValue float64) float64 {
-	if value := os.Getenv(key); value != "" {
+	if value := lookup(key); value != "" {
 		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
 			return floatValue
 		}
@@ -225,7 +812,7 @@ func getFloatOrDefault(key string, defaultValue float64) float64 {
 }
 
 func getBoolOrDefault(key string, defaultValue bool) bool {
-	if value := os.Getenv(key); value != "" {
+	if value := lookup(key); value != "" {
 		if boolValue, err := strconv.ParseBool(value); err == nil {
 			return boolValue
 		}
@@ -234,7 +821,7 @@ func getBoolOrDefault(key string, defaultValue bool) bool {
 }
 
 func getDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
-	if value := os.Getenv(key); value != "" {
+	if value := lookup(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
 			return duration
 		}
@@ -242,6 +829,77 @@ func getDurationOrDefault(key string, defaultValue time.Duration) time.Duration
 	return defaultValue
 }
 
+// getListOrDefault parses a comma-separated list of values
+func getListOrDefault(key string, defaultValue []string) []string {
+	value := lookup(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	if len(result) == 0 {
+		return defaultValue
+	}
+	return result
+}
+
+// getMapOrDefault parses a comma-separated list of key=value pairs
+func getMapOrDefault(key string, defaultValue map[string]string) map[string]string {
+	value := lookup(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	result := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || k == "" || v == "" {
+			continue
+		}
+		result[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return result
+}
+
+// getDurationMapOrDefault parses a comma-separated list of key=duration
+// pairs, skipping values that are not durations
+func getDurationMapOrDefault(key string, defaultValue map[string]time.Duration) map[string]time.Duration {
+	pairs := getMapOrDefault(key, nil)
+	if pairs == nil {
+		return defaultValue
+	}
+
+	result := make(map[string]time.Duration, len(pairs))
+	for k, v := range pairs {
+		if duration, err := time.ParseDuration(v); err == nil {
+			result[k] = duration
+		}
+	}
+	return result
+}
+
+// getFloatMapOrDefault parses a comma-separated list of key=number pairs,
+// skipping values that are not numbers
+func getFloatMapOrDefault(key string, defaultValue map[string]float64) map[string]float64 {
+	pairs := getMapOrDefault(key, nil)
+	if pairs == nil {
+		return defaultValue
+	}
+
+	result := make(map[string]float64, len(pairs))
+	for k, v := range pairs {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			result[k] = f
+		}
+	}
+	return result
+}
+
 // String returns a redacted string representation of the config (safe for logging)
 func (c *Config) String() string {
 	return fmt.Sprintf(
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Settings from the config file named by CONFIG_FILE, keyed by the
+// environment variable they stand in for. They are only set while a
+// configuration is being built, under buildMu.
+var (
+	buildMu      sync.Mutex
+	fileSettings map[string]string
+	requested    map[string]bool
+)
+
+// mapSettings are the settings parsed as key=value lists, which the config
+// file may give as mappings.
+var mapSettings = map[string]bool{
+	"SLACK_ORG_CHANNELS": true,
+	"TEAMS_ORG_WEBHOOKS": true,
+
+	"FAULT_INJECTION_LATENCY":    true,
+	"FAULT_INJECTION_ERROR_RATE": true,
+}
+
+// lookup returns a setting from the environment, falling back to the config
+// file.
+func lookup(key string) string {
+	if requested != nil {
+		requested[key] = true
+	}
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fileSettings[key]
+}
+
+// build reads the config file, if CONFIG_FILE is set, and builds and
+// validates the configuration from it and the environment.
+func build() (*Config, error) {
+	buildMu.Lock()
+	defer buildMu.Unlock()
+
+	path := os.Getenv("CONFIG_FILE")
+	settings, err := readConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fileSettings, requested = settings, make(map[string]bool)
+	defer func() { fileSettings, requested = nil, nil }()
+
+	cfg := fromEnv()
+
+	var unknown []string
+	for key := range settings {
+		if !requested[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown settings in %s: %s", path, strings.Join(unknown, ", "))
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// readConfigFile reads a YAML config file into settings named after the
+// environment variables. Nested keys are joined with underscores, so
+// rate_limit: {rps: 10} sets RATE_LIMIT_RPS, and lists are joined with
+// commas.
+func readConfigFile(path string) (map[string]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+	default:
+		return nil, fmt.Errorf("unsupported config file format %q, use .yaml or .yml", filepath.Ext(path))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	settings := make(map[string]string)
+	if err := flattenSettings("", doc, settings); err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+	return settings, nil
+}
+
+func flattenSettings(prefix string, node map[string]any, settings map[string]string) error {
+	for name, value := range node {
+		key := strings.ToUpper(name)
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+
+		switch v := value.(type) {
+		case nil:
+			continue
+		case map[string]any:
+			if mapSettings[key] {
+				pairs := make([]string, 0, len(v))
+				for k, item := range v {
+					pairs = append(pairs, k+"="+fmt.Sprint(item))
+				}
+				sort.Strings(pairs)
+				if err := setSetting(settings, key, strings.Join(pairs, ",")); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := flattenSettings(key, v, settings); err != nil {
+				return err
+			}
+		case []any:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				if _, nested := item.(map[string]any); nested {
+					return fmt.Errorf("%s: list items must be plain values", key)
+				}
+				items = append(items, fmt.Sprint(item))
+			}
+			if err := setSetting(settings, key, strings.Join(items, ",")); err != nil {
+				return err
+			}
+		default:
+			if err := setSetting(settings, key, fmt.Sprint(v)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func setSetting(settings map[string]string, key, value string) error {
+	if _, exists := settings[key]; exists {
+		return fmt.Errorf("%s is set more than once", key)
+	}
+	settings[key] = value
+	return nil
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"errors"
+	"io/fs"
+	"sync"
+	"sync/atomic"
+
+	"github.com/joho/godotenv"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// Runtime holds the snapshot of the configuration that can change while the
+// service is running. Middlewares read the current snapshot on every request;
+// components that cache settings register a callback with OnReload.
+type Runtime struct {
+	current   atomic.Pointer[domain.RuntimeConfig]
+	mu        sync.Mutex // serializes reloads
+	listeners []func(*domain.RuntimeConfig)
+}
+
+var _ ports.ConfigReloader = (*Runtime)(nil)
+
+// NewRuntime creates a Runtime seeded from the configuration loaded at
+// startup.
+func NewRuntime(cfg *Config) *Runtime {
+	rt := &Runtime{}
+	rt.current.Store(cfg.RuntimeConfig())
+	return rt
+}
+
+// RuntimeConfig returns the reloadable part of the configuration.
+func (c *Config) RuntimeConfig() *domain.RuntimeConfig {
+	return &domain.RuntimeConfig{
+		LogLevel: c.Logging.Level,
+		RateLimit: domain.RuntimeRateLimit{
+			RequestsPerSecond:     c.RateLimit.RequestsPerSecond,
+			Burst:                 c.RateLimit.BurstSize,
+			AuthRequestsPerSecond: c.RateLimit.AuthRPS,
+			AuthBurst:             c.RateLimit.AuthBurst,
+		},
+		CORSOrigins: append([]string(nil), c.CORS.AllowedOrigins...),
+	}
+}
+
+// Current returns the snapshot in effect.
+func (rt *Runtime) Current() *domain.RuntimeConfig {
+	return rt.current.Load()
+}
+
+// OnReload registers fn to be called with every new snapshot. Callbacks run
+// synchronously in the order they were registered.
+func (rt *Runtime) OnReload(fn func(*domain.RuntimeConfig)) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rt.listeners = append(rt.listeners, fn)
+}
+
+// Reload re-reads the .env file, overriding the values loaded at startup,
+// the config file and the environment. The whole configuration is
+// validated, and the current snapshot is kept if it is invalid. Settings
+// outside the runtime subset are ignored until the next restart.
+func (rt *Runtime) Reload() (*domain.RuntimeConfig, error) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if err := godotenv.Overload(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	cfg, err := build()
+	if err != nil {
+		return nil, err
+	}
+
+	next := cfg.RuntimeConfig()
+	rt.current.Store(next)
+	for _, fn := range rt.listeners {
+		fn(next)
+	}
+	return next, nil
+}
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+// MaxAgentOpenTicketsLimit is the highest per-agent open ticket limit that
+// can be configured
+const MaxAgentOpenTicketsLimit = 1000
+
+// CapacityEnforcement decides what happens when a ticket is assigned to an
+// agent who has reached the organization's open ticket limit.
+type CapacityEnforcement string
+
+const (
+	// CapacityWarn assigns the ticket anyway and warns whoever assigned it
+	CapacityWarn CapacityEnforcement = "WARN"
+	// CapacityBlock refuses the assignment
+	CapacityBlock CapacityEnforcement = "BLOCK"
+)
+
+// IsValid checks if the enforcement is one of the defined values
+func (e CapacityEnforcement) IsValid() bool {
+	return e == CapacityWarn || e == CapacityBlock
+}
+
+// AgentCapacity limits how many open tickets an organization's agents
+// should have at once.
+type AgentCapacity struct {
+	OrganizationID uuid.UUID
+	MaxOpenTickets *int // nil sets no limit
+	Enforcement    CapacityEnforcement
+}
+
+// DefaultAgentCapacity returns the capacity of organizations that have not
+// set a limit.
+func DefaultAgentCapacity(orgID uuid.UUID) *AgentCapacity {
+	return &AgentCapacity{OrganizationID: orgID, Enforcement: CapacityWarn}
+}
+
+// Validate validates the limit
+func (c *AgentCapacity) Validate() error {
+	errs := apperrors.NewValidationErrors()
+
+	if c.MaxOpenTickets != nil && (*c.MaxOpenTickets < 1 || *c.MaxOpenTickets > MaxAgentOpenTicketsLimit) {
+		errs.Add("maxOpenTickets", "Limit must be between 1 and 1000 open tickets")
+	}
+	if !c.Enforcement.IsValid() {
+		errs.Add("enforcement", "Enforcement must be WARN or BLOCK")
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Limited reports whether agents have an open ticket limit
+func (c *AgentCapacity) Limited() bool {
+	return c.MaxOpenTickets != nil
+}
+
+// Full reports whether an agent with openTickets open tickets has reached
+// the limit, so that one more would go over it.
+func (c *AgentCapacity) Full(openTickets int64) bool {
+	return c.Limited() && openTickets >= int64(*c.MaxOpenTickets)
+}
+
+// AuditSnapshot returns the capacity in the shape recorded in the audit log.
+func (c *AgentCapacity) AuditSnapshot() map[string]any {
+	return map[string]any{
+		"maxOpenTickets": c.MaxOpenTickets,
+		"enforcement":    c.Enforcement,
+	}
+}
+
+// CapacityWarning tells whoever assigned a ticket that the assignee already
+// had as many open tickets as the organization's limit, under
+// CapacityWarn.
+type CapacityWarning struct {
+	AssigneeID     uuid.UUID
+	OpenTickets    int64
+	MaxOpenTickets int
+}
+
+// Assignee is a user tickets can be assigned to, with how many open tickets
+// they have and the organization's limit, if any.
+type Assignee struct {
+	User           *User
+	OpenTickets    int64
+	MaxOpenTickets *int
+}
+
+// NewAssignees pairs users with their open tickets, counted in workload,
+// and the organization's limit.
+func NewAssignees(users []*User, workload []WorkloadItem, capacity *AgentCapacity) []Assignee {
+	open := make(map[uuid.UUID]int64, len(workload))
+	for _, item := range workload {
+		if item.AssigneeID != nil {
+			open[*item.AssigneeID] = item.Count
+		}
+	}
+
+	assignees := make([]Assignee, 0, len(users))
+	for _, user := range users {
+		assignees = append(assignees, Assignee{
+			User:           user,
+			OpenTickets:    open[user.ID],
+			MaxOpenTickets: capacity.MaxOpenTickets,
+		})
+	}
+	return assignees
+}
+
+// AtCapacity reports whether the assignee has reached the limit.
+func (a Assignee) AtCapacity() bool {
+	return a.MaxOpenTickets != nil && a.OpenTickets >= int64(*a.MaxOpenTickets)
+}
+
+// AssigneeFilter narrows down the users tickets can be assigned to. Query
+// matches names and emails and TeamID keeps the members of a team. When
+// set, Available keeps the agents below the open ticket limit, or with
+// false those at it.
+type AssigneeFilter struct {
+	Query     string
+	TeamID    *uuid.UUID
+	Available *bool
+	Limit     int
+	Offset    int
+}
+
+// Page applies the availability filter to assignees and returns the page
+// of them selected by Limit and Offset, with how many matched in total.
+func (f AssigneeFilter) Page(assignees []Assignee) ([]Assignee, Total) {
+	matches := assignees
+	if f.Available != nil {
+		matches = make([]Assignee, 0, len(assignees))
+		for _, assignee := range assignees {
+			if assignee.AtCapacity() != *f.Available {
+				matches = append(matches, assignee)
+			}
+		}
+	}
+
+	total := Total{Count: int64(len(matches))}
+	start := min(f.Offset, len(matches))
+	end := len(matches)
+	if f.Limit > 0 {
+		end = min(start+f.Limit, end)
+	}
+	return matches[start:end], total
+}
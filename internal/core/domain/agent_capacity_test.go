@@ -0,0 +1,72 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAgentCapacity(t *testing.T) {
+	limit := func(n int) *int { return &n }
+
+	t.Run("validates the limit and enforcement", func(t *testing.T) {
+		assert.NoError(t, (&domain.AgentCapacity{Enforcement: domain.CapacityWarn}).Validate())
+		assert.NoError(t, (&domain.AgentCapacity{MaxOpenTickets: limit(10), Enforcement: domain.CapacityBlock}).Validate())
+		assert.Error(t, (&domain.AgentCapacity{MaxOpenTickets: limit(0), Enforcement: domain.CapacityWarn}).Validate())
+		assert.Error(t, (&domain.AgentCapacity{MaxOpenTickets: limit(10), Enforcement: "IGNORE"}).Validate())
+	})
+
+	t.Run("an agent at the limit is full", func(t *testing.T) {
+		capacity := &domain.AgentCapacity{MaxOpenTickets: limit(2), Enforcement: domain.CapacityBlock}
+
+		assert.False(t, capacity.Full(1))
+		assert.True(t, capacity.Full(2))
+		assert.False(t, domain.DefaultAgentCapacity(uuid.New()).Full(1000))
+	})
+
+	t.Run("assignees carry their open tickets and the limit", func(t *testing.T) {
+		alice := &domain.User{ID: uuid.New()}
+		bob := &domain.User{ID: uuid.New()}
+		workload := []domain.WorkloadItem{{AssigneeID: &alice.ID, Count: 3}, {Count: 7}}
+		capacity := &domain.AgentCapacity{MaxOpenTickets: limit(3), Enforcement: domain.CapacityWarn}
+
+		assignees := domain.NewAssignees([]*domain.User{alice, bob}, workload, capacity)
+
+		assert.Equal(t, int64(3), assignees[0].OpenTickets)
+		assert.True(t, assignees[0].AtCapacity())
+		assert.Equal(t, int64(0), assignees[1].OpenTickets)
+		assert.False(t, assignees[1].AtCapacity())
+	})
+}
+
+func TestAssigneeFilter_Page(t *testing.T) {
+	limit := 2
+	assignees := make([]domain.Assignee, 0, 5)
+	for open := range int64(5) {
+		assignees = append(assignees, domain.Assignee{User: &domain.User{ID: uuid.New()}, OpenTickets: open, MaxOpenTickets: &limit})
+	}
+	available, full := true, false
+
+	t.Run("pages through every assignee", func(t *testing.T) {
+		page, total := domain.AssigneeFilter{Limit: 2, Offset: 2}.Page(assignees)
+
+		assert.Equal(t, int64(5), total.Count)
+		assert.Equal(t, assignees[2:4], page)
+	})
+
+	t.Run("keeps the assignees below the limit", func(t *testing.T) {
+		page, total := domain.AssigneeFilter{Available: &available, Limit: 25}.Page(assignees)
+
+		assert.Equal(t, int64(2), total.Count)
+		assert.Equal(t, assignees[:2], page)
+	})
+
+	t.Run("keeps the assignees at the limit", func(t *testing.T) {
+		page, total := domain.AssigneeFilter{Available: &full, Limit: 25, Offset: 10}.Page(assignees)
+
+		assert.Equal(t, int64(3), total.Count)
+		assert.Empty(t, page)
+	})
+}
@@ -1,11 +1,65 @@
 package domain
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
 )
 
+const (
+	// DefaultAnalyticsDays is how many days the analytics cover by default
+	DefaultAnalyticsDays = 30
+	// MaxAnalyticsRangeDays caps how many days an analytics range can span
+	MaxAnalyticsRangeDays = 366
+)
+
+// AnalyticsRange is the inclusive span of UTC dates that time-based
+// analytics (volume, MTTR and first response) cover.
+type AnalyticsRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// NewAnalyticsRange creates a range from one date to another, inclusive.
+// Times are truncated to their UTC date.
+func NewAnalyticsRange(from, to time.Time) (AnalyticsRange, error) {
+	r := AnalyticsRange{From: utcDate(from), To: utcDate(to)}
+
+	errs := apperrors.NewValidationErrors()
+	if r.To.Before(r.From) {
+		errs.Add("from", "Start date must not be after the end date")
+	} else if r.Days() > MaxAnalyticsRangeDays {
+		errs.Add("to", fmt.Sprintf("Date range cannot span more than %d days", MaxAnalyticsRangeDays))
+	}
+	if errs.HasErrors() {
+		return AnalyticsRange{}, errs
+	}
+
+	return r, nil
+}
+
+// LastDaysRange returns the range of the given number of days ending on the
+// date of now.
+func LastDaysRange(now time.Time, days int) (AnalyticsRange, error) {
+	if days <= 0 {
+		days = DefaultAnalyticsDays
+	}
+	to := utcDate(now)
+	return NewAnalyticsRange(to.AddDate(0, 0, -(days-1)), to)
+}
+
+// Days returns how many days the range spans.
+func (r AnalyticsRange) Days() int {
+	return int(r.To.Sub(r.From).Hours()/24) + 1
+}
+
+func utcDate(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
 type StatusCount struct {
 	Status TicketStatus
 	Count  int64
@@ -24,9 +78,42 @@ type VolumePoint struct {
 	ResolvedCount int64
 }
 
+// PriorityVolume is how many tickets of one priority were created and
+// resolved over the analytics range.
+type PriorityVolume struct {
+	Priority      TicketPriority
+	CreatedCount  int64
+	ResolvedCount int64
+}
+
+// ResponseTimeStats summarizes how long tickets waited for a first response.
+type ResponseTimeStats struct {
+	Count        int64
+	AverageHours float64
+	MedianHours  float64
+	P90Hours     float64
+}
+
+// AgentResponseTime is an agent's first-response times on the tickets they
+// replied to first.
+type AgentResponseTime struct {
+	AgentID  uuid.UUID
+	FullName string
+	Email    string
+	ResponseTimeStats
+}
+
+// AnalyticsOverview summarizes an organization's tickets. Status counts and
+// workload, per agent and per team, describe the current state; the other
+// figures cover Range. MTTRHours counts business hours only.
 type AnalyticsOverview struct {
-	StatusCounts []StatusCount
-	Workload     []WorkloadItem
-	Volume       []VolumePoint
-	MTTRHours    float64
+	Range              AnalyticsRange
+	StatusCounts       []StatusCount
+	Workload           []WorkloadItem
+	TeamWorkload       []TeamWorkload
+	Volume             []VolumePoint
+	VolumeByPriority   []PriorityVolume
+	MTTRHours          float64
+	FirstResponse      ResponseTimeStats
+	AgentFirstResponse []AgentResponseTime
 }
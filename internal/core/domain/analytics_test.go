@@ -0,0 +1,65 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAnalyticsRange(t *testing.T) {
+	t.Run("truncates to UTC dates", func(t *testing.T) {
+		from := time.Date(2026, 3, 1, 23, 30, 0, 0, time.FixedZone("UTC-2", -2*60*60))
+		to := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+
+		rng, err := domain.NewAnalyticsRange(from, to)
+
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), rng.From)
+		assert.Equal(t, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), rng.To)
+		assert.Equal(t, 9, rng.Days())
+	})
+
+	t.Run("a single day is allowed", func(t *testing.T) {
+		day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+		rng, err := domain.NewAnalyticsRange(day, day)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, rng.Days())
+	})
+
+	t.Run("rejects a start after the end", func(t *testing.T) {
+		_, err := domain.NewAnalyticsRange(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+
+		var valErrs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &valErrs)
+		assert.Contains(t, valErrs.Errors, "from")
+	})
+
+	t.Run("rejects ranges longer than the maximum", func(t *testing.T) {
+		from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		_, err := domain.NewAnalyticsRange(from, from.AddDate(0, 0, domain.MaxAnalyticsRangeDays))
+
+		var valErrs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &valErrs)
+		assert.Contains(t, valErrs.Errors, "to")
+	})
+}
+
+func TestLastDaysRange(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+
+	rng, err := domain.LastDaysRange(now, 7)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), rng.From)
+	assert.Equal(t, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), rng.To)
+
+	rng, err = domain.LastDaysRange(now, 0)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DefaultAnalyticsDays, rng.Days())
+}
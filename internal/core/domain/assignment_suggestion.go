@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// AssignmentHistoryDays is how far back the tickets agents worked on
+	// are looked at when suggesting assignees
+	AssignmentHistoryDays = 180
+	// MaxAssignmentSuggestions is how many agents are suggested at most
+	MaxAssignmentSuggestions = 5
+)
+
+// SimilarTicketsQuery selects an organization's tickets that resemble a
+// ticket: those created since Since that share one of its tags or are in
+// its team. The ticket itself is left out.
+type SimilarTicketsQuery struct {
+	TicketID int64
+	Tags     []string
+	TeamID   *uuid.UUID
+	Since    time.Time
+}
+
+// NewSimilarTicketsQuery selects the tickets resembling ticket over the
+// last AssignmentHistoryDays before now.
+func NewSimilarTicketsQuery(ticket *Ticket, now time.Time) SimilarTicketsQuery {
+	return SimilarTicketsQuery{
+		TicketID: ticket.ID,
+		Tags:     ticket.Tags,
+		TeamID:   ticket.TeamID,
+		Since:    now.AddDate(0, 0, -AssignmentHistoryDays),
+	}
+}
+
+// AgentTicketHistory counts the similar tickets an agent was assigned.
+type AgentTicketHistory struct {
+	AgentID          uuid.UUID
+	SharedTagTickets int64
+	SameTeamTickets  int64
+}
+
+// AssignmentSuggestion is an agent suggested for a ticket, with what the
+// suggestion is based on. Agents with a higher Score are a better fit.
+type AssignmentSuggestion struct {
+	Assignee         Assignee
+	SharedTagTickets int64
+	SameTeamTickets  int64
+	Score            float64
+}
+
+// RankAssignmentSuggestions orders candidates by how well they fit a
+// ticket: experience with similar tickets, where a shared tag counts twice
+// as much as the same team, divided among the tickets they have open.
+// Candidates without history still rank by workload. The ticket's current
+// assignee, if any, is left out, and at most limit candidates are returned.
+func RankAssignmentSuggestions(candidates []Assignee, history []AgentTicketHistory, currentAssignee *uuid.UUID, limit int) []AssignmentSuggestion {
+	byAgent := make(map[uuid.UUID]AgentTicketHistory, len(history))
+	for _, h := range history {
+		byAgent[h.AgentID] = h
+	}
+
+	suggestions := make([]AssignmentSuggestion, 0, len(candidates))
+	for _, candidate := range candidates {
+		if currentAssignee != nil && candidate.User.ID == *currentAssignee {
+			continue
+		}
+		h := byAgent[candidate.User.ID]
+		experience := float64(2*h.SharedTagTickets + h.SameTeamTickets)
+		suggestions = append(suggestions, AssignmentSuggestion{
+			Assignee:         candidate,
+			SharedTagTickets: h.SharedTagTickets,
+			SameTeamTickets:  h.SameTeamTickets,
+			Score:            (experience + 1) / float64(candidate.OpenTickets+1),
+		})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Assignee.OpenTickets != b.Assignee.OpenTickets {
+			return a.Assignee.OpenTickets < b.Assignee.OpenTickets
+		}
+		return a.Assignee.User.FullName < b.Assignee.User.FullName
+	})
+	if limit > 0 && len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
@@ -0,0 +1,56 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankAssignmentSuggestions(t *testing.T) {
+	alice := &domain.User{ID: uuid.New(), FullName: "Alice"}
+	bob := &domain.User{ID: uuid.New(), FullName: "Bob"}
+	carol := &domain.User{ID: uuid.New(), FullName: "Carol"}
+	ids := func(suggestions []domain.AssignmentSuggestion) []uuid.UUID {
+		out := make([]uuid.UUID, 0, len(suggestions))
+		for _, s := range suggestions {
+			out = append(out, s.Assignee.User.ID)
+		}
+		return out
+	}
+
+	t.Run("experience is weighed against open tickets", func(t *testing.T) {
+		candidates := []domain.Assignee{{User: alice, OpenTickets: 1}, {User: bob, OpenTickets: 3}, {User: carol}}
+		history := []domain.AgentTicketHistory{
+			{AgentID: alice.ID, SharedTagTickets: 3},
+			{AgentID: bob.ID, SameTeamTickets: 1},
+		}
+
+		suggestions := domain.RankAssignmentSuggestions(candidates, history, nil, 0)
+
+		require.Len(t, suggestions, 3)
+		assert.Equal(t, []uuid.UUID{alice.ID, carol.ID, bob.ID}, ids(suggestions))
+		assert.InDelta(t, 3.5, suggestions[0].Score, 0.001)
+		assert.InDelta(t, 1.0, suggestions[1].Score, 0.001)
+		assert.InDelta(t, 0.5, suggestions[2].Score, 0.001)
+	})
+
+	t.Run("ties go to the agent with fewer open tickets, then by name", func(t *testing.T) {
+		candidates := []domain.Assignee{{User: carol, OpenTickets: 2}, {User: bob}, {User: alice}}
+		history := []domain.AgentTicketHistory{{AgentID: carol.ID, SharedTagTickets: 1}}
+
+		suggestions := domain.RankAssignmentSuggestions(candidates, history, nil, 0)
+
+		assert.Equal(t, []uuid.UUID{alice.ID, bob.ID, carol.ID}, ids(suggestions))
+	})
+
+	t.Run("leaves out the current assignee and caps the list", func(t *testing.T) {
+		candidates := []domain.Assignee{{User: alice}, {User: bob}, {User: carol}}
+
+		suggestions := domain.RankAssignmentSuggestions(candidates, nil, &alice.ID, 1)
+
+		assert.Equal(t, []uuid.UUID{bob.ID}, ids(suggestions))
+	})
+}
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditAction identifies the kind of admin change an audit entry records.
+type AuditAction string
+
+const (
+	AuditUserRoleChanged            AuditAction = "user.role_changed"
+	AuditUserStatusChanged          AuditAction = "user.status_changed"
+	AuditUserPasswordReset          AuditAction = "user.password_reset"
+	AuditUserForcedLogout           AuditAction = "user.forced_logout"
+	AuditUserAnonymized             AuditAction = "user.anonymized"
+	AuditUserEmailChanged           AuditAction = "user.email_changed"
+	AuditUserDataExported           AuditAction = "user.data_exported"
+	AuditUserImported               AuditAction = "user.imported"
+	AuditUserOffboarded             AuditAction = "user.offboarded"
+	AuditBusinessHoursUpdated       AuditAction = "org.business_hours_updated"
+	AuditRetentionPolicyUpdated     AuditAction = "org.retention_policy_updated"
+	AuditRegistrationDomainsUpdated AuditAction = "org.registration_domains_updated"
+	AuditPortalSlugUpdated          AuditAction = "org.portal_slug_updated"
+	AuditAgentCapacityUpdated       AuditAction = "org.agent_capacity_updated"
+	AuditTeamCreated                AuditAction = "team.created"
+	AuditTeamRenamed                AuditAction = "team.renamed"
+	AuditTeamDeleted                AuditAction = "team.deleted"
+	AuditTeamMemberAdded            AuditAction = "team.member_added"
+	AuditTeamMemberRemoved          AuditAction = "team.member_removed"
+	AuditEscalationRuleCreated      AuditAction = "escalation_rule.created"
+	AuditEscalationRuleUpdated      AuditAction = "escalation_rule.updated"
+	AuditEscalationRuleDeleted      AuditAction = "escalation_rule.deleted"
+	AuditMacroCreated               AuditAction = "macro.created"
+	AuditMacroUpdated               AuditAction = "macro.updated"
+	AuditMacroDeleted               AuditAction = "macro.deleted"
+	AuditWebhookCreated             AuditAction = "webhook.created"
+	AuditWebhookDeleted             AuditAction = "webhook.deleted"
+	AuditInboundHookCreated         AuditAction = "inbound_hook.created"
+	AuditInboundHookDeleted         AuditAction = "inbound_hook.deleted"
+	AuditRateLimitOverrideSet       AuditAction = "rate_limit.override_set"
+	AuditRateLimitOverrideDeleted   AuditAction = "rate_limit.override_deleted"
+	AuditRateLimitCleared           AuditAction = "rate_limit.cleared"
+	AuditConfigReloaded             AuditAction = "config.reloaded"
+	AuditEventsReplayed             AuditAction = "events.replayed"
+	AuditOrganizationsListed        AuditAction = "platform.organizations_listed"
+	AuditOrganizationViewed         AuditAction = "platform.organization_viewed"
+	AuditUserImpersonated           AuditAction = "platform.user_impersonated"
+)
+
+// Audit target types
+const (
+	AuditTargetUser           = "user"
+	AuditTargetOrganization   = "organization"
+	AuditTargetTeam           = "team"
+	AuditTargetEscalationRule = "escalation_rule"
+	AuditTargetMacro          = "macro"
+	AuditTargetWebhook        = "webhook"
+	AuditTargetInboundHook    = "inbound_hook"
+	AuditTargetRateLimit      = "rate_limit"
+	AuditTargetConfig         = "config"
+)
+
+// AuditEntry records an admin change: who made it, what it was made to and
+// the relevant values before and after. ImpersonatorID is set when the
+// actor was a super-admin signed in as someone else.
+type AuditEntry struct {
+	ID             int64
+	OrganizationID uuid.UUID
+	ActorID        uuid.UUID
+	ImpersonatorID *uuid.UUID
+	Action         AuditAction
+	TargetType     string
+	TargetID       string
+	Before         json.RawMessage
+	After          json.RawMessage
+	RequestID      string
+	CreatedAt      time.Time
+}
+
+// NewAuditEntry creates an audit entry for the request in ctx. Before and
+// after are stored as JSON; nil values are left empty.
+func NewAuditEntry(ctx context.Context, orgID, actorID uuid.UUID, action AuditAction, targetType, targetID string, before, after any) (*AuditEntry, error) {
+	entry := &AuditEntry{
+		OrganizationID: orgID,
+		ActorID:        actorID,
+		Action:         action,
+		TargetType:     targetType,
+		TargetID:       targetID,
+		ImpersonatorID: ImpersonatorFromContext(ctx),
+		RequestID:      RequestIDFromContext(ctx),
+	}
+
+	var err error
+	if before != nil {
+		if entry.Before, err = json.Marshal(before); err != nil {
+			return nil, err
+		}
+	}
+	if after != nil {
+		if entry.After, err = json.Marshal(after); err != nil {
+			return nil, err
+		}
+	}
+
+	return entry, nil
+}
+
+// AuditLogFilter narrows an audit log listing. Nil fields match everything.
+type AuditLogFilter struct {
+	Action   *AuditAction
+	ActorID  *uuid.UUID
+	TargetID *string
+	From     *time.Time
+	To       *time.Time
+	Limit    int
+	Offset   int
+}
+
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the ID of the request being
+// served, so changes can be traced back to it.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored by WithRequestID, or "".
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+type impersonatorKey struct{}
+
+// WithImpersonator returns a copy of ctx recording that the request is made
+// by the super-admin impersonatorID signed in as another user.
+func WithImpersonator(ctx context.Context, impersonatorID uuid.UUID) context.Context {
+	return context.WithValue(ctx, impersonatorKey{}, impersonatorID)
+}
+
+// ImpersonatorFromContext returns the super-admin stored by
+// WithImpersonator, or nil.
+func ImpersonatorFromContext(ctx context.Context) *uuid.UUID {
+	impersonatorID, ok := ctx.Value(impersonatorKey{}).(uuid.UUID)
+	if !ok {
+		return nil
+	}
+	return &impersonatorID
+}
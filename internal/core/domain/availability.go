@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+const (
+	// MaxOutOfOfficeWindows caps how many upcoming absences a user can schedule
+	MaxOutOfOfficeWindows = 50
+	// MaxOutOfOfficeNoteLength is the maximum length of an absence's note
+	MaxOutOfOfficeNoteLength = 200
+)
+
+// OutOfOfficeWindow is a period during which an agent is away.
+type OutOfOfficeWindow struct {
+	StartsAt time.Time
+	EndsAt   time.Time
+	Note     string
+}
+
+// Covers reports whether t falls within the window.
+func (w OutOfOfficeWindow) Covers(t time.Time) bool {
+	return !t.Before(w.StartsAt) && t.Before(w.EndsAt)
+}
+
+// Availability is when an agent is out of office. Away agents are not
+// offered for assignment; with ReassignWhenAway set, their open tickets are
+// flagged for reassignment while they are away.
+type Availability struct {
+	UserID           uuid.UUID
+	Windows          []OutOfOfficeWindow
+	ReassignWhenAway bool
+}
+
+// Validate validates the windows and normalizes them: windows that ended
+// before now are dropped and the rest are sorted by start.
+func (a *Availability) Validate(now time.Time) error {
+	errs := apperrors.NewValidationErrors()
+
+	windows := make([]OutOfOfficeWindow, 0, len(a.Windows))
+	for i, w := range a.Windows {
+		if !w.StartsAt.Before(w.EndsAt) {
+			errs.Add("windows", fmt.Sprintf("Window %d must end after it starts", i+1))
+			continue
+		}
+		if len(w.Note) > MaxOutOfOfficeNoteLength {
+			errs.Add("windows", fmt.Sprintf("Window %d note must be %d characters or less", i+1, MaxOutOfOfficeNoteLength))
+		}
+		if w.EndsAt.After(now) {
+			windows = append(windows, w)
+		}
+	}
+	if len(windows) > MaxOutOfOfficeWindows {
+		errs.Add("windows", fmt.Sprintf("At most %d upcoming windows are allowed", MaxOutOfOfficeWindows))
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+
+	sort.Slice(windows, func(i, j int) bool { return windows[i].StartsAt.Before(windows[j].StartsAt) })
+	a.Windows = windows
+	return nil
+}
+
+// AwayAt reports whether any window covers t.
+func (a *Availability) AwayAt(t time.Time) bool {
+	for _, w := range a.Windows {
+		if w.Covers(t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvailability_Validate(t *testing.T) {
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("drops ended windows and sorts the rest", func(t *testing.T) {
+		availability := domain.Availability{Windows: []domain.OutOfOfficeWindow{
+			{StartsAt: now.AddDate(0, 0, 10), EndsAt: now.AddDate(0, 0, 12)},
+			{StartsAt: now.AddDate(0, 0, -5), EndsAt: now.AddDate(0, 0, -1)},
+			{StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+		}}
+
+		require.NoError(t, availability.Validate(now))
+
+		require.Len(t, availability.Windows, 2)
+		assert.Equal(t, now.Add(-time.Hour), availability.Windows[0].StartsAt)
+		assert.Equal(t, now.AddDate(0, 0, 10), availability.Windows[1].StartsAt)
+	})
+
+	t.Run("rejects invalid windows", func(t *testing.T) {
+		availability := domain.Availability{Windows: []domain.OutOfOfficeWindow{
+			{StartsAt: now.Add(time.Hour), EndsAt: now.Add(time.Hour)},
+			{StartsAt: now, EndsAt: now.Add(time.Hour), Note: strings.Repeat("x", domain.MaxOutOfOfficeNoteLength+1)},
+		}}
+
+		err := availability.Validate(now)
+
+		var errs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Len(t, errs.Errors["windows"], 2)
+	})
+}
+
+func TestAvailability_AwayAt(t *testing.T) {
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	availability := domain.Availability{Windows: []domain.OutOfOfficeWindow{
+		{StartsAt: start, EndsAt: start.AddDate(0, 0, 7)},
+	}}
+
+	assert.False(t, availability.AwayAt(start.Add(-time.Second)))
+	assert.True(t, availability.AwayAt(start))
+	assert.True(t, availability.AwayAt(start.AddDate(0, 0, 3)))
+	assert.False(t, availability.AwayAt(start.AddDate(0, 0, 7)))
+}
@@ -0,0 +1,273 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+const (
+	// MaxHolidays caps how many holidays an organization can configure
+	MaxHolidays = 366
+	// MaxHolidayNameLength is the maximum length of a holiday's name
+	MaxHolidayNameLength = 100
+	// HolidayDateLayout is the format of holiday dates, e.g. 2026-12-25
+	HolidayDateLayout = "2006-01-02"
+	// maxCalendarScanDays bounds how far ahead working time is searched for,
+	// so a calendar that is all holidays cannot loop forever.
+	maxCalendarScanDays = 5 * 366
+)
+
+// ClockTime is a time of day in minutes after midnight, from 0 to 24:00.
+type ClockTime int
+
+// ParseClockTime parses a 24-hour "HH:MM" time of day. "24:00" is accepted
+// as the end of the day.
+func ParseClockTime(s string) (ClockTime, error) {
+	if len(s) != 5 || s[2] != ':' {
+		return 0, fmt.Errorf("invalid time of day %q", s)
+	}
+	hours, hoursErr := strconv.Atoi(s[:2])
+	minutes, minutesErr := strconv.Atoi(s[3:])
+	if hoursErr != nil || minutesErr != nil ||
+		hours < 0 || minutes < 0 || minutes > 59 || hours > 24 || (hours == 24 && minutes != 0) {
+		return 0, fmt.Errorf("invalid time of day %q", s)
+	}
+	return ClockTime(hours*60 + minutes), nil
+}
+
+// String formats the time of day as "HH:MM"
+func (c ClockTime) String() string {
+	return fmt.Sprintf("%02d:%02d", int(c)/60, int(c)%60)
+}
+
+// WorkingDay is the window of working time on one day of the week.
+type WorkingDay struct {
+	Weekday time.Weekday
+	Open    ClockTime
+	Close   ClockTime
+}
+
+// Holiday is a date on which no working time accrues.
+type Holiday struct {
+	Date string `json:"date"` // HolidayDateLayout in the organization's timezone
+	Name string `json:"name"`
+}
+
+// BusinessHours is an organization's working week and holiday calendar.
+// SLA timers only accrue during its working time.
+type BusinessHours struct {
+	OrganizationID uuid.UUID
+	Timezone       string
+	Days           []WorkingDay
+	Holidays       []Holiday
+	UpdatedAt      time.Time
+
+	location *time.Location
+}
+
+// DefaultBusinessHours returns the calendar used by organizations that have
+// not configured one: Monday to Friday, 09:00 to 17:00 UTC, with no holidays.
+func DefaultBusinessHours(orgID uuid.UUID) *BusinessHours {
+	days := make([]WorkingDay, 0, 5)
+	for d := time.Monday; d <= time.Friday; d++ {
+		days = append(days, WorkingDay{Weekday: d, Open: 9 * 60, Close: 17 * 60})
+	}
+
+	return &BusinessHours{
+		OrganizationID: orgID,
+		Timezone:       "UTC",
+		Days:           days,
+		Holidays:       []Holiday{},
+		location:       time.UTC,
+	}
+}
+
+// Validate validates the calendar and normalizes it: days are sorted by
+// weekday and holidays by date.
+func (b *BusinessHours) Validate() error {
+	errs := apperrors.NewValidationErrors()
+
+	loc, err := time.LoadLocation(b.Timezone)
+	if b.Timezone == "" || err != nil {
+		errs.Add("timezone", "Timezone must be an IANA time zone name, e.g. Europe/Berlin")
+	} else {
+		b.location = loc
+	}
+
+	if len(b.Days) == 0 {
+		errs.Add("days", "At least one working day is required")
+	}
+	seenDays := make(map[time.Weekday]bool, len(b.Days))
+	for _, day := range b.Days {
+		if day.Weekday < time.Sunday || day.Weekday > time.Saturday {
+			errs.Add("days", "Unknown day of the week")
+			continue
+		}
+		if seenDays[day.Weekday] {
+			errs.Add("days", "Each day of the week can only be listed once: "+strings.ToLower(day.Weekday.String()))
+		}
+		seenDays[day.Weekday] = true
+		if day.Open < 0 || day.Close > 24*60 || day.Open >= day.Close {
+			errs.Add("days", "Opening time must be before closing time on "+strings.ToLower(day.Weekday.String()))
+		}
+	}
+
+	if len(b.Holidays) > MaxHolidays {
+		errs.Add("holidays", fmt.Sprintf("At most %d holidays can be configured", MaxHolidays))
+	}
+	seenDates := make(map[string]bool, len(b.Holidays))
+	for _, holiday := range b.Holidays {
+		if _, err := time.Parse(HolidayDateLayout, holiday.Date); err != nil {
+			errs.Add("holidays", "Holiday dates must be formatted YYYY-MM-DD: "+holiday.Date)
+			continue
+		}
+		if seenDates[holiday.Date] {
+			errs.Add("holidays", "Duplicate holiday date: "+holiday.Date)
+		}
+		seenDates[holiday.Date] = true
+		if len(holiday.Name) > MaxHolidayNameLength {
+			errs.Add("holidays", "Holiday name is too long")
+		}
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+
+	sort.Slice(b.Days, func(i, j int) bool { return b.Days[i].Weekday < b.Days[j].Weekday })
+	sort.Slice(b.Holidays, func(i, j int) bool { return b.Holidays[i].Date < b.Holidays[j].Date })
+	return nil
+}
+
+// AuditSnapshot returns the calendar in the shape recorded in the audit log.
+func (b *BusinessHours) AuditSnapshot() map[string]any {
+	days := make([]map[string]string, 0, len(b.Days))
+	for _, day := range b.Days {
+		days = append(days, map[string]string{
+			"day":   strings.ToLower(day.Weekday.String()),
+			"open":  day.Open.String(),
+			"close": day.Close.String(),
+		})
+	}
+
+	return map[string]any{
+		"timezone": b.Timezone,
+		"days":     days,
+		"holidays": b.Holidays,
+	}
+}
+
+// Location returns the calendar's time zone, falling back to UTC if it
+// cannot be loaded.
+func (b *BusinessHours) Location() *time.Location {
+	if b.location == nil {
+		loc, err := time.LoadLocation(b.Timezone)
+		if err != nil {
+			loc = time.UTC
+		}
+		b.location = loc
+	}
+	return b.location
+}
+
+// IsWorkingTime reports whether t falls within working hours.
+func (b *BusinessHours) IsWorkingTime(t time.Time) bool {
+	local := t.In(b.Location())
+	openAt, closeAt, ok := b.window(local)
+	return ok && !local.Before(openAt) && local.Before(closeAt)
+}
+
+// AddWorkingTime returns the instant at which d of working time has elapsed
+// after start, skipping nights, non-working days and holidays. It returns
+// the zero time if the calendar has no working time within several years.
+func (b *BusinessHours) AddWorkingTime(start time.Time, d time.Duration) time.Time {
+	t := start.In(b.Location())
+
+	for i := 0; i < maxCalendarScanDays; i++ {
+		openAt, closeAt, ok := b.window(t)
+		if ok && t.Before(closeAt) {
+			if t.Before(openAt) {
+				t = openAt
+			}
+			remaining := closeAt.Sub(t)
+			if d <= remaining {
+				return t.Add(d)
+			}
+			d -= remaining
+		}
+		t = startOfNextDay(t)
+	}
+
+	return time.Time{}
+}
+
+// WorkingTimeBetween returns how much working time elapses from start to end.
+func (b *BusinessHours) WorkingTimeBetween(start, end time.Time) time.Duration {
+	var total time.Duration
+	t := start.In(b.Location())
+	end = end.In(b.Location())
+
+	for i := 0; i < maxCalendarScanDays && t.Before(end); i++ {
+		openAt, closeAt, ok := b.window(t)
+		if ok {
+			from, to := maxTime(t, openAt), minTime(end, closeAt)
+			if from.Before(to) {
+				total += to.Sub(from)
+			}
+		}
+		t = startOfNextDay(t)
+	}
+
+	return total
+}
+
+// window returns the working window on the local date of t, if that date is
+// a working day and not a holiday.
+func (b *BusinessHours) window(t time.Time) (time.Time, time.Time, bool) {
+	date := t.Format(HolidayDateLayout)
+	for _, holiday := range b.Holidays {
+		if holiday.Date == date {
+			return time.Time{}, time.Time{}, false
+		}
+	}
+
+	for _, day := range b.Days {
+		if day.Weekday == t.Weekday() {
+			return atClockTime(t, day.Open), atClockTime(t, day.Close), true
+		}
+	}
+
+	return time.Time{}, time.Time{}, false
+}
+
+// atClockTime returns the instant at the given time of day on t's local date
+func atClockTime(t time.Time, c ClockTime) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, int(c)/60, int(c)%60, 0, 0, t.Location())
+}
+
+// startOfNextDay returns local midnight at the start of the day after t
+func startOfNextDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, t.Location())
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
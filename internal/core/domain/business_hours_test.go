@@ -0,0 +1,202 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClockTime(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    domain.ClockTime
+		wantErr bool
+	}{
+		{"09:00", 9 * 60, false},
+		{"17:30", 17*60 + 30, false},
+		{"00:00", 0, false},
+		{"24:00", 24 * 60, false},
+		{"24:01", 0, true},
+		{"9:00", 0, true},
+		{"12:60", 0, true},
+		{"ab:cd", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := domain.ParseClockTime(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.input, got.String())
+		})
+	}
+}
+
+func TestBusinessHours_Validate(t *testing.T) {
+	t.Run("accepts the default calendar", func(t *testing.T) {
+		hours := domain.DefaultBusinessHours(uuid.New())
+		assert.NoError(t, hours.Validate())
+	})
+
+	t.Run("sorts days and holidays", func(t *testing.T) {
+		hours := &domain.BusinessHours{
+			Timezone: "Europe/Berlin",
+			Days: []domain.WorkingDay{
+				{Weekday: time.Friday, Open: 9 * 60, Close: 17 * 60},
+				{Weekday: time.Monday, Open: 9 * 60, Close: 17 * 60},
+			},
+			Holidays: []domain.Holiday{
+				{Date: "2026-12-26", Name: "Boxing Day"},
+				{Date: "2026-12-25", Name: "Christmas Day"},
+			},
+		}
+
+		require.NoError(t, hours.Validate())
+		assert.Equal(t, time.Monday, hours.Days[0].Weekday)
+		assert.Equal(t, "2026-12-25", hours.Holidays[0].Date)
+	})
+
+	tests := []struct {
+		name  string
+		hours domain.BusinessHours
+		field string
+	}{
+		{
+			name:  "unknown timezone",
+			hours: domain.BusinessHours{Timezone: "Mars/Olympus", Days: []domain.WorkingDay{{Weekday: time.Monday, Open: 0, Close: 60}}},
+			field: "timezone",
+		},
+		{
+			name:  "no working days",
+			hours: domain.BusinessHours{Timezone: "UTC"},
+			field: "days",
+		},
+		{
+			name: "duplicate day",
+			hours: domain.BusinessHours{Timezone: "UTC", Days: []domain.WorkingDay{
+				{Weekday: time.Monday, Open: 0, Close: 60},
+				{Weekday: time.Monday, Open: 120, Close: 180},
+			}},
+			field: "days",
+		},
+		{
+			name:  "closes before it opens",
+			hours: domain.BusinessHours{Timezone: "UTC", Days: []domain.WorkingDay{{Weekday: time.Monday, Open: 17 * 60, Close: 9 * 60}}},
+			field: "days",
+		},
+		{
+			name: "malformed holiday date",
+			hours: domain.BusinessHours{
+				Timezone: "UTC",
+				Days:     []domain.WorkingDay{{Weekday: time.Monday, Open: 0, Close: 60}},
+				Holidays: []domain.Holiday{{Date: "25/12/2026"}},
+			},
+			field: "holidays",
+		},
+		{
+			name: "duplicate holiday date",
+			hours: domain.BusinessHours{
+				Timezone: "UTC",
+				Days:     []domain.WorkingDay{{Weekday: time.Monday, Open: 0, Close: 60}},
+				Holidays: []domain.Holiday{{Date: "2026-12-25"}, {Date: "2026-12-25"}},
+			},
+			field: "holidays",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.hours.Validate()
+
+			var valErrs *apperrors.ValidationErrors
+			require.ErrorAs(t, err, &valErrs)
+			assert.Contains(t, valErrs.Errors, tt.field)
+		})
+	}
+}
+
+func TestBusinessHours_AddWorkingTime(t *testing.T) {
+	hours := domain.DefaultBusinessHours(uuid.New())
+	hours.Holidays = []domain.Holiday{{Date: "2026-12-25", Name: "Christmas Day"}}
+
+	tests := []struct {
+		name  string
+		start time.Time
+		d     time.Duration
+		want  time.Time
+	}{
+		{
+			name:  "within the same day",
+			start: time.Date(2026, 12, 21, 10, 0, 0, 0, time.UTC), // Monday
+			d:     2 * time.Hour,
+			want:  time.Date(2026, 12, 21, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "before opening starts at opening",
+			start: time.Date(2026, 12, 21, 6, 0, 0, 0, time.UTC),
+			d:     time.Hour,
+			want:  time.Date(2026, 12, 21, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "rolls over to the next morning",
+			start: time.Date(2026, 12, 21, 16, 0, 0, 0, time.UTC),
+			d:     2 * time.Hour,
+			want:  time.Date(2026, 12, 22, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "skips the weekend",
+			start: time.Date(2026, 12, 18, 16, 0, 0, 0, time.UTC), // Friday
+			d:     2 * time.Hour,
+			want:  time.Date(2026, 12, 21, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "skips holidays",
+			start: time.Date(2026, 12, 24, 16, 0, 0, 0, time.UTC), // Thursday
+			d:     2 * time.Hour,
+			want:  time.Date(2026, 12, 28, 10, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hours.AddWorkingTime(tt.start, tt.d)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+
+	t.Run("uses the organization's timezone across DST", func(t *testing.T) {
+		berlin := &domain.BusinessHours{
+			Timezone: "Europe/Berlin",
+			Days:     []domain.WorkingDay{{Weekday: time.Friday, Open: 9 * 60, Close: 17 * 60}, {Weekday: time.Monday, Open: 9 * 60, Close: 17 * 60}},
+		}
+		require.NoError(t, berlin.Validate())
+
+		// Clocks go back on Sunday 2026-10-25; 09:00 is 07:00 UTC on Friday
+		// and 08:00 UTC on Monday.
+		start := time.Date(2026, 10, 23, 15, 0, 0, 0, time.UTC) // 17:00 in Berlin
+		got := berlin.AddWorkingTime(start, time.Hour)
+
+		assert.True(t, time.Date(2026, 10, 26, 9, 0, 0, 0, time.UTC).Equal(got), "got %s", got)
+	})
+}
+
+func TestBusinessHours_WorkingTimeBetween(t *testing.T) {
+	hours := domain.DefaultBusinessHours(uuid.New())
+
+	start := time.Date(2026, 12, 18, 16, 0, 0, 0, time.UTC) // Friday
+	end := time.Date(2026, 12, 21, 10, 30, 0, 0, time.UTC)  // Monday
+
+	assert.Equal(t, 2*time.Hour+30*time.Minute, hours.WorkingTimeBetween(start, end))
+	assert.Zero(t, hours.WorkingTimeBetween(end, start))
+	assert.True(t, hours.IsWorkingTime(end))
+	assert.False(t, hours.IsWorkingTime(time.Date(2026, 12, 19, 12, 0, 0, 0, time.UTC)))
+}
@@ -13,10 +13,13 @@ const (
 
 // Comment is the core domain entity for a ticket comment.
 type Comment struct {
-	ID        int64
-	TicketID  int64
-	AuthorID  uuid.UUID
+	ID       int64
+	TicketID int64
+	AuthorID uuid.UUID
+	// Body is kept as submitted; BodyHTML is the same rich text sanitized
+	// by the server, and is what clients render.
 	Body      string
+	BodyHTML  string
 	CreatedAt time.Time
 }
 
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+// CommentDraft is a reply a user has started on a ticket but not posted
+// yet. Each user has at most one draft per ticket, saved as they type so
+// it survives a closed tab or a browser crash; posting a comment on the
+// ticket discards it.
+type CommentDraft struct {
+	TicketID       int64
+	UserID         uuid.UUID
+	OrganizationID uuid.UUID
+	Body           string
+	UpdatedAt      time.Time
+}
+
+// NewCommentDraft creates userID's draft of a reply to ticket. The body is
+// limited like a comment's, but may be empty while the user is editing.
+func NewCommentDraft(ticket *Ticket, userID uuid.UUID, body string, now time.Time) (*CommentDraft, error) {
+	if len(body) > MaxCommentBodyLength {
+		errs := apperrors.NewValidationErrors()
+		errs.Add("body", "Comment body must be 10,000 characters or less")
+		return nil, errs
+	}
+
+	return &CommentDraft{
+		TicketID:       ticket.ID,
+		UserID:         userID,
+		OrganizationID: ticket.OrganizationID,
+		Body:           body,
+		UpdatedAt:      now.UTC(),
+	}, nil
+}
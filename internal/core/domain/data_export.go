@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DataExportStatus is the state of a personal data export.
+type DataExportStatus string
+
+const (
+	DataExportPending DataExportStatus = "PENDING"
+	DataExportReady   DataExportStatus = "READY"
+	DataExportFailed  DataExportStatus = "FAILED"
+)
+
+// DataExport is a request to assemble everything stored about a user.
+// Exports are generated in the background; Payload is set once the export
+// is ready and is discarded when it expires.
+type DataExport struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+	RequestedBy    uuid.UUID
+	Status         DataExportStatus
+	Payload        json.RawMessage
+	Error          string
+	CreatedAt      time.Time
+	CompletedAt    *time.Time
+	ExpiresAt      *time.Time
+}
+
+// NewDataExport creates a pending export of userID's data requested by
+// requestedBy, who is notified when it is ready.
+func NewDataExport(orgID, userID, requestedBy uuid.UUID) *DataExport {
+	return &DataExport{
+		ID:             uuid.New(),
+		OrganizationID: orgID,
+		UserID:         userID,
+		RequestedBy:    requestedBy,
+		Status:         DataExportPending,
+		CreatedAt:      time.Now().UTC(),
+	}
+}
+
+// IsDownloadable reports whether the export is ready and has not expired.
+func (e *DataExport) IsDownloadable(now time.Time) bool {
+	if e.Status != DataExportReady {
+		return false
+	}
+	return e.ExpiresAt == nil || now.Before(*e.ExpiresAt)
+}
+
+// UserData is the content of a data export: the user's profile and every
+// record they created or that was created about them.
+type UserData struct {
+	ExportedAt   time.Time             `json:"exportedAt"`
+	Profile      UserDataProfile       `json:"profile"`
+	Tickets      []UserDataTicket      `json:"tickets"`
+	Comments     []UserDataComment     `json:"comments"`
+	TicketEvents []UserDataTicketEvent `json:"ticketEvents"`
+	AuditEvents  []UserDataAuditEvent  `json:"auditEvents"`
+}
+
+// UserDataProfile is the user's own account record.
+type UserDataProfile struct {
+	ID              uuid.UUID  `json:"id"`
+	OrganizationID  uuid.UUID  `json:"organizationId"`
+	FullName        string     `json:"fullName"`
+	Email           string     `json:"email"`
+	Roles           []string   `json:"roles"`
+	PhoneNumber     string     `json:"phoneNumber,omitempty"`
+	SMSOptIn        bool       `json:"smsOptIn"`
+	Locale          string     `json:"locale"`
+	Timezone        string     `json:"timezone"`
+	IsActive        bool       `json:"isActive"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	LastActiveAt    *time.Time `json:"lastActiveAt,omitempty"`
+	TokensRevokedAt *time.Time `json:"tokensRevokedAt,omitempty"`
+}
+
+// UserDataTicket is a ticket the user requested or is assigned to.
+type UserDataTicket struct {
+	ID          int64      `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	Status      string     `json:"status"`
+	Priority    string     `json:"priority"`
+	Role        string     `json:"role"` // "requester" or "assignee"
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
+	ClosedAt    *time.Time `json:"closedAt,omitempty"`
+}
+
+// UserDataComment is a comment the user wrote.
+type UserDataComment struct {
+	ID        int64     `json:"id"`
+	TicketID  int64     `json:"ticketId"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// UserDataTicketEvent is a ticket change the user made.
+type UserDataTicketEvent struct {
+	ID        int64           `json:"id"`
+	TicketID  int64           `json:"ticketId"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+// UserDataAuditEvent is an audit log entry the user made or that targets
+// their account, such as a role change or password reset.
+type UserDataAuditEvent struct {
+	Action    AuditAction `json:"action"`
+	ActorID   uuid.UUID   `json:"actorId"`
+	TargetID  string      `json:"targetId"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+// emailChangePurpose separates email change signatures from other tokens
+// signed with the same secret
+const emailChangePurpose = "email-change:"
+
+// EmailChange is a requested change of a user's login email, confirmed
+// through a link sent to the new address. The link is bound to the current
+// address, so it stops working once the email has changed.
+type EmailChange struct {
+	UserID       uuid.UUID
+	CurrentEmail string
+	NewEmail     string
+	ExpiresAt    time.Time
+}
+
+// NewEmailChange validates newEmail as the user's next login email.
+func NewEmailChange(user *User, newEmail string, expiresAt time.Time) (EmailChange, error) {
+	errs := apperrors.NewValidationErrors()
+
+	if newEmail == "" {
+		errs.Add("newEmail", "Email is required")
+	} else if len(newEmail) > MaxEmailLength {
+		errs.Add("newEmail", "Email must be 255 characters or less")
+	} else if !isValidEmail(newEmail) {
+		errs.Add("newEmail", "Invalid email format")
+	} else if strings.EqualFold(newEmail, user.Email) {
+		errs.Add("newEmail", "New email must differ from the current one")
+	}
+
+	if errs.HasErrors() {
+		return EmailChange{}, errs
+	}
+	return EmailChange{
+		UserID:       user.ID,
+		CurrentEmail: user.Email,
+		NewEmail:     newEmail,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+// Sign returns the confirmation token for the change, signed with secret.
+func (c EmailChange) Sign(secret []byte) string {
+	payload := make([]byte, 24, 24+len(c.CurrentEmail)+1+len(c.NewEmail))
+	copy(payload[:16], c.UserID[:])
+	binary.BigEndian.PutUint64(payload[16:24], uint64(c.ExpiresAt.Unix()))
+	payload = append(payload, c.CurrentEmail...)
+	payload = append(payload, 0)
+	payload = append(payload, c.NewEmail...)
+
+	return signToken(secret, emailChangePurpose, payload)
+}
+
+// ParseEmailChange returns the change a confirmation token was signed for.
+// It returns apperrors.ErrInvalidEmailChangeToken if the token was not
+// signed with secret or has expired.
+func ParseEmailChange(secret []byte, token string, now time.Time) (EmailChange, error) {
+	payload, ok := verifyToken(secret, emailChangePurpose, token)
+	if !ok || len(payload) < 24 {
+		return EmailChange{}, apperrors.ErrInvalidEmailChangeToken
+	}
+
+	currentEmail, newEmail, ok := strings.Cut(string(payload[24:]), "\x00")
+	if !ok {
+		return EmailChange{}, apperrors.ErrInvalidEmailChangeToken
+	}
+
+	change := EmailChange{
+		UserID:       uuid.UUID(payload[:16]),
+		CurrentEmail: currentEmail,
+		NewEmail:     newEmail,
+		ExpiresAt:    time.Unix(int64(binary.BigEndian.Uint64(payload[16:24])), 0).UTC(),
+	}
+	if !now.Before(change.ExpiresAt) {
+		return EmailChange{}, apperrors.ErrInvalidEmailChangeToken
+	}
+	return change, nil
+}
@@ -0,0 +1,73 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEmailChange(t *testing.T) {
+	user := &domain.User{ID: uuid.New(), Email: "jane@example.com"}
+	expiresAt := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	change, err := domain.NewEmailChange(user, "jane@example.org", expiresAt)
+	require.NoError(t, err)
+	assert.Equal(t, domain.EmailChange{
+		UserID:       user.ID,
+		CurrentEmail: "jane@example.com",
+		NewEmail:     "jane@example.org",
+		ExpiresAt:    expiresAt,
+	}, change)
+
+	for _, invalid := range []string{"", "not-an-email", "JANE@example.com", strings.Repeat("a", 250) + "@example.com"} {
+		_, err := domain.NewEmailChange(user, invalid, expiresAt)
+		var errs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &errs, invalid)
+		assert.Contains(t, errs.Errors, "newEmail", invalid)
+	}
+}
+
+func TestEmailChange_Token(t *testing.T) {
+	secret := []byte("s3cret")
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	change := domain.EmailChange{
+		UserID:       uuid.New(),
+		CurrentEmail: "jane@example.com",
+		NewEmail:     "jane@example.org",
+		ExpiresAt:    now.Add(time.Hour),
+	}
+	token := change.Sign(secret)
+
+	t.Run("round-trips", func(t *testing.T) {
+		parsed, err := domain.ParseEmailChange(secret, token, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, change, parsed)
+	})
+
+	t.Run("rejects another secret", func(t *testing.T) {
+		_, err := domain.ParseEmailChange([]byte("other"), token, now)
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidEmailChangeToken)
+	})
+
+	t.Run("rejects an expired token", func(t *testing.T) {
+		_, err := domain.ParseEmailChange(secret, token, now.Add(time.Hour))
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidEmailChangeToken)
+	})
+
+	t.Run("rejects a portal link signed with the same secret", func(t *testing.T) {
+		link := domain.PortalLink{TicketID: 1, RequesterID: change.UserID, ExpiresAt: change.ExpiresAt}.Sign(secret)
+
+		_, err := domain.ParseEmailChange(secret, link, now)
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidEmailChangeToken)
+	})
+}
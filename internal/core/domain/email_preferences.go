@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"encoding/binary"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+// emailOptOutPurpose separates opt-out signatures from other tokens signed
+// with the same secret
+const emailOptOutPurpose = "email-opt-out:"
+
+// EmailPreferences are the ticket emails a user has opted out of. Emails
+// about their account, such as email change confirmations, are always sent.
+type EmailPreferences struct {
+	UserID uuid.UUID
+	// Unsubscribed stops every ticket email
+	Unsubscribed bool
+	// MutedTicketIDs are tickets the user gets no more emails about
+	MutedTicketIDs []int64
+}
+
+// Allows reports whether the preferences let an email about ticketID
+// through.
+func (p *EmailPreferences) Allows(ticketID int64) bool {
+	if p.Unsubscribed {
+		return false
+	}
+	for _, id := range p.MutedTicketIDs {
+		if id == ticketID {
+			return false
+		}
+	}
+	return true
+}
+
+// EmailOptOut is what a link at the foot of a ticket email asks for: to
+// stop the recipient's emails about that ticket, or about every ticket when
+// TicketID is zero. The links work without signing in, so they carry a
+// signed token; they don't expire, as the email may be read much later.
+type EmailOptOut struct {
+	UserID   uuid.UUID
+	TicketID int64
+}
+
+// AllTickets reports whether the opt-out is from every ticket email.
+func (o EmailOptOut) AllTickets() bool {
+	return o.TicketID == 0
+}
+
+// Sign returns the token for the opt-out, signed with secret.
+func (o EmailOptOut) Sign(secret []byte) string {
+	payload := make([]byte, 24)
+	copy(payload[:16], o.UserID[:])
+	binary.BigEndian.PutUint64(payload[16:], uint64(o.TicketID))
+
+	return signToken(secret, emailOptOutPurpose, payload)
+}
+
+// ParseEmailOptOut returns the opt-out a token was signed for. It returns
+// apperrors.ErrInvalidOptOutToken if the token was not signed with secret.
+func ParseEmailOptOut(secret []byte, token string) (EmailOptOut, error) {
+	payload, ok := verifyToken(secret, emailOptOutPurpose, token)
+	if !ok || len(payload) != 24 {
+		return EmailOptOut{}, apperrors.ErrInvalidOptOutToken
+	}
+
+	return EmailOptOut{
+		UserID:   uuid.UUID(payload[:16]),
+		TicketID: int64(binary.BigEndian.Uint64(payload[16:])),
+	}, nil
+}
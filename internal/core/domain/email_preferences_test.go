@@ -0,0 +1,56 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailPreferences_Allows(t *testing.T) {
+	prefs := &domain.EmailPreferences{MutedTicketIDs: []int64{7}}
+	assert.True(t, prefs.Allows(8))
+	assert.False(t, prefs.Allows(7))
+
+	prefs.Unsubscribed = true
+	assert.False(t, prefs.Allows(8))
+}
+
+func TestEmailOptOut_Token(t *testing.T) {
+	secret := []byte("s3cret")
+	optOut := domain.EmailOptOut{UserID: uuid.New(), TicketID: 42}
+	token := optOut.Sign(secret)
+
+	t.Run("round-trips", func(t *testing.T) {
+		parsed, err := domain.ParseEmailOptOut(secret, token)
+
+		require.NoError(t, err)
+		assert.Equal(t, optOut, parsed)
+		assert.False(t, parsed.AllTickets())
+	})
+
+	t.Run("round-trips an opt-out from all tickets", func(t *testing.T) {
+		parsed, err := domain.ParseEmailOptOut(secret, domain.EmailOptOut{UserID: optOut.UserID}.Sign(secret))
+
+		require.NoError(t, err)
+		assert.True(t, parsed.AllTickets())
+	})
+
+	t.Run("rejects another secret", func(t *testing.T) {
+		_, err := domain.ParseEmailOptOut([]byte("other"), token)
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidOptOutToken)
+	})
+
+	t.Run("rejects a portal link signed with the same secret", func(t *testing.T) {
+		link := domain.PortalLink{TicketID: 42, RequesterID: optOut.UserID, ExpiresAt: time.Now().Add(time.Hour)}.Sign(secret)
+
+		_, err := domain.ParseEmailOptOut(secret, link)
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidOptOutToken)
+	})
+}
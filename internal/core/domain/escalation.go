@@ -0,0 +1,284 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+const (
+	// MaxEscalationRuleNameLength is the maximum length of a rule's name
+	MaxEscalationRuleNameLength = 100
+	// MaxEscalationActions caps how many actions a rule can take
+	MaxEscalationActions = 10
+	// MaxEscalationThresholdMinutes caps a rule's threshold at 30 days
+	MaxEscalationThresholdMinutes = 30 * 24 * 60
+)
+
+// EscalationTrigger is the condition whose duration an escalation rule
+// measures against its threshold.
+type EscalationTrigger string
+
+const (
+	// EscalationUnassigned measures how long a ticket has had no assignee
+	// since it was created.
+	EscalationUnassigned EscalationTrigger = "UNASSIGNED"
+	// EscalationOverdue measures how long a ticket has been past its due date.
+	EscalationOverdue EscalationTrigger = "OVERDUE"
+	// EscalationIdle measures how long a ticket has gone without an update.
+	EscalationIdle EscalationTrigger = "IDLE"
+)
+
+// IsValid checks if the trigger is supported
+func (t EscalationTrigger) IsValid() bool {
+	switch t {
+	case EscalationUnassigned, EscalationOverdue, EscalationIdle:
+		return true
+	}
+	return false
+}
+
+// EscalationActionType is what an escalation rule does once it fires.
+type EscalationActionType string
+
+const (
+	// EscalationNotify notifies UserID.
+	EscalationNotify EscalationActionType = "NOTIFY"
+	// EscalationAssign assigns the ticket to UserID.
+	EscalationAssign EscalationActionType = "ASSIGN"
+	// EscalationRouteToTeam moves the ticket to TeamID's queue.
+	EscalationRouteToTeam EscalationActionType = "ROUTE_TO_TEAM"
+	// EscalationSetPriority changes the ticket's priority to Priority.
+	EscalationSetPriority EscalationActionType = "SET_PRIORITY"
+)
+
+// IsValid checks if the action type is supported
+func (t EscalationActionType) IsValid() bool {
+	switch t {
+	case EscalationNotify, EscalationAssign, EscalationRouteToTeam, EscalationSetPriority:
+		return true
+	}
+	return false
+}
+
+// EscalationAction is one thing an escalation rule does. Only the target its
+// type needs is set.
+type EscalationAction struct {
+	Type     EscalationActionType `json:"type"`
+	UserID   *uuid.UUID           `json:"userId,omitempty"`
+	TeamID   *uuid.UUID           `json:"teamId,omitempty"`
+	Priority TicketPriority       `json:"priority,omitempty"`
+}
+
+// EscalationRule escalates open tickets of the given priorities, or of any
+// priority if none are given, once its trigger has held for the threshold.
+type EscalationRule struct {
+	ID               uuid.UUID
+	OrganizationID   uuid.UUID
+	Name             string
+	Trigger          EscalationTrigger
+	ThresholdMinutes int
+	Priorities       []TicketPriority
+	Actions          []EscalationAction
+	IsActive         bool
+	CreatedBy        uuid.UUID
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// EscalationRuleParams holds the configurable fields of an escalation rule
+type EscalationRuleParams struct {
+	Name             string
+	Trigger          EscalationTrigger
+	ThresholdMinutes int
+	Priorities       []TicketPriority
+	Actions          []EscalationAction
+	IsActive         bool
+}
+
+// Validate validates escalation rule parameters and trims the name
+func (p *EscalationRuleParams) Validate() error {
+	errs := apperrors.NewValidationErrors()
+
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		errs.Add("name", "Name is required")
+	} else if len(p.Name) > MaxEscalationRuleNameLength {
+		errs.Add("name", "Name must be 100 characters or less")
+	}
+
+	if !p.Trigger.IsValid() {
+		errs.Add("trigger", "Trigger must be UNASSIGNED, OVERDUE or IDLE")
+	}
+
+	if p.ThresholdMinutes < 1 || p.ThresholdMinutes > MaxEscalationThresholdMinutes {
+		errs.Add("thresholdMinutes", fmt.Sprintf("Threshold must be between 1 and %d minutes", MaxEscalationThresholdMinutes))
+	}
+
+	seen := make(map[TicketPriority]bool, len(p.Priorities))
+	for _, priority := range p.Priorities {
+		if !priority.IsValid() {
+			errs.Add("priorities", "Invalid priority: "+string(priority))
+		} else if seen[priority] {
+			errs.Add("priorities", "Duplicate priority: "+string(priority))
+		}
+		seen[priority] = true
+	}
+
+	if len(p.Actions) == 0 {
+		errs.Add("actions", "At least one action is required")
+	} else if len(p.Actions) > MaxEscalationActions {
+		errs.Add("actions", fmt.Sprintf("At most %d actions are allowed", MaxEscalationActions))
+	}
+	for i, action := range p.Actions {
+		if msg := action.validate(); msg != "" {
+			errs.Add("actions", fmt.Sprintf("Action %d %s", i+1, msg))
+		}
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// validate returns what is wrong with the action, if anything
+func (a EscalationAction) validate() string {
+	switch a.Type {
+	case EscalationNotify, EscalationAssign:
+		if a.UserID == nil || *a.UserID == uuid.Nil {
+			return "needs a userId"
+		}
+	case EscalationRouteToTeam:
+		if a.TeamID == nil || *a.TeamID == uuid.Nil {
+			return "needs a teamId"
+		}
+	case EscalationSetPriority:
+		if !a.Priority.IsValid() {
+			return "needs a priority of LOW, MEDIUM or HIGH"
+		}
+	default:
+		return "has an unknown type: " + string(a.Type)
+	}
+	return ""
+}
+
+// NewEscalationRule validates params and creates an escalation rule
+func NewEscalationRule(params EscalationRuleParams, orgID, createdBy uuid.UUID) (*EscalationRule, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	rule := &EscalationRule{
+		OrganizationID: orgID,
+		CreatedBy:      createdBy,
+	}
+	rule.apply(params)
+	return rule, nil
+}
+
+// Update replaces the rule's configuration with params
+func (r *EscalationRule) Update(params EscalationRuleParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	r.apply(params)
+	return nil
+}
+
+func (r *EscalationRule) apply(params EscalationRuleParams) {
+	priorities := params.Priorities
+	if priorities == nil {
+		priorities = []TicketPriority{}
+	}
+
+	r.Name = params.Name
+	r.Trigger = params.Trigger
+	r.ThresholdMinutes = params.ThresholdMinutes
+	r.Priorities = priorities
+	r.Actions = params.Actions
+	r.IsActive = params.IsActive
+}
+
+// AppliesTo reports whether the rule watches the ticket: it must be open
+// and of one of the rule's priorities.
+func (r *EscalationRule) AppliesTo(ticket *Ticket) bool {
+	if ticket.Status == StatusClosed {
+		return false
+	}
+	if len(r.Priorities) == 0 {
+		return true
+	}
+	for _, priority := range r.Priorities {
+		if priority == ticket.Priority {
+			return true
+		}
+	}
+	return false
+}
+
+// FiresAt returns when the rule escalates the ticket if nothing changes in
+// the meantime. It returns false if the rule does not apply to the ticket
+// or its trigger does not hold.
+func (r *EscalationRule) FiresAt(ticket *Ticket) (time.Time, bool) {
+	if !r.AppliesTo(ticket) {
+		return time.Time{}, false
+	}
+
+	var since time.Time
+	switch r.Trigger {
+	case EscalationUnassigned:
+		if ticket.AssigneeID != nil {
+			return time.Time{}, false
+		}
+		since = ticket.CreatedAt
+	case EscalationOverdue:
+		if ticket.DueAt == nil {
+			return time.Time{}, false
+		}
+		since = *ticket.DueAt
+	case EscalationIdle:
+		since = ticket.CreatedAt
+		if ticket.UpdatedAt != nil {
+			since = *ticket.UpdatedAt
+		}
+	default:
+		return time.Time{}, false
+	}
+
+	return since.Add(time.Duration(r.ThresholdMinutes) * time.Minute), true
+}
+
+// EscalationEvaluation is the outcome of evaluating a rule against a ticket
+// at a point in time. FiresAt is nil if the rule would never fire.
+type EscalationEvaluation struct {
+	Rule    *EscalationRule
+	Fires   bool
+	FiresAt *time.Time
+}
+
+// Evaluate reports whether the rule has fired for the ticket by at.
+func (r *EscalationRule) Evaluate(ticket *Ticket, at time.Time) EscalationEvaluation {
+	evaluation := EscalationEvaluation{Rule: r}
+	if firesAt, ok := r.FiresAt(ticket); ok {
+		evaluation.FiresAt = &firesAt
+		evaluation.Fires = !at.Before(firesAt)
+	}
+	return evaluation
+}
+
+// AuditSnapshot returns the rule in the shape recorded in the audit log.
+func (r *EscalationRule) AuditSnapshot() map[string]any {
+	return map[string]any{
+		"name":             r.Name,
+		"trigger":          r.Trigger,
+		"thresholdMinutes": r.ThresholdMinutes,
+		"priorities":       r.Priorities,
+		"actions":          r.Actions,
+		"isActive":         r.IsActive,
+	}
+}
@@ -0,0 +1,131 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validEscalationParams() domain.EscalationRuleParams {
+	userID := uuid.New()
+	return domain.EscalationRuleParams{
+		Name:             "Unassigned high priority",
+		Trigger:          domain.EscalationUnassigned,
+		ThresholdMinutes: 30,
+		Priorities:       []domain.TicketPriority{domain.PriorityHigh},
+		Actions:          []domain.EscalationAction{{Type: domain.EscalationNotify, UserID: &userID}},
+		IsActive:         true,
+	}
+}
+
+func TestEscalationRuleParams_Validate(t *testing.T) {
+	t.Run("accepts valid params and trims the name", func(t *testing.T) {
+		params := validEscalationParams()
+		params.Name = "  Unassigned high priority  "
+
+		require.NoError(t, params.Validate())
+		assert.Equal(t, "Unassigned high priority", params.Name)
+	})
+
+	tests := []struct {
+		name   string
+		modify func(p *domain.EscalationRuleParams)
+		field  string
+	}{
+		{"blank name", func(p *domain.EscalationRuleParams) { p.Name = " " }, "name"},
+		{"unknown trigger", func(p *domain.EscalationRuleParams) { p.Trigger = "LATE" }, "trigger"},
+		{"zero threshold", func(p *domain.EscalationRuleParams) { p.ThresholdMinutes = 0 }, "thresholdMinutes"},
+		{"duplicate priority", func(p *domain.EscalationRuleParams) {
+			p.Priorities = []domain.TicketPriority{domain.PriorityHigh, domain.PriorityHigh}
+		}, "priorities"},
+		{"no actions", func(p *domain.EscalationRuleParams) { p.Actions = nil }, "actions"},
+		{"assign without a user", func(p *domain.EscalationRuleParams) {
+			p.Actions = []domain.EscalationAction{{Type: domain.EscalationAssign}}
+		}, "actions"},
+		{"route without a team", func(p *domain.EscalationRuleParams) {
+			p.Actions = []domain.EscalationAction{{Type: domain.EscalationRouteToTeam}}
+		}, "actions"},
+		{"invalid priority to set", func(p *domain.EscalationRuleParams) {
+			p.Actions = []domain.EscalationAction{{Type: domain.EscalationSetPriority, Priority: "URGENT"}}
+		}, "actions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := validEscalationParams()
+			tt.modify(&params)
+
+			var errs *apperrors.ValidationErrors
+			require.ErrorAs(t, params.Validate(), &errs)
+			assert.Contains(t, errs.Errors, tt.field)
+		})
+	}
+}
+
+func TestEscalationRule_Evaluate(t *testing.T) {
+	created := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	newRule := func(trigger domain.EscalationTrigger) *domain.EscalationRule {
+		params := validEscalationParams()
+		params.Trigger = trigger
+		rule, err := domain.NewEscalationRule(params, uuid.New(), uuid.New())
+		require.NoError(t, err)
+		return rule
+	}
+
+	t.Run("unassigned fires after the threshold", func(t *testing.T) {
+		rule := newRule(domain.EscalationUnassigned)
+		ticket := &domain.Ticket{Status: domain.StatusOpen, Priority: domain.PriorityHigh, CreatedAt: created}
+
+		before := rule.Evaluate(ticket, created.Add(29*time.Minute))
+		after := rule.Evaluate(ticket, created.Add(30*time.Minute))
+
+		assert.False(t, before.Fires)
+		assert.True(t, after.Fires)
+		require.NotNil(t, after.FiresAt)
+		assert.Equal(t, created.Add(30*time.Minute), *after.FiresAt)
+	})
+
+	t.Run("assigned tickets never fire an unassigned rule", func(t *testing.T) {
+		assignee := uuid.New()
+		ticket := &domain.Ticket{Status: domain.StatusOpen, Priority: domain.PriorityHigh, CreatedAt: created, AssigneeID: &assignee}
+
+		evaluation := newRule(domain.EscalationUnassigned).Evaluate(ticket, created.Add(time.Hour))
+
+		assert.False(t, evaluation.Fires)
+		assert.Nil(t, evaluation.FiresAt)
+	})
+
+	t.Run("overdue counts from the due date", func(t *testing.T) {
+		dueAt := created.Add(4 * time.Hour)
+		ticket := &domain.Ticket{Status: domain.StatusOpen, Priority: domain.PriorityHigh, CreatedAt: created, DueAt: &dueAt}
+
+		evaluation := newRule(domain.EscalationOverdue).Evaluate(ticket, dueAt.Add(30*time.Minute))
+
+		assert.True(t, evaluation.Fires)
+	})
+
+	t.Run("idle counts from the last update", func(t *testing.T) {
+		updated := created.Add(time.Hour)
+		ticket := &domain.Ticket{Status: domain.StatusOpen, Priority: domain.PriorityHigh, CreatedAt: created, UpdatedAt: &updated}
+
+		evaluation := newRule(domain.EscalationIdle).Evaluate(ticket, created.Add(80*time.Minute))
+
+		assert.False(t, evaluation.Fires)
+		assert.Equal(t, updated.Add(30*time.Minute), *evaluation.FiresAt)
+	})
+
+	t.Run("ignores closed tickets and other priorities", func(t *testing.T) {
+		rule := newRule(domain.EscalationUnassigned)
+		closed := &domain.Ticket{Status: domain.StatusClosed, Priority: domain.PriorityHigh, CreatedAt: created}
+		low := &domain.Ticket{Status: domain.StatusOpen, Priority: domain.PriorityLow, CreatedAt: created}
+
+		assert.False(t, rule.Evaluate(closed, created.Add(time.Hour)).Fires)
+		assert.False(t, rule.Evaluate(low, created.Add(time.Hour)).Fires)
+	})
+}
@@ -11,21 +11,26 @@ type CommentSnapshot struct {
 	TicketID  int64  `json:"ticketId"`
 	AuthorID  string `json:"authorId"`
 	Body      string `json:"body"`
+	BodyHTML  string `json:"bodyHtml"`
 	CreatedAt string `json:"createdAt"`
 }
 
 // TicketSnapshot matches the API response shape for tickets.
 type TicketSnapshot struct {
-	ID          int64   `json:"id"`
-	Title       string  `json:"title"`
-	Description string  `json:"description"`
-	Status      string  `json:"status"`
-	Priority    string  `json:"priority"`
-	RequesterID string  `json:"requesterId"`
-	AssigneeID  *string `json:"assigneeId"`
-	CreatedAt   string  `json:"createdAt"`
-	UpdatedAt   *string `json:"updatedAt"`
-	ClosedAt    *string `json:"closedAt"`
+	ID              int64    `json:"id"`
+	Title           string   `json:"title"`
+	Description     string   `json:"description"`
+	DescriptionHTML string   `json:"descriptionHtml"`
+	Status          string   `json:"status"`
+	Priority        string   `json:"priority"`
+	RequesterID     string   `json:"requesterId"`
+	AssigneeID      *string  `json:"assigneeId"`
+	TeamID          *string  `json:"teamId"`
+	CreatedAt       string   `json:"createdAt"`
+	UpdatedAt       *string  `json:"updatedAt"`
+	ClosedAt        *string  `json:"closedAt"`
+	Tags            []string `json:"tags"`
+	Visibility      string   `json:"visibility"`
 }
 
 // NewCommentSnapshot builds a comment snapshot from a domain comment.
@@ -35,6 +40,7 @@ func NewCommentSnapshot(comment *Comment) CommentSnapshot {
 		TicketID:  comment.TicketID,
 		AuthorID:  comment.AuthorID.String(),
 		Body:      comment.Body,
+		BodyHTML:  comment.BodyHTML,
 		CreatedAt: comment.CreatedAt.UTC().Format(time.RFC3339),
 	}
 }
@@ -47,6 +53,12 @@ func NewTicketSnapshot(ticket *Ticket) TicketSnapshot {
 		assigneeID = &value
 	}
 
+	var teamID *string
+	if ticket.TeamID != nil {
+		value := ticket.TeamID.String()
+		teamID = &value
+	}
+
 	var updatedAt *string
 	if ticket.UpdatedAt != nil {
 		value := ticket.UpdatedAt.UTC().Format(time.RFC3339)
@@ -60,15 +72,27 @@ func NewTicketSnapshot(ticket *Ticket) TicketSnapshot {
 	}
 
 	return TicketSnapshot{
-		ID:          ticket.ID,
-		Title:       ticket.Title,
-		Description: ticket.Description,
-		Status:      string(ticket.Status),
-		Priority:    string(ticket.Priority),
-		RequesterID: ticket.RequesterID.String(),
-		AssigneeID:  assigneeID,
-		CreatedAt:   ticket.CreatedAt.UTC().Format(time.RFC3339),
-		UpdatedAt:   updatedAt,
-		ClosedAt:    closedAt,
+		ID:              ticket.ID,
+		Title:           ticket.Title,
+		Description:     ticket.Description,
+		DescriptionHTML: ticket.DescriptionHTML,
+		Status:          string(ticket.Status),
+		Priority:        string(ticket.Priority),
+		RequesterID:     ticket.RequesterID.String(),
+		AssigneeID:      assigneeID,
+		TeamID:          teamID,
+		CreatedAt:       ticket.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:       updatedAt,
+		ClosedAt:        closedAt,
+		Tags:            ticketTags(ticket),
+		Visibility:      string(ticket.Visibility),
+	}
+}
+
+// ticketTags returns the ticket's tags, never nil so they encode as a list.
+func ticketTags(ticket *Ticket) []string {
+	if ticket.Tags == nil {
+		return []string{}
 	}
+	return ticket.Tags
 }
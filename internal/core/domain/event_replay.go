@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+const (
+	// MaxEventReplayRangeDays caps how many days one replay can span
+	MaxEventReplayRangeDays = 31
+	// EventReplayBatchSize is how many events one replay request sends at
+	// most; the rest are sent by repeating it with the returned cursor
+	EventReplayBatchSize = 500
+)
+
+// EventReplayFilter selects the ticket events of an organization to send
+// to webhooks again, such as after an outage in which consumers missed
+// them. Events created from From up to, but not including, To are
+// replayed in order, after AfterID.
+type EventReplayFilter struct {
+	OrganizationID uuid.UUID
+	// TicketID limits the replay to one ticket when set
+	TicketID *int64
+	From     time.Time
+	To       time.Time
+	AfterID  int64
+	Limit    int
+}
+
+// NewEventReplayFilter validates a replay of the events between from and
+// to. The replaying service sets the organization.
+func NewEventReplayFilter(ticketID *int64, from, to time.Time, afterID int64) (EventReplayFilter, error) {
+	errs := apperrors.NewValidationErrors()
+	if ticketID != nil && *ticketID <= 0 {
+		errs.Add("ticketId", "Invalid ticket ID")
+	}
+	if !to.After(from) {
+		errs.Add("from", "Start time must be before the end time")
+	} else if to.Sub(from) > MaxEventReplayRangeDays*24*time.Hour {
+		errs.Add("to", fmt.Sprintf("Time range cannot span more than %d days", MaxEventReplayRangeDays))
+	}
+	if afterID < 0 {
+		errs.Add("after", "Invalid cursor")
+	}
+	if errs.HasErrors() {
+		return EventReplayFilter{}, errs
+	}
+
+	return EventReplayFilter{
+		TicketID: ticketID,
+		From:     from.UTC(),
+		To:       to.UTC(),
+		AfterID:  afterID,
+		Limit:    EventReplayBatchSize,
+	}, nil
+}
+
+// EventReplayResult reports a replay. NextCursor is set when more events
+// match than one request sends: replaying again with it as AfterID sends
+// the next batch.
+type EventReplayResult struct {
+	Replayed   int
+	NextCursor *int64
+}
@@ -0,0 +1,29 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEventReplayFilter(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	ticketID := int64(7)
+
+	filter, err := domain.NewEventReplayFilter(&ticketID, from, from.Add(time.Hour), 12)
+	require.NoError(t, err)
+	assert.Equal(t, domain.EventReplayBatchSize, filter.Limit)
+	assert.Equal(t, int64(12), filter.AfterID)
+
+	for name, to := range map[string]time.Time{
+		"empty range":   from,
+		"reversed":      from.Add(-time.Hour),
+		"too many days": from.AddDate(0, 0, domain.MaxEventReplayRangeDays+1),
+	} {
+		_, err := domain.NewEventReplayFilter(nil, from, to, 0)
+		assert.Error(t, err, name)
+	}
+}
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"encoding/json"
+	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,14 +17,117 @@ const (
 	EventStatusUpdated  EventType = "STATUS_UPDATED"
 	EventTicketCreated  EventType = "TICKET_CREATED"
 	EventTicketAssigned EventType = "TICKET_ASSIGNED"
+	// EventTicketUpdated records a change to several fields at once, or to
+	// the title, description or priority.
+	EventTicketUpdated EventType = "TICKET_UPDATED"
 )
 
-// Event represents a persisted ticket event.
+// Event represents a persisted ticket event. Payload is the JSON of the
+// payload type the catalog gives for Type at Version.
 type Event struct {
 	ID        int64           `json:"id"`
 	TicketID  int64           `json:"ticketId"`
 	Type      EventType       `json:"type"`
+	Version   int             `json:"version"`
 	Payload   json.RawMessage `json:"payload"`
 	ActorID   uuid.UUID       `json:"actorId"`
 	CreatedAt time.Time       `json:"createdAt"`
 }
+
+// EventDefinition describes one type of ticket event: the version of its
+// payload that new events are recorded with, and the name it is published
+// under to webhooks.
+type EventDefinition struct {
+	Type        EventType
+	Version     int
+	Webhook     WebhookEventType
+	Description string
+	// Payload is a zero value of the payload type
+	Payload any
+}
+
+// EventCatalog lists every ticket event type. A payload that changes
+// incompatibly gets a new version here; events keep the version they were
+// recorded with.
+var EventCatalog = []EventDefinition{
+	{Type: EventTicketCreated, Version: 1, Webhook: WebhookTicketCreated,
+		Description: "A ticket was created", Payload: TicketSnapshot{}},
+	{Type: EventStatusUpdated, Version: 1, Webhook: WebhookTicketStatusChanged,
+		Description: "A ticket's status changed", Payload: TicketSnapshot{}},
+	{Type: EventTicketAssigned, Version: 1, Webhook: WebhookTicketAssigned,
+		Description: "A ticket was assigned or unassigned", Payload: TicketSnapshot{}},
+	{Type: EventTicketUpdated, Version: 1, Webhook: WebhookTicketUpdated,
+		Description: "Several fields of a ticket, or its title, description or priority, changed", Payload: TicketSnapshot{}},
+	{Type: EventCommentAdded, Version: 1, Webhook: WebhookCommentAdded,
+		Description: "A comment was added to a ticket", Payload: CommentSnapshot{}},
+}
+
+// LookupEvent returns the catalog entry of an event type.
+func LookupEvent(eventType EventType) (EventDefinition, bool) {
+	for _, def := range EventCatalog {
+		if def.Type == eventType {
+			return def, true
+		}
+	}
+	return EventDefinition{}, false
+}
+
+// NewTicketEvent records a change to a ticket by actorID. eventType must be
+// one whose payload is the ticket.
+func NewTicketEvent(eventType EventType, ticket *Ticket, actorID uuid.UUID) (*Event, error) {
+	return newEvent(eventType, ticket.ID, NewTicketSnapshot(ticket), actorID)
+}
+
+// NewCommentAddedEvent records a new comment by actorID.
+func NewCommentAddedEvent(comment *Comment, actorID uuid.UUID) (*Event, error) {
+	return newEvent(EventCommentAdded, comment.TicketID, NewCommentSnapshot(comment), actorID)
+}
+
+// newEvent builds an event of the current version of eventType, checking
+// that payload is of the type the catalog gives for it.
+func newEvent(eventType EventType, ticketID int64, payload any, actorID uuid.UUID) (*Event, error) {
+	def, ok := LookupEvent(eventType)
+	if !ok {
+		return nil, fmt.Errorf("unknown event type %q", eventType)
+	}
+	if reflect.TypeOf(payload) != reflect.TypeOf(def.Payload) {
+		return nil, fmt.Errorf("%s events carry a %T payload, not %T", eventType, def.Payload, payload)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal event payload: %w", err)
+	}
+	return &Event{
+		TicketID: ticketID,
+		Type:     eventType,
+		Version:  def.Version,
+		Payload:  data,
+		ActorID:  actorID,
+	}, nil
+}
+
+// DecodePayload decodes the payload into the type the catalog gives for
+// the event: a TicketSnapshot or a CommentSnapshot.
+func (e *Event) DecodePayload() (any, error) {
+	def, ok := LookupEvent(e.Type)
+	if !ok {
+		return nil, fmt.Errorf("unknown event type %q", e.Type)
+	}
+	if e.Version != def.Version {
+		return nil, fmt.Errorf("unknown version %d of %s events", e.Version, e.Type)
+	}
+
+	switch def.Payload.(type) {
+	case TicketSnapshot:
+		var payload TicketSnapshot
+		err := json.Unmarshal(e.Payload, &payload)
+		return payload, err
+	case CommentSnapshot:
+		var payload CommentSnapshot
+		err := json.Unmarshal(e.Payload, &payload)
+		return payload, err
+	default:
+		return nil, fmt.Errorf("no decoder for %T payloads", def.Payload)
+	}
+}
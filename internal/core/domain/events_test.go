@@ -0,0 +1,99 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventCatalog(t *testing.T) {
+	t.Run("describes every event type once", func(t *testing.T) {
+		seen := map[domain.EventType]bool{}
+		for _, def := range domain.EventCatalog {
+			assert.False(t, seen[def.Type], def.Type)
+			seen[def.Type] = true
+			assert.Positive(t, def.Version, def.Type)
+			assert.NotEmpty(t, def.Description, def.Type)
+			assert.NotNil(t, def.Payload, def.Type)
+		}
+		for _, eventType := range []domain.EventType{
+			domain.EventCommentAdded, domain.EventStatusUpdated, domain.EventTicketCreated,
+			domain.EventTicketAssigned, domain.EventTicketUpdated,
+		} {
+			assert.True(t, seen[eventType], eventType)
+		}
+	})
+
+	t.Run("publishes every subscribable webhook event", func(t *testing.T) {
+		published := map[domain.WebhookEventType]bool{}
+		for _, def := range domain.EventCatalog {
+			published[def.Webhook] = true
+		}
+		for _, eventType := range domain.SubscribableWebhookEvents {
+			assert.True(t, published[eventType], eventType)
+		}
+	})
+}
+
+func TestNewTicketEvent(t *testing.T) {
+	actorID := uuid.New()
+	ticket := &domain.Ticket{
+		ID:          7,
+		Title:       "VPN down",
+		Status:      domain.StatusOpen,
+		RequesterID: uuid.New(),
+		CreatedAt:   time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("records the current version with the ticket as payload", func(t *testing.T) {
+		event, err := domain.NewTicketEvent(domain.EventStatusUpdated, ticket, actorID)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(7), event.TicketID)
+		assert.Equal(t, domain.EventStatusUpdated, event.Type)
+		assert.Equal(t, 1, event.Version)
+		assert.Equal(t, actorID, event.ActorID)
+
+		payload, err := event.DecodePayload()
+		require.NoError(t, err)
+		assert.Equal(t, domain.NewTicketSnapshot(ticket), payload)
+	})
+
+	t.Run("rejects event types whose payload is not a ticket", func(t *testing.T) {
+		_, err := domain.NewTicketEvent(domain.EventCommentAdded, ticket, actorID)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects unknown event types", func(t *testing.T) {
+		_, err := domain.NewTicketEvent(domain.EventType("TICKET_EXPLODED"), ticket, actorID)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestNewCommentAddedEvent(t *testing.T) {
+	comment := &domain.Comment{ID: 3, TicketID: 7, AuthorID: uuid.New(), Body: "Still down", CreatedAt: time.Now()}
+
+	event, err := domain.NewCommentAddedEvent(comment, comment.AuthorID)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(7), event.TicketID)
+	payload, err := event.DecodePayload()
+	require.NoError(t, err)
+	assert.Equal(t, domain.NewCommentSnapshot(comment), payload)
+}
+
+func TestEvent_DecodePayload(t *testing.T) {
+	t.Run("rejects versions it does not know", func(t *testing.T) {
+		event := &domain.Event{Type: domain.EventCommentAdded, Version: 99, Payload: []byte(`{}`)}
+
+		_, err := event.DecodePayload()
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,304 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+// MaxInboundHookSourceLength is the maximum length of an inbound hook's source
+const MaxInboundHookSourceLength = 64
+
+// inboundHookSourcePattern is what a source may look like, as it becomes
+// part of the hook's URL.
+var inboundHookSourcePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// InboundHookFormat identifies the kind of payload an inbound hook receives,
+// which decides its default field mapping.
+type InboundHookFormat string
+
+const (
+	InboundHookAlertmanager InboundHookFormat = "alertmanager"
+	InboundHookGrafana      InboundHookFormat = "grafana"
+	InboundHookSentry       InboundHookFormat = "sentry"
+	InboundHookGeneric      InboundHookFormat = "generic"
+)
+
+// IsValid checks if the format is supported
+func (f InboundHookFormat) IsValid() bool {
+	_, ok := defaultInboundHookMappings[f]
+	return ok
+}
+
+// InboundHookMapping says where in a payload the fields of a ticket are
+// found. Paths are dot-separated, with numbers indexing into arrays, e.g.
+// "alerts.0.labels.alertname". Payloads whose SkipPath holds one of
+// SkipValues, such as resolved alerts, don't create a ticket.
+type InboundHookMapping struct {
+	Title           string                    `json:"title,omitempty"`
+	Description     string                    `json:"description,omitempty"`
+	Priority        string                    `json:"priority,omitempty"`
+	PriorityMap     map[string]TicketPriority `json:"priorityMap,omitempty"`
+	DefaultPriority TicketPriority            `json:"defaultPriority,omitempty"`
+	SkipPath        string                    `json:"skipPath,omitempty"`
+	SkipValues      []string                  `json:"skipValues,omitempty"`
+}
+
+// defaultInboundHookMappings are the mappings of each format. A hook's own
+// mapping overrides them field by field.
+var defaultInboundHookMappings = map[InboundHookFormat]InboundHookMapping{
+	InboundHookAlertmanager: {
+		Title:           "commonLabels.alertname",
+		Description:     "commonAnnotations.description",
+		Priority:        "commonLabels.severity",
+		PriorityMap:     map[string]TicketPriority{"critical": PriorityHigh, "warning": PriorityMedium, "info": PriorityLow},
+		DefaultPriority: PriorityHigh,
+		SkipPath:        "status",
+		SkipValues:      []string{"resolved"},
+	},
+	InboundHookGrafana: {
+		Title:           "title",
+		Description:     "message",
+		Priority:        "commonLabels.severity",
+		PriorityMap:     map[string]TicketPriority{"critical": PriorityHigh, "warning": PriorityMedium, "info": PriorityLow},
+		DefaultPriority: PriorityHigh,
+		SkipPath:        "status",
+		SkipValues:      []string{"resolved"},
+	},
+	InboundHookSentry: {
+		Title:           "data.event.title",
+		Description:     "data.event.web_url",
+		Priority:        "data.event.level",
+		PriorityMap:     map[string]TicketPriority{"fatal": PriorityHigh, "error": PriorityHigh, "warning": PriorityMedium, "info": PriorityLow, "debug": PriorityLow},
+		DefaultPriority: PriorityMedium,
+	},
+	InboundHookGeneric: {
+		Title:           "title",
+		Description:     "description",
+		Priority:        "priority",
+		DefaultPriority: PriorityMedium,
+	},
+}
+
+// merged returns the format's default mapping with m's fields laid over it.
+func (m InboundHookMapping) merged(format InboundHookFormat) InboundHookMapping {
+	result := defaultInboundHookMappings[format]
+	if m.Title != "" {
+		result.Title = m.Title
+	}
+	if m.Description != "" {
+		result.Description = m.Description
+	}
+	if m.Priority != "" {
+		result.Priority = m.Priority
+	}
+	if len(m.PriorityMap) > 0 {
+		result.PriorityMap = m.PriorityMap
+	}
+	if m.DefaultPriority != "" {
+		result.DefaultPriority = m.DefaultPriority
+	}
+	if m.SkipPath != "" {
+		result.SkipPath = m.SkipPath
+		result.SkipValues = m.SkipValues
+	}
+	return result
+}
+
+// InboundHook is an endpoint an external system, such as an alerting tool,
+// posts to in order to open tickets. Tickets are raised on behalf of
+// RequesterID.
+type InboundHook struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Source         string
+	Format         InboundHookFormat
+	Secret         string
+	Mapping        InboundHookMapping
+	RequesterID    uuid.UUID
+	IsActive       bool
+	CreatedBy      uuid.UUID
+	CreatedAt      time.Time
+}
+
+// InboundHookParams holds the caller-supplied fields of a new inbound hook
+type InboundHookParams struct {
+	Source      string
+	Format      InboundHookFormat
+	Mapping     InboundHookMapping
+	RequesterID uuid.UUID
+}
+
+// Validate validates inbound hook parameters
+func (p *InboundHookParams) Validate() error {
+	errs := apperrors.NewValidationErrors()
+
+	if p.Source == "" {
+		errs.Add("source", "Source is required")
+	} else if len(p.Source) > MaxInboundHookSourceLength {
+		errs.Add("source", "Source is too long")
+	} else if !inboundHookSourcePattern.MatchString(p.Source) {
+		errs.Add("source", "Source may only contain lowercase letters, digits and dashes")
+	}
+
+	if !p.Format.IsValid() {
+		errs.Add("format", "Unknown format: "+string(p.Format))
+	}
+
+	if p.RequesterID == uuid.Nil {
+		errs.Add("requesterId", "Requester is required")
+	}
+
+	for value, priority := range p.Mapping.PriorityMap {
+		if !priority.IsValid() {
+			errs.Add("mapping.priorityMap", "Invalid priority for "+value+": "+string(priority))
+		}
+	}
+	if p.Mapping.DefaultPriority != "" && !p.Mapping.DefaultPriority.IsValid() {
+		errs.Add("mapping.defaultPriority", "Invalid priority: "+string(p.Mapping.DefaultPriority))
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// NewInboundHook validates params and creates an active inbound hook with a
+// fresh signing secret
+func NewInboundHook(params InboundHookParams, orgID, createdBy uuid.UUID) (*InboundHook, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	return &InboundHook{
+		OrganizationID: orgID,
+		Source:         params.Source,
+		Format:         params.Format,
+		Secret:         hex.EncodeToString(secret),
+		Mapping:        params.Mapping,
+		RequesterID:    params.RequesterID,
+		IsActive:       true,
+		CreatedBy:      createdBy,
+	}, nil
+}
+
+// VerifySignature checks signature, the hex-encoded HMAC-SHA256 of body keyed
+// with the hook's secret. A "sha256=" prefix is accepted.
+func (h *InboundHook) VerifySignature(signature string, body []byte) bool {
+	got, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(signature), "sha256="))
+	if err != nil || len(got) == 0 {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(h.Secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// MapPayload maps a JSON payload to the parameters of the ticket it raises.
+// It returns false if the payload should not raise a ticket. A payload
+// without a title is given one naming the hook.
+func (h *InboundHook) MapPayload(payload []byte) (TicketParams, bool, error) {
+	var document any
+	if err := json.Unmarshal(payload, &document); err != nil {
+		return TicketParams{}, false, apperrors.ErrInvalidPayload
+	}
+	mapping := h.Mapping.merged(h.Format)
+
+	if mapping.SkipPath != "" {
+		if value, ok := lookupPath(document, mapping.SkipPath); ok {
+			for _, skip := range mapping.SkipValues {
+				if strings.EqualFold(value, skip) {
+					return TicketParams{}, false, nil
+				}
+			}
+		}
+	}
+
+	title, _ := lookupPath(document, mapping.Title)
+	if title = strings.TrimSpace(title); title == "" {
+		title = "Alert from " + h.Source
+	}
+	description, _ := lookupPath(document, mapping.Description)
+
+	priority := mapping.DefaultPriority
+	if value, ok := lookupPath(document, mapping.Priority); ok {
+		if mapped, ok := mapping.PriorityMap[strings.ToLower(value)]; ok {
+			priority = mapped
+		} else if direct := TicketPriority(strings.ToUpper(value)); direct.IsValid() {
+			priority = direct
+		}
+	}
+
+	return TicketParams{
+		Title:       truncate(title, MaxTitleLength),
+		Description: truncate(description, MaxDescriptionLength),
+		Priority:    priority,
+		RequesterID: h.RequesterID,
+	}, true, nil
+}
+
+// lookupPath returns the value at a dot-separated path as a string.
+func lookupPath(document any, path string) (string, bool) {
+	if path == "" {
+		return "", false
+	}
+
+	current := document
+	for _, segment := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]any:
+			value, ok := node[segment]
+			if !ok {
+				return "", false
+			}
+			current = value
+		case []any:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return "", false
+			}
+			current = node[index]
+		default:
+			return "", false
+		}
+	}
+
+	switch value := current.(type) {
+	case string:
+		return value, true
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(value), true
+	default:
+		return "", false
+	}
+}
+
+// truncate shortens s to at most max bytes without splitting a character.
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return s[:max]
+}
@@ -0,0 +1,147 @@
+package domain_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInboundHook(t *testing.T) {
+	orgID, adminID, requesterID := uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("creates an active hook with a secret", func(t *testing.T) {
+		hook, err := domain.NewInboundHook(domain.InboundHookParams{
+			Source:      "prod-alerts",
+			Format:      domain.InboundHookAlertmanager,
+			RequesterID: requesterID,
+		}, orgID, adminID)
+
+		require.NoError(t, err)
+		assert.True(t, hook.IsActive)
+		assert.Len(t, hook.Secret, 64)
+		assert.Equal(t, orgID, hook.OrganizationID)
+	})
+
+	t.Run("validates params", func(t *testing.T) {
+		_, err := domain.NewInboundHook(domain.InboundHookParams{
+			Source:  "Prod Alerts",
+			Format:  "nagios",
+			Mapping: domain.InboundHookMapping{PriorityMap: map[string]domain.TicketPriority{"p1": "URGENT"}},
+		}, orgID, adminID)
+
+		var errs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Contains(t, errs.Errors, "source")
+		assert.Contains(t, errs.Errors, "format")
+		assert.Contains(t, errs.Errors, "requesterId")
+		assert.Contains(t, errs.Errors, "mapping.priorityMap")
+	})
+}
+
+func TestInboundHook_VerifySignature(t *testing.T) {
+	hook := &domain.InboundHook{Secret: "s3cret"}
+	body := []byte(`{"title":"Disk full"}`)
+
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(body)
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	assert.True(t, hook.VerifySignature(signature, body))
+	assert.True(t, hook.VerifySignature("sha256="+signature, body))
+	assert.False(t, hook.VerifySignature(signature, []byte(`{"title":"Disk fine"}`)))
+	assert.False(t, hook.VerifySignature("", body))
+	assert.False(t, hook.VerifySignature("not-hex", body))
+}
+
+func TestInboundHook_MapPayload(t *testing.T) {
+	requesterID := uuid.New()
+
+	t.Run("alertmanager", func(t *testing.T) {
+		hook := &domain.InboundHook{Source: "prod", Format: domain.InboundHookAlertmanager, RequesterID: requesterID}
+
+		params, ok, err := hook.MapPayload([]byte(`{
+			"status": "firing",
+			"commonLabels": {"alertname": "HighErrorRate", "severity": "warning"},
+			"commonAnnotations": {"description": "5xx above 5% for 10m"}
+		}`))
+
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, domain.TicketParams{
+			Title:       "HighErrorRate",
+			Description: "5xx above 5% for 10m",
+			Priority:    domain.PriorityMedium,
+			RequesterID: requesterID,
+		}, params)
+	})
+
+	t.Run("resolved alerts are skipped", func(t *testing.T) {
+		hook := &domain.InboundHook{Source: "prod", Format: domain.InboundHookGrafana, RequesterID: requesterID}
+
+		_, ok, err := hook.MapPayload([]byte(`{"status": "resolved", "title": "[RESOLVED] HighErrorRate"}`))
+
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("sentry", func(t *testing.T) {
+		hook := &domain.InboundHook{Source: "sentry", Format: domain.InboundHookSentry, RequesterID: requesterID}
+
+		params, ok, err := hook.MapPayload([]byte(`{"data": {"event": {"title": "TypeError: x is undefined", "level": "error", "web_url": "https://sentry.example.com/1"}}}`))
+
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, "TypeError: x is undefined", params.Title)
+		assert.Equal(t, "https://sentry.example.com/1", params.Description)
+		assert.Equal(t, domain.PriorityHigh, params.Priority)
+	})
+
+	t.Run("custom mapping overrides the format's", func(t *testing.T) {
+		hook := &domain.InboundHook{
+			Source:      "monitor",
+			Format:      domain.InboundHookGeneric,
+			RequesterID: requesterID,
+			Mapping: domain.InboundHookMapping{
+				Title:       "alerts.0.name",
+				Priority:    "alerts.0.level",
+				PriorityMap: map[string]domain.TicketPriority{"1": domain.PriorityHigh},
+			},
+		}
+
+		params, ok, err := hook.MapPayload([]byte(`{"description": "from the default path", "alerts": [{"name": "Queue backlog", "level": 1}]}`))
+
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, "Queue backlog", params.Title)
+		assert.Equal(t, "from the default path", params.Description)
+		assert.Equal(t, domain.PriorityHigh, params.Priority)
+	})
+
+	t.Run("fallbacks", func(t *testing.T) {
+		hook := &domain.InboundHook{Source: "monitor", Format: domain.InboundHookGeneric, RequesterID: requesterID}
+
+		params, ok, err := hook.MapPayload([]byte(`{"priority": "low", "description": "` + strings.Repeat("x", domain.MaxDescriptionLength+10) + `"}`))
+
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, "Alert from monitor", params.Title)
+		assert.Equal(t, domain.PriorityLow, params.Priority)
+		assert.Len(t, params.Description, domain.MaxDescriptionLength)
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		hook := &domain.InboundHook{Source: "monitor", Format: domain.InboundHookGeneric}
+
+		_, _, err := hook.MapPayload([]byte(`not json`))
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidPayload)
+	})
+}
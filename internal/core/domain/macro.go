@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+const (
+	// MaxMacroNameLength is the maximum length of a macro's name
+	MaxMacroNameLength = 100
+	// MaxMacroActions caps how many actions a macro can take
+	MaxMacroActions = 10
+)
+
+// MacroActionType is one kind of change a macro makes to a ticket.
+type MacroActionType string
+
+const (
+	// MacroSetStatus changes the ticket's status to Status.
+	MacroSetStatus MacroActionType = "SET_STATUS"
+	// MacroSetPriority changes the ticket's priority to Priority.
+	MacroSetPriority MacroActionType = "SET_PRIORITY"
+	// MacroAssign assigns the ticket to AssigneeID.
+	MacroAssign MacroActionType = "ASSIGN"
+	// MacroAddTags adds Tags to the ticket.
+	MacroAddTags MacroActionType = "ADD_TAGS"
+	// MacroReply comments on the ticket with the canned reply in Body.
+	MacroReply MacroActionType = "REPLY"
+)
+
+// MacroAction is one step of a macro. Only the field its type needs is set.
+type MacroAction struct {
+	Type       MacroActionType `json:"type"`
+	Status     TicketStatus    `json:"status,omitempty"`
+	Priority   TicketPriority  `json:"priority,omitempty"`
+	AssigneeID *uuid.UUID      `json:"assigneeId,omitempty"`
+	Tags       []string        `json:"tags,omitempty"`
+	Body       string          `json:"body,omitempty"`
+}
+
+// Macro bundles ticket changes and a canned reply that agents apply to a
+// ticket in one step. Names are unique within an organization.
+type Macro struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Name           string
+	Actions        []MacroAction
+	CreatedBy      uuid.UUID
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// MacroParams holds the configurable fields of a macro
+type MacroParams struct {
+	Name    string
+	Actions []MacroAction
+}
+
+// Validate validates macro parameters, trimming the name and normalizing
+// tags. Each action type can be used once per macro.
+func (p *MacroParams) Validate() error {
+	errs := apperrors.NewValidationErrors()
+
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		errs.Add("name", "Name is required")
+	} else if len(p.Name) > MaxMacroNameLength {
+		errs.Add("name", "Name must be 100 characters or less")
+	}
+
+	if len(p.Actions) == 0 {
+		errs.Add("actions", "At least one action is required")
+	} else if len(p.Actions) > MaxMacroActions {
+		errs.Add("actions", fmt.Sprintf("At most %d actions are allowed", MaxMacroActions))
+	}
+
+	seen := make(map[MacroActionType]bool, len(p.Actions))
+	for i := range p.Actions {
+		action := &p.Actions[i]
+		if seen[action.Type] {
+			errs.Add("actions", fmt.Sprintf("Action %d repeats %s", i+1, action.Type))
+		}
+		seen[action.Type] = true
+
+		if msg := action.normalize(); msg != "" {
+			errs.Add("actions", fmt.Sprintf("Action %d %s", i+1, msg))
+		}
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// normalize normalizes the action's tags and reply and returns what is
+// wrong with the action, if anything.
+func (a *MacroAction) normalize() string {
+	switch a.Type {
+	case MacroSetStatus:
+		if !a.Status.IsValid() {
+			return "needs a status of OPEN, IN_PROGRESS or CLOSED"
+		}
+	case MacroSetPriority:
+		if !a.Priority.IsValid() {
+			return "needs a priority of LOW, MEDIUM or HIGH"
+		}
+	case MacroAssign:
+		if a.AssigneeID == nil || *a.AssigneeID == uuid.Nil {
+			return "needs an assigneeId"
+		}
+	case MacroAddTags:
+		if len(a.Tags) == 0 {
+			return "needs at least one tag"
+		}
+		if len(a.Tags) > MaxTicketTags {
+			return fmt.Sprintf("can add at most %d tags", MaxTicketTags)
+		}
+		for i, tag := range a.Tags {
+			a.Tags[i] = NormalizeTag(tag)
+			if msg := validateTag(a.Tags[i]); msg != "" {
+				return "has an invalid tag: " + msg
+			}
+		}
+	case MacroReply:
+		a.Body = strings.TrimSpace(a.Body)
+		if a.Body == "" {
+			return "needs a body"
+		}
+		if len(a.Body) > MaxCommentBodyLength {
+			return fmt.Sprintf("has a body longer than %d characters", MaxCommentBodyLength)
+		}
+	default:
+		return "has an unknown type: " + string(a.Type)
+	}
+	return ""
+}
+
+// NewMacro validates params and creates a macro
+func NewMacro(params MacroParams, orgID, createdBy uuid.UUID) (*Macro, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Macro{
+		OrganizationID: orgID,
+		Name:           params.Name,
+		Actions:        params.Actions,
+		CreatedBy:      createdBy,
+	}, nil
+}
+
+// Update replaces the macro's name and actions with params
+func (m *Macro) Update(params MacroParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	m.Name = params.Name
+	m.Actions = params.Actions
+	return nil
+}
+
+// TicketChanges returns the changes the macro makes to a ticket and its
+// canned reply, which is empty if it has none.
+func (m *Macro) TicketChanges() (TicketChanges, string) {
+	var (
+		changes TicketChanges
+		reply   string
+	)
+	for _, action := range m.Actions {
+		switch action.Type {
+		case MacroSetStatus:
+			status := action.Status
+			changes.Status = &status
+		case MacroSetPriority:
+			priority := action.Priority
+			changes.Priority = &priority
+		case MacroAssign:
+			assigneeID := *action.AssigneeID
+			changes.AssigneeID = &assigneeID
+		case MacroAddTags:
+			changes.AddTags = append(changes.AddTags, action.Tags...)
+		case MacroReply:
+			reply = action.Body
+		}
+	}
+	return changes, reply
+}
+
+// AuditSnapshot returns the macro in the shape recorded in the audit log.
+func (m *Macro) AuditSnapshot() map[string]any {
+	return map[string]any{
+		"name":    m.Name,
+		"actions": m.Actions,
+	}
+}
@@ -0,0 +1,79 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMacroParams_Validate(t *testing.T) {
+	t.Run("accepts valid params and normalizes them", func(t *testing.T) {
+		params := domain.MacroParams{
+			Name: "  Close as duplicate  ",
+			Actions: []domain.MacroAction{
+				{Type: domain.MacroSetStatus, Status: domain.StatusClosed},
+				{Type: domain.MacroAddTags, Tags: []string{" Duplicate "}},
+				{Type: domain.MacroReply, Body: "  Closing as a duplicate.  "},
+			},
+		}
+
+		require.NoError(t, params.Validate())
+		assert.Equal(t, "Close as duplicate", params.Name)
+		assert.Equal(t, []string{"duplicate"}, params.Actions[1].Tags)
+		assert.Equal(t, "Closing as a duplicate.", params.Actions[2].Body)
+	})
+
+	tests := []struct {
+		name  string
+		macro domain.MacroParams
+		field string
+	}{
+		{"blank name", domain.MacroParams{Name: " ", Actions: []domain.MacroAction{{Type: domain.MacroSetPriority, Priority: domain.PriorityHigh}}}, "name"},
+		{"no actions", domain.MacroParams{Name: "Escalate"}, "actions"},
+		{"repeated action type", domain.MacroParams{Name: "Escalate", Actions: []domain.MacroAction{
+			{Type: domain.MacroSetPriority, Priority: domain.PriorityHigh},
+			{Type: domain.MacroSetPriority, Priority: domain.PriorityLow},
+		}}, "actions"},
+		{"unknown action type", domain.MacroParams{Name: "Escalate", Actions: []domain.MacroAction{{Type: "DELETE"}}}, "actions"},
+		{"status missing", domain.MacroParams{Name: "Escalate", Actions: []domain.MacroAction{{Type: domain.MacroSetStatus}}}, "actions"},
+		{"assignee missing", domain.MacroParams{Name: "Escalate", Actions: []domain.MacroAction{{Type: domain.MacroAssign}}}, "actions"},
+		{"tags missing", domain.MacroParams{Name: "Escalate", Actions: []domain.MacroAction{{Type: domain.MacroAddTags}}}, "actions"},
+		{"blank reply", domain.MacroParams{Name: "Escalate", Actions: []domain.MacroAction{{Type: domain.MacroReply, Body: " "}}}, "actions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.macro.Validate()
+
+			var errs *apperrors.ValidationErrors
+			require.ErrorAs(t, err, &errs)
+			assert.Contains(t, errs.Errors, tt.field)
+		})
+	}
+}
+
+func TestMacro_TicketChanges(t *testing.T) {
+	assigneeID := uuid.New()
+	macro := &domain.Macro{Actions: []domain.MacroAction{
+		{Type: domain.MacroSetStatus, Status: domain.StatusInProgress},
+		{Type: domain.MacroSetPriority, Priority: domain.PriorityHigh},
+		{Type: domain.MacroAssign, AssigneeID: &assigneeID},
+		{Type: domain.MacroAddTags, Tags: []string{"billing"}},
+		{Type: domain.MacroReply, Body: "We're on it."},
+	}}
+
+	changes, reply := macro.TicketChanges()
+
+	require.NotNil(t, changes.Status)
+	assert.Equal(t, domain.StatusInProgress, *changes.Status)
+	require.NotNil(t, changes.Priority)
+	assert.Equal(t, domain.PriorityHigh, *changes.Priority)
+	require.NotNil(t, changes.AssigneeID)
+	assert.Equal(t, assigneeID, *changes.AssigneeID)
+	assert.Equal(t, []string{"billing"}, changes.AddTags)
+	assert.Equal(t, "We're on it.", reply)
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+// Organization is a tenant of the service desk. PortalSlug is "" when its
+// public portal is off.
+type Organization struct {
+	ID         uuid.UUID
+	Name       string
+	PortalSlug string
+	CreatedAt  time.Time
+}
+
+// OrganizationChoiceError is returned by a login that names no organization
+// when the credentials match accounts in several. The user picks one of
+// Organizations and logs in again naming it.
+type OrganizationChoiceError struct {
+	Organizations []*Organization
+}
+
+func (e *OrganizationChoiceError) Error() string {
+	return apperrors.ErrOrganizationRequired.Error()
+}
+
+func (e *OrganizationChoiceError) Unwrap() error {
+	return apperrors.ErrOrganizationRequired
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+const (
+	// DefaultPageLimit is the page size used when a request sets none
+	DefaultPageLimit = 25
+	// MaxPageLimit caps how many rows a keyset page returns
+	MaxPageLimit = 100
+)
+
+// PageCursor marks the last row of a keyset page. Keyset pages are ordered
+// by creation time and then ID, so the next page starts right after the
+// row the cursor points at, however many rows were added in the meantime.
+type PageCursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        string    `json:"i"`
+}
+
+// Encode returns the cursor as an opaque token clients pass back to fetch
+// the next page.
+func (c PageCursor) Encode() string {
+	payload, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// DecodePageCursor parses a token returned by PageCursor.Encode. An empty
+// token means the first page and decodes to nil.
+func DecodePageCursor(token string) (*PageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	var cursor PageCursor
+	payload, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		err = json.Unmarshal(payload, &cursor)
+	}
+	if err != nil || cursor.ID == "" || cursor.CreatedAt.IsZero() {
+		errs := apperrors.NewValidationErrors()
+		errs.Add("cursor", "Invalid cursor")
+		return nil, errs
+	}
+	return &cursor, nil
+}
+
+// PageRequest asks for the page of at most Limit rows after the row After
+// points at, or for the first page if After is nil.
+type PageRequest struct {
+	After *PageCursor
+	Limit int
+}
+
+// PageLimit returns the request's limit, defaulting to DefaultPageLimit and
+// capped at MaxPageLimit.
+func (p PageRequest) PageLimit() int {
+	switch {
+	case p.Limit < 1:
+		return DefaultPageLimit
+	case p.Limit > MaxPageLimit:
+		return MaxPageLimit
+	}
+	return p.Limit
+}
+
+// Page is one page of a keyset listing. NextCursor is nil on the last page.
+type Page[T any] struct {
+	Items      []T
+	NextCursor *PageCursor
+}
+
+// Total is how many rows a listing matches. Approximate totals are the
+// database's estimate, returned when counting exactly took too long.
+type Total struct {
+	Count       int64
+	Approximate bool
+}
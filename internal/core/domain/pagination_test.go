@@ -0,0 +1,50 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageCursor_EncodeDecode(t *testing.T) {
+	t.Run("round trips", func(t *testing.T) {
+		cursor := domain.PageCursor{
+			CreatedAt: time.Date(2024, 3, 1, 12, 30, 0, 123456000, time.UTC),
+			ID:        "42",
+		}
+
+		decoded, err := domain.DecodePageCursor(cursor.Encode())
+
+		require.NoError(t, err)
+		require.NotNil(t, decoded)
+		assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+		assert.Equal(t, "42", decoded.ID)
+	})
+
+	t.Run("an empty token is the first page", func(t *testing.T) {
+		decoded, err := domain.DecodePageCursor("")
+
+		require.NoError(t, err)
+		assert.Nil(t, decoded)
+	})
+
+	for _, token := range []string{"not base64!", "bm90IGpzb24", domain.PageCursor{ID: "42"}.Encode()} {
+		t.Run("rejects "+token, func(t *testing.T) {
+			_, err := domain.DecodePageCursor(token)
+
+			var errs *apperrors.ValidationErrors
+			require.ErrorAs(t, err, &errs)
+			assert.Contains(t, errs.Errors, "cursor")
+		})
+	}
+}
+
+func TestPageRequest_PageLimit(t *testing.T) {
+	assert.Equal(t, domain.DefaultPageLimit, domain.PageRequest{}.PageLimit())
+	assert.Equal(t, 10, domain.PageRequest{Limit: 10}.PageLimit())
+	assert.Equal(t, domain.MaxPageLimit, domain.PageRequest{Limit: 1000}.PageLimit())
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// OrganizationHealthWindow is how far back the recent activity in an
+// organization's health is counted.
+const OrganizationHealthWindow = 7 * 24 * time.Hour
+
+// OrganizationFilter narrows a listing of every organization. Query matches
+// the name or portal slug.
+type OrganizationFilter struct {
+	Query  string
+	Limit  int
+	Offset int
+}
+
+// OrganizationSummary is an organization with its current usage, as listed
+// to super-admins.
+type OrganizationSummary struct {
+	Organization Organization
+	Usage        OrgUsage
+}
+
+// OrganizationHealth summarizes how an organization's service desk is
+// doing. Counts of recent activity start at Since.
+type OrganizationHealth struct {
+	Since time.Time
+	// LastActiveAt is the latest activity of any of the organization's
+	// users, nil if none has been recorded.
+	LastActiveAt             *time.Time
+	TicketsCreated           int64
+	OverdueTickets           int64
+	PendingWebhookDeliveries int64
+	DeadWebhookDeliveries    int64
+}
+
+// OrganizationOverview is everything a super-admin sees of one
+// organization.
+type OrganizationOverview struct {
+	Organization Organization
+	Quota        QuotaReport
+	Health       OrganizationHealth
+}
@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"encoding/binary"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+// GuestRole is the role of requesters created by public portal submissions.
+// Guests have no password, so they can only act through a portal link.
+const GuestRole = "guest"
+
+// MaxPortalSlugLength caps the length of an organization's portal slug
+const MaxPortalSlugLength = 64
+
+// portalSlugPattern matches lowercase slugs such as acme or acme-support
+var portalSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// reservedPortalSlugs are path segments the portal routes use themselves
+var reservedPortalSlugs = map[string]bool{"tickets": true}
+
+// NormalizePortalSlug validates an organization's portal slug and returns it
+// lower-cased. An empty slug turns the organization's portal off.
+func NormalizePortalSlug(slug string) (string, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if slug == "" {
+		return "", nil
+	}
+
+	if len(slug) > MaxPortalSlugLength || !portalSlugPattern.MatchString(slug) {
+		errs := apperrors.NewValidationErrors()
+		errs.Add("slug", "Slug must be at most 64 lowercase letters, digits and single hyphens")
+		return "", errs
+	}
+	if reservedPortalSlugs[slug] {
+		errs := apperrors.NewValidationErrors()
+		errs.Add("slug", "This slug is reserved")
+		return "", errs
+	}
+	return slug, nil
+}
+
+// PortalSubmission is a ticket submitted anonymously through an
+// organization's public portal.
+type PortalSubmission struct {
+	FullName    string
+	Email       string
+	Title       string
+	Description string
+}
+
+// Validate validates a portal submission. The ticket itself is validated
+// again when it is created.
+func (s *PortalSubmission) Validate() error {
+	errs := apperrors.NewValidationErrors()
+
+	if s.FullName == "" {
+		errs.Add("fullName", "Full name is required")
+	} else if len(s.FullName) > MaxFullNameLength {
+		errs.Add("fullName", "Full name must be 255 characters or less")
+	}
+
+	if s.Email == "" {
+		errs.Add("email", "Email is required")
+	} else if len(s.Email) > MaxEmailLength {
+		errs.Add("email", "Email must be 255 characters or less")
+	} else if !isValidEmail(s.Email) {
+		errs.Add("email", "Invalid email format")
+	}
+
+	if s.Title == "" {
+		errs.Add("title", "Title is required")
+	} else if len(s.Title) > MaxTitleLength {
+		errs.Add("title", "Title must be 255 characters or less")
+	}
+
+	if len(s.Description) > MaxDescriptionLength {
+		errs.Add("description", "Description must be 10,000 characters or less")
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// NewGuestUser creates the requester of a portal submission. It has no
+// password hash, so no password can ever log in as it.
+func NewGuestUser(fullName, email string, orgID uuid.UUID) *User {
+	return &User{
+		ID:             uuid.New(),
+		OrganizationID: orgID,
+		FullName:       fullName,
+		Email:          email,
+		CreatedAt:      time.Now().UTC(),
+		IsActive:       true,
+	}
+}
+
+// portalLinkPurpose separates portal link signatures from other tokens
+// signed with the same secret
+const portalLinkPurpose = "portal-link:"
+
+// portalLinkPayloadSize is the size of a portal link's signed payload: the
+// ticket ID, the requester ID and the expiry
+const portalLinkPayloadSize = 8 + 16 + 8
+
+// PortalLink gives a guest access to one of their tickets until it expires.
+type PortalLink struct {
+	TicketID    int64
+	RequesterID uuid.UUID
+	ExpiresAt   time.Time
+}
+
+// Sign returns the link's token, signed with secret.
+func (l PortalLink) Sign(secret []byte) string {
+	payload := make([]byte, portalLinkPayloadSize)
+	binary.BigEndian.PutUint64(payload[:8], uint64(l.TicketID))
+	copy(payload[8:24], l.RequesterID[:])
+	binary.BigEndian.PutUint64(payload[24:], uint64(l.ExpiresAt.Unix()))
+
+	return signToken(secret, portalLinkPurpose, payload)
+}
+
+// ParsePortalLink returns the link a token was signed for. It returns
+// apperrors.ErrInvalidPortalLink if the token was not signed with secret or
+// has expired.
+func ParsePortalLink(secret []byte, token string, now time.Time) (PortalLink, error) {
+	payload, ok := verifyToken(secret, portalLinkPurpose, token)
+	if !ok || len(payload) != portalLinkPayloadSize {
+		return PortalLink{}, apperrors.ErrInvalidPortalLink
+	}
+
+	link := PortalLink{
+		TicketID:    int64(binary.BigEndian.Uint64(payload[:8])),
+		RequesterID: uuid.UUID(payload[8:24]),
+		ExpiresAt:   time.Unix(int64(binary.BigEndian.Uint64(payload[24:])), 0).UTC(),
+	}
+	if !now.Before(link.ExpiresAt) {
+		return PortalLink{}, apperrors.ErrInvalidPortalLink
+	}
+	return link, nil
+}
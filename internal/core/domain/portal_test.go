@@ -0,0 +1,95 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizePortalSlug(t *testing.T) {
+	slug, err := domain.NormalizePortalSlug(" Acme-Support ")
+	require.NoError(t, err)
+	assert.Equal(t, "acme-support", slug)
+
+	slug, err = domain.NormalizePortalSlug("")
+	require.NoError(t, err)
+	assert.Empty(t, slug)
+
+	for _, invalid := range []string{"acme support", "-acme", "acme--support", "tickets", strings.Repeat("a", 65)} {
+		_, err := domain.NormalizePortalSlug(invalid)
+		var errs *apperrors.ValidationErrors
+		assert.ErrorAs(t, err, &errs, invalid)
+	}
+}
+
+func TestPortalSubmission_Validate(t *testing.T) {
+	valid := domain.PortalSubmission{FullName: "Jane Doe", Email: "jane@example.com", Title: "Printer is on fire"}
+	assert.NoError(t, valid.Validate())
+
+	invalid := domain.PortalSubmission{Email: "not-an-email", Description: strings.Repeat("x", domain.MaxDescriptionLength+1)}
+	err := invalid.Validate()
+
+	var errs *apperrors.ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	assert.Contains(t, errs.Errors, "fullName")
+	assert.Contains(t, errs.Errors, "email")
+	assert.Contains(t, errs.Errors, "title")
+	assert.Contains(t, errs.Errors, "description")
+}
+
+func TestNewGuestUser(t *testing.T) {
+	guest := domain.NewGuestUser("Jane Doe", "jane@example.com", uuid.New())
+
+	assert.True(t, guest.IsActive)
+	assert.Empty(t, guest.HashedPassword)
+	assert.False(t, guest.CheckPassword(""))
+}
+
+func TestPortalLink(t *testing.T) {
+	secret := []byte("s3cret")
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	link := domain.PortalLink{TicketID: 42, RequesterID: uuid.New(), ExpiresAt: now.Add(time.Hour)}
+	token := link.Sign(secret)
+
+	t.Run("round-trips", func(t *testing.T) {
+		parsed, err := domain.ParsePortalLink(secret, token, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, link, parsed)
+	})
+
+	t.Run("rejects another secret", func(t *testing.T) {
+		_, err := domain.ParsePortalLink([]byte("other"), token, now)
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidPortalLink)
+	})
+
+	t.Run("rejects a tampered payload", func(t *testing.T) {
+		other := domain.PortalLink{TicketID: 43, RequesterID: link.RequesterID, ExpiresAt: link.ExpiresAt}.Sign(secret)
+		payload, _, _ := strings.Cut(other, ".")
+		_, mac, _ := strings.Cut(token, ".")
+
+		_, err := domain.ParsePortalLink(secret, payload+"."+mac, now)
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidPortalLink)
+	})
+
+	t.Run("rejects an expired link", func(t *testing.T) {
+		_, err := domain.ParsePortalLink(secret, token, now.Add(time.Hour))
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidPortalLink)
+	})
+
+	t.Run("rejects garbage", func(t *testing.T) {
+		for _, garbage := range []string{"", "abc", "abc.def", "!!.!!"} {
+			_, err := domain.ParsePortalLink(secret, garbage, now)
+			assert.ErrorIs(t, err, apperrors.ErrInvalidPortalLink, garbage)
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package domain
+
+// OrgQuota limits what an organization can use. A nil limit is unlimited.
+type OrgQuota struct {
+	MaxUsers       *int
+	MaxOpenTickets *int
+}
+
+// Merge returns the quota with any unset limit taken from defaults.
+func (q OrgQuota) Merge(defaults OrgQuota) OrgQuota {
+	if q.MaxUsers == nil {
+		q.MaxUsers = defaults.MaxUsers
+	}
+	if q.MaxOpenTickets == nil {
+		q.MaxOpenTickets = defaults.MaxOpenTickets
+	}
+	return q
+}
+
+// AllowsUsers reports whether adding more users to the current number of
+// active users stays within the quota.
+func (q OrgQuota) AllowsUsers(current int64, adding int) bool {
+	return q.MaxUsers == nil || current+int64(adding) <= int64(*q.MaxUsers)
+}
+
+// AllowsOpenTicket reports whether one more ticket can be opened.
+func (q OrgQuota) AllowsOpenTicket(current int64) bool {
+	return q.MaxOpenTickets == nil || current < int64(*q.MaxOpenTickets)
+}
+
+// OrgUsage is what an organization currently uses. Deactivated users and
+// closed tickets do not count.
+type OrgUsage struct {
+	ActiveUsers int64
+	OpenTickets int64
+}
+
+// QuotaReport is an organization's usage alongside its quota.
+type QuotaReport struct {
+	Quota OrgQuota
+	Usage OrgUsage
+}
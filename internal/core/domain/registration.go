@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+// MaxRegistrationDomains caps how many email domains an organization can
+// allow to self-register
+const MaxRegistrationDomains = 100
+
+// emailDomainPattern matches a host name with at least two labels, e.g.
+// acme.com or mail.acme.co.uk
+var emailDomainPattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+// NormalizeEmailDomain lower-cases a domain and strips a leading "@", so
+// "@Acme.com" and "acme.com" are the same domain.
+func NormalizeEmailDomain(domain string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "@")
+}
+
+// NormalizeRegistrationDomains validates an organization's registration
+// allowlist and returns it normalized, sorted and without duplicates. An
+// empty allowlist lets anyone register.
+func NormalizeRegistrationDomains(domains []string) ([]string, error) {
+	errs := apperrors.NewValidationErrors()
+
+	if len(domains) > MaxRegistrationDomains {
+		errs.Add("domains", "At most 100 domains can be allowed")
+	}
+
+	seen := make(map[string]bool, len(domains))
+	normalized := make([]string, 0, len(domains))
+	for _, d := range domains {
+		d = NormalizeEmailDomain(d)
+		if !emailDomainPattern.MatchString(d) {
+			errs.Add("domains", "Invalid email domain: "+d)
+			continue
+		}
+		if !seen[d] {
+			seen[d] = true
+			normalized = append(normalized, d)
+		}
+	}
+
+	if errs.HasErrors() {
+		return nil, errs
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// EmailDomainAllowed reports whether an email address may register under
+// the allowlist. Only exact domains match, not their subdomains.
+func EmailDomainAllowed(email string, domains []string) bool {
+	if len(domains) == 0 {
+		return true
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	emailDomain := NormalizeEmailDomain(email[at+1:])
+	for _, d := range domains {
+		if d == emailDomain {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeRegistrationDomains(t *testing.T) {
+	t.Run("normalizes and removes duplicates", func(t *testing.T) {
+		domains, err := domain.NormalizeRegistrationDomains([]string{"@Acme.com", " example.org", "acme.com"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"acme.com", "example.org"}, domains)
+	})
+
+	t.Run("rejects invalid domains", func(t *testing.T) {
+		_, err := domain.NormalizeRegistrationDomains([]string{"acme", "user@acme.com"})
+
+		var validationErr *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, validationErr.Errors, "domains")
+	})
+}
+
+func TestEmailDomainAllowed(t *testing.T) {
+	allowlist := []string{"acme.com"}
+
+	assert.True(t, domain.EmailDomainAllowed("jane@example.com", nil))
+	assert.True(t, domain.EmailDomainAllowed("jane@ACME.com", allowlist))
+	assert.False(t, domain.EmailDomainAllowed("jane@example.com", allowlist))
+	assert.False(t, domain.EmailDomainAllowed("jane@mail.acme.com", allowlist))
+}
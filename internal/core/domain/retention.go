@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+const (
+	// MinRetentionDays is the shortest time closed tickets can be kept
+	MinRetentionDays = 30
+	// MaxRetentionDays is the longest retention that can be configured
+	MaxRetentionDays = 100 * 365
+	// DefaultRetentionGraceDays is how long purged tickets stay recoverable
+	// by default before they are deleted for good
+	DefaultRetentionGraceDays = 30
+	// MaxRetentionGraceDays caps the grace period
+	MaxRetentionGraceDays = 365
+)
+
+// RetentionPolicy decides how long an organization keeps closed tickets.
+// Once a ticket has been closed for ClosedTicketDays it is soft-deleted,
+// hiding it from the API, and GraceDays later it is deleted along with its
+// comments and history.
+type RetentionPolicy struct {
+	OrganizationID   uuid.UUID
+	ClosedTicketDays *int // nil keeps closed tickets forever
+	GraceDays        int
+	UpdatedAt        time.Time
+}
+
+// DefaultRetentionPolicy returns the policy of organizations that have not
+// configured one, which keeps everything.
+func DefaultRetentionPolicy(orgID uuid.UUID) *RetentionPolicy {
+	return &RetentionPolicy{
+		OrganizationID: orgID,
+		GraceDays:      DefaultRetentionGraceDays,
+	}
+}
+
+// Validate validates the policy
+func (p *RetentionPolicy) Validate() error {
+	errs := apperrors.NewValidationErrors()
+
+	if p.ClosedTicketDays != nil && (*p.ClosedTicketDays < MinRetentionDays || *p.ClosedTicketDays > MaxRetentionDays) {
+		errs.Add("closedTicketDays", "Closed tickets must be kept for between 30 and 36500 days")
+	}
+	if p.GraceDays < 0 || p.GraceDays > MaxRetentionGraceDays {
+		errs.Add("graceDays", "Grace period must be between 0 and 365 days")
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Enabled reports whether the policy purges anything
+func (p *RetentionPolicy) Enabled() bool {
+	return p.ClosedTicketDays != nil
+}
+
+// SoftDeleteCutoff returns the time before which closed tickets are due to
+// be soft-deleted. It must only be called on an enabled policy.
+func (p *RetentionPolicy) SoftDeleteCutoff(now time.Time) time.Time {
+	return now.AddDate(0, 0, -*p.ClosedTicketDays)
+}
+
+// HardDeleteCutoff returns the time before which soft-deleted tickets are
+// due to be deleted for good.
+func (p *RetentionPolicy) HardDeleteCutoff(now time.Time) time.Time {
+	return now.AddDate(0, 0, -p.GraceDays)
+}
+
+// AuditSnapshot returns the policy in the shape recorded in the audit log.
+func (p *RetentionPolicy) AuditSnapshot() map[string]any {
+	return map[string]any{
+		"closedTicketDays": p.ClosedTicketDays,
+		"graceDays":        p.GraceDays,
+	}
+}
+
+// PurgeCandidate is a closed ticket that a retention policy will remove.
+// DeletedAt is set once it has been soft-deleted.
+type PurgeCandidate struct {
+	TicketID     int64
+	Title        string
+	ClosedAt     time.Time
+	DeletedAt    *time.Time
+	SoftDeleteAt time.Time
+	HardDeleteAt time.Time
+}
+
+// Schedule fills in when the candidate is soft- and hard-deleted under the
+// policy. A ticket already soft-deleted keeps the time it was hidden.
+func (c *PurgeCandidate) Schedule(policy *RetentionPolicy) {
+	c.SoftDeleteAt = c.ClosedAt.AddDate(0, 0, *policy.ClosedTicketDays)
+	if c.DeletedAt != nil {
+		c.SoftDeleteAt = *c.DeletedAt
+	}
+	c.HardDeleteAt = c.SoftDeleteAt.AddDate(0, 0, policy.GraceDays)
+}
+
+// RetentionReport summarizes one retention run for an organization. In a
+// dry run the counts are what would have been deleted.
+type RetentionReport struct {
+	OrganizationID uuid.UUID
+	SoftDeleted    int64
+	HardDeleted    int64
+	DryRun         bool
+}
@@ -0,0 +1,31 @@
+package domain
+
+// RuntimeConfig is the part of the service configuration that can be changed
+// without a restart, by sending the process SIGHUP or through the admin API.
+type RuntimeConfig struct {
+	LogLevel    string
+	RateLimit   RuntimeRateLimit
+	CORSOrigins []string
+}
+
+// RuntimeRateLimit holds the reloadable rate limiter settings. Whether rate
+// limiting is enabled at all is fixed at startup.
+type RuntimeRateLimit struct {
+	RequestsPerSecond     float64
+	Burst                 int
+	AuthRequestsPerSecond float64
+	AuthBurst             int
+}
+
+// AuditSnapshot returns the configuration in the shape recorded in the audit
+// log.
+func (c *RuntimeConfig) AuditSnapshot() map[string]any {
+	return map[string]any{
+		"logLevel":              c.LogLevel,
+		"requestsPerSecond":     c.RateLimit.RequestsPerSecond,
+		"burst":                 c.RateLimit.Burst,
+		"authRequestsPerSecond": c.RateLimit.AuthRequestsPerSecond,
+		"authBurst":             c.RateLimit.AuthBurst,
+		"corsOrigins":           c.CORSOrigins,
+	}
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+const (
+	// DefaultSearchLimit is the number of hits returned when a search sets none
+	DefaultSearchLimit = 20
+	// MaxSearchLimit caps how many hits a search returns
+	MaxSearchLimit = 100
+	// MaxSearchQueryLength caps the length of search text
+	MaxSearchQueryLength = 200
+)
+
+// SearchDocumentKind is what a search document was built from.
+type SearchDocumentKind string
+
+const (
+	SearchDocumentTicket  SearchDocumentKind = "ticket"
+	SearchDocumentComment SearchDocumentKind = "comment"
+)
+
+// SearchDocument is a ticket or comment as stored in a search index. Every
+// document carries its ticket's organization, requester and visibility so
+// searches can be scoped without going back to the database.
+type SearchDocument struct {
+	Kind           SearchDocumentKind
+	ID             int64 // Ticket or comment ID, depending on Kind
+	TicketID       int64
+	OrganizationID uuid.UUID
+	RequesterID    uuid.UUID
+	Visibility     TicketVisibility
+	Title          string // The ticket's title, on comments too
+	Body           string
+	UpdatedAt      time.Time
+}
+
+// Key identifies the document within an index
+func (d SearchDocument) Key() string {
+	return string(d.Kind) + "-" + strconv.FormatInt(d.ID, 10)
+}
+
+// TicketSearchDocuments builds the documents indexed for a ticket: one for
+// the ticket itself and one per comment.
+func TicketSearchDocuments(ticket *Ticket, comments []*Comment) []SearchDocument {
+	docs := make([]SearchDocument, 0, len(comments)+1)
+
+	updatedAt := ticket.CreatedAt
+	if ticket.UpdatedAt != nil {
+		updatedAt = *ticket.UpdatedAt
+	}
+	visibility := VisibilityPublic
+	if ticket.IsInternal() {
+		visibility = VisibilityInternal
+	}
+	docs = append(docs, SearchDocument{
+		Kind:           SearchDocumentTicket,
+		ID:             ticket.ID,
+		TicketID:       ticket.ID,
+		OrganizationID: ticket.OrganizationID,
+		RequesterID:    ticket.RequesterID,
+		Visibility:     visibility,
+		Title:          ticket.Title,
+		Body:           ticket.Description,
+		UpdatedAt:      updatedAt,
+	})
+
+	for _, comment := range comments {
+		docs = append(docs, SearchDocument{
+			Kind:           SearchDocumentComment,
+			ID:             comment.ID,
+			TicketID:       ticket.ID,
+			OrganizationID: ticket.OrganizationID,
+			RequesterID:    ticket.RequesterID,
+			Visibility:     visibility,
+			Title:          ticket.Title,
+			Body:           comment.Body,
+			UpdatedAt:      comment.CreatedAt,
+		})
+	}
+
+	return docs
+}
+
+// SearchQuery is a full-text search over an organization's tickets and
+// comments. If RequesterID is set only that requester's tickets match, and
+// if HideInternal is set internal tickets never match.
+type SearchQuery struct {
+	OrganizationID uuid.UUID
+	RequesterID    *uuid.UUID
+	HideInternal   bool
+	Text           string
+	Limit          int
+}
+
+// Validate trims the search text and defaults and caps the limit
+func (q *SearchQuery) Validate() error {
+	errs := apperrors.NewValidationErrors()
+
+	q.Text = strings.TrimSpace(q.Text)
+	if q.Text == "" {
+		errs.Add("q", "Search text is required")
+	} else if len(q.Text) > MaxSearchQueryLength {
+		errs.Add("q", "Search text must be 200 characters or less")
+	}
+
+	if q.OrganizationID == uuid.Nil {
+		errs.Add("organizationId", "Organization ID is required")
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+
+	switch {
+	case q.Limit < 1:
+		q.Limit = DefaultSearchLimit
+	case q.Limit > MaxSearchLimit:
+		q.Limit = MaxSearchLimit
+	}
+	return nil
+}
+
+// SearchHit is a document matching a search, best match first. Snippet is
+// an excerpt of the body with matching words wrapped in <em> tags.
+type SearchHit struct {
+	Kind     SearchDocumentKind
+	ID       int64
+	TicketID int64
+	Title    string
+	Snippet  string
+	Score    float64
+}
@@ -0,0 +1,77 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicketSearchDocuments(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	ticket := &domain.Ticket{
+		ID:             7,
+		Title:          "Printer on fire",
+		Description:    "Smoke everywhere",
+		RequesterID:    uuid.New(),
+		OrganizationID: uuid.New(),
+		CreatedAt:      createdAt,
+	}
+	comment := &domain.Comment{ID: 3, TicketID: 7, Body: "Have you tried water?", CreatedAt: createdAt.Add(time.Hour)}
+
+	docs := domain.TicketSearchDocuments(ticket, []*domain.Comment{comment})
+
+	require.Len(t, docs, 2)
+	assert.Equal(t, domain.SearchDocumentTicket, docs[0].Kind)
+	assert.Equal(t, "ticket-7", docs[0].Key())
+	assert.Equal(t, "Smoke everywhere", docs[0].Body)
+	assert.Equal(t, createdAt, docs[0].UpdatedAt)
+
+	assert.Equal(t, domain.SearchDocumentComment, docs[1].Kind)
+	assert.Equal(t, "comment-3", docs[1].Key())
+	assert.Equal(t, int64(7), docs[1].TicketID)
+	assert.Equal(t, "Printer on fire", docs[1].Title)
+	assert.Equal(t, ticket.OrganizationID, docs[1].OrganizationID)
+	assert.Equal(t, ticket.RequesterID, docs[1].RequesterID)
+	assert.Equal(t, domain.VisibilityPublic, docs[1].Visibility)
+
+	ticket.Visibility = domain.VisibilityInternal
+	for _, doc := range domain.TicketSearchDocuments(ticket, []*domain.Comment{comment}) {
+		assert.Equal(t, domain.VisibilityInternal, doc.Visibility, doc.Key())
+	}
+}
+
+func TestSearchQuery_Validate(t *testing.T) {
+	t.Run("trims text and defaults the limit", func(t *testing.T) {
+		q := domain.SearchQuery{OrganizationID: uuid.New(), Text: "  printer  "}
+
+		require.NoError(t, q.Validate())
+		assert.Equal(t, "printer", q.Text)
+		assert.Equal(t, domain.DefaultSearchLimit, q.Limit)
+	})
+
+	t.Run("caps the limit", func(t *testing.T) {
+		q := domain.SearchQuery{OrganizationID: uuid.New(), Text: "printer", Limit: 1000}
+
+		require.NoError(t, q.Validate())
+		assert.Equal(t, domain.MaxSearchLimit, q.Limit)
+	})
+
+	for name, text := range map[string]string{
+		"blank":    "   ",
+		"too long": strings.Repeat("a", domain.MaxSearchQueryLength+1),
+	} {
+		t.Run("rejects "+name+" text", func(t *testing.T) {
+			q := domain.SearchQuery{OrganizationID: uuid.New(), Text: text}
+
+			var errs *apperrors.ValidationErrors
+			require.ErrorAs(t, q.Validate(), &errs)
+			assert.Contains(t, errs.Errors, "q")
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// resolutionTargets is the working time within which a ticket of each
+// priority should be resolved.
+var resolutionTargets = map[TicketPriority]time.Duration{
+	PriorityHigh:   4 * time.Hour,
+	PriorityMedium: 16 * time.Hour,
+	PriorityLow:    40 * time.Hour,
+}
+
+// ResolutionTarget returns the working time within which a ticket of the
+// given priority should be resolved.
+func ResolutionTarget(priority TicketPriority) time.Duration {
+	return resolutionTargets[priority]
+}
+
+// ApplySLA computes the ticket's SLA timers against its organization's
+// business hours, so they pause outside working hours and on holidays. The
+// due date follows from the creation time and priority, and a closed
+// ticket's resolution time is the working time until it closed.
+func (t *Ticket) ApplySLA(hours *BusinessHours) {
+	t.DueAt = nil
+	if target := ResolutionTarget(t.Priority); target > 0 {
+		if dueAt := hours.AddWorkingTime(t.CreatedAt, target); !dueAt.IsZero() {
+			dueAt = dueAt.UTC()
+			t.DueAt = &dueAt
+		}
+	}
+
+	t.ResolutionTime = nil
+	if t.ClosedAt != nil {
+		elapsed := hours.WorkingTimeBetween(t.CreatedAt, *t.ClosedAt)
+		t.ResolutionTime = &elapsed
+	}
+}
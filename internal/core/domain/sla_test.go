@@ -0,0 +1,38 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicket_ApplySLA(t *testing.T) {
+	hours := domain.DefaultBusinessHours(uuid.New())
+	hours.Holidays = []domain.Holiday{{Date: "2026-12-25", Name: "Christmas Day"}}
+	createdAt := time.Date(2026, 12, 24, 15, 0, 0, 0, time.UTC) // Thursday
+
+	t.Run("due date skips nights, weekends and holidays", func(t *testing.T) {
+		ticket := &domain.Ticket{Priority: domain.PriorityHigh, Status: domain.StatusOpen, CreatedAt: createdAt}
+
+		ticket.ApplySLA(hours)
+
+		// 2h on Thursday, none on Christmas Day or the weekend, 2h on Monday
+		require.NotNil(t, ticket.DueAt)
+		assert.Equal(t, time.Date(2026, 12, 28, 11, 0, 0, 0, time.UTC), *ticket.DueAt)
+		assert.Nil(t, ticket.ResolutionTime)
+	})
+
+	t.Run("resolution time counts only working time", func(t *testing.T) {
+		closedAt := time.Date(2026, 12, 28, 10, 0, 0, 0, time.UTC)
+		ticket := &domain.Ticket{Priority: domain.PriorityLow, Status: domain.StatusClosed, CreatedAt: createdAt, ClosedAt: &closedAt}
+
+		ticket.ApplySLA(hours)
+
+		require.NotNil(t, ticket.ResolutionTime)
+		assert.Equal(t, 3*time.Hour, *ticket.ResolutionTime)
+	})
+}
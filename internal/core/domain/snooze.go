@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+const (
+	// MaxSnoozeDuration is how far ahead a ticket can be snoozed
+	MaxSnoozeDuration = 90 * 24 * time.Hour
+	// MaxSnoozeNoteLength is the maximum length of a snooze's note
+	MaxSnoozeNoteLength = 500
+)
+
+// TicketSnooze hides a ticket from agents' queues until Until, when it
+// resurfaces and SnoozedBy is reminded of it. The note says what the
+// reminder is for, such as the reply being waited on.
+type TicketSnooze struct {
+	TicketID       int64
+	OrganizationID uuid.UUID
+	SnoozedBy      uuid.UUID
+	Until          time.Time
+	Note           string
+	CreatedAt      time.Time
+}
+
+// NewTicketSnooze creates a snooze of ticket until the given time, which
+// must be after now and at most MaxSnoozeDuration away. Closed tickets
+// cannot be snoozed.
+func NewTicketSnooze(ticket *Ticket, snoozedBy uuid.UUID, until time.Time, note string, now time.Time) (*TicketSnooze, error) {
+	if ticket.Status == StatusClosed {
+		return nil, apperrors.ErrCannotSnoozeClosed
+	}
+
+	errs := apperrors.NewValidationErrors()
+	if !until.After(now) {
+		errs.Add("until", "Must be in the future")
+	} else if until.Sub(now) > MaxSnoozeDuration {
+		errs.Add("until", fmt.Sprintf("Must be within %d days", int(MaxSnoozeDuration.Hours()/24)))
+	}
+	if len(note) > MaxSnoozeNoteLength {
+		errs.Add("note", fmt.Sprintf("Must be %d characters or less", MaxSnoozeNoteLength))
+	}
+	if errs.HasErrors() {
+		return nil, errs
+	}
+
+	return &TicketSnooze{
+		TicketID:       ticket.ID,
+		OrganizationID: ticket.OrganizationID,
+		SnoozedBy:      snoozedBy,
+		Until:          until.UTC(),
+		Note:           note,
+		CreatedAt:      now.UTC(),
+	}, nil
+}
+
+// IsActive reports whether the ticket is still hidden at t.
+func (s *TicketSnooze) IsActive(t time.Time) bool {
+	return s.Until.After(t)
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+// MaxTeamNameLength is the maximum length of a team's name
+const MaxTeamNameLength = 100
+
+// Team is a group of agents sharing a ticket queue. Tickets assigned to the
+// team wait unassigned in its queue until a member claims one.
+type Team struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Name           string
+	MemberIDs      []uuid.UUID
+	CreatedAt      time.Time
+}
+
+// NewTeam validates name and creates a team without members.
+func NewTeam(orgID uuid.UUID, name string) (*Team, error) {
+	name, err := NormalizeTeamName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Team{
+		OrganizationID: orgID,
+		Name:           name,
+		MemberIDs:      []uuid.UUID{},
+	}, nil
+}
+
+// NormalizeTeamName trims surrounding whitespace from a team's name and
+// validates it.
+func NormalizeTeamName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+
+	errs := apperrors.NewValidationErrors()
+	if name == "" {
+		errs.Add("name", "Name is required")
+	} else if len(name) > MaxTeamNameLength {
+		errs.Add("name", "Name must be 100 characters or less")
+	}
+	if errs.HasErrors() {
+		return "", errs
+	}
+	return name, nil
+}
+
+// HasMember reports whether the user belongs to the team.
+func (t *Team) HasMember(userID uuid.UUID) bool {
+	for _, id := range t.MemberIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// TeamWorkload is how many of a team's tickets are open, and how many of
+// those are still waiting in its queue.
+type TeamWorkload struct {
+	TeamID      uuid.UUID
+	Name        string
+	MemberCount int64
+	OpenCount   int64
+	QueuedCount int64
+}
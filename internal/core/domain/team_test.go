@@ -0,0 +1,47 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTeamName(t *testing.T) {
+	t.Run("trims whitespace", func(t *testing.T) {
+		name, err := domain.NormalizeTeamName("  Billing  ")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Billing", name)
+	})
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"blank", "   "},
+		{"too long", strings.Repeat("a", domain.MaxTeamNameLength+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := domain.NormalizeTeamName(tt.input)
+
+			var errs *apperrors.ValidationErrors
+			require.ErrorAs(t, err, &errs)
+			assert.Contains(t, errs.Errors, "name")
+		})
+	}
+}
+
+func TestTeam_HasMember(t *testing.T) {
+	memberID := uuid.New()
+	team := &domain.Team{MemberIDs: []uuid.UUID{memberID}}
+
+	assert.True(t, team.HasMember(memberID))
+	assert.False(t, team.HasMember(uuid.New()))
+}
@@ -1,6 +1,9 @@
 package domain
 
 import (
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,6 +14,8 @@ import (
 const (
 	MaxTitleLength       = 255
 	MaxDescriptionLength = 10000
+	MaxTicketTags        = 20
+	MaxTagLength         = 50
 )
 
 // TicketStatus represents the possible states of a ticket.
@@ -59,6 +64,31 @@ func (p TicketPriority) String() string {
 	return string(p)
 }
 
+// TicketVisibility controls whether a ticket's requester can see it.
+type TicketVisibility string
+
+const (
+	// VisibilityPublic tickets are visible to their requester.
+	VisibilityPublic TicketVisibility = "PUBLIC"
+	// VisibilityInternal tickets track internal work and are only visible
+	// to those who can read all tickets, even when they are the requester.
+	VisibilityInternal TicketVisibility = "INTERNAL"
+)
+
+// IsValid checks if the visibility is a valid ticket visibility
+func (v TicketVisibility) IsValid() bool {
+	switch v {
+	case VisibilityPublic, VisibilityInternal:
+		return true
+	}
+	return false
+}
+
+// String returns the string representation of the visibility
+func (v TicketVisibility) String() string {
+	return string(v)
+}
+
 // ParseTicketStatus converts a string to TicketStatus with validation
 func ParseTicketStatus(s string) (TicketStatus, error) {
 	status := TicketStatus(s)
@@ -79,24 +109,45 @@ func ParseTicketPriority(s string) (TicketPriority, error) {
 
 // Ticket is the core domain entity.
 type Ticket struct {
-	ID          int64
-	Title       string
-	Description string
-	Status      TicketStatus
-	Priority    TicketPriority
-	RequesterID uuid.UUID
-	AssigneeID  *uuid.UUID
-	CreatedAt   time.Time
-	UpdatedAt   *time.Time
-	ClosedAt    *time.Time
+	ID    int64
+	Title string
+	// Description is kept as submitted; DescriptionHTML is the same rich
+	// text sanitized by the server, and is what clients render.
+	Description     string
+	DescriptionHTML string
+	Status          TicketStatus
+	Priority        TicketPriority
+	RequesterID     uuid.UUID
+	AssigneeID      *uuid.UUID
+	TeamID          *uuid.UUID
+	CreatedAt       time.Time
+	UpdatedAt       *time.Time
+	ClosedAt        *time.Time
+	// OrganizationID is the requester's organization when the ticket was
+	// created. Tickets are only visible within it.
+	OrganizationID uuid.UUID
+	// DueAt is when the ticket should be resolved by; see ApplySLA.
+	DueAt *time.Time
+	// ResolutionTime is the working time the ticket took to close.
+	ResolutionTime *time.Duration
+	// Tags are lowercase labels, kept in the order they were added.
+	Tags []string
+	// ArchivedAt is set on tickets read back from the archive, which can
+	// no longer be changed.
+	ArchivedAt *time.Time
+	// Visibility is PUBLIC unless the ticket is internal-only.
+	Visibility TicketVisibility
 }
 
 // TicketParams holds parameters for creating a new ticket
 type TicketParams struct {
-	Title       string
-	Description string
-	Priority    TicketPriority
-	RequesterID uuid.UUID
+	Title          string
+	Description    string
+	Priority       TicketPriority
+	RequesterID    uuid.UUID
+	OrganizationID uuid.UUID
+	// Visibility defaults to VisibilityPublic
+	Visibility TicketVisibility
 }
 
 // Validate validates the ticket creation parameters
@@ -117,10 +168,18 @@ func (p *TicketParams) Validate() error {
 		errs.Add("priority", "Priority must be LOW, MEDIUM, or HIGH")
 	}
 
+	if p.Visibility != "" && !p.Visibility.IsValid() {
+		errs.Add("visibility", "Visibility must be PUBLIC or INTERNAL")
+	}
+
 	if p.RequesterID == uuid.Nil {
 		errs.Add("requesterId", "Requester ID is required")
 	}
 
+	if p.OrganizationID == uuid.Nil {
+		errs.Add("organizationId", "Organization ID is required")
+	}
+
 	if errs.HasErrors() {
 		return errs
 	}
@@ -133,13 +192,20 @@ func NewTicket(params TicketParams) (*Ticket, error) {
 		return nil, err
 	}
 
+	visibility := params.Visibility
+	if visibility == "" {
+		visibility = VisibilityPublic
+	}
+
 	return &Ticket{
-		Title:       params.Title,
-		Description: params.Description,
-		Status:      StatusOpen, // Default status
-		Priority:    params.Priority,
-		RequesterID: params.RequesterID,
-		CreatedAt:   time.Now().UTC(),
+		OrganizationID: params.OrganizationID,
+		Title:          params.Title,
+		Description:    params.Description,
+		Status:         StatusOpen, // Default status
+		Priority:       params.Priority,
+		RequesterID:    params.RequesterID,
+		CreatedAt:      time.Now().UTC(),
+		Visibility:     visibility,
 	}, nil
 }
 
@@ -215,6 +281,150 @@ func (t *Ticket) Unassign() error {
 	return nil
 }
 
+// AssignToTeam puts the ticket in a team's queue. Any assignee is removed,
+// so the ticket waits for a member of the team to claim it.
+func (t *Ticket) AssignToTeam(teamID uuid.UUID) error {
+	if t.Status == StatusClosed {
+		return apperrors.ErrCannotAssignClosed
+	}
+
+	t.TeamID = &teamID
+	t.AssigneeID = nil
+	now := time.Now().UTC()
+	t.UpdatedAt = &now
+	return nil
+}
+
+// InTeamQueue reports whether the ticket is waiting in a team's queue.
+func (t *Ticket) InTeamQueue() bool {
+	return t.TeamID != nil && t.AssigneeID == nil && t.Status != StatusClosed
+}
+
+// Claim assigns a ticket waiting in a team queue to the agent taking it.
+func (t *Ticket) Claim(agentID uuid.UUID) error {
+	if t.AssigneeID != nil {
+		return apperrors.ErrTicketAlreadyClaimed
+	}
+	return t.Assign(agentID)
+}
+
+// TicketChanges is a partial update of a ticket. Nil fields are left
+// unchanged.
+type TicketChanges struct {
+	Title       *string
+	Description *string
+	Priority    *TicketPriority
+	Status      *TicketStatus
+	AssigneeID  *uuid.UUID
+	// AddTags are added to the ticket's tags; tags it already has are kept.
+	AddTags []string
+}
+
+// IsEmpty reports whether no field is changed.
+func (c TicketChanges) IsEmpty() bool {
+	return c.Title == nil && c.Description == nil && c.Priority == nil && c.Status == nil && c.AssigneeID == nil &&
+		len(c.AddTags) == 0
+}
+
+// ChangesDetails reports whether the title, description, priority or tags
+// are changed.
+func (c TicketChanges) ChangesDetails() bool {
+	return c.Title != nil || c.Description != nil || c.Priority != nil || len(c.AddTags) > 0
+}
+
+// Validate checks the changed fields together, reporting every invalid one.
+func (c TicketChanges) Validate() error {
+	errs := apperrors.NewValidationErrors()
+
+	if c.Title != nil {
+		if *c.Title == "" {
+			errs.Add("title", "Title is required")
+		} else if len(*c.Title) > MaxTitleLength {
+			errs.Add("title", "Title must be 255 characters or less")
+		}
+	}
+
+	if c.Description != nil && len(*c.Description) > MaxDescriptionLength {
+		errs.Add("description", "Description must be 10,000 characters or less")
+	}
+
+	if c.Priority != nil && !c.Priority.IsValid() {
+		errs.Add("priority", "Priority must be LOW, MEDIUM, or HIGH")
+	}
+
+	if c.Status != nil && !c.Status.IsValid() {
+		errs.Add("status", "Status must be OPEN, IN_PROGRESS, or CLOSED")
+	}
+
+	if c.AssigneeID != nil && *c.AssigneeID == uuid.Nil {
+		errs.Add("assigneeId", "Assignee ID is required")
+	}
+
+	for _, tag := range c.AddTags {
+		if msg := validateTag(NormalizeTag(tag)); msg != "" {
+			errs.Add("tags", msg)
+		}
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Apply validates and applies changes, enforcing the same business rules as
+// UpdateStatus and Assign. The status is changed before the assignee, so a
+// ticket cannot be closed and assigned at once. On error the ticket is left
+// unchanged.
+func (t *Ticket) Apply(changes TicketChanges) error {
+	if err := changes.Validate(); err != nil {
+		return err
+	}
+
+	updated := *t
+	if changes.Status != nil && *changes.Status != updated.Status {
+		if err := updated.UpdateStatus(*changes.Status); err != nil {
+			return err
+		}
+	}
+	if changes.AssigneeID != nil {
+		if err := updated.Assign(*changes.AssigneeID); err != nil {
+			return err
+		}
+	}
+	if changes.Title != nil {
+		updated.Title = *changes.Title
+	}
+	if changes.Description != nil {
+		updated.Description = *changes.Description
+	}
+	if changes.Priority != nil {
+		updated.Priority = *changes.Priority
+	}
+	if len(changes.AddTags) > 0 {
+		tags, err := mergeTags(updated.Tags, changes.AddTags)
+		if err != nil {
+			return err
+		}
+		updated.Tags = tags
+	}
+
+	now := time.Now().UTC()
+	updated.UpdatedAt = &now
+	*t = updated
+	return nil
+}
+
+// IsArchived reports whether the ticket was read back from the archive
+func (t *Ticket) IsArchived() bool {
+	return t.ArchivedAt != nil
+}
+
+// IsInternal reports whether the ticket is hidden from its requester
+func (t *Ticket) IsInternal() bool {
+	return t.Visibility == VisibilityInternal
+}
+
 // IsOwnedBy checks if the ticket belongs to the given user
 func (t *Ticket) IsOwnedBy(userID uuid.UUID) bool {
 	return t.RequesterID == userID
@@ -229,3 +439,39 @@ func (t *Ticket) IsAssignedTo(userID uuid.UUID) bool {
 func (t *Ticket) IsClosed() bool {
 	return t.Status == StatusClosed
 }
+
+// NormalizeTag trims and lowercases a tag, so "Billing " and "billing" are
+// the same tag.
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// validateTag returns what is wrong with a normalized tag, if anything.
+func validateTag(tag string) string {
+	if tag == "" {
+		return "Tags cannot be blank"
+	}
+	if len(tag) > MaxTagLength {
+		return fmt.Sprintf("Tags must be %d characters or less", MaxTagLength)
+	}
+	return ""
+}
+
+// mergeTags appends the normalized new tags the ticket does not have yet,
+// returning a new slice.
+func mergeTags(current, added []string) ([]string, error) {
+	merged := append([]string{}, current...)
+	for _, tag := range added {
+		tag = NormalizeTag(tag)
+		if !slices.Contains(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+
+	if len(merged) > MaxTicketTags {
+		errs := apperrors.NewValidationErrors()
+		errs.Add("tags", fmt.Sprintf("A ticket can have at most %d tags", MaxTicketTags))
+		return nil, errs
+	}
+	return merged, nil
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// TicketDocument is a ticket with its comment thread, prepared for export
+// as a document kept outside the service desk, such as an audit submission.
+// People and teams are named rather than referenced by ID.
+type TicketDocument struct {
+	Ticket        *Ticket
+	RequesterName string
+	// AssigneeName and TeamName are empty when the ticket has none
+	AssigneeName string
+	TeamName     string
+	Comments     []TicketDocumentComment
+	// GeneratedBy is the name of the user who exported the ticket, whose
+	// time zone the document's times are shown in.
+	GeneratedBy string
+	GeneratedAt time.Time
+	Location    *time.Location
+}
+
+// TicketDocumentComment is a comment of an exported ticket.
+type TicketDocumentComment struct {
+	AuthorName string
+	Body       string
+	CreatedAt  time.Time
+}
@@ -1,6 +1,7 @@
 package domain_test
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -54,6 +55,7 @@ func TestTicketStatus_IsValid(t *testing.T) {
 
 func TestNewTicket(t *testing.T) {
 	validRequesterID := uuid.New()
+	validOrgID := uuid.New()
 
 	tests := []struct {
 		name        string
@@ -64,20 +66,22 @@ func TestNewTicket(t *testing.T) {
 		{
 			name: "valid ticket",
 			params: domain.TicketParams{
-				Title:       "Test Ticket",
-				Description: "Test description",
-				Priority:    domain.PriorityMedium,
-				RequesterID: validRequesterID,
+				Title:          "Test Ticket",
+				Description:    "Test description",
+				Priority:       domain.PriorityMedium,
+				RequesterID:    validRequesterID,
+				OrganizationID: validOrgID,
 			},
 			expectError: false,
 		},
 		{
 			name: "missing title",
 			params: domain.TicketParams{
-				Title:       "",
-				Description: "Test description",
-				Priority:    domain.PriorityMedium,
-				RequesterID: validRequesterID,
+				Title:          "",
+				Description:    "Test description",
+				Priority:       domain.PriorityMedium,
+				RequesterID:    validRequesterID,
+				OrganizationID: validOrgID,
 			},
 			expectError: true,
 			errorField:  "title",
@@ -85,10 +89,11 @@ func TestNewTicket(t *testing.T) {
 		{
 			name: "title too long",
 			params: domain.TicketParams{
-				Title:       strings.Repeat("a", 256),
-				Description: "Test description",
-				Priority:    domain.PriorityMedium,
-				RequesterID: validRequesterID,
+				Title:          strings.Repeat("a", 256),
+				Description:    "Test description",
+				Priority:       domain.PriorityMedium,
+				RequesterID:    validRequesterID,
+				OrganizationID: validOrgID,
 			},
 			expectError: true,
 			errorField:  "title",
@@ -96,10 +101,11 @@ func TestNewTicket(t *testing.T) {
 		{
 			name: "description too long",
 			params: domain.TicketParams{
-				Title:       "Test Ticket",
-				Description: strings.Repeat("a", 10001),
-				Priority:    domain.PriorityMedium,
-				RequesterID: validRequesterID,
+				Title:          "Test Ticket",
+				Description:    strings.Repeat("a", 10001),
+				Priority:       domain.PriorityMedium,
+				RequesterID:    validRequesterID,
+				OrganizationID: validOrgID,
 			},
 			expectError: true,
 			errorField:  "description",
@@ -107,24 +113,50 @@ func TestNewTicket(t *testing.T) {
 		{
 			name: "invalid priority",
 			params: domain.TicketParams{
-				Title:       "Test Ticket",
-				Description: "Test description",
-				Priority:    domain.TicketPriority("INVALID"),
-				RequesterID: validRequesterID,
+				Title:          "Test Ticket",
+				Description:    "Test description",
+				Priority:       domain.TicketPriority("INVALID"),
+				RequesterID:    validRequesterID,
+				OrganizationID: validOrgID,
 			},
 			expectError: true,
 			errorField:  "priority",
 		},
+		{
+			name: "invalid visibility",
+			params: domain.TicketParams{
+				Title:          "Test Ticket",
+				Description:    "Test description",
+				Priority:       domain.PriorityMedium,
+				RequesterID:    validRequesterID,
+				OrganizationID: validOrgID,
+				Visibility:     domain.TicketVisibility("SECRET"),
+			},
+			expectError: true,
+			errorField:  "visibility",
+		},
 		{
 			name: "missing requester ID",
+			params: domain.TicketParams{
+				Title:          "Test Ticket",
+				Description:    "Test description",
+				Priority:       domain.PriorityMedium,
+				RequesterID:    uuid.Nil,
+				OrganizationID: validOrgID,
+			},
+			expectError: true,
+			errorField:  "requesterId",
+		},
+		{
+			name: "missing organization",
 			params: domain.TicketParams{
 				Title:       "Test Ticket",
 				Description: "Test description",
 				Priority:    domain.PriorityMedium,
-				RequesterID: uuid.Nil,
+				RequesterID: validRequesterID,
 			},
 			expectError: true,
-			errorField:  "requesterId",
+			errorField:  "organizationId",
 		},
 	}
 
@@ -148,7 +180,9 @@ func TestNewTicket(t *testing.T) {
 				assert.Equal(t, tt.params.Description, ticket.Description)
 				assert.Equal(t, tt.params.Priority, ticket.Priority)
 				assert.Equal(t, tt.params.RequesterID, ticket.RequesterID)
-				assert.Equal(t, domain.StatusOpen, ticket.Status) // Default status
+				assert.Equal(t, tt.params.OrganizationID, ticket.OrganizationID)
+				assert.Equal(t, domain.StatusOpen, ticket.Status)           // Default status
+				assert.Equal(t, domain.VisibilityPublic, ticket.Visibility) // Default visibility
 			}
 		})
 	}
@@ -252,6 +286,149 @@ func TestTicket_Assign(t *testing.T) {
 	}
 }
 
+func TestTicket_AssignToTeam(t *testing.T) {
+	teamID := uuid.New()
+
+	t.Run("moves the ticket to the team's queue", func(t *testing.T) {
+		assigneeID := uuid.New()
+		ticket := &domain.Ticket{Status: domain.StatusInProgress, AssigneeID: &assigneeID}
+
+		require.NoError(t, ticket.AssignToTeam(teamID))
+
+		assert.Equal(t, &teamID, ticket.TeamID)
+		assert.Nil(t, ticket.AssigneeID)
+		assert.True(t, ticket.InTeamQueue())
+	})
+
+	t.Run("closed tickets cannot be queued", func(t *testing.T) {
+		ticket := &domain.Ticket{Status: domain.StatusClosed}
+
+		assert.ErrorIs(t, ticket.AssignToTeam(teamID), apperrors.ErrCannotAssignClosed)
+		assert.Nil(t, ticket.TeamID)
+	})
+}
+
+func TestTicket_Claim(t *testing.T) {
+	teamID := uuid.New()
+	agentID := uuid.New()
+
+	t.Run("assigns a queued ticket", func(t *testing.T) {
+		ticket := &domain.Ticket{Status: domain.StatusOpen, TeamID: &teamID}
+
+		require.NoError(t, ticket.Claim(agentID))
+
+		assert.True(t, ticket.IsAssignedTo(agentID))
+		assert.False(t, ticket.InTeamQueue())
+	})
+
+	t.Run("already assigned tickets cannot be claimed", func(t *testing.T) {
+		otherID := uuid.New()
+		ticket := &domain.Ticket{Status: domain.StatusOpen, TeamID: &teamID, AssigneeID: &otherID}
+
+		assert.ErrorIs(t, ticket.Claim(agentID), apperrors.ErrTicketAlreadyClaimed)
+		assert.True(t, ticket.IsAssignedTo(otherID))
+	})
+}
+
+func TestTicket_Apply(t *testing.T) {
+	requesterID := uuid.New()
+	assigneeID := uuid.New()
+	ptr := func(s string) *string { return &s }
+
+	newTicket := func(status domain.TicketStatus) *domain.Ticket {
+		return &domain.Ticket{
+			ID:          1,
+			Title:       "Test",
+			Status:      status,
+			Priority:    domain.PriorityMedium,
+			RequesterID: requesterID,
+		}
+	}
+
+	t.Run("applies several changes", func(t *testing.T) {
+		ticket := newTicket(domain.StatusOpen)
+		status := domain.StatusInProgress
+		priority := domain.PriorityHigh
+
+		err := ticket.Apply(domain.TicketChanges{
+			Title:      ptr("Printer on fire"),
+			Priority:   &priority,
+			Status:     &status,
+			AssigneeID: &assigneeID,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Printer on fire", ticket.Title)
+		assert.Equal(t, domain.PriorityHigh, ticket.Priority)
+		assert.Equal(t, domain.StatusInProgress, ticket.Status)
+		assert.True(t, ticket.IsAssignedTo(assigneeID))
+		assert.NotNil(t, ticket.UpdatedAt)
+	})
+
+	t.Run("reports every invalid field", func(t *testing.T) {
+		ticket := newTicket(domain.StatusOpen)
+		status := domain.TicketStatus("BOGUS")
+		priority := domain.TicketPriority("URGENT")
+
+		err := ticket.Apply(domain.TicketChanges{
+			Title:       ptr(""),
+			Description: ptr(strings.Repeat("a", domain.MaxDescriptionLength+1)),
+			Priority:    &priority,
+			Status:      &status,
+		})
+
+		var validationErrs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &validationErrs)
+		assert.Len(t, validationErrs.Errors, 4)
+		assert.Equal(t, "Test", ticket.Title)
+	})
+
+	t.Run("leaves the ticket unchanged when a rule is broken", func(t *testing.T) {
+		ticket := newTicket(domain.StatusOpen)
+		status := domain.StatusClosed
+
+		err := ticket.Apply(domain.TicketChanges{
+			Title:      ptr("Renamed"),
+			Status:     &status,
+			AssigneeID: &assigneeID,
+		})
+
+		assert.ErrorIs(t, err, apperrors.ErrCannotAssignClosed)
+		assert.Equal(t, "Test", ticket.Title)
+		assert.Equal(t, domain.StatusOpen, ticket.Status)
+		assert.Nil(t, ticket.AssigneeID)
+	})
+
+	t.Run("keeping the current status is not a transition", func(t *testing.T) {
+		ticket := newTicket(domain.StatusOpen)
+		status := domain.StatusOpen
+
+		require.NoError(t, ticket.Apply(domain.TicketChanges{Status: &status, Title: ptr("Renamed")}))
+		assert.Equal(t, "Renamed", ticket.Title)
+	})
+
+	t.Run("adds normalized tags it does not have yet", func(t *testing.T) {
+		ticket := newTicket(domain.StatusOpen)
+		ticket.Tags = []string{"billing"}
+
+		require.NoError(t, ticket.Apply(domain.TicketChanges{AddTags: []string{" Billing", "VIP"}}))
+		assert.Equal(t, []string{"billing", "vip"}, ticket.Tags)
+	})
+
+	t.Run("caps the number of tags", func(t *testing.T) {
+		ticket := newTicket(domain.StatusOpen)
+		tags := make([]string, domain.MaxTicketTags+1)
+		for i := range tags {
+			tags[i] = fmt.Sprintf("tag-%d", i)
+		}
+
+		var errs *apperrors.ValidationErrors
+		require.ErrorAs(t, ticket.Apply(domain.TicketChanges{AddTags: tags}), &errs)
+		assert.Contains(t, errs.Errors, "tags")
+		assert.Empty(t, ticket.Tags)
+	})
+}
+
 func TestTicket_CanTransitionTo(t *testing.T) {
 	requesterID := uuid.New()
 
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"net/mail"
+	"regexp"
 	"time"
 	"unicode"
 
@@ -48,6 +49,14 @@ type User struct {
 	CreatedAt      time.Time
 	IsActive       bool
 	LastActiveAt   *time.Time
+	// TokensRevokedAt invalidates every access token issued at or before it.
+	TokensRevokedAt *time.Time
+	PhoneNumber     string
+	SMSOptIn        bool
+	// Locale selects the language of the user's emails and API messages.
+	Locale string
+	// Timezone is the IANA time zone the user's clients show times in.
+	Timezone string
 }
 
 type UserSummary struct {
@@ -61,6 +70,127 @@ type UserSummary struct {
 	LastActiveAt   *time.Time
 }
 
+// CurrentUser is everything clients load about the signed-in user when they
+// start: their profile, roles and permissions, organization and the ticket
+// emails they opted out of.
+type CurrentUser struct {
+	User             *User
+	Roles            []string
+	Permissions      []string
+	Organization     *Organization
+	EmailPreferences *EmailPreferences
+}
+
+// UserFilter narrows a user listing. Query matches part of a name or email;
+// nil fields match everything.
+type UserFilter struct {
+	Query    string
+	Role     *string
+	IsActive *bool
+	Limit    int
+	Offset   int
+}
+
+// phoneNumberPattern matches E.164 phone numbers, e.g. +14155550123
+var phoneNumberPattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// SMSPreferences holds a user's SMS notification settings
+type SMSPreferences struct {
+	PhoneNumber string
+	OptIn       bool
+}
+
+// Validate validates SMS preferences
+func (p *SMSPreferences) Validate() error {
+	errs := apperrors.NewValidationErrors()
+
+	if p.PhoneNumber != "" && !phoneNumberPattern.MatchString(p.PhoneNumber) {
+		errs.Add("phoneNumber", "Phone number must be in E.164 format, e.g. +14155550123")
+	}
+	if p.OptIn && p.PhoneNumber == "" {
+		errs.Add("phoneNumber", "Phone number is required to opt in to SMS")
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// ProfileChanges is a partial update of a user's own profile. Nil fields are
+// left unchanged; an empty phone number removes it.
+type ProfileChanges struct {
+	FullName    *string
+	PhoneNumber *string
+	Timezone    *string
+	Locale      *string
+}
+
+// IsEmpty reports whether no field is changed.
+func (c ProfileChanges) IsEmpty() bool {
+	return c.FullName == nil && c.PhoneNumber == nil && c.Timezone == nil && c.Locale == nil
+}
+
+// Validate checks the changed fields together, reporting every invalid one.
+// The locale is checked against the supported locales by the caller.
+func (c ProfileChanges) Validate() error {
+	errs := apperrors.NewValidationErrors()
+
+	if c.FullName != nil {
+		if *c.FullName == "" {
+			errs.Add("fullName", "Full name is required")
+		} else if len(*c.FullName) > MaxFullNameLength {
+			errs.Add("fullName", "Full name must be 255 characters or less")
+		}
+	}
+
+	if c.PhoneNumber != nil && *c.PhoneNumber != "" && !phoneNumberPattern.MatchString(*c.PhoneNumber) {
+		errs.Add("phoneNumber", "Phone number must be in E.164 format, e.g. +14155550123")
+	}
+
+	if c.Timezone != nil {
+		if _, err := time.LoadLocation(*c.Timezone); *c.Timezone == "" || *c.Timezone == "Local" || err != nil {
+			errs.Add("timezone", "Timezone must be an IANA time zone name, e.g. Europe/Berlin")
+		}
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// ApplyProfile validates and applies changes to the user's profile. A phone
+// number can't be removed while the user is opted in to SMS. On error the
+// user is left unchanged.
+func (u *User) ApplyProfile(changes ProfileChanges) error {
+	if err := changes.Validate(); err != nil {
+		return err
+	}
+
+	updated := *u
+	if changes.FullName != nil {
+		updated.FullName = *changes.FullName
+	}
+	if changes.PhoneNumber != nil {
+		updated.PhoneNumber = *changes.PhoneNumber
+	}
+	if changes.Timezone != nil {
+		updated.Timezone = *changes.Timezone
+	}
+	if changes.Locale != nil {
+		updated.Locale = *changes.Locale
+	}
+
+	prefs := SMSPreferences{PhoneNumber: updated.PhoneNumber, OptIn: updated.SMSOptIn}
+	if err := prefs.Validate(); err != nil {
+		return err
+	}
+
+	*u = updated
+	return nil
+}
+
 // UserRegistrationParams holds parameters for user registration
 type UserRegistrationParams struct {
 	FullName string
@@ -182,6 +312,23 @@ func HashPassword(password string) (string, error) {
 	return string(bytes), nil
 }
 
+// OffboardResult reports what happened to a deactivated user's open
+// tickets. ReassignedTo is nil when the tickets were left unassigned.
+type OffboardResult struct {
+	UserID       uuid.UUID
+	ReassignedTo *uuid.UUID
+	TicketIDs    []int64
+}
+
+// AnonymizedUserName replaces the full name of an anonymized user.
+const AnonymizedUserName = "Deleted user"
+
+// AnonymizedEmail returns the placeholder email stored for an anonymized
+// user. It stays unique per user and can never receive mail.
+func AnonymizedEmail(userID uuid.UUID) string {
+	return "deleted-" + userID.String() + "@deleted.invalid"
+}
+
 // NewUser creates a new user with validated parameters
 func NewUser(params UserRegistrationParams, orgID uuid.UUID) (*User, error) {
 	if err := params.Validate(); err != nil {
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// MaxUserImportRows caps how many users can be imported at once
+const MaxUserImportRows = 100
+
+// UserRoles lists the roles an admin can give a user
+var UserRoles = []string{"admin", "agent", "customer"}
+
+// UserImportRow is one user to create in a bulk import. Line is the row's
+// line number in the uploaded file, used to report problems.
+type UserImportRow struct {
+	Line     int
+	FullName string
+	Email    string
+	Role     string
+}
+
+// Normalize trims the row's fields and defaults an empty role to customer.
+func (r *UserImportRow) Normalize() {
+	r.FullName = strings.TrimSpace(r.FullName)
+	r.Email = strings.TrimSpace(r.Email)
+	r.Role = strings.ToLower(strings.TrimSpace(r.Role))
+	if r.Role == "" {
+		r.Role = "customer"
+	}
+}
+
+// Validate returns the problems with the row, if any
+func (r *UserImportRow) Validate() []string {
+	var problems []string
+
+	if r.FullName == "" {
+		problems = append(problems, "Name is required")
+	} else if len(r.FullName) > MaxFullNameLength {
+		problems = append(problems, "Name must be 255 characters or less")
+	}
+
+	if r.Email == "" {
+		problems = append(problems, "Email is required")
+	} else if len(r.Email) > MaxEmailLength {
+		problems = append(problems, "Email must be 255 characters or less")
+	} else if !isValidEmail(r.Email) {
+		problems = append(problems, "Invalid email format")
+	}
+
+	if !slices.Contains(UserRoles, r.Role) {
+		problems = append(problems, "Role must be one of: "+strings.Join(UserRoles, ", "))
+	}
+
+	return problems
+}
+
+// UserImportResult reports what happened to one imported row. Created rows
+// carry the new user's ID and temporary password; failed rows an error.
+type UserImportResult struct {
+	Line              int
+	Email             string
+	UserID            uuid.UUID
+	Role              string
+	TemporaryPassword string
+	Error             string
+}
+
+// UserImportReport summarizes a bulk import, with one result per row in
+// the order they were given.
+type UserImportReport struct {
+	Created int
+	Failed  int
+	Results []UserImportResult
+}
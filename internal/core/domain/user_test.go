@@ -243,3 +243,99 @@ func TestDefaultPasswordRequirements(t *testing.T) {
 	assert.True(t, reqs.RequireNumber)
 	assert.False(t, reqs.RequireSpecial) // Optional by default
 }
+
+func TestSMSPreferences_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		prefs       domain.SMSPreferences
+		expectValid bool
+	}{
+		{"opted in with number", domain.SMSPreferences{PhoneNumber: "+14155550123", OptIn: true}, true},
+		{"opted out with number", domain.SMSPreferences{PhoneNumber: "+442071838750"}, true},
+		{"opted out without number", domain.SMSPreferences{}, true},
+		{"opted in without number", domain.SMSPreferences{OptIn: true}, false},
+		{"missing plus", domain.SMSPreferences{PhoneNumber: "14155550123"}, false},
+		{"contains spaces", domain.SMSPreferences{PhoneNumber: "+1 415 555 0123"}, false},
+		{"too long", domain.SMSPreferences{PhoneNumber: "+1234567890123456"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.prefs.Validate()
+			if tt.expectValid {
+				assert.NoError(t, err)
+			} else {
+				var validationErrs *apperrors.ValidationErrors
+				assert.ErrorAs(t, err, &validationErrs)
+			}
+		})
+	}
+}
+
+func TestUserImportRow_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		row      domain.UserImportRow
+		problems int
+	}{
+		{"valid", domain.UserImportRow{FullName: "Jane Doe", Email: "jane@example.com", Role: "agent"}, 0},
+		{"role defaults to customer", domain.UserImportRow{FullName: "Jane Doe", Email: "jane@example.com"}, 0},
+		{"role is case-insensitive", domain.UserImportRow{FullName: "Jane Doe", Email: "jane@example.com", Role: " Admin "}, 0},
+		{"missing name", domain.UserImportRow{Email: "jane@example.com"}, 1},
+		{"invalid email", domain.UserImportRow{FullName: "Jane Doe", Email: "not-an-email"}, 1},
+		{"unknown role", domain.UserImportRow{FullName: "Jane Doe", Email: "jane@example.com", Role: "owner"}, 1},
+		{"several problems", domain.UserImportRow{Role: "owner"}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row := tt.row
+			row.Normalize()
+			assert.Len(t, row.Validate(), tt.problems)
+		})
+	}
+}
+
+func TestUser_ApplyProfile(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+
+	t.Run("applies changed fields only", func(t *testing.T) {
+		user := &domain.User{FullName: "Jane Doe", PhoneNumber: "+14155550123", Locale: "en", Timezone: "UTC"}
+
+		err := user.ApplyProfile(domain.ProfileChanges{FullName: ptr("Jane Smith"), Timezone: ptr("Europe/Berlin")})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Jane Smith", user.FullName)
+		assert.Equal(t, "Europe/Berlin", user.Timezone)
+		assert.Equal(t, "+14155550123", user.PhoneNumber)
+		assert.Equal(t, "en", user.Locale)
+	})
+
+	t.Run("reports every invalid field", func(t *testing.T) {
+		user := &domain.User{FullName: "Jane Doe", Timezone: "UTC"}
+
+		err := user.ApplyProfile(domain.ProfileChanges{
+			FullName:    ptr(""),
+			PhoneNumber: ptr("555-0123"),
+			Timezone:    ptr("Mars/Olympus_Mons"),
+		})
+
+		var errs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Contains(t, errs.Errors, "fullName")
+		assert.Contains(t, errs.Errors, "phoneNumber")
+		assert.Contains(t, errs.Errors, "timezone")
+		assert.Equal(t, "Jane Doe", user.FullName)
+	})
+
+	t.Run("keeps the phone number while opted in to SMS", func(t *testing.T) {
+		user := &domain.User{PhoneNumber: "+14155550123", SMSOptIn: true}
+
+		err := user.ApplyProfile(domain.ProfileChanges{PhoneNumber: ptr("")})
+
+		var errs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Contains(t, errs.Errors, "phoneNumber")
+		assert.Equal(t, "+14155550123", user.PhoneNumber)
+	})
+}
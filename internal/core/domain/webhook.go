@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+const (
+	// MaxWebhookURLLength is the maximum length of a webhook endpoint URL
+	MaxWebhookURLLength = 2048
+	// webhookSecretBytes is the number of random bytes in a signing secret
+	webhookSecretBytes = 32
+)
+
+// WebhookEventType is the public name of an event delivered to webhooks.
+type WebhookEventType string
+
+const (
+	WebhookTicketCreated       WebhookEventType = "ticket.created"
+	WebhookTicketStatusChanged WebhookEventType = "ticket.status_changed"
+	WebhookTicketAssigned      WebhookEventType = "ticket.assigned"
+	WebhookTicketUpdated       WebhookEventType = "ticket.updated"
+	WebhookCommentAdded        WebhookEventType = "comment.added"
+
+	// WebhookTest is only sent by the test-fire endpoint and cannot be subscribed to.
+	WebhookTest WebhookEventType = "webhook.test"
+)
+
+// SubscribableWebhookEvents lists the event types a webhook can subscribe to.
+var SubscribableWebhookEvents = []WebhookEventType{
+	WebhookTicketCreated,
+	WebhookTicketStatusChanged,
+	WebhookTicketAssigned,
+	WebhookTicketUpdated,
+	WebhookCommentAdded,
+}
+
+// IsValid checks if the event type can be subscribed to
+func (t WebhookEventType) IsValid() bool {
+	for _, valid := range SubscribableWebhookEvents {
+		if t == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEventFor maps a ticket event to the webhook event it is published as.
+func WebhookEventFor(eventType EventType) (WebhookEventType, bool) {
+	def, ok := LookupEvent(eventType)
+	if !ok || def.Webhook == "" {
+		return "", false
+	}
+	return def.Webhook, true
+}
+
+// Webhook is an endpoint registered by an organization to receive events.
+type Webhook struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	URL            string
+	Secret         string
+	EventTypes     []WebhookEventType
+	IsActive       bool
+	CreatedBy      uuid.UUID
+	CreatedAt      time.Time
+}
+
+// WebhookParams holds the caller-supplied fields of a new webhook
+type WebhookParams struct {
+	URL        string
+	EventTypes []WebhookEventType
+}
+
+// Validate validates webhook parameters
+func (p *WebhookParams) Validate() error {
+	errs := apperrors.NewValidationErrors()
+
+	if p.URL == "" {
+		errs.Add("url", "URL is required")
+	} else if len(p.URL) > MaxWebhookURLLength {
+		errs.Add("url", "URL is too long")
+	} else if u, err := url.Parse(p.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs.Add("url", "URL must be an absolute http or https URL")
+	}
+
+	if len(p.EventTypes) == 0 {
+		errs.Add("eventTypes", "At least one event type is required")
+	}
+	for _, t := range p.EventTypes {
+		if !t.IsValid() {
+			errs.Add("eventTypes", "Unknown event type: "+string(t))
+		}
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// NewWebhook validates params and creates an active webhook with a fresh signing secret
+func NewWebhook(params WebhookParams, orgID, createdBy uuid.UUID) (*Webhook, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	// Drop duplicate subscriptions so each event is delivered once.
+	eventTypes := make([]WebhookEventType, 0, len(params.EventTypes))
+	seen := make(map[WebhookEventType]bool, len(params.EventTypes))
+	for _, t := range params.EventTypes {
+		if !seen[t] {
+			seen[t] = true
+			eventTypes = append(eventTypes, t)
+		}
+	}
+
+	return &Webhook{
+		OrganizationID: orgID,
+		URL:            params.URL,
+		Secret:         hex.EncodeToString(secret),
+		EventTypes:     eventTypes,
+		IsActive:       true,
+		CreatedBy:      createdBy,
+	}, nil
+}
+
+// WebhookDeliveryStatus is the state of a single webhook delivery.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	WebhookDeliveryDead      WebhookDeliveryStatus = "DEAD"
+)
+
+// WebhookDelivery is one attempt-tracked delivery of an event to a webhook.
+type WebhookDelivery struct {
+	ID             int64
+	WebhookID      uuid.UUID
+	EventType      WebhookEventType
+	Payload        json.RawMessage
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	ResponseStatus *int
+	LastError      *string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+// WebhookEnvelope is the JSON body POSTed to webhook endpoints. EventID and
+// Version identify the ticket event Data is the payload of; Replayed marks
+// events sent again by an admin, which consumers may already have.
+type WebhookEnvelope struct {
+	Event      WebhookEventType `json:"event"`
+	EventID    int64            `json:"eventId,omitempty"`
+	Version    int              `json:"version,omitempty"`
+	TicketID   int64            `json:"ticketId,omitempty"`
+	OccurredAt string           `json:"occurredAt"`
+	Data       json.RawMessage  `json:"data"`
+	Replayed   bool             `json:"replayed,omitempty"`
+}
@@ -0,0 +1,77 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookParams_Validate(t *testing.T) {
+	created := []domain.WebhookEventType{domain.WebhookTicketCreated}
+
+	tests := []struct {
+		name        string
+		params      domain.WebhookParams
+		expectValid bool
+	}{
+		{"valid https", domain.WebhookParams{URL: "https://example.com/hook", EventTypes: created}, true},
+		{"valid http", domain.WebhookParams{URL: "http://example.com:8080/hook", EventTypes: created}, true},
+		{"missing url", domain.WebhookParams{EventTypes: created}, false},
+		{"relative url", domain.WebhookParams{URL: "/hook", EventTypes: created}, false},
+		{"unsupported scheme", domain.WebhookParams{URL: "ftp://example.com/hook", EventTypes: created}, false},
+		{"no event types", domain.WebhookParams{URL: "https://example.com/hook"}, false},
+		{"unknown event type", domain.WebhookParams{URL: "https://example.com/hook", EventTypes: []domain.WebhookEventType{"ticket.deleted"}}, false},
+		{"test event is not subscribable", domain.WebhookParams{URL: "https://example.com/hook", EventTypes: []domain.WebhookEventType{domain.WebhookTest}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if tt.expectValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestNewWebhook(t *testing.T) {
+	orgID := uuid.New()
+	actorID := uuid.New()
+
+	webhook, err := domain.NewWebhook(domain.WebhookParams{
+		URL: "https://example.com/hook",
+		EventTypes: []domain.WebhookEventType{
+			domain.WebhookTicketCreated,
+			domain.WebhookCommentAdded,
+			domain.WebhookTicketCreated,
+		},
+	}, orgID, actorID)
+
+	require.NoError(t, err)
+	assert.Equal(t, orgID, webhook.OrganizationID)
+	assert.Equal(t, actorID, webhook.CreatedBy)
+	assert.True(t, webhook.IsActive)
+	assert.Len(t, webhook.Secret, 64)
+	assert.Equal(t, []domain.WebhookEventType{domain.WebhookTicketCreated, domain.WebhookCommentAdded}, webhook.EventTypes)
+
+	other, err := domain.NewWebhook(domain.WebhookParams{
+		URL:        "https://example.com/hook",
+		EventTypes: []domain.WebhookEventType{domain.WebhookTicketCreated},
+	}, orgID, actorID)
+	require.NoError(t, err)
+	assert.NotEqual(t, webhook.Secret, other.Secret)
+}
+
+func TestWebhookEventFor(t *testing.T) {
+	eventType, ok := domain.WebhookEventFor(domain.EventStatusUpdated)
+	assert.True(t, ok)
+	assert.Equal(t, domain.WebhookTicketStatusChanged, eventType)
+
+	_, ok = domain.WebhookEventFor(domain.EventType("UNKNOWN"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,185 @@
+package errors
+
+import (
+	"errors"
+	"sort"
+)
+
+// ErrorCode is a machine-readable error code returned in the "code" field
+// of API error responses, with the HTTP status it is returned with.
+type ErrorCode struct {
+	Code        string `json:"code"`
+	Status      int    `json:"status"`
+	Description string `json:"description"` // Also the default English message
+}
+
+// Error codes returned by the API
+var (
+	CodeBadRequest              = register("BAD_REQUEST", 400, "The request body could not be read")
+	CodeInvalidValue            = register("VALIDATION_ERROR", 400, "A value breaks a domain rule; the message says which")
+	CodeInvalidStatusTransition = register("INVALID_STATUS_TRANSITION", 400, "Invalid status transition")
+	CodeCannotAssignClosed      = register("CANNOT_ASSIGN_CLOSED", 400, "Cannot assign a closed ticket")
+	CodeCannotSnoozeClosed      = register("CANNOT_SNOOZE_CLOSED", 400, "Cannot snooze a closed ticket")
+	CodeInvalidPayload          = register("INVALID_PAYLOAD", 400, "The payload is not valid JSON")
+	CodeCaptchaFailed           = register("CAPTCHA_FAILED", 400, "The captcha could not be verified")
+	CodeInvalidEmailChangeToken = register("INVALID_EMAIL_CHANGE_TOKEN", 400, "The confirmation link is invalid or has expired")
+	CodeInvalidOptOutToken      = register("INVALID_OPT_OUT_TOKEN", 400, "The link is invalid")
+
+	CodeUnauthorized       = register("UNAUTHORIZED", 401, "Authentication required")
+	CodeInvalidCredentials = register("INVALID_CREDENTIALS", 401, "Invalid credentials")
+	CodeInvalidToken       = register("INVALID_TOKEN", 401, "Invalid or expired token")
+	CodeInvalidAuthFormat  = register("INVALID_AUTH_FORMAT", 401, "Authorization header format must be Bearer {token}")
+	CodeInvalidSignature   = register("INVALID_SIGNATURE", 401, "The request signature is missing or invalid")
+	CodeInvalidPortalLink  = register("INVALID_PORTAL_LINK", 401, "The link is invalid or has expired")
+
+	CodeForbidden               = register("FORBIDDEN", 403, "You do not have permission to perform this action")
+	CodeUserInactive            = register("USER_INACTIVE", 403, "User account is inactive")
+	CodeUserQuotaExceeded       = register("USER_QUOTA_EXCEEDED", 403, "Your organization has reached its user quota")
+	CodeOpenTicketQuotaExceeded = register("OPEN_TICKET_QUOTA_EXCEEDED", 403, "Your organization has reached its open ticket quota")
+	CodeFeatureDisabled         = register("FEATURE_DISABLED", 403, "This feature is not enabled on this server")
+
+	CodeNotFound                  = register("NOT_FOUND", 404, "Resource not found")
+	CodeUserNotFound              = register("USER_NOT_FOUND", 404, "User not found")
+	CodeTicketNotFound            = register("TICKET_NOT_FOUND", 404, "Ticket not found")
+	CodeTeamNotFound              = register("TEAM_NOT_FOUND", 404, "Team not found")
+	CodeEscalationRuleNotFound    = register("ESCALATION_RULE_NOT_FOUND", 404, "Escalation rule not found")
+	CodeMacroNotFound             = register("MACRO_NOT_FOUND", 404, "Macro not found")
+	CodeWebhookNotFound           = register("WEBHOOK_NOT_FOUND", 404, "Webhook not found")
+	CodeInboundHookNotFound       = register("INBOUND_HOOK_NOT_FOUND", 404, "Inbound hook not found")
+	CodePortalNotFound            = register("PORTAL_NOT_FOUND", 404, "Portal not found")
+	CodeOrganizationNotFound      = register("ORGANIZATION_NOT_FOUND", 404, "Organization not found")
+	CodeDataExportNotFound        = register("DATA_EXPORT_NOT_FOUND", 404, "Data export not found")
+	CodeRateLimitOverrideNotFound = register("RATE_LIMIT_OVERRIDE_NOT_FOUND", 404, "Rate limit override not found")
+	CodeRateLimitKeyNotFound      = register("RATE_LIMIT_KEY_NOT_FOUND", 404, "Rate limit key not found")
+	CodeCapturedEmailNotFound     = register("CAPTURED_EMAIL_NOT_FOUND", 404, "Captured email not found")
+	CodeTicketNotSnoozed          = register("TICKET_NOT_SNOOZED", 404, "The ticket is not snoozed")
+	CodeCommentDraftNotFound      = register("COMMENT_DRAFT_NOT_FOUND", 404, "There is no saved draft for this ticket")
+
+	CodeConflict              = register("CONFLICT", 409, "Resource conflict")
+	CodeUserExists            = register("USER_EXISTS", 409, "A user with this email already exists")
+	CodeTeamExists            = register("TEAM_EXISTS", 409, "A team with this name already exists")
+	CodeMacroExists           = register("MACRO_EXISTS", 409, "A macro with this name already exists")
+	CodeTicketAlreadyClaimed  = register("TICKET_ALREADY_CLAIMED", 409, "The ticket is already assigned")
+	CodeAgentAtCapacity       = register("AGENT_AT_CAPACITY", 409, "The assignee already has as many open tickets as the organization allows")
+	CodeTicketArchived        = register("TICKET_ARCHIVED", 409, "The ticket is archived and can no longer be changed")
+	CodeInboundHookExists     = register("INBOUND_HOOK_EXISTS", 409, "An inbound hook with this source already exists")
+	CodePortalSlugTaken       = register("PORTAL_SLUG_TAKEN", 409, "Another organization already uses this portal slug")
+	CodeDataExportNotReady    = register("DATA_EXPORT_NOT_READY", 409, "Data export is not ready")
+	CodeOrganizationRequired  = register("ORGANIZATION_REQUIRED", 409, "The credentials match accounts in several organizations; log in again with one of them")
+	CodeIdempotencyInProgress = register("IDEMPOTENCY_IN_PROGRESS", 409, "A request with this idempotency key is still being processed")
+
+	CodeValidationFailed     = register("VALIDATION_ERROR", 422, "One or more fields are invalid; the fields object lists the problems")
+	CodeInvalidConfig        = register("INVALID_CONFIG", 422, "The configuration is invalid; the current configuration was kept")
+	CodeIdempotencyKeyReused = register("IDEMPOTENCY_KEY_REUSED", 422, "This idempotency key was already used for a different request")
+
+	CodeRateLimited = register("RATE_LIMITED", 429, "Too many requests. Please try again later.")
+
+	CodeInternal           = register("INTERNAL_ERROR", 500, "An unexpected error occurred")
+	CodeServiceUnavailable = register("SERVICE_UNAVAILABLE", 503, "The service is temporarily unavailable. Please try again later.")
+)
+
+var registry []ErrorCode
+
+func register(code string, status int, description string) ErrorCode {
+	c := ErrorCode{Code: code, Status: status, Description: description}
+	registry = append(registry, c)
+	return c
+}
+
+// Codes returns every error code the API can return, ordered by code and
+// status. A code may be returned with more than one status.
+func Codes() []ErrorCode {
+	codes := append([]ErrorCode(nil), registry...)
+	sort.Slice(codes, func(i, j int) bool {
+		if codes[i].Code != codes[j].Code {
+			return codes[i].Code < codes[j].Code
+		}
+		return codes[i].Status < codes[j].Status
+	})
+	return codes
+}
+
+// domainCodes maps domain errors to the code they are returned with,
+// checked in order.
+var domainCodes = []struct {
+	err  error
+	code ErrorCode
+}{
+	// Authentication & Authorization
+	{ErrInvalidCredentials, CodeInvalidCredentials},
+	{ErrInvalidSignature, CodeInvalidSignature},
+	{ErrInvalidPortalLink, CodeInvalidPortalLink},
+	{ErrUnauthorized, CodeUnauthorized},
+	{ErrForbidden, CodeForbidden},
+	{ErrUserInactive, CodeUserInactive},
+	{ErrUserQuotaExceeded, CodeUserQuotaExceeded},
+	{ErrOpenTicketQuotaExceeded, CodeOpenTicketQuotaExceeded},
+	{ErrFeatureDisabled, CodeFeatureDisabled},
+
+	// Not Found errors
+	{ErrUserNotFound, CodeUserNotFound},
+	{ErrTicketNotFound, CodeTicketNotFound},
+	{ErrTeamNotFound, CodeTeamNotFound},
+	{ErrEscalationRuleNotFound, CodeEscalationRuleNotFound},
+	{ErrMacroNotFound, CodeMacroNotFound},
+	{ErrWebhookNotFound, CodeWebhookNotFound},
+	{ErrInboundHookNotFound, CodeInboundHookNotFound},
+	{ErrPortalNotFound, CodePortalNotFound},
+	{ErrOrganizationNotFound, CodeOrganizationNotFound},
+	{ErrDataExportNotFound, CodeDataExportNotFound},
+	{ErrRateLimitOverrideNotFound, CodeRateLimitOverrideNotFound},
+	{ErrRateLimitKeyNotFound, CodeRateLimitKeyNotFound},
+	{ErrCapturedEmailNotFound, CodeCapturedEmailNotFound},
+	{ErrTicketNotSnoozed, CodeTicketNotSnoozed},
+	{ErrCommentDraftNotFound, CodeCommentDraftNotFound},
+
+	// Conflict errors
+	{ErrUserExists, CodeUserExists},
+	{ErrTeamExists, CodeTeamExists},
+	{ErrMacroExists, CodeMacroExists},
+	{ErrTicketAlreadyClaimed, CodeTicketAlreadyClaimed},
+	{ErrAgentAtCapacity, CodeAgentAtCapacity},
+	{ErrTicketArchived, CodeTicketArchived},
+	{ErrInboundHookExists, CodeInboundHookExists},
+	{ErrPortalSlugTaken, CodePortalSlugTaken},
+	{ErrDataExportNotReady, CodeDataExportNotReady},
+	{ErrOrganizationRequired, CodeOrganizationRequired},
+
+	// Validation errors
+	{ErrTitleRequired, CodeInvalidValue},
+	{ErrTitleTooLong, CodeInvalidValue},
+	{ErrDescriptionTooLong, CodeInvalidValue},
+	{ErrInvalidPriority, CodeInvalidValue},
+	{ErrInvalidStatus, CodeInvalidValue},
+	{ErrCommentBodyRequired, CodeInvalidValue},
+	{ErrCommentBodyTooLong, CodeInvalidValue},
+	{ErrEmailRequired, CodeInvalidValue},
+	{ErrEmailInvalid, CodeInvalidValue},
+	{ErrPasswordTooWeak, CodeInvalidValue},
+	{ErrPasswordRequired, CodeInvalidValue},
+	{ErrFullNameRequired, CodeInvalidValue},
+
+	// Business rule violations
+	{ErrInvalidStatusTransition, CodeInvalidStatusTransition},
+	{ErrCannotAssignClosed, CodeCannotAssignClosed},
+	{ErrCannotSnoozeClosed, CodeCannotSnoozeClosed},
+	{ErrInvalidConfig, CodeInvalidConfig},
+	{ErrInvalidPayload, CodeInvalidPayload},
+	{ErrCaptchaFailed, CodeCaptchaFailed},
+	{ErrInvalidEmailChangeToken, CodeInvalidEmailChangeToken},
+	{ErrInvalidOptOutToken, CodeInvalidOptOutToken},
+
+	{ErrRateLimited, CodeRateLimited},
+	{ErrServiceUnavailable, CodeServiceUnavailable},
+}
+
+// CodeFor returns the code a domain error is returned with, or
+// CodeInternal for errors without one.
+func CodeFor(err error) ErrorCode {
+	for _, d := range domainCodes {
+		if errors.Is(err, d.err) {
+			return d.code
+		}
+	}
+	return CodeInternal
+}
@@ -0,0 +1,36 @@
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeFor(t *testing.T) {
+	assert.Equal(t, apperrors.CodeTicketNotFound, apperrors.CodeFor(apperrors.ErrTicketNotFound))
+	assert.Equal(t, apperrors.CodeTicketNotFound, apperrors.CodeFor(fmt.Errorf("get ticket: %w", apperrors.ErrTicketNotFound)))
+	assert.Equal(t, apperrors.CodeInvalidValue, apperrors.CodeFor(apperrors.ErrTitleRequired))
+	assert.Equal(t, apperrors.CodeInternal, apperrors.CodeFor(fmt.Errorf("connection reset")))
+}
+
+func TestCodes(t *testing.T) {
+	codes := apperrors.Codes()
+
+	seen := make(map[string]bool)
+	for i, code := range codes {
+		key := fmt.Sprintf("%s/%d", code.Code, code.Status)
+		assert.False(t, seen[key], "%s is registered twice", key)
+		seen[key] = true
+
+		assert.NotEmpty(t, code.Description, code.Code)
+		if i > 0 {
+			assert.LessOrEqual(t, codes[i-1].Code, code.Code, "codes are sorted")
+		}
+	}
+
+	// Codes returns a copy
+	codes[0].Code = "CHANGED"
+	assert.NotEqual(t, "CHANGED", apperrors.Codes()[0].Code)
+}
@@ -8,13 +8,19 @@ import (
 // Domain errors - these represent business rule violations
 var (
 	// ErrInvalidCredentials Authentication & Authorization
-	ErrInvalidCredentials  = errors.New("invalid credentials")
-	ErrUserExists          = errors.New("user already exists")
-	ErrForbidden           = errors.New("action forbidden")
-	ErrUnauthorized        = errors.New("unauthorized")
-	ErrRoleNotFound        = errors.New("role not found")
-	ErrRoleAlreadyAssigned = errors.New("role already assigned")
-	ErrUserInactive        = errors.New("user is inactive")
+	ErrInvalidCredentials   = errors.New("invalid credentials")
+	ErrUserExists           = errors.New("user already exists")
+	ErrForbidden            = errors.New("action forbidden")
+	ErrUnauthorized         = errors.New("unauthorized")
+	ErrRoleNotFound         = errors.New("role not found")
+	ErrRoleAlreadyAssigned  = errors.New("role already assigned")
+	ErrUserInactive         = errors.New("user is inactive")
+	ErrOrganizationRequired = errors.New("credentials match accounts in several organizations")
+	ErrOrganizationNotFound = errors.New("organization not found")
+
+	// ErrInvalidEmailChangeToken Account changes
+	ErrInvalidEmailChangeToken = errors.New("email change token is invalid or expired")
+	ErrInvalidOptOutToken      = errors.New("email opt-out token is invalid")
 
 	// ErrUserNotFound User validation
 	ErrUserNotFound     = errors.New("user not found")
@@ -35,6 +41,11 @@ var (
 	ErrInvalidStatusTransition = errors.New("invalid status transition")
 	ErrRequesterRequired       = errors.New("requester ID is required")
 	ErrCannotAssignClosed      = errors.New("cannot assign a closed ticket")
+	ErrTicketAlreadyClaimed    = errors.New("ticket is already assigned")
+	ErrAgentAtCapacity         = errors.New("assignee has reached the open ticket limit")
+	ErrTicketArchived          = errors.New("ticket is archived")
+	ErrCannotSnoozeClosed      = errors.New("cannot snooze a closed ticket")
+	ErrTicketNotSnoozed        = errors.New("ticket is not snoozed")
 
 	// ErrCommentBodyRequired Comment validation
 	ErrCommentBodyRequired = errors.New("comment body is required")
@@ -42,12 +53,63 @@ var (
 	ErrTicketIDRequired    = errors.New("ticket ID is required")
 	ErrAuthorIDRequired    = errors.New("author ID is required")
 
+	// ErrCommentDraftNotFound Comment drafts
+	ErrCommentDraftNotFound = errors.New("comment draft not found")
+
+	// ErrTeamNotFound Teams
+	ErrTeamNotFound = errors.New("team not found")
+	ErrTeamExists   = errors.New("a team with this name already exists")
+
+	// ErrEscalationRuleNotFound Escalation rules
+	ErrEscalationRuleNotFound = errors.New("escalation rule not found")
+
+	// ErrMacroNotFound Macros
+	ErrMacroNotFound = errors.New("macro not found")
+	ErrMacroExists   = errors.New("a macro with this name already exists")
+
+	// ErrWebhookNotFound Webhooks
+	ErrWebhookNotFound = errors.New("webhook not found")
+
+	// ErrInboundHookNotFound Inbound hooks
+	ErrInboundHookNotFound = errors.New("inbound hook not found")
+	ErrInboundHookExists   = errors.New("an inbound hook with this source already exists")
+	ErrInvalidSignature    = errors.New("signature is missing or invalid")
+	ErrInvalidPayload      = errors.New("payload is not valid JSON")
+
+	// ErrPortalNotFound Public portal
+	ErrPortalNotFound    = errors.New("portal not found")
+	ErrPortalSlugTaken   = errors.New("portal slug is already taken")
+	ErrCaptchaFailed     = errors.New("captcha verification failed")
+	ErrInvalidPortalLink = errors.New("portal link is invalid or expired")
+
+	// ErrDataExportNotFound Data exports
+	ErrDataExportNotFound = errors.New("data export not found")
+	ErrDataExportNotReady = errors.New("data export is not ready")
+
+	// ErrRateLimitOverrideNotFound Rate limits
+	ErrRateLimitOverrideNotFound = errors.New("rate limit override not found")
+	ErrRateLimitKeyNotFound      = errors.New("rate limit key not found")
+
+	// ErrCapturedEmailNotFound Development email previews
+	ErrCapturedEmailNotFound = errors.New("captured email not found")
+
+	// ErrUserQuotaExceeded Quotas
+	ErrUserQuotaExceeded       = errors.New("organization has reached its user quota")
+	ErrOpenTicketQuotaExceeded = errors.New("organization has reached its open ticket quota")
+
+	// ErrInvalidConfig Runtime configuration
+	ErrInvalidConfig   = errors.New("configuration is invalid")
+	ErrFeatureDisabled = errors.New("feature is not enabled")
+
 	// ErrNotFound Generic
 	ErrNotFound    = errors.New("resource not found")
 	ErrInternal    = errors.New("internal server error")
 	ErrBadRequest  = errors.New("bad request")
 	ErrConflict    = errors.New("resource conflict")
 	ErrRateLimited = errors.New("rate limit exceeded")
+
+	// ErrServiceUnavailable A dependency is down and requests fail fast
+	ErrServiceUnavailable = errors.New("service temporarily unavailable")
 )
 
 // AppError wraps errors with additional context for HTTP responses
@@ -75,8 +137,8 @@ func NewBadRequestError(err error, message string) *AppError {
 	return &AppError{
 		Err:        err,
 		Message:    message,
-		Code:       "BAD_REQUEST",
-		StatusCode: 400,
+		Code:       CodeBadRequest.Code,
+		StatusCode: CodeBadRequest.Status,
 	}
 }
 
@@ -84,8 +146,8 @@ func NewUnauthorizedError(message string) *AppError {
 	return &AppError{
 		Err:        ErrUnauthorized,
 		Message:    message,
-		Code:       "UNAUTHORIZED",
-		StatusCode: 401,
+		Code:       CodeUnauthorized.Code,
+		StatusCode: CodeUnauthorized.Status,
 	}
 }
 
@@ -93,8 +155,8 @@ func NewForbiddenError(message string) *AppError {
 	return &AppError{
 		Err:        ErrForbidden,
 		Message:    message,
-		Code:       "FORBIDDEN",
-		StatusCode: 403,
+		Code:       CodeForbidden.Code,
+		StatusCode: CodeForbidden.Status,
 	}
 }
 
@@ -102,8 +164,8 @@ func NewNotFoundError(err error, message string) *AppError {
 	return &AppError{
 		Err:        err,
 		Message:    message,
-		Code:       "NOT_FOUND",
-		StatusCode: 404,
+		Code:       CodeNotFound.Code,
+		StatusCode: CodeNotFound.Status,
 	}
 }
 
@@ -111,8 +173,8 @@ func NewConflictError(err error, message string) *AppError {
 	return &AppError{
 		Err:        err,
 		Message:    message,
-		Code:       "CONFLICT",
-		StatusCode: 409,
+		Code:       CodeConflict.Code,
+		StatusCode: CodeConflict.Status,
 	}
 }
 
@@ -120,27 +182,30 @@ func NewValidationError(err error, message string, details map[string]interface{
 	return &AppError{
 		Err:        err,
 		Message:    message,
-		Code:       "VALIDATION_ERROR",
-		StatusCode: 422,
+		Code:       CodeValidationFailed.Code,
+		StatusCode: CodeValidationFailed.Status,
 		Details:    details,
 	}
 }
 
-func NewRateLimitError() *AppError {
+// NewRateLimitError reports a rejected request and how many seconds the
+// client should wait before retrying.
+func NewRateLimitError(retryAfterSeconds int) *AppError {
 	return &AppError{
 		Err:        ErrRateLimited,
-		Message:    "Too many requests. Please try again later.",
-		Code:       "RATE_LIMITED",
-		StatusCode: 429,
+		Message:    CodeRateLimited.Description,
+		Code:       CodeRateLimited.Code,
+		StatusCode: CodeRateLimited.Status,
+		Details:    map[string]interface{}{"retryAfter": retryAfterSeconds},
 	}
 }
 
 func NewInternalError(err error) *AppError {
 	return &AppError{
 		Err:        err,
-		Message:    "An unexpected error occurred",
-		Code:       "INTERNAL_ERROR",
-		StatusCode: 500,
+		Message:    CodeInternal.Description,
+		Code:       CodeInternal.Code,
+		StatusCode: CodeInternal.Status,
 	}
 }
 
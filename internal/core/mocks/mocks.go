@@ -27,14 +27,22 @@ func (m *MockUserRepository) Create(ctx context.Context, user *domain.User) (*do
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
-func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
-	args := m.Called(ctx, email)
+func (m *MockUserRepository) GetByEmail(ctx context.Context, orgID uuid.UUID, email string) (*domain.User, error) {
+	args := m.Called(ctx, orgID, email)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockUserRepository) ListByEmail(ctx context.Context, email string) ([]*domain.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+
 func (m *MockUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -48,20 +56,25 @@ func (m *MockUserRepository) CountUsers(ctx context.Context) (int64, error) {
 	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockUserRepository) ListAssignableUsers(ctx context.Context, orgID uuid.UUID) ([]*domain.User, error) {
-	args := m.Called(ctx, orgID)
+func (m *MockUserRepository) ListAssignableUsers(ctx context.Context, orgID uuid.UUID, filter domain.AssigneeFilter) ([]*domain.User, error) {
+	args := m.Called(ctx, orgID, filter)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*domain.User), args.Error(1)
 }
 
-func (m *MockUserRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.UserSummary, error) {
-	args := m.Called(ctx, orgID)
+func (m *MockUserRepository) SearchByOrganization(ctx context.Context, orgID uuid.UUID, filter domain.UserFilter) ([]*domain.UserSummary, domain.Total, error) {
+	args := m.Called(ctx, orgID, filter)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, domain.Total{}, args.Error(2)
 	}
-	return args.Get(0).([]*domain.UserSummary), args.Error(1)
+	return args.Get(0).([]*domain.UserSummary), args.Get(1).(domain.Total), args.Error(2)
+}
+
+func (m *MockUserRepository) ListPageByOrganization(ctx context.Context, orgID uuid.UUID, page domain.PageRequest) (domain.Page[*domain.User], error) {
+	args := m.Called(ctx, orgID, page)
+	return args.Get(0).(domain.Page[*domain.User]), args.Error(1)
 }
 
 func (m *MockUserRepository) SetActive(ctx context.Context, userID uuid.UUID, isActive bool) error {
@@ -74,11 +87,59 @@ func (m *MockUserRepository) UpdatePassword(ctx context.Context, userID uuid.UUI
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) UpdateLastActiveBatch(ctx context.Context, lastActive map[uuid.UUID]time.Time) error {
+	args := m.Called(ctx, lastActive)
+	return args.Error(0)
+}
+
 func (m *MockUserRepository) UpdateLastActive(ctx context.Context, userID uuid.UUID, at time.Time) error {
 	args := m.Called(ctx, userID, at)
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) RevokeTokens(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, userID, at)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) Anonymize(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, userID, at)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateSMSPreferences(ctx context.Context, userID uuid.UUID, prefs domain.SMSPreferences) error {
+	args := m.Called(ctx, userID, prefs)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateLocale(ctx context.Context, userID uuid.UUID, locale string) error {
+	args := m.Called(ctx, userID, locale)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateProfile(ctx context.Context, user *domain.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateEmail(ctx context.Context, userID uuid.UUID, email string) error {
+	args := m.Called(ctx, userID, email)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetAvailability(ctx context.Context, userID uuid.UUID) (*domain.Availability, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Availability), args.Error(1)
+}
+
+func (m *MockUserRepository) SaveAvailability(ctx context.Context, availability *domain.Availability) error {
+	args := m.Called(ctx, availability)
+	return args.Error(0)
+}
+
 // MockTicketRepository is a mock implementation of ports.TicketRepository
 type MockTicketRepository struct {
 	mock.Mock
@@ -96,8 +157,13 @@ func (m *MockTicketRepository) Create(ctx context.Context, ticket *domain.Ticket
 	return args.Get(0).(*domain.Ticket), args.Error(1)
 }
 
-func (m *MockTicketRepository) GetByID(ctx context.Context, id int64) (*domain.Ticket, error) {
-	args := m.Called(ctx, id)
+func (m *MockTicketRepository) CreateBatch(ctx context.Context, tickets []*domain.Ticket) error {
+	args := m.Called(ctx, tickets)
+	return args.Error(0)
+}
+
+func (m *MockTicketRepository) GetByID(ctx context.Context, orgID uuid.UUID, id int64) (*domain.Ticket, error) {
+	args := m.Called(ctx, orgID, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -112,6 +178,14 @@ func (m *MockTicketRepository) Update(ctx context.Context, ticket *domain.Ticket
 	return args.Get(0).(*domain.Ticket), args.Error(1)
 }
 
+func (m *MockTicketRepository) Claim(ctx context.Context, orgID uuid.UUID, ticketID int64, assigneeID uuid.UUID, at time.Time) (*domain.Ticket, error) {
+	args := m.Called(ctx, orgID, ticketID, assigneeID, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Ticket), args.Error(1)
+}
+
 func (m *MockTicketRepository) ListPaginated(ctx context.Context, params ports.ListTicketsRepoParams) ([]*domain.Ticket, error) {
 	args := m.Called(ctx, params)
 	if args.Get(0) == nil {
@@ -128,6 +202,27 @@ func (m *MockTicketRepository) ListByRequesterPaginated(ctx context.Context, par
 	return args.Get(0).([]*domain.Ticket), args.Error(1)
 }
 
+func (m *MockTicketRepository) ListByAssigneePaginated(ctx context.Context, params ports.ListTicketsRepoParams) ([]*domain.Ticket, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Ticket), args.Error(1)
+}
+
+func (m *MockTicketRepository) ListPage(ctx context.Context, params ports.ListTicketsRepoParams, page domain.PageRequest) (domain.Page[*domain.Ticket], error) {
+	args := m.Called(ctx, params, page)
+	return args.Get(0).(domain.Page[*domain.Ticket]), args.Error(1)
+}
+
+func (m *MockTicketRepository) ListOpenByAssignee(ctx context.Context, orgID, assigneeID uuid.UUID) ([]*domain.Ticket, error) {
+	args := m.Called(ctx, orgID, assigneeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Ticket), args.Error(1)
+}
+
 // MockAuthorizationRepository is a mock implementation of ports.AuthorizationRepository
 type MockAuthorizationRepository struct {
 	mock.Mock
@@ -155,6 +250,14 @@ func (m *MockAuthorizationRepository) SetUserRole(ctx context.Context, userID uu
 	return args.Error(0)
 }
 
+func (m *MockAuthorizationRepository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
 func (m *MockAuthorizationRepository) EnsureRBACDefaults(ctx context.Context) error {
 	args := m.Called(ctx)
 	return args.Error(0)
@@ -177,6 +280,11 @@ func (m *MockCommentRepository) Create(ctx context.Context, comment *domain.Comm
 	return args.Get(0).(*domain.Comment), args.Error(1)
 }
 
+func (m *MockCommentRepository) CreateBatch(ctx context.Context, comments []*domain.Comment) error {
+	args := m.Called(ctx, comments)
+	return args.Error(0)
+}
+
 func (m *MockCommentRepository) ListByTicketID(ctx context.Context, ticketID int64) ([]*domain.Comment, error) {
 	args := m.Called(ctx, ticketID)
 	if args.Get(0) == nil {
@@ -185,6 +293,103 @@ func (m *MockCommentRepository) ListByTicketID(ctx context.Context, ticketID int
 	return args.Get(0).([]*domain.Comment), args.Error(1)
 }
 
+func (m *MockCommentRepository) ListLatestByTicketIDs(ctx context.Context, ticketIDs []int64) ([]*domain.Comment, error) {
+	args := m.Called(ctx, ticketIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) ListPageByTicketID(ctx context.Context, ticketID int64, page domain.PageRequest) (domain.Page[*domain.Comment], error) {
+	args := m.Called(ctx, ticketID, page)
+	return args.Get(0).(domain.Page[*domain.Comment]), args.Error(1)
+}
+
+func (m *MockCommentRepository) MarkFirstResponse(ctx context.Context, comment *domain.Comment) error {
+	args := m.Called(ctx, comment)
+	return args.Error(0)
+}
+
+// MockAnalyticsRepository is a mock implementation of ports.AnalyticsRepository
+type MockAnalyticsRepository struct {
+	mock.Mock
+}
+
+func NewMockAnalyticsRepository() *MockAnalyticsRepository {
+	return &MockAnalyticsRepository{}
+}
+
+func (m *MockAnalyticsRepository) GetOverview(ctx context.Context, orgID uuid.UUID, rng domain.AnalyticsRange) (*domain.AnalyticsOverview, error) {
+	args := m.Called(ctx, orgID, rng)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AnalyticsOverview), args.Error(1)
+}
+
+func (m *MockAnalyticsRepository) RollupThrough(ctx context.Context) (*time.Time, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (m *MockAnalyticsRepository) RefreshDailyRollups(ctx context.Context, from, through time.Time) error {
+	args := m.Called(ctx, from, through)
+	return args.Error(0)
+}
+
+func (m *MockAnalyticsRepository) GetWorkload(ctx context.Context, orgID uuid.UUID) ([]domain.WorkloadItem, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.WorkloadItem), args.Error(1)
+}
+
+func (m *MockAnalyticsRepository) ListAgentTicketHistory(ctx context.Context, orgID uuid.UUID, query domain.SimilarTicketsQuery) ([]domain.AgentTicketHistory, error) {
+	args := m.Called(ctx, orgID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.AgentTicketHistory), args.Error(1)
+}
+
+// MockAuthService is a mock implementation of ports.AuthService
+type MockAuthService struct {
+	mock.Mock
+}
+
+func NewMockAuthService() *MockAuthService {
+	return &MockAuthService{}
+}
+
+func (m *MockAuthService) Register(ctx context.Context, fullName, email, password, role string, orgID uuid.UUID) (*domain.User, error) {
+	args := m.Called(ctx, fullName, email, password, role, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockAuthService) Login(ctx context.Context, orgID uuid.UUID, email, password string) (*domain.User, error) {
+	args := m.Called(ctx, orgID, email, password)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockAuthService) ValidateSession(ctx context.Context, userID uuid.UUID, issuedAt time.Time) (*domain.User, error) {
+	args := m.Called(ctx, userID, issuedAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
 // MockAuthorizationService is a mock implementation of ports.AuthorizationService
 type MockAuthorizationService struct {
 	mock.Mock
@@ -240,7 +445,32 @@ func (m *MockTicketService) UpdateStatus(ctx context.Context, params ports.Updat
 	return args.Get(0).(*domain.Ticket), args.Error(1)
 }
 
-func (m *MockTicketService) AssignTicket(ctx context.Context, params ports.AssignTicketParams) (*domain.Ticket, error) {
+func (m *MockTicketService) AssignTicket(ctx context.Context, params ports.AssignTicketParams) (*domain.Ticket, *domain.CapacityWarning, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	warning, _ := args.Get(1).(*domain.CapacityWarning)
+	return args.Get(0).(*domain.Ticket), warning, args.Error(2)
+}
+
+func (m *MockTicketService) AssignTeam(ctx context.Context, params ports.AssignTeamParams) (*domain.Ticket, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Ticket), args.Error(1)
+}
+
+func (m *MockTicketService) ClaimTicket(ctx context.Context, params ports.ClaimTicketParams) (*domain.Ticket, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Ticket), args.Error(1)
+}
+
+func (m *MockTicketService) UpdateTicket(ctx context.Context, params ports.UpdateTicketParams) (*domain.Ticket, error) {
 	args := m.Called(ctx, params)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -256,8 +486,37 @@ func (m *MockTicketService) ListTickets(ctx context.Context, params ports.ListTi
 	return args.Get(0).([]*domain.Ticket), args.Error(1)
 }
 
-func (m *MockTicketService) Shutdown() {
-	m.Called()
+// MockCommentService is a mock implementation of ports.CommentService
+type MockCommentService struct {
+	mock.Mock
+}
+
+func NewMockCommentService() *MockCommentService {
+	return &MockCommentService{}
+}
+
+func (m *MockCommentService) CreateComment(ctx context.Context, params ports.CreateCommentParams) (*domain.Comment, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Comment), args.Error(1)
+}
+
+func (m *MockCommentService) GetCommentsForTicket(ctx context.Context, params ports.GetCommentsParams) ([]*domain.Comment, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Comment), args.Error(1)
+}
+
+func (m *MockCommentService) GetLatestComments(ctx context.Context, params ports.GetLatestCommentsParams) (map[int64]*domain.Comment, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64]*domain.Comment), args.Error(1)
 }
 
 // MockNotifier is a mock implementation of ports.Notifier
@@ -269,29 +528,1054 @@ func NewMockNotifier() *MockNotifier {
 	return &MockNotifier{}
 }
 
-func (m *MockNotifier) Notify(ctx context.Context, params ports.NotificationParams) {
-	m.Called(ctx, params)
+func (m *MockNotifier) Notify(ctx context.Context, params ports.NotificationParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
 }
 
-// MockTicketEventRepository is a mock implementation of ports.TicketEventRepository
-type MockTicketEventRepository struct {
+// MockNotificationOutboxRepository is a mock implementation of ports.NotificationOutboxRepository
+type MockNotificationOutboxRepository struct {
 	mock.Mock
 }
 
-func NewMockTicketEventRepository() *MockTicketEventRepository {
-	return &MockTicketEventRepository{}
+func NewMockNotificationOutboxRepository() *MockNotificationOutboxRepository {
+	return &MockNotificationOutboxRepository{}
 }
 
-func (m *MockTicketEventRepository) Create(ctx context.Context, event *domain.Event) (*domain.Event, error) {
-	args := m.Called(ctx, event)
+func (m *MockNotificationOutboxRepository) Enqueue(ctx context.Context, params ports.NotificationParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
+
+func (m *MockNotificationOutboxRepository) EnqueueBatched(ctx context.Context, batchKey string, params ports.NotificationParams, window time.Duration) error {
+	args := m.Called(ctx, batchKey, params, window)
+	return args.Error(0)
+}
+
+func (m *MockNotificationOutboxRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*ports.OutboxNotification, error) {
+	args := m.Called(ctx, limit, lease)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*domain.Event), args.Error(1)
+	return args.Get(0).([]*ports.OutboxNotification), args.Error(1)
 }
 
-func (m *MockTicketEventRepository) ListByTicketID(ctx context.Context, ticketID int64, afterID int64, limit int) ([]*domain.Event, error) {
-	args := m.Called(ctx, ticketID, afterID, limit)
+func (m *MockNotificationOutboxRepository) MarkSent(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockNotificationOutboxRepository) MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, lastErr string) error {
+	args := m.Called(ctx, id, nextAttemptAt, lastErr)
+	return args.Error(0)
+}
+
+func (m *MockNotificationOutboxRepository) MarkDead(ctx context.Context, id int64, lastErr string) error {
+	args := m.Called(ctx, id, lastErr)
+	return args.Error(0)
+}
+
+// MockInboundHookRepository is a mock implementation of ports.InboundHookRepository
+type MockInboundHookRepository struct {
+	mock.Mock
+}
+
+func NewMockInboundHookRepository() *MockInboundHookRepository {
+	return &MockInboundHookRepository{}
+}
+
+func (m *MockInboundHookRepository) Create(ctx context.Context, hook *domain.InboundHook) (*domain.InboundHook, error) {
+	args := m.Called(ctx, hook)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.InboundHook), args.Error(1)
+}
+
+func (m *MockInboundHookRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.InboundHook, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.InboundHook), args.Error(1)
+}
+
+func (m *MockInboundHookRepository) GetBySource(ctx context.Context, source string) (*domain.InboundHook, error) {
+	args := m.Called(ctx, source)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.InboundHook), args.Error(1)
+}
+
+func (m *MockInboundHookRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.InboundHook, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.InboundHook), args.Error(1)
+}
+
+func (m *MockInboundHookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MockTeamRepository is a mock implementation of ports.TeamRepository
+type MockTeamRepository struct {
+	mock.Mock
+}
+
+func NewMockTeamRepository() *MockTeamRepository {
+	return &MockTeamRepository{}
+}
+
+func (m *MockTeamRepository) Create(ctx context.Context, team *domain.Team) (*domain.Team, error) {
+	args := m.Called(ctx, team)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Team, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.Team, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) ListByMember(ctx context.Context, userID uuid.UUID) ([]*domain.Team, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) Update(ctx context.Context, team *domain.Team) error {
+	args := m.Called(ctx, team)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) AddMember(ctx context.Context, teamID, userID uuid.UUID) error {
+	args := m.Called(ctx, teamID, userID)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) RemoveMember(ctx context.Context, teamID, userID uuid.UUID) error {
+	args := m.Called(ctx, teamID, userID)
+	return args.Error(0)
+}
+
+// MockEscalationRuleRepository is a mock implementation of ports.EscalationRuleRepository
+type MockEscalationRuleRepository struct {
+	mock.Mock
+}
+
+func NewMockEscalationRuleRepository() *MockEscalationRuleRepository {
+	return &MockEscalationRuleRepository{}
+}
+
+func (m *MockEscalationRuleRepository) Create(ctx context.Context, rule *domain.EscalationRule) (*domain.EscalationRule, error) {
+	args := m.Called(ctx, rule)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EscalationRule), args.Error(1)
+}
+
+func (m *MockEscalationRuleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.EscalationRule, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EscalationRule), args.Error(1)
+}
+
+func (m *MockEscalationRuleRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.EscalationRule, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.EscalationRule), args.Error(1)
+}
+
+func (m *MockEscalationRuleRepository) Update(ctx context.Context, rule *domain.EscalationRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockEscalationRuleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MockMacroRepository is a mock implementation of ports.MacroRepository
+type MockMacroRepository struct {
+	mock.Mock
+}
+
+func NewMockMacroRepository() *MockMacroRepository {
+	return &MockMacroRepository{}
+}
+
+func (m *MockMacroRepository) Create(ctx context.Context, macro *domain.Macro) (*domain.Macro, error) {
+	args := m.Called(ctx, macro)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Macro), args.Error(1)
+}
+
+func (m *MockMacroRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Macro, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Macro), args.Error(1)
+}
+
+func (m *MockMacroRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.Macro, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Macro), args.Error(1)
+}
+
+func (m *MockMacroRepository) Update(ctx context.Context, macro *domain.Macro) error {
+	args := m.Called(ctx, macro)
+	return args.Error(0)
+}
+
+func (m *MockMacroRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MockWebhookRepository is a mock implementation of ports.WebhookRepository
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func NewMockWebhookRepository() *MockWebhookRepository {
+	return &MockWebhookRepository{}
+}
+
+func (m *MockWebhookRepository) Create(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	args := m.Called(ctx, webhook)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Webhook, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.Webhook, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) EnqueueForTicket(ctx context.Context, ticketID int64, eventType domain.WebhookEventType, payload []byte) error {
+	args := m.Called(ctx, ticketID, eventType, payload)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) (*domain.WebhookDelivery, error) {
+	args := m.Called(ctx, delivery)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) ListDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]*domain.WebhookDelivery, error) {
+	args := m.Called(ctx, webhookID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*ports.WebhookDeliveryJob, error) {
+	args := m.Called(ctx, limit, lease)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*ports.WebhookDeliveryJob), args.Error(1)
+}
+
+func (m *MockWebhookRepository) MarkDelivered(ctx context.Context, id int64, responseStatus int) error {
+	args := m.Called(ctx, id, responseStatus)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, responseStatus int, lastErr string) error {
+	args := m.Called(ctx, id, nextAttemptAt, responseStatus, lastErr)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) MarkDead(ctx context.Context, id int64, responseStatus int, lastErr string) error {
+	args := m.Called(ctx, id, responseStatus, lastErr)
+	return args.Error(0)
+}
+
+// MockOrgSettingsRepository is a mock implementation of ports.OrgSettingsRepository
+type MockOrgSettingsRepository struct {
+	mock.Mock
+}
+
+func NewMockOrgSettingsRepository() *MockOrgSettingsRepository {
+	return &MockOrgSettingsRepository{}
+}
+
+func (m *MockOrgSettingsRepository) GetBusinessHours(ctx context.Context, orgID uuid.UUID) (*domain.BusinessHours, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BusinessHours), args.Error(1)
+}
+
+func (m *MockOrgSettingsRepository) SaveBusinessHours(ctx context.Context, hours *domain.BusinessHours) (*domain.BusinessHours, error) {
+	args := m.Called(ctx, hours)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BusinessHours), args.Error(1)
+}
+
+func (m *MockOrgSettingsRepository) GetRegistrationDomains(ctx context.Context, orgID uuid.UUID) ([]string, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockOrgSettingsRepository) SaveRegistrationDomains(ctx context.Context, orgID uuid.UUID, domains []string) error {
+	args := m.Called(ctx, orgID, domains)
+	return args.Error(0)
+}
+
+func (m *MockOrgSettingsRepository) GetPortalSlug(ctx context.Context, orgID uuid.UUID) (string, error) {
+	args := m.Called(ctx, orgID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockOrgSettingsRepository) SavePortalSlug(ctx context.Context, orgID uuid.UUID, slug string) error {
+	args := m.Called(ctx, orgID, slug)
+	return args.Error(0)
+}
+
+func (m *MockOrgSettingsRepository) GetOrganizationIDByPortalSlug(ctx context.Context, slug string) (uuid.UUID, error) {
+	args := m.Called(ctx, slug)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *MockOrgSettingsRepository) CreateOrganization(ctx context.Context, name, slug string) (uuid.UUID, error) {
+	args := m.Called(ctx, name, slug)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *MockOrgSettingsRepository) GetOrganization(ctx context.Context, orgID uuid.UUID) (*domain.Organization, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Organization), args.Error(1)
+}
+
+func (m *MockOrgSettingsRepository) GetAgentCapacity(ctx context.Context, orgID uuid.UUID) (*domain.AgentCapacity, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AgentCapacity), args.Error(1)
+}
+
+func (m *MockOrgSettingsRepository) SaveAgentCapacity(ctx context.Context, capacity *domain.AgentCapacity) error {
+	args := m.Called(ctx, capacity)
+	return args.Error(0)
+}
+
+// MockRetentionRepository is a mock implementation of ports.RetentionRepository
+type MockRetentionRepository struct {
+	mock.Mock
+}
+
+func NewMockRetentionRepository() *MockRetentionRepository {
+	return &MockRetentionRepository{}
+}
+
+func (m *MockRetentionRepository) GetPolicy(ctx context.Context, orgID uuid.UUID) (*domain.RetentionPolicy, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RetentionPolicy), args.Error(1)
+}
+
+func (m *MockRetentionRepository) SavePolicy(ctx context.Context, policy *domain.RetentionPolicy) (*domain.RetentionPolicy, error) {
+	args := m.Called(ctx, policy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RetentionPolicy), args.Error(1)
+}
+
+func (m *MockRetentionRepository) ListEnabledPolicies(ctx context.Context) ([]*domain.RetentionPolicy, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.RetentionPolicy), args.Error(1)
+}
+
+func (m *MockRetentionRepository) ListPurgeCandidates(ctx context.Context, orgID uuid.UUID, closedBefore time.Time, limit, offset int) ([]*domain.PurgeCandidate, domain.Total, error) {
+	args := m.Called(ctx, orgID, closedBefore, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(domain.Total), args.Error(2)
+	}
+	return args.Get(0).([]*domain.PurgeCandidate), args.Get(1).(domain.Total), args.Error(2)
+}
+
+func (m *MockRetentionRepository) CountExpired(ctx context.Context, orgID uuid.UUID, closedBefore, deletedBefore time.Time) (int64, int64, error) {
+	args := m.Called(ctx, orgID, closedBefore, deletedBefore)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRetentionRepository) SoftDeleteExpired(ctx context.Context, orgID uuid.UUID, closedBefore, now time.Time) (int64, error) {
+	args := m.Called(ctx, orgID, closedBefore, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRetentionRepository) HardDeleteExpired(ctx context.Context, orgID uuid.UUID, deletedBefore time.Time) (int64, error) {
+	args := m.Called(ctx, orgID, deletedBefore)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockQuotaRepository is a mock implementation of ports.QuotaRepository
+type MockQuotaRepository struct {
+	mock.Mock
+}
+
+func NewMockQuotaRepository() *MockQuotaRepository {
+	return &MockQuotaRepository{}
+}
+
+func (m *MockQuotaRepository) GetQuota(ctx context.Context, orgID uuid.UUID) (*domain.OrgQuota, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OrgQuota), args.Error(1)
+}
+
+func (m *MockQuotaRepository) GetUsage(ctx context.Context, orgID uuid.UUID) (*domain.OrgUsage, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OrgUsage), args.Error(1)
+}
+
+// MockQuotaService is a mock implementation of ports.QuotaService
+type MockQuotaService struct {
+	mock.Mock
+}
+
+func NewMockQuotaService() *MockQuotaService {
+	return &MockQuotaService{}
+}
+
+func (m *MockQuotaService) GetReport(ctx context.Context, actorID, orgID uuid.UUID) (*domain.QuotaReport, error) {
+	args := m.Called(ctx, actorID, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.QuotaReport), args.Error(1)
+}
+
+func (m *MockQuotaService) Report(ctx context.Context, orgID uuid.UUID) (*domain.QuotaReport, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.QuotaReport), args.Error(1)
+}
+
+func (m *MockQuotaService) CheckUsers(ctx context.Context, orgID uuid.UUID, adding int) error {
+	args := m.Called(ctx, orgID, adding)
+	return args.Error(0)
+}
+
+func (m *MockQuotaService) CheckOpenTicket(ctx context.Context, orgID uuid.UUID) error {
+	args := m.Called(ctx, orgID)
+	return args.Error(0)
+}
+
+// MockPlatformRepository is a mock implementation of ports.PlatformRepository
+type MockPlatformRepository struct {
+	mock.Mock
+}
+
+func NewMockPlatformRepository() *MockPlatformRepository {
+	return &MockPlatformRepository{}
+}
+
+func (m *MockPlatformRepository) ListOrganizations(ctx context.Context, filter domain.OrganizationFilter) ([]*domain.OrganizationSummary, domain.Total, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, domain.Total{}, args.Error(2)
+	}
+	return args.Get(0).([]*domain.OrganizationSummary), args.Get(1).(domain.Total), args.Error(2)
+}
+
+func (m *MockPlatformRepository) GetHealth(ctx context.Context, orgID uuid.UUID, since time.Time) (*domain.OrganizationHealth, error) {
+	args := m.Called(ctx, orgID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OrganizationHealth), args.Error(1)
+}
+
+// MockAuditLogRepository is a mock implementation of ports.AuditLogRepository
+type MockAuditLogRepository struct {
+	mock.Mock
+}
+
+func NewMockAuditLogRepository() *MockAuditLogRepository {
+	return &MockAuditLogRepository{}
+}
+
+func (m *MockAuditLogRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockAuditLogRepository) List(ctx context.Context, orgID uuid.UUID, filter domain.AuditLogFilter) ([]*domain.AuditEntry, domain.Total, error) {
+	args := m.Called(ctx, orgID, filter)
+	if args.Get(0) == nil {
+		return nil, domain.Total{}, args.Error(2)
+	}
+	return args.Get(0).([]*domain.AuditEntry), args.Get(1).(domain.Total), args.Error(2)
+}
+
+func (m *MockAuditLogRepository) ListPage(ctx context.Context, orgID uuid.UUID, filter domain.AuditLogFilter, page domain.PageRequest) (domain.Page[*domain.AuditEntry], error) {
+	args := m.Called(ctx, orgID, filter, page)
+	return args.Get(0).(domain.Page[*domain.AuditEntry]), args.Error(1)
+}
+
+// MockDataExportRepository is a mock implementation of ports.DataExportRepository
+type MockDataExportRepository struct {
+	mock.Mock
+}
+
+func NewMockDataExportRepository() *MockDataExportRepository {
+	return &MockDataExportRepository{}
+}
+
+func (m *MockDataExportRepository) Create(ctx context.Context, export *domain.DataExport) error {
+	args := m.Called(ctx, export)
+	return args.Error(0)
+}
+
+func (m *MockDataExportRepository) GetLatest(ctx context.Context, userID uuid.UUID) (*domain.DataExport, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportRepository) ClaimPending(ctx context.Context) (*domain.DataExport, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportRepository) MarkReady(ctx context.Context, id uuid.UUID, payload []byte, completedAt, expiresAt time.Time) error {
+	args := m.Called(ctx, id, payload, completedAt, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockDataExportRepository) MarkFailed(ctx context.Context, id uuid.UUID, completedAt time.Time, lastErr string) error {
+	args := m.Called(ctx, id, completedAt, lastErr)
+	return args.Error(0)
+}
+
+func (m *MockDataExportRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockDataExportRepository) CollectUserData(ctx context.Context, userID uuid.UUID) (*domain.UserData, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserData), args.Error(1)
+}
+
+// MockTicketSnoozeRepository is a mock implementation of ports.TicketSnoozeRepository
+type MockTicketSnoozeRepository struct {
+	mock.Mock
+}
+
+func NewMockTicketSnoozeRepository() *MockTicketSnoozeRepository {
+	return &MockTicketSnoozeRepository{}
+}
+
+func (m *MockTicketSnoozeRepository) Upsert(ctx context.Context, snooze *domain.TicketSnooze) (*domain.TicketSnooze, error) {
+	args := m.Called(ctx, snooze)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TicketSnooze), args.Error(1)
+}
+
+func (m *MockTicketSnoozeRepository) GetByTicketID(ctx context.Context, ticketID int64) (*domain.TicketSnooze, error) {
+	args := m.Called(ctx, ticketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TicketSnooze), args.Error(1)
+}
+
+func (m *MockTicketSnoozeRepository) Delete(ctx context.Context, ticketID int64) error {
+	args := m.Called(ctx, ticketID)
+	return args.Error(0)
+}
+
+func (m *MockTicketSnoozeRepository) TakeDue(ctx context.Context, now time.Time, limit int) ([]*domain.TicketSnooze, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.TicketSnooze), args.Error(1)
+}
+
+// MockCommentDraftRepository is a mock implementation of ports.CommentDraftRepository
+type MockCommentDraftRepository struct {
+	mock.Mock
+}
+
+func NewMockCommentDraftRepository() *MockCommentDraftRepository {
+	return &MockCommentDraftRepository{}
+}
+
+func (m *MockCommentDraftRepository) Upsert(ctx context.Context, draft *domain.CommentDraft) (*domain.CommentDraft, error) {
+	args := m.Called(ctx, draft)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CommentDraft), args.Error(1)
+}
+
+func (m *MockCommentDraftRepository) Get(ctx context.Context, ticketID int64, userID uuid.UUID) (*domain.CommentDraft, error) {
+	args := m.Called(ctx, ticketID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CommentDraft), args.Error(1)
+}
+
+func (m *MockCommentDraftRepository) Delete(ctx context.Context, ticketID int64, userID uuid.UUID) error {
+	args := m.Called(ctx, ticketID, userID)
+	return args.Error(0)
+}
+
+// MockEmailPreferenceRepository is a mock implementation of ports.EmailPreferenceRepository
+type MockEmailPreferenceRepository struct {
+	mock.Mock
+}
+
+func NewMockEmailPreferenceRepository() *MockEmailPreferenceRepository {
+	return &MockEmailPreferenceRepository{}
+}
+
+func (m *MockEmailPreferenceRepository) Get(ctx context.Context, userID uuid.UUID) (*domain.EmailPreferences, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EmailPreferences), args.Error(1)
+}
+
+func (m *MockEmailPreferenceRepository) SetUnsubscribed(ctx context.Context, userID uuid.UUID, unsubscribed bool) error {
+	args := m.Called(ctx, userID, unsubscribed)
+	return args.Error(0)
+}
+
+func (m *MockEmailPreferenceRepository) MuteTicket(ctx context.Context, userID uuid.UUID, ticketID int64) error {
+	args := m.Called(ctx, userID, ticketID)
+	return args.Error(0)
+}
+
+func (m *MockEmailPreferenceRepository) UnmuteTicket(ctx context.Context, userID uuid.UUID, ticketID int64) error {
+	args := m.Called(ctx, userID, ticketID)
+	return args.Error(0)
+}
+
+// MockRateLimitOverrideRepository is a mock implementation of ports.RateLimitOverrideRepository
+type MockRateLimitOverrideRepository struct {
+	mock.Mock
+}
+
+func NewMockRateLimitOverrideRepository() *MockRateLimitOverrideRepository {
+	return &MockRateLimitOverrideRepository{}
+}
+
+func (m *MockRateLimitOverrideRepository) Upsert(ctx context.Context, override *domain.RateLimitOverride) (*domain.RateLimitOverride, error) {
+	args := m.Called(ctx, override)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RateLimitOverride), args.Error(1)
+}
+
+func (m *MockRateLimitOverrideRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.RateLimitOverride, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RateLimitOverride), args.Error(1)
+}
+
+func (m *MockRateLimitOverrideRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.RateLimitOverride, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.RateLimitOverride), args.Error(1)
+}
+
+func (m *MockRateLimitOverrideRepository) ListAll(ctx context.Context) ([]*domain.RateLimitOverride, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.RateLimitOverride), args.Error(1)
+}
+
+func (m *MockRateLimitOverrideRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MockRateLimitControl is a mock implementation of ports.RateLimitControl
+type MockRateLimitControl struct {
+	mock.Mock
+}
+
+func NewMockRateLimitControl() *MockRateLimitControl {
+	return &MockRateLimitControl{}
+}
+
+func (m *MockRateLimitControl) Throttled() []domain.ThrottledClient {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).([]domain.ThrottledClient)
+}
+
+func (m *MockRateLimitControl) Clear(key string) bool {
+	args := m.Called(key)
+	return args.Bool(0)
+}
+
+func (m *MockRateLimitControl) SetOverrides(overrides []*domain.RateLimitOverride) {
+	m.Called(overrides)
+}
+
+// MockCache is a mock implementation of ports.Cache
+type MockCache struct {
+	mock.Mock
+}
+
+func NewMockCache() *MockCache {
+	return &MockCache{}
+}
+
+func (m *MockCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).([]byte), args.Bool(1), args.Error(2)
+}
+
+func (m *MockCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	args := m.Called(ctx, key, value, ttl)
+	return args.Error(0)
+}
+
+func (m *MockCache) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	args := m.Called(ctx, key, value, ttl)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCache) Delete(ctx context.Context, keys ...string) error {
+	args := m.Called(ctx, keys)
+	return args.Error(0)
+}
+
+// MockRateLimitStore is a mock implementation of ports.RateLimitStore
+type MockRateLimitStore struct {
+	mock.Mock
+}
+
+func NewMockRateLimitStore() *MockRateLimitStore {
+	return &MockRateLimitStore{}
+}
+
+func (m *MockRateLimitStore) Allow(ctx context.Context, key string, requestsPerSecond float64, burst int) (ports.RateLimitDecision, error) {
+	args := m.Called(ctx, key, requestsPerSecond, burst)
+	return args.Get(0).(ports.RateLimitDecision), args.Error(1)
+}
+
+func (m *MockRateLimitStore) Reset(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+// MockConfigReloader is a mock implementation of ports.ConfigReloader
+type MockConfigReloader struct {
+	mock.Mock
+}
+
+func NewMockConfigReloader() *MockConfigReloader {
+	return &MockConfigReloader{}
+}
+
+func (m *MockConfigReloader) Current() *domain.RuntimeConfig {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(*domain.RuntimeConfig)
+}
+
+func (m *MockConfigReloader) Reload() (*domain.RuntimeConfig, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RuntimeConfig), args.Error(1)
+}
+
+// MockWebhookSender is a mock implementation of ports.WebhookSender
+type MockWebhookSender struct {
+	mock.Mock
+}
+
+func NewMockWebhookSender() *MockWebhookSender {
+	return &MockWebhookSender{}
+}
+
+func (m *MockWebhookSender) Send(ctx context.Context, job *ports.WebhookDeliveryJob) (int, error) {
+	args := m.Called(ctx, job)
+	return args.Int(0), args.Error(1)
+}
+
+// MockSearchIndex is a mock implementation of ports.SearchIndex
+type MockSearchIndex struct {
+	mock.Mock
+}
+
+func NewMockSearchIndex() *MockSearchIndex {
+	return &MockSearchIndex{}
+}
+
+func (m *MockSearchIndex) Index(ctx context.Context, docs []domain.SearchDocument) error {
+	args := m.Called(ctx, docs)
+	return args.Error(0)
+}
+
+func (m *MockSearchIndex) DeleteTicket(ctx context.Context, orgID uuid.UUID, ticketID int64) error {
+	args := m.Called(ctx, orgID, ticketID)
+	return args.Error(0)
+}
+
+func (m *MockSearchIndex) Search(ctx context.Context, query domain.SearchQuery) ([]*domain.SearchHit, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.SearchHit), args.Error(1)
+}
+
+// MockTicketArchiveRepository is a mock implementation of ports.TicketArchiveRepository
+type MockTicketArchiveRepository struct {
+	mock.Mock
+}
+
+func NewMockTicketArchiveRepository() *MockTicketArchiveRepository {
+	return &MockTicketArchiveRepository{}
+}
+
+func (m *MockTicketArchiveRepository) ArchiveClosedBefore(ctx context.Context, closedBefore time.Time, limit int) (int64, error) {
+	args := m.Called(ctx, closedBefore, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTicketArchiveRepository) GetTicket(ctx context.Context, orgID uuid.UUID, ticketID int64) (*domain.Ticket, error) {
+	args := m.Called(ctx, orgID, ticketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Ticket), args.Error(1)
+}
+
+func (m *MockTicketArchiveRepository) ListComments(ctx context.Context, ticketID int64) ([]*domain.Comment, error) {
+	args := m.Called(ctx, ticketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Comment), args.Error(1)
+}
+
+func (m *MockTicketArchiveRepository) ListEvents(ctx context.Context, ticketID int64) ([]*domain.Event, error) {
+	args := m.Called(ctx, ticketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Event), args.Error(1)
+}
+
+// MockSearchQueueRepository is a mock implementation of ports.SearchQueueRepository
+type MockSearchQueueRepository struct {
+	mock.Mock
+}
+
+func NewMockSearchQueueRepository() *MockSearchQueueRepository {
+	return &MockSearchQueueRepository{}
+}
+
+func (m *MockSearchQueueRepository) Enqueue(ctx context.Context, ticketID int64) error {
+	args := m.Called(ctx, ticketID)
+	return args.Error(0)
+}
+
+func (m *MockSearchQueueRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]*ports.SearchIndexJob, error) {
+	args := m.Called(ctx, limit, lease)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*ports.SearchIndexJob), args.Error(1)
+}
+
+func (m *MockSearchQueueRepository) Complete(ctx context.Context, job *ports.SearchIndexJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+// MockCaptchaVerifier is a mock implementation of ports.CaptchaVerifier
+type MockCaptchaVerifier struct {
+	mock.Mock
+}
+
+func NewMockCaptchaVerifier() *MockCaptchaVerifier {
+	return &MockCaptchaVerifier{}
+}
+
+func (m *MockCaptchaVerifier) Verify(ctx context.Context, token, remoteIP string) (bool, error) {
+	args := m.Called(ctx, token, remoteIP)
+	return args.Bool(0), args.Error(1)
+}
+
+// MockHTMLSanitizer is a mock implementation of ports.HTMLSanitizer
+type MockHTMLSanitizer struct {
+	mock.Mock
+}
+
+func NewMockHTMLSanitizer() *MockHTMLSanitizer {
+	return &MockHTMLSanitizer{}
+}
+
+func (m *MockHTMLSanitizer) Sanitize(html string) string {
+	args := m.Called(html)
+	return args.String(0)
+}
+
+// MockTicketDocumentRenderer is a mock implementation of ports.TicketDocumentRenderer
+type MockTicketDocumentRenderer struct {
+	mock.Mock
+}
+
+func NewMockTicketDocumentRenderer() *MockTicketDocumentRenderer {
+	return &MockTicketDocumentRenderer{}
+}
+
+func (m *MockTicketDocumentRenderer) RenderPDF(doc *domain.TicketDocument, locale string) ([]byte, error) {
+	args := m.Called(doc, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+// MockTicketEventRepository is a mock implementation of ports.TicketEventRepository
+type MockTicketEventRepository struct {
+	mock.Mock
+}
+
+func NewMockTicketEventRepository() *MockTicketEventRepository {
+	return &MockTicketEventRepository{}
+}
+
+func (m *MockTicketEventRepository) Create(ctx context.Context, event *domain.Event) (*domain.Event, error) {
+	args := m.Called(ctx, event)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Event), args.Error(1)
+}
+
+func (m *MockTicketEventRepository) ListByTicketID(ctx context.Context, ticketID int64, afterID int64, limit int) ([]*domain.Event, error) {
+	args := m.Called(ctx, ticketID, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Event), args.Error(1)
+}
+
+func (m *MockTicketEventRepository) ListForReplay(ctx context.Context, filter domain.EventReplayFilter) ([]*domain.Event, error) {
+	args := m.Called(ctx, filter)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -12,23 +12,66 @@ import (
 // UserRepository defines the port for user persistence.
 type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) (*domain.User, error)
-	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+	// GetByEmail returns the user of an organization with an email;
+	// emails are unique within an organization only.
+	GetByEmail(ctx context.Context, orgID uuid.UUID, email string) (*domain.User, error)
+	// ListByEmail returns the users with an email in every organization,
+	// for logins that don't name their organization.
+	ListByEmail(ctx context.Context, email string) ([]*domain.User, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
 	CountUsers(ctx context.Context) (int64, error)
-	ListAssignableUsers(ctx context.Context, orgID uuid.UUID) ([]*domain.User, error)
-	ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.UserSummary, error)
+	// ListAssignableUsers returns the organization's users tickets can be
+	// assigned to that match the Query and TeamID of filter, ordered by
+	// name. The rest of filter is left to the caller.
+	ListAssignableUsers(ctx context.Context, orgID uuid.UUID, filter domain.AssigneeFilter) ([]*domain.User, error)
+	SearchByOrganization(ctx context.Context, orgID uuid.UUID, filter domain.UserFilter) ([]*domain.UserSummary, domain.Total, error)
+	// ListPageByOrganization returns a keyset page of the organization's
+	// users, newest first.
+	ListPageByOrganization(ctx context.Context, orgID uuid.UUID, page domain.PageRequest) (domain.Page[*domain.User], error)
 	SetActive(ctx context.Context, userID uuid.UUID, isActive bool) error
 	UpdatePassword(ctx context.Context, userID uuid.UUID, hashedPassword string) error
 	UpdateLastActive(ctx context.Context, userID uuid.UUID, at time.Time) error
+	// UpdateLastActiveBatch records when several users were last active in
+	// one write. A user's time never moves back, and users that no longer
+	// exist are skipped.
+	UpdateLastActiveBatch(ctx context.Context, lastActive map[uuid.UUID]time.Time) error
+	RevokeTokens(ctx context.Context, userID uuid.UUID, at time.Time) error
+	UpdateSMSPreferences(ctx context.Context, userID uuid.UUID, prefs domain.SMSPreferences) error
+	UpdateLocale(ctx context.Context, userID uuid.UUID, locale string) error
+	UpdateProfile(ctx context.Context, user *domain.User) error
+	UpdateEmail(ctx context.Context, userID uuid.UUID, email string) error
+	GetAvailability(ctx context.Context, userID uuid.UUID) (*domain.Availability, error)
+	SaveAvailability(ctx context.Context, availability *domain.Availability) error
+	Anonymize(ctx context.Context, userID uuid.UUID, at time.Time) error
 }
 
-// TicketRepository defines the port for ticket persistence.
+// TicketRepository defines the port for ticket persistence. Every lookup is
+// scoped to an organization; tickets of other organizations are not found.
 type TicketRepository interface {
 	Create(ctx context.Context, ticket *domain.Ticket) (*domain.Ticket, error)
-	GetByID(ctx context.Context, id int64) (*domain.Ticket, error)
+	// CreateBatch inserts tickets in bulk for imports, setting the ID of
+	// each. Their timestamps are kept and no events are recorded; run it in
+	// a transaction for the batch to be stored all or nothing.
+	CreateBatch(ctx context.Context, tickets []*domain.Ticket) error
+	GetByID(ctx context.Context, orgID uuid.UUID, id int64) (*domain.Ticket, error)
+	// Update persists ticket within ticket.OrganizationID, returning
+	// apperrors.ErrTicketNotFound if it belongs to another organization.
 	Update(ctx context.Context, ticket *domain.Ticket) (*domain.Ticket, error)
 	ListPaginated(ctx context.Context, params ListTicketsRepoParams) ([]*domain.Ticket, error)
 	ListByRequesterPaginated(ctx context.Context, params ListTicketsRepoParams) ([]*domain.Ticket, error)
+	// ListByAssigneePaginated lists the tickets assigned to
+	// params.AssigneeID, newest first. Unassigned and NeedsReassignment in
+	// params are ignored.
+	ListByAssigneePaginated(ctx context.Context, params ListTicketsRepoParams) ([]*domain.Ticket, error)
+	// ListPage returns a keyset page of tickets matching params, newest
+	// first. The Limit and Offset of params are ignored.
+	ListPage(ctx context.Context, params ListTicketsRepoParams, page domain.PageRequest) (domain.Page[*domain.Ticket], error)
+	// ListOpenByAssignee returns the assignee's tickets that are not closed,
+	// locked until the transaction in ctx ends.
+	ListOpenByAssignee(ctx context.Context, orgID, assigneeID uuid.UUID) ([]*domain.Ticket, error)
+	// Claim assigns a ticket to assigneeID only if it has no assignee and is
+	// not closed, returning apperrors.ErrTicketAlreadyClaimed otherwise.
+	Claim(ctx context.Context, orgID uuid.UUID, ticketID int64, assigneeID uuid.UUID, at time.Time) (*domain.Ticket, error)
 }
 
 // AuthorizationRepository defines the port for RBAC data access.
@@ -36,35 +79,324 @@ type AuthorizationRepository interface {
 	GetUserPermissions(ctx context.Context, userID uuid.UUID) ([]string, error)
 	AssignRole(ctx context.Context, userID uuid.UUID, roleName string) error
 	SetUserRole(ctx context.Context, userID uuid.UUID, roleName string) error
+	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error)
 	EnsureRBACDefaults(ctx context.Context) error
 }
 
 // AnalyticsRepository defines the port for analytics data access.
+// Ticket volume is pre-aggregated into daily rollups by RefreshDailyRollups;
+// GetOverview counts days not yet rolled up from the tickets themselves.
 type AnalyticsRepository interface {
-	GetOverview(ctx context.Context, orgID uuid.UUID, days int) (*domain.AnalyticsOverview, error)
+	GetOverview(ctx context.Context, orgID uuid.UUID, rng domain.AnalyticsRange) (*domain.AnalyticsOverview, error)
+	RollupThrough(ctx context.Context) (*time.Time, error)
+	RefreshDailyRollups(ctx context.Context, from, through time.Time) error
+	// GetWorkload counts the open tickets of each assignee, unassigned
+	// tickets included, like the overview's Workload.
+	GetWorkload(ctx context.Context, orgID uuid.UUID) ([]domain.WorkloadItem, error)
+	// ListAgentTicketHistory counts, per assignee, the tickets matching
+	// query. Agents without any are left out.
+	ListAgentTicketHistory(ctx context.Context, orgID uuid.UUID, query domain.SimilarTicketsQuery) ([]domain.AgentTicketHistory, error)
 }
 
 // CommentRepository defines the port for comment persistence.
+// MarkFirstResponse records a reply as the ticket's first response unless
+// one has already been recorded.
 type CommentRepository interface {
 	Create(ctx context.Context, comment *domain.Comment) (*domain.Comment, error)
+	// CreateBatch inserts comments in bulk for imports, like
+	// TicketRepository.CreateBatch.
+	CreateBatch(ctx context.Context, comments []*domain.Comment) error
 	ListByTicketID(ctx context.Context, ticketID int64) ([]*domain.Comment, error)
+	ListLatestByTicketIDs(ctx context.Context, ticketIDs []int64) ([]*domain.Comment, error)
+	// ListPageByTicketID returns a keyset page of the ticket's comments,
+	// oldest first.
+	ListPageByTicketID(ctx context.Context, ticketID int64, page domain.PageRequest) (domain.Page[*domain.Comment], error)
+	MarkFirstResponse(ctx context.Context, comment *domain.Comment) error
 }
 
 // TicketEventRepository defines the port for ticket event persistence.
+// Create honours the transaction in ctx, so an event, and any webhook
+// deliveries queued for it, are only stored if the change behind it commits.
 type TicketEventRepository interface {
 	Create(ctx context.Context, event *domain.Event) (*domain.Event, error)
 	ListByTicketID(ctx context.Context, ticketID int64, afterID int64, limit int) ([]*domain.Event, error)
+	ListForReplay(ctx context.Context, filter domain.EventReplayFilter) ([]*domain.Event, error)
+}
+
+// NotificationOutboxRepository defines the port for the persistent
+// notification outbox. Enqueue honours the transaction in ctx so a
+// notification is only stored if the change that triggered it commits.
+//
+// EnqueueBatched holds a notification back for window so that later ones
+// with the same batch key fold into it: the newest content wins and the
+// notification's Count goes up by one, until it is claimed for delivery.
+type NotificationOutboxRepository interface {
+	Enqueue(ctx context.Context, params NotificationParams) error
+	EnqueueBatched(ctx context.Context, batchKey string, params NotificationParams, window time.Duration) error
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*OutboxNotification, error)
+	MarkSent(ctx context.Context, id int64) error
+	MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, lastErr string) error
+	MarkDead(ctx context.Context, id int64, lastErr string) error
+}
+
+// OutboxNotification is a queued notification claimed for delivery.
+// Count is the number of notifications batched into it, at least 1.
+type OutboxNotification struct {
+	ID       int64
+	Params   NotificationParams
+	Attempts int
+	Count    int
+}
+
+// WebhookRepository defines the port for webhook registrations and their
+// delivery log. EnqueueForTicket honours the transaction in ctx so deliveries
+// are only stored if the change that triggered them commits.
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Webhook, error)
+	ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.Webhook, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	EnqueueForTicket(ctx context.Context, ticketID int64, eventType domain.WebhookEventType, payload []byte) error
+	CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) (*domain.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]*domain.WebhookDelivery, error)
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*WebhookDeliveryJob, error)
+	MarkDelivered(ctx context.Context, id int64, responseStatus int) error
+	MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, responseStatus int, lastErr string) error
+	MarkDead(ctx context.Context, id int64, responseStatus int, lastErr string) error
+}
+
+// TicketArchiveRepository defines the port for the ticket archive, which
+// holds tickets moved out of the ticket, comment and event tables together
+// with their comments and events. ArchiveClosedBefore moves up to limit
+// tickets closed before closedBefore and returns how many it moved.
+// GetTicket returns apperrors.ErrTicketNotFound if the organization has no
+// such archived ticket; the ticket's ArchivedAt is set.
+type TicketArchiveRepository interface {
+	ArchiveClosedBefore(ctx context.Context, closedBefore time.Time, limit int) (int64, error)
+	GetTicket(ctx context.Context, orgID uuid.UUID, ticketID int64) (*domain.Ticket, error)
+	ListComments(ctx context.Context, ticketID int64) ([]*domain.Comment, error)
+	ListEvents(ctx context.Context, ticketID int64) ([]*domain.Event, error)
+}
+
+// SearchQueueRepository defines the port for the queue of tickets waiting to
+// be (re)indexed for search. Enqueue honours the transaction in ctx so a
+// ticket is only queued if the change to it commits. Claim pushes the
+// claimed tickets out by lease so concurrent indexers skip them, and
+// Complete drops a ticket from the queue unless it was queued again since
+// it was claimed.
+type SearchQueueRepository interface {
+	Enqueue(ctx context.Context, ticketID int64) error
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*SearchIndexJob, error)
+	Complete(ctx context.Context, job *SearchIndexJob) error
+}
+
+// InboundHookRepository defines the port for inbound hook registrations.
+type InboundHookRepository interface {
+	Create(ctx context.Context, hook *domain.InboundHook) (*domain.InboundHook, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.InboundHook, error)
+	GetBySource(ctx context.Context, source string) (*domain.InboundHook, error)
+	ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.InboundHook, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// TeamRepository defines the port for teams and their members. Create and
+// Update return apperrors.ErrTeamExists if the organization has another
+// team of the same name.
+type TeamRepository interface {
+	Create(ctx context.Context, team *domain.Team) (*domain.Team, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Team, error)
+	ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.Team, error)
+	ListByMember(ctx context.Context, userID uuid.UUID) ([]*domain.Team, error)
+	Update(ctx context.Context, team *domain.Team) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	AddMember(ctx context.Context, teamID, userID uuid.UUID) error
+	RemoveMember(ctx context.Context, teamID, userID uuid.UUID) error
+}
+
+// EscalationRuleRepository defines the port for escalation rules.
+type EscalationRuleRepository interface {
+	Create(ctx context.Context, rule *domain.EscalationRule) (*domain.EscalationRule, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.EscalationRule, error)
+	ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.EscalationRule, error)
+	Update(ctx context.Context, rule *domain.EscalationRule) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// MacroRepository defines the port for macros. Create and Update return
+// apperrors.ErrMacroExists if the organization has another macro of the
+// same name.
+type MacroRepository interface {
+	Create(ctx context.Context, macro *domain.Macro) (*domain.Macro, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Macro, error)
+	ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.Macro, error)
+	Update(ctx context.Context, macro *domain.Macro) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// OrgSettingsRepository defines the port for per-organization settings.
+// GetBusinessHours returns apperrors.ErrNotFound if none are configured.
+// SaveBusinessHours replaces the working week and the holiday calendar and
+// should be called in a transaction, as should SaveRegistrationDomains.
+// SavePortalSlug returns apperrors.ErrPortalSlugTaken if another
+// organization uses the slug; GetOrganizationIDByPortalSlug returns
+// apperrors.ErrPortalNotFound if none does. CreateOrganization adds an
+// organization whose portal is served under slug, returning
+// apperrors.ErrPortalSlugTaken if the slug is in use.
+type OrgSettingsRepository interface {
+	GetBusinessHours(ctx context.Context, orgID uuid.UUID) (*domain.BusinessHours, error)
+	SaveBusinessHours(ctx context.Context, hours *domain.BusinessHours) (*domain.BusinessHours, error)
+	GetRegistrationDomains(ctx context.Context, orgID uuid.UUID) ([]string, error)
+	SaveRegistrationDomains(ctx context.Context, orgID uuid.UUID, domains []string) error
+	GetPortalSlug(ctx context.Context, orgID uuid.UUID) (string, error)
+	SavePortalSlug(ctx context.Context, orgID uuid.UUID, slug string) error
+	GetOrganizationIDByPortalSlug(ctx context.Context, slug string) (uuid.UUID, error)
+	CreateOrganization(ctx context.Context, name, slug string) (uuid.UUID, error)
+	GetOrganization(ctx context.Context, orgID uuid.UUID) (*domain.Organization, error)
+	GetAgentCapacity(ctx context.Context, orgID uuid.UUID) (*domain.AgentCapacity, error)
+	SaveAgentCapacity(ctx context.Context, capacity *domain.AgentCapacity) error
+}
+
+// RetentionRepository defines the port for data retention policies and the
+// tickets they purge. GetPolicy returns apperrors.ErrNotFound if none is
+// configured. ListPurgeCandidates returns a page of the organization's
+// soft-deleted tickets and closed tickets closed before closedBefore, the
+// soonest to be purged first, and the total number of them.
+type RetentionRepository interface {
+	GetPolicy(ctx context.Context, orgID uuid.UUID) (*domain.RetentionPolicy, error)
+	SavePolicy(ctx context.Context, policy *domain.RetentionPolicy) (*domain.RetentionPolicy, error)
+	ListEnabledPolicies(ctx context.Context) ([]*domain.RetentionPolicy, error)
+	ListPurgeCandidates(ctx context.Context, orgID uuid.UUID, closedBefore time.Time, limit, offset int) ([]*domain.PurgeCandidate, domain.Total, error)
+	CountExpired(ctx context.Context, orgID uuid.UUID, closedBefore, deletedBefore time.Time) (softDelete, hardDelete int64, err error)
+	SoftDeleteExpired(ctx context.Context, orgID uuid.UUID, closedBefore, now time.Time) (int64, error)
+	HardDeleteExpired(ctx context.Context, orgID uuid.UUID, deletedBefore time.Time) (int64, error)
+}
+
+// QuotaRepository defines the port for per-organization quotas. GetQuota
+// returns apperrors.ErrNotFound if the organization has no quota of its own.
+type QuotaRepository interface {
+	GetQuota(ctx context.Context, orgID uuid.UUID) (*domain.OrgQuota, error)
+	GetUsage(ctx context.Context, orgID uuid.UUID) (*domain.OrgUsage, error)
+}
+
+// PlatformRepository defines the port for reading across organizations.
+// ListOrganizations returns a page of organizations, oldest first, with the
+// total number matching the filter. GetHealth counts recent activity from
+// since on.
+type PlatformRepository interface {
+	ListOrganizations(ctx context.Context, filter domain.OrganizationFilter) ([]*domain.OrganizationSummary, domain.Total, error)
+	GetHealth(ctx context.Context, orgID uuid.UUID, since time.Time) (*domain.OrganizationHealth, error)
+}
+
+// AuditLogRepository defines the port for the admin audit log. Create
+// honours the transaction in ctx so an entry is only kept if the change it
+// records commits. List returns a page of entries, newest first, and the
+// total number matching the filter; ListPage returns a keyset page of them
+// instead, ignoring the filter's Limit and Offset.
+type AuditLogRepository interface {
+	Create(ctx context.Context, entry *domain.AuditEntry) error
+	List(ctx context.Context, orgID uuid.UUID, filter domain.AuditLogFilter) ([]*domain.AuditEntry, domain.Total, error)
+	ListPage(ctx context.Context, orgID uuid.UUID, filter domain.AuditLogFilter, page domain.PageRequest) (domain.Page[*domain.AuditEntry], error)
+}
+
+// DataExportRepository defines the port for personal data exports.
+// ClaimPending locks the oldest pending export for the transaction in ctx,
+// so concurrent workers never build the same export, and returns nil if
+// there is none. CollectUserData gathers everything stored about a user.
+type DataExportRepository interface {
+	Create(ctx context.Context, export *domain.DataExport) error
+	GetLatest(ctx context.Context, userID uuid.UUID) (*domain.DataExport, error)
+	ClaimPending(ctx context.Context) (*domain.DataExport, error)
+	MarkReady(ctx context.Context, id uuid.UUID, payload []byte, completedAt, expiresAt time.Time) error
+	MarkFailed(ctx context.Context, id uuid.UUID, completedAt time.Time, lastErr string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+	CollectUserData(ctx context.Context, userID uuid.UUID) (*domain.UserData, error)
+}
+
+// RateLimitOverrideRepository defines the port for rate limit overrides.
+// Upsert replaces any override with the same organization and user.
+type RateLimitOverrideRepository interface {
+	Upsert(ctx context.Context, override *domain.RateLimitOverride) (*domain.RateLimitOverride, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.RateLimitOverride, error)
+	ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.RateLimitOverride, error)
+	ListAll(ctx context.Context) ([]*domain.RateLimitOverride, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// TicketSnoozeRepository defines the port for ticket snoozes. Upsert
+// replaces the ticket's existing snooze. TakeDue removes up to limit
+// snoozes that ended by now and returns them; within a transaction they
+// stay locked against other callers until it ends.
+type TicketSnoozeRepository interface {
+	Upsert(ctx context.Context, snooze *domain.TicketSnooze) (*domain.TicketSnooze, error)
+	GetByTicketID(ctx context.Context, ticketID int64) (*domain.TicketSnooze, error)
+	Delete(ctx context.Context, ticketID int64) error
+	TakeDue(ctx context.Context, now time.Time, limit int) ([]*domain.TicketSnooze, error)
+}
+
+// CommentDraftRepository defines the port for users' unposted replies.
+// Upsert replaces the user's existing draft on the ticket. Delete succeeds
+// when there is no draft.
+type CommentDraftRepository interface {
+	Upsert(ctx context.Context, draft *domain.CommentDraft) (*domain.CommentDraft, error)
+	Get(ctx context.Context, ticketID int64, userID uuid.UUID) (*domain.CommentDraft, error)
+	Delete(ctx context.Context, ticketID int64, userID uuid.UUID) error
+}
+
+// EmailPreferenceRepository defines the port for the ticket emails users
+// have opted out of. Get returns empty preferences for users who haven't
+// opted out of anything; MuteTicket and UnmuteTicket succeed when the
+// ticket is already muted or unmuted.
+type EmailPreferenceRepository interface {
+	Get(ctx context.Context, userID uuid.UUID) (*domain.EmailPreferences, error)
+	SetUnsubscribed(ctx context.Context, userID uuid.UUID, unsubscribed bool) error
+	MuteTicket(ctx context.Context, userID uuid.UUID, ticketID int64) error
+	UnmuteTicket(ctx context.Context, userID uuid.UUID, ticketID int64) error
+}
+
+// WebhookDeliveryJob is a webhook delivery claimed for sending, together with
+// the endpoint it goes to.
+type WebhookDeliveryJob struct {
+	DeliveryID int64
+	WebhookID  uuid.UUID
+	URL        string
+	Secret     string
+	EventType  domain.WebhookEventType
+	Payload    []byte
+	Attempts   int
+}
+
+// SearchIndexJob is a ticket claimed for (re)indexing.
+type SearchIndexJob struct {
+	TicketID       int64
+	OrganizationID uuid.UUID
+	EnqueuedAt     time.Time
 }
 
 // ListTicketsRepoParams defines parameters for paginated ticket queries.
 type ListTicketsRepoParams struct {
-	Limit       int32
-	Offset      int32
-	Status      pgtype.Text
-	Priority    pgtype.Text
-	RequesterID pgtype.UUID
-	AssigneeID  pgtype.UUID
-	Unassigned  pgtype.Bool
-	CreatedFrom pgtype.Timestamptz
-	CreatedTo   pgtype.Timestamptz
+	// OrganizationID is required; only its tickets are listed
+	OrganizationID pgtype.UUID
+	Limit          int32
+	Offset         int32
+	Status         pgtype.Text
+	Priority       pgtype.Text
+	RequesterID    pgtype.UUID
+	AssigneeID     pgtype.UUID
+	Unassigned     pgtype.Bool
+	CreatedFrom    pgtype.Timestamptz
+	CreatedTo      pgtype.Timestamptz
+	// NeedsReassignment keeps open tickets whose assignee is out of office
+	// and asked for their tickets to be reassigned
+	NeedsReassignment pgtype.Bool
+	TeamID            pgtype.UUID
+	// IncludeDeleted lists soft-deleted tickets too, which are otherwise
+	// left out; for admin and restore paths only
+	IncludeDeleted bool
+	// HideSnoozed leaves out tickets snoozed until later; ignored when
+	// listing by requester
+	HideSnoozed bool
+	// HideInternal leaves out internal tickets, which requesters without
+	// permission to read all tickets must not see
+	HideInternal bool
 }
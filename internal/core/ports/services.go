@@ -11,7 +11,11 @@ import (
 // AuthService defines the port for authentication business logic.
 type AuthService interface {
 	Register(ctx context.Context, fullName, email, password, role string, orgID uuid.UUID) (*domain.User, error)
-	Login(ctx context.Context, email, password string) (*domain.User, error)
+	// Login authenticates a user of the organization orgID. With uuid.Nil
+	// every organization is searched, and a *domain.OrganizationChoiceError
+	// is returned when the credentials match accounts in several.
+	Login(ctx context.Context, orgID uuid.UUID, email, password string) (*domain.User, error)
+	ValidateSession(ctx context.Context, userID uuid.UUID, issuedAt time.Time) (*domain.User, error)
 }
 
 // AuthorizationService defines the port for checking user permissions.
@@ -22,16 +26,37 @@ type AuthorizationService interface {
 
 // AssigneeService defines the port for listing assignable users.
 type AssigneeService interface {
-	ListAssignableUsers(ctx context.Context, actorID uuid.UUID, orgID uuid.UUID) ([]*domain.User, error)
+	// ListAssignableUsers returns a page of the users tickets can be
+	// assigned to that match filter, with their open tickets and the
+	// organization's open ticket limit, and how many match in total.
+	ListAssignableUsers(ctx context.Context, actorID uuid.UUID, orgID uuid.UUID, filter domain.AssigneeFilter) ([]domain.Assignee, domain.Total, error)
+	// SuggestAssignees ranks the assignable users for a ticket by their
+	// experience with similar tickets and their current workload.
+	SuggestAssignees(ctx context.Context, actorID, orgID uuid.UUID, ticketID int64) ([]domain.AssignmentSuggestion, error)
 }
 
 // AdminService defines the port for admin-only operations.
 type AdminService interface {
-	ListUsers(ctx context.Context, actorID, orgID uuid.UUID) ([]*domain.UserSummary, error)
+	ListUsers(ctx context.Context, actorID, orgID uuid.UUID, filter domain.UserFilter) ([]*domain.UserSummary, domain.Total, error)
 	UpdateUserRole(ctx context.Context, actorID, orgID, userID uuid.UUID, role string) error
 	UpdateUserStatus(ctx context.Context, actorID, orgID, userID uuid.UUID, isActive bool) error
+	OffboardUser(ctx context.Context, actorID, orgID, userID uuid.UUID, reassignTo *uuid.UUID) (*domain.OffboardResult, error)
 	ResetUserPassword(ctx context.Context, actorID, orgID, userID uuid.UUID) (string, error)
-	GetAnalyticsOverview(ctx context.Context, actorID, orgID uuid.UUID, days int) (*domain.AnalyticsOverview, error)
+	ForceLogout(ctx context.Context, actorID, orgID, userID uuid.UUID) error
+	DeleteUser(ctx context.Context, actorID, orgID, userID uuid.UUID) error
+	ImportUsers(ctx context.Context, actorID, orgID uuid.UUID, rows []domain.UserImportRow) (*domain.UserImportReport, error)
+	GetAnalyticsOverview(ctx context.Context, actorID, orgID uuid.UUID, rng domain.AnalyticsRange) (*domain.AnalyticsOverview, error)
+	ListAuditLog(ctx context.Context, actorID, orgID uuid.UUID, filter domain.AuditLogFilter) ([]*domain.AuditEntry, domain.Total, error)
+}
+
+// PlatformService defines the port for super-admins working across
+// organizations. Every call is recorded in the audit log. Impersonate checks
+// that userID may be impersonated and returns them; the caller issues the
+// token.
+type PlatformService interface {
+	ListOrganizations(ctx context.Context, actorID, actorOrgID uuid.UUID, filter domain.OrganizationFilter) ([]*domain.OrganizationSummary, domain.Total, error)
+	GetOrganization(ctx context.Context, actorID, orgID uuid.UUID) (*domain.OrganizationOverview, error)
+	Impersonate(ctx context.Context, actorID, orgID, userID uuid.UUID) (*domain.User, error)
 }
 
 // UserLookupService provides lightweight user details for display purposes.
@@ -39,12 +64,234 @@ type UserLookupService interface {
 	GetUserInfo(ctx context.Context, orgID uuid.UUID, userIDs []uuid.UUID) (map[uuid.UUID]domain.UserInfo, error)
 }
 
+// ProfileService defines the port for a user managing their own profile.
+type ProfileService interface {
+	GetCurrentUser(ctx context.Context, userID uuid.UUID) (*domain.CurrentUser, error)
+	GetSMSPreferences(ctx context.Context, userID uuid.UUID) (*domain.SMSPreferences, error)
+	UpdateSMSPreferences(ctx context.Context, userID uuid.UUID, prefs domain.SMSPreferences) (*domain.SMSPreferences, error)
+	GetLocale(ctx context.Context, userID uuid.UUID) (string, error)
+	UpdateLocale(ctx context.Context, userID uuid.UUID, locale string) (string, error)
+	UpdateProfile(ctx context.Context, userID uuid.UUID, changes domain.ProfileChanges) (*domain.User, error)
+	RequestEmailChange(ctx context.Context, userID uuid.UUID, newEmail, password string) error
+	ConfirmEmailChange(ctx context.Context, token string) (*domain.User, error)
+	GetAvailability(ctx context.Context, userID uuid.UUID) (*domain.Availability, error)
+	UpdateAvailability(ctx context.Context, userID uuid.UUID, availability domain.Availability) (*domain.Availability, error)
+	DeleteAccount(ctx context.Context, userID uuid.UUID, password string) error
+}
+
+// WebhookService defines the port for managing an organization's webhooks.
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, actorID, orgID uuid.UUID, params domain.WebhookParams) (*domain.Webhook, error)
+	ListWebhooks(ctx context.Context, actorID, orgID uuid.UUID) ([]*domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, actorID, orgID, webhookID uuid.UUID) error
+	ListDeliveries(ctx context.Context, actorID, orgID, webhookID uuid.UUID, limit int) ([]*domain.WebhookDelivery, error)
+	TestWebhook(ctx context.Context, actorID, orgID, webhookID uuid.UUID) (*domain.WebhookDelivery, error)
+}
+
+// EventReplayService defines the port for sending an organization's ticket
+// events to its webhooks again.
+type EventReplayService interface {
+	ReplayEvents(ctx context.Context, actorID, orgID uuid.UUID, filter domain.EventReplayFilter) (*domain.EventReplayResult, error)
+}
+
+// InboundHookService defines the port for managing an organization's inbound
+// hooks and for receiving the payloads posted to them. Receive returns nil
+// for payloads the hook ignores, such as resolved alerts.
+type InboundHookService interface {
+	CreateInboundHook(ctx context.Context, actorID, orgID uuid.UUID, params domain.InboundHookParams) (*domain.InboundHook, error)
+	ListInboundHooks(ctx context.Context, actorID, orgID uuid.UUID) ([]*domain.InboundHook, error)
+	DeleteInboundHook(ctx context.Context, actorID, orgID, hookID uuid.UUID) error
+	Receive(ctx context.Context, source, signature string, payload []byte) (*domain.Ticket, error)
+}
+
+// TeamService defines the port for managing an organization's teams of
+// agents. ListMyTeams returns the teams a user belongs to.
+type TeamService interface {
+	CreateTeam(ctx context.Context, actorID, orgID uuid.UUID, name string) (*domain.Team, error)
+	ListTeams(ctx context.Context, actorID, orgID uuid.UUID) ([]*domain.Team, error)
+	RenameTeam(ctx context.Context, actorID, orgID, teamID uuid.UUID, name string) (*domain.Team, error)
+	DeleteTeam(ctx context.Context, actorID, orgID, teamID uuid.UUID) error
+	AddMember(ctx context.Context, actorID, orgID, teamID, userID uuid.UUID) (*domain.Team, error)
+	RemoveMember(ctx context.Context, actorID, orgID, teamID, userID uuid.UUID) (*domain.Team, error)
+	ListMyTeams(ctx context.Context, userID uuid.UUID) ([]*domain.Team, error)
+}
+
+// EscalationService defines the port for managing an organization's
+// escalation rules. DryRun evaluates the draft rule, or else every active
+// rule, against a sample ticket without changing anything.
+type EscalationService interface {
+	CreateRule(ctx context.Context, actorID, orgID uuid.UUID, params domain.EscalationRuleParams) (*domain.EscalationRule, error)
+	ListRules(ctx context.Context, actorID, orgID uuid.UUID) ([]*domain.EscalationRule, error)
+	UpdateRule(ctx context.Context, actorID, orgID, ruleID uuid.UUID, params domain.EscalationRuleParams) (*domain.EscalationRule, error)
+	DeleteRule(ctx context.Context, actorID, orgID, ruleID uuid.UUID) error
+	DryRun(ctx context.Context, actorID, orgID uuid.UUID, params EscalationDryRunParams) ([]domain.EscalationEvaluation, error)
+}
+
+// EscalationDryRunParams defines the input for evaluating escalation rules
+// against a sample ticket at a point in time. A nil Rule evaluates the
+// organization's active rules; a zero At means now. If the ticket has no
+// due date, one is computed from the organization's business hours.
+type EscalationDryRunParams struct {
+	Rule   *domain.EscalationRuleParams
+	Ticket domain.Ticket
+	At     time.Time
+}
+
+// MacroService defines the port for macros. Admins manage an
+// organization's macros; agents list them and apply them to tickets.
+type MacroService interface {
+	CreateMacro(ctx context.Context, actorID, orgID uuid.UUID, params domain.MacroParams) (*domain.Macro, error)
+	ListMacros(ctx context.Context, actorID, orgID uuid.UUID) ([]*domain.Macro, error)
+	UpdateMacro(ctx context.Context, actorID, orgID, macroID uuid.UUID, params domain.MacroParams) (*domain.Macro, error)
+	DeleteMacro(ctx context.Context, actorID, orgID, macroID uuid.UUID) error
+	ApplyMacro(ctx context.Context, params ApplyMacroParams) (*domain.Ticket, error)
+}
+
+// ApplyMacroParams defines the input for applying a macro to a ticket.
+type ApplyMacroParams struct {
+	TicketID int64
+	MacroID  uuid.UUID
+	ActorID  uuid.UUID
+	OrgID    uuid.UUID
+}
+
+// SnoozeService defines the port for snoozing tickets. A snoozed ticket is
+// left out of agents' queues until the snooze ends.
+type SnoozeService interface {
+	SnoozeTicket(ctx context.Context, params SnoozeTicketParams) (*domain.TicketSnooze, error)
+	GetSnooze(ctx context.Context, ticketID int64, actorID uuid.UUID) (*domain.TicketSnooze, error)
+	UnsnoozeTicket(ctx context.Context, ticketID int64, actorID uuid.UUID) error
+}
+
+// SnoozeTicketParams defines the input for snoozing a ticket.
+type SnoozeTicketParams struct {
+	TicketID int64
+	ActorID  uuid.UUID
+	Until    time.Time
+	Note     string
+}
+
+// TicketExportService defines the port for exporting a ticket with its
+// metadata and comment thread as a document, for records kept outside the
+// service desk. Anyone who can read a ticket can export it.
+type TicketExportService interface {
+	ExportTicketPDF(ctx context.Context, ticketID int64, viewerID uuid.UUID, locale string) ([]byte, error)
+}
+
+// CommentDraftService defines the port for autosaving a user's reply to a
+// ticket before it is posted. Drafts are private to their author.
+type CommentDraftService interface {
+	SaveDraft(ctx context.Context, params SaveCommentDraftParams) (*domain.CommentDraft, error)
+	GetDraft(ctx context.Context, ticketID int64, actorID uuid.UUID) (*domain.CommentDraft, error)
+	DiscardDraft(ctx context.Context, ticketID int64, actorID uuid.UUID) error
+}
+
+// SaveCommentDraftParams defines the input for saving a comment draft.
+type SaveCommentDraftParams struct {
+	TicketID int64
+	ActorID  uuid.UUID
+	Body     string
+}
+
+// EmailPreferenceService defines the port for the ticket emails users opt
+// out of, from their profile or through the signed links at the foot of
+// ticket emails, which work without signing in.
+type EmailPreferenceService interface {
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*domain.EmailPreferences, error)
+	SetUnsubscribed(ctx context.Context, userID uuid.UUID, unsubscribed bool) (*domain.EmailPreferences, error)
+	MuteTicket(ctx context.Context, userID uuid.UUID, ticketID int64) error
+	UnmuteTicket(ctx context.Context, userID uuid.UUID, ticketID int64) error
+	MuteTicketWithToken(ctx context.Context, token string) (*domain.EmailOptOut, error)
+	UnsubscribeWithToken(ctx context.Context, token string) (*domain.EmailOptOut, error)
+}
+
+// OrgSettingsService defines the port for managing an organization's settings.
+type OrgSettingsService interface {
+	GetBusinessHours(ctx context.Context, actorID, orgID uuid.UUID) (*domain.BusinessHours, error)
+	UpdateBusinessHours(ctx context.Context, actorID, orgID uuid.UUID, hours domain.BusinessHours) (*domain.BusinessHours, error)
+	GetRetentionPolicy(ctx context.Context, actorID, orgID uuid.UUID) (*domain.RetentionPolicy, error)
+	UpdateRetentionPolicy(ctx context.Context, actorID, orgID uuid.UUID, policy domain.RetentionPolicy) (*domain.RetentionPolicy, error)
+	ListUpcomingPurges(ctx context.Context, actorID, orgID uuid.UUID, withinDays, limit, offset int) ([]*domain.PurgeCandidate, domain.Total, error)
+	GetRegistrationDomains(ctx context.Context, actorID, orgID uuid.UUID) ([]string, error)
+	UpdateRegistrationDomains(ctx context.Context, actorID, orgID uuid.UUID, domains []string) ([]string, error)
+	GetPortalSlug(ctx context.Context, actorID, orgID uuid.UUID) (string, error)
+	UpdatePortalSlug(ctx context.Context, actorID, orgID uuid.UUID, slug string) (string, error)
+	GetAgentCapacity(ctx context.Context, actorID, orgID uuid.UUID) (*domain.AgentCapacity, error)
+	UpdateAgentCapacity(ctx context.Context, actorID, orgID uuid.UUID, capacity domain.AgentCapacity) (*domain.AgentCapacity, error)
+}
+
+// PortalService defines the port for an organization's public portal, where
+// anyone can submit a ticket without an account. The submitter gets an
+// emailed link whose token lets them follow the ticket and reply to it.
+type PortalService interface {
+	SubmitTicket(ctx context.Context, params SubmitPortalTicketParams) (*domain.Ticket, error)
+	GetTicket(ctx context.Context, ticketID int64, token string) (*domain.Ticket, []*domain.Comment, error)
+	AddComment(ctx context.Context, ticketID int64, token, body string) (*domain.Comment, error)
+}
+
+// SubmitPortalTicketParams defines the input for a public portal submission.
+// CaptchaToken is the response of the captcha the submitter solved.
+type SubmitPortalTicketParams struct {
+	Slug         string
+	Submission   domain.PortalSubmission
+	CaptchaToken string
+	RemoteIP     string
+}
+
+// CaptchaVerifier defines the port for checking a captcha response with the
+// captcha provider. It returns false for responses the provider rejects and
+// an error only if it could not ask.
+type CaptchaVerifier interface {
+	Verify(ctx context.Context, token, remoteIP string) (bool, error)
+}
+
+// DataExportService defines the port for personal data exports. Users export
+// their own data; admins can export the data of anyone in their organization.
+type DataExportService interface {
+	RequestOwnExport(ctx context.Context, userID uuid.UUID) (*domain.DataExport, error)
+	GetOwnExport(ctx context.Context, userID uuid.UUID) (*domain.DataExport, error)
+	RequestUserExport(ctx context.Context, actorID, orgID, userID uuid.UUID) (*domain.DataExport, error)
+	GetUserExport(ctx context.Context, actorID, orgID, userID uuid.UUID) (*domain.DataExport, error)
+}
+
+// RateLimitService defines the port for inspecting the API rate limiters and
+// managing the overrides that exempt trusted clients from them.
+type RateLimitService interface {
+	ListThrottled(ctx context.Context, actorID uuid.UUID) ([]domain.ThrottledClient, error)
+	ClearThrottled(ctx context.Context, actorID, orgID uuid.UUID, key string) error
+	ListOverrides(ctx context.Context, actorID, orgID uuid.UUID) ([]*domain.RateLimitOverride, error)
+	SetOverride(ctx context.Context, actorID, orgID uuid.UUID, params domain.RateLimitOverrideParams) (*domain.RateLimitOverride, error)
+	DeleteOverride(ctx context.Context, actorID, orgID, overrideID uuid.UUID) error
+	LoadOverrides(ctx context.Context) error
+}
+
+// ConfigService defines the port for inspecting and reloading the runtime
+// configuration.
+type ConfigService interface {
+	GetRuntimeConfig(ctx context.Context, actorID uuid.UUID) (*domain.RuntimeConfig, error)
+	ReloadConfig(ctx context.Context, actorID, orgID uuid.UUID) (*domain.RuntimeConfig, error)
+}
+
+// QuotaService defines the port for per-organization usage quotas. The
+// Check methods are called wherever users or tickets are created and return
+// apperrors.ErrUserQuotaExceeded or apperrors.ErrOpenTicketQuotaExceeded.
+// Report is GetReport for callers that have checked permissions themselves.
+type QuotaService interface {
+	GetReport(ctx context.Context, actorID, orgID uuid.UUID) (*domain.QuotaReport, error)
+	Report(ctx context.Context, orgID uuid.UUID) (*domain.QuotaReport, error)
+	CheckUsers(ctx context.Context, orgID uuid.UUID, adding int) error
+	CheckOpenTicket(ctx context.Context, orgID uuid.UUID) error
+}
+
 // CreateTicketParams defines the required input for creating a new ticket.
 type CreateTicketParams struct {
 	Title       string
 	Description string
 	Priority    domain.TicketPriority
 	RequesterID uuid.UUID
+	// Visibility defaults to public; only those who can read all tickets
+	// may create internal ones
+	Visibility domain.TicketVisibility
 }
 
 // UpdateStatusParams defines the input for changing a ticket's status.
@@ -61,6 +308,27 @@ type AssignTicketParams struct {
 	ActorID    uuid.UUID
 }
 
+// AssignTeamParams defines the input for putting a ticket in a team's queue.
+type AssignTeamParams struct {
+	TicketID int64
+	TeamID   uuid.UUID
+	ActorID  uuid.UUID
+}
+
+// ClaimTicketParams defines the input for an agent claiming a ticket from
+// one of their team queues.
+type ClaimTicketParams struct {
+	TicketID int64
+	ActorID  uuid.UUID
+}
+
+// UpdateTicketParams defines the input for a partial ticket update.
+type UpdateTicketParams struct {
+	TicketID int64
+	Changes  domain.TicketChanges
+	ActorID  uuid.UUID
+}
+
 // CreateCommentParams defines the input for creating a comment.
 type CreateCommentParams struct {
 	TicketID int64
@@ -74,6 +342,13 @@ type GetCommentsParams struct {
 	ActorID  uuid.UUID
 }
 
+// GetLatestCommentsParams defines the input for retrieving the latest
+// comment on each of a set of tickets the actor has already fetched.
+type GetLatestCommentsParams struct {
+	Tickets []*domain.Ticket
+	ActorID uuid.UUID
+}
+
 // ListTicketsParams defines the input for listing tickets.
 type ListTicketsParams struct {
 	ViewerID    uuid.UUID
@@ -85,6 +360,13 @@ type ListTicketsParams struct {
 	Unassigned  bool
 	CreatedFrom *time.Time
 	CreatedTo   *time.Time
+	// NeedsReassignment keeps open tickets flagged for reassignment because
+	// their assignee is out of office
+	NeedsReassignment bool
+	TeamID            *uuid.UUID
+	// IncludeSnoozed lists tickets snoozed until later too, which are
+	// otherwise left out of agents' lists
+	IncludeSnoozed bool
 }
 
 // ListTicketEventsParams defines the input for listing ticket events.
@@ -95,12 +377,45 @@ type ListTicketEventsParams struct {
 	Limit    int
 }
 
+// SearchParams defines the input for a ticket and comment search.
+type SearchParams struct {
+	ViewerID uuid.UUID
+	Text     string
+	Limit    int
+}
+
+// NotificationType identifies the kind of notification, and so the template
+// used to render it.
+type NotificationType string
+
+const (
+	NotificationTicketCreated    NotificationType = "ticket_created"
+	NotificationStatusChanged    NotificationType = "status_changed"
+	NotificationCommentAdded     NotificationType = "comment_added"
+	NotificationTicketAssigned   NotificationType = "ticket_assigned"
+	NotificationDataExportReady  NotificationType = "data_export_ready"
+	NotificationPortalLink       NotificationType = "portal_link"
+	NotificationEmailChange      NotificationType = "email_change"
+	NotificationEmailChanged     NotificationType = "email_changed"
+	NotificationSnoozeEnded      NotificationType = "snooze_ended"
+	NotificationRequesterReplied NotificationType = "requester_replied"
+)
+
+// NotificationDataRecipientEmail is the Data key that sends an email
+// notification to an address other than the recipient's login email, such
+// as an address the user has yet to confirm.
+const NotificationDataRecipientEmail = "recipient_email"
+
 // NotificationParams defines the input for sending a notification.
+// Subject and Message are always set so notifiers without templates can fall
+// back to them; Data carries the extra fields a template may render.
 type NotificationParams struct {
 	RecipientUserID uuid.UUID
+	Type            NotificationType
 	Subject         string
 	Message         string
 	TicketID        int64
+	Data            map[string]string
 }
 
 // TicketService defines the core business operations for managing tickets.
@@ -108,15 +423,26 @@ type TicketService interface {
 	CreateTicket(ctx context.Context, params CreateTicketParams) (*domain.Ticket, error)
 	GetTicket(ctx context.Context, ticketID int64, viewerID uuid.UUID) (*domain.Ticket, error)
 	UpdateStatus(ctx context.Context, params UpdateStatusParams) (*domain.Ticket, error)
-	AssignTicket(ctx context.Context, params AssignTicketParams) (*domain.Ticket, error)
+	// AssignTicket returns a warning along with the ticket when the assignee
+	// was already at the organization's open ticket limit and the limit
+	// only warns; when it blocks, apperrors.ErrAgentAtCapacity is returned.
+	AssignTicket(ctx context.Context, params AssignTicketParams) (*domain.Ticket, *domain.CapacityWarning, error)
+	AssignTeam(ctx context.Context, params AssignTeamParams) (*domain.Ticket, error)
+	ClaimTicket(ctx context.Context, params ClaimTicketParams) (*domain.Ticket, error)
+	UpdateTicket(ctx context.Context, params UpdateTicketParams) (*domain.Ticket, error)
 	ListTickets(ctx context.Context, params ListTicketsParams) ([]*domain.Ticket, error)
-	Shutdown()
 }
 
 // CommentService defines the port for comment-related business logic.
 type CommentService interface {
 	CreateComment(ctx context.Context, params CreateCommentParams) (*domain.Comment, error)
 	GetCommentsForTicket(ctx context.Context, params GetCommentsParams) ([]*domain.Comment, error)
+	GetLatestComments(ctx context.Context, params GetLatestCommentsParams) (map[int64]*domain.Comment, error)
+}
+
+// SearchService defines the port for searching tickets and comments.
+type SearchService interface {
+	Search(ctx context.Context, params SearchParams) ([]*domain.SearchHit, error)
 }
 
 // EventService defines the port for ticket event queries.
@@ -124,9 +450,105 @@ type EventService interface {
 	ListTicketEvents(ctx context.Context, params ListTicketEventsParams) ([]*domain.Event, error)
 }
 
-// Notifier defines the port for sending asynchronous notifications.
+// Notifier defines the port for delivering a notification to its recipient.
+// A returned error means delivery failed and may be retried.
 type Notifier interface {
-	Notify(ctx context.Context, params NotificationParams)
+	Notify(ctx context.Context, params NotificationParams) error
+}
+
+// HTMLSanitizer defines the port for making user-submitted rich text safe
+// to render in a browser. Sanitize keeps formatting, links and images but
+// removes scripts, event handlers and unsafe URLs; plain text comes back
+// escaped.
+type HTMLSanitizer interface {
+	Sanitize(html string) string
+}
+
+// TicketDocumentRenderer defines the port for laying out an exported ticket
+// as a printable document. Unsupported locales render in the default one.
+type TicketDocumentRenderer interface {
+	RenderPDF(doc *domain.TicketDocument, locale string) ([]byte, error)
+}
+
+// CapturedEmail is a rendered email kept for previewing instead of being
+// sent, in development.
+type CapturedEmail struct {
+	ID         int64
+	Type       NotificationType
+	TicketID   int64
+	ToName     string
+	ToEmail    string
+	Subject    string
+	HTMLBody   string
+	TextBody   string
+	CapturedAt time.Time
+}
+
+// EmailCapture defines the port for reading captured emails, newest first.
+type EmailCapture interface {
+	List(ctx context.Context, limit int) ([]CapturedEmail, error)
+	Get(ctx context.Context, id int64) (*CapturedEmail, error)
+}
+
+// WebhookSender defines the port for POSTing a signed payload to a webhook
+// endpoint. It returns the HTTP status received, or 0 if there was no
+// response; any non-2xx status is reported as an error.
+type WebhookSender interface {
+	Send(ctx context.Context, job *WebhookDeliveryJob) (int, error)
+}
+
+// SearchIndex defines the port for the full-text index of tickets and their
+// comments. Index adds documents or replaces those with the same key, and
+// DeleteTicket removes every document of a ticket.
+type SearchIndex interface {
+	Index(ctx context.Context, docs []domain.SearchDocument) error
+	DeleteTicket(ctx context.Context, orgID uuid.UUID, ticketID int64) error
+	Search(ctx context.Context, query domain.SearchQuery) ([]*domain.SearchHit, error)
+}
+
+// RateLimitControl defines the port for the in-memory rate limiters guarding
+// the API. Clear forgets a client so its next request starts with a full
+// allowance, and reports whether the client was known. SetOverrides replaces
+// the overrides the limiters apply.
+type RateLimitControl interface {
+	Throttled() []domain.ThrottledClient
+	Clear(key string) bool
+	SetOverrides(overrides []*domain.RateLimitOverride)
+}
+
+// Cache defines the port for a key-value cache with expiry. Backed by Redis
+// it is shared between replicas; otherwise it lives in process memory. Get
+// reports whether the key was found. SetNX only stores the value if the key
+// is absent, and reports whether it did.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// RateLimitDecision is the outcome of taking a token from a rate limit bucket.
+type RateLimitDecision struct {
+	Allowed    bool
+	Remaining  int           // Tokens left in the bucket
+	RetryAfter time.Duration // Until a token is available; zero when allowed
+	Reset      time.Duration // Until the bucket is full again
+}
+
+// RateLimitStore defines the port for rate limiter state shared between
+// replicas. Allow takes a token from key's bucket, which refills at
+// requestsPerSecond up to burst. Reset refills the bucket.
+type RateLimitStore interface {
+	Allow(ctx context.Context, key string, requestsPerSecond float64, burst int) (RateLimitDecision, error)
+	Reset(ctx context.Context, key string) error
+}
+
+// ConfigReloader defines the port for the runtime configuration snapshot.
+// Reload re-reads the configuration and swaps in the new snapshot, leaving the
+// current one in place if the new configuration is invalid.
+type ConfigReloader interface {
+	Current() *domain.RuntimeConfig
+	Reload() (*domain.RuntimeConfig, error)
 }
 
 // TransactionManager defines the port for running atomic operations.
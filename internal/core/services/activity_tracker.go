@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// ActivityConfig controls how user activity is recorded.
+type ActivityConfig struct {
+	Throttle      time.Duration // A user's activity is recorded at most once per Throttle
+	FlushInterval time.Duration // How often recorded activity is written
+}
+
+// ActivityTracker keeps when users were last active up to date without a
+// write per request: Touch notes the time in memory, at most once per user
+// per cfg.Throttle, and Run writes what was noted in one batch every
+// cfg.FlushInterval. Activity noted since the last flush is lost if the
+// process dies.
+type ActivityTracker struct {
+	userRepo ports.UserRepository
+	cfg      ActivityConfig
+	logger   *slog.Logger
+	now      func() time.Time
+
+	mu      sync.Mutex
+	pending map[uuid.UUID]time.Time
+	// recorded is when each user's activity was last noted, for throttling
+	recorded map[uuid.UUID]time.Time
+}
+
+// NewActivityTracker creates a new activity tracker
+func NewActivityTracker(userRepo ports.UserRepository, cfg ActivityConfig, logger *slog.Logger) *ActivityTracker {
+	return &ActivityTracker{
+		userRepo: userRepo,
+		cfg:      cfg,
+		logger:   logger.With("component", "activity"),
+		now:      time.Now,
+		pending:  make(map[uuid.UUID]time.Time),
+		recorded: make(map[uuid.UUID]time.Time),
+	}
+}
+
+// Touch notes that the user is active now, unless their activity was noted
+// less than cfg.Throttle ago. It never blocks on the database.
+func (t *ActivityTracker) Touch(userID uuid.UUID) {
+	now := t.now().UTC()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if last, ok := t.recorded[userID]; ok && now.Sub(last) < t.cfg.Throttle {
+		return
+	}
+	t.recorded[userID] = now
+	t.pending[userID] = now
+}
+
+// Run writes noted activity every cfg.FlushInterval until ctx is
+// cancelled, and once more on the way out.
+func (t *ActivityTracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if _, err := t.Flush(context.WithoutCancel(ctx)); err != nil {
+				t.logger.Error("failed to record user activity", "error", err)
+			}
+			return
+		case <-ticker.C:
+			if _, err := t.Flush(ctx); err != nil && ctx.Err() == nil {
+				t.logger.Error("failed to record user activity", "error", err)
+			}
+		}
+	}
+}
+
+// Flush writes the activity noted since the last flush and returns for how
+// many users. If the write fails, the activity is kept for the next flush.
+func (t *ActivityTracker) Flush(ctx context.Context) (int, error) {
+	t.mu.Lock()
+	batch := t.pending
+	t.pending = make(map[uuid.UUID]time.Time, len(batch))
+	// Users seen longer than the throttle ago would be noted again anyway
+	cutoff := t.now().UTC().Add(-t.cfg.Throttle)
+	for userID, last := range t.recorded {
+		if last.Before(cutoff) {
+			delete(t.recorded, userID)
+		}
+	}
+	t.mu.Unlock()
+
+	if len(batch) == 0 {
+		return 0, nil
+	}
+
+	if err := t.userRepo.UpdateLastActiveBatch(ctx, batch); err != nil {
+		t.mu.Lock()
+		for userID, at := range batch {
+			if _, ok := t.pending[userID]; !ok {
+				t.pending[userID] = at
+			}
+		}
+		t.mu.Unlock()
+		return 0, err
+	}
+	return len(batch), nil
+}
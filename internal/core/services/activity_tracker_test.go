@@ -0,0 +1,78 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivityTracker_Flush(t *testing.T) {
+	ctx := context.Background()
+	alice := uuid.New()
+	bob := uuid.New()
+
+	newTracker := func(userRepo *mocks.MockUserRepository) *services.ActivityTracker {
+		return services.NewActivityTracker(userRepo, services.ActivityConfig{
+			Throttle:      time.Hour,
+			FlushInterval: time.Minute,
+		}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	}
+	batchOf := func(userIDs ...uuid.UUID) any {
+		return mock.MatchedBy(func(batch map[uuid.UUID]time.Time) bool {
+			if len(batch) != len(userIDs) {
+				return false
+			}
+			for _, id := range userIDs {
+				if _, ok := batch[id]; !ok {
+					return false
+				}
+			}
+			return true
+		})
+	}
+
+	t.Run("writes each user once per throttle period", func(t *testing.T) {
+		userRepo := mocks.NewMockUserRepository()
+		tracker := newTracker(userRepo)
+		userRepo.On("UpdateLastActiveBatch", ctx, batchOf(alice, bob)).Return(nil).Once()
+
+		tracker.Touch(alice)
+		tracker.Touch(alice)
+		tracker.Touch(bob)
+		n, err := tracker.Flush(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+
+		tracker.Touch(alice)
+		n, err = tracker.Flush(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, n)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("keeps the activity when the write fails", func(t *testing.T) {
+		userRepo := mocks.NewMockUserRepository()
+		tracker := newTracker(userRepo)
+		userRepo.On("UpdateLastActiveBatch", ctx, batchOf(alice)).Return(errors.New("connection reset")).Once()
+		userRepo.On("UpdateLastActiveBatch", ctx, batchOf(alice)).Return(nil).Once()
+
+		tracker.Touch(alice)
+		_, err := tracker.Flush(ctx)
+		require.Error(t, err)
+
+		n, err := tracker.Flush(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		userRepo.AssertExpectations(t)
+	})
+}
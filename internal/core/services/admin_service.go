@@ -3,7 +3,11 @@ package services
 import (
 	"context"
 	"crypto/rand"
+	"errors"
+	"fmt"
 	"math/big"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lorrc/service-desk-backend/internal/core/domain"
@@ -11,11 +15,28 @@ import (
 	"github.com/lorrc/service-desk-backend/internal/core/ports"
 )
 
+const (
+	// defaultUserListLimit is how many users are listed when no limit is given
+	defaultUserListLimit = 50
+	// maxUserListLimit caps how many users can be listed at once
+	maxUserListLimit = 200
+	// defaultAuditLogLimit is how many audit entries are listed when no limit is given
+	defaultAuditLogLimit = 50
+	// maxAuditLogLimit caps how many audit entries can be listed at once
+	maxAuditLogLimit = 200
+)
+
 type AdminService struct {
 	userRepo      ports.UserRepository
 	authRepo      ports.AuthorizationRepository
 	authzSvc      ports.AuthorizationService
+	ticketRepo    ports.TicketRepository
+	eventRepo     ports.TicketEventRepository
+	outbox        ports.NotificationOutboxRepository
 	analyticsRepo ports.AnalyticsRepository
+	auditRepo     ports.AuditLogRepository
+	quotaSvc      ports.QuotaService
+	txManager     ports.TransactionManager
 }
 
 var _ ports.AdminService = (*AdminService)(nil)
@@ -24,22 +45,47 @@ func NewAdminService(
 	userRepo ports.UserRepository,
 	authRepo ports.AuthorizationRepository,
 	authzSvc ports.AuthorizationService,
+	ticketRepo ports.TicketRepository,
+	eventRepo ports.TicketEventRepository,
+	outbox ports.NotificationOutboxRepository,
 	analyticsRepo ports.AnalyticsRepository,
+	auditRepo ports.AuditLogRepository,
+	quotaSvc ports.QuotaService,
+	txManager ports.TransactionManager,
 ) ports.AdminService {
 	return &AdminService{
 		userRepo:      userRepo,
 		authRepo:      authRepo,
 		authzSvc:      authzSvc,
+		ticketRepo:    ticketRepo,
+		eventRepo:     eventRepo,
+		outbox:        outbox,
 		analyticsRepo: analyticsRepo,
+		auditRepo:     auditRepo,
+		quotaSvc:      quotaSvc,
+		txManager:     txManager,
 	}
 }
 
-func (s *AdminService) ListUsers(ctx context.Context, actorID, orgID uuid.UUID) ([]*domain.UserSummary, error) {
+// ListUsers returns a page of the organization's users matching filter and
+// the total number of matches.
+func (s *AdminService) ListUsers(ctx context.Context, actorID, orgID uuid.UUID, filter domain.UserFilter) ([]*domain.UserSummary, domain.Total, error) {
 	if err := s.requireAdmin(ctx, actorID); err != nil {
-		return nil, err
+		return nil, domain.Total{}, err
 	}
 
-	return s.userRepo.ListByOrganization(ctx, orgID)
+	if filter.Limit <= 0 {
+		filter.Limit = defaultUserListLimit
+	}
+	if filter.Limit > maxUserListLimit {
+		filter.Limit = maxUserListLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	filter.Query = strings.TrimSpace(filter.Query)
+
+	return s.userRepo.SearchByOrganization(ctx, orgID, filter)
 }
 
 func (s *AdminService) UpdateUserRole(ctx context.Context, actorID, orgID, userID uuid.UUID, role string) error {
@@ -47,15 +93,25 @@ func (s *AdminService) UpdateUserRole(ctx context.Context, actorID, orgID, userI
 		return err
 	}
 
-	user, err := s.userRepo.GetByID(ctx, userID)
-	if err != nil {
+	if _, err := s.managedUser(ctx, orgID, userID); err != nil {
 		return err
 	}
-	if user.OrganizationID != orgID {
-		return apperrors.ErrForbidden
-	}
 
-	return s.authRepo.SetUserRole(ctx, userID, role)
+	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		roles, err := s.authRepo.GetUserRoles(txCtx, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.authRepo.SetUserRole(txCtx, userID, role); err != nil {
+			return err
+		}
+
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditUserRoleChanged, userID,
+			map[string]any{"roles": roles},
+			map[string]any{"roles": []string{role}},
+		)
+	})
 }
 
 func (s *AdminService) UpdateUserStatus(ctx context.Context, actorID, orgID, userID uuid.UUID, isActive bool) error {
@@ -66,15 +122,155 @@ func (s *AdminService) UpdateUserStatus(ctx context.Context, actorID, orgID, use
 		return apperrors.ErrForbidden
 	}
 
-	user, err := s.userRepo.GetByID(ctx, userID)
+	user, err := s.managedUser(ctx, orgID, userID)
 	if err != nil {
 		return err
 	}
-	if user.OrganizationID != orgID {
-		return apperrors.ErrForbidden
+
+	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.userRepo.SetActive(txCtx, userID, isActive); err != nil {
+			return err
+		}
+
+		// Deactivation also revokes every outstanding token, so reactivating the
+		// account later does not bring old sessions back to life.
+		if !isActive {
+			if err := s.userRepo.RevokeTokens(txCtx, userID, time.Now().UTC()); err != nil {
+				return err
+			}
+		}
+
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditUserStatusChanged, userID,
+			map[string]any{"isActive": user.IsActive},
+			map[string]any{"isActive": isActive},
+		)
+	})
+}
+
+// OffboardUser deactivates a user and hands their open tickets to
+// reassignTo, or leaves them unassigned when reassignTo is nil. Every change
+// happens in one transaction, and the new assignee is notified of each
+// ticket they receive.
+func (s *AdminService) OffboardUser(ctx context.Context, actorID, orgID, userID uuid.UUID, reassignTo *uuid.UUID) (*domain.OffboardResult, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+	if userID == actorID {
+		return nil, apperrors.ErrForbidden
 	}
 
-	return s.userRepo.SetActive(ctx, userID, isActive)
+	user, err := s.managedUser(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reassignTo != nil {
+		if err := s.checkReassignTarget(ctx, orgID, userID, *reassignTo); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &domain.OffboardResult{
+		UserID:       userID,
+		ReassignedTo: reassignTo,
+		TicketIDs:    make([]int64, 0),
+	}
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.userRepo.SetActive(txCtx, userID, false); err != nil {
+			return err
+		}
+		if err := s.userRepo.RevokeTokens(txCtx, userID, time.Now().UTC()); err != nil {
+			return err
+		}
+
+		tickets, err := s.ticketRepo.ListOpenByAssignee(txCtx, orgID, userID)
+		if err != nil {
+			return err
+		}
+		for _, ticket := range tickets {
+			if err := s.handOffTicket(txCtx, actorID, ticket, reassignTo); err != nil {
+				return err
+			}
+			result.TicketIDs = append(result.TicketIDs, ticket.ID)
+		}
+
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditUserOffboarded, userID,
+			map[string]any{"isActive": user.IsActive},
+			map[string]any{"isActive": false, "reassignedTo": reassignTo, "ticketIds": result.TicketIDs},
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// checkReassignTarget makes sure tickets can be handed to assigneeID: an
+// active agent or admin in the organization other than the user leaving.
+func (s *AdminService) checkReassignTarget(ctx context.Context, orgID, userID, assigneeID uuid.UUID) error {
+	invalid := func() error {
+		errs := apperrors.NewValidationErrors()
+		errs.Add("reassignTo", "Must be another active agent or admin in your organization")
+		return errs
+	}
+
+	if assigneeID == userID {
+		return invalid()
+	}
+
+	assignee, err := s.userRepo.GetByID(ctx, assigneeID)
+	if errors.Is(err, apperrors.ErrUserNotFound) {
+		return invalid()
+	}
+	if err != nil {
+		return err
+	}
+	if assignee.OrganizationID != orgID || !assignee.IsActive {
+		return invalid()
+	}
+
+	roles, err := s.authRepo.GetUserRoles(ctx, assigneeID)
+	if err != nil {
+		return err
+	}
+	for _, role := range roles {
+		if role == "admin" || role == "agent" {
+			return nil
+		}
+	}
+	return invalid()
+}
+
+// handOffTicket moves one of a leaving user's tickets to assigneeID, or
+// unassigns it when assigneeID is nil, recording the change as an event.
+func (s *AdminService) handOffTicket(ctx context.Context, actorID uuid.UUID, ticket *domain.Ticket, assigneeID *uuid.UUID) error {
+	if assigneeID != nil {
+		if err := ticket.Assign(*assigneeID); err != nil {
+			return err
+		}
+	} else if err := ticket.Unassign(); err != nil {
+		return err
+	}
+
+	saved, err := s.ticketRepo.Update(ctx, ticket)
+	if err != nil {
+		return err
+	}
+
+	event, err := domain.NewTicketEvent(domain.EventTicketAssigned, saved, actorID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.eventRepo.Create(ctx, event); err != nil {
+		return err
+	}
+
+	if assigneeID == nil || *assigneeID == actorID {
+		return nil
+	}
+	notification := ticketAssignedNotification(saved, *assigneeID, lookupRequesterName(ctx, s.userRepo, saved))
+	return s.outbox.Enqueue(ctx, notification)
 }
 
 func (s *AdminService) ResetUserPassword(ctx context.Context, actorID, orgID, userID uuid.UUID) (string, error) {
@@ -82,13 +278,9 @@ func (s *AdminService) ResetUserPassword(ctx context.Context, actorID, orgID, us
 		return "", err
 	}
 
-	user, err := s.userRepo.GetByID(ctx, userID)
-	if err != nil {
+	if _, err := s.managedUser(ctx, orgID, userID); err != nil {
 		return "", err
 	}
-	if user.OrganizationID != orgID {
-		return "", apperrors.ErrForbidden
-	}
 
 	temporaryPassword, err := generateTemporaryPassword(12)
 	if err != nil {
@@ -100,19 +292,217 @@ func (s *AdminService) ResetUserPassword(ctx context.Context, actorID, orgID, us
 		return "", err
 	}
 
-	if err := s.userRepo.UpdatePassword(ctx, userID, hashedPassword); err != nil {
+	// The password itself is never written to the audit log.
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.userRepo.UpdatePassword(txCtx, userID, hashedPassword); err != nil {
+			return err
+		}
+
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditUserPasswordReset, userID, nil, nil)
+	}); err != nil {
 		return "", err
 	}
 
 	return temporaryPassword, nil
 }
 
-func (s *AdminService) GetAnalyticsOverview(ctx context.Context, actorID, orgID uuid.UUID, days int) (*domain.AnalyticsOverview, error) {
+// ForceLogout revokes every token issued to the user so far, ending all of
+// their sessions without deactivating the account.
+func (s *AdminService) ForceLogout(ctx context.Context, actorID, orgID, userID uuid.UUID) error {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return err
+	}
+
+	if _, err := s.managedUser(ctx, orgID, userID); err != nil {
+		return err
+	}
+
+	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.userRepo.RevokeTokens(txCtx, userID, time.Now().UTC()); err != nil {
+			return err
+		}
+
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditUserForcedLogout, userID, nil, nil)
+	})
+}
+
+// DeleteUser anonymizes a user's personal data. Their tickets and comments
+// are kept, attributed to the anonymized account.
+func (s *AdminService) DeleteUser(ctx context.Context, actorID, orgID, userID uuid.UUID) error {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return err
+	}
+	if userID == actorID {
+		return apperrors.ErrForbidden
+	}
+
+	if _, err := s.managedUser(ctx, orgID, userID); err != nil {
+		return err
+	}
+
+	// The removed personal data is not copied into the audit log.
+	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.userRepo.Anonymize(txCtx, userID, time.Now().UTC()); err != nil {
+			return err
+		}
+
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditUserAnonymized, userID, nil, nil)
+	})
+}
+
+// ImportUsers creates users in bulk, each with a temporary password. Rows
+// that are invalid, repeat an email or belong to an existing user are
+// reported and skipped; the rest are created together in one transaction.
+func (s *AdminService) ImportUsers(ctx context.Context, actorID, orgID uuid.UUID, rows []domain.UserImportRow) (*domain.UserImportReport, error) {
 	if err := s.requireAdmin(ctx, actorID); err != nil {
 		return nil, err
 	}
 
-	return s.analyticsRepo.GetOverview(ctx, orgID, days)
+	if len(rows) == 0 || len(rows) > domain.MaxUserImportRows {
+		errs := apperrors.NewValidationErrors()
+		errs.Add("file", fmt.Sprintf("File must contain between 1 and %d users", domain.MaxUserImportRows))
+		return nil, errs
+	}
+
+	report := &domain.UserImportReport{Results: make([]domain.UserImportResult, len(rows))}
+	pending := make([]int, 0, len(rows))
+	users := make([]*domain.User, len(rows))
+	seen := make(map[string]int, len(rows))
+
+	for i := range rows {
+		row := rows[i]
+		row.Normalize()
+		result := &report.Results[i]
+		result.Line = row.Line
+		result.Email = row.Email
+		result.Role = row.Role
+
+		if problems := row.Validate(); len(problems) > 0 {
+			result.Error = strings.Join(problems, "; ")
+			continue
+		}
+
+		key := strings.ToLower(row.Email)
+		if line, ok := seen[key]; ok {
+			result.Error = fmt.Sprintf("Email is a duplicate of line %d", line)
+			continue
+		}
+		seen[key] = row.Line
+
+		if _, err := s.userRepo.GetByEmail(ctx, orgID, row.Email); err == nil {
+			result.Error = "A user with this email already exists"
+			continue
+		} else if !errors.Is(err, apperrors.ErrUserNotFound) {
+			return nil, err
+		}
+
+		temporaryPassword, err := generateTemporaryPassword(12)
+		if err != nil {
+			return nil, err
+		}
+		user, err := domain.NewUser(domain.UserRegistrationParams{
+			FullName: row.FullName,
+			Email:    row.Email,
+			Password: temporaryPassword,
+		}, orgID)
+		if err != nil {
+			return nil, err
+		}
+
+		result.TemporaryPassword = temporaryPassword
+		users[i] = user
+		pending = append(pending, i)
+	}
+
+	if len(pending) > 0 {
+		if err := s.quotaSvc.CheckUsers(ctx, orgID, len(pending)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		for _, i := range pending {
+			created, err := s.userRepo.Create(txCtx, users[i])
+			if err != nil {
+				return fmt.Errorf("line %d: %w", report.Results[i].Line, err)
+			}
+			if err := s.authRepo.AssignRole(txCtx, created.ID, report.Results[i].Role); err != nil {
+				return fmt.Errorf("line %d: %w", report.Results[i].Line, err)
+			}
+			if err := s.recordAudit(txCtx, orgID, actorID, domain.AuditUserImported, created.ID, nil,
+				map[string]any{"roles": []string{report.Results[i].Role}},
+			); err != nil {
+				return err
+			}
+			report.Results[i].UserID = created.ID
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	report.Created = len(pending)
+	report.Failed = len(rows) - len(pending)
+	return report, nil
+}
+
+func (s *AdminService) GetAnalyticsOverview(ctx context.Context, actorID, orgID uuid.UUID, rng domain.AnalyticsRange) (*domain.AnalyticsOverview, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	return s.analyticsRepo.GetOverview(ctx, orgID, rng)
+}
+
+// ListAuditLog returns a page of the organization's audit log, newest first,
+// and the total number of matching entries.
+func (s *AdminService) ListAuditLog(ctx context.Context, actorID, orgID uuid.UUID, filter domain.AuditLogFilter) ([]*domain.AuditEntry, domain.Total, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, domain.Total{}, err
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditLogLimit
+	}
+	if filter.Limit > maxAuditLogLimit {
+		filter.Limit = maxAuditLogLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	return s.auditRepo.List(ctx, orgID, filter)
+}
+
+// recordAudit logs a change made to a user.
+func (s *AdminService) recordAudit(ctx context.Context, orgID, actorID uuid.UUID, action domain.AuditAction, userID uuid.UUID, before, after any) error {
+	entry, err := domain.NewAuditEntry(ctx, orgID, actorID, action, domain.AuditTargetUser, userID.String(), before, after)
+	if err != nil {
+		return err
+	}
+	return s.auditRepo.Create(ctx, entry)
+}
+
+// managedUser returns the user if the organization's admins may manage
+// them: they belong to the organization and are not a super-admin, whose
+// account is only managed at the platform level.
+func (s *AdminService) managedUser(ctx context.Context, orgID, userID uuid.UUID) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.OrganizationID != orgID {
+		return nil, apperrors.ErrForbidden
+	}
+
+	platform, err := s.authzSvc.Can(ctx, userID, "platform:access")
+	if err != nil {
+		return nil, err
+	}
+	if platform {
+		return nil, apperrors.ErrForbidden
+	}
+	return user, nil
 }
 
 func (s *AdminService) requireAdmin(ctx context.Context, actorID uuid.UUID) error {
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// AnalyticsRollupConfig controls how often the analytics rollups are rebuilt.
+type AnalyticsRollupConfig struct {
+	Interval     time.Duration // How often the rollups are refreshed
+	LookbackDays int           // Completed days rebuilt on every refresh, to pick up late changes
+}
+
+// AnalyticsRollupJob keeps the daily analytics rollups up to date. Each run
+// rebuilds every day up to yesterday that is new or within the lookback, so
+// tickets closed or reopened after the fact are reflected.
+type AnalyticsRollupJob struct {
+	analyticsRepo ports.AnalyticsRepository
+	txManager     ports.TransactionManager
+	cfg           AnalyticsRollupConfig
+	logger        *slog.Logger
+	now           func() time.Time
+}
+
+// NewAnalyticsRollupJob creates a new analytics rollup job
+func NewAnalyticsRollupJob(
+	analyticsRepo ports.AnalyticsRepository,
+	txManager ports.TransactionManager,
+	cfg AnalyticsRollupConfig,
+	logger *slog.Logger,
+) *AnalyticsRollupJob {
+	return &AnalyticsRollupJob{
+		analyticsRepo: analyticsRepo,
+		txManager:     txManager,
+		cfg:           cfg,
+		logger:        logger.With("component", "analytics_rollup"),
+		now:           time.Now,
+	}
+}
+
+// Run refreshes the rollups straight away and then every cfg.Interval until
+// ctx is cancelled.
+func (j *AnalyticsRollupJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.Refresh(ctx); err != nil && ctx.Err() == nil {
+			j.logger.Error("failed to refresh analytics rollups", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh rebuilds the rollups through yesterday. The first refresh builds
+// them from the beginning of the ticket history.
+func (j *AnalyticsRollupJob) Refresh(ctx context.Context) error {
+	y, m, d := j.now().UTC().Date()
+	through := time.Date(y, m, d-1, 0, 0, 0, 0, time.UTC)
+
+	last, err := j.analyticsRepo.RollupThrough(ctx)
+	if err != nil {
+		return err
+	}
+
+	// A zero start rebuilds everything.
+	var from time.Time
+	if last != nil {
+		from = through.AddDate(0, 0, 1-j.cfg.LookbackDays)
+		if next := last.AddDate(0, 0, 1); next.Before(from) {
+			from = next
+		}
+	}
+
+	return j.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		return j.analyticsRepo.RefreshDailyRollups(txCtx, from, through)
+	})
+}
@@ -0,0 +1,63 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAnalyticsRollupJob_Refresh(t *testing.T) {
+	ctx := context.Background()
+	newJob := func(repo *mocks.MockAnalyticsRepository) *services.AnalyticsRollupJob {
+		return services.NewAnalyticsRollupJob(repo, stubTransactionManager{}, services.AnalyticsRollupConfig{
+			Interval:     time.Hour,
+			LookbackDays: 3,
+		}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	}
+	y, m, d := time.Now().UTC().Date()
+	yesterday := time.Date(y, m, d-1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("builds all history on the first run", func(t *testing.T) {
+		repo := mocks.NewMockAnalyticsRepository()
+		repo.On("RollupThrough", ctx).Return(nil, nil)
+		repo.On("RefreshDailyRollups", ctx, time.Time{}, yesterday).Return(nil)
+
+		assert.NoError(t, newJob(repo).Refresh(ctx))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rebuilds the lookback window when up to date", func(t *testing.T) {
+		repo := mocks.NewMockAnalyticsRepository()
+		repo.On("RollupThrough", ctx).Return(&yesterday, nil)
+		repo.On("RefreshDailyRollups", ctx, yesterday.AddDate(0, 0, -2), yesterday).Return(nil)
+
+		assert.NoError(t, newJob(repo).Refresh(ctx))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("catches up from the last rolled up day", func(t *testing.T) {
+		last := yesterday.AddDate(0, 0, -10)
+		repo := mocks.NewMockAnalyticsRepository()
+		repo.On("RollupThrough", ctx).Return(&last, nil)
+		repo.On("RefreshDailyRollups", ctx, last.AddDate(0, 0, 1), yesterday).Return(nil)
+
+		assert.NoError(t, newJob(repo).Refresh(ctx))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("returns lookup errors without refreshing", func(t *testing.T) {
+		repo := mocks.NewMockAnalyticsRepository()
+		repo.On("RollupThrough", ctx).Return(nil, errors.New("db down"))
+
+		assert.Error(t, newJob(repo).Refresh(ctx))
+		repo.AssertNotCalled(t, "RefreshDailyRollups", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// ArchiveConfig controls how closed tickets are archived.
+type ArchiveConfig struct {
+	Interval  time.Duration // How often closed tickets are archived
+	AfterDays int           // How long a ticket stays closed before it is archived
+	BatchSize int           // How many tickets are moved per transaction
+}
+
+// ArchiveJob moves tickets that have been closed for a while, with their
+// comments and history, out of the hot tables and into the archive, where
+// they can still be read but no longer changed. Tickets are moved in
+// batches so no single transaction holds many locks.
+type ArchiveJob struct {
+	archiveRepo ports.TicketArchiveRepository
+	cfg         ArchiveConfig
+	logger      *slog.Logger
+	now         func() time.Time
+}
+
+// NewArchiveJob creates a new archive job
+func NewArchiveJob(archiveRepo ports.TicketArchiveRepository, cfg ArchiveConfig, logger *slog.Logger) *ArchiveJob {
+	return &ArchiveJob{
+		archiveRepo: archiveRepo,
+		cfg:         cfg,
+		logger:      logger.With("component", "archive"),
+		now:         time.Now,
+	}
+}
+
+// Run archives closed tickets straight away and then every cfg.Interval
+// until ctx is cancelled.
+func (j *ArchiveJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := j.Archive(ctx); err != nil && ctx.Err() == nil {
+			j.logger.Error("failed to archive tickets", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Archive moves every ticket closed for longer than cfg.AfterDays into the
+// archive and returns how many it moved.
+func (j *ArchiveJob) Archive(ctx context.Context) (int64, error) {
+	closedBefore := j.now().UTC().AddDate(0, 0, -j.cfg.AfterDays)
+
+	var total int64
+	for {
+		n, err := j.archiveRepo.ArchiveClosedBefore(ctx, closedBefore, j.cfg.BatchSize)
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if n < int64(j.cfg.BatchSize) || ctx.Err() != nil {
+			break
+		}
+	}
+
+	if total > 0 {
+		j.logger.Info("archived closed tickets", "count", total, "closed_before", closedBefore)
+	}
+	return total, nil
+}
@@ -0,0 +1,57 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveJob_Archive(t *testing.T) {
+	ctx := context.Background()
+
+	newJob := func(repo *mocks.MockTicketArchiveRepository) *services.ArchiveJob {
+		return services.NewArchiveJob(repo, services.ArchiveConfig{
+			Interval:  time.Hour,
+			AfterDays: 180,
+			BatchSize: 2,
+		}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	}
+
+	t.Run("archives batches until one comes back short", func(t *testing.T) {
+		repo := mocks.NewMockTicketArchiveRepository()
+
+		var closedBefore time.Time
+		repo.On("ArchiveClosedBefore", ctx, mock.Anything, 2).
+			Run(func(args mock.Arguments) { closedBefore = args.Get(1).(time.Time) }).
+			Return(int64(2), nil).Twice()
+		repo.On("ArchiveClosedBefore", ctx, mock.Anything, 2).Return(int64(1), nil).Once()
+
+		archived, err := newJob(repo).Archive(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), archived)
+		assert.WithinDuration(t, time.Now().AddDate(0, 0, -180), closedBefore, time.Minute)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		repo := mocks.NewMockTicketArchiveRepository()
+		repo.On("ArchiveClosedBefore", ctx, mock.Anything, 2).Return(int64(2), nil).Once()
+		repo.On("ArchiveClosedBefore", ctx, mock.Anything, 2).Return(int64(0), errors.New("db down")).Once()
+
+		archived, err := newJob(repo).Archive(ctx)
+
+		require.Error(t, err)
+		assert.Equal(t, int64(2), archived)
+		repo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// ArchiveReadThroughTicketRepository wraps a TicketRepository and falls
+// back to the ticket archive for tickets that are no longer in it, so
+// archived tickets can still be read wherever tickets are looked up by ID.
+// Archived tickets cannot be changed: updating or claiming one returns
+// apperrors.ErrTicketArchived.
+type ArchiveReadThroughTicketRepository struct {
+	ports.TicketRepository
+	archive ports.TicketArchiveRepository
+}
+
+var _ ports.TicketRepository = (*ArchiveReadThroughTicketRepository)(nil)
+
+// NewArchiveReadThroughTicketRepository creates a new read-through ticket repository
+func NewArchiveReadThroughTicketRepository(ticketRepo ports.TicketRepository, archive ports.TicketArchiveRepository) ports.TicketRepository {
+	return &ArchiveReadThroughTicketRepository{
+		TicketRepository: ticketRepo,
+		archive:          archive,
+	}
+}
+
+// GetByID returns the ticket, from the archive if it was archived
+func (r *ArchiveReadThroughTicketRepository) GetByID(ctx context.Context, orgID uuid.UUID, id int64) (*domain.Ticket, error) {
+	ticket, err := r.TicketRepository.GetByID(ctx, orgID, id)
+	if errors.Is(err, apperrors.ErrTicketNotFound) {
+		return r.archive.GetTicket(ctx, orgID, id)
+	}
+	return ticket, err
+}
+
+// Update persists the ticket unless it is archived
+func (r *ArchiveReadThroughTicketRepository) Update(ctx context.Context, ticket *domain.Ticket) (*domain.Ticket, error) {
+	if ticket.IsArchived() {
+		return nil, apperrors.ErrTicketArchived
+	}
+	return r.TicketRepository.Update(ctx, ticket)
+}
+
+// Claim assigns the ticket unless it is archived
+func (r *ArchiveReadThroughTicketRepository) Claim(ctx context.Context, orgID uuid.UUID, ticketID int64, assigneeID uuid.UUID, at time.Time) (*domain.Ticket, error) {
+	claimed, err := r.TicketRepository.Claim(ctx, orgID, ticketID, assigneeID, at)
+	if errors.Is(err, apperrors.ErrTicketNotFound) || errors.Is(err, apperrors.ErrTicketAlreadyClaimed) {
+		if _, archiveErr := r.archive.GetTicket(ctx, orgID, ticketID); archiveErr == nil {
+			return nil, apperrors.ErrTicketArchived
+		}
+	}
+	return claimed, err
+}
+
+// ArchiveReadThroughCommentRepository wraps a CommentRepository and reads
+// the comments of archived tickets from the archive. Callers check access
+// to the ticket first, so it is only consulted for tickets without
+// comments in the hot table.
+type ArchiveReadThroughCommentRepository struct {
+	ports.CommentRepository
+	archive ports.TicketArchiveRepository
+}
+
+var _ ports.CommentRepository = (*ArchiveReadThroughCommentRepository)(nil)
+
+// NewArchiveReadThroughCommentRepository creates a new read-through comment repository
+func NewArchiveReadThroughCommentRepository(commentRepo ports.CommentRepository, archive ports.TicketArchiveRepository) ports.CommentRepository {
+	return &ArchiveReadThroughCommentRepository{
+		CommentRepository: commentRepo,
+		archive:           archive,
+	}
+}
+
+// ListByTicketID returns the ticket's comments, from the archive if it was archived
+func (r *ArchiveReadThroughCommentRepository) ListByTicketID(ctx context.Context, ticketID int64) ([]*domain.Comment, error) {
+	comments, err := r.CommentRepository.ListByTicketID(ctx, ticketID)
+	if err != nil || len(comments) > 0 {
+		return comments, err
+	}
+	return r.archive.ListComments(ctx, ticketID)
+}
+
+// ListPageByTicketID returns a keyset page of the ticket's comments, from
+// the archive if it was archived
+func (r *ArchiveReadThroughCommentRepository) ListPageByTicketID(ctx context.Context, ticketID int64, page domain.PageRequest) (domain.Page[*domain.Comment], error) {
+	result, err := r.CommentRepository.ListPageByTicketID(ctx, ticketID, page)
+	if err != nil || len(result.Items) > 0 {
+		return result, err
+	}
+
+	comments, err := r.archive.ListComments(ctx, ticketID)
+	if err != nil {
+		return domain.Page[*domain.Comment]{}, err
+	}
+	return archivedCommentPage(comments, page), nil
+}
+
+// archivedCommentPage pages through archived comments the way the comment
+// table is paged: oldest first, by creation time and then ID.
+func archivedCommentPage(comments []*domain.Comment, page domain.PageRequest) domain.Page[*domain.Comment] {
+	sort.Slice(comments, func(i, j int) bool {
+		if comments[i].CreatedAt.Equal(comments[j].CreatedAt) {
+			return comments[i].ID < comments[j].ID
+		}
+		return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+	})
+
+	if page.After != nil {
+		afterID, _ := strconv.ParseInt(page.After.ID, 10, 64)
+		start := sort.Search(len(comments), func(i int) bool {
+			c := comments[i]
+			return c.CreatedAt.After(page.After.CreatedAt) ||
+				(c.CreatedAt.Equal(page.After.CreatedAt) && c.ID > afterID)
+		})
+		comments = comments[start:]
+	}
+
+	limit := page.PageLimit()
+	if len(comments) <= limit {
+		return domain.Page[*domain.Comment]{Items: comments}
+	}
+
+	items := comments[:limit]
+	last := items[len(items)-1]
+	return domain.Page[*domain.Comment]{
+		Items:      items,
+		NextCursor: &domain.PageCursor{CreatedAt: last.CreatedAt, ID: strconv.FormatInt(last.ID, 10)},
+	}
+}
+
+// ArchiveReadThroughEventRepository wraps a TicketEventRepository and reads
+// the history of archived tickets from the archive. Like the comment
+// repository, it is only consulted for tickets without events in the hot
+// table.
+type ArchiveReadThroughEventRepository struct {
+	ports.TicketEventRepository
+	archive ports.TicketArchiveRepository
+}
+
+var _ ports.TicketEventRepository = (*ArchiveReadThroughEventRepository)(nil)
+
+// NewArchiveReadThroughEventRepository creates a new read-through event repository
+func NewArchiveReadThroughEventRepository(eventRepo ports.TicketEventRepository, archive ports.TicketArchiveRepository) ports.TicketEventRepository {
+	return &ArchiveReadThroughEventRepository{
+		TicketEventRepository: eventRepo,
+		archive:               archive,
+	}
+}
+
+// ListByTicketID returns the ticket's events after afterID, from the
+// archive if it was archived
+func (r *ArchiveReadThroughEventRepository) ListByTicketID(ctx context.Context, ticketID int64, afterID int64, limit int) ([]*domain.Event, error) {
+	events, err := r.TicketEventRepository.ListByTicketID(ctx, ticketID, afterID, limit)
+	if err != nil || len(events) > 0 {
+		return events, err
+	}
+
+	archived, err := r.archive.ListEvents(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	page := make([]*domain.Event, 0, limit)
+	for _, event := range archived {
+		if event.ID > afterID && len(page) < limit {
+			page = append(page, event)
+		}
+	}
+	return page, nil
+}
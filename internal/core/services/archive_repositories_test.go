@@ -0,0 +1,118 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveReadThroughTicketRepository(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	archivedAt := time.Now()
+
+	t.Run("reads archived tickets", func(t *testing.T) {
+		tickets := mocks.NewMockTicketRepository()
+		archive := mocks.NewMockTicketArchiveRepository()
+		archived := &domain.Ticket{ID: 7, OrganizationID: orgID, ArchivedAt: &archivedAt}
+		tickets.On("GetByID", ctx, orgID, int64(7)).Return(nil, apperrors.ErrTicketNotFound)
+		archive.On("GetTicket", ctx, orgID, int64(7)).Return(archived, nil)
+
+		ticket, err := services.NewArchiveReadThroughTicketRepository(tickets, archive).GetByID(ctx, orgID, 7)
+
+		require.NoError(t, err)
+		assert.Same(t, archived, ticket)
+	})
+
+	t.Run("does not read live tickets from the archive", func(t *testing.T) {
+		tickets := mocks.NewMockTicketRepository()
+		archive := mocks.NewMockTicketArchiveRepository()
+		tickets.On("GetByID", ctx, orgID, int64(7)).Return(&domain.Ticket{ID: 7}, nil)
+
+		_, err := services.NewArchiveReadThroughTicketRepository(tickets, archive).GetByID(ctx, orgID, 7)
+
+		require.NoError(t, err)
+		archive.AssertNotCalled(t, "GetTicket")
+	})
+
+	t.Run("refuses to update archived tickets", func(t *testing.T) {
+		tickets := mocks.NewMockTicketRepository()
+		archive := mocks.NewMockTicketArchiveRepository()
+
+		_, err := services.NewArchiveReadThroughTicketRepository(tickets, archive).
+			Update(ctx, &domain.Ticket{ID: 7, ArchivedAt: &archivedAt})
+
+		assert.ErrorIs(t, err, apperrors.ErrTicketArchived)
+		tickets.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("refuses to claim archived tickets", func(t *testing.T) {
+		tickets := mocks.NewMockTicketRepository()
+		archive := mocks.NewMockTicketArchiveRepository()
+		assignee := uuid.New()
+		at := time.Now()
+		tickets.On("Claim", ctx, orgID, int64(7), assignee, at).Return(nil, apperrors.ErrTicketNotFound)
+		archive.On("GetTicket", ctx, orgID, int64(7)).Return(&domain.Ticket{ID: 7, ArchivedAt: &archivedAt}, nil)
+
+		_, err := services.NewArchiveReadThroughTicketRepository(tickets, archive).Claim(ctx, orgID, 7, assignee, at)
+
+		assert.ErrorIs(t, err, apperrors.ErrTicketArchived)
+	})
+}
+
+func TestArchiveReadThroughCommentRepository_ListPageByTicketID(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	archived := make([]*domain.Comment, 0, 5)
+	for i := 5; i >= 1; i-- {
+		archived = append(archived, &domain.Comment{ID: int64(i), TicketID: 7, CreatedAt: start.Add(time.Duration(i) * time.Minute)})
+	}
+
+	comments := mocks.NewMockCommentRepository()
+	archive := mocks.NewMockTicketArchiveRepository()
+	comments.On("ListPageByTicketID", ctx, int64(7), domain.PageRequest{Limit: 2}).
+		Return(domain.Page[*domain.Comment]{}, nil)
+	archive.On("ListComments", ctx, int64(7)).Return(archived, nil)
+	repo := services.NewArchiveReadThroughCommentRepository(comments, archive)
+
+	page, err := repo.ListPageByTicketID(ctx, 7, domain.PageRequest{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, int64(1), page.Items[0].ID)
+	assert.Equal(t, int64(2), page.Items[1].ID)
+	require.NotNil(t, page.NextCursor)
+	assert.Equal(t, "2", page.NextCursor.ID)
+
+	next := domain.PageRequest{After: page.NextCursor, Limit: 2}
+	comments.On("ListPageByTicketID", ctx, int64(7), next).Return(domain.Page[*domain.Comment]{}, nil)
+
+	page, err = repo.ListPageByTicketID(ctx, 7, next)
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, int64(3), page.Items[0].ID)
+}
+
+func TestArchiveReadThroughEventRepository_ListByTicketID(t *testing.T) {
+	ctx := context.Background()
+
+	events := mocks.NewMockTicketEventRepository()
+	archive := mocks.NewMockTicketArchiveRepository()
+	events.On("ListByTicketID", ctx, int64(7), int64(1), 2).Return([]*domain.Event{}, nil)
+	archive.On("ListEvents", ctx, int64(7)).Return([]*domain.Event{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}, nil)
+
+	page, err := services.NewArchiveReadThroughEventRepository(events, archive).ListByTicketID(ctx, 7, 1, 2)
+
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, int64(2), page[0].ID)
+	assert.Equal(t, int64(3), page[1].ID)
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lorrc/service-desk-backend/internal/core/domain"
@@ -11,29 +12,114 @@ import (
 
 // AssigneeService implements business logic for listing assignable users.
 type AssigneeService struct {
-	userRepo ports.UserRepository
-	authzSvc ports.AuthorizationService
+	userRepo      ports.UserRepository
+	ticketRepo    ports.TicketRepository
+	analyticsRepo ports.AnalyticsRepository
+	settingsRepo  ports.OrgSettingsRepository
+	authzSvc      ports.AuthorizationService
+	now           func() time.Time
 }
 
 var _ ports.AssigneeService = (*AssigneeService)(nil)
 
 // NewAssigneeService creates a new assignee service.
-func NewAssigneeService(userRepo ports.UserRepository, authzSvc ports.AuthorizationService) ports.AssigneeService {
+func NewAssigneeService(
+	userRepo ports.UserRepository,
+	ticketRepo ports.TicketRepository,
+	analyticsRepo ports.AnalyticsRepository,
+	settingsRepo ports.OrgSettingsRepository,
+	authzSvc ports.AuthorizationService,
+) ports.AssigneeService {
 	return &AssigneeService{
-		userRepo: userRepo,
-		authzSvc: authzSvc,
+		userRepo:      userRepo,
+		ticketRepo:    ticketRepo,
+		analyticsRepo: analyticsRepo,
+		settingsRepo:  settingsRepo,
+		authzSvc:      authzSvc,
+		now:           time.Now,
 	}
 }
 
-// ListAssignableUsers returns users eligible for assignment within the org.
-func (s *AssigneeService) ListAssignableUsers(ctx context.Context, actorID uuid.UUID, orgID uuid.UUID) ([]*domain.User, error) {
-	canAssign, err := s.authzSvc.Can(ctx, actorID, "tickets:assign")
+// ListAssignableUsers returns a page of the users eligible for assignment
+// within the org that match filter, with how many open tickets each has
+// against the organization's limit.
+func (s *AssigneeService) ListAssignableUsers(ctx context.Context, actorID uuid.UUID, orgID uuid.UUID, filter domain.AssigneeFilter) ([]domain.Assignee, domain.Total, error) {
+	if err := s.requireAssign(ctx, actorID); err != nil {
+		return nil, domain.Total{}, err
+	}
+
+	assignees, _, err := s.assignees(ctx, orgID, filter)
 	if err != nil {
+		return nil, domain.Total{}, err
+	}
+
+	page, total := filter.Page(assignees)
+	return page, total, nil
+}
+
+// SuggestAssignees ranks the users eligible for assignment by the tickets
+// sharing a tag or the team with ticketID they worked on recently, and by
+// how many tickets they have open. Out-of-office and deactivated agents are
+// not eligible, so they are never suggested, and neither are agents at the
+// open ticket limit when it blocks assignments.
+func (s *AssigneeService) SuggestAssignees(ctx context.Context, actorID, orgID uuid.UUID, ticketID int64) ([]domain.AssignmentSuggestion, error) {
+	if err := s.requireAssign(ctx, actorID); err != nil {
 		return nil, err
 	}
-	if !canAssign {
-		return nil, apperrors.ErrForbidden
+
+	ticket, err := s.ticketRepo.GetByID(ctx, orgID, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignees, capacity, err := s.assignees(ctx, orgID, domain.AssigneeFilter{})
+	if err != nil {
+		return nil, err
+	}
+	candidates := assignees
+	if capacity.Enforcement == domain.CapacityBlock {
+		candidates = make([]domain.Assignee, 0, len(assignees))
+		for _, assignee := range assignees {
+			if !assignee.AtCapacity() {
+				candidates = append(candidates, assignee)
+			}
+		}
+	}
+
+	history, err := s.analyticsRepo.ListAgentTicketHistory(ctx, orgID, domain.NewSimilarTicketsQuery(ticket, s.now()))
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.RankAssignmentSuggestions(candidates, history, ticket.AssigneeID, domain.MaxAssignmentSuggestions), nil
+}
+
+// assignees loads the organization's assignable users matching the query
+// and team of filter with their open tickets, and its open ticket limit.
+func (s *AssigneeService) assignees(ctx context.Context, orgID uuid.UUID, filter domain.AssigneeFilter) ([]domain.Assignee, *domain.AgentCapacity, error) {
+	users, err := s.userRepo.ListAssignableUsers(ctx, orgID, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	workload, err := s.analyticsRepo.GetWorkload(ctx, orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+	capacity, err := s.settingsRepo.GetAgentCapacity(ctx, orgID)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return s.userRepo.ListAssignableUsers(ctx, orgID)
+	return domain.NewAssignees(users, workload, capacity), capacity, nil
+}
+
+func (s *AssigneeService) requireAssign(ctx context.Context, actorID uuid.UUID) error {
+	canAssign, err := s.authzSvc.Can(ctx, actorID, "tickets:assign")
+	if err != nil {
+		return err
+	}
+	if !canAssign {
+		return apperrors.ErrForbidden
+	}
+	return nil
 }
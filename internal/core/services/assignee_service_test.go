@@ -0,0 +1,123 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssigneeService_ListAssignableUsers(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	orgID := uuid.New()
+	teamID := uuid.New()
+	busy := &domain.User{ID: uuid.New(), FullName: "Bea Busy"}
+	free := &domain.User{ID: uuid.New(), FullName: "Finn Free"}
+	limit := 3
+	available := true
+	filter := domain.AssigneeFilter{Query: "b", TeamID: &teamID, Available: &available, Limit: 25}
+
+	authz := mocks.NewMockAuthorizationService()
+	users := mocks.NewMockUserRepository()
+	analytics := mocks.NewMockAnalyticsRepository()
+	settings := mocks.NewMockOrgSettingsRepository()
+	authz.On("Can", ctx, actorID, "tickets:assign").Return(true, nil)
+	users.On("ListAssignableUsers", ctx, orgID, filter).Return([]*domain.User{busy, free}, nil)
+	analytics.On("GetWorkload", ctx, orgID).Return([]domain.WorkloadItem{{AssigneeID: &busy.ID, Count: 3}}, nil)
+	settings.On("GetAgentCapacity", ctx, orgID).
+		Return(&domain.AgentCapacity{OrganizationID: orgID, MaxOpenTickets: &limit, Enforcement: domain.CapacityWarn}, nil)
+	svc := services.NewAssigneeService(users, mocks.NewMockTicketRepository(), analytics, settings, authz)
+
+	assignees, total, err := svc.ListAssignableUsers(ctx, actorID, orgID, filter)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total.Count)
+	require.Len(t, assignees, 1, "agents at the limit are not available")
+	assert.Equal(t, free.ID, assignees[0].User.ID)
+	users.AssertExpectations(t)
+}
+
+func TestAssigneeService_SuggestAssignees(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	orgID := uuid.New()
+	teamID := uuid.New()
+	expert := &domain.User{ID: uuid.New(), FullName: "Erin Expert"}
+	idle := &domain.User{ID: uuid.New(), FullName: "Ian Idle"}
+	current := &domain.User{ID: uuid.New(), FullName: "Cora Current"}
+	ticket := &domain.Ticket{ID: 7, OrganizationID: orgID, Tags: []string{"vpn"}, TeamID: &teamID, AssigneeID: &current.ID}
+
+	type deps struct {
+		analytics *mocks.MockAnalyticsRepository
+	}
+	newService := func(capacity *domain.AgentCapacity) (ports.AssigneeService, deps) {
+		authz := mocks.NewMockAuthorizationService()
+		users := mocks.NewMockUserRepository()
+		tickets := mocks.NewMockTicketRepository()
+		analytics := mocks.NewMockAnalyticsRepository()
+		settings := mocks.NewMockOrgSettingsRepository()
+		authz.On("Can", ctx, actorID, "tickets:assign").Return(true, nil)
+		tickets.On("GetByID", ctx, orgID, ticket.ID).Return(ticket, nil)
+		users.On("ListAssignableUsers", ctx, orgID, domain.AssigneeFilter{}).Return([]*domain.User{current, expert, idle}, nil)
+		analytics.On("ListAgentTicketHistory", ctx, orgID, mock.MatchedBy(func(q domain.SimilarTicketsQuery) bool {
+			return q.TicketID == ticket.ID && q.TeamID == &teamID && assert.ObjectsAreEqual([]string{"vpn"}, q.Tags)
+		})).Return([]domain.AgentTicketHistory{
+			{AgentID: expert.ID, SharedTagTickets: 4, SameTeamTickets: 2},
+			{AgentID: current.ID, SharedTagTickets: 9},
+		}, nil)
+		analytics.On("GetWorkload", ctx, orgID).Return([]domain.WorkloadItem{
+			{AssigneeID: &expert.ID, Count: 2},
+			{AssigneeID: nil, Count: 5},
+		}, nil)
+		settings.On("GetAgentCapacity", ctx, orgID).Return(capacity, nil)
+		return services.NewAssigneeService(users, tickets, analytics, settings, authz), deps{analytics: analytics}
+	}
+
+	t.Run("requires permission to assign", func(t *testing.T) {
+		authz := mocks.NewMockAuthorizationService()
+		tickets := mocks.NewMockTicketRepository()
+		authz.On("Can", ctx, actorID, "tickets:assign").Return(false, nil)
+		svc := services.NewAssigneeService(mocks.NewMockUserRepository(), tickets, mocks.NewMockAnalyticsRepository(),
+			mocks.NewMockOrgSettingsRepository(), authz)
+
+		_, err := svc.SuggestAssignees(ctx, actorID, orgID, ticket.ID)
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		tickets.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ranks agents by similar tickets and workload", func(t *testing.T) {
+		svc, d := newService(domain.DefaultAgentCapacity(orgID))
+
+		suggestions, err := svc.SuggestAssignees(ctx, actorID, orgID, ticket.ID)
+
+		require.NoError(t, err)
+		require.Len(t, suggestions, 2, "the current assignee is not suggested")
+		assert.Equal(t, expert.ID, suggestions[0].Assignee.User.ID)
+		assert.Equal(t, int64(4), suggestions[0].SharedTagTickets)
+		assert.Equal(t, int64(2), suggestions[0].Assignee.OpenTickets)
+		assert.Equal(t, idle.ID, suggestions[1].Assignee.User.ID)
+		assert.Equal(t, int64(0), suggestions[1].Assignee.OpenTickets)
+		d.analytics.AssertExpectations(t)
+	})
+
+	t.Run("leaves out agents at a blocking limit", func(t *testing.T) {
+		limit := 2
+		svc, _ := newService(&domain.AgentCapacity{OrganizationID: orgID, MaxOpenTickets: &limit, Enforcement: domain.CapacityBlock})
+
+		suggestions, err := svc.SuggestAssignees(ctx, actorID, orgID, ticket.ID)
+
+		require.NoError(t, err)
+		require.Len(t, suggestions, 1)
+		assert.Equal(t, idle.ID, suggestions[0].Assignee.User.ID)
+	})
+}
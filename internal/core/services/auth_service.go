@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt" // Added for error wrapping
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,6 +17,8 @@ import (
 type AuthService struct {
 	userRepo     ports.UserRepository
 	authRepo     ports.AuthorizationRepository // <--- ADDED: Dependency for role assignment
+	settingsRepo ports.OrgSettingsRepository
+	quotaSvc     ports.QuotaService
 	defaultOrgID uuid.UUID
 }
 
@@ -26,11 +29,15 @@ var _ ports.AuthService = (*AuthService)(nil)
 func NewAuthService(
 	userRepo ports.UserRepository,
 	authRepo ports.AuthorizationRepository, // <--- ADDED: Inject dependency
+	settingsRepo ports.OrgSettingsRepository,
+	quotaSvc ports.QuotaService,
 	defaultOrgID uuid.UUID,
 ) ports.AuthService {
 	return &AuthService{
 		userRepo:     userRepo,
 		authRepo:     authRepo, // <--- ADDED: Assign dependency
+		settingsRepo: settingsRepo,
+		quotaSvc:     quotaSvc,
 		defaultOrgID: defaultOrgID,
 	}
 }
@@ -48,8 +55,20 @@ func (s *AuthService) Register(ctx context.Context, fullName, email, password, r
 		return nil, err
 	}
 
-	// 2. Check if user already exists
-	_, err := s.userRepo.GetByEmail(ctx, email)
+	// 2. Determine organization ID
+	targetOrgID := orgID
+	if targetOrgID == uuid.Nil {
+		targetOrgID = s.defaultOrgID
+	}
+
+	// 3. Enforce the organization's email domain allowlist, before revealing
+	// whether the address is already registered
+	if err := s.checkEmailDomain(ctx, targetOrgID, email); err != nil {
+		return nil, err
+	}
+
+	// 4. Check if user already exists
+	_, err := s.userRepo.GetByEmail(ctx, targetOrgID, email)
 	if err == nil {
 		return nil, apperrors.ErrUserExists
 	}
@@ -57,34 +76,33 @@ func (s *AuthService) Register(ctx context.Context, fullName, email, password, r
 		return nil, err // An actual DB error occurred
 	}
 
-	// 3. Determine organization ID
-	targetOrgID := orgID
-	if targetOrgID == uuid.Nil {
-		targetOrgID = s.defaultOrgID
+	// 5. Enforce the organization's user quota
+	if err := s.quotaSvc.CheckUsers(ctx, targetOrgID, 1); err != nil {
+		return nil, err
 	}
 
-	// 4. Determine if this is the first user
+	// 6. Determine if this is the first user
 	userCount, err := s.userRepo.CountUsers(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// 5. Create user domain object
+	// 7. Create user domain object
 	user, err := domain.NewUser(params, targetOrgID)
 	if err != nil {
 		return nil, err
 	}
 
-	// 6. Persist the user
+	// 8. Persist the user
 	createdUser, err := s.userRepo.Create(ctx, user)
 	if err != nil {
 		return nil, err
 	}
 
-	// 7. Assign Role
-	// First user is auto-promoted to admin.
+	// 9. Assign Role
+	// First user is auto-promoted to admin, unless they are a super-admin.
 	assignRole := "customer"
-	if userCount == 0 {
+	if userCount == 0 && role != "superadmin" {
 		assignRole = "admin"
 	} else if role != "" {
 		assignRole = role
@@ -100,8 +118,27 @@ func (s *AuthService) Register(ctx context.Context, fullName, email, password, r
 	return createdUser, nil
 }
 
-// Login authenticates a user with email and password
-func (s *AuthService) Login(ctx context.Context, email, password string) (*domain.User, error) {
+// checkEmailDomain rejects emails outside the organization's registration
+// allowlist, if it has one.
+func (s *AuthService) checkEmailDomain(ctx context.Context, orgID uuid.UUID, email string) error {
+	domains, err := s.settingsRepo.GetRegistrationDomains(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if domain.EmailDomainAllowed(email, domains) {
+		return nil
+	}
+
+	errs := apperrors.NewValidationErrors()
+	errs.Add("email", "Registration is restricted to email addresses at "+strings.Join(domains, ", "))
+	return errs
+}
+
+// Login authenticates a user with email and password. Emails are unique
+// within an organization only, so a login naming no organization checks the
+// password against the accounts in every organization and asks the user to
+// choose when it matches several.
+func (s *AuthService) Login(ctx context.Context, orgID uuid.UUID, email, password string) (*domain.User, error) {
 	// Basic validation
 	if email == "" {
 		return nil, apperrors.ErrEmailRequired
@@ -110,20 +147,26 @@ func (s *AuthService) Login(ctx context.Context, email, password string) (*domai
 		return nil, apperrors.ErrPasswordRequired
 	}
 
-	// Find user by email
-	user, err := s.userRepo.GetByEmail(ctx, email)
+	// Find the accounts with the email
+	candidates, err := s.loginCandidates(ctx, orgID, email)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrUserNotFound) {
-			// Don't reveal whether email exists
-			return nil, apperrors.ErrInvalidCredentials
-		}
 		return nil, err
 	}
 
 	// Verify password
-	if !user.CheckPassword(password) {
+	var matches []*domain.User
+	for _, candidate := range candidates {
+		if candidate.CheckPassword(password) {
+			matches = append(matches, candidate)
+		}
+	}
+	if len(matches) == 0 {
 		return nil, apperrors.ErrInvalidCredentials
 	}
+	if len(matches) > 1 {
+		return nil, s.organizationChoice(ctx, matches)
+	}
+	user := matches[0]
 
 	if !user.IsActive {
 		return nil, apperrors.ErrUserInactive
@@ -137,3 +180,59 @@ func (s *AuthService) Login(ctx context.Context, email, password string) (*domai
 
 	return user, nil
 }
+
+// loginCandidates returns the accounts a login may be for: the one with the
+// email in orgID, or those in every organization when orgID is uuid.Nil.
+func (s *AuthService) loginCandidates(ctx context.Context, orgID uuid.UUID, email string) ([]*domain.User, error) {
+	if orgID == uuid.Nil {
+		return s.userRepo.ListByEmail(ctx, email)
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, orgID, email)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) {
+			// Don't reveal whether email exists
+			return nil, nil
+		}
+		return nil, err
+	}
+	return []*domain.User{user}, nil
+}
+
+// organizationChoice lists the organizations of the accounts a login
+// matched, for the user to pick one.
+func (s *AuthService) organizationChoice(ctx context.Context, users []*domain.User) error {
+	choice := &domain.OrganizationChoiceError{}
+	for _, user := range users {
+		org, err := s.settingsRepo.GetOrganization(ctx, user.OrganizationID)
+		if err != nil {
+			return err
+		}
+		choice.Organizations = append(choice.Organizations, org)
+	}
+	return choice
+}
+
+// ValidateSession checks that a token issued at issuedAt still belongs to an
+// active user whose tokens have not been revoked since, and returns that user.
+func (s *AuthService) ValidateSession(ctx context.Context, userID uuid.UUID, issuedAt time.Time) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) {
+			return nil, apperrors.ErrUnauthorized
+		}
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return nil, apperrors.ErrUserInactive
+	}
+
+	// Token timestamps only carry second precision, so a token issued in the
+	// same second as the revocation is treated as revoked.
+	if user.TokensRevokedAt != nil && !issuedAt.After(*user.TokensRevokedAt) {
+		return nil, apperrors.ErrUnauthorized
+	}
+
+	return user, nil
+}
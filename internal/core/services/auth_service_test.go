@@ -15,6 +15,14 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// openRegistration returns settings for an organization that lets any email
+// domain register.
+func openRegistration(orgID uuid.UUID) *mocks.MockOrgSettingsRepository {
+	repo := mocks.NewMockOrgSettingsRepository()
+	repo.On("GetRegistrationDomains", mock.Anything, orgID).Return([]string{}, nil).Maybe()
+	return repo
+}
+
 func TestAuthService_Register(t *testing.T) {
 	ctx := context.Background()
 	testOrgID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
@@ -22,10 +30,10 @@ func TestAuthService_Register(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockUserRepo := mocks.NewMockUserRepository()
 		mockAuthRepo := mocks.NewMockAuthorizationRepository()
-		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, testOrgID)
+		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, openRegistration(testOrgID), unlimitedQuota(), testOrgID)
 
 		// User doesn't exist yet
-		mockUserRepo.On("GetByEmail", ctx, "newuser@example.com").
+		mockUserRepo.On("GetByEmail", ctx, testOrgID, "newuser@example.com").
 			Return(nil, apperrors.ErrUserNotFound)
 
 		mockUserRepo.On("CountUsers", ctx).
@@ -57,13 +65,13 @@ func TestAuthService_Register(t *testing.T) {
 	t.Run("user already exists", func(t *testing.T) {
 		mockUserRepo := mocks.NewMockUserRepository()
 		mockAuthRepo := mocks.NewMockAuthorizationRepository()
-		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, testOrgID)
+		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, openRegistration(testOrgID), unlimitedQuota(), testOrgID)
 
 		existingUser := &domain.User{
 			ID:    uuid.New(),
 			Email: "existing@example.com",
 		}
-		mockUserRepo.On("GetByEmail", ctx, "existing@example.com").
+		mockUserRepo.On("GetByEmail", ctx, testOrgID, "existing@example.com").
 			Return(existingUser, nil)
 
 		user, err := svc.Register(ctx, "User", "existing@example.com", "Password123", "", uuid.Nil)
@@ -76,7 +84,7 @@ func TestAuthService_Register(t *testing.T) {
 	t.Run("weak password", func(t *testing.T) {
 		mockUserRepo := mocks.NewMockUserRepository()
 		mockAuthRepo := mocks.NewMockAuthorizationRepository()
-		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, testOrgID)
+		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, openRegistration(testOrgID), unlimitedQuota(), testOrgID)
 
 		user, err := svc.Register(ctx, "User", "user@example.com", "weak", "", uuid.Nil)
 
@@ -93,7 +101,7 @@ func TestAuthService_Register(t *testing.T) {
 	t.Run("invalid email", func(t *testing.T) {
 		mockUserRepo := mocks.NewMockUserRepository()
 		mockAuthRepo := mocks.NewMockAuthorizationRepository()
-		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, testOrgID)
+		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, openRegistration(testOrgID), unlimitedQuota(), testOrgID)
 
 		user, err := svc.Register(ctx, "User", "invalid-email", "Password123", "", uuid.Nil)
 
@@ -107,7 +115,7 @@ func TestAuthService_Register(t *testing.T) {
 	t.Run("empty full name", func(t *testing.T) {
 		mockUserRepo := mocks.NewMockUserRepository()
 		mockAuthRepo := mocks.NewMockAuthorizationRepository()
-		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, testOrgID)
+		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, openRegistration(testOrgID), unlimitedQuota(), testOrgID)
 
 		user, err := svc.Register(ctx, "", "user@example.com", "Password123", "", uuid.Nil)
 
@@ -121,9 +129,9 @@ func TestAuthService_Register(t *testing.T) {
 	t.Run("role already assigned", func(t *testing.T) {
 		mockUserRepo := mocks.NewMockUserRepository()
 		mockAuthRepo := mocks.NewMockAuthorizationRepository()
-		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, testOrgID)
+		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, openRegistration(testOrgID), unlimitedQuota(), testOrgID)
 
-		mockUserRepo.On("GetByEmail", ctx, "newuser@example.com").
+		mockUserRepo.On("GetByEmail", ctx, testOrgID, "newuser@example.com").
 			Return(nil, apperrors.ErrUserNotFound)
 		mockUserRepo.On("CountUsers", ctx).
 			Return(int64(1), nil)
@@ -147,9 +155,9 @@ func TestAuthService_Register(t *testing.T) {
 	t.Run("role not found", func(t *testing.T) {
 		mockUserRepo := mocks.NewMockUserRepository()
 		mockAuthRepo := mocks.NewMockAuthorizationRepository()
-		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, testOrgID)
+		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, openRegistration(testOrgID), unlimitedQuota(), testOrgID)
 
-		mockUserRepo.On("GetByEmail", ctx, "newuser@example.com").
+		mockUserRepo.On("GetByEmail", ctx, testOrgID, "newuser@example.com").
 			Return(nil, apperrors.ErrUserNotFound)
 		mockUserRepo.On("CountUsers", ctx).
 			Return(int64(1), nil)
@@ -169,6 +177,24 @@ func TestAuthService_Register(t *testing.T) {
 		assert.Nil(t, user)
 		assert.ErrorIs(t, err, apperrors.ErrRoleNotFound)
 	})
+
+	t.Run("email domain not allowed", func(t *testing.T) {
+		mockUserRepo := mocks.NewMockUserRepository()
+		mockAuthRepo := mocks.NewMockAuthorizationRepository()
+		settingsRepo := mocks.NewMockOrgSettingsRepository()
+		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, settingsRepo, unlimitedQuota(), testOrgID)
+
+		settingsRepo.On("GetRegistrationDomains", ctx, testOrgID).Return([]string{"acme.com"}, nil)
+
+		user, err := svc.Register(ctx, "New User", "newuser@example.com", "Password123", "", uuid.Nil)
+
+		assert.Nil(t, user)
+		var validationErr *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, validationErr.Errors, "email")
+		mockUserRepo.AssertNotCalled(t, "GetByEmail", mock.Anything, mock.Anything, mock.Anything)
+		mockUserRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
 }
 
 func TestAuthService_Login(t *testing.T) {
@@ -178,7 +204,7 @@ func TestAuthService_Login(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockUserRepo := mocks.NewMockUserRepository()
 		mockAuthRepo := mocks.NewMockAuthorizationRepository()
-		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, testOrgID)
+		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, openRegistration(testOrgID), unlimitedQuota(), testOrgID)
 
 		// Create a valid password hash
 		hash, _ := domain.HashPassword("Password123")
@@ -191,12 +217,12 @@ func TestAuthService_Login(t *testing.T) {
 			IsActive:       true,
 		}
 
-		mockUserRepo.On("GetByEmail", ctx, "user@example.com").
+		mockUserRepo.On("GetByEmail", ctx, testOrgID, "user@example.com").
 			Return(existingUser, nil)
 		mockUserRepo.On("UpdateLastActive", ctx, existingUser.ID, mock.AnythingOfType("time.Time")).
 			Return(nil)
 
-		user, err := svc.Login(ctx, "user@example.com", "Password123")
+		user, err := svc.Login(ctx, testOrgID, "user@example.com", "Password123")
 
 		require.NoError(t, err)
 		assert.Equal(t, existingUser.ID, user.ID)
@@ -206,12 +232,12 @@ func TestAuthService_Login(t *testing.T) {
 	t.Run("user not found", func(t *testing.T) {
 		mockUserRepo := mocks.NewMockUserRepository()
 		mockAuthRepo := mocks.NewMockAuthorizationRepository()
-		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, testOrgID)
+		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, openRegistration(testOrgID), unlimitedQuota(), testOrgID)
 
-		mockUserRepo.On("GetByEmail", ctx, "unknown@example.com").
+		mockUserRepo.On("GetByEmail", ctx, testOrgID, "unknown@example.com").
 			Return(nil, apperrors.ErrUserNotFound)
 
-		user, err := svc.Login(ctx, "unknown@example.com", "Password123")
+		user, err := svc.Login(ctx, testOrgID, "unknown@example.com", "Password123")
 
 		assert.Nil(t, user)
 		// Should return generic invalid credentials, not reveal user doesn't exist
@@ -221,7 +247,7 @@ func TestAuthService_Login(t *testing.T) {
 	t.Run("wrong password", func(t *testing.T) {
 		mockUserRepo := mocks.NewMockUserRepository()
 		mockAuthRepo := mocks.NewMockAuthorizationRepository()
-		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, testOrgID)
+		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, openRegistration(testOrgID), unlimitedQuota(), testOrgID)
 
 		hash, _ := domain.HashPassword("Password123")
 
@@ -232,21 +258,70 @@ func TestAuthService_Login(t *testing.T) {
 			IsActive:       true,
 		}
 
-		mockUserRepo.On("GetByEmail", ctx, "user@example.com").
+		mockUserRepo.On("GetByEmail", ctx, testOrgID, "user@example.com").
 			Return(existingUser, nil)
 
-		user, err := svc.Login(ctx, "user@example.com", "WrongPassword123")
+		user, err := svc.Login(ctx, testOrgID, "user@example.com", "WrongPassword123")
 
 		assert.Nil(t, user)
 		assert.ErrorIs(t, err, apperrors.ErrInvalidCredentials)
 	})
 
+	t.Run("without an organization logs into the account the password matches", func(t *testing.T) {
+		mockUserRepo := mocks.NewMockUserRepository()
+		mockAuthRepo := mocks.NewMockAuthorizationRepository()
+		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, openRegistration(testOrgID), unlimitedQuota(), testOrgID)
+
+		hash, _ := domain.HashPassword("Password123")
+		otherHash, _ := domain.HashPassword("OtherPassword123")
+		matching := &domain.User{ID: uuid.New(), OrganizationID: uuid.New(), Email: "user@example.com", HashedPassword: hash, IsActive: true}
+		other := &domain.User{ID: uuid.New(), OrganizationID: testOrgID, Email: "user@example.com", HashedPassword: otherHash, IsActive: true}
+
+		mockUserRepo.On("ListByEmail", ctx, "user@example.com").
+			Return([]*domain.User{other, matching}, nil)
+		mockUserRepo.On("UpdateLastActive", ctx, matching.ID, mock.AnythingOfType("time.Time")).
+			Return(nil)
+
+		user, err := svc.Login(ctx, uuid.Nil, "user@example.com", "Password123")
+
+		require.NoError(t, err)
+		assert.Equal(t, matching.ID, user.ID)
+		mockUserRepo.AssertNotCalled(t, "GetByEmail", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("without an organization asks to choose when several accounts match", func(t *testing.T) {
+		mockUserRepo := mocks.NewMockUserRepository()
+		mockAuthRepo := mocks.NewMockAuthorizationRepository()
+		settingsRepo := openRegistration(testOrgID)
+		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, settingsRepo, unlimitedQuota(), testOrgID)
+
+		hash, _ := domain.HashPassword("Password123")
+		acme := &domain.Organization{ID: uuid.New(), Name: "Acme"}
+		globex := &domain.Organization{ID: uuid.New(), Name: "Globex"}
+
+		mockUserRepo.On("ListByEmail", ctx, "user@example.com").Return([]*domain.User{
+			{ID: uuid.New(), OrganizationID: acme.ID, Email: "user@example.com", HashedPassword: hash, IsActive: true},
+			{ID: uuid.New(), OrganizationID: globex.ID, Email: "user@example.com", HashedPassword: hash, IsActive: true},
+		}, nil)
+		settingsRepo.On("GetOrganization", ctx, acme.ID).Return(acme, nil)
+		settingsRepo.On("GetOrganization", ctx, globex.ID).Return(globex, nil)
+
+		user, err := svc.Login(ctx, uuid.Nil, "user@example.com", "Password123")
+
+		assert.Nil(t, user)
+		assert.ErrorIs(t, err, apperrors.ErrOrganizationRequired)
+		var choice *domain.OrganizationChoiceError
+		require.ErrorAs(t, err, &choice)
+		assert.Equal(t, []*domain.Organization{acme, globex}, choice.Organizations)
+		mockUserRepo.AssertNotCalled(t, "UpdateLastActive", mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	t.Run("empty email", func(t *testing.T) {
 		mockUserRepo := mocks.NewMockUserRepository()
 		mockAuthRepo := mocks.NewMockAuthorizationRepository()
-		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, testOrgID)
+		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, openRegistration(testOrgID), unlimitedQuota(), testOrgID)
 
-		user, err := svc.Login(ctx, "", "Password123")
+		user, err := svc.Login(ctx, testOrgID, "", "Password123")
 
 		assert.Nil(t, user)
 		assert.ErrorIs(t, err, apperrors.ErrEmailRequired)
@@ -256,12 +331,86 @@ func TestAuthService_Login(t *testing.T) {
 	t.Run("empty password", func(t *testing.T) {
 		mockUserRepo := mocks.NewMockUserRepository()
 		mockAuthRepo := mocks.NewMockAuthorizationRepository()
-		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, testOrgID)
+		svc := services.NewAuthService(mockUserRepo, mockAuthRepo, openRegistration(testOrgID), unlimitedQuota(), testOrgID)
 
-		user, err := svc.Login(ctx, "user@example.com", "")
+		user, err := svc.Login(ctx, testOrgID, "user@example.com", "")
 
 		assert.Nil(t, user)
 		assert.ErrorIs(t, err, apperrors.ErrPasswordRequired)
 		mockUserRepo.AssertNotCalled(t, "GetByEmail")
 	})
 }
+
+func TestAuthService_ValidateSession(t *testing.T) {
+	ctx := context.Background()
+	testOrgID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	issuedAt := time.Now().Add(-time.Minute)
+
+	t.Run("active user with valid token", func(t *testing.T) {
+		mockUserRepo := mocks.NewMockUserRepository()
+		svc := services.NewAuthService(mockUserRepo, mocks.NewMockAuthorizationRepository(), openRegistration(testOrgID), unlimitedQuota(), testOrgID)
+
+		userID := uuid.New()
+		mockUserRepo.On("GetByID", ctx, userID).
+			Return(&domain.User{ID: userID, IsActive: true}, nil)
+
+		user, err := svc.ValidateSession(ctx, userID, issuedAt)
+
+		require.NoError(t, err)
+		assert.Equal(t, userID, user.ID)
+	})
+
+	t.Run("inactive user", func(t *testing.T) {
+		mockUserRepo := mocks.NewMockUserRepository()
+		svc := services.NewAuthService(mockUserRepo, mocks.NewMockAuthorizationRepository(), openRegistration(testOrgID), unlimitedQuota(), testOrgID)
+
+		userID := uuid.New()
+		mockUserRepo.On("GetByID", ctx, userID).
+			Return(&domain.User{ID: userID, IsActive: false}, nil)
+
+		_, err := svc.ValidateSession(ctx, userID, issuedAt)
+
+		assert.ErrorIs(t, err, apperrors.ErrUserInactive)
+	})
+
+	t.Run("token issued before revocation", func(t *testing.T) {
+		mockUserRepo := mocks.NewMockUserRepository()
+		svc := services.NewAuthService(mockUserRepo, mocks.NewMockAuthorizationRepository(), openRegistration(testOrgID), unlimitedQuota(), testOrgID)
+
+		userID := uuid.New()
+		revokedAt := time.Now()
+		mockUserRepo.On("GetByID", ctx, userID).
+			Return(&domain.User{ID: userID, IsActive: true, TokensRevokedAt: &revokedAt}, nil)
+
+		_, err := svc.ValidateSession(ctx, userID, issuedAt)
+
+		assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+	})
+
+	t.Run("token issued after revocation", func(t *testing.T) {
+		mockUserRepo := mocks.NewMockUserRepository()
+		svc := services.NewAuthService(mockUserRepo, mocks.NewMockAuthorizationRepository(), openRegistration(testOrgID), unlimitedQuota(), testOrgID)
+
+		userID := uuid.New()
+		revokedAt := issuedAt.Add(-time.Hour)
+		mockUserRepo.On("GetByID", ctx, userID).
+			Return(&domain.User{ID: userID, IsActive: true, TokensRevokedAt: &revokedAt}, nil)
+
+		_, err := svc.ValidateSession(ctx, userID, issuedAt)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		mockUserRepo := mocks.NewMockUserRepository()
+		svc := services.NewAuthService(mockUserRepo, mocks.NewMockAuthorizationRepository(), openRegistration(testOrgID), unlimitedQuota(), testOrgID)
+
+		userID := uuid.New()
+		mockUserRepo.On("GetByID", ctx, userID).
+			Return(nil, apperrors.ErrUserNotFound)
+
+		_, err := svc.ValidateSession(ctx, userID, issuedAt)
+
+		assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+	})
+}
@@ -33,6 +33,10 @@ func (f *fakeAuthRepo) SetUserRole(_ context.Context, _ uuid.UUID, _ string) err
 	return nil
 }
 
+func (f *fakeAuthRepo) GetUserRoles(_ context.Context, _ uuid.UUID) ([]string, error) {
+	return nil, nil
+}
+
 func (f *fakeAuthRepo) EnsureRBACDefaults(_ context.Context) error {
 	return nil
 }
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// CachingAuthorizationRepository wraps an AuthorizationRepository and caches
+// each user's permissions for a short time, since they are checked on every
+// request. A user's entry is dropped when their roles change; changes to
+// the permissions of a role are picked up when entries expire. Cache errors
+// fall back to the wrapped repository.
+type CachingAuthorizationRepository struct {
+	ports.AuthorizationRepository
+	cache  ports.Cache
+	ttl    time.Duration
+	logger *slog.Logger
+}
+
+var _ ports.AuthorizationRepository = (*CachingAuthorizationRepository)(nil)
+
+// NewCachingAuthorizationRepository creates a new caching authorization repository
+func NewCachingAuthorizationRepository(authRepo ports.AuthorizationRepository, cache ports.Cache, ttl time.Duration, logger *slog.Logger) ports.AuthorizationRepository {
+	return &CachingAuthorizationRepository{
+		AuthorizationRepository: authRepo,
+		cache:                   cache,
+		ttl:                     ttl,
+		logger:                  logger.With("component", "permission_cache"),
+	}
+}
+
+// GetUserPermissions returns the user's cached permissions, loading them
+// from the wrapped repository on a miss.
+func (r *CachingAuthorizationRepository) GetUserPermissions(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	key := permissionsCacheKey(userID)
+
+	data, found, err := r.cache.Get(ctx, key)
+	if err != nil {
+		r.logger.Warn("cache read failed", "error", err)
+	}
+	if found {
+		var permissions []string
+		if err := json.Unmarshal(data, &permissions); err == nil {
+			return permissions, nil
+		}
+	}
+
+	permissions, err := r.AuthorizationRepository.GetUserPermissions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Users without permissions are about to be given the default role, so
+	// they aren't cached
+	if len(permissions) > 0 {
+		data, err := json.Marshal(permissions)
+		if err == nil {
+			err = r.cache.Set(ctx, key, data, r.ttl)
+		}
+		if err != nil {
+			r.logger.Warn("cache write failed", "error", err)
+		}
+	}
+	return permissions, nil
+}
+
+// AssignRole assigns the role and drops the user's cached permissions.
+func (r *CachingAuthorizationRepository) AssignRole(ctx context.Context, userID uuid.UUID, roleName string) error {
+	if err := r.AuthorizationRepository.AssignRole(ctx, userID, roleName); err != nil {
+		return err
+	}
+	r.invalidate(ctx, userID)
+	return nil
+}
+
+// SetUserRole replaces the user's roles and drops their cached permissions.
+func (r *CachingAuthorizationRepository) SetUserRole(ctx context.Context, userID uuid.UUID, roleName string) error {
+	if err := r.AuthorizationRepository.SetUserRole(ctx, userID, roleName); err != nil {
+		return err
+	}
+	r.invalidate(ctx, userID)
+	return nil
+}
+
+func (r *CachingAuthorizationRepository) invalidate(ctx context.Context, userID uuid.UUID) {
+	if err := r.cache.Delete(ctx, permissionsCacheKey(userID)); err != nil {
+		r.logger.Warn("cache invalidation failed", "user_id", userID, "error", err)
+	}
+}
+
+func permissionsCacheKey(userID uuid.UUID) string {
+	return "perms:" + userID.String()
+}
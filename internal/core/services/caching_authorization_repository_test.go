@@ -0,0 +1,96 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachingAuthorizationRepository_GetUserPermissions(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	key := "perms:" + userID.String()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("serves cached permissions", func(t *testing.T) {
+		authRepo := mocks.NewMockAuthorizationRepository()
+		cache := mocks.NewMockCache()
+		cache.On("Get", ctx, key).Return([]byte(`["tickets:read"]`), true, nil)
+
+		repo := services.NewCachingAuthorizationRepository(authRepo, cache, time.Minute, logger)
+		permissions, err := repo.GetUserPermissions(ctx, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"tickets:read"}, permissions)
+		authRepo.AssertNotCalled(t, "GetUserPermissions", mock.Anything, mock.Anything)
+	})
+
+	t.Run("loads and caches on a miss", func(t *testing.T) {
+		authRepo := mocks.NewMockAuthorizationRepository()
+		cache := mocks.NewMockCache()
+		cache.On("Get", ctx, key).Return(nil, false, nil)
+		authRepo.On("GetUserPermissions", ctx, userID).Return([]string{"tickets:read"}, nil)
+		cache.On("Set", ctx, key, []byte(`["tickets:read"]`), time.Minute).Return(nil)
+
+		repo := services.NewCachingAuthorizationRepository(authRepo, cache, time.Minute, logger)
+		permissions, err := repo.GetUserPermissions(ctx, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"tickets:read"}, permissions)
+		cache.AssertExpectations(t)
+	})
+
+	t.Run("does not cache empty permissions", func(t *testing.T) {
+		authRepo := mocks.NewMockAuthorizationRepository()
+		cache := mocks.NewMockCache()
+		cache.On("Get", ctx, key).Return(nil, false, nil)
+		authRepo.On("GetUserPermissions", ctx, userID).Return([]string{}, nil)
+
+		repo := services.NewCachingAuthorizationRepository(authRepo, cache, time.Minute, logger)
+		permissions, err := repo.GetUserPermissions(ctx, userID)
+
+		require.NoError(t, err)
+		assert.Empty(t, permissions)
+		cache.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("falls back to the repository when the cache fails", func(t *testing.T) {
+		authRepo := mocks.NewMockAuthorizationRepository()
+		cache := mocks.NewMockCache()
+		cache.On("Get", ctx, key).Return(nil, false, errors.New("connection refused"))
+		authRepo.On("GetUserPermissions", ctx, userID).Return([]string{"tickets:read"}, nil)
+		cache.On("Set", ctx, key, mock.Anything, time.Minute).Return(errors.New("connection refused"))
+
+		repo := services.NewCachingAuthorizationRepository(authRepo, cache, time.Minute, logger)
+		permissions, err := repo.GetUserPermissions(ctx, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"tickets:read"}, permissions)
+	})
+}
+
+func TestCachingAuthorizationRepository_SetUserRoleInvalidates(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	authRepo := mocks.NewMockAuthorizationRepository()
+	cache := mocks.NewMockCache()
+	authRepo.On("SetUserRole", ctx, userID, "agent").Return(nil)
+	cache.On("Delete", ctx, []string{"perms:" + userID.String()}).Return(nil)
+
+	repo := services.NewCachingAuthorizationRepository(authRepo, cache, time.Minute, logger)
+	require.NoError(t, repo.SetUserRole(ctx, userID, "agent"))
+
+	cache.AssertExpectations(t)
+}
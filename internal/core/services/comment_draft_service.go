@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// CommentDraftService autosaves replies users are writing, so they can
+// pick them up again after a closed tab or a crashed browser. The
+// CommentService discards the draft once the reply is posted.
+type CommentDraftService struct {
+	draftRepo ports.CommentDraftRepository
+	ticketSvc ports.TicketService
+	authzSvc  ports.AuthorizationService
+	now       func() time.Time
+}
+
+var _ ports.CommentDraftService = (*CommentDraftService)(nil)
+
+// NewCommentDraftService creates a new CommentDraftService.
+func NewCommentDraftService(
+	draftRepo ports.CommentDraftRepository,
+	ticketSvc ports.TicketService,
+	authzSvc ports.AuthorizationService,
+) ports.CommentDraftService {
+	return &CommentDraftService{
+		draftRepo: draftRepo,
+		ticketSvc: ticketSvc,
+		authzSvc:  authzSvc,
+		now:       time.Now,
+	}
+}
+
+// SaveDraft stores the actor's draft reply to a ticket they can comment on,
+// replacing the one they saved before.
+func (s *CommentDraftService) SaveDraft(ctx context.Context, params ports.SaveCommentDraftParams) (*domain.CommentDraft, error) {
+	ticket, err := s.getTicket(ctx, params.TicketID, params.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	if ticket.IsArchived() {
+		return nil, apperrors.ErrTicketArchived
+	}
+
+	draft, err := domain.NewCommentDraft(ticket, params.ActorID, params.Body, s.now())
+	if err != nil {
+		return nil, err
+	}
+	return s.draftRepo.Upsert(ctx, draft)
+}
+
+// GetDraft returns the actor's draft reply to a ticket, or
+// ErrCommentDraftNotFound if they have none.
+func (s *CommentDraftService) GetDraft(ctx context.Context, ticketID int64, actorID uuid.UUID) (*domain.CommentDraft, error) {
+	if _, err := s.getTicket(ctx, ticketID, actorID); err != nil {
+		return nil, err
+	}
+	return s.draftRepo.Get(ctx, ticketID, actorID)
+}
+
+// DiscardDraft removes the actor's draft reply to a ticket. Discarding a
+// draft that doesn't exist succeeds.
+func (s *CommentDraftService) DiscardDraft(ctx context.Context, ticketID int64, actorID uuid.UUID) error {
+	if _, err := s.getTicket(ctx, ticketID, actorID); err != nil {
+		return err
+	}
+	return s.draftRepo.Delete(ctx, ticketID, actorID)
+}
+
+// getTicket checks that the actor may comment and can see this ticket.
+func (s *CommentDraftService) getTicket(ctx context.Context, ticketID int64, actorID uuid.UUID) (*domain.Ticket, error) {
+	allowed, err := s.authzSvc.Can(ctx, actorID, "comments:create")
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, apperrors.ErrForbidden
+	}
+	return s.ticketSvc.GetTicket(ctx, ticketID, actorID)
+}
@@ -0,0 +1,101 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentDraftService_SaveDraft(t *testing.T) {
+	ctx := context.Background()
+	agentID := uuid.New()
+	orgID := uuid.New()
+
+	newService := func() (ports.CommentDraftService, *mocks.MockCommentDraftRepository, *mocks.MockTicketService, *mocks.MockAuthorizationService) {
+		repo := mocks.NewMockCommentDraftRepository()
+		tickets := mocks.NewMockTicketService()
+		authz := mocks.NewMockAuthorizationService()
+		return services.NewCommentDraftService(repo, tickets, authz), repo, tickets, authz
+	}
+
+	t.Run("requires permission to comment", func(t *testing.T) {
+		svc, repo, _, authz := newService()
+		authz.On("Can", ctx, agentID, "comments:create").Return(false, nil)
+
+		_, err := svc.SaveDraft(ctx, ports.SaveCommentDraftParams{TicketID: 7, ActorID: agentID, Body: "Hi"})
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects bodies longer than a comment", func(t *testing.T) {
+		svc, repo, tickets, authz := newService()
+		authz.On("Can", ctx, agentID, "comments:create").Return(true, nil)
+		tickets.On("GetTicket", ctx, int64(7), agentID).Return(&domain.Ticket{ID: 7, OrganizationID: orgID}, nil)
+
+		_, err := svc.SaveDraft(ctx, ports.SaveCommentDraftParams{
+			TicketID: 7, ActorID: agentID, Body: strings.Repeat("x", domain.MaxCommentBodyLength+1),
+		})
+
+		var errs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Contains(t, errs.Errors, "body")
+		repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+	})
+
+	t.Run("archived tickets take no drafts", func(t *testing.T) {
+		svc, _, tickets, authz := newService()
+		archivedAt := time.Now()
+		authz.On("Can", ctx, agentID, "comments:create").Return(true, nil)
+		tickets.On("GetTicket", ctx, int64(7), agentID).Return(&domain.Ticket{ID: 7, ArchivedAt: &archivedAt}, nil)
+
+		_, err := svc.SaveDraft(ctx, ports.SaveCommentDraftParams{TicketID: 7, ActorID: agentID, Body: "Hi"})
+
+		assert.ErrorIs(t, err, apperrors.ErrTicketArchived)
+	})
+
+	t.Run("stores the actor's draft", func(t *testing.T) {
+		svc, repo, tickets, authz := newService()
+		authz.On("Can", ctx, agentID, "comments:create").Return(true, nil)
+		tickets.On("GetTicket", ctx, int64(7), agentID).Return(&domain.Ticket{ID: 7, OrganizationID: orgID}, nil)
+		repo.On("Upsert", ctx, mock.MatchedBy(func(d *domain.CommentDraft) bool {
+			return d.TicketID == 7 && d.UserID == agentID && d.OrganizationID == orgID && d.Body == "Half a reply"
+		})).Return(&domain.CommentDraft{TicketID: 7, UserID: agentID, Body: "Half a reply"}, nil)
+
+		draft, err := svc.SaveDraft(ctx, ports.SaveCommentDraftParams{TicketID: 7, ActorID: agentID, Body: "Half a reply"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Half a reply", draft.Body)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestCommentDraftService_GetDraft(t *testing.T) {
+	ctx := context.Background()
+	agentID := uuid.New()
+
+	t.Run("drafts of tickets the actor cannot see are not returned", func(t *testing.T) {
+		repo := mocks.NewMockCommentDraftRepository()
+		tickets := mocks.NewMockTicketService()
+		authz := mocks.NewMockAuthorizationService()
+		svc := services.NewCommentDraftService(repo, tickets, authz)
+		authz.On("Can", ctx, agentID, "comments:create").Return(true, nil)
+		tickets.On("GetTicket", ctx, int64(7), agentID).Return(nil, apperrors.ErrForbidden)
+
+		_, err := svc.GetDraft(ctx, 7, agentID)
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		repo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lorrc/service-desk-backend/internal/core/domain"
@@ -16,9 +17,15 @@ type CommentService struct {
 	commentRepo ports.CommentRepository
 	ticketSvc   ports.TicketService
 	authzSvc    ports.AuthorizationService
-	notifier    ports.Notifier
+	outbox      ports.NotificationOutboxRepository
 	eventRepo   ports.TicketEventRepository
+	snoozeRepo  ports.TicketSnoozeRepository
+	draftRepo   ports.CommentDraftRepository
+	sanitizer   ports.HTMLSanitizer
 	txManager   ports.TransactionManager
+	// batchWindow is how long comment notifications wait to be combined
+	batchWindow time.Duration
+	now         func() time.Time
 }
 
 // Ensure implementation matches the interface.
@@ -29,17 +36,26 @@ func NewCommentService(
 	commentRepo ports.CommentRepository,
 	ticketSvc ports.TicketService,
 	authzSvc ports.AuthorizationService,
-	notifier ports.Notifier,
+	outbox ports.NotificationOutboxRepository,
 	eventRepo ports.TicketEventRepository,
+	snoozeRepo ports.TicketSnoozeRepository,
+	draftRepo ports.CommentDraftRepository,
+	sanitizer ports.HTMLSanitizer,
 	txManager ports.TransactionManager,
+	batchWindow time.Duration,
 ) ports.CommentService {
 	return &CommentService{
 		commentRepo: commentRepo,
 		ticketSvc:   ticketSvc,
 		authzSvc:    authzSvc,
-		notifier:    notifier,
+		outbox:      outbox,
 		eventRepo:   eventRepo,
+		snoozeRepo:  snoozeRepo,
+		draftRepo:   draftRepo,
+		sanitizer:   sanitizer,
 		txManager:   txManager,
+		batchWindow: batchWindow,
+		now:         time.Now,
 	}
 }
 
@@ -76,6 +92,9 @@ func (s *CommentService) CreateComment(ctx context.Context, params ports.CreateC
 		// GetTicket already returns ErrForbidden if access is denied
 		return nil, err
 	}
+	if ticket.IsArchived() {
+		return nil, apperrors.ErrTicketArchived
+	}
 
 	// 3. Create the domain entity using the new params-based constructor.
 	commentParams := domain.CommentParams{
@@ -87,6 +106,7 @@ func (s *CommentService) CreateComment(ctx context.Context, params ports.CreateC
 	if err != nil {
 		return nil, err // e.g., validation error
 	}
+	comment.BodyHTML = s.sanitizer.Sanitize(comment.Body)
 
 	// 4. Persist the comment and event atomically.
 	var newComment *domain.Comment
@@ -96,19 +116,31 @@ func (s *CommentService) CreateComment(ctx context.Context, params ports.CreateC
 			return err
 		}
 
-		payload, err := marshalEventPayload(domain.NewCommentSnapshot(createdComment))
+		event, err := domain.NewCommentAddedEvent(createdComment, params.ActorID)
 		if err != nil {
 			return err
 		}
 
-		event := &domain.Event{
-			TicketID: createdComment.TicketID,
-			Type:     domain.EventCommentAdded,
-			Payload:  payload,
-			ActorID:  params.ActorID,
+		if _, err := s.eventRepo.Create(txCtx, event); err != nil {
+			return err
 		}
 
-		if _, err := s.eventRepo.Create(txCtx, event); err != nil {
+		// The reply the author was drafting has now been posted
+		if err := s.draftRepo.Delete(txCtx, createdComment.TicketID, params.ActorID); err != nil {
+			return err
+		}
+
+		// 5. Queue an email notification.
+		// We notify the requester *unless* they are the one who made the comment.
+		// A comment from anyone else is also a response to the requester.
+		if ticket.RequesterID != params.ActorID {
+			if err := s.commentRepo.MarkFirstResponse(txCtx, createdComment); err != nil {
+				return err
+			}
+			if err := s.enqueueCommentNotification(txCtx, ticket, createdComment); err != nil {
+				return err
+			}
+		} else if err := s.resurfaceSnoozed(txCtx, ticket, createdComment); err != nil {
 			return err
 		}
 
@@ -118,18 +150,73 @@ func (s *CommentService) CreateComment(ctx context.Context, params ports.CreateC
 		return nil, err
 	}
 
-	// 5. Send email notification (asynchronously)
-	// We notify the requester *unless* they are the one who made the comment.
-	if ticket.RequesterID != params.ActorID {
-		go s.notifier.Notify(context.Background(), ports.NotificationParams{
-			RecipientUserID: ticket.RequesterID,
-			Subject:         fmt.Sprintf("A new comment was added to your ticket: #%d", ticket.ID),
-			Message:         fmt.Sprintf("A new comment has been added to your ticket '%s'.", ticket.Title),
-			TicketID:        ticket.ID,
-		})
+	return newComment, nil
+}
+
+// enqueueCommentNotification queues the requester's comment notification.
+// Within the batch window, further comments on the same ticket are combined
+// with it so a burst of replies arrives as a single email.
+func (s *CommentService) enqueueCommentNotification(ctx context.Context, ticket *domain.Ticket, comment *domain.Comment) error {
+	notification := ports.NotificationParams{
+		RecipientUserID: ticket.RequesterID,
+		Type:            ports.NotificationCommentAdded,
+		Subject:         fmt.Sprintf("A new comment was added to your ticket: #%d", ticket.ID),
+		Message:         fmt.Sprintf("A new comment has been added to your ticket '%s'.", ticket.Title),
+		TicketID:        ticket.ID,
+		Data: map[string]string{
+			"title":   ticket.Title,
+			"comment": comment.Body,
+		},
 	}
 
-	return newComment, nil
+	if s.batchWindow <= 0 {
+		return s.outbox.Enqueue(ctx, notification)
+	}
+
+	batchKey := fmt.Sprintf("%s:%s:%d", ports.NotificationCommentAdded, ticket.RequesterID, ticket.ID)
+	return s.outbox.EnqueueBatched(ctx, batchKey, notification, s.batchWindow)
+}
+
+// resurfaceSnoozed ends the snooze of a ticket the requester replied to,
+// which is usually what the ticket was parked waiting for, so it returns
+// to the queue straight away. The assignee, or whoever snoozed the ticket
+// if it has none, is told of the reply.
+func (s *CommentService) resurfaceSnoozed(ctx context.Context, ticket *domain.Ticket, comment *domain.Comment) error {
+	snooze, err := s.snoozeRepo.GetByTicketID(ctx, ticket.ID)
+	if errors.Is(err, apperrors.ErrTicketNotSnoozed) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// A snooze that already ended is left to the SnoozeJob, which sends
+	// its reminder
+	if !snooze.IsActive(s.now()) {
+		return nil
+	}
+	if err := s.snoozeRepo.Delete(ctx, ticket.ID); err != nil {
+		return err
+	}
+
+	recipientID := snooze.SnoozedBy
+	if ticket.AssigneeID != nil {
+		recipientID = *ticket.AssigneeID
+	}
+	if recipientID == comment.AuthorID {
+		return nil
+	}
+
+	return s.outbox.Enqueue(ctx, ports.NotificationParams{
+		RecipientUserID: recipientID,
+		Type:            ports.NotificationRequesterReplied,
+		Subject:         fmt.Sprintf("The requester replied to a snoozed ticket: #%d", ticket.ID),
+		Message:         fmt.Sprintf("The requester replied to the snoozed ticket '%s', so it is back in the queue.", ticket.Title),
+		TicketID:        ticket.ID,
+		Data: map[string]string{
+			"title":   ticket.Title,
+			"comment": comment.Body,
+		},
+	})
 }
 
 // GetCommentsForTicket retrieves all comments for a specific ticket.
@@ -155,3 +242,52 @@ func (s *CommentService) GetCommentsForTicket(ctx context.Context, params ports.
 	// 3. Retrieve the comments.
 	return s.commentRepo.ListByTicketID(ctx, params.TicketID)
 }
+
+// GetLatestComments retrieves the most recent comment on each of the given
+// tickets, keyed by ticket ID. Tickets without comments are left out.
+func (s *CommentService) GetLatestComments(ctx context.Context, params ports.GetLatestCommentsParams) (map[int64]*domain.Comment, error) {
+	// 1. Check permission to read comments.
+	canRead, err := s.authzSvc.Can(ctx, params.ActorID, "comments:read")
+	if err != nil {
+		return nil, err
+	}
+	if !canRead {
+		return nil, apperrors.ErrForbidden
+	}
+
+	// 2. Check the actor can see every ticket. The tickets were fetched
+	// through the ticket service already, so this mirrors GetTicket's
+	// check instead of loading each ticket again: internal tickets, and
+	// those the actor neither owns nor is assigned, need read-all.
+	ticketIDs := make([]int64, 0, len(params.Tickets))
+	checkedReadAll := false
+	for _, ticket := range params.Tickets {
+		visible := !ticket.IsInternal() && (ticket.IsOwnedBy(params.ActorID) || ticket.IsAssignedTo(params.ActorID))
+		if !visible && !checkedReadAll {
+			canReadAll, err := s.authzSvc.Can(ctx, params.ActorID, "tickets:read:all")
+			if err != nil {
+				return nil, err
+			}
+			if !canReadAll {
+				return nil, apperrors.ErrForbidden
+			}
+			checkedReadAll = true
+		}
+		ticketIDs = append(ticketIDs, ticket.ID)
+	}
+
+	latest := make(map[int64]*domain.Comment, len(ticketIDs))
+	if len(ticketIDs) == 0 {
+		return latest, nil
+	}
+
+	// 3. Retrieve the comments.
+	comments, err := s.commentRepo.ListLatestByTicketIDs(ctx, ticketIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, comment := range comments {
+		latest[comment.TicketID] = comment
+	}
+	return latest, nil
+}
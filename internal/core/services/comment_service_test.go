@@ -0,0 +1,269 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// txKey marks the context of a transaction started by recordingTransactionManager.
+type txKey struct{}
+
+// recordingTransactionManager runs fn with a context marked by txKey and
+// reports fn's error, so tests can check which calls ran in the transaction.
+type recordingTransactionManager struct{}
+
+func (recordingTransactionManager) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(context.WithValue(ctx, txKey{}, true))
+}
+
+// inTx matches contexts of a transaction started by recordingTransactionManager.
+var inTx = mock.MatchedBy(func(ctx context.Context) bool {
+	return ctx.Value(txKey{}) != nil
+})
+
+func TestCommentService_CreateComment(t *testing.T) {
+	ctx := context.Background()
+	requesterID := uuid.New()
+	agentID := uuid.New()
+	ticket := &domain.Ticket{ID: 1, Title: "Printer on fire", RequesterID: requesterID, AssigneeID: &agentID}
+
+	type deps struct {
+		comments  *mocks.MockCommentRepository
+		events    *mocks.MockTicketEventRepository
+		outbox    *mocks.MockNotificationOutboxRepository
+		snoozes   *mocks.MockTicketSnoozeRepository
+		drafts    *mocks.MockCommentDraftRepository
+		sanitizer *mocks.MockHTMLSanitizer
+	}
+	newService := func() (ports.CommentService, deps) {
+		d := deps{
+			comments:  mocks.NewMockCommentRepository(),
+			events:    mocks.NewMockTicketEventRepository(),
+			outbox:    mocks.NewMockNotificationOutboxRepository(),
+			snoozes:   mocks.NewMockTicketSnoozeRepository(),
+			drafts:    mocks.NewMockCommentDraftRepository(),
+			sanitizer: mocks.NewMockHTMLSanitizer(),
+		}
+		d.sanitizer.On("Sanitize", "On my way").Return("On my way").Maybe()
+		d.drafts.On("Delete", inTx, ticket.ID, mock.Anything).Return(nil).Maybe()
+		authz := mocks.NewMockAuthorizationService()
+		ticketSvc := mocks.NewMockTicketService()
+		for _, userID := range []uuid.UUID{agentID, requesterID} {
+			authz.On("Can", ctx, userID, "comments:create").Return(true, nil)
+			ticketSvc.On("GetTicket", ctx, ticket.ID, userID).Return(ticket, nil)
+		}
+
+		svc := services.NewCommentService(d.comments, ticketSvc, authz, d.outbox, d.events, d.snoozes, d.drafts, d.sanitizer, recordingTransactionManager{}, 0)
+		return svc, d
+	}
+	params := ports.CreateCommentParams{TicketID: ticket.ID, ActorID: agentID, Body: "On my way"}
+	created := &domain.Comment{ID: 10, TicketID: ticket.ID, AuthorID: agentID, Body: "On my way"}
+
+	t.Run("writes the comment, event and notification in one transaction", func(t *testing.T) {
+		svc, d := newService()
+		d.comments.On("Create", inTx, mock.Anything).Return(created, nil)
+		d.events.On("Create", inTx, mock.Anything).Return(&domain.Event{}, nil)
+		d.comments.On("MarkFirstResponse", inTx, created).Return(nil)
+		d.outbox.On("Enqueue", inTx, mock.MatchedBy(func(n ports.NotificationParams) bool {
+			return n.RecipientUserID == requesterID && n.TicketID == ticket.ID
+		})).Return(nil)
+
+		comment, err := svc.CreateComment(ctx, params)
+
+		require.NoError(t, err)
+		assert.Equal(t, created, comment)
+		d.comments.AssertExpectations(t)
+		d.events.AssertExpectations(t)
+		d.outbox.AssertExpectations(t)
+	})
+
+	t.Run("discards the author's draft of the reply", func(t *testing.T) {
+		svc, d := newService()
+		d.comments.On("Create", inTx, mock.Anything).Return(created, nil)
+		d.events.On("Create", inTx, mock.Anything).Return(&domain.Event{}, nil)
+		d.comments.On("MarkFirstResponse", inTx, created).Return(nil)
+		d.outbox.On("Enqueue", inTx, mock.Anything).Return(nil)
+
+		_, err := svc.CreateComment(ctx, params)
+
+		require.NoError(t, err)
+		d.drafts.AssertCalled(t, "Delete", inTx, ticket.ID, agentID)
+	})
+
+	t.Run("stores the sanitized body alongside the raw one", func(t *testing.T) {
+		svc, d := newService()
+		raw := `<p>Try <a href="javascript:alert(1)">this</a></p>`
+		d.sanitizer.On("Sanitize", raw).Return("<p>Try this</p>")
+		d.comments.On("Create", inTx, mock.MatchedBy(func(c *domain.Comment) bool {
+			return c.Body == raw && c.BodyHTML == "<p>Try this</p>"
+		})).Return(created, nil)
+		d.events.On("Create", inTx, mock.Anything).Return(&domain.Event{}, nil)
+		d.comments.On("MarkFirstResponse", inTx, created).Return(nil)
+		d.outbox.On("Enqueue", inTx, mock.Anything).Return(nil)
+
+		_, err := svc.CreateComment(ctx, ports.CreateCommentParams{TicketID: ticket.ID, ActorID: agentID, Body: raw})
+
+		require.NoError(t, err)
+		d.comments.AssertExpectations(t)
+	})
+
+	t.Run("a requester reply brings a snoozed ticket back and tells the assignee", func(t *testing.T) {
+		svc, d := newService()
+		reply := &domain.Comment{ID: 11, TicketID: ticket.ID, AuthorID: requesterID, Body: "Here is the log"}
+		d.sanitizer.On("Sanitize", reply.Body).Return(reply.Body)
+		d.comments.On("Create", inTx, mock.Anything).Return(reply, nil)
+		d.events.On("Create", inTx, mock.Anything).Return(&domain.Event{}, nil)
+		d.snoozes.On("GetByTicketID", inTx, ticket.ID).
+			Return(&domain.TicketSnooze{TicketID: ticket.ID, SnoozedBy: uuid.New(), Until: time.Now().Add(time.Hour)}, nil)
+		d.snoozes.On("Delete", inTx, ticket.ID).Return(nil)
+		d.outbox.On("Enqueue", inTx, mock.MatchedBy(func(n ports.NotificationParams) bool {
+			return n.RecipientUserID == agentID &&
+				n.Type == ports.NotificationRequesterReplied &&
+				n.Data["comment"] == "Here is the log"
+		})).Return(nil)
+
+		_, err := svc.CreateComment(ctx, ports.CreateCommentParams{TicketID: ticket.ID, ActorID: requesterID, Body: "Here is the log"})
+
+		require.NoError(t, err)
+		d.snoozes.AssertExpectations(t)
+		d.outbox.AssertExpectations(t)
+		d.comments.AssertNotCalled(t, "MarkFirstResponse", mock.Anything, mock.Anything)
+	})
+
+	t.Run("a requester reply to a ticket in the queue notifies no one", func(t *testing.T) {
+		svc, d := newService()
+		reply := &domain.Comment{ID: 11, TicketID: ticket.ID, AuthorID: requesterID, Body: "Any news?"}
+		d.sanitizer.On("Sanitize", reply.Body).Return(reply.Body)
+		d.comments.On("Create", inTx, mock.Anything).Return(reply, nil)
+		d.events.On("Create", inTx, mock.Anything).Return(&domain.Event{}, nil)
+		d.snoozes.On("GetByTicketID", inTx, ticket.ID).Return(nil, apperrors.ErrTicketNotSnoozed)
+
+		_, err := svc.CreateComment(ctx, ports.CreateCommentParams{TicketID: ticket.ID, ActorID: requesterID, Body: "Any news?"})
+
+		require.NoError(t, err)
+		d.snoozes.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+		d.outbox.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails without the comment when the notification cannot be queued", func(t *testing.T) {
+		svc, d := newService()
+		d.comments.On("Create", inTx, mock.Anything).Return(created, nil)
+		d.events.On("Create", inTx, mock.Anything).Return(&domain.Event{}, nil)
+		d.comments.On("MarkFirstResponse", inTx, created).Return(nil)
+		d.outbox.On("Enqueue", inTx, mock.Anything).Return(errors.New("db down"))
+
+		comment, err := svc.CreateComment(ctx, params)
+
+		require.Error(t, err)
+		assert.Nil(t, comment)
+	})
+}
+
+func TestCommentService_GetLatestComments(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	otherID := uuid.New()
+
+	ownTicket := &domain.Ticket{ID: 1, RequesterID: actorID}
+	otherTicket := &domain.Ticket{ID: 2, RequesterID: otherID}
+
+	newService := func() (ports.CommentService, *mocks.MockCommentRepository, *mocks.MockAuthorizationService) {
+		commentRepo := mocks.NewMockCommentRepository()
+		authz := mocks.NewMockAuthorizationService()
+		svc := services.NewCommentService(
+			commentRepo,
+			mocks.NewMockTicketService(),
+			authz,
+			mocks.NewMockNotificationOutboxRepository(),
+			mocks.NewMockTicketEventRepository(),
+			mocks.NewMockTicketSnoozeRepository(),
+			mocks.NewMockCommentDraftRepository(),
+			mocks.NewMockHTMLSanitizer(),
+			stubTransactionManager{},
+			0,
+		)
+		return svc, commentRepo, authz
+	}
+
+	t.Run("keys comments by ticket", func(t *testing.T) {
+		svc, commentRepo, authz := newService()
+		authz.On("Can", ctx, actorID, "comments:read").Return(true, nil)
+		authz.On("Can", ctx, actorID, "tickets:read:all").Return(true, nil).Once()
+		comment := &domain.Comment{ID: 10, TicketID: 2, AuthorID: otherID, Body: "latest"}
+		commentRepo.On("ListLatestByTicketIDs", ctx, []int64{1, 2}).Return([]*domain.Comment{comment}, nil)
+
+		latest, err := svc.GetLatestComments(ctx, ports.GetLatestCommentsParams{
+			Tickets: []*domain.Ticket{ownTicket, otherTicket},
+			ActorID: actorID,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[int64]*domain.Comment{2: comment}, latest)
+		authz.AssertExpectations(t)
+	})
+
+	t.Run("forbidden without comments:read", func(t *testing.T) {
+		svc, commentRepo, authz := newService()
+		authz.On("Can", ctx, actorID, "comments:read").Return(false, nil)
+
+		_, err := svc.GetLatestComments(ctx, ports.GetLatestCommentsParams{
+			Tickets: []*domain.Ticket{ownTicket},
+			ActorID: actorID,
+		})
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		commentRepo.AssertNotCalled(t, "ListLatestByTicketIDs")
+	})
+
+	t.Run("forbidden for another user's ticket", func(t *testing.T) {
+		svc, commentRepo, authz := newService()
+		authz.On("Can", ctx, actorID, "comments:read").Return(true, nil)
+		authz.On("Can", ctx, actorID, "tickets:read:all").Return(false, nil)
+
+		_, err := svc.GetLatestComments(ctx, ports.GetLatestCommentsParams{
+			Tickets: []*domain.Ticket{ownTicket, otherTicket},
+			ActorID: actorID,
+		})
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		commentRepo.AssertNotCalled(t, "ListLatestByTicketIDs")
+	})
+
+	t.Run("forbidden for the requester's own internal ticket", func(t *testing.T) {
+		svc, commentRepo, authz := newService()
+		authz.On("Can", ctx, actorID, "comments:read").Return(true, nil)
+		authz.On("Can", ctx, actorID, "tickets:read:all").Return(false, nil)
+		internalTicket := &domain.Ticket{ID: 3, RequesterID: actorID, Visibility: domain.VisibilityInternal}
+
+		_, err := svc.GetLatestComments(ctx, ports.GetLatestCommentsParams{
+			Tickets: []*domain.Ticket{internalTicket},
+			ActorID: actorID,
+		})
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		commentRepo.AssertNotCalled(t, "ListLatestByTicketIDs")
+	})
+
+	t.Run("no tickets", func(t *testing.T) {
+		svc, commentRepo, authz := newService()
+		authz.On("Can", ctx, actorID, "comments:read").Return(true, nil)
+
+		latest, err := svc.GetLatestComments(ctx, ports.GetLatestCommentsParams{ActorID: actorID})
+
+		require.NoError(t, err)
+		assert.Empty(t, latest)
+		commentRepo.AssertNotCalled(t, "ListLatestByTicketIDs")
+	})
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// ConfigService lets admins see the runtime configuration and reload it
+// without restarting the service. The same reload is triggered by SIGHUP.
+type ConfigService struct {
+	reloader  ports.ConfigReloader
+	authzSvc  ports.AuthorizationService
+	auditRepo ports.AuditLogRepository
+}
+
+var _ ports.ConfigService = (*ConfigService)(nil)
+
+// NewConfigService creates a new ConfigService.
+func NewConfigService(
+	reloader ports.ConfigReloader,
+	authzSvc ports.AuthorizationService,
+	auditRepo ports.AuditLogRepository,
+) ports.ConfigService {
+	return &ConfigService{
+		reloader:  reloader,
+		authzSvc:  authzSvc,
+		auditRepo: auditRepo,
+	}
+}
+
+// GetRuntimeConfig returns the runtime configuration currently in effect.
+func (s *ConfigService) GetRuntimeConfig(ctx context.Context, actorID uuid.UUID) (*domain.RuntimeConfig, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	return s.reloader.Current(), nil
+}
+
+// ReloadConfig re-reads the configuration and applies it. The reload is
+// recorded in the audit log of the admin's organization.
+func (s *ConfigService) ReloadConfig(ctx context.Context, actorID, orgID uuid.UUID) (*domain.RuntimeConfig, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	before := s.reloader.Current()
+	after, err := s.reloader.Reload()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", apperrors.ErrInvalidConfig, err)
+	}
+
+	entry, err := domain.NewAuditEntry(ctx, orgID, actorID, domain.AuditConfigReloaded, domain.AuditTargetConfig, "runtime", before.AuditSnapshot(), after.AuditSnapshot())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.auditRepo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+func (s *ConfigService) requireAdmin(ctx context.Context, actorID uuid.UUID) error {
+	allowed, err := s.authzSvc.Can(ctx, actorID, "admin:access")
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return apperrors.ErrForbidden
+	}
+	return nil
+}
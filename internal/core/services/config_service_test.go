@@ -0,0 +1,69 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigService_ReloadConfig(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	orgID := uuid.New()
+	before := &domain.RuntimeConfig{LogLevel: "info", CORSOrigins: []string{"*"}}
+	after := &domain.RuntimeConfig{LogLevel: "debug", CORSOrigins: []string{"https://desk.example.com"}}
+
+	t.Run("reloads and records the change", func(t *testing.T) {
+		reloader, authz, audit := mocks.NewMockConfigReloader(), mocks.NewMockAuthorizationService(), mocks.NewMockAuditLogRepository()
+		svc := services.NewConfigService(reloader, authz, audit)
+
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		reloader.On("Current").Return(before)
+		reloader.On("Reload").Return(after, nil)
+		audit.On("Create", ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+			return e.Action == domain.AuditConfigReloaded && e.TargetType == domain.AuditTargetConfig && e.OrganizationID == orgID
+		})).Return(nil)
+
+		cfg, err := svc.ReloadConfig(ctx, actorID, orgID)
+
+		require.NoError(t, err)
+		assert.Equal(t, "debug", cfg.LogLevel)
+		reloader.AssertExpectations(t)
+		audit.AssertExpectations(t)
+	})
+
+	t.Run("reports an invalid configuration", func(t *testing.T) {
+		reloader, authz, audit := mocks.NewMockConfigReloader(), mocks.NewMockAuthorizationService(), mocks.NewMockAuditLogRepository()
+		svc := services.NewConfigService(reloader, authz, audit)
+
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		reloader.On("Current").Return(before)
+		reloader.On("Reload").Return(nil, errors.New("LOG_LEVEL must be one of debug, info, warn, error"))
+
+		_, err := svc.ReloadConfig(ctx, actorID, orgID)
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidConfig)
+		audit.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("requires an admin", func(t *testing.T) {
+		reloader, authz, audit := mocks.NewMockConfigReloader(), mocks.NewMockAuthorizationService(), mocks.NewMockAuditLogRepository()
+		svc := services.NewConfigService(reloader, authz, audit)
+
+		authz.On("Can", ctx, actorID, "admin:access").Return(false, nil)
+
+		_, err := svc.ReloadConfig(ctx, actorID, orgID)
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		reloader.AssertNotCalled(t, "Reload")
+	})
+}
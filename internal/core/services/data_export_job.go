@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// DataExportConfig controls how personal data exports are built and kept.
+type DataExportConfig struct {
+	PollInterval time.Duration // How often to look for pending exports
+	Retention    time.Duration // How long a finished export can be downloaded
+}
+
+// DataExportJob builds pending data exports in the background and notifies
+// whoever requested each one when it is ready. Expired exports are deleted
+// so the personal data in them is not kept around.
+type DataExportJob struct {
+	exportRepo ports.DataExportRepository
+	outbox     ports.NotificationOutboxRepository
+	txManager  ports.TransactionManager
+	cfg        DataExportConfig
+	logger     *slog.Logger
+	now        func() time.Time
+}
+
+// NewDataExportJob creates a new data export job
+func NewDataExportJob(
+	exportRepo ports.DataExportRepository,
+	outbox ports.NotificationOutboxRepository,
+	txManager ports.TransactionManager,
+	cfg DataExportConfig,
+	logger *slog.Logger,
+) *DataExportJob {
+	return &DataExportJob{
+		exportRepo: exportRepo,
+		outbox:     outbox,
+		txManager:  txManager,
+		cfg:        cfg,
+		logger:     logger.With("component", "data_export"),
+		now:        time.Now,
+	}
+}
+
+// Run builds pending exports until ctx is cancelled
+func (j *DataExportJob) Run(ctx context.Context) {
+	pollCfg := DispatcherConfig{PollInterval: j.cfg.PollInterval, BatchSize: 1}
+	poll(ctx, pollCfg, func(ctx context.Context) (int, error) {
+		if err := j.DeleteExpired(ctx); err != nil {
+			return 0, err
+		}
+		return j.ProcessNext(ctx)
+	}, func(err error) {
+		j.logger.Error("failed to process data exports", "error", err)
+	})
+}
+
+// DeleteExpired removes exports past their retention
+func (j *DataExportJob) DeleteExpired(ctx context.Context) error {
+	n, err := j.exportRepo.DeleteExpired(ctx, j.now())
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		j.logger.Info("deleted expired data exports", "count", n)
+	}
+	return nil
+}
+
+// ProcessNext builds the oldest pending export and returns how many exports
+// it processed, 0 or 1. An export that cannot be built is marked failed.
+func (j *DataExportJob) ProcessNext(ctx context.Context) (int, error) {
+	var (
+		claimed  *domain.DataExport
+		buildErr error
+	)
+
+	err := j.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		export, err := j.exportRepo.ClaimPending(txCtx)
+		if err != nil || export == nil {
+			return err
+		}
+		claimed = export
+
+		payload, err := j.build(txCtx, export)
+		if err != nil {
+			buildErr = err
+			return err
+		}
+
+		now := j.now().UTC()
+		expiresAt := now.Add(j.cfg.Retention)
+		if err := j.exportRepo.MarkReady(txCtx, export.ID, payload, now, expiresAt); err != nil {
+			return err
+		}
+
+		return j.outbox.Enqueue(txCtx, readyNotification(export, expiresAt))
+	})
+
+	if buildErr != nil {
+		// The failed transaction released the export, so it is marked on
+		// its own; the requester can ask for a new one.
+		j.logger.Error("failed to build data export", "export_id", claimed.ID, "error", buildErr)
+		if err := j.exportRepo.MarkFailed(ctx, claimed.ID, j.now().UTC(), buildErr.Error()); err != nil {
+			return 0, err
+		}
+		return 1, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if claimed == nil {
+		return 0, nil
+	}
+	return 1, nil
+}
+
+// build collects the user's data and encodes it as JSON
+func (j *DataExportJob) build(ctx context.Context, export *domain.DataExport) ([]byte, error) {
+	data, err := j.exportRepo.CollectUserData(ctx, export.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("collect user data: %w", err)
+	}
+	data.ExportedAt = j.now().UTC()
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("encode user data: %w", err)
+	}
+	return payload, nil
+}
+
+// readyNotification tells the requester an export can be downloaded
+func readyNotification(export *domain.DataExport, expiresAt time.Time) ports.NotificationParams {
+	expires := expiresAt.Format(time.RFC3339)
+	return ports.NotificationParams{
+		RecipientUserID: export.RequestedBy,
+		Type:            ports.NotificationDataExportReady,
+		Subject:         "Your data export is ready",
+		Message:         "The data export you requested is ready to download until " + expires + ".",
+		Data: map[string]string{
+			"export_id":  export.ID.String(),
+			"user_id":    export.UserID.String(),
+			"expires_at": expires,
+		},
+	}
+}
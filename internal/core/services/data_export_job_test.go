@@ -0,0 +1,95 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataExportJob_ProcessNext(t *testing.T) {
+	ctx := context.Background()
+	newJob := func(repo *mocks.MockDataExportRepository, outbox *mocks.MockNotificationOutboxRepository) *services.DataExportJob {
+		return services.NewDataExportJob(repo, outbox, stubTransactionManager{}, services.DataExportConfig{
+			PollInterval: time.Second,
+			Retention:    24 * time.Hour,
+		}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	}
+
+	t.Run("does nothing when no export is pending", func(t *testing.T) {
+		repo := mocks.NewMockDataExportRepository()
+		repo.On("ClaimPending", ctx).Return(nil, nil)
+
+		n, err := newJob(repo, mocks.NewMockNotificationOutboxRepository()).ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, n)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("stores the export and notifies the requester", func(t *testing.T) {
+		export := domain.NewDataExport(uuid.New(), uuid.New(), uuid.New())
+		data := &domain.UserData{Profile: domain.UserDataProfile{ID: export.UserID, FullName: "Jane Doe"}}
+
+		repo := mocks.NewMockDataExportRepository()
+		outbox := mocks.NewMockNotificationOutboxRepository()
+		repo.On("ClaimPending", ctx).Return(export, nil)
+		repo.On("CollectUserData", ctx, export.UserID).Return(data, nil)
+
+		var payload []byte
+		var completedAt, expiresAt time.Time
+		repo.On("MarkReady", ctx, export.ID, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				payload = args.Get(2).([]byte)
+				completedAt = args.Get(3).(time.Time)
+				expiresAt = args.Get(4).(time.Time)
+			}).Return(nil)
+		outbox.On("Enqueue", ctx, mock.MatchedBy(func(p ports.NotificationParams) bool {
+			return p.RecipientUserID == export.RequestedBy &&
+				p.Type == ports.NotificationDataExportReady &&
+				p.Data["export_id"] == export.ID.String()
+		})).Return(nil)
+
+		n, err := newJob(repo, outbox).ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.Equal(t, 24*time.Hour, expiresAt.Sub(completedAt))
+
+		var decoded domain.UserData
+		require.NoError(t, json.Unmarshal(payload, &decoded))
+		assert.Equal(t, "Jane Doe", decoded.Profile.FullName)
+		assert.False(t, decoded.ExportedAt.IsZero())
+
+		repo.AssertExpectations(t)
+		outbox.AssertExpectations(t)
+	})
+
+	t.Run("marks the export failed when it cannot be built", func(t *testing.T) {
+		export := domain.NewDataExport(uuid.New(), uuid.New(), uuid.New())
+
+		repo := mocks.NewMockDataExportRepository()
+		outbox := mocks.NewMockNotificationOutboxRepository()
+		repo.On("ClaimPending", ctx).Return(export, nil)
+		repo.On("CollectUserData", ctx, export.UserID).Return(nil, errors.New("db down"))
+		repo.On("MarkFailed", ctx, export.ID, mock.Anything, mock.MatchedBy(func(msg string) bool {
+			return msg != ""
+		})).Return(nil)
+
+		n, err := newJob(repo, outbox).ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		repo.AssertExpectations(t)
+		outbox.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// DataExportService handles requests for personal data exports. The exports
+// themselves are built in the background by DataExportJob.
+type DataExportService struct {
+	exportRepo ports.DataExportRepository
+	userRepo   ports.UserRepository
+	authzSvc   ports.AuthorizationService
+	auditRepo  ports.AuditLogRepository
+	txManager  ports.TransactionManager
+}
+
+var _ ports.DataExportService = (*DataExportService)(nil)
+
+// NewDataExportService creates a new DataExportService.
+func NewDataExportService(
+	exportRepo ports.DataExportRepository,
+	userRepo ports.UserRepository,
+	authzSvc ports.AuthorizationService,
+	auditRepo ports.AuditLogRepository,
+	txManager ports.TransactionManager,
+) ports.DataExportService {
+	return &DataExportService{
+		exportRepo: exportRepo,
+		userRepo:   userRepo,
+		authzSvc:   authzSvc,
+		auditRepo:  auditRepo,
+		txManager:  txManager,
+	}
+}
+
+// RequestOwnExport queues an export of the user's own data.
+func (s *DataExportService) RequestOwnExport(ctx context.Context, userID uuid.UUID) (*domain.DataExport, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.request(ctx, user, userID)
+}
+
+// GetOwnExport returns the user's most recent export.
+func (s *DataExportService) GetOwnExport(ctx context.Context, userID uuid.UUID) (*domain.DataExport, error) {
+	return s.exportRepo.GetLatest(ctx, userID)
+}
+
+// RequestUserExport queues an export of another user's data on behalf of an
+// admin, who is notified when it is ready. The request is audited.
+func (s *DataExportService) RequestUserExport(ctx context.Context, actorID, orgID, userID uuid.UUID) (*domain.DataExport, error) {
+	user, err := s.getOrgUser(ctx, actorID, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var export *domain.DataExport
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		export, err = s.request(txCtx, user, actorID)
+		if err != nil {
+			return err
+		}
+
+		entry, err := domain.NewAuditEntry(txCtx, orgID, actorID, domain.AuditUserDataExported,
+			domain.AuditTargetUser, userID.String(), nil, map[string]any{"exportId": export.ID})
+		if err != nil {
+			return err
+		}
+		return s.auditRepo.Create(txCtx, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return export, nil
+}
+
+// GetUserExport returns the most recent export of a user in the admin's
+// organization.
+func (s *DataExportService) GetUserExport(ctx context.Context, actorID, orgID, userID uuid.UUID) (*domain.DataExport, error) {
+	if _, err := s.getOrgUser(ctx, actorID, orgID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.exportRepo.GetLatest(ctx, userID)
+}
+
+// request queues an export of user's data, unless one is already waiting to
+// be built, in which case that export is returned.
+func (s *DataExportService) request(ctx context.Context, user *domain.User, requestedBy uuid.UUID) (*domain.DataExport, error) {
+	latest, err := s.exportRepo.GetLatest(ctx, user.ID)
+	if err != nil && !errors.Is(err, apperrors.ErrDataExportNotFound) {
+		return nil, err
+	}
+	if latest != nil && latest.Status == domain.DataExportPending {
+		return latest, nil
+	}
+
+	export := domain.NewDataExport(user.OrganizationID, user.ID, requestedBy)
+	if err := s.exportRepo.Create(ctx, export); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// getOrgUser checks that actorID is an admin and returns userID if they
+// belong to the admin's organization.
+func (s *DataExportService) getOrgUser(ctx context.Context, actorID, orgID, userID uuid.UUID) (*domain.User, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.OrganizationID != orgID {
+		return nil, apperrors.ErrForbidden
+	}
+	return user, nil
+}
+
+func (s *DataExportService) requireAdmin(ctx context.Context, actorID uuid.UUID) error {
+	allowed, err := s.authzSvc.Can(ctx, actorID, "admin:access")
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return apperrors.ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,300 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// DemoDataOptions controls how much demo data is generated.
+type DemoDataOptions struct {
+	Tickets     int           // How many tickets to create
+	Agents      int           // How many agents work the tickets
+	Customers   int           // How many customers raise them
+	Period      time.Duration // How far back tickets are spread
+	EmailDomain string        // Domain of the demo accounts' email addresses
+	Password    string        // Password of every demo account
+	BatchSize   int           // How many tickets are written at a time
+	Seed        int64         // Seeds the generator; the same seed gives the same data
+}
+
+// DemoDataResult summarizes what was generated.
+type DemoDataResult struct {
+	Users    []*domain.User
+	Tickets  int
+	Comments int
+}
+
+// DemoDataGenerator fills an organization with believable data for
+// staging environments and analytics dashboards: agents and customers,
+// tickets spread over a period with varied statuses and priorities, and
+// comment threads between them. Running it again reuses the accounts and
+// adds more tickets.
+type DemoDataGenerator struct {
+	authService  ports.AuthService
+	userRepo     ports.UserRepository
+	ticketRepo   ports.TicketRepository
+	commentRepo  ports.CommentRepository
+	settingsRepo ports.OrgSettingsRepository
+	sanitizer    ports.HTMLSanitizer
+	now          func() time.Time
+}
+
+// NewDemoDataGenerator creates a new demo data generator
+func NewDemoDataGenerator(
+	authService ports.AuthService,
+	userRepo ports.UserRepository,
+	ticketRepo ports.TicketRepository,
+	commentRepo ports.CommentRepository,
+	settingsRepo ports.OrgSettingsRepository,
+	sanitizer ports.HTMLSanitizer,
+) *DemoDataGenerator {
+	return &DemoDataGenerator{
+		authService:  authService,
+		userRepo:     userRepo,
+		ticketRepo:   ticketRepo,
+		commentRepo:  commentRepo,
+		settingsRepo: settingsRepo,
+		sanitizer:    sanitizer,
+		now:          time.Now,
+	}
+}
+
+// demoTopic is a kind of request customers raise, with the tag it gets
+// and the replies an agent and the customer might write on it.
+type demoTopic struct {
+	tag           string
+	titles        []string
+	description   string
+	agentReplies  []string
+	customerNotes []string
+}
+
+var demoTopics = []demoTopic{
+	{
+		tag:           "login",
+		titles:        []string{"Cannot log in", "Password reset email never arrives", "Locked out after too many attempts"},
+		description:   "I have been unable to sign in since this morning. I tried resetting my password but it did not help.",
+		agentReplies:  []string{"I've reset your account, could you try signing in again?", "The reset emails were delayed by our mail provider, a new one is on its way."},
+		customerNotes: []string{"Still no luck, same error as before.", "That worked, thank you!"},
+	},
+	{
+		tag:           "billing",
+		titles:        []string{"Invoice shows the wrong amount", "Charged twice this month", "Need a copy of last year's invoices"},
+		description:   "Our latest invoice does not match what we agreed on. Can someone from billing take a look?",
+		agentReplies:  []string{"I've passed this on to billing, they will correct the invoice.", "The duplicate charge has been refunded, it can take a few days to show up."},
+		customerNotes: []string{"Any update on this?", "Received the corrected invoice, thanks."},
+	},
+	{
+		tag:           "reporting",
+		titles:        []string{"Export to CSV fails", "Report page is very slow", "Dashboard shows no data"},
+		description:   "The reports section stopped working for us. The page loads for a long time and then shows an error.",
+		agentReplies:  []string{"We could reproduce this and are working on a fix.", "A fix has been deployed, could you check again?"},
+		customerNotes: []string{"It happens for everyone on our team.", "Looks good now."},
+	},
+	{
+		tag:           "accounts",
+		titles:        []string{"Add a new user to our account", "Remove access for a former employee", "Change the account owner"},
+		description:   "We have had some changes in the team and need the account's users updated.",
+		agentReplies:  []string{"Done, the changes are in place.", "Could you confirm the email address of the new user?"},
+		customerNotes: []string{"It's the same address as in the original request.", "Perfect, thanks for the quick help."},
+	},
+	{
+		tag:           "integrations",
+		titles:        []string{"Integration stopped syncing", "Webhook deliveries failing", "API returns 500 errors"},
+		description:   "Our integration has not received any updates since yesterday. Nothing changed on our side.",
+		agentReplies:  []string{"We are seeing failed deliveries to your endpoint, is it reachable from the internet?", "There was an outage on our side, deliveries are being retried now."},
+		customerNotes: []string{"Our endpoint is up, we can reach it ourselves.", "Everything is syncing again."},
+	},
+}
+
+var (
+	demoFirstNames = []string{"Ava", "Ben", "Chloe", "Daniel", "Ella", "Finn", "Grace", "Hugo", "Isla", "Jack", "Leah", "Mason", "Nora", "Oscar", "Ruby", "Sam"}
+	demoLastNames  = []string{"Adams", "Baker", "Clarke", "Evans", "Foster", "Green", "Hughes", "Khan", "Lewis", "Moore", "Patel", "Reid", "Shaw", "Turner", "Walsh", "Young"}
+)
+
+// Generate creates the demo data in the organization.
+func (g *DemoDataGenerator) Generate(ctx context.Context, orgID uuid.UUID, opts DemoDataOptions) (*DemoDataResult, error) {
+	if opts.Agents < 1 || opts.Customers < 1 {
+		return nil, errors.New("demo data needs at least one agent and one customer")
+	}
+	if opts.BatchSize < 1 {
+		opts.BatchSize = 500
+	}
+	rng := rand.New(rand.NewSource(opts.Seed))
+	result := &DemoDataResult{}
+
+	agents, err := g.ensureUsers(ctx, orgID, "agent", opts.Agents, opts, rng)
+	if err != nil {
+		return nil, err
+	}
+	customers, err := g.ensureUsers(ctx, orgID, "customer", opts.Customers, opts, rng)
+	if err != nil {
+		return nil, err
+	}
+	result.Users = append(agents, customers...)
+
+	hours, err := businessHoursFor(ctx, g.settingsRepo, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := g.now().UTC()
+	for created := 0; created < opts.Tickets; {
+		n := min(opts.BatchSize, opts.Tickets-created)
+		tickets := make([]*domain.Ticket, n)
+		threads := make([][]*domain.Comment, n)
+		for i := range tickets {
+			tickets[i], threads[i] = demoTicket(rng, orgID, agents, customers, hours, opts.Period, now)
+			tickets[i].DescriptionHTML = g.sanitizer.Sanitize(tickets[i].Description)
+		}
+		if err := g.ticketRepo.CreateBatch(ctx, tickets); err != nil {
+			return nil, fmt.Errorf("create tickets: %w", err)
+		}
+
+		var comments []*domain.Comment
+		for i, thread := range threads {
+			for _, comment := range thread {
+				comment.TicketID = tickets[i].ID
+				comment.BodyHTML = g.sanitizer.Sanitize(comment.Body)
+			}
+			comments = append(comments, thread...)
+		}
+		if err := g.commentRepo.CreateBatch(ctx, comments); err != nil {
+			return nil, fmt.Errorf("create comments: %w", err)
+		}
+		for i, thread := range threads {
+			// The first comment not by the requester is the first response
+			for _, comment := range thread {
+				if comment.AuthorID != tickets[i].RequesterID {
+					if err := g.commentRepo.MarkFirstResponse(ctx, comment); err != nil {
+						return nil, fmt.Errorf("mark first response: %w", err)
+					}
+					break
+				}
+			}
+		}
+
+		created += n
+		result.Tickets += n
+		result.Comments += len(comments)
+	}
+	return result, nil
+}
+
+// ensureUsers registers count users with the role, reusing any that a
+// previous run created.
+func (g *DemoDataGenerator) ensureUsers(ctx context.Context, orgID uuid.UUID, role string, count int, opts DemoDataOptions, rng *rand.Rand) ([]*domain.User, error) {
+	users := make([]*domain.User, 0, count)
+	for i := 1; i <= count; i++ {
+		email := fmt.Sprintf("%s%d@%s", role, i, opts.EmailDomain)
+		name := demoFirstNames[rng.Intn(len(demoFirstNames))] + " " + demoLastNames[rng.Intn(len(demoLastNames))]
+
+		user, err := g.authService.Register(ctx, name, email, opts.Password, role, orgID)
+		if errors.Is(err, apperrors.ErrUserExists) {
+			user, err = g.userRepo.GetByEmail(ctx, orgID, email)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("demo user %s: %w", email, err)
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// demoTicket makes up a ticket raised at some point in the period before
+// now, with the comments written on it. Older tickets are more likely to
+// have been closed.
+func demoTicket(rng *rand.Rand, orgID uuid.UUID, agents, customers []*domain.User, hours *domain.BusinessHours, period time.Duration, now time.Time) (*domain.Ticket, []*domain.Comment) {
+	topic := demoTopics[rng.Intn(len(demoTopics))]
+	requester := customers[rng.Intn(len(customers))]
+	agent := agents[rng.Intn(len(agents))]
+
+	// Most tickets arrive during the working day
+	age := time.Duration(rng.Int63n(int64(period) + 1))
+	createdAt := now.Add(-age)
+	createdAt = time.Date(createdAt.Year(), createdAt.Month(), createdAt.Day(), 8+rng.Intn(10), rng.Intn(60), 0, 0, time.UTC)
+	if createdAt.After(now) {
+		createdAt = createdAt.AddDate(0, 0, -1)
+	}
+
+	ticket := &domain.Ticket{
+		OrganizationID: orgID,
+		Title:          topic.titles[rng.Intn(len(topic.titles))],
+		Description:    topic.description,
+		Priority:       demoPriority(rng),
+		RequesterID:    requester.ID,
+		CreatedAt:      createdAt,
+	}
+
+	closeChance := 0.2 + 0.7*float64(age)/float64(period+1)
+	switch roll := rng.Float64(); {
+	case roll < closeChance:
+		ticket.Status = domain.StatusClosed
+	case roll < closeChance+(1-closeChance)/2:
+		ticket.Status = domain.StatusInProgress
+	default:
+		ticket.Status = domain.StatusOpen
+	}
+	if ticket.Status != domain.StatusOpen || rng.Intn(3) == 0 {
+		ticket.AssigneeID = &agent.ID
+	}
+
+	// Replies alternate between the agent and the requester, hours apart
+	var comments []*domain.Comment
+	at := createdAt
+	if ticket.AssigneeID != nil {
+		for i := range 1 + rng.Intn(4) {
+			at = at.Add(time.Duration(30+rng.Intn(24*60)) * time.Minute)
+			if at.After(now) {
+				break
+			}
+			author, replies := agent, topic.agentReplies
+			if i%2 == 1 {
+				author, replies = requester, topic.customerNotes
+			}
+			comments = append(comments, &domain.Comment{
+				AuthorID:  author.ID,
+				Body:      replies[rng.Intn(len(replies))],
+				CreatedAt: at,
+			})
+		}
+	}
+
+	if ticket.Status == domain.StatusClosed {
+		closedAt := at.Add(time.Duration(10+rng.Intn(8*60)) * time.Minute)
+		if closedAt.After(now) {
+			closedAt = now
+		}
+		ticket.ClosedAt = &closedAt
+		at = closedAt
+	}
+	if at.After(createdAt) {
+		updatedAt := at
+		ticket.UpdatedAt = &updatedAt
+	}
+	ticket.ApplySLA(hours)
+	if rng.Intn(4) == 0 {
+		ticket.Tags = []string{topic.tag}
+	}
+	return ticket, comments
+}
+
+// demoPriority picks a priority, most tickets being of medium priority.
+func demoPriority(rng *rand.Rand) domain.TicketPriority {
+	switch n := rng.Intn(10); {
+	case n < 2:
+		return domain.PriorityHigh
+	case n < 7:
+		return domain.PriorityMedium
+	default:
+		return domain.PriorityLow
+	}
+}
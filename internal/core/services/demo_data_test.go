@@ -0,0 +1,131 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemoDataGenerator_Generate(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+
+	type deps struct {
+		auth      *mocks.MockAuthService
+		users     *mocks.MockUserRepository
+		tickets   *mocks.MockTicketRepository
+		comments  *mocks.MockCommentRepository
+		settings  *mocks.MockOrgSettingsRepository
+		sanitizer *mocks.MockHTMLSanitizer
+	}
+	setup := func() (deps, *services.DemoDataGenerator) {
+		d := deps{
+			auth:      mocks.NewMockAuthService(),
+			users:     mocks.NewMockUserRepository(),
+			tickets:   mocks.NewMockTicketRepository(),
+			comments:  mocks.NewMockCommentRepository(),
+			settings:  mocks.NewMockOrgSettingsRepository(),
+			sanitizer: mocks.NewMockHTMLSanitizer(),
+		}
+		for _, role := range []string{"agent", "customer"} {
+			d.auth.On("Register", ctx, mock.Anything, mock.Anything, "Password123!", role, orgID).
+				Return(&domain.User{ID: uuid.New(), OrganizationID: orgID}, nil)
+		}
+		d.settings.On("GetBusinessHours", ctx, orgID).Return(nil, apperrors.ErrNotFound)
+		d.sanitizer.On("Sanitize", mock.Anything).Return("<p>sanitized</p>").Maybe()
+		return d, services.NewDemoDataGenerator(d.auth, d.users, d.tickets, d.comments, d.settings, d.sanitizer)
+	}
+	opts := services.DemoDataOptions{
+		Tickets:     25,
+		Agents:      2,
+		Customers:   3,
+		Period:      30 * 24 * time.Hour,
+		EmailDomain: "demo.example.com",
+		Password:    "Password123!",
+		BatchSize:   10,
+		Seed:        1,
+	}
+
+	t.Run("creates tickets in batches with varied statuses and threads", func(t *testing.T) {
+		d, generator := setup()
+
+		var tickets []*domain.Ticket
+		nextID := int64(0)
+		d.tickets.On("CreateBatch", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			for _, ticket := range args.Get(1).([]*domain.Ticket) {
+				nextID++
+				ticket.ID = nextID
+				tickets = append(tickets, ticket)
+			}
+		}).Return(nil)
+		var comments []*domain.Comment
+		d.comments.On("CreateBatch", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			comments = append(comments, args.Get(1).([]*domain.Comment)...)
+		}).Return(nil)
+		d.comments.On("MarkFirstResponse", ctx, mock.Anything).Return(nil)
+
+		result, err := generator.Generate(ctx, orgID, opts)
+
+		require.NoError(t, err)
+		assert.Equal(t, 25, result.Tickets)
+		assert.Len(t, result.Users, 5)
+		assert.Len(t, tickets, 25)
+		assert.Equal(t, len(comments), result.Comments)
+		d.tickets.AssertNumberOfCalls(t, "CreateBatch", 3)
+		d.auth.AssertCalled(t, "Register", ctx, mock.Anything, "agent1@demo.example.com", "Password123!", "agent", orgID)
+		d.auth.AssertCalled(t, "Register", ctx, mock.Anything, "customer3@demo.example.com", "Password123!", "customer", orgID)
+
+		statuses := map[domain.TicketStatus]int{}
+		oldest := time.Now()
+		for _, ticket := range tickets {
+			statuses[ticket.Status]++
+			assert.Equal(t, orgID, ticket.OrganizationID)
+			assert.NotNil(t, ticket.DueAt)
+			assert.Equal(t, "<p>sanitized</p>", ticket.DescriptionHTML)
+			if ticket.CreatedAt.Before(oldest) {
+				oldest = ticket.CreatedAt
+			}
+			if ticket.Status == domain.StatusClosed {
+				require.NotNil(t, ticket.ClosedAt)
+				assert.NotNil(t, ticket.AssigneeID)
+				assert.NotNil(t, ticket.ResolutionTime)
+				assert.False(t, ticket.ClosedAt.Before(ticket.CreatedAt))
+			}
+		}
+		assert.Greater(t, statuses[domain.StatusClosed], 0)
+		assert.Greater(t, statuses[domain.StatusOpen]+statuses[domain.StatusInProgress], 0)
+		assert.True(t, oldest.Before(time.Now().Add(-7*24*time.Hour)), "tickets should be spread over the period")
+
+		for _, comment := range comments {
+			assert.NotZero(t, comment.TicketID)
+			assert.NotEmpty(t, comment.Body)
+			assert.Equal(t, "<p>sanitized</p>", comment.BodyHTML)
+		}
+	})
+
+	t.Run("reuses accounts from a previous run", func(t *testing.T) {
+		d, generator := setup()
+		d.auth.ExpectedCalls = nil
+		existing := &domain.User{ID: uuid.New(), OrganizationID: orgID}
+		d.auth.On("Register", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, orgID).Return(nil, apperrors.ErrUserExists)
+		d.users.On("GetByEmail", ctx, orgID, mock.Anything).Return(existing, nil)
+		d.tickets.On("CreateBatch", ctx, mock.Anything).Return(nil)
+		d.comments.On("CreateBatch", ctx, mock.Anything).Return(nil)
+		d.comments.On("MarkFirstResponse", ctx, mock.Anything).Return(nil)
+
+		result, err := generator.Generate(ctx, orgID, opts)
+
+		require.NoError(t, err)
+		assert.Len(t, result.Users, 5)
+		d.users.AssertNumberOfCalls(t, "GetByEmail", 5)
+	})
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// EmailPreferenceService manages the ticket emails users opt out of. The
+// OptOutNotifier applies the preferences when emails are sent.
+type EmailPreferenceService struct {
+	prefsRepo ports.EmailPreferenceRepository
+	ticketSvc ports.TicketService
+	// secret signs the opt-out links in ticket emails
+	secret []byte
+}
+
+var _ ports.EmailPreferenceService = (*EmailPreferenceService)(nil)
+
+// NewEmailPreferenceService creates a new EmailPreferenceService. secret
+// must be the one the OptOutNotifier signs links with.
+func NewEmailPreferenceService(
+	prefsRepo ports.EmailPreferenceRepository,
+	ticketSvc ports.TicketService,
+	secret []byte,
+) ports.EmailPreferenceService {
+	return &EmailPreferenceService{
+		prefsRepo: prefsRepo,
+		ticketSvc: ticketSvc,
+		secret:    secret,
+	}
+}
+
+// GetPreferences returns the ticket emails the user opted out of.
+func (s *EmailPreferenceService) GetPreferences(ctx context.Context, userID uuid.UUID) (*domain.EmailPreferences, error) {
+	return s.prefsRepo.Get(ctx, userID)
+}
+
+// SetUnsubscribed turns all of the user's ticket emails off, or back on.
+// Tickets they muted stay muted.
+func (s *EmailPreferenceService) SetUnsubscribed(ctx context.Context, userID uuid.UUID, unsubscribed bool) (*domain.EmailPreferences, error) {
+	if err := s.prefsRepo.SetUnsubscribed(ctx, userID, unsubscribed); err != nil {
+		return nil, err
+	}
+	return s.prefsRepo.Get(ctx, userID)
+}
+
+// MuteTicket stops the user's emails about a ticket they can see.
+func (s *EmailPreferenceService) MuteTicket(ctx context.Context, userID uuid.UUID, ticketID int64) error {
+	if _, err := s.ticketSvc.GetTicket(ctx, ticketID, userID); err != nil {
+		return err
+	}
+	return s.prefsRepo.MuteTicket(ctx, userID, ticketID)
+}
+
+// UnmuteTicket lets the user's emails about a ticket through again.
+func (s *EmailPreferenceService) UnmuteTicket(ctx context.Context, userID uuid.UUID, ticketID int64) error {
+	return s.prefsRepo.UnmuteTicket(ctx, userID, ticketID)
+}
+
+// MuteTicketWithToken applies a "mute this ticket" link from a ticket
+// email. Following a link again, or for a ticket deleted since, succeeds.
+func (s *EmailPreferenceService) MuteTicketWithToken(ctx context.Context, token string) (*domain.EmailOptOut, error) {
+	optOut, err := domain.ParseEmailOptOut(s.secret, token)
+	if err != nil {
+		return nil, err
+	}
+	if optOut.AllTickets() {
+		return nil, apperrors.ErrInvalidOptOutToken
+	}
+
+	if err := s.prefsRepo.MuteTicket(ctx, optOut.UserID, optOut.TicketID); err != nil {
+		return nil, err
+	}
+	return &optOut, nil
+}
+
+// UnsubscribeWithToken applies an "unsubscribe from all" link from a
+// ticket email.
+func (s *EmailPreferenceService) UnsubscribeWithToken(ctx context.Context, token string) (*domain.EmailOptOut, error) {
+	optOut, err := domain.ParseEmailOptOut(s.secret, token)
+	if err != nil {
+		return nil, err
+	}
+	if !optOut.AllTickets() {
+		return nil, apperrors.ErrInvalidOptOutToken
+	}
+
+	if err := s.prefsRepo.SetUnsubscribed(ctx, optOut.UserID, true); err != nil {
+		return nil, err
+	}
+	return &optOut, nil
+}
@@ -0,0 +1,107 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailPreferenceService_MuteTicket(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	newService := func() (ports.EmailPreferenceService, *mocks.MockEmailPreferenceRepository, *mocks.MockTicketService) {
+		repo := mocks.NewMockEmailPreferenceRepository()
+		tickets := mocks.NewMockTicketService()
+		return services.NewEmailPreferenceService(repo, tickets, []byte("s3cret")), repo, tickets
+	}
+
+	t.Run("mutes a ticket the user can see", func(t *testing.T) {
+		svc, repo, tickets := newService()
+		tickets.On("GetTicket", ctx, int64(7), userID).Return(&domain.Ticket{ID: 7}, nil)
+		repo.On("MuteTicket", ctx, userID, int64(7)).Return(nil)
+
+		require.NoError(t, svc.MuteTicket(ctx, userID, 7))
+
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("tickets the user cannot see are not muted", func(t *testing.T) {
+		svc, repo, tickets := newService()
+		tickets.On("GetTicket", ctx, int64(7), userID).Return(nil, apperrors.ErrTicketNotFound)
+
+		err := svc.MuteTicket(ctx, userID, 7)
+
+		assert.ErrorIs(t, err, apperrors.ErrTicketNotFound)
+		repo.AssertNotCalled(t, "MuteTicket", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestEmailPreferenceService_WithToken(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	secret := []byte("s3cret")
+	muteToken := domain.EmailOptOut{UserID: userID, TicketID: 7}.Sign(secret)
+	unsubscribeToken := domain.EmailOptOut{UserID: userID}.Sign(secret)
+
+	newService := func() (ports.EmailPreferenceService, *mocks.MockEmailPreferenceRepository) {
+		repo := mocks.NewMockEmailPreferenceRepository()
+		return services.NewEmailPreferenceService(repo, mocks.NewMockTicketService(), secret), repo
+	}
+
+	t.Run("mutes the ticket the link was sent about", func(t *testing.T) {
+		svc, repo := newService()
+		repo.On("MuteTicket", ctx, userID, int64(7)).Return(nil)
+
+		optOut, err := svc.MuteTicketWithToken(ctx, muteToken)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), optOut.TicketID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("unsubscribes the recipient of the link", func(t *testing.T) {
+		svc, repo := newService()
+		repo.On("SetUnsubscribed", ctx, userID, true).Return(nil)
+
+		optOut, err := svc.UnsubscribeWithToken(ctx, unsubscribeToken)
+
+		require.NoError(t, err)
+		assert.Equal(t, userID, optOut.UserID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("a mute link does not unsubscribe from everything", func(t *testing.T) {
+		svc, repo := newService()
+
+		_, err := svc.UnsubscribeWithToken(ctx, muteToken)
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidOptOutToken)
+		repo.AssertNotCalled(t, "SetUnsubscribed", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("an unsubscribe link does not mute a ticket", func(t *testing.T) {
+		svc, _ := newService()
+
+		_, err := svc.MuteTicketWithToken(ctx, unsubscribeToken)
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidOptOutToken)
+	})
+
+	t.Run("rejects tampered tokens", func(t *testing.T) {
+		svc, _ := newService()
+
+		_, err := svc.MuteTicketWithToken(ctx, muteToken+"x")
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidOptOutToken)
+	})
+}
@@ -0,0 +1,262 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// EscalationService lets admins configure when open tickets escalate and
+// what happens when they do, and preview rules against sample tickets.
+type EscalationService struct {
+	ruleRepo     ports.EscalationRuleRepository
+	teamRepo     ports.TeamRepository
+	userRepo     ports.UserRepository
+	settingsRepo ports.OrgSettingsRepository
+	authzSvc     ports.AuthorizationService
+	auditRepo    ports.AuditLogRepository
+	txManager    ports.TransactionManager
+	now          func() time.Time
+}
+
+var _ ports.EscalationService = (*EscalationService)(nil)
+
+// NewEscalationService creates a new EscalationService.
+func NewEscalationService(
+	ruleRepo ports.EscalationRuleRepository,
+	teamRepo ports.TeamRepository,
+	userRepo ports.UserRepository,
+	settingsRepo ports.OrgSettingsRepository,
+	authzSvc ports.AuthorizationService,
+	auditRepo ports.AuditLogRepository,
+	txManager ports.TransactionManager,
+) ports.EscalationService {
+	return &EscalationService{
+		ruleRepo:     ruleRepo,
+		teamRepo:     teamRepo,
+		userRepo:     userRepo,
+		settingsRepo: settingsRepo,
+		authzSvc:     authzSvc,
+		auditRepo:    auditRepo,
+		txManager:    txManager,
+		now:          time.Now,
+	}
+}
+
+// CreateRule creates an escalation rule.
+func (s *EscalationService) CreateRule(ctx context.Context, actorID, orgID uuid.UUID, params domain.EscalationRuleParams) (*domain.EscalationRule, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	rule, err := domain.NewEscalationRule(params, orgID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateTargets(ctx, orgID, rule.Actions); err != nil {
+		return nil, err
+	}
+
+	var created *domain.EscalationRule
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		created, err = s.ruleRepo.Create(txCtx, rule)
+		if err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditEscalationRuleCreated, created.ID, nil, created.AuditSnapshot())
+	}); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// ListRules returns the organization's escalation rules.
+func (s *EscalationService) ListRules(ctx context.Context, actorID, orgID uuid.UUID) ([]*domain.EscalationRule, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	return s.ruleRepo.ListByOrganization(ctx, orgID)
+}
+
+// UpdateRule replaces an escalation rule's configuration.
+func (s *EscalationService) UpdateRule(ctx context.Context, actorID, orgID, ruleID uuid.UUID, params domain.EscalationRuleParams) (*domain.EscalationRule, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.getRule(ctx, orgID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := rule.AuditSnapshot()
+	if err := rule.Update(params); err != nil {
+		return nil, err
+	}
+	if err := s.validateTargets(ctx, orgID, rule.Actions); err != nil {
+		return nil, err
+	}
+
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.ruleRepo.Update(txCtx, rule); err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditEscalationRuleUpdated, rule.ID, before, rule.AuditSnapshot())
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.ruleRepo.GetByID(ctx, ruleID)
+}
+
+// DeleteRule removes an escalation rule.
+func (s *EscalationService) DeleteRule(ctx context.Context, actorID, orgID, ruleID uuid.UUID) error {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return err
+	}
+
+	rule, err := s.getRule(ctx, orgID, ruleID)
+	if err != nil {
+		return err
+	}
+
+	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.ruleRepo.Delete(txCtx, ruleID); err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditEscalationRuleDeleted, rule.ID, rule.AuditSnapshot(), nil)
+	})
+}
+
+// DryRun evaluates escalation rules against a sample ticket. Nothing is
+// saved and no actions are taken.
+func (s *EscalationService) DryRun(ctx context.Context, actorID, orgID uuid.UUID, params ports.EscalationDryRunParams) ([]domain.EscalationEvaluation, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	var rules []*domain.EscalationRule
+	if params.Rule != nil {
+		draft, err := domain.NewEscalationRule(*params.Rule, orgID, actorID)
+		if err != nil {
+			return nil, err
+		}
+		rules = []*domain.EscalationRule{draft}
+	} else {
+		saved, err := s.ruleRepo.ListByOrganization(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range saved {
+			if rule.IsActive {
+				rules = append(rules, rule)
+			}
+		}
+	}
+
+	ticket := params.Ticket
+	if ticket.DueAt == nil {
+		hours, err := businessHoursFor(ctx, s.settingsRepo, orgID)
+		if err != nil {
+			return nil, err
+		}
+		ticket.ApplySLA(hours)
+	}
+
+	at := params.At
+	if at.IsZero() {
+		at = s.now()
+	}
+
+	evaluations := make([]domain.EscalationEvaluation, 0, len(rules))
+	for _, rule := range rules {
+		evaluations = append(evaluations, rule.Evaluate(&ticket, at))
+	}
+	return evaluations, nil
+}
+
+// validateTargets checks that every user and team an action targets
+// belongs to the organization. Users to assign to must be active agents.
+func (s *EscalationService) validateTargets(ctx context.Context, orgID uuid.UUID, actions []domain.EscalationAction) error {
+	errs := apperrors.NewValidationErrors()
+
+	for i, action := range actions {
+		switch {
+		case action.UserID != nil:
+			ok, err := s.isValidUserTarget(ctx, orgID, *action.UserID, action.Type == domain.EscalationAssign)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				errs.Add("actions", fmt.Sprintf("Action %d must target an active user in your organization", i+1))
+			}
+		case action.TeamID != nil:
+			team, err := s.teamRepo.GetByID(ctx, *action.TeamID)
+			if err != nil && !errors.Is(err, apperrors.ErrTeamNotFound) {
+				return err
+			}
+			if team == nil || team.OrganizationID != orgID {
+				errs.Add("actions", fmt.Sprintf("Action %d must target a team in your organization", i+1))
+			}
+		}
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+func (s *EscalationService) isValidUserTarget(ctx context.Context, orgID, userID uuid.UUID, mustClaim bool) (bool, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil && !errors.Is(err, apperrors.ErrUserNotFound) {
+		return false, err
+	}
+	if user == nil || user.OrganizationID != orgID || !user.IsActive {
+		return false, nil
+	}
+	if !mustClaim {
+		return true, nil
+	}
+	return s.authzSvc.Can(ctx, userID, "tickets:claim")
+}
+
+// getRule fetches an escalation rule, hiding rules of other organizations.
+func (s *EscalationService) getRule(ctx context.Context, orgID, ruleID uuid.UUID) (*domain.EscalationRule, error) {
+	rule, err := s.ruleRepo.GetByID(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if rule.OrganizationID != orgID {
+		return nil, apperrors.ErrEscalationRuleNotFound
+	}
+	return rule, nil
+}
+
+// recordAudit logs a change made to an escalation rule.
+func (s *EscalationService) recordAudit(ctx context.Context, orgID, actorID uuid.UUID, action domain.AuditAction, ruleID uuid.UUID, before, after any) error {
+	entry, err := domain.NewAuditEntry(ctx, orgID, actorID, action, domain.AuditTargetEscalationRule, ruleID.String(), before, after)
+	if err != nil {
+		return err
+	}
+	return s.auditRepo.Create(ctx, entry)
+}
+
+func (s *EscalationService) requireAdmin(ctx context.Context, actorID uuid.UUID) error {
+	allowed, err := s.authzSvc.Can(ctx, actorID, "admin:access")
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return apperrors.ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscalationService_CreateRule(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	params := func(action domain.EscalationActionType) domain.EscalationRuleParams {
+		return domain.EscalationRuleParams{
+			Name:             "Unassigned too long",
+			Trigger:          domain.EscalationUnassigned,
+			ThresholdMinutes: 60,
+			Actions:          []domain.EscalationAction{{Type: action, UserID: &userID}},
+			IsActive:         true,
+		}
+	}
+
+	newService := func() (ports.EscalationService, *mocks.MockEscalationRuleRepository, *mocks.MockUserRepository, *mocks.MockAuthorizationService, *mocks.MockAuditLogRepository) {
+		repo := mocks.NewMockEscalationRuleRepository()
+		users := mocks.NewMockUserRepository()
+		authz := mocks.NewMockAuthorizationService()
+		audit := mocks.NewMockAuditLogRepository()
+		svc := services.NewEscalationService(repo, mocks.NewMockTeamRepository(), users, defaultBusinessHours(), authz, audit, stubTransactionManager{})
+		return svc, repo, users, authz, audit
+	}
+
+	t.Run("requires admin access", func(t *testing.T) {
+		svc, repo, _, authz, _ := newService()
+		authz.On("Can", ctx, actorID, "admin:access").Return(false, nil)
+
+		_, err := svc.CreateRule(ctx, actorID, orgID, params(domain.EscalationNotify))
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects users of other organizations", func(t *testing.T) {
+		svc, repo, users, authz, _ := newService()
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		users.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, OrganizationID: uuid.New(), IsActive: true}, nil)
+
+		_, err := svc.CreateRule(ctx, actorID, orgID, params(domain.EscalationNotify))
+
+		var errs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Contains(t, errs.Errors, "actions")
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("assignee must be able to claim tickets", func(t *testing.T) {
+		svc, repo, users, authz, _ := newService()
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		users.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, OrganizationID: orgID, IsActive: true}, nil)
+		authz.On("Can", ctx, userID, "tickets:claim").Return(false, nil)
+
+		_, err := svc.CreateRule(ctx, actorID, orgID, params(domain.EscalationAssign))
+
+		var errs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Contains(t, errs.Errors, "actions")
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("creates the rule and audits it", func(t *testing.T) {
+		svc, repo, users, authz, audit := newService()
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		users.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, OrganizationID: orgID, IsActive: true}, nil)
+		repo.On("Create", ctx, mock.MatchedBy(func(rule *domain.EscalationRule) bool {
+			return rule.OrganizationID == orgID && rule.CreatedBy == actorID
+		})).Return(&domain.EscalationRule{ID: uuid.New(), OrganizationID: orgID, Name: "Unassigned too long"}, nil)
+		audit.On("Create", ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+			return e.Action == domain.AuditEscalationRuleCreated && e.TargetType == domain.AuditTargetEscalationRule
+		})).Return(nil)
+
+		rule, err := svc.CreateRule(ctx, actorID, orgID, params(domain.EscalationNotify))
+
+		require.NoError(t, err)
+		assert.Equal(t, "Unassigned too long", rule.Name)
+		audit.AssertExpectations(t)
+	})
+}
+
+func TestEscalationService_DryRun(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	orgID := uuid.New()
+	// Monday 09:00 UTC, the start of the default working day.
+	created := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	newService := func() (ports.EscalationService, *mocks.MockEscalationRuleRepository) {
+		repo := mocks.NewMockEscalationRuleRepository()
+		authz := mocks.NewMockAuthorizationService()
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		svc := services.NewEscalationService(repo, mocks.NewMockTeamRepository(), mocks.NewMockUserRepository(),
+			defaultBusinessHours(), authz, mocks.NewMockAuditLogRepository(), stubTransactionManager{})
+		return svc, repo
+	}
+
+	overdue := func(active bool) *domain.EscalationRule {
+		return &domain.EscalationRule{
+			ID:               uuid.New(),
+			OrganizationID:   orgID,
+			Name:             "Overdue",
+			Trigger:          domain.EscalationOverdue,
+			ThresholdMinutes: 60,
+			Priorities:       []domain.TicketPriority{},
+			Actions:          []domain.EscalationAction{{Type: domain.EscalationSetPriority, Priority: domain.PriorityHigh}},
+			IsActive:         active,
+		}
+	}
+
+	t.Run("evaluates active rules against the due date from business hours", func(t *testing.T) {
+		svc, repo := newService()
+		active := overdue(true)
+		repo.On("ListByOrganization", ctx, orgID).Return([]*domain.EscalationRule{active, overdue(false)}, nil)
+
+		// A HIGH ticket is due four working hours after creation.
+		evaluations, err := svc.DryRun(ctx, actorID, orgID, ports.EscalationDryRunParams{
+			Ticket: domain.Ticket{Status: domain.StatusOpen, Priority: domain.PriorityHigh, CreatedAt: created},
+			At:     created.Add(5 * time.Hour),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, evaluations, 1)
+		assert.Equal(t, active.ID, evaluations[0].Rule.ID)
+		assert.True(t, evaluations[0].Fires)
+		assert.Equal(t, created.Add(5*time.Hour), *evaluations[0].FiresAt)
+	})
+
+	t.Run("evaluates a draft rule without loading saved ones", func(t *testing.T) {
+		svc, repo := newService()
+
+		evaluations, err := svc.DryRun(ctx, actorID, orgID, ports.EscalationDryRunParams{
+			Rule: &domain.EscalationRuleParams{
+				Name:             "Idle",
+				Trigger:          domain.EscalationIdle,
+				ThresholdMinutes: 120,
+				Actions:          []domain.EscalationAction{{Type: domain.EscalationSetPriority, Priority: domain.PriorityHigh}},
+			},
+			Ticket: domain.Ticket{Status: domain.StatusOpen, Priority: domain.PriorityLow, CreatedAt: created},
+			At:     created.Add(time.Hour),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, evaluations, 1)
+		assert.False(t, evaluations[0].Fires)
+		repo.AssertNotCalled(t, "ListByOrganization", mock.Anything, mock.Anything)
+	})
+}
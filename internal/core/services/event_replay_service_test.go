@@ -0,0 +1,105 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventReplayService_ReplayEvents(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	orgID := uuid.New()
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	filter, err := domain.NewEventReplayFilter(nil, from, from.Add(24*time.Hour), 0)
+	require.NoError(t, err)
+
+	type deps struct {
+		events   *mocks.MockTicketEventRepository
+		webhooks *mocks.MockWebhookRepository
+		authz    *mocks.MockAuthorizationService
+		audit    *mocks.MockAuditLogRepository
+	}
+	newService := func() (ports.EventReplayService, deps) {
+		d := deps{
+			events:   mocks.NewMockTicketEventRepository(),
+			webhooks: mocks.NewMockWebhookRepository(),
+			authz:    mocks.NewMockAuthorizationService(),
+			audit:    mocks.NewMockAuditLogRepository(),
+		}
+		return services.NewEventReplayService(d.events, d.webhooks, d.authz, d.audit, stubTransactionManager{}), d
+	}
+	event := func(id int64) *domain.Event {
+		return &domain.Event{ID: id, TicketID: 7, Type: domain.EventStatusUpdated, Version: 1,
+			Payload: json.RawMessage(`{"status":"OPEN"}`), CreatedAt: from.Add(time.Hour)}
+	}
+
+	t.Run("requires admin access", func(t *testing.T) {
+		svc, d := newService()
+		d.authz.On("Can", ctx, actorID, "admin:access").Return(false, nil)
+
+		_, err := svc.ReplayEvents(ctx, actorID, orgID, filter)
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		d.events.AssertNotCalled(t, "ListForReplay", mock.Anything, mock.Anything)
+	})
+
+	t.Run("queues the organization's events marked as replayed", func(t *testing.T) {
+		svc, d := newService()
+		d.authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		d.events.On("ListForReplay", ctx, mock.MatchedBy(func(f domain.EventReplayFilter) bool {
+			return f.OrganizationID == orgID && f.From.Equal(from) && f.Limit == domain.EventReplayBatchSize+1
+		})).Return([]*domain.Event{event(1), event(2)}, nil)
+		var payloads [][]byte
+		d.webhooks.On("EnqueueForTicket", ctx, int64(7), domain.WebhookTicketStatusChanged, mock.Anything).
+			Run(func(args mock.Arguments) { payloads = append(payloads, args.Get(3).([]byte)) }).
+			Return(nil)
+		d.audit.On("Create", ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+			return e.Action == domain.AuditEventsReplayed && e.TargetID == orgID.String()
+		})).Return(nil)
+
+		result, err := svc.ReplayEvents(ctx, actorID, orgID, filter)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Replayed)
+		assert.Nil(t, result.NextCursor)
+		require.Len(t, payloads, 2)
+		var envelope domain.WebhookEnvelope
+		require.NoError(t, json.Unmarshal(payloads[1], &envelope))
+		assert.True(t, envelope.Replayed)
+		assert.Equal(t, int64(2), envelope.EventID)
+		assert.Equal(t, 1, envelope.Version)
+		d.audit.AssertExpectations(t)
+	})
+
+	t.Run("returns a cursor when more events match than a batch", func(t *testing.T) {
+		svc, d := newService()
+		small := filter
+		small.Limit = 2
+		d.authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		d.events.On("ListForReplay", ctx, mock.MatchedBy(func(f domain.EventReplayFilter) bool {
+			return f.Limit == 3
+		})).Return([]*domain.Event{event(1), event(2), event(3)}, nil)
+		d.webhooks.On("EnqueueForTicket", ctx, int64(7), domain.WebhookTicketStatusChanged, mock.Anything).Return(nil)
+		d.audit.On("Create", ctx, mock.Anything).Return(nil)
+
+		result, err := svc.ReplayEvents(ctx, actorID, orgID, small)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Replayed)
+		require.NotNil(t, result.NextCursor)
+		assert.Equal(t, int64(2), *result.NextCursor)
+		d.webhooks.AssertNumberOfCalls(t, "EnqueueForTicket", 2)
+	})
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// InboundHookService lets admins register inbound hooks and turns the
+// payloads posted to them into tickets.
+type InboundHookService struct {
+	hookRepo  ports.InboundHookRepository
+	ticketSvc ports.TicketService
+	userRepo  ports.UserRepository
+	authzSvc  ports.AuthorizationService
+	auditRepo ports.AuditLogRepository
+	txManager ports.TransactionManager
+}
+
+var _ ports.InboundHookService = (*InboundHookService)(nil)
+
+// NewInboundHookService creates a new InboundHookService.
+func NewInboundHookService(
+	hookRepo ports.InboundHookRepository,
+	ticketSvc ports.TicketService,
+	userRepo ports.UserRepository,
+	authzSvc ports.AuthorizationService,
+	auditRepo ports.AuditLogRepository,
+	txManager ports.TransactionManager,
+) ports.InboundHookService {
+	return &InboundHookService{
+		hookRepo:  hookRepo,
+		ticketSvc: ticketSvc,
+		userRepo:  userRepo,
+		authzSvc:  authzSvc,
+		auditRepo: auditRepo,
+		txManager: txManager,
+	}
+}
+
+// CreateInboundHook registers a new inbound hook for the organization. The
+// requester tickets are raised for must be an active user of the same
+// organization.
+func (s *InboundHookService) CreateInboundHook(ctx context.Context, actorID, orgID uuid.UUID, params domain.InboundHookParams) (*domain.InboundHook, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	hook, err := domain.NewInboundHook(params, orgID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	requester, err := s.userRepo.GetByID(ctx, params.RequesterID)
+	if err != nil && !errors.Is(err, apperrors.ErrUserNotFound) {
+		return nil, err
+	}
+	if requester == nil || requester.OrganizationID != orgID || !requester.IsActive {
+		errs := apperrors.NewValidationErrors()
+		errs.Add("requesterId", "Requester must be an active user in your organization")
+		return nil, errs
+	}
+
+	var created *domain.InboundHook
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		created, err = s.hookRepo.Create(txCtx, hook)
+		if err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditInboundHookCreated, created, nil, inboundHookAuditSnapshot(created))
+	}); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// ListInboundHooks returns the organization's inbound hooks.
+func (s *InboundHookService) ListInboundHooks(ctx context.Context, actorID, orgID uuid.UUID) ([]*domain.InboundHook, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	return s.hookRepo.ListByOrganization(ctx, orgID)
+}
+
+// DeleteInboundHook removes an inbound hook. Tickets it raised are kept.
+func (s *InboundHookService) DeleteInboundHook(ctx context.Context, actorID, orgID, hookID uuid.UUID) error {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return err
+	}
+
+	hook, err := s.hookRepo.GetByID(ctx, hookID)
+	if err != nil {
+		return err
+	}
+	if hook.OrganizationID != orgID {
+		return apperrors.ErrInboundHookNotFound
+	}
+
+	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.hookRepo.Delete(txCtx, hookID); err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditInboundHookDeleted, hook, inboundHookAuditSnapshot(hook), nil)
+	})
+}
+
+// Receive verifies a payload posted to a hook and raises a ticket from it
+// on behalf of the hook's requester. It returns nil if the hook ignores the
+// payload.
+func (s *InboundHookService) Receive(ctx context.Context, source, signature string, payload []byte) (*domain.Ticket, error) {
+	hook, err := s.hookRepo.GetBySource(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	if !hook.IsActive {
+		return nil, apperrors.ErrInboundHookNotFound
+	}
+
+	if !hook.VerifySignature(signature, payload) {
+		return nil, apperrors.ErrInvalidSignature
+	}
+
+	params, ok, err := hook.MapPayload(payload)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return s.ticketSvc.CreateTicket(ctx, ports.CreateTicketParams{
+		Title:       params.Title,
+		Description: params.Description,
+		Priority:    params.Priority,
+		RequesterID: params.RequesterID,
+	})
+}
+
+// recordAudit logs a change made to an inbound hook.
+func (s *InboundHookService) recordAudit(ctx context.Context, orgID, actorID uuid.UUID, action domain.AuditAction, hook *domain.InboundHook, before, after any) error {
+	entry, err := domain.NewAuditEntry(ctx, orgID, actorID, action, domain.AuditTargetInboundHook, hook.ID.String(), before, after)
+	if err != nil {
+		return err
+	}
+	return s.auditRepo.Create(ctx, entry)
+}
+
+// inboundHookAuditSnapshot is the audited view of an inbound hook. The
+// signing secret is left out.
+func inboundHookAuditSnapshot(hook *domain.InboundHook) map[string]any {
+	return map[string]any{
+		"source":      hook.Source,
+		"format":      hook.Format,
+		"mapping":     hook.Mapping,
+		"requesterId": hook.RequesterID,
+	}
+}
+
+func (s *InboundHookService) requireAdmin(ctx context.Context, actorID uuid.UUID) error {
+	allowed, err := s.authzSvc.Can(ctx, actorID, "admin:access")
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return apperrors.ErrForbidden
+	}
+	return nil
+}
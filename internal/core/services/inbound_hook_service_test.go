@@ -0,0 +1,154 @@
+package services_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInboundHookService_CreateInboundHook(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	orgID := uuid.New()
+	requesterID := uuid.New()
+	params := domain.InboundHookParams{
+		Source:      "prod-alerts",
+		Format:      domain.InboundHookAlertmanager,
+		RequesterID: requesterID,
+	}
+
+	newService := func() (ports.InboundHookService, *mocks.MockInboundHookRepository, *mocks.MockUserRepository, *mocks.MockAuthorizationService, *mocks.MockAuditLogRepository) {
+		repo := mocks.NewMockInboundHookRepository()
+		users := mocks.NewMockUserRepository()
+		authz := mocks.NewMockAuthorizationService()
+		audit := mocks.NewMockAuditLogRepository()
+		svc := services.NewInboundHookService(repo, mocks.NewMockTicketService(), users, authz, audit, stubTransactionManager{})
+		return svc, repo, users, authz, audit
+	}
+
+	t.Run("requires admin access", func(t *testing.T) {
+		svc, repo, _, authz, _ := newService()
+		authz.On("Can", ctx, actorID, "admin:access").Return(false, nil)
+
+		_, err := svc.CreateInboundHook(ctx, actorID, orgID, params)
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("requester must belong to the organization", func(t *testing.T) {
+		svc, repo, users, authz, _ := newService()
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		users.On("GetByID", ctx, requesterID).Return(&domain.User{ID: requesterID, OrganizationID: uuid.New(), IsActive: true}, nil)
+
+		_, err := svc.CreateInboundHook(ctx, actorID, orgID, params)
+
+		var errs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Contains(t, errs.Errors, "requesterId")
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("creates the hook and audits it without the secret", func(t *testing.T) {
+		svc, repo, users, authz, audit := newService()
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		users.On("GetByID", ctx, requesterID).Return(&domain.User{ID: requesterID, OrganizationID: orgID, IsActive: true}, nil)
+		repo.On("Create", ctx, mock.MatchedBy(func(h *domain.InboundHook) bool {
+			return h.OrganizationID == orgID && h.CreatedBy == actorID && h.Secret != ""
+		})).Return(&domain.InboundHook{ID: uuid.New(), OrganizationID: orgID, Source: params.Source, Secret: "secret"}, nil)
+		audit.On("Create", ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+			return e.Action == domain.AuditInboundHookCreated && e.Before == nil && !strings.Contains(string(e.After), "secret")
+		})).Return(nil)
+
+		hook, err := svc.CreateInboundHook(ctx, actorID, orgID, params)
+
+		require.NoError(t, err)
+		assert.Equal(t, "secret", hook.Secret)
+		audit.AssertExpectations(t)
+	})
+}
+
+func TestInboundHookService_Receive(t *testing.T) {
+	ctx := context.Background()
+	requesterID := uuid.New()
+	hook := &domain.InboundHook{
+		ID:          uuid.New(),
+		Source:      "prod-alerts",
+		Format:      domain.InboundHookAlertmanager,
+		Secret:      "s3cret",
+		RequesterID: requesterID,
+		IsActive:    true,
+	}
+
+	sign := func(body string) string {
+		mac := hmac.New(sha256.New, []byte(hook.Secret))
+		mac.Write([]byte(body))
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	newService := func() (ports.InboundHookService, *mocks.MockInboundHookRepository, *mocks.MockTicketService) {
+		repo := mocks.NewMockInboundHookRepository()
+		tickets := mocks.NewMockTicketService()
+		svc := services.NewInboundHookService(repo, tickets, mocks.NewMockUserRepository(), mocks.NewMockAuthorizationService(), mocks.NewMockAuditLogRepository(), stubTransactionManager{})
+		repo.On("GetBySource", ctx, "prod-alerts").Return(hook, nil)
+		return svc, repo, tickets
+	}
+
+	t.Run("raises a ticket for the hook's requester", func(t *testing.T) {
+		svc, _, tickets := newService()
+		body := `{"status":"firing","commonLabels":{"alertname":"DiskFull","severity":"critical"}}`
+		tickets.On("CreateTicket", ctx, ports.CreateTicketParams{
+			Title:       "DiskFull",
+			Priority:    domain.PriorityHigh,
+			RequesterID: requesterID,
+		}).Return(&domain.Ticket{ID: 42}, nil)
+
+		ticket, err := svc.Receive(ctx, "prod-alerts", sign(body), []byte(body))
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), ticket.ID)
+	})
+
+	t.Run("rejects a bad signature", func(t *testing.T) {
+		svc, _, tickets := newService()
+
+		_, err := svc.Receive(ctx, "prod-alerts", sign("{}"), []byte(`{"status":"firing"}`))
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidSignature)
+		tickets.AssertNotCalled(t, "CreateTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ignores resolved alerts", func(t *testing.T) {
+		svc, _, tickets := newService()
+		body := `{"status":"resolved"}`
+
+		ticket, err := svc.Receive(ctx, "prod-alerts", sign(body), []byte(body))
+
+		require.NoError(t, err)
+		assert.Nil(t, ticket)
+		tickets.AssertNotCalled(t, "CreateTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown source", func(t *testing.T) {
+		repo := mocks.NewMockInboundHookRepository()
+		svc := services.NewInboundHookService(repo, mocks.NewMockTicketService(), mocks.NewMockUserRepository(), mocks.NewMockAuthorizationService(), mocks.NewMockAuditLogRepository(), stubTransactionManager{})
+		repo.On("GetBySource", ctx, "nope").Return(nil, apperrors.ErrInboundHookNotFound)
+
+		_, err := svc.Receive(ctx, "nope", "", []byte(`{}`))
+
+		assert.ErrorIs(t, err, apperrors.ErrInboundHookNotFound)
+	})
+}
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// MacroService lets admins bundle ticket changes and a canned reply into
+// macros that agents apply in one step.
+type MacroService struct {
+	macroRepo  ports.MacroRepository
+	userRepo   ports.UserRepository
+	ticketSvc  ports.TicketService
+	commentSvc ports.CommentService
+	authzSvc   ports.AuthorizationService
+	auditRepo  ports.AuditLogRepository
+	txManager  ports.TransactionManager
+}
+
+var _ ports.MacroService = (*MacroService)(nil)
+
+// NewMacroService creates a new MacroService.
+func NewMacroService(
+	macroRepo ports.MacroRepository,
+	userRepo ports.UserRepository,
+	ticketSvc ports.TicketService,
+	commentSvc ports.CommentService,
+	authzSvc ports.AuthorizationService,
+	auditRepo ports.AuditLogRepository,
+	txManager ports.TransactionManager,
+) ports.MacroService {
+	return &MacroService{
+		macroRepo:  macroRepo,
+		userRepo:   userRepo,
+		ticketSvc:  ticketSvc,
+		commentSvc: commentSvc,
+		authzSvc:   authzSvc,
+		auditRepo:  auditRepo,
+		txManager:  txManager,
+	}
+}
+
+// CreateMacro creates a macro. Macro names are unique within an
+// organization.
+func (s *MacroService) CreateMacro(ctx context.Context, actorID, orgID uuid.UUID, params domain.MacroParams) (*domain.Macro, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	macro, err := domain.NewMacro(params, orgID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateAssignee(ctx, orgID, macro); err != nil {
+		return nil, err
+	}
+
+	var created *domain.Macro
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		created, err = s.macroRepo.Create(txCtx, macro)
+		if err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditMacroCreated, created.ID, nil, created.AuditSnapshot())
+	}); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// ListMacros returns the organization's macros to users who can apply them.
+func (s *MacroService) ListMacros(ctx context.Context, actorID, orgID uuid.UUID) ([]*domain.Macro, error) {
+	if err := s.requirePermission(ctx, actorID, "macros:apply"); err != nil {
+		return nil, err
+	}
+
+	return s.macroRepo.ListByOrganization(ctx, orgID)
+}
+
+// UpdateMacro replaces a macro's name and actions.
+func (s *MacroService) UpdateMacro(ctx context.Context, actorID, orgID, macroID uuid.UUID, params domain.MacroParams) (*domain.Macro, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	macro, err := s.getMacro(ctx, orgID, macroID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := macro.AuditSnapshot()
+	if err := macro.Update(params); err != nil {
+		return nil, err
+	}
+	if err := s.validateAssignee(ctx, orgID, macro); err != nil {
+		return nil, err
+	}
+
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.macroRepo.Update(txCtx, macro); err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditMacroUpdated, macro.ID, before, macro.AuditSnapshot())
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.macroRepo.GetByID(ctx, macroID)
+}
+
+// DeleteMacro removes a macro.
+func (s *MacroService) DeleteMacro(ctx context.Context, actorID, orgID, macroID uuid.UUID) error {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return err
+	}
+
+	macro, err := s.getMacro(ctx, orgID, macroID)
+	if err != nil {
+		return err
+	}
+
+	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.macroRepo.Delete(txCtx, macroID); err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditMacroDeleted, macro.ID, macro.AuditSnapshot(), nil)
+	})
+}
+
+// ApplyMacro posts the macro's canned reply and applies its changes to the
+// ticket in one transaction, so either every action takes effect or none
+// does. The actor needs the permissions each action would need on its own.
+func (s *MacroService) ApplyMacro(ctx context.Context, params ports.ApplyMacroParams) (*domain.Ticket, error) {
+	if err := s.requirePermission(ctx, params.ActorID, "macros:apply"); err != nil {
+		return nil, err
+	}
+
+	macro, err := s.getMacro(ctx, params.OrgID, params.MacroID)
+	if err != nil {
+		return nil, err
+	}
+	changes, reply := macro.TicketChanges()
+
+	var ticket *domain.Ticket
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if reply != "" {
+			if _, err := s.commentSvc.CreateComment(txCtx, ports.CreateCommentParams{
+				TicketID: params.TicketID,
+				ActorID:  params.ActorID,
+				Body:     reply,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if changes.IsEmpty() {
+			return nil
+		}
+		ticket, err = s.ticketSvc.UpdateTicket(txCtx, ports.UpdateTicketParams{
+			TicketID: params.TicketID,
+			Changes:  changes,
+			ActorID:  params.ActorID,
+		})
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if ticket == nil {
+		return s.ticketSvc.GetTicket(ctx, params.TicketID, params.ActorID)
+	}
+	return ticket, nil
+}
+
+// validateAssignee checks that a macro's assignee is an active agent of
+// the organization.
+func (s *MacroService) validateAssignee(ctx context.Context, orgID uuid.UUID, macro *domain.Macro) error {
+	changes, _ := macro.TicketChanges()
+	if changes.AssigneeID == nil {
+		return nil
+	}
+	assigneeID := *changes.AssigneeID
+
+	user, err := s.userRepo.GetByID(ctx, assigneeID)
+	if err != nil && !errors.Is(err, apperrors.ErrUserNotFound) {
+		return err
+	}
+	canClaim := false
+	if user != nil && user.OrganizationID == orgID && user.IsActive {
+		canClaim, err = s.authzSvc.Can(ctx, assigneeID, "tickets:claim")
+		if err != nil {
+			return err
+		}
+	}
+	if !canClaim {
+		errs := apperrors.NewValidationErrors()
+		errs.Add("actions", "Assignee must be an active agent in your organization")
+		return errs
+	}
+	return nil
+}
+
+// getMacro fetches a macro, hiding macros of other organizations.
+func (s *MacroService) getMacro(ctx context.Context, orgID, macroID uuid.UUID) (*domain.Macro, error) {
+	macro, err := s.macroRepo.GetByID(ctx, macroID)
+	if err != nil {
+		return nil, err
+	}
+	if macro.OrganizationID != orgID {
+		return nil, apperrors.ErrMacroNotFound
+	}
+	return macro, nil
+}
+
+// recordAudit logs a change made to a macro.
+func (s *MacroService) recordAudit(ctx context.Context, orgID, actorID uuid.UUID, action domain.AuditAction, macroID uuid.UUID, before, after any) error {
+	entry, err := domain.NewAuditEntry(ctx, orgID, actorID, action, domain.AuditTargetMacro, macroID.String(), before, after)
+	if err != nil {
+		return err
+	}
+	return s.auditRepo.Create(ctx, entry)
+}
+
+func (s *MacroService) requireAdmin(ctx context.Context, actorID uuid.UUID) error {
+	return s.requirePermission(ctx, actorID, "admin:access")
+}
+
+func (s *MacroService) requirePermission(ctx context.Context, actorID uuid.UUID, permission string) error {
+	allowed, err := s.authzSvc.Can(ctx, actorID, permission)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return apperrors.ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type macroServiceDeps struct {
+	repo     *mocks.MockMacroRepository
+	users    *mocks.MockUserRepository
+	tickets  *mocks.MockTicketService
+	comments *mocks.MockCommentService
+	authz    *mocks.MockAuthorizationService
+	audit    *mocks.MockAuditLogRepository
+}
+
+func newMacroService() (ports.MacroService, macroServiceDeps) {
+	deps := macroServiceDeps{
+		repo:     mocks.NewMockMacroRepository(),
+		users:    mocks.NewMockUserRepository(),
+		tickets:  mocks.NewMockTicketService(),
+		comments: mocks.NewMockCommentService(),
+		authz:    mocks.NewMockAuthorizationService(),
+		audit:    mocks.NewMockAuditLogRepository(),
+	}
+	svc := services.NewMacroService(deps.repo, deps.users, deps.tickets, deps.comments, deps.authz, deps.audit, stubTransactionManager{})
+	return svc, deps
+}
+
+func TestMacroService_CreateMacro(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	orgID := uuid.New()
+	assigneeID := uuid.New()
+
+	params := func() domain.MacroParams {
+		return domain.MacroParams{
+			Name:    "Hand to billing",
+			Actions: []domain.MacroAction{{Type: domain.MacroAssign, AssigneeID: &assigneeID}},
+		}
+	}
+
+	t.Run("requires admin access", func(t *testing.T) {
+		svc, deps := newMacroService()
+		deps.authz.On("Can", ctx, actorID, "admin:access").Return(false, nil)
+
+		_, err := svc.CreateMacro(ctx, actorID, orgID, params())
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		deps.repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("assignee must be able to claim tickets", func(t *testing.T) {
+		svc, deps := newMacroService()
+		deps.authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		deps.users.On("GetByID", ctx, assigneeID).Return(&domain.User{ID: assigneeID, OrganizationID: orgID, IsActive: true}, nil)
+		deps.authz.On("Can", ctx, assigneeID, "tickets:claim").Return(false, nil)
+
+		_, err := svc.CreateMacro(ctx, actorID, orgID, params())
+
+		var errs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Contains(t, errs.Errors, "actions")
+		deps.repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("creates the macro and audits it", func(t *testing.T) {
+		svc, deps := newMacroService()
+		deps.authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		deps.users.On("GetByID", ctx, assigneeID).Return(&domain.User{ID: assigneeID, OrganizationID: orgID, IsActive: true}, nil)
+		deps.authz.On("Can", ctx, assigneeID, "tickets:claim").Return(true, nil)
+		deps.repo.On("Create", ctx, mock.MatchedBy(func(m *domain.Macro) bool {
+			return m.OrganizationID == orgID && m.CreatedBy == actorID
+		})).Return(&domain.Macro{ID: uuid.New(), OrganizationID: orgID, Name: "Hand to billing"}, nil)
+		deps.audit.On("Create", ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+			return e.Action == domain.AuditMacroCreated && e.TargetType == domain.AuditTargetMacro
+		})).Return(nil)
+
+		macro, err := svc.CreateMacro(ctx, actorID, orgID, params())
+
+		require.NoError(t, err)
+		assert.Equal(t, "Hand to billing", macro.Name)
+		deps.audit.AssertExpectations(t)
+	})
+}
+
+func TestMacroService_ApplyMacro(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	orgID := uuid.New()
+	macroID := uuid.New()
+
+	macro := &domain.Macro{
+		ID:             macroID,
+		OrganizationID: orgID,
+		Name:           "Waiting on customer",
+		Actions: []domain.MacroAction{
+			{Type: domain.MacroSetStatus, Status: domain.StatusInProgress},
+			{Type: domain.MacroAddTags, Tags: []string{"waiting"}},
+			{Type: domain.MacroReply, Body: "Could you send us a screenshot?"},
+		},
+	}
+	params := ports.ApplyMacroParams{TicketID: 42, MacroID: macroID, ActorID: actorID, OrgID: orgID}
+
+	t.Run("requires the macros:apply permission", func(t *testing.T) {
+		svc, deps := newMacroService()
+		deps.authz.On("Can", ctx, actorID, "macros:apply").Return(false, nil)
+
+		_, err := svc.ApplyMacro(ctx, params)
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		deps.tickets.AssertNotCalled(t, "UpdateTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("hides macros of other organizations", func(t *testing.T) {
+		svc, deps := newMacroService()
+		deps.authz.On("Can", ctx, actorID, "macros:apply").Return(true, nil)
+		other := *macro
+		other.OrganizationID = uuid.New()
+		deps.repo.On("GetByID", ctx, macroID).Return(&other, nil)
+
+		_, err := svc.ApplyMacro(ctx, params)
+
+		assert.ErrorIs(t, err, apperrors.ErrMacroNotFound)
+		deps.comments.AssertNotCalled(t, "CreateComment", mock.Anything, mock.Anything)
+	})
+
+	t.Run("posts the reply and applies the changes", func(t *testing.T) {
+		svc, deps := newMacroService()
+		deps.authz.On("Can", ctx, actorID, "macros:apply").Return(true, nil)
+		deps.repo.On("GetByID", ctx, macroID).Return(macro, nil)
+		deps.comments.On("CreateComment", ctx, ports.CreateCommentParams{
+			TicketID: 42,
+			ActorID:  actorID,
+			Body:     "Could you send us a screenshot?",
+		}).Return(&domain.Comment{ID: 1}, nil)
+		deps.tickets.On("UpdateTicket", ctx, mock.MatchedBy(func(p ports.UpdateTicketParams) bool {
+			return p.TicketID == 42 && p.ActorID == actorID &&
+				p.Changes.Status != nil && *p.Changes.Status == domain.StatusInProgress &&
+				assert.ObjectsAreEqual([]string{"waiting"}, p.Changes.AddTags)
+		})).Return(&domain.Ticket{ID: 42, Status: domain.StatusInProgress, Tags: []string{"waiting"}}, nil)
+
+		ticket, err := svc.ApplyMacro(ctx, params)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"waiting"}, ticket.Tags)
+		deps.comments.AssertExpectations(t)
+		deps.tickets.AssertExpectations(t)
+	})
+
+	t.Run("stops when the reply cannot be posted", func(t *testing.T) {
+		svc, deps := newMacroService()
+		deps.authz.On("Can", ctx, actorID, "macros:apply").Return(true, nil)
+		deps.repo.On("GetByID", ctx, macroID).Return(macro, nil)
+		deps.comments.On("CreateComment", ctx, mock.Anything).Return(nil, apperrors.ErrForbidden)
+
+		_, err := svc.ApplyMacro(ctx, params)
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		deps.tickets.AssertNotCalled(t, "UpdateTicket", mock.Anything, mock.Anything)
+	})
+}
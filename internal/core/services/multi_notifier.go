@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// MultiNotifier delivers every notification through several notifiers.
+// Delivery is attempted on all of them even if one fails, and the failures
+// are joined. Because the outbox retries the whole notification, channels
+// that already succeeded may see it again on retry.
+type MultiNotifier struct {
+	notifiers []ports.Notifier
+}
+
+var _ ports.Notifier = (*MultiNotifier)(nil)
+
+// NewMultiNotifier creates a notifier that fans out to all given notifiers
+func NewMultiNotifier(notifiers ...ports.Notifier) ports.Notifier {
+	return &MultiNotifier{notifiers: notifiers}
+}
+
+// Notify delivers the notification through every notifier
+func (m *MultiNotifier) Notify(ctx context.Context, params ports.NotificationParams) error {
+	var errs []error
+	for _, n := range m.notifiers {
+		if err := n.Notify(ctx, params); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
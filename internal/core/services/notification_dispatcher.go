@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// DispatcherConfig controls how a delivery queue is drained.
+type DispatcherConfig struct {
+	PollInterval time.Duration // How often to look for due notifications
+	BatchSize    int           // Maximum notifications claimed per poll
+	ClaimLease   time.Duration // How long a claimed notification is hidden from other workers
+	MaxAttempts  int           // Attempts before a notification is dead-lettered
+	BaseBackoff  time.Duration // Delay before the first retry, doubled on each attempt
+	MaxBackoff   time.Duration // Upper bound on the retry delay
+}
+
+// NotificationDispatcher delivers queued notifications from the outbox,
+// retrying failures with exponential backoff and dead-lettering those that
+// keep failing.
+type NotificationDispatcher struct {
+	outbox   ports.NotificationOutboxRepository
+	notifier ports.Notifier
+	cfg      DispatcherConfig
+	logger   *slog.Logger
+	now      func() time.Time
+}
+
+// NewNotificationDispatcher creates a new outbox dispatcher
+func NewNotificationDispatcher(
+	outbox ports.NotificationOutboxRepository,
+	notifier ports.Notifier,
+	cfg DispatcherConfig,
+	logger *slog.Logger,
+) *NotificationDispatcher {
+	return &NotificationDispatcher{
+		outbox:   outbox,
+		notifier: notifier,
+		cfg:      cfg,
+		logger:   logger.With("component", "notification_dispatcher"),
+		now:      time.Now,
+	}
+}
+
+// Run polls the outbox until ctx is cancelled
+func (d *NotificationDispatcher) Run(ctx context.Context) {
+	poll(ctx, d.cfg, d.DispatchBatch, func(err error) {
+		d.logger.Error("failed to dispatch notifications", "error", err)
+	})
+}
+
+// poll calls dispatch every cfg.PollInterval until ctx is cancelled. It keeps
+// draining while full batches come back, then waits for the next tick.
+func poll(ctx context.Context, cfg DispatcherConfig, dispatch func(context.Context) (int, error), onError func(error)) {
+	ticker := time.NewTicker(cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := dispatch(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					onError(err)
+				}
+				break
+			}
+			if n < cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchBatch claims and delivers one batch of due notifications and
+// returns how many were claimed.
+func (d *NotificationDispatcher) DispatchBatch(ctx context.Context) (int, error) {
+	batch, err := d.outbox.ClaimDue(ctx, d.cfg.BatchSize, d.cfg.ClaimLease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, n := range batch {
+		d.deliver(ctx, n)
+	}
+
+	return len(batch), nil
+}
+
+// deliver sends a single notification and records the outcome
+func (d *NotificationDispatcher) deliver(ctx context.Context, n *ports.OutboxNotification) {
+	params := n.Params
+	if n.Count > 1 {
+		params = summarizeBatch(params, n.Count)
+	}
+
+	sendErr := d.notifier.Notify(ctx, params)
+	if sendErr == nil {
+		if err := d.outbox.MarkSent(ctx, n.ID); err != nil {
+			d.logger.Error("failed to mark notification sent", "id", n.ID, "error", err)
+		}
+		return
+	}
+
+	attempts := n.Attempts + 1
+	if attempts >= d.cfg.MaxAttempts {
+		d.logger.Error("notification dead-lettered",
+			"id", n.ID,
+			"attempts", attempts,
+			"error", sendErr,
+		)
+		if err := d.outbox.MarkDead(ctx, n.ID, sendErr.Error()); err != nil {
+			d.logger.Error("failed to dead-letter notification", "id", n.ID, "error", err)
+		}
+		return
+	}
+
+	nextAttemptAt := d.now().Add(d.cfg.backoff(attempts))
+	d.logger.Warn("notification delivery failed, will retry",
+		"id", n.ID,
+		"attempts", attempts,
+		"next_attempt_at", nextAttemptAt,
+		"error", sendErr,
+	)
+	if err := d.outbox.MarkRetry(ctx, n.ID, nextAttemptAt, sendErr.Error()); err != nil {
+		d.logger.Error("failed to schedule notification retry", "id", n.ID, "error", err)
+	}
+}
+
+// summarizeBatch rewrites a notification that absorbed count events while it
+// waited in the outbox. Its content is that of the newest event; the subject
+// and message are reworded to cover all of them and Data["count"] is set for
+// templates.
+func summarizeBatch(params ports.NotificationParams, count int) ports.NotificationParams {
+	data := make(map[string]string, len(params.Data)+1)
+	for k, v := range params.Data {
+		data[k] = v
+	}
+	data["count"] = strconv.Itoa(count)
+	params.Data = data
+
+	if params.Type == ports.NotificationCommentAdded {
+		params.Subject = fmt.Sprintf("%d new comments on your ticket: #%d", count, params.TicketID)
+		params.Message = fmt.Sprintf("%d new comments have been added to your ticket '%s'.", count, data["title"])
+	}
+
+	return params
+}
+
+// backoff returns the delay before retrying after the given number of failed attempts
+func (c DispatcherConfig) backoff(attempts int) time.Duration {
+	delay := c.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= c.MaxBackoff {
+			return c.MaxBackoff
+		}
+	}
+	return delay
+}
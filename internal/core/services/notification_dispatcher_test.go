@@ -0,0 +1,141 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDispatcher(outbox *mocks.MockNotificationOutboxRepository, notifier *mocks.MockNotifier) *services.NotificationDispatcher {
+	return services.NewNotificationDispatcher(outbox, notifier, services.DispatcherConfig{
+		PollInterval: time.Second,
+		BatchSize:    10,
+		ClaimLease:   time.Minute,
+		MaxAttempts:  3,
+		BaseBackoff:  time.Minute,
+		MaxBackoff:   time.Hour,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestNotificationDispatcher_DispatchBatch(t *testing.T) {
+	ctx := context.Background()
+	params := ports.NotificationParams{
+		RecipientUserID: uuid.New(),
+		Type:            ports.NotificationStatusChanged,
+		Subject:         "Your ticket status has been updated: #1",
+		TicketID:        1,
+	}
+
+	t.Run("marks delivered notifications as sent", func(t *testing.T) {
+		outbox := mocks.NewMockNotificationOutboxRepository()
+		notifier := mocks.NewMockNotifier()
+		dispatcher := newTestDispatcher(outbox, notifier)
+
+		outbox.On("ClaimDue", ctx, 10, time.Minute).
+			Return([]*ports.OutboxNotification{{ID: 7, Params: params}}, nil)
+		notifier.On("Notify", ctx, params).Return(nil)
+		outbox.On("MarkSent", ctx, int64(7)).Return(nil)
+
+		n, err := dispatcher.DispatchBatch(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		outbox.AssertExpectations(t)
+		notifier.AssertExpectations(t)
+	})
+
+	t.Run("schedules a retry with backoff on failure", func(t *testing.T) {
+		outbox := mocks.NewMockNotificationOutboxRepository()
+		notifier := mocks.NewMockNotifier()
+		dispatcher := newTestDispatcher(outbox, notifier)
+
+		outbox.On("ClaimDue", ctx, 10, time.Minute).
+			Return([]*ports.OutboxNotification{{ID: 7, Params: params, Attempts: 1}}, nil)
+		notifier.On("Notify", ctx, params).Return(errors.New("smtp unavailable"))
+
+		var nextAttemptAt time.Time
+		outbox.On("MarkRetry", ctx, int64(7), mock.AnythingOfType("time.Time"), "smtp unavailable").
+			Run(func(args mock.Arguments) { nextAttemptAt = args.Get(2).(time.Time) }).
+			Return(nil)
+
+		start := time.Now()
+		_, err := dispatcher.DispatchBatch(ctx)
+
+		require.NoError(t, err)
+		// Second failed attempt waits twice the base backoff.
+		assert.WithinDuration(t, start.Add(2*time.Minute), nextAttemptAt, 5*time.Second)
+		outbox.AssertExpectations(t)
+		outbox.AssertNotCalled(t, "MarkDead", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("dead-letters after the final attempt", func(t *testing.T) {
+		outbox := mocks.NewMockNotificationOutboxRepository()
+		notifier := mocks.NewMockNotifier()
+		dispatcher := newTestDispatcher(outbox, notifier)
+
+		outbox.On("ClaimDue", ctx, 10, time.Minute).
+			Return([]*ports.OutboxNotification{{ID: 7, Params: params, Attempts: 2}}, nil)
+		notifier.On("Notify", ctx, params).Return(errors.New("smtp unavailable"))
+		outbox.On("MarkDead", ctx, int64(7), "smtp unavailable").Return(nil)
+
+		_, err := dispatcher.DispatchBatch(ctx)
+
+		require.NoError(t, err)
+		outbox.AssertExpectations(t)
+		outbox.AssertNotCalled(t, "MarkRetry", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("summarizes batched comment notifications", func(t *testing.T) {
+		outbox := mocks.NewMockNotificationOutboxRepository()
+		notifier := mocks.NewMockNotifier()
+		dispatcher := newTestDispatcher(outbox, notifier)
+
+		batched := ports.NotificationParams{
+			RecipientUserID: uuid.New(),
+			Type:            ports.NotificationCommentAdded,
+			Subject:         "A new comment was added to your ticket: #4",
+			TicketID:        4,
+			Data:            map[string]string{"title": "Printer", "comment": "Latest reply"},
+		}
+
+		outbox.On("ClaimDue", ctx, 10, time.Minute).
+			Return([]*ports.OutboxNotification{{ID: 8, Params: batched, Count: 3}}, nil)
+		notifier.On("Notify", ctx, mock.MatchedBy(func(p ports.NotificationParams) bool {
+			return p.Subject == "3 new comments on your ticket: #4" &&
+				p.Data["count"] == "3" &&
+				p.Data["comment"] == "Latest reply"
+		})).Return(nil)
+		outbox.On("MarkSent", ctx, int64(8)).Return(nil)
+
+		_, err := dispatcher.DispatchBatch(ctx)
+
+		require.NoError(t, err)
+		notifier.AssertExpectations(t)
+		assert.NotContains(t, batched.Data, "count", "claimed params must not be mutated")
+	})
+
+	t.Run("returns claim errors", func(t *testing.T) {
+		outbox := mocks.NewMockNotificationOutboxRepository()
+		notifier := mocks.NewMockNotifier()
+		dispatcher := newTestDispatcher(outbox, notifier)
+
+		outbox.On("ClaimDue", ctx, 10, time.Minute).Return(nil, errors.New("db down"))
+
+		n, err := dispatcher.DispatchBatch(ctx)
+
+		assert.Error(t, err)
+		assert.Zero(t, n)
+		notifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// OptOutConfig controls the opt-out links added to ticket emails.
+type OptOutConfig struct {
+	LinkURL string // Page the links open, with ?action= and ?token= added. Empty leaves the links out
+	Secret  []byte
+}
+
+// OptOutNotifier wraps the email notifier to honour users' email
+// preferences. Ticket emails the recipient opted out of are dropped, and
+// the rest get "mute this ticket" and "unsubscribe from all" links, signed
+// for their recipient. Emails about the account itself, and the email
+// that gives a portal requester their link to the ticket, pass through as
+// they are.
+type OptOutNotifier struct {
+	next      ports.Notifier
+	prefsRepo ports.EmailPreferenceRepository
+	cfg       OptOutConfig
+}
+
+var _ ports.Notifier = (*OptOutNotifier)(nil)
+
+// NewOptOutNotifier creates a notifier that applies email preferences
+// before delivering through next.
+func NewOptOutNotifier(next ports.Notifier, prefsRepo ports.EmailPreferenceRepository, cfg OptOutConfig) ports.Notifier {
+	return &OptOutNotifier{next: next, prefsRepo: prefsRepo, cfg: cfg}
+}
+
+// Notify delivers the notification unless its recipient opted out of it.
+// Preferences are read at delivery, so they also stop emails that were
+// already queued.
+func (n *OptOutNotifier) Notify(ctx context.Context, params ports.NotificationParams) error {
+	if params.TicketID == 0 || params.Type == ports.NotificationPortalLink {
+		return n.next.Notify(ctx, params)
+	}
+
+	prefs, err := n.prefsRepo.Get(ctx, params.RecipientUserID)
+	if err != nil {
+		return fmt.Errorf("get email preferences of %s: %w", params.RecipientUserID, err)
+	}
+	if !prefs.Allows(params.TicketID) {
+		return nil
+	}
+
+	if n.cfg.LinkURL != "" {
+		data := make(map[string]string, len(params.Data)+2)
+		for k, v := range params.Data {
+			data[k] = v
+		}
+		data["mute_link"] = n.link("mute", domain.EmailOptOut{UserID: params.RecipientUserID, TicketID: params.TicketID})
+		data["unsubscribe_link"] = n.link("unsubscribe", domain.EmailOptOut{UserID: params.RecipientUserID})
+		params.Data = data
+	}
+	return n.next.Notify(ctx, params)
+}
+
+// link returns the address of the page that applies optOut.
+func (n *OptOutNotifier) link(action string, optOut domain.EmailOptOut) string {
+	u, err := url.Parse(n.cfg.LinkURL)
+	if err != nil {
+		// The URL is validated with the configuration
+		return ""
+	}
+	query := u.Query()
+	query.Set("action", action)
+	query.Set("token", optOut.Sign(n.cfg.Secret))
+	u.RawQuery = query.Encode()
+	return u.String()
+}
@@ -0,0 +1,115 @@
+package services_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptOutNotifier_Notify(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	secret := []byte("s3cret")
+
+	newNotifier := func(linkURL string) (ports.Notifier, *mocks.MockNotifier, *mocks.MockEmailPreferenceRepository) {
+		next := mocks.NewMockNotifier()
+		prefs := mocks.NewMockEmailPreferenceRepository()
+		return services.NewOptOutNotifier(next, prefs, services.OptOutConfig{LinkURL: linkURL, Secret: secret}), next, prefs
+	}
+	ticketEmail := ports.NotificationParams{
+		RecipientUserID: userID,
+		Type:            ports.NotificationStatusChanged,
+		TicketID:        7,
+		Data:            map[string]string{"ticket_title": "VPN down"},
+	}
+
+	t.Run("adds signed opt-out links to ticket emails", func(t *testing.T) {
+		n, next, prefs := newNotifier("https://help.example.com/email?lang=en")
+		prefs.On("Get", ctx, userID).Return(&domain.EmailPreferences{UserID: userID, MutedTicketIDs: []int64{8}}, nil)
+		var sent ports.NotificationParams
+		next.On("Notify", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			sent = args.Get(1).(ports.NotificationParams)
+		}).Return(nil)
+
+		require.NoError(t, n.Notify(ctx, ticketEmail))
+
+		assert.Equal(t, "VPN down", sent.Data["ticket_title"])
+		mute, err := url.Parse(sent.Data["mute_link"])
+		require.NoError(t, err)
+		assert.Equal(t, "help.example.com", mute.Host)
+		assert.Equal(t, "en", mute.Query().Get("lang"))
+		assert.Equal(t, "mute", mute.Query().Get("action"))
+		optOut, err := domain.ParseEmailOptOut(secret, mute.Query().Get("token"))
+		require.NoError(t, err)
+		assert.Equal(t, domain.EmailOptOut{UserID: userID, TicketID: 7}, optOut)
+
+		unsubscribe, err := url.Parse(sent.Data["unsubscribe_link"])
+		require.NoError(t, err)
+		assert.Equal(t, "unsubscribe", unsubscribe.Query().Get("action"))
+		optOut, err = domain.ParseEmailOptOut(secret, unsubscribe.Query().Get("token"))
+		require.NoError(t, err)
+		assert.True(t, optOut.AllTickets())
+
+		// The caller's data is left as it was
+		assert.NotContains(t, ticketEmail.Data, "mute_link")
+	})
+
+	t.Run("drops emails about muted tickets", func(t *testing.T) {
+		n, next, prefs := newNotifier("https://help.example.com/email")
+		prefs.On("Get", ctx, userID).Return(&domain.EmailPreferences{UserID: userID, MutedTicketIDs: []int64{7}}, nil)
+
+		require.NoError(t, n.Notify(ctx, ticketEmail))
+
+		next.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+	})
+
+	t.Run("drops every ticket email after unsubscribing", func(t *testing.T) {
+		n, next, prefs := newNotifier("")
+		prefs.On("Get", ctx, userID).Return(&domain.EmailPreferences{UserID: userID, Unsubscribed: true}, nil)
+
+		require.NoError(t, n.Notify(ctx, ticketEmail))
+
+		next.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+	})
+
+	t.Run("sends account emails regardless", func(t *testing.T) {
+		n, next, prefs := newNotifier("https://help.example.com/email")
+		accountEmail := ports.NotificationParams{RecipientUserID: userID, Type: ports.NotificationEmailChange}
+		next.On("Notify", ctx, accountEmail).Return(nil)
+
+		require.NoError(t, n.Notify(ctx, accountEmail))
+
+		next.AssertExpectations(t)
+		prefs.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+
+	t.Run("sends portal links regardless", func(t *testing.T) {
+		n, next, prefs := newNotifier("https://help.example.com/email")
+		portalLink := ports.NotificationParams{RecipientUserID: userID, Type: ports.NotificationPortalLink, TicketID: 7}
+		next.On("Notify", ctx, portalLink).Return(nil)
+
+		require.NoError(t, n.Notify(ctx, portalLink))
+
+		next.AssertExpectations(t)
+		prefs.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+
+	t.Run("leaves the links out without a link URL", func(t *testing.T) {
+		n, next, prefs := newNotifier("")
+		prefs.On("Get", ctx, userID).Return(&domain.EmailPreferences{UserID: userID}, nil)
+		next.On("Notify", ctx, ticketEmail).Return(nil)
+
+		require.NoError(t, n.Notify(ctx, ticketEmail))
+
+		next.AssertExpectations(t)
+	})
+}
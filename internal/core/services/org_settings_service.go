@@ -0,0 +1,337 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// OrgSettingsService lets admins manage their organization's settings.
+type OrgSettingsService struct {
+	settingsRepo  ports.OrgSettingsRepository
+	retentionRepo ports.RetentionRepository
+	auditRepo     ports.AuditLogRepository
+	authzSvc      ports.AuthorizationService
+	txManager     ports.TransactionManager
+	now           func() time.Time
+}
+
+var _ ports.OrgSettingsService = (*OrgSettingsService)(nil)
+
+// NewOrgSettingsService creates a new OrgSettingsService.
+func NewOrgSettingsService(
+	settingsRepo ports.OrgSettingsRepository,
+	retentionRepo ports.RetentionRepository,
+	auditRepo ports.AuditLogRepository,
+	authzSvc ports.AuthorizationService,
+	txManager ports.TransactionManager,
+) ports.OrgSettingsService {
+	return &OrgSettingsService{
+		settingsRepo:  settingsRepo,
+		retentionRepo: retentionRepo,
+		auditRepo:     auditRepo,
+		authzSvc:      authzSvc,
+		txManager:     txManager,
+		now:           time.Now,
+	}
+}
+
+// GetBusinessHours returns the organization's business hours, or the
+// default calendar if none have been configured.
+func (s *OrgSettingsService) GetBusinessHours(ctx context.Context, actorID, orgID uuid.UUID) (*domain.BusinessHours, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	return businessHoursFor(ctx, s.settingsRepo, orgID)
+}
+
+// businessHoursFor returns the organization's business hours, or the
+// default calendar if none have been configured.
+func businessHoursFor(ctx context.Context, settingsRepo ports.OrgSettingsRepository, orgID uuid.UUID) (*domain.BusinessHours, error) {
+	hours, err := settingsRepo.GetBusinessHours(ctx, orgID)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return domain.DefaultBusinessHours(orgID), nil
+	}
+	return hours, err
+}
+
+// UpdateBusinessHours replaces the organization's working week and holidays.
+func (s *OrgSettingsService) UpdateBusinessHours(ctx context.Context, actorID, orgID uuid.UUID, hours domain.BusinessHours) (*domain.BusinessHours, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	hours.OrganizationID = orgID
+	if err := hours.Validate(); err != nil {
+		return nil, err
+	}
+
+	var saved *domain.BusinessHours
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		// Organizations that never saved their hours have no previous value.
+		var before any
+		previous, err := s.settingsRepo.GetBusinessHours(txCtx, orgID)
+		switch {
+		case err == nil:
+			before = previous.AuditSnapshot()
+		case !errors.Is(err, apperrors.ErrNotFound):
+			return err
+		}
+
+		saved, err = s.settingsRepo.SaveBusinessHours(txCtx, &hours)
+		if err != nil {
+			return err
+		}
+
+		entry, err := domain.NewAuditEntry(txCtx, orgID, actorID, domain.AuditBusinessHoursUpdated,
+			domain.AuditTargetOrganization, orgID.String(), before, saved.AuditSnapshot())
+		if err != nil {
+			return err
+		}
+		return s.auditRepo.Create(txCtx, entry)
+	}); err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// GetRetentionPolicy returns the organization's data retention policy, or
+// the default policy, which keeps everything, if none has been configured.
+func (s *OrgSettingsService) GetRetentionPolicy(ctx context.Context, actorID, orgID uuid.UUID) (*domain.RetentionPolicy, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	return s.retentionPolicy(ctx, orgID)
+}
+
+// UpdateRetentionPolicy replaces the organization's data retention policy.
+// Tickets it makes due are purged on the retention job's next run.
+func (s *OrgSettingsService) UpdateRetentionPolicy(ctx context.Context, actorID, orgID uuid.UUID, policy domain.RetentionPolicy) (*domain.RetentionPolicy, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	policy.OrganizationID = orgID
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	var saved *domain.RetentionPolicy
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		previous, err := s.retentionPolicy(txCtx, orgID)
+		if err != nil {
+			return err
+		}
+
+		saved, err = s.retentionRepo.SavePolicy(txCtx, &policy)
+		if err != nil {
+			return err
+		}
+
+		entry, err := domain.NewAuditEntry(txCtx, orgID, actorID, domain.AuditRetentionPolicyUpdated,
+			domain.AuditTargetOrganization, orgID.String(), previous.AuditSnapshot(), saved.AuditSnapshot())
+		if err != nil {
+			return err
+		}
+		return s.auditRepo.Create(txCtx, entry)
+	}); err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// ListUpcomingPurges returns the tickets the retention policy will soft- or
+// hard-delete within the next withinDays days, soonest first, along with
+// tickets already soft-deleted and waiting out the grace period.
+func (s *OrgSettingsService) ListUpcomingPurges(ctx context.Context, actorID, orgID uuid.UUID, withinDays, limit, offset int) ([]*domain.PurgeCandidate, domain.Total, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, domain.Total{}, err
+	}
+
+	policy, err := s.retentionPolicy(ctx, orgID)
+	if err != nil {
+		return nil, domain.Total{}, err
+	}
+	if !policy.Enabled() {
+		return []*domain.PurgeCandidate{}, domain.Total{}, nil
+	}
+
+	closedBefore := policy.SoftDeleteCutoff(s.now().UTC().AddDate(0, 0, withinDays))
+	candidates, total, err := s.retentionRepo.ListPurgeCandidates(ctx, orgID, closedBefore, limit, offset)
+	if err != nil {
+		return nil, domain.Total{}, err
+	}
+	for _, candidate := range candidates {
+		candidate.Schedule(policy)
+	}
+	return candidates, total, nil
+}
+
+// GetRegistrationDomains returns the email domains allowed to self-register
+// into the organization. An empty list means anyone can register.
+func (s *OrgSettingsService) GetRegistrationDomains(ctx context.Context, actorID, orgID uuid.UUID) ([]string, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	return s.settingsRepo.GetRegistrationDomains(ctx, orgID)
+}
+
+// UpdateRegistrationDomains replaces the organization's registration
+// allowlist. Existing users are not affected.
+func (s *OrgSettingsService) UpdateRegistrationDomains(ctx context.Context, actorID, orgID uuid.UUID, domains []string) ([]string, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	normalized, err := domain.NormalizeRegistrationDomains(domains)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		previous, err := s.settingsRepo.GetRegistrationDomains(txCtx, orgID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.settingsRepo.SaveRegistrationDomains(txCtx, orgID, normalized); err != nil {
+			return err
+		}
+
+		entry, err := domain.NewAuditEntry(txCtx, orgID, actorID, domain.AuditRegistrationDomainsUpdated,
+			domain.AuditTargetOrganization, orgID.String(),
+			map[string]any{"domains": previous}, map[string]any{"domains": normalized})
+		if err != nil {
+			return err
+		}
+		return s.auditRepo.Create(txCtx, entry)
+	}); err != nil {
+		return nil, err
+	}
+
+	return normalized, nil
+}
+
+// GetPortalSlug returns the slug the organization's public portal is served
+// under, or "" if the portal is off.
+func (s *OrgSettingsService) GetPortalSlug(ctx context.Context, actorID, orgID uuid.UUID) (string, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return "", err
+	}
+
+	return s.settingsRepo.GetPortalSlug(ctx, orgID)
+}
+
+// UpdatePortalSlug changes the slug of the organization's public portal. An
+// empty slug turns the portal off; links already emailed to guests keep
+// working.
+func (s *OrgSettingsService) UpdatePortalSlug(ctx context.Context, actorID, orgID uuid.UUID, slug string) (string, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return "", err
+	}
+
+	normalized, err := domain.NormalizePortalSlug(slug)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		previous, err := s.settingsRepo.GetPortalSlug(txCtx, orgID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.settingsRepo.SavePortalSlug(txCtx, orgID, normalized); err != nil {
+			return err
+		}
+
+		entry, err := domain.NewAuditEntry(txCtx, orgID, actorID, domain.AuditPortalSlugUpdated,
+			domain.AuditTargetOrganization, orgID.String(),
+			map[string]any{"slug": previous}, map[string]any{"slug": normalized})
+		if err != nil {
+			return err
+		}
+		return s.auditRepo.Create(txCtx, entry)
+	}); err != nil {
+		return "", err
+	}
+
+	return normalized, nil
+}
+
+// GetAgentCapacity returns how many open tickets the organization's agents
+// should have at most.
+func (s *OrgSettingsService) GetAgentCapacity(ctx context.Context, actorID, orgID uuid.UUID) (*domain.AgentCapacity, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	return s.settingsRepo.GetAgentCapacity(ctx, orgID)
+}
+
+// UpdateAgentCapacity sets the open ticket limit of the organization's
+// agents and whether assigning past it warns or is refused. Agents already
+// over a new limit keep their tickets.
+func (s *OrgSettingsService) UpdateAgentCapacity(ctx context.Context, actorID, orgID uuid.UUID, capacity domain.AgentCapacity) (*domain.AgentCapacity, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	capacity.OrganizationID = orgID
+	if err := capacity.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		previous, err := s.settingsRepo.GetAgentCapacity(txCtx, orgID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.settingsRepo.SaveAgentCapacity(txCtx, &capacity); err != nil {
+			return err
+		}
+
+		entry, err := domain.NewAuditEntry(txCtx, orgID, actorID, domain.AuditAgentCapacityUpdated,
+			domain.AuditTargetOrganization, orgID.String(), previous.AuditSnapshot(), capacity.AuditSnapshot())
+		if err != nil {
+			return err
+		}
+		return s.auditRepo.Create(txCtx, entry)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &capacity, nil
+}
+
+// retentionPolicy loads the organization's policy, falling back to the
+// default.
+func (s *OrgSettingsService) retentionPolicy(ctx context.Context, orgID uuid.UUID) (*domain.RetentionPolicy, error) {
+	policy, err := s.retentionRepo.GetPolicy(ctx, orgID)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return domain.DefaultRetentionPolicy(orgID), nil
+	}
+	return policy, err
+}
+
+func (s *OrgSettingsService) requireAdmin(ctx context.Context, actorID uuid.UUID) error {
+	allowed, err := s.authzSvc.Can(ctx, actorID, "admin:access")
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return apperrors.ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrgSettingsService_GetBusinessHours(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	orgID := uuid.New()
+
+	t.Run("requires admin access", func(t *testing.T) {
+		repo := mocks.NewMockOrgSettingsRepository()
+		authz := mocks.NewMockAuthorizationService()
+		audit := mocks.NewMockAuditLogRepository()
+		svc := services.NewOrgSettingsService(repo, mocks.NewMockRetentionRepository(), audit, authz, stubTransactionManager{})
+
+		authz.On("Can", ctx, actorID, "admin:access").Return(false, nil)
+
+		_, err := svc.GetBusinessHours(ctx, actorID, orgID)
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		repo.AssertNotCalled(t, "GetBusinessHours", mock.Anything, mock.Anything)
+	})
+
+	t.Run("falls back to the default calendar", func(t *testing.T) {
+		repo := mocks.NewMockOrgSettingsRepository()
+		authz := mocks.NewMockAuthorizationService()
+		audit := mocks.NewMockAuditLogRepository()
+		svc := services.NewOrgSettingsService(repo, mocks.NewMockRetentionRepository(), audit, authz, stubTransactionManager{})
+
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		repo.On("GetBusinessHours", ctx, orgID).Return(nil, apperrors.ErrNotFound)
+
+		hours, err := svc.GetBusinessHours(ctx, actorID, orgID)
+
+		require.NoError(t, err)
+		assert.Equal(t, orgID, hours.OrganizationID)
+		assert.Equal(t, "UTC", hours.Timezone)
+		assert.Len(t, hours.Days, 5)
+	})
+}
+
+func TestOrgSettingsService_UpdateBusinessHours(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	orgID := uuid.New()
+
+	t.Run("rejects an invalid calendar", func(t *testing.T) {
+		repo := mocks.NewMockOrgSettingsRepository()
+		authz := mocks.NewMockAuthorizationService()
+		audit := mocks.NewMockAuditLogRepository()
+		svc := services.NewOrgSettingsService(repo, mocks.NewMockRetentionRepository(), audit, authz, stubTransactionManager{})
+
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+
+		_, err := svc.UpdateBusinessHours(ctx, actorID, orgID, domain.BusinessHours{Timezone: "Nowhere/Else"})
+
+		var valErrs *apperrors.ValidationErrors
+		assert.ErrorAs(t, err, &valErrs)
+		repo.AssertNotCalled(t, "SaveBusinessHours", mock.Anything, mock.Anything)
+	})
+
+	t.Run("saves the calendar for the admin's organization", func(t *testing.T) {
+		repo := mocks.NewMockOrgSettingsRepository()
+		authz := mocks.NewMockAuthorizationService()
+		audit := mocks.NewMockAuditLogRepository()
+		svc := services.NewOrgSettingsService(repo, mocks.NewMockRetentionRepository(), audit, authz, stubTransactionManager{})
+
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		repo.On("GetBusinessHours", ctx, orgID).Return(nil, apperrors.ErrNotFound)
+		audit.On("Create", ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+			return e.Action == domain.AuditBusinessHoursUpdated && e.TargetID == orgID.String() &&
+				e.Before == nil && strings.Contains(string(e.After), "Europe/Berlin")
+		})).Return(nil)
+		repo.On("SaveBusinessHours", ctx, mock.MatchedBy(func(h *domain.BusinessHours) bool {
+			return h.OrganizationID == orgID && h.Timezone == "Europe/Berlin"
+		})).Return(&domain.BusinessHours{OrganizationID: orgID, Timezone: "Europe/Berlin"}, nil)
+
+		hours, err := svc.UpdateBusinessHours(ctx, actorID, orgID, domain.BusinessHours{
+			Timezone: "Europe/Berlin",
+			Days:     []domain.WorkingDay{{Weekday: time.Monday, Open: 8 * 60, Close: 16 * 60}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, orgID, hours.OrganizationID)
+		repo.AssertExpectations(t)
+		audit.AssertExpectations(t)
+	})
+}
+
+func TestOrgSettingsService_UpdateRetentionPolicy(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	orgID := uuid.New()
+
+	t.Run("rejects a retention period that is too short", func(t *testing.T) {
+		retention := mocks.NewMockRetentionRepository()
+		authz := mocks.NewMockAuthorizationService()
+		svc := services.NewOrgSettingsService(mocks.NewMockOrgSettingsRepository(), retention, mocks.NewMockAuditLogRepository(), authz, stubTransactionManager{})
+
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		days := 7
+
+		_, err := svc.UpdateRetentionPolicy(ctx, actorID, orgID, domain.RetentionPolicy{ClosedTicketDays: &days, GraceDays: 30})
+
+		var valErrs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &valErrs)
+		assert.Contains(t, valErrs.Errors, "closedTicketDays")
+		retention.AssertNotCalled(t, "SavePolicy", mock.Anything, mock.Anything)
+	})
+
+	t.Run("saves and audits the policy", func(t *testing.T) {
+		retention := mocks.NewMockRetentionRepository()
+		authz := mocks.NewMockAuthorizationService()
+		audit := mocks.NewMockAuditLogRepository()
+		svc := services.NewOrgSettingsService(mocks.NewMockOrgSettingsRepository(), retention, audit, authz, stubTransactionManager{})
+
+		days := 730
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		retention.On("GetPolicy", ctx, orgID).Return(nil, apperrors.ErrNotFound)
+		retention.On("SavePolicy", ctx, mock.MatchedBy(func(p *domain.RetentionPolicy) bool {
+			return p.OrganizationID == orgID && *p.ClosedTicketDays == 730 && p.GraceDays == 14
+		})).Return(&domain.RetentionPolicy{OrganizationID: orgID, ClosedTicketDays: &days, GraceDays: 14}, nil)
+		audit.On("Create", ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+			return e.Action == domain.AuditRetentionPolicyUpdated &&
+				strings.Contains(string(e.Before), `"closedTicketDays":null`) &&
+				strings.Contains(string(e.After), `"closedTicketDays":730`)
+		})).Return(nil)
+
+		policy, err := svc.UpdateRetentionPolicy(ctx, actorID, orgID, domain.RetentionPolicy{ClosedTicketDays: &days, GraceDays: 14})
+
+		require.NoError(t, err)
+		assert.Equal(t, 730, *policy.ClosedTicketDays)
+		retention.AssertExpectations(t)
+		audit.AssertExpectations(t)
+	})
+}
+
+func TestOrgSettingsService_UpdateAgentCapacity(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	orgID := uuid.New()
+
+	t.Run("saves and audits the limit", func(t *testing.T) {
+		repo := mocks.NewMockOrgSettingsRepository()
+		authz := mocks.NewMockAuthorizationService()
+		audit := mocks.NewMockAuditLogRepository()
+		svc := services.NewOrgSettingsService(repo, mocks.NewMockRetentionRepository(), audit, authz, stubTransactionManager{})
+
+		limit := 15
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		repo.On("GetAgentCapacity", ctx, orgID).Return(domain.DefaultAgentCapacity(orgID), nil)
+		repo.On("SaveAgentCapacity", ctx, mock.MatchedBy(func(c *domain.AgentCapacity) bool {
+			return c.OrganizationID == orgID && *c.MaxOpenTickets == 15 && c.Enforcement == domain.CapacityBlock
+		})).Return(nil)
+		audit.On("Create", ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+			return e.Action == domain.AuditAgentCapacityUpdated &&
+				strings.Contains(string(e.Before), `"maxOpenTickets":null`) &&
+				strings.Contains(string(e.After), `"maxOpenTickets":15`)
+		})).Return(nil)
+
+		capacity, err := svc.UpdateAgentCapacity(ctx, actorID, orgID, domain.AgentCapacity{MaxOpenTickets: &limit, Enforcement: domain.CapacityBlock})
+
+		require.NoError(t, err)
+		assert.Equal(t, orgID, capacity.OrganizationID)
+		repo.AssertExpectations(t)
+		audit.AssertExpectations(t)
+	})
+}
+
+func TestOrgSettingsService_ListUpcomingPurges(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	orgID := uuid.New()
+
+	t.Run("lists nothing without a retention period", func(t *testing.T) {
+		retention := mocks.NewMockRetentionRepository()
+		authz := mocks.NewMockAuthorizationService()
+		svc := services.NewOrgSettingsService(mocks.NewMockOrgSettingsRepository(), retention, mocks.NewMockAuditLogRepository(), authz, stubTransactionManager{})
+
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		retention.On("GetPolicy", ctx, orgID).Return(nil, apperrors.ErrNotFound)
+
+		candidates, total, err := svc.ListUpcomingPurges(ctx, actorID, orgID, 30, 25, 0)
+
+		require.NoError(t, err)
+		assert.Empty(t, candidates)
+		assert.Zero(t, total)
+		retention.AssertNotCalled(t, "ListPurgeCandidates", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("schedules each candidate", func(t *testing.T) {
+		retention := mocks.NewMockRetentionRepository()
+		authz := mocks.NewMockAuthorizationService()
+		svc := services.NewOrgSettingsService(mocks.NewMockOrgSettingsRepository(), retention, mocks.NewMockAuditLogRepository(), authz, stubTransactionManager{})
+
+		days := 365
+		closedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+		deletedAt := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		retention.On("GetPolicy", ctx, orgID).Return(&domain.RetentionPolicy{OrganizationID: orgID, ClosedTicketDays: &days, GraceDays: 30}, nil)
+		retention.On("ListPurgeCandidates", ctx, orgID, mock.MatchedBy(func(closedBefore time.Time) bool {
+			return closedBefore.Sub(time.Now().AddDate(0, 0, 7-365)).Abs() < time.Minute
+		}), 25, 0).Return([]*domain.PurgeCandidate{
+			{TicketID: 1, ClosedAt: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), DeletedAt: &deletedAt},
+			{TicketID: 2, ClosedAt: closedAt},
+		}, domain.Total{Count: 2}, nil)
+
+		candidates, total, err := svc.ListUpcomingPurges(ctx, actorID, orgID, 7, 25, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.Total{Count: 2}, total)
+		assert.Equal(t, deletedAt, candidates[0].SoftDeleteAt)
+		assert.Equal(t, deletedAt.AddDate(0, 0, 30), candidates[0].HardDeleteAt)
+		assert.Equal(t, closedAt.AddDate(0, 0, 365), candidates[1].SoftDeleteAt)
+		assert.Equal(t, closedAt.AddDate(0, 0, 395), candidates[1].HardDeleteAt)
+	})
+}
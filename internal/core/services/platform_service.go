@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+const (
+	// defaultOrganizationListLimit is how many organizations are listed when
+	// no limit is given
+	defaultOrganizationListLimit = 50
+	// maxOrganizationListLimit caps how many organizations can be listed at once
+	maxOrganizationListLimit = 200
+)
+
+// PlatformService lets super-admins, who hold platform:access, look after
+// every organization: list them, check their usage and health, and sign in
+// as their admins to help out. Everything a super-admin sees or does this
+// way is recorded in the audit log.
+type PlatformService struct {
+	platformRepo ports.PlatformRepository
+	settingsRepo ports.OrgSettingsRepository
+	userRepo     ports.UserRepository
+	authzSvc     ports.AuthorizationService
+	quotaSvc     ports.QuotaService
+	auditRepo    ports.AuditLogRepository
+	now          func() time.Time
+}
+
+var _ ports.PlatformService = (*PlatformService)(nil)
+
+// NewPlatformService creates a new PlatformService.
+func NewPlatformService(
+	platformRepo ports.PlatformRepository,
+	settingsRepo ports.OrgSettingsRepository,
+	userRepo ports.UserRepository,
+	authzSvc ports.AuthorizationService,
+	quotaSvc ports.QuotaService,
+	auditRepo ports.AuditLogRepository,
+) ports.PlatformService {
+	return &PlatformService{
+		platformRepo: platformRepo,
+		settingsRepo: settingsRepo,
+		userRepo:     userRepo,
+		authzSvc:     authzSvc,
+		quotaSvc:     quotaSvc,
+		auditRepo:    auditRepo,
+		now:          time.Now,
+	}
+}
+
+// ListOrganizations returns a page of the organizations matching filter,
+// with their usage, and the total number of matches. The listing is
+// recorded in the audit log of the super-admin's own organization.
+func (s *PlatformService) ListOrganizations(ctx context.Context, actorID, actorOrgID uuid.UUID, filter domain.OrganizationFilter) ([]*domain.OrganizationSummary, domain.Total, error) {
+	if err := s.requirePlatform(ctx, actorID); err != nil {
+		return nil, domain.Total{}, err
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultOrganizationListLimit
+	}
+	if filter.Limit > maxOrganizationListLimit {
+		filter.Limit = maxOrganizationListLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	filter.Query = strings.TrimSpace(filter.Query)
+
+	organizations, total, err := s.platformRepo.ListOrganizations(ctx, filter)
+	if err != nil {
+		return nil, domain.Total{}, err
+	}
+
+	if err := s.recordAudit(ctx, actorOrgID, actorID, domain.AuditOrganizationsListed, domain.AuditTargetOrganization, "",
+		map[string]any{"query": filter.Query, "limit": filter.Limit, "offset": filter.Offset},
+	); err != nil {
+		return nil, domain.Total{}, err
+	}
+	return organizations, total, nil
+}
+
+// GetOrganization returns an organization with its quota, usage and health.
+func (s *PlatformService) GetOrganization(ctx context.Context, actorID, orgID uuid.UUID) (*domain.OrganizationOverview, error) {
+	if err := s.requirePlatform(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	org, err := s.settingsRepo.GetOrganization(ctx, orgID)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return nil, apperrors.ErrOrganizationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	report, err := s.quotaSvc.Report(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	health, err := s.platformRepo.GetHealth(ctx, orgID, s.now().UTC().Add(-domain.OrganizationHealthWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.recordAudit(ctx, orgID, actorID, domain.AuditOrganizationViewed, domain.AuditTargetOrganization, orgID.String(), nil); err != nil {
+		return nil, err
+	}
+	return &domain.OrganizationOverview{Organization: *org, Quota: *report, Health: *health}, nil
+}
+
+// Impersonate lets the super-admin act as userID, who must be an active
+// admin of the organization. Other super-admins cannot be impersonated.
+func (s *PlatformService) Impersonate(ctx context.Context, actorID, orgID, userID uuid.UUID) (*domain.User, error) {
+	if err := s.requirePlatform(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.impersonationTarget(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.recordAudit(ctx, orgID, actorID, domain.AuditUserImpersonated, domain.AuditTargetUser, userID.String(), nil); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// impersonationTarget returns the user if they are an active admin of the
+// organization without platform access of their own.
+func (s *PlatformService) impersonationTarget(ctx context.Context, orgID, userID uuid.UUID) (*domain.User, error) {
+	invalid := func() error {
+		errs := apperrors.NewValidationErrors()
+		errs.Add("userId", "Must be an active admin of the organization")
+		return errs
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, apperrors.ErrUserNotFound) {
+		return nil, invalid()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.OrganizationID != orgID || !user.IsActive {
+		return nil, invalid()
+	}
+
+	admin, err := s.authzSvc.Can(ctx, userID, "admin:access")
+	if err != nil {
+		return nil, err
+	}
+	platform, err := s.authzSvc.Can(ctx, userID, "platform:access")
+	if err != nil {
+		return nil, err
+	}
+	if !admin || platform {
+		return nil, invalid()
+	}
+	return user, nil
+}
+
+func (s *PlatformService) recordAudit(ctx context.Context, orgID, actorID uuid.UUID, action domain.AuditAction, targetType, targetID string, after any) error {
+	entry, err := domain.NewAuditEntry(ctx, orgID, actorID, action, targetType, targetID, nil, after)
+	if err != nil {
+		return err
+	}
+	return s.auditRepo.Create(ctx, entry)
+}
+
+func (s *PlatformService) requirePlatform(ctx context.Context, actorID uuid.UUID) error {
+	allowed, err := s.authzSvc.Can(ctx, actorID, "platform:access")
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return apperrors.ErrForbidden
+	}
+	return nil
+}
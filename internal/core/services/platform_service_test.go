@@ -0,0 +1,178 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type platformMocks struct {
+	platform *mocks.MockPlatformRepository
+	settings *mocks.MockOrgSettingsRepository
+	users    *mocks.MockUserRepository
+	authz    *mocks.MockAuthorizationService
+	quota    *mocks.MockQuotaService
+	audit    *mocks.MockAuditLogRepository
+}
+
+func newPlatformService() (ports.PlatformService, platformMocks) {
+	m := platformMocks{
+		platform: mocks.NewMockPlatformRepository(),
+		settings: mocks.NewMockOrgSettingsRepository(),
+		users:    mocks.NewMockUserRepository(),
+		authz:    mocks.NewMockAuthorizationService(),
+		quota:    mocks.NewMockQuotaService(),
+		audit:    mocks.NewMockAuditLogRepository(),
+	}
+	return services.NewPlatformService(m.platform, m.settings, m.users, m.authz, m.quota, m.audit), m
+}
+
+func auditedAs(action domain.AuditAction, orgID uuid.UUID) any {
+	return mock.MatchedBy(func(e *domain.AuditEntry) bool {
+		return e.Action == action && e.OrganizationID == orgID
+	})
+}
+
+func TestPlatformService_ListOrganizations(t *testing.T) {
+	ctx := context.Background()
+	actorID, actorOrgID := uuid.New(), uuid.New()
+
+	t.Run("lists organizations and records the listing", func(t *testing.T) {
+		svc, m := newPlatformService()
+		summaries := []*domain.OrganizationSummary{{Organization: domain.Organization{ID: uuid.New(), Name: "Acme"}}}
+
+		m.authz.On("Can", ctx, actorID, "platform:access").Return(true, nil)
+		m.platform.On("ListOrganizations", ctx, domain.OrganizationFilter{Query: "acme", Limit: 200}).
+			Return(summaries, domain.Total{Count: 1}, nil)
+		m.audit.On("Create", ctx, auditedAs(domain.AuditOrganizationsListed, actorOrgID)).Return(nil)
+
+		got, total, err := svc.ListOrganizations(ctx, actorID, actorOrgID, domain.OrganizationFilter{Query: " acme ", Limit: 500, Offset: -1})
+
+		require.NoError(t, err)
+		assert.Equal(t, summaries, got)
+		assert.Equal(t, int64(1), total.Count)
+		m.audit.AssertExpectations(t)
+	})
+
+	t.Run("requires platform access", func(t *testing.T) {
+		svc, m := newPlatformService()
+
+		m.authz.On("Can", ctx, actorID, "platform:access").Return(false, nil)
+
+		_, _, err := svc.ListOrganizations(ctx, actorID, actorOrgID, domain.OrganizationFilter{})
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		m.platform.AssertNotCalled(t, "ListOrganizations", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPlatformService_GetOrganization(t *testing.T) {
+	ctx := context.Background()
+	actorID, orgID := uuid.New(), uuid.New()
+
+	t.Run("returns quota, usage and health and records the view", func(t *testing.T) {
+		svc, m := newPlatformService()
+		report := &domain.QuotaReport{Usage: domain.OrgUsage{ActiveUsers: 3, OpenTickets: 7}}
+		health := &domain.OrganizationHealth{TicketsCreated: 12, OverdueTickets: 2}
+
+		m.authz.On("Can", ctx, actorID, "platform:access").Return(true, nil)
+		m.settings.On("GetOrganization", ctx, orgID).Return(&domain.Organization{ID: orgID, Name: "Acme"}, nil)
+		m.quota.On("Report", ctx, orgID).Return(report, nil)
+		m.platform.On("GetHealth", ctx, orgID, mock.AnythingOfType("time.Time")).Return(health, nil)
+		m.audit.On("Create", ctx, auditedAs(domain.AuditOrganizationViewed, orgID)).Return(nil)
+
+		overview, err := svc.GetOrganization(ctx, actorID, orgID)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Acme", overview.Organization.Name)
+		assert.Equal(t, int64(7), overview.Quota.Usage.OpenTickets)
+		assert.Equal(t, int64(12), overview.Health.TicketsCreated)
+		m.audit.AssertExpectations(t)
+	})
+
+	t.Run("reports an unknown organization", func(t *testing.T) {
+		svc, m := newPlatformService()
+
+		m.authz.On("Can", ctx, actorID, "platform:access").Return(true, nil)
+		m.settings.On("GetOrganization", ctx, orgID).Return(nil, apperrors.ErrNotFound)
+
+		_, err := svc.GetOrganization(ctx, actorID, orgID)
+
+		assert.ErrorIs(t, err, apperrors.ErrOrganizationNotFound)
+		m.audit.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPlatformService_Impersonate(t *testing.T) {
+	ctx := context.Background()
+	actorID, orgID := uuid.New(), uuid.New()
+
+	t.Run("returns the admin and records the impersonation", func(t *testing.T) {
+		svc, m := newPlatformService()
+		admin := &domain.User{ID: uuid.New(), OrganizationID: orgID, IsActive: true}
+
+		m.authz.On("Can", ctx, actorID, "platform:access").Return(true, nil)
+		m.users.On("GetByID", ctx, admin.ID).Return(admin, nil)
+		m.authz.On("Can", ctx, admin.ID, "admin:access").Return(true, nil)
+		m.authz.On("Can", ctx, admin.ID, "platform:access").Return(false, nil)
+		m.audit.On("Create", ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+			return e.Action == domain.AuditUserImpersonated && e.OrganizationID == orgID &&
+				e.ActorID == actorID && e.TargetID == admin.ID.String()
+		})).Return(nil)
+
+		user, err := svc.Impersonate(ctx, actorID, orgID, admin.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, admin, user)
+		m.audit.AssertExpectations(t)
+	})
+
+	t.Run("refuses users who are not an active admin of the organization", func(t *testing.T) {
+		cases := map[string]struct {
+			user     *domain.User
+			admin    bool
+			platform bool
+		}{
+			"another organization": {user: &domain.User{ID: uuid.New(), OrganizationID: uuid.New(), IsActive: true}, admin: true},
+			"inactive":             {user: &domain.User{ID: uuid.New(), OrganizationID: orgID}, admin: true},
+			"not an admin":         {user: &domain.User{ID: uuid.New(), OrganizationID: orgID, IsActive: true}},
+			"a super-admin":        {user: &domain.User{ID: uuid.New(), OrganizationID: orgID, IsActive: true}, admin: true, platform: true},
+		}
+		for name, tc := range cases {
+			t.Run(name, func(t *testing.T) {
+				svc, m := newPlatformService()
+
+				m.authz.On("Can", ctx, actorID, "platform:access").Return(true, nil)
+				m.users.On("GetByID", ctx, tc.user.ID).Return(tc.user, nil)
+				m.authz.On("Can", ctx, tc.user.ID, "admin:access").Return(tc.admin, nil)
+				m.authz.On("Can", ctx, tc.user.ID, "platform:access").Return(tc.platform, nil)
+
+				_, err := svc.Impersonate(ctx, actorID, orgID, tc.user.ID)
+
+				var validationErr *apperrors.ValidationErrors
+				assert.ErrorAs(t, err, &validationErr)
+				m.audit.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("requires platform access", func(t *testing.T) {
+		svc, m := newPlatformService()
+
+		m.authz.On("Can", ctx, actorID, "platform:access").Return(false, nil)
+
+		_, err := svc.Impersonate(ctx, actorID, orgID, uuid.New())
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		m.users.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// PortalConfig controls the links emailed to public portal submitters.
+type PortalConfig struct {
+	LinkURL    string // Page the link opens; ?ticket= and ?token= are added
+	LinkSecret []byte
+	LinkTTL    time.Duration
+}
+
+// PortalService takes anonymous ticket submissions through organizations'
+// public portals. Each submitter becomes a guest requester, emailed a link
+// to follow the ticket and reply to it; guests act through that link with
+// the permissions of the guest role.
+type PortalService struct {
+	settingsRepo ports.OrgSettingsRepository
+	userRepo     ports.UserRepository
+	authzRepo    ports.AuthorizationRepository
+	ticketSvc    ports.TicketService
+	commentSvc   ports.CommentService
+	outbox       ports.NotificationOutboxRepository
+	captcha      ports.CaptchaVerifier
+	txManager    ports.TransactionManager
+	cfg          PortalConfig
+	now          func() time.Time
+}
+
+var _ ports.PortalService = (*PortalService)(nil)
+
+// NewPortalService creates a new PortalService.
+func NewPortalService(
+	settingsRepo ports.OrgSettingsRepository,
+	userRepo ports.UserRepository,
+	authzRepo ports.AuthorizationRepository,
+	ticketSvc ports.TicketService,
+	commentSvc ports.CommentService,
+	outbox ports.NotificationOutboxRepository,
+	captcha ports.CaptchaVerifier,
+	txManager ports.TransactionManager,
+	cfg PortalConfig,
+) ports.PortalService {
+	return &PortalService{
+		settingsRepo: settingsRepo,
+		userRepo:     userRepo,
+		authzRepo:    authzRepo,
+		ticketSvc:    ticketSvc,
+		commentSvc:   commentSvc,
+		outbox:       outbox,
+		captcha:      captcha,
+		txManager:    txManager,
+		cfg:          cfg,
+		now:          time.Now,
+	}
+}
+
+// SubmitTicket raises a ticket submitted through the portal at params.Slug
+// and emails the submitter a link to it.
+func (s *PortalService) SubmitTicket(ctx context.Context, params ports.SubmitPortalTicketParams) (*domain.Ticket, error) {
+	// 1. Validate the submission before spending the captcha on it
+	if err := params.Submission.Validate(); err != nil {
+		return nil, err
+	}
+
+	ok, err := s.captcha.Verify(ctx, params.CaptchaToken, params.RemoteIP)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, apperrors.ErrCaptchaFailed
+	}
+
+	// 2. Find the organization and the requester
+	orgID, err := s.settingsRepo.GetOrganizationIDByPortalSlug(ctx, strings.ToLower(params.Slug))
+	if err != nil {
+		return nil, err
+	}
+
+	requester, err := s.guestRequester(ctx, orgID, params.Submission)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Raise the ticket as the requester and email the link in one
+	// transaction: the link is the submitter's only way back to the ticket.
+	// Guests triage nothing, so the priority is left for agents to change.
+	var ticket *domain.Ticket
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		ticket, err = s.ticketSvc.CreateTicket(txCtx, ports.CreateTicketParams{
+			Title:       params.Submission.Title,
+			Description: params.Submission.Description,
+			Priority:    domain.PriorityMedium,
+			RequesterID: requester.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		notification, err := s.linkNotification(ticket)
+		if err != nil {
+			return err
+		}
+		return s.outbox.Enqueue(txCtx, notification)
+	}); err != nil {
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+// GetTicket returns a ticket and its comments to the holder of its access
+// token.
+func (s *PortalService) GetTicket(ctx context.Context, ticketID int64, token string) (*domain.Ticket, []*domain.Comment, error) {
+	link, err := s.parseLink(ticketID, token)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ticket, err := s.ticketSvc.GetTicket(ctx, link.TicketID, link.RequesterID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	comments, err := s.commentSvc.GetCommentsForTicket(ctx, ports.GetCommentsParams{
+		TicketID: link.TicketID,
+		ActorID:  link.RequesterID,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ticket, comments, nil
+}
+
+// AddComment replies to a ticket as its requester, for the holder of its
+// access token.
+func (s *PortalService) AddComment(ctx context.Context, ticketID int64, token, body string) (*domain.Comment, error) {
+	link, err := s.parseLink(ticketID, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.commentSvc.CreateComment(ctx, ports.CreateCommentParams{
+		TicketID: link.TicketID,
+		ActorID:  link.RequesterID,
+		Body:     body,
+	})
+}
+
+// guestRequester returns the user a submission is raised for: the user with
+// the submitted email in the organization if there is one, otherwise a new
+// guest. The guest is committed before the ticket is raised, as permission checks
+// read outside the caller's transaction.
+func (s *PortalService) guestRequester(ctx context.Context, orgID uuid.UUID, submission domain.PortalSubmission) (*domain.User, error) {
+	user, err := s.userRepo.GetByEmail(ctx, orgID, submission.Email)
+	if err == nil {
+		if !user.IsActive {
+			errs := apperrors.NewValidationErrors()
+			errs.Add("email", "This email address can't be used to submit a request here")
+			return nil, errs
+		}
+		return user, nil
+	}
+	if !errors.Is(err, apperrors.ErrUserNotFound) {
+		return nil, err
+	}
+
+	guest := domain.NewGuestUser(submission.FullName, submission.Email, orgID)
+
+	var created *domain.User
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		created, err = s.userRepo.Create(txCtx, guest)
+		if err != nil {
+			return err
+		}
+		return s.authzRepo.AssignRole(txCtx, created.ID, domain.GuestRole)
+	}); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// parseLink checks that token grants access to ticketID. A token for another
+// ticket is as invalid as a forged one.
+func (s *PortalService) parseLink(ticketID int64, token string) (domain.PortalLink, error) {
+	link, err := domain.ParsePortalLink(s.cfg.LinkSecret, token, s.now())
+	if err != nil {
+		return domain.PortalLink{}, err
+	}
+	if link.TicketID != ticketID {
+		return domain.PortalLink{}, apperrors.ErrInvalidPortalLink
+	}
+	return link, nil
+}
+
+// linkNotification emails the requester of a portal ticket the link to it.
+func (s *PortalService) linkNotification(ticket *domain.Ticket) (ports.NotificationParams, error) {
+	link := domain.PortalLink{
+		TicketID:    ticket.ID,
+		RequesterID: ticket.RequesterID,
+		ExpiresAt:   s.now().UTC().Add(s.cfg.LinkTTL),
+	}
+
+	u, err := url.Parse(s.cfg.LinkURL)
+	if err != nil {
+		return ports.NotificationParams{}, fmt.Errorf("parse portal link URL: %w", err)
+	}
+	query := u.Query()
+	query.Set("ticket", strconv.FormatInt(ticket.ID, 10))
+	query.Set("token", link.Sign(s.cfg.LinkSecret))
+	u.RawQuery = query.Encode()
+
+	expires := link.ExpiresAt.Format(time.RFC3339)
+	return ports.NotificationParams{
+		RecipientUserID: ticket.RequesterID,
+		Type:            ports.NotificationPortalLink,
+		Subject:         fmt.Sprintf("Follow your request: #%d", ticket.ID),
+		Message:         "Follow your request and reply to our team at " + u.String() + " until " + expires + ".",
+		TicketID:        ticket.ID,
+		Data: map[string]string{
+			"title":      ticket.Title,
+			"link":       u.String(),
+			"expires_at": expires,
+		},
+	}, nil
+}
@@ -0,0 +1,205 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var portalSecret = []byte("portal-secret")
+
+type portalFixture struct {
+	settingsRepo *mocks.MockOrgSettingsRepository
+	userRepo     *mocks.MockUserRepository
+	authzRepo    *mocks.MockAuthorizationRepository
+	ticketSvc    *mocks.MockTicketService
+	commentSvc   *mocks.MockCommentService
+	outbox       *mocks.MockNotificationOutboxRepository
+	captcha      *mocks.MockCaptchaVerifier
+	svc          ports.PortalService
+}
+
+func newPortalFixture() *portalFixture {
+	f := &portalFixture{
+		settingsRepo: mocks.NewMockOrgSettingsRepository(),
+		userRepo:     mocks.NewMockUserRepository(),
+		authzRepo:    mocks.NewMockAuthorizationRepository(),
+		ticketSvc:    mocks.NewMockTicketService(),
+		commentSvc:   mocks.NewMockCommentService(),
+		outbox:       mocks.NewMockNotificationOutboxRepository(),
+		captcha:      mocks.NewMockCaptchaVerifier(),
+	}
+	f.svc = services.NewPortalService(
+		f.settingsRepo, f.userRepo, f.authzRepo, f.ticketSvc, f.commentSvc, f.outbox, f.captcha,
+		recordingTransactionManager{},
+		services.PortalConfig{
+			LinkURL:    "https://help.example.com/request",
+			LinkSecret: portalSecret,
+			LinkTTL:    time.Hour,
+		},
+	)
+	return f
+}
+
+func portalSubmission() ports.SubmitPortalTicketParams {
+	return ports.SubmitPortalTicketParams{
+		Slug: "Acme",
+		Submission: domain.PortalSubmission{
+			FullName: "Jane Doe",
+			Email:    "jane@example.com",
+			Title:    "Printer is on fire",
+		},
+		CaptchaToken: "captcha",
+		RemoteIP:     "203.0.113.7",
+	}
+}
+
+func TestPortalService_SubmitTicket(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+
+	t.Run("creates a guest and emails the link", func(t *testing.T) {
+		f := newPortalFixture()
+		guestID := uuid.New()
+
+		f.captcha.On("Verify", ctx, "captcha", "203.0.113.7").Return(true, nil)
+		f.settingsRepo.On("GetOrganizationIDByPortalSlug", ctx, "acme").Return(orgID, nil)
+		f.userRepo.On("GetByEmail", ctx, orgID, "jane@example.com").Return(nil, apperrors.ErrUserNotFound)
+		f.userRepo.On("Create", inTx, mock.MatchedBy(func(u *domain.User) bool {
+			return u.OrganizationID == orgID && u.HashedPassword == ""
+		})).Return(&domain.User{ID: guestID, OrganizationID: orgID, IsActive: true}, nil)
+		f.authzRepo.On("AssignRole", inTx, guestID, domain.GuestRole).Return(nil)
+		f.ticketSvc.On("CreateTicket", inTx, mock.MatchedBy(func(p ports.CreateTicketParams) bool {
+			return p.RequesterID == guestID && p.Priority == domain.PriorityMedium
+		})).Return(&domain.Ticket{ID: 7, Title: "Printer is on fire", RequesterID: guestID}, nil)
+
+		var notification ports.NotificationParams
+		f.outbox.On("Enqueue", inTx, mock.AnythingOfType("ports.NotificationParams")).
+			Run(func(args mock.Arguments) { notification = args.Get(1).(ports.NotificationParams) }).
+			Return(nil)
+
+		ticket, err := f.svc.SubmitTicket(ctx, portalSubmission())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), ticket.ID)
+		assert.Equal(t, ports.NotificationPortalLink, notification.Type)
+		assert.Equal(t, guestID, notification.RecipientUserID)
+
+		link, err := url.Parse(notification.Data["link"])
+		require.NoError(t, err)
+		parsed, err := domain.ParsePortalLink(portalSecret, link.Query().Get("token"), time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), parsed.TicketID)
+		assert.Equal(t, guestID, parsed.RequesterID)
+		assert.Equal(t, "7", link.Query().Get("ticket"))
+	})
+
+	t.Run("fails if the link cannot be emailed", func(t *testing.T) {
+		f := newPortalFixture()
+		requesterID := uuid.New()
+
+		f.captcha.On("Verify", ctx, "captcha", "203.0.113.7").Return(true, nil)
+		f.settingsRepo.On("GetOrganizationIDByPortalSlug", ctx, "acme").Return(orgID, nil)
+		f.userRepo.On("GetByEmail", ctx, orgID, "jane@example.com").
+			Return(&domain.User{ID: requesterID, OrganizationID: orgID, IsActive: true}, nil)
+		f.ticketSvc.On("CreateTicket", inTx, mock.Anything).
+			Return(&domain.Ticket{ID: 7, RequesterID: requesterID}, nil)
+		f.outbox.On("Enqueue", inTx, mock.Anything).Return(errors.New("outbox unavailable"))
+
+		_, err := f.svc.SubmitTicket(ctx, portalSubmission())
+
+		assert.EqualError(t, err, "outbox unavailable")
+	})
+
+	t.Run("rejects a failed captcha", func(t *testing.T) {
+		f := newPortalFixture()
+		f.captcha.On("Verify", ctx, "captcha", "203.0.113.7").Return(false, nil)
+
+		_, err := f.svc.SubmitTicket(ctx, portalSubmission())
+
+		assert.ErrorIs(t, err, apperrors.ErrCaptchaFailed)
+		f.settingsRepo.AssertNotCalled(t, "GetOrganizationIDByPortalSlug", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects the email of a deactivated user", func(t *testing.T) {
+		f := newPortalFixture()
+		f.captcha.On("Verify", ctx, "captcha", "203.0.113.7").Return(true, nil)
+		f.settingsRepo.On("GetOrganizationIDByPortalSlug", ctx, "acme").Return(orgID, nil)
+		f.userRepo.On("GetByEmail", ctx, orgID, "jane@example.com").
+			Return(&domain.User{ID: uuid.New(), OrganizationID: orgID, IsActive: false}, nil)
+
+		_, err := f.svc.SubmitTicket(ctx, portalSubmission())
+
+		var errs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Contains(t, errs.Errors, "email")
+		f.ticketSvc.AssertNotCalled(t, "CreateTicket", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPortalService_GetTicket(t *testing.T) {
+	ctx := context.Background()
+	requesterID := uuid.New()
+
+	t.Run("reads as the requester", func(t *testing.T) {
+		f := newPortalFixture()
+		token := domain.PortalLink{TicketID: 7, RequesterID: requesterID, ExpiresAt: time.Now().Add(time.Hour)}.Sign(portalSecret)
+
+		f.ticketSvc.On("GetTicket", ctx, int64(7), requesterID).Return(&domain.Ticket{ID: 7, RequesterID: requesterID}, nil)
+		f.commentSvc.On("GetCommentsForTicket", ctx, ports.GetCommentsParams{TicketID: 7, ActorID: requesterID}).
+			Return([]*domain.Comment{{ID: 1, AuthorID: requesterID}}, nil)
+
+		ticket, comments, err := f.svc.GetTicket(ctx, 7, token)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), ticket.ID)
+		assert.Len(t, comments, 1)
+	})
+
+	t.Run("rejects a token for another ticket", func(t *testing.T) {
+		f := newPortalFixture()
+		token := domain.PortalLink{TicketID: 8, RequesterID: requesterID, ExpiresAt: time.Now().Add(time.Hour)}.Sign(portalSecret)
+
+		_, _, err := f.svc.GetTicket(ctx, 7, token)
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidPortalLink)
+		f.ticketSvc.AssertNotCalled(t, "GetTicket", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an expired link", func(t *testing.T) {
+		f := newPortalFixture()
+		token := domain.PortalLink{TicketID: 7, RequesterID: requesterID, ExpiresAt: time.Now().Add(-time.Minute)}.Sign(portalSecret)
+
+		_, _, err := f.svc.GetTicket(ctx, 7, token)
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidPortalLink)
+		f.ticketSvc.AssertNotCalled(t, "GetTicket", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestPortalService_AddComment(t *testing.T) {
+	ctx := context.Background()
+	requesterID := uuid.New()
+	f := newPortalFixture()
+	token := domain.PortalLink{TicketID: 7, RequesterID: requesterID, ExpiresAt: time.Now().Add(time.Hour)}.Sign(portalSecret)
+
+	f.commentSvc.On("CreateComment", ctx, ports.CreateCommentParams{TicketID: 7, ActorID: requesterID, Body: "Still broken"}).
+		Return(&domain.Comment{ID: 3, TicketID: 7, AuthorID: requesterID, Body: "Still broken"}, nil)
+
+	comment, err := f.svc.AddComment(ctx, 7, token, "Still broken")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), comment.ID)
+}
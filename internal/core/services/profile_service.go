@@ -0,0 +1,397 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/i18n"
+)
+
+// EmailChangeConfig controls the links that confirm a change of email.
+type EmailChangeConfig struct {
+	ConfirmURL string // Page the link opens; the token is added as ?token=. Empty disables email changes
+	Secret     []byte
+	LinkTTL    time.Duration
+}
+
+// ProfileService manages a user's own profile settings.
+type ProfileService struct {
+	userRepo      ports.UserRepository
+	authzRepo     ports.AuthorizationRepository
+	settingsRepo  ports.OrgSettingsRepository
+	emailPrefRepo ports.EmailPreferenceRepository
+	auditRepo     ports.AuditLogRepository
+	outbox        ports.NotificationOutboxRepository
+	txManager     ports.TransactionManager
+	emailChange   EmailChangeConfig
+	now           func() time.Time
+}
+
+var _ ports.ProfileService = (*ProfileService)(nil)
+
+// NewProfileService creates a new ProfileService.
+func NewProfileService(
+	userRepo ports.UserRepository,
+	authzRepo ports.AuthorizationRepository,
+	settingsRepo ports.OrgSettingsRepository,
+	emailPrefRepo ports.EmailPreferenceRepository,
+	auditRepo ports.AuditLogRepository,
+	outbox ports.NotificationOutboxRepository,
+	txManager ports.TransactionManager,
+	emailChange EmailChangeConfig,
+) ports.ProfileService {
+	return &ProfileService{
+		userRepo:      userRepo,
+		authzRepo:     authzRepo,
+		settingsRepo:  settingsRepo,
+		emailPrefRepo: emailPrefRepo,
+		auditRepo:     auditRepo,
+		outbox:        outbox,
+		txManager:     txManager,
+		emailChange:   emailChange,
+		now:           time.Now,
+	}
+}
+
+// GetCurrentUser returns the user's profile together with their roles,
+// permissions, organization and email preferences.
+func (s *ProfileService) GetCurrentUser(ctx context.Context, userID uuid.UUID) (*domain.CurrentUser, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := s.authzRepo.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := s.authzRepo.GetUserPermissions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := s.settingsRepo.GetOrganization(ctx, user.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	emailPrefs, err := s.emailPrefRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CurrentUser{
+		User:             user,
+		Roles:            roles,
+		Permissions:      permissions,
+		Organization:     org,
+		EmailPreferences: emailPrefs,
+	}, nil
+}
+
+// GetSMSPreferences returns the user's SMS notification settings.
+func (s *ProfileService) GetSMSPreferences(ctx context.Context, userID uuid.UUID) (*domain.SMSPreferences, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.SMSPreferences{
+		PhoneNumber: user.PhoneNumber,
+		OptIn:       user.SMSOptIn,
+	}, nil
+}
+
+// UpdateSMSPreferences validates and stores the user's SMS notification settings.
+func (s *ProfileService) UpdateSMSPreferences(ctx context.Context, userID uuid.UUID, prefs domain.SMSPreferences) (*domain.SMSPreferences, error) {
+	prefs.PhoneNumber = strings.TrimSpace(prefs.PhoneNumber)
+	if err := prefs.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.UpdateSMSPreferences(ctx, userID, prefs); err != nil {
+		return nil, err
+	}
+
+	return &prefs, nil
+}
+
+// GetLocale returns the user's preferred locale.
+func (s *ProfileService) GetLocale(ctx context.Context, userID uuid.UUID) (string, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	if !i18n.IsSupported(user.Locale) {
+		return i18n.DefaultLocale, nil
+	}
+	return user.Locale, nil
+}
+
+// UpdateLocale validates and stores the user's preferred locale. Regional
+// tags such as "es-MX" are stored as their supported base language.
+func (s *ProfileService) UpdateLocale(ctx context.Context, userID uuid.UUID, locale string) (string, error) {
+	normalized := i18n.Normalize(locale)
+	if normalized == "" {
+		errs := apperrors.NewValidationErrors()
+		errs.Add("locale", "Locale must be one of: "+strings.Join(i18n.Locales(), ", "))
+		return "", errs
+	}
+
+	if err := s.userRepo.UpdateLocale(ctx, userID, normalized); err != nil {
+		return "", err
+	}
+
+	return normalized, nil
+}
+
+// UpdateProfile validates and saves a partial update of the user's own
+// profile. Text fields are trimmed and the locale is normalized as in
+// UpdateLocale.
+func (s *ProfileService) UpdateProfile(ctx context.Context, userID uuid.UUID, changes domain.ProfileChanges) (*domain.User, error) {
+	if changes.IsEmpty() {
+		errs := apperrors.NewValidationErrors()
+		errs.Add("body", "At least one field must be changed")
+		return nil, errs
+	}
+
+	changes.FullName = trimmed(changes.FullName)
+	changes.PhoneNumber = trimmed(changes.PhoneNumber)
+	changes.Timezone = trimmed(changes.Timezone)
+	if changes.Locale != nil {
+		normalized := i18n.Normalize(*changes.Locale)
+		if normalized == "" {
+			errs := apperrors.NewValidationErrors()
+			errs.Add("locale", "Locale must be one of: "+strings.Join(i18n.Locales(), ", "))
+			return nil, errs
+		}
+		changes.Locale = &normalized
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := user.ApplyProfile(changes); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.UpdateProfile(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// trimmed returns a pointer to value with surrounding whitespace removed.
+func trimmed(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	t := strings.TrimSpace(*value)
+	return &t
+}
+
+// GetAvailability returns the user's upcoming out-of-office windows.
+func (s *ProfileService) GetAvailability(ctx context.Context, userID uuid.UUID) (*domain.Availability, error) {
+	return s.userRepo.GetAvailability(ctx, userID)
+}
+
+// UpdateAvailability replaces the user's out-of-office windows. Windows that
+// have already ended are dropped.
+func (s *ProfileService) UpdateAvailability(ctx context.Context, userID uuid.UUID, availability domain.Availability) (*domain.Availability, error) {
+	availability.UserID = userID
+	for i := range availability.Windows {
+		availability.Windows[i].Note = strings.TrimSpace(availability.Windows[i].Note)
+	}
+	if err := availability.Validate(s.now()); err != nil {
+		return nil, err
+	}
+
+	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		return s.userRepo.SaveAvailability(txCtx, &availability)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &availability, nil
+}
+
+// RequestEmailChange starts changing the user's login email to newEmail,
+// after confirming their current password. Nothing changes until the link
+// emailed to the new address is confirmed with ConfirmEmailChange.
+func (s *ProfileService) RequestEmailChange(ctx context.Context, userID uuid.UUID, newEmail, password string) error {
+	if s.emailChange.ConfirmURL == "" {
+		return apperrors.ErrFeatureDisabled
+	}
+	if password == "" {
+		return apperrors.ErrPasswordRequired
+	}
+
+	// 1. Confirm it is the user asking
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.CheckPassword(password) {
+		return apperrors.ErrInvalidCredentials
+	}
+
+	// 2. Check the new address as registration would
+	change, err := domain.NewEmailChange(user, strings.TrimSpace(newEmail), s.now().UTC().Add(s.emailChange.LinkTTL))
+	if err != nil {
+		return err
+	}
+
+	domains, err := s.settingsRepo.GetRegistrationDomains(ctx, user.OrganizationID)
+	if err != nil {
+		return err
+	}
+	if !domain.EmailDomainAllowed(change.NewEmail, domains) {
+		errs := apperrors.NewValidationErrors()
+		errs.Add("newEmail", "Email addresses are restricted to "+strings.Join(domains, ", "))
+		return errs
+	}
+
+	_, err = s.userRepo.GetByEmail(ctx, user.OrganizationID, change.NewEmail)
+	if err == nil {
+		return apperrors.ErrUserExists
+	}
+	if !errors.Is(err, apperrors.ErrUserNotFound) {
+		return err
+	}
+
+	// 3. Email the confirmation link to the new address
+	notification, err := s.emailChangeNotification(change)
+	if err != nil {
+		return err
+	}
+	return s.outbox.Enqueue(ctx, notification)
+}
+
+// ConfirmEmailChange changes a user's login email as requested with
+// RequestEmailChange. The change is audit-logged and the previous address
+// is told about it.
+func (s *ProfileService) ConfirmEmailChange(ctx context.Context, token string) (*domain.User, error) {
+	change, err := domain.ParseEmailChange(s.emailChange.Secret, token, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, change.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, apperrors.ErrUserInactive
+	}
+	// The link is spent once the email has changed, or superseded if it has
+	// changed some other way since
+	if user.Email != change.CurrentEmail {
+		return nil, apperrors.ErrInvalidEmailChangeToken
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.userRepo.UpdateEmail(txCtx, user.ID, change.NewEmail); err != nil {
+			return err
+		}
+
+		entry, err := domain.NewAuditEntry(txCtx, user.OrganizationID, user.ID, domain.AuditUserEmailChanged,
+			domain.AuditTargetUser, user.ID.String(),
+			map[string]string{"email": change.CurrentEmail},
+			map[string]string{"email": change.NewEmail})
+		if err != nil {
+			return err
+		}
+		if err := s.auditRepo.Create(txCtx, entry); err != nil {
+			return err
+		}
+
+		return s.outbox.Enqueue(txCtx, ports.NotificationParams{
+			RecipientUserID: user.ID,
+			Type:            ports.NotificationEmailChanged,
+			Subject:         "Your email address was changed",
+			Message:         "The email address you sign in with was changed from " + change.CurrentEmail + " to " + change.NewEmail + ".",
+			Data: map[string]string{
+				ports.NotificationDataRecipientEmail: change.CurrentEmail,
+				"old_email":                          change.CurrentEmail,
+				"new_email":                          change.NewEmail,
+			},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	user.Email = change.NewEmail
+	return user, nil
+}
+
+// emailChangeNotification emails the confirmation link for a change to the
+// new address.
+func (s *ProfileService) emailChangeNotification(change domain.EmailChange) (ports.NotificationParams, error) {
+	u, err := url.Parse(s.emailChange.ConfirmURL)
+	if err != nil {
+		return ports.NotificationParams{}, fmt.Errorf("parse email change confirm URL: %w", err)
+	}
+	query := u.Query()
+	query.Set("token", change.Sign(s.emailChange.Secret))
+	u.RawQuery = query.Encode()
+
+	expires := change.ExpiresAt.Format(time.RFC3339)
+	return ports.NotificationParams{
+		RecipientUserID: change.UserID,
+		Type:            ports.NotificationEmailChange,
+		Subject:         "Confirm your new email address",
+		Message:         "Confirm the change of your email address to " + change.NewEmail + " at " + u.String() + " until " + expires + ".",
+		Data: map[string]string{
+			ports.NotificationDataRecipientEmail: change.NewEmail,
+			"old_email":                          change.CurrentEmail,
+			"new_email":                          change.NewEmail,
+			"link":                               u.String(),
+			"expires_at":                         expires,
+		},
+	}, nil
+}
+
+// DeleteAccount anonymizes the user's own account after confirming their
+// current password. Their tickets and comments are kept, attributed to the
+// anonymized account.
+func (s *ProfileService) DeleteAccount(ctx context.Context, userID uuid.UUID, password string) error {
+	if password == "" {
+		return apperrors.ErrPasswordRequired
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.CheckPassword(password) {
+		return apperrors.ErrInvalidCredentials
+	}
+
+	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.userRepo.Anonymize(txCtx, userID, time.Now().UTC()); err != nil {
+			return err
+		}
+
+		entry, err := domain.NewAuditEntry(txCtx, user.OrganizationID, userID, domain.AuditUserAnonymized,
+			domain.AuditTargetUser, userID.String(), nil, nil)
+		if err != nil {
+			return err
+		}
+		return s.auditRepo.Create(txCtx, entry)
+	})
+}
@@ -0,0 +1,256 @@
+package services_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var emailChangeSecret = []byte("email-change-secret")
+
+type profileFixture struct {
+	userRepo      *mocks.MockUserRepository
+	authzRepo     *mocks.MockAuthorizationRepository
+	settingsRepo  *mocks.MockOrgSettingsRepository
+	emailPrefRepo *mocks.MockEmailPreferenceRepository
+	auditRepo     *mocks.MockAuditLogRepository
+	outbox        *mocks.MockNotificationOutboxRepository
+	svc           ports.ProfileService
+}
+
+func newProfileFixture(confirmURL string) *profileFixture {
+	f := &profileFixture{
+		userRepo:      mocks.NewMockUserRepository(),
+		authzRepo:     mocks.NewMockAuthorizationRepository(),
+		settingsRepo:  mocks.NewMockOrgSettingsRepository(),
+		emailPrefRepo: mocks.NewMockEmailPreferenceRepository(),
+		auditRepo:     mocks.NewMockAuditLogRepository(),
+		outbox:        mocks.NewMockNotificationOutboxRepository(),
+	}
+	f.svc = services.NewProfileService(f.userRepo, f.authzRepo, f.settingsRepo, f.emailPrefRepo, f.auditRepo, f.outbox, stubTransactionManager{},
+		services.EmailChangeConfig{
+			ConfirmURL: confirmURL,
+			Secret:     emailChangeSecret,
+			LinkTTL:    time.Hour,
+		})
+	return f
+}
+
+func newProfileUser(t *testing.T) *domain.User {
+	t.Helper()
+	user, err := domain.NewUser(domain.UserRegistrationParams{
+		FullName: "Jane Doe",
+		Email:    "jane@example.com",
+		Password: "Password1",
+	}, uuid.New())
+	require.NoError(t, err)
+	return user
+}
+
+func TestProfileService_GetCurrentUser(t *testing.T) {
+	ctx := context.Background()
+	f := newProfileFixture("")
+	user := newProfileUser(t)
+	org := &domain.Organization{ID: user.OrganizationID, Name: "Acme", PortalSlug: "acme"}
+
+	f.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	f.authzRepo.On("GetUserRoles", ctx, user.ID).Return([]string{"agent"}, nil)
+	f.authzRepo.On("GetUserPermissions", ctx, user.ID).Return([]string{"tickets:read", "tickets:assign"}, nil)
+	f.settingsRepo.On("GetOrganization", ctx, user.OrganizationID).Return(org, nil)
+	f.emailPrefRepo.On("Get", ctx, user.ID).Return(&domain.EmailPreferences{UserID: user.ID, MutedTicketIDs: []int64{7}}, nil)
+
+	me, err := f.svc.GetCurrentUser(ctx, user.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, user, me.User)
+	assert.Equal(t, []string{"agent"}, me.Roles)
+	assert.Len(t, me.Permissions, 2)
+	assert.Equal(t, org, me.Organization)
+	assert.Equal(t, []int64{7}, me.EmailPreferences.MutedTicketIDs)
+}
+
+func TestProfileService_RequestEmailChange(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("emails a confirmation link to the new address", func(t *testing.T) {
+		f := newProfileFixture("https://help.example.com/confirm-email")
+		user := newProfileUser(t)
+
+		f.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+		f.settingsRepo.On("GetRegistrationDomains", ctx, user.OrganizationID).Return([]string{}, nil)
+		f.userRepo.On("GetByEmail", ctx, user.OrganizationID, "jane@example.org").Return(nil, apperrors.ErrUserNotFound)
+
+		var notification ports.NotificationParams
+		f.outbox.On("Enqueue", ctx, mock.AnythingOfType("ports.NotificationParams")).
+			Run(func(args mock.Arguments) { notification = args.Get(1).(ports.NotificationParams) }).
+			Return(nil)
+
+		err := f.svc.RequestEmailChange(ctx, user.ID, " jane@example.org ", "Password1")
+
+		require.NoError(t, err)
+		assert.Equal(t, ports.NotificationEmailChange, notification.Type)
+		assert.Equal(t, "jane@example.org", notification.Data[ports.NotificationDataRecipientEmail])
+
+		link, err := url.Parse(notification.Data["link"])
+		require.NoError(t, err)
+		change, err := domain.ParseEmailChange(emailChangeSecret, link.Query().Get("token"), time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, change.UserID)
+		assert.Equal(t, "jane@example.com", change.CurrentEmail)
+		assert.Equal(t, "jane@example.org", change.NewEmail)
+	})
+
+	t.Run("requires the current password", func(t *testing.T) {
+		f := newProfileFixture("https://help.example.com/confirm-email")
+		user := newProfileUser(t)
+		f.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+
+		err := f.svc.RequestEmailChange(ctx, user.ID, "jane@example.org", "Wrong1234")
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidCredentials)
+		f.outbox.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an address in use", func(t *testing.T) {
+		f := newProfileFixture("https://help.example.com/confirm-email")
+		user := newProfileUser(t)
+		f.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+		f.settingsRepo.On("GetRegistrationDomains", ctx, user.OrganizationID).Return([]string{}, nil)
+		f.userRepo.On("GetByEmail", ctx, user.OrganizationID, "john@example.com").Return(&domain.User{ID: uuid.New()}, nil)
+
+		err := f.svc.RequestEmailChange(ctx, user.ID, "john@example.com", "Password1")
+
+		assert.ErrorIs(t, err, apperrors.ErrUserExists)
+	})
+
+	t.Run("enforces the registration domain allowlist", func(t *testing.T) {
+		f := newProfileFixture("https://help.example.com/confirm-email")
+		user := newProfileUser(t)
+		f.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+		f.settingsRepo.On("GetRegistrationDomains", ctx, user.OrganizationID).Return([]string{"example.com"}, nil)
+
+		err := f.svc.RequestEmailChange(ctx, user.ID, "jane@example.org", "Password1")
+
+		var errs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Contains(t, errs.Errors, "newEmail")
+	})
+
+	t.Run("is disabled without a confirm URL", func(t *testing.T) {
+		f := newProfileFixture("")
+
+		err := f.svc.RequestEmailChange(ctx, uuid.New(), "jane@example.org", "Password1")
+
+		assert.ErrorIs(t, err, apperrors.ErrFeatureDisabled)
+	})
+}
+
+func TestProfileService_ConfirmEmailChange(t *testing.T) {
+	ctx := context.Background()
+
+	newToken := func(user *domain.User, expiresAt time.Time) string {
+		return domain.EmailChange{
+			UserID:       user.ID,
+			CurrentEmail: user.Email,
+			NewEmail:     "jane@example.org",
+			ExpiresAt:    expiresAt,
+		}.Sign(emailChangeSecret)
+	}
+
+	t.Run("changes the email, audits it and tells the old address", func(t *testing.T) {
+		f := newProfileFixture("https://help.example.com/confirm-email")
+		user := newProfileUser(t)
+		token := newToken(user, time.Now().Add(time.Hour))
+
+		f.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+		f.userRepo.On("UpdateEmail", ctx, user.ID, "jane@example.org").Return(nil)
+		f.auditRepo.On("Create", ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+			return e.Action == domain.AuditUserEmailChanged && e.ActorID == user.ID && e.TargetID == user.ID.String()
+		})).Return(nil)
+		f.outbox.On("Enqueue", ctx, mock.MatchedBy(func(p ports.NotificationParams) bool {
+			return p.Type == ports.NotificationEmailChanged && p.Data[ports.NotificationDataRecipientEmail] == "jane@example.com"
+		})).Return(nil)
+
+		updated, err := f.svc.ConfirmEmailChange(ctx, token)
+
+		require.NoError(t, err)
+		assert.Equal(t, "jane@example.org", updated.Email)
+		f.auditRepo.AssertExpectations(t)
+		f.outbox.AssertExpectations(t)
+	})
+
+	t.Run("rejects a token once the email has changed", func(t *testing.T) {
+		f := newProfileFixture("https://help.example.com/confirm-email")
+		user := newProfileUser(t)
+		token := newToken(user, time.Now().Add(time.Hour))
+		changed := *user
+		changed.Email = "jane@example.org"
+
+		f.userRepo.On("GetByID", ctx, user.ID).Return(&changed, nil)
+
+		_, err := f.svc.ConfirmEmailChange(ctx, token)
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidEmailChangeToken)
+		f.userRepo.AssertNotCalled(t, "UpdateEmail", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an expired token", func(t *testing.T) {
+		f := newProfileFixture("https://help.example.com/confirm-email")
+		token := newToken(newProfileUser(t), time.Now().Add(-time.Minute))
+
+		_, err := f.svc.ConfirmEmailChange(ctx, token)
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidEmailChangeToken)
+	})
+}
+
+func TestProfileService_UpdateAvailability(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("saves upcoming windows", func(t *testing.T) {
+		f := newProfileFixture("")
+		userID := uuid.New()
+		start := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+		f.userRepo.On("SaveAvailability", ctx, mock.MatchedBy(func(a *domain.Availability) bool {
+			return a.UserID == userID && a.ReassignWhenAway && len(a.Windows) == 1 && a.Windows[0].Note == "Holiday"
+		})).Return(nil)
+
+		availability, err := f.svc.UpdateAvailability(ctx, userID, domain.Availability{
+			Windows: []domain.OutOfOfficeWindow{
+				{StartsAt: start, EndsAt: start.Add(48 * time.Hour), Note: " Holiday "},
+				{StartsAt: start.Add(-72 * time.Hour), EndsAt: start.Add(-48 * time.Hour)},
+			},
+			ReassignWhenAway: true,
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, availability.Windows, 1)
+		f.userRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects a window that ends before it starts", func(t *testing.T) {
+		f := newProfileFixture("")
+		start := time.Now().Add(24 * time.Hour)
+
+		_, err := f.svc.UpdateAvailability(ctx, uuid.New(), domain.Availability{
+			Windows: []domain.OutOfOfficeWindow{{StartsAt: start, EndsAt: start.Add(-time.Hour)}},
+		})
+
+		var errs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Contains(t, errs.Errors, "windows")
+		f.userRepo.AssertNotCalled(t, "SaveAvailability", mock.Anything, mock.Anything)
+	})
+}
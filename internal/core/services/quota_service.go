@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// QuotaService enforces per-organization usage quotas. An organization's
+// own quota takes precedence over the server-wide defaults, limit by limit.
+type QuotaService struct {
+	quotaRepo ports.QuotaRepository
+	authzSvc  ports.AuthorizationService
+	defaults  domain.OrgQuota
+}
+
+var _ ports.QuotaService = (*QuotaService)(nil)
+
+// NewQuotaService creates a new QuotaService.
+func NewQuotaService(quotaRepo ports.QuotaRepository, authzSvc ports.AuthorizationService, defaults domain.OrgQuota) ports.QuotaService {
+	return &QuotaService{
+		quotaRepo: quotaRepo,
+		authzSvc:  authzSvc,
+		defaults:  defaults,
+	}
+}
+
+// GetReport returns the organization's current usage and its quota.
+func (s *QuotaService) GetReport(ctx context.Context, actorID, orgID uuid.UUID) (*domain.QuotaReport, error) {
+	allowed, err := s.authzSvc.Can(ctx, actorID, "admin:access")
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, apperrors.ErrForbidden
+	}
+	return s.Report(ctx, orgID)
+}
+
+// Report returns the organization's current usage and its quota without
+// checking who is asking.
+func (s *QuotaService) Report(ctx context.Context, orgID uuid.UUID) (*domain.QuotaReport, error) {
+	quota, err := s.quota(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := s.quotaRepo.GetUsage(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.QuotaReport{Quota: quota, Usage: *usage}, nil
+}
+
+// CheckUsers returns apperrors.ErrUserQuotaExceeded if adding users would
+// take the organization over its user quota.
+func (s *QuotaService) CheckUsers(ctx context.Context, orgID uuid.UUID, adding int) error {
+	quota, err := s.quota(ctx, orgID)
+	if err != nil || quota.MaxUsers == nil {
+		return err
+	}
+
+	usage, err := s.quotaRepo.GetUsage(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if !quota.AllowsUsers(usage.ActiveUsers, adding) {
+		return apperrors.ErrUserQuotaExceeded
+	}
+	return nil
+}
+
+// CheckOpenTicket returns apperrors.ErrOpenTicketQuotaExceeded if the
+// organization cannot open another ticket.
+func (s *QuotaService) CheckOpenTicket(ctx context.Context, orgID uuid.UUID) error {
+	quota, err := s.quota(ctx, orgID)
+	if err != nil || quota.MaxOpenTickets == nil {
+		return err
+	}
+
+	usage, err := s.quotaRepo.GetUsage(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if !quota.AllowsOpenTicket(usage.OpenTickets) {
+		return apperrors.ErrOpenTicketQuotaExceeded
+	}
+	return nil
+}
+
+// quota returns the organization's quota with the defaults filled in.
+func (s *QuotaService) quota(ctx context.Context, orgID uuid.UUID) (domain.OrgQuota, error) {
+	quota, err := s.quotaRepo.GetQuota(ctx, orgID)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return s.defaults, nil
+	}
+	if err != nil {
+		return domain.OrgQuota{}, err
+	}
+	return quota.Merge(s.defaults), nil
+}
@@ -0,0 +1,73 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaService_CheckUsers(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	limit := func(n int) *int { return &n }
+
+	t.Run("unlimited without a quota", func(t *testing.T) {
+		repo := mocks.NewMockQuotaRepository()
+		svc := services.NewQuotaService(repo, mocks.NewMockAuthorizationService(), domain.OrgQuota{})
+
+		repo.On("GetQuota", ctx, orgID).Return(nil, apperrors.ErrNotFound)
+
+		require.NoError(t, svc.CheckUsers(ctx, orgID, 1000))
+		repo.AssertNotCalled(t, "GetUsage", ctx, orgID)
+	})
+
+	t.Run("falls back to the default limit", func(t *testing.T) {
+		repo := mocks.NewMockQuotaRepository()
+		svc := services.NewQuotaService(repo, mocks.NewMockAuthorizationService(), domain.OrgQuota{MaxUsers: limit(10)})
+
+		repo.On("GetQuota", ctx, orgID).Return(&domain.OrgQuota{MaxOpenTickets: limit(5)}, nil)
+		repo.On("GetUsage", ctx, orgID).Return(&domain.OrgUsage{ActiveUsers: 8}, nil)
+
+		assert.NoError(t, svc.CheckUsers(ctx, orgID, 2))
+		assert.ErrorIs(t, svc.CheckUsers(ctx, orgID, 3), apperrors.ErrUserQuotaExceeded)
+	})
+
+	t.Run("prefers the organization's own limit", func(t *testing.T) {
+		repo := mocks.NewMockQuotaRepository()
+		svc := services.NewQuotaService(repo, mocks.NewMockAuthorizationService(), domain.OrgQuota{MaxUsers: limit(10)})
+
+		repo.On("GetQuota", ctx, orgID).Return(&domain.OrgQuota{MaxUsers: limit(50)}, nil)
+		repo.On("GetUsage", ctx, orgID).Return(&domain.OrgUsage{ActiveUsers: 20}, nil)
+
+		assert.NoError(t, svc.CheckUsers(ctx, orgID, 1))
+	})
+}
+
+func TestQuotaService_GetReport(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	orgID := uuid.New()
+
+	repo := mocks.NewMockQuotaRepository()
+	authz := mocks.NewMockAuthorizationService()
+	maxTickets := 100
+	svc := services.NewQuotaService(repo, authz, domain.OrgQuota{MaxOpenTickets: &maxTickets})
+
+	authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+	repo.On("GetQuota", ctx, orgID).Return(nil, apperrors.ErrNotFound)
+	repo.On("GetUsage", ctx, orgID).Return(&domain.OrgUsage{ActiveUsers: 3, OpenTickets: 42}, nil)
+
+	report, err := svc.GetReport(ctx, actorID, orgID)
+
+	require.NoError(t, err)
+	assert.Nil(t, report.Quota.MaxUsers)
+	assert.Equal(t, 100, *report.Quota.MaxOpenTickets)
+	assert.Equal(t, domain.OrgUsage{ActiveUsers: 3, OpenTickets: 42}, report.Usage)
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// RateLimitService lets admins see who the API rate limiters are turning
+// away, lift a block, and exempt trusted clients through overrides. The
+// limiters live in memory, so every override change is pushed to them.
+type RateLimitService struct {
+	overrideRepo ports.RateLimitOverrideRepository
+	control      ports.RateLimitControl
+	userRepo     ports.UserRepository
+	authzSvc     ports.AuthorizationService
+	auditRepo    ports.AuditLogRepository
+	txManager    ports.TransactionManager
+}
+
+var _ ports.RateLimitService = (*RateLimitService)(nil)
+
+// NewRateLimitService creates a new RateLimitService.
+func NewRateLimitService(
+	overrideRepo ports.RateLimitOverrideRepository,
+	control ports.RateLimitControl,
+	userRepo ports.UserRepository,
+	authzSvc ports.AuthorizationService,
+	auditRepo ports.AuditLogRepository,
+	txManager ports.TransactionManager,
+) ports.RateLimitService {
+	return &RateLimitService{
+		overrideRepo: overrideRepo,
+		control:      control,
+		userRepo:     userRepo,
+		authzSvc:     authzSvc,
+		auditRepo:    auditRepo,
+		txManager:    txManager,
+	}
+}
+
+// ListThrottled returns the clients the rate limiters have recently turned
+// away.
+func (s *RateLimitService) ListThrottled(ctx context.Context, actorID uuid.UUID) ([]domain.ThrottledClient, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	return s.control.Throttled(), nil
+}
+
+// ClearThrottled lifts the block on a client, giving it a full allowance of
+// requests again.
+func (s *RateLimitService) ClearThrottled(ctx context.Context, actorID, orgID uuid.UUID, key string) error {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return err
+	}
+
+	if !s.control.Clear(key) {
+		return apperrors.ErrRateLimitKeyNotFound
+	}
+
+	entry, err := domain.NewAuditEntry(ctx, orgID, actorID, domain.AuditRateLimitCleared, domain.AuditTargetRateLimit, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	return s.auditRepo.Create(ctx, entry)
+}
+
+// ListOverrides returns the organization's rate limit overrides.
+func (s *RateLimitService) ListOverrides(ctx context.Context, actorID, orgID uuid.UUID) ([]*domain.RateLimitOverride, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	return s.overrideRepo.ListByOrganization(ctx, orgID)
+}
+
+// SetOverride creates or replaces the override for a user in the
+// organization, or for the organization as a whole when params.UserID is nil.
+func (s *RateLimitService) SetOverride(ctx context.Context, actorID, orgID uuid.UUID, params domain.RateLimitOverrideParams) (*domain.RateLimitOverride, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	override, err := domain.NewRateLimitOverride(params, orgID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.UserID != nil {
+		user, err := s.userRepo.GetByID(ctx, *params.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user.OrganizationID != orgID {
+			return nil, apperrors.ErrUserNotFound
+		}
+	}
+
+	var saved *domain.RateLimitOverride
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		saved, err = s.overrideRepo.Upsert(txCtx, override)
+		if err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditRateLimitOverrideSet, saved, nil, rateLimitOverrideAuditSnapshot(saved))
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := s.LoadOverrides(ctx); err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// DeleteOverride removes one of the organization's overrides.
+func (s *RateLimitService) DeleteOverride(ctx context.Context, actorID, orgID, overrideID uuid.UUID) error {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return err
+	}
+
+	override, err := s.overrideRepo.GetByID(ctx, overrideID)
+	if err != nil {
+		return err
+	}
+	if override.OrganizationID != orgID {
+		return apperrors.ErrRateLimitOverrideNotFound
+	}
+
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.overrideRepo.Delete(txCtx, overrideID); err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditRateLimitOverrideDeleted, override, rateLimitOverrideAuditSnapshot(override), nil)
+	}); err != nil {
+		return err
+	}
+
+	return s.LoadOverrides(ctx)
+}
+
+// LoadOverrides hands every stored override to the rate limiters. It runs at
+// startup and after each change.
+func (s *RateLimitService) LoadOverrides(ctx context.Context) error {
+	overrides, err := s.overrideRepo.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.control.SetOverrides(overrides)
+	return nil
+}
+
+// recordAudit logs a change made to a rate limit override.
+func (s *RateLimitService) recordAudit(ctx context.Context, orgID, actorID uuid.UUID, action domain.AuditAction, override *domain.RateLimitOverride, before, after any) error {
+	entry, err := domain.NewAuditEntry(ctx, orgID, actorID, action, domain.AuditTargetRateLimit, override.ID.String(), before, after)
+	if err != nil {
+		return err
+	}
+	return s.auditRepo.Create(ctx, entry)
+}
+
+// rateLimitOverrideAuditSnapshot is the audited view of an override.
+func rateLimitOverrideAuditSnapshot(override *domain.RateLimitOverride) map[string]any {
+	return map[string]any{
+		"userId":            override.UserID,
+		"unlimited":         override.Unlimited,
+		"requestsPerSecond": override.RequestsPerSecond,
+		"burst":             override.Burst,
+		"note":              override.Note,
+	}
+}
+
+func (s *RateLimitService) requireAdmin(ctx context.Context, actorID uuid.UUID) error {
+	allowed, err := s.authzSvc.Can(ctx, actorID, "admin:access")
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return apperrors.ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitService_SetOverride(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	setup := func() (*mocks.MockRateLimitOverrideRepository, *mocks.MockRateLimitControl, *mocks.MockUserRepository, *mocks.MockAuditLogRepository, *mocks.MockAuthorizationService) {
+		return mocks.NewMockRateLimitOverrideRepository(), mocks.NewMockRateLimitControl(), mocks.NewMockUserRepository(),
+			mocks.NewMockAuditLogRepository(), mocks.NewMockAuthorizationService()
+	}
+
+	t.Run("stores the override and reloads the limiters", func(t *testing.T) {
+		repo, control, users, audit, authz := setup()
+		svc := services.NewRateLimitService(repo, control, users, authz, audit, stubTransactionManager{})
+
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		users.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, OrganizationID: orgID}, nil)
+		repo.On("Upsert", ctx, mock.MatchedBy(func(o *domain.RateLimitOverride) bool {
+			return o.OrganizationID == orgID && *o.UserID == userID && o.Unlimited && o.RequestsPerSecond == 0
+		})).Return(&domain.RateLimitOverride{ID: uuid.New(), OrganizationID: orgID, UserID: &userID, Unlimited: true}, nil)
+		audit.On("Create", ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+			return e.Action == domain.AuditRateLimitOverrideSet && e.TargetType == domain.AuditTargetRateLimit
+		})).Return(nil)
+		stored := []*domain.RateLimitOverride{{ID: uuid.New(), OrganizationID: orgID, UserID: &userID, Unlimited: true}}
+		repo.On("ListAll", ctx).Return(stored, nil)
+		control.On("SetOverrides", stored).Return()
+
+		override, err := svc.SetOverride(ctx, actorID, orgID, domain.RateLimitOverrideParams{
+			UserID:            &userID,
+			Unlimited:         true,
+			RequestsPerSecond: 5,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, override.Unlimited)
+		repo.AssertExpectations(t)
+		control.AssertExpectations(t)
+		audit.AssertExpectations(t)
+	})
+
+	t.Run("rejects users from other organizations", func(t *testing.T) {
+		repo, control, users, audit, authz := setup()
+		svc := services.NewRateLimitService(repo, control, users, authz, audit, stubTransactionManager{})
+
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		users.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, OrganizationID: uuid.New()}, nil)
+
+		_, err := svc.SetOverride(ctx, actorID, orgID, domain.RateLimitOverrideParams{UserID: &userID, Unlimited: true})
+
+		assert.ErrorIs(t, err, apperrors.ErrUserNotFound)
+		repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+	})
+
+	t.Run("validates the limits", func(t *testing.T) {
+		repo, control, users, audit, authz := setup()
+		svc := services.NewRateLimitService(repo, control, users, authz, audit, stubTransactionManager{})
+
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+
+		_, err := svc.SetOverride(ctx, actorID, orgID, domain.RateLimitOverrideParams{RequestsPerSecond: 0, Burst: 0})
+
+		var validationErrs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &validationErrs)
+		assert.Contains(t, validationErrs.Errors, "requestsPerSecond")
+		assert.Contains(t, validationErrs.Errors, "burst")
+	})
+}
+
+func TestRateLimitService_ClearThrottled(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	orgID := uuid.New()
+
+	t.Run("clears a known client and audits it", func(t *testing.T) {
+		control := mocks.NewMockRateLimitControl()
+		authz := mocks.NewMockAuthorizationService()
+		audit := mocks.NewMockAuditLogRepository()
+		svc := services.NewRateLimitService(mocks.NewMockRateLimitOverrideRepository(), control, mocks.NewMockUserRepository(), authz, audit, stubTransactionManager{})
+
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		control.On("Clear", "203.0.113.7").Return(true)
+		audit.On("Create", ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+			return e.Action == domain.AuditRateLimitCleared && e.TargetID == "203.0.113.7"
+		})).Return(nil)
+
+		require.NoError(t, svc.ClearThrottled(ctx, actorID, orgID, "203.0.113.7"))
+		audit.AssertExpectations(t)
+	})
+
+	t.Run("reports unknown clients", func(t *testing.T) {
+		control := mocks.NewMockRateLimitControl()
+		authz := mocks.NewMockAuthorizationService()
+		audit := mocks.NewMockAuditLogRepository()
+		svc := services.NewRateLimitService(mocks.NewMockRateLimitOverrideRepository(), control, mocks.NewMockUserRepository(), authz, audit, stubTransactionManager{})
+
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		control.On("Clear", "203.0.113.7").Return(false)
+
+		err := svc.ClearThrottled(ctx, actorID, orgID, "203.0.113.7")
+
+		assert.ErrorIs(t, err, apperrors.ErrRateLimitKeyNotFound)
+		audit.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// RetentionConfig controls how the data retention policies are applied.
+type RetentionConfig struct {
+	Interval time.Duration // How often expired tickets are purged
+	DryRun   bool          // Only report what would be purged
+}
+
+// RetentionJob applies each organization's data retention policy. Closed
+// tickets past the retention period are soft-deleted, and soft-deleted
+// tickets past the grace period are deleted for good with their comments
+// and history. A dry run only logs what it would have done.
+type RetentionJob struct {
+	retentionRepo ports.RetentionRepository
+	txManager     ports.TransactionManager
+	cfg           RetentionConfig
+	logger        *slog.Logger
+	now           func() time.Time
+}
+
+// NewRetentionJob creates a new retention job
+func NewRetentionJob(
+	retentionRepo ports.RetentionRepository,
+	txManager ports.TransactionManager,
+	cfg RetentionConfig,
+	logger *slog.Logger,
+) *RetentionJob {
+	return &RetentionJob{
+		retentionRepo: retentionRepo,
+		txManager:     txManager,
+		cfg:           cfg,
+		logger:        logger.With("component", "retention"),
+		now:           time.Now,
+	}
+}
+
+// Run purges expired tickets straight away and then every cfg.Interval until
+// ctx is cancelled.
+func (j *RetentionJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := j.Purge(ctx); err != nil && ctx.Err() == nil {
+			j.logger.Error("failed to apply retention policies", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge applies every enabled retention policy and reports what was purged
+// for each organization. Tickets soft-deleted by this run are never
+// hard-deleted in the same run.
+func (j *RetentionJob) Purge(ctx context.Context) ([]domain.RetentionReport, error) {
+	policies, err := j.retentionRepo.ListEnabledPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := j.now().UTC()
+	reports := make([]domain.RetentionReport, 0, len(policies))
+	for _, policy := range policies {
+		report, err := j.apply(ctx, policy, now)
+		if err != nil {
+			return reports, err
+		}
+		if report.SoftDeleted > 0 || report.HardDeleted > 0 {
+			j.logger.Info("applied retention policy",
+				"organization_id", report.OrganizationID,
+				"soft_deleted", report.SoftDeleted,
+				"hard_deleted", report.HardDeleted,
+				"dry_run", report.DryRun,
+			)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// apply purges one organization's expired tickets
+func (j *RetentionJob) apply(ctx context.Context, policy *domain.RetentionPolicy, now time.Time) (domain.RetentionReport, error) {
+	report := domain.RetentionReport{OrganizationID: policy.OrganizationID, DryRun: j.cfg.DryRun}
+	closedBefore := policy.SoftDeleteCutoff(now)
+	deletedBefore := policy.HardDeleteCutoff(now)
+
+	if j.cfg.DryRun {
+		var err error
+		report.SoftDeleted, report.HardDeleted, err = j.retentionRepo.CountExpired(ctx, policy.OrganizationID, closedBefore, deletedBefore)
+		return report, err
+	}
+
+	err := j.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		report.HardDeleted, err = j.retentionRepo.HardDeleteExpired(txCtx, policy.OrganizationID, deletedBefore)
+		if err != nil {
+			return err
+		}
+		report.SoftDeleted, err = j.retentionRepo.SoftDeleteExpired(txCtx, policy.OrganizationID, closedBefore, now)
+		return err
+	})
+	return report, err
+}
@@ -0,0 +1,67 @@
+package services_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetentionJob_Purge(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	days := 365
+	policy := &domain.RetentionPolicy{OrganizationID: orgID, ClosedTicketDays: &days, GraceDays: 30}
+
+	newJob := func(repo *mocks.MockRetentionRepository, dryRun bool) *services.RetentionJob {
+		return services.NewRetentionJob(repo, stubTransactionManager{}, services.RetentionConfig{
+			Interval: time.Hour,
+			DryRun:   dryRun,
+		}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	}
+
+	t.Run("hard-deletes before soft-deleting", func(t *testing.T) {
+		repo := mocks.NewMockRetentionRepository()
+		repo.On("ListEnabledPolicies", ctx).Return([]*domain.RetentionPolicy{policy}, nil)
+
+		var closedBefore, deletedBefore time.Time
+		hard := repo.On("HardDeleteExpired", ctx, orgID, mock.Anything).
+			Run(func(args mock.Arguments) { deletedBefore = args.Get(2).(time.Time) }).
+			Return(int64(2), nil)
+		repo.On("SoftDeleteExpired", ctx, orgID, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { closedBefore = args.Get(2).(time.Time) }).
+			Return(int64(5), nil).
+			NotBefore(hard)
+
+		reports, err := newJob(repo, false).Purge(ctx)
+
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		assert.Equal(t, domain.RetentionReport{OrganizationID: orgID, SoftDeleted: 5, HardDeleted: 2}, reports[0])
+		assert.WithinDuration(t, time.Now().AddDate(0, 0, -365), closedBefore, time.Minute)
+		assert.WithinDuration(t, time.Now().AddDate(0, 0, -30), deletedBefore, time.Minute)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("dry run only counts", func(t *testing.T) {
+		repo := mocks.NewMockRetentionRepository()
+		repo.On("ListEnabledPolicies", ctx).Return([]*domain.RetentionPolicy{policy}, nil)
+		repo.On("CountExpired", ctx, orgID, mock.Anything, mock.Anything).Return(int64(5), int64(2), nil)
+
+		reports, err := newJob(repo, true).Purge(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.RetentionReport{OrganizationID: orgID, SoftDeleted: 5, HardDeleted: 2, DryRun: true}, reports[0])
+		repo.AssertNotCalled(t, "SoftDeleteExpired", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "HardDeleteExpired", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
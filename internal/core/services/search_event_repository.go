@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// SearchIndexingEventRepository wraps a TicketEventRepository and queues the
+// event's ticket to be reindexed for search. Every change to a ticket or its
+// comments records an event, and the ticket is queued in the same
+// transaction, so the index catches up with exactly the changes that commit.
+type SearchIndexingEventRepository struct {
+	ports.TicketEventRepository
+	queue ports.SearchQueueRepository
+}
+
+var _ ports.TicketEventRepository = (*SearchIndexingEventRepository)(nil)
+
+// NewSearchIndexingEventRepository creates a new search-indexing event repository
+func NewSearchIndexingEventRepository(eventRepo ports.TicketEventRepository, queue ports.SearchQueueRepository) ports.TicketEventRepository {
+	return &SearchIndexingEventRepository{
+		TicketEventRepository: eventRepo,
+		queue:                 queue,
+	}
+}
+
+// Create persists the event and queues its ticket for reindexing
+func (r *SearchIndexingEventRepository) Create(ctx context.Context, event *domain.Event) (*domain.Event, error) {
+	created, err := r.TicketEventRepository.Create(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.queue.Enqueue(ctx, created.TicketID); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// SearchIndexer keeps the search index in step with tickets and their
+// comments. It claims queued tickets in batches and reindexes each with all
+// of its comments; tickets that no longer exist are removed from the index.
+// A ticket that fails to index stays queued and is retried once its claim
+// lease runs out.
+type SearchIndexer struct {
+	queue       ports.SearchQueueRepository
+	index       ports.SearchIndex
+	ticketRepo  ports.TicketRepository
+	commentRepo ports.CommentRepository
+	cfg         DispatcherConfig
+	logger      *slog.Logger
+}
+
+// NewSearchIndexer creates a new search indexer
+func NewSearchIndexer(
+	queue ports.SearchQueueRepository,
+	index ports.SearchIndex,
+	ticketRepo ports.TicketRepository,
+	commentRepo ports.CommentRepository,
+	cfg DispatcherConfig,
+	logger *slog.Logger,
+) *SearchIndexer {
+	return &SearchIndexer{
+		queue:       queue,
+		index:       index,
+		ticketRepo:  ticketRepo,
+		commentRepo: commentRepo,
+		cfg:         cfg,
+		logger:      logger.With("component", "search_indexer"),
+	}
+}
+
+// Run polls for queued tickets until ctx is cancelled
+func (i *SearchIndexer) Run(ctx context.Context) {
+	poll(ctx, i.cfg, i.IndexBatch, func(err error) {
+		i.logger.Error("failed to index tickets", "error", err)
+	})
+}
+
+// IndexBatch claims and indexes one batch of queued tickets and returns how
+// many were claimed.
+func (i *SearchIndexer) IndexBatch(ctx context.Context) (int, error) {
+	batch, err := i.queue.Claim(ctx, i.cfg.BatchSize, i.cfg.ClaimLease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, job := range batch {
+		if err := i.indexTicket(ctx, job); err != nil {
+			i.logger.Warn("failed to index ticket, will retry", "ticket_id", job.TicketID, "error", err)
+			continue
+		}
+		if err := i.queue.Complete(ctx, job); err != nil {
+			i.logger.Error("failed to complete search index job", "ticket_id", job.TicketID, "error", err)
+		}
+	}
+
+	return len(batch), nil
+}
+
+// indexTicket replaces the ticket's documents in the index
+func (i *SearchIndexer) indexTicket(ctx context.Context, job *ports.SearchIndexJob) error {
+	ticket, err := i.ticketRepo.GetByID(ctx, job.OrganizationID, job.TicketID)
+	if errors.Is(err, apperrors.ErrTicketNotFound) {
+		return i.index.DeleteTicket(ctx, job.OrganizationID, job.TicketID)
+	}
+	if err != nil {
+		return err
+	}
+
+	comments, err := i.commentRepo.ListByTicketID(ctx, ticket.ID)
+	if err != nil {
+		return err
+	}
+
+	return i.index.Index(ctx, domain.TicketSearchDocuments(ticket, comments))
+}
@@ -0,0 +1,88 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchIndexer_IndexBatch(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	job := &ports.SearchIndexJob{TicketID: 7, OrganizationID: orgID, EnqueuedAt: time.Now()}
+
+	setup := func() (*services.SearchIndexer, *mocks.MockSearchQueueRepository, *mocks.MockSearchIndex, *mocks.MockTicketRepository, *mocks.MockCommentRepository) {
+		queue := mocks.NewMockSearchQueueRepository()
+		index := mocks.NewMockSearchIndex()
+		ticketRepo := mocks.NewMockTicketRepository()
+		commentRepo := mocks.NewMockCommentRepository()
+		indexer := services.NewSearchIndexer(queue, index, ticketRepo, commentRepo, services.DispatcherConfig{
+			PollInterval: time.Second,
+			BatchSize:    10,
+			ClaimLease:   time.Minute,
+		}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		queue.On("Claim", ctx, 10, time.Minute).Return([]*ports.SearchIndexJob{job}, nil)
+		return indexer, queue, index, ticketRepo, commentRepo
+	}
+
+	t.Run("indexes the ticket with its comments", func(t *testing.T) {
+		indexer, queue, index, ticketRepo, commentRepo := setup()
+		ticket := &domain.Ticket{ID: 7, Title: "Printer on fire", OrganizationID: orgID, RequesterID: uuid.New()}
+		comments := []*domain.Comment{{ID: 3, TicketID: 7, Body: "Have you tried water?"}}
+
+		ticketRepo.On("GetByID", ctx, orgID, int64(7)).Return(ticket, nil)
+		commentRepo.On("ListByTicketID", ctx, int64(7)).Return(comments, nil)
+		index.On("Index", ctx, domain.TicketSearchDocuments(ticket, comments)).Return(nil)
+		queue.On("Complete", ctx, job).Return(nil)
+
+		n, err := indexer.IndexBatch(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		index.AssertExpectations(t)
+		queue.AssertExpectations(t)
+	})
+
+	t.Run("removes tickets that no longer exist", func(t *testing.T) {
+		indexer, queue, index, ticketRepo, _ := setup()
+
+		ticketRepo.On("GetByID", ctx, orgID, int64(7)).Return(nil, apperrors.ErrTicketNotFound)
+		index.On("DeleteTicket", ctx, orgID, int64(7)).Return(nil)
+		queue.On("Complete", ctx, job).Return(nil)
+
+		_, err := indexer.IndexBatch(ctx)
+
+		require.NoError(t, err)
+		index.AssertExpectations(t)
+		queue.AssertExpectations(t)
+	})
+
+	t.Run("leaves tickets that fail to index queued", func(t *testing.T) {
+		indexer, queue, index, ticketRepo, commentRepo := setup()
+		ticket := &domain.Ticket{ID: 7, OrganizationID: orgID}
+
+		ticketRepo.On("GetByID", ctx, orgID, int64(7)).Return(ticket, nil)
+		commentRepo.On("ListByTicketID", ctx, int64(7)).Return([]*domain.Comment{}, nil)
+		index.On("Index", ctx, mock.Anything).Return(errors.New("index unavailable"))
+
+		n, err := indexer.IndexBatch(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		queue.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything)
+	})
+}
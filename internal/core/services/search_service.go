@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// SearchService searches tickets and comments through the configured
+// search index. Users who can list all tickets search their whole
+// organization, everyone else only their own tickets. Internal tickets are
+// only found by users who can read all tickets, as with GetTicket.
+type SearchService struct {
+	index    ports.SearchIndex
+	authzSvc ports.AuthorizationService
+	userRepo ports.UserRepository
+}
+
+var _ ports.SearchService = (*SearchService)(nil)
+
+// NewSearchService creates a new search service
+func NewSearchService(index ports.SearchIndex, authzSvc ports.AuthorizationService, userRepo ports.UserRepository) ports.SearchService {
+	return &SearchService{
+		index:    index,
+		authzSvc: authzSvc,
+		userRepo: userRepo,
+	}
+}
+
+// Search returns the viewer's best matching tickets and comments
+func (s *SearchService) Search(ctx context.Context, params ports.SearchParams) ([]*domain.SearchHit, error) {
+	viewer, err := s.userRepo.GetByID(ctx, params.ViewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := domain.SearchQuery{
+		OrganizationID: viewer.OrganizationID,
+		Text:           params.Text,
+		Limit:          params.Limit,
+	}
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	canListAll, err := s.authzSvc.Can(ctx, params.ViewerID, "tickets:list:all")
+	if err != nil {
+		return nil, err
+	}
+	if !canListAll {
+		requesterID := params.ViewerID
+		query.RequesterID = &requesterID
+	}
+
+	canReadAll, err := s.authzSvc.Can(ctx, params.ViewerID, "tickets:read:all")
+	if err != nil {
+		return nil, err
+	}
+	query.HideInternal = !canReadAll
+
+	return s.index.Search(ctx, query)
+}
@@ -0,0 +1,83 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchService_Search(t *testing.T) {
+	ctx := context.Background()
+	viewerID := uuid.New()
+	orgID := uuid.New()
+	hits := []*domain.SearchHit{{Kind: domain.SearchDocumentTicket, ID: 7, TicketID: 7, Title: "Printer on fire"}}
+
+	setup := func(canListAll, canReadAll bool) (ports.SearchService, *mocks.MockSearchIndex) {
+		index := mocks.NewMockSearchIndex()
+		authz := mocks.NewMockAuthorizationService()
+		userRepo := mocks.NewMockUserRepository()
+
+		userRepo.On("GetByID", ctx, viewerID).Return(&domain.User{ID: viewerID, OrganizationID: orgID}, nil)
+		authz.On("Can", ctx, viewerID, "tickets:list:all").Return(canListAll, nil)
+		authz.On("Can", ctx, viewerID, "tickets:read:all").Return(canReadAll, nil)
+		return services.NewSearchService(index, authz, userRepo), index
+	}
+
+	t.Run("searches the whole organization for agents", func(t *testing.T) {
+		svc, index := setup(true, true)
+		index.On("Search", ctx, domain.SearchQuery{
+			OrganizationID: orgID,
+			Text:           "printer",
+			Limit:          domain.DefaultSearchLimit,
+		}).Return(hits, nil)
+
+		result, err := svc.Search(ctx, ports.SearchParams{ViewerID: viewerID, Text: " printer "})
+
+		require.NoError(t, err)
+		assert.Equal(t, hits, result)
+		index.AssertExpectations(t)
+	})
+
+	t.Run("searches only their own tickets for requesters", func(t *testing.T) {
+		svc, index := setup(false, false)
+		index.On("Search", ctx, mock.MatchedBy(func(q domain.SearchQuery) bool {
+			return q.OrganizationID == orgID && q.RequesterID != nil && *q.RequesterID == viewerID && q.HideInternal
+		})).Return(hits, nil)
+
+		_, err := svc.Search(ctx, ports.SearchParams{ViewerID: viewerID, Text: "printer", Limit: 5})
+
+		require.NoError(t, err)
+		index.AssertExpectations(t)
+	})
+
+	t.Run("hides internal tickets from users who cannot read all tickets", func(t *testing.T) {
+		svc, index := setup(true, false)
+		index.On("Search", ctx, mock.MatchedBy(func(q domain.SearchQuery) bool {
+			return q.RequesterID == nil && q.HideInternal
+		})).Return(hits, nil)
+
+		_, err := svc.Search(ctx, ports.SearchParams{ViewerID: viewerID, Text: "printer"})
+
+		require.NoError(t, err)
+		index.AssertExpectations(t)
+	})
+
+	t.Run("rejects empty text", func(t *testing.T) {
+		svc, index := setup(true, true)
+
+		_, err := svc.Search(ctx, ports.SearchParams{ViewerID: viewerID, Text: "  "})
+
+		var errs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		index.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// SnoozeConfig controls how often ended snoozes are looked for.
+type SnoozeConfig struct {
+	Interval  time.Duration // How often ended snoozes are looked for
+	BatchSize int           // How many snoozes are ended per transaction
+}
+
+// SnoozeJob ends snoozes whose time has come, which puts their tickets
+// back in agents' queues, and reminds whoever snoozed each ticket. Tickets
+// closed, deleted or archived in the meantime resurface without a reminder.
+type SnoozeJob struct {
+	snoozeRepo ports.TicketSnoozeRepository
+	ticketRepo ports.TicketRepository
+	outbox     ports.NotificationOutboxRepository
+	txManager  ports.TransactionManager
+	cfg        SnoozeConfig
+	logger     *slog.Logger
+	now        func() time.Time
+}
+
+// NewSnoozeJob creates a new snooze job
+func NewSnoozeJob(
+	snoozeRepo ports.TicketSnoozeRepository,
+	ticketRepo ports.TicketRepository,
+	outbox ports.NotificationOutboxRepository,
+	txManager ports.TransactionManager,
+	cfg SnoozeConfig,
+	logger *slog.Logger,
+) *SnoozeJob {
+	return &SnoozeJob{
+		snoozeRepo: snoozeRepo,
+		ticketRepo: ticketRepo,
+		outbox:     outbox,
+		txManager:  txManager,
+		cfg:        cfg,
+		logger:     logger.With("component", "snooze"),
+		now:        time.Now,
+	}
+}
+
+// Run ends due snoozes straight away and then every cfg.Interval until ctx
+// is cancelled.
+func (j *SnoozeJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := j.Resurface(ctx); err != nil && ctx.Err() == nil {
+			j.logger.Error("failed to end snoozes", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Resurface ends every snooze due by now and returns how many it ended.
+// Each batch is ended and its reminders queued in one transaction, so a
+// snooze is never ended without its reminder.
+func (j *SnoozeJob) Resurface(ctx context.Context) (int, error) {
+	now := j.now().UTC()
+
+	var total int
+	for {
+		var n int
+		err := j.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+			snoozes, err := j.snoozeRepo.TakeDue(txCtx, now, j.cfg.BatchSize)
+			if err != nil {
+				return err
+			}
+			n = len(snoozes)
+
+			for _, snooze := range snoozes {
+				if err := j.remind(txCtx, snooze); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return total, err
+		}
+		total += n
+		if n < j.cfg.BatchSize || ctx.Err() != nil {
+			break
+		}
+	}
+
+	if total > 0 {
+		j.logger.Info("ended snoozes", "count", total)
+	}
+	return total, nil
+}
+
+// remind queues the reminder for an ended snooze, unless its ticket no
+// longer needs attention.
+func (j *SnoozeJob) remind(ctx context.Context, snooze *domain.TicketSnooze) error {
+	ticket, err := j.ticketRepo.GetByID(ctx, snooze.OrganizationID, snooze.TicketID)
+	if errors.Is(err, apperrors.ErrTicketNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if ticket.Status == domain.StatusClosed {
+		return nil
+	}
+
+	return j.outbox.Enqueue(ctx, snoozeEndedNotification(ticket, snooze))
+}
+
+// snoozeEndedNotification reminds whoever snoozed a ticket that it is back
+func snoozeEndedNotification(ticket *domain.Ticket, snooze *domain.TicketSnooze) ports.NotificationParams {
+	message := "The ticket you snoozed is back in the queue: " + ticket.Title
+	if snooze.Note != "" {
+		message += "\nNote: " + snooze.Note
+	}
+	return ports.NotificationParams{
+		RecipientUserID: snooze.SnoozedBy,
+		Type:            ports.NotificationSnoozeEnded,
+		Subject:         "Snoozed ticket is back",
+		Message:         message,
+		TicketID:        ticket.ID,
+		Data: map[string]string{
+			"title":         ticket.Title,
+			"note":          snooze.Note,
+			"snoozed_until": snooze.Until.Format(time.RFC3339),
+		},
+	}
+}
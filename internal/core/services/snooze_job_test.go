@@ -0,0 +1,80 @@
+package services_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnoozeJob_Resurface(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	agentID := uuid.New()
+
+	newJob := func(snoozeRepo *mocks.MockTicketSnoozeRepository, ticketRepo *mocks.MockTicketRepository, outbox *mocks.MockNotificationOutboxRepository) *services.SnoozeJob {
+		return services.NewSnoozeJob(snoozeRepo, ticketRepo, outbox, stubTransactionManager{}, services.SnoozeConfig{
+			Interval:  time.Minute,
+			BatchSize: 2,
+		}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	}
+	snoozeOf := func(ticketID int64) *domain.TicketSnooze {
+		return &domain.TicketSnooze{
+			TicketID:       ticketID,
+			OrganizationID: orgID,
+			SnoozedBy:      agentID,
+			Until:          time.Now().Add(-time.Minute),
+			Note:           "Waiting for the vendor",
+		}
+	}
+
+	t.Run("reminds whoever snoozed the ticket", func(t *testing.T) {
+		snoozeRepo := mocks.NewMockTicketSnoozeRepository()
+		ticketRepo := mocks.NewMockTicketRepository()
+		outbox := mocks.NewMockNotificationOutboxRepository()
+
+		snoozeRepo.On("TakeDue", ctx, mock.Anything, 2).Return([]*domain.TicketSnooze{snoozeOf(1)}, nil).Once()
+		ticketRepo.On("GetByID", ctx, orgID, int64(1)).
+			Return(&domain.Ticket{ID: 1, Title: "Printer offline", Status: domain.StatusOpen, OrganizationID: orgID}, nil)
+		outbox.On("Enqueue", ctx, mock.MatchedBy(func(p ports.NotificationParams) bool {
+			return p.RecipientUserID == agentID &&
+				p.Type == ports.NotificationSnoozeEnded &&
+				p.TicketID == 1 &&
+				p.Data["note"] == "Waiting for the vendor"
+		})).Return(nil)
+
+		n, err := newJob(snoozeRepo, ticketRepo, outbox).Resurface(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		snoozeRepo.AssertExpectations(t)
+		outbox.AssertExpectations(t)
+	})
+
+	t.Run("skips tickets that were closed or removed", func(t *testing.T) {
+		snoozeRepo := mocks.NewMockTicketSnoozeRepository()
+		ticketRepo := mocks.NewMockTicketRepository()
+		outbox := mocks.NewMockNotificationOutboxRepository()
+
+		snoozeRepo.On("TakeDue", ctx, mock.Anything, 2).Return([]*domain.TicketSnooze{snoozeOf(1), snoozeOf(2)}, nil).Once()
+		snoozeRepo.On("TakeDue", ctx, mock.Anything, 2).Return([]*domain.TicketSnooze{}, nil).Once()
+		ticketRepo.On("GetByID", ctx, orgID, int64(1)).
+			Return(&domain.Ticket{ID: 1, Status: domain.StatusClosed, OrganizationID: orgID}, nil)
+		ticketRepo.On("GetByID", ctx, orgID, int64(2)).Return(nil, apperrors.ErrTicketNotFound)
+
+		n, err := newJob(snoozeRepo, ticketRepo, outbox).Resurface(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+		outbox.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything)
+	})
+}
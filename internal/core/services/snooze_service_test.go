@@ -0,0 +1,83 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnoozeService_SnoozeTicket(t *testing.T) {
+	ctx := context.Background()
+	agentID := uuid.New()
+	orgID := uuid.New()
+
+	newService := func() (ports.SnoozeService, *mocks.MockTicketSnoozeRepository, *mocks.MockTicketService, *mocks.MockAuthorizationService) {
+		repo := mocks.NewMockTicketSnoozeRepository()
+		tickets := mocks.NewMockTicketService()
+		authz := mocks.NewMockAuthorizationService()
+		return services.NewSnoozeService(repo, tickets, authz), repo, tickets, authz
+	}
+	params := func(until time.Time) ports.SnoozeTicketParams {
+		return ports.SnoozeTicketParams{TicketID: 7, ActorID: agentID, Until: until, Note: "Call back"}
+	}
+
+	t.Run("requires permission to update tickets", func(t *testing.T) {
+		svc, repo, _, authz := newService()
+		authz.On("Can", ctx, agentID, "tickets:update").Return(false, nil)
+
+		_, err := svc.SnoozeTicket(ctx, params(time.Now().Add(time.Hour)))
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects times in the past", func(t *testing.T) {
+		svc, repo, tickets, authz := newService()
+		authz.On("Can", ctx, agentID, "tickets:update").Return(true, nil)
+		tickets.On("GetTicket", ctx, int64(7), agentID).Return(&domain.Ticket{ID: 7, Status: domain.StatusOpen, OrganizationID: orgID}, nil)
+
+		_, err := svc.SnoozeTicket(ctx, params(time.Now().Add(-time.Hour)))
+
+		var errs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Contains(t, errs.Errors, "until")
+		repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+	})
+
+	t.Run("closed tickets cannot be snoozed", func(t *testing.T) {
+		svc, _, tickets, authz := newService()
+		authz.On("Can", ctx, agentID, "tickets:update").Return(true, nil)
+		tickets.On("GetTicket", ctx, int64(7), agentID).Return(&domain.Ticket{ID: 7, Status: domain.StatusClosed, OrganizationID: orgID}, nil)
+
+		_, err := svc.SnoozeTicket(ctx, params(time.Now().Add(time.Hour)))
+
+		assert.ErrorIs(t, err, apperrors.ErrCannotSnoozeClosed)
+	})
+
+	t.Run("stores the snooze", func(t *testing.T) {
+		svc, repo, tickets, authz := newService()
+		until := time.Now().Add(48 * time.Hour)
+		authz.On("Can", ctx, agentID, "tickets:update").Return(true, nil)
+		tickets.On("GetTicket", ctx, int64(7), agentID).Return(&domain.Ticket{ID: 7, Status: domain.StatusOpen, OrganizationID: orgID}, nil)
+		repo.On("Upsert", ctx, mock.MatchedBy(func(s *domain.TicketSnooze) bool {
+			return s.TicketID == 7 && s.OrganizationID == orgID && s.SnoozedBy == agentID &&
+				s.Until.Equal(until) && s.Note == "Call back"
+		})).Return(&domain.TicketSnooze{TicketID: 7, SnoozedBy: agentID, Until: until}, nil)
+
+		snooze, err := svc.SnoozeTicket(ctx, params(until))
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), snooze.TicketID)
+		repo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// TeamService lets admins group agents into teams, each with a queue of
+// tickets its members can claim.
+type TeamService struct {
+	teamRepo  ports.TeamRepository
+	userRepo  ports.UserRepository
+	authzSvc  ports.AuthorizationService
+	auditRepo ports.AuditLogRepository
+	txManager ports.TransactionManager
+}
+
+var _ ports.TeamService = (*TeamService)(nil)
+
+// NewTeamService creates a new TeamService.
+func NewTeamService(
+	teamRepo ports.TeamRepository,
+	userRepo ports.UserRepository,
+	authzSvc ports.AuthorizationService,
+	auditRepo ports.AuditLogRepository,
+	txManager ports.TransactionManager,
+) ports.TeamService {
+	return &TeamService{
+		teamRepo:  teamRepo,
+		userRepo:  userRepo,
+		authzSvc:  authzSvc,
+		auditRepo: auditRepo,
+		txManager: txManager,
+	}
+}
+
+// CreateTeam creates a team without members. Team names are unique within
+// an organization.
+func (s *TeamService) CreateTeam(ctx context.Context, actorID, orgID uuid.UUID, name string) (*domain.Team, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	team, err := domain.NewTeam(orgID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var created *domain.Team
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		created, err = s.teamRepo.Create(txCtx, team)
+		if err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditTeamCreated, created.ID, nil, map[string]any{"name": created.Name})
+	}); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// ListTeams returns the organization's teams.
+func (s *TeamService) ListTeams(ctx context.Context, actorID, orgID uuid.UUID) ([]*domain.Team, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	return s.teamRepo.ListByOrganization(ctx, orgID)
+}
+
+// RenameTeam changes a team's name.
+func (s *TeamService) RenameTeam(ctx context.Context, actorID, orgID, teamID uuid.UUID, name string) (*domain.Team, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	name, err := domain.NormalizeTeamName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := s.getTeam(ctx, orgID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if team.Name == name {
+		return team, nil
+	}
+
+	before := map[string]any{"name": team.Name}
+	team.Name = name
+
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.teamRepo.Update(txCtx, team); err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditTeamRenamed, team.ID, before, map[string]any{"name": team.Name})
+	}); err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// DeleteTeam removes a team. Tickets in its queue become unassigned; tickets
+// already claimed keep their assignee.
+func (s *TeamService) DeleteTeam(ctx context.Context, actorID, orgID, teamID uuid.UUID) error {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return err
+	}
+
+	team, err := s.getTeam(ctx, orgID, teamID)
+	if err != nil {
+		return err
+	}
+
+	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.teamRepo.Delete(txCtx, teamID); err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditTeamDeleted, team.ID, map[string]any{"name": team.Name}, nil)
+	})
+}
+
+// AddMember adds a user to a team. The user must be an active user of the
+// same organization who can claim tickets.
+func (s *TeamService) AddMember(ctx context.Context, actorID, orgID, teamID, userID uuid.UUID) (*domain.Team, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	team, err := s.getTeam(ctx, orgID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if team.HasMember(userID) {
+		return team, nil
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil && !errors.Is(err, apperrors.ErrUserNotFound) {
+		return nil, err
+	}
+	canClaim := false
+	if user != nil && user.OrganizationID == orgID && user.IsActive {
+		canClaim, err = s.authzSvc.Can(ctx, userID, "tickets:claim")
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !canClaim {
+		errs := apperrors.NewValidationErrors()
+		errs.Add("userId", "Member must be an active agent in your organization")
+		return nil, errs
+	}
+
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.teamRepo.AddMember(txCtx, teamID, userID); err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditTeamMemberAdded, team.ID, nil, map[string]any{"userId": userID})
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.teamRepo.GetByID(ctx, teamID)
+}
+
+// RemoveMember removes a user from a team. Tickets the user claimed from
+// the team's queue stay assigned to them.
+func (s *TeamService) RemoveMember(ctx context.Context, actorID, orgID, teamID, userID uuid.UUID) (*domain.Team, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	team, err := s.getTeam(ctx, orgID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.teamRepo.RemoveMember(txCtx, teamID, userID); err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditTeamMemberRemoved, team.ID, map[string]any{"userId": userID}, nil)
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.teamRepo.GetByID(ctx, teamID)
+}
+
+// ListMyTeams returns the teams the user belongs to.
+func (s *TeamService) ListMyTeams(ctx context.Context, userID uuid.UUID) ([]*domain.Team, error) {
+	return s.teamRepo.ListByMember(ctx, userID)
+}
+
+// getTeam fetches a team, hiding teams of other organizations.
+func (s *TeamService) getTeam(ctx context.Context, orgID, teamID uuid.UUID) (*domain.Team, error) {
+	team, err := s.teamRepo.GetByID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if team.OrganizationID != orgID {
+		return nil, apperrors.ErrTeamNotFound
+	}
+	return team, nil
+}
+
+// recordAudit logs a change made to a team.
+func (s *TeamService) recordAudit(ctx context.Context, orgID, actorID uuid.UUID, action domain.AuditAction, teamID uuid.UUID, before, after any) error {
+	entry, err := domain.NewAuditEntry(ctx, orgID, actorID, action, domain.AuditTargetTeam, teamID.String(), before, after)
+	if err != nil {
+		return err
+	}
+	return s.auditRepo.Create(ctx, entry)
+}
+
+func (s *TeamService) requireAdmin(ctx context.Context, actorID uuid.UUID) error {
+	allowed, err := s.authzSvc.Can(ctx, actorID, "admin:access")
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return apperrors.ErrForbidden
+	}
+	return nil
+}
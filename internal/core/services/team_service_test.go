@@ -0,0 +1,130 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamService_CreateTeam(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	orgID := uuid.New()
+
+	newService := func() (ports.TeamService, *mocks.MockTeamRepository, *mocks.MockAuthorizationService, *mocks.MockAuditLogRepository) {
+		repo := mocks.NewMockTeamRepository()
+		authz := mocks.NewMockAuthorizationService()
+		audit := mocks.NewMockAuditLogRepository()
+		svc := services.NewTeamService(repo, mocks.NewMockUserRepository(), authz, audit, stubTransactionManager{})
+		return svc, repo, authz, audit
+	}
+
+	t.Run("requires admin access", func(t *testing.T) {
+		svc, repo, authz, _ := newService()
+		authz.On("Can", ctx, actorID, "admin:access").Return(false, nil)
+
+		_, err := svc.CreateTeam(ctx, actorID, orgID, "Billing")
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a blank name", func(t *testing.T) {
+		svc, repo, authz, _ := newService()
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+
+		_, err := svc.CreateTeam(ctx, actorID, orgID, "   ")
+
+		var errs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Contains(t, errs.Errors, "name")
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("creates the team and audits it", func(t *testing.T) {
+		svc, repo, authz, audit := newService()
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		repo.On("Create", ctx, mock.MatchedBy(func(team *domain.Team) bool {
+			return team.OrganizationID == orgID && team.Name == "Billing"
+		})).Return(&domain.Team{ID: uuid.New(), OrganizationID: orgID, Name: "Billing"}, nil)
+		audit.On("Create", ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+			return e.Action == domain.AuditTeamCreated && e.TargetType == domain.AuditTargetTeam
+		})).Return(nil)
+
+		team, err := svc.CreateTeam(ctx, actorID, orgID, " Billing ")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Billing", team.Name)
+		audit.AssertExpectations(t)
+	})
+}
+
+func TestTeamService_AddMember(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	orgID := uuid.New()
+	userID := uuid.New()
+	team := &domain.Team{ID: uuid.New(), OrganizationID: orgID, Name: "Billing"}
+
+	newService := func() (ports.TeamService, *mocks.MockTeamRepository, *mocks.MockUserRepository, *mocks.MockAuthorizationService, *mocks.MockAuditLogRepository) {
+		repo := mocks.NewMockTeamRepository()
+		users := mocks.NewMockUserRepository()
+		authz := mocks.NewMockAuthorizationService()
+		audit := mocks.NewMockAuditLogRepository()
+		svc := services.NewTeamService(repo, users, authz, audit, stubTransactionManager{})
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		return svc, repo, users, authz, audit
+	}
+
+	t.Run("hides teams of other organizations", func(t *testing.T) {
+		svc, repo, _, _, _ := newService()
+		repo.On("GetByID", ctx, team.ID).Return(team, nil)
+
+		_, err := svc.AddMember(ctx, actorID, uuid.New(), team.ID, userID)
+
+		assert.ErrorIs(t, err, apperrors.ErrTeamNotFound)
+		repo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("member must be able to claim tickets", func(t *testing.T) {
+		svc, repo, users, authz, _ := newService()
+		repo.On("GetByID", ctx, team.ID).Return(team, nil)
+		users.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, OrganizationID: orgID, IsActive: true}, nil)
+		authz.On("Can", ctx, userID, "tickets:claim").Return(false, nil)
+
+		_, err := svc.AddMember(ctx, actorID, orgID, team.ID, userID)
+
+		var errs *apperrors.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Contains(t, errs.Errors, "userId")
+		repo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("adds an agent and audits it", func(t *testing.T) {
+		svc, repo, users, authz, audit := newService()
+		withMember := &domain.Team{ID: team.ID, OrganizationID: orgID, Name: team.Name, MemberIDs: []uuid.UUID{userID}}
+		repo.On("GetByID", ctx, team.ID).Return(team, nil).Once()
+		repo.On("GetByID", ctx, team.ID).Return(withMember, nil).Once()
+		users.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, OrganizationID: orgID, IsActive: true}, nil)
+		authz.On("Can", ctx, userID, "tickets:claim").Return(true, nil)
+		repo.On("AddMember", ctx, team.ID, userID).Return(nil)
+		audit.On("Create", ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+			return e.Action == domain.AuditTeamMemberAdded && e.TargetID == team.ID.String()
+		})).Return(nil)
+
+		updated, err := svc.AddMember(ctx, actorID, orgID, team.ID, userID)
+
+		require.NoError(t, err)
+		assert.True(t, updated.HasMember(userID))
+		audit.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,87 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicketExportService_ExportTicketPDF(t *testing.T) {
+	ctx := context.Background()
+	agentID := uuid.New()
+	requesterID := uuid.New()
+	removedID := uuid.New()
+	teamID := uuid.New()
+
+	type deps struct {
+		tickets  *mocks.MockTicketService
+		comments *mocks.MockCommentService
+		users    *mocks.MockUserRepository
+		teams    *mocks.MockTeamRepository
+		renderer *mocks.MockTicketDocumentRenderer
+	}
+	newService := func() (ports.TicketExportService, deps) {
+		d := deps{
+			tickets:  mocks.NewMockTicketService(),
+			comments: mocks.NewMockCommentService(),
+			users:    mocks.NewMockUserRepository(),
+			teams:    mocks.NewMockTeamRepository(),
+			renderer: mocks.NewMockTicketDocumentRenderer(),
+		}
+		return services.NewTicketExportService(d.tickets, d.comments, d.users, d.teams, d.renderer), d
+	}
+	commentsParams := ports.GetCommentsParams{TicketID: 7, ActorID: agentID}
+
+	t.Run("names everyone involved and renders the thread", func(t *testing.T) {
+		svc, d := newService()
+		ticket := &domain.Ticket{ID: 7, Title: "VPN down", RequesterID: requesterID, AssigneeID: &agentID, TeamID: &teamID}
+		created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+		d.tickets.On("GetTicket", ctx, int64(7), agentID).Return(ticket, nil)
+		d.comments.On("GetCommentsForTicket", ctx, commentsParams).Return([]*domain.Comment{
+			{ID: 1, TicketID: 7, AuthorID: requesterID, Body: "Still down", CreatedAt: created},
+			{ID: 2, TicketID: 7, AuthorID: removedID, Body: "Looking", CreatedAt: created.Add(time.Hour)},
+		}, nil)
+		d.users.On("GetByID", ctx, agentID).Return(&domain.User{ID: agentID, FullName: "Alice Agent", Timezone: "Europe/Madrid"}, nil)
+		d.users.On("GetByID", ctx, requesterID).Return(&domain.User{ID: requesterID, FullName: "Carl Customer"}, nil).Once()
+		d.users.On("GetByID", ctx, removedID).Return(nil, apperrors.ErrUserNotFound)
+		d.teams.On("GetByID", ctx, teamID).Return(&domain.Team{ID: teamID, Name: "Network"}, nil)
+		d.renderer.On("RenderPDF", mock.MatchedBy(func(doc *domain.TicketDocument) bool {
+			return doc.Ticket == ticket &&
+				doc.RequesterName == "Carl Customer" &&
+				doc.AssigneeName == "Alice Agent" &&
+				doc.TeamName == "Network" &&
+				doc.GeneratedBy == "Alice Agent" &&
+				doc.Location.String() == "Europe/Madrid" &&
+				len(doc.Comments) == 2 &&
+				doc.Comments[0].AuthorName == "Carl Customer" && doc.Comments[0].Body == "Still down" &&
+				doc.Comments[1].AuthorName == removedID.String()
+		}), "es").Return([]byte("%PDF-1.4"), nil)
+
+		out, err := svc.ExportTicketPDF(ctx, 7, agentID, "es")
+
+		require.NoError(t, err)
+		assert.Equal(t, []byte("%PDF-1.4"), out)
+		d.renderer.AssertExpectations(t)
+	})
+
+	t.Run("tickets the viewer cannot see are not exported", func(t *testing.T) {
+		svc, d := newService()
+		d.tickets.On("GetTicket", ctx, int64(7), agentID).Return(nil, apperrors.ErrForbidden)
+
+		_, err := svc.ExportTicketPDF(ctx, 7, agentID, "en")
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		d.comments.AssertNotCalled(t, "GetCommentsForTicket", mock.Anything, mock.Anything)
+		d.renderer.AssertNotCalled(t, "RenderPDF", mock.Anything, mock.Anything)
+	})
+}
@@ -3,7 +3,6 @@ package services
 import (
 	"context"
 	"fmt"
-	"sync"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -15,12 +14,16 @@ import (
 
 // TicketService implements business logic for ticket management
 type TicketService struct {
-	ticketRepo  ports.TicketRepository
-	authzSvc    ports.AuthorizationService
-	notifier    ports.Notifier
-	eventRepo   ports.TicketEventRepository
-	txManager   ports.TransactionManager
-	wg          sync.WaitGroup
+	ticketRepo   ports.TicketRepository
+	authzSvc     ports.AuthorizationService
+	outbox       ports.NotificationOutboxRepository
+	eventRepo    ports.TicketEventRepository
+	userRepo     ports.UserRepository
+	teamRepo     ports.TeamRepository
+	settingsRepo ports.OrgSettingsRepository
+	quotaSvc     ports.QuotaService
+	sanitizer    ports.HTMLSanitizer
+	txManager    ports.TransactionManager
 }
 
 var _ ports.TicketService = (*TicketService)(nil)
@@ -29,16 +32,26 @@ var _ ports.TicketService = (*TicketService)(nil)
 func NewTicketService(
 	ticketRepo ports.TicketRepository,
 	authzSvc ports.AuthorizationService,
-	notifier ports.Notifier,
+	outbox ports.NotificationOutboxRepository,
 	eventRepo ports.TicketEventRepository,
+	userRepo ports.UserRepository,
+	teamRepo ports.TeamRepository,
+	settingsRepo ports.OrgSettingsRepository,
+	quotaSvc ports.QuotaService,
+	sanitizer ports.HTMLSanitizer,
 	txManager ports.TransactionManager,
 ) ports.TicketService {
 	return &TicketService{
-		ticketRepo:  ticketRepo,
-		authzSvc:    authzSvc,
-		notifier:    notifier,
-		eventRepo:   eventRepo,
-		txManager:   txManager,
+		ticketRepo:   ticketRepo,
+		authzSvc:     authzSvc,
+		outbox:       outbox,
+		eventRepo:    eventRepo,
+		userRepo:     userRepo,
+		teamRepo:     teamRepo,
+		settingsRepo: settingsRepo,
+		quotaSvc:     quotaSvc,
+		sanitizer:    sanitizer,
+		txManager:    txManager,
 	}
 }
 
@@ -52,21 +65,53 @@ func (s *TicketService) CreateTicket(ctx context.Context, params ports.CreateTic
 	if !canCreate {
 		return nil, apperrors.ErrForbidden
 	}
+	if params.Visibility == domain.VisibilityInternal {
+		// Internal tickets are for agents' own work; whoever creates one
+		// must be able to read it back
+		canReadAll, err := s.authzSvc.Can(ctx, params.RequesterID, "tickets:read:all")
+		if err != nil {
+			return nil, err
+		}
+		if !canReadAll {
+			return nil, apperrors.ErrForbidden
+		}
+	}
+
+	// 2. The ticket belongs to the requester's organization
+	requester, err := s.userRepo.GetByID(ctx, params.RequesterID)
+	if err != nil {
+		return nil, err
+	}
 
-	// 2. Create domain entity with validation
+	// 3. Create domain entity with validation
 	ticketParams := domain.TicketParams{
-		Title:       params.Title,
-		Description: params.Description,
-		Priority:    params.Priority,
-		RequesterID: params.RequesterID,
+		Title:          params.Title,
+		Description:    params.Description,
+		Priority:       params.Priority,
+		RequesterID:    params.RequesterID,
+		OrganizationID: requester.OrganizationID,
+		Visibility:     params.Visibility,
 	}
 
 	ticket, err := domain.NewTicket(ticketParams)
 	if err != nil {
 		return nil, err // Validation errors are returned here
 	}
+	ticket.DescriptionHTML = s.sanitizer.Sanitize(ticket.Description)
+
+	// 4. Enforce the organization's open ticket quota
+	if err := s.quotaSvc.CheckOpenTicket(ctx, requester.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	// 5. Start the SLA timer against the organization's business hours
+	hours, err := businessHoursFor(ctx, s.settingsRepo, requester.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	ticket.ApplySLA(hours)
 
-	// 3. Persist the ticket and event atomically
+	// 6. Persist the ticket and event atomically
 	var createdTicket *domain.Ticket
 	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
 		newTicket, err := s.ticketRepo.Create(txCtx, ticket)
@@ -74,19 +119,17 @@ func (s *TicketService) CreateTicket(ctx context.Context, params ports.CreateTic
 			return err
 		}
 
-		payload, err := marshalEventPayload(domain.NewTicketSnapshot(newTicket))
+		event, err := domain.NewTicketEvent(domain.EventTicketCreated, newTicket, params.RequesterID)
 		if err != nil {
 			return err
 		}
 
-		event := &domain.Event{
-			TicketID: newTicket.ID,
-			Type:     domain.EventTicketCreated,
-			Payload:  payload,
-			ActorID:  params.RequesterID,
+		if _, err := s.eventRepo.Create(txCtx, event); err != nil {
+			return err
 		}
 
-		if _, err := s.eventRepo.Create(txCtx, event); err != nil {
+		// Confirm receipt to the requester
+		if err := s.outbox.Enqueue(txCtx, ticketCreatedNotification(newTicket)); err != nil {
 			return err
 		}
 
@@ -110,17 +153,23 @@ func (s *TicketService) GetTicket(ctx context.Context, ticketID int64, viewerID
 		return nil, apperrors.ErrForbidden
 	}
 
-	// 2. Fetch the ticket
-	ticket, err := s.ticketRepo.GetByID(ctx, ticketID)
+	// 2. Fetch the ticket from the viewer's organization; tickets of other
+	// organizations are not found, whatever the viewer's permissions
+	orgID, err := s.actorOrganization(ctx, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	ticket, err := s.ticketRepo.GetByID(ctx, orgID, ticketID)
 	if err != nil {
 		return nil, err
 	}
 
-	// 3. Check ownership or elevated permissions
+	// 3. Check ownership or elevated permissions. Internal tickets are
+	// never visible through ownership or assignment alone.
 	isOwner := ticket.IsOwnedBy(viewerID)
 	isAssignee := ticket.IsAssignedTo(viewerID)
 
-	if !isOwner && !isAssignee {
+	if ticket.IsInternal() || (!isOwner && !isAssignee) {
 		// Check if the user can view all tickets (admin/agent)
 		canReadAll, _ := s.authzSvc.Can(ctx, viewerID, "tickets:read:all")
 		if !canReadAll {
@@ -143,7 +192,11 @@ func (s *TicketService) UpdateStatus(ctx context.Context, params ports.UpdateSta
 	}
 
 	// 2. Fetch and update domain entity
-	ticket, err := s.ticketRepo.GetByID(ctx, params.TicketID)
+	orgID, err := s.actorOrganization(ctx, params.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	ticket, err := s.ticketRepo.GetByID(ctx, orgID, params.TicketID)
 	if err != nil {
 		return nil, err
 	}
@@ -152,6 +205,11 @@ func (s *TicketService) UpdateStatus(ctx context.Context, params ports.UpdateSta
 	if err := ticket.UpdateStatus(params.Status); err != nil {
 		return nil, err
 	}
+	if ticket.ClosedAt != nil {
+		if err := s.applySLA(ctx, ticket); err != nil {
+			return nil, err
+		}
+	}
 
 	// 4. Persist changes
 	var updatedTicket *domain.Ticket
@@ -161,59 +219,141 @@ func (s *TicketService) UpdateStatus(ctx context.Context, params ports.UpdateSta
 			return err
 		}
 
-		payload, err := marshalEventPayload(domain.NewTicketSnapshot(savedTicket))
+		event, err := domain.NewTicketEvent(domain.EventStatusUpdated, savedTicket, params.ActorID)
 		if err != nil {
 			return err
 		}
 
-		event := &domain.Event{
-			TicketID: savedTicket.ID,
-			Type:     domain.EventStatusUpdated,
-			Payload:  payload,
-			ActorID:  params.ActorID,
-		}
-
 		if _, err := s.eventRepo.Create(txCtx, event); err != nil {
 			return err
 		}
 
+		// Let the requester know, unless they changed the status themselves
+		if savedTicket.RequesterID != params.ActorID {
+			if err := s.outbox.Enqueue(txCtx, statusChangedNotification(savedTicket)); err != nil {
+				return err
+			}
+		}
+
 		updatedTicket = savedTicket
 		return nil
 	}); err != nil {
 		return nil, err
 	}
 
-	// 5. Send notification (async, in background context)
-	if ticket.RequesterID != params.ActorID {
-		go s.notifyStatusUpdate(updatedTicket, params.ActorID)
-	}
-
 	return updatedTicket, nil
 }
 
 // AssignTicket assigns a ticket to an agent
-func (s *TicketService) AssignTicket(ctx context.Context, params ports.AssignTicketParams) (*domain.Ticket, error) {
+func (s *TicketService) AssignTicket(ctx context.Context, params ports.AssignTicketParams) (*domain.Ticket, *domain.CapacityWarning, error) {
 	// 1. Fetch ticket with access controls to avoid assigning tickets the actor cannot see.
 	ticket, err := s.GetTicket(ctx, params.TicketID, params.ActorID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// 2. Authorization check: only users with tickets:assign can assign.
 	canAssign, err := s.authzSvc.Can(ctx, params.ActorID, "tickets:assign")
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if !canAssign {
-		return nil, apperrors.ErrForbidden
+		return nil, nil, apperrors.ErrForbidden
 	}
 
 	// 3. Apply assignment (domain validates business rules)
+	previousAssignee := ticket.AssigneeID
 	if err := ticket.Assign(params.AssigneeID); err != nil {
+		return nil, nil, err
+	}
+
+	// 4. Enforce the organization's open ticket limit on a new assignee
+	var warning *domain.CapacityWarning
+	if previousAssignee == nil || *previousAssignee != params.AssigneeID {
+		warning, err = s.checkAgentCapacity(ctx, ticket.OrganizationID, params.AssigneeID)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// 5. Self-assignment needs no notification; otherwise resolve who raised the ticket
+	notifyAssignee := params.AssigneeID != params.ActorID
+	requesterName := ""
+	if notifyAssignee {
+		requesterName = lookupRequesterName(ctx, s.userRepo, ticket)
+	}
+
+	// 6. Persist changes and event atomically
+	var updatedTicket *domain.Ticket
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		savedTicket, err := s.ticketRepo.Update(txCtx, ticket)
+		if err != nil {
+			return err
+		}
+
+		event, err := domain.NewTicketEvent(domain.EventTicketAssigned, savedTicket, params.ActorID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.eventRepo.Create(txCtx, event); err != nil {
+			return err
+		}
+
+		// Let the new assignee know the ticket is now theirs
+		if notifyAssignee {
+			notification := ticketAssignedNotification(savedTicket, params.AssigneeID, requesterName)
+			if err := s.outbox.Enqueue(txCtx, notification); err != nil {
+				return err
+			}
+		}
+
+		updatedTicket = savedTicket
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return updatedTicket, warning, nil
+}
+
+// AssignTeam puts a ticket in a team's queue, removing any assignee, so a
+// member of the team can claim it.
+func (s *TicketService) AssignTeam(ctx context.Context, params ports.AssignTeamParams) (*domain.Ticket, error) {
+	// 1. Fetch ticket with access controls
+	ticket, err := s.GetTicket(ctx, params.TicketID, params.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Authorization check: routing to a team is a form of assignment
+	canAssign, err := s.authzSvc.Can(ctx, params.ActorID, "tickets:assign")
+	if err != nil {
 		return nil, err
 	}
+	if !canAssign {
+		return nil, apperrors.ErrForbidden
+	}
 
-	// 4. Persist changes and event atomically
+	// 3. The team must belong to the actor's organization
+	actor, err := s.userRepo.GetByID(ctx, params.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	team, err := s.teamRepo.GetByID(ctx, params.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	if team.OrganizationID != actor.OrganizationID {
+		return nil, apperrors.ErrTeamNotFound
+	}
+
+	// 4. Apply the assignment (domain validates business rules)
+	if err := ticket.AssignToTeam(team.ID); err != nil {
+		return nil, err
+	}
+
+	// 5. Persist changes and event atomically
 	var updatedTicket *domain.Ticket
 	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
 		savedTicket, err := s.ticketRepo.Update(txCtx, ticket)
@@ -221,22 +361,210 @@ func (s *TicketService) AssignTicket(ctx context.Context, params ports.AssignTic
 			return err
 		}
 
-		payload, err := marshalEventPayload(domain.NewTicketSnapshot(savedTicket))
+		if err := s.recordAssignment(txCtx, savedTicket, params.ActorID); err != nil {
+			return err
+		}
+
+		updatedTicket = savedTicket
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return updatedTicket, nil
+}
+
+// ClaimTicket assigns a ticket waiting in one of the actor's team queues to
+// the actor. Of two agents claiming the same ticket, only the first gets it.
+func (s *TicketService) ClaimTicket(ctx context.Context, params ports.ClaimTicketParams) (*domain.Ticket, error) {
+	// 1. Fetch ticket with access controls
+	ticket, err := s.GetTicket(ctx, params.TicketID, params.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Authorization check
+	canClaim, err := s.authzSvc.Can(ctx, params.ActorID, "tickets:claim")
+	if err != nil {
+		return nil, err
+	}
+	if !canClaim {
+		return nil, apperrors.ErrForbidden
+	}
+
+	// 3. Only members of the ticket's team can claim it
+	if ticket.TeamID == nil {
+		return nil, apperrors.ErrForbidden
+	}
+	team, err := s.teamRepo.GetByID(ctx, *ticket.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	if !team.HasMember(params.ActorID) {
+		return nil, apperrors.ErrForbidden
+	}
+
+	// 4. Check the claim against the ticket as read; the repository rechecks
+	// it atomically
+	if err := ticket.Claim(params.ActorID); err != nil {
+		return nil, err
+	}
+
+	// 5. Persist the claim and event atomically
+	var claimedTicket *domain.Ticket
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		claimed, err := s.ticketRepo.Claim(txCtx, ticket.OrganizationID, ticket.ID, params.ActorID, *ticket.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		if err := s.recordAssignment(txCtx, claimed, params.ActorID); err != nil {
+			return err
+		}
+
+		claimedTicket = claimed
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return claimedTicket, nil
+}
+
+// actorOrganization returns the organization of the acting user, which
+// scopes every ticket lookup.
+func (s *TicketService) actorOrganization(ctx context.Context, actorID uuid.UUID) (uuid.UUID, error) {
+	actor, err := s.userRepo.GetByID(ctx, actorID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return actor.OrganizationID, nil
+}
+
+// applySLA recomputes the ticket's SLA timers against the business hours of
+// its organization.
+func (s *TicketService) applySLA(ctx context.Context, ticket *domain.Ticket) error {
+	hours, err := businessHoursFor(ctx, s.settingsRepo, ticket.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	ticket.ApplySLA(hours)
+	return nil
+}
+
+// recordAssignment records a TICKET_ASSIGNED event for the ticket as saved.
+func (s *TicketService) recordAssignment(ctx context.Context, ticket *domain.Ticket, actorID uuid.UUID) error {
+	event, err := domain.NewTicketEvent(domain.EventTicketAssigned, ticket, actorID)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.eventRepo.Create(ctx, event)
+	return err
+}
+
+// UpdateTicket applies a partial update of a ticket's status, assignee,
+// title, description and priority. All changes are validated together and
+// saved in one transaction, recorded as a single event.
+func (s *TicketService) UpdateTicket(ctx context.Context, params ports.UpdateTicketParams) (*domain.Ticket, error) {
+	changes := params.Changes
+	if changes.IsEmpty() {
+		errs := apperrors.NewValidationErrors()
+		errs.Add("body", "At least one field must be changed")
+		return nil, errs
+	}
+
+	// 1. Fetch ticket with access controls
+	ticket, err := s.GetTicket(ctx, params.TicketID, params.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Each kind of change needs its own permission
+	var required []string
+	if changes.Status != nil {
+		required = append(required, "tickets:update:status")
+	}
+	if changes.AssigneeID != nil {
+		required = append(required, "tickets:assign")
+	}
+	if changes.ChangesDetails() {
+		required = append(required, "tickets:update")
+	}
+	for _, permission := range required {
+		allowed, err := s.authzSvc.Can(ctx, params.ActorID, permission)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, apperrors.ErrForbidden
+		}
+	}
+
+	// 3. Apply changes (domain validates them and the business rules)
+	previousStatus := ticket.Status
+	previousAssignee := ticket.AssigneeID
+	if err := ticket.Apply(changes); err != nil {
+		return nil, err
+	}
+	if changes.Description != nil {
+		ticket.DescriptionHTML = s.sanitizer.Sanitize(ticket.Description)
+	}
+	statusChanged := ticket.Status != previousStatus
+	assigneeChanged := changes.AssigneeID != nil &&
+		(previousAssignee == nil || *previousAssignee != *changes.AssigneeID)
+	if assigneeChanged {
+		// Under a limit that only warns the assignment goes ahead; the
+		// warning is returned by AssignTicket alone
+		if _, err := s.checkAgentCapacity(ctx, ticket.OrganizationID, *changes.AssigneeID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Closing stops the SLA timer; a new priority moves the due date
+	if ticket.ClosedAt != nil || changes.Priority != nil {
+		if err := s.applySLA(ctx, ticket); err != nil {
+			return nil, err
+		}
+	}
+
+	// 4. Only a new assignee other than the actor is notified
+	notifyAssignee := assigneeChanged && *ticket.AssigneeID != params.ActorID
+	requesterName := ""
+	if notifyAssignee {
+		requesterName = lookupRequesterName(ctx, s.userRepo, ticket)
+	}
+
+	// 5. Persist changes and event atomically
+	var updatedTicket *domain.Ticket
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		savedTicket, err := s.ticketRepo.Update(txCtx, ticket)
 		if err != nil {
 			return err
 		}
 
-		event := &domain.Event{
-			TicketID: savedTicket.ID,
-			Type:     domain.EventTicketAssigned,
-			Payload:  payload,
-			ActorID:  params.ActorID,
+		event, err := domain.NewTicketEvent(ticketUpdateEventType(changes), savedTicket, params.ActorID)
+		if err != nil {
+			return err
 		}
 
 		if _, err := s.eventRepo.Create(txCtx, event); err != nil {
 			return err
 		}
 
+		if statusChanged && savedTicket.RequesterID != params.ActorID {
+			if err := s.outbox.Enqueue(txCtx, statusChangedNotification(savedTicket)); err != nil {
+				return err
+			}
+		}
+		if notifyAssignee {
+			notification := ticketAssignedNotification(savedTicket, *savedTicket.AssigneeID, requesterName)
+			if err := s.outbox.Enqueue(txCtx, notification); err != nil {
+				return err
+			}
+		}
+
 		updatedTicket = savedTicket
 		return nil
 	}); err != nil {
@@ -246,6 +574,50 @@ func (s *TicketService) AssignTicket(ctx context.Context, params ports.AssignTic
 	return updatedTicket, nil
 }
 
+// checkAgentCapacity enforces the organization's open ticket limit on
+// assigning a ticket to assigneeID. It returns apperrors.ErrAgentAtCapacity
+// if the assignee is at the limit and it blocks, and a warning if it only
+// warns.
+func (s *TicketService) checkAgentCapacity(ctx context.Context, orgID, assigneeID uuid.UUID) (*domain.CapacityWarning, error) {
+	capacity, err := s.settingsRepo.GetAgentCapacity(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !capacity.Limited() {
+		return nil, nil
+	}
+
+	open, err := s.ticketRepo.ListOpenByAssignee(ctx, orgID, assigneeID)
+	if err != nil {
+		return nil, err
+	}
+	if !capacity.Full(int64(len(open))) {
+		return nil, nil
+	}
+	if capacity.Enforcement == domain.CapacityBlock {
+		return nil, apperrors.ErrAgentAtCapacity
+	}
+	return &domain.CapacityWarning{
+		AssigneeID:     assigneeID,
+		OpenTickets:    int64(len(open)),
+		MaxOpenTickets: *capacity.MaxOpenTickets,
+	}, nil
+}
+
+// ticketUpdateEventType records an update of only the status or only the
+// assignee as the same event as the dedicated endpoints, so webhook
+// subscribers see it; any other update is a TICKET_UPDATED event.
+func ticketUpdateEventType(changes domain.TicketChanges) domain.EventType {
+	switch {
+	case changes.ChangesDetails() || (changes.Status != nil && changes.AssigneeID != nil):
+		return domain.EventTicketUpdated
+	case changes.Status != nil:
+		return domain.EventStatusUpdated
+	default:
+		return domain.EventTicketAssigned
+	}
+}
+
 // ListTickets retrieves tickets based on user permissions
 func (s *TicketService) ListTickets(ctx context.Context, params ports.ListTicketsParams) ([]*domain.Ticket, error) {
 	// 1. Check if user can see all tickets
@@ -254,6 +626,12 @@ func (s *TicketService) ListTickets(ctx context.Context, params ports.ListTicket
 		return nil, err
 	}
 
+	// 2. Only tickets of the viewer's organization are listed
+	orgID, err := s.actorOrganization(ctx, params.ViewerID)
+	if err != nil {
+		return nil, err
+	}
+
 	fetchLimit := params.Limit + 1
 
 	assigneeID := pgtype.UUID{}
@@ -276,46 +654,96 @@ func (s *TicketService) ListTickets(ctx context.Context, params ports.ListTicket
 		unassigned = pgtype.Bool{Bool: true, Valid: true}
 	}
 
+	teamID := pgtype.UUID{}
+	if params.TeamID != nil {
+		teamID = pgtype.UUID{Bytes: *params.TeamID, Valid: true}
+	}
+
+	needsReassignment := pgtype.Bool{}
+	if params.NeedsReassignment {
+		needsReassignment = pgtype.Bool{Bool: true, Valid: true}
+	}
+
 	repoParams := ports.ListTicketsRepoParams{
-		Limit:       int32(fetchLimit),
-		Offset:      int32(params.Offset),
-		Status:      utils.ToNullString(params.Status),
-		Priority:    utils.ToNullString(params.Priority),
-		AssigneeID:  assigneeID,
-		Unassigned:  unassigned,
-		CreatedFrom: createdFrom,
-		CreatedTo:   createdTo,
+		OrganizationID:    pgtype.UUID{Bytes: orgID, Valid: true},
+		Limit:             int32(fetchLimit),
+		Offset:            int32(params.Offset),
+		Status:            utils.ToNullString(params.Status),
+		Priority:          utils.ToNullString(params.Priority),
+		AssigneeID:        assigneeID,
+		Unassigned:        unassigned,
+		CreatedFrom:       createdFrom,
+		CreatedTo:         createdTo,
+		NeedsReassignment: needsReassignment,
+		TeamID:            teamID,
 	}
 
 	// ... execute query ...
 	// 3. Query based on permissions
 	if canListAll {
+		repoParams.HideSnoozed = !params.IncludeSnoozed
 		return s.ticketRepo.ListPaginated(ctx, repoParams)
 	}
 
-	// Default: scope query to the requesting user's tickets
+	// Default: scope query to the requesting user's public tickets
 	repoParams.RequesterID = pgtype.UUID{Bytes: params.ViewerID, Valid: true}
+	repoParams.HideInternal = true
 	return s.ticketRepo.ListByRequesterPaginated(ctx, repoParams)
 }
 
-// notifyStatusUpdate sends email notification for status changes
-func (s *TicketService) notifyStatusUpdate(ticket *domain.Ticket, actorID uuid.UUID) {
-	s.wg.Add(1)
-	go func() {
-		defer s.wg.Done()
-		// Use background context since the HTTP request may be done
-		ctx := context.Background()
-
-		s.notifier.Notify(ctx, ports.NotificationParams{
-			RecipientUserID: ticket.RequesterID,
-			Subject:         fmt.Sprintf("Your ticket status has been updated: #%d", ticket.ID),
-			Message:         fmt.Sprintf("The status of your ticket '%s' was changed to %s.", ticket.Title, ticket.Status),
-			TicketID:        ticket.ID,
-		})
-	}()
+// lookupRequesterName returns the display name of the ticket's requester. A
+// failed lookup only degrades the notification, so it never blocks the change
+// itself.
+func lookupRequesterName(ctx context.Context, userRepo ports.UserRepository, ticket *domain.Ticket) string {
+	requester, err := userRepo.GetByID(ctx, ticket.RequesterID)
+	if err != nil {
+		return ""
+	}
+	return requester.FullName
 }
 
-// broadcastStatusUpdate sends real-time event for status changes
-func (s *TicketService) Shutdown() {
-	s.wg.Wait()
+// ticketCreatedNotification confirms to the requester that their ticket was received
+func ticketCreatedNotification(ticket *domain.Ticket) ports.NotificationParams {
+	return ports.NotificationParams{
+		RecipientUserID: ticket.RequesterID,
+		Type:            ports.NotificationTicketCreated,
+		Subject:         fmt.Sprintf("We received your ticket: #%d", ticket.ID),
+		Message:         fmt.Sprintf("Your ticket '%s' has been created.", ticket.Title),
+		TicketID:        ticket.ID,
+		Data: map[string]string{
+			"title":    ticket.Title,
+			"priority": string(ticket.Priority),
+		},
+	}
+}
+
+// statusChangedNotification tells the requester their ticket's status changed
+func statusChangedNotification(ticket *domain.Ticket) ports.NotificationParams {
+	return ports.NotificationParams{
+		RecipientUserID: ticket.RequesterID,
+		Type:            ports.NotificationStatusChanged,
+		Subject:         fmt.Sprintf("Your ticket status has been updated: #%d", ticket.ID),
+		Message:         fmt.Sprintf("The status of your ticket '%s' was changed to %s.", ticket.Title, ticket.Status),
+		TicketID:        ticket.ID,
+		Data: map[string]string{
+			"title":  ticket.Title,
+			"status": string(ticket.Status),
+		},
+	}
+}
+
+// ticketAssignedNotification tells a user a ticket was assigned to them
+func ticketAssignedNotification(ticket *domain.Ticket, assigneeID uuid.UUID, requesterName string) ports.NotificationParams {
+	return ports.NotificationParams{
+		RecipientUserID: assigneeID,
+		Type:            ports.NotificationTicketAssigned,
+		Subject:         fmt.Sprintf("Ticket assigned to you: #%d", ticket.ID),
+		Message:         fmt.Sprintf("The %s priority ticket '%s' has been assigned to you.", ticket.Priority, ticket.Title),
+		TicketID:        ticket.ID,
+		Data: map[string]string{
+			"title":          ticket.Title,
+			"priority":       string(ticket.Priority),
+			"requester_name": requesterName,
+		},
+	}
 }
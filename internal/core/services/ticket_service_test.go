@@ -3,6 +3,7 @@ package services_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lorrc/service-desk-backend/internal/core/domain"
@@ -21,22 +22,54 @@ func (stubTransactionManager) WithTransaction(ctx context.Context, fn func(conte
 	return fn(ctx)
 }
 
+// unlimitedQuota returns a quota service that allows everything.
+func unlimitedQuota() *mocks.MockQuotaService {
+	quotaSvc := mocks.NewMockQuotaService()
+	quotaSvc.On("CheckUsers", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	quotaSvc.On("CheckOpenTicket", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return quotaSvc
+}
+
+// plainSanitizer returns a sanitizer that leaves rich text empty.
+func plainSanitizer() *mocks.MockHTMLSanitizer {
+	sanitizer := mocks.NewMockHTMLSanitizer()
+	sanitizer.On("Sanitize", mock.Anything).Return("").Maybe()
+	return sanitizer
+}
+
+// defaultBusinessHours returns a settings repository for organizations that
+// have not configured business hours, nor an open ticket limit for agents.
+func defaultBusinessHours() *mocks.MockOrgSettingsRepository {
+	settingsRepo := mocks.NewMockOrgSettingsRepository()
+	settingsRepo.On("GetBusinessHours", mock.Anything, mock.Anything).Return(nil, apperrors.ErrNotFound).Maybe()
+	settingsRepo.On("GetAgentCapacity", mock.Anything, mock.Anything).
+		Return(&domain.AgentCapacity{Enforcement: domain.CapacityWarn}, nil).Maybe()
+	return settingsRepo
+}
+
 func TestTicketService_CreateTicket(t *testing.T) {
 	ctx := context.Background()
 	userID := uuid.New()
+	orgID := uuid.New()
 
 	t.Run("success", func(t *testing.T) {
 		mockRepo := mocks.NewMockTicketRepository()
 		mockAuthz := mocks.NewMockAuthorizationService()
-		mockNotifier := mocks.NewMockNotifier()
+		mockOutbox := mocks.NewMockNotificationOutboxRepository()
 		mockEventRepo := mocks.NewMockTicketEventRepository()
+		mockUserRepo := mocks.NewMockUserRepository()
+		sanitizer := mocks.NewMockHTMLSanitizer()
 		txManager := stubTransactionManager{}
 
-		svc := services.NewTicketService(mockRepo, mockAuthz, mockNotifier, mockEventRepo, txManager)
+		svc := services.NewTicketService(mockRepo, mockAuthz, mockOutbox, mockEventRepo, mockUserRepo, mocks.NewMockTeamRepository(), defaultBusinessHours(), unlimitedQuota(), sanitizer, txManager)
 
 		// Setup expectations
 		mockAuthz.On("Can", ctx, userID, "tickets:create").Return(true, nil)
-		mockRepo.On("Create", ctx, mock.AnythingOfType("*domain.Ticket")).
+		mockUserRepo.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, OrganizationID: orgID}, nil)
+		sanitizer.On("Sanitize", "Test Description").Return("<p>Test Description</p>")
+		mockRepo.On("Create", ctx, mock.MatchedBy(func(t *domain.Ticket) bool {
+			return t.OrganizationID == orgID && t.DescriptionHTML == "<p>Test Description</p>"
+		})).
 			Return(&domain.Ticket{
 				ID:          1,
 				Title:       "Test Ticket",
@@ -47,6 +80,9 @@ func TestTicketService_CreateTicket(t *testing.T) {
 			}, nil)
 		mockEventRepo.On("Create", ctx, mock.AnythingOfType("*domain.Event")).
 			Return(&domain.Event{ID: 1}, nil)
+		mockOutbox.On("Enqueue", mock.Anything, mock.MatchedBy(func(p ports.NotificationParams) bool {
+			return p.RecipientUserID == userID && p.Type == ports.NotificationTicketCreated && p.TicketID == 1
+		})).Return(nil)
 
 		params := ports.CreateTicketParams{
 			Title:       "Test Ticket",
@@ -66,16 +102,72 @@ func TestTicketService_CreateTicket(t *testing.T) {
 		mockAuthz.AssertExpectations(t)
 		mockRepo.AssertExpectations(t)
 		mockEventRepo.AssertExpectations(t)
+		mockOutbox.AssertExpectations(t)
+	})
+
+	t.Run("open ticket quota reached", func(t *testing.T) {
+		mockRepo := mocks.NewMockTicketRepository()
+		mockAuthz := mocks.NewMockAuthorizationService()
+		mockUserRepo := mocks.NewMockUserRepository()
+		quotaSvc := mocks.NewMockQuotaService()
+
+		svc := services.NewTicketService(mockRepo, mockAuthz, mocks.NewMockNotificationOutboxRepository(), mocks.NewMockTicketEventRepository(), mockUserRepo, mocks.NewMockTeamRepository(), defaultBusinessHours(), quotaSvc, plainSanitizer(), stubTransactionManager{})
+
+		mockAuthz.On("Can", ctx, userID, "tickets:create").Return(true, nil)
+		mockUserRepo.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, OrganizationID: orgID}, nil)
+		quotaSvc.On("CheckOpenTicket", ctx, orgID).Return(apperrors.ErrOpenTicketQuotaExceeded)
+
+		ticket, err := svc.CreateTicket(ctx, ports.CreateTicketParams{
+			Title:       "Test Ticket",
+			Priority:    domain.PriorityMedium,
+			RequesterID: userID,
+		})
+
+		assert.Nil(t, ticket)
+		assert.ErrorIs(t, err, apperrors.ErrOpenTicketQuotaExceeded)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("due date follows the organization's business hours", func(t *testing.T) {
+		mockRepo := mocks.NewMockTicketRepository()
+		mockAuthz := mocks.NewMockAuthorizationService()
+		mockUserRepo := mocks.NewMockUserRepository()
+		settingsRepo := mocks.NewMockOrgSettingsRepository()
+
+		svc := services.NewTicketService(mockRepo, mockAuthz, mocks.NewMockNotificationOutboxRepository(), mocks.NewMockTicketEventRepository(), mockUserRepo, mocks.NewMockTeamRepository(), settingsRepo, unlimitedQuota(), plainSanitizer(), stubTransactionManager{})
+
+		// Working around the clock, the timer never pauses
+		hours := &domain.BusinessHours{OrganizationID: orgID, Timezone: "UTC"}
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			hours.Days = append(hours.Days, domain.WorkingDay{Weekday: d, Open: 0, Close: 24 * 60})
+		}
+
+		mockAuthz.On("Can", ctx, userID, "tickets:create").Return(true, nil)
+		mockUserRepo.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, OrganizationID: orgID}, nil)
+		settingsRepo.On("GetBusinessHours", ctx, orgID).Return(hours, nil)
+		mockRepo.On("Create", ctx, mock.MatchedBy(func(t *domain.Ticket) bool {
+			return t.DueAt != nil && t.DueAt.Sub(t.CreatedAt) == domain.ResolutionTarget(domain.PriorityHigh)
+		})).Return(nil, assert.AnError)
+
+		_, err := svc.CreateTicket(ctx, ports.CreateTicketParams{
+			Title:       "Test Ticket",
+			Priority:    domain.PriorityHigh,
+			RequesterID: userID,
+		})
+
+		assert.ErrorIs(t, err, assert.AnError)
+		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("forbidden when no permission", func(t *testing.T) {
 		mockRepo := mocks.NewMockTicketRepository()
 		mockAuthz := mocks.NewMockAuthorizationService()
-		mockNotifier := mocks.NewMockNotifier()
+		mockOutbox := mocks.NewMockNotificationOutboxRepository()
 		mockEventRepo := mocks.NewMockTicketEventRepository()
+		mockUserRepo := mocks.NewMockUserRepository()
 		txManager := stubTransactionManager{}
 
-		svc := services.NewTicketService(mockRepo, mockAuthz, mockNotifier, mockEventRepo, txManager)
+		svc := services.NewTicketService(mockRepo, mockAuthz, mockOutbox, mockEventRepo, mockUserRepo, mocks.NewMockTeamRepository(), defaultBusinessHours(), unlimitedQuota(), plainSanitizer(), txManager)
 
 		mockAuthz.On("Can", ctx, userID, "tickets:create").Return(false, nil)
 
@@ -93,16 +185,39 @@ func TestTicketService_CreateTicket(t *testing.T) {
 		mockRepo.AssertNotCalled(t, "Create")
 	})
 
+	t.Run("internal tickets need permission to read all tickets", func(t *testing.T) {
+		mockRepo := mocks.NewMockTicketRepository()
+		mockAuthz := mocks.NewMockAuthorizationService()
+
+		svc := services.NewTicketService(mockRepo, mockAuthz, mocks.NewMockNotificationOutboxRepository(), mocks.NewMockTicketEventRepository(), mocks.NewMockUserRepository(), mocks.NewMockTeamRepository(), defaultBusinessHours(), unlimitedQuota(), plainSanitizer(), stubTransactionManager{})
+
+		mockAuthz.On("Can", ctx, userID, "tickets:create").Return(true, nil)
+		mockAuthz.On("Can", ctx, userID, "tickets:read:all").Return(false, nil)
+
+		ticket, err := svc.CreateTicket(ctx, ports.CreateTicketParams{
+			Title:       "Rotate the database credentials",
+			Priority:    domain.PriorityMedium,
+			RequesterID: userID,
+			Visibility:  domain.VisibilityInternal,
+		})
+
+		assert.Nil(t, ticket)
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
 	t.Run("validation error for empty title", func(t *testing.T) {
 		mockRepo := mocks.NewMockTicketRepository()
 		mockAuthz := mocks.NewMockAuthorizationService()
-		mockNotifier := mocks.NewMockNotifier()
+		mockOutbox := mocks.NewMockNotificationOutboxRepository()
 		mockEventRepo := mocks.NewMockTicketEventRepository()
+		mockUserRepo := mocks.NewMockUserRepository()
 		txManager := stubTransactionManager{}
 
-		svc := services.NewTicketService(mockRepo, mockAuthz, mockNotifier, mockEventRepo, txManager)
+		svc := services.NewTicketService(mockRepo, mockAuthz, mockOutbox, mockEventRepo, mockUserRepo, mocks.NewMockTeamRepository(), defaultBusinessHours(), unlimitedQuota(), plainSanitizer(), txManager)
 
 		mockAuthz.On("Can", ctx, userID, "tickets:create").Return(true, nil)
+		mockUserRepo.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, OrganizationID: orgID}, nil)
 
 		params := ports.CreateTicketParams{
 			Title:       "", // Empty title
@@ -122,16 +237,18 @@ func TestTicketService_CreateTicket(t *testing.T) {
 func TestTicketService_GetTicket(t *testing.T) {
 	ctx := context.Background()
 	userID := uuid.New()
+	orgID := uuid.New()
 	ticketID := int64(1)
 
 	t.Run("owner can access own ticket", func(t *testing.T) {
 		mockRepo := mocks.NewMockTicketRepository()
 		mockAuthz := mocks.NewMockAuthorizationService()
-		mockNotifier := mocks.NewMockNotifier()
+		mockOutbox := mocks.NewMockNotificationOutboxRepository()
 		mockEventRepo := mocks.NewMockTicketEventRepository()
+		mockUserRepo := mocks.NewMockUserRepository()
 		txManager := stubTransactionManager{}
 
-		svc := services.NewTicketService(mockRepo, mockAuthz, mockNotifier, mockEventRepo, txManager)
+		svc := services.NewTicketService(mockRepo, mockAuthz, mockOutbox, mockEventRepo, mockUserRepo, mocks.NewMockTeamRepository(), defaultBusinessHours(), unlimitedQuota(), plainSanitizer(), txManager)
 
 		expectedTicket := &domain.Ticket{
 			ID:          ticketID,
@@ -141,7 +258,8 @@ func TestTicketService_GetTicket(t *testing.T) {
 		}
 
 		mockAuthz.On("Can", ctx, userID, "tickets:read").Return(true, nil)
-		mockRepo.On("GetByID", ctx, ticketID).Return(expectedTicket, nil)
+		mockUserRepo.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, OrganizationID: orgID}, nil)
+		mockRepo.On("GetByID", ctx, orgID, ticketID).Return(expectedTicket, nil)
 
 		ticket, err := svc.GetTicket(ctx, ticketID, userID)
 
@@ -152,11 +270,12 @@ func TestTicketService_GetTicket(t *testing.T) {
 	t.Run("non-owner without admin permission is forbidden", func(t *testing.T) {
 		mockRepo := mocks.NewMockTicketRepository()
 		mockAuthz := mocks.NewMockAuthorizationService()
-		mockNotifier := mocks.NewMockNotifier()
+		mockOutbox := mocks.NewMockNotificationOutboxRepository()
 		mockEventRepo := mocks.NewMockTicketEventRepository()
+		mockUserRepo := mocks.NewMockUserRepository()
 		txManager := stubTransactionManager{}
 
-		svc := services.NewTicketService(mockRepo, mockAuthz, mockNotifier, mockEventRepo, txManager)
+		svc := services.NewTicketService(mockRepo, mockAuthz, mockOutbox, mockEventRepo, mockUserRepo, mocks.NewMockTeamRepository(), defaultBusinessHours(), unlimitedQuota(), plainSanitizer(), txManager)
 
 		otherUserID := uuid.New()
 		expectedTicket := &domain.Ticket{
@@ -167,7 +286,34 @@ func TestTicketService_GetTicket(t *testing.T) {
 		}
 
 		mockAuthz.On("Can", ctx, userID, "tickets:read").Return(true, nil)
-		mockRepo.On("GetByID", ctx, ticketID).Return(expectedTicket, nil)
+		mockUserRepo.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, OrganizationID: orgID}, nil)
+		mockRepo.On("GetByID", ctx, orgID, ticketID).Return(expectedTicket, nil)
+		mockAuthz.On("Can", ctx, userID, "tickets:read:all").Return(false, nil)
+
+		ticket, err := svc.GetTicket(ctx, ticketID, userID)
+
+		assert.Nil(t, ticket)
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+	})
+
+	t.Run("requester without admin permission cannot see an internal ticket", func(t *testing.T) {
+		mockRepo := mocks.NewMockTicketRepository()
+		mockAuthz := mocks.NewMockAuthorizationService()
+		mockUserRepo := mocks.NewMockUserRepository()
+
+		svc := services.NewTicketService(mockRepo, mockAuthz, mocks.NewMockNotificationOutboxRepository(), mocks.NewMockTicketEventRepository(), mockUserRepo, mocks.NewMockTeamRepository(), defaultBusinessHours(), unlimitedQuota(), plainSanitizer(), stubTransactionManager{})
+
+		internalTicket := &domain.Ticket{
+			ID:          ticketID,
+			Title:       "Upgrade the mail relay",
+			RequesterID: userID, // User is the requester
+			Status:      domain.StatusOpen,
+			Visibility:  domain.VisibilityInternal,
+		}
+
+		mockAuthz.On("Can", ctx, userID, "tickets:read").Return(true, nil)
+		mockUserRepo.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, OrganizationID: orgID}, nil)
+		mockRepo.On("GetByID", ctx, orgID, ticketID).Return(internalTicket, nil)
 		mockAuthz.On("Can", ctx, userID, "tickets:read:all").Return(false, nil)
 
 		ticket, err := svc.GetTicket(ctx, ticketID, userID)
@@ -179,11 +325,12 @@ func TestTicketService_GetTicket(t *testing.T) {
 	t.Run("admin can access any ticket", func(t *testing.T) {
 		mockRepo := mocks.NewMockTicketRepository()
 		mockAuthz := mocks.NewMockAuthorizationService()
-		mockNotifier := mocks.NewMockNotifier()
+		mockOutbox := mocks.NewMockNotificationOutboxRepository()
 		mockEventRepo := mocks.NewMockTicketEventRepository()
+		mockUserRepo := mocks.NewMockUserRepository()
 		txManager := stubTransactionManager{}
 
-		svc := services.NewTicketService(mockRepo, mockAuthz, mockNotifier, mockEventRepo, txManager)
+		svc := services.NewTicketService(mockRepo, mockAuthz, mockOutbox, mockEventRepo, mockUserRepo, mocks.NewMockTeamRepository(), defaultBusinessHours(), unlimitedQuota(), plainSanitizer(), txManager)
 
 		otherUserID := uuid.New()
 		expectedTicket := &domain.Ticket{
@@ -194,7 +341,8 @@ func TestTicketService_GetTicket(t *testing.T) {
 		}
 
 		mockAuthz.On("Can", ctx, userID, "tickets:read").Return(true, nil)
-		mockRepo.On("GetByID", ctx, ticketID).Return(expectedTicket, nil)
+		mockUserRepo.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, OrganizationID: orgID}, nil)
+		mockRepo.On("GetByID", ctx, orgID, ticketID).Return(expectedTicket, nil)
 		mockAuthz.On("Can", ctx, userID, "tickets:read:all").Return(true, nil)
 
 		ticket, err := svc.GetTicket(ctx, ticketID, userID)
@@ -206,35 +354,58 @@ func TestTicketService_GetTicket(t *testing.T) {
 	t.Run("ticket not found", func(t *testing.T) {
 		mockRepo := mocks.NewMockTicketRepository()
 		mockAuthz := mocks.NewMockAuthorizationService()
-		mockNotifier := mocks.NewMockNotifier()
+		mockOutbox := mocks.NewMockNotificationOutboxRepository()
 		mockEventRepo := mocks.NewMockTicketEventRepository()
+		mockUserRepo := mocks.NewMockUserRepository()
 		txManager := stubTransactionManager{}
 
-		svc := services.NewTicketService(mockRepo, mockAuthz, mockNotifier, mockEventRepo, txManager)
+		svc := services.NewTicketService(mockRepo, mockAuthz, mockOutbox, mockEventRepo, mockUserRepo, mocks.NewMockTeamRepository(), defaultBusinessHours(), unlimitedQuota(), plainSanitizer(), txManager)
+
+		mockAuthz.On("Can", ctx, userID, "tickets:read").Return(true, nil)
+		mockUserRepo.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, OrganizationID: orgID}, nil)
+		mockRepo.On("GetByID", ctx, orgID, ticketID).Return(nil, apperrors.ErrTicketNotFound)
+
+		ticket, err := svc.GetTicket(ctx, ticketID, userID)
 
+		assert.Nil(t, ticket)
+		assert.ErrorIs(t, err, apperrors.ErrTicketNotFound)
+	})
+	t.Run("tickets of other organizations are not found", func(t *testing.T) {
+		mockRepo := mocks.NewMockTicketRepository()
+		mockAuthz := mocks.NewMockAuthorizationService()
+		mockUserRepo := mocks.NewMockUserRepository()
+
+		svc := services.NewTicketService(mockRepo, mockAuthz, mocks.NewMockNotificationOutboxRepository(), mocks.NewMockTicketEventRepository(), mockUserRepo, mocks.NewMockTeamRepository(), defaultBusinessHours(), unlimitedQuota(), plainSanitizer(), stubTransactionManager{})
+
+		// Reading all tickets doesn't reach beyond the viewer's organization
 		mockAuthz.On("Can", ctx, userID, "tickets:read").Return(true, nil)
-		mockRepo.On("GetByID", ctx, ticketID).Return(nil, apperrors.ErrTicketNotFound)
+		mockAuthz.On("Can", ctx, userID, "tickets:read:all").Return(true, nil).Maybe()
+		mockUserRepo.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, OrganizationID: orgID}, nil)
+		mockRepo.On("GetByID", ctx, orgID, ticketID).Return(nil, apperrors.ErrTicketNotFound)
 
 		ticket, err := svc.GetTicket(ctx, ticketID, userID)
 
 		assert.Nil(t, ticket)
 		assert.ErrorIs(t, err, apperrors.ErrTicketNotFound)
+		mockRepo.AssertExpectations(t)
 	})
 }
 
 func TestTicketService_UpdateStatus(t *testing.T) {
 	ctx := context.Background()
 	userID := uuid.New()
+	orgID := uuid.New()
 	ticketID := int64(1)
 
 	t.Run("success", func(t *testing.T) {
 		mockRepo := mocks.NewMockTicketRepository()
 		mockAuthz := mocks.NewMockAuthorizationService()
-		mockNotifier := mocks.NewMockNotifier()
+		mockOutbox := mocks.NewMockNotificationOutboxRepository()
 		mockEventRepo := mocks.NewMockTicketEventRepository()
+		mockUserRepo := mocks.NewMockUserRepository()
 		txManager := stubTransactionManager{}
 
-		svc := services.NewTicketService(mockRepo, mockAuthz, mockNotifier, mockEventRepo, txManager)
+		svc := services.NewTicketService(mockRepo, mockAuthz, mockOutbox, mockEventRepo, mockUserRepo, mocks.NewMockTeamRepository(), defaultBusinessHours(), unlimitedQuota(), plainSanitizer(), txManager)
 
 		existingTicket := &domain.Ticket{
 			ID:          ticketID,
@@ -244,14 +415,15 @@ func TestTicketService_UpdateStatus(t *testing.T) {
 		}
 
 		mockAuthz.On("Can", ctx, userID, "tickets:update:status").Return(true, nil)
-		mockRepo.On("GetByID", ctx, ticketID).Return(existingTicket, nil)
+		mockUserRepo.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, OrganizationID: orgID}, nil)
+		mockRepo.On("GetByID", ctx, orgID, ticketID).Return(existingTicket, nil)
 		mockRepo.On("Update", ctx, mock.AnythingOfType("*domain.Ticket")).
 			Return(&domain.Ticket{
 				ID:     ticketID,
 				Title:  "Test Ticket",
 				Status: domain.StatusInProgress,
 			}, nil)
-		mockNotifier.On("Notify", mock.Anything, mock.Anything).Return()
+		mockOutbox.On("Enqueue", mock.Anything, mock.Anything).Return(nil)
 		mockEventRepo.On("Create", ctx, mock.AnythingOfType("*domain.Event")).
 			Return(&domain.Event{ID: 1}, nil)
 
@@ -271,11 +443,12 @@ func TestTicketService_UpdateStatus(t *testing.T) {
 	t.Run("invalid status transition", func(t *testing.T) {
 		mockRepo := mocks.NewMockTicketRepository()
 		mockAuthz := mocks.NewMockAuthorizationService()
-		mockNotifier := mocks.NewMockNotifier()
+		mockOutbox := mocks.NewMockNotificationOutboxRepository()
 		mockEventRepo := mocks.NewMockTicketEventRepository()
+		mockUserRepo := mocks.NewMockUserRepository()
 		txManager := stubTransactionManager{}
 
-		svc := services.NewTicketService(mockRepo, mockAuthz, mockNotifier, mockEventRepo, txManager)
+		svc := services.NewTicketService(mockRepo, mockAuthz, mockOutbox, mockEventRepo, mockUserRepo, mocks.NewMockTeamRepository(), defaultBusinessHours(), unlimitedQuota(), plainSanitizer(), txManager)
 
 		closedTicket := &domain.Ticket{
 			ID:          ticketID,
@@ -285,7 +458,8 @@ func TestTicketService_UpdateStatus(t *testing.T) {
 		}
 
 		mockAuthz.On("Can", ctx, userID, "tickets:update:status").Return(true, nil)
-		mockRepo.On("GetByID", ctx, ticketID).Return(closedTicket, nil)
+		mockUserRepo.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, OrganizationID: orgID}, nil)
+		mockRepo.On("GetByID", ctx, orgID, ticketID).Return(closedTicket, nil)
 
 		params := ports.UpdateStatusParams{
 			TicketID: ticketID,
@@ -303,15 +477,17 @@ func TestTicketService_UpdateStatus(t *testing.T) {
 func TestTicketService_ListTickets(t *testing.T) {
 	ctx := context.Background()
 	userID := uuid.New()
+	orgID := uuid.New()
 
 	t.Run("admin sees all tickets", func(t *testing.T) {
 		mockRepo := mocks.NewMockTicketRepository()
 		mockAuthz := mocks.NewMockAuthorizationService()
-		mockNotifier := mocks.NewMockNotifier()
+		mockOutbox := mocks.NewMockNotificationOutboxRepository()
 		mockEventRepo := mocks.NewMockTicketEventRepository()
+		mockUserRepo := mocks.NewMockUserRepository()
 		txManager := stubTransactionManager{}
 
-		svc := services.NewTicketService(mockRepo, mockAuthz, mockNotifier, mockEventRepo, txManager)
+		svc := services.NewTicketService(mockRepo, mockAuthz, mockOutbox, mockEventRepo, mockUserRepo, mocks.NewMockTeamRepository(), defaultBusinessHours(), unlimitedQuota(), plainSanitizer(), txManager)
 
 		expectedTickets := []*domain.Ticket{
 			{ID: 1, Title: "Ticket 1"},
@@ -319,7 +495,10 @@ func TestTicketService_ListTickets(t *testing.T) {
 		}
 
 		mockAuthz.On("Can", ctx, userID, "tickets:list:all").Return(true, nil)
-		mockRepo.On("ListPaginated", ctx, mock.Anything).Return(expectedTickets, nil)
+		mockUserRepo.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, OrganizationID: orgID}, nil)
+		mockRepo.On("ListPaginated", ctx, mock.MatchedBy(func(p ports.ListTicketsRepoParams) bool {
+			return p.OrganizationID.Valid && uuid.UUID(p.OrganizationID.Bytes) == orgID
+		})).Return(expectedTickets, nil)
 
 		params := ports.ListTicketsParams{
 			ViewerID: userID,
@@ -336,18 +515,23 @@ func TestTicketService_ListTickets(t *testing.T) {
 	t.Run("customer sees only own tickets", func(t *testing.T) {
 		mockRepo := mocks.NewMockTicketRepository()
 		mockAuthz := mocks.NewMockAuthorizationService()
-		mockNotifier := mocks.NewMockNotifier()
+		mockOutbox := mocks.NewMockNotificationOutboxRepository()
 		mockEventRepo := mocks.NewMockTicketEventRepository()
+		mockUserRepo := mocks.NewMockUserRepository()
 		txManager := stubTransactionManager{}
 
-		svc := services.NewTicketService(mockRepo, mockAuthz, mockNotifier, mockEventRepo, txManager)
+		svc := services.NewTicketService(mockRepo, mockAuthz, mockOutbox, mockEventRepo, mockUserRepo, mocks.NewMockTeamRepository(), defaultBusinessHours(), unlimitedQuota(), plainSanitizer(), txManager)
 
 		expectedTickets := []*domain.Ticket{
 			{ID: 1, Title: "My Ticket", RequesterID: userID},
 		}
 
 		mockAuthz.On("Can", ctx, userID, "tickets:list:all").Return(false, nil)
-		mockRepo.On("ListByRequesterPaginated", ctx, mock.Anything).Return(expectedTickets, nil)
+		mockUserRepo.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, OrganizationID: orgID}, nil)
+		// Internal tickets are left out even when the customer requested them
+		mockRepo.On("ListByRequesterPaginated", ctx, mock.MatchedBy(func(p ports.ListTicketsRepoParams) bool {
+			return uuid.UUID(p.RequesterID.Bytes) == userID && p.HideInternal
+		})).Return(expectedTickets, nil)
 
 		params := ports.ListTicketsParams{
 			ViewerID: userID,
@@ -362,3 +546,405 @@ func TestTicketService_ListTickets(t *testing.T) {
 		mockRepo.AssertNotCalled(t, "ListPaginated")
 	})
 }
+
+func TestTicketService_AssignTicket(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	assigneeID := uuid.New()
+	orgID := uuid.New()
+	ticketID := int64(1)
+
+	t.Run("notifies the new assignee", func(t *testing.T) {
+		mockRepo := mocks.NewMockTicketRepository()
+		mockAuthz := mocks.NewMockAuthorizationService()
+		mockOutbox := mocks.NewMockNotificationOutboxRepository()
+		mockEventRepo := mocks.NewMockTicketEventRepository()
+		mockUserRepo := mocks.NewMockUserRepository()
+		txManager := stubTransactionManager{}
+
+		svc := services.NewTicketService(mockRepo, mockAuthz, mockOutbox, mockEventRepo, mockUserRepo, mocks.NewMockTeamRepository(), defaultBusinessHours(), unlimitedQuota(), plainSanitizer(), txManager)
+
+		existingTicket := &domain.Ticket{
+			ID:          ticketID,
+			Title:       "Printer on fire",
+			RequesterID: uuid.New(),
+			Status:      domain.StatusOpen,
+		}
+
+		mockAuthz.On("Can", ctx, actorID, "tickets:read").Return(true, nil)
+		mockUserRepo.On("GetByID", ctx, actorID).Return(&domain.User{ID: actorID, OrganizationID: orgID}, nil)
+		mockRepo.On("GetByID", ctx, orgID, ticketID).Return(existingTicket, nil)
+		mockAuthz.On("Can", ctx, actorID, "tickets:read:all").Return(true, nil)
+		mockAuthz.On("Can", ctx, actorID, "tickets:assign").Return(true, nil)
+		mockRepo.On("Update", ctx, mock.AnythingOfType("*domain.Ticket")).
+			Return(&domain.Ticket{
+				ID:          ticketID,
+				Title:       "Printer on fire",
+				RequesterID: existingTicket.RequesterID,
+				AssigneeID:  &assigneeID,
+				Status:      domain.StatusOpen,
+			}, nil)
+		mockEventRepo.On("Create", ctx, mock.AnythingOfType("*domain.Event")).
+			Return(&domain.Event{ID: 1}, nil)
+		mockUserRepo.On("GetByID", ctx, existingTicket.RequesterID).
+			Return(&domain.User{ID: existingTicket.RequesterID, FullName: "Jane Requester"}, nil)
+		mockOutbox.On("Enqueue", mock.Anything, mock.MatchedBy(func(p ports.NotificationParams) bool {
+			return p.RecipientUserID == assigneeID && p.TicketID == ticketID &&
+				p.Data["requester_name"] == "Jane Requester"
+		})).Return(nil)
+
+		ticket, _, err := svc.AssignTicket(ctx, ports.AssignTicketParams{
+			TicketID:   ticketID,
+			AssigneeID: assigneeID,
+			ActorID:    actorID,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, ticket.IsAssignedTo(assigneeID))
+		mockOutbox.AssertExpectations(t)
+	})
+
+	t.Run("self-assignment sends no notification", func(t *testing.T) {
+		mockRepo := mocks.NewMockTicketRepository()
+		mockAuthz := mocks.NewMockAuthorizationService()
+		mockOutbox := mocks.NewMockNotificationOutboxRepository()
+		mockEventRepo := mocks.NewMockTicketEventRepository()
+		mockUserRepo := mocks.NewMockUserRepository()
+		txManager := stubTransactionManager{}
+
+		svc := services.NewTicketService(mockRepo, mockAuthz, mockOutbox, mockEventRepo, mockUserRepo, mocks.NewMockTeamRepository(), defaultBusinessHours(), unlimitedQuota(), plainSanitizer(), txManager)
+
+		existingTicket := &domain.Ticket{
+			ID:          ticketID,
+			Title:       "Printer on fire",
+			RequesterID: uuid.New(),
+			Status:      domain.StatusOpen,
+		}
+
+		mockAuthz.On("Can", ctx, actorID, "tickets:read").Return(true, nil)
+		mockUserRepo.On("GetByID", ctx, actorID).Return(&domain.User{ID: actorID, OrganizationID: orgID}, nil)
+		mockRepo.On("GetByID", ctx, orgID, ticketID).Return(existingTicket, nil)
+		mockAuthz.On("Can", ctx, actorID, "tickets:read:all").Return(true, nil)
+		mockAuthz.On("Can", ctx, actorID, "tickets:assign").Return(true, nil)
+		mockRepo.On("Update", ctx, mock.AnythingOfType("*domain.Ticket")).
+			Return(&domain.Ticket{
+				ID:          ticketID,
+				Title:       "Printer on fire",
+				RequesterID: existingTicket.RequesterID,
+				AssigneeID:  &actorID,
+				Status:      domain.StatusOpen,
+			}, nil)
+		mockEventRepo.On("Create", ctx, mock.AnythingOfType("*domain.Event")).
+			Return(&domain.Event{ID: 1}, nil)
+
+		ticket, _, err := svc.AssignTicket(ctx, ports.AssignTicketParams{
+			TicketID:   ticketID,
+			AssigneeID: actorID,
+			ActorID:    actorID,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, ticket.IsAssignedTo(actorID))
+		mockOutbox.AssertNotCalled(t, "Enqueue")
+		mockUserRepo.AssertNotCalled(t, "GetByID", ctx, existingTicket.RequesterID)
+	})
+
+	t.Run("closed ticket is not assigned", func(t *testing.T) {
+		mockRepo := mocks.NewMockTicketRepository()
+		mockAuthz := mocks.NewMockAuthorizationService()
+		mockOutbox := mocks.NewMockNotificationOutboxRepository()
+		mockEventRepo := mocks.NewMockTicketEventRepository()
+		mockUserRepo := mocks.NewMockUserRepository()
+		txManager := stubTransactionManager{}
+
+		svc := services.NewTicketService(mockRepo, mockAuthz, mockOutbox, mockEventRepo, mockUserRepo, mocks.NewMockTeamRepository(), defaultBusinessHours(), unlimitedQuota(), plainSanitizer(), txManager)
+
+		closedTicket := &domain.Ticket{
+			ID:          ticketID,
+			Title:       "Done",
+			RequesterID: actorID,
+			Status:      domain.StatusClosed,
+		}
+
+		mockAuthz.On("Can", ctx, actorID, "tickets:read").Return(true, nil)
+		mockUserRepo.On("GetByID", ctx, actorID).Return(&domain.User{ID: actorID, OrganizationID: orgID}, nil)
+		mockRepo.On("GetByID", ctx, orgID, ticketID).Return(closedTicket, nil)
+		mockAuthz.On("Can", ctx, actorID, "tickets:assign").Return(true, nil)
+
+		ticket, _, err := svc.AssignTicket(ctx, ports.AssignTicketParams{
+			TicketID:   ticketID,
+			AssigneeID: assigneeID,
+			ActorID:    actorID,
+		})
+
+		assert.Nil(t, ticket)
+		assert.ErrorIs(t, err, apperrors.ErrCannotAssignClosed)
+		mockOutbox.AssertNotCalled(t, "Enqueue")
+	})
+
+	t.Run("enforces the open ticket limit", func(t *testing.T) {
+		limit := 2
+		newService := func(enforcement domain.CapacityEnforcement) (ports.TicketService, *mocks.MockTicketRepository) {
+			mockRepo := mocks.NewMockTicketRepository()
+			mockAuthz := mocks.NewMockAuthorizationService()
+			mockEventRepo := mocks.NewMockTicketEventRepository()
+			mockUserRepo := mocks.NewMockUserRepository()
+			settingsRepo := mocks.NewMockOrgSettingsRepository()
+
+			openTicket := &domain.Ticket{ID: ticketID, RequesterID: actorID, Status: domain.StatusOpen, OrganizationID: orgID}
+			mockAuthz.On("Can", ctx, actorID, "tickets:read").Return(true, nil)
+			mockAuthz.On("Can", ctx, actorID, "tickets:assign").Return(true, nil)
+			mockUserRepo.On("GetByID", ctx, actorID).Return(&domain.User{ID: actorID, OrganizationID: orgID}, nil)
+			mockRepo.On("GetByID", ctx, orgID, ticketID).Return(openTicket, nil)
+			settingsRepo.On("GetAgentCapacity", ctx, orgID).
+				Return(&domain.AgentCapacity{OrganizationID: orgID, MaxOpenTickets: &limit, Enforcement: enforcement}, nil)
+			mockRepo.On("ListOpenByAssignee", ctx, orgID, assigneeID).
+				Return([]*domain.Ticket{{ID: 10}, {ID: 11}}, nil)
+			mockRepo.On("Update", ctx, mock.AnythingOfType("*domain.Ticket")).Return(openTicket, nil).Maybe()
+			mockEventRepo.On("Create", ctx, mock.AnythingOfType("*domain.Event")).Return(&domain.Event{ID: 1}, nil).Maybe()
+			mockUserRepo.On("GetByID", ctx, actorID).Return(&domain.User{ID: actorID}, nil).Maybe()
+			outbox := mocks.NewMockNotificationOutboxRepository()
+			outbox.On("Enqueue", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			return services.NewTicketService(mockRepo, mockAuthz, outbox, mockEventRepo, mockUserRepo, mocks.NewMockTeamRepository(), settingsRepo, unlimitedQuota(), plainSanitizer(), stubTransactionManager{}), mockRepo
+		}
+		params := ports.AssignTicketParams{TicketID: ticketID, AssigneeID: assigneeID, ActorID: actorID}
+
+		svc, mockRepo := newService(domain.CapacityBlock)
+		_, _, err := svc.AssignTicket(ctx, params)
+		assert.ErrorIs(t, err, apperrors.ErrAgentAtCapacity)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+
+		svc, mockRepo = newService(domain.CapacityWarn)
+		ticket, warning, err := svc.AssignTicket(ctx, params)
+		require.NoError(t, err)
+		require.NotNil(t, ticket)
+		require.NotNil(t, warning)
+		assert.Equal(t, int64(2), warning.OpenTickets)
+		assert.Equal(t, 2, warning.MaxOpenTickets)
+		mockRepo.AssertCalled(t, "Update", ctx, mock.AnythingOfType("*domain.Ticket"))
+	})
+}
+
+func TestTicketService_ClaimTicket(t *testing.T) {
+	ctx := context.Background()
+	agentID := uuid.New()
+	ticketID := int64(1)
+	teamID := uuid.New()
+	orgID := uuid.New()
+
+	newService := func(team *domain.Team, ticket *domain.Ticket) (ports.TicketService, *mocks.MockTicketRepository, *mocks.MockTicketEventRepository) {
+		mockRepo := mocks.NewMockTicketRepository()
+		mockAuthz := mocks.NewMockAuthorizationService()
+		mockEventRepo := mocks.NewMockTicketEventRepository()
+		mockTeamRepo := mocks.NewMockTeamRepository()
+		mockUserRepo := mocks.NewMockUserRepository()
+
+		svc := services.NewTicketService(mockRepo, mockAuthz, mocks.NewMockNotificationOutboxRepository(), mockEventRepo, mockUserRepo, mockTeamRepo, defaultBusinessHours(), unlimitedQuota(), plainSanitizer(), stubTransactionManager{})
+
+		mockAuthz.On("Can", ctx, agentID, "tickets:read").Return(true, nil)
+		mockAuthz.On("Can", ctx, agentID, "tickets:read:all").Return(true, nil)
+		mockAuthz.On("Can", ctx, agentID, "tickets:claim").Return(true, nil)
+		mockUserRepo.On("GetByID", ctx, agentID).Return(&domain.User{ID: agentID, OrganizationID: orgID}, nil)
+		mockRepo.On("GetByID", ctx, orgID, ticketID).Return(ticket, nil)
+		mockTeamRepo.On("GetByID", ctx, teamID).Return(team, nil)
+		return svc, mockRepo, mockEventRepo
+	}
+
+	queued := func() *domain.Ticket {
+		return &domain.Ticket{ID: ticketID, RequesterID: uuid.New(), OrganizationID: orgID, Status: domain.StatusOpen, TeamID: &teamID}
+	}
+
+	t.Run("member claims a queued ticket", func(t *testing.T) {
+		svc, mockRepo, mockEventRepo := newService(&domain.Team{ID: teamID, MemberIDs: []uuid.UUID{agentID}}, queued())
+		mockRepo.On("Claim", ctx, orgID, ticketID, agentID, mock.AnythingOfType("time.Time")).
+			Return(&domain.Ticket{ID: ticketID, Status: domain.StatusOpen, TeamID: &teamID, AssigneeID: &agentID}, nil)
+		mockEventRepo.On("Create", ctx, mock.MatchedBy(func(e *domain.Event) bool {
+			return e.Type == domain.EventTicketAssigned && e.ActorID == agentID
+		})).Return(&domain.Event{ID: 1}, nil)
+
+		ticket, err := svc.ClaimTicket(ctx, ports.ClaimTicketParams{TicketID: ticketID, ActorID: agentID})
+
+		require.NoError(t, err)
+		assert.True(t, ticket.IsAssignedTo(agentID))
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("non-members cannot claim", func(t *testing.T) {
+		svc, mockRepo, _ := newService(&domain.Team{ID: teamID}, queued())
+
+		_, err := svc.ClaimTicket(ctx, ports.ClaimTicketParams{TicketID: ticketID, ActorID: agentID})
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		mockRepo.AssertNotCalled(t, "Claim", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("losing a race reports the ticket as claimed", func(t *testing.T) {
+		svc, mockRepo, mockEventRepo := newService(&domain.Team{ID: teamID, MemberIDs: []uuid.UUID{agentID}}, queued())
+		mockRepo.On("Claim", ctx, orgID, ticketID, agentID, mock.AnythingOfType("time.Time")).
+			Return(nil, apperrors.ErrTicketAlreadyClaimed)
+
+		_, err := svc.ClaimTicket(ctx, ports.ClaimTicketParams{TicketID: ticketID, ActorID: agentID})
+
+		assert.ErrorIs(t, err, apperrors.ErrTicketAlreadyClaimed)
+		mockEventRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTicketService_UpdateTicket(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	assigneeID := uuid.New()
+	orgID := uuid.New()
+	ticketID := int64(1)
+
+	setup := func() (*mocks.MockTicketRepository, *mocks.MockAuthorizationService, *mocks.MockNotificationOutboxRepository, *mocks.MockTicketEventRepository, *mocks.MockUserRepository, ports.TicketService) {
+		mockRepo := mocks.NewMockTicketRepository()
+		mockAuthz := mocks.NewMockAuthorizationService()
+		mockOutbox := mocks.NewMockNotificationOutboxRepository()
+		mockEventRepo := mocks.NewMockTicketEventRepository()
+		mockUserRepo := mocks.NewMockUserRepository()
+		svc := services.NewTicketService(mockRepo, mockAuthz, mockOutbox, mockEventRepo, mockUserRepo, mocks.NewMockTeamRepository(), defaultBusinessHours(), unlimitedQuota(), plainSanitizer(), stubTransactionManager{})
+		return mockRepo, mockAuthz, mockOutbox, mockEventRepo, mockUserRepo, svc
+	}
+
+	existing := func() *domain.Ticket {
+		return &domain.Ticket{
+			ID:             ticketID,
+			Title:          "Printer on fire",
+			RequesterID:    uuid.New(),
+			OrganizationID: orgID,
+			Status:         domain.StatusOpen,
+			Priority:       domain.PriorityMedium,
+		}
+	}
+
+	t.Run("applies all changes as one event", func(t *testing.T) {
+		mockRepo, mockAuthz, mockOutbox, mockEventRepo, mockUserRepo, svc := setup()
+		ticket := existing()
+		status := domain.StatusInProgress
+		priority := domain.PriorityHigh
+
+		mockAuthz.On("Can", ctx, actorID, "tickets:read").Return(true, nil)
+		mockUserRepo.On("GetByID", ctx, actorID).Return(&domain.User{ID: actorID, OrganizationID: orgID}, nil)
+		mockRepo.On("GetByID", ctx, orgID, ticketID).Return(ticket, nil)
+		mockAuthz.On("Can", ctx, actorID, "tickets:read:all").Return(true, nil)
+		mockAuthz.On("Can", ctx, actorID, "tickets:update:status").Return(true, nil)
+		mockAuthz.On("Can", ctx, actorID, "tickets:assign").Return(true, nil)
+		mockAuthz.On("Can", ctx, actorID, "tickets:update").Return(true, nil)
+		mockUserRepo.On("GetByID", ctx, ticket.RequesterID).
+			Return(&domain.User{ID: ticket.RequesterID, FullName: "Jane Requester"}, nil)
+		mockRepo.On("Update", ctx, mock.MatchedBy(func(t *domain.Ticket) bool {
+			return t.Status == domain.StatusInProgress && t.Priority == domain.PriorityHigh && t.IsAssignedTo(assigneeID)
+		})).Return(ticket, nil)
+		mockEventRepo.On("Create", ctx, mock.MatchedBy(func(e *domain.Event) bool {
+			return e.Type == domain.EventTicketUpdated
+		})).Return(&domain.Event{ID: 1}, nil).Once()
+		mockOutbox.On("Enqueue", mock.Anything, mock.MatchedBy(func(p ports.NotificationParams) bool {
+			return p.Type == ports.NotificationStatusChanged && p.RecipientUserID == ticket.RequesterID
+		})).Return(nil).Once()
+		mockOutbox.On("Enqueue", mock.Anything, mock.MatchedBy(func(p ports.NotificationParams) bool {
+			return p.Type == ports.NotificationTicketAssigned && p.RecipientUserID == assigneeID
+		})).Return(nil).Once()
+
+		updated, err := svc.UpdateTicket(ctx, ports.UpdateTicketParams{
+			TicketID: ticketID,
+			Changes:  domain.TicketChanges{Status: &status, Priority: &priority, AssigneeID: &assigneeID},
+			ActorID:  actorID,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusInProgress, updated.Status)
+		mockEventRepo.AssertExpectations(t)
+		mockOutbox.AssertExpectations(t)
+	})
+
+	t.Run("a status-only update is recorded as a status change", func(t *testing.T) {
+		mockRepo, mockAuthz, mockOutbox, mockEventRepo, mockUserRepo, svc := setup()
+		ticket := existing()
+		status := domain.StatusClosed
+
+		mockAuthz.On("Can", ctx, actorID, "tickets:read").Return(true, nil)
+		mockUserRepo.On("GetByID", ctx, actorID).Return(&domain.User{ID: actorID, OrganizationID: orgID}, nil)
+		mockRepo.On("GetByID", ctx, orgID, ticketID).Return(ticket, nil)
+		mockAuthz.On("Can", ctx, actorID, "tickets:read:all").Return(true, nil)
+		mockAuthz.On("Can", ctx, actorID, "tickets:update:status").Return(true, nil)
+		mockRepo.On("Update", ctx, mock.MatchedBy(func(t *domain.Ticket) bool {
+			return t.ResolutionTime != nil
+		})).Return(ticket, nil)
+		mockEventRepo.On("Create", ctx, mock.MatchedBy(func(e *domain.Event) bool {
+			return e.Type == domain.EventStatusUpdated
+		})).Return(&domain.Event{ID: 1}, nil)
+		mockOutbox.On("Enqueue", mock.Anything, mock.Anything).Return(nil)
+
+		_, err := svc.UpdateTicket(ctx, ports.UpdateTicketParams{
+			TicketID: ticketID,
+			Changes:  domain.TicketChanges{Status: &status},
+			ActorID:  actorID,
+		})
+
+		require.NoError(t, err)
+		mockEventRepo.AssertExpectations(t)
+		mockAuthz.AssertNotCalled(t, "Can", ctx, actorID, "tickets:update")
+	})
+
+	t.Run("a new description is sanitized", func(t *testing.T) {
+		mockRepo := mocks.NewMockTicketRepository()
+		mockAuthz := mocks.NewMockAuthorizationService()
+		mockEventRepo := mocks.NewMockTicketEventRepository()
+		mockUserRepo := mocks.NewMockUserRepository()
+		sanitizer := mocks.NewMockHTMLSanitizer()
+		svc := services.NewTicketService(mockRepo, mockAuthz, mocks.NewMockNotificationOutboxRepository(), mockEventRepo, mockUserRepo, mocks.NewMockTeamRepository(), defaultBusinessHours(), unlimitedQuota(), sanitizer, stubTransactionManager{})
+		description := `<p onclick="steal()">Still broken</p>`
+
+		mockAuthz.On("Can", ctx, actorID, "tickets:read").Return(true, nil)
+		mockUserRepo.On("GetByID", ctx, actorID).Return(&domain.User{ID: actorID, OrganizationID: orgID}, nil)
+		mockRepo.On("GetByID", ctx, orgID, ticketID).Return(existing(), nil)
+		mockAuthz.On("Can", ctx, actorID, "tickets:read:all").Return(true, nil)
+		mockAuthz.On("Can", ctx, actorID, "tickets:update").Return(true, nil)
+		sanitizer.On("Sanitize", description).Return("<p>Still broken</p>")
+		mockRepo.On("Update", ctx, mock.MatchedBy(func(t *domain.Ticket) bool {
+			return t.Description == description && t.DescriptionHTML == "<p>Still broken</p>"
+		})).Return(existing(), nil)
+		mockEventRepo.On("Create", ctx, mock.Anything).Return(&domain.Event{ID: 1}, nil)
+
+		_, err := svc.UpdateTicket(ctx, ports.UpdateTicketParams{
+			TicketID: ticketID,
+			Changes:  domain.TicketChanges{Description: &description},
+			ActorID:  actorID,
+		})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("editing details needs tickets:update", func(t *testing.T) {
+		mockRepo, mockAuthz, _, _, mockUserRepo, svc := setup()
+		title := "Renamed"
+
+		mockAuthz.On("Can", ctx, actorID, "tickets:read").Return(true, nil)
+		mockUserRepo.On("GetByID", ctx, actorID).Return(&domain.User{ID: actorID, OrganizationID: orgID}, nil)
+		mockRepo.On("GetByID", ctx, orgID, ticketID).Return(existing(), nil)
+		mockAuthz.On("Can", ctx, actorID, "tickets:read:all").Return(true, nil)
+		mockAuthz.On("Can", ctx, actorID, "tickets:update").Return(false, nil)
+
+		_, err := svc.UpdateTicket(ctx, ports.UpdateTicketParams{
+			TicketID: ticketID,
+			Changes:  domain.TicketChanges{Title: &title},
+			ActorID:  actorID,
+		})
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		mockRepo.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("an empty update is rejected", func(t *testing.T) {
+		mockRepo, _, _, _, _, svc := setup()
+
+		_, err := svc.UpdateTicket(ctx, ports.UpdateTicketParams{TicketID: ticketID, ActorID: actorID})
+
+		var validationErrs *apperrors.ValidationErrors
+		assert.ErrorAs(t, err, &validationErrs)
+		mockRepo.AssertNotCalled(t, "GetByID")
+	})
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// WebhookDispatcher delivers queued webhook events, retrying failures with
+// exponential backoff and dead-lettering those that keep failing. Every
+// outcome is recorded on the delivery so admins can inspect it.
+type WebhookDispatcher struct {
+	webhookRepo ports.WebhookRepository
+	sender      ports.WebhookSender
+	cfg         DispatcherConfig
+	logger      *slog.Logger
+	now         func() time.Time
+}
+
+// NewWebhookDispatcher creates a new webhook dispatcher
+func NewWebhookDispatcher(
+	webhookRepo ports.WebhookRepository,
+	sender ports.WebhookSender,
+	cfg DispatcherConfig,
+	logger *slog.Logger,
+) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhookRepo: webhookRepo,
+		sender:      sender,
+		cfg:         cfg,
+		logger:      logger.With("component", "webhook_dispatcher"),
+		now:         time.Now,
+	}
+}
+
+// Run polls for due deliveries until ctx is cancelled
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	poll(ctx, d.cfg, d.DispatchBatch, func(err error) {
+		d.logger.Error("failed to dispatch webhooks", "error", err)
+	})
+}
+
+// DispatchBatch claims and sends one batch of due deliveries and returns
+// how many were claimed.
+func (d *WebhookDispatcher) DispatchBatch(ctx context.Context) (int, error) {
+	batch, err := d.webhookRepo.ClaimDueDeliveries(ctx, d.cfg.BatchSize, d.cfg.ClaimLease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, job := range batch {
+		d.deliver(ctx, job)
+	}
+
+	return len(batch), nil
+}
+
+// deliver sends a single delivery and records the outcome
+func (d *WebhookDispatcher) deliver(ctx context.Context, job *ports.WebhookDeliveryJob) {
+	status, sendErr := d.sender.Send(ctx, job)
+	if sendErr == nil {
+		if err := d.webhookRepo.MarkDelivered(ctx, job.DeliveryID, status); err != nil {
+			d.logger.Error("failed to mark webhook delivered", "id", job.DeliveryID, "error", err)
+		}
+		return
+	}
+
+	attempts := job.Attempts + 1
+	if attempts >= d.cfg.MaxAttempts {
+		d.logger.Error("webhook delivery dead-lettered",
+			"id", job.DeliveryID,
+			"webhook_id", job.WebhookID,
+			"attempts", attempts,
+			"error", sendErr,
+		)
+		if err := d.webhookRepo.MarkDead(ctx, job.DeliveryID, status, sendErr.Error()); err != nil {
+			d.logger.Error("failed to dead-letter webhook delivery", "id", job.DeliveryID, "error", err)
+		}
+		return
+	}
+
+	nextAttemptAt := d.now().Add(d.cfg.backoff(attempts))
+	d.logger.Warn("webhook delivery failed, will retry",
+		"id", job.DeliveryID,
+		"webhook_id", job.WebhookID,
+		"attempts", attempts,
+		"next_attempt_at", nextAttemptAt,
+		"error", sendErr,
+	)
+	if err := d.webhookRepo.MarkRetry(ctx, job.DeliveryID, nextAttemptAt, status, sendErr.Error()); err != nil {
+		d.logger.Error("failed to schedule webhook retry", "id", job.DeliveryID, "error", err)
+	}
+}
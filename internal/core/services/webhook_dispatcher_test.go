@@ -0,0 +1,103 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWebhookDispatcher(repo *mocks.MockWebhookRepository, sender *mocks.MockWebhookSender) *services.WebhookDispatcher {
+	return services.NewWebhookDispatcher(repo, sender, services.DispatcherConfig{
+		PollInterval: time.Second,
+		BatchSize:    10,
+		ClaimLease:   time.Minute,
+		MaxAttempts:  3,
+		BaseBackoff:  time.Minute,
+		MaxBackoff:   time.Hour,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestWebhookDispatcher_DispatchBatch(t *testing.T) {
+	ctx := context.Background()
+	newJob := func(attempts int) *ports.WebhookDeliveryJob {
+		return &ports.WebhookDeliveryJob{
+			DeliveryID: 7,
+			WebhookID:  uuid.New(),
+			URL:        "https://example.com/hook",
+			Secret:     "secret",
+			EventType:  domain.WebhookTicketCreated,
+			Payload:    []byte(`{}`),
+			Attempts:   attempts,
+		}
+	}
+
+	t.Run("records successful deliveries", func(t *testing.T) {
+		repo := mocks.NewMockWebhookRepository()
+		sender := mocks.NewMockWebhookSender()
+		dispatcher := newTestWebhookDispatcher(repo, sender)
+		job := newJob(0)
+
+		repo.On("ClaimDueDeliveries", ctx, 10, time.Minute).Return([]*ports.WebhookDeliveryJob{job}, nil)
+		sender.On("Send", ctx, job).Return(http.StatusOK, nil)
+		repo.On("MarkDelivered", ctx, int64(7), http.StatusOK).Return(nil)
+
+		n, err := dispatcher.DispatchBatch(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		repo.AssertExpectations(t)
+		sender.AssertExpectations(t)
+	})
+
+	t.Run("schedules a retry with the response status", func(t *testing.T) {
+		repo := mocks.NewMockWebhookRepository()
+		sender := mocks.NewMockWebhookSender()
+		dispatcher := newTestWebhookDispatcher(repo, sender)
+		job := newJob(0)
+
+		repo.On("ClaimDueDeliveries", ctx, 10, time.Minute).Return([]*ports.WebhookDeliveryJob{job}, nil)
+		sender.On("Send", ctx, job).Return(http.StatusBadGateway, errors.New("webhook returned 502"))
+
+		var nextAttemptAt time.Time
+		repo.On("MarkRetry", ctx, int64(7), mock.AnythingOfType("time.Time"), http.StatusBadGateway, "webhook returned 502").
+			Run(func(args mock.Arguments) { nextAttemptAt = args.Get(2).(time.Time) }).
+			Return(nil)
+
+		start := time.Now()
+		_, err := dispatcher.DispatchBatch(ctx)
+
+		require.NoError(t, err)
+		assert.WithinDuration(t, start.Add(time.Minute), nextAttemptAt, 5*time.Second)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("dead-letters after the final attempt", func(t *testing.T) {
+		repo := mocks.NewMockWebhookRepository()
+		sender := mocks.NewMockWebhookSender()
+		dispatcher := newTestWebhookDispatcher(repo, sender)
+		job := newJob(2)
+
+		repo.On("ClaimDueDeliveries", ctx, 10, time.Minute).Return([]*ports.WebhookDeliveryJob{job}, nil)
+		sender.On("Send", ctx, job).Return(0, errors.New("connection refused"))
+		repo.On("MarkDead", ctx, int64(7), 0, "connection refused").Return(nil)
+
+		_, err := dispatcher.DispatchBatch(ctx)
+
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "MarkRetry", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// WebhookPublishingEventRepository wraps a TicketEventRepository and queues
+// a webhook delivery for every ticket event that has a public webhook event.
+// Deliveries are queued in the same transaction as the event, so webhooks
+// only fire for changes that commit.
+type WebhookPublishingEventRepository struct {
+	ports.TicketEventRepository
+	webhookRepo ports.WebhookRepository
+}
+
+var _ ports.TicketEventRepository = (*WebhookPublishingEventRepository)(nil)
+
+// NewWebhookPublishingEventRepository creates a new webhook-publishing event repository
+func NewWebhookPublishingEventRepository(eventRepo ports.TicketEventRepository, webhookRepo ports.WebhookRepository) ports.TicketEventRepository {
+	return &WebhookPublishingEventRepository{
+		TicketEventRepository: eventRepo,
+		webhookRepo:           webhookRepo,
+	}
+}
+
+// Create persists the event and queues deliveries to subscribed webhooks
+func (r *WebhookPublishingEventRepository) Create(ctx context.Context, event *domain.Event) (*domain.Event, error) {
+	created, err := r.TicketEventRepository.Create(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := enqueueWebhookEvent(ctx, r.webhookRepo, created, false); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// enqueueWebhookEvent queues deliveries of a ticket event to the webhooks
+// subscribed to it, reporting false for events webhooks are not sent.
+func enqueueWebhookEvent(ctx context.Context, webhookRepo ports.WebhookRepository, event *domain.Event, replayed bool) (bool, error) {
+	eventType, ok := domain.WebhookEventFor(event.Type)
+	if !ok {
+		return false, nil
+	}
+
+	payload, err := json.Marshal(domain.WebhookEnvelope{
+		Event:      eventType,
+		EventID:    event.ID,
+		Version:    event.Version,
+		TicketID:   event.TicketID,
+		OccurredAt: event.CreatedAt.UTC().Format(time.RFC3339),
+		Data:       event.Payload,
+		Replayed:   replayed,
+	})
+	if err != nil {
+		return false, fmt.Errorf("marshal webhook payload: %w", err)
+	}
+
+	if err := webhookRepo.EnqueueForTicket(ctx, event.TicketID, eventType, payload); err != nil {
+		return false, err
+	}
+	return true, nil
+}
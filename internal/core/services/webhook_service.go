@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+const (
+	// defaultDeliveryLimit is how many deliveries are listed when no limit is given
+	defaultDeliveryLimit = 50
+	// maxDeliveryLimit caps how many deliveries can be listed at once
+	maxDeliveryLimit = 200
+	// testDeliveryHold keeps a test delivery away from the dispatcher while
+	// it is being sent inline.
+	testDeliveryHold = 5 * time.Minute
+)
+
+// WebhookService lets admins manage their organization's webhooks.
+type WebhookService struct {
+	webhookRepo ports.WebhookRepository
+	sender      ports.WebhookSender
+	authzSvc    ports.AuthorizationService
+	auditRepo   ports.AuditLogRepository
+	txManager   ports.TransactionManager
+	now         func() time.Time
+}
+
+var _ ports.WebhookService = (*WebhookService)(nil)
+
+// NewWebhookService creates a new WebhookService.
+func NewWebhookService(
+	webhookRepo ports.WebhookRepository,
+	sender ports.WebhookSender,
+	authzSvc ports.AuthorizationService,
+	auditRepo ports.AuditLogRepository,
+	txManager ports.TransactionManager,
+) ports.WebhookService {
+	return &WebhookService{
+		webhookRepo: webhookRepo,
+		sender:      sender,
+		authzSvc:    authzSvc,
+		auditRepo:   auditRepo,
+		txManager:   txManager,
+		now:         time.Now,
+	}
+}
+
+// CreateWebhook registers a new webhook for the organization.
+func (s *WebhookService) CreateWebhook(ctx context.Context, actorID, orgID uuid.UUID, params domain.WebhookParams) (*domain.Webhook, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	webhook, err := domain.NewWebhook(params, orgID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	var created *domain.Webhook
+	if err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		created, err = s.webhookRepo.Create(txCtx, webhook)
+		if err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditWebhookCreated, created, nil, webhookAuditSnapshot(created))
+	}); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// ListWebhooks returns the organization's webhooks.
+func (s *WebhookService) ListWebhooks(ctx context.Context, actorID, orgID uuid.UUID) ([]*domain.Webhook, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	return s.webhookRepo.ListByOrganization(ctx, orgID)
+}
+
+// DeleteWebhook removes a webhook and its delivery log.
+func (s *WebhookService) DeleteWebhook(ctx context.Context, actorID, orgID, webhookID uuid.UUID) error {
+	webhook, err := s.getWebhook(ctx, actorID, orgID, webhookID)
+	if err != nil {
+		return err
+	}
+
+	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.webhookRepo.Delete(txCtx, webhookID); err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, orgID, actorID, domain.AuditWebhookDeleted, webhook, webhookAuditSnapshot(webhook), nil)
+	})
+}
+
+// ListDeliveries returns the most recent deliveries to a webhook.
+func (s *WebhookService) ListDeliveries(ctx context.Context, actorID, orgID, webhookID uuid.UUID, limit int) ([]*domain.WebhookDelivery, error) {
+	if _, err := s.getWebhook(ctx, actorID, orgID, webhookID); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultDeliveryLimit
+	}
+	if limit > maxDeliveryLimit {
+		limit = maxDeliveryLimit
+	}
+
+	return s.webhookRepo.ListDeliveries(ctx, webhookID, limit)
+}
+
+// TestWebhook sends a webhook.test event to the webhook straight away and
+// returns the recorded delivery. Test deliveries are not retried.
+func (s *WebhookService) TestWebhook(ctx context.Context, actorID, orgID, webhookID uuid.UUID) (*domain.WebhookDelivery, error) {
+	webhook, err := s.getWebhook(ctx, actorID, orgID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(map[string]string{"webhookId": webhook.ID.String()})
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	payload, err := json.Marshal(domain.WebhookEnvelope{
+		Event:      domain.WebhookTest,
+		OccurredAt: now.UTC().Format(time.RFC3339),
+		Data:       data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.webhookRepo.CreateDelivery(ctx, &domain.WebhookDelivery{
+		WebhookID:     webhook.ID,
+		EventType:     domain.WebhookTest,
+		Payload:       payload,
+		Status:        domain.WebhookDeliveryPending,
+		NextAttemptAt: now.Add(testDeliveryHold),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	status, sendErr := s.sender.Send(ctx, &ports.WebhookDeliveryJob{
+		DeliveryID: delivery.ID,
+		WebhookID:  webhook.ID,
+		URL:        webhook.URL,
+		Secret:     webhook.Secret,
+		EventType:  domain.WebhookTest,
+		Payload:    payload,
+	})
+
+	delivery.Attempts++
+	if status != 0 {
+		delivery.ResponseStatus = &status
+	}
+	if sendErr == nil {
+		if err := s.webhookRepo.MarkDelivered(ctx, delivery.ID, status); err != nil {
+			return nil, err
+		}
+		deliveredAt := s.now()
+		delivery.Status = domain.WebhookDeliverySucceeded
+		delivery.DeliveredAt = &deliveredAt
+		return delivery, nil
+	}
+
+	lastErr := sendErr.Error()
+	if err := s.webhookRepo.MarkDead(ctx, delivery.ID, status, lastErr); err != nil {
+		return nil, err
+	}
+	delivery.Status = domain.WebhookDeliveryDead
+	delivery.LastError = &lastErr
+	return delivery, nil
+}
+
+// getWebhook checks the actor is an admin and loads a webhook from their
+// organization. Webhooks of other organizations are reported as not found.
+func (s *WebhookService) getWebhook(ctx context.Context, actorID, orgID, webhookID uuid.UUID) (*domain.Webhook, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.webhookRepo.GetByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if webhook.OrganizationID != orgID {
+		return nil, apperrors.ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+// recordAudit logs a change made to a webhook.
+func (s *WebhookService) recordAudit(ctx context.Context, orgID, actorID uuid.UUID, action domain.AuditAction, webhook *domain.Webhook, before, after any) error {
+	entry, err := domain.NewAuditEntry(ctx, orgID, actorID, action, domain.AuditTargetWebhook, webhook.ID.String(), before, after)
+	if err != nil {
+		return err
+	}
+	return s.auditRepo.Create(ctx, entry)
+}
+
+// webhookAuditSnapshot is the audited view of a webhook. The signing secret
+// is left out.
+func webhookAuditSnapshot(webhook *domain.Webhook) map[string]any {
+	return map[string]any{
+		"url":        webhook.URL,
+		"eventTypes": webhook.EventTypes,
+	}
+}
+
+func (s *WebhookService) requireAdmin(ctx context.Context, actorID uuid.UUID) error {
+	allowed, err := s.authzSvc.Can(ctx, actorID, "admin:access")
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return apperrors.ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lorrc/service-desk-backend/internal/core/domain"
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/mocks"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/lorrc/service-desk-backend/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookService_CreateWebhook(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	orgID := uuid.New()
+	params := domain.WebhookParams{
+		URL:        "https://example.com/hook",
+		EventTypes: []domain.WebhookEventType{domain.WebhookTicketCreated},
+	}
+
+	t.Run("requires admin access", func(t *testing.T) {
+		repo := mocks.NewMockWebhookRepository()
+		authz := mocks.NewMockAuthorizationService()
+		audit := mocks.NewMockAuditLogRepository()
+		svc := services.NewWebhookService(repo, mocks.NewMockWebhookSender(), authz, audit, stubTransactionManager{})
+
+		authz.On("Can", ctx, actorID, "admin:access").Return(false, nil)
+
+		_, err := svc.CreateWebhook(ctx, actorID, orgID, params)
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("creates a webhook with a secret for the admin's organization", func(t *testing.T) {
+		repo := mocks.NewMockWebhookRepository()
+		authz := mocks.NewMockAuthorizationService()
+		audit := mocks.NewMockAuditLogRepository()
+		svc := services.NewWebhookService(repo, mocks.NewMockWebhookSender(), authz, audit, stubTransactionManager{})
+
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		repo.On("Create", ctx, mock.MatchedBy(func(w *domain.Webhook) bool {
+			return w.OrganizationID == orgID && w.CreatedBy == actorID && w.Secret != ""
+		})).Return(&domain.Webhook{ID: uuid.New(), OrganizationID: orgID, URL: params.URL, Secret: "secret"}, nil)
+		audit.On("Create", ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+			return e.Action == domain.AuditWebhookCreated && e.ActorID == actorID &&
+				e.Before == nil && !strings.Contains(string(e.After), "secret")
+		})).Return(nil)
+
+		webhook, err := svc.CreateWebhook(ctx, actorID, orgID, params)
+
+		require.NoError(t, err)
+		assert.Equal(t, orgID, webhook.OrganizationID)
+		repo.AssertExpectations(t)
+		audit.AssertExpectations(t)
+	})
+}
+
+func TestWebhookService_TestWebhook(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	orgID := uuid.New()
+	webhook := &domain.Webhook{
+		ID:             uuid.New(),
+		OrganizationID: orgID,
+		URL:            "https://example.com/hook",
+		Secret:         "secret",
+	}
+
+	setup := func() (*mocks.MockWebhookRepository, *mocks.MockWebhookSender, ports.WebhookService) {
+		repo := mocks.NewMockWebhookRepository()
+		sender := mocks.NewMockWebhookSender()
+		authz := mocks.NewMockAuthorizationService()
+		authz.On("Can", ctx, actorID, "admin:access").Return(true, nil)
+		repo.On("GetByID", ctx, webhook.ID).Return(webhook, nil)
+		return repo, sender, services.NewWebhookService(repo, sender, authz, mocks.NewMockAuditLogRepository(), stubTransactionManager{})
+	}
+
+	t.Run("sends a test event and records success", func(t *testing.T) {
+		repo, sender, svc := setup()
+
+		repo.On("CreateDelivery", ctx, mock.MatchedBy(func(d *domain.WebhookDelivery) bool {
+			return d.EventType == domain.WebhookTest && d.NextAttemptAt.After(time.Now())
+		})).Return(&domain.WebhookDelivery{ID: 3, WebhookID: webhook.ID, Status: domain.WebhookDeliveryPending}, nil)
+		sender.On("Send", ctx, mock.MatchedBy(func(job *ports.WebhookDeliveryJob) bool {
+			var envelope domain.WebhookEnvelope
+			return job.DeliveryID == 3 && job.URL == webhook.URL &&
+				json.Unmarshal(job.Payload, &envelope) == nil && envelope.Event == domain.WebhookTest
+		})).Return(http.StatusOK, nil)
+		repo.On("MarkDelivered", ctx, int64(3), http.StatusOK).Return(nil)
+
+		delivery, err := svc.TestWebhook(ctx, actorID, orgID, webhook.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.WebhookDeliverySucceeded, delivery.Status)
+		assert.Equal(t, 1, delivery.Attempts)
+		require.NotNil(t, delivery.ResponseStatus)
+		assert.Equal(t, http.StatusOK, *delivery.ResponseStatus)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("records failures without retrying", func(t *testing.T) {
+		repo, sender, svc := setup()
+
+		repo.On("CreateDelivery", ctx, mock.Anything).
+			Return(&domain.WebhookDelivery{ID: 3, WebhookID: webhook.ID, Status: domain.WebhookDeliveryPending}, nil)
+		sender.On("Send", ctx, mock.Anything).Return(0, errors.New("connection refused"))
+		repo.On("MarkDead", ctx, int64(3), 0, "connection refused").Return(nil)
+
+		delivery, err := svc.TestWebhook(ctx, actorID, orgID, webhook.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.WebhookDeliveryDead, delivery.Status)
+		assert.Nil(t, delivery.ResponseStatus)
+		require.NotNil(t, delivery.LastError)
+		assert.Equal(t, "connection refused", *delivery.LastError)
+	})
+
+	t.Run("hides webhooks of other organizations", func(t *testing.T) {
+		repo, sender, svc := setup()
+
+		_, err := svc.TestWebhook(ctx, actorID, uuid.New(), webhook.ID)
+
+		assert.ErrorIs(t, err, apperrors.ErrWebhookNotFound)
+		repo.AssertNotCalled(t, "CreateDelivery", mock.Anything, mock.Anything)
+		sender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWebhookPublishingEventRepository_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("queues deliveries for public events", func(t *testing.T) {
+		events := mocks.NewMockTicketEventRepository()
+		webhooks := mocks.NewMockWebhookRepository()
+		repo := services.NewWebhookPublishingEventRepository(events, webhooks)
+
+		event := &domain.Event{TicketID: 42, Type: domain.EventCommentAdded, Version: 1, Payload: json.RawMessage(`{"body":"hi"}`)}
+		created := &domain.Event{ID: 1, TicketID: 42, Type: domain.EventCommentAdded, Version: 1, Payload: event.Payload, CreatedAt: time.Now()}
+		events.On("Create", ctx, event).Return(created, nil)
+
+		var payload []byte
+		webhooks.On("EnqueueForTicket", ctx, int64(42), domain.WebhookCommentAdded, mock.Anything).
+			Run(func(args mock.Arguments) { payload = args.Get(3).([]byte) }).
+			Return(nil)
+
+		got, err := repo.Create(ctx, event)
+
+		require.NoError(t, err)
+		assert.Equal(t, created, got)
+
+		var envelope domain.WebhookEnvelope
+		require.NoError(t, json.Unmarshal(payload, &envelope))
+		assert.Equal(t, domain.WebhookCommentAdded, envelope.Event)
+		assert.Equal(t, 1, envelope.Version)
+		assert.Equal(t, int64(42), envelope.TicketID)
+		assert.JSONEq(t, `{"body":"hi"}`, string(envelope.Data))
+	})
+
+	t.Run("fails the event when queueing fails", func(t *testing.T) {
+		events := mocks.NewMockTicketEventRepository()
+		webhooks := mocks.NewMockWebhookRepository()
+		repo := services.NewWebhookPublishingEventRepository(events, webhooks)
+
+		event := &domain.Event{TicketID: 42, Type: domain.EventTicketCreated}
+		events.On("Create", ctx, event).Return(event, nil)
+		webhooks.On("EnqueueForTicket", ctx, int64(42), domain.WebhookTicketCreated, mock.Anything).
+			Return(errors.New("db down"))
+
+		_, err := repo.Create(ctx, event)
+
+		assert.Error(t, err)
+	})
+}
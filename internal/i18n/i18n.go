@@ -0,0 +1,161 @@
+// Package i18n resolves user-facing messages from embedded per-locale
+// catalogs. Messages are looked up by key and formatted with fmt verbs;
+// a key missing from a locale falls back to DefaultLocale.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale is used when no supported locale is requested, and for keys a
+// locale's catalog does not translate.
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// catalogs maps a locale to its messages, keyed by message key.
+var catalogs = mustLoadCatalogs()
+
+// mustLoadCatalogs parses the embedded catalogs, panicking if they are broken
+// since that can only happen at build time.
+func mustLoadCatalogs() map[string]map[string]string {
+	entries, err := localeFS.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+
+	loaded := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		raw, err := localeFS.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+
+		var messages map[string]string
+		if err := json.Unmarshal(raw, &messages); err != nil {
+			panic(fmt.Sprintf("parse catalog %s: %v", entry.Name(), err))
+		}
+
+		loaded[strings.TrimSuffix(entry.Name(), ".json")] = messages
+	}
+
+	if _, ok := loaded[DefaultLocale]; !ok {
+		panic("missing catalog for default locale " + DefaultLocale)
+	}
+
+	return loaded
+}
+
+// Locales returns the supported locales in sorted order.
+func Locales() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// IsSupported reports whether there is a catalog for locale.
+func IsSupported(locale string) bool {
+	_, ok := catalogs[locale]
+	return ok
+}
+
+// Has reports whether key is defined for locale or the default locale.
+func Has(locale, key string) bool {
+	if _, ok := catalogs[locale][key]; ok {
+		return true
+	}
+	_, ok := catalogs[DefaultLocale][key]
+	return ok
+}
+
+// T returns the message for key in locale, formatted with args. Unknown
+// locales and untranslated keys fall back to the default locale; a key that
+// is not defined at all is returned as is.
+func T(locale, key string, args ...any) string {
+	return TOr(locale, key, key, args...)
+}
+
+// TOr is like T but returns fallback, unformatted, if key is not defined.
+func TOr(locale, key, fallback string, args ...any) string {
+	message, ok := catalogs[locale][key]
+	if !ok {
+		message, ok = catalogs[DefaultLocale][key]
+	}
+	if !ok {
+		return fallback
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// Match picks the best supported locale for an Accept-Language header value,
+// honouring quality weights and falling back from a regional tag such as
+// "es-MX" to its base language. It returns DefaultLocale if nothing matches.
+func Match(acceptLanguage string) string {
+	best, bestQ := DefaultLocale, 0.0
+
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, q := parseLanguageRange(part)
+		if tag == "" || q <= bestQ {
+			continue
+		}
+
+		if locale, ok := supportedBase(tag); ok {
+			best, bestQ = locale, q
+		}
+	}
+
+	return best
+}
+
+// Normalize lowercases a language tag and reduces it to a supported locale,
+// returning "" if it has none.
+func Normalize(tag string) string {
+	locale, ok := supportedBase(strings.ToLower(strings.TrimSpace(tag)))
+	if !ok {
+		return ""
+	}
+	return locale
+}
+
+// parseLanguageRange splits one Accept-Language entry such as "es-MX;q=0.8"
+// into its lowercased tag and quality.
+func parseLanguageRange(part string) (string, float64) {
+	tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+	q := 1.0
+
+	if name, value, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(name) == "q" {
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return "", 0
+		}
+		q = parsed
+	}
+
+	return strings.ToLower(strings.TrimSpace(tag)), q
+}
+
+// supportedBase returns the supported locale for a lowercased tag, trying the
+// full tag before its primary language subtag.
+func supportedBase(tag string) (string, bool) {
+	if IsSupported(tag) {
+		return tag, true
+	}
+	base, _, _ := strings.Cut(tag, "-")
+	if IsSupported(base) {
+		return base, true
+	}
+	return "", false
+}
@@ -0,0 +1,64 @@
+package i18n
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "Ticket not found", T("en", "error.ticket_not_found"))
+	assert.Equal(t, "Ticket no encontrado", T("es", "error.ticket_not_found"))
+	assert.Equal(t, "Hi Jane,", T("en", "email.greeting", "Jane"))
+
+	// Unknown locales fall back to English, unknown keys to the key itself.
+	assert.Equal(t, "Ticket not found", T("xx", "error.ticket_not_found"))
+	assert.Equal(t, "no.such.key", T("es", "no.such.key"))
+	assert.Equal(t, "fallback", TOr("es", "no.such.key", "fallback"))
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", "en"},
+		{"es", "es"},
+		{"es-MX,es;q=0.9,en;q=0.8", "es"},
+		{"fr-CA,fr;q=0.9,en;q=0.5", "en"},
+		{"en;q=0.4, es;q=0.7", "es"},
+		{"es;q=abc", "en"},
+		{"*", "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, Match(tt.header))
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "es", Normalize("ES-mx"))
+	assert.Equal(t, "en", Normalize(" en "))
+	assert.Equal(t, "", Normalize("fr"))
+}
+
+func TestCatalogsDefineTheSameKeys(t *testing.T) {
+	raw, err := localeFS.ReadFile("locales/en.json")
+	require.NoError(t, err)
+
+	var english map[string]string
+	require.NoError(t, json.Unmarshal(raw, &english))
+
+	for _, locale := range Locales() {
+		for key := range english {
+			assert.Contains(t, catalogs[locale], key, "locale %s is missing %s", locale, key)
+		}
+		for key := range catalogs[locale] {
+			assert.Contains(t, english, key, "locale %s defines unknown key %s", locale, key)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Package circuitbreaker stops calls to a dependency that keeps failing, so
+// callers fail fast instead of queueing up behind timeouts.
+package circuitbreaker
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+)
+
+// ErrOpen is returned instead of calling a dependency whose breaker is open.
+var ErrOpen = fmt.Errorf("circuit breaker open: %w", apperrors.ErrServiceUnavailable)
+
+// State is the state of a breaker.
+type State int
+
+const (
+	// Closed lets calls through and counts consecutive failures.
+	Closed State = iota
+	// Open rejects calls until the open timeout has passed.
+	Open
+	// HalfOpen lets one trial call through to decide whether to close.
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// Config holds breaker configuration.
+type Config struct {
+	Name             string        // Identifies the dependency in logs and /health
+	FailureThreshold int           // Consecutive failures that open the breaker
+	OpenTimeout      time.Duration // How long the breaker stays open before a trial call
+	Logger           *slog.Logger  // Reports state changes; defaults to slog.Default()
+}
+
+// Breaker is a consecutive-failure circuit breaker. It is safe for
+// concurrent use.
+type Breaker struct {
+	name        string
+	threshold   int
+	openTimeout time.Duration
+	logger      *slog.Logger
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	// trialUntil is when the trial call of a half-open breaker is given up
+	// on, so a trial that never reports back doesn't keep it half-open
+	trialUntil time.Time
+}
+
+// New creates a closed breaker.
+func New(cfg Config) *Breaker {
+	b := &Breaker{
+		name:        cfg.Name,
+		threshold:   max(cfg.FailureThreshold, 1),
+		openTimeout: cfg.OpenTimeout,
+		logger:      cfg.Logger,
+	}
+	if b.logger == nil {
+		b.logger = slog.Default()
+	}
+	return b
+}
+
+// Name returns the name of the dependency the breaker protects.
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// Allow returns ErrOpen if the call should not be made. When the open
+// timeout has passed, one trial call is allowed.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	switch b.state {
+	case Open:
+		if now.Sub(b.openedAt) < b.openTimeout {
+			return ErrOpen
+		}
+		b.setState(HalfOpen)
+		b.trialUntil = now.Add(b.openTimeout)
+		return nil
+	case HalfOpen:
+		if now.Before(b.trialUntil) {
+			return ErrOpen
+		}
+		b.trialUntil = now.Add(b.openTimeout)
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Success records a call that succeeded, closing the breaker.
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	if b.state != Closed {
+		b.setState(Closed)
+	}
+}
+
+// Failure records a call that failed. The breaker opens once the failure
+// threshold is reached, or straight away if the trial call failed.
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == HalfOpen || (b.state == Closed && b.failures >= b.threshold) {
+		b.openedAt = time.Now()
+		b.setState(Open)
+	}
+}
+
+// setState changes the state and logs the transition. The caller holds mu.
+func (b *Breaker) setState(state State) {
+	from := b.state
+	b.state = state
+	switch state {
+	case Open:
+		b.logger.Warn("circuit breaker opened", "breaker", b.name, "from", from.String(), "failures", b.failures)
+	case Closed:
+		b.logger.Info("circuit breaker closed", "breaker", b.name)
+	default:
+		b.logger.Info("circuit breaker half open, trying a call", "breaker", b.name)
+	}
+}
+
+// Snapshot describes a breaker's state at a point in time.
+type Snapshot struct {
+	Name                string     `json:"name"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+}
+
+// Snapshot returns the breaker's current state.
+func (b *Breaker) Snapshot() Snapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	snapshot := Snapshot{
+		Name:                b.name,
+		State:               b.state.String(),
+		ConsecutiveFailures: b.failures,
+	}
+	if b.state != Closed {
+		openedAt := b.openedAt.UTC()
+		snapshot.OpenedAt = &openedAt
+	}
+	return snapshot
+}
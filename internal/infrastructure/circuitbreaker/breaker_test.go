@@ -0,0 +1,90 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	apperrors "github.com/lorrc/service-desk-backend/internal/core/errors"
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBreaker(openTimeout time.Duration) *Breaker {
+	return New(Config{
+		Name:             "test",
+		FailureThreshold: 2,
+		OpenTimeout:      openTimeout,
+		Logger:           slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+}
+
+func TestBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	b := newTestBreaker(time.Hour)
+
+	b.Failure()
+	b.Success()
+	b.Failure()
+	require.NoError(t, b.Allow(), "a success resets the failure count")
+
+	b.Failure()
+	err := b.Allow()
+	require.ErrorIs(t, err, ErrOpen)
+	assert.ErrorIs(t, err, apperrors.ErrServiceUnavailable)
+	assert.Equal(t, "open", b.Snapshot().State)
+}
+
+func TestBreaker_HalfOpenAllowsOneTrial(t *testing.T) {
+	b := newTestBreaker(20 * time.Millisecond)
+	b.Failure()
+	b.Failure()
+	require.ErrorIs(t, b.Allow(), ErrOpen)
+
+	time.Sleep(25 * time.Millisecond)
+	require.NoError(t, b.Allow(), "the first call after the timeout is a trial")
+	assert.Equal(t, "half_open", b.Snapshot().State)
+	require.ErrorIs(t, b.Allow(), ErrOpen, "other calls wait for the trial")
+
+	t.Run("a failed trial opens the breaker again", func(t *testing.T) {
+		b.Failure()
+		assert.Equal(t, "open", b.Snapshot().State)
+		require.ErrorIs(t, b.Allow(), ErrOpen)
+	})
+
+	t.Run("a successful trial closes the breaker", func(t *testing.T) {
+		time.Sleep(25 * time.Millisecond)
+		require.NoError(t, b.Allow())
+		b.Success()
+
+		snapshot := b.Snapshot()
+		assert.Equal(t, "closed", snapshot.State)
+		assert.Zero(t, snapshot.ConsecutiveFailures)
+		assert.Nil(t, snapshot.OpenedAt)
+	})
+}
+
+type failingNotifier struct {
+	err   error
+	calls int
+}
+
+func (n *failingNotifier) Notify(_ context.Context, _ ports.NotificationParams) error {
+	n.calls++
+	return n.err
+}
+
+func TestNotifier_FailsFastWhileOpen(t *testing.T) {
+	ctx := context.Background()
+	next := &failingNotifier{err: errors.New("smtp: connection refused")}
+	notifier := NewNotifier(newTestBreaker(time.Hour), next)
+
+	for range 2 {
+		require.Error(t, notifier.Notify(ctx, ports.NotificationParams{}))
+	}
+	require.ErrorIs(t, notifier.Notify(ctx, ports.NotificationParams{}), ErrOpen)
+	assert.Equal(t, 2, next.calls)
+}
@@ -0,0 +1,41 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lorrc/service-desk-backend/internal/core/ports"
+)
+
+// Notifier wraps a notifier in a breaker. While the breaker is open,
+// notifications fail with ErrOpen straight away and the outbox retries
+// them later.
+type Notifier struct {
+	next    ports.Notifier
+	breaker *Breaker
+}
+
+var _ ports.Notifier = (*Notifier)(nil)
+
+// NewNotifier creates a notifier that calls next through breaker
+func NewNotifier(breaker *Breaker, next ports.Notifier) ports.Notifier {
+	return &Notifier{next: next, breaker: breaker}
+}
+
+// Notify delivers the notification unless the breaker is open
+func (n *Notifier) Notify(ctx context.Context, params ports.NotificationParams) error {
+	if err := n.breaker.Allow(); err != nil {
+		return err
+	}
+
+	err := n.next.Notify(ctx, params)
+	switch {
+	case err == nil:
+		n.breaker.Success()
+	case errors.Is(err, context.Canceled):
+		// The caller gave up; that says nothing about the dependency
+	default:
+		n.breaker.Failure()
+	}
+	return err
+}
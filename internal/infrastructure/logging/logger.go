@@ -23,8 +23,9 @@ const (
 
 // Config holds logger configuration
 type Config struct {
-	Level       string // debug, info, warn, error
-	Format      string // json, text
+	Level       string         // debug, info, warn, error
+	LevelVar    *slog.LevelVar // if set, the level can be changed after creation
+	Format      string         // json, text
 	Output      io.Writer
 	AddSource   bool
 	ServiceName string
@@ -43,18 +44,27 @@ func DefaultConfig() Config {
 	}
 }
 
-// NewLogger creates a new structured logger with the given configuration
-func NewLogger(cfg Config) *slog.Logger {
-	var level slog.Level
-	switch cfg.Level {
+// ParseLevel converts a configured level name to a slog.Level, defaulting
+// to info
+func ParseLevel(name string) slog.Level {
+	switch name {
 	case "debug":
-		level = slog.LevelDebug
+		return slog.LevelDebug
 	case "warn":
-		level = slog.LevelWarn
+		return slog.LevelWarn
 	case "error":
-		level = slog.LevelError
+		return slog.LevelError
 	default:
-		level = slog.LevelInfo
+		return slog.LevelInfo
+	}
+}
+
+// NewLogger creates a new structured logger with the given configuration
+func NewLogger(cfg Config) *slog.Logger {
+	var level slog.Leveler = ParseLevel(cfg.Level)
+	if cfg.LevelVar != nil {
+		cfg.LevelVar.Set(level.Level())
+		level = cfg.LevelVar
 	}
 
 	opts := &slog.HandlerOptions{
@@ -0,0 +1,3 @@
+ALTER TABLE users
+    DROP COLUMN IF EXISTS sms_opt_in,
+    DROP COLUMN IF EXISTS phone_number;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_notification_outbox_batch_key;
+
+ALTER TABLE notification_outbox
+    DROP COLUMN IF EXISTS batch_count,
+    DROP COLUMN IF EXISTS batch_key;
@@ -0,0 +1,9 @@
+ALTER TABLE notification_outbox
+    ADD COLUMN IF NOT EXISTS batch_key TEXT,
+    ADD COLUMN IF NOT EXISTS batch_count INT NOT NULL DEFAULT 1;
+
+-- Only notifications still waiting out their aggregation window keep a
+-- batch key; claiming one for delivery clears it.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notification_outbox_batch_key
+    ON notification_outbox (batch_key)
+    WHERE batch_key IS NOT NULL;
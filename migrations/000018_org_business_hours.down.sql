@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS organization_holidays;
+DROP TABLE IF EXISTS organization_business_hours;
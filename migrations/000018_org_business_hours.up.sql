@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS organization_business_hours (
+    organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    timezone TEXT NOT NULL,
+    -- Working windows as [{"weekday": 1, "open": 540, "close": 1020}, ...],
+    -- with times of day in minutes after midnight
+    working_days JSONB NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS organization_holidays (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    holiday_date DATE NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (organization_id, holiday_date)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE tickets
+    DROP COLUMN IF EXISTS first_response_by,
+    DROP COLUMN IF EXISTS first_response_at;
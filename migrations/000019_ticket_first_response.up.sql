@@ -0,0 +1,17 @@
+ALTER TABLE tickets
+    ADD COLUMN IF NOT EXISTS first_response_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS first_response_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+-- Backfill from the earliest comment not written by the requester.
+UPDATE tickets t
+SET first_response_at = fr.created_at,
+    first_response_by = fr.author_id
+FROM (
+    SELECT DISTINCT ON (c.ticket_id) c.ticket_id, c.created_at, c.author_id
+    FROM comments c
+    JOIN tickets tk ON tk.id = c.ticket_id
+    WHERE c.author_id <> tk.requester_id
+    ORDER BY c.ticket_id, c.created_at
+) fr
+WHERE t.id = fr.ticket_id
+  AND t.first_response_at IS NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS analytics_rollup_state;
+DROP TABLE IF EXISTS analytics_daily_volume;
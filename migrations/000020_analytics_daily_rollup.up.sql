@@ -0,0 +1,19 @@
+-- Daily ticket volume per organization and priority, maintained by the
+-- analytics rollup job so the overview does not scan every ticket.
+CREATE TABLE IF NOT EXISTS analytics_daily_volume (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    priority TEXT NOT NULL,
+    created_count BIGINT NOT NULL DEFAULT 0,
+    resolved_count BIGINT NOT NULL DEFAULT 0,
+    -- Sum of created-to-closed time of the tickets resolved that day, for MTTR.
+    resolution_seconds DOUBLE PRECISION NOT NULL DEFAULT 0,
+    PRIMARY KEY (organization_id, day, priority)
+);
+
+-- Single row recording the last day the rollups are complete for.
+CREATE TABLE IF NOT EXISTS analytics_rollup_state (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    rolled_up_through DATE NOT NULL,
+    refreshed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS data_exports (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    requested_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'PENDING',
+    payload JSONB,
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ,
+    CONSTRAINT data_exports_status_check CHECK (status IN ('PENDING', 'READY', 'FAILED'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_exports_user_created
+    ON data_exports (user_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_data_exports_pending
+    ON data_exports (created_at)
+    WHERE status = 'PENDING';
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS rate_limit_overrides (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    -- NULL applies the override to every user in the organization.
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    unlimited BOOLEAN NOT NULL DEFAULT FALSE,
+    requests_per_second DOUBLE PRECISION NOT NULL DEFAULT 0,
+    burst INT NOT NULL DEFAULT 0,
+    note TEXT NOT NULL DEFAULT '',
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- One override per user, and one for the organization as a whole.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_rate_limit_overrides_scope
+    ON rate_limit_overrides (organization_id, (COALESCE(user_id, '00000000-0000-0000-0000-000000000000')));
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS organization_retention_policies;
+DROP INDEX IF EXISTS idx_tickets_deleted_at;
+ALTER TABLE tickets DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,13 @@
+-- Tickets purged by a retention policy are hidden first and only removed
+-- for good once the organization's grace period has passed.
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_tickets_deleted_at ON tickets (deleted_at) WHERE deleted_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS organization_retention_policies (
+    organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    -- NULL keeps closed tickets forever.
+    closed_ticket_days INT,
+    grace_days INT NOT NULL DEFAULT 30,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
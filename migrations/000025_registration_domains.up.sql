@@ -0,0 +1,7 @@
+-- Email domains allowed to self-register into an organization. An
+-- organization without any accepts every domain.
+CREATE TABLE IF NOT EXISTS organization_registration_domains (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    domain TEXT NOT NULL,
+    PRIMARY KEY (organization_id, domain)
+);
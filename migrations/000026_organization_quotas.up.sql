@@ -0,0 +1,8 @@
+-- Per-organization usage limits. A NULL limit falls back to the
+-- server-wide default.
+CREATE TABLE IF NOT EXISTS organization_quotas (
+    organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    max_users INT,
+    max_open_tickets INT,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
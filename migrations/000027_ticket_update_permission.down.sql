@@ -0,0 +1,6 @@
+DELETE FROM role_permissions rp
+USING permissions p
+WHERE rp.permission_id = p.id
+  AND p.code = 'tickets:update';
+
+DELETE FROM permissions WHERE code = 'tickets:update';
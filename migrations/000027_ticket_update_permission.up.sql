@@ -0,0 +1,11 @@
+-- Lets agents and admins edit a ticket's title, description and priority
+-- through PATCH /tickets/{id}.
+INSERT INTO permissions (code) VALUES ('tickets:update')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.code = 'tickets:update'
+WHERE r.name IN ('admin', 'agent')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,16 @@
+-- Endpoints external systems post alerts to, each raising tickets on behalf
+-- of a requester. The source is the hook's path segment under /hooks.
+CREATE TABLE IF NOT EXISTS inbound_hooks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    source TEXT NOT NULL UNIQUE,
+    format TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    mapping JSONB NOT NULL DEFAULT '{}',
+    requester_id UUID NOT NULL REFERENCES users(id),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_inbound_hooks_organization_id ON inbound_hooks (organization_id);
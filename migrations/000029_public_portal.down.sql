@@ -0,0 +1,3 @@
+DELETE FROM roles WHERE name = 'guest';
+
+ALTER TABLE organizations DROP COLUMN IF EXISTS portal_slug;
@@ -0,0 +1,14 @@
+-- Organizations opt in to the public ticket portal by choosing a slug,
+-- served at /public/{slug}/tickets.
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS portal_slug TEXT UNIQUE;
+
+-- Requesters created by portal submissions. Guests have no password and
+-- act only through the link emailed to them.
+INSERT INTO roles (name) VALUES ('guest') ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.code IN ('tickets:create', 'tickets:read', 'comments:create', 'comments:read')
+WHERE r.name = 'guest'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN IF EXISTS reassign_when_away;
+
+DROP TABLE IF EXISTS out_of_office_windows;
@@ -0,0 +1,14 @@
+-- Periods agents are away. Away agents are left out of the assignee picker,
+-- and agents who opt in have their open tickets flagged for reassignment.
+CREATE TABLE IF NOT EXISTS out_of_office_windows (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_out_of_office_windows_user_id ON out_of_office_windows (user_id, ends_at);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS reassign_when_away BOOLEAN NOT NULL DEFAULT FALSE;
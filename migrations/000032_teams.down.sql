@@ -0,0 +1,11 @@
+DELETE FROM role_permissions rp
+USING permissions p
+WHERE rp.permission_id = p.id
+  AND p.code = 'tickets:claim';
+
+DELETE FROM permissions WHERE code = 'tickets:claim';
+
+ALTER TABLE tickets DROP COLUMN IF EXISTS team_id;
+
+DROP TABLE IF EXISTS team_members;
+DROP TABLE IF EXISTS teams;
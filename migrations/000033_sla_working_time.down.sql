@@ -0,0 +1,2 @@
+ALTER TABLE tickets DROP COLUMN IF EXISTS resolution_working_seconds;
+ALTER TABLE tickets DROP COLUMN IF EXISTS due_at;
@@ -0,0 +1,6 @@
+-- SLA timers only run during the organization's business hours. due_at is
+-- when a ticket should be resolved by; resolution_working_seconds is the
+-- working time it took, set when it closes. Tickets closed before this
+-- migration have none and count their calendar time towards MTTR.
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS due_at TIMESTAMPTZ;
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS resolution_working_seconds DOUBLE PRECISION;
@@ -0,0 +1,17 @@
+-- Escalation rules act on open tickets once a condition has held for a
+-- threshold. Actions are stored as JSON, each naming its own target.
+CREATE TABLE IF NOT EXISTS escalation_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    trigger TEXT NOT NULL,
+    threshold_minutes INTEGER NOT NULL CHECK (threshold_minutes > 0),
+    priorities TEXT[] NOT NULL DEFAULT '{}',
+    actions JSONB NOT NULL DEFAULT '[]',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_escalation_rules_organization_id ON escalation_rules (organization_id);
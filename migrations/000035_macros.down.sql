@@ -0,0 +1,11 @@
+DELETE FROM role_permissions rp
+USING permissions p
+WHERE rp.permission_id = p.id
+  AND p.code = 'macros:apply';
+
+DELETE FROM permissions WHERE code = 'macros:apply';
+
+DROP TABLE IF EXISTS macros;
+
+DROP INDEX IF EXISTS idx_tickets_tags;
+ALTER TABLE tickets DROP COLUMN IF EXISTS tags;
@@ -0,0 +1,28 @@
+-- Free-form labels on tickets, stored lowercase.
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_tickets_tags ON tickets USING GIN (tags);
+
+-- Macros bundle ticket changes and a canned reply that agents apply in one
+-- step. Actions are stored as JSON, each carrying the value it sets.
+CREATE TABLE IF NOT EXISTS macros (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    actions JSONB NOT NULL DEFAULT '[]',
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (organization_id, name)
+);
+
+-- Lets agents list macros and apply them to tickets.
+INSERT INTO permissions (code) VALUES ('macros:apply')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.code = 'macros:apply'
+WHERE r.name IN ('admin', 'agent')
+ON CONFLICT DO NOTHING;
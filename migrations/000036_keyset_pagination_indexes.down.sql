@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_org_created_at_id;
+DROP INDEX IF EXISTS idx_comments_ticket_created_at_id;
+DROP INDEX IF EXISTS idx_tickets_created_at_id;
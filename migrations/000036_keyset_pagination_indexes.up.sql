@@ -0,0 +1,11 @@
+-- Keyset pages are ordered by (created_at, id); these indexes let each page
+-- start right after the previous one without scanning the rows before it.
+-- audit_log is already covered by idx_audit_log_org_created.
+CREATE INDEX IF NOT EXISTS idx_tickets_created_at_id
+    ON tickets (created_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_comments_ticket_created_at_id
+    ON comments (ticket_id, created_at, id);
+
+CREATE INDEX IF NOT EXISTS idx_users_org_created_at_id
+    ON users (organization_id, created_at DESC, id DESC);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_tickets_org_status;
+DROP INDEX IF EXISTS idx_tickets_org_created_at_id;
+CREATE INDEX IF NOT EXISTS idx_tickets_created_at_id
+    ON tickets (created_at DESC, id DESC);
+
+ALTER TABLE tickets DROP COLUMN IF EXISTS organization_id;
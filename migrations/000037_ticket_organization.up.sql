@@ -0,0 +1,20 @@
+-- Tickets were scoped to an organization only through their requester.
+-- Store the organization on the ticket itself, taken from the requester
+-- when the ticket was created, so a requester moving organizations no
+-- longer moves their tickets along.
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE;
+
+UPDATE tickets t
+SET organization_id = u.organization_id
+FROM users u
+WHERE u.id = t.requester_id
+  AND t.organization_id IS NULL;
+
+ALTER TABLE tickets ALTER COLUMN organization_id SET NOT NULL;
+
+-- Every ticket listing filters by organization first
+DROP INDEX IF EXISTS idx_tickets_created_at_id;
+CREATE INDEX IF NOT EXISTS idx_tickets_org_created_at_id
+    ON tickets (organization_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_tickets_org_status
+    ON tickets (organization_id, status);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS search_index_queue;
+DROP TABLE IF EXISTS search_documents;
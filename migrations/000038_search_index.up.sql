@@ -0,0 +1,43 @@
+-- Documents searched by the Postgres search backend, one per ticket and
+-- per comment. They are written by the search indexer rather than kept in
+-- sync by triggers, so installations searching with OpenSearch leave this
+-- table empty.
+CREATE TABLE IF NOT EXISTS search_documents (
+    kind            TEXT NOT NULL CHECK (kind IN ('ticket', 'comment')),
+    id              BIGINT NOT NULL,
+    ticket_id       BIGINT NOT NULL,
+    organization_id UUID NOT NULL,
+    requester_id    UUID NOT NULL,
+    title           TEXT NOT NULL,
+    body            TEXT NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL,
+    document        TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', title), 'A') ||
+        setweight(to_tsvector('simple', body), 'B')
+    ) STORED,
+    PRIMARY KEY (kind, id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_search_documents_document
+    ON search_documents USING GIN (document);
+CREATE INDEX IF NOT EXISTS idx_search_documents_org_ticket
+    ON search_documents (organization_id, ticket_id);
+
+-- Tickets waiting to be (re)indexed, queued alongside the ticket events
+-- that change them. A ticket is queued at most once; queueing it again
+-- while it waits only bumps enqueued_at. Rows outlive their ticket so a
+-- deleted ticket is removed from the index too.
+CREATE TABLE IF NOT EXISTS search_index_queue (
+    ticket_id       BIGINT PRIMARY KEY,
+    organization_id UUID NOT NULL,
+    enqueued_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    available_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_search_index_queue_available_at
+    ON search_index_queue (available_at);
+
+-- Index every existing ticket
+INSERT INTO search_index_queue (ticket_id, organization_id)
+SELECT id, organization_id FROM tickets
+ON CONFLICT (ticket_id) DO NOTHING;
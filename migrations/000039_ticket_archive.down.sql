@@ -0,0 +1,17 @@
+-- Move archived tickets back into the hot tables before dropping the archive
+INSERT INTO tickets
+SELECT (jsonb_populate_record(NULL::tickets, a.ticket)).*
+FROM ticket_archive a
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO comments
+SELECT (jsonb_populate_record(NULL::comments, c.value)).*
+FROM ticket_archive a, jsonb_array_elements(a.comments) c
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO ticket_events
+SELECT (jsonb_populate_record(NULL::ticket_events, e.value)).*
+FROM ticket_archive a, jsonb_array_elements(a.events) e
+ON CONFLICT (id) DO NOTHING;
+
+DROP TABLE IF EXISTS ticket_archive;
@@ -0,0 +1,19 @@
+-- Tickets moved out of the hot tables once they have been closed for a
+-- while. Each row holds the ticket, its comments and its events as JSON
+-- snapshots of their rows, so archived tickets need not follow later
+-- changes to those tables. The columns retention works on are kept
+-- alongside: archived tickets are soft- and hard-deleted like live ones.
+CREATE TABLE IF NOT EXISTS ticket_archive (
+    ticket_id       BIGINT PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    title           TEXT NOT NULL,
+    closed_at       TIMESTAMPTZ NOT NULL,
+    archived_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at      TIMESTAMPTZ,
+    ticket          JSONB NOT NULL,
+    comments        JSONB NOT NULL DEFAULT '[]',
+    events          JSONB NOT NULL DEFAULT '[]'
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_archive_org_closed_at
+    ON ticket_archive (organization_id, closed_at);
@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS idx_tickets_assignee_id ON tickets (assignee_id);
+
+DROP INDEX IF EXISTS idx_tickets_assignee_status_created_at;
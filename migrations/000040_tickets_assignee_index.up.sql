@@ -0,0 +1,8 @@
+-- Agent queues, workload views and offboarding list an assignee's tickets,
+-- usually filtered by status and newest first. The composite index serves
+-- those lists and plain assignee lookups, so it replaces the single-column
+-- index.
+CREATE INDEX IF NOT EXISTS idx_tickets_assignee_status_created_at
+    ON tickets (assignee_id, status, created_at DESC);
+
+DROP INDEX IF EXISTS idx_tickets_assignee_id;
@@ -0,0 +1,2 @@
+ALTER TABLE comments DROP COLUMN IF EXISTS body_html;
+ALTER TABLE tickets DROP COLUMN IF EXISTS description_html;
@@ -0,0 +1,14 @@
+-- Ticket descriptions and comment bodies are kept as submitted and as HTML
+-- sanitized by the server, which is what clients render. Everything written
+-- before was plain text, so its safe HTML is the text escaped.
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS description_html TEXT;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS body_html TEXT NOT NULL DEFAULT '';
+
+UPDATE tickets
+SET description_html = replace(replace(replace(replace(replace(
+        description, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '''', '&#39;')
+WHERE description IS NOT NULL;
+
+UPDATE comments
+SET body_html = replace(replace(replace(replace(replace(
+        body, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '''', '&#39;');
@@ -0,0 +1,14 @@
+-- A snoozed ticket is left out of agents' queues until snoozed_until, when
+-- it resurfaces and whoever snoozed it is notified. A ticket has at most
+-- one snooze; snoozing it again moves the time.
+CREATE TABLE IF NOT EXISTS ticket_snoozes (
+    ticket_id BIGINT PRIMARY KEY REFERENCES tickets(id) ON DELETE CASCADE,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    snoozed_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    snoozed_until TIMESTAMPTZ NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_snoozes_until
+    ON ticket_snoozes (snoozed_until);
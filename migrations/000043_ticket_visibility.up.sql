@@ -0,0 +1,5 @@
+-- Internal tickets track agents' own work and are hidden from their
+-- requester unless the requester can read all tickets. Existing tickets
+-- stay public.
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS visibility TEXT NOT NULL DEFAULT 'PUBLIC'
+    CONSTRAINT tickets_visibility_check CHECK (visibility IN ('PUBLIC', 'INTERNAL'));
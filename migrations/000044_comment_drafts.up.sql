@@ -0,0 +1,11 @@
+-- A comment draft is a reply a user has started on a ticket but not posted,
+-- saved as they type. Each user has at most one draft per ticket; posting a
+-- comment on the ticket removes it.
+CREATE TABLE IF NOT EXISTS comment_drafts (
+    ticket_id BIGINT NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    body TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (ticket_id, user_id)
+);
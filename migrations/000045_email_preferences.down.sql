@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS muted_tickets;
+DROP TABLE IF EXISTS email_preferences;
@@ -0,0 +1,15 @@
+-- The ticket emails users have opted out of, through the links at the foot
+-- of ticket emails or their profile. Users without a row get every email.
+CREATE TABLE IF NOT EXISTS email_preferences (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    unsubscribed BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Tickets a user gets no more emails about
+CREATE TABLE IF NOT EXISTS muted_tickets (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ticket_id BIGINT NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, ticket_id)
+);
@@ -0,0 +1 @@
+ALTER TABLE ticket_events DROP COLUMN IF EXISTS version;
@@ -0,0 +1,3 @@
+-- Events record the version of their payload, so its shape can change
+-- without reinterpreting older events. Existing events are version 1.
+ALTER TABLE ticket_events ADD COLUMN IF NOT EXISTS version SMALLINT NOT NULL DEFAULT 1;
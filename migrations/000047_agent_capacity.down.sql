@@ -0,0 +1,2 @@
+ALTER TABLE organizations DROP COLUMN IF EXISTS agent_capacity_enforcement;
+ALTER TABLE organizations DROP COLUMN IF EXISTS agent_max_open_tickets;
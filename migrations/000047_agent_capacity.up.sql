@@ -0,0 +1,7 @@
+-- How many open tickets each agent of an organization should have at most.
+-- A NULL limit sets none; the enforcement decides whether assigning past
+-- the limit only warns or is refused.
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS agent_max_open_tickets INTEGER
+    CHECK (agent_max_open_tickets > 0);
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS agent_capacity_enforcement TEXT NOT NULL DEFAULT 'WARN'
+    CHECK (agent_capacity_enforcement IN ('WARN', 'BLOCK'));
@@ -0,0 +1,5 @@
+-- Fails while an email is used in more than one organization
+DROP INDEX IF EXISTS idx_users_email;
+DROP INDEX IF EXISTS idx_users_organization_email;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users(email);
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
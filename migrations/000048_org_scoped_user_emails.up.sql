@@ -0,0 +1,7 @@
+-- An email is unique within an organization rather than across all of
+-- them, so the same person can have an account in several organizations.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+DROP INDEX IF EXISTS idx_users_email;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_organization_email ON users(organization_id, email);
+-- Logins that don't name their organization still look users up by email
+CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
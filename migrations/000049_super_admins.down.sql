@@ -0,0 +1,20 @@
+ALTER TABLE audit_log DROP COLUMN IF EXISTS impersonator_id;
+
+DELETE FROM user_roles ur
+USING roles r
+WHERE ur.role_id = r.id
+  AND r.name = 'superadmin';
+
+DELETE FROM role_permissions rp
+USING roles r
+WHERE rp.role_id = r.id
+  AND r.name = 'superadmin';
+
+DELETE FROM roles WHERE name = 'superadmin';
+
+DELETE FROM role_permissions rp
+USING permissions p
+WHERE rp.permission_id = p.id
+  AND p.code = 'platform:access';
+
+DELETE FROM permissions WHERE code = 'platform:access';
@@ -0,0 +1,18 @@
+-- Super-admins run the platform rather than one organization: on top of
+-- everything an admin can do they see every organization and can sign in
+-- as its admins. Only super-admins hold platform:access.
+INSERT INTO permissions (code) VALUES ('platform:access')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO roles (name) VALUES ('superadmin')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'superadmin'
+ON CONFLICT DO NOTHING;
+
+-- Entries made while a super-admin was signed in as someone else name the
+-- super-admin here.
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS impersonator_id UUID;
@@ -0,0 +1 @@
+ALTER TABLE search_documents DROP COLUMN IF EXISTS visibility;
@@ -0,0 +1,16 @@
+-- Search documents carry their ticket's visibility so internal tickets
+-- can be left out of searches by users who cannot read all tickets.
+ALTER TABLE search_documents ADD COLUMN IF NOT EXISTS visibility TEXT NOT NULL DEFAULT 'PUBLIC'
+    CONSTRAINT search_documents_visibility_check CHECK (visibility IN ('PUBLIC', 'INTERNAL'));
+
+UPDATE search_documents d
+SET visibility = t.visibility
+FROM tickets t
+WHERE t.id = d.ticket_id
+  AND t.visibility <> d.visibility;
+
+-- Reindex internal tickets so an OpenSearch index gets their visibility too
+INSERT INTO search_index_queue (ticket_id, organization_id)
+SELECT id, organization_id FROM tickets
+WHERE visibility = 'INTERNAL'
+ON CONFLICT (ticket_id) DO NOTHING;
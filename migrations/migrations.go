@@ -0,0 +1,34 @@
+// Package migrations embeds the SQL schema migrations, so the binary can
+// apply them without the source tree.
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+
+	"github.com/golang-migrate/migrate/v4/source"
+)
+
+// FS holds the *.up.sql and *.down.sql migration files.
+//
+//go:embed *.sql
+var FS embed.FS
+
+// LatestVersion returns the version of the newest embedded migration, the
+// schema version this build expects.
+func LatestVersion() (uint, error) {
+	entries, err := fs.ReadDir(FS, ".")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest uint
+	for _, entry := range entries {
+		mig, err := source.DefaultParse(entry.Name())
+		if err != nil || mig.Direction != source.Up {
+			continue
+		}
+		latest = max(latest, mig.Version)
+	}
+	return latest, nil
+}